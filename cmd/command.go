@@ -20,15 +20,45 @@
 package answercmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/apache/answer/internal/base/conf"
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/translator"
 	"github.com/apache/answer/internal/cli"
 	"github.com/apache/answer/internal/install"
 	"github.com/apache/answer/internal/migrations"
+	auditLogRepo "github.com/apache/answer/internal/repo/audit_log"
+	authRepo "github.com/apache/answer/internal/repo/auth"
+	"github.com/apache/answer/internal/repo/bot"
+	configRepo "github.com/apache/answer/internal/repo/config"
+	"github.com/apache/answer/internal/repo/email_template"
+	exportRepo "github.com/apache/answer/internal/repo/export"
+	"github.com/apache/answer/internal/repo/personal_access_token"
+	"github.com/apache/answer/internal/repo/plugin_config"
+	rankRepo "github.com/apache/answer/internal/repo/rank"
+	roleRepo "github.com/apache/answer/internal/repo/role"
+	"github.com/apache/answer/internal/repo/search_sync"
+	"github.com/apache/answer/internal/repo/site_info"
+	"github.com/apache/answer/internal/repo/user"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/audit_log"
+	"github.com/apache/answer/internal/service/auth"
+	"github.com/apache/answer/internal/service/config"
+	"github.com/apache/answer/internal/service/export"
+	"github.com/apache/answer/internal/service/plugin_common"
+	rankService "github.com/apache/answer/internal/service/rank"
+	"github.com/apache/answer/internal/service/role"
+	"github.com/apache/answer/internal/service/search_rebuild"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/internal/service/user_import"
 	"github.com/apache/answer/plugin"
+	"github.com/segmentfault/pacman/contrib/cache/memory"
 	"github.com/segmentfault/pacman/log"
 	"github.com/spf13/cobra"
 )
@@ -53,6 +83,20 @@ var (
 	i18nSourcePath string
 	// i18nTargetPath i18n to path
 	i18nTargetPath string
+	// recalcStartUserID recalculate the reputation of users whose id is greater than this
+	recalcStartUserID string
+	// recalcSince recalculate the reputation of users who have activities changed since this date
+	recalcSince string
+	// recalcDryRun only print the users whose reputation would be changed
+	recalcDryRun bool
+	// searchRebuildRestart ignore the checkpoint and rebuild the search index from the beginning
+	searchRebuildRestart bool
+	// searchRebuildBatchSize the number of contents sent to the search plugin in one batch
+	searchRebuildBatchSize int
+	// importUsersFile the csv file of the users to import
+	importUsersFile string
+	// importUsersSendActivationEmail send the activation emails to the imported users
+	importUsersSendActivationEmail bool
 )
 
 func init() {
@@ -76,7 +120,23 @@ func init() {
 
 	i18nCmd.Flags().StringVarP(&i18nTargetPath, "target", "t", "", "i18n target path, eg: -t ./i18n/target")
 
-	for _, cmd := range []*cobra.Command{initCmd, checkCmd, runCmd, dumpCmd, upgradeCmd, buildCmd, pluginCmd, configCmd, i18nCmd} {
+	recalcReputationCmd.Flags().StringVarP(&recalcStartUserID, "start-user-id", "s", "", "resume from the user id, eg: -s 10001")
+
+	recalcReputationCmd.Flags().StringVarP(&recalcSince, "since", "", "", "only the users who have activities changed since the date, eg: --since 2024-01-01")
+
+	recalcReputationCmd.Flags().BoolVarP(&recalcDryRun, "dry-run", "", false, "only print the users whose reputation would be changed")
+
+	searchRebuildCmd.Flags().BoolVarP(&searchRebuildRestart, "restart", "", false, "ignore the checkpoint and rebuild from the beginning")
+
+	searchRebuildCmd.Flags().IntVarP(&searchRebuildBatchSize, "batch-size", "b", 100, "the number of contents sent in one batch, eg: -b 500")
+
+	searchCmd.AddCommand(searchRebuildCmd)
+
+	importUsersCmd.Flags().StringVarP(&importUsersFile, "file", "f", "", "the csv file with the columns email,name,role,reputation, eg: -f ./users.csv")
+
+	importUsersCmd.Flags().BoolVarP(&importUsersSendActivationEmail, "send-activation-email", "", false, "send the activation emails, stop answer first if it uses the memory cache because the codes are saved in the cache file")
+
+	for _, cmd := range []*cobra.Command{initCmd, checkCmd, runCmd, dumpCmd, upgradeCmd, buildCmd, pluginCmd, configCmd, i18nCmd, recalcReputationCmd, searchCmd, importUsersCmd} {
 		rootCmd.AddCommand(cmd)
 	}
 }
@@ -297,8 +357,191 @@ To run answer, use:
 			}
 		},
 	}
+
+	recalcReputationCmd = &cobra.Command{
+		Use:   "recalc-reputation",
+		Short: "Recalculate the reputation of users",
+		Long:  `Replay the reputation activities to rebuild the reputation of users`,
+		Run: func(_ *cobra.Command, _ []string) {
+			log.SetLogger(log.NewStdLogger(os.Stdout))
+			cli.FormatAllPath(dataDirPath)
+			c, err := conf.ReadConfig(cli.GetConfigFilePath())
+			if err != nil {
+				fmt.Println("read config failed: ", err.Error())
+				return
+			}
+			req := &schema.ReputationRecalcReq{StartUserID: recalcStartUserID, DryRun: recalcDryRun}
+			if len(recalcSince) > 0 {
+				since, err := time.Parse(time.DateOnly, recalcSince)
+				if err != nil {
+					fmt.Println("parse since date failed: ", err.Error())
+					return
+				}
+				req.Since = since.Unix()
+			}
+			db, err := data.NewDB(false, c.Data.Database)
+			if err != nil {
+				fmt.Println("connect database failed: ", err.Error())
+				return
+			}
+			defer db.Close()
+
+			recalcService := rankService.NewReputationRecalcService(rankRepo.NewReputationRecalcRepo(&data.Data{DB: db}))
+			progress, err := recalcService.Recalculate(context.Background(), req, func(p *schema.ReputationRecalcProgress) {
+				fmt.Printf("recalculated %d/%d users, %d changed, last user id: %s\n",
+					p.Processed, p.Total, p.Changed, p.LastUserID)
+			})
+			if err != nil {
+				fmt.Println("recalculate reputation failed: ", err.Error())
+				if progress != nil && len(progress.LastUserID) > 0 {
+					fmt.Printf("use --start-user-id %s to resume\n", progress.LastUserID)
+				}
+				return
+			}
+			if progress.DryRun {
+				fmt.Printf("dry run, the reputation of %d users would be changed\n", progress.Changed)
+				return
+			}
+			fmt.Printf("recalculate reputation done, the reputation of %d users changed\n", progress.Changed)
+		},
+	}
+
+	searchCmd = &cobra.Command{
+		Use:   "search",
+		Short: "Manage the search index",
+		Long:  `Manage the search index of the active search plugin`,
+	}
+
+	searchRebuildCmd = &cobra.Command{
+		Use:   "rebuild",
+		Short: "Rebuild the search index",
+		Long:  `Send all questions and answers to the active search plugin, the interrupted rebuild is resumed from the checkpoint`,
+		Run: func(_ *cobra.Command, _ []string) {
+			log.SetLogger(log.NewStdLogger(os.Stdout))
+			cli.FormatAllPath(dataDirPath)
+			c, err := conf.ReadConfig(cli.GetConfigFilePath())
+			if err != nil {
+				fmt.Println("read config failed: ", err.Error())
+				return
+			}
+			db, err := data.NewDB(false, c.Data.Database)
+			if err != nil {
+				fmt.Println("connect database failed: ", err.Error())
+				return
+			}
+			defer db.Close()
+			dataData := &data.Data{DB: db, Cache: memory.NewCache()}
+
+			ctx := context.Background()
+			plugin_common.LoadPluginStatusAndConfig(ctx,
+				config.NewConfigService(configRepo.NewConfigRepo(dataData)), plugin_config.NewPluginConfigRepo(dataData))
+			var finder plugin.Search
+			_ = plugin.CallSearch(func(search plugin.Search) error {
+				finder = search
+				return nil
+			})
+			if finder == nil {
+				fmt.Println("no search plugin is enabled")
+				return
+			}
+
+			rebuildService := search_rebuild.NewSearchRebuildService(
+				search_sync.NewSearchRebuildRepo(dataData), site_info.NewSiteInfo(dataData))
+			req := &schema.SearchRebuildReq{Restart: searchRebuildRestart, BatchSize: searchRebuildBatchSize}
+			progress, err := rebuildService.Rebuild(ctx, finder, req, func(p *schema.SearchRebuildProgress) {
+				fmt.Printf("sent %d/%d contents, last %s id: %s\n", p.Processed, p.Total, p.ObjectType, p.LastID)
+			})
+			if err != nil {
+				fmt.Println("rebuild search index failed: ", err.Error())
+				fmt.Println("run the command again to resume from the checkpoint")
+				return
+			}
+			if progress.Resumed {
+				fmt.Println("the search index is rebuilt from the checkpoint of last interrupted rebuild")
+			}
+			fmt.Printf("rebuild search index of %s done, %d contents sent\n", progress.PluginSlugName, progress.Processed)
+		},
+	}
+
+	importUsersCmd = &cobra.Command{
+		Use:   "import-users",
+		Short: "Import users from csv",
+		Long:  `Create users in bulk from the csv for migrating an existing community, the imported users set the password by resetting it`,
+		Run: func(_ *cobra.Command, _ []string) {
+			log.SetLogger(log.NewStdLogger(os.Stdout))
+			cli.FormatAllPath(dataDirPath)
+			if len(importUsersFile) == 0 {
+				fmt.Println("the csv file is required, eg: -f ./users.csv")
+				return
+			}
+			content, err := os.ReadFile(importUsersFile)
+			if err != nil {
+				fmt.Println("read csv file failed: ", err.Error())
+				return
+			}
+			c, err := conf.ReadConfig(cli.GetConfigFilePath())
+			if err != nil {
+				fmt.Println("read config failed: ", err.Error())
+				return
+			}
+			if _, err = translator.NewTranslator(c.I18n); err != nil {
+				fmt.Println("load i18n failed: ", err.Error())
+				return
+			}
+			db, err := data.NewDB(false, c.Data.Database)
+			if err != nil {
+				fmt.Println("connect database failed: ", err.Error())
+				return
+			}
+			defer db.Close()
+			cache, cleanup, err := data.NewCache(c.Data.Cache)
+			if err != nil {
+				fmt.Println("init cache failed: ", err.Error())
+				return
+			}
+			defer cleanup()
+
+			req := &schema.ImportUsersReq{
+				CSV:                 string(content),
+				SendActivationEmail: importUsersSendActivationEmail,
+				WaitForEmails:       true,
+			}
+			resp, errFields, err := newUserImportService(&data.Data{DB: db, Cache: cache}).ImportUsers(context.Background(), req)
+			for _, field := range errFields {
+				fmt.Println(field.ErrorMsg)
+			}
+			if resp != nil {
+				for _, user := range resp.Users {
+					fmt.Printf("imported user %s <%s>, id: %s\n", user.Username, user.Email, user.UserID)
+				}
+			}
+			if err != nil {
+				fmt.Println("import users failed: ", err.Error())
+				return
+			}
+			fmt.Printf("import users done, %d users imported\n", resp.Count)
+		},
+	}
 )
 
+// newUserImportService build the user import service with the repositories of the data
+func newUserImportService(dataData *data.Data) *user_import.UserImportService {
+	userRepo := user.NewUserRepo(dataData)
+	siteInfoCommonService := siteinfo_common.NewSiteInfoCommonService(site_info.NewSiteInfo(dataData))
+	userRoleRelRepo := roleRepo.NewUserRoleRelRepo(dataData)
+	roleService := role.NewRoleService(roleRepo.NewRoleRepo(dataData), roleRepo.NewPowerRepo(dataData),
+		roleRepo.NewRolePowerRelRepo(dataData), userRoleRelRepo)
+	userRoleRelService := role.NewUserRoleRelService(userRoleRelRepo, roleService)
+	authService := auth.NewAuthService(authRepo.NewAuthRepo(dataData), bot.NewBotTokenRepo(dataData),
+		personal_access_token.NewPersonalAccessTokenAuthRepo(dataData))
+	userCommon := usercommon.NewUserCommon(userRepo, userRoleRelService, authService, siteInfoCommonService)
+	emailService := export.NewEmailService(config.NewConfigService(configRepo.NewConfigRepo(dataData)),
+		exportRepo.NewEmailRepo(dataData), siteInfoCommonService, email_template.NewEmailTemplateRepo(dataData))
+	auditLogService := audit_log.NewAuditLogService(auditLogRepo.NewAuditLogRepo(dataData), userCommon)
+	return user_import.NewUserImportService(user.NewUserImportRepo(dataData), userRepo, userCommon, roleService,
+		emailService, siteInfoCommonService, auditLogService)
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main(). It only needs to happen once to the rootCmd.
 func Execute() {
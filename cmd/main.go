@@ -30,6 +30,10 @@ import (
 	"github.com/apache/answer/internal/base/cron"
 	"github.com/apache/answer/internal/cli"
 	"github.com/apache/answer/internal/schema"
+	// first-party plugins, they are disabled until enabled by the admin
+	_ "github.com/apache/answer/plugin/anti_spam_akismet"
+	_ "github.com/apache/answer/plugin/search_elasticsearch"
+	_ "github.com/apache/answer/plugin/search_meilisearch"
 	"github.com/gin-gonic/gin"
 	"github.com/segmentfault/pacman"
 	"github.com/segmentfault/pacman/contrib/log/zap"
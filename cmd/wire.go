@@ -65,6 +65,7 @@ func initApplication(
 		repo.ProviderSetRepo,
 		translator.ProviderSet,
 		middleware.ProviderSetMiddleware,
+		wire.FieldsOf(new(*conf.Server), "HTTP"),
 		newApplication,
 	))
 }
@@ -39,81 +39,164 @@ import (
 	"github.com/apache/answer/internal/repo/activity"
 	"github.com/apache/answer/internal/repo/activity_common"
 	"github.com/apache/answer/internal/repo/answer"
+	"github.com/apache/answer/internal/repo/audit_log"
 	"github.com/apache/answer/internal/repo/auth"
+	"github.com/apache/answer/internal/repo/auto_promotion"
 	"github.com/apache/answer/internal/repo/badge"
 	"github.com/apache/answer/internal/repo/badge_award"
 	"github.com/apache/answer/internal/repo/badge_group"
+	"github.com/apache/answer/internal/repo/bot"
+	"github.com/apache/answer/internal/repo/cache_warming"
 	"github.com/apache/answer/internal/repo/captcha"
 	"github.com/apache/answer/internal/repo/collection"
 	"github.com/apache/answer/internal/repo/comment"
 	"github.com/apache/answer/internal/repo/config"
+	"github.com/apache/answer/internal/repo/content_freshness"
+	"github.com/apache/answer/internal/repo/content_language"
+	"github.com/apache/answer/internal/repo/data_dump"
+	"github.com/apache/answer/internal/repo/digest"
+	"github.com/apache/answer/internal/repo/email_template"
 	"github.com/apache/answer/internal/repo/export"
 	"github.com/apache/answer/internal/repo/file_record"
+	"github.com/apache/answer/internal/repo/helpfulness_survey"
+	"github.com/apache/answer/internal/repo/inbound_email"
+	"github.com/apache/answer/internal/repo/invite_code"
+	"github.com/apache/answer/internal/repo/ip_ban"
+	"github.com/apache/answer/internal/repo/jira"
+	"github.com/apache/answer/internal/repo/leaderboard"
 	"github.com/apache/answer/internal/repo/limit"
+	"github.com/apache/answer/internal/repo/login_protection"
 	"github.com/apache/answer/internal/repo/meta"
+	"github.com/apache/answer/internal/repo/mobile_push"
 	notification2 "github.com/apache/answer/internal/repo/notification"
+	"github.com/apache/answer/internal/repo/oauth"
+	"github.com/apache/answer/internal/repo/personal_access_token"
 	"github.com/apache/answer/internal/repo/plugin_config"
 	"github.com/apache/answer/internal/repo/question"
+	"github.com/apache/answer/internal/repo/question_close_vote"
+	"github.com/apache/answer/internal/repo/question_quality"
 	"github.com/apache/answer/internal/repo/rank"
+	"github.com/apache/answer/internal/repo/re_engagement"
 	"github.com/apache/answer/internal/repo/reason"
 	"github.com/apache/answer/internal/repo/report"
+	"github.com/apache/answer/internal/repo/report_appeal"
+	"github.com/apache/answer/internal/repo/reputation_sync"
 	"github.com/apache/answer/internal/repo/review"
 	"github.com/apache/answer/internal/repo/revision"
 	"github.com/apache/answer/internal/repo/role"
+	"github.com/apache/answer/internal/repo/saved_search"
+	"github.com/apache/answer/internal/repo/scim"
 	"github.com/apache/answer/internal/repo/search_common"
 	"github.com/apache/answer/internal/repo/site_info"
+	"github.com/apache/answer/internal/repo/syndication"
+	"github.com/apache/answer/internal/repo/table_partition"
 	"github.com/apache/answer/internal/repo/tag"
+	"github.com/apache/answer/internal/repo/tag_analytics"
 	"github.com/apache/answer/internal/repo/tag_common"
+	"github.com/apache/answer/internal/repo/tag_ignore"
+	"github.com/apache/answer/internal/repo/tag_moderator"
+	"github.com/apache/answer/internal/repo/tag_translation"
 	"github.com/apache/answer/internal/repo/unique"
 	"github.com/apache/answer/internal/repo/user"
 	"github.com/apache/answer/internal/repo/user_external_login"
+	"github.com/apache/answer/internal/repo/user_mute"
 	"github.com/apache/answer/internal/repo/user_notification_config"
+	"github.com/apache/answer/internal/repo/user_timeline"
+	"github.com/apache/answer/internal/repo/vote_fraud"
+	"github.com/apache/answer/internal/repo/web_push"
+	"github.com/apache/answer/internal/repo/webhook"
 	"github.com/apache/answer/internal/router"
 	"github.com/apache/answer/internal/service/action"
 	activity2 "github.com/apache/answer/internal/service/activity"
 	activity_common2 "github.com/apache/answer/internal/service/activity_common"
 	"github.com/apache/answer/internal/service/activity_queue"
+	"github.com/apache/answer/internal/service/admin_export"
 	"github.com/apache/answer/internal/service/answer_common"
+	audit_log2 "github.com/apache/answer/internal/service/audit_log"
 	auth2 "github.com/apache/answer/internal/service/auth"
+	"github.com/apache/answer/internal/service/auto_comment"
+	auto_promotion2 "github.com/apache/answer/internal/service/auto_promotion"
 	badge2 "github.com/apache/answer/internal/service/badge"
+	bot2 "github.com/apache/answer/internal/service/bot"
+	cache_warming2 "github.com/apache/answer/internal/service/cache_warming"
+	"github.com/apache/answer/internal/service/chat_intake"
 	collection2 "github.com/apache/answer/internal/service/collection"
 	"github.com/apache/answer/internal/service/collection_common"
 	comment2 "github.com/apache/answer/internal/service/comment"
 	"github.com/apache/answer/internal/service/comment_common"
 	config2 "github.com/apache/answer/internal/service/config"
 	"github.com/apache/answer/internal/service/content"
+	content_freshness2 "github.com/apache/answer/internal/service/content_freshness"
+	content_language2 "github.com/apache/answer/internal/service/content_language"
 	"github.com/apache/answer/internal/service/dashboard"
+	data_dump2 "github.com/apache/answer/internal/service/data_dump"
+	digest2 "github.com/apache/answer/internal/service/digest"
+	email_template2 "github.com/apache/answer/internal/service/email_template"
 	"github.com/apache/answer/internal/service/event_queue"
 	export2 "github.com/apache/answer/internal/service/export"
 	file_record2 "github.com/apache/answer/internal/service/file_record"
 	"github.com/apache/answer/internal/service/follow"
+	helpfulness_survey2 "github.com/apache/answer/internal/service/helpfulness_survey"
 	"github.com/apache/answer/internal/service/importer"
+	inbound_email2 "github.com/apache/answer/internal/service/inbound_email"
+	invite_code2 "github.com/apache/answer/internal/service/invite_code"
+	ip_ban2 "github.com/apache/answer/internal/service/ip_ban"
+	jira2 "github.com/apache/answer/internal/service/jira"
+	leaderboard2 "github.com/apache/answer/internal/service/leaderboard"
+	login_protection2 "github.com/apache/answer/internal/service/login_protection"
+	"github.com/apache/answer/internal/service/messenger"
 	meta2 "github.com/apache/answer/internal/service/meta"
 	"github.com/apache/answer/internal/service/meta_common"
+	mobile_push2 "github.com/apache/answer/internal/service/mobile_push"
 	"github.com/apache/answer/internal/service/notice_queue"
 	"github.com/apache/answer/internal/service/notification"
 	"github.com/apache/answer/internal/service/notification_common"
+	"github.com/apache/answer/internal/service/notification_retention"
+	oauth2 "github.com/apache/answer/internal/service/oauth"
 	"github.com/apache/answer/internal/service/object_info"
+	personal_access_token2 "github.com/apache/answer/internal/service/personal_access_token"
 	"github.com/apache/answer/internal/service/plugin_common"
+	question_close_vote2 "github.com/apache/answer/internal/service/question_close_vote"
 	"github.com/apache/answer/internal/service/question_common"
+	"github.com/apache/answer/internal/service/question_heat"
+	question_quality2 "github.com/apache/answer/internal/service/question_quality"
 	rank2 "github.com/apache/answer/internal/service/rank"
+	re_engagement2 "github.com/apache/answer/internal/service/re_engagement"
 	reason2 "github.com/apache/answer/internal/service/reason"
 	report2 "github.com/apache/answer/internal/service/report"
+	report_appeal2 "github.com/apache/answer/internal/service/report_appeal"
 	"github.com/apache/answer/internal/service/report_handle"
+	reputation_sync2 "github.com/apache/answer/internal/service/reputation_sync"
 	review2 "github.com/apache/answer/internal/service/review"
 	"github.com/apache/answer/internal/service/revision_common"
 	role2 "github.com/apache/answer/internal/service/role"
+	saved_search2 "github.com/apache/answer/internal/service/saved_search"
+	scim2 "github.com/apache/answer/internal/service/scim"
 	"github.com/apache/answer/internal/service/search_parser"
 	"github.com/apache/answer/internal/service/service_config"
 	"github.com/apache/answer/internal/service/siteinfo"
 	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/apache/answer/internal/service/slack"
+	"github.com/apache/answer/internal/service/space_common"
+	syndication2 "github.com/apache/answer/internal/service/syndication"
+	"github.com/apache/answer/internal/service/syndication_common"
+	table_partition2 "github.com/apache/answer/internal/service/table_partition"
 	tag2 "github.com/apache/answer/internal/service/tag"
+	tag_analytics2 "github.com/apache/answer/internal/service/tag_analytics"
 	tag_common2 "github.com/apache/answer/internal/service/tag_common"
+	tag_moderator2 "github.com/apache/answer/internal/service/tag_moderator"
 	"github.com/apache/answer/internal/service/uploader"
 	"github.com/apache/answer/internal/service/user_admin"
 	"github.com/apache/answer/internal/service/user_common"
 	user_external_login2 "github.com/apache/answer/internal/service/user_external_login"
+	"github.com/apache/answer/internal/service/user_import"
+	user_mute2 "github.com/apache/answer/internal/service/user_mute"
 	user_notification_config2 "github.com/apache/answer/internal/service/user_notification_config"
+	"github.com/apache/answer/internal/service/user_session"
+	user_timeline2 "github.com/apache/answer/internal/service/user_timeline"
+	vote_fraud2 "github.com/apache/answer/internal/service/vote_fraud"
+	web_push2 "github.com/apache/answer/internal/service/web_push"
+	webhook2 "github.com/apache/answer/internal/service/webhook"
 	"github.com/segmentfault/pacman"
 	"github.com/segmentfault/pacman/log"
 )
@@ -131,6 +214,7 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	if err != nil {
 		return nil, nil, err
 	}
+	queryStats := data.NewQueryStats(engine, dbConf)
 	cache, cleanup, err := data.NewCache(cacheConf)
 	if err != nil {
 		return nil, nil, err
@@ -144,7 +228,9 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	siteInfoCommonService := siteinfo_common.NewSiteInfoCommonService(siteInfoRepo)
 	langController := controller.NewLangController(i18nTranslator, siteInfoCommonService)
 	authRepo := auth.NewAuthRepo(dataData)
-	authService := auth2.NewAuthService(authRepo)
+	botTokenRepo := bot.NewBotTokenRepo(dataData)
+	personalAccessTokenRepo := personal_access_token.NewPersonalAccessTokenAuthRepo(dataData)
+	authService := auth2.NewAuthService(authRepo, botTokenRepo, personalAccessTokenRepo)
 	userRepo := user.NewUserRepo(dataData)
 	uniqueIDRepo := unique.NewUniqueIDRepo(dataData)
 	configRepo := config.NewConfigRepo(dataData)
@@ -153,16 +239,24 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	userRankRepo := rank.NewUserRankRepo(dataData, configService)
 	userActiveActivityRepo := activity.NewUserActiveActivityRepo(dataData, activityRepo, userRankRepo, configService)
 	emailRepo := export.NewEmailRepo(dataData)
-	emailService := export2.NewEmailService(configService, emailRepo, siteInfoCommonService)
+	emailTemplateRepo := email_template.NewEmailTemplateRepo(dataData)
+	emailService := export2.NewEmailService(configService, emailRepo, siteInfoCommonService, emailTemplateRepo)
 	userRoleRelRepo := role.NewUserRoleRelRepo(dataData)
 	roleRepo := role.NewRoleRepo(dataData)
-	roleService := role2.NewRoleService(roleRepo)
+	powerRepo := role.NewPowerRepo(dataData)
+	rolePowerRelRepo := role.NewRolePowerRelRepo(dataData)
+	roleService := role2.NewRoleService(roleRepo, powerRepo, rolePowerRelRepo, userRoleRelRepo)
 	userRoleRelService := role2.NewUserRoleRelService(userRoleRelRepo, roleService)
 	userCommon := usercommon.NewUserCommon(userRepo, userRoleRelService, authService, siteInfoCommonService)
+	auditLogRepo := audit_log.NewAuditLogRepo(dataData)
+	auditLogService := audit_log2.NewAuditLogService(auditLogRepo, userCommon)
+	userTimelineRepo := user_timeline.NewUserTimelineRepo(dataData)
+	userTimelineService := user_timeline2.NewUserTimelineService(userTimelineRepo, userRepo, userCommon)
 	userExternalLoginRepo := user_external_login.NewUserExternalLoginRepo(dataData)
 	userNotificationConfigRepo := user_notification_config.NewUserNotificationConfigRepo(dataData)
 	userNotificationConfigService := user_notification_config2.NewUserNotificationConfigService(userRepo, userNotificationConfigRepo)
-	userExternalLoginService := user_external_login2.NewUserExternalLoginService(userRepo, userCommon, userExternalLoginRepo, emailService, siteInfoCommonService, userActiveActivityRepo, userNotificationConfigService)
+	eventQueueService := event_queue.NewEventQueueService()
+	userExternalLoginService := user_external_login2.NewUserExternalLoginService(userRepo, userCommon, userExternalLoginRepo, emailService, siteInfoCommonService, userActiveActivityRepo, userNotificationConfigService, eventQueueService)
 	questionRepo := question.NewQuestionRepo(dataData, uniqueIDRepo)
 	answerRepo := answer.NewAnswerRepo(dataData, uniqueIDRepo, userRankRepo, activityRepo)
 	voteRepo := activity_common.NewVoteRepo(dataData, activityRepo)
@@ -173,46 +267,72 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	revisionRepo := revision.NewRevisionRepo(dataData, uniqueIDRepo)
 	revisionService := revision_common.NewRevisionService(revisionRepo, userRepo)
 	activityQueueService := activity_queue.NewActivityQueueService()
-	tagCommonService := tag_common2.NewTagCommonService(tagCommonRepo, tagRelRepo, tagRepo, revisionService, siteInfoCommonService, activityQueueService)
+	tagTranslationRepo := tag_translation.NewTagTranslationRepo(dataData)
+	tagCommonService := tag_common2.NewTagCommonService(tagCommonRepo, tagRelRepo, tagRepo, revisionService, siteInfoCommonService, activityQueueService, tagTranslationRepo)
 	collectionRepo := collection.NewCollectionRepo(dataData, uniqueIDRepo)
 	collectionCommon := collectioncommon.NewCollectionCommon(collectionRepo)
 	answerCommon := answercommon.NewAnswerCommon(answerRepo)
 	metaRepo := meta.NewMetaRepo(dataData)
 	metaCommonService := metacommon.NewMetaCommonService(metaRepo)
 	questionCommon := questioncommon.NewQuestionCommon(questionRepo, answerRepo, voteRepo, followRepo, tagCommonService, userCommon, collectionCommon, answerCommon, metaCommonService, configService, activityQueueService, revisionRepo, siteInfoCommonService, dataData)
-	eventQueueService := event_queue.NewEventQueueService()
 	fileRecordRepo := file_record.NewFileRecordRepo(dataData)
 	fileRecordService := file_record2.NewFileRecordService(fileRecordRepo, revisionRepo, serviceConf, siteInfoCommonService, userCommon)
-	userService := content.NewUserService(userRepo, userActiveActivityRepo, activityRepo, emailService, authService, siteInfoCommonService, userRoleRelService, userCommon, userExternalLoginService, userNotificationConfigRepo, userNotificationConfigService, questionCommon, eventQueueService, fileRecordService)
+	badgeAwardRepo := badge_award.NewBadgeAwardRepo(dataData, uniqueIDRepo)
+	inviteCodeRepo := invite_code.NewInviteCodeRepo(dataData)
+	inviteCodeService := invite_code2.NewInviteCodeService(inviteCodeRepo, userRepo, siteInfoCommonService)
+	userService := content.NewUserService(userRepo, userActiveActivityRepo, activityRepo, emailService, authService, siteInfoCommonService, userRoleRelService, userCommon, userExternalLoginService, userNotificationConfigRepo, userNotificationConfigService, questionCommon, eventQueueService, fileRecordService, userTimelineService, badgeAwardRepo, inviteCodeService)
 	captchaRepo := captcha.NewCaptchaRepo(dataData)
 	captchaService := action.NewCaptchaService(captchaRepo)
-	userController := controller.NewUserController(authService, userService, captchaService, emailService, siteInfoCommonService, userNotificationConfigService)
+	loginProtectionRepo := login_protection.NewLoginProtectionRepo(dataData)
+	loginProtectionService := login_protection2.NewLoginProtectionService(loginProtectionRepo, userRepo, siteInfoCommonService, emailService)
+	userController := controller.NewUserController(authService, userService, captchaService, emailService, siteInfoCommonService, userNotificationConfigService, loginProtectionService)
 	commentRepo := comment.NewCommentRepo(dataData, uniqueIDRepo)
 	commentCommonRepo := comment.NewCommentCommonRepo(dataData, uniqueIDRepo)
 	objService := object_info.NewObjService(answerRepo, questionRepo, commentCommonRepo, tagCommonRepo, tagCommonService)
 	notificationQueueService := notice_queue.NewNotificationQueueService()
+	notificationFanOutQueueService := notice_queue.NewNotificationFanOutQueueService()
 	externalNotificationQueueService := notice_queue.NewNewQuestionNotificationQueueService()
-	commentService := comment2.NewCommentService(commentRepo, commentCommonRepo, userCommon, objService, voteRepo, emailService, userRepo, notificationQueueService, externalNotificationQueueService, activityQueueService, eventQueueService)
-	rolePowerRelRepo := role.NewRolePowerRelRepo(dataData)
+	spaceCommon := space_common.NewSpaceCommon(siteInfoCommonService, tagCommonService, userCommon, userRoleRelService)
 	rolePowerRelService := role2.NewRolePowerRelService(rolePowerRelRepo, userRoleRelService)
-	rankService := rank2.NewRankService(userCommon, userRankRepo, objService, userRoleRelService, rolePowerRelService, configService)
+	tagModeratorRepo := tag_moderator.NewTagModeratorRepo(dataData)
+	rankService := rank2.NewRankService(userCommon, userRankRepo, objService, userRoleRelService, rolePowerRelService, configService, tagModeratorRepo)
 	limitRepo := limit.NewRateLimitRepo(dataData)
-	rateLimitMiddleware := middleware.NewRateLimitMiddleware(limitRepo)
-	commentController := controller.NewCommentController(commentService, rankService, captchaService, rateLimitMiddleware)
+	rateLimitMiddleware := middleware.NewRateLimitMiddleware(limitRepo, siteInfoCommonService, userCommon)
+	loadSheddingMiddleware := middleware.NewLoadSheddingMiddleware(siteInfoCommonService)
+	ipBanRepo := ip_ban.NewIPBanRepo(dataData)
+	ipBanService := ip_ban2.NewIPBanService(ipBanRepo, auditLogService)
+	ipBanMiddleware := middleware.NewIPBanMiddleware(ipBanService)
+	loadSheddingController := controller_admin.NewLoadSheddingController(loadSheddingMiddleware)
+	queryStatsController := controller_admin.NewQueryStatsController(queryStats)
 	reportRepo := report.NewReportRepo(dataData, uniqueIDRepo)
-	tagService := tag2.NewTagService(tagRepo, tagCommonService, revisionService, followRepo, siteInfoCommonService, activityQueueService)
+	tagIgnoreRepo := tag_ignore.NewTagIgnoreRepo(dataData)
+	tagService := tag2.NewTagService(tagRepo, tagCommonService, revisionService, followRepo, activityRepo, siteInfoCommonService, activityQueueService, tagIgnoreRepo, auditLogService)
 	answerActivityRepo := activity.NewAnswerActivityRepo(dataData, activityRepo, userRankRepo, notificationQueueService)
 	answerActivityService := activity2.NewAnswerActivityService(answerActivityRepo, configService)
-	externalNotificationService := notification.NewExternalNotificationService(dataData, userNotificationConfigRepo, followRepo, emailService, userRepo, externalNotificationQueueService, userExternalLoginRepo, siteInfoCommonService)
+	userMuteRepo := user_mute.NewUserMuteRepo(dataData)
+	userMuteService := user_mute2.NewUserMuteService(userMuteRepo, questionRepo, userCommon)
+	externalNotificationService := notification.NewExternalNotificationService(dataData, userNotificationConfigRepo, followRepo, emailService, userRepo, externalNotificationQueueService, userExternalLoginRepo, siteInfoCommonService, userMuteService)
 	reviewRepo := review.NewReviewRepo(dataData)
-	reviewService := review2.NewReviewService(reviewRepo, objService, userCommon, userRepo, questionRepo, answerRepo, userRoleRelService, externalNotificationQueueService, tagCommonService, questionCommon, notificationQueueService, siteInfoCommonService)
-	questionService := content.NewQuestionService(activityRepo, questionRepo, answerRepo, tagCommonService, tagService, questionCommon, userCommon, userRepo, userRoleRelService, revisionService, metaCommonService, collectionCommon, answerActivityService, emailService, notificationQueueService, externalNotificationQueueService, activityQueueService, siteInfoCommonService, externalNotificationService, reviewService, configService, eventQueueService, reviewRepo)
-	answerService := content.NewAnswerService(answerRepo, questionRepo, questionCommon, userCommon, collectionCommon, userRepo, revisionService, answerActivityService, answerCommon, voteRepo, emailService, userRoleRelService, notificationQueueService, externalNotificationQueueService, activityQueueService, reviewService, eventQueueService)
+	contentLanguageRepo := content_language.NewContentLanguageRepo(dataData)
+	contentLanguageService := content_language2.NewContentLanguageService(contentLanguageRepo, siteInfoCommonService)
+	reviewService := review2.NewReviewService(reviewRepo, objService, userCommon, userRepo, questionRepo, answerRepo, userRoleRelService, externalNotificationQueueService, tagCommonService, questionCommon, notificationQueueService, siteInfoCommonService, contentLanguageService, commentCommonRepo)
+	commentService := comment2.NewCommentService(commentRepo, commentCommonRepo, userCommon, objService, voteRepo, emailService, userRepo, notificationQueueService, externalNotificationQueueService, activityQueueService, eventQueueService, spaceCommon, auditLogService, reviewService)
+	commentController := controller.NewCommentController(commentService, rankService, captchaService, rateLimitMiddleware)
+	syndicationRepo := syndication.NewSyndicationRepo(dataData)
+	syndicationCommon := syndication_common.NewSyndicationCommon(syndicationRepo)
+	helpfulnessSurveyRepo := helpfulness_survey.NewHelpfulnessSurveyRepo(dataData)
+	helpfulnessSurveyService := helpfulness_survey2.NewHelpfulnessSurveyService(helpfulnessSurveyRepo, questionRepo, answerRepo, siteInfoCommonService)
+	questionQualityRepo := question_quality.NewQuestionQualityRepo(dataData)
+	questionQualityService := question_quality2.NewQuestionQualityService(questionQualityRepo, questionRepo, tagCommonService, userRepo, userCommon, siteInfoCommonService)
+	autoCommentService := auto_comment.NewAutoCommentService(dataData, questionRepo, userRepo, commentService, siteInfoCommonService)
+	questionHeatTracker := question_heat.NewQuestionHeatTracker()
+	questionService := content.NewQuestionService(activityRepo, questionRepo, answerRepo, tagCommonService, tagService, questionCommon, userCommon, userRepo, userRoleRelService, revisionService, metaCommonService, collectionCommon, answerActivityService, emailService, notificationQueueService, externalNotificationQueueService, activityQueueService, siteInfoCommonService, externalNotificationService, reviewService, configService, eventQueueService, reviewRepo, syndicationCommon, questionQualityService, autoCommentService, questionHeatTracker, spaceCommon, auditLogService, serviceConf)
+	answerService := content.NewAnswerService(answerRepo, questionRepo, questionCommon, userCommon, collectionCommon, userRepo, revisionService, answerActivityService, answerCommon, voteRepo, emailService, userRoleRelService, notificationQueueService, externalNotificationQueueService, activityQueueService, reviewService, eventQueueService, siteInfoCommonService, syndicationCommon, helpfulnessSurveyService, spaceCommon, auditLogService)
 	reportHandle := report_handle.NewReportHandle(questionService, answerService, commentService)
-	reportService := report2.NewReportService(reportRepo, objService, userCommon, answerRepo, questionRepo, commentCommonRepo, reportHandle, configService, eventQueueService)
+	reportService := report2.NewReportService(reportRepo, objService, userCommon, answerRepo, questionRepo, commentCommonRepo, reportHandle, configService, eventQueueService, notificationQueueService, auditLogService)
 	reportController := controller.NewReportController(reportService, rankService, captchaService)
 	contentVoteRepo := activity.NewVoteRepo(dataData, activityRepo, userRankRepo, notificationQueueService)
-	voteService := content.NewVoteService(contentVoteRepo, configService, questionRepo, answerRepo, commentCommonRepo, objService, eventQueueService)
+	voteService := content.NewVoteService(contentVoteRepo, configService, questionRepo, answerRepo, commentCommonRepo, objService, eventQueueService, siteInfoCommonService)
 	voteController := controller.NewVoteController(voteService, rankService, captchaService)
 	tagController := controller.NewTagController(tagService, tagCommonService, rankService)
 	followFollowRepo := activity.NewFollowRepo(dataData, uniqueIDRepo, activityRepo)
@@ -225,7 +345,7 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	answerController := controller.NewAnswerController(answerService, rankService, captchaService, siteInfoCommonService, rateLimitMiddleware)
 	searchParser := search_parser.NewSearchParser(tagCommonService, userCommon)
 	searchRepo := search_common.NewSearchRepo(dataData, uniqueIDRepo, userCommon, tagCommonService)
-	searchService := content.NewSearchService(searchParser, searchRepo)
+	searchService := content.NewSearchService(searchParser, searchRepo, tagCommonService, spaceCommon)
 	searchController := controller.NewSearchController(searchService, captchaService)
 	reviewActivityRepo := activity.NewReviewActivityRepo(dataData, activityRepo, userRankRepo, configService)
 	contentRevisionService := content.NewRevisionService(revisionRepo, userCommon, questionCommon, answerService, objService, questionRepo, answerRepo, tagRepo, tagCommonService, notificationQueueService, activityQueueService, reportRepo, reviewService, reviewActivityRepo)
@@ -234,17 +354,23 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	userAdminRepo := user.NewUserAdminRepo(dataData, authRepo)
 	notificationRepo := notification2.NewNotificationRepo(dataData)
 	pluginUserConfigRepo := plugin_config.NewPluginUserConfigRepo(dataData)
-	badgeAwardRepo := badge_award.NewBadgeAwardRepo(dataData, uniqueIDRepo)
-	userAdminService := user_admin.NewUserAdminService(userAdminRepo, userRoleRelService, authService, userCommon, userActiveActivityRepo, siteInfoCommonService, emailService, questionRepo, answerRepo, commentCommonRepo, userExternalLoginRepo, notificationRepo, pluginUserConfigRepo, badgeAwardRepo)
+	userAdminService := user_admin.NewUserAdminService(userAdminRepo, userRoleRelService, authService, userCommon, userActiveActivityRepo, siteInfoCommonService, emailService, questionRepo, answerRepo, commentCommonRepo, userExternalLoginRepo, notificationRepo, pluginUserConfigRepo, badgeAwardRepo, voteService, userTimelineService, auditLogService)
 	userAdminController := controller_admin.NewUserAdminController(userAdminService)
+	adminExportService := admin_export.NewAdminExportService(dataData, questionService, userAdminService, auditLogService, serviceConf)
+	adminExportController := controller_admin.NewAdminExportController(adminExportService)
 	reasonRepo := reason.NewReasonRepo(configService)
 	reasonService := reason2.NewReasonService(reasonRepo)
 	reasonController := controller.NewReasonController(reasonService)
 	themeController := controller_admin.NewThemeController()
-	siteInfoService := siteinfo.NewSiteInfoService(siteInfoRepo, siteInfoCommonService, emailService, tagCommonService, configService, questionCommon, fileRecordService)
+	siteInfoService := siteinfo.NewSiteInfoService(siteInfoRepo, siteInfoCommonService, emailService, tagCommonService, configService, questionCommon, fileRecordService, auditLogService)
 	siteInfoController := controller_admin.NewSiteInfoController(siteInfoService)
-	controllerSiteInfoController := controller.NewSiteInfoController(siteInfoCommonService)
-	notificationCommon := notificationcommon.NewNotificationCommon(dataData, notificationRepo, userCommon, activityRepo, followRepo, objService, notificationQueueService, userExternalLoginRepo, siteInfoCommonService)
+	controllerSiteInfoController := controller.NewSiteInfoController(siteInfoCommonService, spaceCommon)
+	voteMilestoneRepo := notification2.NewVoteMilestoneRepo(dataData)
+	webPushRepo := web_push.NewWebPushRepo(dataData)
+	webPushService := web_push2.NewWebPushService(webPushRepo, siteInfoCommonService, userCommon, userNotificationConfigRepo)
+	mobilePushRepo := mobile_push.NewMobilePushRepo(dataData)
+	mobilePushService := mobile_push2.NewMobilePushService(mobilePushRepo, siteInfoCommonService, userCommon, userNotificationConfigRepo)
+	notificationCommon := notificationcommon.NewNotificationCommon(dataData, notificationRepo, userCommon, activityRepo, followRepo, objService, notificationQueueService, userExternalLoginRepo, siteInfoCommonService, voteMilestoneRepo, userNotificationConfigRepo, notificationFanOutQueueService, webPushService, mobilePushService, userMuteService)
 	badgeRepo := badge.NewBadgeRepo(dataData, uniqueIDRepo)
 	notificationService := notification.NewNotificationService(dataData, notificationRepo, notificationCommon, revisionService, userRepo, reportRepo, reviewService, badgeRepo)
 	notificationController := controller.NewNotificationController(notificationService, rankService)
@@ -255,11 +381,11 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	activityActivityRepo := activity.NewActivityRepo(dataData, configService)
 	activityCommon := activity_common2.NewActivityCommon(activityRepo, activityQueueService)
 	commentCommonService := comment_common.NewCommentCommonService(commentCommonRepo)
-	activityService := activity2.NewActivityService(activityActivityRepo, userCommon, activityCommon, tagCommonService, objService, commentCommonService, revisionService, metaCommonService, configService)
+	activityService := activity2.NewActivityService(activityActivityRepo, userCommon, activityCommon, tagCommonService, objService, commentCommonService, revisionService, metaCommonService, configService, answerRepo, userRoleRelService, spaceCommon)
 	activityController := controller.NewActivityController(activityService)
-	roleController := controller_admin.NewRoleController(roleService)
+	roleController := controller_admin.NewRoleController(roleService, auditLogService)
 	pluginConfigRepo := plugin_config.NewPluginConfigRepo(dataData)
-	importerService := importer.NewImporterService(questionService, rankService, userCommon)
+	importerService := importer.NewImporterService(questionService, answerService, rankService, userCommon)
 	pluginCommonService := plugin_common.NewPluginCommonService(pluginConfigRepo, pluginUserConfigRepo, configService, dataData, importerService)
 	pluginController := controller_admin.NewPluginController(pluginCommonService)
 	permissionController := controller.NewPermissionController(rankService)
@@ -274,24 +400,116 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	badgeService := badge2.NewBadgeService(badgeRepo, badgeGroupRepo, badgeAwardRepo, badgeEventService, siteInfoCommonService)
 	badgeController := controller.NewBadgeController(badgeService, badgeAwardService)
 	controller_adminBadgeController := controller_admin.NewBadgeController(badgeService)
-	answerAPIRouter := router.NewAnswerAPIRouter(langController, userController, commentController, reportController, voteController, tagController, followController, collectionController, questionController, answerController, searchController, revisionController, rankController, userAdminController, reasonController, themeController, siteInfoController, controllerSiteInfoController, notificationController, dashboardController, uploadController, activityController, roleController, pluginController, permissionController, userPluginController, reviewController, metaController, badgeController, controller_adminBadgeController)
+	questionCloseVoteRepo := question_close_vote.NewQuestionCloseVoteRepo(dataData)
+	questionCloseVoteService := question_close_vote2.NewQuestionCloseVoteService(questionCloseVoteRepo, questionRepo, questionService, configService, siteInfoCommonService, notificationQueueService)
+	questionCloseVoteController := controller.NewQuestionCloseVoteController(questionCloseVoteService, rankService)
+	reportAppealRepo := report_appeal.NewReportAppealRepo(dataData)
+	reportAppealService := report_appeal2.NewReportAppealService(reportAppealRepo, reportRepo, reportService, questionService, answerService, userCommon, notificationQueueService)
+	reportAppealController := controller.NewReportAppealController(reportAppealService, rankService)
+	dataDumpRepo := data_dump.NewDataDumpRepo(dataData)
+	dataDumpService := data_dump2.NewDataDumpService(dataDumpRepo, siteInfoRepo, siteInfoCommonService, serviceConf, spaceCommon)
+	dataDumpController := controller.NewDataDumpController(dataDumpService)
+	chatIntakeService := chat_intake.NewChatIntakeService(siteInfoCommonService, userCommon, questionService, searchService, rankService)
+	chatIntakeController := controller.NewChatIntakeController(chatIntakeService)
+	reputationRecalcRepo := rank.NewReputationRecalcRepo(dataData)
+	reputationRecalcService := rank2.NewReputationRecalcService(reputationRecalcRepo)
+	reputationController := controller_admin.NewReputationController(reputationRecalcService)
+	reputationSyncRepo := reputation_sync.NewReputationSyncRepo(dataData)
+	reputationSyncService := reputation_sync2.NewReputationSyncService(reputationSyncRepo, siteInfoRepo, siteInfoCommonService, userRepo, userRoleRelService, roleService)
+	voteFraudRepo := vote_fraud.NewVoteFraudRepo(dataData)
+	voteFraudService := vote_fraud2.NewVoteFraudService(voteFraudRepo, voteService, configService, siteInfoCommonService, userCommon)
+	voteFraudController := controller_admin.NewVoteFraudController(voteFraudService)
+	autoPromotionRepo := auto_promotion.NewAutoPromotionRepo(dataData)
+	autoPromotionService := auto_promotion2.NewAutoPromotionService(autoPromotionRepo, siteInfoCommonService, roleService, userRoleRelService, authService, notificationQueueService, userCommon)
+	autoPromotionController := controller_admin.NewAutoPromotionController(autoPromotionService)
+	contentFreshnessRepo := content_freshness.NewContentFreshnessRepo(dataData)
+	contentFreshnessService := content_freshness2.NewContentFreshnessService(contentFreshnessRepo, siteInfoCommonService, tagCommonService)
+	contentFreshnessController := controller_admin.NewContentFreshnessController(contentFreshnessService)
+	botRepo := bot.NewBotRepo(dataData)
+	botService := bot2.NewBotService(botRepo, userCommon, authService, siteInfoCommonService)
+	webhookRepo := webhook.NewWebhookRepo(dataData)
+	webhookService := webhook2.NewWebhookService(webhookRepo, siteInfoCommonService, userCommon, objService, spaceCommon, eventQueueService)
+	webhookController := controller_admin.NewWebhookController(webhookService)
+	slackService := slack.NewSlackService(questionRepo, tagCommonService, objService, userCommon, siteInfoCommonService, spaceCommon, eventQueueService)
+	messengerService := messenger.NewMessengerService(tagCommonService, objService, userCommon, siteInfoCommonService, spaceCommon, eventQueueService)
+	messengerController := controller_admin.NewMessengerController(messengerService)
+	adminPermissionController := controller_admin.NewPermissionController(rankService)
+	jiraRepo := jira.NewJiraRepo(dataData)
+	jiraService := jira2.NewJiraService(jiraRepo, questionRepo, tagCommonService, userRepo, commentService, siteInfoCommonService, spaceCommon, eventQueueService)
+	jiraController := controller.NewJiraController(jiraService)
+	webPushController := controller.NewWebPushController(webPushService)
+	mobilePushController := controller.NewMobilePushController(mobilePushService)
+	emailTemplateService := email_template2.NewEmailTemplateService(emailTemplateRepo)
+	emailTemplateController := controller_admin.NewEmailTemplateController(emailTemplateService)
+	inboundEmailRepo := inbound_email.NewInboundEmailRepo(dataData)
+	inboundEmailService := inbound_email2.NewInboundEmailService(inboundEmailRepo, siteInfoCommonService, userRepo, commentCommonRepo, answerService, commentService, rankService)
+	inboundEmailController := controller.NewInboundEmailController(inboundEmailService)
+	userMuteController := controller.NewUserMuteController(userMuteService)
+	scimRepo := scim.NewSCIMRepo(dataData)
+	scimService := scim2.NewSCIMService(scimRepo, userRepo, userCommon, userAdminService, userExternalLoginRepo, userRoleRelService, roleService, authService, siteInfoCommonService)
+	scimController := controller.NewSCIMController(scimService)
+	personal_access_tokenPersonalAccessTokenRepo := personal_access_token.NewPersonalAccessTokenRepo(dataData)
+	personalAccessTokenService := personal_access_token2.NewPersonalAccessTokenService(personal_access_tokenPersonalAccessTokenRepo, authService)
+	personalAccessTokenController := controller.NewPersonalAccessTokenController(personalAccessTokenService)
+	oauthRepo := oauth.NewOAuthRepo(dataData)
+	oauthService := oauth2.NewOAuthService(oauthRepo, userRepo, siteInfoCommonService)
+	oauthController := controller.NewOAuthController(oauthService)
+	oauthClientController := controller_admin.NewOAuthClientController(oauthService)
+	userSessionService := user_session.NewUserSessionService(authService, userRepo)
+	userSessionController := controller.NewUserSessionController(userSessionService)
+	controller_adminUserSessionController := controller_admin.NewUserSessionController(userSessionService)
+	inviteCodeController := controller.NewInviteCodeController(inviteCodeService)
+	controller_adminInviteCodeController := controller_admin.NewInviteCodeController(inviteCodeService)
+	controller_adminIPBanController := controller_admin.NewIPBanController(ipBanService)
+	userImportRepo := user.NewUserImportRepo(dataData)
+	userImportService := user_import.NewUserImportService(userImportRepo, userRepo, userCommon, roleService, emailService, siteInfoCommonService, auditLogService)
+	controller_adminUserImportController := controller_admin.NewUserImportController(userImportService)
+	auditLogController := controller_admin.NewAuditLogController(auditLogService)
+	botController := controller_admin.NewBotController(botService)
+	savedSearchRepo := saved_search.NewSavedSearchRepo(dataData)
+	savedSearchService := saved_search2.NewSavedSearchService(savedSearchRepo, searchService, notificationQueueService, spaceCommon)
+	savedSearchController := controller.NewSavedSearchController(savedSearchService)
+	cacheWarmingRepo := cache_warming.NewCacheWarmingRepo(dataData)
+	cacheWarmingService := cache_warming2.NewCacheWarmingService(cacheWarmingRepo, questionHeatTracker, questionCommon, siteInfoCommonService)
+	tablePartitionRepo := table_partition.NewTablePartitionRepo(dataData)
+	tablePartitionService := table_partition2.NewTablePartitionService(tablePartitionRepo, serviceConf)
+	contentLanguageController := controller_admin.NewContentLanguageController(contentLanguageService)
+	userTimelineController := controller_admin.NewUserTimelineController(userTimelineService)
+	leaderboardRepo := leaderboard.NewLeaderboardRepo(dataData)
+	leaderboardService := leaderboard2.NewLeaderboardService(leaderboardRepo, activityRepo, tagCommonService, userCommon)
+	leaderboardController := controller.NewLeaderboardController(leaderboardService)
+	syndicationService := syndication2.NewSyndicationService(syndicationRepo, questionService, tagCommonService, siteInfoCommonService, userCommon, spaceCommon)
+	syndicationController := controller.NewSyndicationController(syndicationService)
+	helpfulnessSurveyController := controller.NewHelpfulnessSurveyController(helpfulnessSurveyService)
+	questionQualityController := controller.NewQuestionQualityController(questionQualityService)
+	tagModeratorService := tag_moderator2.NewTagModeratorService(tagModeratorRepo, tagCommonService, userCommon)
+	tagModeratorController := controller.NewTagModeratorController(tagModeratorService)
+	tagAnalyticsRepo := tag_analytics.NewTagAnalyticsRepo(dataData)
+	tagAnalyticsService := tag_analytics2.NewTagAnalyticsService(tagAnalyticsRepo, tagCommonService, userCommon)
+	tagAnalyticsController := controller.NewTagAnalyticsController(tagAnalyticsService)
+	answerAPIRouter := router.NewAnswerAPIRouter(langController, userController, commentController, reportController, voteController, tagController, followController, collectionController, questionController, answerController, searchController, revisionController, rankController, userAdminController, reasonController, themeController, siteInfoController, controllerSiteInfoController, notificationController, dashboardController, uploadController, activityController, roleController, pluginController, permissionController, userPluginController, reviewController, metaController, badgeController, controller_adminBadgeController, questionCloseVoteController, reportAppealController, dataDumpController, reputationController, chatIntakeController, voteFraudController, contentLanguageController, leaderboardController, userTimelineController, syndicationController, helpfulnessSurveyController, questionQualityController, tagModeratorController, tagAnalyticsController, adminExportController, loadSheddingController, queryStatsController, autoPromotionController, contentFreshnessController, botController, savedSearchController, webhookController, jiraController, messengerController, adminPermissionController, webPushController, mobilePushController, inboundEmailController, emailTemplateController, userMuteController, scimController, personalAccessTokenController, oauthController, oauthClientController, userSessionController, controller_adminUserSessionController, inviteCodeController, controller_adminInviteCodeController, auditLogController, controller_adminIPBanController, controller_adminUserImportController)
 	swaggerRouter := router.NewSwaggerRouter(swaggerConf)
 	uiRouter := router.NewUIRouter(controllerSiteInfoController, siteInfoCommonService)
-	authUserMiddleware := middleware.NewAuthUserMiddleware(authService, siteInfoCommonService)
+	authUserMiddleware := middleware.NewAuthUserMiddleware(authService, siteInfoCommonService, rolePowerRelService)
 	avatarMiddleware := middleware.NewAvatarMiddleware(serviceConf, uploaderService)
 	shortIDMiddleware := middleware.NewShortIDMiddleware(siteInfoCommonService)
 	templateRenderController := templaterender.NewTemplateRenderController(questionService, userService, tagService, answerService, commentService, siteInfoCommonService, questionRepo)
 	templateController := controller.NewTemplateController(templateRenderController, siteInfoCommonService, eventQueueService, userService, questionService)
 	templateRouter := router.NewTemplateRouter(templateController, templateRenderController, siteInfoController, authUserMiddleware)
 	connectorController := controller.NewConnectorController(siteInfoCommonService, emailService, userExternalLoginService)
-	userCenterLoginService := user_external_login2.NewUserCenterLoginService(userRepo, userCommon, userExternalLoginRepo, userActiveActivityRepo, siteInfoCommonService)
+	userCenterLoginService := user_external_login2.NewUserCenterLoginService(userRepo, userCommon, userExternalLoginRepo, userActiveActivityRepo, siteInfoCommonService, eventQueueService)
 	userCenterController := controller.NewUserCenterController(userCenterLoginService, siteInfoCommonService)
 	captchaController := controller.NewCaptchaController()
 	embedController := controller.NewEmbedController()
 	renderController := controller.NewRenderController()
 	pluginAPIRouter := router.NewPluginAPIRouter(connectorController, userCenterController, captchaController, embedController, renderController)
-	ginEngine := server.NewHTTPServer(debug, staticRouter, answerAPIRouter, swaggerRouter, uiRouter, authUserMiddleware, avatarMiddleware, shortIDMiddleware, templateRouter, pluginAPIRouter, uiConf)
-	scheduledTaskManager := cron.NewScheduledTaskManager(siteInfoCommonService, questionService, fileRecordService, userAdminService, serviceConf)
+	ginEngine := server.NewHTTPServer(debug, staticRouter, answerAPIRouter, swaggerRouter, uiRouter, authUserMiddleware, avatarMiddleware, shortIDMiddleware, loadSheddingMiddleware, ipBanMiddleware, templateRouter, pluginAPIRouter, uiConf, serverConf.HTTP)
+	reEngagementRepo := re_engagement.NewReEngagementRepo(dataData)
+	reEngagementService := re_engagement2.NewReEngagementService(reEngagementRepo, siteInfoCommonService, userRepo, userNotificationConfigRepo, followRepo, questionRepo, emailService)
+	digestRepo := digest.NewDigestRepo(dataData)
+	digestService := digest2.NewDigestService(digestRepo, siteInfoCommonService, userRepo, userNotificationConfigRepo, followRepo, questionRepo, emailService, spaceCommon)
+	notificationRetentionService := notification_retention.NewNotificationRetentionService(notificationRepo, siteInfoCommonService)
+	scheduledTaskManager := cron.NewScheduledTaskManager(siteInfoCommonService, questionService, fileRecordService, userAdminService, questionCloseVoteService, dataDumpService, reputationSyncService, voteFraudService, leaderboardService, syndicationService, tagService, reEngagementService, tagAnalyticsService, autoPromotionService, contentFreshnessService, savedSearchService, cacheWarmingService, tablePartitionService, webhookService, slackService, jiraService, digestService, inboundEmailService, notificationRetentionService, serviceConf)
 	application := newApplication(serverConf, ginEngine, scheduledTaskManager)
 	return application, func() {
 		cleanup2()
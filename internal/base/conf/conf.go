@@ -23,6 +23,7 @@ import (
 	"bytes"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/apache/answer/internal/base/data"
 	"github.com/apache/answer/internal/base/server"
@@ -50,6 +51,7 @@ type envConfigOverrides struct {
 	SwaggerHost        string
 	SwaggerAddressPort string
 	SiteAddr           string
+	TrustedProxies     string
 }
 
 func loadEnvs() (envOverrides *envConfigOverrides) {
@@ -57,6 +59,7 @@ func loadEnvs() (envOverrides *envConfigOverrides) {
 		SwaggerHost:        os.Getenv("SWAGGER_HOST"),
 		SwaggerAddressPort: os.Getenv("SWAGGER_ADDRESS_PORT"),
 		SiteAddr:           os.Getenv("SITE_ADDR"),
+		TrustedProxies:     os.Getenv("TRUSTED_PROXIES"),
 	}
 }
 
@@ -87,6 +90,9 @@ func (c *AllConfig) SetEnvironmentOverrides() {
 	if envs.SiteAddr != "" {
 		c.Server.HTTP.Addr = envs.SiteAddr
 	}
+	if envs.TrustedProxies != "" {
+		c.Server.HTTP.TrustedProxies = strings.Split(envs.TrustedProxies, ",")
+	}
 	if envs.SwaggerHost != "" {
 		c.Swaggerui.Host = envs.SwaggerHost
 	}
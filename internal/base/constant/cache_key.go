@@ -32,6 +32,7 @@ const (
 	AdminTokenCacheKey                         = "answer:admin:token:"
 	AdminTokenCacheTime                        = 7 * 24 * time.Hour
 	UserTokenMappingCacheKey                   = "answer:user-token:mapping:"
+	UserSessionActiveRefreshTime               = 5 * time.Minute
 	UserEmailCodeCacheKey                      = "answer:user:email-code:"
 	UserEmailCodeCacheTime                     = 10 * time.Minute
 	UserLatestEmailCodeCacheKey                = "answer:user-id:email-code:"
@@ -50,6 +51,17 @@ const (
 	NewQuestionNotificationLimitMax            = 50
 	RateLimitCacheKeyPrefix                    = "answer:rate-limit:"
 	RateLimitCacheTime                         = 5 * time.Minute
+	RateLimitActionCacheKeyPrefix              = "answer:rate-limit:action:"
 	RedDotCacheKey                             = "answer:red-dot:%s:%s"
 	RedDotCacheTime                            = 30 * 24 * time.Hour
+	AutoCommentLimitCacheKeyPrefix             = "answer:auto-comment-limit:"
+	AutoCommentLimitCacheTime                  = time.Hour
+	QuestionVisitorInfoCacheKey                = "answer:question:visitor-info:%s:%s"
+	QuestionVisitorInfoCacheTime               = 2 * time.Minute
+	OAuthAuthorizationCodeCacheKey             = "answer:oauth:code:"
+	OAuthAuthorizationCodeTakenCacheKey        = "answer:oauth:code-taken:"
+	OAuthAccessTokenCacheKey                   = "answer:oauth:access-token:"
+	LoginFailureCacheKey                       = "answer:login-failure:"
+	ActiveIPBansCacheKey                       = "answer:ip-ban:active"
+	ActiveIPBansCacheTime                      = 10 * time.Minute
 )
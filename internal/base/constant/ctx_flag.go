@@ -22,4 +22,10 @@ package constant
 const (
 	AcceptLanguageFlag = "Accept-Language"
 	ShortIDFlag        = "Short-ID-Enabled"
+	// ExpectedVersionFlag the version of settings the write is based on, from the If-Match header
+	ExpectedVersionFlag = "Expected-Version"
+	// OperatorIDFlag the user id of admin who does the write
+	OperatorIDFlag = "Operator-ID"
+	// RequestBodyLimitFlag the max request body size of the route, it overrides the limit of site
+	RequestBodyLimitFlag = "Request-Body-Limit"
 )
@@ -43,4 +43,19 @@ const (
 
 	EmailTplKeyNewQuestionTitle = "email_tpl.new_question.title"
 	EmailTplKeyNewQuestionBody  = "email_tpl.new_question.body"
+
+	EmailTplKeyReEngagementNewAnswersTitle = "email_tpl.re_engagement_new_answers.title"
+	EmailTplKeyReEngagementNewAnswersBody  = "email_tpl.re_engagement_new_answers.body"
+
+	EmailTplKeyReEngagementInactiveUserTitle = "email_tpl.re_engagement_inactive_user.title"
+	EmailTplKeyReEngagementInactiveUserBody  = "email_tpl.re_engagement_inactive_user.body"
+
+	EmailTplKeyDigestDailyTitle  = "email_tpl.digest_daily.title"
+	EmailTplKeyDigestWeeklyTitle = "email_tpl.digest_weekly.title"
+	EmailTplKeyDigestBody        = "email_tpl.digest.body"
+
+	EmailTplKeySuspiciousLoginTitle = "email_tpl.suspicious_login.title"
+	EmailTplKeySuspiciousLoginBody  = "email_tpl.suspicious_login.body"
+
+	EmailTplKeyReplyAboveThisLine = "email_tpl.reply_above_this_line"
 )
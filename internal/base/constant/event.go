@@ -43,6 +43,7 @@ const (
 )
 
 const (
+	EventUserCreate EventType = eventUser + "." + eventCreate
 	EventUserUpdate EventType = eventUser + "." + eventUpdate
 	EventUserShare  EventType = eventUser + "." + eventShare
 )
@@ -48,6 +48,8 @@ const (
 	NotificationMentionYou = "notification.action.mention_you"
 	// NotificationYourQuestionIsClosed your question is closed
 	NotificationYourQuestionIsClosed = "notification.action.your_question_is_closed"
+	// NotificationYourQuestionIsReopened your question is reopened
+	NotificationYourQuestionIsReopened = "notification.action.your_question_is_reopened"
 	// NotificationYourQuestionWasDeleted your question was deleted
 	NotificationYourQuestionWasDeleted = "notification.action.your_question_was_deleted"
 	// NotificationYourAnswerWasDeleted your answer was deleted
@@ -58,8 +60,122 @@ const (
 	NotificationInvitedYouToAnswer = "notification.action.invited_you_to_answer"
 	// NotificationEarnedBadge earned badge
 	NotificationEarnedBadge = "notification.action.earned_badge"
+	// NotificationYourPostWasRemovedByFlag your post was removed because of flag
+	NotificationYourPostWasRemovedByFlag = "notification.action.your_post_was_removed_by_flag"
+	// NotificationYourAppealWasAccepted your appeal was accepted
+	NotificationYourAppealWasAccepted = "notification.action.your_appeal_was_accepted"
+	// NotificationYourAppealWasRejected your appeal was rejected
+	NotificationYourAppealWasRejected = "notification.action.your_appeal_was_rejected"
+	// NotificationVoteMilestone your post reached the vote milestone
+	NotificationVoteMilestone = "notification.action.vote_milestone"
+	// NotificationPromotedToRole you were promoted to the role by the auto promotion
+	NotificationPromotedToRole = "notification.action.promoted_to_role"
+	// NotificationRoleRevoked your role granted by the auto promotion was revoked
+	NotificationRoleRevoked = "notification.action.role_revoked"
+	// NotificationSavedSearchMatched the new post matched your saved search
+	NotificationSavedSearchMatched = "notification.action.saved_search_matched"
+	// NotificationSavedSearchDigest the daily digest of the new posts matched your saved search
+	NotificationSavedSearchDigest = "notification.action.saved_search_digest"
+	// NotificationMarkedAsDuplicate the question was closed as a duplicate of another question
+	NotificationMarkedAsDuplicate = "notification.action.marked_as_duplicate"
+	// NotificationDuplicateOfQuestion another question was closed as a duplicate of the question
+	NotificationDuplicateOfQuestion = "notification.action.duplicate_of_question"
+	// NotificationYourFlagWasHelpful your flag was handled as helpful
+	NotificationYourFlagWasHelpful = "notification.action.your_flag_was_helpful"
+	// NotificationYourFlagWasDeclined your flag was declined
+	NotificationYourFlagWasDeclined = "notification.action.your_flag_was_declined"
+	// NotificationFlagAssignedToYou the flag was assigned to you to handle
+	NotificationFlagAssignedToYou = "notification.action.flag_assigned_to_you"
 )
 
+// IsVoteNotificationAction the notification action is sent for the single vote
+func IsVoteNotificationAction(action string) bool {
+	return IsUpVoteNotificationAction(action) ||
+		action == NotificationDownVotedTheQuestion ||
+		action == NotificationDownVotedTheAnswer
+}
+
+// IsUpVoteNotificationAction the notification action is sent for the single upvote
+func IsUpVoteNotificationAction(action string) bool {
+	return action == NotificationUpVotedTheQuestion ||
+		action == NotificationUpVotedTheAnswer ||
+		action == NotificationUpVotedTheComment
+}
+
+// the event types of the notification preference matrix, each groups the notification actions
+const (
+	NotificationEventAnswer      = "answer"
+	NotificationEventComment     = "comment"
+	NotificationEventMention     = "mention"
+	NotificationEventInvite      = "invite"
+	NotificationEventAccept      = "accept"
+	NotificationEventEdit        = "edit"
+	NotificationEventVote        = "vote"
+	NotificationEventModeration  = "moderation"
+	NotificationEventAchievement = "achievement"
+	NotificationEventSavedSearch = "saved_search"
+)
+
+// NotificationEvents the event types of the notification preference matrix in display order
+var NotificationEvents = []string{
+	NotificationEventAnswer,
+	NotificationEventComment,
+	NotificationEventMention,
+	NotificationEventInvite,
+	NotificationEventAccept,
+	NotificationEventEdit,
+	NotificationEventVote,
+	NotificationEventModeration,
+	NotificationEventAchievement,
+	NotificationEventSavedSearch,
+}
+
+var notificationActionEvents = map[string]string{
+	NotificationAnswerTheQuestion:        NotificationEventAnswer,
+	NotificationCommentQuestion:          NotificationEventComment,
+	NotificationCommentAnswer:            NotificationEventComment,
+	NotificationReplyToYou:               NotificationEventComment,
+	NotificationMentionYou:               NotificationEventMention,
+	NotificationInvitedYouToAnswer:       NotificationEventInvite,
+	NotificationAcceptAnswer:             NotificationEventAccept,
+	NotificationUpdateQuestion:           NotificationEventEdit,
+	NotificationUpdateAnswer:             NotificationEventEdit,
+	NotificationUpVotedTheQuestion:       NotificationEventVote,
+	NotificationDownVotedTheQuestion:     NotificationEventVote,
+	NotificationUpVotedTheAnswer:         NotificationEventVote,
+	NotificationDownVotedTheAnswer:       NotificationEventVote,
+	NotificationUpVotedTheComment:        NotificationEventVote,
+	NotificationVoteMilestone:            NotificationEventVote,
+	NotificationYourQuestionIsClosed:     NotificationEventModeration,
+	NotificationYourQuestionIsReopened:   NotificationEventModeration,
+	NotificationYourQuestionWasDeleted:   NotificationEventModeration,
+	NotificationYourAnswerWasDeleted:     NotificationEventModeration,
+	NotificationYourCommentWasDeleted:    NotificationEventModeration,
+	NotificationYourPostWasRemovedByFlag: NotificationEventModeration,
+	NotificationYourAppealWasAccepted:    NotificationEventModeration,
+	NotificationYourAppealWasRejected:    NotificationEventModeration,
+	NotificationMarkedAsDuplicate:        NotificationEventModeration,
+	NotificationDuplicateOfQuestion:      NotificationEventModeration,
+	NotificationYourFlagWasHelpful:       NotificationEventModeration,
+	NotificationYourFlagWasDeclined:      NotificationEventModeration,
+	NotificationFlagAssignedToYou:        NotificationEventModeration,
+	NotificationEarnedBadge:              NotificationEventAchievement,
+	NotificationPromotedToRole:           NotificationEventAchievement,
+	NotificationRoleRevoked:              NotificationEventAchievement,
+	NotificationSavedSearchMatched:       NotificationEventSavedSearch,
+	NotificationSavedSearchDigest:        NotificationEventSavedSearch,
+}
+
+// GetNotificationEvent get the event type of the notification action, empty if it is not in the preference matrix
+func GetNotificationEvent(action string) string {
+	return notificationActionEvents[action]
+}
+
+// NotificationEventSource the source storing the channels of the event type in the preference matrix
+func NotificationEventSource(event string) NotificationSource {
+	return NotificationSource("event_" + event)
+}
+
 type NotificationChannelKey string
 type NotificationSource string
 
@@ -67,10 +183,25 @@ const (
 	InboxSource                          NotificationSource = "inbox"
 	AllNewQuestionSource                 NotificationSource = "all_new_question"
 	AllNewQuestionForFollowingTagsSource NotificationSource = "all_new_question_for_following_tags"
+	// ReEngagementSource the re-engagement emails are sent unless the user opts out
+	ReEngagementSource NotificationSource = "re_engagement"
+	// EveryVoteSource the inbox notification for every single vote, off by default
+	EveryVoteSource NotificationSource = "every_vote"
+	// VoteMilestoneSource the inbox notification when the post crosses the vote milestones, on by default
+	VoteMilestoneSource NotificationSource = "vote_milestone"
+	// WebPushSource the browser push of the inbox notifications to the subscribed devices, off until the user opts in
+	WebPushSource NotificationSource = "web_push"
+	// DigestDailySource DigestWeeklySource the email digests, off until the user opts in
+	DigestDailySource  NotificationSource = "digest_daily"
+	DigestWeeklySource NotificationSource = "digest_weekly"
 )
 
 const (
-	EmailChannel NotificationChannelKey = "email"
+	EmailChannel   NotificationChannelKey = "email"
+	InboxChannel   NotificationChannelKey = "inbox"
+	WebPushChannel NotificationChannelKey = "web_push"
+	// WebhookChannel the notification plugins delivering to the webhooks connected by the user
+	WebhookChannel NotificationChannelKey = "webhook"
 )
 
 const (
@@ -81,23 +212,37 @@ const (
 
 var (
 	NotificationMsgTypeMapping = map[string]int{
-		NotificationUpdateQuestion:         1,
-		NotificationAnswerTheQuestion:      1,
-		NotificationUpVotedTheQuestion:     2,
-		NotificationDownVotedTheQuestion:   2,
-		NotificationUpdateAnswer:           1,
-		NotificationAcceptAnswer:           1,
-		NotificationUpVotedTheAnswer:       2,
-		NotificationDownVotedTheAnswer:     2,
-		NotificationCommentQuestion:        1,
-		NotificationCommentAnswer:          1,
-		NotificationUpVotedTheComment:      2,
-		NotificationReplyToYou:             1,
-		NotificationMentionYou:             1,
-		NotificationYourQuestionIsClosed:   1,
-		NotificationYourQuestionWasDeleted: 1,
-		NotificationYourAnswerWasDeleted:   1,
-		NotificationYourCommentWasDeleted:  1,
-		NotificationInvitedYouToAnswer:     3,
+		NotificationUpdateQuestion:           1,
+		NotificationAnswerTheQuestion:        1,
+		NotificationUpVotedTheQuestion:       2,
+		NotificationDownVotedTheQuestion:     2,
+		NotificationUpdateAnswer:             1,
+		NotificationAcceptAnswer:             1,
+		NotificationUpVotedTheAnswer:         2,
+		NotificationDownVotedTheAnswer:       2,
+		NotificationCommentQuestion:          1,
+		NotificationCommentAnswer:            1,
+		NotificationUpVotedTheComment:        2,
+		NotificationReplyToYou:               1,
+		NotificationMentionYou:               1,
+		NotificationYourQuestionIsClosed:     1,
+		NotificationYourQuestionIsReopened:   1,
+		NotificationYourQuestionWasDeleted:   1,
+		NotificationYourAnswerWasDeleted:     1,
+		NotificationYourCommentWasDeleted:    1,
+		NotificationInvitedYouToAnswer:       3,
+		NotificationYourPostWasRemovedByFlag: 1,
+		NotificationYourAppealWasAccepted:    1,
+		NotificationYourAppealWasRejected:    1,
+		NotificationVoteMilestone:            2,
+		NotificationPromotedToRole:           1,
+		NotificationRoleRevoked:              1,
+		NotificationSavedSearchMatched:       1,
+		NotificationSavedSearchDigest:        1,
+		NotificationMarkedAsDuplicate:        1,
+		NotificationDuplicateOfQuestion:      1,
+		NotificationYourFlagWasHelpful:       1,
+		NotificationYourFlagWasDeclined:      1,
+		NotificationFlagAssignedToYou:        1,
 	}
 )
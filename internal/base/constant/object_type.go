@@ -29,6 +29,8 @@ const (
 	ReportObjectType     = "report"
 	BadgeObjectType      = "badge"
 	BadgeAwardObjectType = "badge_award"
+	// RoleChangeObjectType the role granted or revoked by the auto promotion, it is the object of notification
+	RoleChangeObjectType = "role_change"
 )
 
 var (
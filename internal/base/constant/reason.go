@@ -28,6 +28,7 @@ const (
 	ReasonNoLongerNeeded    = "reason.no_longer_needed"
 	ReasonCommunitySpecific = "reason.community_specific"
 	ReasonNotClarity        = "reason.not_clarity"
+	ReasonOffTopic          = "reason.off_topic"
 	ReasonNormal            = "reason.normal"
 	ReasonNormalUser        = "reason.normal.user"
 	ReasonClosed            = "reason.closed"
@@ -40,3 +41,6 @@ const (
 	ReasonNeedsClose        = "reason.needs_close"
 	ReasonNeedsDelete       = "reason.needs_delete"
 )
+
+// QuestionCloseReasonsKey the config key of the reason keys that a question can be closed for
+const QuestionCloseReasonsKey = "question.close.reasons"
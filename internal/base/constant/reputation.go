@@ -0,0 +1,66 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package constant
+
+import "github.com/apache/answer/internal/base/reason"
+
+// ReputationRule the reputation awarded or deducted when the event happened, the key is the config key
+type ReputationRule struct {
+	Key   string `validate:"required" json:"key"`
+	Label string `json:"label"`
+	Value int    `validate:"gte=-1000,lte=1000" json:"value"`
+}
+
+const (
+	ReputationUserActivatedKey     = "user.activated"
+	ReputationQuestionVoteUpKey    = "question.vote_up"
+	ReputationQuestionVotedUpKey   = "question.voted_up"
+	ReputationQuestionVoteDownKey  = "question.vote_down"
+	ReputationQuestionVotedDownKey = "question.voted_down"
+	ReputationAnswerVoteUpKey      = "answer.vote_up"
+	ReputationAnswerVotedUpKey     = "answer.voted_up"
+	ReputationAnswerVoteDownKey    = "answer.vote_down"
+	ReputationAnswerVotedDownKey   = "answer.voted_down"
+	ReputationAnswerAcceptKey      = "answer.accept"
+	ReputationAnswerAcceptedKey    = "answer.accepted"
+	ReputationCommentVoteUpKey     = "comment.vote_up"
+	ReputationEditAcceptedKey      = "edit.accepted"
+	ReputationDailyLimitKey        = "daily_rank_limit"
+)
+
+var (
+	// ReputationAllRules the events that change the reputation of user.
+	// When the action is undone, such as cancel the vote, the reputation gained or lost will be restored.
+	ReputationAllRules = []*ReputationRule{
+		{Label: reason.ReputationUserActivatedLabel, Key: ReputationUserActivatedKey},
+		{Label: reason.ReputationQuestionVotedUpLabel, Key: ReputationQuestionVotedUpKey},
+		{Label: reason.ReputationQuestionVotedDownLabel, Key: ReputationQuestionVotedDownKey},
+		{Label: reason.ReputationAnswerVotedUpLabel, Key: ReputationAnswerVotedUpKey},
+		{Label: reason.ReputationAnswerVotedDownLabel, Key: ReputationAnswerVotedDownKey},
+		{Label: reason.ReputationAnswerAcceptedLabel, Key: ReputationAnswerAcceptedKey},
+		{Label: reason.ReputationAnswerAcceptLabel, Key: ReputationAnswerAcceptKey},
+		{Label: reason.ReputationEditAcceptedLabel, Key: ReputationEditAcceptedKey},
+		{Label: reason.ReputationQuestionVoteUpLabel, Key: ReputationQuestionVoteUpKey},
+		{Label: reason.ReputationQuestionVoteDownLabel, Key: ReputationQuestionVoteDownKey},
+		{Label: reason.ReputationAnswerVoteUpLabel, Key: ReputationAnswerVoteUpKey},
+		{Label: reason.ReputationAnswerVoteDownLabel, Key: ReputationAnswerVoteDownKey},
+		{Label: reason.ReputationCommentVoteUpLabel, Key: ReputationCommentVoteUpKey},
+	}
+)
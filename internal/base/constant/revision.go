@@ -42,3 +42,12 @@ const (
 	ReviewFlaggedPostLabel       = "review.flagged_post"
 	ReviewSuggestedPostEditLabel = "review.suggested_post_edit"
 )
+
+// IsReportRemovalOperation whether the report operation removes the content from the author
+func IsReportRemovalOperation(operationType string) bool {
+	switch operationType {
+	case ReportOperationClosePost, ReportOperationDeletePost, ReportOperationUnlistPost:
+		return true
+	}
+	return false
+}
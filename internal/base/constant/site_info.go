@@ -53,4 +53,7 @@ const (
 	DefaultMaxImageMegapixel = 40 * 1000 * 1000
 	DefaultMaxImageSize      = 4 * 1024 * 1024
 	DefaultMaxAttachmentSize = 8 * 1024 * 1024
+	DefaultMaxVideoSize      = 20 * 1024 * 1024
+	// DefaultMaxVideoDuration the max duration of video in seconds
+	DefaultMaxVideoDuration = 60
 )
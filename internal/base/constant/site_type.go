@@ -31,4 +31,44 @@ const (
 	SiteTypeTheme         = "theme"
 	SiteTypePrivileges    = "privileges"
 	SiteTypeUsers         = "users"
+	SiteTypeLimits        = "limits"
+	SiteTypeQuestionClose = "question_close"
+	SiteTypeRateLimit     = "rate_limit"
+	SiteTypeDataDump      = "data_dump"
+	SiteTypeDataDumpState = "data_dump_state"
+
+	SiteTypeReputationSync        = "reputation_sync"
+	SiteTypeReputationSyncState   = "reputation_sync_state"
+	SiteTypeSearchRebuildState    = "search_rebuild_state"
+	SiteTypeChatIntake            = "chat_intake"
+	SiteTypeVoteFraud             = "vote_fraud"
+	SiteTypeAnswerRanking         = "answer_ranking"
+	SiteTypeLanguageDetection     = "language_detection"
+	SiteTypeDownvoteCost          = "downvote_cost"
+	SiteTypeSyndication           = "syndication"
+	SiteTypeHelpfulnessSurvey     = "helpfulness_survey"
+	SiteTypeReEngagement          = "re_engagement"
+	SiteTypeQuestionQuality       = "question_quality"
+	SiteTypeTagRules              = "tag_rules"
+	SiteTypeVoteMilestone         = "vote_milestone"
+	SiteTypeAutoComment           = "auto_comment"
+	SiteTypeTagBlocklist          = "tag_blocklist"
+	SiteTypeLoadShedding          = "load_shedding"
+	SiteTypeAutoPromotion         = "auto_promotion"
+	SiteTypeContentFreshness      = "content_freshness"
+	SiteTypeQuestionMigration     = "question_migration"
+	SiteTypeCacheWarming          = "cache_warming"
+	SiteTypeWebhook               = "webhook"
+	SiteTypeSlack                 = "slack"
+	SiteTypeJira                  = "jira"
+	SiteTypeSpaces                = "spaces"
+	SiteTypeMessenger             = "messenger"
+	SiteTypeWebPush               = "web_push"
+	SiteTypeDigest                = "digest"
+	SiteTypeInboundEmail          = "inbound_email"
+	SiteTypeNotificationRetention = "notification_retention"
+	SiteTypeSCIM                  = "scim"
+	SiteTypeOAuthProvider         = "oauth_provider"
+	SiteTypeLoginProtection       = "login_protection"
+	SiteTypeInvite                = "invite"
 )
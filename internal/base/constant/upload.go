@@ -26,4 +26,10 @@ const (
 	BrandingSubPath    = "branding"
 	FilesPostSubPath   = "files/post"
 	DeletedSubPath     = "deleted"
+	DataDumpSubPath    = "data_dump"
+	AdminExportSubPath = "admin_export"
+	// VideoSubPath keeps the short videos played in posts and their thumbnails
+	VideoSubPath = "video"
+	// ChunkedSubPath keeps the chunks of resumable uploads until they are assembled
+	ChunkedSubPath = "chunked"
 )
@@ -24,6 +24,8 @@ const (
 	UserSuspended = "suspended"
 	UserDeleted   = "deleted"
 	UserInactive  = "inactive"
+	// UserShadowBanned the user can use the site as usual, but the new content is invisible to others
+	UserShadowBanned = "shadow_banned"
 )
 const (
 	EmailStatusAvailable    = 1
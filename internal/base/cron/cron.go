@@ -23,22 +23,62 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/apache/answer/internal/service/auto_promotion"
+	"github.com/apache/answer/internal/service/cache_warming"
 	"github.com/apache/answer/internal/service/content"
+	"github.com/apache/answer/internal/service/content_freshness"
+	"github.com/apache/answer/internal/service/data_dump"
+	"github.com/apache/answer/internal/service/digest"
 	"github.com/apache/answer/internal/service/file_record"
+	"github.com/apache/answer/internal/service/inbound_email"
+	"github.com/apache/answer/internal/service/jira"
+	"github.com/apache/answer/internal/service/leaderboard"
+	"github.com/apache/answer/internal/service/notification_retention"
+	"github.com/apache/answer/internal/service/question_close_vote"
+	"github.com/apache/answer/internal/service/re_engagement"
+	"github.com/apache/answer/internal/service/reputation_sync"
+	"github.com/apache/answer/internal/service/saved_search"
 	"github.com/apache/answer/internal/service/service_config"
 	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/apache/answer/internal/service/slack"
+	"github.com/apache/answer/internal/service/syndication"
+	"github.com/apache/answer/internal/service/table_partition"
+	"github.com/apache/answer/internal/service/tag"
+	"github.com/apache/answer/internal/service/tag_analytics"
 	"github.com/apache/answer/internal/service/user_admin"
+	"github.com/apache/answer/internal/service/vote_fraud"
+	"github.com/apache/answer/internal/service/webhook"
 	"github.com/robfig/cron/v3"
 	"github.com/segmentfault/pacman/log"
 )
 
 // ScheduledTaskManager scheduled task manager
 type ScheduledTaskManager struct {
-	siteInfoService   siteinfo_common.SiteInfoCommonService
-	questionService   *content.QuestionService
-	fileRecordService *file_record.FileRecordService
-	userAdminService  *user_admin.UserAdminService
-	serviceConfig     *service_config.ServiceConfig
+	siteInfoService       siteinfo_common.SiteInfoCommonService
+	questionService       *content.QuestionService
+	fileRecordService     *file_record.FileRecordService
+	userAdminService      *user_admin.UserAdminService
+	closeVoteService      *question_close_vote.QuestionCloseVoteService
+	dataDumpService       *data_dump.DataDumpService
+	reputationSync        *reputation_sync.ReputationSyncService
+	voteFraudService      *vote_fraud.VoteFraudService
+	leaderboard           *leaderboard.LeaderboardService
+	syndication           *syndication.SyndicationService
+	tagService            *tag.TagService
+	reEngagement          *re_engagement.ReEngagementService
+	tagAnalytics          *tag_analytics.TagAnalyticsService
+	autoPromotion         *auto_promotion.AutoPromotionService
+	contentFreshness      *content_freshness.ContentFreshnessService
+	savedSearch           *saved_search.SavedSearchService
+	cacheWarming          *cache_warming.CacheWarmingService
+	tablePartition        *table_partition.TablePartitionService
+	webhook               *webhook.WebhookService
+	slack                 *slack.SlackService
+	jira                  *jira.JiraService
+	digest                *digest.DigestService
+	inboundEmail          *inbound_email.InboundEmailService
+	notificationRetention *notification_retention.NotificationRetentionService
+	serviceConfig         *service_config.ServiceConfig
 }
 
 // NewScheduledTaskManager new scheduled task manager
@@ -47,14 +87,54 @@ func NewScheduledTaskManager(
 	questionService *content.QuestionService,
 	fileRecordService *file_record.FileRecordService,
 	userAdminService *user_admin.UserAdminService,
+	closeVoteService *question_close_vote.QuestionCloseVoteService,
+	dataDumpService *data_dump.DataDumpService,
+	reputationSync *reputation_sync.ReputationSyncService,
+	voteFraudService *vote_fraud.VoteFraudService,
+	leaderboard *leaderboard.LeaderboardService,
+	syndication *syndication.SyndicationService,
+	tagService *tag.TagService,
+	reEngagement *re_engagement.ReEngagementService,
+	tagAnalytics *tag_analytics.TagAnalyticsService,
+	autoPromotion *auto_promotion.AutoPromotionService,
+	contentFreshness *content_freshness.ContentFreshnessService,
+	savedSearch *saved_search.SavedSearchService,
+	cacheWarming *cache_warming.CacheWarmingService,
+	tablePartition *table_partition.TablePartitionService,
+	webhook *webhook.WebhookService,
+	slack *slack.SlackService,
+	jira *jira.JiraService,
+	digest *digest.DigestService,
+	inboundEmail *inbound_email.InboundEmailService,
+	notificationRetention *notification_retention.NotificationRetentionService,
 	serviceConfig *service_config.ServiceConfig,
 ) *ScheduledTaskManager {
 	manager := &ScheduledTaskManager{
-		siteInfoService:   siteInfoService,
-		questionService:   questionService,
-		fileRecordService: fileRecordService,
-		userAdminService:  userAdminService,
-		serviceConfig:     serviceConfig,
+		siteInfoService:       siteInfoService,
+		questionService:       questionService,
+		fileRecordService:     fileRecordService,
+		userAdminService:      userAdminService,
+		closeVoteService:      closeVoteService,
+		dataDumpService:       dataDumpService,
+		reputationSync:        reputationSync,
+		voteFraudService:      voteFraudService,
+		leaderboard:           leaderboard,
+		syndication:           syndication,
+		tagService:            tagService,
+		reEngagement:          reEngagement,
+		tagAnalytics:          tagAnalytics,
+		autoPromotion:         autoPromotion,
+		contentFreshness:      contentFreshness,
+		savedSearch:           savedSearch,
+		cacheWarming:          cacheWarming,
+		tablePartition:        tablePartition,
+		webhook:               webhook,
+		slack:                 slack,
+		jira:                  jira,
+		digest:                digest,
+		inboundEmail:          inboundEmail,
+		notificationRetention: notificationRetention,
+		serviceConfig:         serviceConfig,
 	}
 	return manager
 }
@@ -95,6 +175,204 @@ func (s *ScheduledTaskManager) Run() {
 		log.Error(err)
 	}
 
+	_, err = c.AddFunc("0 */1 * * *", func() {
+		ctx := context.Background()
+		log.Infof("expire question close votes cron execution")
+		s.closeVoteService.ExpireVotesCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("30 */1 * * *", func() {
+		ctx := context.Background()
+		log.Infof("public data dump cron execution")
+		s.dataDumpService.DataDumpCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("*/5 * * * *", func() {
+		ctx := context.Background()
+		log.Infof("reputation sync cron execution")
+		s.reputationSync.SyncCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("15 */1 * * *", func() {
+		ctx := context.Background()
+		log.Infof("vote fraud detection cron execution")
+		s.voteFraudService.DetectCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("45 */1 * * *", func() {
+		ctx := context.Background()
+		log.Infof("refresh leaderboard cron execution")
+		s.leaderboard.RefreshCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("*/1 * * * *", func() {
+		ctx := context.Background()
+		log.Infof("deliver webhooks cron execution")
+		s.webhook.DeliverCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("25 3 * * *", func() {
+		ctx := context.Background()
+		log.Infof("clean webhook deliveries cron execution")
+		s.webhook.CleanCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("0 9 * * *", func() {
+		ctx := context.Background()
+		log.Infof("slack unanswered reminder cron execution")
+		s.slack.UnansweredReminderCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("*/10 * * * *", func() {
+		ctx := context.Background()
+		log.Infof("sync jira issues cron execution")
+		s.jira.SyncCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("*/5 * * * *", func() {
+		ctx := context.Background()
+		log.Infof("push syndication cron execution")
+		s.syndication.PushCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("30 */1 * * *", func() {
+		ctx := context.Background()
+		log.Infof("remap synonym tags cron execution")
+		s.tagService.RemapSynonymTagsCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("40 3 * * *", func() {
+		ctx := context.Background()
+		log.Infof("burninate deprecated tags cron execution")
+		s.tagService.BurninateDeprecatedTagsCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("0 10 * * *", func() {
+		ctx := context.Background()
+		log.Infof("send re-engagement emails cron execution")
+		s.reEngagement.SendCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	// the digests are sent after the send hour of site, checked every hour to resume the capped runs
+	_, err = c.AddFunc("5 * * * *", func() {
+		ctx := context.Background()
+		log.Infof("send digest emails cron execution")
+		s.digest.SendCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("*/5 * * * *", func() {
+		ctx := context.Background()
+		log.Infof("poll inbound email cron execution")
+		s.inboundEmail.PollCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("0 3 * * *", func() {
+		ctx := context.Background()
+		log.Infof("clean notifications out of retention cron execution")
+		s.notificationRetention.CleanCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("20 0 * * *", func() {
+		ctx := context.Background()
+		log.Infof("aggregate tag stats cron execution")
+		s.tagAnalytics.AggregateCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("50 2 * * *", func() {
+		ctx := context.Background()
+		log.Infof("evaluate auto promotion cron execution")
+		s.autoPromotion.EvaluateCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("10 4 * * *", func() {
+		ctx := context.Background()
+		log.Infof("analyze content freshness cron execution")
+		s.contentFreshness.AnalyzeCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("*/10 * * * *", func() {
+		ctx := context.Background()
+		log.Infof("evaluate saved searches cron execution")
+		s.savedSearch.EvaluateCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	// the visitor cache expires in two minutes, so the hot questions are warmed every minute
+	_, err = c.AddFunc("* * * * *", func() {
+		s.cacheWarming.WarmCron(context.Background())
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("30 1 * * *", func() {
+		ctx := context.Background()
+		log.Infof("rotate table partitions cron execution")
+		s.tablePartition.RotateCron(ctx)
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
 	if s.serviceConfig.CleanUpUploads {
 		log.Infof("clean up uploads cron enabled")
 
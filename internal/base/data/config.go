@@ -26,6 +26,8 @@ type Database struct {
 	ConnMaxLifeTime int    `json:"conn_max_life_time" mapstructure:"conn_max_life_time" yaml:"conn_max_life_time,omitempty"`
 	MaxOpenConn     int    `json:"max_open_conn" mapstructure:"max_open_conn" yaml:"max_open_conn,omitempty"`
 	MaxIdleConn     int    `json:"max_idle_conn" mapstructure:"max_idle_conn" yaml:"max_idle_conn,omitempty"`
+	// SlowQueryThresholdMs queries that take longer than this are recorded in the slow query report
+	SlowQueryThresholdMs int `json:"slow_query_threshold_ms" mapstructure:"slow_query_threshold_ms" yaml:"slow_query_threshold_ms,omitempty"`
 }
 
 // CacheConf cache
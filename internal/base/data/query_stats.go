@@ -0,0 +1,218 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package data
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"xorm.io/xorm"
+	"xorm.io/xorm/contexts"
+)
+
+const (
+	// DefaultSlowQueryThreshold default duration a query must exceed to be recorded as slow
+	DefaultSlowQueryThreshold = 200 * time.Millisecond
+	// slowQueryCapacity the number of latest slow queries kept for the report
+	slowQueryCapacity = 1000
+	// UnknownQueryOrigin the origin of queries not executed within a http request, such as cron jobs
+	UnknownQueryOrigin = "background"
+)
+
+var (
+	sqlStringLiteral  = regexp.MustCompile(`'(?:[^']|'')*'`)
+	sqlNumberLiteral  = regexp.MustCompile(`\b\d+\b`)
+	sqlPlaceholderSeq = regexp.MustCompile(`\?(?:\s*,\s*\?)+`)
+	sqlPgPlaceholder  = regexp.MustCompile(`\$\d+`)
+	sqlWhitespace     = regexp.MustCompile(`\s+`)
+)
+
+// SlowQuery a query that took longer than the slow query threshold
+type SlowQuery struct {
+	// Origin the http handler that executed the query, e.g. GET /answer/api/v1/question/page
+	Origin string
+	// SQL normalized sql without literal values
+	SQL      string
+	Args     []string
+	Duration time.Duration
+	Err      string
+	At       time.Time
+}
+
+// QueryStats xorm hook that records query durations and keeps the latest slow queries
+type QueryStats struct {
+	threshold    time.Duration
+	totalQueries atomic.Int64
+	slowQueries  atomic.Int64
+	startedAt    time.Time
+
+	mu     sync.Mutex
+	ring   []*SlowQuery
+	cursor int
+}
+
+// NewQueryStats create query stats and register it as a hook of the database engine
+func NewQueryStats(db *xorm.Engine, dataConf *Database) *QueryStats {
+	threshold := DefaultSlowQueryThreshold
+	if dataConf != nil && dataConf.SlowQueryThresholdMs > 0 {
+		threshold = time.Duration(dataConf.SlowQueryThresholdMs) * time.Millisecond
+	}
+	qs := &QueryStats{
+		threshold: threshold,
+		startedAt: time.Now(),
+		ring:      make([]*SlowQuery, 0, slowQueryCapacity),
+	}
+	db.AddHook(qs)
+	return qs
+}
+
+// BeforeProcess implements contexts.Hook
+func (qs *QueryStats) BeforeProcess(c *contexts.ContextHook) (context.Context, error) {
+	return c.Ctx, nil
+}
+
+// AfterProcess implements contexts.Hook
+func (qs *QueryStats) AfterProcess(c *contexts.ContextHook) error {
+	qs.totalQueries.Add(1)
+	if c.ExecuteTime < qs.threshold {
+		return nil
+	}
+	qs.slowQueries.Add(1)
+	query := &SlowQuery{
+		Origin:   QueryOrigin(c.Ctx),
+		SQL:      NormalizeSQL(c.SQL),
+		Args:     SanitizeQueryArgs(c.Args),
+		Duration: c.ExecuteTime,
+		At:       time.Now(),
+	}
+	if c.Err != nil {
+		query.Err = c.Err.Error()
+	}
+	qs.mu.Lock()
+	if len(qs.ring) < slowQueryCapacity {
+		qs.ring = append(qs.ring, query)
+	} else {
+		qs.ring[qs.cursor] = query
+	}
+	qs.cursor = (qs.cursor + 1) % slowQueryCapacity
+	qs.mu.Unlock()
+	return nil
+}
+
+// Threshold the duration a query must exceed to be recorded as slow
+func (qs *QueryStats) Threshold() time.Duration {
+	return qs.threshold
+}
+
+// Counters the number of queries and slow queries since the server started
+func (qs *QueryStats) Counters() (total, slow int64, since time.Time) {
+	qs.mu.Lock()
+	defer qs.mu.Unlock()
+	return qs.totalQueries.Load(), qs.slowQueries.Load(), qs.startedAt
+}
+
+// SlowQueries the latest recorded slow queries
+func (qs *QueryStats) SlowQueries() []*SlowQuery {
+	qs.mu.Lock()
+	defer qs.mu.Unlock()
+	queries := make([]*SlowQuery, len(qs.ring))
+	copy(queries, qs.ring)
+	return queries
+}
+
+// Reset clear the recorded slow queries and counters
+func (qs *QueryStats) Reset() {
+	qs.mu.Lock()
+	defer qs.mu.Unlock()
+	qs.ring = make([]*SlowQuery, 0, slowQueryCapacity)
+	qs.cursor = 0
+	qs.totalQueries.Store(0)
+	qs.slowQueries.Store(0)
+	qs.startedAt = time.Now()
+}
+
+// QueryOrigin the method and route of the http request that the context belongs to
+func QueryOrigin(ctx context.Context) string {
+	if ctx == nil {
+		return UnknownQueryOrigin
+	}
+	ginCtx, ok := ctx.Value(gin.ContextKey).(*gin.Context)
+	if !ok || ginCtx.Request == nil {
+		return UnknownQueryOrigin
+	}
+	route := ginCtx.FullPath()
+	if len(route) == 0 {
+		route = ginCtx.Request.URL.Path
+	}
+	return ginCtx.Request.Method + " " + route
+}
+
+// NormalizeSQL replace the literal values in sql so that the same query with different values is grouped together
+func NormalizeSQL(sql string) string {
+	sql = sqlStringLiteral.ReplaceAllString(sql, "?")
+	sql = sqlPgPlaceholder.ReplaceAllString(sql, "?")
+	sql = sqlNumberLiteral.ReplaceAllString(sql, "?")
+	sql = sqlPlaceholderSeq.ReplaceAllString(sql, "?...")
+	return strings.TrimSpace(sqlWhitespace.ReplaceAllString(sql, " "))
+}
+
+// SanitizeQueryArgs keep the type and shape of the arguments without leaking the content of strings,
+// which may contain email, password hash or token.
+func SanitizeQueryArgs(args []any) (sanitized []string) {
+	sanitized = make([]string, 0, len(args))
+	for _, arg := range args {
+		switch v := arg.(type) {
+		case nil:
+			sanitized = append(sanitized, "NULL")
+		case string:
+			sanitized = append(sanitized, sanitizeString(v))
+		case []byte:
+			sanitized = append(sanitized, fmt.Sprintf("<%d bytes>", len(v)))
+		case time.Time:
+			sanitized = append(sanitized, v.Format(time.RFC3339))
+		case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+			sanitized = append(sanitized, fmt.Sprintf("%v", v))
+		default:
+			sanitized = append(sanitized, fmt.Sprintf("<%T>", v))
+		}
+	}
+	return sanitized
+}
+
+// sanitizeString ids are kept for locating the data, other strings are masked
+func sanitizeString(s string) string {
+	isDigits := len(s) > 0
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			isDigits = false
+			break
+		}
+	}
+	if isDigits && len(s) <= 20 {
+		return s
+	}
+	return fmt.Sprintf("<string len=%d>", len(s))
+}
@@ -64,13 +64,28 @@ func HandleResponse(ctx *gin.Context, err error, data interface{}) {
 func BindAndCheck(ctx *gin.Context, data interface{}) bool {
 	lang := GetLang(ctx)
 	ctx.Set(constant.AcceptLanguageFlag, lang)
+	limits := validator.GetContentLimits(ctx)
+	if ctx.Request.Body != nil {
+		ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, getRequestBodyLimit(ctx, limits))
+	}
 	if err := ctx.ShouldBind(data); err != nil {
 		log.Errorf("http_handle BindAndCheck fail, %s", err.Error())
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			HandleResponse(ctx, myErrors.New(http.StatusRequestEntityTooLarge, reason.RequestBodyTooLarge), nil)
+			return true
+		}
 		HandleResponse(ctx, myErrors.New(http.StatusBadRequest, reason.RequestFormatError), nil)
 		return true
 	}
 
-	errField, err := validator.GetValidatorByLang(lang).Check(data)
+	v := validator.GetValidatorByLang(lang)
+	errField, err := v.Check(data)
+	if err != nil {
+		HandleResponse(ctx, err, errField)
+		return true
+	}
+	errField, err = v.CheckLimits(data, limits)
 	if err != nil {
 		HandleResponse(ctx, err, errField)
 		return true
@@ -78,6 +93,14 @@ func BindAndCheck(ctx *gin.Context, data interface{}) bool {
 	return false
 }
 
+// getRequestBodyLimit the limit set by the route overrides the limit of site
+func getRequestBodyLimit(ctx *gin.Context, limits *validator.ContentLimits) int64 {
+	if limit := ctx.GetInt64(constant.RequestBodyLimitFlag); limit > 0 {
+		return limit
+	}
+	return limits.MaxRequestBodySize
+}
+
 // BindAndCheckReturnErr bind request and check
 func BindAndCheckReturnErr(ctx *gin.Context, data interface{}) (errFields []*validator.FormErrorField) {
 	lang := GetLang(ctx)
@@ -0,0 +1,95 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/validator"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type bindAndCheckReq struct {
+	Name string `json:"name"`
+}
+
+func doBindAndCheck(routeLimit int64, body string) (resp *httptest.ResponseRecorder, bound bool) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	handlers := make([]gin.HandlerFunc, 0)
+	if routeLimit > 0 {
+		handlers = append(handlers, func(ctx *gin.Context) {
+			ctx.Set(constant.RequestBodyLimitFlag, routeLimit)
+		})
+	}
+	handlers = append(handlers, func(ctx *gin.Context) {
+		bound = !BindAndCheck(ctx, &bindAndCheckReq{})
+	})
+	r.POST("/", handlers...)
+	resp = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(resp, req)
+	return resp, bound
+}
+
+func TestBindAndCheck_RequestBodyLimit(t *testing.T) {
+	body := `{"name":"` + strings.Repeat("a", 1024) + `"}`
+
+	// the body is in the limit of site
+	resp, bound := doBindAndCheck(0, body)
+	assert.True(t, bound)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	// the limit of route overrides the limit of site
+	resp, bound = doBindAndCheck(512, body)
+	assert.False(t, bound)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.Code)
+	respBody := &RespBody{}
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), respBody))
+	assert.Equal(t, reason.RequestBodyTooLarge, respBody.Reason)
+
+	resp, bound = doBindAndCheck(validator.DefaultMaxRequestBodySize*2, body)
+	assert.True(t, bound)
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestBindAndCheck_SiteRequestBodyLimit(t *testing.T) {
+	validator.RegisterContentLimitsGetter(func(ctx context.Context) *validator.ContentLimits {
+		return &validator.ContentLimits{MaxRequestBodySize: 256}
+	})
+	defer validator.RegisterContentLimitsGetter(nil)
+
+	resp, bound := doBindAndCheck(0, `{"name":"`+strings.Repeat("a", 512)+`"}`)
+	assert.False(t, bound)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.Code)
+
+	resp, bound = doBindAndCheck(0, `{"name":"a"}`)
+	assert.True(t, bound)
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
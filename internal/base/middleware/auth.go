@@ -44,15 +44,18 @@ var ctxUUIDKey = "ctxUuidKey"
 type AuthUserMiddleware struct {
 	authService           *auth.AuthService
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService
+	rolePowerRelService   *role.RolePowerRelService
 }
 
 // NewAuthUserMiddleware new auth user middleware
 func NewAuthUserMiddleware(
 	authService *auth.AuthService,
-	siteInfoCommonService siteinfo_common.SiteInfoCommonService) *AuthUserMiddleware {
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
+	rolePowerRelService *role.RolePowerRelService) *AuthUserMiddleware {
 	return &AuthUserMiddleware{
 		authService:           authService,
 		siteInfoCommonService: siteInfoCommonService,
+		rolePowerRelService:   rolePowerRelService,
 	}
 }
 
@@ -69,7 +72,9 @@ func (am *AuthUserMiddleware) Auth() gin.HandlerFunc {
 			ctx.Next()
 			return
 		}
-		if userInfo != nil {
+		// the personal access token without the scope is treated as no login
+		if userInfo != nil && hasTokenScope(ctx, userInfo) {
+			am.authService.RefreshUserSession(ctx, token, userInfo, ctx.ClientIP(), ctx.Request.UserAgent())
 			ctx.Set(ctxUUIDKey, userInfo)
 		}
 		ctx.Next()
@@ -122,11 +127,17 @@ func (am *AuthUserMiddleware) MustAuthWithoutAccountAvailable() gin.HandlerFunc
 			ctx.Abort()
 			return
 		}
+		if !hasTokenScope(ctx, userInfo) {
+			handler.HandleResponse(ctx, errors.Forbidden(reason.PersonalAccessTokenScopeDenied), nil)
+			ctx.Abort()
+			return
+		}
 		if userInfo.UserStatus == entity.UserStatusDeleted {
 			handler.HandleResponse(ctx, errors.Unauthorized(reason.UnauthorizedError), nil)
 			ctx.Abort()
 			return
 		}
+		am.authService.RefreshUserSession(ctx, token, userInfo, ctx.ClientIP(), ctx.Request.UserAgent())
 		ctx.Set(ctxUUIDKey, userInfo)
 		ctx.Next()
 	}
@@ -147,6 +158,11 @@ func (am *AuthUserMiddleware) MustAuthAndAccountAvailable() gin.HandlerFunc {
 			ctx.Abort()
 			return
 		}
+		if !hasTokenScope(ctx, userInfo) {
+			handler.HandleResponse(ctx, errors.Forbidden(reason.PersonalAccessTokenScopeDenied), nil)
+			ctx.Abort()
+			return
+		}
 		if userInfo.EmailStatus != entity.EmailStatusAvailable {
 			handler.HandleResponse(ctx, errors.Forbidden(reason.EmailNeedToBeVerified),
 				&schema.ForbiddenResp{Type: schema.ForbiddenReasonTypeInactive})
@@ -164,6 +180,7 @@ func (am *AuthUserMiddleware) MustAuthAndAccountAvailable() gin.HandlerFunc {
 			ctx.Abort()
 			return
 		}
+		am.authService.RefreshUserSession(ctx, token, userInfo, ctx.ClientIP(), ctx.Request.UserAgent())
 		ctx.Set(ctxUUIDKey, userInfo)
 		ctx.Next()
 	}
@@ -177,7 +194,7 @@ func (am *AuthUserMiddleware) AdminAuth() gin.HandlerFunc {
 			ctx.Abort()
 			return
 		}
-		userInfo, err := am.authService.GetAdminUserCacheInfo(ctx, token)
+		userInfo, err := am.getAdminUserCacheInfo(ctx, token)
 		if err != nil || userInfo == nil {
 			handler.HandleResponse(ctx, errors.Forbidden(reason.UnauthorizedError), nil)
 			ctx.Abort()
@@ -195,6 +212,66 @@ func (am *AuthUserMiddleware) AdminAuth() gin.HandlerFunc {
 	}
 }
 
+// PowerAuth auth the admin, or the available user whose role has the power, for the admin api delegated to the custom role
+func (am *AuthUserMiddleware) PowerAuth(power string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		token := ExtractToken(ctx)
+		if len(token) == 0 {
+			handler.HandleResponse(ctx, errors.Unauthorized(reason.UnauthorizedError), nil)
+			ctx.Abort()
+			return
+		}
+		userInfo, err := am.getAdminUserCacheInfo(ctx, token)
+		if err == nil && userInfo != nil && userInfo.UserStatus != entity.UserStatusDeleted {
+			ctx.Set(ctxUUIDKey, userInfo)
+			ctx.Next()
+			return
+		}
+		userInfo, err = am.authService.GetUserCacheInfo(ctx, token)
+		if err != nil || userInfo == nil || userInfo.UserStatus != entity.UserStatusAvailable ||
+			userInfo.EmailStatus != entity.EmailStatusAvailable || !hasTokenScope(ctx, userInfo) {
+			handler.HandleResponse(ctx, errors.Forbidden(reason.UnauthorizedError), nil)
+			ctx.Abort()
+			return
+		}
+		has, err := am.rolePowerRelService.HasRolePower(ctx, userInfo.RoleID, power)
+		if err != nil || !has {
+			handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+			ctx.Abort()
+			return
+		}
+		am.authService.RefreshUserSession(ctx, token, userInfo, ctx.ClientIP(), ctx.Request.UserAgent())
+		ctx.Set(ctxUUIDKey, userInfo)
+		ctx.Next()
+	}
+}
+
+// getAdminUserCacheInfo get the admin logged in, or the available admin with the personal access token of admin scope
+func (am *AuthUserMiddleware) getAdminUserCacheInfo(ctx *gin.Context, token string) (
+	userInfo *entity.UserCacheInfo, err error) {
+	if !strings.HasPrefix(token, auth.PersonalAccessTokenPrefix) {
+		return am.authService.GetAdminUserCacheInfo(ctx, token)
+	}
+	userInfo, err = am.authService.GetUserCacheInfo(ctx, token)
+	if err != nil || userInfo == nil {
+		return nil, err
+	}
+	if userInfo.RoleID != role.RoleAdminID || userInfo.UserStatus != entity.UserStatusAvailable ||
+		!schema.PersonalAccessTokenHasScope(userInfo.Scopes, schema.PersonalAccessTokenScopeAdmin) {
+		return nil, nil
+	}
+	return userInfo, nil
+}
+
+// hasTokenScope whether the personal access token has the scope required by the request, the login session has the full access
+func hasTokenScope(ctx *gin.Context, userInfo *entity.UserCacheInfo) bool {
+	if len(userInfo.Scopes) == 0 {
+		return true
+	}
+	return schema.PersonalAccessTokenHasScope(userInfo.Scopes,
+		schema.PersonalAccessTokenRequiredScope(ctx.Request.Method, ctx.FullPath()))
+}
+
 func (am *AuthUserMiddleware) CheckPrivateMode() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		resp, err := am.siteInfoCommonService.GetSiteLogin(ctx)
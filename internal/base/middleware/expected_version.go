@@ -0,0 +1,43 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package middleware
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/gin-gonic/gin"
+)
+
+// ExtractExpectedVersion extract the version of settings from the If-Match header like "3" or W/"3",
+// the write is rejected if the settings have been changed after the version
+func ExtractExpectedVersion(ctx *gin.Context) {
+	if ifMatch := ctx.GetHeader("If-Match"); len(ifMatch) > 0 {
+		ifMatch = strings.Trim(strings.TrimPrefix(strings.TrimSpace(ifMatch), "W/"), `"`)
+		if version, err := strconv.Atoi(ifMatch); err == nil && version >= 0 {
+			ctx.Set(constant.ExpectedVersionFlag, version)
+		}
+	}
+	if userID := GetLoginUserIDFromContext(ctx); len(userID) > 0 {
+		ctx.Set(constant.OperatorIDFlag, userID)
+	}
+	ctx.Next()
+}
@@ -0,0 +1,83 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/service/ip_ban"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// ipBanPaths the registration and posting api rejected for the banned ip
+var ipBanPaths = []string{
+	"/answer/api/v1/user/register/email",
+	"/answer/api/v1/question",
+	"/answer/api/v1/question/answer",
+	"/answer/api/v1/answer",
+	"/answer/api/v1/comment",
+}
+
+// IPBanMiddleware reject the registration and posting from the banned ip and CIDR ranges
+type IPBanMiddleware struct {
+	ipBanService *ip_ban.IPBanService
+}
+
+// NewIPBanMiddleware new ip ban middleware
+func NewIPBanMiddleware(ipBanService *ip_ban.IPBanService) *IPBanMiddleware {
+	return &IPBanMiddleware{ipBanService: ipBanService}
+}
+
+// BanIP reject the request if the client ip is banned, only the registration and posting are checked
+func (im *IPBanMiddleware) BanIP() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !isIPBanCheckedRequest(ctx) {
+			ctx.Next()
+			return
+		}
+		ip := ctx.ClientIP()
+		if !im.ipBanService.IsBanned(ctx, ip) {
+			ctx.Next()
+			return
+		}
+		log.Debugf("banned ip %s: %s %s", ip, ctx.Request.Method, ctx.Request.URL.Path)
+		handler.HandleResponse(ctx, errors.Forbidden(reason.IPBanned), nil)
+		ctx.Abort()
+	}
+}
+
+// isIPBanCheckedRequest check whether the request registers or posts the content
+func isIPBanCheckedRequest(ctx *gin.Context) bool {
+	if ctx.Request.Method != http.MethodPost {
+		return false
+	}
+	fullPath := ctx.FullPath()
+	for _, path := range ipBanPaths {
+		if strings.HasSuffix(fullPath, path) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,212 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// loadSheddingLatencyWindow the latency is averaged over the requests finished in the window
+	loadSheddingLatencyWindow = 10 * time.Second
+	// loadSheddingPollInterval the interval of checking whether the queued request can be processed
+	loadSheddingPollInterval = 20 * time.Millisecond
+)
+
+// lowPriorityPathSuffixes the anonymous list and feed requests that can be shed under overload
+var lowPriorityPathSuffixes = []string{"/page", "/questions", "/tags", "/feed", "/rss", "/sitemap.xml"}
+
+// LoadSheddingMiddleware protect the server from overload by queueing and shedding low priority requests
+type LoadSheddingMiddleware struct {
+	siteInfoService siteinfo_common.SiteInfoCommonService
+	latency         *latencyWindow
+	inFlight        atomic.Int64
+	queueDepth      atomic.Int64
+	totalRequests   atomic.Int64
+	queuedRequests  atomic.Int64
+	shedRequests    atomic.Int64
+}
+
+// NewLoadSheddingMiddleware new load shedding middleware
+func NewLoadSheddingMiddleware(siteInfoService siteinfo_common.SiteInfoCommonService) *LoadSheddingMiddleware {
+	return &LoadSheddingMiddleware{
+		siteInfoService: siteInfoService,
+		latency:         &latencyWindow{window: loadSheddingLatencyWindow},
+	}
+}
+
+// LoadShedding track the in-flight requests and latency, and shed the low priority requests under overload.
+// The writes and the requests of logged-in users are never shed.
+func (lm *LoadSheddingMiddleware) LoadShedding() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		lm.totalRequests.Add(1)
+		if isLowPriorityRequest(ctx) && lm.shed(ctx) {
+			return
+		}
+		lm.inFlight.Add(1)
+		start := time.Now()
+		defer func() {
+			lm.inFlight.Add(-1)
+			lm.latency.add(time.Since(start))
+		}()
+		ctx.Next()
+	}
+}
+
+// Metrics get the load shedding metrics
+func (lm *LoadSheddingMiddleware) Metrics(ctx *gin.Context) (resp *schema.LoadSheddingMetricsResp, err error) {
+	conf, err := lm.siteInfoService.GetSiteLoadShedding(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &schema.LoadSheddingMetricsResp{
+		Enabled:        conf.Enabled,
+		Overloaded:     lm.overloaded(conf),
+		InFlight:       lm.inFlight.Load(),
+		QueueDepth:     lm.queueDepth.Load(),
+		AvgLatencyMs:   lm.latency.avg().Milliseconds(),
+		TotalRequests:  lm.totalRequests.Load(),
+		QueuedRequests: lm.queuedRequests.Load(),
+		ShedRequests:   lm.shedRequests.Load(),
+	}, nil
+}
+
+// shed queue the low priority request while the server is overloaded, reject it if the queue is full or timeout
+func (lm *LoadSheddingMiddleware) shed(ctx *gin.Context) (reject bool) {
+	conf, err := lm.siteInfoService.GetSiteLoadShedding(ctx)
+	if err != nil {
+		log.Errorf("get site load shedding error: %s", err.Error())
+		return false
+	}
+	if !conf.Enabled || !lm.overloaded(conf) {
+		return false
+	}
+	if lm.queueDepth.Add(1) <= int64(conf.MaxQueueDepth) {
+		lm.queuedRequests.Add(1)
+		admitted := lm.wait(ctx, conf)
+		lm.queueDepth.Add(-1)
+		if admitted {
+			return false
+		}
+	} else {
+		lm.queueDepth.Add(-1)
+	}
+	lm.shedRequests.Add(1)
+	log.Debugf("load shedding: %s %s", ctx.Request.Method, ctx.Request.URL.Path)
+	ctx.Header("Retry-After", "1")
+	handler.HandleResponse(ctx, errors.New(http.StatusServiceUnavailable, reason.ServiceOverloaded), nil)
+	ctx.Abort()
+	return true
+}
+
+// wait wait until the server is not overloaded, false if timeout or the request is cancelled
+func (lm *LoadSheddingMiddleware) wait(ctx *gin.Context, conf *schema.SiteLoadSheddingResp) (admitted bool) {
+	timer := time.NewTimer(time.Duration(conf.QueueTimeoutMs) * time.Millisecond)
+	defer timer.Stop()
+	ticker := time.NewTicker(loadSheddingPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return false
+		case <-timer.C:
+			return false
+		case <-ticker.C:
+			if !lm.overloaded(conf) {
+				return true
+			}
+		}
+	}
+}
+
+func (lm *LoadSheddingMiddleware) overloaded(conf *schema.SiteLoadSheddingResp) bool {
+	return lm.inFlight.Load() >= int64(conf.MaxInFlight) ||
+		lm.latency.avg() > time.Duration(conf.LatencyThresholdMs)*time.Millisecond
+}
+
+// isLowPriorityRequest check whether the request is an anonymous list or feed request
+func isLowPriorityRequest(ctx *gin.Context) bool {
+	if ctx.Request.Method != http.MethodGet && ctx.Request.Method != http.MethodHead {
+		return false
+	}
+	if len(ExtractToken(ctx)) > 0 {
+		return false
+	}
+	path := strings.TrimSuffix(ctx.Request.URL.Path, "/")
+	if len(path) == 0 || strings.Contains(path, "/sitemap/") {
+		return true
+	}
+	for _, suffix := range lowPriorityPathSuffixes {
+		if strings.HasSuffix(path, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// latencyWindow the average latency of the requests finished in the last window
+type latencyWindow struct {
+	window  time.Duration
+	mu      sync.Mutex
+	start   time.Time
+	sum     time.Duration
+	count   int64
+	lastAvg time.Duration
+}
+
+func (w *latencyWindow) add(d time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.rotate(time.Now())
+	w.sum += d
+	w.count++
+}
+
+func (w *latencyWindow) avg() time.Duration {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.rotate(time.Now())
+	return w.lastAvg
+}
+
+// rotate start a new window if the current one is over, the average is reset if no request finished in the last window
+func (w *latencyWindow) rotate(now time.Time) {
+	elapsed := now.Sub(w.start)
+	if elapsed < w.window {
+		return
+	}
+	w.lastAvg = 0
+	if w.count > 0 && elapsed < 2*w.window {
+		w.lastAvg = w.sum / time.Duration(w.count)
+	}
+	w.start, w.sum, w.count = now, 0, 0
+}
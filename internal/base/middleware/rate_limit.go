@@ -22,9 +22,14 @@ package middleware
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"time"
+
 	"github.com/apache/answer/internal/base/handler"
 	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/repo/limit"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
 	"github.com/apache/answer/pkg/encryption"
 	"github.com/gin-gonic/gin"
 	"github.com/segmentfault/pacman/errors"
@@ -32,13 +37,21 @@ import (
 )
 
 type RateLimitMiddleware struct {
-	limitRepo *limit.LimitRepo
+	limitRepo       *limit.LimitRepo
+	siteInfoService siteinfo_common.SiteInfoCommonService
+	userCommon      *usercommon.UserCommon
 }
 
 // NewRateLimitMiddleware new rate limit middleware
-func NewRateLimitMiddleware(limitRepo *limit.LimitRepo) *RateLimitMiddleware {
+func NewRateLimitMiddleware(
+	limitRepo *limit.LimitRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	userCommon *usercommon.UserCommon,
+) *RateLimitMiddleware {
 	return &RateLimitMiddleware{
-		limitRepo: limitRepo,
+		limitRepo:       limitRepo,
+		siteInfoService: siteInfoService,
+		userCommon:      userCommon,
 	}
 }
 
@@ -63,6 +76,56 @@ func (rm *RateLimitMiddleware) DuplicateRequestRejection(ctx *gin.Context, req a
 	return true, key
 }
 
+// ActionRateLimit limits how often the user can do the action, the limit depends on the reputation of user.
+// Admin and moderator are not limited.
+func (rm *RateLimitMiddleware) ActionRateLimit(ctx *gin.Context, action string) (reject bool) {
+	if GetUserIsAdminModerator(ctx) {
+		return false
+	}
+	userID := GetLoginUserIDFromContext(ctx)
+	if len(userID) == 0 {
+		return false
+	}
+	userInfo, exist, err := rm.userCommon.GetUserBasicInfoByID(ctx, userID)
+	if err != nil || !exist {
+		return false
+	}
+	return rm.checkActionLimit(ctx, action, userID, userInfo.Rank)
+}
+
+// AnonymousActionRateLimit limits how often the anonymous user can do the action by client ip,
+// the limit is the one for no reputation. Logged-in user is not limited here.
+func (rm *RateLimitMiddleware) AnonymousActionRateLimit(ctx *gin.Context, action string) (reject bool) {
+	if len(GetLoginUserIDFromContext(ctx)) > 0 {
+		return false
+	}
+	return rm.checkActionLimit(ctx, action, "ip:"+ctx.ClientIP(), 0)
+}
+
+func (rm *RateLimitMiddleware) checkActionLimit(ctx *gin.Context, action, key string, reputation int) (reject bool) {
+	rateLimit, err := rm.siteInfoService.GetSiteRateLimit(ctx)
+	if err != nil {
+		log.Errorf("get site rate limit error: %s", err.Error())
+		return false
+	}
+	tier := rateLimit.GetTier(action, reputation)
+	if tier == nil {
+		return false
+	}
+	reject, err = rm.limitRepo.CheckAndIncrease(ctx, fmt.Sprintf("%s:%s", action, key),
+		int64(tier.MaxCount), time.Duration(tier.PeriodSeconds)*time.Second)
+	if err != nil {
+		log.Errorf("check action rate limit error: %s", err.Error())
+		return false
+	}
+	if !reject {
+		return false
+	}
+	log.Debugf("action rate limit: [%s] %s", action, key)
+	handler.HandleResponse(ctx, errors.New(http.StatusTooManyRequests, reason.RateLimitExceeded), nil)
+	return true
+}
+
 // DuplicateRequestClear clear duplicate request record
 func (rm *RateLimitMiddleware) DuplicateRequestClear(ctx *gin.Context, key string) {
 	err := rm.limitRepo.ClearRecord(ctx, key)
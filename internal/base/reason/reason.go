@@ -47,14 +47,27 @@ const (
 	QuestionAlreadyDeleted           = "error.question.already_deleted"
 	QuestionUnderReview              = "error.question.under_review"
 	QuestionContentCannotEmpty       = "error.question.content_cannot_empty"
+	QuestionCloseVoteDisabled        = "error.question.close_vote_disabled"
+	QuestionCloseVoteAlreadyCast     = "error.question.close_vote_already_cast"
+	QuestionCloseVoteNotFound        = "error.question.close_vote_not_found"
+	QuestionCloseTypeInvalid         = "error.question.close_type_invalid"
+	QuestionAlreadyClosed            = "error.question.already_closed"
+	QuestionNotClosed                = "error.question.not_closed"
+	QuestionMigrationNotFound        = "error.question.migration_not_found"
+	QuestionMigrationNotOffTopic     = "error.question.migration_not_off_topic"
 	AnswerNotFound                   = "error.answer.not_found"
 	AnswerCannotDeleted              = "error.answer.cannot_deleted"
 	AnswerCannotUpdate               = "error.answer.cannot_update"
 	AnswerCannotAddByClosedQuestion  = "error.answer.question_closed_cannot_add"
 	AnswerRestrictAnswer             = "error.answer.restrict_answer"
 	AnswerContentCannotEmpty         = "error.answer.content_cannot_empty"
+	AnswerResolutionNoteRequired     = "error.answer.resolution_note_required"
+	AnswerSelfAcceptTooEarly         = "error.answer.self_accept_too_early"
+	AnswerPinAccepted                = "error.answer.pin_accepted"
 	CommentEditWithoutPermission     = "error.comment.edit_without_permission"
 	CommentContentCannotEmpty        = "error.comment.content_cannot_empty"
+	CommentBulkRemoveFilterRequired  = "error.comment.bulk_remove_filter_required"
+	CommentCodeAnchorInvalid         = "error.comment.code_anchor_invalid"
 	DisallowVote                     = "error.object.disallow_vote"
 	DisallowFollow                   = "error.object.disallow_follow"
 	DisallowVoteYourSelf             = "error.object.disallow_vote_your_self"
@@ -84,21 +97,48 @@ const (
 	LangNotFound                     = "error.lang.not_found"
 	ReportHandleFailed               = "error.report.handle_failed"
 	ReportNotFound                   = "error.report.not_found"
+	ReportCannotAppeal               = "error.report.cannot_appeal"
+	ReportAppealAlreadyExist         = "error.report.appeal_already_exist"
+	ReportAppealNotFound             = "error.report.appeal_not_found"
+	ReportAppealAlreadyReviewed      = "error.report.appeal_already_reviewed"
+	ReportAppealSameModerator        = "error.report.appeal_same_moderator"
+	ReportAlreadyHandled             = "error.report.already_handled"
+	ReportAssigneeInvalid            = "error.report.assignee_invalid"
 	ReadConfigFailed                 = "error.config.read_config_failed"
 	DatabaseConnectionFailed         = "error.database.connection_failed"
 	InstallCreateTableFailed         = "error.database.create_table_failed"
 	InstallConfigFailed              = "error.install.create_config_failed"
 	SiteInfoConfigNotFound           = "error.site_info.config_not_found"
+	SiteInfoVersionConflict          = "error.site_info.version_conflict"
+	SiteInfoHistoryNotFound          = "error.site_info.history_not_found"
 	UploadFileSourceUnsupported      = "error.upload.source_unsupported"
 	UploadFileUnsupportedFileFormat  = "error.upload.unsupported_file_format"
+	UploadFileTooLarge               = "error.upload.file_too_large"
+	UploadChecksumInvalid            = "error.upload.checksum_invalid"
+	UploadChecksumMismatch           = "error.upload.checksum_mismatch"
+	UploadOffsetMismatch             = "error.upload.offset_mismatch"
+	UploadNotFound                   = "error.upload.not_found"
+	UploadChunkedUnsupported         = "error.upload.chunked_unsupported"
+	UploadVideoTooLong               = "error.upload.video_too_long"
+	UploadVideoUnsupported           = "error.upload.video_unsupported"
+	AutoPromotionRuleDuplicate       = "error.auto_promotion.rule_duplicate"
+	AutoPromotionInProgress          = "error.auto_promotion.in_progress"
+	ContentFreshnessInProgress       = "error.content_freshness.in_progress"
 	RecommendTagNotExist             = "error.tag.recommend_tag_not_found"
 	RecommendTagEnter                = "error.tag.recommend_tag_enter"
 	RevisionReviewUnderway           = "error.revision.review_underway"
 	RevisionNoPermission             = "error.revision.no_permission"
 	UserCannotUpdateYourRole         = "error.user.cannot_update_your_role"
 	TagCannotSetSynonymAsItself      = "error.tag.cannot_set_synonym_as_itself"
+	TagCannotSetSynonymOfSynonym     = "error.tag.cannot_set_synonym_of_synonym"
+	TagSynonymHasSynonyms            = "error.tag.synonym_has_synonyms"
+	TagIsNotSynonym                  = "error.tag.is_not_synonym"
 	NotAllowedRegistration           = "error.user.not_allowed_registration"
 	NotAllowedLoginViaPassword       = "error.user.not_allowed_login_via_password"
+	BotNotAllowedLogin               = "error.user.bot_not_allowed_login"
+	BotNotFound                      = "error.user.bot_not_found"
+	SavedSearchNotFound              = "error.saved_search.not_found"
+	SavedSearchLimitExceeded         = "error.saved_search.limit_exceeded"
 	SMTPConfigFromNameCannotBeEmail  = "error.smtp.config_from_name_cannot_be_email"
 	AdminCannotUpdateTheirPassword   = "error.admin.cannot_update_their_password"
 	AdminCannotEditTheirProfile      = "error.admin.cannot_edit_their_profile"
@@ -110,11 +150,158 @@ const (
 	InvalidURLError                  = "error.common.invalid_url"
 	MetaObjectNotFound               = "error.meta.object_not_found"
 	BadgeObjectNotFound              = "error.badge.object_not_found"
+	BadgeBackfillInProgress          = "error.badge.backfill_in_progress"
 	StatusInvalid                    = "error.common.status_invalid"
 	UserStatusInactive               = "error.user.status_inactive"
 	UserStatusSuspendedForever       = "error.user.status_suspended_forever"
 	UserStatusSuspendedUntil         = "error.user.status_suspended_until"
 	UserStatusDeleted                = "error.user.status_deleted"
+	RequestBodyTooLarge              = "error.limit.request_body_too_large"
+	TitleTooLong                     = "error.limit.title_too_long"
+	ContentTooLong                   = "error.limit.content_too_long"
+	CommentTooLong                   = "error.limit.comment_too_long"
+	TooManyTags                      = "error.limit.too_many_tags"
+	TooManyImages                    = "error.limit.too_many_images"
+	RateLimitExceeded                = "error.limit.rate_limit_exceeded"
+	ServiceOverloaded                = "error.limit.service_overloaded"
+	DataDumpNotFound                 = "error.data_dump.not_found"
+	DataDumpInProgress               = "error.data_dump.in_progress"
+	ReputationRecalcInProgress       = "error.reputation.recalc_in_progress"
+	ReputationRecalcRankAgentEnabled = "error.reputation.recalc_rank_agent_enabled"
+	ReputationRuleNotFound           = "error.reputation.rule_not_found"
+	ChatIntakeDisabled               = "error.chat_intake.disabled"
+	ChatIntakeSignatureInvalid       = "error.chat_intake.signature_invalid"
+	ChatIntakeUserNotFound           = "error.chat_intake.user_not_found"
+	SyndicationDisabled              = "error.syndication.disabled"
+	HelpfulnessSurveyNotFound        = "error.helpfulness_survey.not_found"
+	HelpfulnessSurveyResponded       = "error.helpfulness_survey.already_responded"
+	ReEngagementTemplateInvalid      = "error.re_engagement.template_invalid"
+	QuestionQualityDisabled          = "error.question_quality.disabled"
+	QuestionQualityNotFound          = "error.question_quality.not_found"
+	QuestionQualityClaimed           = "error.question_quality.claimed"
+	TagRuleRequiredOneOf             = "error.tag.rule_required_one_of"
+	TagRuleModeratorOnly             = "error.tag.rule_moderator_only"
+	AutoCommentTemplateInvalid       = "error.auto_comment.template_invalid"
+	AutoCommentRuleTagsRequired      = "error.auto_comment.rule_tags_required"
+	TagEditDescriptionOnly           = "error.tag.edit_description_only"
+	TagBlocked                       = "error.tag.blocked"
+	TagDeprecated                    = "error.tag.deprecated"
+	TagDeprecatedWithReplacement     = "error.tag.deprecated_with_replacement"
+	TagReplacementInvalid            = "error.tag.replacement_invalid"
+	TagBlocklistPatternInvalid       = "error.tag.blocklist_pattern_invalid"
+	AdminExportNotFound              = "error.admin_export.not_found"
+	AdminExportNotReady              = "error.admin_export.not_ready"
+	AdminExportInProgress            = "error.admin_export.in_progress"
+	SyndicationSignatureInvalid      = "error.syndication.signature_invalid"
+	SyndicationSourceNotAllowed      = "error.syndication.source_not_allowed"
+	SyndicationMirrorUserNotFound    = "error.syndication.mirror_user_not_found"
+	QuestionSyndicatedReadOnly       = "error.question.syndicated_read_only"
+	WebhookDeliveryNotFound          = "error.webhook.delivery_not_found"
+	JiraDisabled                     = "error.jira.disabled"
+	JiraProjectNotFound              = "error.jira.project_not_found"
+	JiraTemplateInvalid              = "error.jira.template_invalid"
+	JiraRequestFailed                = "error.jira.request_failed"
+	SpaceSlugDuplicate               = "error.space.slug_duplicate"
+	SpaceTagDuplicate                = "error.space.tag_duplicate"
+	SpaceWriteModeratorOnly          = "error.space.write_moderator_only"
+	SpaceWriteReputationRequired     = "error.space.write_reputation_required"
+	MessengerConnectorInvalid        = "error.messenger.connector_invalid"
+	MessengerConnectorNotFound       = "error.messenger.connector_not_found"
+	MessengerSendFailed              = "error.messenger.send_failed"
+	WebPushDisabled                  = "error.web_push.disabled"
+	WebPushEndpointInvalid           = "error.web_push.endpoint_invalid"
+	MobilePushPlatformUnsupported    = "error.mobile_push.platform_unsupported"
+	InboundEmailDisabled             = "error.inbound_email.disabled"
+	InboundEmailWebhookTokenInvalid  = "error.inbound_email.webhook_token_invalid"
+	InboundEmailSenderMismatch       = "error.inbound_email.sender_mismatch"
+	InboundEmailReplyEmpty           = "error.inbound_email.reply_empty"
+	EmailTemplateNotFound            = "error.email_template.not_found"
+	EmailTemplateInvalid             = "error.email_template.invalid"
+	UserMuteYourself                 = "error.user_mute.yourself"
+	SCIMDisabled                     = "error.scim.disabled"
+	SCIMFilterInvalid                = "error.scim.filter_invalid"
+	SCIMPatchInvalid                 = "error.scim.patch_invalid"
+	SCIMGroupNotFound                = "error.scim.group_not_found"
+	SCIMUserInvalid                  = "error.scim.user_invalid"
+	PersonalAccessTokenNotFound      = "error.personal_access_token.not_found"
+	PersonalAccessTokenLimit         = "error.personal_access_token.limit"
+	PersonalAccessTokenScopeDenied   = "error.personal_access_token.scope_denied"
+	PersonalAccessTokenForbidden     = "error.personal_access_token.forbidden"
+	OAuthProviderDisabled            = "error.oauth.provider_disabled"
+	OAuthClientNotFound              = "error.oauth.client_not_found"
+	OAuthRedirectURIInvalid          = "error.oauth.redirect_uri_invalid"
+	OAuthRequestInvalid              = "error.oauth.request_invalid"
+	OAuthSigningKeyInvalid           = "error.oauth.signing_key_invalid"
+	UserSessionNotFound              = "error.user_session.not_found"
+	LoginLocked                      = "error.login.locked"
+	InviteCodeRequired               = "error.invite_code.required"
+	InviteCodeInvalid                = "error.invite_code.invalid"
+	InviteCodeNotFound               = "error.invite_code.not_found"
+	InviteCodeLimit                  = "error.invite_code.limit"
+	InviteCodeForbidden              = "error.invite_code.forbidden"
+	RoleNotFound                     = "error.role.not_found"
+	RoleNameDuplicate                = "error.role.name_duplicate"
+	RoleCannotModify                 = "error.role.cannot_modify"
+	RoleCannotRemove                 = "error.role.cannot_remove"
+	RoleInUse                        = "error.role.in_use"
+	RolePowerNotFound                = "error.role.power_not_found"
+	IPBanned                         = "error.ip_ban.banned"
+	IPBanRangeInvalid                = "error.ip_ban.range_invalid"
+	IPBanAlreadyExist                = "error.ip_ban.already_exist"
+	IPBanNotFound                    = "error.ip_ban.not_found"
+)
+
+// chat intake messages
+const (
+	ChatIntakeHelp            = "chat_intake.help"
+	ChatIntakeQuestionCreated = "chat_intake.question_created"
+	ChatIntakeNoResult        = "chat_intake.no_result"
+)
+
+// syndication messages
+const (
+	SyndicationMirroredFrom = "syndication.mirrored_from"
+)
+
+// slack connector messages
+const (
+	SlackNewQuestion        = "slack.new_question"
+	SlackUnansweredReminder = "slack.unanswered_reminder"
+	SlackContentReported    = "slack.content_reported"
+)
+
+// messenger connector messages
+const (
+	MessengerNewQuestion    = "messenger.new_question"
+	MessengerAnswerAccepted = "messenger.answer_accepted"
+	MessengerTestMessage    = "messenger.test_message"
+)
+
+// jira connector messages
+const (
+	JiraStatusChanged = "jira.status_changed"
+	JiraIssueCreated  = "jira.issue_created"
+)
+
+// web push messages
+const (
+	WebPushNotificationTitle = "web_push.notification_title"
+)
+
+// question duplicate messages
+const (
+	QuestionDuplicateOf = "question.duplicate_of"
+)
+
+// content language messages
+const (
+	ContentLanguageNotAllowed = "content_language.not_allowed"
+	ContentLanguageTranslated = "content_language.translated"
+)
+
+// anti-spam messages
+const (
+	AntiSpamMarkedAsSpam = "anti_spam.marked_as_spam"
 )
 
 // user external login reasons
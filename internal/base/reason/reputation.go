@@ -0,0 +1,36 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package reason
+
+const (
+	ReputationUserActivatedLabel     = "reputation.user_activated_label"
+	ReputationQuestionVotedUpLabel   = "reputation.question_voted_up_label"
+	ReputationQuestionVotedDownLabel = "reputation.question_voted_down_label"
+	ReputationAnswerVotedUpLabel     = "reputation.answer_voted_up_label"
+	ReputationAnswerVotedDownLabel   = "reputation.answer_voted_down_label"
+	ReputationAnswerAcceptedLabel    = "reputation.answer_accepted_label"
+	ReputationAnswerAcceptLabel      = "reputation.answer_accept_label"
+	ReputationEditAcceptedLabel      = "reputation.edit_accepted_label"
+	ReputationQuestionVoteUpLabel    = "reputation.question_vote_up_label"
+	ReputationQuestionVoteDownLabel  = "reputation.question_vote_down_label"
+	ReputationAnswerVoteUpLabel      = "reputation.answer_vote_up_label"
+	ReputationAnswerVoteDownLabel    = "reputation.answer_vote_down_label"
+	ReputationCommentVoteUpLabel     = "reputation.comment_vote_up_label"
+)
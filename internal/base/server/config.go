@@ -22,6 +22,9 @@ package server
 // HTTP http config
 type HTTP struct {
 	Addr string `json:"addr" mapstructure:"addr"`
+	// the ips or cidr ranges of the reverse proxies, only their X-Forwarded-For header is used as the client ip,
+	// no proxy is trusted by default
+	TrustedProxies []string `json:"trusted_proxies" mapstructure:"trusted_proxies" yaml:"trusted_proxies,omitempty"`
 }
 
 // UI ui config
@@ -29,6 +29,7 @@ import (
 	"github.com/apache/answer/plugin"
 	"github.com/apache/answer/ui"
 	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/log"
 )
 
 // NewHTTPServer new http server.
@@ -40,9 +41,12 @@ func NewHTTPServer(debug bool,
 	authUserMiddleware *middleware.AuthUserMiddleware,
 	avatarMiddleware *middleware.AvatarMiddleware,
 	shortIDMiddleware *middleware.ShortIDMiddleware,
+	loadSheddingMiddleware *middleware.LoadSheddingMiddleware,
+	ipBanMiddleware *middleware.IPBanMiddleware,
 	templateRouter *router.TemplateRouter,
 	pluginAPIRouter *router.PluginAPIRouter,
 	uiConf *UI,
+	httpConf *HTTP,
 ) *gin.Engine {
 
 	if debug {
@@ -51,7 +55,13 @@ func NewHTTPServer(debug bool,
 		gin.SetMode(gin.ReleaseMode)
 	}
 	r := gin.New()
-	r.Use(brotli.Brotli(brotli.DefaultCompression), middleware.ExtractAndSetAcceptLanguage, shortIDMiddleware.SetShortIDFlag())
+	// the client ip is used by the ip bans and rate limits, so the forwarded header is only trusted from the proxies
+	if err := r.SetTrustedProxies(httpConf.TrustedProxies); err != nil {
+		log.Errorf("set trusted proxies failed: %v", err)
+		_ = r.SetTrustedProxies(nil)
+	}
+	r.Use(brotli.Brotli(brotli.DefaultCompression), middleware.ExtractAndSetAcceptLanguage, shortIDMiddleware.SetShortIDFlag(),
+		loadSheddingMiddleware.LoadShedding(), ipBanMiddleware.BanIP())
 	r.GET("/healthz", func(ctx *gin.Context) { ctx.String(200, "OK") })
 
 	html, _ := fs.Sub(ui.Template, "template")
@@ -85,10 +95,18 @@ func NewHTTPServer(debug bool,
 	authV1.Use(authUserMiddleware.MustAuthAndAccountAvailable())
 	answerRouter.RegisterAnswerAPIRouter(authV1)
 
+	// register the SCIM api for the identity provider, it is authenticated by the SCIM token instead of the user
+	scimV2 := r.Group(uiConf.APIBaseURL + "/answer/scim/v2")
+	answerRouter.RegisterSCIMRouter(scimV2)
+
 	adminauthV1 := r.Group(uiConf.APIBaseURL + "/answer/admin/api")
-	adminauthV1.Use(authUserMiddleware.AdminAuth())
+	adminauthV1.Use(authUserMiddleware.AdminAuth(), middleware.ExtractExpectedVersion)
 	answerRouter.RegisterAnswerAdminAPIRouter(adminauthV1)
 
+	// register the admin api that can be accessed by the role with the corresponding power
+	adminPowerV1 := r.Group(uiConf.APIBaseURL + "/answer/admin/api")
+	answerRouter.RegisterAnswerAdminPowerAPIRouter(authUserMiddleware, adminPowerV1)
+
 	templateRouter.RegisterTemplateRouter(rootGroup, uiConf.BaseURL)
 
 	// plugin routes
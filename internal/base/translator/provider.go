@@ -20,9 +20,11 @@
 package translator
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/google/wire"
 	myTran "github.com/segmentfault/pacman/contrib/i18n"
@@ -160,3 +162,39 @@ func TrWithData(lang i18n.Language, key string, templateData any) string {
 	}
 	return translation
 }
+
+// TrRaw get the raw template of the key without replacing the template data.
+// If this language translation is not available, return default english template.
+func TrRaw(lang i18n.Language, key string) string {
+	if GlobalTrans == nil {
+		return ""
+	}
+	if tmpl := trRaw(lang, key); len(tmpl) > 0 {
+		return tmpl
+	}
+	return trRaw(i18n.DefaultLanguage, key)
+}
+
+func trRaw(lang i18n.Language, key string) string {
+	content, err := GlobalTrans.Dump(lang)
+	if err != nil {
+		return ""
+	}
+	var node any
+	if err = json.Unmarshal(content, &node); err != nil {
+		return ""
+	}
+	for _, field := range strings.Split(key, ".") {
+		m, ok := node.(map[string]any)
+		if !ok {
+			return ""
+		}
+		node = m[field]
+	}
+	m, ok := node.(map[string]any)
+	if !ok {
+		return ""
+	}
+	tmpl, _ := m["other"].(string)
+	return tmpl
+}
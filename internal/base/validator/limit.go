@@ -0,0 +1,157 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package validator
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
+	myErrors "github.com/segmentfault/pacman/errors"
+)
+
+const (
+	// LimitTagTitle the field is a post title
+	LimitTagTitle = "title"
+	// LimitTagContent the field is a post body (question or answer)
+	LimitTagContent = "content"
+	// LimitTagComment the field is a comment body
+	LimitTagComment = "comment"
+	// LimitTagTags the field is the tag list of a question
+	LimitTagTags = "tags"
+)
+
+const (
+	DefaultMaxRequestBodySize = 10 * 1024 * 1024
+	DefaultMaxTitleLength     = 150
+	DefaultMaxContentLength   = 65535
+	DefaultMaxCommentLength   = 600
+	DefaultMaxTagsPerQuestion = 5
+	// SmallRequestBodySize the max body size of the routes which only take a few short fields, such as voting
+	SmallRequestBodySize = 64 * 1024
+)
+
+var imagePattern = regexp.MustCompile(`!\[[^\]]*\]\([^)]*\)|<img[\s>]`)
+
+// ContentLimits the limits which are applied to the request content. All the values can be tuned by admin.
+// A value less than or equal to zero means using the default value, except MaxImagesPerPost which means no limit.
+type ContentLimits struct {
+	MaxRequestBodySize int64
+	MaxTitleLength     int
+	MaxContentLength   int
+	MaxCommentLength   int
+	MaxTagsPerQuestion int
+	MaxImagesPerPost   int
+}
+
+var contentLimitsGetter func(ctx context.Context) *ContentLimits
+
+// RegisterContentLimitsGetter register the function which returns the current content limits
+func RegisterContentLimitsGetter(fn func(ctx context.Context) *ContentLimits) {
+	contentLimitsGetter = fn
+}
+
+// GetContentLimits get current content limits, fill the default value if not set
+func GetContentLimits(ctx context.Context) (limits *ContentLimits) {
+	if contentLimitsGetter != nil {
+		limits = contentLimitsGetter(ctx)
+	}
+	if limits == nil {
+		limits = &ContentLimits{}
+	}
+	if limits.MaxRequestBodySize <= 0 {
+		limits.MaxRequestBodySize = DefaultMaxRequestBodySize
+	}
+	if limits.MaxTitleLength <= 0 {
+		limits.MaxTitleLength = DefaultMaxTitleLength
+	}
+	if limits.MaxContentLength <= 0 {
+		limits.MaxContentLength = DefaultMaxContentLength
+	}
+	if limits.MaxCommentLength <= 0 {
+		limits.MaxCommentLength = DefaultMaxCommentLength
+	}
+	if limits.MaxTagsPerQuestion <= 0 {
+		limits.MaxTagsPerQuestion = DefaultMaxTagsPerQuestion
+	}
+	return limits
+}
+
+// CountImages count the images in the markdown or html content
+func CountImages(content string) int {
+	return len(imagePattern.FindAllStringIndex(content, -1))
+}
+
+// CheckLimits check the fields with `limit` tag by the content limits
+func (m *MyValidator) CheckLimits(value interface{}, limits *ContentLimits) (errFields []*FormErrorField, err error) {
+	v := reflect.Indirect(reflect.ValueOf(value))
+	if v.Kind() != reflect.Struct {
+		return nil, nil
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		limitTag := structField.Tag.Get("limit")
+		if len(limitTag) == 0 {
+			continue
+		}
+		field := v.Field(i)
+		fieldName := structField.Tag.Get("json")
+		if len(fieldName) == 0 {
+			fieldName = structField.Tag.Get("form")
+		}
+
+		switch limitTag {
+		case LimitTagTitle:
+			if utf8.RuneCountInString(field.String()) > limits.MaxTitleLength {
+				errFields = append(errFields, m.limitErrField(fieldName, reason.TitleTooLong, limits.MaxTitleLength))
+			}
+		case LimitTagContent:
+			if utf8.RuneCountInString(field.String()) > limits.MaxContentLength {
+				errFields = append(errFields, m.limitErrField(fieldName, reason.ContentTooLong, limits.MaxContentLength))
+			}
+			if limits.MaxImagesPerPost > 0 && CountImages(field.String()) > limits.MaxImagesPerPost {
+				errFields = append(errFields, m.limitErrField(fieldName, reason.TooManyImages, limits.MaxImagesPerPost))
+			}
+		case LimitTagComment:
+			if utf8.RuneCountInString(field.String()) > limits.MaxCommentLength {
+				errFields = append(errFields, m.limitErrField(fieldName, reason.CommentTooLong, limits.MaxCommentLength))
+			}
+		case LimitTagTags:
+			if field.Kind() == reflect.Slice && field.Len() > limits.MaxTagsPerQuestion {
+				errFields = append(errFields, m.limitErrField(fieldName, reason.TooManyTags, limits.MaxTagsPerQuestion))
+			}
+		}
+	}
+	if len(errFields) == 0 {
+		return nil, nil
+	}
+	return errFields, myErrors.BadRequest(reason.RequestFormatError).WithMsg(errFields[0].ErrorMsg)
+}
+
+func (m *MyValidator) limitErrField(fieldName, msgKey string, limit int) *FormErrorField {
+	return &FormErrorField{
+		ErrorField: fieldName,
+		ErrorMsg:   translator.TrWithData(m.Lang, msgKey, map[string]any{"Limit": limit}),
+	}
+}
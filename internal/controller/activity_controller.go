@@ -66,6 +66,27 @@ func (ac *ActivityController) GetObjectTimeline(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, resp)
 }
 
+// GetQuestionTimeline get question timeline
+// @Summary get question timeline
+// @Description get the timeline of question and its answers, including the revisions, close and status changes
+// @Tags Activity
+// @Produce json
+// @Param question_id query string true "question id"
+// @Success 200 {object} handler.RespBody{data=schema.GetQuestionTimelineResp}
+// @Router /answer/api/v1/question/timeline [get]
+func (ac *ActivityController) GetQuestionTimeline(ctx *gin.Context) {
+	req := &schema.GetQuestionTimelineReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.QuestionID = uid.DeShortID(req.QuestionID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.IsAdminModerator = middleware.GetUserIsAdminModerator(ctx)
+
+	resp, err := ac.activityService.GetQuestionTimeline(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // GetObjectTimelineDetail get object timeline detail
 // @Summary get object timeline detail
 // @Description get object timeline detail
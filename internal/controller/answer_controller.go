@@ -66,6 +66,29 @@ func NewAnswerController(
 	}
 }
 
+// UpdateAnswerProvenance update answer provenance
+// @Summary update answer provenance
+// @Description update the source url, import batch and AI-assisted flag of answer, the import batch is only updated by admins
+// @Tags Answer
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UpdateAnswerProvenanceReq true "provenance"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/answer/provenance [put]
+func (ac *AnswerController) UpdateAnswerProvenance(ctx *gin.Context) {
+	req := &schema.UpdateAnswerProvenanceReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.ID = uid.DeShortID(req.ID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.IsAdmin = middleware.GetUserIsAdminModerator(ctx)
+
+	err := ac.answerService.UpdateAnswerProvenance(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
 // RemoveAnswer delete answer
 // @Summary delete answer
 // @Description delete answer
@@ -195,6 +218,9 @@ func (ac *AnswerController) AddAnswer(ctx *gin.Context) {
 	if handler.BindAndCheck(ctx, req) {
 		return
 	}
+	if ac.rateLimitMiddleware.ActionRateLimit(ctx, schema.RateLimitActionAnswer) {
+		return
+	}
 	reject, rejectKey := ac.rateLimitMiddleware.DuplicateRequestRejection(ctx, req)
 	if reject {
 		return
@@ -220,6 +246,9 @@ func (ac *AnswerController) AddAnswer(ctx *gin.Context) {
 
 	linkUrlLimitUser := canList[2]
 	isAdmin := middleware.GetUserIsAdminModerator(ctx)
+	if req.Provenance != nil && !isAdmin {
+		req.Provenance.ImportBatch = ""
+	}
 	if !isAdmin || !linkUrlLimitUser {
 		captchaPass := ac.actionService.ActionRecordVerifyCaptcha(ctx, entity.CaptchaActionAnswer, req.UserID, req.CaptchaID, req.CaptchaCode)
 		if !captchaPass {
@@ -309,7 +338,7 @@ func (ac *AnswerController) UpdateAnswer(ctx *gin.Context) {
 	}
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
 
-	canList, err := ac.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
+	canList, _, err := ac.rankService.CheckObjectOperationPermissions(ctx, req.UserID, req.ID, []string{
 		permission.AnswerEdit,
 		permission.AnswerEditWithoutReview,
 		permission.LinkUrlLimit,
@@ -378,7 +407,7 @@ func (ac *AnswerController) AnswerList(ctx *gin.Context) {
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
 	req.QuestionID = uid.DeShortID(req.QuestionID)
 
-	canList, err := ac.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
+	canList, _, err := ac.rankService.CheckObjectOperationPermissions(ctx, req.UserID, req.QuestionID, []string{
 		permission.AnswerEdit,
 		permission.AnswerDelete,
 		permission.AnswerUnDelete,
@@ -411,6 +440,7 @@ func (ac *AnswerController) AnswerList(ctx *gin.Context) {
 // @Security ApiKeyAuth
 // @Param data body schema.AcceptAnswerReq true "AcceptAnswerReq"
 // @Success 200 {object} handler.RespBody{}
+// @Failure 400 {object} handler.RespBody{data=schema.AcceptAnswerResp}
 // @Router /answer/api/v1/answer/acceptance [post]
 func (ac *AnswerController) AcceptAnswer(ctx *gin.Context) {
 	req := &schema.AcceptAnswerReq{}
@@ -431,7 +461,41 @@ func (ac *AnswerController) AcceptAnswer(ctx *gin.Context) {
 		return
 	}
 
-	err = ac.answerService.AcceptAnswer(ctx, req)
+	resp, err := ac.answerService.AcceptAnswer(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// PinAnswer pin answer
+// @Summary pin the answer to the top of question
+// @Description pin the answer to the top of question by the question author or moderators, the answer id is empty to unpin
+// @Tags Answer
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.PinAnswerReq true "PinAnswerReq"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/api/v1/answer/pin [put]
+func (ac *AnswerController) PinAnswer(ctx *gin.Context) {
+	req := &schema.PinAnswerReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.AnswerID = uid.DeShortID(req.AnswerID)
+	req.QuestionID = uid.DeShortID(req.QuestionID)
+	// the same as accepting answer, the question author and moderators can pin the answer
+	can, err := ac.rankService.CheckOperationPermission(ctx, req.UserID, permission.AnswerAccept, req.QuestionID)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if !can {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+		return
+	}
+
+	err = ac.answerService.PinAnswer(ctx, req)
 	handler.HandleResponse(ctx, err, nil)
 }
 
@@ -456,3 +520,24 @@ func (ac *AnswerController) AdminUpdateAnswerStatus(ctx *gin.Context) {
 	err := ac.answerService.AdminSetAnswerStatus(ctx, req)
 	handler.HandleResponse(ctx, err, nil)
 }
+
+// AdminPreviewAnswerRanking preview the answer order with the ranking weights
+// @Summary preview the answer order with the ranking weights
+// @Description preview the answer order of question with the ranking weights, the weights are not saved
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AdminPreviewAnswerRankingReq true "AdminPreviewAnswerRankingReq"
+// @Success 200 {object} handler.RespBody{data=[]schema.AdminPreviewAnswerRankingResp}
+// @Router /answer/admin/api/answer/ranking/preview [post]
+func (ac *AnswerController) AdminPreviewAnswerRanking(ctx *gin.Context) {
+	req := &schema.AdminPreviewAnswerRankingReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.QuestionID = uid.DeShortID(req.QuestionID)
+
+	resp, err := ac.answerService.AdminPreviewAnswerRanking(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
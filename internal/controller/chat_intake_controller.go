@@ -0,0 +1,129 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/chat_intake"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// ChatIntakeController chat intake controller
+type ChatIntakeController struct {
+	chatIntakeService *chat_intake.ChatIntakeService
+}
+
+// NewChatIntakeController new controller
+func NewChatIntakeController(chatIntakeService *chat_intake.ChatIntakeService) *ChatIntakeController {
+	return &ChatIntakeController{chatIntakeService: chatIntakeService}
+}
+
+// SlackCommand handle slack slash command
+// @Summary handle slack slash command
+// @Description create or search questions by slack slash command, the request is signed by slack signing secret
+// @Tags ChatIntake
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Success 200 {object} schema.ChatCommandResp
+// @Router /answer/api/v1/chat/slack/command [post]
+func (cc *ChatIntakeController) SlackCommand(ctx *gin.Context) {
+	if !cc.verifySlackRequest(ctx) {
+		return
+	}
+	req := &schema.ChatCommandReq{}
+	if err := ctx.ShouldBind(req); err != nil {
+		handler.HandleResponse(ctx, errors.BadRequest(reason.RequestFormatError), nil)
+		return
+	}
+	req.Platform = schema.ChatPlatformSlack
+	ctx.JSON(http.StatusOK, cc.chatIntakeService.HandleCommand(ctx, req))
+}
+
+// SlackAction handle slack message action
+// @Summary handle slack message action
+// @Description create question from slack message, the result is posted to the response url of action
+// @Tags ChatIntake
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Success 200
+// @Router /answer/api/v1/chat/slack/action [post]
+func (cc *ChatIntakeController) SlackAction(ctx *gin.Context) {
+	if !cc.verifySlackRequest(ctx) {
+		return
+	}
+	payload := &schema.SlackActionPayload{}
+	if err := json.Unmarshal([]byte(ctx.PostForm("payload")), payload); err != nil {
+		handler.HandleResponse(ctx, errors.BadRequest(reason.RequestFormatError), nil)
+		return
+	}
+	if payload.Type != "message_action" {
+		ctx.Status(http.StatusOK)
+		return
+	}
+	cc.chatIntakeService.HandleSlackAction(ctx, payload)
+	ctx.Status(http.StatusOK)
+}
+
+// MattermostCommand handle mattermost slash command
+// @Summary handle mattermost slash command
+// @Description create or search questions by mattermost slash command, the request is verified by the command token
+// @Tags ChatIntake
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Success 200 {object} schema.ChatCommandResp
+// @Router /answer/api/v1/chat/mattermost/command [post]
+func (cc *ChatIntakeController) MattermostCommand(ctx *gin.Context) {
+	req := &schema.ChatCommandReq{}
+	if err := ctx.ShouldBind(req); err != nil {
+		handler.HandleResponse(ctx, errors.BadRequest(reason.RequestFormatError), nil)
+		return
+	}
+	if err := cc.chatIntakeService.VerifyMattermostRequest(ctx, req.Token); err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	req.Platform = schema.ChatPlatformMattermost
+	ctx.JSON(http.StatusOK, cc.chatIntakeService.HandleCommand(ctx, req))
+}
+
+// verifySlackRequest verify the signature with raw body, and then restore the body for binding
+func (cc *ChatIntakeController) verifySlackRequest(ctx *gin.Context) bool {
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		handler.HandleResponse(ctx, errors.BadRequest(reason.RequestFormatError), nil)
+		return false
+	}
+	ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+	err = cc.chatIntakeService.VerifySlackRequest(ctx,
+		ctx.GetHeader(schema.SlackTimestampHeader), ctx.GetHeader(schema.SlackSignatureHeader), body)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return false
+	}
+	return true
+}
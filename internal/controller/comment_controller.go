@@ -75,6 +75,9 @@ func (cc *CommentController) AddComment(ctx *gin.Context) {
 	if handler.BindAndCheck(ctx, req) {
 		return
 	}
+	if cc.rateLimitMiddleware.ActionRateLimit(ctx, schema.RateLimitActionComment) {
+		return
+	}
 	reject, rejectKey := cc.rateLimitMiddleware.DuplicateRequestRejection(ctx, req)
 	if reject {
 		return
@@ -119,6 +122,8 @@ func (cc *CommentController) AddComment(ctx *gin.Context) {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
 		return
 	}
+	req.UserAgent = ctx.GetHeader("User-Agent")
+	req.IP = ctx.ClientIP()
 
 	resp, err := cc.commentService.AddComment(ctx, req)
 	if !isAdmin || !linkUrlLimitUser {
@@ -173,6 +178,27 @@ func (cc *CommentController) RemoveComment(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, nil)
 }
 
+// AdminBulkRemoveComment remove the comments matched the filter
+// @Summary remove the comments matched the filter
+// @Description remove the comments by user, date range or keyword in one operation, use dry run to preview the count
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AdminBulkRemoveCommentReq true "filter"
+// @Success 200 {object} handler.RespBody{data=schema.AdminBulkRemoveCommentResp}
+// @Router /answer/admin/api/comments [delete]
+func (cc *CommentController) AdminBulkRemoveComment(ctx *gin.Context) {
+	req := &schema.AdminBulkRemoveCommentReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := cc.commentService.AdminBulkRemoveComment(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // UpdateComment update comment
 // @Summary update comment
 // @Description update comment
@@ -275,6 +301,7 @@ func (cc *CommentController) GetCommentPersonalWithPage(ctx *gin.Context) {
 	}
 
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.LoginUserID = req.UserID
 
 	resp, err := cc.commentService.GetCommentPersonalWithPage(ctx, req)
 	handler.HandleResponse(ctx, err, resp)
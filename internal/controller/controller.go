@@ -53,4 +53,25 @@ var ProviderSetController = wire.NewSet(
 	NewEmbedController,
 	NewBadgeController,
 	NewRenderController,
+	NewQuestionCloseVoteController,
+	NewReportAppealController,
+	NewDataDumpController,
+	NewChatIntakeController,
+	NewLeaderboardController,
+	NewSyndicationController,
+	NewHelpfulnessSurveyController,
+	NewQuestionQualityController,
+	NewTagModeratorController,
+	NewTagAnalyticsController,
+	NewSavedSearchController,
+	NewJiraController,
+	NewWebPushController,
+	NewMobilePushController,
+	NewInboundEmailController,
+	NewUserMuteController,
+	NewSCIMController,
+	NewPersonalAccessTokenController,
+	NewOAuthController,
+	NewUserSessionController,
+	NewInviteCodeController,
 )
@@ -0,0 +1,77 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/service/data_dump"
+	"github.com/gin-gonic/gin"
+)
+
+// DataDumpController public data dump controller
+type DataDumpController struct {
+	dataDumpService *data_dump.DataDumpService
+}
+
+// NewDataDumpController new controller
+func NewDataDumpController(dataDumpService *data_dump.DataDumpService) *DataDumpController {
+	return &DataDumpController{dataDumpService: dataDumpService}
+}
+
+// GetDataDumpInfo get the latest public data dump info
+// @Summary get the latest public data dump info
+// @Description get the latest public data dump info, including the license of data
+// @Tags DataDump
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.GetDataDumpInfoResp}
+// @Router /answer/api/v1/data-dump [get]
+func (dc *DataDumpController) GetDataDumpInfo(ctx *gin.Context) {
+	resp, err := dc.dataDumpService.GetDataDumpInfo(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// DownloadDataDump download the latest public data dump
+// @Summary download the latest public data dump
+// @Description download the latest public data dump as a zip file of JSONL files
+// @Tags DataDump
+// @Produce application/zip
+// @Success 200 {file} file
+// @Router /answer/api/v1/data-dump/download [get]
+func (dc *DataDumpController) DownloadDataDump(ctx *gin.Context) {
+	filePath, fileName, err := dc.dataDumpService.GetLatestDumpFile(ctx)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	ctx.FileAttachment(filePath, fileName)
+}
+
+// TriggerDataDump generate a new public data dump
+// @Summary generate a new public data dump
+// @Description generate a new public data dump in background, the previous one will be replaced
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/data-dump [post]
+func (dc *DataDumpController) TriggerDataDump(ctx *gin.Context) {
+	err := dc.dataDumpService.TriggerDataDump(ctx)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,113 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/helpfulness_survey"
+	"github.com/gin-gonic/gin"
+)
+
+// HelpfulnessSurveyController helpfulness survey controller
+type HelpfulnessSurveyController struct {
+	helpfulnessSurveyService *helpfulness_survey.HelpfulnessSurveyService
+}
+
+// NewHelpfulnessSurveyController new controller
+func NewHelpfulnessSurveyController(
+	helpfulnessSurveyService *helpfulness_survey.HelpfulnessSurveyService) *HelpfulnessSurveyController {
+	return &HelpfulnessSurveyController{helpfulnessSurveyService: helpfulnessSurveyService}
+}
+
+// RecordView record the view of question from search
+// @Summary record the view of question from search
+// @Description if the question has accepted answer and the referrer is a search engine or the search page,
+// @Description the user is asked whether the answer solved the problem after the delay days
+// @Security ApiKeyAuth
+// @Tags HelpfulnessSurvey
+// @Accept json
+// @Produce json
+// @Param data body schema.RecordHelpfulnessViewReq true "view"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/helpfulness-survey/view [post]
+func (hc *HelpfulnessSurveyController) RecordView(ctx *gin.Context) {
+	req := &schema.RecordHelpfulnessViewReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := hc.helpfulnessSurveyService.RecordView(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetPendingSurveys get the due surveys of user
+// @Summary get the due surveys of user
+// @Description get the due surveys of user to prompt
+// @Security ApiKeyAuth
+// @Tags HelpfulnessSurvey
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]schema.HelpfulnessSurveyItem}
+// @Router /answer/api/v1/helpfulness-survey/pending [get]
+func (hc *HelpfulnessSurveyController) GetPendingSurveys(ctx *gin.Context) {
+	req := &schema.GetPendingHelpfulnessSurveysReq{}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := hc.helpfulnessSurveyService.GetPendingSurveys(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RespondSurvey submit the survey result
+// @Summary submit the survey result
+// @Description submit whether the answer solved the problem by the survey token, login is not required
+// @Tags HelpfulnessSurvey
+// @Accept json
+// @Produce json
+// @Param data body schema.RespondHelpfulnessSurveyReq true "result"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/helpfulness-survey/respond [post]
+func (hc *HelpfulnessSurveyController) RespondSurvey(ctx *gin.Context) {
+	req := &schema.RespondHelpfulnessSurveyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := hc.helpfulnessSurveyService.RespondSurvey(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetAnswerHelpfulness get the survey results of answer
+// @Summary get the survey results of answer
+// @Description get the solved rate of answer, only the author, admin or moderator can see it
+// @Security ApiKeyAuth
+// @Tags Answer
+// @Produce json
+// @Param answer_id query string true "answer id"
+// @Success 200 {object} handler.RespBody{data=schema.AnswerHelpfulnessResp}
+// @Router /answer/api/v1/answer/helpfulness [get]
+func (hc *HelpfulnessSurveyController) GetAnswerHelpfulness(ctx *gin.Context) {
+	req := &schema.GetAnswerHelpfulnessReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.IsAdminModerator = middleware.GetUserIsAdminModerator(ctx)
+	resp, err := hc.helpfulnessSurveyService.GetAnswerHelpfulness(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/inbound_email"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// InboundEmailController inbound email controller
+type InboundEmailController struct {
+	inboundEmailService *inbound_email.InboundEmailService
+}
+
+// NewInboundEmailController new controller
+func NewInboundEmailController(inboundEmailService *inbound_email.InboundEmailService) *InboundEmailController {
+	return &InboundEmailController{inboundEmailService: inboundEmailService}
+}
+
+// ReceiveWebhook receive the reply email
+// @Summary receive the reply email
+// @Description receive the raw reply email pushed by the inbound parse service of the mail provider,
+// @Description the body is the raw email, or the multipart form with the raw email in the email or body-mime field
+// @Tags InboundEmail
+// @Accept plain
+// @Produce json
+// @Param token query string true "the webhook token of the site inbound email settings"
+// @Success 200 {object} handler.RespBody{data=schema.InboundEmailResp}
+// @Router /answer/api/v1/inbound-email/webhook [post]
+func (ic *InboundEmailController) ReceiveWebhook(ctx *gin.Context) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, schema.InboundEmailMaxSize+1<<20)
+	var raw []byte
+	if strings.HasPrefix(ctx.ContentType(), "multipart/form-data") {
+		if err := ctx.Request.ParseMultipartForm(schema.InboundEmailMaxSize); err != nil {
+			handler.HandleResponse(ctx, errors.BadRequest(reason.RequestFormatError), nil)
+			return
+		}
+		for _, field := range []string{"email", "body-mime"} {
+			if value := ctx.Request.PostFormValue(field); len(value) > 0 {
+				raw = []byte(value)
+				break
+			}
+		}
+	} else {
+		body, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			handler.HandleResponse(ctx, errors.BadRequest(reason.RequestFormatError), nil)
+			return
+		}
+		raw = body
+	}
+	resp, err := ic.inboundEmailService.ReceiveWebhook(ctx, ctx.Query("token"), raw)
+	handler.HandleResponse(ctx, err, resp)
+}
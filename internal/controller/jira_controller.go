@@ -0,0 +1,86 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/jira"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// JiraController jira connector controller
+type JiraController struct {
+	jiraService *jira.JiraService
+}
+
+// NewJiraController new controller
+func NewJiraController(jiraService *jira.JiraService) *JiraController {
+	return &JiraController{jiraService: jiraService}
+}
+
+// CreateIssue create jira issue from the question
+// @Summary create jira issue from the question
+// @Description create jira issue from the question in the project matching its tags, only for moderators
+// @Tags Jira
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.CreateJiraIssueReq true "question"
+// @Success 200 {object} handler.RespBody{data=schema.JiraIssueResp}
+// @Router /answer/api/v1/jira/issue [post]
+func (jc *JiraController) CreateIssue(ctx *gin.Context) {
+	req := &schema.CreateJiraIssueReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	if !middleware.GetUserIsAdminModerator(ctx) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := jc.jiraService.CreateIssue(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetQuestionModeratorNotes get the private moderator notes of the question
+// @Summary get the private moderator notes of the question
+// @Description get the linked jira issue and the private moderator notes of the question, only for moderators
+// @Tags Jira
+// @Produce json
+// @Security ApiKeyAuth
+// @Param question_id query string true "question id"
+// @Success 200 {object} handler.RespBody{data=schema.GetQuestionModeratorNotesResp}
+// @Router /answer/api/v1/question/moderator/notes [get]
+func (jc *JiraController) GetQuestionModeratorNotes(ctx *gin.Context) {
+	req := &schema.GetQuestionModeratorNotesReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	if !middleware.GetUserIsAdminModerator(ctx) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+		return
+	}
+	resp, err := jc.jiraService.GetQuestionModeratorNotes(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -0,0 +1,58 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/leaderboard"
+	"github.com/gin-gonic/gin"
+)
+
+// LeaderboardController leaderboard controller
+type LeaderboardController struct {
+	leaderboardService *leaderboard.LeaderboardService
+}
+
+// NewLeaderboardController new controller
+func NewLeaderboardController(leaderboardService *leaderboard.LeaderboardService) *LeaderboardController {
+	return &LeaderboardController{leaderboardService: leaderboardService}
+}
+
+// GetLeaderboard get the top users by reputation earned in the period
+// @Summary get the top users by reputation earned in the period
+// @Description get the top users by reputation earned this week, month or quarter, on the whole site or in the tag.
+// @Description The leaderboard is refreshed hourly.
+// @Tags User
+// @Produce json
+// @Param period query string true "period" Enums(week, month, quarter)
+// @Param tag query string false "tag slug name"
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=schema.GetLeaderboardResp}
+// @Router /answer/api/v1/user/leaderboard [get]
+func (lc *LeaderboardController) GetLeaderboard(ctx *gin.Context) {
+	req := &schema.GetLeaderboardReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := lc.leaderboardService.GetLeaderboard(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -0,0 +1,103 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/mobile_push"
+	"github.com/gin-gonic/gin"
+)
+
+// MobilePushController mobile push controller
+type MobilePushController struct {
+	mobilePushService *mobile_push.MobilePushService
+}
+
+// NewMobilePushController new controller
+func NewMobilePushController(mobilePushService *mobile_push.MobilePushService) *MobilePushController {
+	return &MobilePushController{mobilePushService: mobilePushService}
+}
+
+// GetPlatforms get the supported platforms
+// @Summary get the supported platforms
+// @Description get the platforms of the device tokens supported by the enabled push provider plugins
+// @Tags MobilePush
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.GetMobilePushPlatformsResp}
+// @Router /answer/api/v1/mobile-push/platforms [get]
+func (mc *MobilePushController) GetPlatforms(ctx *gin.Context) {
+	resp, err := mc.mobilePushService.GetPlatforms(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RegisterDevice register the token of the current device
+// @Summary register the token of the current device
+// @Description save the push token of the mobile app, the user is opted in push notifications on the first device
+// @Tags MobilePush
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.MobilePushDeviceRegisterReq true "device"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/api/v1/mobile-push/device [post]
+func (mc *MobilePushController) RegisterDevice(ctx *gin.Context) {
+	req := &schema.MobilePushDeviceRegisterReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := mc.mobilePushService.RegisterDevice(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RemoveDevice remove the token of the device
+// @Summary remove the token of the device
+// @Description remove the push token of the mobile app
+// @Tags MobilePush
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.MobilePushDeviceRemoveReq true "device"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/api/v1/mobile-push/device [delete]
+func (mc *MobilePushController) RemoveDevice(ctx *gin.Context) {
+	req := &schema.MobilePushDeviceRemoveReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := mc.mobilePushService.RemoveDevice(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetDevices get the registered devices
+// @Summary get the registered devices
+// @Description get the registered mobile devices of the current user
+// @Tags MobilePush
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=[]schema.MobilePushDeviceResp}
+// @Router /answer/api/v1/mobile-push/devices [get]
+func (mc *MobilePushController) GetDevices(ctx *gin.Context) {
+	resp, err := mc.mobilePushService.GetDevices(ctx, middleware.GetLoginUserIDFromContext(ctx))
+	handler.HandleResponse(ctx, err, resp)
+}
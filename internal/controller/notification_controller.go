@@ -124,11 +124,44 @@ func (nc *NotificationController) ClearUnRead(ctx *gin.Context) {
 	if handler.BindAndCheck(ctx, req) {
 		return
 	}
-	userID := middleware.GetLoginUserIDFromContext(ctx)
-	err := nc.notificationService.ClearUnRead(ctx, userID, req.NotificationType)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := nc.notificationService.ClearUnRead(ctx, req)
 	handler.HandleResponse(ctx, err, gin.H{})
 }
 
+// GetUnreadCount get unread notification count
+// @Summary get unread notification count
+// @Description get the unread notification count of each category
+// @Tags Notification
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.NotificationUnreadCountResp}
+// @Router /answer/api/v1/notification/unread/count [get]
+func (nc *NotificationController) GetUnreadCount(ctx *gin.Context) {
+	resp, err := nc.notificationService.GetUnreadCount(ctx, middleware.GetLoginUserIDFromContext(ctx))
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// DeleteNotifications delete notifications by age
+// @Summary delete notifications by age
+// @Description delete the notifications older than the days, the unread notifications are kept by default
+// @Tags Notification
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.NotificationDeleteRequest true "NotificationDeleteRequest"
+// @Success 200 {object} handler.RespBody{data=schema.NotificationDeleteResp}
+// @Router /answer/api/v1/notification [delete]
+func (nc *NotificationController) DeleteNotifications(ctx *gin.Context) {
+	req := &schema.NotificationDeleteRequest{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := nc.notificationService.DeleteNotifications(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // ClearIDUnRead
 // @Summary ClearUnRead
 // @Description ClearUnRead
@@ -160,6 +193,11 @@ func (nc *NotificationController) ClearIDUnRead(ctx *gin.Context) {
 // @Param page_size query int false "page size"
 // @Param type query string true "type" Enums(inbox,achievement)
 // @Param inbox_type query string true "inbox_type" Enums(all,posts,invites,votes)
+// @Param action query string false "notification action"
+// @Param read_state query string false "read_state" Enums(read,unread)
+// @Param start_time query int false "updated after this unix timestamp"
+// @Param end_time query int false "updated before this unix timestamp"
+// @Param q query string false "search the title"
 // @Success 200 {object} handler.RespBody
 // @Router /answer/api/v1/notification/page [get]
 func (nc *NotificationController) GetList(ctx *gin.Context) {
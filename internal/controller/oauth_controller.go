@@ -0,0 +1,212 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/oauth"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/log"
+)
+
+// OAuthController OAuth controller, the site is the OpenID provider of the registered clients
+type OAuthController struct {
+	oauthService *oauth.OAuthService
+}
+
+// NewOAuthController new controller
+func NewOAuthController(oauthService *oauth.OAuthService) *OAuthController {
+	return &OAuthController{oauthService: oauthService}
+}
+
+// GetDiscovery get the OpenID provider metadata
+// @Summary get the OpenID provider metadata
+// @Description get the OpenID provider metadata for the clients to discover the endpoints
+// @Tags OAuth
+// @Produce json
+// @Success 200 {object} map[string]any
+// @Router /answer/api/v1/oauth/.well-known/openid-configuration [get]
+func (oc *OAuthController) GetDiscovery(ctx *gin.Context) {
+	resp, err := oc.oauthService.GetDiscovery(ctx)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetJWKS get the JSON web key set
+// @Summary get the JSON web key set
+// @Description get the JSON web key set verifying the signature of the id tokens
+// @Tags OAuth
+// @Produce json
+// @Success 200 {object} map[string]any
+// @Router /answer/api/v1/oauth/jwks [get]
+func (oc *OAuthController) GetJWKS(ctx *gin.Context) {
+	resp, err := oc.oauthService.GetJWKS(ctx)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// AuthorizePage redirect the authorization request to the page the user approves it in
+// @Summary redirect the authorization request to the page the user approves it in
+// @Description redirect the authorization request of the client to the page of site, the user logs in and approves it
+// @Tags OAuth
+// @Param response_type query string true "code"
+// @Param client_id query string true "client id"
+// @Param redirect_uri query string true "redirect uri"
+// @Param scope query string false "scope"
+// @Param state query string false "state"
+// @Param nonce query string false "nonce"
+// @Param code_challenge query string false "PKCE code challenge"
+// @Param code_challenge_method query string false "plain or S256"
+// @Success 302
+// @Router /answer/api/v1/oauth/authorize [get]
+func (oc *OAuthController) AuthorizePage(ctx *gin.Context) {
+	pageURL, err := oc.oauthService.GetAuthorizePageURL(ctx, ctx.Request.URL.RawQuery)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	ctx.Redirect(http.StatusFound, pageURL)
+}
+
+// GetAuthorizeInfo get the authorization request info
+// @Summary get the authorization request info
+// @Description get the client name and the scopes of the authorization request shown to the login user to approve
+// @Security ApiKeyAuth
+// @Tags OAuth
+// @Produce json
+// @Param response_type query string true "code"
+// @Param client_id query string true "client id"
+// @Param redirect_uri query string true "redirect uri"
+// @Param scope query string false "scope"
+// @Success 200 {object} handler.RespBody{data=schema.OAuthAuthorizeInfoResp}
+// @Router /answer/api/v1/oauth/authorize/info [get]
+func (oc *OAuthController) GetAuthorizeInfo(ctx *gin.Context) {
+	req := &schema.OAuthAuthorizeReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := oc.oauthService.GetAuthorizeInfo(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// Authorize approve or deny the authorization request
+// @Summary approve or deny the authorization request
+// @Description approve or deny the authorization request, the user should be redirected to the returned url
+// @Security ApiKeyAuth
+// @Tags OAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.OAuthAuthorizeReq true "authorization request"
+// @Success 200 {object} handler.RespBody{data=schema.OAuthAuthorizeResp}
+// @Router /answer/api/v1/oauth/authorize [post]
+func (oc *OAuthController) Authorize(ctx *gin.Context) {
+	req := &schema.OAuthAuthorizeReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := oc.oauthService.Authorize(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// Token exchange the authorization code for the tokens
+// @Summary exchange the authorization code for the tokens
+// @Description exchange the authorization code for the access token and id token,
+// @Description the client authenticates with the basic auth or the client_id and client_secret of form
+// @Tags OAuth
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param grant_type formData string true "authorization_code"
+// @Param code formData string true "authorization code"
+// @Param redirect_uri formData string true "redirect uri"
+// @Param client_id formData string false "client id"
+// @Param client_secret formData string false "client secret"
+// @Param code_verifier formData string false "PKCE code verifier"
+// @Success 200 {object} schema.OAuthTokenResp
+// @Failure 400 {object} schema.OAuthError
+// @Router /answer/api/v1/oauth/token [post]
+func (oc *OAuthController) Token(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Header("Pragma", "no-cache")
+	req := &schema.OAuthTokenReq{}
+	if err := ctx.ShouldBind(req); err != nil {
+		oc.handleOAuthError(ctx, schema.NewOAuthError(schema.OAuthErrorInvalidRequest, "the request is malformed"))
+		return
+	}
+	if clientID, clientSecret, ok := ctx.Request.BasicAuth(); ok {
+		req.ClientID, _ = url.QueryUnescape(clientID)
+		req.ClientSecret, _ = url.QueryUnescape(clientSecret)
+	}
+	resp, err := oc.oauthService.ExchangeToken(ctx, req)
+	if err != nil {
+		oc.handleOAuthError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetUserInfo get the claims of the user authorized the access token
+// @Summary get the claims of the user authorized the access token
+// @Description get the claims of the user authorized the access token, the claims depend on the scopes
+// @Tags OAuth
+// @Produce json
+// @Param Authorization header string true "Bearer access token"
+// @Success 200 {object} schema.OAuthUserInfo
+// @Failure 401 {object} schema.OAuthError
+// @Router /answer/api/v1/oauth/userinfo [get]
+func (oc *OAuthController) GetUserInfo(ctx *gin.Context) {
+	accessToken := ctx.GetHeader("Authorization")
+	if !strings.HasPrefix(accessToken, "Bearer ") {
+		oc.handleOAuthError(ctx, schema.NewOAuthError(schema.OAuthErrorInvalidToken, "the access token is required"))
+		return
+	}
+	resp, err := oc.oauthService.GetUserInfo(ctx, strings.TrimSpace(strings.TrimPrefix(accessToken, "Bearer ")))
+	if err != nil {
+		oc.handleOAuthError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// handleOAuthError the token and userinfo endpoint respond the errors defined by OAuth instead of the RespBody
+func (oc *OAuthController) handleOAuthError(ctx *gin.Context, err error) {
+	oauthErr := &schema.OAuthError{}
+	if !errors.As(err, &oauthErr) {
+		log.Error(err)
+		oauthErr = schema.NewOAuthError(schema.OAuthErrorServerError, "")
+	}
+	if oauthErr.Code == schema.OAuthErrorInvalidToken {
+		ctx.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+	}
+	ctx.JSON(oauthErr.Status, oauthErr)
+}
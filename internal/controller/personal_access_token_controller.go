@@ -0,0 +1,109 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/personal_access_token"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// PersonalAccessTokenController personal access token controller
+type PersonalAccessTokenController struct {
+	personalAccessTokenService *personal_access_token.PersonalAccessTokenService
+}
+
+// NewPersonalAccessTokenController new controller
+func NewPersonalAccessTokenController(
+	personalAccessTokenService *personal_access_token.PersonalAccessTokenService) *PersonalAccessTokenController {
+	return &PersonalAccessTokenController{personalAccessTokenService: personalAccessTokenService}
+}
+
+// GetPersonalAccessTokens get personal access tokens
+// @Summary get personal access tokens
+// @Description get the personal access tokens of the login user, the tokens themselves are not returned
+// @Security ApiKeyAuth
+// @Tags User
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]schema.PersonalAccessTokenItem}
+// @Router /answer/api/v1/user/personal-access-tokens [get]
+func (pc *PersonalAccessTokenController) GetPersonalAccessTokens(ctx *gin.Context) {
+	resp, err := pc.personalAccessTokenService.GetPersonalAccessTokens(ctx, middleware.GetLoginUserIDFromContext(ctx))
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddPersonalAccessToken add personal access token
+// @Summary add personal access token
+// @Description add personal access token with the scopes, the token is only shown once.
+// @Description The token is accepted as the Authorization header like the access token of login.
+// @Security ApiKeyAuth
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param data body schema.AddPersonalAccessTokenReq true "personal access token"
+// @Success 200 {object} handler.RespBody{data=schema.AddPersonalAccessTokenResp}
+// @Router /answer/api/v1/user/personal-access-token [post]
+func (pc *PersonalAccessTokenController) AddPersonalAccessToken(ctx *gin.Context) {
+	req := &schema.AddPersonalAccessTokenReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	userInfo := middleware.GetUserInfoFromContext(ctx)
+	// the token cannot create the tokens of more scopes
+	if len(userInfo.Scopes) > 0 {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.PersonalAccessTokenForbidden), nil)
+		return
+	}
+
+	req.UserID = userInfo.UserID
+	req.RoleID = userInfo.RoleID
+	resp, err := pc.personalAccessTokenService.AddPersonalAccessToken(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RemovePersonalAccessToken revoke personal access token
+// @Summary revoke personal access token
+// @Description revoke personal access token, it is invalid immediately
+// @Security ApiKeyAuth
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param data body schema.RemovePersonalAccessTokenReq true "personal access token"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/personal-access-token [delete]
+func (pc *PersonalAccessTokenController) RemovePersonalAccessToken(ctx *gin.Context) {
+	req := &schema.RemovePersonalAccessTokenReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	userInfo := middleware.GetUserInfoFromContext(ctx)
+	if len(userInfo.Scopes) > 0 {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.PersonalAccessTokenForbidden), nil)
+		return
+	}
+
+	req.UserID = userInfo.UserID
+	err := pc.personalAccessTokenService.RemovePersonalAccessToken(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,151 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/permission"
+	"github.com/apache/answer/internal/service/question_close_vote"
+	"github.com/apache/answer/internal/service/rank"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// QuestionCloseVoteController question close vote controller
+type QuestionCloseVoteController struct {
+	questionCloseVoteService *question_close_vote.QuestionCloseVoteService
+	rankService              *rank.RankService
+}
+
+// NewQuestionCloseVoteController new controller
+func NewQuestionCloseVoteController(
+	questionCloseVoteService *question_close_vote.QuestionCloseVoteService,
+	rankService *rank.RankService,
+) *QuestionCloseVoteController {
+	return &QuestionCloseVoteController{
+		questionCloseVoteService: questionCloseVoteService,
+		rankService:              rankService,
+	}
+}
+
+// GetVoteStatus get question close or reopen vote status
+// @Summary get question close or reopen vote status
+// @Description get question close or reopen vote status
+// @Tags Question
+// @Produce json
+// @Param question_id query string true "question id"
+// @Success 200 {object} handler.RespBody{data=schema.QuestionCloseVoteResp}
+// @Router /answer/api/v1/question/close/vote [get]
+func (qc *QuestionCloseVoteController) GetVoteStatus(ctx *gin.Context) {
+	req := &schema.GetQuestionCloseVoteReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.QuestionID = uid.DeShortID(req.QuestionID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := qc.questionCloseVoteService.GetVoteStatus(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// CastCloseVote vote to close question
+// @Summary vote to close question
+// @Description vote to close question, the question is closed when enough votes are cast
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.QuestionCloseVoteReq true "vote"
+// @Success 200 {object} handler.RespBody{data=schema.QuestionCloseVoteResp}
+// @Router /answer/api/v1/question/close/vote [post]
+func (qc *QuestionCloseVoteController) CastCloseVote(ctx *gin.Context) {
+	req := &schema.QuestionCloseVoteReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.QuestionID = uid.DeShortID(req.QuestionID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	if !qc.checkPermission(ctx, req.UserID) {
+		return
+	}
+	resp, err := qc.questionCloseVoteService.CastCloseVote(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// CastReopenVote vote to reopen question
+// @Summary vote to reopen question
+// @Description vote to reopen question, the question is reopened when enough votes are cast
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.QuestionReopenVoteReq true "vote"
+// @Success 200 {object} handler.RespBody{data=schema.QuestionCloseVoteResp}
+// @Router /answer/api/v1/question/reopen/vote [post]
+func (qc *QuestionCloseVoteController) CastReopenVote(ctx *gin.Context) {
+	req := &schema.QuestionReopenVoteReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.QuestionID = uid.DeShortID(req.QuestionID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	if !qc.checkPermission(ctx, req.UserID) {
+		return
+	}
+	resp, err := qc.questionCloseVoteService.CastReopenVote(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RevokeVote revoke close or reopen vote
+// @Summary revoke close or reopen vote
+// @Description revoke close or reopen vote
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RevokeQuestionCloseVoteReq true "vote"
+// @Success 200 {object} handler.RespBody{data=schema.QuestionCloseVoteResp}
+// @Router /answer/api/v1/question/close/vote [delete]
+func (qc *QuestionCloseVoteController) RevokeVote(ctx *gin.Context) {
+	req := &schema.RevokeQuestionCloseVoteReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.QuestionID = uid.DeShortID(req.QuestionID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := qc.questionCloseVoteService.RevokeVote(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+func (qc *QuestionCloseVoteController) checkPermission(ctx *gin.Context, userID string) bool {
+	can, err := qc.rankService.CheckOperationPermission(ctx, userID, permission.QuestionCloseVote, "")
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return false
+	}
+	if !can {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+		return false
+	}
+	return true
+}
@@ -20,6 +20,7 @@
 package controller
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/apache/answer/internal/base/handler"
@@ -175,12 +176,14 @@ func (qc *QuestionController) CloseQuestion(ctx *gin.Context) {
 	}
 	req.ID = uid.DeShortID(req.ID)
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
-	can, err := qc.rankService.CheckOperationPermission(ctx, req.UserID, permission.QuestionClose, "")
+	canList, _, err := qc.rankService.CheckObjectOperationPermissions(ctx, req.UserID, req.ID, []string{
+		permission.QuestionClose,
+	})
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
 		return
 	}
-	if !can {
+	if !canList[0] {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
 		return
 	}
@@ -206,12 +209,14 @@ func (qc *QuestionController) ReopenQuestion(ctx *gin.Context) {
 	}
 	req.QuestionID = uid.DeShortID(req.QuestionID)
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
-	can, err := qc.rankService.CheckOperationPermission(ctx, req.UserID, permission.QuestionReopen, "")
+	canList, _, err := qc.rankService.CheckObjectOperationPermissions(ctx, req.UserID, req.QuestionID, []string{
+		permission.QuestionReopen,
+	})
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
 		return
 	}
-	if !can {
+	if !canList[0] {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
 		return
 	}
@@ -234,7 +239,7 @@ func (qc *QuestionController) GetQuestion(ctx *gin.Context) {
 	id = uid.DeShortID(id)
 	userID := middleware.GetLoginUserIDFromContext(ctx)
 	req := schema.QuestionPermission{}
-	canList, err := qc.rankService.CheckOperationPermissions(ctx, userID, []string{
+	canList, _, err := qc.rankService.CheckObjectOperationPermissions(ctx, userID, id, []string{
 		permission.QuestionEdit,
 		permission.QuestionDelete,
 		permission.QuestionClose,
@@ -628,7 +633,7 @@ func (qc *QuestionController) UpdateQuestion(ctx *gin.Context) {
 	}
 	req.ID = uid.DeShortID(req.ID)
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
-	canList, requireRanks, err := qc.rankService.CheckOperationPermissionsForRanks(ctx, req.UserID, []string{
+	canList, requireRanks, err := qc.rankService.CheckObjectOperationPermissions(ctx, req.UserID, req.ID, []string{
 		permission.QuestionEdit,
 		permission.QuestionDelete,
 		permission.QuestionEditWithoutReview,
@@ -809,7 +814,7 @@ func (qc *QuestionController) UpdateQuestionInviteUser(ctx *gin.Context) {
 // @Router /answer/api/v1/question/similar [get]
 func (qc *QuestionController) GetSimilarQuestions(ctx *gin.Context) {
 	title := ctx.Query("title")
-	resp, err := qc.questionService.GetQuestionsByTitle(ctx, title)
+	resp, err := qc.questionService.GetQuestionsByTitle(ctx, title, middleware.GetLoginUserIDFromContext(ctx))
 	handler.HandleResponse(ctx, err, resp)
 }
 
@@ -997,3 +1002,42 @@ func (qc *QuestionController) GetQuestionLink(ctx *gin.Context) {
 	}
 	handler.HandleResponse(ctx, nil, pager.NewPageModel(total, questions))
 }
+
+// ExportQuestion export question with its accepted and top answers
+// @Summary export question with its accepted and top answers as a pdf or markdown document
+// @Description export question with its accepted and top answers as a pdf or markdown document
+// @Tags Question
+// @Produce application/pdf,text/markdown
+// @Param id path string true "question id"
+// @Param format query string false "export format" Enums(pdf, md)
+// @Param answer_count query int false "the max number of answers"
+// @Success 200 {file} file
+// @Router /answer/api/v1/question/{id}/export [get]
+func (qc *QuestionController) ExportQuestion(ctx *gin.Context) {
+	req := &schema.ExportQuestionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	if qc.rateLimitMiddleware.ActionRateLimit(ctx, schema.RateLimitActionExport) ||
+		qc.rateLimitMiddleware.AnonymousActionRateLimit(ctx, schema.RateLimitActionExport) {
+		return
+	}
+	req.ID = uid.DeShortID(ctx.Param("id"))
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	canList, err := qc.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
+		permission.QuestionReopen,
+	})
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	req.CanViewDeleted = canList[0]
+
+	resp, err := qc.questionService.ExportQuestion(ctx, req)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", resp.FileName))
+	ctx.Data(http.StatusOK, resp.ContentType, resp.Data)
+}
@@ -0,0 +1,104 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/question_quality"
+	"github.com/gin-gonic/gin"
+)
+
+// QuestionQualityController question quality controller
+type QuestionQualityController struct {
+	questionQualityService *question_quality.QuestionQualityService
+}
+
+// NewQuestionQualityController new controller
+func NewQuestionQualityController(
+	questionQualityService *question_quality.QuestionQualityService) *QuestionQualityController {
+	return &QuestionQualityController{questionQualityService: questionQualityService}
+}
+
+// GetQueuePage get the needs improvement queue
+// @Summary get the needs improvement queue
+// @Description get the low scored new questions that need improvement, the lowest scored first,
+// @Description only the users whose reputation reaches the min reputation can browse the queue
+// @Security ApiKeyAuth
+// @Tags QuestionQuality
+// @Produce json
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param mine query bool false "only the items claimed by me"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.QuestionQualityQueueItem}}
+// @Router /answer/api/v1/question/quality/queue [get]
+func (qc *QuestionQualityController) GetQueuePage(ctx *gin.Context) {
+	req := &schema.GetQuestionQualityQueueReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.IsAdminModerator = middleware.GetUserIsAdminModerator(ctx)
+	resp, err := qc.questionQualityService.GetQueuePage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// ClaimItem claim the queue item
+// @Summary claim the queue item
+// @Description claim the queue item to edit or comment the question, the claim is released after the claim hours
+// @Security ApiKeyAuth
+// @Tags QuestionQuality
+// @Accept json
+// @Produce json
+// @Param data body schema.ClaimQuestionQualityReq true "claim"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/question/quality/claim [post]
+func (qc *QuestionQualityController) ClaimItem(ctx *gin.Context) {
+	req := &schema.ClaimQuestionQualityReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.IsAdminModerator = middleware.GetUserIsAdminModerator(ctx)
+	err := qc.questionQualityService.ClaimItem(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// ReleaseItem release the claimed queue item
+// @Summary release the claimed queue item
+// @Description release the claimed queue item back to the queue
+// @Security ApiKeyAuth
+// @Tags QuestionQuality
+// @Accept json
+// @Produce json
+// @Param data body schema.ReleaseQuestionQualityReq true "release"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/question/quality/release [post]
+func (qc *QuestionQualityController) ReleaseItem(ctx *gin.Context) {
+	req := &schema.ReleaseQuestionQualityReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.IsAdminModerator = middleware.GetUserIsAdminModerator(ctx)
+	err := qc.questionQualityService.ReleaseItem(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -20,6 +20,8 @@
 package controller
 
 import (
+	"net/http"
+
 	"github.com/apache/answer/internal/base/handler"
 	"github.com/apache/answer/internal/base/middleware"
 	"github.com/apache/answer/internal/schema"
@@ -59,3 +61,56 @@ func (cc *RankController) GetRankPersonalWithPage(ctx *gin.Context) {
 	resp, err := cc.rankService.GetRankPersonalPage(ctx, req)
 	handler.HandleResponse(ctx, err, resp)
 }
+
+// GetRankHistoryPage user reputation history
+// @Summary user reputation history
+// @Description get the full reputation history of current user, including the cancelled reputation changes
+// @Security ApiKeyAuth
+// @Tags Rank
+// @Produce json
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param rank_type query string false "rank type, e.g. question.voted_up"
+// @Param start_time query int false "start time, unix timestamp in seconds"
+// @Param end_time query int false "end time, unix timestamp in seconds"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.GetRankHistoryResp}}
+// @Router /answer/api/v1/personal/rank/history [get]
+func (cc *RankController) GetRankHistoryPage(ctx *gin.Context) {
+	req := &schema.GetRankHistoryReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := cc.rankService.GetRankHistoryPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// ExportRankHistory export user reputation history as csv
+// @Summary export user reputation history as csv
+// @Description export the full reputation history of current user as csv
+// @Security ApiKeyAuth
+// @Tags Rank
+// @Produce text/csv
+// @Param rank_type query string false "rank type, e.g. question.voted_up"
+// @Param start_time query int false "start time, unix timestamp in seconds"
+// @Param end_time query int false "end time, unix timestamp in seconds"
+// @Success 200 {file} file
+// @Router /answer/api/v1/personal/rank/history/export [get]
+func (cc *RankController) ExportRankHistory(ctx *gin.Context) {
+	req := &schema.GetRankHistoryReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	data, err := cc.rankService.ExportRankHistoryCSV(ctx, req)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	ctx.Header("Content-Disposition", `attachment; filename="reputation-history.csv"`)
+	ctx.Data(http.StatusOK, "text/csv; charset=utf-8", data)
+}
@@ -0,0 +1,147 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/permission"
+	"github.com/apache/answer/internal/service/rank"
+	"github.com/apache/answer/internal/service/report_appeal"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// ReportAppealController report appeal controller
+type ReportAppealController struct {
+	reportAppealService *report_appeal.ReportAppealService
+	rankService         *rank.RankService
+}
+
+// NewReportAppealController new controller
+func NewReportAppealController(
+	reportAppealService *report_appeal.ReportAppealService,
+	rankService *rank.RankService,
+) *ReportAppealController {
+	return &ReportAppealController{
+		reportAppealService: reportAppealService,
+		rankService:         rankService,
+	}
+}
+
+// AddAppeal appeal against the flag which removed the content
+// @Summary appeal against the flag which removed the content
+// @Description appeal against the flag which removed the content, only the author of content can appeal
+// @Tags Report
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddReportAppealReq true "appeal"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/report/appeal [post]
+func (rc *ReportAppealController) AddAppeal(ctx *gin.Context) {
+	req := &schema.AddReportAppealReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := rc.reportAppealService.AddAppeal(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetAppeal get my appeal of the flag
+// @Summary get my appeal of the flag
+// @Description get my appeal of the flag
+// @Tags Report
+// @Produce json
+// @Security ApiKeyAuth
+// @Param flag_id query string true "flag id"
+// @Success 200 {object} handler.RespBody{data=schema.GetReportAppealResp}
+// @Router /answer/api/v1/report/appeal [get]
+func (rc *ReportAppealController) GetAppeal(ctx *gin.Context) {
+	req := &schema.GetReportAppealReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := rc.reportAppealService.GetAppeal(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetPendingAppealPage get pending appeal page
+// @Summary get pending appeal page
+// @Description get pending appeal page with the flag context, the appeals of flags handled by yourself are excluded
+// @Tags Report
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.GetReportAppealPageResp}}
+// @Router /answer/api/v1/report/appeal/page [get]
+func (rc *ReportAppealController) GetPendingAppealPage(ctx *gin.Context) {
+	req := &schema.GetReportAppealPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.IsAdmin = rc.rankService.CheckUserPower(ctx, req.UserID, permission.ReportHandle)
+	resp, err := rc.reportAppealService.GetPendingAppealPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// ReviewAppeal review appeal
+// @Summary review appeal
+// @Description review appeal, uphold the moderation result or overturn it to restore the content
+// @Tags Report
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.ReviewReportAppealReq true "appeal"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/report/appeal/review [put]
+func (rc *ReportAppealController) ReviewAppeal(ctx *gin.Context) {
+	req := &schema.ReviewReportAppealReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.IsAdmin = rc.rankService.CheckUserPower(ctx, req.UserID, permission.ReportHandle)
+	if !req.IsAdmin {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+		return
+	}
+	err := rc.reportAppealService.ReviewAppeal(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetAppealStats get appeal outcomes of moderators
+// @Summary get appeal outcomes of moderators
+// @Description get appeal outcomes of moderators
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=[]schema.GetReportAppealStatsResp}
+// @Router /answer/admin/api/report/appeal/stats [get]
+func (rc *ReportAppealController) GetAppealStats(ctx *gin.Context) {
+	resp, err := rc.reportAppealService.GetAppealStats(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -120,12 +120,72 @@ func (rc *ReportController) GetUnreviewedReportPostPage(ctx *gin.Context) {
 	}
 
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
-	req.IsAdmin = middleware.GetUserIsAdminModerator(ctx)
+	req.IsAdmin = rc.rankService.CheckUserPower(ctx, req.UserID, permission.ReportHandle)
 
 	resp, err := rc.reportService.GetUnreviewedReportPostPage(ctx, req)
 	handler.HandleResponse(ctx, err, resp)
 }
 
+// GetReportPage get report page
+// @Summary get report page
+// @Description get the flags filtered by status, category, object type and assignee
+// @Tags Report
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param status query string false "status" Enums(pending, completed, ignored)
+// @Param category query string false "category" Enums(spam, abuse, quality, legal, other)
+// @Param object_type query string false "object type" Enums(question, answer, comment)
+// @Param assignee query string false "me, none or the user id of the assignee"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.GetReportListPageResp}}
+// @Router /answer/api/v1/report/page [get]
+func (rc *ReportController) GetReportPage(ctx *gin.Context) {
+	req := &schema.GetReportPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.IsAdmin = rc.rankService.CheckUserPower(ctx, req.UserID, permission.ReportHandle)
+
+	resp, err := rc.reportService.GetReportPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AssignReport assign report
+// @Summary assign report
+// @Description assign the flag to the moderator, or unassign it if the assignee is empty
+// @Tags Report
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AssignReportReq true "flag"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/report/assign [put]
+func (rc *ReportController) AssignReport(ctx *gin.Context) {
+	req := &schema.AssignReportReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.IsAdmin = rc.rankService.CheckUserPower(ctx, req.UserID, permission.ReportHandle)
+	if !req.IsAdmin {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+		return
+	}
+	if len(req.AssigneeID) > 0 && req.AssigneeID != "0" &&
+		!rc.rankService.CheckUserPower(ctx, req.AssigneeID, permission.ReportHandle) {
+		handler.HandleResponse(ctx, errors.BadRequest(reason.ReportAssigneeInvalid), nil)
+		return
+	}
+
+	err := rc.reportService.AssignReport(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
 // ReviewReport review report
 // @Summary review report
 // @Description review report
@@ -143,7 +203,7 @@ func (rc *ReportController) ReviewReport(ctx *gin.Context) {
 	}
 
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
-	req.IsAdmin = middleware.GetUserIsAdminModerator(ctx)
+	req.IsAdmin = rc.rankService.CheckUserPower(ctx, req.UserID, permission.ReportHandle)
 	if !req.IsAdmin {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
 		return
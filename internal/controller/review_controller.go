@@ -78,6 +78,11 @@ func (rc *ReviewController) GetUnreviewedPostPage(ctx *gin.Context) {
 		req.ReviewerMapping[info.SlugName] = info.Name.Translate(ctx)
 		return nil
 	})
+	_ = plugin.CallAntiSpam(func(base plugin.AntiSpam) error {
+		info := base.Info()
+		req.ReviewerMapping[info.SlugName] = info.Name.Translate(ctx)
+		return nil
+	})
 
 	resp, err := rc.reviewService.GetUnreviewedPostPage(ctx, req)
 	handler.HandleResponse(ctx, err, resp)
@@ -129,7 +129,12 @@ func (rc *RevisionController) RevisionAudit(ctx *gin.Context) {
 		return
 	}
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
-	canList, err := rc.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
+	objectID, err := rc.revisionListService.GetRevisionObjectID(ctx, req.ID)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	canList, _, err := rc.rankService.CheckObjectOperationPermissions(ctx, req.UserID, objectID, []string{
 		permission.QuestionAudit,
 		permission.AnswerAudit,
 		permission.TagAudit,
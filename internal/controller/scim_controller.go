@@ -0,0 +1,312 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/scim"
+	"github.com/gin-gonic/gin"
+	myErrors "github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// SCIMContentType the content type of SCIM response
+const SCIMContentType = "application/scim+json"
+
+// SCIMController SCIM controller, the responses are in the SCIM format instead of the response body of site
+type SCIMController struct {
+	scimService *scim.SCIMService
+}
+
+// NewSCIMController new controller
+func NewSCIMController(scimService *scim.SCIMService) *SCIMController {
+	return &SCIMController{scimService: scimService}
+}
+
+// Authenticate check the bearer token of the identity provider
+func (sc *SCIMController) Authenticate() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		token := strings.TrimSpace(strings.TrimPrefix(ctx.GetHeader("Authorization"), "Bearer "))
+		if err := sc.scimService.CheckToken(ctx, token); err != nil {
+			sc.handleResponse(ctx, err, http.StatusOK, nil)
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// GetServiceProviderConfig get the features supported by the SCIM endpoint
+// @Summary get the features supported by the SCIM endpoint
+// @Description get the features supported by the SCIM endpoint
+// @Security ApiKeyAuth
+// @Tags SCIM
+// @Produce json
+// @Success 200 {object} map[string]any
+// @Router /answer/scim/v2/ServiceProviderConfig [get]
+func (sc *SCIMController) GetServiceProviderConfig(ctx *gin.Context) {
+	sc.handleResponse(ctx, nil, http.StatusOK, schema.NewSCIMServiceProviderConfig())
+}
+
+// GetUsers get users
+// @Summary get users
+// @Description get the page of users, only the eq filter of userName, emails.value and id is supported
+// @Security ApiKeyAuth
+// @Tags SCIM
+// @Produce json
+// @Param filter query string false "filter, e.g. userName eq \"alice@example.com\""
+// @Param startIndex query int false "start index, 1-based"
+// @Param count query int false "count"
+// @Success 200 {object} schema.SCIMListResponse{Resources=[]schema.SCIMUser}
+// @Router /answer/scim/v2/Users [get]
+func (sc *SCIMController) GetUsers(ctx *gin.Context) {
+	req := &schema.SCIMListReq{}
+	if err := ctx.ShouldBindQuery(req); err != nil {
+		sc.handleResponse(ctx, myErrors.BadRequest(reason.RequestFormatError), http.StatusOK, nil)
+		return
+	}
+	resp, err := sc.scimService.GetUsers(ctx, req)
+	sc.handleResponse(ctx, err, http.StatusOK, resp)
+}
+
+// GetUser get user
+// @Summary get user
+// @Description get user
+// @Security ApiKeyAuth
+// @Tags SCIM
+// @Produce json
+// @Param id path string true "user id"
+// @Success 200 {object} schema.SCIMUser
+// @Router /answer/scim/v2/Users/{id} [get]
+func (sc *SCIMController) GetUser(ctx *gin.Context) {
+	resp, err := sc.scimService.GetUser(ctx, ctx.Param("id"))
+	sc.handleResponse(ctx, err, http.StatusOK, resp)
+}
+
+// CreateUser create user
+// @Summary create user
+// @Description create user, the user is suspended if it is not active
+// @Security ApiKeyAuth
+// @Tags SCIM
+// @Accept json
+// @Produce json
+// @Param data body schema.SCIMUser true "user"
+// @Success 201 {object} schema.SCIMUser
+// @Router /answer/scim/v2/Users [post]
+func (sc *SCIMController) CreateUser(ctx *gin.Context) {
+	req := &schema.SCIMUser{}
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		sc.handleResponse(ctx, myErrors.BadRequest(reason.RequestFormatError), http.StatusOK, nil)
+		return
+	}
+	resp, err := sc.scimService.CreateUser(ctx, req)
+	if err == nil {
+		ctx.Header("Location", resp.Meta.Location)
+	}
+	sc.handleResponse(ctx, err, http.StatusCreated, resp)
+}
+
+// ReplaceUser replace user
+// @Summary replace user
+// @Description replace the attributes of user, the user is suspended if it is not active
+// @Security ApiKeyAuth
+// @Tags SCIM
+// @Accept json
+// @Produce json
+// @Param id path string true "user id"
+// @Param data body schema.SCIMUser true "user"
+// @Success 200 {object} schema.SCIMUser
+// @Router /answer/scim/v2/Users/{id} [put]
+func (sc *SCIMController) ReplaceUser(ctx *gin.Context) {
+	req := &schema.SCIMUser{}
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		sc.handleResponse(ctx, myErrors.BadRequest(reason.RequestFormatError), http.StatusOK, nil)
+		return
+	}
+	resp, err := sc.scimService.ReplaceUser(ctx, ctx.Param("id"), req)
+	sc.handleResponse(ctx, err, http.StatusOK, resp)
+}
+
+// PatchUser patch user
+// @Summary patch user
+// @Description patch user, the add and replace operations of active, userName, displayName, externalId, name, emails and password are supported
+// @Security ApiKeyAuth
+// @Tags SCIM
+// @Accept json
+// @Produce json
+// @Param id path string true "user id"
+// @Param data body schema.SCIMPatchReq true "patch operations"
+// @Success 200 {object} schema.SCIMUser
+// @Router /answer/scim/v2/Users/{id} [patch]
+func (sc *SCIMController) PatchUser(ctx *gin.Context) {
+	req := &schema.SCIMPatchReq{}
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		sc.handleResponse(ctx, myErrors.BadRequest(reason.SCIMPatchInvalid), http.StatusOK, nil)
+		return
+	}
+	resp, err := sc.scimService.PatchUser(ctx, ctx.Param("id"), req)
+	sc.handleResponse(ctx, err, http.StatusOK, resp)
+}
+
+// DeleteUser delete user
+// @Summary delete user
+// @Description delete user
+// @Security ApiKeyAuth
+// @Tags SCIM
+// @Param id path string true "user id"
+// @Success 204
+// @Router /answer/scim/v2/Users/{id} [delete]
+func (sc *SCIMController) DeleteUser(ctx *gin.Context) {
+	err := sc.scimService.DeleteUser(ctx, ctx.Param("id"))
+	sc.handleResponse(ctx, err, http.StatusNoContent, nil)
+}
+
+// GetGroups get groups
+// @Summary get groups
+// @Description get the roles as groups, only the eq filter of displayName and id is supported
+// @Security ApiKeyAuth
+// @Tags SCIM
+// @Produce json
+// @Param filter query string false "filter, e.g. displayName eq \"Moderator\""
+// @Param startIndex query int false "start index, 1-based"
+// @Param count query int false "count"
+// @Success 200 {object} schema.SCIMListResponse{Resources=[]schema.SCIMGroup}
+// @Router /answer/scim/v2/Groups [get]
+func (sc *SCIMController) GetGroups(ctx *gin.Context) {
+	req := &schema.SCIMListReq{}
+	if err := ctx.ShouldBindQuery(req); err != nil {
+		sc.handleResponse(ctx, myErrors.BadRequest(reason.RequestFormatError), http.StatusOK, nil)
+		return
+	}
+	resp, err := sc.scimService.GetGroups(ctx, req)
+	sc.handleResponse(ctx, err, http.StatusOK, resp)
+}
+
+// GetGroup get group
+// @Summary get group
+// @Description get the role as group, the members of the default user role are not listed
+// @Security ApiKeyAuth
+// @Tags SCIM
+// @Produce json
+// @Param id path string true "role id"
+// @Success 200 {object} schema.SCIMGroup
+// @Router /answer/scim/v2/Groups/{id} [get]
+func (sc *SCIMController) GetGroup(ctx *gin.Context) {
+	resp, err := sc.scimService.GetGroup(ctx, ctx.Param("id"))
+	sc.handleResponse(ctx, err, http.StatusOK, resp)
+}
+
+// PatchGroup patch group
+// @Summary patch group
+// @Description assign the role to the added members, the removed members are reset to the user role
+// @Security ApiKeyAuth
+// @Tags SCIM
+// @Accept json
+// @Produce json
+// @Param id path string true "role id"
+// @Param data body schema.SCIMPatchReq true "patch operations"
+// @Success 200 {object} schema.SCIMGroup
+// @Router /answer/scim/v2/Groups/{id} [patch]
+func (sc *SCIMController) PatchGroup(ctx *gin.Context) {
+	req := &schema.SCIMPatchReq{}
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		sc.handleResponse(ctx, myErrors.BadRequest(reason.SCIMPatchInvalid), http.StatusOK, nil)
+		return
+	}
+	resp, err := sc.scimService.PatchGroup(ctx, ctx.Param("id"), req)
+	sc.handleResponse(ctx, err, http.StatusOK, resp)
+}
+
+// ReplaceGroup replace group
+// @Summary replace group
+// @Description replace the members of group
+// @Security ApiKeyAuth
+// @Tags SCIM
+// @Accept json
+// @Produce json
+// @Param id path string true "role id"
+// @Param data body schema.SCIMGroup true "group"
+// @Success 200 {object} schema.SCIMGroup
+// @Router /answer/scim/v2/Groups/{id} [put]
+func (sc *SCIMController) ReplaceGroup(ctx *gin.Context) {
+	req := &schema.SCIMGroup{}
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		sc.handleResponse(ctx, myErrors.BadRequest(reason.RequestFormatError), http.StatusOK, nil)
+		return
+	}
+	resp, err := sc.scimService.ReplaceGroup(ctx, ctx.Param("id"), req)
+	sc.handleResponse(ctx, err, http.StatusOK, resp)
+}
+
+// handleResponse write the SCIM response, the error is written as the SCIM error
+func (sc *SCIMController) handleResponse(ctx *gin.Context, err error, status int, data any) {
+	if err == nil {
+		if data == nil {
+			ctx.Status(status)
+			return
+		}
+		ctx.Render(status, scimJSON{data: data})
+		return
+	}
+
+	var myErr *myErrors.Error
+	if !errors.As(err, &myErr) {
+		log.Error(err, "\n", myErrors.LogStack(2, 5))
+		myErr = myErrors.InternalServer(reason.UnknownError)
+	}
+	if myErrors.IsInternalServer(myErr) {
+		log.Error(myErr)
+	}
+	scimType := ""
+	switch myErr.Reason {
+	case reason.UsernameDuplicate, reason.EmailDuplicate:
+		scimType = "uniqueness"
+	case reason.SCIMFilterInvalid:
+		scimType = "invalidFilter"
+	case reason.SCIMPatchInvalid, reason.RequestFormatError:
+		scimType = "invalidSyntax"
+	case reason.SCIMUserInvalid:
+		scimType = "invalidValue"
+	}
+	detail := translator.Tr(handler.GetLang(ctx), myErr.Reason)
+	ctx.Render(myErr.Code, scimJSON{data: schema.NewSCIMError(myErr.Code, scimType, detail)})
+}
+
+// scimJSON render the data as JSON with the SCIM content type
+type scimJSON struct {
+	data any
+}
+
+func (r scimJSON) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	return json.NewEncoder(w).Encode(r.data)
+}
+
+func (r scimJSON) WriteContentType(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", SCIMContentType)
+}
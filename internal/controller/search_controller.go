@@ -92,6 +92,25 @@ func (sc *SearchController) Search(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, resp)
 }
 
+// Suggest get search suggestion
+// @Summary get question title completions and matching tags for the prefix
+// @Description get question title completions and matching tags for the prefix
+// @Tags Search
+// @Produce json
+// @Param q query string true "prefix"
+// @Param size query int false "size of each kind of suggestion"
+// @Success 200 {object} handler.RespBody{data=schema.SearchSuggestResp}
+// @Router /answer/api/v1/search/suggest [get]
+func (sc *SearchController) Suggest(ctx *gin.Context) {
+	req := &schema.SearchSuggestReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := sc.searchService.Suggest(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // SearchDesc get search description
 // @Summary get search description
 // @Description get search description
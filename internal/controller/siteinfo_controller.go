@@ -26,18 +26,24 @@ import (
 	"github.com/apache/answer/internal/base/handler"
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/apache/answer/internal/service/space_common"
 	"github.com/gin-gonic/gin"
 	"github.com/segmentfault/pacman/log"
 )
 
 type SiteInfoController struct {
 	siteInfoService siteinfo_common.SiteInfoCommonService
+	spaceCommon     *space_common.SpaceCommon
 }
 
 // NewSiteInfoController new site info controller.
-func NewSiteInfoController(siteInfoService siteinfo_common.SiteInfoCommonService) *SiteInfoController {
+func NewSiteInfoController(
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	spaceCommon *space_common.SpaceCommon,
+) *SiteInfoController {
 	return &SiteInfoController{
 		siteInfoService: siteInfoService,
+		spaceCommon:     spaceCommon,
 	}
 }
 
@@ -46,6 +52,8 @@ func NewSiteInfoController(siteInfoService siteinfo_common.SiteInfoCommonService
 // @Description get site info
 // @Tags site
 // @Produce json
+// @Param space query string false "the slug of the space overriding the site settings"
+// @Param tag query string false "the slug name of the tag, the settings of its space override the site settings"
 // @Success 200 {object} handler.RespBody{data=schema.SiteInfoResp}
 // @Router /answer/api/v1/siteinfo [get]
 func (sc *SiteInfoController) GetSiteInfo(ctx *gin.Context) {
@@ -91,9 +99,29 @@ func (sc *SiteInfoController) GetSiteInfo(ctx *gin.Context) {
 	if err != nil {
 		log.Error(err)
 	}
+	resp.Limits, err = sc.siteInfoService.GetSiteLimits(ctx)
+	if err != nil {
+		log.Error(err)
+	}
+	resp.QuestionClose, err = sc.siteInfoService.GetSiteQuestionClose(ctx)
+	if err != nil {
+		log.Error(err)
+	}
 	if legal, err := sc.siteInfoService.GetSiteLegal(ctx); err == nil {
 		resp.Legal = &schema.SiteLegalSimpleResp{ExternalContentDisplay: legal.ExternalContentDisplay}
 	}
+	if invite, err := sc.siteInfoService.GetSiteInvite(ctx); err == nil {
+		resp.Invite = &schema.SiteInviteSimpleResp{InviteOnly: invite.InviteOnly}
+	}
+	space, err := sc.spaceCommon.ResolveSpace(ctx, ctx.Query("space"), ctx.Query("tag"))
+	if err != nil {
+		log.Error(err)
+	}
+	if space != nil {
+		resp.Branding = space.ApplyBranding(resp.Branding)
+		resp.Theme = space.ApplyTheme(resp.Theme)
+		resp.Space = space.ToInfoResp()
+	}
 
 	handler.HandleResponse(ctx, nil, resp)
 }
@@ -0,0 +1,67 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/syndication"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// SyndicationController syndication controller
+type SyndicationController struct {
+	syndicationService *syndication.SyndicationService
+}
+
+// NewSyndicationController new controller
+func NewSyndicationController(syndicationService *syndication.SyndicationService) *SyndicationController {
+	return &SyndicationController{syndicationService: syndicationService}
+}
+
+// ReceiveWebhook receive syndication webhook
+// @Summary receive syndication webhook
+// @Description receive the question pushed from peer instance and keep it as read-only mirror, the request is signed by the shared secret
+// @Tags Syndication
+// @Accept json
+// @Produce json
+// @Param data body schema.SyndicationWebhookPayload true "payload"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/syndication/webhook [post]
+func (sc *SyndicationController) ReceiveWebhook(ctx *gin.Context) {
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		handler.HandleResponse(ctx, errors.BadRequest(reason.RequestFormatError), nil)
+		return
+	}
+	timestamp, _ := strconv.ParseInt(ctx.GetHeader(schema.SyndicationTimestampHeader), 10, 64)
+	req := &schema.ReceiveSyndicationWebhookReq{
+		Timestamp: timestamp,
+		Signature: ctx.GetHeader(schema.SyndicationSignatureHeader),
+		Body:      body,
+	}
+	err = sc.syndicationService.ReceiveWebhook(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
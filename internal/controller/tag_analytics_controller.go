@@ -0,0 +1,57 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/tag_analytics"
+	"github.com/gin-gonic/gin"
+)
+
+// TagAnalyticsController tag analytics controller
+type TagAnalyticsController struct {
+	tagAnalyticsService *tag_analytics.TagAnalyticsService
+}
+
+// NewTagAnalyticsController new controller
+func NewTagAnalyticsController(tagAnalyticsService *tag_analytics.TagAnalyticsService) *TagAnalyticsController {
+	return &TagAnalyticsController{tagAnalyticsService: tagAnalyticsService}
+}
+
+// AdminGetTagAnalytics get tag analytics
+// @Summary get tag analytics
+// @Description get the questions asked, answer rate, median time to first answer and top answerers of tag by day, aggregated nightly
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param tag_id query string false "tag id"
+// @Param slug_name query string false "tag slug name"
+// @Param days query int false "the number of days before today, default 30"
+// @Success 200 {object} handler.RespBody{data=schema.GetTagAnalyticsResp}
+// @Router /answer/admin/api/tag/analytics [get]
+func (tc *TagAnalyticsController) AdminGetTagAnalytics(ctx *gin.Context) {
+	req := &schema.GetTagAnalyticsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := tc.tagAnalyticsService.GetTagAnalytics(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -173,11 +173,9 @@ func (tc *TagController) UpdateTag(ctx *gin.Context) {
 		handler.HandleResponse(ctx, err, nil)
 		return
 	}
-	if !canList[0] {
-		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
-		return
-	}
-	req.NoNeedReview = canList[1]
+	// the user without the privilege can still propose the description edit, it enters the review queue
+	req.DescriptionOnly = !canList[0]
+	req.NoNeedReview = canList[0] && canList[1]
 
 	err = tc.tagService.UpdateTag(ctx, req)
 	if err != nil {
@@ -246,7 +244,8 @@ func (tc *TagController) GetTagInfo(ctx *gin.Context) {
 		handler.HandleResponse(ctx, err, nil)
 		return
 	}
-	req.CanEdit = canList[0]
+	// the logged-in user without the privilege can propose the description edit
+	req.CanEdit = canList[0] || len(req.UserID) > 0
 	req.CanDelete = canList[1]
 	req.CanRecover = canList[2]
 	req.CanMerge = middleware.GetUserIsAdminModerator(ctx)
@@ -300,6 +299,41 @@ func (tc *TagController) GetFollowingTags(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, resp)
 }
 
+// GetIgnoredTags get ignored tags
+// @Summary get ignored tags
+// @Description get the tags ignored by the login user, the questions carrying them are excluded from the user's feeds
+// @Security ApiKeyAuth
+// @Tags Tag
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]schema.GetTagBasicResp}
+// @Router /answer/api/v1/tags/ignored [get]
+func (tc *TagController) GetIgnoredTags(ctx *gin.Context) {
+	userID := middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := tc.tagService.GetIgnoredTags(ctx, userID)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// IgnoreTag ignore tag
+// @Summary ignore tag
+// @Description ignore the tag or stop ignoring it
+// @Security ApiKeyAuth
+// @Tags Tag
+// @Accept json
+// @Produce json
+// @Param data body schema.IgnoreTagReq true "tag"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/tag/ignore [put]
+func (tc *TagController) IgnoreTag(ctx *gin.Context) {
+	req := &schema.IgnoreTagReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := tc.tagService.IgnoreTag(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
 // GetTagSynonyms get tag synonyms
 // @Summary get tag synonyms
 // @Description get tag synonyms
@@ -357,6 +391,47 @@ func (tc *TagController) UpdateTagSynonym(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, nil)
 }
 
+// AdminAddTagSynonym add tag synonym
+// @Summary add tag synonym
+// @Description mark the tag as the synonym of main tag, the posts tagged with the synonym are retagged to the main tag
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.AddTagSynonymReq true "synonym"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/tag/synonym [post]
+func (tc *TagController) AdminAddTagSynonym(ctx *gin.Context) {
+	req := &schema.AddTagSynonymReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := tc.tagService.AddTagSynonym(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// AdminRemoveTagSynonym remove tag synonym
+// @Summary remove tag synonym
+// @Description remove the synonym relation, the posts that have been retagged keep the main tag
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.RemoveTagSynonymReq true "synonym"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/tag/synonym [delete]
+func (tc *TagController) AdminRemoveTagSynonym(ctx *gin.Context) {
+	req := &schema.RemoveTagSynonymReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := tc.tagService.RemoveTagSynonym(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
 // MergeTag merge tag
 // @Summary merge tag
 // @Description merge tag
@@ -364,8 +439,8 @@ func (tc *TagController) UpdateTagSynonym(ctx *gin.Context) {
 // @Tags Tag
 // @Accept json
 // @Produce json
-// @Param data body schema.AddTagReq true "tag"
-// @Success 200 {object} handler.RespBody
+// @Param data body schema.MergeTagReq true "tag"
+// @Success 200 {object} handler.RespBody{data=schema.MergeTagResp}
 // @Router /answer/api/v1/tag/merge [post]
 func (tc *TagController) MergeTag(ctx *gin.Context) {
 	req := &schema.MergeTagReq{}
@@ -380,7 +455,111 @@ func (tc *TagController) MergeTag(ctx *gin.Context) {
 	}
 
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
-	err := tc.tagService.MergeTag(ctx, req)
+	resp, err := tc.tagService.MergeTag(ctx, req)
+
+	handler.HandleResponse(ctx, err, resp)
+}
 
+// DeprecateTag deprecate tag
+// @Summary deprecate tag
+// @Description mark the tag as deprecated so that it can't be added to new posts, or undo it
+// @Security ApiKeyAuth
+// @Tags Tag
+// @Accept json
+// @Produce json
+// @Param data body schema.DeprecateTagReq true "tag"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/tag/deprecation [put]
+func (tc *TagController) DeprecateTag(ctx *gin.Context) {
+	req := &schema.DeprecateTagReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	isAdminModerator := middleware.GetUserIsAdminModerator(ctx)
+	if !isAdminModerator {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := tc.tagService.DeprecateTag(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// AdminMergeTag merge tag
+// @Summary merge tag
+// @Description merge source tag into target tag in one transaction, retag the posts, move the followers and combine the counts
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.MergeTagReq true "tag"
+// @Success 200 {object} handler.RespBody{data=schema.MergeTagResp}
+// @Router /answer/admin/api/tag/merge [post]
+func (tc *TagController) AdminMergeTag(ctx *gin.Context) {
+	req := &schema.MergeTagReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := tc.tagService.MergeTag(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AdminGetTagTranslations get tag translations
+// @Summary get tag translations
+// @Description get the display names and descriptions of tag in all translated languages
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param tag_id query string true "tag id"
+// @Success 200 {object} handler.RespBody{data=[]schema.TagTranslationItem}
+// @Router /answer/admin/api/tag/translations [get]
+func (tc *TagController) AdminGetTagTranslations(ctx *gin.Context) {
+	req := &schema.GetTagTranslationsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := tc.tagCommonService.GetTagTranslations(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AdminSaveTagTranslation save tag translation
+// @Summary save tag translation
+// @Description add or update the display name and description of tag shown to the users of the language
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.SaveTagTranslationReq true "tag translation"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/tag/translation [put]
+func (tc *TagController) AdminSaveTagTranslation(ctx *gin.Context) {
+	req := &schema.SaveTagTranslationReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := tc.tagCommonService.SaveTagTranslation(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// AdminRemoveTagTranslation remove tag translation
+// @Summary remove tag translation
+// @Description remove the translation of tag, the canonical display name and description are shown instead
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.RemoveTagTranslationReq true "tag translation"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/tag/translation [delete]
+func (tc *TagController) AdminRemoveTagTranslation(ctx *gin.Context) {
+	req := &schema.RemoveTagTranslationReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := tc.tagCommonService.RemoveTagTranslation(ctx, req)
 	handler.HandleResponse(ctx, err, nil)
 }
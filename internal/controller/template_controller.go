@@ -299,6 +299,19 @@ func (tc *TemplateController) QuestionInfoRedirect(ctx *gin.Context, siteInfo *s
 	}
 }
 
+// isExternalReferer whether the visitor comes from other sites by the referer
+func isExternalReferer(ctx *gin.Context, siteURL string) bool {
+	referer, err := url.Parse(ctx.Request.Referer())
+	if err != nil || len(referer.Hostname()) == 0 {
+		return false
+	}
+	site, err := url.Parse(siteURL)
+	if err != nil {
+		return false
+	}
+	return !strings.EqualFold(referer.Hostname(), site.Hostname())
+}
+
 // QuestionInfo question and answers info
 func (tc *TemplateController) QuestionInfo(ctx *gin.Context) {
 	id := ctx.Param("id")
@@ -339,6 +352,9 @@ func (tc *TemplateController) QuestionInfo(ctx *gin.Context) {
 	}
 
 	siteInfo := tc.SiteInfo(ctx)
+	if isExternalReferer(ctx, siteInfo.General.SiteUrl) {
+		tc.questionService.MarkQuestionExternallyLinked(id)
+	}
 	jump, jumpurl := tc.QuestionInfoRedirect(ctx, siteInfo, correctTitle)
 	if jump {
 		ctx.Redirect(http.StatusFound, jumpurl)
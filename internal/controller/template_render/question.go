@@ -50,7 +50,7 @@ func (t *TemplateRenderController) Sitemap(ctx *gin.Context) {
 		return
 	}
 
-	questions, err := t.questionRepo.SitemapQuestions(ctx, 1, constant.SitemapMaxSize)
+	questions, err := t.questionService.SitemapQuestions(ctx, 1, constant.SitemapMaxSize)
 	if err != nil {
 		log.Errorf("get sitemap questions failed: %s", err)
 		return
@@ -123,7 +123,7 @@ func (t *TemplateRenderController) SitemapPage(ctx *gin.Context, page int) error
 		return err
 	}
 
-	questions, err := t.questionRepo.SitemapQuestions(ctx, page, constant.SitemapMaxSize)
+	questions, err := t.questionService.SitemapQuestions(ctx, page, constant.SitemapMaxSize)
 	if err != nil {
 		log.Errorf("get sitemap questions failed: %s", err)
 		return err
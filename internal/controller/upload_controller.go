@@ -39,6 +39,8 @@ const (
 	fileFromAvatar = "avatar"
 	// file is logo/icon images
 	fileFromBranding = "branding"
+	// file is the short video such as screen recording played in posts
+	fileFromPostVideo = "post_video"
 )
 
 // UploadController upload controller
@@ -59,7 +61,7 @@ func NewUploadController(uploaderService uploader.UploaderService) *UploadContro
 // @Tags Upload
 // @Accept multipart/form-data
 // @Security ApiKeyAuth
-// @Param source formData string true "identify the source of the file upload" Enums(post, post_attachment, avatar, branding)
+// @Param source formData string true "identify the source of the file upload" Enums(post, post_attachment, post_video, avatar, branding)
 // @Param file formData file true "file"
 // @Success 200 {object} handler.RespBody{data=string}
 // @Router /answer/api/v1/file [post]
@@ -84,6 +86,8 @@ func (uc *UploadController) UploadFile(ctx *gin.Context) {
 		url, err = uc.uploaderService.UploadBrandingFile(ctx, userID)
 	case fileFromPostAttachment:
 		url, err = uc.uploaderService.UploadPostAttachment(ctx, userID)
+	case fileFromPostVideo:
+		url, err = uc.uploaderService.UploadPostVideo(ctx, userID)
 	default:
 		handler.HandleResponse(ctx, errors.BadRequest(reason.UploadFileSourceUnsupported), nil)
 		return
@@ -112,3 +116,79 @@ func (uc *UploadController) PostRender(ctx *gin.Context) {
 	}
 	handler.HandleResponse(ctx, nil, converter.Markdown2HTML(req.Content))
 }
+
+// CreateChunkedUpload start a resumable upload
+// @Summary start a resumable upload
+// @Description start a resumable upload for large post images and attachments, the chunks are uploaded in order
+// @Tags Upload
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.CreateChunkedUploadReq true "CreateChunkedUploadReq"
+// @Success 200 {object} handler.RespBody{data=schema.ChunkedUploadResp}
+// @Router /answer/api/v1/file/chunked [post]
+func (uc *UploadController) CreateChunkedUpload(ctx *gin.Context) {
+	req := &schema.CreateChunkedUploadReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := uc.uploaderService.CreateChunkedUpload(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetChunkedUpload get resumable upload
+// @Summary get resumable upload
+// @Description get the offset of resumable upload to resume from
+// @Tags Upload
+// @Produce json
+// @Security ApiKeyAuth
+// @Param upload_id path string true "upload id"
+// @Success 200 {object} handler.RespBody{data=schema.ChunkedUploadResp}
+// @Router /answer/api/v1/file/chunked/{upload_id} [get]
+func (uc *UploadController) GetChunkedUpload(ctx *gin.Context) {
+	req := &schema.GetChunkedUploadReq{UploadID: ctx.Param("upload_id")}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := uc.uploaderService.GetChunkedUpload(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UploadChunk upload chunk
+// @Summary upload chunk
+// @Description upload the chunk in request body at offset, the file is assembled and checked after the last chunk
+// @Tags Upload
+// @Accept application/octet-stream
+// @Produce json
+// @Security ApiKeyAuth
+// @Param upload_id path string true "upload id"
+// @Param offset query int true "the offset of the chunk, must be equal to the offset of the upload"
+// @Param X-Chunk-Checksum header string false "sha256 hex checksum of the chunk"
+// @Success 200 {object} handler.RespBody{data=schema.ChunkedUploadResp}
+// @Router /answer/api/v1/file/chunked/{upload_id} [patch]
+func (uc *UploadController) UploadChunk(ctx *gin.Context) {
+	// the request body is the raw chunk, so it must not be bound
+	req := &schema.UploadChunkReq{
+		UploadID: ctx.Param("upload_id"),
+		Offset:   converter.StringToInt64(ctx.Query("offset")),
+		Checksum: ctx.GetHeader(schema.ChunkChecksumHeader),
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := uc.uploaderService.UploadChunk(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AbortChunkedUpload abort resumable upload
+// @Summary abort resumable upload
+// @Description abort resumable upload and remove the uploaded chunks
+// @Tags Upload
+// @Produce json
+// @Security ApiKeyAuth
+// @Param upload_id path string true "upload id"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/file/chunked/{upload_id} [delete]
+func (uc *UploadController) AbortChunkedUpload(ctx *gin.Context) {
+	req := &schema.GetChunkedUploadReq{UploadID: ctx.Param("upload_id")}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := uc.uploaderService.AbortChunkedUpload(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -34,9 +34,9 @@ import (
 	"github.com/apache/answer/internal/service/auth"
 	"github.com/apache/answer/internal/service/content"
 	"github.com/apache/answer/internal/service/export"
+	"github.com/apache/answer/internal/service/login_protection"
 	"github.com/apache/answer/internal/service/siteinfo_common"
 	"github.com/apache/answer/internal/service/user_notification_config"
-	"github.com/apache/answer/pkg/checker"
 	"github.com/gin-gonic/gin"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
@@ -50,6 +50,7 @@ type UserController struct {
 	emailService                  *export.EmailService
 	siteInfoCommonService         siteinfo_common.SiteInfoCommonService
 	userNotificationConfigService *user_notification_config.UserNotificationConfigService
+	loginProtectionService        *login_protection.LoginProtectionService
 }
 
 // NewUserController new controller
@@ -60,6 +61,7 @@ func NewUserController(
 	emailService *export.EmailService,
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
 	userNotificationConfigService *user_notification_config.UserNotificationConfigService,
+	loginProtectionService *login_protection.LoginProtectionService,
 ) *UserController {
 	return &UserController{
 		authService:                   authService,
@@ -68,6 +70,7 @@ func NewUserController(
 		emailService:                  emailService,
 		siteInfoCommonService:         siteInfoCommonService,
 		userNotificationConfigService: userNotificationConfigService,
+		loginProtectionService:        loginProtectionService,
 	}
 }
 
@@ -149,9 +152,17 @@ func (uc *UserController) UserEmailLogin(ctx *gin.Context) {
 		}
 	}
 
+	req.IP = ctx.ClientIP()
+	// the account or ip is locked by the failed attempts
+	lockedResp, err := uc.loginProtectionService.CheckLogin(ctx, req.Email, req.IP)
+	if err != nil {
+		handler.HandleResponse(ctx, err, lockedResp)
+		return
+	}
 	resp, err := uc.userService.EmailLogin(ctx, req)
 	if err != nil {
 		_, _ = uc.actionService.ActionRecordAdd(ctx, entity.CaptchaActionPassword, ctx.ClientIP())
+		uc.loginProtectionService.LoginFailed(ctx, req.Email, req.IP)
 		errFields := append([]*validator.FormErrorField{}, &validator.FormErrorField{
 			ErrorField: "e_mail",
 			ErrorMsg:   translator.Tr(handler.GetLang(ctx), reason.EmailOrPasswordWrong),
@@ -162,6 +173,7 @@ func (uc *UserController) UserEmailLogin(ctx *gin.Context) {
 	if !isAdmin {
 		uc.actionService.ActionRecordDel(ctx, entity.CaptchaActionPassword, ctx.ClientIP())
 	}
+	uc.loginProtectionService.LoginSucceeded(ctx, req.Email)
 	if resp.Status == constant.UserSuspended {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.UserSuspended),
 			&schema.ForbiddenResp{Type: schema.ForbiddenReasonTypeUserSuspended})
@@ -275,10 +287,6 @@ func (uc *UserController) UserRegisterByEmail(ctx *gin.Context) {
 	if handler.BindAndCheck(ctx, req) {
 		return
 	}
-	if !checker.EmailInAllowEmailDomain(req.Email, siteInfo.AllowEmailDomains) {
-		handler.HandleResponse(ctx, errors.BadRequest(reason.EmailIllegalDomainError), nil)
-		return
-	}
 	req.IP = ctx.ClientIP()
 	isAdmin := middleware.GetUserIsAdminModerator(ctx)
 	if !isAdmin {
@@ -551,6 +559,41 @@ func (uc *UserController) UpdateUserNotificationConfig(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, nil)
 }
 
+// GetUserNotificationPreferences get user's notification preference matrix
+// @Summary get user's notification preference matrix
+// @Description get the channels of each notification event type of the user
+// @Tags User
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.GetNotificationPreferenceResp}
+// @Router /answer/api/v1/user/notification/preferences [get]
+func (uc *UserController) GetUserNotificationPreferences(ctx *gin.Context) {
+	userID := middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := uc.userNotificationConfigService.GetUserNotificationPreferences(ctx, userID)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateUserNotificationPreferences update user's notification preference matrix
+// @Summary update user's notification preference matrix
+// @Description update the channels of the listed notification event types of the user
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UpdateNotificationPreferenceReq true "UpdateNotificationPreferenceReq"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/api/v1/user/notification/preferences [put]
+func (uc *UserController) UpdateUserNotificationPreferences(ctx *gin.Context) {
+	req := &schema.UpdateNotificationPreferenceReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := uc.userNotificationConfigService.UpdateUserNotificationPreferences(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
 // UserChangeEmailSendCode send email to the user email then change their email
 // @Summary send email to the user email then change their email
 // @Description send email to the user email then change their email
@@ -579,7 +622,7 @@ func (uc *UserController) UserChangeEmailSendCode(ctx *gin.Context) {
 		handler.HandleResponse(ctx, err, nil)
 		return
 	}
-	if !checker.EmailInAllowEmailDomain(req.Email, siteInfo.AllowEmailDomains) {
+	if !siteInfo.IsEmailDomainAllowed(req.Email) {
 		handler.HandleResponse(ctx, errors.BadRequest(reason.EmailIllegalDomainError), nil)
 		return
 	}
@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/user_mute"
+	"github.com/gin-gonic/gin"
+)
+
+// UserMuteController user mute controller
+type UserMuteController struct {
+	userMuteService *user_mute.UserMuteService
+}
+
+// NewUserMuteController new controller
+func NewUserMuteController(userMuteService *user_mute.UserMuteService) *UserMuteController {
+	return &UserMuteController{userMuteService: userMuteService}
+}
+
+// GetMutes get the muted questions and users
+// @Summary get the muted questions and users
+// @Description get the questions and users muted by the login user, the notifications about them are not delivered
+// @Security ApiKeyAuth
+// @Tags Notification
+// @Produce json
+// @Param mute_type query string false "question or user, all if empty" Enums(question, user)
+// @Success 200 {object} handler.RespBody{data=[]schema.MuteItem}
+// @Router /answer/api/v1/notification/mutes [get]
+func (uc *UserMuteController) GetMutes(ctx *gin.Context) {
+	req := &schema.GetMutesReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := uc.userMuteService.GetMutes(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// Mute mute the question or user
+// @Summary mute the question or user
+// @Description mute the question or user, or stop muting it
+// @Security ApiKeyAuth
+// @Tags Notification
+// @Accept json
+// @Produce json
+// @Param data body schema.MuteReq true "mute"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/notification/mute [put]
+func (uc *UserMuteController) Mute(ctx *gin.Context) {
+	req := &schema.MuteReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := uc.userMuteService.Mute(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,90 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/user_session"
+	"github.com/gin-gonic/gin"
+)
+
+// UserSessionController user session controller
+type UserSessionController struct {
+	userSessionService *user_session.UserSessionService
+}
+
+// NewUserSessionController new controller
+func NewUserSessionController(userSessionService *user_session.UserSessionService) *UserSessionController {
+	return &UserSessionController{userSessionService: userSessionService}
+}
+
+// GetUserSessions get user sessions
+// @Summary get user sessions
+// @Description get the active login sessions of the login user with the ip, user agent and last active time
+// @Security ApiKeyAuth
+// @Tags User
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]schema.UserSessionItem}
+// @Router /answer/api/v1/user/sessions [get]
+func (uc *UserSessionController) GetUserSessions(ctx *gin.Context) {
+	resp, err := uc.userSessionService.GetUserSessions(ctx,
+		middleware.GetLoginUserIDFromContext(ctx), middleware.ExtractToken(ctx))
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RemoveUserSession remove user session
+// @Summary remove user session
+// @Description log out the login session of the login user
+// @Security ApiKeyAuth
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param data body schema.RemoveUserSessionReq true "session"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/session [delete]
+func (uc *UserSessionController) RemoveUserSession(ctx *gin.Context) {
+	req := &schema.RemoveUserSessionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.AccessToken = middleware.ExtractToken(ctx)
+	err := uc.userSessionService.RemoveUserSession(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RemoveOtherUserSessions remove other user sessions
+// @Summary remove other user sessions
+// @Description log out all the login sessions of the login user except the current one
+// @Security ApiKeyAuth
+// @Tags User
+// @Produce json
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/sessions/others [delete]
+func (uc *UserSessionController) RemoveOtherUserSessions(ctx *gin.Context) {
+	req := &schema.RemoveOtherUserSessionsReq{
+		UserID:      middleware.GetLoginUserIDFromContext(ctx),
+		AccessToken: middleware.ExtractToken(ctx),
+	}
+	err := uc.userSessionService.RemoveOtherUserSessions(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,107 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/web_push"
+	"github.com/gin-gonic/gin"
+)
+
+// WebPushController web push controller
+type WebPushController struct {
+	webPushService *web_push.WebPushService
+}
+
+// NewWebPushController new controller
+func NewWebPushController(webPushService *web_push.WebPushService) *WebPushController {
+	return &WebPushController{webPushService: webPushService}
+}
+
+// GetVAPIDKey get the vapid public key
+// @Summary get the vapid public key
+// @Description get the vapid public key used as the applicationServerKey to subscribe the browser
+// @Tags WebPush
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.GetWebPushKeyResp}
+// @Router /answer/api/v1/webpush/vapid-key [get]
+func (wc *WebPushController) GetVAPIDKey(ctx *gin.Context) {
+	resp, err := wc.webPushService.GetVAPIDPublicKey(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// Subscribe subscribe the browser of the current device
+// @Summary subscribe the browser of the current device
+// @Description save the push subscription of the browser, the user is opted in web push on the first subscription
+// @Tags WebPush
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.WebPushSubscribeReq true "subscription"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/api/v1/webpush/subscription [post]
+func (wc *WebPushController) Subscribe(ctx *gin.Context) {
+	req := &schema.WebPushSubscribeReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.UserAgent = ctx.GetHeader("User-Agent")
+	if len(req.UserAgent) > 512 {
+		req.UserAgent = req.UserAgent[:512]
+	}
+	err := wc.webPushService.Subscribe(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// Unsubscribe unsubscribe the browser of the device
+// @Summary unsubscribe the browser of the device
+// @Description remove the push subscription of the endpoint
+// @Tags WebPush
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.WebPushUnsubscribeReq true "subscription"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/api/v1/webpush/subscription [delete]
+func (wc *WebPushController) Unsubscribe(ctx *gin.Context) {
+	req := &schema.WebPushUnsubscribeReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := wc.webPushService.Unsubscribe(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSubscriptions get the subscribed devices
+// @Summary get the subscribed devices
+// @Description get the subscribed devices of the current user
+// @Tags WebPush
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=[]schema.WebPushSubscriptionResp}
+// @Router /answer/api/v1/webpush/subscriptions [get]
+func (wc *WebPushController) GetSubscriptions(ctx *gin.Context) {
+	resp, err := wc.webPushService.GetSubscriptions(ctx, middleware.GetLoginUserIDFromContext(ctx))
+	handler.HandleResponse(ctx, err, resp)
+}
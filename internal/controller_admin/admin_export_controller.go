@@ -0,0 +1,183 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"fmt"
+
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/admin_export"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/log"
+)
+
+// AdminExportController admin list export controller
+type AdminExportController struct {
+	adminExportService *admin_export.AdminExportService
+}
+
+// NewAdminExportController new controller
+func NewAdminExportController(adminExportService *admin_export.AdminExportService) *AdminExportController {
+	return &AdminExportController{adminExportService: adminExportService}
+}
+
+// ExportQuestions export the filtered admin question list as csv
+// @Summary export the filtered admin question list as csv
+// @Description export the filtered admin question list as csv, the large list is exported in background and the export job is returned
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce text/csv,json
+// @Param status query string false "question status" Enums(available, closed, deleted, pending)
+// @Param query query string false "question id or title"
+// @Param async query bool false "export in background"
+// @Success 200 {object} handler.RespBody{data=schema.AdminExportJob}
+// @Router /answer/admin/api/question/export [get]
+func (ec *AdminExportController) ExportQuestions(ctx *gin.Context) {
+	req := &schema.AdminQuestionExportReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	ec.export(ctx, ec.adminExportService.QuestionExport(req))
+}
+
+// ExportAnswers export the filtered admin answer list as csv
+// @Summary export the filtered admin answer list as csv
+// @Description export the filtered admin answer list as csv, the large list is exported in background and the export job is returned
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce text/csv,json
+// @Param status query string false "answer status" Enums(available,deleted,pending)
+// @Param query query string false "answer id or question title"
+// @Param question_id query string false "question id"
+// @Param provenance query string false "answer provenance" Enums(ai_assisted, imported, sourced)
+// @Param import_batch query string false "import batch"
+// @Param async query bool false "export in background"
+// @Success 200 {object} handler.RespBody{data=schema.AdminExportJob}
+// @Router /answer/admin/api/answer/export [get]
+func (ec *AdminExportController) ExportAnswers(ctx *gin.Context) {
+	req := &schema.AdminAnswerExportReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	ec.export(ctx, ec.adminExportService.AnswerExport(req))
+}
+
+// ExportUsers export the filtered admin user list as csv
+// @Summary export the filtered admin user list as csv
+// @Description export the filtered admin user list as csv, the large list is exported in background and the export job is returned
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce text/csv,json
+// @Param query query string false "search query: email, username or id:[id]"
+// @Param staff query bool false "staff user"
+// @Param status query string false "user status" Enums(suspended, deleted, inactive)
+// @Param async query bool false "export in background"
+// @Success 200 {object} handler.RespBody{data=schema.AdminExportJob}
+// @Router /answer/admin/api/users/export [get]
+func (ec *AdminExportController) ExportUsers(ctx *gin.Context) {
+	req := &schema.AdminUserExportReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	ec.export(ctx, ec.adminExportService.UserExport(req))
+}
+
+// ExportAuditLogs export the filtered audit logs as csv
+// @Summary export the filtered audit logs as csv
+// @Description export the filtered audit logs as csv, the large list is exported in background and the export job is returned
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce text/csv,json
+// @Param operator query string false "username of operator"
+// @Param action query string false "action"
+// @Param object_type query string false "object type"
+// @Param object_id query string false "object id"
+// @Param start_time query int false "start time, unix timestamp in seconds"
+// @Param end_time query int false "end time, unix timestamp in seconds"
+// @Param async query bool false "export in background"
+// @Success 200 {object} handler.RespBody{data=schema.AdminExportJob}
+// @Router /answer/admin/api/audit-log/export [get]
+func (ec *AdminExportController) ExportAuditLogs(ctx *gin.Context) {
+	req := &schema.AuditLogExportReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	ec.export(ctx, ec.adminExportService.AuditLogExport(req))
+}
+
+// export write the csv directly if the list is small, otherwise create an export job
+func (ec *AdminExportController) export(ctx *gin.Context, export *admin_export.AdminExport) {
+	async, err := ec.adminExportService.IsAsync(ctx, export)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if async {
+		job, err := ec.adminExportService.CreateExportJob(ctx, export)
+		handler.HandleResponse(ctx, err, job)
+		return
+	}
+	ctx.Header("Content-Type", "text/csv; charset=utf-8")
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", export.FileName()))
+	if _, err = ec.adminExportService.WriteCSV(ctx, export, ctx.Writer); err != nil {
+		log.Errorf("export admin list failed: %v", err)
+	}
+}
+
+// GetExportJob get the admin export job
+// @Summary get the admin export job
+// @Description get the status of the admin export job, the download url is returned when it is done
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param job_id path string true "export job id"
+// @Success 200 {object} handler.RespBody{data=schema.AdminExportJob}
+// @Router /answer/admin/api/export/{job_id} [get]
+func (ec *AdminExportController) GetExportJob(ctx *gin.Context) {
+	req := &schema.GetAdminExportJobReq{JobID: ctx.Param("job_id")}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := ec.adminExportService.GetExportJob(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// DownloadExport download the csv file of the finished admin export job
+// @Summary download the csv file of the finished admin export job
+// @Description download the csv file of the finished admin export job
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce text/csv
+// @Param job_id path string true "export job id"
+// @Success 200 {file} file
+// @Router /answer/admin/api/export/{job_id}/download [get]
+func (ec *AdminExportController) DownloadExport(ctx *gin.Context) {
+	req := &schema.GetAdminExportJobReq{JobID: ctx.Param("job_id")}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	filePath, fileName, err := ec.adminExportService.GetExportFile(ctx, req)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	ctx.FileAttachment(filePath, fileName)
+}
@@ -0,0 +1,61 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package controller_admin
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/audit_log"
+	"github.com/gin-gonic/gin"
+)
+
+// AuditLogController audit log controller
+type AuditLogController struct {
+	auditLogService *audit_log.AuditLogService
+}
+
+// NewAuditLogController new controller
+func NewAuditLogController(auditLogService *audit_log.AuditLogService) *AuditLogController {
+	return &AuditLogController{auditLogService: auditLogService}
+}
+
+// GetAuditLogPage get audit log page
+// @Summary get audit log page
+// @Description get the privileged actions done by admins and moderators, filtered by operator, action, object and time
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param operator query string false "username of operator"
+// @Param action query string false "action, such as user.status, setting.edit"
+// @Param object_type query string false "object type" Enums(user, question, answer, comment, setting)
+// @Param object_id query string false "object id"
+// @Param start_time query int false "start time, unix timestamp in seconds"
+// @Param end_time query int false "end time, unix timestamp in seconds"
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.AuditLogItem}}
+// @Router /answer/admin/api/audit-log/page [get]
+func (ac *AuditLogController) GetAuditLogPage(ctx *gin.Context) {
+	req := &schema.GetAuditLogPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := ac.auditLogService.GetAuditLogPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
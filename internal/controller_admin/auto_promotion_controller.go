@@ -0,0 +1,70 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/auto_promotion"
+	"github.com/gin-gonic/gin"
+)
+
+// AutoPromotionController auto promotion controller
+type AutoPromotionController struct {
+	autoPromotionService *auto_promotion.AutoPromotionService
+}
+
+// NewAutoPromotionController new controller
+func NewAutoPromotionController(autoPromotionService *auto_promotion.AutoPromotionService) *AutoPromotionController {
+	return &AutoPromotionController{autoPromotionService: autoPromotionService}
+}
+
+// GetAutoPromotionLogs get the audit trail of the roles granted and revoked by the auto promotion
+// @Summary get the audit trail of the roles granted and revoked by the auto promotion
+// @Description get the audit trail of the roles granted and revoked by the auto promotion, the latest first
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param user_id query string false "user id"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.AutoPromotionLogItem}}
+// @Router /answer/admin/api/auto-promotion/logs [get]
+func (ac *AutoPromotionController) GetAutoPromotionLogs(ctx *gin.Context) {
+	req := &schema.GetAutoPromotionLogsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := ac.autoPromotionService.GetAutoPromotionLogs(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// EvaluateAutoPromotion evaluate the auto promotion rules now
+// @Summary evaluate the auto promotion rules now
+// @Description evaluate the auto promotion rules without waiting for the daily evaluation, runs in background
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/auto-promotion/evaluate [post]
+func (ac *AutoPromotionController) EvaluateAutoPromotion(ctx *gin.Context) {
+	err := ac.autoPromotionService.TriggerEvaluate(ctx)
+	handler.HandleResponse(ctx, err, nil)
+}
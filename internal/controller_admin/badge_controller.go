@@ -84,3 +84,17 @@ func (b *BadgeController) UpdateBadgeStatus(ctx *gin.Context) {
 	err := b.badgeService.UpdateStatus(ctx, req)
 	handler.HandleResponse(ctx, err, nil)
 }
+
+// BackfillTagBadges award the tag badges users have already reached
+// @Summary award the tag badges users have already reached
+// @Description award the tag badges for the answer score users have already reached in each tag, runs in background
+// @Tags AdminBadge
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/badge/tag/backfill [post]
+func (b *BadgeController) BackfillTagBadges(ctx *gin.Context) {
+	err := b.badgeService.TriggerTagBadgeBackfill(ctx)
+	handler.HandleResponse(ctx, err, nil)
+}
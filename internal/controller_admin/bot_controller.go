@@ -0,0 +1,94 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/bot"
+	"github.com/gin-gonic/gin"
+)
+
+// BotController bot controller
+type BotController struct {
+	botService *bot.BotService
+}
+
+// NewBotController new controller
+func NewBotController(botService *bot.BotService) *BotController {
+	return &BotController{botService: botService}
+}
+
+// GetBotPage get bot page
+// @Summary get bot page
+// @Description get bot page, the latest created first
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.BotItem}}
+// @Router /answer/admin/api/bots/page [get]
+func (bc *BotController) GetBotPage(ctx *gin.Context) {
+	req := &schema.GetBotPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := bc.botService.GetBotPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddBot add bot account
+// @Summary add bot account
+// @Description add bot account, the token is only returned once and used as the Authorization header
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.AddBotReq true "bot"
+// @Success 200 {object} handler.RespBody{data=schema.AddBotResp}
+// @Router /answer/admin/api/bot [post]
+func (bc *BotController) AddBot(ctx *gin.Context) {
+	req := &schema.AddBotReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := bc.botService.AddBot(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RegenerateBotToken regenerate bot token
+// @Summary regenerate bot token
+// @Description regenerate bot token, the previous token is revoked immediately
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.RegenerateBotTokenReq true "bot"
+// @Success 200 {object} handler.RespBody{data=schema.RegenerateBotTokenResp}
+// @Router /answer/admin/api/bot/token [put]
+func (bc *BotController) RegenerateBotToken(ctx *gin.Context) {
+	req := &schema.RegenerateBotTokenReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := bc.botService.RegenerateBotToken(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/content_freshness"
+	"github.com/gin-gonic/gin"
+)
+
+// ContentFreshnessController content freshness controller
+type ContentFreshnessController struct {
+	contentFreshnessService *content_freshness.ContentFreshnessService
+}
+
+// NewContentFreshnessController new controller
+func NewContentFreshnessController(contentFreshnessService *content_freshness.ContentFreshnessService) *ContentFreshnessController {
+	return &ContentFreshnessController{contentFreshnessService: contentFreshnessService}
+}
+
+// GetContentFreshnessReport get the content freshness report
+// @Summary get the content freshness report
+// @Description get the freshness metrics of all active tags and the page of active tags, the most stale first
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=schema.GetContentFreshnessReportResp}
+// @Router /answer/admin/api/content-freshness/report [get]
+func (cc *ContentFreshnessController) GetContentFreshnessReport(ctx *gin.Context) {
+	req := &schema.GetContentFreshnessReportReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := cc.contentFreshnessService.GetContentFreshnessReport(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AnalyzeContentFreshness analyze the content freshness now
+// @Summary analyze the content freshness now
+// @Description analyze the content freshness without waiting for the daily analysis, runs in background
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/content-freshness/analyze [post]
+func (cc *ContentFreshnessController) AnalyzeContentFreshness(ctx *gin.Context) {
+	err := cc.contentFreshnessService.TriggerAnalyze(ctx)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,49 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/service/content_language"
+	"github.com/gin-gonic/gin"
+)
+
+// ContentLanguageController content language controller
+type ContentLanguageController struct {
+	contentLanguageService *content_language.ContentLanguageService
+}
+
+// NewContentLanguageController new controller
+func NewContentLanguageController(contentLanguageService *content_language.ContentLanguageService) *ContentLanguageController {
+	return &ContentLanguageController{contentLanguageService: contentLanguageService}
+}
+
+// GetLanguageStats get the statistics of the detected post languages
+// @Summary get the statistics of the detected post languages
+// @Description get the statistics of the detected post languages, the most posts first
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]schema.GetContentLanguageStatResp}
+// @Router /answer/admin/api/content-language/stats [get]
+func (cc *ContentLanguageController) GetLanguageStats(ctx *gin.Context) {
+	resp, err := cc.contentLanguageService.GetLanguageStats(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -29,4 +29,24 @@ var ProviderSetController = wire.NewSet(
 	NewRoleController,
 	NewPluginController,
 	NewBadgeController,
+	NewReputationController,
+	NewVoteFraudController,
+	NewContentLanguageController,
+	NewUserTimelineController,
+	NewAdminExportController,
+	NewLoadSheddingController,
+	NewQueryStatsController,
+	NewAutoPromotionController,
+	NewContentFreshnessController,
+	NewBotController,
+	NewWebhookController,
+	NewMessengerController,
+	NewPermissionController,
+	NewEmailTemplateController,
+	NewOAuthClientController,
+	NewUserSessionController,
+	NewInviteCodeController,
+	NewAuditLogController,
+	NewIPBanController,
+	NewUserImportController,
 )
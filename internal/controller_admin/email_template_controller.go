@@ -0,0 +1,107 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/email_template"
+	"github.com/gin-gonic/gin"
+)
+
+// EmailTemplateController email template controller
+type EmailTemplateController struct {
+	emailTemplateService *email_template.EmailTemplateService
+}
+
+// NewEmailTemplateController new controller
+func NewEmailTemplateController(emailTemplateService *email_template.EmailTemplateService) *EmailTemplateController {
+	return &EmailTemplateController{emailTemplateService: emailTemplateService}
+}
+
+// GetEmailTemplates get email templates
+// @Summary get email templates
+// @Description get the email templates could be customized with the placeholders and customized languages
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]schema.EmailTemplateItem}
+// @Router /answer/admin/api/email-templates [get]
+func (ec *EmailTemplateController) GetEmailTemplates(ctx *gin.Context) {
+	resp, err := ec.emailTemplateService.GetEmailTemplates(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetEmailTemplate get email template
+// @Summary get email template
+// @Description get the email template of the language with the default template
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param key query string true "template key"
+// @Param language query string true "language"
+// @Success 200 {object} handler.RespBody{data=schema.GetEmailTemplateResp}
+// @Router /answer/admin/api/email-template [get]
+func (ec *EmailTemplateController) GetEmailTemplate(ctx *gin.Context) {
+	req := &schema.GetEmailTemplateReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := ec.emailTemplateService.GetEmailTemplate(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// SaveEmailTemplate save email template
+// @Summary save email template
+// @Description save the custom email template of the language, the placeholders are validated
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.SaveEmailTemplateReq true "email template"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/email-template [put]
+func (ec *EmailTemplateController) SaveEmailTemplate(ctx *gin.Context) {
+	req := &schema.SaveEmailTemplateReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := ec.emailTemplateService.SaveEmailTemplate(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// DeleteEmailTemplate delete email template
+// @Summary delete email template
+// @Description delete the custom email template of the language, the default template is used again
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.DeleteEmailTemplateReq true "email template"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/email-template [delete]
+func (ec *EmailTemplateController) DeleteEmailTemplate(ctx *gin.Context) {
+	req := &schema.DeleteEmailTemplateReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := ec.emailTemplateService.DeleteEmailTemplate(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,117 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/invite_code"
+	"github.com/gin-gonic/gin"
+)
+
+// InviteCodeController invite code controller
+type InviteCodeController struct {
+	inviteCodeService *invite_code.InviteCodeService
+}
+
+// NewInviteCodeController new controller
+func NewInviteCodeController(inviteCodeService *invite_code.InviteCodeService) *InviteCodeController {
+	return &InviteCodeController{inviteCodeService: inviteCodeService}
+}
+
+// GetInviteCodePage get invite code page
+// @Summary get invite code page
+// @Description get all the invite codes generated by the admins and users
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.InviteCodeItem}}
+// @Router /answer/admin/api/invite-codes/page [get]
+func (ic *InviteCodeController) GetInviteCodePage(ctx *gin.Context) {
+	req := &schema.GetInviteCodePageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := ic.inviteCodeService.GetInviteCodePage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddInviteCode add invite code
+// @Summary add invite code
+// @Description generate an invite code without the limits of the users
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.AddInviteCodeReq true "invite code"
+// @Success 200 {object} handler.RespBody{data=schema.InviteCodeItem}
+// @Router /answer/admin/api/invite-code [post]
+func (ic *InviteCodeController) AddInviteCode(ctx *gin.Context) {
+	req := &schema.AddInviteCodeReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.IsAdmin = true
+	resp, err := ic.inviteCodeService.AddInviteCode(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RemoveInviteCode remove invite code
+// @Summary remove invite code
+// @Description revoke any invite code, the users registered with it are kept
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.RemoveInviteCodeReq true "invite code"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/invite-code [delete]
+func (ic *InviteCodeController) RemoveInviteCode(ctx *gin.Context) {
+	req := &schema.RemoveInviteCodeReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.IsAdmin = true
+	err := ic.inviteCodeService.RemoveInviteCode(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetInvitedUsers get invited users
+// @Summary get invited users
+// @Description get the users registered with the invite code
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param id query string true "invite code id"
+// @Success 200 {object} handler.RespBody{data=[]schema.InvitedUserItem}
+// @Router /answer/admin/api/invite-code/users [get]
+func (ic *InviteCodeController) GetInvitedUsers(ctx *gin.Context) {
+	req := &schema.GetInvitedUsersReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.IsAdmin = true
+	resp, err := ic.inviteCodeService.GetInvitedUsers(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
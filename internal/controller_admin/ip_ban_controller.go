@@ -0,0 +1,97 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/ip_ban"
+	"github.com/gin-gonic/gin"
+)
+
+// IPBanController ip ban controller
+type IPBanController struct {
+	ipBanService *ip_ban.IPBanService
+}
+
+// NewIPBanController new controller
+func NewIPBanController(ipBanService *ip_ban.IPBanService) *IPBanController {
+	return &IPBanController{ipBanService: ipBanService}
+}
+
+// GetIPBanPage get ip ban page
+// @Summary get ip ban page
+// @Description get the banned ip and CIDR ranges with the hit counts
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.IPBanItem}}
+// @Router /answer/admin/api/ip-bans/page [get]
+func (ic *IPBanController) GetIPBanPage(ctx *gin.Context) {
+	req := &schema.GetIPBanPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := ic.ipBanService.GetIPBanPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddIPBan add ip ban
+// @Summary add ip ban
+// @Description ban the ip or CIDR range from registering and posting
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.AddIPBanReq true "ip ban"
+// @Success 200 {object} handler.RespBody{data=schema.IPBanItem}
+// @Router /answer/admin/api/ip-ban [post]
+func (ic *IPBanController) AddIPBan(ctx *gin.Context) {
+	req := &schema.AddIPBanReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := ic.ipBanService.AddIPBan(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RemoveIPBan remove ip ban
+// @Summary remove ip ban
+// @Description lift the ban of the ip or CIDR range
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.RemoveIPBanReq true "ip ban"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/ip-ban [delete]
+func (ic *IPBanController) RemoveIPBan(ctx *gin.Context) {
+	req := &schema.RemoveIPBanReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := ic.ipBanService.RemoveIPBan(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
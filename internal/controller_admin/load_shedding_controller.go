@@ -0,0 +1,49 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// LoadSheddingController load shedding controller
+type LoadSheddingController struct {
+	loadSheddingMiddleware *middleware.LoadSheddingMiddleware
+}
+
+// NewLoadSheddingController new controller
+func NewLoadSheddingController(loadSheddingMiddleware *middleware.LoadSheddingMiddleware) *LoadSheddingController {
+	return &LoadSheddingController{loadSheddingMiddleware: loadSheddingMiddleware}
+}
+
+// GetLoadSheddingMetrics get load shedding metrics
+// @Summary get load shedding metrics
+// @Description get the in-flight requests, queue depth, latency and the shed counters of this server
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.LoadSheddingMetricsResp}
+// @Router /answer/admin/api/load-shedding/metrics [get]
+func (lc *LoadSheddingController) GetLoadSheddingMetrics(ctx *gin.Context) {
+	resp, err := lc.loadSheddingMiddleware.Metrics(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -0,0 +1,56 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/messenger"
+	"github.com/gin-gonic/gin"
+)
+
+// MessengerController messenger connector controller
+type MessengerController struct {
+	messengerService *messenger.MessengerService
+}
+
+// NewMessengerController new controller
+func NewMessengerController(messengerService *messenger.MessengerService) *MessengerController {
+	return &MessengerController{messengerService: messengerService}
+}
+
+// TestMessengerConnector test messenger connector
+// @Summary test messenger connector
+// @Description send a test message to the telegram chat or discord channel of the saved connector
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.TestMessengerConnectorReq true "connector"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/messenger/connector/test [post]
+func (mc *MessengerController) TestMessengerConnector(ctx *gin.Context) {
+	req := &schema.TestMessengerConnectorReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := mc.messengerService.TestConnector(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,126 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/oauth"
+	"github.com/gin-gonic/gin"
+)
+
+// OAuthClientController OAuth client controller
+type OAuthClientController struct {
+	oauthService *oauth.OAuthService
+}
+
+// NewOAuthClientController new controller
+func NewOAuthClientController(oauthService *oauth.OAuthService) *OAuthClientController {
+	return &OAuthClientController{oauthService: oauthService}
+}
+
+// GetOAuthClients get OAuth clients
+// @Summary get OAuth clients
+// @Description get the OAuth clients logging in with the site, the client secrets are not returned
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]schema.OAuthClientItem}
+// @Router /answer/admin/api/oauth-clients [get]
+func (oc *OAuthClientController) GetOAuthClients(ctx *gin.Context) {
+	resp, err := oc.oauthService.GetOAuthClients(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddOAuthClient add OAuth client
+// @Summary add OAuth client
+// @Description register the OAuth client with the redirect uris, the client secret is only shown once
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.AddOAuthClientReq true "OAuth client"
+// @Success 200 {object} handler.RespBody{data=schema.AddOAuthClientResp}
+// @Router /answer/admin/api/oauth-client [post]
+func (oc *OAuthClientController) AddOAuthClient(ctx *gin.Context) {
+	req := &schema.AddOAuthClientReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := oc.oauthService.AddOAuthClient(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateOAuthClient update OAuth client
+// @Summary update OAuth client
+// @Description update the name and the redirect uris of the OAuth client
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.UpdateOAuthClientReq true "OAuth client"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/oauth-client [put]
+func (oc *OAuthClientController) UpdateOAuthClient(ctx *gin.Context) {
+	req := &schema.UpdateOAuthClientReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := oc.oauthService.UpdateOAuthClient(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RegenerateOAuthClientSecret regenerate OAuth client secret
+// @Summary regenerate OAuth client secret
+// @Description regenerate the secret of the OAuth client, the previous secret is revoked
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.RegenerateOAuthClientSecretReq true "OAuth client"
+// @Success 200 {object} handler.RespBody{data=schema.RegenerateOAuthClientSecretResp}
+// @Router /answer/admin/api/oauth-client/secret [put]
+func (oc *OAuthClientController) RegenerateOAuthClientSecret(ctx *gin.Context) {
+	req := &schema.RegenerateOAuthClientSecretReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := oc.oauthService.RegenerateOAuthClientSecret(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RemoveOAuthClient remove OAuth client
+// @Summary remove OAuth client
+// @Description remove the OAuth client, the access tokens issued to it are revoked
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.RemoveOAuthClientReq true "OAuth client"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/oauth-client [delete]
+func (oc *OAuthClientController) RemoveOAuthClient(ctx *gin.Context) {
+	req := &schema.RemoveOAuthClientReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := oc.oauthService.RemoveOAuthClient(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
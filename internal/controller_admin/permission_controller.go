@@ -0,0 +1,58 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/rank"
+	"github.com/gin-gonic/gin"
+)
+
+// PermissionController permission controller
+type PermissionController struct {
+	rankService *rank.RankService
+}
+
+// NewPermissionController new controller
+func NewPermissionController(rankService *rank.RankService) *PermissionController {
+	return &PermissionController{rankService: rankService}
+}
+
+// ExplainPermission explain permission
+// @Summary explain permission
+// @Description explain whether the user can operate the action on the object with the trace of every check,
+// @Description the role, ownership, reputation threshold, tag moderator, user status and object status
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param user_id query string true "user id"
+// @Param action query string true "permission action, e.g. question.edit"
+// @Param object_id query string false "object id"
+// @Success 200 {object} handler.RespBody{data=schema.ExplainPermissionResp}
+// @Router /answer/admin/api/permission/explain [get]
+func (pc *PermissionController) ExplainPermission(ctx *gin.Context) {
+	req := &schema.ExplainPermissionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := pc.rankService.ExplainPermission(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -0,0 +1,77 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/schema"
+	"github.com/gin-gonic/gin"
+)
+
+// QueryStatsController database query stats controller
+type QueryStatsController struct {
+	queryStats *data.QueryStats
+}
+
+// NewQueryStatsController new controller
+func NewQueryStatsController(queryStats *data.QueryStats) *QueryStatsController {
+	return &QueryStatsController{queryStats: queryStats}
+}
+
+// GetSlowQueryReport get slow query report
+// @Summary get slow query report
+// @Description get the latest slow database queries grouped by the handler that executed them and the normalized sql
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param origin query string false "only the queries executed by this handler, e.g. GET /answer/api/v1/question/page"
+// @Param limit query int false "limit"
+// @Success 200 {object} handler.RespBody{data=schema.GetSlowQueryReportResp}
+// @Router /answer/admin/api/slow-queries [get]
+func (qc *QueryStatsController) GetSlowQueryReport(ctx *gin.Context) {
+	req := &schema.GetSlowQueryReportReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	total, slow, since := qc.queryStats.Counters()
+	resp := &schema.GetSlowQueryReportResp{
+		ThresholdMs:  qc.queryStats.Threshold().Milliseconds(),
+		TotalQueries: total,
+		SlowQueries:  slow,
+		Since:        since.Unix(),
+		Items:        schema.NewSlowQueryReportItems(qc.queryStats.SlowQueries(), req.Origin, req.Limit),
+	}
+	handler.HandleResponse(ctx, nil, resp)
+}
+
+// ResetSlowQueryReport reset slow query report
+// @Summary reset slow query report
+// @Description clear the recorded slow queries and counters, e.g. after deploying an optimization
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/slow-queries [delete]
+func (qc *QueryStatsController) ResetSlowQueryReport(ctx *gin.Context) {
+	qc.queryStats.Reset()
+	handler.HandleResponse(ctx, nil, nil)
+}
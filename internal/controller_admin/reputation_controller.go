@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/rank"
+	"github.com/gin-gonic/gin"
+)
+
+// ReputationController reputation controller
+type ReputationController struct {
+	reputationRecalcService *rank.ReputationRecalcService
+}
+
+// NewReputationController new controller
+func NewReputationController(reputationRecalcService *rank.ReputationRecalcService) *ReputationController {
+	return &ReputationController{reputationRecalcService: reputationRecalcService}
+}
+
+// RecalculateReputation recalculate the user reputation
+// @Summary recalculate the user reputation
+// @Description replay the reputation activities to rebuild the user reputation in background
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.ReputationRecalcReq true "recalculation options"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/reputation/recalculate [post]
+func (rc *ReputationController) RecalculateReputation(ctx *gin.Context) {
+	req := &schema.ReputationRecalcReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := rc.reputationRecalcService.StartRecalculate(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetRecalculateReputationProgress get the progress of reputation recalculation
+// @Summary get the progress of reputation recalculation
+// @Description get the progress of the current or last reputation recalculation
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.ReputationRecalcProgress}
+// @Router /answer/admin/api/reputation/recalculate [get]
+func (rc *ReputationController) GetRecalculateReputationProgress(ctx *gin.Context) {
+	resp := rc.reputationRecalcService.GetProgress(ctx)
+	handler.HandleResponse(ctx, nil, resp)
+}
@@ -20,20 +20,46 @@
 package controller_admin
 
 import (
+	"strconv"
+
 	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/audit_log"
 	service "github.com/apache/answer/internal/service/role"
 	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/log"
 )
 
 // RoleController role controller
 type RoleController struct {
-	roleService *service.RoleService
+	roleService     *service.RoleService
+	auditLogService *audit_log.AuditLogService
+}
+
+// NewRoleController new controller, the role changes are recorded in the audit log here
+// because the audit log service depends on the role service
+func NewRoleController(roleService *service.RoleService, auditLogService *audit_log.AuditLogService) *RoleController {
+	return &RoleController{roleService: roleService, auditLogService: auditLogService}
 }
 
-// NewRoleController new controller
-func NewRoleController(roleService *service.RoleService) *RoleController {
-	return &RoleController{roleService: roleService}
+// recordRoleAuditLog record the role change with the role values before and after it
+func (rc *RoleController) recordRoleAuditLog(ctx *gin.Context, action string, roleID int, before any) {
+	var after any
+	if action != entity.AuditLogActionRoleRemove {
+		value, err := rc.roleService.GetRoleAuditValue(ctx, roleID)
+		if err != nil {
+			log.Error(err)
+		}
+		after = value
+	}
+	rc.auditLogService.Record(ctx, &schema.AuditLogRecord{
+		Action:     action,
+		ObjectType: entity.AuditLogObjectTypeRole,
+		ObjectID:   strconv.Itoa(roleID),
+		Before:     before,
+		After:      after,
+	})
 }
 
 // GetRoleList get role list
@@ -52,3 +78,110 @@ func (rc *RoleController) GetRoleList(ctx *gin.Context) {
 	resp, err := rc.roleService.GetRoleList(ctx)
 	handler.HandleResponse(ctx, err, resp)
 }
+
+// GetPowerList get power list
+// @Summary get all the powers that can be granted to the role
+// @Description get all the powers that can be granted to the role
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]schema.GetPowerResp}
+// @Router /answer/admin/api/powers [get]
+func (rc *RoleController) GetPowerList(ctx *gin.Context) {
+	resp, err := rc.roleService.GetPowerList(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetRolePowers get role powers
+// @Summary get the powers granted to the role
+// @Description get the powers granted to the role
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param role_id query int true "role id"
+// @Success 200 {object} handler.RespBody{data=schema.GetRolePowersResp}
+// @Router /answer/admin/api/role/powers [get]
+func (rc *RoleController) GetRolePowers(ctx *gin.Context) {
+	req := &schema.GetRolePowersReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := rc.roleService.GetRolePowers(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddRole add role
+// @Summary add custom role with powers
+// @Description add custom role with powers
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.AddRoleReq true "role"
+// @Success 200 {object} handler.RespBody{data=schema.AddRoleResp}
+// @Router /answer/admin/api/role [post]
+func (rc *RoleController) AddRole(ctx *gin.Context) {
+	req := &schema.AddRoleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := rc.roleService.AddRole(ctx, req)
+	if err == nil {
+		rc.recordRoleAuditLog(ctx, entity.AuditLogActionRoleAdd, resp.ID, nil)
+	}
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateRole update role
+// @Summary update role and its powers
+// @Description update role and its powers, the name of built-in roles and the powers of admin can not be modified
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.UpdateRoleReq true "role"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/role [put]
+func (rc *RoleController) UpdateRole(ctx *gin.Context) {
+	req := &schema.UpdateRoleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	before, err := rc.roleService.GetRoleAuditValue(ctx, req.ID)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	err = rc.roleService.UpdateRole(ctx, req)
+	if err == nil {
+		rc.recordRoleAuditLog(ctx, entity.AuditLogActionRoleUpdate, req.ID, before)
+	}
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RemoveRole remove role
+// @Summary remove custom role
+// @Description remove custom role which is not assigned to any user
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.RemoveRoleReq true "role"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/role [delete]
+func (rc *RoleController) RemoveRole(ctx *gin.Context) {
+	req := &schema.RemoveRoleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	before, err := rc.roleService.GetRoleAuditValue(ctx, req.ID)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	err = rc.roleService.RemoveRole(ctx, req)
+	if err == nil {
+		rc.recordRoleAuditLog(ctx, entity.AuditLogActionRoleRemove, req.ID, before)
+	}
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -398,6 +398,1173 @@ func (sc *SiteInfoController) UpdateSiteUsers(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, nil)
 }
 
+// GetSiteLimits get site content limits
+// @Summary get site content limits
+// @Description get site content limits
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteLimitsResp}
+// @Router /answer/admin/api/siteinfo/limits [get]
+func (sc *SiteInfoController) GetSiteLimits(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteLimits(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteLimits update site content limits
+// @Summary update site content limits
+// @Description update site content limits
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteLimitsReq true "limits info"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/limits [put]
+func (sc *SiteInfoController) UpdateSiteLimits(ctx *gin.Context) {
+	req := &schema.SiteLimitsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteLimits(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteQuestionClose get site question close vote config
+// @Summary get site question close vote config
+// @Description get site question close vote config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteQuestionCloseResp}
+// @Router /answer/admin/api/siteinfo/question-close [get]
+func (sc *SiteInfoController) GetSiteQuestionClose(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteQuestionClose(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteQuestionClose update site question close vote config
+// @Summary update site question close vote config
+// @Description update site question close vote config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteQuestionCloseReq true "question close vote config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/question-close [put]
+func (sc *SiteInfoController) UpdateSiteQuestionClose(ctx *gin.Context) {
+	req := &schema.SiteQuestionCloseReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteQuestionClose(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteRateLimit get site rate limit config
+// @Summary get site rate limit config
+// @Description get site rate limit config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteRateLimitResp}
+// @Router /answer/admin/api/siteinfo/rate-limit [get]
+func (sc *SiteInfoController) GetSiteRateLimit(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteRateLimit(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteRateLimit update site rate limit config
+// @Summary update site rate limit config
+// @Description update site rate limit config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteRateLimitReq true "rate limit config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/rate-limit [put]
+func (sc *SiteInfoController) UpdateSiteRateLimit(ctx *gin.Context) {
+	req := &schema.SiteRateLimitReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteRateLimit(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteDataDump get site public data dump config
+// @Summary get site public data dump config
+// @Description get site public data dump config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteDataDumpResp}
+// @Router /answer/admin/api/siteinfo/data-dump [get]
+func (sc *SiteInfoController) GetSiteDataDump(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteDataDump(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteDataDump update site public data dump config
+// @Summary update site public data dump config
+// @Description update site public data dump config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteDataDumpReq true "data dump config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/data-dump [put]
+func (sc *SiteInfoController) UpdateSiteDataDump(ctx *gin.Context) {
+	req := &schema.SiteDataDumpReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteDataDump(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteReputationSync get site reputation and role sync config
+// @Summary get site reputation and role sync config
+// @Description get site reputation and role sync config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteReputationSyncResp}
+// @Router /answer/admin/api/siteinfo/reputation-sync [get]
+func (sc *SiteInfoController) GetSiteReputationSync(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteReputationSync(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteReputationSync update site reputation and role sync config
+// @Summary update site reputation and role sync config
+// @Description update site reputation and role sync config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteReputationSyncReq true "reputation sync config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/reputation-sync [put]
+func (sc *SiteInfoController) UpdateSiteReputationSync(ctx *gin.Context) {
+	req := &schema.SiteReputationSyncReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteReputationSync(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteChatIntake get site chat intake config
+// @Summary get site chat intake config
+// @Description get site chat intake config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteChatIntakeResp}
+// @Router /answer/admin/api/siteinfo/chat-intake [get]
+func (sc *SiteInfoController) GetSiteChatIntake(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteChatIntake(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteChatIntake update site chat intake config
+// @Summary update site chat intake config
+// @Description update site chat intake config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteChatIntakeReq true "chat intake config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/chat-intake [put]
+func (sc *SiteInfoController) UpdateSiteChatIntake(ctx *gin.Context) {
+	req := &schema.SiteChatIntakeReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteChatIntake(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteVoteFraud get site vote fraud detection config
+// @Summary get site vote fraud detection config
+// @Description get site vote fraud detection config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteVoteFraudResp}
+// @Router /answer/admin/api/siteinfo/vote-fraud [get]
+func (sc *SiteInfoController) GetSiteVoteFraud(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteVoteFraud(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteVoteFraud update site vote fraud detection config
+// @Summary update site vote fraud detection config
+// @Description update site vote fraud detection config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteVoteFraudReq true "vote fraud config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/vote-fraud [put]
+func (sc *SiteInfoController) UpdateSiteVoteFraud(ctx *gin.Context) {
+	req := &schema.SiteVoteFraudReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteVoteFraud(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteAnswerRanking get site answer ranking config
+// @Summary get site answer ranking config
+// @Description get site answer ranking config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteAnswerRankingResp}
+// @Router /answer/admin/api/siteinfo/answer-ranking [get]
+func (sc *SiteInfoController) GetSiteAnswerRanking(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteAnswerRanking(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteAnswerRanking update site answer ranking config
+// @Summary update site answer ranking config
+// @Description update site answer ranking config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteAnswerRankingReq true "answer ranking config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/answer-ranking [put]
+func (sc *SiteInfoController) UpdateSiteAnswerRanking(ctx *gin.Context) {
+	req := &schema.SiteAnswerRankingReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteAnswerRanking(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteLanguageDetection get site content language detection config
+// @Summary get site content language detection config
+// @Description get site content language detection config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteLanguageDetectionResp}
+// @Router /answer/admin/api/siteinfo/language-detection [get]
+func (sc *SiteInfoController) GetSiteLanguageDetection(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteLanguageDetection(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteLanguageDetection update site content language detection config
+// @Summary update site content language detection config
+// @Description update site content language detection config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteLanguageDetectionReq true "content language detection config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/language-detection [put]
+func (sc *SiteInfoController) UpdateSiteLanguageDetection(ctx *gin.Context) {
+	req := &schema.SiteLanguageDetectionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteLanguageDetection(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteDownvoteCost get site downvote cost config
+// @Summary get site downvote cost config
+// @Description get site downvote cost config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteDownvoteCostResp}
+// @Router /answer/admin/api/siteinfo/downvote-cost [get]
+func (sc *SiteInfoController) GetSiteDownvoteCost(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteDownvoteCost(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteDownvoteCost update site downvote cost config
+// @Summary update site downvote cost config
+// @Description update site downvote cost config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteDownvoteCostReq true "downvote cost config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/downvote-cost [put]
+func (sc *SiteInfoController) UpdateSiteDownvoteCost(ctx *gin.Context) {
+	req := &schema.SiteDownvoteCostReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteDownvoteCost(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteSyndication get site question syndication config
+// @Summary get site question syndication config
+// @Description get site question syndication config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteSyndicationResp}
+// @Router /answer/admin/api/siteinfo/syndication [get]
+func (sc *SiteInfoController) GetSiteSyndication(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteSyndication(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteSyndication update site question syndication config
+// @Summary update site question syndication config
+// @Description update site question syndication config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteSyndicationReq true "question syndication config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/syndication [put]
+func (sc *SiteInfoController) UpdateSiteSyndication(ctx *gin.Context) {
+	req := &schema.SiteSyndicationReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteSyndication(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteHelpfulnessSurvey get site helpfulness survey config
+// @Summary get site helpfulness survey config
+// @Description get site helpfulness survey config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteHelpfulnessSurveyResp}
+// @Router /answer/admin/api/siteinfo/helpfulness-survey [get]
+func (sc *SiteInfoController) GetSiteHelpfulnessSurvey(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteHelpfulnessSurvey(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteHelpfulnessSurvey update site helpfulness survey config
+// @Summary update site helpfulness survey config
+// @Description update site helpfulness survey config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteHelpfulnessSurveyReq true "helpfulness survey config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/helpfulness-survey [put]
+func (sc *SiteInfoController) UpdateSiteHelpfulnessSurvey(ctx *gin.Context) {
+	req := &schema.SiteHelpfulnessSurveyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteHelpfulnessSurvey(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteReEngagement get site re-engagement email config
+// @Summary get site re-engagement email config
+// @Description get site re-engagement email config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteReEngagementResp}
+// @Router /answer/admin/api/siteinfo/re-engagement [get]
+func (sc *SiteInfoController) GetSiteReEngagement(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteReEngagement(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteReEngagement update site re-engagement email config
+// @Summary update site re-engagement email config
+// @Description update site re-engagement email config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteReEngagementReq true "re-engagement email config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/re-engagement [put]
+func (sc *SiteInfoController) UpdateSiteReEngagement(ctx *gin.Context) {
+	req := &schema.SiteReEngagementReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteReEngagement(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteQuestionQuality get site question quality config
+// @Summary get site question quality config
+// @Description get site question quality config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteQuestionQualityResp}
+// @Router /answer/admin/api/siteinfo/question-quality [get]
+func (sc *SiteInfoController) GetSiteQuestionQuality(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteQuestionQuality(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteQuestionQuality update site question quality config
+// @Summary update site question quality config
+// @Description update site question quality config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteQuestionQualityReq true "question quality config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/question-quality [put]
+func (sc *SiteInfoController) UpdateSiteQuestionQuality(ctx *gin.Context) {
+	req := &schema.SiteQuestionQualityReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteQuestionQuality(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteTagRules get site tag rules config
+// @Summary get site tag rules config
+// @Description get site tag rules config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteTagRulesResp}
+// @Router /answer/admin/api/siteinfo/tag-rules [get]
+func (sc *SiteInfoController) GetSiteTagRules(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteTagRules(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteTagRules update site tag rules config
+// @Summary update site tag rules config
+// @Description update site tag rules config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteTagRulesReq true "tag rules config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/tag-rules [put]
+func (sc *SiteInfoController) UpdateSiteTagRules(ctx *gin.Context) {
+	req := &schema.SiteTagRulesReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteTagRules(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteVoteMilestone get site vote milestone config
+// @Summary get site vote milestone config
+// @Description get site vote milestone config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteVoteMilestoneResp}
+// @Router /answer/admin/api/siteinfo/vote-milestone [get]
+func (sc *SiteInfoController) GetSiteVoteMilestone(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteVoteMilestone(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteVoteMilestone update site vote milestone config
+// @Summary update site vote milestone config
+// @Description update site vote milestone config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteVoteMilestoneReq true "vote milestone config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/vote-milestone [put]
+func (sc *SiteInfoController) UpdateSiteVoteMilestone(ctx *gin.Context) {
+	req := &schema.SiteVoteMilestoneReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteVoteMilestone(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteAutoComment get site auto comment config
+// @Summary get site auto comment config
+// @Description get site auto comment config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteAutoCommentResp}
+// @Router /answer/admin/api/siteinfo/auto-comment [get]
+func (sc *SiteInfoController) GetSiteAutoComment(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteAutoComment(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteAutoComment update site auto comment config
+// @Summary update site auto comment config
+// @Description update site auto comment config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteAutoCommentReq true "auto comment config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/auto-comment [put]
+func (sc *SiteInfoController) UpdateSiteAutoComment(ctx *gin.Context) {
+	req := &schema.SiteAutoCommentReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteAutoComment(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteTagBlocklist get site tag blocklist config
+// @Summary get site tag blocklist config
+// @Description get site tag blocklist config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteTagBlocklistResp}
+// @Router /answer/admin/api/siteinfo/tag-blocklist [get]
+func (sc *SiteInfoController) GetSiteTagBlocklist(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteTagBlocklist(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteTagBlocklist update site tag blocklist config
+// @Summary update site tag blocklist config
+// @Description update site tag blocklist config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteTagBlocklistReq true "tag blocklist config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/tag-blocklist [put]
+func (sc *SiteInfoController) UpdateSiteTagBlocklist(ctx *gin.Context) {
+	req := &schema.SiteTagBlocklistReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteTagBlocklist(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteLoadShedding get site load shedding config
+// @Summary get site load shedding config
+// @Description get site load shedding config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteLoadSheddingResp}
+// @Router /answer/admin/api/siteinfo/load-shedding [get]
+func (sc *SiteInfoController) GetSiteLoadShedding(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteLoadShedding(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteLoadShedding update site load shedding config
+// @Summary update site load shedding config
+// @Description update site load shedding config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteLoadSheddingReq true "load shedding config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/load-shedding [put]
+func (sc *SiteInfoController) UpdateSiteLoadShedding(ctx *gin.Context) {
+	req := &schema.SiteLoadSheddingReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteLoadShedding(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteAutoPromotion get site auto promotion config
+// @Summary get site auto promotion config
+// @Description get site auto promotion config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteAutoPromotionResp}
+// @Router /answer/admin/api/siteinfo/auto-promotion [get]
+func (sc *SiteInfoController) GetSiteAutoPromotion(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteAutoPromotion(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteAutoPromotion update site auto promotion config
+// @Summary update site auto promotion config
+// @Description update site auto promotion config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteAutoPromotionReq true "auto promotion config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/auto-promotion [put]
+func (sc *SiteInfoController) UpdateSiteAutoPromotion(ctx *gin.Context) {
+	req := &schema.SiteAutoPromotionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteAutoPromotion(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteContentFreshness get site content freshness config
+// @Summary get site content freshness config
+// @Description get site content freshness config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteContentFreshnessResp}
+// @Router /answer/admin/api/siteinfo/content-freshness [get]
+func (sc *SiteInfoController) GetSiteContentFreshness(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteContentFreshness(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteContentFreshness update site content freshness config
+// @Summary update site content freshness config
+// @Description update site content freshness config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteContentFreshnessReq true "content freshness config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/content-freshness [put]
+func (sc *SiteInfoController) UpdateSiteContentFreshness(ctx *gin.Context) {
+	req := &schema.SiteContentFreshnessReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteContentFreshness(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteQuestionMigration get site question migration config
+// @Summary get site question migration config
+// @Description get site question migration config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteQuestionMigrationResp}
+// @Router /answer/admin/api/siteinfo/question-migration [get]
+func (sc *SiteInfoController) GetSiteQuestionMigration(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteQuestionMigration(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteQuestionMigration update site question migration config
+// @Summary update site question migration config
+// @Description update site question migration config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteQuestionMigrationReq true "question migration config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/question-migration [put]
+func (sc *SiteInfoController) UpdateSiteQuestionMigration(ctx *gin.Context) {
+	req := &schema.SiteQuestionMigrationReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteQuestionMigration(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteCacheWarming get site cache warming config
+// @Summary get site cache warming config
+// @Description get site cache warming config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteCacheWarmingResp}
+// @Router /answer/admin/api/siteinfo/cache-warming [get]
+func (sc *SiteInfoController) GetSiteCacheWarming(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteCacheWarming(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteCacheWarming update site cache warming config
+// @Summary update site cache warming config
+// @Description update site cache warming config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteCacheWarmingReq true "cache warming config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/cache-warming [put]
+func (sc *SiteInfoController) UpdateSiteCacheWarming(ctx *gin.Context) {
+	req := &schema.SiteCacheWarmingReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteCacheWarming(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteWebhook get site webhook config
+// @Summary get site webhook config
+// @Description get site webhook config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteWebhookResp}
+// @Router /answer/admin/api/siteinfo/webhook [get]
+func (sc *SiteInfoController) GetSiteWebhook(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteWebhook(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteWebhook update site webhook config
+// @Summary update site webhook config
+// @Description update site webhook config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteWebhookReq true "webhook config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/webhook [put]
+func (sc *SiteInfoController) UpdateSiteWebhook(ctx *gin.Context) {
+	req := &schema.SiteWebhookReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteWebhook(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteSlack get site slack connector config
+// @Summary get site slack connector config
+// @Description get site slack connector config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteSlackResp}
+// @Router /answer/admin/api/siteinfo/slack [get]
+func (sc *SiteInfoController) GetSiteSlack(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteSlack(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteSlack update site slack connector config
+// @Summary update site slack connector config
+// @Description update site slack connector config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteSlackReq true "slack connector config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/slack [put]
+func (sc *SiteInfoController) UpdateSiteSlack(ctx *gin.Context) {
+	req := &schema.SiteSlackReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteSlack(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteJira get site jira connector config
+// @Summary get site jira connector config
+// @Description get site jira connector config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteJiraResp}
+// @Router /answer/admin/api/siteinfo/jira [get]
+func (sc *SiteInfoController) GetSiteJira(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteJira(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteJira update site jira connector config
+// @Summary update site jira connector config
+// @Description update site jira connector config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteJiraReq true "jira connector config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/jira [put]
+func (sc *SiteInfoController) UpdateSiteJira(ctx *gin.Context) {
+	req := &schema.SiteJiraReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteJira(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteSpaces get site spaces config
+// @Summary get site spaces config
+// @Description get site spaces config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteSpacesResp}
+// @Router /answer/admin/api/siteinfo/spaces [get]
+func (sc *SiteInfoController) GetSiteSpaces(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteSpaces(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteSpaces update site spaces config
+// @Summary update site spaces config
+// @Description update site spaces config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteSpacesReq true "spaces config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/spaces [put]
+func (sc *SiteInfoController) UpdateSiteSpaces(ctx *gin.Context) {
+	req := &schema.SiteSpacesReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteSpaces(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteMessenger get site messenger connectors config
+// @Summary get site messenger connectors config
+// @Description get site messenger connectors config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteMessengerResp}
+// @Router /answer/admin/api/siteinfo/messenger [get]
+func (sc *SiteInfoController) GetSiteMessenger(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteMessenger(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteMessenger update site messenger connectors config
+// @Summary update site messenger connectors config
+// @Description update site messenger connectors config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteMessengerReq true "messenger connectors config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/messenger [put]
+func (sc *SiteInfoController) UpdateSiteMessenger(ctx *gin.Context) {
+	req := &schema.SiteMessengerReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteMessenger(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteWebPush get site web push config
+// @Summary get site web push config
+// @Description get site web push config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteWebPushResp}
+// @Router /answer/admin/api/siteinfo/web-push [get]
+func (sc *SiteInfoController) GetSiteWebPush(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteWebPush(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteWebPush update site web push config
+// @Summary update site web push config
+// @Description update site web push config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteWebPushReq true "web push config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/web-push [put]
+func (sc *SiteInfoController) UpdateSiteWebPush(ctx *gin.Context) {
+	req := &schema.SiteWebPushReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteWebPush(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteDigest get site email digest config
+// @Summary get site email digest config
+// @Description get site email digest config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteDigestResp}
+// @Router /answer/admin/api/siteinfo/digest [get]
+func (sc *SiteInfoController) GetSiteDigest(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteDigest(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteDigest update site email digest config
+// @Summary update site email digest config
+// @Description update site email digest config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteDigestReq true "email digest config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/digest [put]
+func (sc *SiteInfoController) UpdateSiteDigest(ctx *gin.Context) {
+	req := &schema.SiteDigestReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteDigest(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteInboundEmail get site inbound email config
+// @Summary get site inbound email config
+// @Description get site inbound email config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteInboundEmailResp}
+// @Router /answer/admin/api/siteinfo/inbound-email [get]
+func (sc *SiteInfoController) GetSiteInboundEmail(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteInboundEmail(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteInboundEmail update site inbound email config
+// @Summary update site inbound email config
+// @Description update site inbound email config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteInboundEmailReq true "inbound email config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/inbound-email [put]
+func (sc *SiteInfoController) UpdateSiteInboundEmail(ctx *gin.Context) {
+	req := &schema.SiteInboundEmailReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteInboundEmail(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteNotificationRetention get site notification retention config
+// @Summary get site notification retention config
+// @Description get site notification retention config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteNotificationRetentionResp}
+// @Router /answer/admin/api/siteinfo/notification-retention [get]
+func (sc *SiteInfoController) GetSiteNotificationRetention(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteNotificationRetention(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteNotificationRetention update site notification retention config
+// @Summary update site notification retention config
+// @Description update site notification retention config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteNotificationRetentionReq true "notification retention config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/notification-retention [put]
+func (sc *SiteInfoController) UpdateSiteNotificationRetention(ctx *gin.Context) {
+	req := &schema.SiteNotificationRetentionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteNotificationRetention(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteSCIM get site SCIM config
+// @Summary get site SCIM config
+// @Description get site SCIM config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteSCIMResp}
+// @Router /answer/admin/api/siteinfo/scim [get]
+func (sc *SiteInfoController) GetSiteSCIM(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteSCIM(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteSCIM update site SCIM config
+// @Summary update site SCIM config
+// @Description update site SCIM config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteSCIMReq true "SCIM config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/scim [put]
+func (sc *SiteInfoController) UpdateSiteSCIM(ctx *gin.Context) {
+	req := &schema.SiteSCIMReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteSCIM(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteOAuthProvider get site OAuth provider config
+// @Summary get site OAuth provider config
+// @Description get site OAuth provider config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteOAuthProviderResp}
+// @Router /answer/admin/api/siteinfo/oauth-provider [get]
+func (sc *SiteInfoController) GetSiteOAuthProvider(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteOAuthProvider(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteOAuthProvider update site OAuth provider config
+// @Summary update site OAuth provider config
+// @Description update site OAuth provider config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteOAuthProviderReq true "OAuth provider config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/oauth-provider [put]
+func (sc *SiteInfoController) UpdateSiteOAuthProvider(ctx *gin.Context) {
+	req := &schema.SiteOAuthProviderReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteOAuthProvider(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteLoginProtection get site login protection config
+// @Summary get site login protection config
+// @Description get site login protection config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteLoginProtectionResp}
+// @Router /answer/admin/api/siteinfo/login-protection [get]
+func (sc *SiteInfoController) GetSiteLoginProtection(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteLoginProtection(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteLoginProtection update site login protection config
+// @Summary update site login protection config
+// @Description update site login protection config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteLoginProtectionReq true "login protection config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/login-protection [put]
+func (sc *SiteInfoController) UpdateSiteLoginProtection(ctx *gin.Context) {
+	req := &schema.SiteLoginProtectionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteLoginProtection(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteInvite get site invite config
+// @Summary get site invite config
+// @Description get site invite config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteInviteResp}
+// @Router /answer/admin/api/siteinfo/invite [get]
+func (sc *SiteInfoController) GetSiteInvite(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteInvite(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSiteInvite update site invite config
+// @Summary update site invite config
+// @Description update site invite config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteInviteReq true "invite config"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/invite [put]
+func (sc *SiteInfoController) UpdateSiteInvite(ctx *gin.Context) {
+	req := &schema.SiteInviteReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteInvite(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSiteInfoVersions get the current version of all settings
+// @Summary get the current version of all settings
+// @Description get the current version of all settings, the version is sent in If-Match header when saving the settings
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]schema.SiteInfoVersionItem}
+// @Router /answer/admin/api/siteinfo/versions [get]
+func (sc *SiteInfoController) GetSiteInfoVersions(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteInfoVersions(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetSiteInfoHistoryPage get the change history of settings
+// @Summary get the change history of settings
+// @Description get the change history of settings
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param type query string true "settings type"
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.SiteInfoHistoryItem}}
+// @Router /answer/admin/api/siteinfo/history/page [get]
+func (sc *SiteInfoController) GetSiteInfoHistoryPage(ctx *gin.Context) {
+	req := &schema.GetSiteInfoHistoryPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := sc.siteInfoService.GetSiteInfoHistoryPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RevertSiteInfo revert the settings to the saved version
+// @Summary revert the settings to the saved version
+// @Description revert the settings to the saved version
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.RevertSiteInfoReq true "revert"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/history/revert [put]
+func (sc *SiteInfoController) RevertSiteInfo(ctx *gin.Context) {
+	req := &schema.RevertSiteInfoReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.RevertSiteInfo(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
 // GetSMTPConfig get smtp config
 // @Summary GetSMTPConfig get smtp config
 // @Description GetSMTPConfig get smtp config
@@ -459,3 +1626,34 @@ func (sc *SiteInfoController) UpdatePrivilegesConfig(ctx *gin.Context) {
 	err := sc.siteInfoService.UpdatePrivilegesConfig(ctx, req)
 	handler.HandleResponse(ctx, err, nil)
 }
+
+// GetReputationRules get reputation rules
+// @Summary get the reputation awarded or deducted for each event
+// @Description get the reputation awarded or deducted for each event
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.GetReputationRulesResp}
+// @Router /answer/admin/api/setting/reputation [get]
+func (sc *SiteInfoController) GetReputationRules(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetReputationRules(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateReputationRules update reputation rules
+// @Summary update the reputation awarded or deducted for each event
+// @Description update the reputation awarded or deducted for each event, only affect the new activities
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.UpdateReputationRulesReq true "rules"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/setting/reputation [put]
+func (sc *SiteInfoController) UpdateReputationRules(ctx *gin.Context) {
+	req := &schema.UpdateReputationRulesReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.UpdateReputationRules(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
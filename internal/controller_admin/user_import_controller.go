@@ -0,0 +1,63 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/user_import"
+	"github.com/gin-gonic/gin"
+)
+
+// UserImportController user import controller
+type UserImportController struct {
+	userImportService *user_import.UserImportService
+}
+
+// NewUserImportController new controller
+func NewUserImportController(userImportService *user_import.UserImportService) *UserImportController {
+	return &UserImportController{userImportService: userImportService}
+}
+
+// ImportUsers import users
+// @Summary import users from csv
+// @Description create users in bulk from the csv with the columns email,name,role,reputation, the imported users
+// @Description set the password by resetting it
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.ImportUsersReq true "users"
+// @Success 200 {object} handler.RespBody{data=schema.ImportUsersResp}
+// @Router /answer/admin/api/users/import [post]
+func (uc *UserImportController) ImportUsers(ctx *gin.Context) {
+	req := &schema.ImportUsersReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.LoginUserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, errFields, err := uc.userImportService.ImportUsers(ctx, req)
+	if len(errFields) > 0 {
+		handler.HandleResponse(ctx, err, errFields)
+		return
+	}
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -0,0 +1,74 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/user_session"
+	"github.com/gin-gonic/gin"
+)
+
+// UserSessionController user session controller
+type UserSessionController struct {
+	userSessionService *user_session.UserSessionService
+}
+
+// NewUserSessionController new controller
+func NewUserSessionController(userSessionService *user_session.UserSessionService) *UserSessionController {
+	return &UserSessionController{userSessionService: userSessionService}
+}
+
+// GetUserSessions get user sessions
+// @Summary get user sessions
+// @Description get the active login sessions of the user
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param user_id query string true "user id"
+// @Success 200 {object} handler.RespBody{data=[]schema.UserSessionItem}
+// @Router /answer/admin/api/user/sessions [get]
+func (uc *UserSessionController) GetUserSessions(ctx *gin.Context) {
+	req := &schema.GetUserSessionsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := uc.userSessionService.GetUserSessions(ctx, req.UserID, "")
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// ForceLogoutUser force logout user
+// @Summary force logout user
+// @Description log out all the login sessions of the user, the bot tokens and personal access tokens are kept
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.ForceLogoutUserReq true "user"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/user/logout [put]
+func (uc *UserSessionController) ForceLogoutUser(ctx *gin.Context) {
+	req := &schema.ForceLogoutUserReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := uc.userSessionService.ForceLogoutUser(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
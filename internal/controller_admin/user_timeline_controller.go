@@ -0,0 +1,58 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/user_timeline"
+	"github.com/gin-gonic/gin"
+)
+
+// UserTimelineController user timeline controller
+type UserTimelineController struct {
+	userTimelineService *user_timeline.UserTimelineService
+}
+
+// NewUserTimelineController new controller
+func NewUserTimelineController(userTimelineService *user_timeline.UserTimelineService) *UserTimelineController {
+	return &UserTimelineController{userTimelineService: userTimelineService}
+}
+
+// GetUserTimeline get the history timeline of user
+// @Summary get the history timeline of user
+// @Description get the chronological history of user, including posts, edits, flags raised and received,
+// @Description status changes, logins and ips, the latest first
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param user_id query string true "user id"
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=schema.GetUserTimelineResp}
+// @Router /answer/admin/api/user/timeline [get]
+func (uc *UserTimelineController) GetUserTimeline(ctx *gin.Context) {
+	req := &schema.GetUserTimelineReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := uc.userTimelineService.GetUserTimeline(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
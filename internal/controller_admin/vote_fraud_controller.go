@@ -0,0 +1,77 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/vote_fraud"
+	"github.com/gin-gonic/gin"
+)
+
+// VoteFraudController vote fraud controller
+type VoteFraudController struct {
+	voteFraudService *vote_fraud.VoteFraudService
+}
+
+// NewVoteFraudController new controller
+func NewVoteFraudController(voteFraudService *vote_fraud.VoteFraudService) *VoteFraudController {
+	return &VoteFraudController{voteFraudService: voteFraudService}
+}
+
+// GetVoteInvalidationPage get the page of votes invalidated by vote fraud detection
+// @Summary get the page of votes invalidated by vote fraud detection
+// @Description get the page of votes invalidated by vote fraud detection, the latest first
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.GetVoteInvalidationPageResp}}
+// @Router /answer/admin/api/vote-fraud/invalidations/page [get]
+func (vc *VoteFraudController) GetVoteInvalidationPage(ctx *gin.Context) {
+	req := &schema.GetVoteInvalidationPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := vc.voteFraudService.GetVoteInvalidationPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// ReverseUserVotes reverse all votes cast by the user
+// @Summary reverse all votes cast by the user
+// @Description reverse all votes cast by the user, the scores and reputation are updated and each vote is recorded as invalidation
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.ReverseUserVotesReq true "user"
+// @Success 200 {object} handler.RespBody{data=schema.ReverseUserVotesResp}
+// @Router /answer/admin/api/vote-fraud/user/votes/reverse [post]
+func (vc *VoteFraudController) ReverseUserVotes(ctx *gin.Context) {
+	req := &schema.ReverseUserVotesReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.OperatorID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := vc.voteFraudService.ReverseUserVotes(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -0,0 +1,78 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/webhook"
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookController webhook controller
+type WebhookController struct {
+	webhookService *webhook.WebhookService
+}
+
+// NewWebhookController new controller
+func NewWebhookController(webhookService *webhook.WebhookService) *WebhookController {
+	return &WebhookController{webhookService: webhookService}
+}
+
+// GetWebhookDeliveryPage get webhook delivery page
+// @Summary get webhook delivery page
+// @Description get webhook delivery logs, the latest first
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param status query string false "status" Enums(pending, success, failed)
+// @Param event query string false "event"
+// @Param webhook_url query string false "webhook url"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.WebhookDeliveryItem}}
+// @Router /answer/admin/api/webhook/deliveries/page [get]
+func (wc *WebhookController) GetWebhookDeliveryPage(ctx *gin.Context) {
+	req := &schema.GetWebhookDeliveryPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := wc.webhookService.GetDeliveryPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RedeliverWebhook redeliver webhook
+// @Summary redeliver webhook
+// @Description post the payload of the delivery to the webhook again
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.RedeliverWebhookReq true "delivery"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/webhook/delivery/redeliver [post]
+func (wc *WebhookController) RedeliverWebhook(ctx *gin.Context) {
+	req := &schema.RedeliverWebhookReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := wc.webhookService.Redeliver(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -53,6 +53,12 @@ type Answer struct {
 	CommentCount   int       `xorm:"not null default 0 INT(11) comment_count"`
 	VoteCount      int       `xorm:"not null default 0 INT(11) vote_count"`
 	RevisionID     string    `xorm:"not null default 0 BIGINT(20) revision_id"`
+	// the provenance of the imported or AI-assisted content
+	SourceURL   string `xorm:"not null default '' VARCHAR(512) source_url"`
+	ImportBatch string `xorm:"not null default '' VARCHAR(64) INDEX import_batch"`
+	AIAssisted  bool   `xorm:"not null default false BOOL ai_assisted"`
+	// the answer of the shadow-banned user, it is only visible to the author and the staff
+	Shadow bool `xorm:"not null default false BOOL shadow"`
 }
 
 type AnswerSearch struct {
@@ -60,6 +66,7 @@ type AnswerSearch struct {
 	IncludeDeleted bool   `json:"include_deleted"`
 	LoginUserID    string `json:"login_user_id"`
 	Order          string `json:"order_by"`                   // default or updated
+	PinnedAnswerID string `json:"pinned_answer_id"`           // shown on top in all orders
 	Page           int    `json:"page" form:"page"`           // Query number of pages
 	PageSize       int    `json:"page_size" form:"page_size"` // Search page size
 }
@@ -0,0 +1,75 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package entity
+
+import "time"
+
+const (
+	AuditLogActionUserStatus        = "user.status"
+	AuditLogActionUserRole          = "user.role"
+	AuditLogActionUserPassword      = "user.password"
+	AuditLogActionUserImport        = "user.import"
+	AuditLogActionQuestionStatus    = "question.status"
+	AuditLogActionQuestionDelete    = "question.delete"
+	AuditLogActionQuestionOperation = "question.operation"
+	AuditLogActionAnswerStatus      = "answer.status"
+	AuditLogActionAnswerDelete      = "answer.delete"
+	AuditLogActionCommentDelete     = "comment.delete"
+	AuditLogActionCommentBulk       = "comment.bulk_delete"
+	AuditLogActionContentPurge      = "content.delete_permanently"
+	AuditLogActionSettingEdit       = "setting.edit"
+	AuditLogActionIPBanAdd          = "ip_ban.add"
+	AuditLogActionIPBanRemove       = "ip_ban.remove"
+	AuditLogActionRoleAdd           = "role.add"
+	AuditLogActionRoleUpdate        = "role.update"
+	AuditLogActionRoleRemove        = "role.remove"
+	AuditLogActionTagDelete         = "tag.delete"
+	AuditLogActionTagMerge          = "tag.merge"
+	AuditLogActionReportResolve     = "report.resolve"
+
+	AuditLogObjectTypeUser     = "user"
+	AuditLogObjectTypeQuestion = "question"
+	AuditLogObjectTypeAnswer   = "answer"
+	AuditLogObjectTypeComment  = "comment"
+	AuditLogObjectTypeSetting  = "setting"
+	AuditLogObjectTypeIPBan    = "ip_ban"
+	AuditLogObjectTypeRole     = "role"
+	AuditLogObjectTypeTag      = "tag"
+	AuditLogObjectTypeReport   = "report"
+)
+
+// AuditLog the privileged action done by admin or moderator, kept for accountability and never modified
+type AuditLog struct {
+	ID        string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP INDEX created_at"`
+	// the user who did the action, 0 means the system
+	OperatorID string `xorm:"not null default 0 BIGINT(20) INDEX operator_id"`
+	Action     string `xorm:"not null default '' VARCHAR(64) INDEX action"`
+	ObjectType string `xorm:"not null default '' VARCHAR(32) INDEX(object) object_type"`
+	ObjectID   string `xorm:"not null default '' VARCHAR(128) INDEX(object) object_id"`
+	// the json values of the object before and after the action
+	Before string `xorm:"TEXT before_value"`
+	After  string `xorm:"TEXT after_value"`
+	IP     string `xorm:"not null default '' VARCHAR(64) ip"`
+}
+
+// TableName audit log table name
+func (AuditLog) TableName() string {
+	return "audit_log"
+}
@@ -27,4 +27,13 @@ type UserCacheInfo struct {
 	RoleID      int    `json:"role_id"`
 	ExternalID  string `json:"external_id"`
 	VisitToken  string `json:"visit_token"`
+	// the scopes of the personal access token, the login session has no scopes and the full access
+	Scopes []string `json:"scopes,omitempty"`
+	// the unix time the personal access token expires, 0 means never
+	ExpiredAt int64 `json:"expired_at,omitempty"`
+	// the device and time of the login session
+	IP           string `json:"ip,omitempty"`
+	UserAgent    string `json:"user_agent,omitempty"`
+	CreatedAt    int64  `json:"created_at,omitempty"`
+	LastActiveAt int64  `json:"last_active_at,omitempty"`
 }
@@ -0,0 +1,74 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	AutoPromotionActionGrant  = "grant"
+	AutoPromotionActionRevoke = "revoke"
+)
+
+// UserAutoPromotion the progress of user evaluated by the auto promotion rule of the role
+type UserAutoPromotion struct {
+	ID        string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt time.Time `xorm:"updated TIMESTAMP updated_at"`
+	UserID    string    `xorm:"not null default 0 BIGINT(20) UNIQUE(user_role) user_id"`
+	RoleID    int       `xorm:"not null default 0 INT(11) UNIQUE(user_role) INDEX role_id"`
+	// the number of consecutive evaluations in which the user met the thresholds
+	QualifiedTimes int `xorm:"not null default 0 INT(11) qualified_times"`
+	// the number of consecutive evaluations in which the user failed the thresholds after granted
+	FailedTimes int `xorm:"not null default 0 INT(11) failed_times"`
+	// only the role granted by the auto promotion can be revoked by it
+	Granted         bool      `xorm:"not null default false BOOL granted"`
+	LastEvaluatedAt time.Time `xorm:"TIMESTAMP last_evaluated_at"`
+}
+
+// TableName user auto promotion table name
+func (UserAutoPromotion) TableName() string {
+	return "user_auto_promotion"
+}
+
+// AutoPromotionLog the audit trail of the roles granted and revoked by the auto promotion
+type AutoPromotionLog struct {
+	ID        string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
+	UserID    string    `xorm:"not null default 0 BIGINT(20) INDEX user_id"`
+	RoleID    int       `xorm:"not null default 0 INT(11) role_id"`
+	Action    string    `xorm:"not null default '' VARCHAR(16) action"`
+	// the activity of user when evaluated, such as reputation 1200, answers 30, accepted 12, score 85
+	Detail string `xorm:"not null default '' VARCHAR(255) detail"`
+}
+
+// TableName auto promotion log table name
+func (AutoPromotionLog) TableName() string {
+	return "auto_promotion_log"
+}
+
+// UserActivityStat the activity of user evaluated by the auto promotion
+type UserActivityStat struct {
+	UserID        string    `xorm:"user_id"`
+	Rank          int       `xorm:"rank"`
+	CreatedAt     time.Time `xorm:"created_at"`
+	AnswerCount   int       `xorm:"answer_count"`
+	AcceptedCount int       `xorm:"accepted_count"`
+	AnswerScore   int       `xorm:"answer_score"`
+}
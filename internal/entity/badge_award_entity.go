@@ -67,3 +67,24 @@ type BadgeAwardRecent struct {
 func (BadgeAwardRecent) TableName() string {
 	return "badge_award"
 }
+
+// TagBadgeAward tag badge award, the award key is the tag id
+type TagBadgeAward struct {
+	BadgeID     string     `xorm:"badge_id"`
+	TagID       string     `xorm:"award_key"`
+	Level       BadgeLevel `xorm:"level"`
+	SlugName    string     `xorm:"-"`
+	DisplayName string     `xorm:"-"`
+}
+
+// TableName badge_award table name
+func (TagBadgeAward) TableName() string {
+	return "badge_award"
+}
+
+// UserTagAnswerScore sum of user's answer vote count in a tag
+type UserTagAnswerScore struct {
+	UserID string `xorm:"user_id"`
+	TagID  string `xorm:"tag_id"`
+	Score  int64  `xorm:"score"`
+}
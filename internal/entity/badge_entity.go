@@ -37,6 +37,9 @@ const (
 
 	BadgeSingleAward = 1
 	BadgeMultiAward  = 2
+
+	// TagAnswerScoreBadgeHandler awards tag badges with the tag id as award key
+	TagAnswerScoreBadgeHandler = "ReachTagAnswerScore"
 )
 
 // Badge badge
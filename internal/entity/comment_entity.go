@@ -47,6 +47,12 @@ type Comment struct {
 	Status         int           `xorm:"not null default 0 TINYINT(4) status"`
 	OriginalText   string        `xorm:"not null MEDIUMTEXT original_text"`
 	ParsedText     string        `xorm:"not null MEDIUMTEXT parsed_text"`
+	CodeBlockIndex int           `xorm:"not null default 0 INT(11) code_block_index"`
+	CodeLineStart  int           `xorm:"not null default 0 INT(11) code_line_start"`
+	CodeLineEnd    int           `xorm:"not null default 0 INT(11) code_line_end"`
+	CodeExcerpt    string        `xorm:"TEXT code_excerpt"`
+	// the comment of the shadow-banned user, it is only visible to the author and the staff
+	Shadow bool `xorm:"not null default false BOOL shadow"`
 }
 
 // TableName comment table name
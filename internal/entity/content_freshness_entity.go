@@ -0,0 +1,43 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// ContentFreshnessStat the freshness metrics of the questions in an active tag, analyzed by the scheduler.
+// The tag id 0 presents the questions in all active tags.
+type ContentFreshnessStat struct {
+	ID            int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt     time.Time `xorm:"created TIMESTAMP created_at"`
+	TagID         string    `xorm:"not null default 0 BIGINT(20) UNIQUE tag_id"`
+	QuestionCount int       `xorm:"not null default 0 INT(11) question_count"`
+	AcceptedCount int       `xorm:"not null default 0 INT(11) accepted_count"`
+	// the accepted answers not updated in the stale years
+	StaleAcceptedCount int `xorm:"not null default 0 INT(11) stale_accepted_count"`
+	// the questions referencing the deprecated versions
+	DeprecatedCount int `xorm:"not null default 0 INT(11) deprecated_count"`
+	// the percentage of stale accepted answers in accepted answers, multiplied by 100 to keep two decimals
+	StalePercent int `xorm:"not null default 0 INT(11) INDEX stale_percent"`
+}
+
+// TableName content freshness stat table name
+func (ContentFreshnessStat) TableName() string {
+	return "content_freshness_stat"
+}
@@ -0,0 +1,38 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// ContentLanguageStat the statistics of post language detection
+type ContentLanguageStat struct {
+	ID              int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt       time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt       time.Time `xorm:"updated TIMESTAMP updated_at"`
+	Language        string    `xorm:"not null default '' VARCHAR(16) UNIQUE language"`
+	PostCount       int64     `xorm:"not null default 0 BIGINT(20) post_count"`
+	ReviewCount     int64     `xorm:"not null default 0 BIGINT(20) review_count"`
+	TranslatedCount int64     `xorm:"not null default 0 BIGINT(20) translated_count"`
+}
+
+// TableName content language stat table name
+func (ContentLanguageStat) TableName() string {
+	return "content_language_stat"
+}
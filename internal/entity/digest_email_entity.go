@@ -0,0 +1,41 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// DigestEmail the digest email sent to user, it is used to send one digest of each frequency in a period
+type DigestEmail struct {
+	ID        string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt time.Time `xorm:"created INDEX(user_frequency) TIMESTAMP created_at"`
+	UserID    string    `xorm:"not null default 0 BIGINT(20) INDEX(user_frequency) user_id"`
+	Frequency string    `xorm:"not null default '' VARCHAR(16) INDEX(user_frequency) frequency"`
+}
+
+// TableName digest email table name
+func (DigestEmail) TableName() string {
+	return "digest_email"
+}
+
+// DigestAnswerStat the number of new answers of question
+type DigestAnswerStat struct {
+	QuestionID     string `xorm:"question_id"`
+	NewAnswerCount int    `xorm:"new_answer_count"`
+}
@@ -0,0 +1,38 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// EmailTemplate the email template customized by admin for the language, the default translation is used if not customized
+type EmailTemplate struct {
+	ID          string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt   time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt   time.Time `xorm:"updated TIMESTAMP updated_at"`
+	TemplateKey string    `xorm:"not null default '' VARCHAR(64) UNIQUE(key_language) template_key"`
+	Language    string    `xorm:"not null default '' VARCHAR(16) UNIQUE(key_language) language"`
+	Subject     string    `xorm:"not null default '' VARCHAR(512) subject"`
+	Body        string    `xorm:"not null MEDIUMTEXT body"`
+}
+
+// TableName email template table name
+func (EmailTemplate) TableName() string {
+	return "email_template"
+}
@@ -0,0 +1,57 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	HelpfulnessSurveyStatusPending   = 1
+	HelpfulnessSurveyStatusSolved    = 2
+	HelpfulnessSurveyStatusNotSolved = 3
+	HelpfulnessSurveyStatusDismissed = 4
+)
+
+// HelpfulnessSurvey the follow-up survey of whether the accepted answer solved the problem of viewer
+type HelpfulnessSurvey struct {
+	ID         string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt  time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt  time.Time `xorm:"updated TIMESTAMP updated_at"`
+	UserID     string    `xorm:"not null default 0 BIGINT(20) UNIQUE(user_answer) user_id"`
+	QuestionID string    `xorm:"not null default 0 BIGINT(20) question_id"`
+	AnswerID   string    `xorm:"not null default 0 BIGINT(20) UNIQUE(user_answer) INDEX answer_id"`
+	// the token is used to submit the survey result without login, e.g. from the link in email
+	Token  string `xorm:"not null default '' VARCHAR(64) UNIQUE token"`
+	Status int    `xorm:"not null default 1 INT(11) status"`
+	// the survey is prompted after the time
+	DueAt      time.Time `xorm:"TIMESTAMP INDEX due_at"`
+	AnsweredAt time.Time `xorm:"TIMESTAMP answered_at"`
+}
+
+// TableName helpfulness survey table name
+func (HelpfulnessSurvey) TableName() string {
+	return "helpfulness_survey"
+}
+
+// AnswerHelpfulnessStat the aggregated survey results of answer
+type AnswerHelpfulnessStat struct {
+	AnswerID  string `xorm:"answer_id"`
+	Responses int    `xorm:"responses"`
+	Solved    int    `xorm:"solved"`
+}
@@ -0,0 +1,39 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// InboundEmail the processed reply email, the message id keeps the reply from being posted twice
+type InboundEmail struct {
+	ID        string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
+	MessageID string    `xorm:"not null default '' VARCHAR(255) UNIQUE message_id"`
+	UserID    string    `xorm:"not null default 0 BIGINT(20) INDEX user_id"`
+	// the object replied to and the answer or comment posted
+	ReplyObjectID  string `xorm:"not null default 0 BIGINT(20) reply_object_id"`
+	PostedObjectID string `xorm:"not null default 0 BIGINT(20) posted_object_id"`
+	Status         string `xorm:"not null default '' VARCHAR(16) status"`
+}
+
+// TableName inbound email table name
+func (InboundEmail) TableName() string {
+	return "inbound_email"
+}
@@ -0,0 +1,60 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	InviteCodeStatusAvailable = 1
+	InviteCodeStatusRevoked   = 2
+)
+
+// InviteCode the invite code registering with when the registration is invite-only
+type InviteCode struct {
+	ID        string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt time.Time `xorm:"updated TIMESTAMP updated_at"`
+	Code      string    `xorm:"not null default '' VARCHAR(64) UNIQUE code"`
+	// the user generated the invite code
+	UserID    string `xorm:"not null default 0 BIGINT(20) INDEX user_id"`
+	MaxUses   int    `xorm:"not null default 1 INT(11) max_uses"`
+	UsedCount int    `xorm:"not null default 0 INT(11) used_count"`
+	Status    int    `xorm:"not null default 1 INT(11) status"`
+	// the invite code never expires if it is null
+	ExpiredAt time.Time `xorm:"TIMESTAMP expired_at"`
+}
+
+// TableName invite code table name
+func (InviteCode) TableName() string {
+	return "invite_code"
+}
+
+// InviteCodeUsage the user registered with the invite code
+type InviteCodeUsage struct {
+	ID           string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt    time.Time `xorm:"created TIMESTAMP created_at"`
+	InviteCodeID string    `xorm:"not null default 0 BIGINT(20) INDEX invite_code_id"`
+	UserID       string    `xorm:"not null default 0 BIGINT(20) UNIQUE user_id"`
+}
+
+// TableName invite code usage table name
+func (InviteCodeUsage) TableName() string {
+	return "invite_code_usage"
+}
@@ -0,0 +1,44 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// IPBan the ip or CIDR range banned from registering and posting
+type IPBan struct {
+	ID        string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt time.Time `xorm:"updated TIMESTAMP updated_at"`
+	// the CIDR range, the single ip is saved as /32 or /128
+	IPRange string `xorm:"not null default '' VARCHAR(64) UNIQUE ip_range"`
+	Reason  string `xorm:"not null default '' VARCHAR(255) reason"`
+	// the admin banned the range
+	OperatorID string `xorm:"not null default 0 BIGINT(20) operator_id"`
+	// the times of the requests rejected by the ban
+	HitCount  int       `xorm:"not null default 0 INT(11) hit_count"`
+	LastHitAt time.Time `xorm:"TIMESTAMP last_hit_at"`
+	// the ban never expires if it is null
+	ExpiredAt time.Time `xorm:"TIMESTAMP INDEX expired_at"`
+}
+
+// TableName ip ban table name
+func (IPBan) TableName() string {
+	return "ip_ban"
+}
@@ -0,0 +1,59 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// JiraIssueLink the jira issue created from the flagged question
+type JiraIssueLink struct {
+	ID         string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt  time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt  time.Time `xorm:"updated TIMESTAMP updated_at"`
+	QuestionID string    `xorm:"not null default 0 BIGINT(20) UNIQUE question_id"`
+	ProjectKey string    `xorm:"not null default '' VARCHAR(32) project_key"`
+	IssueKey   string    `xorm:"not null default '' VARCHAR(64) issue_key"`
+	// the user created the issue, empty if created automatically when the question is flagged
+	UserID string `xorm:"not null default 0 BIGINT(20) user_id"`
+	Status string `xorm:"not null default '' VARCHAR(64) status"`
+	// the resolved issue is no longer synced
+	Resolved bool `xorm:"not null default false BOOL INDEX resolved"`
+}
+
+// TableName jira issue link table name
+func (JiraIssueLink) TableName() string {
+	return "jira_issue_link"
+}
+
+// QuestionModeratorNote the private note of the question only visible to the moderators
+type QuestionModeratorNote struct {
+	ID         string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt  time.Time `xorm:"created TIMESTAMP created_at"`
+	QuestionID string    `xorm:"not null default 0 BIGINT(20) INDEX question_id"`
+	Source     string    `xorm:"not null default '' VARCHAR(16) source"`
+	// the id of the note in the source, used to skip the synced notes
+	ExternalID string `xorm:"not null default '' VARCHAR(64) external_id"`
+	Author     string `xorm:"not null default '' VARCHAR(128) author"`
+	Content    string `xorm:"not null MEDIUMTEXT content"`
+}
+
+// TableName question moderator note table name
+func (QuestionModeratorNote) TableName() string {
+	return "question_moderator_note"
+}
@@ -0,0 +1,45 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// LeaderboardStat the pre-aggregated reputation earned by user in a period, refreshed by the scheduler
+type LeaderboardStat struct {
+	ID         int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt  time.Time `xorm:"created TIMESTAMP created_at"`
+	Period     string    `xorm:"not null default '' VARCHAR(16) UNIQUE(period_tag_user) period"`
+	TagID      string    `xorm:"not null default 0 BIGINT(20) UNIQUE(period_tag_user) tag_id"`
+	UserID     string    `xorm:"not null default 0 BIGINT(20) UNIQUE(period_tag_user) user_id"`
+	Reputation int       `xorm:"not null default 0 INT(11) reputation"`
+	Position   int       `xorm:"not null default 0 INT(11) position"`
+}
+
+// LeaderboardUserTagRankStat the reputation earned by user in the tag
+type LeaderboardUserTagRankStat struct {
+	UserID string `xorm:"user_id"`
+	TagID  string `xorm:"tag_id"`
+	Rank   int    `xorm:"rank_amount"`
+}
+
+// TableName leaderboard stat table name
+func (LeaderboardStat) TableName() string {
+	return "leaderboard_stat"
+}
@@ -33,6 +33,8 @@ type Notification struct {
 	MsgType   int       `xorm:"not null default 0 INT(11) msg_type"`
 	IsRead    int       `xorm:"not null default 1 INT(11) is_read"`
 	Status    int       `xorm:"not null default 1 INT(11) status"`
+	// the unread notifications with the same group key in a short time are merged into one
+	GroupKey string `xorm:"not null default '' VARCHAR(128) INDEX group_key"`
 }
 
 // TableName notification table name
@@ -0,0 +1,40 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// OAuthClient the client registered by admin logging in with the site as the OpenID provider,
+// only the hash of the client secret is saved
+type OAuthClient struct {
+	ID         string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt  time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt  time.Time `xorm:"updated TIMESTAMP updated_at"`
+	ClientID   string    `xorm:"not null default '' VARCHAR(64) UNIQUE client_id"`
+	SecretHash string    `xorm:"not null default '' VARCHAR(64) secret_hash"`
+	Name       string    `xorm:"not null default '' VARCHAR(100) name"`
+	// the redirect uris allowed, json array
+	RedirectURIs string `xorm:"not null TEXT redirect_uris"`
+}
+
+// TableName oauth client table name
+func (OAuthClient) TableName() string {
+	return "oauth_client"
+}
@@ -0,0 +1,42 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// PersonalAccessToken the long-lived api token of user, only the hash of token is saved
+type PersonalAccessToken struct {
+	ID        string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt time.Time `xorm:"updated TIMESTAMP updated_at"`
+	UserID    string    `xorm:"not null default 0 BIGINT(20) INDEX user_id"`
+	Name      string    `xorm:"not null default '' VARCHAR(100) name"`
+	TokenHash string    `xorm:"not null default '' VARCHAR(64) UNIQUE token_hash"`
+	// the scopes separated by comma
+	Scopes string `xorm:"not null default '' VARCHAR(255) scopes"`
+	// the token never expires if it is null
+	ExpiredAt  time.Time `xorm:"TIMESTAMP expired_at"`
+	LastUsedAt time.Time `xorm:"TIMESTAMP last_used_at"`
+}
+
+// TableName personal access token table name
+func (PersonalAccessToken) TableName() string {
+	return "personal_access_token"
+}
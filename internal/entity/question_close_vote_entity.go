@@ -0,0 +1,50 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	QuestionCloseVoteTypeClose  = "close"
+	QuestionCloseVoteTypeReopen = "reopen"
+
+	QuestionCloseVoteStatusActive  = 1
+	QuestionCloseVoteStatusApplied = 2
+	QuestionCloseVoteStatusExpired = 3
+	QuestionCloseVoteStatusRevoked = 10
+)
+
+// QuestionCloseVote vote to close or reopen a question
+type QuestionCloseVote struct {
+	ID         int       `xorm:"not null pk autoincr INT(10) id"`
+	CreatedAt  time.Time `xorm:"not null default CURRENT_TIMESTAMP created TIMESTAMP created_at"`
+	UpdatedAt  time.Time `xorm:"not null default CURRENT_TIMESTAMP updated TIMESTAMP updated_at"`
+	QuestionID string    `xorm:"not null default 0 INDEX BIGINT(20) question_id"`
+	UserID     string    `xorm:"not null default 0 INDEX BIGINT(20) user_id"`
+	VoteType   string    `xorm:"not null default '' VARCHAR(16) vote_type"`
+	CloseType  int       `xorm:"not null default 0 INT(11) close_type"`
+	CloseMsg   string    `xorm:"not null default '' VARCHAR(4096) close_msg"`
+	Status     int       `xorm:"not null default 1 TINYINT(4) status"`
+}
+
+// TableName question close vote table name
+func (QuestionCloseVote) TableName() string {
+	return "question_close_vote"
+}
@@ -32,6 +32,8 @@ const (
 	QuestionPin             = 2
 	QuestionShow            = 1
 	QuestionHide            = 2
+	// QuestionShadow the question of the shadow-banned user, it is only visible to the author and the staff
+	QuestionShadow = 3
 )
 
 var AdminQuestionSearchStatus = map[string]int{
@@ -70,10 +72,13 @@ type Question struct {
 	CollectionCount  int       `xorm:"not null default 0 INT(11) collection_count"`
 	FollowCount      int       `xorm:"not null default 0 INT(11) follow_count"`
 	AcceptedAnswerID string    `xorm:"not null default 0 BIGINT(20) accepted_answer_id"`
+	PinnedAnswerID   string    `xorm:"not null default 0 BIGINT(20) pinned_answer_id"`
 	LastAnswerID     string    `xorm:"not null default 0 BIGINT(20) last_answer_id"`
 	PostUpdateTime   time.Time `xorm:"post_update_time TIMESTAMP"`
 	RevisionID       string    `xorm:"not null default 0 BIGINT(20) revision_id"`
 	LinkedCount      int       `xorm:"not null default 0 INT(11) linked_count"`
+	ResolutionNote   string    `xorm:"not null default '' VARCHAR(500) resolution_note"`
+	Language         string    `xorm:"not null default '' VARCHAR(16) INDEX language"`
 }
 
 // TableName question table name
@@ -28,6 +28,20 @@ const (
 	QuestionLinkStatusDeleted   = 2
 )
 
+// the link types of url and mention are the same as the link types parsed by checker
+const (
+	QuestionLinkTypeURL       = 1
+	QuestionLinkTypeMention   = 2
+	QuestionLinkTypeDuplicate = 3
+)
+
+// QuestionLinkTypeMapping the link type name shown to the user
+var QuestionLinkTypeMapping = map[int]string{
+	QuestionLinkTypeURL:       "url",
+	QuestionLinkTypeMention:   "mention",
+	QuestionLinkTypeDuplicate: "duplicate",
+}
+
 type QuestionLink struct {
 	ID             string    `xorm:"not null pk autoincr BIGINT(20) id"`
 	CreatedAt      time.Time `xorm:"not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
@@ -37,6 +51,7 @@ type QuestionLink struct {
 	ToQuestionID   string    `xorm:"not null default 0 BIGINT(20) index to_question_id"`
 	ToAnswerID     string    `xorm:"BIGINT(20) to_answer_id"`
 	Status         int       `xorm:"not null default 1 INT(11) status"`
+	LinkType       int       `xorm:"not null default 1 INT(11) link_type"`
 }
 
 func (QuestionLink) TableName() string {
@@ -0,0 +1,47 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	QuestionQualityStatusPending  = 1
+	QuestionQualityStatusClaimed  = 2
+	QuestionQualityStatusResolved = 3
+)
+
+// QuestionQuality the quality score of question, the low scored question waits in the needs improvement queue
+type QuestionQuality struct {
+	ID         string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt  time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt  time.Time `xorm:"updated TIMESTAMP updated_at"`
+	QuestionID string    `xorm:"not null default 0 BIGINT(20) UNIQUE question_id"`
+	Score      int       `xorm:"not null default 0 INT(11) score"`
+	// the reasons that lower the score, json array
+	Reasons   string    `xorm:"not null default '' VARCHAR(255) reasons"`
+	Status    int       `xorm:"not null default 1 INT(11) INDEX status"`
+	ClaimedBy string    `xorm:"not null default 0 BIGINT(20) claimed_by"`
+	ClaimedAt time.Time `xorm:"TIMESTAMP claimed_at"`
+}
+
+// TableName question quality table name
+func (QuestionQuality) TableName() string {
+	return "question_quality"
+}
@@ -0,0 +1,32 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+// QuestionTitleTrigram the trigrams of question title, used for search suggestion
+type QuestionTitleTrigram struct {
+	ID         string `xorm:"not null pk autoincr BIGINT(20) id"`
+	QuestionID string `xorm:"not null default 0 BIGINT(20) INDEX question_id"`
+	Trigram    string `xorm:"not null default '' VARCHAR(3) INDEX trigram"`
+}
+
+// TableName question title trigram table name
+func (QuestionTitleTrigram) TableName() string {
+	return "question_title_trigram"
+}
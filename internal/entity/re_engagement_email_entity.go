@@ -0,0 +1,42 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// ReEngagementEmail the re-engagement email sent to user, it is used to cap the emails of each campaign
+type ReEngagementEmail struct {
+	ID        string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt time.Time `xorm:"created INDEX(user_campaign) TIMESTAMP created_at"`
+	UserID    string    `xorm:"not null default 0 BIGINT(20) INDEX(user_campaign) user_id"`
+	Campaign  string    `xorm:"not null default '' VARCHAR(32) INDEX(user_campaign) campaign"`
+}
+
+// TableName re-engagement email table name
+func (ReEngagementEmail) TableName() string {
+	return "re_engagement_email"
+}
+
+// ReEngagementNewAnswerStat the number of new answers of question
+type ReEngagementNewAnswerStat struct {
+	QuestionID     string `xorm:"question_id"`
+	UserID         string `xorm:"user_id"`
+	NewAnswerCount int    `xorm:"new_answer_count"`
+}
@@ -0,0 +1,49 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	ReportAppealStatusPending    = 1
+	ReportAppealStatusUpheld     = 2
+	ReportAppealStatusOverturned = 3
+)
+
+// ReportAppeal appeal of the content author against the handled report
+type ReportAppeal struct {
+	ID          string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt   time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt   time.Time `xorm:"updated TIMESTAMP updated_at"`
+	ReportID    string    `xorm:"not null default 0 UNIQUE BIGINT(20) report_id"`
+	ObjectID    string    `xorm:"not null default 0 BIGINT(20) object_id"`
+	UserID      string    `xorm:"not null default 0 INDEX BIGINT(20) user_id"`
+	Content     string    `xorm:"not null TEXT content"`
+	ModeratorID string    `xorm:"not null default 0 INDEX BIGINT(20) moderator_id"`
+	HandledOp   string    `xorm:"not null default '' VARCHAR(32) handled_op"`
+	ReviewerID  string    `xorm:"not null default 0 BIGINT(20) reviewer_id"`
+	ReviewMsg   string    `xorm:"TEXT review_msg"`
+	Status      int       `xorm:"not null default 1 INDEX INT(11) status"`
+}
+
+// TableName report appeal table name
+func (ReportAppeal) TableName() string {
+	return "report_appeal"
+}
@@ -32,10 +32,52 @@ var (
 	ReportStatus = map[string]int{
 		"pending":   ReportStatusPending,
 		"completed": ReportStatusCompleted,
+		"ignored":   ReportStatusIgnore,
 		"deleted":   ReportStatusDeleted,
 	}
 )
 
+// the categories of the report, the moderators filter the reports by them
+const (
+	ReportCategorySpam    = "spam"
+	ReportCategoryAbuse   = "abuse"
+	ReportCategoryQuality = "quality"
+	ReportCategoryLegal   = "legal"
+	ReportCategoryOther   = "other"
+)
+
+// ReportCategories the categories of the report in display order
+var ReportCategories = []string{
+	ReportCategorySpam,
+	ReportCategoryAbuse,
+	ReportCategoryQuality,
+	ReportCategoryLegal,
+	ReportCategoryOther,
+}
+
+// reportReasonCategories the default category of the report reason
+var reportReasonCategories = map[string]string{
+	"reason.spam":             ReportCategorySpam,
+	"reason.rude_or_abusive":  ReportCategoryAbuse,
+	"reason.a_duplicate":      ReportCategoryQuality,
+	"reason.not_a_answer":     ReportCategoryQuality,
+	"reason.no_longer_needed": ReportCategoryQuality,
+}
+
+// GetReportCategoryByReason get the default category of the report reason key, it is other if not matched
+func GetReportCategoryByReason(reasonKey string) string {
+	if category, ok := reportReasonCategories[reasonKey]; ok {
+		return category
+	}
+	return ReportCategoryOther
+}
+
+// the resolution outcomes of the handled report, they are told to the reporter
+const (
+	ReportResolutionHelpful  = "helpful"
+	ReportResolutionDeclined = "declined"
+)
+
 // Report report
 type Report struct {
 	ID             string    `xorm:"not null pk autoincr BIGINT(20) id"`
@@ -46,10 +88,18 @@ type Report struct {
 	ReportedUserID string    `xorm:"not null default 0 BIGINT(20) reported_user_id"`
 	ObjectType     int       `xorm:"not null default 0 INT(11) object_type"`
 	ReportType     int       `xorm:"not null default 0 INT(11) report_type"`
+	Category       string    `xorm:"not null default '' VARCHAR(32) index category"`
 	Content        string    `xorm:"not null TEXT content"`
+	Detail         string    `xorm:"TEXT detail"`
 	FlaggedType    int       `xorm:"not null default 0 INT(11) flagged_type"`
 	FlaggedContent string    `xorm:"TEXT flagged_content"`
-	Status         int       `xorm:"not null default 1 INT(11) status"`
+	HandledBy      string    `xorm:"not null default 0 BIGINT(20) handled_by"`
+	HandledOp      string    `xorm:"not null default '' VARCHAR(32) handled_op"`
+	// the moderator assigned to handle the report, 0 means anyone can pick it up
+	AssigneeID       string `xorm:"not null default 0 BIGINT(20) index assignee_id"`
+	Resolution       string `xorm:"not null default '' VARCHAR(16) resolution"`
+	ResolutionReason string `xorm:"TEXT resolution_reason"`
+	Status           int    `xorm:"not null default 1 INT(11) status"`
 }
 
 // TableName report table name
@@ -0,0 +1,48 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	// SavedSearchFrequencyInstant notify each new result when the saved searches are evaluated
+	SavedSearchFrequencyInstant = "instant"
+	// SavedSearchFrequencyDaily notify the new results once a day as a digest
+	SavedSearchFrequencyDaily = "daily"
+	// SavedSearchFrequencyNone the saved search is not notified
+	SavedSearchFrequencyNone = "none"
+)
+
+// SavedSearch the search query saved by user, the results created after the last checked time are notified
+type SavedSearch struct {
+	ID            string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt     time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt     time.Time `xorm:"updated TIMESTAMP updated_at"`
+	UserID        string    `xorm:"not null default 0 BIGINT(20) INDEX user_id"`
+	Name          string    `xorm:"not null default '' VARCHAR(100) name"`
+	Query         string    `xorm:"not null default '' VARCHAR(255) query"`
+	Frequency     string    `xorm:"not null default 'instant' VARCHAR(20) frequency"`
+	LastCheckedAt time.Time `xorm:"TIMESTAMP last_checked_at"`
+}
+
+// TableName saved search table name
+func (SavedSearch) TableName() string {
+	return "saved_search"
+}
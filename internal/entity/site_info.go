@@ -21,7 +21,8 @@ package entity
 
 import "time"
 
-// SiteInfo site information setting
+// SiteInfo site information setting.
+// The version is increased by xorm on every update, and the update is not applied if the version has been changed.
 type SiteInfo struct {
 	ID        string    `xorm:"not null pk autoincr INT(11) id"`
 	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
@@ -29,6 +30,7 @@ type SiteInfo struct {
 	Type      string    `xorm:"not null VARCHAR(64) type"`
 	Content   string    `xorm:"not null MEDIUMTEXT content"`
 	Status    int       `xorm:"not null default 1 INT(11) status"`
+	Version   int       `xorm:"not null default 0 INT(11) version"`
 }
 
 // TableName table name
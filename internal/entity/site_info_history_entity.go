@@ -0,0 +1,37 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// SiteInfoHistory the saved versions of site settings, used to show the changes and revert
+type SiteInfoHistory struct {
+	ID         string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt  time.Time `xorm:"created TIMESTAMP created_at"`
+	Type       string    `xorm:"not null VARCHAR(64) UNIQUE(type_version) type"`
+	Version    int       `xorm:"not null default 0 INT(11) UNIQUE(type_version) 'version'"`
+	Content    string    `xorm:"not null MEDIUMTEXT content"`
+	OperatorID string    `xorm:"not null default 0 BIGINT(20) operator_id"`
+}
+
+// TableName site info history table name
+func (SiteInfoHistory) TableName() string {
+	return "site_info_history"
+}
@@ -0,0 +1,50 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	// SyndicationDirectionPush the local question is pushed to the peer
+	SyndicationDirectionPush = "push"
+	// SyndicationDirectionMirror the local question is the read-only mirror of the peer question
+	SyndicationDirectionMirror = "mirror"
+)
+
+// SyndicationLink the link between the local question and the question of peer instance
+type SyndicationLink struct {
+	ID        string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt time.Time `xorm:"updated TIMESTAMP updated_at"`
+	Direction string    `xorm:"not null default '' VARCHAR(16) UNIQUE(direction_peer_source) direction"`
+	// the webhook url of push target, or the site url of mirror source
+	PeerURL          string `xorm:"not null default '' VARCHAR(512) UNIQUE(direction_peer_source) peer_url"`
+	SourceQuestionID string `xorm:"not null default '' VARCHAR(64) UNIQUE(direction_peer_source) source_question_id"`
+	LocalQuestionID  string `xorm:"not null default 0 BIGINT(20) INDEX local_question_id"`
+	// the url of source question, the backlink of mirror
+	SourceURL string `xorm:"not null default '' VARCHAR(1024) source_url"`
+	// the update time of source question synced
+	SourceUpdatedAt time.Time `xorm:"TIMESTAMP source_updated_at"`
+}
+
+// TableName syndication link table name
+func (SyndicationLink) TableName() string {
+	return "syndication_link"
+}
@@ -0,0 +1,60 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// TagDailyStat the pre-aggregated stats of the questions asked in the tag on the day, refreshed by the scheduler
+type TagDailyStat struct {
+	ID        int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
+	TagID     string    `xorm:"not null default 0 BIGINT(20) UNIQUE(tag_date) tag_id"`
+	// the day in format 2006-01-02
+	Date          string `xorm:"not null default '' VARCHAR(10) UNIQUE(tag_date) INDEX date"`
+	QuestionCount int    `xorm:"not null default 0 INT(11) question_count"`
+	// the questions asked on the day answered before the aggregation
+	AnsweredCount int `xorm:"not null default 0 INT(11) answered_count"`
+	// the median seconds from the question asked to its first answer, 0 if no question is answered
+	MedianFirstAnswerSeconds int64 `xorm:"not null default 0 BIGINT(20) median_first_answer_seconds"`
+	// the answers posted in the tag on the day
+	AnswerCount int `xorm:"not null default 0 INT(11) answer_count"`
+	// the json of the users who posted the most answers on the day
+	TopAnswerers string `xorm:"not null TEXT top_answerers"`
+}
+
+// TagQuestionFirstAnswer the question asked in the tag and the time of its first answer
+type TagQuestionFirstAnswer struct {
+	TagID         string    `xorm:"tag_id"`
+	QuestionID    string    `xorm:"question_id"`
+	CreatedAt     time.Time `xorm:"created_at"`
+	FirstAnswerAt time.Time `xorm:"-"`
+}
+
+// TagAnswererStat the answers posted by the user in the tag
+type TagAnswererStat struct {
+	TagID       string `xorm:"tag_id"`
+	UserID      string `xorm:"user_id"`
+	AnswerCount int    `xorm:"answer_count"`
+}
+
+// TableName tag daily stat table name
+func (TagDailyStat) TableName() string {
+	return "tag_daily_stat"
+}
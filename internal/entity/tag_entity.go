@@ -19,7 +19,10 @@
 
 package entity
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 const (
 	TagStatusAvailable = 1
@@ -49,9 +52,22 @@ type Tag struct {
 	Reserved        bool      `xorm:"not null default false BOOL reserved"`
 	RevisionID      string    `xorm:"not null default 0 BIGINT(20) revision_id"`
 	UserID          string    `xorm:"not null default 0 BIGINT(20) user_id"`
+	Deprecated      bool      `xorm:"not null default false BOOL deprecated"`
+	// ReplacementTags the slug names of the tags to use instead of the deprecated tag, separated by comma
+	ReplacementTags string `xorm:"not null default '' VARCHAR(255) replacement_tags"`
 }
 
 // TableName tag table name
 func (Tag) TableName() string {
 	return "tag"
 }
+
+// GetReplacementTags get the slug names of the replacement tags
+func (t *Tag) GetReplacementTags() (slugNames []string) {
+	for _, slugName := range strings.Split(t.ReplacementTags, ",") {
+		if slugName = strings.TrimSpace(slugName); len(slugName) > 0 {
+			slugNames = append(slugNames, slugName)
+		}
+	}
+	return slugNames
+}
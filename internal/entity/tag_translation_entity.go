@@ -0,0 +1,40 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// TagTranslation the display name and description of tag in a specific language,
+// the slug name of tag is never translated and used in urls and search
+type TagTranslation struct {
+	ID           string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt    time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt    time.Time `xorm:"updated TIMESTAMP updated_at"`
+	TagID        string    `xorm:"not null default 0 BIGINT(20) UNIQUE(tag_language) tag_id"`
+	Language     string    `xorm:"not null default '' VARCHAR(20) UNIQUE(tag_language) INDEX language"`
+	DisplayName  string    `xorm:"not null default '' VARCHAR(35) display_name"`
+	OriginalText string    `xorm:"not null MEDIUMTEXT original_text"`
+	ParsedText   string    `xorm:"not null MEDIUMTEXT parsed_text"`
+}
+
+// TableName tag translation table name
+func (TagTranslation) TableName() string {
+	return "tag_translation"
+}
@@ -36,6 +36,12 @@ const (
 	UserAdminFlag = 1
 )
 
+const (
+	UserTypeNormal = 0
+	// UserTypeBot the bot account created by admin, it can't log in and only access the api with its token
+	UserTypeBot = 1
+)
+
 // PermanentSuspensionTime is a fixed time representing permanent suspension (2099-12-31 23:59:59)
 var PermanentSuspensionTime = time.Date(2099, 12, 31, 23, 59, 59, 0, time.UTC)
 
@@ -70,6 +76,9 @@ type User struct {
 	IsAdmin        bool      `xorm:"not null default false BOOL is_admin"`
 	Language       string    `xorm:"not null default '' VARCHAR(100) language"`
 	ColorScheme    string    `xorm:"not null default '' VARCHAR(100) color_scheme"`
+	UserType       int       `xorm:"not null default 0 INT(11) user_type"`
+	// the new content of the shadow-banned user is only visible to the user and the staff
+	ShadowBanned bool `xorm:"not null default false BOOL shadow_banned"`
 }
 
 // TableName user table name
@@ -0,0 +1,55 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	UserEventTypeLogin        = "login"
+	UserEventTypeStatusChange = "status_change"
+)
+
+// UserEvent the account event of user, such as login and status change, used by the user history timeline
+type UserEvent struct {
+	ID        string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
+	UserID    string    `xorm:"not null default 0 BIGINT(20) INDEX user_id"`
+	EventType string    `xorm:"not null default '' VARCHAR(32) event_type"`
+	IP        string    `xorm:"not null default '' VARCHAR(64) ip"`
+	// the admin or moderator who did the operation, 0 means the user himself or the system
+	OperatorID string `xorm:"not null default 0 BIGINT(20) operator_id"`
+	Content    string `xorm:"not null default '' VARCHAR(255) content"`
+}
+
+// TableName user event table name
+func (UserEvent) TableName() string {
+	return "user_event"
+}
+
+// UserTimelineRecord the record of user history timeline, assembled from posts, revisions, reports and user events
+type UserTimelineRecord struct {
+	Type          string    `xorm:"record_type"`
+	ObjectID      string    `xorm:"object_id"`
+	CreatedAt     time.Time `xorm:"created_at"`
+	Summary       string    `xorm:"summary"`
+	Status        int       `xorm:"status"`
+	RelatedUserID string    `xorm:"related_user_id"`
+	IP            string    `xorm:"ip"`
+}
@@ -0,0 +1,57 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	VoteInvalidationReasonSerialVoting = "serial_voting"
+	VoteInvalidationReasonVotingRing   = "voting_ring"
+	// VoteInvalidationReasonAdminReversal all votes of the user are reversed by admin
+	VoteInvalidationReasonAdminReversal = "admin_reversal"
+)
+
+// VoteInvalidation the vote reversed because of vote fraud or by admin
+type VoteInvalidation struct {
+	ID           string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt    time.Time `xorm:"created TIMESTAMP created_at"`
+	VoterID      string    `xorm:"not null default 0 index BIGINT(20) voter_id"`
+	TargetUserID string    `xorm:"not null default 0 index BIGINT(20) target_user_id"`
+	ObjectID     string    `xorm:"not null default 0 BIGINT(20) object_id"`
+	VoteUp       bool      `xorm:"not null default false BOOL vote_up"`
+	Reason       string    `xorm:"not null default '' VARCHAR(32) reason"`
+	// the admin who reversed the vote, 0 means the vote fraud detection
+	OperatorID string `xorm:"not null default 0 BIGINT(20) operator_id"`
+	// the reputation of target user reversed
+	Rank    int       `xorm:"not null default 0 INT(11) rank"`
+	VotedAt time.Time `xorm:"TIMESTAMP voted_at"`
+}
+
+// TableName vote invalidation table name
+func (VoteInvalidation) TableName() string {
+	return "vote_invalidation"
+}
+
+// VotePairCount the number of votes from voter to target user
+type VotePairCount struct {
+	VoterID      string `xorm:"trigger_user_id"`
+	TargetUserID string `xorm:"user_id"`
+	VoteCount    int    `xorm:"vote_count"`
+}
@@ -0,0 +1,50 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	WebhookDeliveryStatusPending = "pending"
+	WebhookDeliveryStatusSuccess = "success"
+	WebhookDeliveryStatusFailed  = "failed"
+)
+
+// WebhookDelivery the delivery of an event to the webhook url, and the log of the attempts
+type WebhookDelivery struct {
+	ID         string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt  time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt  time.Time `xorm:"updated TIMESTAMP updated_at"`
+	WebhookURL string    `xorm:"not null default '' VARCHAR(512) INDEX webhook_url"`
+	Event      string    `xorm:"not null default '' VARCHAR(64) event"`
+	Payload    string    `xorm:"not null MEDIUMTEXT payload"`
+	Status     string    `xorm:"not null default 'pending' VARCHAR(16) INDEX(status_next_attempt) status"`
+	Attempts   int       `xorm:"not null default 0 INT(11) attempts"`
+	// the time of next retry for the pending delivery
+	NextAttemptAt time.Time `xorm:"TIMESTAMP INDEX(status_next_attempt) next_attempt_at"`
+	// the response status code of last attempt, 0 if the request failed
+	ResponseStatus int    `xorm:"not null default 0 INT(11) response_status"`
+	LastError      string `xorm:"not null default '' VARCHAR(1024) last_error"`
+}
+
+// TableName webhook delivery table name
+func (WebhookDelivery) TableName() string {
+	return "webhook_delivery"
+}
@@ -296,6 +296,8 @@ func (m *Mentor) initSiteInfoWrite() {
 		"max_image_size":                   4,
 		"max_attachment_size":              8,
 		"max_image_megapixel":              40,
+		"max_video_size":                   20,
+		"max_video_duration":               60,
 		"authorized_image_extensions":      []string{"jpg", "jpeg", "png", "gif", "webp"},
 		"authorized_attachment_extensions": []string{},
 	}
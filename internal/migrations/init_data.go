@@ -76,6 +76,45 @@ var (
 		&entity.BadgeAward{},
 		&entity.FileRecord{},
 		&entity.PluginKVStorage{},
+		&entity.QuestionCloseVote{},
+		&entity.ReportAppeal{},
+		&entity.UserReputationSync{},
+		&entity.VoteInvalidation{},
+		&entity.ContentLanguageStat{},
+		&entity.LeaderboardStat{},
+		&entity.UserEvent{},
+		&entity.SyndicationLink{},
+		&entity.HelpfulnessSurvey{},
+		&entity.ReEngagementEmail{},
+		&entity.QuestionQuality{},
+		&entity.TagModerator{},
+		&entity.VoteMilestone{},
+		&entity.TagDailyStat{},
+		&entity.TagIgnore{},
+		&entity.TagTranslation{},
+		&entity.UserAutoPromotion{},
+		&entity.AutoPromotionLog{},
+		&entity.ContentFreshnessStat{},
+		&entity.BotToken{},
+		&entity.SavedSearch{},
+		&entity.QuestionTitleTrigram{},
+		&entity.SiteInfoHistory{},
+		&entity.WebhookDelivery{},
+		&entity.JiraIssueLink{},
+		&entity.QuestionModeratorNote{},
+		&entity.WebPushSubscription{},
+		&entity.DigestEmail{},
+		&entity.MobilePushDevice{},
+		&entity.InboundEmail{},
+		&entity.EmailTemplate{},
+		&entity.UserMute{},
+		&entity.NotificationArchive{},
+		&entity.PersonalAccessToken{},
+		&entity.OAuthClient{},
+		&entity.InviteCode{},
+		&entity.InviteCodeUsage{},
+		&entity.AuditLog{},
+		&entity.IPBan{},
 	}
 
 	roles = []*entity.Role{
@@ -126,6 +165,10 @@ var (
 		{ID: 39, Name: "recover answer", PowerType: permission.AnswerUnDelete, Description: "recover deleted answer"},
 		{ID: 40, Name: "recover question", PowerType: permission.QuestionUnDelete, Description: "recover deleted question"},
 		{ID: 41, Name: "recover tag", PowerType: permission.TagUnDelete, Description: "recover deleted tag"},
+		{ID: 42, Name: "manage tags", PowerType: permission.TagManage, Description: "manage tags, synonyms, translations and tag moderators"},
+		{ID: 43, Name: "handle flags", PowerType: permission.ReportHandle, Description: "review the flagged posts and appeals"},
+		{ID: 44, Name: "view reports", PowerType: permission.ReportView, Description: "view the dashboard and the site reports"},
+		{ID: 45, Name: "edit site settings", PowerType: permission.SiteSettingEdit, Description: "edit the site settings"},
 	}
 
 	rolePowerRels = []*entity.RolePowerRel{
@@ -171,6 +214,10 @@ var (
 		{RoleID: 2, PowerType: permission.AnswerUnDelete},
 		{RoleID: 2, PowerType: permission.QuestionUnDelete},
 		{RoleID: 2, PowerType: permission.TagUnDelete},
+		{RoleID: 2, PowerType: permission.TagManage},
+		{RoleID: 2, PowerType: permission.ReportHandle},
+		{RoleID: 2, PowerType: permission.ReportView},
+		{RoleID: 2, PowerType: permission.SiteSettingEdit},
 
 		{RoleID: 3, PowerType: permission.QuestionAdd},
 		{RoleID: 3, PowerType: permission.QuestionEdit},
@@ -213,6 +260,7 @@ var (
 		{RoleID: 3, PowerType: permission.AnswerUnDelete},
 		{RoleID: 3, PowerType: permission.QuestionUnDelete},
 		{RoleID: 3, PowerType: permission.TagUnDelete},
+		{RoleID: 3, PowerType: permission.ReportHandle},
 	}
 
 	adminUserRoleRel = &entity.UserRoleRel{
@@ -298,7 +346,7 @@ var (
 		{ID: 76, Key: "question.flag.reasons", Value: `["reason.spam","reason.rude_or_abusive","reason.something","reason.a_duplicate"]`},
 		{ID: 77, Key: "answer.flag.reasons", Value: `["reason.spam","reason.rude_or_abusive","reason.something","reason.not_a_answer"]`},
 		{ID: 78, Key: "comment.flag.reasons", Value: `["reason.spam","reason.rude_or_abusive","reason.something","reason.no_longer_needed"]`},
-		{ID: 79, Key: "question.close.reasons", Value: `["reason.a_duplicate","reason.community_specific","reason.not_clarity","reason.something"]`},
+		{ID: 79, Key: "question.close.reasons", Value: `["reason.a_duplicate","reason.off_topic","reason.community_specific","reason.not_clarity","reason.something"]`},
 		{ID: 80, Key: "question.status.reasons", Value: `["reason.normal","reason.closed","reason.deleted"]`},
 		{ID: 81, Key: "answer.status.reasons", Value: `["reason.normal","reason.deleted"]`},
 		{ID: 82, Key: "comment.status.reasons", Value: `["reason.normal","reason.deleted"]`},
@@ -350,12 +398,15 @@ var (
 		{ID: 128, Key: "rank.answer.undeleted", Value: `-1`},
 		{ID: 129, Key: "rank.question.undeleted", Value: `-1`},
 		{ID: 130, Key: "rank.tag.undeleted", Value: `-1`},
+		{ID: 131, Key: "rank.question.close_vote", Value: `3000`},
+		{ID: 132, Key: "reason.off_topic", Value: `{"name":"off-topic","description":"This question doesn't belong here, it may be a better fit for another community."}`},
 	}
 
 	defaultBadgeGroupTable = []*entity.BadgeGroup{
 		{ID: "1", Name: "badge.default_badge_groups.getting_started.name"},
 		{ID: "2", Name: "badge.default_badge_groups.community.name"},
 		{ID: "3", Name: "badge.default_badge_groups.posting.name"},
+		{ID: "4", Name: "badge.default_badge_groups.tags.name"},
 	}
 
 	defaultBadgeTable = []*entity.Badge{
@@ -506,5 +557,38 @@ var (
 			Handler:      "ReachQuestionVote",
 			Param:        `{"amount":"50"}`,
 		},
+		{
+			Name:         "badge.default_badges.tag_bronze.name",
+			Icon:         "tag-fill",
+			Description:  "badge.default_badges.tag_bronze.desc",
+			Status:       entity.BadgeStatusActive,
+			BadgeGroupID: 4,
+			Level:        entity.BadgeLevelBronze,
+			Single:       entity.BadgeMultiAward,
+			Handler:      entity.TagAnswerScoreBadgeHandler,
+			Param:        `{"score":"100"}`,
+		},
+		{
+			Name:         "badge.default_badges.tag_silver.name",
+			Icon:         "tag-fill",
+			Description:  "badge.default_badges.tag_silver.desc",
+			Status:       entity.BadgeStatusActive,
+			BadgeGroupID: 4,
+			Level:        entity.BadgeLevelSilver,
+			Single:       entity.BadgeMultiAward,
+			Handler:      entity.TagAnswerScoreBadgeHandler,
+			Param:        `{"score":"400"}`,
+		},
+		{
+			Name:         "badge.default_badges.tag_gold.name",
+			Icon:         "tag-fill",
+			Description:  "badge.default_badges.tag_gold.desc",
+			Status:       entity.BadgeStatusActive,
+			BadgeGroupID: 4,
+			Level:        entity.BadgeLevelGold,
+			Single:       entity.BadgeMultiAward,
+			Handler:      entity.TagAnswerScoreBadgeHandler,
+			Param:        `{"score":"1000"}`,
+		},
 	}
 )
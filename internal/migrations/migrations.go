@@ -103,6 +103,56 @@ var migrations = []Migration{
 	NewMigration("v1.4.5", "add file record", addFileRecord, true),
 	NewMigration("v1.5.1", "add plugin kv storage", addPluginKVStorage, true),
 	NewMigration("v1.6.0", "move user config to interface", moveUserConfigToInterface, true),
+	NewMigration("v1.6.1", "add question close vote", addQuestionCloseVote, true),
+	NewMigration("v1.6.2", "add report appeal", addReportAppeal, true),
+	NewMigration("v1.6.3", "add user reputation sync", addUserReputationSync, true),
+	NewMigration("v1.6.4", "add vote invalidation", addVoteInvalidation, true),
+	NewMigration("v1.6.5", "add question resolution note", addQuestionResolutionNote, true),
+	NewMigration("v1.6.6", "add comment code anchor", addCommentCodeAnchor, true),
+	NewMigration("v1.6.7", "add content language stat", addContentLanguageStat, true),
+	NewMigration("v1.6.8", "add leaderboard stat", addLeaderboardStat, true),
+	NewMigration("v1.6.9", "add vote invalidation operator", addVoteInvalidationOperator, true),
+	NewMigration("v1.6.10", "add user event", addUserEvent, true),
+	NewMigration("v1.6.11", "add syndication link", addSyndicationLink, true),
+	NewMigration("v1.6.12", "add helpfulness survey", addHelpfulnessSurvey, true),
+	NewMigration("v1.6.13", "add re-engagement email", addReEngagementEmail, true),
+	NewMigration("v1.6.14", "add question quality", addQuestionQuality, true),
+	NewMigration("v1.6.15", "add tag moderator", addTagModerator, true),
+	NewMigration("v1.6.16", "add vote milestone", addVoteMilestone, true),
+	NewMigration("v1.6.17", "add answer provenance", addAnswerProvenance, true),
+	NewMigration("v1.6.18", "add tag daily stat", addTagDailyStat, true),
+	NewMigration("v1.6.19", "add tag badges", addTagBadges, true),
+	NewMigration("v1.6.20", "add tag deprecation", addTagDeprecation, true),
+	NewMigration("v1.6.21", "add tag ignore", addTagIgnore, true),
+	NewMigration("v1.6.22", "add tag translation", addTagTranslation, true),
+	NewMigration("v1.6.23", "add auto promotion", addAutoPromotion, true),
+	NewMigration("v1.6.24", "add content freshness", addContentFreshness, true),
+	NewMigration("v1.6.25", "add off-topic close reason with migration suggestion", addOffTopicCloseReason, true),
+	NewMigration("v1.6.26", "add question link type", addQuestionLinkType, true),
+	NewMigration("v1.6.27", "add bot account", addBotAccount, true),
+	NewMigration("v1.6.28", "add saved search", addSavedSearch, true),
+	NewMigration("v1.6.29", "add question title trigram", addQuestionTitleTrigram, true),
+	NewMigration("v1.6.30", "add question language", addQuestionLanguage, true),
+	NewMigration("v1.6.31", "add site info version and history", addSiteInfoVersion, true),
+	NewMigration("v1.6.32", "add question pinned answer", addQuestionPinnedAnswer, true),
+	NewMigration("v1.6.33", "add webhook delivery", addWebhookDelivery, true),
+	NewMigration("v1.6.34", "add jira issue link and question moderator note", addJiraIssueLink, true),
+	NewMigration("v1.6.35", "add web push subscription", addWebPushSubscription, true),
+	NewMigration("v1.6.36", "add digest email", addDigestEmail, true),
+	NewMigration("v1.6.37", "add notification group key", addNotificationGroupKey, true),
+	NewMigration("v1.6.38", "add mobile push device", addMobilePushDevice, true),
+	NewMigration("v1.6.39", "add inbound email", addInboundEmail, true),
+	NewMigration("v1.6.40", "add email template", addEmailTemplate, true),
+	NewMigration("v1.6.41", "add user mute", addUserMute, true),
+	NewMigration("v1.6.42", "add notification archive", addNotificationArchive, true),
+	NewMigration("v1.6.43", "add personal access token", addPersonalAccessToken, true),
+	NewMigration("v1.6.44", "add oauth client", addOAuthClient, true),
+	NewMigration("v1.6.45", "add invite code", addInviteCode, true),
+	NewMigration("v1.6.46", "add granular role permission", addGranularRolePermission, true),
+	NewMigration("v1.6.47", "add audit log", addAuditLog, true),
+	NewMigration("v1.6.48", "add structured report", addStructuredReport, true),
+	NewMigration("v1.6.49", "add shadow ban", addShadowBan, true),
+	NewMigration("v1.6.50", "add ip ban", addIPBan, true),
 }
 
 func GetMigrations() []Migration {
@@ -0,0 +1,70 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/unique"
+	"xorm.io/xorm"
+)
+
+// addTagBadges add the tag badge group and the tag answer score badges,
+// existing badges are left untouched so that their status set by admin is kept.
+func addTagBadges(ctx context.Context, x *xorm.Engine) (err error) {
+	uniqueIDRepo := unique.NewUniqueIDRepo(&data.Data{DB: x})
+
+	for _, badgeGroup := range defaultBadgeGroupTable {
+		exist, err := x.Context(ctx).Get(&entity.BadgeGroup{ID: badgeGroup.ID})
+		if err != nil {
+			return err
+		}
+		if exist {
+			continue
+		}
+		if _, err = x.Context(ctx).Insert(badgeGroup); err != nil {
+			return fmt.Errorf("insert badge group failed: %w", err)
+		}
+	}
+
+	for _, badge := range defaultBadgeTable {
+		if badge.Handler != entity.TagAnswerScoreBadgeHandler {
+			continue
+		}
+		exist, err := x.Context(ctx).Get(&entity.Badge{Name: badge.Name})
+		if err != nil {
+			return err
+		}
+		if exist {
+			continue
+		}
+		badge.ID, err = uniqueIDRepo.GenUniqueIDStr(ctx, new(entity.Badge).TableName())
+		if err != nil {
+			return err
+		}
+		if _, err = x.Context(ctx).Insert(badge); err != nil {
+			return fmt.Errorf("insert badge failed: %w", err)
+		}
+	}
+	return nil
+}
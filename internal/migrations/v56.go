@@ -0,0 +1,62 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/pkg/converter"
+	"xorm.io/xorm"
+)
+
+func addQuestionTitleTrigram(ctx context.Context, x *xorm.Engine) error {
+	if err := x.Context(ctx).Sync(new(entity.QuestionTitleTrigram)); err != nil {
+		return fmt.Errorf("sync question title trigram table failed: %w", err)
+	}
+
+	// build the trigrams of all existing question titles
+	startID := "0"
+	for {
+		questions := make([]*entity.Question, 0)
+		err := x.Context(ctx).Cols("id", "title").Where("id > ?", startID).
+			OrderBy("id ASC").Limit(100).Find(&questions)
+		if err != nil {
+			return fmt.Errorf("get questions failed: %w", err)
+		}
+		if len(questions) == 0 {
+			return nil
+		}
+		for _, question := range questions {
+			trigrams := make([]*entity.QuestionTitleTrigram, 0)
+			for _, t := range converter.Trigrams(question.Title) {
+				trigrams = append(trigrams, &entity.QuestionTitleTrigram{QuestionID: question.ID, Trigram: t})
+			}
+			if len(trigrams) == 0 {
+				continue
+			}
+			if _, err = x.Context(ctx).Insert(trigrams); err != nil {
+				return fmt.Errorf("insert question title trigrams failed: %w", err)
+			}
+		}
+		startID = questions[len(questions)-1].ID
+	}
+}
@@ -0,0 +1,60 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/pkg/checker"
+	"xorm.io/xorm"
+)
+
+func addQuestionLanguage(ctx context.Context, x *xorm.Engine) error {
+	if err := x.Context(ctx).Sync(new(entity.Question)); err != nil {
+		return fmt.Errorf("sync question table failed: %w", err)
+	}
+
+	// detect the language of all existing questions
+	startID := "0"
+	for {
+		questions := make([]*entity.Question, 0)
+		err := x.Context(ctx).Cols("id", "title", "original_text").Where("id > ?", startID).
+			OrderBy("id ASC").Limit(100).Find(&questions)
+		if err != nil {
+			return fmt.Errorf("get questions failed: %w", err)
+		}
+		if len(questions) == 0 {
+			return nil
+		}
+		for _, question := range questions {
+			question.Language, _ = checker.DetectLanguage(question.Title + " " + question.OriginalText)
+			if len(question.Language) == 0 {
+				continue
+			}
+			_, err = x.Context(ctx).ID(question.ID).Cols("language").Update(&entity.Question{Language: question.Language})
+			if err != nil {
+				return fmt.Errorf("update question language failed: %w", err)
+			}
+		}
+		startID = questions[len(questions)-1].ID
+	}
+}
@@ -0,0 +1,74 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/permission"
+	"xorm.io/xorm"
+)
+
+func addGranularRolePermission(ctx context.Context, x *xorm.Engine) error {
+	powers := []*entity.Power{
+		{ID: 42, Name: "manage tags", PowerType: permission.TagManage, Description: "manage tags, synonyms, translations and tag moderators"},
+		{ID: 43, Name: "handle flags", PowerType: permission.ReportHandle, Description: "review the flagged posts and appeals"},
+		{ID: 44, Name: "view reports", PowerType: permission.ReportView, Description: "view the dashboard and the site reports"},
+		{ID: 45, Name: "edit site settings", PowerType: permission.SiteSettingEdit, Description: "edit the site settings"},
+	}
+	for _, power := range powers {
+		exist, err := x.Context(ctx).Get(&entity.Power{ID: power.ID})
+		if err != nil {
+			return err
+		}
+		if exist {
+			_, err = x.Context(ctx).ID(power.ID).Update(power)
+		} else {
+			_, err = x.Context(ctx).Insert(power)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	rolePowerRels := []*entity.RolePowerRel{
+		{RoleID: 2, PowerType: permission.TagManage},
+		{RoleID: 2, PowerType: permission.ReportHandle},
+		{RoleID: 2, PowerType: permission.ReportView},
+		{RoleID: 2, PowerType: permission.SiteSettingEdit},
+
+		{RoleID: 3, PowerType: permission.ReportHandle},
+	}
+	for _, rel := range rolePowerRels {
+		exist, err := x.Context(ctx).Get(&entity.RolePowerRel{RoleID: rel.RoleID, PowerType: rel.PowerType})
+		if err != nil {
+			return err
+		}
+		if exist {
+			continue
+		}
+		_, err = x.Context(ctx).Insert(rel)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/answer/internal/entity"
+	"xorm.io/xorm"
+)
+
+func addStructuredReport(ctx context.Context, x *xorm.Engine) error {
+	if err := x.Context(ctx).Sync(new(entity.Report)); err != nil {
+		return fmt.Errorf("sync report table failed: %w", err)
+	}
+
+	// the category of the existing reports is inferred from the report reason
+	reportTypes := make([]int, 0)
+	if err := x.Context(ctx).Table(new(entity.Report)).Distinct("report_type").
+		Where("category = ?", "").Find(&reportTypes); err != nil {
+		return fmt.Errorf("get report types failed: %w", err)
+	}
+	for _, reportType := range reportTypes {
+		category := entity.ReportCategoryOther
+		cf := &entity.Config{ID: reportType}
+		exist, err := x.Context(ctx).Get(cf)
+		if err != nil {
+			return fmt.Errorf("get config failed: %w", err)
+		}
+		if exist {
+			category = entity.GetReportCategoryByReason(cf.Key)
+		}
+		_, err = x.Context(ctx).Where("report_type = ? AND category = ?", reportType, "").
+			Cols("category").Update(&entity.Report{Category: category})
+		if err != nil {
+			return fmt.Errorf("update report category failed: %w", err)
+		}
+	}
+	return nil
+}
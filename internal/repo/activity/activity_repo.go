@@ -65,6 +65,21 @@ func (ar *activityRepo) GetObjectAllActivity(ctx context.Context, objectID strin
 	return activityList, nil
 }
 
+// GetObjectsActivityByTypes get the activities of the objects with the activity types, ordered by the created time
+func (ar *activityRepo) GetObjectsActivityByTypes(ctx context.Context, objectIDs []string, activityTypes []int) (
+	activityList []*entity.Activity, err error) {
+	activityList = make([]*entity.Activity, 0)
+	if len(objectIDs) == 0 || len(activityTypes) == 0 {
+		return activityList, nil
+	}
+	err = ar.data.DB.Context(ctx).In("original_object_id", objectIDs).In("activity_type", activityTypes).
+		Asc("created_at", "id").Find(&activityList)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return activityList, nil
+}
+
 func (ar *activityRepo) getAllActivityType(ctx context.Context) (activityTypes []int) {
 	var activityTypeNotShown []int
 	for _, key := range activity_type.VoteActivityTypeList {
@@ -22,6 +22,7 @@ package activity
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/apache/answer/internal/service/content"
@@ -207,6 +208,176 @@ func (vr *VoteRepo) ListUserVotes(ctx context.Context, userID string,
 	return
 }
 
+// RevokeUserVotesAndReputation cancel the votes cast by user and the activities that user earned reputation from.
+// The reputation granted to others by the votes is rolled back, and the reputation of user is reduced to the minimum.
+// It returns the objects voted by or voted on the user, so their vote count can be recalculated.
+func (vr *VoteRepo) RevokeUserVotesAndReputation(ctx context.Context, userID string, voteActivityTypes []int) (
+	votedObjectIDs []string, err error) {
+	_, err = vr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+
+		activities := make([]*entity.Activity, 0)
+		err = session.Where(builder.Eq{"cancelled": entity.ActivityAvailable}).
+			And(builder.Or(
+				builder.Eq{"trigger_user_id": userID}.And(builder.In("activity_type", voteActivityTypes)),
+				builder.Eq{"user_id": userID}.And(builder.Or(
+					builder.In("activity_type", voteActivityTypes),
+					builder.Neq{"`rank`": 0},
+				)),
+			)).ForUpdate().Find(&activities)
+		if err != nil {
+			return nil, err
+		}
+		if len(activities) == 0 {
+			return nil, nil
+		}
+
+		activityIDs := make([]string, 0, len(activities))
+		rankChanges := make(map[string]int)
+		objectIDs := make(map[string]bool)
+		voteTypes := make(map[int]bool, len(voteActivityTypes))
+		for _, t := range voteActivityTypes {
+			voteTypes[t] = true
+		}
+		for _, activity := range activities {
+			activityIDs = append(activityIDs, activity.ID)
+			rankChanges[activity.UserID] -= activity.Rank
+			if voteTypes[activity.ActivityType] {
+				objectIDs[activity.ObjectID] = true
+			}
+		}
+		_, err = session.In("id", activityIDs).Cols("cancelled", "cancelled_at").
+			Update(&entity.Activity{
+				Cancelled:   entity.ActivityCancelled,
+				CancelledAt: time.Now(),
+			})
+		if err != nil {
+			return nil, err
+		}
+
+		userIDs := make([]string, 0, len(rankChanges))
+		for id := range rankChanges {
+			userIDs = append(userIDs, id)
+		}
+		userInfoMapping, err := vr.acquireUserInfo(session, userIDs)
+		if err != nil {
+			return nil, err
+		}
+		for id, deltaRank := range rankChanges {
+			user := userInfoMapping[id]
+			if user == nil {
+				continue
+			}
+			if err = vr.userRankRepo.ChangeUserRank(ctx, session, id, user.Rank, deltaRank); err != nil {
+				return nil, err
+			}
+		}
+		for objectID := range objectIDs {
+			votedObjectIDs = append(votedObjectIDs, objectID)
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return votedObjectIDs, nil
+}
+
+// RevokeUserCastVotes reverse all the votes cast by the voter in one transaction, including the reputation changed
+// by the votes. Each reversed vote is recorded as a vote invalidation of the operator.
+func (vr *VoteRepo) RevokeUserCastVotes(ctx context.Context, voterID, operatorID string,
+	castActivityTypes, votedActivityTypes, voteUpActivityTypes []int) (invalidations []*entity.VoteInvalidation, err error) {
+	invalidations = make([]*entity.VoteInvalidation, 0)
+	_, err = vr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+
+		activities := make([]*entity.Activity, 0)
+		err = session.Where(builder.Eq{"cancelled": entity.ActivityAvailable}).
+			And(builder.Or(
+				builder.Eq{"user_id": voterID}.And(builder.In("activity_type", castActivityTypes)),
+				builder.Eq{"trigger_user_id": voterID}.And(builder.In("activity_type", votedActivityTypes)),
+			)).ForUpdate().Find(&activities)
+		if err != nil {
+			return nil, err
+		}
+		if len(activities) == 0 {
+			return nil, nil
+		}
+
+		voteUpTypes := make(map[int]bool, len(voteUpActivityTypes))
+		for _, t := range voteUpActivityTypes {
+			voteUpTypes[t] = true
+		}
+		votedTypes := make(map[int]bool, len(votedActivityTypes))
+		for _, t := range votedActivityTypes {
+			votedTypes[t] = true
+		}
+		activityIDs := make([]string, 0, len(activities))
+		rankChanges := make(map[string]int)
+		objectInvalidations := make(map[string]*entity.VoteInvalidation)
+		for _, activity := range activities {
+			activityIDs = append(activityIDs, activity.ID)
+			rankChanges[activity.UserID] -= activity.Rank
+
+			// one vote has the activity of voter and the activity of the voted object creator
+			record, ok := objectInvalidations[activity.ObjectID]
+			if !ok {
+				record = &entity.VoteInvalidation{
+					VoterID:      voterID,
+					TargetUserID: "0",
+					OperatorID:   operatorID,
+					ObjectID:     activity.ObjectID,
+					VoteUp:       voteUpTypes[activity.ActivityType],
+					Reason:       entity.VoteInvalidationReasonAdminReversal,
+					VotedAt:      activity.UpdatedAt,
+				}
+				objectInvalidations[activity.ObjectID] = record
+				invalidations = append(invalidations, record)
+			}
+			if votedTypes[activity.ActivityType] {
+				record.TargetUserID = activity.UserID
+				record.Rank = activity.Rank
+			}
+		}
+		_, err = session.In("id", activityIDs).Cols("cancelled", "cancelled_at").
+			Update(&entity.Activity{
+				Cancelled:   entity.ActivityCancelled,
+				CancelledAt: time.Now(),
+			})
+		if err != nil {
+			return nil, err
+		}
+
+		userIDs := make([]string, 0, len(rankChanges))
+		for id := range rankChanges {
+			userIDs = append(userIDs, id)
+		}
+		userInfoMapping, err := vr.acquireUserInfo(session, userIDs)
+		if err != nil {
+			return nil, err
+		}
+		for id, deltaRank := range rankChanges {
+			user := userInfoMapping[id]
+			if user == nil || deltaRank == 0 {
+				continue
+			}
+			if err = vr.userRankRepo.ChangeUserRank(ctx, session, id, user.Rank, deltaRank); err != nil {
+				return nil, err
+			}
+		}
+		for _, record := range invalidations {
+			if _, err = session.Insert(record); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return invalidations, nil
+}
+
 func (vr *VoteRepo) votePreCheck(ctx context.Context, op *schema.VoteOperationInfo) (noNeedToVote bool, err error) {
 	activities, err := vr.getExistActivity(ctx, op)
 	if err != nil {
@@ -501,6 +672,11 @@ func (vr *VoteRepo) sendVoteInboxNotification(ctx context.Context, triggerUserID
 			msg.NotificationAction = constant.NotificationUpVotedTheComment
 		}
 	}
+	if upvote {
+		// the vote count is used by the notification pipeline to aggregate votes into milestones
+		voteCount := vr.countVoteUp(ctx, objectID, objectType) - vr.countVoteDown(ctx, objectID, objectType)
+		msg.ExtraInfo = map[string]string{"vote_count": strconv.FormatInt(voteCount, 10)}
+	}
 	if len(msg.NotificationAction) > 0 {
 		vr.notificationQueueService.Send(ctx, msg)
 	}
@@ -115,6 +115,38 @@ func (ar *FollowRepo) GetFollowUserIDs(ctx context.Context, objectID string) (us
 	return userIDs, nil
 }
 
+// GetFollowUserIDsByObjectIDs get the distinct users following any of the objects, the objects can be in different types
+func (ar *FollowRepo) GetFollowUserIDsByObjectIDs(ctx context.Context, objectIDs []string) (userIDs []string, err error) {
+	userIDs = make([]string, 0)
+	objectIDsByActivityType := make(map[int][]string)
+	for _, objectID := range objectIDs {
+		objectTypeStr, err := obj.GetObjectTypeStrByObjectID(objectID)
+		if err != nil {
+			return nil, err
+		}
+		activityType, err := ar.activityRepo.GetActivityTypeByObjectType(ctx, objectTypeStr, "follow")
+		if err != nil {
+			log.Errorf("can't get activity type by object key: %s", objectTypeStr)
+			return nil, err
+		}
+		objectIDsByActivityType[activityType] = append(objectIDsByActivityType[activityType], objectID)
+	}
+	if len(objectIDsByActivityType) == 0 {
+		return userIDs, nil
+	}
+
+	cond := builder.NewCond()
+	for activityType, ids := range objectIDsByActivityType {
+		cond = cond.Or(builder.In("object_id", ids).And(builder.Eq{"activity_type": activityType}))
+	}
+	err = ar.data.DB.Context(ctx).Table(entity.Activity{}.TableName()).Distinct("user_id").
+		Where(cond).And("cancelled = 0").Find(&userIDs)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return userIDs, nil
+}
+
 // GetFollowIDs get all follow id list
 func (ar *FollowRepo) GetFollowIDs(ctx context.Context, userID, objectKey string) (followIDs []string, err error) {
 	followIDs = make([]string, 0)
@@ -21,6 +21,7 @@ package answer
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/apache/answer/internal/base/constant"
@@ -34,6 +35,7 @@ import (
 	answercommon "github.com/apache/answer/internal/service/answer_common"
 	"github.com/apache/answer/internal/service/rank"
 	"github.com/apache/answer/internal/service/unique"
+	"github.com/apache/answer/pkg/converter"
 	"github.com/apache/answer/pkg/uid"
 	"github.com/apache/answer/plugin"
 	"github.com/segmentfault/pacman/errors"
@@ -291,7 +293,8 @@ func (ar *answerRepo) GetByIDs(ctx context.Context, answerIDs ...string) ([]*ent
 func (ar *answerRepo) GetCountByQuestionID(ctx context.Context, questionID string) (int64, error) {
 	questionID = uid.DeShortID(questionID)
 	var resp = new(entity.Answer)
-	count, err := ar.data.DB.Context(ctx).Where("question_id =? and  status = ?", questionID, entity.AnswerStatusAvailable).Count(resp)
+	count, err := ar.data.DB.Context(ctx).Where("question_id =? and  status = ?", questionID, entity.AnswerStatusAvailable).
+		And("shadow = ?", false).Count(resp)
 	if err != nil {
 		return count, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
@@ -351,6 +354,10 @@ func (ar *answerRepo) SearchList(ctx context.Context, search *entity.AnswerSearc
 	if len(search.UserID) > 0 {
 		session = session.And("user_id = ?", search.UserID)
 	}
+	// the pinned answer is shown on top regardless of the order
+	if pinnedID := converter.StringToInt64(uid.DeShortID(search.PinnedAnswerID)); pinnedID > 0 {
+		session = session.OrderBy(fmt.Sprintf("CASE WHEN id = %d THEN 0 ELSE 1 END", pinnedID))
+	}
 	switch search.Order {
 	case entity.AnswerSearchOrderByTime:
 		session = session.OrderBy("created_at desc")
@@ -361,11 +368,12 @@ func (ar *answerRepo) SearchList(ctx context.Context, search *entity.AnswerSearc
 	default:
 		session = session.OrderBy("adopted desc,vote_count desc,created_at asc")
 	}
+	// the answers of the shadow-banned users are only visible to the authors and the staff who can see the deleted answers
 	if !search.IncludeDeleted {
 		if search.LoginUserID == "" {
-			session = session.And("status = ? ", entity.AnswerStatusAvailable)
+			session = session.And("status = ? AND shadow = ?", entity.AnswerStatusAvailable, false)
 		} else {
-			session = session.And("status = ? OR user_id = ?", entity.AnswerStatusAvailable, search.LoginUserID)
+			session = session.And("(status = ? AND shadow = ?) OR user_id = ?", entity.AnswerStatusAvailable, false, search.LoginUserID)
 		}
 	}
 
@@ -403,7 +411,7 @@ func (ar *answerRepo) GetPersonalAnswerPage(ctx context.Context, req *entity.Per
 	if req.ShowPending {
 		session = session.And("status != ?", entity.AnswerStatusDeleted)
 	} else {
-		session = session.And("status = ?", entity.AnswerStatusAvailable)
+		session = session.And("status = ? AND shadow = ?", entity.AnswerStatusAvailable, false)
 	}
 	resp = make([]*entity.Answer, 0)
 	total, err = pager.Help(req.Page, req.PageSize, &resp, cond, session)
@@ -438,6 +446,17 @@ func (ar *answerRepo) AdminSearchList(ctx context.Context, req *schema.AdminAnsw
 	if req.Status > 0 {
 		cond.Status = req.Status
 	}
+	switch req.Provenance {
+	case schema.AnswerProvenanceAIAssisted:
+		session.Where("answer.ai_assisted = ?", true)
+	case schema.AnswerProvenanceImported:
+		session.Where("answer.import_batch <> ''")
+	case schema.AnswerProvenanceSourced:
+		session.Where("answer.source_url <> ''")
+	}
+	if len(req.ImportBatch) > 0 {
+		session.Where("answer.import_batch = ?", req.ImportBatch)
+	}
 	session.Desc("answer.created_at")
 
 	resp = make([]*entity.Answer, 0)
@@ -464,13 +483,18 @@ func (ar *answerRepo) updateSearch(ctx context.Context, answerID string) (err er
 	answerID = uid.DeShortID(answerID)
 	// check search plugin
 	var (
-		s plugin.Search
+		s  plugin.Search
+		vs plugin.VectorSearch
 	)
 	_ = plugin.CallSearch(func(search plugin.Search) error {
 		s = search
 		return nil
 	})
-	if s == nil {
+	_ = plugin.CallVectorSearch(func(search plugin.VectorSearch) error {
+		vs = search
+		return nil
+	})
+	if s == nil && vs == nil {
 		return
 	}
 	answer, exist, err := ar.GetAnswer(ctx, answerID)
@@ -524,7 +548,14 @@ func (ar *answerRepo) updateSearch(ctx context.Context, answerID string) (err er
 		Score:       int64(answer.VoteCount),
 		HasAccepted: answer.Accepted == schema.AnswerAcceptedEnable,
 	}
-	err = s.UpdateContent(ctx, content)
+	if s != nil {
+		if err = s.UpdateContent(ctx, content); err != nil {
+			return err
+		}
+	}
+	if vs != nil {
+		err = vs.UpdateVector(ctx, content)
+	}
 	return
 }
 
@@ -0,0 +1,85 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package audit_log
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/audit_log"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// auditLogRepo audit log repository
+type auditLogRepo struct {
+	data *data.Data
+}
+
+// NewAuditLogRepo new repository
+func NewAuditLogRepo(data *data.Data) audit_log.AuditLogRepo {
+	return &auditLogRepo{
+		data: data,
+	}
+}
+
+// AddAuditLog add audit log
+func (ar *auditLogRepo) AddAuditLog(ctx context.Context, auditLog *entity.AuditLog) (err error) {
+	_, err = ar.data.DB.Context(ctx).Insert(auditLog)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAuditLogPage get the audit logs matched the condition, the latest first
+func (ar *auditLogRepo) GetAuditLogPage(ctx context.Context, page, pageSize int, cond *schema.AuditLogCond) (
+	auditLogs []*entity.AuditLog, total int64, err error) {
+	auditLogs = make([]*entity.AuditLog, 0)
+	where := builder.NewCond()
+	if len(cond.OperatorID) > 0 {
+		where = where.And(builder.Eq{"operator_id": cond.OperatorID})
+	}
+	if len(cond.Action) > 0 {
+		where = where.And(builder.Eq{"action": cond.Action})
+	}
+	if len(cond.ObjectType) > 0 {
+		where = where.And(builder.Eq{"object_type": cond.ObjectType})
+	}
+	if len(cond.ObjectID) > 0 {
+		where = where.And(builder.Eq{"object_id": cond.ObjectID})
+	}
+	if cond.StartTime > 0 {
+		where = where.And(builder.Gte{"created_at": time.Unix(cond.StartTime, 0)})
+	}
+	if cond.EndTime > 0 {
+		where = where.And(builder.Lte{"created_at": time.Unix(cond.EndTime, 0)})
+	}
+	session := ar.data.DB.Context(ctx).Where(where).Desc("id")
+	total, err = pager.Help(page, pageSize, &auditLogs, &entity.AuditLog{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -206,6 +206,45 @@ func (ar *authRepo) AddUserTokenMapping(ctx context.Context, userID, accessToken
 	return ar.data.Cache.SetString(ctx, key, string(content), constant.UserTokenCacheTime)
 }
 
+// GetUserTokens get the access tokens of user, the expired tokens may be included
+func (ar *authRepo) GetUserTokens(ctx context.Context, userID string) (accessTokens []string, err error) {
+	resp, _, err := ar.data.Cache.GetString(ctx, constant.UserTokenMappingCacheKey+userID)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	mapping := make(map[string]bool, 0)
+	if len(resp) > 0 {
+		_ = json.Unmarshal([]byte(resp), &mapping)
+	}
+	for accessToken := range mapping {
+		accessTokens = append(accessTokens, accessToken)
+	}
+	return accessTokens, nil
+}
+
+// RemoveUserTokenMapping remove the access tokens from the user token mapping
+func (ar *authRepo) RemoveUserTokenMapping(ctx context.Context, userID string, accessTokens ...string) (err error) {
+	key := constant.UserTokenMappingCacheKey + userID
+	resp, exist, err := ar.data.Cache.GetString(ctx, key)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		return nil
+	}
+	mapping := make(map[string]bool, 0)
+	_ = json.Unmarshal([]byte(resp), &mapping)
+	for _, accessToken := range accessTokens {
+		delete(mapping, accessToken)
+	}
+	content, _ := json.Marshal(mapping)
+	err = ar.data.Cache.SetString(ctx, key, string(content), constant.UserTokenCacheTime)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
 // RemoveUserTokens Log out all users under this user id
 func (ar *authRepo) RemoveUserTokens(ctx context.Context, userID string, remainToken string) {
 	key := constant.UserTokenMappingCacheKey + userID
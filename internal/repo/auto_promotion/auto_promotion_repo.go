@@ -0,0 +1,156 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package auto_promotion
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/auto_promotion"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// autoPromotionRepo auto promotion repository
+type autoPromotionRepo struct {
+	data *data.Data
+}
+
+// NewAutoPromotionRepo new repository
+func NewAutoPromotionRepo(data *data.Data) auto_promotion.AutoPromotionRepo {
+	return &autoPromotionRepo{
+		data: data,
+	}
+}
+
+// GetUserActivityStats get the activity of the available users whose reputation reaches the min reputation
+// and registered before the time, the users are ordered by id and start after the user id
+func (ar *autoPromotionRepo) GetUserActivityStats(ctx context.Context, minReputation int, registeredBefore time.Time,
+	startUserID string, limit int) (stats []*entity.UserActivityStat, err error) {
+	stats = make([]*entity.UserActivityStat, 0)
+	users := make([]*entity.User, 0)
+	err = ar.data.DB.Context(ctx).
+		Where(builder.Eq{"status": entity.UserStatusAvailable}).
+		And(builder.Gte{"`rank`": minReputation}).
+		And(builder.Lte{"created_at": registeredBefore}).
+		And(builder.Gt{"id": startUserID}).
+		Asc("id").Limit(limit).Find(&users)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if len(users) == 0 {
+		return stats, nil
+	}
+
+	userIDs := make([]string, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.ID)
+	}
+	answerStats := make([]*entity.UserActivityStat, 0)
+	err = ar.data.DB.Context(ctx).Table(entity.Answer{}.TableName()).
+		Select(fmt.Sprintf("user_id, COUNT(*) AS answer_count, "+
+			"SUM(CASE WHEN adopted = %d THEN 1 ELSE 0 END) AS accepted_count, "+
+			"SUM(vote_count) AS answer_score", schema.AnswerAcceptedEnable)).
+		Where(builder.Eq{"status": entity.AnswerStatusAvailable}).
+		And(builder.In("user_id", userIDs)).
+		GroupBy("user_id").Find(&answerStats)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	answerStatMapping := make(map[string]*entity.UserActivityStat, len(answerStats))
+	for _, stat := range answerStats {
+		answerStatMapping[stat.UserID] = stat
+	}
+
+	for _, user := range users {
+		stat := &entity.UserActivityStat{UserID: user.ID, Rank: user.Rank, CreatedAt: user.CreatedAt}
+		if answerStat, ok := answerStatMapping[user.ID]; ok {
+			stat.AnswerCount = answerStat.AnswerCount
+			stat.AcceptedCount = answerStat.AcceptedCount
+			stat.AnswerScore = answerStat.AnswerScore
+		}
+		stats = append(stats, stat)
+	}
+	return stats, nil
+}
+
+// GetUserAutoPromotions get the progress of users evaluated by the rule of the role
+func (ar *autoPromotionRepo) GetUserAutoPromotions(ctx context.Context, roleID int) (
+	progresses []*entity.UserAutoPromotion, err error) {
+	progresses = make([]*entity.UserAutoPromotion, 0)
+	err = ar.data.DB.Context(ctx).Where(builder.Eq{"role_id": roleID}).Find(&progresses)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// SaveUserAutoPromotion add the progress or update it if exists
+func (ar *autoPromotionRepo) SaveUserAutoPromotion(ctx context.Context, progress *entity.UserAutoPromotion) (err error) {
+	if len(progress.ID) == 0 {
+		_, err = ar.data.DB.Context(ctx).Insert(progress)
+	} else {
+		_, err = ar.data.DB.Context(ctx).ID(progress.ID).
+			Cols("qualified_times", "failed_times", "granted", "last_evaluated_at").Update(progress)
+	}
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveUserAutoPromotion remove the progress
+func (ar *autoPromotionRepo) RemoveUserAutoPromotion(ctx context.Context, id string) (err error) {
+	_, err = ar.data.DB.Context(ctx).ID(id).Delete(&entity.UserAutoPromotion{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddAutoPromotionLog add the audit log
+func (ar *autoPromotionRepo) AddAutoPromotionLog(ctx context.Context, promotionLog *entity.AutoPromotionLog) (err error) {
+	_, err = ar.data.DB.Context(ctx).Insert(promotionLog)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAutoPromotionLogPage get the audit logs, the latest first
+func (ar *autoPromotionRepo) GetAutoPromotionLogPage(ctx context.Context, page, pageSize int, userID string) (
+	logs []*entity.AutoPromotionLog, total int64, err error) {
+	logs = make([]*entity.AutoPromotionLog, 0)
+	session := ar.data.DB.Context(ctx).Desc("id")
+	if len(userID) > 0 {
+		session.Where(builder.Eq{"user_id": userID})
+	}
+	total, err = pager.Help(page, pageSize, &logs, &entity.AutoPromotionLog{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -21,6 +21,7 @@ package badge
 
 import (
 	"context"
+	"fmt"
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/data"
 	"github.com/apache/answer/internal/base/reason"
@@ -30,6 +31,7 @@ import (
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
 	"strconv"
+	"xorm.io/xorm"
 )
 
 // eventRuleRepo event rule repo
@@ -56,7 +58,7 @@ func NewEventRuleRepo(data *data.Data) badge.EventRuleRepo {
 		constant.EventAnswerCreate:   nil,
 		constant.EventAnswerUpdate:   {b.FirstPostEdit},
 		constant.EventAnswerDelete:   nil,
-		constant.EventAnswerVote:     {b.FirstVotedPost, b.ReachAnswerVote},
+		constant.EventAnswerVote:     {b.FirstVotedPost, b.ReachAnswerVote, b.ReachTagAnswerScore},
 		constant.EventAnswerFlag:     {b.FirstFlaggedPost},
 		constant.EventAnswerReact:    {b.FirstReactedPost},
 		constant.EventCommentCreate:  nil,
@@ -234,6 +236,96 @@ func (br *eventRuleRepo) ReachQuestionVote(ctx context.Context,
 	return awards, nil
 }
 
+// ReachTagAnswerScore reach the total answer score in the tags of the voted answer's question
+func (br *eventRuleRepo) ReachTagAnswerScore(ctx context.Context,
+	event *schema.EventMsg) (awards []*entity.BadgeAward, err error) {
+	badges := br.getBadgesByHandler(ctx, entity.TagAnswerScoreBadgeHandler)
+	if len(badges) == 0 || len(event.AnswerID) == 0 || len(event.AnswerUserID) == 0 {
+		return nil, nil
+	}
+
+	answer := &entity.Answer{}
+	exist, err := br.data.DB.Context(ctx).ID(event.AnswerID).Cols("question_id").Get(answer)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		return nil, nil
+	}
+
+	tagRelList := make([]*entity.TagRel, 0)
+	err = br.data.DB.Context(ctx).Cols("tag_id").
+		Where("object_id = ? AND status = ?", answer.QuestionID, entity.TagRelStatusAvailable).Find(&tagRelList)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if len(tagRelList) == 0 {
+		return nil, nil
+	}
+	tagIDs := make([]string, 0, len(tagRelList))
+	for _, tagRel := range tagRelList {
+		tagIDs = append(tagIDs, tagRel.TagID)
+	}
+
+	scores := make([]*entity.UserTagAnswerScore, 0)
+	session := br.tagAnswerScoreSession(ctx).
+		Where("answer.user_id = ?", event.AnswerUserID).
+		In("tag_rel.tag_id", tagIDs)
+	if err = session.Find(&scores); err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return br.createTagAnswerScoreAwards(badges, scores), nil
+}
+
+// ListTagAnswerScoreAwards list the tag badge awards that all users have reached, used to backfill tag badges
+func (br *eventRuleRepo) ListTagAnswerScoreAwards(ctx context.Context) (awards []*entity.BadgeAward, err error) {
+	badges := br.getBadgesByHandler(ctx, entity.TagAnswerScoreBadgeHandler)
+	if len(badges) == 0 {
+		return nil, nil
+	}
+	minScore := int64(0)
+	for _, b := range badges {
+		requirement := b.GetIntParam("score")
+		if requirement > 0 && (minScore == 0 || requirement < minScore) {
+			minScore = requirement
+		}
+	}
+	if minScore == 0 {
+		return nil, nil
+	}
+
+	scores := make([]*entity.UserTagAnswerScore, 0)
+	session := br.tagAnswerScoreSession(ctx).Having(fmt.Sprintf("SUM(answer.vote_count) >= %d", minScore))
+	if err = session.Find(&scores); err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return br.createTagAnswerScoreAwards(badges, scores), nil
+}
+
+// tagAnswerScoreSession sum the vote count of available answers grouped by user and tag
+func (br *eventRuleRepo) tagAnswerScoreSession(ctx context.Context) *xorm.Session {
+	return br.data.DB.Context(ctx).Table(entity.Answer{}.TableName()).
+		Select("answer.user_id, tag_rel.tag_id, SUM(answer.vote_count) AS score").
+		Join("INNER", entity.TagRel{}.TableName(), "tag_rel.object_id = answer.question_id").
+		Where("answer.status = ? AND tag_rel.status = ?", entity.AnswerStatusAvailable, entity.TagRelStatusAvailable).
+		GroupBy("answer.user_id, tag_rel.tag_id")
+}
+
+func (br *eventRuleRepo) createTagAnswerScoreAwards(badges []*entity.Badge, scores []*entity.UserTagAnswerScore) (
+	awards []*entity.BadgeAward) {
+	for _, score := range scores {
+		for _, b := range badges {
+			// get badge requirement
+			requirement := b.GetIntParam("score")
+			if requirement == 0 || score.Score < requirement {
+				continue
+			}
+			awards = append(awards, br.createBadgeAward(score.UserID, score.TagID, b))
+		}
+	}
+	return awards
+}
+
 func (br *eventRuleRepo) getBadgesByHandler(ctx context.Context, handler string) (badges []*entity.Badge) {
 	badges = make([]*entity.Badge, 0)
 	err := br.data.DB.Context(ctx).Where("handler = ?", handler).Find(&badges)
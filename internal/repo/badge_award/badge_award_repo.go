@@ -161,6 +161,51 @@ func (r *badgeAwardRepo) ListNewestEarned(ctx context.Context, userID string, li
 	return
 }
 
+// ListTagBadgeAwards list the active tag badges earned by user with the tag info
+func (r *badgeAwardRepo) ListTagBadgeAwards(ctx context.Context, userID string) (
+	tagBadgeAwards []*entity.TagBadgeAward, err error) {
+	tagBadgeAwards = make([]*entity.TagBadgeAward, 0)
+	err = r.data.DB.Context(ctx).
+		Select("badge_award.badge_id, badge_award.award_key, badge.level").
+		Join("INNER", "badge", "badge.id = badge_award.badge_id").
+		Where("badge_award.user_id = ? AND badge_award.is_badge_deleted = ?", userID, entity.IsBadgeNotDeleted).
+		And("badge.handler = ? AND badge.status = ?", entity.TagAnswerScoreBadgeHandler, entity.BadgeStatusActive).
+		Find(&tagBadgeAwards)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if len(tagBadgeAwards) == 0 {
+		return tagBadgeAwards, nil
+	}
+
+	tagIDs := make([]string, 0, len(tagBadgeAwards))
+	for _, award := range tagBadgeAwards {
+		tagIDs = append(tagIDs, award.TagID)
+	}
+	tags := make([]*entity.Tag, 0)
+	err = r.data.DB.Context(ctx).In("id", tagIDs).And("status = ?", entity.TagStatusAvailable).Find(&tags)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	tagMapping := make(map[string]*entity.Tag, len(tags))
+	for _, tag := range tags {
+		tagMapping[tag.ID] = tag
+	}
+
+	// skip the awards whose tag has been deleted
+	available := make([]*entity.TagBadgeAward, 0, len(tagBadgeAwards))
+	for _, award := range tagBadgeAwards {
+		tag, ok := tagMapping[award.TagID]
+		if !ok {
+			continue
+		}
+		award.SlugName = tag.SlugName
+		award.DisplayName = tag.DisplayName
+		available = append(available, award)
+	}
+	return available, nil
+}
+
 // GetByUserIdAndBadgeId get badge award by user id and badge id
 func (r *badgeAwardRepo) GetByUserIdAndBadgeId(ctx context.Context, userID string, badgeID string) (
 	badgeAward *entity.BadgeAward, exists bool, err error) {
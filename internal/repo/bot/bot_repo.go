@@ -0,0 +1,172 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package bot
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/auth"
+	"github.com/apache/answer/internal/service/bot"
+	"github.com/apache/answer/internal/service/role"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// botRepo bot repository
+type botRepo struct {
+	data *data.Data
+}
+
+// NewBotRepo new repository
+func NewBotRepo(data *data.Data) bot.BotRepo {
+	return &botRepo{
+		data: data,
+	}
+}
+
+// AddBot add the bot user with its token
+func (br *botRepo) AddBot(ctx context.Context, user *entity.User, tokenHash string) (err error) {
+	_, err = br.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		exist, err := session.Where(builder.Eq{"username": user.Username}).Exist(&entity.User{})
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		if exist {
+			return nil, errors.BadRequest(reason.UsernameDuplicate)
+		}
+		if _, err = session.Insert(user); err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		_, err = session.Insert(&entity.BotToken{UserID: user.ID, TokenHash: tokenHash})
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		return nil, nil
+	})
+	return err
+}
+
+// GetBotToken get the token of bot
+func (br *botRepo) GetBotToken(ctx context.Context, userID string) (botToken *entity.BotToken, exist bool, err error) {
+	botToken = &entity.BotToken{}
+	exist, err = br.data.DB.Context(ctx).Where(builder.Eq{"user_id": userID}).Get(botToken)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetBotTokens get the tokens of bots
+func (br *botRepo) GetBotTokens(ctx context.Context, userIDs []string) (botTokens []*entity.BotToken, err error) {
+	botTokens = make([]*entity.BotToken, 0)
+	if len(userIDs) == 0 {
+		return botTokens, nil
+	}
+	err = br.data.DB.Context(ctx).In("user_id", userIDs).Find(&botTokens)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateBotToken replace the token of bot
+func (br *botRepo) UpdateBotToken(ctx context.Context, userID, tokenHash string) (err error) {
+	_, err = br.data.DB.Context(ctx).Where(builder.Eq{"user_id": userID}).
+		MustCols("last_used_at").Update(&entity.BotToken{TokenHash: tokenHash})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetBotPage get bot page
+func (br *botRepo) GetBotPage(ctx context.Context, page, pageSize int) (
+	users []*entity.User, total int64, err error) {
+	users = make([]*entity.User, 0)
+	session := br.data.DB.Context(ctx).Where(builder.Eq{"user_type": entity.UserTypeBot}).Desc("id")
+	total, err = pager.Help(page, pageSize, &users, &entity.User{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// botTokenRepo bot token repository
+type botTokenRepo struct {
+	data *data.Data
+}
+
+// NewBotTokenRepo new repository
+func NewBotTokenRepo(data *data.Data) auth.BotTokenRepo {
+	return &botTokenRepo{
+		data: data,
+	}
+}
+
+// GetBotUserCacheInfo get the bot user by the hash of token
+func (br *botTokenRepo) GetBotUserCacheInfo(ctx context.Context, tokenHash string) (
+	userInfo *entity.UserCacheInfo, exist bool, err error) {
+	botToken := &entity.BotToken{}
+	exist, err = br.data.DB.Context(ctx).Where(builder.Eq{"token_hash": tokenHash}).Get(botToken)
+	if err != nil {
+		return nil, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		return nil, false, nil
+	}
+	user := &entity.User{}
+	exist, err = br.data.DB.Context(ctx).ID(botToken.UserID).
+		Where(builder.Eq{"user_type": entity.UserTypeBot}).Get(user)
+	if err != nil {
+		return nil, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		return nil, false, nil
+	}
+	userRoleRel := &entity.UserRoleRel{}
+	exist, err = br.data.DB.Context(ctx).Where(builder.Eq{"user_id": user.ID}).Get(userRoleRel)
+	if err != nil {
+		return nil, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	roleID := role.RoleUserID
+	if exist {
+		roleID = userRoleRel.RoleID
+	}
+
+	_, err = br.data.DB.Context(ctx).ID(botToken.ID).Cols("last_used_at").
+		Update(&entity.BotToken{LastUsedAt: time.Now()})
+	if err != nil {
+		log.Errorf("update bot token last used time failed: %v", err)
+	}
+	return &entity.UserCacheInfo{
+		UserID:      user.ID,
+		UserStatus:  user.Status,
+		EmailStatus: user.MailStatus,
+		RoleID:      roleID,
+	}, true, nil
+}
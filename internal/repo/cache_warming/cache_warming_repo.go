@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package cache_warming
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/cache_warming"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// cacheWarmingRepo cache warming repository
+type cacheWarmingRepo struct {
+	data *data.Data
+}
+
+// NewCacheWarmingRepo new repository
+func NewCacheWarmingRepo(data *data.Data) cache_warming.CacheWarmingRepo {
+	return &cacheWarmingRepo{
+		data: data,
+	}
+}
+
+// GetRecentlyAnsweredQuestionIDs get the questions answered since the time, the latest answered first
+func (cr *cacheWarmingRepo) GetRecentlyAnsweredQuestionIDs(ctx context.Context, since time.Time, limit int) (
+	questionIDs []string, err error) {
+	questionIDs = make([]string, 0)
+	err = cr.data.DB.Context(ctx).Table(entity.Answer{}.TableName()).
+		Select("question_id").
+		Where(builder.Eq{"status": entity.AnswerStatusAvailable}).
+		And(builder.Gte{"created_at": since}).
+		GroupBy("question_id").
+		OrderBy("MAX(created_at) DESC").
+		Limit(limit).
+		Find(&questionIDs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetTrendingQuestionIDs get the visible questions with the highest hot score
+func (cr *cacheWarmingRepo) GetTrendingQuestionIDs(ctx context.Context, limit int) (questionIDs []string, err error) {
+	questionIDs = make([]string, 0)
+	err = cr.data.DB.Context(ctx).Table(entity.Question{}.TableName()).
+		Select("id").
+		Where(builder.In("status", entity.QuestionStatusAvailable, entity.QuestionStatusClosed)).
+		And(builder.Eq{"show": entity.QuestionShow}).
+		And(builder.Gt{"hot_score": 0}).
+		Desc("hot_score").
+		Limit(limit).
+		Find(&questionIDs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
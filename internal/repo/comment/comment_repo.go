@@ -32,6 +32,7 @@ import (
 	"github.com/apache/answer/internal/service/comment_common"
 	"github.com/apache/answer/internal/service/unique"
 	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
 )
 
 // commentRepo comment repository
@@ -79,6 +80,48 @@ func (cr *commentRepo) RemoveComment(ctx context.Context, commentID string) (err
 	return
 }
 
+// UpdateCommentStatus update comment status
+func (cr *commentRepo) UpdateCommentStatus(ctx context.Context, commentID string, status int) (err error) {
+	_, err = cr.data.DB.Context(ctx).ID(commentID).Cols("status").Update(&entity.Comment{Status: status})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveComments remove comments by ids
+func (cr *commentRepo) RemoveComments(ctx context.Context, commentIDs []string) (err error) {
+	_, err = cr.data.DB.Context(ctx).In("id", commentIDs).Update(&entity.Comment{Status: entity.CommentStatusDeleted})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetCommentsByFilter get the not deleted comments matched the filter, the newest first
+func (cr *commentRepo) GetCommentsByFilter(ctx context.Context, filter *comment.CommentFilter, limit int) (
+	comments []*entity.Comment, err error) {
+	comments = make([]*entity.Comment, 0)
+	session := cr.data.DB.Context(ctx).Where("status != ?", entity.CommentStatusDeleted)
+	if len(filter.UserID) > 0 {
+		session.And("user_id = ?", filter.UserID)
+	}
+	if !filter.StartTime.IsZero() {
+		session.And("created_at >= ?", filter.StartTime)
+	}
+	if !filter.EndTime.IsZero() {
+		session.And("created_at <= ?", filter.EndTime)
+	}
+	if len(filter.Keyword) > 0 {
+		session.And("original_text LIKE ?", "%"+filter.Keyword+"%")
+	}
+	err = session.Cols("id", "user_id").Desc("id").Limit(limit).Find(&comments)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
 // UpdateCommentContent update comment
 func (cr *commentRepo) UpdateCommentContent(
 	ctx context.Context, commentID string, originalText string, parsedText string) (err error) {
@@ -132,6 +175,9 @@ func (cr *commentRepo) GetCommentPage(ctx context.Context, commentQuery *comment
 	session := cr.data.DB.Context(ctx)
 	session.OrderBy(commentQuery.GetOrderBy())
 	session.Where("status = ?", entity.CommentStatusAvailable)
+	if !commentQuery.IncludeShadow {
+		session.And(builder.Or(builder.Eq{"shadow": false}, builder.Eq{"user_id": commentQuery.LoginUserID}))
+	}
 
 	cond := &entity.Comment{ObjectID: commentQuery.ObjectID, UserID: commentQuery.UserID}
 	total, err = pager.Help(commentQuery.Page, commentQuery.PageSize, &commentList, cond, session)
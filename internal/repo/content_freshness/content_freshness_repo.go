@@ -0,0 +1,185 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content_freshness
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/content_freshness"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// contentFreshnessRepo content freshness repository
+type contentFreshnessRepo struct {
+	data *data.Data
+}
+
+// NewContentFreshnessRepo new repository
+func NewContentFreshnessRepo(data *data.Data) content_freshness.ContentFreshnessRepo {
+	return &contentFreshnessRepo{
+		data: data,
+	}
+}
+
+type tagQuestionCount struct {
+	TagID  string `xorm:"tag_id"`
+	Amount int    `xorm:"amount"`
+}
+
+// AnalyzeContentFreshness analyze the freshness metrics of the questions in the tags with questions asked since the time,
+// the accepted answers not updated before the stale time and the questions referencing the deprecated versions are counted
+func (cr *contentFreshnessRepo) AnalyzeContentFreshness(ctx context.Context, activeSince, staleBefore time.Time,
+	deprecatedVersions []string) (stats []*entity.ContentFreshnessStat, err error) {
+	activeTags := builder.Select("atr.tag_id").From(entity.TagRel{}.TableName(), "atr").
+		InnerJoin(entity.Question{}.TableName()+" aq", "aq.id = atr.object_id").
+		Where(builder.Eq{"atr.status": entity.TagRelStatusAvailable}.
+			And(builder.In("aq.status", entity.QuestionStatusAvailable, entity.QuestionStatusClosed)).
+			And(builder.Gte{"aq.created_at": activeSince}))
+
+	questionCounts, err := cr.countTagQuestions(ctx, activeTags, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	acceptedCounts, err := cr.countTagQuestions(ctx, activeTags, builder.Neq{"q.accepted_answer_id": 0}, false)
+	if err != nil {
+		return nil, err
+	}
+	staleCounts, err := cr.countTagQuestions(ctx, activeTags, builder.Lt{"a.created_at": staleBefore}.
+		And(builder.Or(builder.IsNull{"a.updated_at"}, builder.Lt{"a.updated_at": staleBefore})), true)
+	if err != nil {
+		return nil, err
+	}
+	deprecatedCounts := make(map[string]int)
+	if len(deprecatedVersions) > 0 {
+		cond := builder.NewCond()
+		for _, version := range deprecatedVersions {
+			cond = cond.Or(builder.Like{"q.title", version}, builder.Like{"q.original_text", version})
+		}
+		deprecatedCounts, err = cr.countTagQuestions(ctx, activeTags, cond, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	stats = make([]*entity.ContentFreshnessStat, 0, len(questionCounts))
+	for tagID, questionCount := range questionCounts {
+		stat := &entity.ContentFreshnessStat{
+			TagID:              tagID,
+			QuestionCount:      questionCount,
+			AcceptedCount:      acceptedCounts[tagID],
+			StaleAcceptedCount: staleCounts[tagID],
+			DeprecatedCount:    deprecatedCounts[tagID],
+		}
+		stat.StalePercent = schema.ContentFreshnessStalePercent(stat.StaleAcceptedCount, stat.AcceptedCount)
+		stats = append(stats, stat)
+	}
+	return stats, nil
+}
+
+// countTagQuestions count the questions meeting the condition in each active tag, and the distinct questions
+// in all active tags with the tag id 0
+func (cr *contentFreshnessRepo) countTagQuestions(ctx context.Context, activeTags *builder.Builder,
+	cond builder.Cond, joinAcceptedAnswer bool) (counts map[string]int, err error) {
+	newSession := func() *xorm.Session {
+		session := cr.data.DB.Context(ctx).Table(entity.TagRel{}.TableName()).Alias("tr").
+			Join("INNER", []string{entity.Question{}.TableName(), "q"}, "q.id = tr.object_id")
+		if joinAcceptedAnswer {
+			session.Join("INNER", []string{entity.Answer{}.TableName(), "a"}, "a.id = q.accepted_answer_id")
+		}
+		session.Where(builder.Eq{"tr.status": entity.TagRelStatusAvailable}).
+			And(builder.In("q.status", entity.QuestionStatusAvailable, entity.QuestionStatusClosed)).
+			And(builder.In("tr.tag_id", activeTags))
+		if cond != nil {
+			session.And(cond)
+		}
+		return session
+	}
+
+	tagCounts := make([]*tagQuestionCount, 0)
+	err = newSession().Select("tr.tag_id, COUNT(*) AS amount").GroupBy("tr.tag_id").Find(&tagCounts)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	var total int64
+	_, err = newSession().Select("COUNT(DISTINCT q.id)").Get(&total)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	counts = make(map[string]int, len(tagCounts)+1)
+	for _, count := range tagCounts {
+		counts[count.TagID] = count.Amount
+	}
+	counts["0"] = int(total)
+	return counts, nil
+}
+
+// ReplaceContentFreshnessStats replace all stats with the latest analysis
+func (cr *contentFreshnessRepo) ReplaceContentFreshnessStats(ctx context.Context, stats []*entity.ContentFreshnessStat) (err error) {
+	_, err = cr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		if _, err = session.Where(builder.Gt{"id": 0}).Delete(&entity.ContentFreshnessStat{}); err != nil {
+			return nil, err
+		}
+		for start := 0; start < len(stats); start += 100 {
+			end := min(start+100, len(stats))
+			if _, err = session.Insert(stats[start:end]); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetContentFreshnessStat get the stat of the tag, the tag id 0 means all active tags
+func (cr *contentFreshnessRepo) GetContentFreshnessStat(ctx context.Context, tagID string) (
+	stat *entity.ContentFreshnessStat, exist bool, err error) {
+	stat = &entity.ContentFreshnessStat{}
+	exist, err = cr.data.DB.Context(ctx).Where(builder.Eq{"tag_id": tagID}).Get(stat)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetContentFreshnessStatPage get the stats of active tags, the most stale first
+func (cr *contentFreshnessRepo) GetContentFreshnessStatPage(ctx context.Context, page, pageSize int) (
+	stats []*entity.ContentFreshnessStat, total int64, err error) {
+	stats = make([]*entity.ContentFreshnessStat, 0)
+	session := cr.data.DB.Context(ctx).Where(builder.Neq{"tag_id": "0"}).
+		Desc("stale_percent", "deprecated_count").Asc("id")
+	total, err = pager.Help(page, pageSize, &stats, &entity.ContentFreshnessStat{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
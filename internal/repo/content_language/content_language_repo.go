@@ -0,0 +1,87 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content_language
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/content_language"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// contentLanguageRepo content language repository
+type contentLanguageRepo struct {
+	data *data.Data
+}
+
+// NewContentLanguageRepo new repository
+func NewContentLanguageRepo(data *data.Data) content_language.ContentLanguageRepo {
+	return &contentLanguageRepo{
+		data: data,
+	}
+}
+
+// IncrLanguageStat increase the statistics of the language, create it if not exist
+func (cr *contentLanguageRepo) IncrLanguageStat(ctx context.Context, language string, review, translated bool) (err error) {
+	incr := func() (int64, error) {
+		session := cr.data.DB.Context(ctx).Where("language = ?", language).Incr("post_count")
+		if review {
+			session.Incr("review_count")
+		}
+		if translated {
+			session.Incr("translated_count")
+		}
+		return session.Update(&entity.ContentLanguageStat{})
+	}
+	affected, err := incr()
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if affected > 0 {
+		return nil
+	}
+	stat := &entity.ContentLanguageStat{Language: language, PostCount: 1}
+	if review {
+		stat.ReviewCount = 1
+	}
+	if translated {
+		stat.TranslatedCount = 1
+	}
+	if _, err = cr.data.DB.Context(ctx).Insert(stat); err != nil {
+		// the stat may be created by another request at the same time
+		if _, err = incr(); err != nil {
+			return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+	}
+	return nil
+}
+
+// GetLanguageStats get all language statistics, the most posts first
+func (cr *contentLanguageRepo) GetLanguageStats(ctx context.Context) (stats []*entity.ContentLanguageStat, err error) {
+	stats = make([]*entity.ContentLanguageStat, 0)
+	err = cr.data.DB.Context(ctx).Desc("post_count").Find(&stats)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
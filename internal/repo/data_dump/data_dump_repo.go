@@ -0,0 +1,145 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package data_dump
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/data_dump"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// dataDumpRepo public data dump repository
+type dataDumpRepo struct {
+	data *data.Data
+}
+
+// NewDataDumpRepo new repository
+func NewDataDumpRepo(data *data.Data) data_dump.DataDumpRepo {
+	return &dataDumpRepo{
+		data: data,
+	}
+}
+
+// GetQuestionsAfter get the public questions whose id is greater than lastID, excluding the questions with the tags
+func (dr *dataDumpRepo) GetQuestionsAfter(ctx context.Context, lastID string, limit int, excludedTagIDs []string) (
+	questions []*entity.Question, err error) {
+	questions = make([]*entity.Question, 0)
+	session := dr.data.DB.Context(ctx).Table("question").
+		Where("question.id > ?", lastID).
+		In("question.status", []int{entity.QuestionStatusAvailable, entity.QuestionStatusClosed}).
+		And("question.`show` = ?", entity.QuestionShow)
+	if len(excludedTagIDs) > 0 {
+		session.And(excludeTaggedQuestions(excludedTagIDs))
+	}
+	err = session.Asc("question.id").Limit(limit).Find(&questions)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAnswersAfter get the public answers of public questions whose id is greater than lastID,
+// excluding the answers of the questions with the tags
+func (dr *dataDumpRepo) GetAnswersAfter(ctx context.Context, lastID string, limit int, excludedTagIDs []string) (
+	answers []*entity.Answer, err error) {
+	answers = make([]*entity.Answer, 0)
+	session := dr.data.DB.Context(ctx).Table("answer").Select("answer.*").
+		Join("INNER", "question", "answer.question_id = question.id").
+		Where("answer.id > ?", lastID).
+		And("answer.status = ?", entity.AnswerStatusAvailable).
+		And("answer.shadow = ?", false).
+		In("question.status", []int{entity.QuestionStatusAvailable, entity.QuestionStatusClosed}).
+		And("question.`show` = ?", entity.QuestionShow)
+	if len(excludedTagIDs) > 0 {
+		session.And(excludeTaggedQuestions(excludedTagIDs))
+	}
+	err = session.Asc("answer.id").Limit(limit).Find(&answers)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// excludeTaggedQuestions the condition excluding the questions carrying any of the tags
+func excludeTaggedQuestions(tagIDs []string) builder.Cond {
+	return builder.NotIn("question.id", builder.Select("object_id").From(entity.TagRel{}.TableName()).
+		Where(builder.In("tag_id", tagIDs).And(builder.Eq{"status": entity.TagRelStatusAvailable})))
+}
+
+// GetTagsAfter get the available tags whose id is greater than lastID
+func (dr *dataDumpRepo) GetTagsAfter(ctx context.Context, lastID string, limit int) (
+	tags []*entity.Tag, err error) {
+	tags = make([]*entity.Tag, 0)
+	err = dr.data.DB.Context(ctx).
+		Where("id > ?", lastID).
+		And("status = ?", entity.TagStatusAvailable).
+		Asc("id").Limit(limit).Find(&tags)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUsersAfter get the not deleted users whose id is greater than lastID
+func (dr *dataDumpRepo) GetUsersAfter(ctx context.Context, lastID string, limit int) (
+	users []*entity.User, err error) {
+	users = make([]*entity.User, 0)
+	err = dr.data.DB.Context(ctx).
+		Cols("id", "created_at", "`rank`", "question_count", "answer_count").
+		Where("id > ?", lastID).
+		And("status <> ?", entity.UserStatusDeleted).
+		Asc("id").Limit(limit).Find(&users)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetTagSlugNamesByObjectIDs get the available tag slug names of objects, the key is object id
+func (dr *dataDumpRepo) GetTagSlugNamesByObjectIDs(ctx context.Context, objectIDs []string) (
+	mapping map[string][]string, err error) {
+	mapping = make(map[string][]string, len(objectIDs))
+	if len(objectIDs) == 0 {
+		return mapping, nil
+	}
+	rows := make([]*struct {
+		ObjectID string `xorm:"object_id"`
+		SlugName string `xorm:"slug_name"`
+	}, 0)
+	err = dr.data.DB.Context(ctx).Table("tag_rel").
+		Select("tag_rel.object_id, tag.slug_name").
+		Join("INNER", "tag", "tag_rel.tag_id = tag.id").
+		In("tag_rel.object_id", objectIDs).
+		And("tag_rel.status = ?", entity.TagRelStatusAvailable).
+		Asc("tag_rel.id").
+		Find(&rows)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	for _, row := range rows {
+		mapping[row.ObjectID] = append(mapping[row.ObjectID], row.SlugName)
+	}
+	return mapping, nil
+}
@@ -0,0 +1,135 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package digest
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/digest"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// digestRepo digest repository
+type digestRepo struct {
+	data *data.Data
+}
+
+// NewDigestRepo new repository
+func NewDigestRepo(data *data.Data) digest.DigestRepo {
+	return &digestRepo{
+		data: data,
+	}
+}
+
+// GetSentUserIDs get the users who have been sent the digest of the frequency since the time
+func (dr *digestRepo) GetSentUserIDs(ctx context.Context, frequency string, userIDs []string, since time.Time) (
+	sent map[string]bool, err error) {
+	sent = make(map[string]bool)
+	if len(userIDs) == 0 {
+		return sent, nil
+	}
+	records := make([]*entity.DigestEmail, 0)
+	err = dr.data.DB.Context(ctx).
+		Where(builder.Eq{"frequency": frequency}).
+		And(builder.In("user_id", userIDs)).
+		And(builder.Gte{"created_at": since}).
+		Find(&records)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	for _, record := range records {
+		sent[record.UserID] = true
+	}
+	return sent, nil
+}
+
+// GetTagQuestions get the latest visible questions with any of the tags created by others in the time range
+func (dr *digestRepo) GetTagQuestions(ctx context.Context, tagIDs []string, excludeUserID string,
+	since, until time.Time, limit int) (questions []*entity.Question, total int64, err error) {
+	questions = make([]*entity.Question, 0)
+	if len(tagIDs) == 0 {
+		return questions, 0, nil
+	}
+	subQuery := builder.Select("object_id").From(entity.TagRel{}.TableName()).
+		Where(builder.In("tag_id", tagIDs).And(builder.Eq{"status": entity.TagRelStatusAvailable}))
+	total, err = dr.data.DB.Context(ctx).
+		Where(builder.In("id", subQuery)).
+		And("question.status = ? AND question.show = ?", entity.QuestionStatusAvailable, entity.QuestionShow).
+		And(builder.Neq{"user_id": excludeUserID}).
+		And(builder.Gte{"created_at": since}).
+		And(builder.Lt{"created_at": until}).
+		Desc("created_at").Limit(limit).FindAndCount(&questions)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetNewAnswerStats get the number of answers posted by others in the time range for the questions
+func (dr *digestRepo) GetNewAnswerStats(ctx context.Context, questionIDs []string, excludeUserID string,
+	since, until time.Time) (stats []*entity.DigestAnswerStat, err error) {
+	stats = make([]*entity.DigestAnswerStat, 0)
+	if len(questionIDs) == 0 {
+		return stats, nil
+	}
+	err = dr.data.DB.Context(ctx).Table(entity.Answer{}.TableName()).
+		Select("question_id, COUNT(*) AS new_answer_count").
+		Where(builder.In("question_id", questionIDs)).
+		And(builder.Eq{"status": entity.AnswerStatusAvailable}).
+		And(builder.Neq{"user_id": excludeUserID}).
+		And(builder.Gte{"created_at": since}).
+		And(builder.Lt{"created_at": until}).
+		GroupBy("question_id").
+		Find(&stats)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetTopQuestions get the most voted visible questions created in the time range
+func (dr *digestRepo) GetTopQuestions(ctx context.Context, since, until time.Time, limit int) (
+	questions []*entity.Question, err error) {
+	questions = make([]*entity.Question, 0)
+	err = dr.data.DB.Context(ctx).
+		Where("question.status = ? AND question.show = ?", entity.QuestionStatusAvailable, entity.QuestionShow).
+		And(builder.Gte{"created_at": since}).
+		And(builder.Lt{"created_at": until}).
+		And(builder.Gt{"vote_count": 0}).
+		Desc("vote_count", "answer_count").Limit(limit).Find(&questions)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddEmailRecord add the record of digest sent
+func (dr *digestRepo) AddEmailRecord(ctx context.Context, record *entity.DigestEmail) (err error) {
+	_, err = dr.data.DB.Context(ctx).Insert(record)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
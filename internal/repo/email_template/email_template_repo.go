@@ -0,0 +1,93 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package email_template
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/email_template"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// emailTemplateRepo email template repository
+type emailTemplateRepo struct {
+	data *data.Data
+}
+
+// NewEmailTemplateRepo new repository
+func NewEmailTemplateRepo(data *data.Data) email_template.EmailTemplateRepo {
+	return &emailTemplateRepo{
+		data: data,
+	}
+}
+
+// GetEmailTemplate get the custom template of the key and language
+func (er *emailTemplateRepo) GetEmailTemplate(ctx context.Context, key, language string) (
+	tpl *entity.EmailTemplate, exist bool, err error) {
+	tpl = &entity.EmailTemplate{}
+	exist, err = er.data.DB.Context(ctx).Where("template_key = ? AND language = ?", key, language).Get(tpl)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetEmailTemplates get all the custom templates
+func (er *emailTemplateRepo) GetEmailTemplates(ctx context.Context) (tpls []*entity.EmailTemplate, err error) {
+	tpls = make([]*entity.EmailTemplate, 0)
+	err = er.data.DB.Context(ctx).Asc("template_key", "language").Find(&tpls)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// SaveEmailTemplate add the custom template or update it if the key and language is customized before
+func (er *emailTemplateRepo) SaveEmailTemplate(ctx context.Context, tpl *entity.EmailTemplate) (err error) {
+	old := &entity.EmailTemplate{}
+	exist, err := er.data.DB.Context(ctx).Where("template_key = ? AND language = ?", tpl.TemplateKey, tpl.Language).
+		Get(old)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		tpl.ID = old.ID
+		_, err = er.data.DB.Context(ctx).ID(old.ID).Cols("subject", "body").Update(tpl)
+	} else {
+		_, err = er.data.DB.Context(ctx).Insert(tpl)
+	}
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// DeleteEmailTemplate delete the custom template of the key and language
+func (er *emailTemplateRepo) DeleteEmailTemplate(ctx context.Context, key, language string) (err error) {
+	_, err = er.data.DB.Context(ctx).Where("template_key = ? AND language = ?", key, language).
+		Delete(&entity.EmailTemplate{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
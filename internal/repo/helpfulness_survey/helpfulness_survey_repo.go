@@ -0,0 +1,123 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package helpfulness_survey
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/helpfulness_survey"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// helpfulnessSurveyRepo helpfulness survey repository
+type helpfulnessSurveyRepo struct {
+	data *data.Data
+}
+
+// NewHelpfulnessSurveyRepo new repository
+func NewHelpfulnessSurveyRepo(data *data.Data) helpfulness_survey.HelpfulnessSurveyRepo {
+	return &helpfulnessSurveyRepo{
+		data: data,
+	}
+}
+
+// AddSurvey add survey
+func (hr *helpfulnessSurveyRepo) AddSurvey(ctx context.Context, survey *entity.HelpfulnessSurvey) (err error) {
+	_, err = hr.data.DB.Context(ctx).Insert(survey)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetSurvey get the survey of user for the answer
+func (hr *helpfulnessSurveyRepo) GetSurvey(ctx context.Context, userID, answerID string) (
+	survey *entity.HelpfulnessSurvey, exist bool, err error) {
+	survey = &entity.HelpfulnessSurvey{}
+	exist, err = hr.data.DB.Context(ctx).Where(builder.Eq{"user_id": userID, "answer_id": answerID}).Get(survey)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetSurveyByToken get survey by token
+func (hr *helpfulnessSurveyRepo) GetSurveyByToken(ctx context.Context, token string) (
+	survey *entity.HelpfulnessSurvey, exist bool, err error) {
+	survey = &entity.HelpfulnessSurvey{}
+	exist, err = hr.data.DB.Context(ctx).Where(builder.Eq{"token": token}).Get(survey)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDueSurveys get the pending surveys of user that are due, the earliest first
+func (hr *helpfulnessSurveyRepo) GetDueSurveys(ctx context.Context, userID string, now time.Time, limit int) (
+	surveys []*entity.HelpfulnessSurvey, err error) {
+	surveys = make([]*entity.HelpfulnessSurvey, 0)
+	err = hr.data.DB.Context(ctx).
+		Where(builder.Eq{"user_id": userID, "status": entity.HelpfulnessSurveyStatusPending}).
+		And(builder.Lte{"due_at": now}).
+		Asc("due_at").Limit(limit).Find(&surveys)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateSurveyResult update the status and result of survey
+func (hr *helpfulnessSurveyRepo) UpdateSurveyResult(ctx context.Context, survey *entity.HelpfulnessSurvey) (err error) {
+	_, err = hr.data.DB.Context(ctx).ID(survey.ID).Cols("status", "answered_at").Update(survey)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAnswersHelpfulnessStat get the aggregated responses of answers, the dismissed surveys are not counted
+func (hr *helpfulnessSurveyRepo) GetAnswersHelpfulnessStat(ctx context.Context, answerIDs []string) (
+	stats map[string]*entity.AnswerHelpfulnessStat, err error) {
+	stats = make(map[string]*entity.AnswerHelpfulnessStat, len(answerIDs))
+	if len(answerIDs) == 0 {
+		return stats, nil
+	}
+	list := make([]*entity.AnswerHelpfulnessStat, 0)
+	err = hr.data.DB.Context(ctx).Table(entity.HelpfulnessSurvey{}.TableName()).
+		Select(fmt.Sprintf("answer_id, COUNT(*) AS responses, SUM(CASE WHEN status = %d THEN 1 ELSE 0 END) AS solved",
+			entity.HelpfulnessSurveyStatusSolved)).
+		Where(builder.In("answer_id", answerIDs)).
+		And(builder.In("status", entity.HelpfulnessSurveyStatusSolved, entity.HelpfulnessSurveyStatusNotSolved)).
+		GroupBy("answer_id").
+		Find(&list)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	for _, stat := range list {
+		stats[stat.AnswerID] = stat
+	}
+	return stats, nil
+}
@@ -0,0 +1,60 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package inbound_email
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/inbound_email"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// inboundEmailRepo inbound email repository
+type inboundEmailRepo struct {
+	data *data.Data
+}
+
+// NewInboundEmailRepo new repository
+func NewInboundEmailRepo(data *data.Data) inbound_email.InboundEmailRepo {
+	return &inboundEmailRepo{
+		data: data,
+	}
+}
+
+// AddInboundEmail add the record of the processed email
+func (ir *inboundEmailRepo) AddInboundEmail(ctx context.Context, email *entity.InboundEmail) (err error) {
+	_, err = ir.data.DB.Context(ctx).Insert(email)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// IsProcessed whether the email of the message id is processed
+func (ir *inboundEmailRepo) IsProcessed(ctx context.Context, messageID string) (processed bool, err error) {
+	processed, err = ir.data.DB.Context(ctx).Where("message_id = ?", messageID).Exist(&entity.InboundEmail{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
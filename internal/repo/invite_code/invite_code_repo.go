@@ -0,0 +1,149 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package invite_code
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/invite_code"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// inviteCodeRepo invite code repository
+type inviteCodeRepo struct {
+	data *data.Data
+}
+
+// NewInviteCodeRepo new repository
+func NewInviteCodeRepo(data *data.Data) invite_code.InviteCodeRepo {
+	return &inviteCodeRepo{
+		data: data,
+	}
+}
+
+// AddInviteCode add invite code
+func (ir *inviteCodeRepo) AddInviteCode(ctx context.Context, inviteCode *entity.InviteCode) (err error) {
+	_, err = ir.data.DB.Context(ctx).Insert(inviteCode)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetInviteCode get invite code by id
+func (ir *inviteCodeRepo) GetInviteCode(ctx context.Context, id string) (
+	inviteCode *entity.InviteCode, exist bool, err error) {
+	inviteCode = &entity.InviteCode{}
+	exist, err = ir.data.DB.Context(ctx).ID(id).Get(inviteCode)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetInviteCodeByCode get invite code by the code
+func (ir *inviteCodeRepo) GetInviteCodeByCode(ctx context.Context, code string) (
+	inviteCode *entity.InviteCode, exist bool, err error) {
+	inviteCode = &entity.InviteCode{}
+	exist, err = ir.data.DB.Context(ctx).Where(builder.Eq{"code": code}).Get(inviteCode)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetInviteCodesByUserID get the invite codes generated by user, the latest first
+func (ir *inviteCodeRepo) GetInviteCodesByUserID(ctx context.Context, userID string) (
+	inviteCodes []*entity.InviteCode, err error) {
+	inviteCodes = make([]*entity.InviteCode, 0)
+	err = ir.data.DB.Context(ctx).Where(builder.Eq{"user_id": userID}).Desc("id").Find(&inviteCodes)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetInviteCodePage get all the invite codes, the latest first
+func (ir *inviteCodeRepo) GetInviteCodePage(ctx context.Context, page, pageSize int) (
+	inviteCodes []*entity.InviteCode, total int64, err error) {
+	inviteCodes = make([]*entity.InviteCode, 0)
+	session := ir.data.DB.Context(ctx).Desc("id")
+	total, err = pager.Help(page, pageSize, &inviteCodes, &entity.InviteCode{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RevokeInviteCode revoke invite code
+func (ir *inviteCodeRepo) RevokeInviteCode(ctx context.Context, id string) (err error) {
+	_, err = ir.data.DB.Context(ctx).ID(id).Cols("status").
+		Update(&entity.InviteCode{Status: entity.InviteCodeStatusRevoked})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UseInviteCode increase the used count if the invite code is not revoked or used up
+func (ir *inviteCodeRepo) UseInviteCode(ctx context.Context, id string) (used bool, err error) {
+	affected, err := ir.data.DB.Context(ctx).ID(id).
+		Where(builder.Eq{"status": entity.InviteCodeStatusAvailable}).And("used_count < max_uses").
+		Incr("used_count").Update(&entity.InviteCode{})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return affected > 0, nil
+}
+
+// ReleaseInviteCode decrease the used count if the registration is failed
+func (ir *inviteCodeRepo) ReleaseInviteCode(ctx context.Context, id string) (err error) {
+	_, err = ir.data.DB.Context(ctx).ID(id).And("used_count > 0").
+		Decr("used_count").Update(&entity.InviteCode{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddInviteCodeUsage record the user registered with the invite code
+func (ir *inviteCodeRepo) AddInviteCodeUsage(ctx context.Context, usage *entity.InviteCodeUsage) (err error) {
+	_, err = ir.data.DB.Context(ctx).Insert(usage)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetInviteCodeUsages get the users registered with the invite code
+func (ir *inviteCodeRepo) GetInviteCodeUsages(ctx context.Context, inviteCodeID string) (
+	usages []*entity.InviteCodeUsage, err error) {
+	usages = make([]*entity.InviteCodeUsage, 0)
+	err = ir.data.DB.Context(ctx).Where(builder.Eq{"invite_code_id": inviteCodeID}).Desc("id").Find(&usages)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
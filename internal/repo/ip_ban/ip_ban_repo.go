@@ -0,0 +1,160 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ip_ban
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/ip_ban"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+	"xorm.io/builder"
+)
+
+// ipBanRepo ip ban repository
+type ipBanRepo struct {
+	data *data.Data
+}
+
+// NewIPBanRepo new repository
+func NewIPBanRepo(data *data.Data) ip_ban.IPBanRepo {
+	return &ipBanRepo{
+		data: data,
+	}
+}
+
+// AddIPBan add ip ban
+func (ir *ipBanRepo) AddIPBan(ctx context.Context, ipBan *entity.IPBan) (err error) {
+	_, err = ir.data.DB.Context(ctx).Insert(ipBan)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	ir.removeActiveCache(ctx)
+	return
+}
+
+// GetIPBan get ip ban by id
+func (ir *ipBanRepo) GetIPBan(ctx context.Context, id string) (ipBan *entity.IPBan, exist bool, err error) {
+	ipBan = &entity.IPBan{}
+	exist, err = ir.data.DB.Context(ctx).ID(id).Get(ipBan)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetIPBanByRange get ip ban by the CIDR range
+func (ir *ipBanRepo) GetIPBanByRange(ctx context.Context, ipRange string) (
+	ipBan *entity.IPBan, exist bool, err error) {
+	ipBan = &entity.IPBan{}
+	exist, err = ir.data.DB.Context(ctx).Where(builder.Eq{"ip_range": ipRange}).Get(ipBan)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetIPBanPage get all the ip bans, the latest first
+func (ir *ipBanRepo) GetIPBanPage(ctx context.Context, page, pageSize int) (
+	ipBans []*entity.IPBan, total int64, err error) {
+	ipBans = make([]*entity.IPBan, 0)
+	session := ir.data.DB.Context(ctx).Desc("id")
+	total, err = pager.Help(page, pageSize, &ipBans, &entity.IPBan{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetActiveIPBans get the ip bans not expired at the time.
+// The active bans are cached until any ban is added or removed, the ones expired since are filtered out of the cache.
+func (ir *ipBanRepo) GetActiveIPBans(ctx context.Context, now time.Time) (ipBans []*entity.IPBan, err error) {
+	if cached, ok := ir.getActiveCache(ctx); ok {
+		ipBans = make([]*entity.IPBan, 0, len(cached))
+		for _, ipBan := range cached {
+			if ipBan.ExpiredAt.IsZero() || ipBan.ExpiredAt.After(now) {
+				ipBans = append(ipBans, ipBan)
+			}
+		}
+		return ipBans, nil
+	}
+	ipBans = make([]*entity.IPBan, 0)
+	err = ir.data.DB.Context(ctx).
+		Where(builder.Or(builder.IsNull{"expired_at"}, builder.Gt{"expired_at": now})).
+		Find(&ipBans)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	ir.setActiveCache(ctx, ipBans)
+	return
+}
+
+// RemoveIPBan remove ip ban
+func (ir *ipBanRepo) RemoveIPBan(ctx context.Context, id string) (err error) {
+	_, err = ir.data.DB.Context(ctx).ID(id).Delete(&entity.IPBan{})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	ir.removeActiveCache(ctx)
+	return
+}
+
+// IncreaseHitCount increase the hit count and record the last hit time
+func (ir *ipBanRepo) IncreaseHitCount(ctx context.Context, id string, hitAt time.Time) (err error) {
+	_, err = ir.data.DB.Context(ctx).ID(id).Incr("hit_count").Cols("last_hit_at").
+		Update(&entity.IPBan{LastHitAt: hitAt})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+func (ir *ipBanRepo) getActiveCache(ctx context.Context) (ipBans []*entity.IPBan, ok bool) {
+	ipBansCache, exist, err := ir.data.Cache.GetString(ctx, constant.ActiveIPBansCacheKey)
+	if err != nil || !exist {
+		return nil, false
+	}
+	if err = json.Unmarshal([]byte(ipBansCache), &ipBans); err != nil {
+		return nil, false
+	}
+	return ipBans, true
+}
+
+func (ir *ipBanRepo) setActiveCache(ctx context.Context, ipBans []*entity.IPBan) {
+	ipBansCache, _ := json.Marshal(ipBans)
+	err := ir.data.Cache.SetString(ctx,
+		constant.ActiveIPBansCacheKey, string(ipBansCache), constant.ActiveIPBansCacheTime)
+	if err != nil {
+		log.Error(err)
+	}
+}
+
+func (ir *ipBanRepo) removeActiveCache(ctx context.Context) {
+	if err := ir.data.Cache.Del(ctx, constant.ActiveIPBansCacheKey); err != nil {
+		log.Error(err)
+	}
+}
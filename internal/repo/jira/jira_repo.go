@@ -0,0 +1,122 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package jira
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/jira"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// jiraRepo jira repository
+type jiraRepo struct {
+	data *data.Data
+}
+
+// NewJiraRepo new repository
+func NewJiraRepo(data *data.Data) jira.JiraRepo {
+	return &jiraRepo{
+		data: data,
+	}
+}
+
+// AddIssueLink add the issue link of the question
+func (jr *jiraRepo) AddIssueLink(ctx context.Context, link *entity.JiraIssueLink) (err error) {
+	_, err = jr.data.DB.Context(ctx).Insert(link)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetIssueLinkByQuestionID get the issue link of the question
+func (jr *jiraRepo) GetIssueLinkByQuestionID(ctx context.Context, questionID string) (
+	link *entity.JiraIssueLink, exist bool, err error) {
+	link = &entity.JiraIssueLink{}
+	exist, err = jr.data.DB.Context(ctx).Where("question_id = ?", questionID).Get(link)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUnresolvedIssueLinks get the unresolved issue links after the id in batch
+func (jr *jiraRepo) GetUnresolvedIssueLinks(ctx context.Context, afterID string, limit int) (
+	links []*entity.JiraIssueLink, err error) {
+	links = make([]*entity.JiraIssueLink, 0)
+	session := jr.data.DB.Context(ctx).Where("resolved = ?", false)
+	if len(afterID) > 0 {
+		session.And("id > ?", afterID)
+	}
+	err = session.Asc("id").Limit(limit).Find(&links)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateIssueLink update the synced status of the issue link
+func (jr *jiraRepo) UpdateIssueLink(ctx context.Context, link *entity.JiraIssueLink) (err error) {
+	_, err = jr.data.DB.Context(ctx).ID(link.ID).Cols("status", "resolved").Update(link)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddModeratorNotes add the moderator notes
+func (jr *jiraRepo) AddModeratorNotes(ctx context.Context, notes []*entity.QuestionModeratorNote) (err error) {
+	if len(notes) == 0 {
+		return nil
+	}
+	_, err = jr.data.DB.Context(ctx).Insert(notes)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetModeratorNotes get the moderator notes of the question, the earliest first
+func (jr *jiraRepo) GetModeratorNotes(ctx context.Context, questionID string) (
+	notes []*entity.QuestionModeratorNote, err error) {
+	notes = make([]*entity.QuestionModeratorNote, 0)
+	err = jr.data.DB.Context(ctx).Where("question_id = ?", questionID).Asc("id").Find(&notes)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetModeratorNoteExternalIDs get the external ids of the synced notes of the question from the source
+func (jr *jiraRepo) GetModeratorNoteExternalIDs(ctx context.Context, questionID, source string) (
+	externalIDs []string, err error) {
+	externalIDs = make([]string, 0)
+	err = jr.data.DB.Context(ctx).Table(entity.QuestionModeratorNote{}.TableName()).
+		Where("question_id = ? AND source = ? AND external_id != ''", questionID, source).
+		Cols("external_id").Find(&externalIDs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
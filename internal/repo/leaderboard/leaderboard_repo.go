@@ -0,0 +1,98 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package leaderboard
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/leaderboard"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+// leaderboardRepo leaderboard repository
+type leaderboardRepo struct {
+	data *data.Data
+}
+
+// NewLeaderboardRepo new repository
+func NewLeaderboardRepo(data *data.Data) leaderboard.LeaderboardRepo {
+	return &leaderboardRepo{
+		data: data,
+	}
+}
+
+// GetUserTagRankStat get the reputation earned by users in each tag since the time.
+// The reputation of answer is counted in the tags of its question.
+func (lr *leaderboardRepo) GetUserTagRankStat(ctx context.Context, startTime time.Time) (
+	stats []*entity.LeaderboardUserTagRankStat, err error) {
+	stats = make([]*entity.LeaderboardUserTagRankStat, 0)
+	err = lr.data.DB.Context(ctx).Table(entity.Activity{}.TableName()).Alias("a").
+		Select("a.user_id, tr.tag_id, SUM(a.`rank`) AS rank_amount").
+		Join("LEFT", []string{entity.Answer{}.TableName(), "ans"}, "ans.id = a.object_id").
+		Join("INNER", []string{entity.TagRel{}.TableName(), "tr"}, "tr.object_id = COALESCE(ans.question_id, a.object_id)").
+		Where("a.has_rank = 1 AND a.cancelled = 0").
+		And("a.created_at >= ?", startTime).
+		And("tr.status = ?", entity.TagRelStatusAvailable).
+		GroupBy("a.user_id, tr.tag_id").
+		Find(&stats)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// ReplaceLeaderboard replace all the stats of the period
+func (lr *leaderboardRepo) ReplaceLeaderboard(ctx context.Context, period string, stats []*entity.LeaderboardStat) (err error) {
+	_, err = lr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		if _, err = session.Where("period = ?", period).Delete(&entity.LeaderboardStat{}); err != nil {
+			return nil, err
+		}
+		for start := 0; start < len(stats); start += 100 {
+			end := min(start+100, len(stats))
+			if _, err = session.Insert(stats[start:end]); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetLeaderboardPage get the leaderboard page of the period and tag, the tag id 0 means the whole site
+func (lr *leaderboardRepo) GetLeaderboardPage(ctx context.Context, period, tagID string, page, pageSize int) (
+	stats []*entity.LeaderboardStat, total int64, err error) {
+	stats = make([]*entity.LeaderboardStat, 0)
+	session := lr.data.DB.Context(ctx).Where("period = ? AND tag_id = ?", period, tagID).Asc("position")
+	total, err = pager.Help(page, pageSize, &stats, &entity.LeaderboardStat{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -58,6 +58,30 @@ func (lr *LimitRepo) CheckAndRecord(ctx context.Context, key string) (limit bool
 	return false, nil
 }
 
+// CheckAndIncrease increase the count of key in current period, limit if the increased count exceeds the max count.
+// The count is increased atomically, so the concurrent requests can not pass the limit together.
+func (lr *LimitRepo) CheckAndIncrease(ctx context.Context, key string, maxCount int64, period time.Duration) (
+	limit bool, err error) {
+	// fixed window, the key is changed when the period is over
+	key = fmt.Sprintf("%s%s:%d", constant.RateLimitActionCacheKeyPrefix, key, time.Now().Unix()/int64(period.Seconds()))
+	// the counter of the new window is created with expiration, the memory cache can not increase the missing key
+	// and the redis cache creates the missing key without expiration
+	_, exist, err := lr.data.Cache.GetInt64(ctx, key)
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		if err = lr.data.Cache.SetInt64(ctx, key, 0, period); err != nil {
+			return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+	}
+	count, err := lr.data.Cache.Increase(ctx, key, 1)
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return count > maxCount, nil
+}
+
 // ClearRecord clear
 func (lr *LimitRepo) ClearRecord(ctx context.Context, key string) error {
 	return lr.data.Cache.Del(ctx, constant.RateLimitCacheKeyPrefix+key)
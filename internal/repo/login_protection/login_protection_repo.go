@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package login_protection
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/login_protection"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// loginProtectionRepo login protection repository
+type loginProtectionRepo struct {
+	data *data.Data
+}
+
+// NewLoginProtectionRepo new repository
+func NewLoginProtectionRepo(data *data.Data) login_protection.LoginProtectionRepo {
+	return &loginProtectionRepo{
+		data: data,
+	}
+}
+
+// GetLoginFailure get the failed login attempts of the key, nil if not found
+func (lr *loginProtectionRepo) GetLoginFailure(ctx context.Context, key string) (failure *schema.LoginFailure, err error) {
+	res, exist, err := lr.data.Cache.GetString(ctx, constant.LoginFailureCacheKey+key)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		return nil, nil
+	}
+	failure = &schema.LoginFailure{}
+	_ = json.Unmarshal([]byte(res), failure)
+	return failure, nil
+}
+
+// SetLoginFailure set the failed login attempts of the key
+func (lr *loginProtectionRepo) SetLoginFailure(ctx context.Context, key string, failure *schema.LoginFailure,
+	ttl time.Duration) (err error) {
+	value, _ := json.Marshal(failure)
+	err = lr.data.Cache.SetString(ctx, constant.LoginFailureCacheKey+key, string(value), ttl)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// RemoveLoginFailure remove the failed login attempts of the key
+func (lr *loginProtectionRepo) RemoveLoginFailure(ctx context.Context, key string) (err error) {
+	err = lr.data.Cache.Del(ctx, constant.LoginFailureCacheKey+key)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
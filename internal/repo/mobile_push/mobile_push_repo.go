@@ -0,0 +1,103 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package mobile_push
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/mobile_push"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// mobilePushRepo mobile push device repository
+type mobilePushRepo struct {
+	data *data.Data
+}
+
+// NewMobilePushRepo new repository
+func NewMobilePushRepo(data *data.Data) mobile_push.MobilePushRepo {
+	return &mobilePushRepo{
+		data: data,
+	}
+}
+
+// SaveDevice add the device, the token registered before is moved to the user as the app may be signed in by another account
+func (mr *mobilePushRepo) SaveDevice(ctx context.Context, device *entity.MobilePushDevice) (err error) {
+	old := &entity.MobilePushDevice{}
+	exist, err := mr.data.DB.Context(ctx).Where("token = ?", device.Token).Get(old)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		device.ID = old.ID
+		_, err = mr.data.DB.Context(ctx).ID(old.ID).Cols("user_id", "platform", "device_name").Update(device)
+	} else {
+		_, err = mr.data.DB.Context(ctx).Insert(device)
+	}
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveDevice remove the device of the token of the user
+func (mr *mobilePushRepo) RemoveDevice(ctx context.Context, userID, token string) (err error) {
+	_, err = mr.data.DB.Context(ctx).Where("user_id = ? AND token = ?", userID, token).
+		Delete(&entity.MobilePushDevice{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveDeviceByID remove the device by id
+func (mr *mobilePushRepo) RemoveDeviceByID(ctx context.Context, id string) (err error) {
+	_, err = mr.data.DB.Context(ctx).ID(id).Delete(&entity.MobilePushDevice{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveDevicesByTokens remove the devices of the tokens
+func (mr *mobilePushRepo) RemoveDevicesByTokens(ctx context.Context, tokens []string) (err error) {
+	if len(tokens) == 0 {
+		return nil
+	}
+	_, err = mr.data.DB.Context(ctx).In("token", tokens).Delete(&entity.MobilePushDevice{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDevicesByUserID get the devices of the user, the latest first
+func (mr *mobilePushRepo) GetDevicesByUserID(ctx context.Context, userID string) (
+	devices []*entity.MobilePushDevice, err error) {
+	devices = make([]*entity.MobilePushDevice, 0)
+	err = mr.data.DB.Context(ctx).Where("user_id = ?", userID).Desc("id").Find(&devices)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
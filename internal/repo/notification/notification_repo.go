@@ -21,6 +21,8 @@ package notification
 
 import (
 	"context"
+	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/apache/answer/internal/base/data"
@@ -31,6 +33,8 @@ import (
 	notficationcommon "github.com/apache/answer/internal/service/notification_common"
 	"github.com/apache/answer/pkg/uid"
 	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
 )
 
 // notificationRepo notification repository
@@ -55,6 +59,39 @@ func (nr *notificationRepo) AddNotification(ctx context.Context, notification *e
 	return
 }
 
+// AddNotifications add the notifications in batches
+func (nr *notificationRepo) AddNotifications(ctx context.Context, notifications []*entity.Notification) (err error) {
+	for _, notification := range notifications {
+		notification.ObjectID = uid.DeShortID(notification.ObjectID)
+	}
+	for start := 0; start < len(notifications); start += 100 {
+		end := min(start+100, len(notifications))
+		if _, err = nr.data.DB.Context(ctx).Insert(notifications[start:end]); err != nil {
+			return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+	}
+	return
+}
+
+// GetUnreadGroupedNotifications get the unread notifications of the group updated since the time for the users
+func (nr *notificationRepo) GetUnreadGroupedNotifications(ctx context.Context, userIDs []string, groupKey string,
+	since time.Time) (notifications []*entity.Notification, err error) {
+	notifications = make([]*entity.Notification, 0)
+	if len(userIDs) == 0 || len(groupKey) == 0 {
+		return notifications, nil
+	}
+	err = nr.data.DB.Context(ctx).
+		Where(builder.In("user_id", userIDs)).
+		And(builder.Eq{"group_key": groupKey, "is_read": schema.NotificationNotRead,
+			"status": schema.NotificationStatusNormal}).
+		And(builder.Gte{"updated_at": since}).
+		Find(&notifications)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
 func (nr *notificationRepo) UpdateNotificationContent(ctx context.Context, notification *entity.Notification) (err error) {
 	now := time.Now()
 	notification.UpdatedAt = now
@@ -76,6 +113,32 @@ func (nr *notificationRepo) ClearUnRead(ctx context.Context, userID string, noti
 	return
 }
 
+// ClearUnReadByMsgType mark the notifications of the message type as read
+func (nr *notificationRepo) ClearUnReadByMsgType(ctx context.Context, userID string, notificationType, msgType int) (err error) {
+	info := &entity.Notification{}
+	info.IsRead = schema.NotificationRead
+	_, err = nr.data.DB.Context(ctx).Where("user_id = ?", userID).And("type = ?", notificationType).
+		And("msg_type = ?", msgType).Cols("is_read").Update(info)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// ClearUnReadByAction mark the inbox notifications of the action as read
+func (nr *notificationRepo) ClearUnReadByAction(ctx context.Context, userID string, action string) (err error) {
+	info := &entity.Notification{}
+	info.IsRead = schema.NotificationRead
+	_, err = nr.data.DB.Context(ctx).Where("user_id = ?", userID).And("type = ?", schema.NotificationTypeInbox).
+		And("is_read = ?", schema.NotificationNotRead).
+		And("content LIKE ?", "%\"notification_action\":"+jsonString(action)+"%").
+		Cols("is_read").Update(info)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
 func (nr *notificationRepo) ClearIDUnRead(ctx context.Context, userID string, id string) (err error) {
 	info := &entity.Notification{}
 	info.IsRead = schema.NotificationRead
@@ -123,6 +186,27 @@ func (nr *notificationRepo) GetNotificationPage(ctx context.Context, searchCond
 	if searchCond.InboxType > 0 {
 		cond.MsgType = searchCond.InboxType
 	}
+	switch searchCond.ReadState {
+	case "read":
+		cond.IsRead = schema.NotificationRead
+	case "unread":
+		cond.IsRead = schema.NotificationNotRead
+	}
+	if searchCond.StartTime > 0 {
+		session.And("updated_at >= ?", time.Unix(searchCond.StartTime, 0))
+	}
+	if searchCond.EndTime > 0 {
+		session.And("updated_at <= ?", time.Unix(searchCond.EndTime, 0))
+	}
+	// the content is the json of notification, so match the value of the field
+	if len(searchCond.Action) > 0 {
+		session.And("content LIKE ?", "%\"notification_action\":"+jsonString(searchCond.Action)+"%")
+	}
+	if len(searchCond.Query) > 0 {
+		// the object_id follows the title in object_info, so the query must be inside the title
+		query := strings.Trim(jsonString(searchCond.Query), "\"")
+		session.And("content LIKE ?", "%\"title\":\"%"+query+"%\",\"object_id\":%")
+	}
 	total, err = pager.Help(searchCond.Page, searchCond.PageSize, &notificationList, cond, session)
 	if err != nil {
 		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
@@ -130,6 +214,12 @@ func (nr *notificationRepo) GetNotificationPage(ctx context.Context, searchCond
 	return
 }
 
+// jsonString encode the string as json, so it can be matched with the json content
+func jsonString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
 func (nr *notificationRepo) CountNotificationByUser(ctx context.Context, cond *entity.Notification) (int64, error) {
 	count, err := nr.data.DB.Context(ctx).Count(cond)
 	if err != nil {
@@ -138,11 +228,95 @@ func (nr *notificationRepo) CountNotificationByUser(ctx context.Context, cond *e
 	return count, err
 }
 
+// CountUnreadNotifications count the unread notifications of the user group by type and message type
+func (nr *notificationRepo) CountUnreadNotifications(ctx context.Context, userID string) (
+	counts []*schema.NotificationUnreadCountDTO, err error) {
+	counts = make([]*schema.NotificationUnreadCountDTO, 0)
+	err = nr.data.DB.Context(ctx).Table(entity.Notification{}.TableName()).
+		Select("type, msg_type, count(*) AS count").
+		Where("user_id = ?", userID).And("is_read = ?", schema.NotificationNotRead).
+		GroupBy("type, msg_type").
+		Find(&counts)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// DeleteNotificationsBefore delete the notifications of the user updated before the time,
+// notificationType 0 means all types
+func (nr *notificationRepo) DeleteNotificationsBefore(ctx context.Context, userID string, notificationType int,
+	before time.Time, includeUnread bool) (deleted int64, err error) {
+	session := nr.data.DB.Context(ctx).Where("user_id = ?", userID).And("updated_at < ?", before)
+	if notificationType > 0 {
+		session.And("type = ?", notificationType)
+	}
+	if !includeUnread {
+		session.And("is_read = ?", schema.NotificationRead)
+	}
+	deleted, err = session.Delete(&entity.Notification{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CleanReadNotificationsBefore archive or delete the read and deleted notifications not updated since the time,
+// at most the limit of notifications are cleaned at once
+func (nr *notificationRepo) CleanReadNotificationsBefore(ctx context.Context, before time.Time, limit int,
+	archive bool) (cleaned int64, err error) {
+	notifications := make([]*entity.Notification, 0)
+	err = nr.data.DB.Context(ctx).
+		Where(builder.Or(builder.Eq{"is_read": schema.NotificationRead}, builder.Eq{"status": schema.NotificationStatusDelete})).
+		And("updated_at < ?", before).Asc("id").Limit(limit).Find(&notifications)
+	if err != nil {
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if len(notifications) == 0 {
+		return 0, nil
+	}
+	ids := make([]string, 0, len(notifications))
+	archives := make([]*entity.NotificationArchive, 0, len(notifications))
+	for _, notification := range notifications {
+		ids = append(ids, notification.ID)
+		archives = append(archives, &entity.NotificationArchive{
+			ID:        notification.ID,
+			CreatedAt: notification.CreatedAt,
+			UpdatedAt: notification.UpdatedAt,
+			UserID:    notification.UserID,
+			ObjectID:  notification.ObjectID,
+			Content:   notification.Content,
+			Type:      notification.Type,
+			MsgType:   notification.MsgType,
+			IsRead:    notification.IsRead,
+			Status:    notification.Status,
+		})
+	}
+	_, err = nr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		if archive {
+			if _, err = session.Insert(archives); err != nil {
+				return nil, err
+			}
+		}
+		cleaned, err = session.In("id", ids).Delete(&entity.Notification{})
+		return nil, err
+	})
+	if err != nil {
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return cleaned, nil
+}
+
 func (nr *notificationRepo) DeleteNotification(ctx context.Context, userID string) (err error) {
 	_, err = nr.data.DB.Context(ctx).Where("user_id = ?", userID).Delete(&entity.Notification{})
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	_, err = nr.data.DB.Context(ctx).Where("user_id = ?", userID).Delete(&entity.NotificationArchive{})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
 	return
 }
 
@@ -0,0 +1,70 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package notification
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	notficationcommon "github.com/apache/answer/internal/service/notification_common"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+// voteMilestoneRepo vote milestone repository
+type voteMilestoneRepo struct {
+	data *data.Data
+}
+
+// NewVoteMilestoneRepo new repository
+func NewVoteMilestoneRepo(data *data.Data) notficationcommon.VoteMilestoneRepo {
+	return &voteMilestoneRepo{
+		data: data,
+	}
+}
+
+// RaiseVoteMilestone record the milestone of the object, raised is false if the object has reached it before
+func (vr *voteMilestoneRepo) RaiseVoteMilestone(ctx context.Context, objectID string, milestone int) (
+	raised bool, err error) {
+	objectID = uid.DeShortID(objectID)
+	_, err = vr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		exist, err := session.Where("object_id = ?", objectID).Exist(&entity.VoteMilestone{})
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			_, err = session.Insert(&entity.VoteMilestone{ObjectID: objectID, Milestone: milestone})
+			raised = err == nil
+			return nil, err
+		}
+		affected, err := session.Where("object_id = ?", objectID).And("milestone < ?", milestone).
+			Cols("milestone").Update(&entity.VoteMilestone{Milestone: milestone})
+		raised = affected > 0
+		return nil, err
+	})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return raised, nil
+}
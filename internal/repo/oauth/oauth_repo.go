@@ -0,0 +1,187 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/oauth"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// oauthRepo OAuth repository
+type oauthRepo struct {
+	data *data.Data
+}
+
+// NewOAuthRepo new repository
+func NewOAuthRepo(data *data.Data) oauth.OAuthRepo {
+	return &oauthRepo{
+		data: data,
+	}
+}
+
+// AddOAuthClient add OAuth client
+func (or *oauthRepo) AddOAuthClient(ctx context.Context, client *entity.OAuthClient) (err error) {
+	_, err = or.data.DB.Context(ctx).Insert(client)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateOAuthClient update the name and redirect uris of OAuth client
+func (or *oauthRepo) UpdateOAuthClient(ctx context.Context, client *entity.OAuthClient) (err error) {
+	_, err = or.data.DB.Context(ctx).ID(client.ID).Cols("name", "redirect_uris").Update(client)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateOAuthClientSecret replace the secret of OAuth client
+func (or *oauthRepo) UpdateOAuthClientSecret(ctx context.Context, id, secretHash string) (err error) {
+	_, err = or.data.DB.Context(ctx).ID(id).Cols("secret_hash").Update(&entity.OAuthClient{SecretHash: secretHash})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetOAuthClient get OAuth client by id
+func (or *oauthRepo) GetOAuthClient(ctx context.Context, id string) (client *entity.OAuthClient, exist bool, err error) {
+	client = &entity.OAuthClient{}
+	exist, err = or.data.DB.Context(ctx).ID(id).Get(client)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetOAuthClientByClientID get OAuth client by client id
+func (or *oauthRepo) GetOAuthClientByClientID(ctx context.Context, clientID string) (
+	client *entity.OAuthClient, exist bool, err error) {
+	client = &entity.OAuthClient{}
+	exist, err = or.data.DB.Context(ctx).Where(builder.Eq{"client_id": clientID}).Get(client)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetOAuthClients get all OAuth clients, the latest first
+func (or *oauthRepo) GetOAuthClients(ctx context.Context) (clients []*entity.OAuthClient, err error) {
+	clients = make([]*entity.OAuthClient, 0)
+	err = or.data.DB.Context(ctx).Desc("id").Find(&clients)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveOAuthClient remove OAuth client
+func (or *oauthRepo) RemoveOAuthClient(ctx context.Context, id string) (err error) {
+	_, err = or.data.DB.Context(ctx).ID(id).Delete(&entity.OAuthClient{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// SetAuthorizationCode cache the authorization code with the counter of the times it is taken
+func (or *oauthRepo) SetAuthorizationCode(ctx context.Context, code string, info *schema.OAuthAuthorizationCode) (err error) {
+	content, _ := json.Marshal(info)
+	err = or.data.Cache.SetInt64(ctx, constant.OAuthAuthorizationCodeTakenCacheKey+code, 0,
+		schema.OAuthAuthorizationCodeTTL)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	err = or.data.Cache.SetString(ctx, constant.OAuthAuthorizationCodeCacheKey+code, string(content),
+		schema.OAuthAuthorizationCodeTTL)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// TakeAuthorizationCode get the authorization code and remove it, so that the code is only exchanged once.
+// The cache can not get and delete atomically, so only the caller increasing the taken counter first gets the code.
+func (or *oauthRepo) TakeAuthorizationCode(ctx context.Context, code string) (info *schema.OAuthAuthorizationCode, err error) {
+	key := constant.OAuthAuthorizationCodeCacheKey + code
+	takenKey := constant.OAuthAuthorizationCodeTakenCacheKey + code
+	// the memory cache fails to increase the missing counter, the code is expired or taken
+	taken, err := or.data.Cache.Increase(ctx, takenKey, 1)
+	if err != nil || taken != 1 {
+		return nil, nil
+	}
+	content, exist, err := or.data.Cache.GetString(ctx, key)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	// the code is removed before the counter, so the counter recreated by other callers never finds it
+	if err = or.data.Cache.Del(ctx, key); err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if err = or.data.Cache.Del(ctx, takenKey); err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		return nil, nil
+	}
+	info = &schema.OAuthAuthorizationCode{}
+	if err = json.Unmarshal([]byte(content), info); err != nil {
+		return nil, nil
+	}
+	return info, nil
+}
+
+// SetAccessToken cache the access token by its hash
+func (or *oauthRepo) SetAccessToken(ctx context.Context, tokenHash string, info *schema.OAuthAccessToken) (err error) {
+	content, _ := json.Marshal(info)
+	err = or.data.Cache.SetString(ctx, constant.OAuthAccessTokenCacheKey+tokenHash, string(content),
+		schema.OAuthAccessTokenTTL)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAccessToken get the access token by its hash
+func (or *oauthRepo) GetAccessToken(ctx context.Context, tokenHash string) (info *schema.OAuthAccessToken, err error) {
+	content, exist, err := or.data.Cache.GetString(ctx, constant.OAuthAccessTokenCacheKey+tokenHash)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		return nil, nil
+	}
+	info = &schema.OAuthAccessToken{}
+	if err = json.Unmarshal([]byte(content), info); err != nil {
+		return nil, nil
+	}
+	return info, nil
+}
@@ -0,0 +1,75 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package oauth
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/schema"
+	"github.com/segmentfault/pacman/contrib/cache/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOAuthRepo_TakeAuthorizationCode_Twice(t *testing.T) {
+	ctx := context.Background()
+	repo := NewOAuthRepo(&data.Data{Cache: memory.NewCache()})
+	require.NoError(t, repo.SetAuthorizationCode(ctx, "code", &schema.OAuthAuthorizationCode{ClientID: "client", UserID: "1"}))
+
+	info, err := repo.TakeAuthorizationCode(ctx, "code")
+	require.NoError(t, err)
+	require.NotNil(t, info)
+	assert.Equal(t, "client", info.ClientID)
+	assert.Equal(t, "1", info.UserID)
+
+	info, err = repo.TakeAuthorizationCode(ctx, "code")
+	require.NoError(t, err)
+	assert.Nil(t, info)
+
+	info, err = repo.TakeAuthorizationCode(ctx, "unknown")
+	require.NoError(t, err)
+	assert.Nil(t, info)
+}
+
+func TestOAuthRepo_TakeAuthorizationCode_Concurrently(t *testing.T) {
+	ctx := context.Background()
+	repo := NewOAuthRepo(&data.Data{Cache: memory.NewCache()})
+	require.NoError(t, repo.SetAuthorizationCode(ctx, "code", &schema.OAuthAuthorizationCode{ClientID: "client"}))
+
+	var taken atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			info, err := repo.TakeAuthorizationCode(ctx, "code")
+			assert.NoError(t, err)
+			if info != nil {
+				taken.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), taken.Load())
+}
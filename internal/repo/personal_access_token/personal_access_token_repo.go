@@ -0,0 +1,147 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package personal_access_token
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/auth"
+	"github.com/apache/answer/internal/service/personal_access_token"
+	"github.com/apache/answer/internal/service/role"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+	"xorm.io/builder"
+)
+
+// personalAccessTokenRepo personal access token repository
+type personalAccessTokenRepo struct {
+	data *data.Data
+}
+
+// NewPersonalAccessTokenRepo new repository
+func NewPersonalAccessTokenRepo(data *data.Data) personal_access_token.PersonalAccessTokenRepo {
+	return &personalAccessTokenRepo{
+		data: data,
+	}
+}
+
+// AddPersonalAccessToken add personal access token
+func (pr *personalAccessTokenRepo) AddPersonalAccessToken(ctx context.Context, token *entity.PersonalAccessToken) (err error) {
+	_, err = pr.data.DB.Context(ctx).Insert(token)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetPersonalAccessToken get personal access token of user
+func (pr *personalAccessTokenRepo) GetPersonalAccessToken(ctx context.Context, userID, id string) (
+	token *entity.PersonalAccessToken, exist bool, err error) {
+	token = &entity.PersonalAccessToken{}
+	exist, err = pr.data.DB.Context(ctx).Where(builder.Eq{"id": id, "user_id": userID}).Get(token)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetPersonalAccessTokens get the personal access tokens of user, the latest first
+func (pr *personalAccessTokenRepo) GetPersonalAccessTokens(ctx context.Context, userID string) (
+	tokens []*entity.PersonalAccessToken, err error) {
+	tokens = make([]*entity.PersonalAccessToken, 0)
+	err = pr.data.DB.Context(ctx).Where(builder.Eq{"user_id": userID}).Desc("id").Find(&tokens)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemovePersonalAccessToken remove personal access token
+func (pr *personalAccessTokenRepo) RemovePersonalAccessToken(ctx context.Context, id string) (err error) {
+	_, err = pr.data.DB.Context(ctx).ID(id).Delete(&entity.PersonalAccessToken{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// personalAccessTokenAuthRepo personal access token repository of auth
+type personalAccessTokenAuthRepo struct {
+	data *data.Data
+}
+
+// NewPersonalAccessTokenAuthRepo new repository
+func NewPersonalAccessTokenAuthRepo(data *data.Data) auth.PersonalAccessTokenRepo {
+	return &personalAccessTokenAuthRepo{
+		data: data,
+	}
+}
+
+// GetPersonalAccessTokenUserCacheInfo get the user by the hash of the token not expired
+func (pr *personalAccessTokenAuthRepo) GetPersonalAccessTokenUserCacheInfo(ctx context.Context, tokenHash string) (
+	userInfo *entity.UserCacheInfo, exist bool, err error) {
+	token := &entity.PersonalAccessToken{}
+	exist, err = pr.data.DB.Context(ctx).Where(builder.Eq{"token_hash": tokenHash}).Get(token)
+	if err != nil {
+		return nil, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist || (!token.ExpiredAt.IsZero() && !token.ExpiredAt.After(time.Now())) {
+		return nil, false, nil
+	}
+	user := &entity.User{}
+	exist, err = pr.data.DB.Context(ctx).ID(token.UserID).Get(user)
+	if err != nil {
+		return nil, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		return nil, false, nil
+	}
+	userRoleRel := &entity.UserRoleRel{}
+	exist, err = pr.data.DB.Context(ctx).Where(builder.Eq{"user_id": user.ID}).Get(userRoleRel)
+	if err != nil {
+		return nil, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	roleID := role.RoleUserID
+	if exist {
+		roleID = userRoleRel.RoleID
+	}
+
+	_, err = pr.data.DB.Context(ctx).ID(token.ID).Cols("last_used_at").
+		Update(&entity.PersonalAccessToken{LastUsedAt: time.Now()})
+	if err != nil {
+		log.Errorf("update personal access token last used time failed: %v", err)
+	}
+	userInfo = &entity.UserCacheInfo{
+		UserID:      user.ID,
+		UserStatus:  user.Status,
+		EmailStatus: user.MailStatus,
+		RoleID:      roleID,
+		Scopes:      strings.Split(token.Scopes, ","),
+	}
+	if !token.ExpiredAt.IsZero() {
+		userInfo.ExpiredAt = token.ExpiredAt.Unix()
+	}
+	return userInfo, true, nil
+}
@@ -24,35 +24,72 @@ import (
 	"github.com/apache/answer/internal/repo/activity"
 	"github.com/apache/answer/internal/repo/activity_common"
 	"github.com/apache/answer/internal/repo/answer"
+	"github.com/apache/answer/internal/repo/audit_log"
 	"github.com/apache/answer/internal/repo/auth"
+	"github.com/apache/answer/internal/repo/auto_promotion"
 	"github.com/apache/answer/internal/repo/badge"
 	"github.com/apache/answer/internal/repo/badge_award"
 	"github.com/apache/answer/internal/repo/badge_group"
+	"github.com/apache/answer/internal/repo/bot"
+	"github.com/apache/answer/internal/repo/cache_warming"
 	"github.com/apache/answer/internal/repo/captcha"
 	"github.com/apache/answer/internal/repo/collection"
 	"github.com/apache/answer/internal/repo/comment"
 	"github.com/apache/answer/internal/repo/config"
+	"github.com/apache/answer/internal/repo/content_freshness"
+	"github.com/apache/answer/internal/repo/content_language"
+	"github.com/apache/answer/internal/repo/data_dump"
+	"github.com/apache/answer/internal/repo/digest"
+	"github.com/apache/answer/internal/repo/email_template"
 	"github.com/apache/answer/internal/repo/export"
 	"github.com/apache/answer/internal/repo/file_record"
+	"github.com/apache/answer/internal/repo/helpfulness_survey"
+	"github.com/apache/answer/internal/repo/inbound_email"
+	"github.com/apache/answer/internal/repo/invite_code"
+	"github.com/apache/answer/internal/repo/ip_ban"
+	"github.com/apache/answer/internal/repo/jira"
+	"github.com/apache/answer/internal/repo/leaderboard"
 	"github.com/apache/answer/internal/repo/limit"
+	"github.com/apache/answer/internal/repo/login_protection"
 	"github.com/apache/answer/internal/repo/meta"
+	"github.com/apache/answer/internal/repo/mobile_push"
 	"github.com/apache/answer/internal/repo/notification"
+	"github.com/apache/answer/internal/repo/oauth"
+	"github.com/apache/answer/internal/repo/personal_access_token"
 	"github.com/apache/answer/internal/repo/plugin_config"
 	"github.com/apache/answer/internal/repo/question"
+	"github.com/apache/answer/internal/repo/question_close_vote"
+	"github.com/apache/answer/internal/repo/question_quality"
 	"github.com/apache/answer/internal/repo/rank"
+	"github.com/apache/answer/internal/repo/re_engagement"
 	"github.com/apache/answer/internal/repo/reason"
 	"github.com/apache/answer/internal/repo/report"
+	"github.com/apache/answer/internal/repo/report_appeal"
+	"github.com/apache/answer/internal/repo/reputation_sync"
 	"github.com/apache/answer/internal/repo/review"
 	"github.com/apache/answer/internal/repo/revision"
 	"github.com/apache/answer/internal/repo/role"
+	"github.com/apache/answer/internal/repo/saved_search"
+	"github.com/apache/answer/internal/repo/scim"
 	"github.com/apache/answer/internal/repo/search_common"
 	"github.com/apache/answer/internal/repo/site_info"
+	"github.com/apache/answer/internal/repo/syndication"
+	"github.com/apache/answer/internal/repo/table_partition"
 	"github.com/apache/answer/internal/repo/tag"
+	"github.com/apache/answer/internal/repo/tag_analytics"
 	"github.com/apache/answer/internal/repo/tag_common"
+	"github.com/apache/answer/internal/repo/tag_ignore"
+	"github.com/apache/answer/internal/repo/tag_moderator"
+	"github.com/apache/answer/internal/repo/tag_translation"
 	"github.com/apache/answer/internal/repo/unique"
 	"github.com/apache/answer/internal/repo/user"
 	"github.com/apache/answer/internal/repo/user_external_login"
+	"github.com/apache/answer/internal/repo/user_mute"
 	"github.com/apache/answer/internal/repo/user_notification_config"
+	"github.com/apache/answer/internal/repo/user_timeline"
+	"github.com/apache/answer/internal/repo/vote_fraud"
+	"github.com/apache/answer/internal/repo/web_push"
+	"github.com/apache/answer/internal/repo/webhook"
 	"github.com/google/wire"
 )
 
@@ -60,6 +97,7 @@ import (
 var ProviderSetRepo = wire.NewSet(
 	data.NewData,
 	data.NewDB,
+	data.NewQueryStats,
 	data.NewCache,
 	comment.NewCommentRepo,
 	comment.NewCommentCommonRepo,
@@ -71,6 +109,7 @@ var ProviderSetRepo = wire.NewSet(
 	config.NewConfigRepo,
 	user.NewUserRepo,
 	user.NewUserAdminRepo,
+	user.NewUserImportRepo,
 	rank.NewUserRankRepo,
 	question.NewQuestionRepo,
 	answer.NewAnswerRepo,
@@ -94,6 +133,7 @@ var ProviderSetRepo = wire.NewSet(
 	reason.NewReasonRepo,
 	site_info.NewSiteInfo,
 	notification.NewNotificationRepo,
+	notification.NewVoteMilestoneRepo,
 	role.NewRoleRepo,
 	role.NewUserRoleRelRepo,
 	role.NewRolePowerRelRepo,
@@ -109,4 +149,40 @@ var ProviderSetRepo = wire.NewSet(
 	badge_group.NewBadgeGroupRepo,
 	badge_award.NewBadgeAwardRepo,
 	file_record.NewFileRecordRepo,
+	question_close_vote.NewQuestionCloseVoteRepo,
+	report_appeal.NewReportAppealRepo,
+	data_dump.NewDataDumpRepo,
+	rank.NewReputationRecalcRepo,
+	reputation_sync.NewReputationSyncRepo,
+	vote_fraud.NewVoteFraudRepo,
+	leaderboard.NewLeaderboardRepo,
+	user_timeline.NewUserTimelineRepo,
+	syndication.NewSyndicationRepo,
+	re_engagement.NewReEngagementRepo,
+	question_quality.NewQuestionQualityRepo,
+	tag_moderator.NewTagModeratorRepo,
+	tag_ignore.NewTagIgnoreRepo,
+	tag_translation.NewTagTranslationRepo,
+	tag_analytics.NewTagAnalyticsRepo,
+	helpfulness_survey.NewHelpfulnessSurveyRepo,
+	content_language.NewContentLanguageRepo,
+	auto_promotion.NewAutoPromotionRepo,
+	content_freshness.NewContentFreshnessRepo,
+	bot.NewBotRepo,
+	bot.NewBotTokenRepo,
+	saved_search.NewSavedSearchRepo,
+	cache_warming.NewCacheWarmingRepo,
+	table_partition.NewTablePartitionRepo,
+	webhook.NewWebhookRepo,
+	jira.NewJiraRepo,
+	web_push.NewWebPushRepo,
+	mobile_push.NewMobilePushRepo,
+	inbound_email.NewInboundEmailRepo,
+	email_template.NewEmailTemplateRepo,
+	user_mute.NewUserMuteRepo,
+	scim.NewSCIMRepo,
+	personal_access_token.NewPersonalAccessTokenRepo,
+	personal_access_token.NewPersonalAccessTokenAuthRepo, oauth.NewOAuthRepo, login_protection.NewLoginProtectionRepo, invite_code.NewInviteCodeRepo, audit_log.NewAuditLogRepo,
+	digest.NewDigestRepo,
+	ip_ban.NewIPBanRepo,
 )
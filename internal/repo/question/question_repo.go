@@ -36,6 +36,8 @@ import (
 	"github.com/apache/answer/internal/schema"
 	questioncommon "github.com/apache/answer/internal/service/question_common"
 	"github.com/apache/answer/internal/service/unique"
+	"github.com/apache/answer/pkg/checker"
+	"github.com/apache/answer/pkg/converter"
 	"github.com/apache/answer/pkg/htmltext"
 	"github.com/apache/answer/pkg/uid"
 	"github.com/apache/answer/plugin"
@@ -68,10 +70,12 @@ func (qr *questionRepo) AddQuestion(ctx context.Context, question *entity.Questi
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	question.Language, _ = checker.DetectLanguage(question.Title + " " + question.OriginalText)
 	_, err = qr.data.DB.Context(ctx).Insert(question)
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	qr.updateTitleTrigrams(ctx, question.ID, question.Title)
 	if handler.GetEnableShortID(ctx) {
 		question.ID = uid.EnShortID(question.ID)
 	}
@@ -91,10 +95,25 @@ func (qr *questionRepo) RemoveQuestion(ctx context.Context, id string) (err erro
 // UpdateQuestion update question
 func (qr *questionRepo) UpdateQuestion(ctx context.Context, question *entity.Question, Cols []string) (err error) {
 	question.ID = uid.DeShortID(question.ID)
+	titleChanged := false
+	for _, col := range Cols {
+		if col == "title" {
+			titleChanged = true
+			break
+		}
+	}
+	// the title and content are always updated together, so the language is detected again
+	if titleChanged {
+		question.Language, _ = checker.DetectLanguage(question.Title + " " + question.OriginalText)
+		Cols = append(Cols, "language")
+	}
 	_, err = qr.data.DB.Context(ctx).Where("id =?", question.ID).Cols(Cols...).Update(question)
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	if titleChanged {
+		qr.updateTitleTrigrams(ctx, question.ID, question.Title)
+	}
 	if handler.GetEnableShortID(ctx) {
 		question.ID = uid.EnShortID(question.ID)
 	}
@@ -185,6 +204,11 @@ func (qr *questionRepo) DeletePermanentlyQuestions(ctx context.Context) (err err
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
 
+	_, err = qr.data.DB.Context(ctx).In("question_id", ids).Delete(&entity.QuestionTitleTrigram{})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
 	_, err = qr.data.DB.Context(ctx).Where("status = ?", entity.QuestionStatusDeleted).Delete(&entity.Question{})
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
@@ -192,6 +216,29 @@ func (qr *questionRepo) DeletePermanentlyQuestions(ctx context.Context) (err err
 	return nil
 }
 
+// updateTitleTrigrams rebuild the title trigrams of the question for search suggestion.
+// The suggestion is not critical, so the error is only logged.
+func (qr *questionRepo) updateTitleTrigrams(ctx context.Context, questionID, title string) {
+	questionID = uid.DeShortID(questionID)
+	trigrams := make([]*entity.QuestionTitleTrigram, 0)
+	for _, t := range converter.Trigrams(title) {
+		trigrams = append(trigrams, &entity.QuestionTitleTrigram{QuestionID: questionID, Trigram: t})
+	}
+	_, err := qr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		if _, err = session.Where("question_id = ?", questionID).Delete(&entity.QuestionTitleTrigram{}); err != nil {
+			return nil, err
+		}
+		if len(trigrams) > 0 {
+			_, err = session.Insert(trigrams)
+		}
+		return nil, err
+	})
+	if err != nil {
+		log.Errorf("update question %s title trigrams failed: %v", questionID, err)
+	}
+}
+
 func (qr *questionRepo) RecoverQuestion(ctx context.Context, questionID string) (err error) {
 	questionID = uid.DeShortID(questionID)
 	_, err = qr.data.DB.Context(ctx).ID(questionID).Cols("status").Update(&entity.Question{Status: entity.QuestionStatusAvailable})
@@ -213,7 +260,7 @@ func (qr *questionRepo) UpdateQuestionOperation(ctx context.Context, question *e
 
 func (qr *questionRepo) UpdateAccepted(ctx context.Context, question *entity.Question) (err error) {
 	question.ID = uid.DeShortID(question.ID)
-	_, err = qr.data.DB.Context(ctx).Where("id =?", question.ID).Cols("accepted_answer_id").Update(question)
+	_, err = qr.data.DB.Context(ctx).Where("id =?", question.ID).Cols("accepted_answer_id", "resolution_note").Update(question)
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
@@ -254,6 +301,7 @@ func (qr *questionRepo) GetQuestionsByTitle(ctx context.Context, title string, p
 	questionList = make([]*entity.Question, 0)
 	session := qr.data.DB.Context(ctx)
 	session.Where("status != ?", entity.QuestionStatusDeleted)
+	session.Where("`show` != ?", entity.QuestionShadow)
 	session.Where("title like ?", "%"+title+"%")
 	session.Limit(pageSize)
 	err = session.Find(&questionList)
@@ -342,7 +390,7 @@ func (qr *questionRepo) GetUserQuestionCount(ctx context.Context, userID string,
 	return
 }
 
-func (qr *questionRepo) SitemapQuestions(ctx context.Context, page, pageSize int) (
+func (qr *questionRepo) SitemapQuestions(ctx context.Context, page, pageSize int, excludedTagIDs []string) (
 	questionIDList []*schema.SiteMapQuestionInfo, err error) {
 	page = page - 1
 	questionIDList = make([]*schema.SiteMapQuestionInfo, 0)
@@ -361,6 +409,9 @@ func (qr *questionRepo) SitemapQuestions(ctx context.Context, page, pageSize int
 	session.Select("id,title,created_at,post_update_time")
 	session.Where("`show` = ?", entity.QuestionShow)
 	session.Where("status = ? OR status = ?", entity.QuestionStatusAvailable, entity.QuestionStatusClosed)
+	if len(excludedTagIDs) > 0 {
+		session.And(excludeTaggedQuestions(excludedTagIDs))
+	}
 	session.Limit(pageSize, page*pageSize)
 	session.Asc("created_at")
 	err = session.Find(&rows)
@@ -393,7 +444,7 @@ func (qr *questionRepo) SitemapQuestions(ctx context.Context, page, pageSize int
 
 // GetQuestionPage query question page
 func (qr *questionRepo) GetQuestionPage(ctx context.Context, page, pageSize int,
-	tagIDs []string, userID, orderCond string, inDays int, showHidden, showPending bool) (
+	tagIDs, excludedTagIDs []string, userID, orderCond string, inDays int, showHidden, showPending bool) (
 	questionList []*entity.Question, total int64, err error) {
 	questionList = make([]*entity.Question, 0)
 	session := qr.data.DB.Context(ctx)
@@ -411,6 +462,9 @@ func (qr *questionRepo) GetQuestionPage(ctx context.Context, page, pageSize int,
 		session.In("tag_rel.tag_id", tagIDs)
 		session.And("tag_rel.status = ?", entity.TagRelStatusAvailable)
 	}
+	if len(excludedTagIDs) > 0 {
+		session.And(excludeTaggedQuestions(excludedTagIDs))
+	}
 	if len(userID) > 0 {
 		session.And("question.user_id = ?", userID)
 		if !showHidden {
@@ -457,7 +511,7 @@ func (qr *questionRepo) GetQuestionPage(ctx context.Context, page, pageSize int,
 }
 
 // GetRecommendQuestionPageByTags get recommend question page by tags
-func (qr *questionRepo) GetRecommendQuestionPageByTags(ctx context.Context, userID string, tagIDs, followedQuestionIDs []string, page, pageSize int) (
+func (qr *questionRepo) GetRecommendQuestionPageByTags(ctx context.Context, userID string, tagIDs, excludedTagIDs, followedQuestionIDs []string, page, pageSize int) (
 	questionList []*entity.Question, total int64, err error) {
 	questionList = make([]*entity.Question, 0)
 	orderBySQL := "question.pin DESC, question.created_at DESC"
@@ -492,6 +546,9 @@ func (qr *questionRepo) GetRecommendQuestionPageByTags(ctx context.Context, user
 		}
 	}
 
+	if len(excludedTagIDs) > 0 {
+		session.And(excludeTaggedQuestions(excludedTagIDs))
+	}
 	session.
 		And("question.show = ? and question.status = ?", entity.QuestionShow, entity.QuestionStatusAvailable).
 		Distinct("question.id").
@@ -511,6 +568,12 @@ func (qr *questionRepo) GetRecommendQuestionPageByTags(ctx context.Context, user
 	return questionList, total, err
 }
 
+// excludeTaggedQuestions the condition excluding the questions carrying any of the tags
+func excludeTaggedQuestions(tagIDs []string) builder.Cond {
+	return builder.NotIn("question.id", builder.Select("object_id").From(entity.TagRel{}.TableName()).
+		Where(builder.In("tag_id", tagIDs).And(builder.Eq{"status": entity.TagRelStatusAvailable})))
+}
+
 func (qr *questionRepo) AdminQuestionPage(ctx context.Context, search *schema.AdminQuestionPageReq) ([]*entity.Question, int64, error) {
 	var (
 		count   int64
@@ -580,15 +643,22 @@ func (qr *questionRepo) AdminQuestionPage(ctx context.Context, search *schema.Ad
 	return rows, count, nil
 }
 
-// UpdateSearch update search, if search plugin not enable, do nothing
+// UpdateSearch update search, if neither search plugin nor vector search plugin enable, do nothing
 func (qr *questionRepo) UpdateSearch(ctx context.Context, questionID string) (err error) {
 	// check search plugin
-	var s plugin.Search
+	var (
+		s  plugin.Search
+		vs plugin.VectorSearch
+	)
 	_ = plugin.CallSearch(func(search plugin.Search) error {
 		s = search
 		return nil
 	})
-	if s == nil {
+	_ = plugin.CallVectorSearch(func(search plugin.VectorSearch) error {
+		vs = search
+		return nil
+	})
+	if s == nil && vs == nil {
 		return
 	}
 	questionID = uid.DeShortID(questionID)
@@ -630,7 +700,14 @@ func (qr *questionRepo) UpdateSearch(ctx context.Context, questionID string) (er
 		Score:       int64(question.VoteCount),
 		HasAccepted: question.AcceptedAnswerID != "" && question.AcceptedAnswerID != "0",
 	}
-	err = s.UpdateContent(ctx, content)
+	if s != nil {
+		if err = s.UpdateContent(ctx, content); err != nil {
+			return err
+		}
+	}
+	if vs != nil {
+		err = vs.UpdateVector(ctx, content)
+	}
 	return
 }
 
@@ -706,8 +783,11 @@ func (qr *questionRepo) LinkQuestion(ctx context.Context, link ...*entity.Questi
 	for _, link := range links {
 		key := fmt.Sprintf("%s:%s:%s:%s", link.FromQuestionID, link.ToQuestionID, link.FromAnswerID, link.ToAnswerID)
 		if el, exist := existMap[key]; exist {
-			if el.Status == entity.QuestionLinkStatusDeleted {
+			if el.Status == entity.QuestionLinkStatusDeleted || (link.LinkType > 0 && el.LinkType != link.LinkType) {
 				el.Status = entity.QuestionLinkStatusAvailable
+				if link.LinkType > 0 {
+					el.LinkType = link.LinkType
+				}
 				el.UpdatedAt = time.Now()
 				updateLinks = append(updateLinks, el)
 			}
@@ -722,7 +802,10 @@ func (qr *questionRepo) LinkQuestion(ctx context.Context, link ...*entity.Questi
 	// Batch update
 	if len(updateLinks) > 0 {
 		for _, link := range updateLinks {
-			_, err = qr.data.DB.Context(ctx).ID(link.ID).Cols("status").Update(&entity.QuestionLink{Status: entity.QuestionLinkStatusAvailable})
+			_, err = qr.data.DB.Context(ctx).ID(link.ID).Cols("status", "link_type").Update(&entity.QuestionLink{
+				Status:   entity.QuestionLinkStatusAvailable,
+				LinkType: link.LinkType,
+			})
 			if err != nil {
 				return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 			}
@@ -816,6 +899,32 @@ func (qr *questionRepo) UpdateQuestionLinkStatus(ctx context.Context, status int
 	return
 }
 
+// GetQuestionLinksFrom get the available links from the question and its answers to others
+func (qr *questionRepo) GetQuestionLinksFrom(ctx context.Context, questionID string, limit int) (
+	links []*entity.QuestionLink, err error) {
+	links = make([]*entity.QuestionLink, 0)
+	err = qr.data.DB.Context(ctx).Where("from_question_id = ?", uid.DeShortID(questionID)).
+		And("status = ?", entity.QuestionLinkStatusAvailable).
+		Desc("updated_at").Limit(limit).Find(&links)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetQuestionLinksTo get the available links from others to the question and its answers
+func (qr *questionRepo) GetQuestionLinksTo(ctx context.Context, questionID string, limit int) (
+	links []*entity.QuestionLink, err error) {
+	links = make([]*entity.QuestionLink, 0)
+	err = qr.data.DB.Context(ctx).Where("to_question_id = ?", uid.DeShortID(questionID)).
+		And("status = ?", entity.QuestionLinkStatusAvailable).
+		Desc("updated_at").Limit(limit).Find(&links)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
 // GetQuestionLink get linked question to questionID
 func (qr *questionRepo) GetQuestionLink(ctx context.Context, page, pageSize int, questionID string, orderCond string, inDays int) (questionList []*entity.Question, total int64, err error) {
 	questionList = make([]*entity.Question, 0)
@@ -0,0 +1,126 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question_close_vote
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/question_close_vote"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// questionCloseVoteRepo question close vote repository
+type questionCloseVoteRepo struct {
+	data *data.Data
+}
+
+// NewQuestionCloseVoteRepo new repository
+func NewQuestionCloseVoteRepo(data *data.Data) question_close_vote.QuestionCloseVoteRepo {
+	return &questionCloseVoteRepo{
+		data: data,
+	}
+}
+
+// AddVote add close or reopen vote
+func (qr *questionCloseVoteRepo) AddVote(ctx context.Context, vote *entity.QuestionCloseVote) (err error) {
+	_, err = qr.data.DB.Context(ctx).Insert(vote)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserActiveVote get the active vote of user for question
+func (qr *questionCloseVoteRepo) GetUserActiveVote(ctx context.Context, questionID, userID string, since time.Time) (
+	vote *entity.QuestionCloseVote, exist bool, err error) {
+	vote = &entity.QuestionCloseVote{}
+	exist, err = qr.data.DB.Context(ctx).
+		Where("question_id = ? AND user_id = ? AND status = ?", questionID, userID, entity.QuestionCloseVoteStatusActive).
+		And("created_at >= ?", since).
+		Get(vote)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetActiveVotes get the active votes of question by vote type
+func (qr *questionCloseVoteRepo) GetActiveVotes(ctx context.Context, questionID, voteType string, since time.Time) (
+	votes []*entity.QuestionCloseVote, err error) {
+	votes = make([]*entity.QuestionCloseVote, 0)
+	err = qr.data.DB.Context(ctx).
+		Where("question_id = ? AND vote_type = ? AND status = ?", questionID, voteType, entity.QuestionCloseVoteStatusActive).
+		And("created_at >= ?", since).
+		Desc("id").
+		Find(&votes)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateVoteStatus update vote status
+func (qr *questionCloseVoteRepo) UpdateVoteStatus(ctx context.Context, id, status int) (err error) {
+	_, err = qr.data.DB.Context(ctx).ID(id).Cols("status").Update(&entity.QuestionCloseVote{Status: status})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// ApplyActiveVotes mark all active votes of question as applied
+func (qr *questionCloseVoteRepo) ApplyActiveVotes(ctx context.Context, questionID string) (err error) {
+	_, err = qr.data.DB.Context(ctx).
+		Where("question_id = ? AND status = ?", questionID, entity.QuestionCloseVoteStatusActive).
+		Cols("status").
+		Update(&entity.QuestionCloseVote{Status: entity.QuestionCloseVoteStatusApplied})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// ExpireActiveVotes mark all the active votes of question as expired
+func (qr *questionCloseVoteRepo) ExpireActiveVotes(ctx context.Context, questionID string) (err error) {
+	_, err = qr.data.DB.Context(ctx).
+		Where("question_id = ? AND status = ?", questionID, entity.QuestionCloseVoteStatusActive).
+		Cols("status").
+		Update(&entity.QuestionCloseVote{Status: entity.QuestionCloseVoteStatusExpired})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// ExpireVotes mark the active votes created before the time as expired
+func (qr *questionCloseVoteRepo) ExpireVotes(ctx context.Context, before time.Time) (affected int64, err error) {
+	affected, err = qr.data.DB.Context(ctx).
+		Where("status = ? AND created_at < ?", entity.QuestionCloseVoteStatusActive, before).
+		Cols("status").
+		Update(&entity.QuestionCloseVote{Status: entity.QuestionCloseVoteStatusExpired})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
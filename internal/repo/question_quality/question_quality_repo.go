@@ -0,0 +1,142 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question_quality
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/question_quality"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// questionQualityRepo question quality repository
+type questionQualityRepo struct {
+	data *data.Data
+}
+
+// NewQuestionQualityRepo new repository
+func NewQuestionQualityRepo(data *data.Data) question_quality.QuestionQualityRepo {
+	return &questionQualityRepo{
+		data: data,
+	}
+}
+
+// AddQuality add the quality of question
+func (qr *questionQualityRepo) AddQuality(ctx context.Context, quality *entity.QuestionQuality) (err error) {
+	quality.QuestionID = uid.DeShortID(quality.QuestionID)
+	_, err = qr.data.DB.Context(ctx).Insert(quality)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateQuality update the score, reasons and status of question quality
+func (qr *questionQualityRepo) UpdateQuality(ctx context.Context, quality *entity.QuestionQuality) (err error) {
+	_, err = qr.data.DB.Context(ctx).ID(quality.ID).Cols("score", "reasons", "status").Update(quality)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetQuality get the quality of question by id
+func (qr *questionQualityRepo) GetQuality(ctx context.Context, id string) (
+	quality *entity.QuestionQuality, exist bool, err error) {
+	quality = &entity.QuestionQuality{}
+	exist, err = qr.data.DB.Context(ctx).ID(id).Get(quality)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetQualityByQuestionID get the quality of question
+func (qr *questionQualityRepo) GetQualityByQuestionID(ctx context.Context, questionID string) (
+	quality *entity.QuestionQuality, exist bool, err error) {
+	quality = &entity.QuestionQuality{}
+	exist, err = qr.data.DB.Context(ctx).Where(builder.Eq{"question_id": uid.DeShortID(questionID)}).Get(quality)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetQueuePage get the unresolved items of available questions, the lowest scored first.
+// If claimedBy is set, only the items claimed by the user and not expired are returned.
+func (qr *questionQualityRepo) GetQueuePage(ctx context.Context, claimedBy string, claimExpiredBefore time.Time,
+	page, pageSize int) (list []*entity.QuestionQuality, total int64, err error) {
+	list = make([]*entity.QuestionQuality, 0)
+	tableName := entity.QuestionQuality{}.TableName()
+	session := qr.data.DB.Context(ctx).Table(tableName).Select(tableName+".*").
+		Join("INNER", entity.Question{}.TableName(), "question.id = "+tableName+".question_id").
+		Where(builder.Eq{"question.status": entity.QuestionStatusAvailable})
+	if len(claimedBy) > 0 {
+		session.And(builder.Eq{tableName + ".status": entity.QuestionQualityStatusClaimed, tableName + ".claimed_by": claimedBy}).
+			And(builder.Gte{tableName + ".claimed_at": claimExpiredBefore})
+	} else {
+		session.And(builder.Neq{tableName + ".status": entity.QuestionQualityStatusResolved})
+	}
+	session.Asc(tableName + ".score").Desc(tableName + ".id")
+	total, err = pager.Help(page, pageSize, &list, &entity.QuestionQuality{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// ClaimQuality claim the item if it is pending or the claim of others is expired
+func (qr *questionQualityRepo) ClaimQuality(ctx context.Context, id, userID string, claimExpiredBefore time.Time) (
+	claimed bool, err error) {
+	affected, err := qr.data.DB.Context(ctx).ID(id).
+		Where(builder.Or(
+			builder.Eq{"status": entity.QuestionQualityStatusPending},
+			builder.And(builder.Eq{"status": entity.QuestionQualityStatusClaimed}, builder.Lt{"claimed_at": claimExpiredBefore}),
+			builder.And(builder.Eq{"status": entity.QuestionQualityStatusClaimed}, builder.Eq{"claimed_by": userID}),
+		)).
+		Cols("status", "claimed_by", "claimed_at").
+		Update(&entity.QuestionQuality{
+			Status:    entity.QuestionQualityStatusClaimed,
+			ClaimedBy: userID,
+			ClaimedAt: time.Now(),
+		})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return affected > 0, nil
+}
+
+// ReleaseQuality release the claimed item back to the queue
+func (qr *questionQualityRepo) ReleaseQuality(ctx context.Context, id string) (err error) {
+	_, err = qr.data.DB.Context(ctx).ID(id).Where(builder.Eq{"status": entity.QuestionQualityStatusClaimed}).
+		Cols("status", "claimed_by").
+		Update(&entity.QuestionQuality{Status: entity.QuestionQualityStatusPending, ClaimedBy: "0"})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
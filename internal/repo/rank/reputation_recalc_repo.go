@@ -0,0 +1,108 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package rank
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/rank"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// reputationRecalcRepo reputation recalculation repository
+type reputationRecalcRepo struct {
+	data *data.Data
+}
+
+// NewReputationRecalcRepo new repository
+func NewReputationRecalcRepo(data *data.Data) rank.ReputationRecalcRepo {
+	return &reputationRecalcRepo{
+		data: data,
+	}
+}
+
+// CountUsers count the not deleted users need to be recalculated
+func (rr *reputationRecalcRepo) CountUsers(ctx context.Context, startUserID string, since time.Time) (
+	total int64, err error) {
+	total, err = rr.usersSession(ctx, startUserID, since).Count(&entity.User{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUsers get the not deleted users need to be recalculated, order by id
+func (rr *reputationRecalcRepo) GetUsers(ctx context.Context, startUserID string, since time.Time, limit int) (
+	users []*entity.User, err error) {
+	users = make([]*entity.User, 0)
+	err = rr.usersSession(ctx, startUserID, since).
+		Cols("id", "`rank`", "mail_status").
+		Asc("id").Limit(limit).Find(&users)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+func (rr *reputationRecalcRepo) usersSession(ctx context.Context, startUserID string, since time.Time) *xorm.Session {
+	session := rr.data.DB.Context(ctx).
+		Where("id > ?", startUserID).
+		And("status <> ?", entity.UserStatusDeleted)
+	if !since.IsZero() {
+		session.And(builder.In("id",
+			builder.Select("user_id").From("activity").Where(builder.Gte{"updated_at": since})))
+	}
+	return session
+}
+
+// GetUsersActivityRankSum get the sum of rank of the not cancelled activities, the key is user id
+func (rr *reputationRecalcRepo) GetUsersActivityRankSum(ctx context.Context, userIDs []string) (
+	rankMapping map[string]int, err error) {
+	rankMapping = make(map[string]int, len(userIDs))
+	stats := make([]*entity.ActivityUserRankStat, 0)
+	err = rr.data.DB.Context(ctx).Table("activity").
+		Select("user_id, SUM(`rank`) AS rank_amount").
+		In("user_id", userIDs).
+		And("cancelled = ?", entity.ActivityAvailable).
+		GroupBy("user_id").
+		Find(&stats)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	for _, stat := range stats {
+		rankMapping[stat.UserID] = stat.Rank
+	}
+	return rankMapping, nil
+}
+
+// UpdateUserRank set the user rank
+func (rr *reputationRecalcRepo) UpdateUserRank(ctx context.Context, userID string, rank int) (err error) {
+	_, err = rr.data.DB.Context(ctx).ID(userID).Cols("`rank`").Update(&entity.User{Rank: rank})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
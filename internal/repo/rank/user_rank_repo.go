@@ -21,11 +21,13 @@ package rank
 
 import (
 	"context"
+	"time"
 
 	"github.com/apache/answer/internal/base/data"
 	"github.com/apache/answer/internal/base/pager"
 	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/config"
 	"github.com/apache/answer/internal/service/rank"
 	"github.com/apache/answer/plugin"
@@ -89,6 +91,11 @@ func (ur *UserRankRepo) ChangeUserRank(
 	}
 
 	// If user rank is lower than 1 after this action, then user rank will be set to 1 only.
+	isBot, err := ur.isBotUser(session, userID)
+	if err != nil || isBot {
+		return err
+	}
+
 	if deltaRank < 0 && userCurrentScore+deltaRank < 1 {
 		deltaRank = 1 - userCurrentScore
 	}
@@ -110,6 +117,14 @@ func (ur *UserRankRepo) TriggerUserRank(ctx context.Context,
 	if plugin.RankAgentEnabled() || deltaRank == 0 {
 		return false, nil
 	}
+	// the bot doesn't earn or lose reputation, the activity is recorded as reached the standard without rank
+	isBot, err := ur.isBotUser(session, userID)
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if isBot {
+		return true, nil
+	}
 
 	if deltaRank < 0 {
 		// if user rank is lower than 1 after this action, then user rank will be set to 1 only.
@@ -141,6 +156,10 @@ func (ur *UserRankRepo) TriggerUserRank(ctx context.Context,
 	return false, nil
 }
 
+func (ur *UserRankRepo) isBotUser(session *xorm.Session, userID string) (isBot bool, err error) {
+	return session.Where(builder.Eq{"id": userID, "user_type": entity.UserTypeBot}).Exist(&entity.User{})
+}
+
 func (ur *UserRankRepo) checkUserMinRank(ctx context.Context, session *xorm.Session, userID string, deltaRank int) (
 	isReachStandard bool, err error,
 ) {
@@ -213,3 +232,29 @@ func (ur *UserRankRepo) UserRankPage(ctx context.Context, userID string, page, p
 	}
 	return
 }
+
+// UserRankHistoryPage get all the reputation changes of user including the cancelled, the latest first
+func (ur *UserRankRepo) UserRankHistoryPage(ctx context.Context, req *schema.GetRankHistoryReq) (
+	rankPage []*entity.Activity, total int64, err error,
+) {
+	rankPage = make([]*entity.Activity, 0)
+
+	session := ur.data.DB.Context(ctx).Where(builder.Eq{"has_rank": 1}).And(builder.Neq{"`rank`": 0})
+	if req.ActivityType > 0 {
+		session.And(builder.Eq{"activity_type": req.ActivityType})
+	}
+	if req.StartTime > 0 {
+		session.And(builder.Gte{"created_at": time.Unix(req.StartTime, 0)})
+	}
+	if req.EndTime > 0 {
+		session.And(builder.Lt{"created_at": time.Unix(req.EndTime, 0)})
+	}
+	session.Desc("created_at", "id")
+
+	cond := &entity.Activity{UserID: req.UserID}
+	total, err = pager.Help(req.Page, req.PageSize, &rankPage, cond, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,135 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package re_engagement
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/re_engagement"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// reEngagementRepo re-engagement repository
+type reEngagementRepo struct {
+	data *data.Data
+}
+
+// NewReEngagementRepo new repository
+func NewReEngagementRepo(data *data.Data) re_engagement.ReEngagementRepo {
+	return &reEngagementRepo{
+		data: data,
+	}
+}
+
+// GetNewAnswerStats get the number of answers posted in the time range by others for the questions
+// created before the time
+func (rr *reEngagementRepo) GetNewAnswerStats(ctx context.Context, since, until, questionCreatedBefore time.Time) (
+	stats []*entity.ReEngagementNewAnswerStat, err error) {
+	stats = make([]*entity.ReEngagementNewAnswerStat, 0)
+	err = rr.data.DB.Context(ctx).Table(entity.Answer{}.TableName()).Alias("a").
+		Select("q.id AS question_id, q.user_id AS user_id, COUNT(*) AS new_answer_count").
+		Join("INNER", []string{entity.Question{}.TableName(), "q"}, "q.id = a.question_id").
+		Where("a.created_at >= ? AND a.created_at < ?", since, until).
+		And("a.status = ?", entity.AnswerStatusAvailable).
+		And("a.user_id <> q.user_id").
+		And(builder.In("q.status", entity.QuestionStatusAvailable, entity.QuestionStatusClosed)).
+		And("q.created_at < ?", questionCreatedBefore).
+		GroupBy("q.id, q.user_id").
+		Find(&stats)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetInactiveUsers get the active users with verified email who have not logged in since the time,
+// the users emailed by the campaign since the cooldown time are excluded
+func (rr *reEngagementRepo) GetInactiveUsers(ctx context.Context, campaign string,
+	inactiveBefore, cooldownSince time.Time, startUserID string, limit int) (users []*entity.User, err error) {
+	users = make([]*entity.User, 0)
+	sentQuery := builder.Select("user_id").From(entity.ReEngagementEmail{}.TableName()).
+		Where(builder.Eq{"campaign": campaign}.And(builder.Gte{"created_at": cooldownSince}))
+	err = rr.data.DB.Context(ctx).
+		Where(builder.Eq{"status": entity.UserStatusAvailable, "mail_status": entity.EmailStatusAvailable}).
+		And(builder.Lt{"last_login_date": inactiveBefore}).
+		And(builder.Gt{"id": startUserID}).
+		And(builder.NotIn("id", sentQuery)).
+		Asc("id").Limit(limit).Find(&users)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetSentUserIDs get the users emailed by the campaign since the time
+func (rr *reEngagementRepo) GetSentUserIDs(ctx context.Context, campaign string, userIDs []string, since time.Time) (
+	sent map[string]bool, err error) {
+	sent = make(map[string]bool)
+	if len(userIDs) == 0 {
+		return sent, nil
+	}
+	records := make([]*entity.ReEngagementEmail, 0)
+	err = rr.data.DB.Context(ctx).
+		Where(builder.Eq{"campaign": campaign}).
+		And(builder.In("user_id", userIDs)).
+		And(builder.Gte{"created_at": since}).
+		Find(&records)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	for _, record := range records {
+		sent[record.UserID] = true
+	}
+	return sent, nil
+}
+
+// GetTagQuestionsSince get the latest available questions with any of the tags created since the time
+func (rr *reEngagementRepo) GetTagQuestionsSince(ctx context.Context, tagIDs []string, since time.Time, limit int) (
+	questions []*entity.Question, total int64, err error) {
+	questions = make([]*entity.Question, 0)
+	if len(tagIDs) == 0 {
+		return questions, 0, nil
+	}
+	subQuery := builder.Select("object_id").From(entity.TagRel{}.TableName()).
+		Where(builder.In("tag_id", tagIDs).And(builder.Eq{"status": entity.TagRelStatusAvailable}))
+	total, err = rr.data.DB.Context(ctx).
+		Where(builder.In("id", subQuery)).
+		And(builder.Eq{"status": entity.QuestionStatusAvailable}).
+		And(builder.Gte{"created_at": since}).
+		Desc("created_at").Limit(limit).FindAndCount(&questions)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddEmailRecord add the record of email sent
+func (rr *reEngagementRepo) AddEmailRecord(ctx context.Context, record *entity.ReEngagementEmail) (err error) {
+	_, err = rr.data.DB.Context(ctx).Insert(record)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
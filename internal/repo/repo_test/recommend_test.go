@@ -186,7 +186,7 @@ func Test_questionRepo_GetRecommend(t *testing.T) {
 	}
 
 	// get recommend
-	questionList, total, err := questionRepo.GetRecommendQuestionPageByTags(context.TODO(), user.ID, []string{tags[0].ID}, followQuestionIDs, 1, 20)
+	questionList, total, err := questionRepo.GetRecommendQuestionPageByTags(context.TODO(), user.ID, []string{tags[0].ID}, nil, followQuestionIDs, 1, 20)
 	assert.NoError(t, err)
 	assert.Equal(t, int64(5), total)
 	assert.Equal(t, 5, len(questionList))
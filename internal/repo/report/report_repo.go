@@ -65,6 +65,9 @@ func (rr *reportRepo) GetReportListPage(ctx context.Context, dto *schema.GetRepo
 	reports []*entity.Report, total int64, err error) {
 	cond := &entity.Report{}
 	cond.Status = dto.Status
+	cond.Category = dto.Category
+	cond.ObjectType = dto.ObjectType
+	cond.AssigneeID = dto.AssigneeID
 	session := rr.data.DB.Context(ctx).Desc("updated_at")
 	total, err = pager.Help(dto.Page, dto.PageSize, &reports, cond, session)
 	if err != nil {
@@ -92,6 +95,25 @@ func (rr *reportRepo) UpdateStatus(ctx context.Context, id string, status int) (
 	return
 }
 
+// UpdateHandleResult update report status with the moderator, operation and resolution who handled it
+func (rr *reportRepo) UpdateHandleResult(ctx context.Context, report *entity.Report) (err error) {
+	_, err = rr.data.DB.Context(ctx).ID(report.ID).
+		Cols("status", "handled_by", "handled_op", "resolution", "resolution_reason").Update(report)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateAssignee update the moderator assigned to handle the report
+func (rr *reportRepo) UpdateAssignee(ctx context.Context, id, assigneeID string) (err error) {
+	_, err = rr.data.DB.Context(ctx).ID(id).Cols("assignee_id").Update(&entity.Report{AssigneeID: assigneeID})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
 func (rr *reportRepo) GetReportCount(ctx context.Context) (count int64, err error) {
 	list := make([]*entity.Report, 0)
 	count, err = rr.data.DB.Context(ctx).Where("status =?", entity.ReportStatusPending).FindAndCount(&list)
@@ -0,0 +1,112 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package report_appeal
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/report_appeal"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// reportAppealRepo report appeal repository
+type reportAppealRepo struct {
+	data *data.Data
+}
+
+// NewReportAppealRepo new repository
+func NewReportAppealRepo(data *data.Data) report_appeal.ReportAppealRepo {
+	return &reportAppealRepo{
+		data: data,
+	}
+}
+
+// AddAppeal add report appeal
+func (rr *reportAppealRepo) AddAppeal(ctx context.Context, appeal *entity.ReportAppeal) (err error) {
+	_, err = rr.data.DB.Context(ctx).Insert(appeal)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetByID get report appeal by id
+func (rr *reportAppealRepo) GetByID(ctx context.Context, id string) (
+	appeal *entity.ReportAppeal, exist bool, err error) {
+	appeal = &entity.ReportAppeal{}
+	exist, err = rr.data.DB.Context(ctx).ID(id).Get(appeal)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetByReportID get report appeal by report id
+func (rr *reportAppealRepo) GetByReportID(ctx context.Context, reportID string) (
+	appeal *entity.ReportAppeal, exist bool, err error) {
+	appeal = &entity.ReportAppeal{}
+	exist, err = rr.data.DB.Context(ctx).Where("report_id = ?", reportID).Get(appeal)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetPendingAppealPage get pending appeals which are not handled by the moderator
+func (rr *reportAppealRepo) GetPendingAppealPage(ctx context.Context, page, pageSize int, excludeModeratorID string) (
+	appeals []*entity.ReportAppeal, total int64, err error) {
+	appeals = make([]*entity.ReportAppeal, 0)
+	session := rr.data.DB.Context(ctx).Where("moderator_id <> ?", excludeModeratorID).Asc("created_at")
+	total, err = pager.Help(page, pageSize, &appeals, &entity.ReportAppeal{Status: entity.ReportAppealStatusPending}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateReviewResult update the review result of the pending appeal
+func (rr *reportAppealRepo) UpdateReviewResult(ctx context.Context, appeal *entity.ReportAppeal) (
+	affected int64, err error) {
+	affected, err = rr.data.DB.Context(ctx).ID(appeal.ID).
+		Where("status = ?", entity.ReportAppealStatusPending).
+		Cols("status", "reviewer_id", "review_msg").Update(appeal)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAppealStats get appeal count group by moderator and status
+func (rr *reportAppealRepo) GetAppealStats(ctx context.Context) (stats []*schema.ReportAppealStatsDTO, err error) {
+	stats = make([]*schema.ReportAppealStatsDTO, 0)
+	err = rr.data.DB.Context(ctx).Table(entity.ReportAppeal{}.TableName()).
+		Select("moderator_id, status, count(*) AS count").
+		GroupBy("moderator_id, status").
+		Find(&stats)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,104 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package reputation_sync
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/reputation_sync"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// reputationSyncRepo reputation sync repository
+type reputationSyncRepo struct {
+	data *data.Data
+}
+
+// NewReputationSyncRepo new repository
+func NewReputationSyncRepo(data *data.Data) reputation_sync.ReputationSyncRepo {
+	return &reputationSyncRepo{
+		data: data,
+	}
+}
+
+// GetUpdatedUserIDs get the id of users updated between start and end, order by id
+func (rr *reputationSyncRepo) GetUpdatedUserIDs(ctx context.Context, start, end time.Time, lastUserID string, limit int) (
+	userIDs []string, err error) {
+	userIDs = make([]string, 0)
+	session := rr.data.DB.Context(ctx).Table("user").
+		Where("id > ?", lastUserID).
+		And("updated_at < ?", end)
+	if !start.IsZero() {
+		session.And("updated_at >= ?", start)
+	}
+	err = session.Asc("id").Limit(limit).Select("id").Find(&userIDs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetRoleUpdatedUserIDs get the id of users whose role updated between start and end
+func (rr *reputationSyncRepo) GetRoleUpdatedUserIDs(ctx context.Context, start, end time.Time) (
+	userIDs []string, err error) {
+	userIDs = make([]string, 0)
+	session := rr.data.DB.Context(ctx).Table("user_role_rel").
+		Where("updated_at < ?", end)
+	if !start.IsZero() {
+		session.And("updated_at >= ?", start)
+	}
+	err = session.Select("user_id").Find(&userIDs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetSyncRecords get the last synced records of users, the key is user id
+func (rr *reputationSyncRepo) GetSyncRecords(ctx context.Context, userIDs []string) (
+	records map[string]*entity.UserReputationSync, err error) {
+	records = make(map[string]*entity.UserReputationSync, len(userIDs))
+	list := make([]*entity.UserReputationSync, 0)
+	err = rr.data.DB.Context(ctx).In("user_id", userIDs).Find(&list)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	for _, record := range list {
+		records[record.UserID] = record
+	}
+	return records, nil
+}
+
+// SaveSyncRecord add or update the last synced record of user
+func (rr *reputationSyncRepo) SaveSyncRecord(ctx context.Context, record *entity.UserReputationSync) (err error) {
+	if len(record.ID) == 0 {
+		_, err = rr.data.DB.Context(ctx).Insert(record)
+	} else {
+		_, err = rr.data.DB.Context(ctx).ID(record.ID).Cols("tier", "role_id").Update(record)
+	}
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
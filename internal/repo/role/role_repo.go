@@ -27,6 +27,8 @@ import (
 	"github.com/apache/answer/internal/entity"
 	service "github.com/apache/answer/internal/service/role"
 	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
 )
 
 // roleRepo role repository
@@ -63,3 +65,91 @@ func (rr *roleRepo) GetRoleAllMapping(ctx context.Context) (roleMapping map[int]
 	}
 	return roleMapping, nil
 }
+
+// GetRole get role by id
+func (rr *roleRepo) GetRole(ctx context.Context, roleID int) (role *entity.Role, exist bool, err error) {
+	role = &entity.Role{}
+	exist, err = rr.data.DB.Context(ctx).ID(roleID).Get(role)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetRoleByName get role by name
+func (rr *roleRepo) GetRoleByName(ctx context.Context, name string) (role *entity.Role, exist bool, err error) {
+	role = &entity.Role{}
+	exist, err = rr.data.DB.Context(ctx).Where(builder.Eq{"name": name}).Get(role)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddRole add role with its powers
+func (rr *roleRepo) AddRole(ctx context.Context, role *entity.Role, powers []string) (err error) {
+	_, err = rr.data.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		// the built-in roles are inserted with the specified id, so the id is allocated manually
+		// to avoid the conflict with the sequence of some databases.
+		maxID := 0
+		if _, err := session.Table(role.TableName()).Select("COALESCE(MAX(id), 0)").Get(&maxID); err != nil {
+			return nil, err
+		}
+		role.ID = maxID + 1
+		if _, err := session.Insert(role); err != nil {
+			return nil, err
+		}
+		return nil, saveRolePowers(session, role.ID, powers)
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateRole update role and replace its powers
+func (rr *roleRepo) UpdateRole(ctx context.Context, role *entity.Role, powers []string) (err error) {
+	_, err = rr.data.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		if _, err := session.ID(role.ID).Cols("name", "description").Update(role); err != nil {
+			return nil, err
+		}
+		if _, err := session.Where(builder.Eq{"role_id": role.ID}).Delete(&entity.RolePowerRel{}); err != nil {
+			return nil, err
+		}
+		return nil, saveRolePowers(session, role.ID, powers)
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveRole remove role and its powers
+func (rr *roleRepo) RemoveRole(ctx context.Context, roleID int) (err error) {
+	_, err = rr.data.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		if _, err := session.Where(builder.Eq{"role_id": roleID}).Delete(&entity.RolePowerRel{}); err != nil {
+			return nil, err
+		}
+		_, err := session.ID(roleID).Delete(&entity.Role{})
+		return nil, err
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+func saveRolePowers(session *xorm.Session, roleID int, powers []string) (err error) {
+	if len(powers) == 0 {
+		return nil
+	}
+	rels := make([]*entity.RolePowerRel, 0, len(powers))
+	for _, power := range powers {
+		rels = append(rels, &entity.RolePowerRel{RoleID: roleID, PowerType: power})
+	}
+	_, err = session.Insert(rels)
+	return err
+}
@@ -0,0 +1,121 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package saved_search
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/saved_search"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// savedSearchRepo saved search repository
+type savedSearchRepo struct {
+	data *data.Data
+}
+
+// NewSavedSearchRepo new repository
+func NewSavedSearchRepo(data *data.Data) saved_search.SavedSearchRepo {
+	return &savedSearchRepo{
+		data: data,
+	}
+}
+
+// AddSavedSearch add saved search
+func (sr *savedSearchRepo) AddSavedSearch(ctx context.Context, savedSearch *entity.SavedSearch) (err error) {
+	_, err = sr.data.DB.Context(ctx).Insert(savedSearch)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateSavedSearch update the name, query, frequency and last checked time of saved search
+func (sr *savedSearchRepo) UpdateSavedSearch(ctx context.Context, savedSearch *entity.SavedSearch) (err error) {
+	_, err = sr.data.DB.Context(ctx).ID(savedSearch.ID).Cols("name", "query", "frequency", "last_checked_at").
+		Update(savedSearch)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateLastCheckedAt update the last checked time of saved search
+func (sr *savedSearchRepo) UpdateLastCheckedAt(ctx context.Context, id string, lastCheckedAt time.Time) (err error) {
+	_, err = sr.data.DB.Context(ctx).ID(id).Cols("last_checked_at").
+		Update(&entity.SavedSearch{LastCheckedAt: lastCheckedAt})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveSavedSearch remove the saved search of user
+func (sr *savedSearchRepo) RemoveSavedSearch(ctx context.Context, id, userID string) (err error) {
+	_, err = sr.data.DB.Context(ctx).Where(builder.Eq{"id": id, "user_id": userID}).Delete(&entity.SavedSearch{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetSavedSearch get saved search
+func (sr *savedSearchRepo) GetSavedSearch(ctx context.Context, id string) (
+	savedSearch *entity.SavedSearch, exist bool, err error) {
+	savedSearch = &entity.SavedSearch{}
+	exist, err = sr.data.DB.Context(ctx).ID(id).Get(savedSearch)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserSavedSearches get the saved searches of user
+func (sr *savedSearchRepo) GetUserSavedSearches(ctx context.Context, userID string) (
+	savedSearches []*entity.SavedSearch, err error) {
+	savedSearches = make([]*entity.SavedSearch, 0)
+	err = sr.data.DB.Context(ctx).Where(builder.Eq{"user_id": userID}).Asc("id").Find(&savedSearches)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDueSavedSearches get the saved searches need to be evaluated after the start id,
+// the daily saved searches are due if they are not checked since the daily time
+func (sr *savedSearchRepo) GetDueSavedSearches(ctx context.Context, startID string, dailyBefore time.Time,
+	limit int) (savedSearches []*entity.SavedSearch, err error) {
+	savedSearches = make([]*entity.SavedSearch, 0)
+	err = sr.data.DB.Context(ctx).
+		Where(builder.Gt{"id": startID}).
+		And(builder.Eq{"frequency": entity.SavedSearchFrequencyInstant}.
+			Or(builder.Eq{"frequency": entity.SavedSearchFrequencyDaily}.
+				And(builder.Lte{"last_checked_at": dailyBefore}))).
+		Asc("id").Limit(limit).Find(&savedSearches)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
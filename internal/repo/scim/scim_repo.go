@@ -0,0 +1,70 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package scim
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/scim"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// scimRepo SCIM repository
+type scimRepo struct {
+	data *data.Data
+}
+
+// NewSCIMRepo new repository
+func NewSCIMRepo(data *data.Data) scim.SCIMRepo {
+	return &scimRepo{
+		data: data,
+	}
+}
+
+// GetUsers get the page of users provisioned by SCIM or not, the deleted users and bots are excluded
+func (sr *scimRepo) GetUsers(ctx context.Context, offset, limit int) (users []*entity.User, total int64, err error) {
+	users = make([]*entity.User, 0)
+	total, err = sr.data.DB.Context(ctx).
+		Where(builder.Neq{"status": entity.UserStatusDeleted}.And(builder.Neq{"user_type": entity.UserTypeBot})).
+		Asc("id").Limit(limit, offset).FindAndCount(&users)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetExternalLogins get the SCIM bindings of the users
+func (sr *scimRepo) GetExternalLogins(ctx context.Context, userIDs []string) (logins []*entity.UserExternalLogin, err error) {
+	logins = make([]*entity.UserExternalLogin, 0)
+	if len(userIDs) == 0 {
+		return logins, nil
+	}
+	err = sr.data.DB.Context(ctx).Where(builder.Eq{"provider": schema.SCIMExternalLoginProvider}).
+		In("user_id", userIDs).Find(&logins)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -46,6 +46,9 @@ import (
 	"xorm.io/builder"
 )
 
+// searchTagFacetLimit the max number of tags returned in the search facets
+const searchTagFacetLimit = 10
+
 var (
 	qFields = []string{
 		"`question`.`id`",
@@ -59,6 +62,7 @@ var (
 		"CASE WHEN `accepted_answer_id` > 0 THEN 2 ELSE 0 END as `accepted`",
 		"`question`.`status` as `status`",
 		"`post_update_time`",
+		"`question`.`id` as `object_id`",
 	}
 	aFields = []string{
 		"`answer`.`id` as `id`",
@@ -72,6 +76,21 @@ var (
 		"`adopted` as `accepted`",
 		"`answer`.`status` as `status`",
 		"`answer`.`created_at` as `post_update_time`",
+		"`answer`.`id` as `object_id`",
+	}
+	cFields = []string{
+		"`comment`.`id` as `id`",
+		"`comment`.`question_id` as `question_id`",
+		"`question`.`title` as `title`",
+		"`comment`.`parsed_text` as `parsed_text`",
+		"`comment`.`created_at` as `created_at`",
+		"`comment`.`user_id` as `user_id`",
+		"`comment`.`vote_count` as `vote_count`",
+		"0 as `answer_count`",
+		"0 as `accepted`",
+		"`comment`.`status` as `status`",
+		"`comment`.`created_at` as `post_update_time`",
+		"`comment`.`object_id` as `object_id`",
 	}
 )
 
@@ -98,23 +117,27 @@ func NewSearchRepo(
 	}
 }
 
-// SearchContents search question and answer data
-func (sr *searchRepo) SearchContents(ctx context.Context, words []string, tagIDs [][]string, userID string, votes int, page, pageSize int, order string) (resp []*schema.SearchResult, total int64, err error) {
+// SearchContents search question, answer and comment data
+func (sr *searchRepo) SearchContents(ctx context.Context, words []string, tagIDs [][]string, userID string, votes int, page, pageSize int, order string, withFacets bool) (resp []*schema.SearchResult, total int64, facets *schema.SearchFacets, err error) {
 	words = filterWords(words)
 
 	var (
 		b     *builder.Builder
 		ub    *builder.Builder
+		cb    *builder.Builder
 		qfs   = qFields
 		afs   = aFields
+		cfs   = cFields
 		argsQ = []interface{}{}
 		argsA = []interface{}{}
+		argsC = []interface{}{}
 	)
 
 	if order == "relevance" {
 		if len(words) > 0 {
 			qfs, argsQ = addRelevanceField([]string{"title", "original_text"}, words, qfs)
 			afs, argsA = addRelevanceField([]string{"`answer`.`original_text`"}, words, afs)
+			cfs, argsC = addRelevanceField([]string{"`comment`.`original_text`"}, words, cfs)
 		} else {
 			order = "newest"
 		}
@@ -128,13 +151,17 @@ func (sr *searchRepo) SearchContents(ctx context.Context, words []string, tagIDs
 		And(builder.Eq{"`question`.`show`": entity.QuestionShow})
 	ub.Where(builder.Lt{"`question`.`status`": entity.QuestionStatusDeleted}).
 		And(builder.Lt{"`answer`.`status`": entity.AnswerStatusDeleted}).
-		And(builder.Eq{"`question`.`show`": entity.QuestionShow})
+		And(builder.Eq{"`question`.`show`": entity.QuestionShow}).
+		And(builder.Eq{"`answer`.`shadow`": false})
+	cb = sr.commentBuilder(cfs)
 
 	argsQ = append(argsQ, entity.QuestionStatusDeleted, entity.QuestionShow)
-	argsA = append(argsA, entity.QuestionStatusDeleted, entity.AnswerStatusDeleted, entity.QuestionShow)
+	argsA = append(argsA, entity.QuestionStatusDeleted, entity.AnswerStatusDeleted, entity.QuestionShow, false)
+	argsC = append(argsC, entity.QuestionStatusDeleted, entity.CommentStatusAvailable, entity.QuestionShow, false)
 
 	likeConQ := builder.NewCond()
 	likeConA := builder.NewCond()
+	likeConC := builder.NewCond()
 	for _, word := range words {
 		likeConQ = likeConQ.Or(builder.Like{"title", word}).
 			Or(builder.Like{"original_text", word})
@@ -143,10 +170,14 @@ func (sr *searchRepo) SearchContents(ctx context.Context, words []string, tagIDs
 
 		likeConA = likeConA.Or(builder.Like{"`answer`.original_text", word})
 		argsA = append(argsA, "%"+word+"%")
+
+		likeConC = likeConC.Or(builder.Like{"`comment`.original_text", word})
+		argsC = append(argsC, "%"+word+"%")
 	}
 
 	b.Where(likeConQ)
 	ub.Where(likeConA)
+	cb.Where(likeConC)
 
 	// check tag
 	for ti, tagID := range tagIDs {
@@ -161,11 +192,18 @@ func (sr *searchRepo) SearchContents(ctx context.Context, words []string, tagIDs
 				ast + ".status": entity.TagRelStatusAvailable,
 			}).
 			And(builder.In(ast+".tag_id", tagID))
+		cb.Join("INNER", "tag_rel as "+ast, "`comment`.`question_id` = "+ast+".object_id").
+			And(builder.Eq{
+				ast + ".status": entity.TagRelStatusAvailable,
+			}).
+			And(builder.In(ast+".tag_id", tagID))
 		argsQ = append(argsQ, entity.TagRelStatusAvailable)
 		argsA = append(argsA, entity.TagRelStatusAvailable)
+		argsC = append(argsC, entity.TagRelStatusAvailable)
 		for _, t := range tagID {
 			argsQ = append(argsQ, t)
 			argsA = append(argsA, t)
+			argsC = append(argsC, t)
 		}
 	}
 
@@ -173,21 +211,27 @@ func (sr *searchRepo) SearchContents(ctx context.Context, words []string, tagIDs
 	if userID != "" {
 		b.Where(builder.Eq{"question.user_id": userID})
 		ub.Where(builder.Eq{"answer.user_id": userID})
+		cb.Where(builder.Eq{"comment.user_id": userID})
 		argsQ = append(argsQ, userID)
 		argsA = append(argsA, userID)
+		argsC = append(argsC, userID)
 	}
 
 	// check vote
 	if votes == 0 {
 		b.Where(builder.Eq{"question.vote_count": votes})
 		ub.Where(builder.Eq{"answer.vote_count": votes})
+		cb.Where(builder.Eq{"comment.vote_count": votes})
 		argsQ = append(argsQ, votes)
 		argsA = append(argsA, votes)
+		argsC = append(argsC, votes)
 	} else if votes > 0 {
 		b.Where(builder.Gte{"question.vote_count": votes})
 		ub.Where(builder.Gte{"answer.vote_count": votes})
+		cb.Where(builder.Gte{"comment.vote_count": votes})
 		argsQ = append(argsQ, votes)
 		argsA = append(argsA, votes)
+		argsC = append(argsC, votes)
 	}
 
 	//b = b.Union("all", ub)
@@ -199,13 +243,25 @@ func (sr *searchRepo) SearchContents(ctx context.Context, words []string, tagIDs
 	if err != nil {
 		return
 	}
-	sql := fmt.Sprintf("(%s UNION ALL %s)", bSQL, ubSQL)
+	cbSQL, _, err := cb.ToSQL()
+	if err != nil {
+		return
+	}
+	sql := fmt.Sprintf("(%s UNION ALL %s UNION ALL %s)", bSQL, ubSQL, cbSQL)
 
 	countSQL, _, err := builder.MySQL().Select("count(*) total").From(sql, "c").ToSQL()
 	if err != nil {
 		return
 	}
 
+	if withFacets {
+		facetArgs := append(append(append([]interface{}{}, argsQ...), argsA...), argsC...)
+		facets, err = sr.searchFacets(ctx, sql, facetArgs)
+		if err != nil {
+			return
+		}
+	}
+
 	startNum := (page - 1) * pageSize
 	querySQL, _, err := builder.MySQL().Select("*").From(sql, "t").OrderBy(sr.parseOrder(ctx, order)).Limit(pageSize, startNum).ToSQL()
 	if err != nil {
@@ -218,10 +274,12 @@ func (sr *searchRepo) SearchContents(ctx context.Context, words []string, tagIDs
 	queryArgs = append(queryArgs, querySQL)
 	queryArgs = append(queryArgs, argsQ...)
 	queryArgs = append(queryArgs, argsA...)
+	queryArgs = append(queryArgs, argsC...)
 
 	countArgs = append(countArgs, countSQL)
 	countArgs = append(countArgs, argsQ...)
 	countArgs = append(countArgs, argsA...)
+	countArgs = append(countArgs, argsC...)
 
 	res, err := sr.data.DB.Context(ctx).Query(queryArgs...)
 	if err != nil {
@@ -242,7 +300,7 @@ func (sr *searchRepo) SearchContents(ctx context.Context, words []string, tagIDs
 }
 
 // SearchQuestions search question data
-func (sr *searchRepo) SearchQuestions(ctx context.Context, words []string, tagIDs [][]string, notAccepted bool, views, answers int, page, pageSize int, order string) (resp []*schema.SearchResult, total int64, err error) {
+func (sr *searchRepo) SearchQuestions(ctx context.Context, words []string, tagIDs [][]string, notAccepted bool, views, answers int, language string, page, pageSize int, order string, withFacets bool) (resp []*schema.SearchResult, total int64, facets *schema.SearchFacets, err error) {
 	words = filterWords(words)
 	var (
 		qfs  = qFields
@@ -296,6 +354,12 @@ func (sr *searchRepo) SearchQuestions(ctx context.Context, words []string, tagID
 		args = append(args, views)
 	}
 
+	// check language
+	if len(language) > 0 {
+		b.And(builder.Eq{"`question`.`language`": language})
+		args = append(args, language)
+	}
+
 	// check answers
 	if answers == 0 {
 		b.And(builder.Eq{"answer_count": answers})
@@ -321,6 +385,18 @@ func (sr *searchRepo) SearchQuestions(ctx context.Context, words []string, tagID
 		return
 	}
 
+	if withFacets {
+		var facetSQL string
+		facetSQL, _, err = b.ToSQL()
+		if err != nil {
+			return
+		}
+		facets, err = sr.searchFacets(ctx, "("+facetSQL+")", args)
+		if err != nil {
+			return
+		}
+	}
+
 	startNum := (page - 1) * pageSize
 	querySQL, _, err := b.OrderBy(sr.parseOrder(ctx, order)).Limit(pageSize, startNum).ToSQL()
 	if err != nil {
@@ -353,7 +429,7 @@ func (sr *searchRepo) SearchQuestions(ctx context.Context, words []string, tagID
 }
 
 // SearchAnswers search answer data
-func (sr *searchRepo) SearchAnswers(ctx context.Context, words []string, tagIDs [][]string, accepted bool, questionID string, page, pageSize int, order string) (resp []*schema.SearchResult, total int64, err error) {
+func (sr *searchRepo) SearchAnswers(ctx context.Context, words []string, tagIDs [][]string, accepted bool, questionID string, page, pageSize int, order string, withFacets bool) (resp []*schema.SearchResult, total int64, facets *schema.SearchFacets, err error) {
 	words = filterWords(words)
 
 	var (
@@ -372,8 +448,9 @@ func (sr *searchRepo) SearchAnswers(ctx context.Context, words []string, tagIDs
 		LeftJoin("`question`", "`question`.id = `answer`.question_id")
 
 	b.Where(builder.Lt{"`question`.`status`": entity.QuestionStatusDeleted}).
-		And(builder.Lt{"`answer`.`status`": entity.AnswerStatusDeleted}).And(builder.Eq{"`question`.`show`": entity.QuestionShow})
-	args = append(args, entity.QuestionStatusDeleted, entity.AnswerStatusDeleted, entity.QuestionShow)
+		And(builder.Lt{"`answer`.`status`": entity.AnswerStatusDeleted}).And(builder.Eq{"`question`.`show`": entity.QuestionShow}).
+		And(builder.Eq{"`answer`.`shadow`": false})
+	args = append(args, entity.QuestionStatusDeleted, entity.AnswerStatusDeleted, entity.QuestionShow, false)
 
 	likeConA := builder.NewCond()
 	for _, word := range words {
@@ -417,6 +494,121 @@ func (sr *searchRepo) SearchAnswers(ctx context.Context, words []string, tagIDs
 		return
 	}
 
+	if withFacets {
+		var facetSQL string
+		facetSQL, _, err = b.ToSQL()
+		if err != nil {
+			return
+		}
+		facets, err = sr.searchFacets(ctx, "("+facetSQL+")", args)
+		if err != nil {
+			return
+		}
+	}
+
+	startNum := (page - 1) * pageSize
+	querySQL, _, err := b.OrderBy(sr.parseOrder(ctx, order)).Limit(pageSize, startNum).ToSQL()
+	if err != nil {
+		return
+	}
+
+	queryArgs = append(queryArgs, querySQL)
+	queryArgs = append(queryArgs, args...)
+
+	countArgs = append(countArgs, countSQL)
+	countArgs = append(countArgs, args...)
+
+	res, err := sr.data.DB.Context(ctx).Query(queryArgs...)
+	if err != nil {
+		return
+	}
+
+	tr, err := sr.data.DB.Context(ctx).Query(countArgs...)
+	if err != nil {
+		return
+	}
+
+	total = converter.StringToInt64(string(tr[0]["total"]))
+	resp, err = sr.parseResult(ctx, res, words)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// SearchComments search comment data, the matched comments are returned with their question
+func (sr *searchRepo) SearchComments(ctx context.Context, words []string, tagIDs [][]string, userID, questionID string, page, pageSize int, order string, withFacets bool) (resp []*schema.SearchResult, total int64, facets *schema.SearchFacets, err error) {
+	words = filterWords(words)
+
+	var (
+		cfs  = cFields
+		args = []interface{}{}
+	)
+	if order == "relevance" {
+		if len(words) > 0 {
+			cfs, args = addRelevanceField([]string{"`comment`.`original_text`"}, words, cfs)
+		} else {
+			order = "newest"
+		}
+	}
+
+	b := sr.commentBuilder(cfs)
+	args = append(args, entity.QuestionStatusDeleted, entity.CommentStatusAvailable, entity.QuestionShow, false)
+
+	likeConC := builder.NewCond()
+	for _, word := range words {
+		likeConC = likeConC.Or(builder.Like{"`comment`.original_text", word})
+		args = append(args, "%"+word+"%")
+	}
+
+	b.Where(likeConC)
+
+	// check tag
+	for ti, tagID := range tagIDs {
+		ast := "tag_rel" + strconv.Itoa(ti)
+		b.Join("INNER", "tag_rel as "+ast, "`comment`.`question_id` = "+ast+".object_id").
+			And(builder.Eq{
+				ast + ".status": entity.TagRelStatusAvailable,
+			}).
+			And(builder.In(ast+".tag_id", tagID))
+		args = append(args, entity.TagRelStatusAvailable)
+		for _, t := range tagID {
+			args = append(args, t)
+		}
+	}
+
+	// check user
+	if userID != "" {
+		b.Where(builder.Eq{"comment.user_id": userID})
+		args = append(args, userID)
+	}
+
+	// check question id
+	if questionID != "" {
+		b.Where(builder.Eq{"comment.question_id": questionID})
+		args = append(args, questionID)
+	}
+
+	queryArgs := []interface{}{}
+	countArgs := []interface{}{}
+
+	countSQL, _, err := builder.MySQL().Select("count(*) total").From(b, "c").ToSQL()
+	if err != nil {
+		return
+	}
+
+	if withFacets {
+		var facetSQL string
+		facetSQL, _, err = b.ToSQL()
+		if err != nil {
+			return
+		}
+		facets, err = sr.searchFacets(ctx, "("+facetSQL+")", args)
+		if err != nil {
+			return
+		}
+	}
+
 	startNum := (page - 1) * pageSize
 	querySQL, _, err := b.OrderBy(sr.parseOrder(ctx, order)).Limit(pageSize, startNum).ToSQL()
 	if err != nil {
@@ -447,6 +639,98 @@ func (sr *searchRepo) SearchAnswers(ctx context.Context, words []string, tagIDs
 	return
 }
 
+// searchFacets count the matched results grouped by tag, question status and date,
+// fromSQL is the parenthesized query of all matched results which must return question_id and created_at
+func (sr *searchRepo) searchFacets(ctx context.Context, fromSQL string, args []interface{}) (facets *schema.SearchFacets, err error) {
+	now := time.Now()
+	dateArgs := []interface{}{
+		now.AddDate(0, 0, -1).Format(time.DateTime),
+		now.AddDate(0, 0, -7).Format(time.DateTime),
+		now.AddDate(0, -1, 0).Format(time.DateTime),
+		now.AddDate(-1, 0, 0).Format(time.DateTime),
+	}
+	statSQL := "SELECT " +
+		"SUM(CASE WHEN q.answer_count > 0 THEN 1 ELSE 0 END) AS answered, " +
+		"SUM(CASE WHEN q.answer_count = 0 THEN 1 ELSE 0 END) AS unanswered, " +
+		"SUM(CASE WHEN t.created_at >= ? THEN 1 ELSE 0 END) AS past_day, " +
+		"SUM(CASE WHEN t.created_at >= ? THEN 1 ELSE 0 END) AS past_week, " +
+		"SUM(CASE WHEN t.created_at >= ? THEN 1 ELSE 0 END) AS past_month, " +
+		"SUM(CASE WHEN t.created_at >= ? THEN 1 ELSE 0 END) AS past_year " +
+		"FROM " + fromSQL + " t INNER JOIN `question` q ON q.id = t.question_id"
+	statArgs := []interface{}{statSQL}
+	statArgs = append(statArgs, dateArgs...)
+	statArgs = append(statArgs, args...)
+	stat, err := sr.data.DB.Context(ctx).Query(statArgs...)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		return
+	}
+
+	facets = &schema.SearchFacets{
+		Tags:   make([]*schema.SearchTagFacet, 0),
+		Status: &schema.SearchStatusFacet{},
+		Date:   &schema.SearchDateFacet{},
+	}
+	if len(stat) != 0 {
+		facets.Status.Answered = converter.StringToInt64(string(stat[0]["answered"]))
+		facets.Status.Unanswered = converter.StringToInt64(string(stat[0]["unanswered"]))
+		facets.Date.PastDay = converter.StringToInt64(string(stat[0]["past_day"]))
+		facets.Date.PastWeek = converter.StringToInt64(string(stat[0]["past_week"]))
+		facets.Date.PastMonth = converter.StringToInt64(string(stat[0]["past_month"]))
+		facets.Date.PastYear = converter.StringToInt64(string(stat[0]["past_year"]))
+	}
+
+	tagSQL := fmt.Sprintf("SELECT tr.tag_id AS tag_id, COUNT(*) AS total FROM %s t "+
+		"INNER JOIN `tag_rel` tr ON tr.object_id = t.question_id AND tr.status = ? "+
+		"GROUP BY tr.tag_id ORDER BY total DESC LIMIT %d", fromSQL, searchTagFacetLimit)
+	tagArgs := []interface{}{tagSQL}
+	tagArgs = append(tagArgs, args...)
+	tagArgs = append(tagArgs, entity.TagRelStatusAvailable)
+	tagRows, err := sr.data.DB.Context(ctx).Query(tagArgs...)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		return
+	}
+	if len(tagRows) == 0 {
+		return
+	}
+
+	tagIDs := make([]string, 0, len(tagRows))
+	for _, row := range tagRows {
+		tagIDs = append(tagIDs, string(row["tag_id"]))
+	}
+	tags, err := sr.tagCommon.GetTagListByIDs(ctx, tagIDs)
+	if err != nil {
+		return
+	}
+	tagMapping := make(map[string]*entity.Tag, len(tags))
+	for _, tag := range tags {
+		tagMapping[tag.ID] = tag
+	}
+	for _, row := range tagRows {
+		tag, ok := tagMapping[string(row["tag_id"])]
+		if !ok {
+			continue
+		}
+		facets.Tags = append(facets.Tags, &schema.SearchTagFacet{
+			SlugName:    tag.SlugName,
+			DisplayName: tag.DisplayName,
+			Count:       converter.StringToInt64(string(row["total"])),
+		})
+	}
+	return
+}
+
+// commentBuilder the available comments of the shown questions, the comments of the shadow-banned users are excluded
+func (sr *searchRepo) commentBuilder(fields []string) *builder.Builder {
+	return builder.MySQL().Select(fields...).From("`comment`").
+		LeftJoin("`question`", "`question`.id = `comment`.question_id").
+		Where(builder.Lt{"`question`.`status`": entity.QuestionStatusDeleted}).
+		And(builder.Eq{"`comment`.`status`": entity.CommentStatusAvailable}).
+		And(builder.Eq{"`question`.`show`": entity.QuestionShow}).
+		And(builder.Eq{"`comment`.`shadow`": false})
+}
+
 func (sr *searchRepo) parseOrder(ctx context.Context, order string) (res string) {
 	switch order {
 	case "newest":
@@ -474,12 +758,17 @@ func (sr *searchRepo) ParseSearchPluginResult(ctx context.Context, sres []plugin
 		switch r.Type {
 		case "question":
 			b = builder.MySQL().Select(qFields...).From("question").Where(builder.Eq{"id": r.ID}).
-				And(builder.Lt{"`status`": entity.QuestionStatusDeleted})
+				And(builder.Lt{"`status`": entity.QuestionStatusDeleted}).And(builder.Eq{"`show`": entity.QuestionShow})
 		case "answer":
 			b = builder.MySQL().Select(aFields...).From("answer").LeftJoin("`question`", "`question`.`id` = `answer`.`question_id`").
 				Where(builder.Eq{"`answer`.`id`": r.ID}).
 				And(builder.Lt{"`question`.`status`": entity.QuestionStatusDeleted}).
-				And(builder.Lt{"`answer`.`status`": entity.AnswerStatusDeleted}).And(builder.Eq{"`question`.`show`": entity.QuestionShow})
+				And(builder.Lt{"`answer`.`status`": entity.AnswerStatusDeleted}).And(builder.Eq{"`question`.`show`": entity.QuestionShow}).
+				And(builder.Eq{"`answer`.`shadow`": false})
+		case "comment":
+			b = sr.commentBuilder(cFields).And(builder.Eq{"`comment`.`id`": r.ID})
+		default:
+			continue
 		}
 		qres, err = sr.data.DB.Context(ctx).Query(b)
 		if err != nil || len(qres) == 0 {
@@ -487,7 +776,34 @@ func (sr *searchRepo) ParseSearchPluginResult(ctx context.Context, sres []plugin
 		}
 		res = append(res, qres[0])
 	}
-	return sr.parseResult(ctx, res, words)
+	resp, err = sr.parseResult(ctx, res, words)
+	if err != nil {
+		return nil, err
+	}
+
+	// prefer the highlights of search plugin if it provides
+	highlights := make(map[string]plugin.SearchResult, len(sres))
+	for _, r := range sres {
+		if len(r.TitleHighlight) > 0 || len(r.ContentHighlights) > 0 {
+			highlights[r.ID] = r
+		}
+	}
+	for _, item := range resp {
+		r, ok := highlights[uid.DeShortID(item.Object.ID)]
+		if !ok {
+			continue
+		}
+		if len(r.TitleHighlight) > 0 {
+			item.Object.Highlight.Title = htmltext.SanitizeHighlight(r.TitleHighlight)
+		}
+		if len(r.ContentHighlights) > 0 {
+			item.Object.Highlight.Fragments = make([]string, 0, len(r.ContentHighlights))
+			for _, fragment := range r.ContentHighlights {
+				item.Object.Highlight.Fragments = append(item.Object.Highlight.Fragments, htmltext.SanitizeHighlight(fragment))
+			}
+		}
+	}
+	return resp, nil
 }
 
 // parseResult parse search result, return the data structure
@@ -507,12 +823,13 @@ func (sr *searchRepo) parseResult(ctx context.Context, res []map[string][]byte,
 			QuestionID = uid.EnShortID(QuestionID)
 		}
 
+		excerpt := htmltext.FetchMatchedExcerpt(string(r["parsed_text"]), words, "...", 100)
 		object := &schema.SearchObject{
 			ID:              ID,
 			QuestionID:      QuestionID,
 			Title:           string(r["title"]),
 			UrlTitle:        htmltext.UrlTitle(string(r["title"])),
-			Excerpt:         htmltext.FetchMatchedExcerpt(string(r["parsed_text"]), words, "...", 100),
+			Excerpt:         excerpt,
 			CreatedAtParsed: tp.Unix(),
 			UserInfo: &schema.SearchObjectUser{
 				ID: string(r["user_id"]),
@@ -521,6 +838,10 @@ func (sr *searchRepo) parseResult(ctx context.Context, res []map[string][]byte,
 			VoteCount:   converter.StringToInt(string(r["vote_count"])),
 			Accepted:    string(r["accepted"]) == "2",
 			AnswerCount: converter.StringToInt(string(r["answer_count"])),
+			Highlight: &schema.SearchHighlight{
+				Title:     htmltext.HighlightWords(string(r["title"]), words),
+				Fragments: []string{htmltext.HighlightWords(excerpt, words)},
+			},
 		}
 
 		objectKey, err := obj.GetObjectTypeStrByObjectID(string(r["id"]))
@@ -543,6 +864,14 @@ func (sr *searchRepo) parseResult(ctx context.Context, res []map[string][]byte,
 					break
 				}
 			}
+		case "comment":
+			// the comment is shown with its question, and the answer if it is commented on an answer
+			if objectType, _ := obj.GetObjectTypeStrByObjectID(string(r["object_id"])); objectType == "answer" {
+				object.AnswerID = string(r["object_id"])
+				if handler.GetEnableShortID(ctx) {
+					object.AnswerID = uid.EnShortID(object.AnswerID)
+				}
+			}
 		}
 
 		resultList = append(resultList, &schema.SearchResult{
@@ -616,3 +945,64 @@ func filterWords(words []string) (res []string) {
 	}
 	return
 }
+
+// SuggestQuestions get the visible questions which title contains the query, matched by the title trigrams
+func (sr *searchRepo) SuggestQuestions(ctx context.Context, query string, size int) (
+	resp []*schema.SearchSuggestQuestion, err error) {
+	resp = make([]*schema.SearchSuggestQuestion, 0)
+	trigrams := converter.Trigrams(query)
+	if len(trigrams) == 0 {
+		return resp, nil
+	}
+
+	// the questions containing all trigrams are candidates, fetch more than needed because the order of trigrams is not checked
+	questionIDs := make([]string, 0)
+	err = sr.data.DB.Context(ctx).Table("question_title_trigram").Alias("qt").
+		Select("qt.question_id").
+		Join("INNER", []string{"question", "q"}, "q.id = qt.question_id").
+		In("qt.trigram", trigrams).
+		In("q.status", []int{entity.QuestionStatusAvailable, entity.QuestionStatusClosed}).
+		And("q.show = ?", entity.QuestionShow).
+		GroupBy("qt.question_id").
+		Having(fmt.Sprintf("COUNT(DISTINCT qt.trigram) = %d", len(trigrams))).
+		OrderBy("MAX(q.vote_count) DESC, qt.question_id DESC").
+		Limit(size * 3).
+		Find(&questionIDs)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if len(questionIDs) == 0 {
+		return resp, nil
+	}
+
+	questions := make([]*entity.Question, 0)
+	err = sr.data.DB.Context(ctx).Cols("id", "title", "vote_count").In("id", questionIDs).Find(&questions)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	questionMapping := make(map[string]*entity.Question, len(questions))
+	for _, question := range questions {
+		questionMapping[question.ID] = question
+	}
+
+	normalizedQuery := converter.NormalizeTrigramText(query)
+	for _, id := range questionIDs {
+		question, ok := questionMapping[id]
+		if !ok || !strings.Contains(converter.NormalizeTrigramText(question.Title), normalizedQuery) {
+			continue
+		}
+		if handler.GetEnableShortID(ctx) {
+			id = uid.EnShortID(id)
+		}
+		resp = append(resp, &schema.SearchSuggestQuestion{
+			ID:        id,
+			Title:     question.Title,
+			UrlTitle:  htmltext.UrlTitle(question.Title),
+			VoteCount: question.VoteCount,
+		})
+		if len(resp) >= size {
+			break
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,94 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search_sync
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/search_rebuild"
+	"github.com/apache/answer/plugin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+type searchRebuildRepo struct {
+	data   *data.Data
+	syncer *PluginSyncer
+}
+
+// NewSearchRebuildRepo new repository
+func NewSearchRebuildRepo(data *data.Data) search_rebuild.SearchRebuildRepo {
+	return &searchRebuildRepo{
+		data:   data,
+		syncer: &PluginSyncer{data: data},
+	}
+}
+
+// CountQuestions count the questions whose id is greater than the last id
+func (sr *searchRebuildRepo) CountQuestions(ctx context.Context, lastID string) (total int64, err error) {
+	total, err = sr.data.DB.Context(ctx).Where("id > ?", lastID).Count(&entity.Question{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountAnswers count the answers whose id is greater than the last id
+func (sr *searchRebuildRepo) CountAnswers(ctx context.Context, lastID string) (total int64, err error) {
+	total, err = sr.data.DB.Context(ctx).Where("id > ?", lastID).Count(&entity.Answer{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetQuestionContents get the search contents of the questions after the last id in order of id,
+// the fetched is the number of questions read including the ones failed to convert
+func (sr *searchRebuildRepo) GetQuestionContents(ctx context.Context, lastID string, limit int) (
+	contents []*plugin.SearchContent, nextID string, fetched int, err error) {
+	questions := make([]*entity.Question, 0)
+	err = sr.data.DB.Context(ctx).Where("id > ?", lastID).OrderBy("id ASC").Limit(limit).Find(&questions)
+	if err != nil {
+		return nil, "", 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if len(questions) == 0 {
+		return nil, "", 0, nil
+	}
+	contents, err = sr.syncer.convertQuestions(ctx, questions)
+	return contents, questions[len(questions)-1].ID, len(questions), err
+}
+
+// GetAnswerContents get the search contents of the answers after the last id in order of id,
+// the fetched is the number of answers read including the ones whose question is not found
+func (sr *searchRebuildRepo) GetAnswerContents(ctx context.Context, lastID string, limit int) (
+	contents []*plugin.SearchContent, nextID string, fetched int, err error) {
+	answers := make([]*entity.Answer, 0)
+	err = sr.data.DB.Context(ctx).Where("id > ?", lastID).OrderBy("id ASC").Limit(limit).Find(&answers)
+	if err != nil {
+		return nil, "", 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if len(answers) == 0 {
+		return nil, "", 0, nil
+	}
+	contents, err = sr.syncer.convertAnswers(ctx, answers)
+	return contents, answers[len(answers)-1].ID, len(answers), err
+}
@@ -25,14 +25,27 @@ import (
 
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/pager"
 	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/service/siteinfo_common"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
 	"xorm.io/builder"
+	"xorm.io/xorm"
 )
 
+// siteInfoHistoryLimit the number of versions kept for each type of settings
+const siteInfoHistoryLimit = 50
+
+// historySkippedSiteTypes the states saved by jobs are not settings, so they have no history
+var historySkippedSiteTypes = map[string]bool{
+	constant.SiteTypeDataDumpState:       true,
+	constant.SiteTypeReputationSyncState: true,
+	constant.SiteTypeSearchRebuildState:  true,
+}
+
 type siteInfoRepo struct {
 	data *data.Data
 }
@@ -43,22 +56,124 @@ func NewSiteInfo(data *data.Data) siteinfo_common.SiteInfoRepo {
 	}
 }
 
-// SaveByType save site setting by type
+// SaveByType save site setting by type.
+// If the write is based on an old version, the concurrent changes of other fields are merged, otherwise it is rejected.
 func (sr *siteInfoRepo) SaveByType(ctx context.Context, siteType string, data *entity.SiteInfo) (err error) {
-	old := &entity.SiteInfo{}
-	exist, err := sr.data.DB.Context(ctx).Where(builder.Eq{"type": siteType}).Get(old)
+	_, err = sr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		old := &entity.SiteInfo{}
+		exist, err := session.Where(builder.Eq{"type": siteType}).Get(old)
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		if expectedVersion, ok := handler.GetExpectedVersion(ctx); ok && exist && expectedVersion != old.Version {
+			if err = sr.mergeConcurrentChange(ctx, session, old, expectedVersion, data); err != nil {
+				return nil, err
+			}
+		}
+
+		if exist {
+			data.Version = old.Version
+			affected, err := session.ID(old.ID).Update(data)
+			if err != nil {
+				return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+			}
+			// saved by others after read
+			if affected == 0 {
+				return nil, siteinfo_common.NewVersionConflictError(ctx, nil)
+			}
+			data.ID = old.ID
+			data.CreatedAt = old.CreatedAt
+		} else {
+			if _, err = session.Insert(data); err != nil {
+				return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+			}
+		}
+		if historySkippedSiteTypes[siteType] {
+			return nil, nil
+		}
+		return nil, sr.addHistory(ctx, session, siteType, data)
+	})
+	if err != nil {
+		return err
+	}
+	sr.setCache(ctx, siteType, data)
+	return
+}
+
+// mergeConcurrentChange merge the content changed by others after the expected version into data
+func (sr *siteInfoRepo) mergeConcurrentChange(ctx context.Context, session *xorm.Session,
+	current *entity.SiteInfo, expectedVersion int, data *entity.SiteInfo) (err error) {
+	base := &entity.SiteInfoHistory{}
+	exist, err := session.Where(builder.Eq{"type": current.Type, "version": expectedVersion}).Get(base)
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
-	if exist {
-		_, err = sr.data.DB.Context(ctx).ID(old.ID).Update(data)
-	} else {
-		_, err = sr.data.DB.Context(ctx).Insert(data)
+	// without the base content, all fields different from the current are conflicts
+	if !exist {
+		base.Content = ""
 	}
+	merged, conflicts, err := siteinfo_common.MergeSiteInfoContent(base.Content, current.Content, data.Content)
 	if err != nil {
+		return errors.BadRequest(reason.RequestFormatError).WithError(err).WithStack()
+	}
+	if len(conflicts) > 0 {
+		return siteinfo_common.NewVersionConflictError(ctx, conflicts)
+	}
+	data.Content = merged
+	return nil
+}
+
+// addHistory add the saved version and remove the oldest versions out of limit
+func (sr *siteInfoRepo) addHistory(ctx context.Context, session *xorm.Session, siteType string, data *entity.SiteInfo) (err error) {
+	history := &entity.SiteInfoHistory{
+		Type:       siteType,
+		Version:    data.Version,
+		Content:    data.Content,
+		OperatorID: handler.GetOperatorID(ctx),
+	}
+	if _, err = session.Insert(history); err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
-	sr.setCache(ctx, siteType, data)
+	_, err = session.Where(builder.Eq{"type": siteType}).
+		And(builder.Lte{"version": data.Version - siteInfoHistoryLimit}).
+		Delete(&entity.SiteInfoHistory{})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// GetVersions get the current version of all types of settings
+func (sr *siteInfoRepo) GetVersions(ctx context.Context) (siteInfoList []*entity.SiteInfo, err error) {
+	siteInfoList = make([]*entity.SiteInfo, 0)
+	err = sr.data.DB.Context(ctx).Cols("type", "version", "updated_at").Asc("type").Find(&siteInfoList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetHistory get the saved version of settings
+func (sr *siteInfoRepo) GetHistory(ctx context.Context, siteType string, version int) (
+	history *entity.SiteInfoHistory, exist bool, err error) {
+	history = &entity.SiteInfoHistory{}
+	exist, err = sr.data.DB.Context(ctx).Where(builder.Eq{"type": siteType, "version": version}).Get(history)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetHistoryPage get the saved versions of settings, the latest first
+func (sr *siteInfoRepo) GetHistoryPage(ctx context.Context, siteType string, page, pageSize int) (
+	historyList []*entity.SiteInfoHistory, total int64, err error) {
+	historyList = make([]*entity.SiteInfoHistory, 0)
+	session := sr.data.DB.Context(ctx).Where(builder.Eq{"type": siteType}).Desc("version")
+	total, err = pager.Help(page, pageSize, &historyList, &entity.SiteInfoHistory{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
 	return
 }
 
@@ -0,0 +1,131 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package syndication
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/syndication_common"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// syndicationRepo syndication repository
+type syndicationRepo struct {
+	data *data.Data
+}
+
+// NewSyndicationRepo new repository
+func NewSyndicationRepo(data *data.Data) syndication_common.SyndicationRepo {
+	return &syndicationRepo{
+		data: data,
+	}
+}
+
+// GetLink get the link of the source question
+func (sr *syndicationRepo) GetLink(ctx context.Context, direction, peerURL, sourceQuestionID string) (
+	link *entity.SyndicationLink, exist bool, err error) {
+	link = &entity.SyndicationLink{}
+	exist, err = sr.data.DB.Context(ctx).Where(builder.Eq{
+		"direction":          direction,
+		"peer_url":           peerURL,
+		"source_question_id": sourceQuestionID,
+	}).Get(link)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetMirrorLinkByQuestionID get the mirror link of the local question
+func (sr *syndicationRepo) GetMirrorLinkByQuestionID(ctx context.Context, questionID string) (
+	link *entity.SyndicationLink, exist bool, err error) {
+	link = &entity.SyndicationLink{}
+	exist, err = sr.data.DB.Context(ctx).Where(builder.Eq{
+		"direction":         entity.SyndicationDirectionMirror,
+		"local_question_id": uid.DeShortID(questionID),
+	}).Get(link)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// SaveLink add the link if not exist, otherwise update it
+func (sr *syndicationRepo) SaveLink(ctx context.Context, link *entity.SyndicationLink) (err error) {
+	if len(link.ID) == 0 {
+		_, err = sr.data.DB.Context(ctx).Insert(link)
+	} else {
+		_, err = sr.data.DB.Context(ctx).ID(link.ID).
+			Cols("local_question_id", "source_url", "source_updated_at").Update(link)
+	}
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetPushCursor get the update time of the latest question pushed to the peer
+func (sr *syndicationRepo) GetPushCursor(ctx context.Context, peerURL string) (cursor time.Time, err error) {
+	link := &entity.SyndicationLink{}
+	exist, err := sr.data.DB.Context(ctx).Where(builder.Eq{
+		"direction": entity.SyndicationDirectionPush,
+		"peer_url":  peerURL,
+	}).Desc("source_updated_at").Get(link)
+	if err != nil {
+		return cursor, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		cursor = link.SourceUpdatedAt
+	}
+	return cursor, nil
+}
+
+// GetQuestionsByTagsUpdatedSince get the available and listed questions with any of the tags and none of the excluded tags,
+// posted or updated since the time,
+// the earliest first
+func (sr *syndicationRepo) GetQuestionsByTagsUpdatedSince(ctx context.Context, tagIDs, excludedTagIDs []string,
+	since time.Time, limit int) (questions []*entity.Question, err error) {
+	questions = make([]*entity.Question, 0)
+	if len(tagIDs) == 0 {
+		return questions, nil
+	}
+	subQuery := builder.Select("object_id").From(entity.TagRel{}.TableName()).
+		Where(builder.In("tag_id", tagIDs).And(builder.Eq{"status": entity.TagRelStatusAvailable}))
+	session := sr.data.DB.Context(ctx).
+		Where(builder.In("id", subQuery)).
+		And(builder.In("status", entity.QuestionStatusAvailable, entity.QuestionStatusClosed)).
+		And(builder.Eq{"`show`": entity.QuestionShow}).
+		And(builder.Gte{"post_update_time": since})
+	if len(excludedTagIDs) > 0 {
+		session.And(builder.NotIn("id", builder.Select("object_id").From(entity.TagRel{}.TableName()).
+			Where(builder.In("tag_id", excludedTagIDs).And(builder.Eq{"status": entity.TagRelStatusAvailable}))))
+	}
+	err = session.Asc("post_update_time").Limit(limit).Find(&questions)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,168 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package table_partition
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/service/table_partition"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm/schemas"
+)
+
+// mysqlMaxValuePartition the catch-all partition of MySQL, the new partitions are split from it if exists
+const mysqlMaxValuePartition = "pmax"
+
+// tablePartitionRepo table partition repository.
+// MySQL tables are partitioned by RANGE (UNIX_TIMESTAMP(created_at)) and PostgreSQL tables are partitioned by RANGE (created_at),
+// the PostgreSQL partitions are the tables named like activity_p202601.
+type tablePartitionRepo struct {
+	data *data.Data
+}
+
+// NewTablePartitionRepo new repository
+func NewTablePartitionRepo(data *data.Data) table_partition.TablePartitionRepo {
+	return &tablePartitionRepo{
+		data: data,
+	}
+}
+
+func (tr *tablePartitionRepo) dbType() schemas.DBType {
+	return tr.data.DB.Dialect().URI().DBType
+}
+
+// IsSupported SQLite does not support partition
+func (tr *tablePartitionRepo) IsSupported() bool {
+	return tr.dbType() == schemas.MYSQL || tr.dbType() == schemas.POSTGRES
+}
+
+// IsPartitioned check the table is partitioned or not
+func (tr *tablePartitionRepo) IsPartitioned(ctx context.Context, table string) (partitioned bool, err error) {
+	var sql string
+	switch tr.dbType() {
+	case schemas.MYSQL:
+		sql = "SELECT COUNT(*) AS total FROM information_schema.PARTITIONS " +
+			"WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND PARTITION_NAME IS NOT NULL"
+	case schemas.POSTGRES:
+		sql = "SELECT COUNT(*) AS total FROM pg_partitioned_table pt " +
+			"INNER JOIN pg_class c ON c.oid = pt.partrelid WHERE c.relname = ?"
+	default:
+		return false, nil
+	}
+	res, err := tr.data.DB.Context(ctx).QueryString(sql, table)
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return len(res) > 0 && res[0]["total"] != "0", nil
+}
+
+// GetPartitionMonths get the months of partitions which are named by month
+func (tr *tablePartitionRepo) GetPartitionMonths(ctx context.Context, table string) (months []time.Time, err error) {
+	names, err := tr.getPartitionNames(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+	months = make([]time.Time, 0, len(names))
+	for _, name := range names {
+		if month, ok := table_partition.ParseMonthPartitionName(strings.TrimPrefix(name, table+"_")); ok {
+			months = append(months, month)
+		}
+	}
+	return months, nil
+}
+
+func (tr *tablePartitionRepo) getPartitionNames(ctx context.Context, table string) (names []string, err error) {
+	var sql string
+	switch tr.dbType() {
+	case schemas.MYSQL:
+		sql = "SELECT PARTITION_NAME AS name FROM information_schema.PARTITIONS " +
+			"WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND PARTITION_NAME IS NOT NULL"
+	case schemas.POSTGRES:
+		sql = "SELECT c.relname AS name FROM pg_inherits i " +
+			"INNER JOIN pg_class c ON c.oid = i.inhrelid " +
+			"INNER JOIN pg_class p ON p.oid = i.inhparent WHERE p.relname = ?"
+	default:
+		return nil, nil
+	}
+	res, err := tr.data.DB.Context(ctx).QueryString(sql, table)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	names = make([]string, 0, len(res))
+	for _, r := range res {
+		names = append(names, r["name"])
+	}
+	return names, nil
+}
+
+// AddMonthPartition add the partition contains the records created in the month
+func (tr *tablePartitionRepo) AddMonthPartition(ctx context.Context, table string, month time.Time) (err error) {
+	name := table_partition.MonthPartitionName(month)
+	end := month.AddDate(0, 1, 0).Format(time.DateTime)
+	var sql string
+	switch tr.dbType() {
+	case schemas.MYSQL:
+		names, err := tr.getPartitionNames(ctx, table)
+		if err != nil {
+			return err
+		}
+		partition := fmt.Sprintf("PARTITION %s VALUES LESS THAN (UNIX_TIMESTAMP('%s'))", name, end)
+		sql = fmt.Sprintf("ALTER TABLE `%s` ADD PARTITION (%s)", table, partition)
+		for _, n := range names {
+			if n == mysqlMaxValuePartition {
+				sql = fmt.Sprintf("ALTER TABLE `%s` REORGANIZE PARTITION %s INTO (%s, PARTITION %s VALUES LESS THAN MAXVALUE)",
+					table, mysqlMaxValuePartition, partition, mysqlMaxValuePartition)
+				break
+			}
+		}
+	case schemas.POSTGRES:
+		sql = fmt.Sprintf(`CREATE TABLE IF NOT EXISTS "%s_%s" PARTITION OF "%s" FOR VALUES FROM ('%s') TO ('%s')`,
+			table, name, table, month.Format(time.DateTime), end)
+	default:
+		return nil
+	}
+	if _, err = tr.data.DB.Context(ctx).Exec(sql); err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// DropMonthPartition drop the partition of month with all records in it
+func (tr *tablePartitionRepo) DropMonthPartition(ctx context.Context, table string, month time.Time) (err error) {
+	name := table_partition.MonthPartitionName(month)
+	var sql string
+	switch tr.dbType() {
+	case schemas.MYSQL:
+		sql = fmt.Sprintf("ALTER TABLE `%s` DROP PARTITION %s", table, name)
+	case schemas.POSTGRES:
+		sql = fmt.Sprintf(`DROP TABLE IF EXISTS "%s_%s"`, table, name)
+	default:
+		return nil
+	}
+	if _, err = tr.data.DB.Context(ctx).Exec(sql); err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
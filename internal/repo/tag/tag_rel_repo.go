@@ -112,6 +112,16 @@ func (tr *tagRelRepo) RemoveTagRelListByIDs(ctx context.Context, ids []int64) (e
 	return
 }
 
+// DeleteTagRelListByTagID delete all the relations of the tag no matter status,
+// so that the tag is not brought back when a hidden or deleted post is recovered
+func (tr *tagRelRepo) DeleteTagRelListByTagID(ctx context.Context, tagID string) (err error) {
+	_, err = tr.data.DB.Context(ctx).Where("tag_id = ?", tagID).Delete(&entity.TagRel{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
 // GetObjectTagRelWithoutStatus get object tag relation no matter status
 func (tr *tagRelRepo) GetObjectTagRelWithoutStatus(ctx context.Context, objectID, tagID string) (
 	tagRel *entity.TagRel, exist bool, err error,
@@ -21,6 +21,7 @@ package tag
 
 import (
 	"context"
+	"time"
 
 	"github.com/apache/answer/internal/base/data"
 	"github.com/apache/answer/internal/base/reason"
@@ -30,6 +31,7 @@ import (
 	"github.com/apache/answer/pkg/converter"
 	"github.com/segmentfault/pacman/errors"
 	"xorm.io/builder"
+	"xorm.io/xorm"
 )
 
 // tagRepo tag repository
@@ -68,6 +70,27 @@ func (tr *tagRepo) UpdateTag(ctx context.Context, tag *entity.Tag) (err error) {
 	return
 }
 
+// UpdateTagDeprecation mark the tag as deprecated or not along with its replacement tags
+func (tr *tagRepo) UpdateTagDeprecation(ctx context.Context, tagID string, deprecated bool, replacementTags string) (err error) {
+	_, err = tr.data.DB.Context(ctx).ID(tagID).Cols("deprecated", "replacement_tags").
+		Update(&entity.Tag{Deprecated: deprecated, ReplacementTags: replacementTags})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDeprecatedTagList get all available deprecated tags
+func (tr *tagRepo) GetDeprecatedTagList(ctx context.Context) (tagList []*entity.Tag, err error) {
+	tagList = make([]*entity.Tag, 0)
+	err = tr.data.DB.Context(ctx).Where(builder.Eq{"deprecated": true}).
+		And(builder.Eq{"status": entity.TagStatusAvailable}).Find(&tagList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
 // RecoverTag recover deleted tag
 func (tr *tagRepo) RecoverTag(ctx context.Context, tagID string) (err error) {
 	_, err = tr.data.DB.Context(ctx).ID(tagID).Update(&entity.Tag{Status: entity.TagStatusAvailable})
@@ -111,6 +134,142 @@ func (tr *tagRepo) UpdateTagSynonym(ctx context.Context, tagSlugNameList []strin
 	return
 }
 
+// MergeTag merge source tag into target tag in one transaction: retag the posts, move the followers,
+// combine the counts, then make the source tag a synonym of target tag or delete it
+func (tr *tagRepo) MergeTag(ctx context.Context, sourceTagID string, target *entity.Tag, followActivityType int,
+	deleteSource bool) (err error) {
+	targetTagID := target.ID
+	_, err = tr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+
+		// 1. the synonyms of source tag become the synonyms of target tag
+		_, err = session.Where(builder.Eq{"main_tag_id": converter.StringToInt64(sourceTagID)}).
+			MustCols("main_tag_id", "main_tag_slug_name").
+			Update(&entity.Tag{MainTagID: converter.StringToInt64(target.ID), MainTagSlugName: target.SlugName})
+		if err != nil {
+			return nil, err
+		}
+
+		// 2. retag the posts, skip the posts already tagged with target tag
+		sourceRelList := make([]*entity.TagRel, 0)
+		if err = session.Where(builder.Eq{"tag_id": sourceTagID}).Find(&sourceRelList); err != nil {
+			return nil, err
+		}
+		targetObjectIDs := make([]string, 0)
+		err = session.Table(entity.TagRel{}.TableName()).Where(builder.Eq{"tag_id": targetTagID}).
+			Cols("object_id").Find(&targetObjectIDs)
+		if err != nil {
+			return nil, err
+		}
+		tagged := make(map[string]bool, len(targetObjectIDs))
+		for _, objectID := range targetObjectIDs {
+			tagged[objectID] = true
+		}
+		newRelList := make([]*entity.TagRel, 0)
+		for _, rel := range sourceRelList {
+			if tagged[rel.ObjectID] {
+				continue
+			}
+			tagged[rel.ObjectID] = true
+			newRelList = append(newRelList, &entity.TagRel{TagID: targetTagID, ObjectID: rel.ObjectID, Status: rel.Status})
+		}
+		if len(newRelList) > 0 {
+			if _, err = session.Insert(newRelList); err != nil {
+				return nil, err
+			}
+		}
+		if _, err = session.Where(builder.Eq{"tag_id": sourceTagID}).Delete(&entity.TagRel{}); err != nil {
+			return nil, err
+		}
+
+		// 3. move the followers, skip the users already following target tag
+		followerIDs := make([]string, 0)
+		err = session.Table(entity.Activity{}.TableName()).
+			Where(builder.Eq{"object_id": sourceTagID, "activity_type": followActivityType, "cancelled": entity.ActivityAvailable}).
+			Cols("user_id").Find(&followerIDs)
+		if err != nil {
+			return nil, err
+		}
+		targetFollows := make([]*entity.Activity, 0)
+		err = session.Where(builder.Eq{"object_id": targetTagID, "activity_type": followActivityType}).
+			Find(&targetFollows)
+		if err != nil {
+			return nil, err
+		}
+		followed := make(map[string]*entity.Activity, len(targetFollows))
+		for _, act := range targetFollows {
+			followed[act.UserID] = act
+		}
+		for _, userID := range followerIDs {
+			act, ok := followed[userID]
+			if ok && act.Cancelled == entity.ActivityAvailable {
+				continue
+			}
+			if ok {
+				_, err = session.ID(act.ID).Cols("cancelled").Update(&entity.Activity{Cancelled: entity.ActivityAvailable})
+			} else {
+				_, err = session.Insert(&entity.Activity{
+					UserID:           userID,
+					ObjectID:         targetTagID,
+					OriginalObjectID: targetTagID,
+					ActivityType:     followActivityType,
+					CreatedAt:        time.Now(),
+					UpdatedAt:        time.Now(),
+					Cancelled:        entity.ActivityAvailable,
+				})
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		_, err = session.Where(builder.Eq{"object_id": sourceTagID, "activity_type": followActivityType}).
+			Delete(&entity.Activity{})
+		if err != nil {
+			return nil, err
+		}
+
+		// 4. combine the counts
+		questionCount, err := session.Count(&entity.TagRel{TagID: targetTagID, Status: entity.TagRelStatusAvailable})
+		if err != nil {
+			return nil, err
+		}
+		followCount, err := session.Count(&entity.Activity{ObjectID: targetTagID, ActivityType: followActivityType,
+			Cancelled: entity.ActivityAvailable})
+		if err != nil {
+			return nil, err
+		}
+		_, err = session.ID(targetTagID).MustCols("question_count", "follow_count").
+			Update(&entity.Tag{QuestionCount: int(questionCount), FollowCount: int(followCount)})
+		if err != nil {
+			return nil, err
+		}
+
+		// 5. leave source tag as a synonym of target tag, or delete it
+		source := &entity.Tag{MainTagID: converter.StringToInt64(target.ID), MainTagSlugName: target.SlugName}
+		if deleteSource {
+			source = &entity.Tag{Status: entity.TagStatusDeleted}
+		}
+		_, err = session.ID(sourceTagID).
+			MustCols("main_tag_id", "main_tag_slug_name", "question_count", "follow_count").Update(source)
+		return nil, err
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetSynonymTagList get all available tags that are synonyms of other tags
+func (tr *tagRepo) GetSynonymTagList(ctx context.Context) (tagList []*entity.Tag, err error) {
+	tagList = make([]*entity.Tag, 0)
+	err = tr.data.DB.Context(ctx).Where(builder.Gt{"main_tag_id": 0}).
+		And(builder.Eq{"status": entity.TagStatusAvailable}).Find(&tagList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
 func (tr *tagRepo) GetTagSynonymCount(ctx context.Context, tagID string) (count int64, err error) {
 	count, err = tr.data.DB.Context(ctx).Count(&entity.Tag{MainTagID: converter.StringToInt64(tagID), Status: entity.TagStatusAvailable})
 	if err != nil {
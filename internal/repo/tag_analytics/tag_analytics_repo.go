@@ -0,0 +1,150 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tag_analytics
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/tag_analytics"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// tagAnalyticsRepo tag analytics repository
+type tagAnalyticsRepo struct {
+	data *data.Data
+}
+
+// NewTagAnalyticsRepo new repository
+func NewTagAnalyticsRepo(data *data.Data) tag_analytics.TagAnalyticsRepo {
+	return &tagAnalyticsRepo{
+		data: data,
+	}
+}
+
+// GetQuestionFirstAnswers get the questions asked in the tags in the time range and the time of their first answers,
+// the first answer time is zero if the question is not answered
+func (tr *tagAnalyticsRepo) GetQuestionFirstAnswers(ctx context.Context, startTime, endTime time.Time) (
+	questions []*entity.TagQuestionFirstAnswer, err error) {
+	questions = make([]*entity.TagQuestionFirstAnswer, 0)
+	err = tr.data.DB.Context(ctx).Table(entity.TagRel{}.TableName()).Alias("tr").
+		Select("tr.tag_id, q.id AS question_id, q.created_at").
+		Join("INNER", []string{entity.Question{}.TableName(), "q"}, "q.id = tr.object_id").
+		Where("tr.status = ?", entity.TagRelStatusAvailable).
+		And(builder.In("q.status", entity.QuestionStatusAvailable, entity.QuestionStatusClosed)).
+		And("q.created_at >= ? AND q.created_at < ?", startTime, endTime).
+		Find(&questions)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	questionIDs := make([]string, 0, len(questions))
+	for _, q := range questions {
+		questionIDs = append(questionIDs, q.QuestionID)
+	}
+	firstAnswerAt := make(map[string]time.Time)
+	for start := 0; start < len(questionIDs); start += 500 {
+		end := min(start+500, len(questionIDs))
+		answers := make([]*entity.Answer, 0)
+		err = tr.data.DB.Context(ctx).Cols("question_id", "created_at").
+			Where("status = ?", entity.AnswerStatusAvailable).
+			And(builder.In("question_id", questionIDs[start:end])).
+			Find(&answers)
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		for _, answer := range answers {
+			if at, ok := firstAnswerAt[answer.QuestionID]; !ok || answer.CreatedAt.Before(at) {
+				firstAnswerAt[answer.QuestionID] = answer.CreatedAt
+			}
+		}
+	}
+	for _, q := range questions {
+		q.FirstAnswerAt = firstAnswerAt[q.QuestionID]
+	}
+	return questions, nil
+}
+
+// GetAnswererStats get the answers posted by users in the tags in the time range
+func (tr *tagAnalyticsRepo) GetAnswererStats(ctx context.Context, startTime, endTime time.Time) (
+	stats []*entity.TagAnswererStat, err error) {
+	stats = make([]*entity.TagAnswererStat, 0)
+	err = tr.data.DB.Context(ctx).Table(entity.Answer{}.TableName()).Alias("a").
+		Select("tr.tag_id, a.user_id, COUNT(*) AS answer_count").
+		Join("INNER", []string{entity.TagRel{}.TableName(), "tr"}, "tr.object_id = a.question_id").
+		Where("a.status = ?", entity.AnswerStatusAvailable).
+		And("tr.status = ?", entity.TagRelStatusAvailable).
+		And("a.created_at >= ? AND a.created_at < ?", startTime, endTime).
+		GroupBy("tr.tag_id, a.user_id").
+		Find(&stats)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// ReplaceDailyStats replace all the tag stats of the day
+func (tr *tagAnalyticsRepo) ReplaceDailyStats(ctx context.Context, date string, stats []*entity.TagDailyStat) (err error) {
+	_, err = tr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		if _, err = session.Where("date = ?", date).Delete(&entity.TagDailyStat{}); err != nil {
+			return nil, err
+		}
+		for start := 0; start < len(stats); start += 100 {
+			end := min(start+100, len(stats))
+			if _, err = session.Insert(stats[start:end]); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDailyStats get the stats of the tag between the days, both inclusive, ordered by day
+func (tr *tagAnalyticsRepo) GetDailyStats(ctx context.Context, tagID, startDate, endDate string) (
+	stats []*entity.TagDailyStat, err error) {
+	stats = make([]*entity.TagDailyStat, 0)
+	err = tr.data.DB.Context(ctx).Where("tag_id = ?", tagID).
+		And("date >= ? AND date <= ?", startDate, endDate).
+		Asc("date").Find(&stats)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetLatestDate get the latest aggregated day
+func (tr *tagAnalyticsRepo) GetLatestDate(ctx context.Context) (date string, exist bool, err error) {
+	stat := &entity.TagDailyStat{}
+	exist, err = tr.data.DB.Context(ctx).Desc("date").Get(stat)
+	if err != nil {
+		return "", false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return stat.Date, exist, nil
+}
@@ -0,0 +1,88 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tag_ignore
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/tag"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// tagIgnoreRepo tag ignore repository
+type tagIgnoreRepo struct {
+	data *data.Data
+}
+
+// NewTagIgnoreRepo new repository
+func NewTagIgnoreRepo(data *data.Data) tag.TagIgnoreRepo {
+	return &tagIgnoreRepo{
+		data: data,
+	}
+}
+
+// AddTagIgnore ignore the tag, do nothing if the user already ignored it
+func (tr *tagIgnoreRepo) AddTagIgnore(ctx context.Context, userID, tagID string) (err error) {
+	exist, err := tr.data.DB.Context(ctx).Exist(&entity.TagIgnore{UserID: userID, TagID: tagID})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		return nil
+	}
+	_, err = tr.data.DB.Context(ctx).Insert(&entity.TagIgnore{UserID: userID, TagID: tagID})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveTagIgnore stop ignoring the tag
+func (tr *tagIgnoreRepo) RemoveTagIgnore(ctx context.Context, userID, tagID string) (err error) {
+	_, err = tr.data.DB.Context(ctx).Where(builder.Eq{"user_id": userID, "tag_id": tagID}).Delete(&entity.TagIgnore{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetIgnoredTagIDs get the ids of the tags ignored by user
+func (tr *tagIgnoreRepo) GetIgnoredTagIDs(ctx context.Context, userID string) (tagIDs []string, err error) {
+	tagIDs = make([]string, 0)
+	err = tr.data.DB.Context(ctx).Table(entity.TagIgnore{}.TableName()).
+		Where(builder.Eq{"user_id": userID}).Asc("id").Cols("tag_id").Find(&tagIDs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// IsIgnored whether the user ignored the tag
+func (tr *tagIgnoreRepo) IsIgnored(ctx context.Context, userID, tagID string) (ignored bool, err error) {
+	ignored, err = tr.data.DB.Context(ctx).Exist(&entity.TagIgnore{UserID: userID, TagID: tagID})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
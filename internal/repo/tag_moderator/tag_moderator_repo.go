@@ -0,0 +1,96 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tag_moderator
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/tag_moderator"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// tagModeratorRepo tag moderator repository
+type tagModeratorRepo struct {
+	data *data.Data
+}
+
+// NewTagModeratorRepo new repository
+func NewTagModeratorRepo(data *data.Data) tag_moderator.TagModeratorRepo {
+	return &tagModeratorRepo{
+		data: data,
+	}
+}
+
+// AddTagModerator add tag moderator, do nothing if the user is already the moderator of tag
+func (tr *tagModeratorRepo) AddTagModerator(ctx context.Context, tagModerator *entity.TagModerator) (err error) {
+	exist, err := tr.data.DB.Context(ctx).Exist(&entity.TagModerator{TagID: tagModerator.TagID, UserID: tagModerator.UserID})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		return nil
+	}
+	_, err = tr.data.DB.Context(ctx).Insert(tagModerator)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveTagModerator remove tag moderator
+func (tr *tagModeratorRepo) RemoveTagModerator(ctx context.Context, tagID, userID string) (err error) {
+	_, err = tr.data.DB.Context(ctx).Where(builder.Eq{"tag_id": tagID, "user_id": userID}).Delete(&entity.TagModerator{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetTagModerators get the moderators of tag, the earliest assigned first
+func (tr *tagModeratorRepo) GetTagModerators(ctx context.Context, tagID string) (
+	tagModerators []*entity.TagModerator, err error) {
+	tagModerators = make([]*entity.TagModerator, 0)
+	err = tr.data.DB.Context(ctx).Where(builder.Eq{"tag_id": tagID}).Asc("id").Find(&tagModerators)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// IsQuestionTagModerator whether the user is the moderator of any tag carried by the question
+func (tr *tagModeratorRepo) IsQuestionTagModerator(ctx context.Context, userID, questionID string) (
+	is bool, err error) {
+	count, err := tr.data.DB.Context(ctx).Table(entity.TagModerator{}.TableName()).
+		Join("INNER", entity.TagRel{}.TableName(), "tag_rel.tag_id = tag_moderator.tag_id").
+		Where(builder.Eq{
+			"tag_moderator.user_id": userID,
+			"tag_rel.object_id":     questionID,
+			"tag_rel.status":        entity.TagRelStatusAvailable,
+		}).
+		Count()
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return count > 0, nil
+}
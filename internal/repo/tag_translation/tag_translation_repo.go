@@ -0,0 +1,99 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tag_translation
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/tag_common"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// tagTranslationRepo tag translation repository
+type tagTranslationRepo struct {
+	data *data.Data
+}
+
+// NewTagTranslationRepo new repository
+func NewTagTranslationRepo(data *data.Data) tag_common.TagTranslationRepo {
+	return &tagTranslationRepo{
+		data: data,
+	}
+}
+
+// SaveTagTranslation add the translation or update it if the tag already has one in the language
+func (tr *tagTranslationRepo) SaveTagTranslation(ctx context.Context, translation *entity.TagTranslation) (err error) {
+	old := &entity.TagTranslation{}
+	exist, err := tr.data.DB.Context(ctx).
+		Where(builder.Eq{"tag_id": translation.TagID, "language": translation.Language}).Get(old)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		_, err = tr.data.DB.Context(ctx).ID(old.ID).
+			Cols("display_name", "original_text", "parsed_text").Update(translation)
+	} else {
+		_, err = tr.data.DB.Context(ctx).Insert(translation)
+	}
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveTagTranslation remove the translation of tag in the language
+func (tr *tagTranslationRepo) RemoveTagTranslation(ctx context.Context, tagID, language string) (err error) {
+	_, err = tr.data.DB.Context(ctx).Where(builder.Eq{"tag_id": tagID, "language": language}).
+		Delete(&entity.TagTranslation{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetTagTranslations get all translations of tag
+func (tr *tagTranslationRepo) GetTagTranslations(ctx context.Context, tagID string) (
+	translations []*entity.TagTranslation, err error) {
+	translations = make([]*entity.TagTranslation, 0)
+	err = tr.data.DB.Context(ctx).Where(builder.Eq{"tag_id": tagID}).Asc("language").Find(&translations)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetTagTranslationsByLanguage get the translations of tags in the language
+func (tr *tagTranslationRepo) GetTagTranslationsByLanguage(ctx context.Context, tagIDs []string, language string) (
+	translations []*entity.TagTranslation, err error) {
+	translations = make([]*entity.TagTranslation, 0)
+	if len(tagIDs) == 0 {
+		return translations, nil
+	}
+	err = tr.data.DB.Context(ctx).Where(builder.Eq{"language": language}).
+		And(builder.In("tag_id", tagIDs)).Find(&translations)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -147,6 +147,15 @@ func (ur *userAdminRepo) GetUserInfoByEmail(ctx context.Context, email string) (
 	return
 }
 
+// UpdateUserShadowBanned update whether the user is shadow-banned
+func (ur *userAdminRepo) UpdateUserShadowBanned(ctx context.Context, userID string, shadowBanned bool) (err error) {
+	_, err = ur.data.DB.Context(ctx).ID(userID).Cols("shadow_banned").Update(&entity.User{ShadowBanned: shadowBanned})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
 // GetUserPage get user page
 func (ur *userAdminRepo) GetUserPage(ctx context.Context, page, pageSize int, user *entity.User,
 	usernameOrDisplayName string, isStaff bool) (users []*entity.User, total int64, err error) {
@@ -0,0 +1,68 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/role"
+	"github.com/apache/answer/internal/service/user_import"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+// userImportRepo user import repository
+type userImportRepo struct {
+	data *data.Data
+}
+
+// NewUserImportRepo new repository
+func NewUserImportRepo(data *data.Data) user_import.UserImportRepo {
+	return &userImportRepo{
+		data: data,
+	}
+}
+
+// AddImportedUsers add the users with their roles in one transaction, nothing is saved if any of them failed
+func (ur *userImportRepo) AddImportedUsers(ctx context.Context, users []*entity.User, roleIDs []int) (err error) {
+	_, err = ur.data.DB.Transaction(func(session *xorm.Session) (any, error) {
+		session = session.Context(ctx)
+		for i, user := range users {
+			if _, err := session.Insert(user); err != nil {
+				return nil, err
+			}
+			// the user without role relation is a normal user
+			if roleIDs[i] == role.RoleUserID {
+				continue
+			}
+			if _, err := session.Insert(&entity.UserRoleRel{UserID: user.ID, RoleID: roleIDs[i]}); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
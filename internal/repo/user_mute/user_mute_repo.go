@@ -0,0 +1,110 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_mute
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/user_mute"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// userMuteRepo user mute repository
+type userMuteRepo struct {
+	data *data.Data
+}
+
+// NewUserMuteRepo new repository
+func NewUserMuteRepo(data *data.Data) user_mute.UserMuteRepo {
+	return &userMuteRepo{
+		data: data,
+	}
+}
+
+// AddUserMute mute the object, do nothing if the user already muted it
+func (ur *userMuteRepo) AddUserMute(ctx context.Context, userID, muteType, objectID string) (err error) {
+	mute := &entity.UserMute{UserID: userID, MuteType: muteType, ObjectID: objectID}
+	exist, err := ur.data.DB.Context(ctx).Exist(mute)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		return nil
+	}
+	_, err = ur.data.DB.Context(ctx).Insert(mute)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveUserMute stop muting the object
+func (ur *userMuteRepo) RemoveUserMute(ctx context.Context, userID, muteType, objectID string) (err error) {
+	_, err = ur.data.DB.Context(ctx).Where(builder.Eq{"user_id": userID, "mute_type": muteType, "object_id": objectID}).
+		Delete(&entity.UserMute{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserMutes get the objects muted by user, the latest first, all the types if the mute type is empty
+func (ur *userMuteRepo) GetUserMutes(ctx context.Context, userID, muteType string) (mutes []*entity.UserMute, err error) {
+	mutes = make([]*entity.UserMute, 0)
+	cond := builder.Eq{"user_id": userID}
+	if len(muteType) > 0 {
+		cond["mute_type"] = muteType
+	}
+	err = ur.data.DB.Context(ctx).Where(cond).Desc("id").Find(&mutes)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetMutedUserIDs get the users muted the question or the user triggering the notification in the users
+func (ur *userMuteRepo) GetMutedUserIDs(ctx context.Context, userIDs []string, questionID, triggerUserID string) (
+	mutedUserIDs []string, err error) {
+	mutedUserIDs = make([]string, 0)
+	if len(userIDs) == 0 {
+		return mutedUserIDs, nil
+	}
+	muted := builder.NewCond()
+	if len(questionID) > 0 {
+		muted = muted.Or(builder.Eq{"mute_type": constant.QuestionObjectType, "object_id": questionID})
+	}
+	if len(triggerUserID) > 0 {
+		muted = muted.Or(builder.Eq{"mute_type": constant.UserObjectType, "object_id": triggerUserID})
+	}
+	if !muted.IsValid() {
+		return mutedUserIDs, nil
+	}
+	err = ur.data.DB.Context(ctx).Table(entity.UserMute{}.TableName()).Distinct("user_id").
+		Where(builder.In("user_id", userIDs).And(muted)).Find(&mutedUserIDs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,150 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_timeline
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/user_timeline"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// userTimelineRepo user timeline repository
+type userTimelineRepo struct {
+	data *data.Data
+}
+
+// NewUserTimelineRepo new repository
+func NewUserTimelineRepo(data *data.Data) user_timeline.UserTimelineRepo {
+	return &userTimelineRepo{
+		data: data,
+	}
+}
+
+// timelineSource the table and columns of one kind of timeline records
+type timelineSource struct {
+	recordType string
+	table      string
+	cols       string
+	cond       builder.Cond
+}
+
+// AddUserEvent add user event
+func (ur *userTimelineRepo) AddUserEvent(ctx context.Context, event *entity.UserEvent) (err error) {
+	_, err = ur.data.DB.Context(ctx).Insert(event)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserTimelineRecords get the latest records of each kind for the user, at most limit records of each kind.
+// The total is the number of all records of the user.
+func (ur *userTimelineRepo) GetUserTimelineRecords(ctx context.Context, userID string, limit int) (
+	records []*entity.UserTimelineRecord, total int64, err error) {
+	sources := []*timelineSource{
+		{
+			recordType: schema.UserTimelineTypeQuestion,
+			table:      entity.Question{}.TableName(),
+			cols:       "id AS object_id, created_at, title AS summary, status",
+			cond:       builder.Eq{"user_id": userID},
+		},
+		{
+			recordType: schema.UserTimelineTypeAnswer,
+			table:      entity.Answer{}.TableName(),
+			cols:       "id AS object_id, created_at, original_text AS summary, status",
+			cond:       builder.Eq{"user_id": userID},
+		},
+		{
+			recordType: schema.UserTimelineTypeComment,
+			table:      (&entity.Comment{}).TableName(),
+			cols:       "id AS object_id, created_at, original_text AS summary, status",
+			cond:       builder.Eq{"user_id": userID},
+		},
+		{
+			// the first revision is created with the post, so it is not an edit
+			recordType: schema.UserTimelineTypeEdit,
+			table:      entity.Revision{}.TableName(),
+			cols:       "object_id, created_at, log AS summary, status",
+			cond: builder.Eq{"user_id": userID}.And(builder.Expr(
+				"id > (SELECT MIN(r.id) FROM revision r WHERE r.object_id = revision.object_id)")),
+		},
+		{
+			recordType: schema.UserTimelineTypeFlagRaised,
+			table:      entity.Report{}.TableName(),
+			cols:       "object_id, created_at, content AS summary, status, reported_user_id AS related_user_id",
+			cond:       builder.Eq{"user_id": userID},
+		},
+		{
+			recordType: schema.UserTimelineTypeFlagReceived,
+			table:      entity.Report{}.TableName(),
+			cols:       "object_id, created_at, content AS summary, status, user_id AS related_user_id",
+			cond:       builder.Eq{"reported_user_id": userID},
+		},
+		{
+			recordType: "",
+			table:      entity.UserEvent{}.TableName(),
+			cols:       "id AS object_id, created_at, event_type AS record_type, content AS summary, operator_id AS related_user_id, ip",
+			cond:       builder.Eq{"user_id": userID},
+		},
+	}
+
+	records = make([]*entity.UserTimelineRecord, 0)
+	for _, source := range sources {
+		count, err := ur.data.DB.Context(ctx).Table(source.table).Where(source.cond).Count()
+		if err != nil {
+			return nil, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		total += count
+		if count == 0 {
+			continue
+		}
+
+		list := make([]*entity.UserTimelineRecord, 0)
+		err = ur.data.DB.Context(ctx).Table(source.table).Select(source.cols).Where(source.cond).
+			Desc("created_at").Limit(limit).Find(&list)
+		if err != nil {
+			return nil, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		for _, record := range list {
+			if len(source.recordType) > 0 {
+				record.Type = source.recordType
+			}
+		}
+		records = append(records, list...)
+	}
+	return records, total, nil
+}
+
+// GetUserIPs get the distinct ips used by the user
+func (ur *userTimelineRepo) GetUserIPs(ctx context.Context, userID string) (ips []string, err error) {
+	ips = make([]string, 0)
+	err = ur.data.DB.Context(ctx).Table(entity.UserEvent{}.TableName()).Distinct("ip").
+		Where(builder.Eq{"user_id": userID}).And(builder.Neq{"ip": ""}).Find(&ips)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
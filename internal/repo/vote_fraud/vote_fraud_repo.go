@@ -0,0 +1,106 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package vote_fraud
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/vote_fraud"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// voteFraudRepo vote fraud repository
+type voteFraudRepo struct {
+	data *data.Data
+}
+
+// NewVoteFraudRepo new repository
+func NewVoteFraudRepo(data *data.Data) vote_fraud.VoteFraudRepo {
+	return &voteFraudRepo{
+		data: data,
+	}
+}
+
+// GetVotePairCounts get the number of votes from voter to target user since the time, at least minCount
+func (vr *voteFraudRepo) GetVotePairCounts(ctx context.Context, activityTypes []int, since time.Time, minCount int) (
+	pairs []*entity.VotePairCount, err error) {
+	pairs = make([]*entity.VotePairCount, 0)
+	if len(activityTypes) == 0 {
+		return pairs, nil
+	}
+	err = vr.data.DB.Context(ctx).Table(entity.Activity{}.TableName()).
+		Select("trigger_user_id, user_id, COUNT(*) AS vote_count").
+		Where(builder.In("activity_type", activityTypes)).
+		And(builder.Eq{"cancelled": entity.ActivityAvailable}).
+		And(builder.Gte{"updated_at": since}).
+		And(builder.Gt{"trigger_user_id": 0}).
+		GroupBy("trigger_user_id, user_id").
+		Having(fmt.Sprintf("COUNT(*) >= %d", minCount)).
+		Find(&pairs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetPairVotes get the votes from voter to target user since the time
+func (vr *voteFraudRepo) GetPairVotes(ctx context.Context, activityTypes []int, since time.Time, voterID, targetUserID string) (
+	activities []*entity.Activity, err error) {
+	activities = make([]*entity.Activity, 0)
+	err = vr.data.DB.Context(ctx).
+		Where(builder.In("activity_type", activityTypes)).
+		And(builder.Eq{"cancelled": entity.ActivityAvailable}).
+		And(builder.Gte{"updated_at": since}).
+		And(builder.Eq{"trigger_user_id": voterID}).
+		And(builder.Eq{"user_id": targetUserID}).
+		Find(&activities)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddVoteInvalidation add vote invalidation record
+func (vr *voteFraudRepo) AddVoteInvalidation(ctx context.Context, record *entity.VoteInvalidation) (err error) {
+	_, err = vr.data.DB.Context(ctx).Insert(record)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetVoteInvalidationPage get vote invalidation page, the latest first
+func (vr *voteFraudRepo) GetVoteInvalidationPage(ctx context.Context, page, pageSize int) (
+	records []*entity.VoteInvalidation, total int64, err error) {
+	records = make([]*entity.VoteInvalidation, 0)
+	session := vr.data.DB.Context(ctx).Desc("id")
+	total, err = pager.Help(page, pageSize, &records, &entity.VoteInvalidation{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
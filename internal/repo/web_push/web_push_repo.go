@@ -0,0 +1,92 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package web_push
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/web_push"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// webPushRepo web push subscription repository
+type webPushRepo struct {
+	data *data.Data
+}
+
+// NewWebPushRepo new repository
+func NewWebPushRepo(data *data.Data) web_push.WebPushRepo {
+	return &webPushRepo{
+		data: data,
+	}
+}
+
+// SaveSubscription add the subscription, or update the keys if the endpoint of the user is subscribed
+func (wr *webPushRepo) SaveSubscription(ctx context.Context, subscription *entity.WebPushSubscription) (err error) {
+	old := &entity.WebPushSubscription{}
+	exist, err := wr.data.DB.Context(ctx).Where("user_id = ? AND endpoint = ?",
+		subscription.UserID, subscription.Endpoint).Get(old)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		subscription.ID = old.ID
+		_, err = wr.data.DB.Context(ctx).ID(old.ID).Cols("p256dh", "auth", "user_agent").Update(subscription)
+	} else {
+		_, err = wr.data.DB.Context(ctx).Insert(subscription)
+	}
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveSubscription remove the subscription of the endpoint of the user
+func (wr *webPushRepo) RemoveSubscription(ctx context.Context, userID, endpoint string) (err error) {
+	_, err = wr.data.DB.Context(ctx).Where("user_id = ? AND endpoint = ?", userID, endpoint).
+		Delete(&entity.WebPushSubscription{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveSubscriptionByID remove the subscription by id
+func (wr *webPushRepo) RemoveSubscriptionByID(ctx context.Context, id string) (err error) {
+	_, err = wr.data.DB.Context(ctx).ID(id).Delete(&entity.WebPushSubscription{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetSubscriptionsByUserID get the subscriptions of the user, the latest first
+func (wr *webPushRepo) GetSubscriptionsByUserID(ctx context.Context, userID string) (
+	subscriptions []*entity.WebPushSubscription, err error) {
+	subscriptions = make([]*entity.WebPushSubscription, 0)
+	err = wr.data.DB.Context(ctx).Where("user_id = ?", userID).Desc("id").Find(&subscriptions)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,120 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/webhook"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// webhookRepo webhook repository
+type webhookRepo struct {
+	data *data.Data
+}
+
+// NewWebhookRepo new repository
+func NewWebhookRepo(data *data.Data) webhook.WebhookRepo {
+	return &webhookRepo{
+		data: data,
+	}
+}
+
+// AddDeliveries add the deliveries of an event
+func (wr *webhookRepo) AddDeliveries(ctx context.Context, deliveries []*entity.WebhookDelivery) (err error) {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	_, err = wr.data.DB.Context(ctx).Insert(deliveries)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDelivery get delivery by id
+func (wr *webhookRepo) GetDelivery(ctx context.Context, id string) (
+	delivery *entity.WebhookDelivery, exist bool, err error) {
+	delivery = &entity.WebhookDelivery{}
+	exist, err = wr.data.DB.Context(ctx).ID(id).Get(delivery)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDueDeliveries get the pending deliveries whose next attempt time is due, the earliest first
+func (wr *webhookRepo) GetDueDeliveries(ctx context.Context, now time.Time, limit int) (
+	deliveries []*entity.WebhookDelivery, err error) {
+	deliveries = make([]*entity.WebhookDelivery, 0)
+	err = wr.data.DB.Context(ctx).
+		Where("status = ?", entity.WebhookDeliveryStatusPending).
+		And("next_attempt_at <= ?", now).
+		Asc("next_attempt_at", "id").Limit(limit).Find(&deliveries)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateDelivery update the result of delivery attempt
+func (wr *webhookRepo) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) (err error) {
+	_, err = wr.data.DB.Context(ctx).ID(delivery.ID).
+		Cols("status", "attempts", "next_attempt_at", "response_status", "last_error").Update(delivery)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDeliveryPage get delivery page, the latest first
+func (wr *webhookRepo) GetDeliveryPage(ctx context.Context, req *schema.GetWebhookDeliveryPageReq) (
+	deliveries []*entity.WebhookDelivery, total int64, err error) {
+	deliveries = make([]*entity.WebhookDelivery, 0)
+	cond := &entity.WebhookDelivery{
+		Status:     req.Status,
+		Event:      req.Event,
+		WebhookURL: req.WebhookURL,
+	}
+	session := wr.data.DB.Context(ctx).Desc("id")
+	total, err = pager.Help(req.Page, req.PageSize, &deliveries, cond, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// DeleteDeliveriesBefore delete the finished deliveries created before the time
+func (wr *webhookRepo) DeleteDeliveriesBefore(ctx context.Context, before time.Time) (err error) {
+	_, err = wr.data.DB.Context(ctx).
+		Where("status <> ?", entity.WebhookDeliveryStatusPending).
+		And("created_at < ?", before).Delete(&entity.WebhookDelivery{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
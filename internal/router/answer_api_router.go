@@ -21,42 +21,85 @@ package router
 
 import (
 	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/base/validator"
 	"github.com/apache/answer/internal/controller"
 	"github.com/apache/answer/internal/controller_admin"
+	"github.com/apache/answer/internal/service/permission"
 	"github.com/gin-gonic/gin"
 )
 
 type AnswerAPIRouter struct {
-	langController          *controller.LangController
-	userController          *controller.UserController
-	commentController       *controller.CommentController
-	reportController        *controller.ReportController
-	voteController          *controller.VoteController
-	tagController           *controller.TagController
-	followController        *controller.FollowController
-	collectionController    *controller.CollectionController
-	questionController      *controller.QuestionController
-	answerController        *controller.AnswerController
-	searchController        *controller.SearchController
-	revisionController      *controller.RevisionController
-	rankController          *controller.RankController
-	adminUserController     *controller_admin.UserAdminController
-	reasonController        *controller.ReasonController
-	themeController         *controller_admin.ThemeController
-	adminSiteInfoController *controller_admin.SiteInfoController
-	siteInfoController      *controller.SiteInfoController
-	notificationController  *controller.NotificationController
-	dashboardController     *controller.DashboardController
-	uploadController        *controller.UploadController
-	activityController      *controller.ActivityController
-	roleController          *controller_admin.RoleController
-	pluginController        *controller_admin.PluginController
-	permissionController    *controller.PermissionController
-	userPluginController    *controller.UserPluginController
-	reviewController        *controller.ReviewController
-	metaController          *controller.MetaController
-	badgeController         *controller.BadgeController
-	adminBadgeController    *controller_admin.BadgeController
+	langController                *controller.LangController
+	userController                *controller.UserController
+	commentController             *controller.CommentController
+	reportController              *controller.ReportController
+	voteController                *controller.VoteController
+	tagController                 *controller.TagController
+	followController              *controller.FollowController
+	collectionController          *controller.CollectionController
+	questionController            *controller.QuestionController
+	answerController              *controller.AnswerController
+	searchController              *controller.SearchController
+	revisionController            *controller.RevisionController
+	rankController                *controller.RankController
+	adminUserController           *controller_admin.UserAdminController
+	reasonController              *controller.ReasonController
+	themeController               *controller_admin.ThemeController
+	adminSiteInfoController       *controller_admin.SiteInfoController
+	siteInfoController            *controller.SiteInfoController
+	notificationController        *controller.NotificationController
+	dashboardController           *controller.DashboardController
+	uploadController              *controller.UploadController
+	activityController            *controller.ActivityController
+	roleController                *controller_admin.RoleController
+	pluginController              *controller_admin.PluginController
+	permissionController          *controller.PermissionController
+	userPluginController          *controller.UserPluginController
+	reviewController              *controller.ReviewController
+	metaController                *controller.MetaController
+	badgeController               *controller.BadgeController
+	adminBadgeController          *controller_admin.BadgeController
+	questionCloseVoteController   *controller.QuestionCloseVoteController
+	reportAppealController        *controller.ReportAppealController
+	dataDumpController            *controller.DataDumpController
+	reputationController          *controller_admin.ReputationController
+	chatIntakeController          *controller.ChatIntakeController
+	voteFraudController           *controller_admin.VoteFraudController
+	contentLanguageController     *controller_admin.ContentLanguageController
+	leaderboardController         *controller.LeaderboardController
+	userTimelineController        *controller_admin.UserTimelineController
+	syndicationController         *controller.SyndicationController
+	helpfulnessSurveyController   *controller.HelpfulnessSurveyController
+	questionQualityController     *controller.QuestionQualityController
+	tagModeratorController        *controller.TagModeratorController
+	tagAnalyticsController        *controller.TagAnalyticsController
+	adminExportController         *controller_admin.AdminExportController
+	loadSheddingController        *controller_admin.LoadSheddingController
+	queryStatsController          *controller_admin.QueryStatsController
+	autoPromotionController       *controller_admin.AutoPromotionController
+	contentFreshnessController    *controller_admin.ContentFreshnessController
+	botController                 *controller_admin.BotController
+	savedSearchController         *controller.SavedSearchController
+	webhookController             *controller_admin.WebhookController
+	jiraController                *controller.JiraController
+	messengerController           *controller_admin.MessengerController
+	adminPermissionController     *controller_admin.PermissionController
+	webPushController             *controller.WebPushController
+	mobilePushController          *controller.MobilePushController
+	inboundEmailController        *controller.InboundEmailController
+	emailTemplateController       *controller_admin.EmailTemplateController
+	userMuteController            *controller.UserMuteController
+	scimController                *controller.SCIMController
+	personalAccessTokenController *controller.PersonalAccessTokenController
+	oauthController               *controller.OAuthController
+	oauthClientController         *controller_admin.OAuthClientController
+	userSessionController         *controller.UserSessionController
+	adminUserSessionController    *controller_admin.UserSessionController
+	inviteCodeController          *controller.InviteCodeController
+	adminInviteCodeController     *controller_admin.InviteCodeController
+	adminAuditLogController       *controller_admin.AuditLogController
+	adminIPBanController          *controller_admin.IPBanController
+	adminUserImportController     *controller_admin.UserImportController
 }
 
 func NewAnswerAPIRouter(
@@ -90,38 +133,120 @@ func NewAnswerAPIRouter(
 	metaController *controller.MetaController,
 	badgeController *controller.BadgeController,
 	adminBadgeController *controller_admin.BadgeController,
+	questionCloseVoteController *controller.QuestionCloseVoteController,
+	reportAppealController *controller.ReportAppealController,
+	dataDumpController *controller.DataDumpController,
+	reputationController *controller_admin.ReputationController,
+	chatIntakeController *controller.ChatIntakeController,
+	voteFraudController *controller_admin.VoteFraudController,
+	contentLanguageController *controller_admin.ContentLanguageController,
+	leaderboardController *controller.LeaderboardController,
+	userTimelineController *controller_admin.UserTimelineController,
+	syndicationController *controller.SyndicationController,
+	helpfulnessSurveyController *controller.HelpfulnessSurveyController,
+	questionQualityController *controller.QuestionQualityController,
+	tagModeratorController *controller.TagModeratorController,
+	tagAnalyticsController *controller.TagAnalyticsController,
+	adminExportController *controller_admin.AdminExportController,
+	loadSheddingController *controller_admin.LoadSheddingController,
+	queryStatsController *controller_admin.QueryStatsController,
+	autoPromotionController *controller_admin.AutoPromotionController,
+	contentFreshnessController *controller_admin.ContentFreshnessController,
+	botController *controller_admin.BotController,
+	savedSearchController *controller.SavedSearchController,
+	webhookController *controller_admin.WebhookController,
+	jiraController *controller.JiraController,
+	messengerController *controller_admin.MessengerController,
+	adminPermissionController *controller_admin.PermissionController,
+	webPushController *controller.WebPushController,
+	mobilePushController *controller.MobilePushController,
+	inboundEmailController *controller.InboundEmailController,
+	emailTemplateController *controller_admin.EmailTemplateController,
+	userMuteController *controller.UserMuteController,
+	scimController *controller.SCIMController,
+	personalAccessTokenController *controller.PersonalAccessTokenController,
+	oauthController *controller.OAuthController,
+	oauthClientController *controller_admin.OAuthClientController,
+	userSessionController *controller.UserSessionController,
+	adminUserSessionController *controller_admin.UserSessionController,
+	inviteCodeController *controller.InviteCodeController,
+	adminInviteCodeController *controller_admin.InviteCodeController,
+	adminAuditLogController *controller_admin.AuditLogController,
+	adminIPBanController *controller_admin.IPBanController,
+	adminUserImportController *controller_admin.UserImportController,
 ) *AnswerAPIRouter {
 	return &AnswerAPIRouter{
-		langController:          langController,
-		userController:          userController,
-		commentController:       commentController,
-		reportController:        reportController,
-		voteController:          voteController,
-		tagController:           tagController,
-		followController:        followController,
-		collectionController:    collectionController,
-		questionController:      questionController,
-		answerController:        answerController,
-		searchController:        searchController,
-		revisionController:      revisionController,
-		rankController:          rankController,
-		adminUserController:     adminUserController,
-		reasonController:        reasonController,
-		themeController:         themeController,
-		adminSiteInfoController: adminSiteInfoController,
-		notificationController:  notificationController,
-		siteInfoController:      siteInfoController,
-		dashboardController:     dashboardController,
-		uploadController:        uploadController,
-		activityController:      activityController,
-		roleController:          roleController,
-		pluginController:        pluginController,
-		permissionController:    permissionController,
-		userPluginController:    userPluginController,
-		reviewController:        reviewController,
-		metaController:          metaController,
-		badgeController:         badgeController,
-		adminBadgeController:    adminBadgeController,
+		langController:                langController,
+		userController:                userController,
+		commentController:             commentController,
+		reportController:              reportController,
+		voteController:                voteController,
+		tagController:                 tagController,
+		followController:              followController,
+		collectionController:          collectionController,
+		questionController:            questionController,
+		answerController:              answerController,
+		searchController:              searchController,
+		revisionController:            revisionController,
+		rankController:                rankController,
+		adminUserController:           adminUserController,
+		reasonController:              reasonController,
+		themeController:               themeController,
+		adminSiteInfoController:       adminSiteInfoController,
+		notificationController:        notificationController,
+		siteInfoController:            siteInfoController,
+		dashboardController:           dashboardController,
+		uploadController:              uploadController,
+		activityController:            activityController,
+		roleController:                roleController,
+		pluginController:              pluginController,
+		permissionController:          permissionController,
+		userPluginController:          userPluginController,
+		reviewController:              reviewController,
+		metaController:                metaController,
+		badgeController:               badgeController,
+		adminBadgeController:          adminBadgeController,
+		questionCloseVoteController:   questionCloseVoteController,
+		reportAppealController:        reportAppealController,
+		dataDumpController:            dataDumpController,
+		reputationController:          reputationController,
+		chatIntakeController:          chatIntakeController,
+		voteFraudController:           voteFraudController,
+		contentLanguageController:     contentLanguageController,
+		leaderboardController:         leaderboardController,
+		userTimelineController:        userTimelineController,
+		syndicationController:         syndicationController,
+		helpfulnessSurveyController:   helpfulnessSurveyController,
+		questionQualityController:     questionQualityController,
+		tagModeratorController:        tagModeratorController,
+		tagAnalyticsController:        tagAnalyticsController,
+		adminExportController:         adminExportController,
+		loadSheddingController:        loadSheddingController,
+		queryStatsController:          queryStatsController,
+		autoPromotionController:       autoPromotionController,
+		contentFreshnessController:    contentFreshnessController,
+		botController:                 botController,
+		savedSearchController:         savedSearchController,
+		webhookController:             webhookController,
+		jiraController:                jiraController,
+		messengerController:           messengerController,
+		adminPermissionController:     adminPermissionController,
+		webPushController:             webPushController,
+		mobilePushController:          mobilePushController,
+		inboundEmailController:        inboundEmailController,
+		emailTemplateController:       emailTemplateController,
+		userMuteController:            userMuteController,
+		scimController:                scimController,
+		personalAccessTokenController: personalAccessTokenController,
+		oauthController:               oauthController,
+		oauthClientController:         oauthClientController,
+		userSessionController:         userSessionController,
+		adminUserSessionController:    adminUserSessionController,
+		inviteCodeController:          inviteCodeController,
+		adminInviteCodeController:     adminInviteCodeController,
+		adminAuditLogController:       adminAuditLogController,
+		adminIPBanController:          adminIPBanController,
+		adminUserImportController:     adminUserImportController,
 	}
 }
 
@@ -134,6 +259,29 @@ func (a *AnswerAPIRouter) RegisterMustUnAuthAnswerAPIRouter(authUserMiddleware *
 	r.GET("/siteinfo", a.siteInfoController.GetSiteInfo)
 	r.GET("/siteinfo/legal", a.siteInfoController.GetSiteLegalInfo)
 
+	// chat intake, the requests are verified by the signature of chat platform
+	r.POST("/chat/slack/command", a.chatIntakeController.SlackCommand)
+	r.POST("/chat/slack/action", a.chatIntakeController.SlackAction)
+
+	// syndication
+	r.POST("/syndication/webhook", a.syndicationController.ReceiveWebhook)
+
+	// helpfulness survey
+	r.POST("/helpfulness-survey/respond", a.helpfulnessSurveyController.RespondSurvey)
+	r.POST("/chat/mattermost/command", a.chatIntakeController.MattermostCommand)
+	r.POST("/inbound-email/webhook", a.inboundEmailController.ReceiveWebhook)
+
+	// invite code
+	r.GET("/invite-code/check", a.inviteCodeController.CheckInviteCode)
+
+	// oauth provider, the clients authenticate themselves
+	r.GET("/oauth/.well-known/openid-configuration", a.oauthController.GetDiscovery)
+	r.GET("/oauth/jwks", a.oauthController.GetJWKS)
+	r.GET("/oauth/authorize", a.oauthController.AuthorizePage)
+	r.POST("/oauth/token", a.oauthController.Token)
+	r.GET("/oauth/userinfo", a.oauthController.GetUserInfo)
+	r.POST("/oauth/userinfo", a.oauthController.GetUserInfo)
+
 	// user
 	r.GET("/user/info", a.userController.GetUserInfoByUserID)
 	r.GET("/user/action/record", authUserMiddleware.Auth(), a.userController.ActionRecord)
@@ -150,10 +298,29 @@ func (a *AnswerAPIRouter) RegisterMustUnAuthAnswerAPIRouter(authUserMiddleware *
 	r.GET("/plugin/status", a.pluginController.GetAllPluginStatus)
 }
 
+// RegisterSCIMRouter register the SCIM 2.0 api authenticated by the SCIM token of site
+func (a *AnswerAPIRouter) RegisterSCIMRouter(r *gin.RouterGroup) {
+	r.Use(a.scimController.Authenticate())
+	r.GET("/ServiceProviderConfig", a.scimController.GetServiceProviderConfig)
+
+	r.GET("/Users", a.scimController.GetUsers)
+	r.POST("/Users", a.scimController.CreateUser)
+	r.GET("/Users/:id", a.scimController.GetUser)
+	r.PUT("/Users/:id", a.scimController.ReplaceUser)
+	r.PATCH("/Users/:id", a.scimController.PatchUser)
+	r.DELETE("/Users/:id", a.scimController.DeleteUser)
+
+	r.GET("/Groups", a.scimController.GetGroups)
+	r.GET("/Groups/:id", a.scimController.GetGroup)
+	r.PUT("/Groups/:id", a.scimController.ReplaceGroup)
+	r.PATCH("/Groups/:id", a.scimController.PatchGroup)
+}
+
 func (a *AnswerAPIRouter) RegisterUnAuthAnswerAPIRouter(r *gin.RouterGroup) {
 	// user
 	r.GET("/personal/user/info", a.userController.GetOtherUserInfoByUsername)
 	r.GET("/user/ranking", a.userController.UserRanking)
+	r.GET("/user/leaderboard", a.leaderboardController.GetLeaderboard)
 	r.GET("/user/staff", a.userController.UserStaff)
 
 	// answer
@@ -163,6 +330,7 @@ func (a *AnswerAPIRouter) RegisterUnAuthAnswerAPIRouter(r *gin.RouterGroup) {
 
 	// question
 	r.GET("/question/info", a.questionController.GetQuestion)
+	r.GET("/question/:id/export", a.questionController.ExportQuestion)
 	r.GET("/question/invite", a.questionController.GetQuestionInviteUserInfo)
 	r.GET("/question/page", a.questionController.QuestionPage)
 	r.GET("/question/recommend/page", a.questionController.QuestionRecommendPage)
@@ -170,6 +338,8 @@ func (a *AnswerAPIRouter) RegisterUnAuthAnswerAPIRouter(r *gin.RouterGroup) {
 	r.GET("/personal/qa/top", a.questionController.UserTop)
 	r.GET("/personal/question/page", a.questionController.PersonalQuestionPage)
 	r.GET("/question/link", a.questionController.GetQuestionLink)
+	r.GET("/question/close/vote", a.questionCloseVoteController.GetVoteStatus)
+	r.GET("/question/timeline", a.activityController.GetQuestionTimeline)
 
 	// comment
 	r.GET("/comment/page", a.commentController.GetCommentWithPage)
@@ -182,13 +352,16 @@ func (a *AnswerAPIRouter) RegisterUnAuthAnswerAPIRouter(r *gin.RouterGroup) {
 	// tag
 	r.GET("/tags/page", a.tagController.GetTagWithPage)
 	r.GET("/tags/following", a.tagController.GetFollowingTags)
+	r.GET("/tags/ignored", a.tagController.GetIgnoredTags)
 	r.GET("/tag", a.tagController.GetTagInfo)
 	r.GET("/tags", a.tagController.GetTagsBySlugName)
 	r.GET("/tag/synonyms", a.tagController.GetTagSynonyms)
+	r.GET("/tag/moderators", a.tagModeratorController.GetTagModerators)
 
 	// search
 	r.GET("/search", a.searchController.Search)
 	r.GET("/search/desc", a.searchController.SearchDesc)
+	r.GET("/search/suggest", a.searchController.Suggest)
 
 	// rank
 	r.GET("/personal/rank/page", a.rankController.GetRankPersonalWithPage)
@@ -202,6 +375,10 @@ func (a *AnswerAPIRouter) RegisterUnAuthAnswerAPIRouter(r *gin.RouterGroup) {
 	r.GET("/badge/user/awards/recent", a.badgeController.GetRecentBadgeAwardListByUsername)
 	r.GET("/badge/user/awards", a.badgeController.GetAllBadgeAwardListByUsername)
 	r.GET("/badges", a.badgeController.GetBadgeList)
+
+	// data dump
+	r.GET("/data-dump", a.dataDumpController.GetDataDumpInfo)
+	r.GET("/data-dump/download", a.dataDumpController.DownloadDataDump)
 }
 
 func (a *AnswerAPIRouter) RegisterAuthUserWithAnyStatusAnswerAPIRouter(r *gin.RouterGroup) {
@@ -211,12 +388,31 @@ func (a *AnswerAPIRouter) RegisterAuthUserWithAnyStatusAnswerAPIRouter(r *gin.Ro
 }
 
 func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
+	// the routes only taking a few short fields accept the small body
+	smallBody := middleware.RequestBodyLimit(validator.SmallRequestBodySize)
+
 	// revisions
 	r.GET("/revisions/unreviewed", a.revisionController.GetUnreviewedRevisionList)
 	r.PUT("/revisions/audit", a.revisionController.RevisionAudit)
 	r.GET("/revisions/edit/check", a.revisionController.CheckCanUpdateRevision)
 	r.GET("/reviewing/type", a.revisionController.GetReviewingType)
 
+	// helpfulness survey
+	r.POST("/helpfulness-survey/view", a.helpfulnessSurveyController.RecordView)
+	r.GET("/helpfulness-survey/pending", a.helpfulnessSurveyController.GetPendingSurveys)
+	r.GET("/answer/helpfulness", a.helpfulnessSurveyController.GetAnswerHelpfulness)
+
+	// saved search
+	r.GET("/saved-searches", a.savedSearchController.GetSavedSearches)
+	r.POST("/saved-search", a.savedSearchController.AddSavedSearch)
+	r.PUT("/saved-search", a.savedSearchController.UpdateSavedSearch)
+	r.DELETE("/saved-search", a.savedSearchController.RemoveSavedSearch)
+
+	// question quality
+	r.GET("/question/quality/queue", a.questionQualityController.GetQueuePage)
+	r.POST("/question/quality/claim", a.questionQualityController.ClaimItem)
+	r.POST("/question/quality/release", a.questionQualityController.ReleaseItem)
+
 	// comment
 	r.POST("/comment", a.commentController.AddComment)
 	r.DELETE("/comment", a.commentController.RemoveComment)
@@ -226,18 +422,40 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	r.POST("/report", a.reportController.AddReport)
 	r.GET("/report/unreviewed/post", a.reportController.GetUnreviewedReportPostPage)
 	r.PUT("/report/review", a.reportController.ReviewReport)
+	r.GET("/report/page", a.reportController.GetReportPage)
+	r.PUT("/report/assign", a.reportController.AssignReport)
+	r.POST("/report/appeal", a.reportAppealController.AddAppeal)
+	r.GET("/report/appeal", a.reportAppealController.GetAppeal)
+	r.GET("/report/appeal/page", a.reportAppealController.GetPendingAppealPage)
+	r.PUT("/report/appeal/review", a.reportAppealController.ReviewAppeal)
+
+	// jira
+	r.POST("/jira/issue", a.jiraController.CreateIssue)
+	r.GET("/question/moderator/notes", a.jiraController.GetQuestionModeratorNotes)
+
+	// web push
+	r.GET("/webpush/vapid-key", a.webPushController.GetVAPIDKey)
+	r.POST("/webpush/subscription", a.webPushController.Subscribe)
+	r.DELETE("/webpush/subscription", a.webPushController.Unsubscribe)
+	r.GET("/webpush/subscriptions", a.webPushController.GetSubscriptions)
+
+	// mobile push
+	r.GET("/mobile-push/platforms", a.mobilePushController.GetPlatforms)
+	r.POST("/mobile-push/device", a.mobilePushController.RegisterDevice)
+	r.DELETE("/mobile-push/device", a.mobilePushController.RemoveDevice)
+	r.GET("/mobile-push/devices", a.mobilePushController.GetDevices)
 
 	// review
 	r.GET("/review/pending/post/page", a.reviewController.GetUnreviewedPostPage)
 	r.PUT("/review/pending/post", a.reviewController.UpdateReview)
 
 	// vote
-	r.POST("/vote/up", a.voteController.VoteUp)
-	r.POST("/vote/down", a.voteController.VoteDown)
+	r.POST("/vote/up", smallBody, a.voteController.VoteUp)
+	r.POST("/vote/down", smallBody, a.voteController.VoteDown)
 
 	// follow
-	r.POST("/follow", a.followController.Follow)
-	r.PUT("/follow/tags", a.followController.UpdateFollowTags)
+	r.POST("/follow", smallBody, a.followController.Follow)
+	r.PUT("/follow/tags", smallBody, a.followController.UpdateFollowTags)
 
 	// tag
 	r.GET("/question/tags", a.tagController.SearchTagLike)
@@ -247,9 +465,11 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	r.DELETE("/tag", a.tagController.RemoveTag)
 	r.PUT("/tag/synonym", a.tagController.UpdateTagSynonym)
 	r.POST("/tag/merge", a.tagController.MergeTag)
+	r.PUT("/tag/deprecation", a.tagController.DeprecateTag)
+	r.PUT("/tag/ignore", a.tagController.IgnoreTag)
 
 	// collection
-	r.POST("/collection/switch", a.collectionController.CollectionSwitch)
+	r.POST("/collection/switch", smallBody, a.collectionController.CollectionSwitch)
 	r.GET("/personal/collection/page", a.questionController.PersonalCollectionPage)
 
 	// question
@@ -261,13 +481,18 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	r.PUT("/question/status", a.questionController.CloseQuestion)
 	r.PUT("/question/operation", a.questionController.OperationQuestion)
 	r.PUT("/question/reopen", a.questionController.ReopenQuestion)
+	r.POST("/question/close/vote", smallBody, a.questionCloseVoteController.CastCloseVote)
+	r.DELETE("/question/close/vote", smallBody, a.questionCloseVoteController.RevokeVote)
+	r.POST("/question/reopen/vote", smallBody, a.questionCloseVoteController.CastReopenVote)
 	r.GET("/question/similar", a.questionController.GetSimilarQuestions)
 	r.POST("/question/recover", a.questionController.QuestionRecover)
 
 	// answer
 	r.POST("/answer", a.answerController.AddAnswer)
 	r.PUT("/answer", a.answerController.UpdateAnswer)
+	r.PUT("/answer/provenance", a.answerController.UpdateAnswerProvenance)
 	r.POST("/answer/acceptance", a.answerController.AcceptAnswer)
+	r.PUT("/answer/pin", a.answerController.PinAnswer)
 	r.DELETE("/answer", a.answerController.RemoveAnswer)
 	r.POST("/answer/recover", a.answerController.RecoverAnswer)
 
@@ -277,11 +502,17 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	r.PUT("/user/interface", a.userController.UserUpdateInterface)
 	r.GET("/user/notification/config", a.userController.GetUserNotificationConfig)
 	r.PUT("/user/notification/config", a.userController.UpdateUserNotificationConfig)
+	r.GET("/user/notification/preferences", a.userController.GetUserNotificationPreferences)
+	r.PUT("/user/notification/preferences", a.userController.UpdateUserNotificationPreferences)
 	r.GET("/user/info/search", a.userController.SearchUserListByName)
 
 	// vote
 	r.GET("/personal/vote/page", a.voteController.UserVotes)
 
+	// rank
+	r.GET("/personal/rank/history", a.rankController.GetRankHistoryPage)
+	r.GET("/personal/rank/history/export", a.rankController.ExportRankHistory)
+
 	// reason
 	r.GET("/reasons", a.reasonController.Reasons)
 
@@ -294,9 +525,37 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	r.GET("/notification/page", a.notificationController.GetList)
 	r.PUT("/notification/read/state/all", a.notificationController.ClearUnRead)
 	r.PUT("/notification/read/state", a.notificationController.ClearIDUnRead)
+	r.GET("/notification/unread/count", a.notificationController.GetUnreadCount)
+	r.DELETE("/notification", a.notificationController.DeleteNotifications)
+	r.GET("/notification/mutes", a.userMuteController.GetMutes)
+	r.PUT("/notification/mute", a.userMuteController.Mute)
+
+	// personal access token
+	r.GET("/user/personal-access-tokens", a.personalAccessTokenController.GetPersonalAccessTokens)
+	r.POST("/user/personal-access-token", a.personalAccessTokenController.AddPersonalAccessToken)
+	r.DELETE("/user/personal-access-token", a.personalAccessTokenController.RemovePersonalAccessToken)
+
+	// oauth provider
+	r.GET("/oauth/authorize/info", a.oauthController.GetAuthorizeInfo)
+	r.POST("/oauth/authorize", a.oauthController.Authorize)
+
+	// user session
+	r.GET("/user/sessions", a.userSessionController.GetUserSessions)
+	r.DELETE("/user/session", a.userSessionController.RemoveUserSession)
+	r.DELETE("/user/sessions/others", a.userSessionController.RemoveOtherUserSessions)
+
+	// invite code
+	r.GET("/user/invite-codes", a.inviteCodeController.GetInviteCodes)
+	r.POST("/user/invite-code", a.inviteCodeController.AddInviteCode)
+	r.DELETE("/user/invite-code", a.inviteCodeController.RemoveInviteCode)
+	r.GET("/user/invite-code/users", a.inviteCodeController.GetInvitedUsers)
 
 	// upload file
 	r.POST("/file", a.uploadController.UploadFile)
+	r.POST("/file/chunked", a.uploadController.CreateChunkedUpload)
+	r.GET("/file/chunked/:upload_id", a.uploadController.GetChunkedUpload)
+	r.PATCH("/file/chunked/:upload_id", a.uploadController.UploadChunk)
+	r.DELETE("/file/chunked/:upload_id", a.uploadController.AbortChunkedUpload)
 	r.POST("/post/render", a.uploadController.PostRender)
 
 	// activity
@@ -317,15 +576,31 @@ func (a *AnswerAPIRouter) RegisterAnswerAdminAPIRouter(r *gin.RouterGroup) {
 	r.PUT("/question/status", a.questionController.AdminUpdateQuestionStatus)
 	r.GET("/answer/page", a.questionController.AdminAnswerPage)
 	r.PUT("/answer/status", a.answerController.AdminUpdateAnswerStatus)
+	r.POST("/answer/ranking/preview", a.answerController.AdminPreviewAnswerRanking)
+	r.DELETE("/comments", a.commentController.AdminBulkRemoveComment)
+
+	// audit log
+	r.GET("/audit-log/page", a.adminAuditLogController.GetAuditLogPage)
+
+	// export
+	r.GET("/question/export", a.adminExportController.ExportQuestions)
+	r.GET("/answer/export", a.adminExportController.ExportAnswers)
+	r.GET("/users/export", a.adminExportController.ExportUsers)
+	r.GET("/audit-log/export", a.adminExportController.ExportAuditLogs)
+	r.GET("/export/:job_id", a.adminExportController.GetExportJob)
+	r.GET("/export/:job_id/download", a.adminExportController.DownloadExport)
 
 	// user
 	r.GET("/users/page", a.adminUserController.GetUserPage)
 	r.PUT("/user/status", a.adminUserController.UpdateUserStatus)
+	r.GET("/user/sessions", a.adminUserSessionController.GetUserSessions)
+	r.PUT("/user/logout", a.adminUserSessionController.ForceLogoutUser)
 	r.PUT("/user/role", a.adminUserController.UpdateUserRole)
 	r.GET("/user/activation", a.adminUserController.GetUserActivation)
 	r.POST("/user/activation", a.adminUserController.SendUserActivation)
 	r.POST("/user", a.adminUserController.AddUser)
 	r.POST("/users", a.adminUserController.AddUsers)
+	r.POST("/users/import", a.adminUserImportController.ImportUsers)
 	r.PUT("/user/password", a.adminUserController.UpdateUserPassword)
 	r.PUT("/user/profile", a.adminUserController.EditUserProfile)
 
@@ -340,37 +615,76 @@ func (a *AnswerAPIRouter) RegisterAnswerAdminAPIRouter(r *gin.RouterGroup) {
 	// theme
 	r.GET("/theme/options", a.themeController.GetThemeOptions)
 
-	// siteinfo
-	r.GET("/siteinfo/general", a.adminSiteInfoController.GetGeneral)
-	r.PUT("/siteinfo/general", a.adminSiteInfoController.UpdateGeneral)
-	r.GET("/siteinfo/interface", a.adminSiteInfoController.GetInterface)
-	r.PUT("/siteinfo/interface", a.adminSiteInfoController.UpdateInterface)
-	r.GET("/siteinfo/branding", a.adminSiteInfoController.GetSiteBranding)
-	r.PUT("/siteinfo/branding", a.adminSiteInfoController.UpdateBranding)
-	r.GET("/siteinfo/write", a.adminSiteInfoController.GetSiteWrite)
-	r.PUT("/siteinfo/write", a.adminSiteInfoController.UpdateSiteWrite)
-	r.GET("/siteinfo/legal", a.adminSiteInfoController.GetSiteLegal)
-	r.PUT("/siteinfo/legal", a.adminSiteInfoController.UpdateSiteLegal)
-	r.GET("/siteinfo/seo", a.adminSiteInfoController.GetSeo)
-	r.PUT("/siteinfo/seo", a.adminSiteInfoController.UpdateSeo)
-	r.GET("/siteinfo/login", a.adminSiteInfoController.GetSiteLogin)
-	r.PUT("/siteinfo/login", a.adminSiteInfoController.UpdateSiteLogin)
-	r.GET("/siteinfo/custom-css-html", a.adminSiteInfoController.GetSiteCustomCssHTML)
-	r.PUT("/siteinfo/custom-css-html", a.adminSiteInfoController.UpdateSiteCustomCssHTML)
-	r.GET("/siteinfo/theme", a.adminSiteInfoController.GetSiteTheme)
-	r.PUT("/siteinfo/theme", a.adminSiteInfoController.SaveSiteTheme)
-	r.GET("/siteinfo/users", a.adminSiteInfoController.GetSiteUsers)
-	r.PUT("/siteinfo/users", a.adminSiteInfoController.UpdateSiteUsers)
-	r.GET("/setting/smtp", a.adminSiteInfoController.GetSMTPConfig)
-	r.PUT("/setting/smtp", a.adminSiteInfoController.UpdateSMTPConfig)
-	r.GET("/setting/privileges", a.adminSiteInfoController.GetPrivilegesConfig)
-	r.PUT("/setting/privileges", a.adminSiteInfoController.UpdatePrivilegesConfig)
+	// data dump
+	r.POST("/data-dump", a.dataDumpController.TriggerDataDump)
 
-	// dashboard
-	r.GET("/dashboard", a.dashboardController.DashboardInfo)
+	// reputation
+	r.POST("/reputation/recalculate", a.reputationController.RecalculateReputation)
+	r.GET("/reputation/recalculate", a.reputationController.GetRecalculateReputationProgress)
+
+	// vote fraud
+	r.GET("/vote-fraud/invalidations/page", a.voteFraudController.GetVoteInvalidationPage)
+	r.POST("/vote-fraud/user/votes/reverse", a.voteFraudController.ReverseUserVotes)
+
+	// auto promotion
+	r.GET("/auto-promotion/logs", a.autoPromotionController.GetAutoPromotionLogs)
+	r.POST("/auto-promotion/evaluate", a.autoPromotionController.EvaluateAutoPromotion)
+
+	// content freshness
+	r.POST("/content-freshness/analyze", a.contentFreshnessController.AnalyzeContentFreshness)
+
+	// bot
+	r.GET("/bots/page", a.botController.GetBotPage)
+	r.POST("/bot", a.botController.AddBot)
+	r.PUT("/bot/token", a.botController.RegenerateBotToken)
+
+	// webhook
+	r.GET("/webhook/deliveries/page", a.webhookController.GetWebhookDeliveryPage)
+	r.POST("/webhook/delivery/redeliver", a.webhookController.RedeliverWebhook)
+
+	// permission
+	r.GET("/permission/explain", a.adminPermissionController.ExplainPermission)
+
+	// messenger
+	r.POST("/messenger/connector/test", a.messengerController.TestMessengerConnector)
+
+	// email template
+	r.GET("/email-templates", a.emailTemplateController.GetEmailTemplates)
+	r.GET("/email-template", a.emailTemplateController.GetEmailTemplate)
+	r.PUT("/email-template", a.emailTemplateController.SaveEmailTemplate)
+	r.DELETE("/email-template", a.emailTemplateController.DeleteEmailTemplate)
+
+	// oauth client
+	r.GET("/oauth-clients", a.oauthClientController.GetOAuthClients)
+	r.POST("/oauth-client", a.oauthClientController.AddOAuthClient)
+	r.PUT("/oauth-client", a.oauthClientController.UpdateOAuthClient)
+	r.PUT("/oauth-client/secret", a.oauthClientController.RegenerateOAuthClientSecret)
+	r.DELETE("/oauth-client", a.oauthClientController.RemoveOAuthClient)
+
+	// invite code
+	r.GET("/invite-codes/page", a.adminInviteCodeController.GetInviteCodePage)
+	r.POST("/invite-code", a.adminInviteCodeController.AddInviteCode)
+	r.DELETE("/invite-code", a.adminInviteCodeController.RemoveInviteCode)
+	r.GET("/invite-code/users", a.adminInviteCodeController.GetInvitedUsers)
+
+	// ip ban
+	r.GET("/ip-bans/page", a.adminIPBanController.GetIPBanPage)
+	r.POST("/ip-ban", a.adminIPBanController.AddIPBan)
+	r.DELETE("/ip-ban", a.adminIPBanController.RemoveIPBan)
+
+	// user timeline
+	r.GET("/user/timeline", a.userTimelineController.GetUserTimeline)
+
+	// slow queries
+	r.DELETE("/slow-queries", a.queryStatsController.ResetSlowQueryReport)
 
 	// roles
 	r.GET("/roles", a.roleController.GetRoleList)
+	r.GET("/powers", a.roleController.GetPowerList)
+	r.GET("/role/powers", a.roleController.GetRolePowers)
+	r.POST("/role", a.roleController.AddRole)
+	r.PUT("/role", a.roleController.UpdateRole)
+	r.DELETE("/role", a.roleController.RemoveRole)
 
 	// plugin
 	r.GET("/plugins", a.pluginController.GetPluginList)
@@ -378,7 +692,138 @@ func (a *AnswerAPIRouter) RegisterAnswerAdminAPIRouter(r *gin.RouterGroup) {
 	r.GET("/plugin/config", a.pluginController.GetPluginConfig)
 	r.PUT("/plugin/config", a.pluginController.UpdatePluginConfig)
 
+	// the site settings with secrets or crossing the trust boundary, they are never delegated to the custom roles
+	r.GET("/siteinfo/custom-css-html", a.adminSiteInfoController.GetSiteCustomCssHTML)
+	r.PUT("/siteinfo/custom-css-html", a.adminSiteInfoController.UpdateSiteCustomCssHTML)
+	r.GET("/siteinfo/reputation-sync", a.adminSiteInfoController.GetSiteReputationSync)
+	r.PUT("/siteinfo/reputation-sync", a.adminSiteInfoController.UpdateSiteReputationSync)
+	r.GET("/siteinfo/chat-intake", a.adminSiteInfoController.GetSiteChatIntake)
+	r.PUT("/siteinfo/chat-intake", a.adminSiteInfoController.UpdateSiteChatIntake)
+	r.GET("/siteinfo/syndication", a.adminSiteInfoController.GetSiteSyndication)
+	r.PUT("/siteinfo/syndication", a.adminSiteInfoController.UpdateSiteSyndication)
+	r.GET("/siteinfo/webhook", a.adminSiteInfoController.GetSiteWebhook)
+	r.PUT("/siteinfo/webhook", a.adminSiteInfoController.UpdateSiteWebhook)
+	r.GET("/siteinfo/slack", a.adminSiteInfoController.GetSiteSlack)
+	r.PUT("/siteinfo/slack", a.adminSiteInfoController.UpdateSiteSlack)
+	r.GET("/siteinfo/jira", a.adminSiteInfoController.GetSiteJira)
+	r.PUT("/siteinfo/jira", a.adminSiteInfoController.UpdateSiteJira)
+	r.GET("/siteinfo/spaces", a.adminSiteInfoController.GetSiteSpaces)
+	r.PUT("/siteinfo/spaces", a.adminSiteInfoController.UpdateSiteSpaces)
+	r.GET("/siteinfo/messenger", a.adminSiteInfoController.GetSiteMessenger)
+	r.PUT("/siteinfo/messenger", a.adminSiteInfoController.UpdateSiteMessenger)
+	r.GET("/siteinfo/web-push", a.adminSiteInfoController.GetSiteWebPush)
+	r.PUT("/siteinfo/web-push", a.adminSiteInfoController.UpdateSiteWebPush)
+	r.GET("/siteinfo/inbound-email", a.adminSiteInfoController.GetSiteInboundEmail)
+	r.PUT("/siteinfo/inbound-email", a.adminSiteInfoController.UpdateSiteInboundEmail)
+	r.GET("/siteinfo/scim", a.adminSiteInfoController.GetSiteSCIM)
+	r.PUT("/siteinfo/scim", a.adminSiteInfoController.UpdateSiteSCIM)
+	r.GET("/siteinfo/oauth-provider", a.adminSiteInfoController.GetSiteOAuthProvider)
+	r.PUT("/siteinfo/oauth-provider", a.adminSiteInfoController.UpdateSiteOAuthProvider)
+	r.GET("/siteinfo/versions", a.adminSiteInfoController.GetSiteInfoVersions)
+	r.GET("/siteinfo/history/page", a.adminSiteInfoController.GetSiteInfoHistoryPage)
+	r.PUT("/siteinfo/history/revert", a.adminSiteInfoController.RevertSiteInfo)
+	r.GET("/setting/smtp", a.adminSiteInfoController.GetSMTPConfig)
+	r.PUT("/setting/smtp", a.adminSiteInfoController.UpdateSMTPConfig)
+
 	// badge
 	r.GET("/badges", a.adminBadgeController.GetBadgeList)
 	r.PUT("/badge/status", a.adminBadgeController.UpdateBadgeStatus)
+	r.POST("/badge/tag/backfill", a.adminBadgeController.BackfillTagBadges)
+}
+
+// RegisterAnswerAdminPowerAPIRouter register the admin api that can be accessed by the role with the corresponding power
+func (a *AnswerAPIRouter) RegisterAnswerAdminPowerAPIRouter(authUserMiddleware *middleware.AuthUserMiddleware, r *gin.RouterGroup) {
+	// manage tags
+	tagRouter := r.Group("", authUserMiddleware.PowerAuth(permission.TagManage), middleware.ExtractExpectedVersion)
+	tagRouter.POST("/tag/synonym", a.tagController.AdminAddTagSynonym)
+	tagRouter.DELETE("/tag/synonym", a.tagController.AdminRemoveTagSynonym)
+	tagRouter.POST("/tag/merge", a.tagController.AdminMergeTag)
+	tagRouter.GET("/tag/translations", a.tagController.AdminGetTagTranslations)
+	tagRouter.PUT("/tag/translation", a.tagController.AdminSaveTagTranslation)
+	tagRouter.DELETE("/tag/translation", a.tagController.AdminRemoveTagTranslation)
+	tagRouter.POST("/tag/moderator", a.tagModeratorController.AdminAddTagModerator)
+	tagRouter.DELETE("/tag/moderator", a.tagModeratorController.AdminRemoveTagModerator)
+
+	// view reports
+	reportRouter := r.Group("", authUserMiddleware.PowerAuth(permission.ReportView), middleware.ExtractExpectedVersion)
+	reportRouter.GET("/tag/analytics", a.tagAnalyticsController.AdminGetTagAnalytics)
+	reportRouter.GET("/report/appeal/stats", a.reportAppealController.GetAppealStats)
+	reportRouter.GET("/content-freshness/report", a.contentFreshnessController.GetContentFreshnessReport)
+	reportRouter.GET("/content-language/stats", a.contentLanguageController.GetLanguageStats)
+	reportRouter.GET("/load-shedding/metrics", a.loadSheddingController.GetLoadSheddingMetrics)
+	reportRouter.GET("/slow-queries", a.queryStatsController.GetSlowQueryReport)
+	reportRouter.GET("/dashboard", a.dashboardController.DashboardInfo)
+
+	// edit site settings
+	settingRouter := r.Group("", authUserMiddleware.PowerAuth(permission.SiteSettingEdit), middleware.ExtractExpectedVersion)
+	settingRouter.GET("/siteinfo/general", a.adminSiteInfoController.GetGeneral)
+	settingRouter.PUT("/siteinfo/general", a.adminSiteInfoController.UpdateGeneral)
+	settingRouter.GET("/siteinfo/interface", a.adminSiteInfoController.GetInterface)
+	settingRouter.PUT("/siteinfo/interface", a.adminSiteInfoController.UpdateInterface)
+	settingRouter.GET("/siteinfo/branding", a.adminSiteInfoController.GetSiteBranding)
+	settingRouter.PUT("/siteinfo/branding", a.adminSiteInfoController.UpdateBranding)
+	settingRouter.GET("/siteinfo/write", a.adminSiteInfoController.GetSiteWrite)
+	settingRouter.PUT("/siteinfo/write", a.adminSiteInfoController.UpdateSiteWrite)
+	settingRouter.GET("/siteinfo/legal", a.adminSiteInfoController.GetSiteLegal)
+	settingRouter.PUT("/siteinfo/legal", a.adminSiteInfoController.UpdateSiteLegal)
+	settingRouter.GET("/siteinfo/seo", a.adminSiteInfoController.GetSeo)
+	settingRouter.PUT("/siteinfo/seo", a.adminSiteInfoController.UpdateSeo)
+	settingRouter.GET("/siteinfo/login", a.adminSiteInfoController.GetSiteLogin)
+	settingRouter.PUT("/siteinfo/login", a.adminSiteInfoController.UpdateSiteLogin)
+	settingRouter.GET("/siteinfo/theme", a.adminSiteInfoController.GetSiteTheme)
+	settingRouter.PUT("/siteinfo/theme", a.adminSiteInfoController.SaveSiteTheme)
+	settingRouter.GET("/siteinfo/users", a.adminSiteInfoController.GetSiteUsers)
+	settingRouter.PUT("/siteinfo/users", a.adminSiteInfoController.UpdateSiteUsers)
+	settingRouter.GET("/siteinfo/limits", a.adminSiteInfoController.GetSiteLimits)
+	settingRouter.PUT("/siteinfo/limits", a.adminSiteInfoController.UpdateSiteLimits)
+	settingRouter.GET("/siteinfo/question-close", a.adminSiteInfoController.GetSiteQuestionClose)
+	settingRouter.PUT("/siteinfo/question-close", a.adminSiteInfoController.UpdateSiteQuestionClose)
+	settingRouter.GET("/siteinfo/rate-limit", a.adminSiteInfoController.GetSiteRateLimit)
+	settingRouter.PUT("/siteinfo/rate-limit", a.adminSiteInfoController.UpdateSiteRateLimit)
+	settingRouter.GET("/siteinfo/data-dump", a.adminSiteInfoController.GetSiteDataDump)
+	settingRouter.PUT("/siteinfo/data-dump", a.adminSiteInfoController.UpdateSiteDataDump)
+	settingRouter.GET("/siteinfo/vote-fraud", a.adminSiteInfoController.GetSiteVoteFraud)
+	settingRouter.PUT("/siteinfo/vote-fraud", a.adminSiteInfoController.UpdateSiteVoteFraud)
+	settingRouter.GET("/siteinfo/answer-ranking", a.adminSiteInfoController.GetSiteAnswerRanking)
+	settingRouter.PUT("/siteinfo/answer-ranking", a.adminSiteInfoController.UpdateSiteAnswerRanking)
+	settingRouter.GET("/siteinfo/language-detection", a.adminSiteInfoController.GetSiteLanguageDetection)
+	settingRouter.PUT("/siteinfo/language-detection", a.adminSiteInfoController.UpdateSiteLanguageDetection)
+	settingRouter.GET("/siteinfo/downvote-cost", a.adminSiteInfoController.GetSiteDownvoteCost)
+	settingRouter.PUT("/siteinfo/downvote-cost", a.adminSiteInfoController.UpdateSiteDownvoteCost)
+	settingRouter.GET("/siteinfo/helpfulness-survey", a.adminSiteInfoController.GetSiteHelpfulnessSurvey)
+	settingRouter.PUT("/siteinfo/helpfulness-survey", a.adminSiteInfoController.UpdateSiteHelpfulnessSurvey)
+	settingRouter.GET("/siteinfo/re-engagement", a.adminSiteInfoController.GetSiteReEngagement)
+	settingRouter.PUT("/siteinfo/re-engagement", a.adminSiteInfoController.UpdateSiteReEngagement)
+	settingRouter.GET("/siteinfo/question-quality", a.adminSiteInfoController.GetSiteQuestionQuality)
+	settingRouter.PUT("/siteinfo/question-quality", a.adminSiteInfoController.UpdateSiteQuestionQuality)
+	settingRouter.GET("/siteinfo/tag-rules", a.adminSiteInfoController.GetSiteTagRules)
+	settingRouter.PUT("/siteinfo/tag-rules", a.adminSiteInfoController.UpdateSiteTagRules)
+	settingRouter.GET("/siteinfo/vote-milestone", a.adminSiteInfoController.GetSiteVoteMilestone)
+	settingRouter.PUT("/siteinfo/vote-milestone", a.adminSiteInfoController.UpdateSiteVoteMilestone)
+	settingRouter.GET("/siteinfo/auto-comment", a.adminSiteInfoController.GetSiteAutoComment)
+	settingRouter.PUT("/siteinfo/auto-comment", a.adminSiteInfoController.UpdateSiteAutoComment)
+	settingRouter.GET("/siteinfo/tag-blocklist", a.adminSiteInfoController.GetSiteTagBlocklist)
+	settingRouter.PUT("/siteinfo/tag-blocklist", a.adminSiteInfoController.UpdateSiteTagBlocklist)
+	settingRouter.GET("/siteinfo/load-shedding", a.adminSiteInfoController.GetSiteLoadShedding)
+	settingRouter.PUT("/siteinfo/load-shedding", a.adminSiteInfoController.UpdateSiteLoadShedding)
+	settingRouter.GET("/siteinfo/auto-promotion", a.adminSiteInfoController.GetSiteAutoPromotion)
+	settingRouter.PUT("/siteinfo/auto-promotion", a.adminSiteInfoController.UpdateSiteAutoPromotion)
+	settingRouter.GET("/siteinfo/content-freshness", a.adminSiteInfoController.GetSiteContentFreshness)
+	settingRouter.PUT("/siteinfo/content-freshness", a.adminSiteInfoController.UpdateSiteContentFreshness)
+	settingRouter.GET("/siteinfo/question-migration", a.adminSiteInfoController.GetSiteQuestionMigration)
+	settingRouter.PUT("/siteinfo/question-migration", a.adminSiteInfoController.UpdateSiteQuestionMigration)
+	settingRouter.GET("/siteinfo/cache-warming", a.adminSiteInfoController.GetSiteCacheWarming)
+	settingRouter.PUT("/siteinfo/cache-warming", a.adminSiteInfoController.UpdateSiteCacheWarming)
+	settingRouter.GET("/siteinfo/digest", a.adminSiteInfoController.GetSiteDigest)
+	settingRouter.PUT("/siteinfo/digest", a.adminSiteInfoController.UpdateSiteDigest)
+	settingRouter.GET("/siteinfo/notification-retention", a.adminSiteInfoController.GetSiteNotificationRetention)
+	settingRouter.PUT("/siteinfo/notification-retention", a.adminSiteInfoController.UpdateSiteNotificationRetention)
+	settingRouter.GET("/siteinfo/login-protection", a.adminSiteInfoController.GetSiteLoginProtection)
+	settingRouter.PUT("/siteinfo/login-protection", a.adminSiteInfoController.UpdateSiteLoginProtection)
+	settingRouter.GET("/siteinfo/invite", a.adminSiteInfoController.GetSiteInvite)
+	settingRouter.PUT("/siteinfo/invite", a.adminSiteInfoController.UpdateSiteInvite)
+	settingRouter.GET("/setting/privileges", a.adminSiteInfoController.GetPrivilegesConfig)
+	settingRouter.PUT("/setting/privileges", a.adminSiteInfoController.UpdatePrivilegesConfig)
+	settingRouter.GET("/setting/reputation", a.adminSiteInfoController.GetReputationRules)
+	settingRouter.PUT("/setting/reputation", a.adminSiteInfoController.UpdateReputationRules)
 }
@@ -0,0 +1,87 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package router
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/entity"
+	authRepo "github.com/apache/answer/internal/repo/auth"
+	"github.com/apache/answer/internal/service/auth"
+	"github.com/apache/answer/internal/service/permission"
+	"github.com/apache/answer/internal/service/role"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/contrib/cache/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// settingEditRolePowerRelRepo the custom role only has the power to edit the site settings
+type settingEditRolePowerRelRepo struct{}
+
+func (settingEditRolePowerRelRepo) GetRolePowerTypeList(_ context.Context, roleID int) ([]string, error) {
+	if roleID == 4 {
+		return []string{permission.SiteSettingEdit}, nil
+	}
+	return nil, nil
+}
+
+func TestRegisterAnswerAdminPowerAPIRouter_SensitiveSettings(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	dataData := &data.Data{Cache: memory.NewCache()}
+	repo := authRepo.NewAuthRepo(dataData)
+	require.NoError(t, repo.SetUserCacheInfo(ctx, "setting-editor", "visit", &entity.UserCacheInfo{
+		UserID:      "10",
+		RoleID:      4,
+		UserStatus:  entity.UserStatusAvailable,
+		EmailStatus: entity.EmailStatusAvailable,
+	}))
+	authUserMiddleware := middleware.NewAuthUserMiddleware(auth.NewAuthService(repo, nil, nil), nil,
+		role.NewRolePowerRelService(settingEditRolePowerRelRepo{}, nil))
+
+	a := &AnswerAPIRouter{}
+	r := gin.New()
+	r.Use(gin.Recovery())
+	adminAPI := r.Group("/answer/admin/api")
+	adminAPI.Use(authUserMiddleware.AdminAuth())
+	a.RegisterAnswerAdminAPIRouter(adminAPI)
+	a.RegisterAnswerAdminPowerAPIRouter(authUserMiddleware, r.Group("/answer/admin/api"))
+
+	status := func(method, path string) int {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "setting-editor")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+	for _, path := range []string{"/siteinfo/scim", "/siteinfo/oauth-provider", "/setting/smtp",
+		"/siteinfo/custom-css-html", "/siteinfo/spaces", "/siteinfo/webhook"} {
+		assert.Equal(t, http.StatusForbidden, status(http.MethodGet, "/answer/admin/api"+path), path)
+		assert.Equal(t, http.StatusForbidden, status(http.MethodPut, "/answer/admin/api"+path), path)
+	}
+	// the delegated settings pass the auth, the controller is not set up so it is recovered as 500
+	assert.NotEqual(t, http.StatusForbidden, status(http.MethodGet, "/answer/admin/api/siteinfo/general"))
+}
@@ -110,3 +110,70 @@ type PassReviewActivity struct {
 	OriginalObjectID string `json:"original_object_id"`
 	RevisionID       string `json:"revision_id"`
 }
+
+const (
+	QuestionTimelineCategoryPost     = "post"
+	QuestionTimelineCategoryRevision = "revision"
+	QuestionTimelineCategoryClose    = "close"
+	QuestionTimelineCategoryStatus   = "status"
+)
+
+// QuestionTimelineActivities the activities shown in the question timeline and their categories
+var QuestionTimelineActivities = map[constant.ActivityTypeKey]string{
+	constant.ActQuestionAsked:     QuestionTimelineCategoryPost,
+	constant.ActAnswerAnswered:    QuestionTimelineCategoryPost,
+	constant.ActQuestionEdited:    QuestionTimelineCategoryRevision,
+	constant.ActQuestionRollback:  QuestionTimelineCategoryRevision,
+	constant.ActAnswerEdited:      QuestionTimelineCategoryRevision,
+	constant.ActAnswerRollback:    QuestionTimelineCategoryRevision,
+	constant.ActQuestionClosed:    QuestionTimelineCategoryClose,
+	constant.ActQuestionReopened:  QuestionTimelineCategoryClose,
+	constant.ActQuestionDeleted:   QuestionTimelineCategoryStatus,
+	constant.ActQuestionUndeleted: QuestionTimelineCategoryStatus,
+	constant.ActQuestionPin:       QuestionTimelineCategoryStatus,
+	constant.ActQuestionUnPin:     QuestionTimelineCategoryStatus,
+	constant.ActQuestionHide:      QuestionTimelineCategoryStatus,
+	constant.ActQuestionShow:      QuestionTimelineCategoryStatus,
+	constant.ActAnswerAccept:      QuestionTimelineCategoryStatus,
+	constant.ActAnswerDeleted:     QuestionTimelineCategoryStatus,
+	constant.ActAnswerUndeleted:   QuestionTimelineCategoryStatus,
+}
+
+// GetQuestionTimelineReq get question timeline request
+type GetQuestionTimelineReq struct {
+	QuestionID       string `validate:"required,gt=0,lte=30" form:"question_id"`
+	UserID           string `json:"-"`
+	IsAdminModerator bool   `json:"-"`
+}
+
+// GetQuestionTimelineResp get question timeline response
+type GetQuestionTimelineResp struct {
+	QuestionID string                   `json:"question_id"`
+	Title      string                   `json:"title"`
+	Timeline   []*QuestionTimelineEvent `json:"timeline"`
+}
+
+// QuestionTimelineEvent the event of the question or its answers in question timeline
+type QuestionTimelineEvent struct {
+	ActivityID string `json:"activity_id"`
+	// the activity type key, such as question.closed or answer.edited
+	ActivityType string `json:"activity_type"`
+	Category     string `json:"category"`
+	// the localized description of the event
+	Description string `json:"description"`
+	// the edit summary of revision or the close reason
+	Comment    string `json:"comment"`
+	RevisionID string `json:"revision_id"`
+	ObjectID   string `json:"object_id"`
+	ObjectType string `json:"object_type"`
+	// the event is performed by an admin or moderator on the post of another user
+	ModeratorAction bool           `json:"moderator_action"`
+	Cancelled       bool           `json:"cancelled"`
+	CreatedAt       int64          `json:"created_at"`
+	UserInfo        *UserBasicInfo `json:"user_info"`
+}
+
+// QuestionTimelineTplData the template data of question timeline event description
+type QuestionTimelineTplData struct {
+	User string
+}
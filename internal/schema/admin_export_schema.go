@@ -0,0 +1,177 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+)
+
+const (
+	AdminExportTypeQuestion = "question"
+	AdminExportTypeAnswer   = "answer"
+	AdminExportTypeUser     = "user"
+	AdminExportTypeAuditLog = "audit_log"
+
+	AdminExportStatusPending = "pending"
+	AdminExportStatusDone    = "done"
+	AdminExportStatusFailed  = "failed"
+
+	AdminExportJobCacheKeyPrefix = "answer:admin-export:"
+	AdminExportJobCacheTime      = 24 * time.Hour
+)
+
+// AdminQuestionExportReq export the filtered admin question list
+type AdminQuestionExportReq struct {
+	AdminQuestionPageReq
+	// generate the csv file in background even if the list is small
+	Async bool `validate:"omitempty" form:"async"`
+}
+
+// AdminAnswerExportReq export the filtered admin answer list
+type AdminAnswerExportReq struct {
+	AdminAnswerPageReq
+	// generate the csv file in background even if the list is small
+	Async bool `validate:"omitempty" form:"async"`
+}
+
+// AdminUserExportReq export the filtered admin user list
+type AdminUserExportReq struct {
+	GetUserPageReq
+	// generate the csv file in background even if the list is small
+	Async bool `validate:"omitempty" form:"async"`
+}
+
+// GetAdminExportJobReq get admin export job request
+type GetAdminExportJobReq struct {
+	JobID string `validate:"required,gt=0,lte=64" uri:"job_id" form:"job_id"`
+}
+
+// AdminExportJob the csv export job running in background
+type AdminExportJob struct {
+	JobID       string `json:"job_id"`
+	Type        string `json:"type"`
+	Status      string `json:"status"`
+	RowCount    int64  `json:"row_count"`
+	FileName    string `json:"file_name"`
+	DownloadURL string `json:"download_url"`
+	CreatedAt   int64  `json:"created_at"`
+	FinishedAt  int64  `json:"finished_at"`
+}
+
+// AdminQuestionCSVHeader the header of exported admin question list
+var AdminQuestionCSVHeader = []string{
+	"id", "title", "author", "vote_count", "answer_count", "accepted_answer_id", "pinned", "created_at", "updated_at",
+}
+
+// AdminAnswerCSVHeader the header of exported admin answer list
+var AdminAnswerCSVHeader = []string{
+	"id", "question_id", "question_title", "author", "vote_count", "accepted", "created_at", "updated_at",
+	"source_url", "import_batch", "ai_assisted",
+}
+
+// AdminUserCSVHeader the header of exported admin user list
+var AdminUserCSVHeader = []string{
+	"user_id", "username", "display_name", "email", "rank", "status", "role", "created_at",
+}
+
+// CSVRecord format the admin question as a csv record
+func (q *AdminQuestionInfo) CSVRecord() []string {
+	acceptedAnswerID := q.AcceptedAnswerID
+	if acceptedAnswerID == "0" {
+		acceptedAnswerID = ""
+	}
+	return csvSafeRecord(
+		q.ID,
+		q.Title,
+		csvUsername(q.UserInfo),
+		strconv.Itoa(q.VoteCount),
+		strconv.Itoa(q.AnswerCount),
+		acceptedAnswerID,
+		strconv.FormatBool(q.Pin == entity.QuestionPin),
+		csvTime(q.CreateTime),
+		csvTime(q.UpdateTime),
+	)
+}
+
+// CSVRecord format the admin answer as a csv record
+func (a *AdminAnswerInfo) CSVRecord() []string {
+	provenance := a.Provenance
+	if provenance == nil {
+		provenance = &AnswerProvenance{}
+	}
+	return csvSafeRecord(
+		a.ID,
+		a.QuestionID,
+		a.QuestionInfo.Title,
+		csvUsername(a.UserInfo),
+		strconv.Itoa(a.VoteCount),
+		strconv.FormatBool(a.Accepted == AnswerAcceptedEnable),
+		csvTime(a.CreateTime),
+		csvTime(a.UpdateTime),
+		provenance.SourceURL,
+		provenance.ImportBatch,
+		strconv.FormatBool(provenance.AIAssisted),
+	)
+}
+
+// CSVRecord format the admin user as a csv record
+func (u *GetUserPageResp) CSVRecord() []string {
+	return csvSafeRecord(
+		u.UserID,
+		u.Username,
+		u.DisplayName,
+		u.EMail,
+		strconv.Itoa(u.Rank),
+		u.Status,
+		u.RoleName,
+		csvTime(u.CreatedAt),
+	)
+}
+
+func csvUsername(userInfo *UserBasicInfo) string {
+	if userInfo == nil {
+		return ""
+	}
+	return userInfo.Username
+}
+
+func csvTime(t int64) string {
+	if t <= 0 {
+		return ""
+	}
+	return time.Unix(t, 0).UTC().Format(time.RFC3339)
+}
+
+// csvSafeRecord escape the text cells which could be interpreted as formulas by spreadsheet applications
+func csvSafeRecord(cells ...string) []string {
+	for i, cell := range cells {
+		if _, err := strconv.Atoi(cell); err == nil {
+			continue
+		}
+		if len(cell) > 0 && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+			cells[i] = "'" + cell
+		}
+	}
+	return cells
+}
@@ -0,0 +1,57 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminQuestionInfo_CSVRecord(t *testing.T) {
+	record := (&AdminQuestionInfo{
+		ID:               "10010000000000001",
+		Title:            "=HYPERLINK(\"http://example.com\")",
+		AcceptedAnswerID: "0",
+		CreateTime:       1700000000,
+		UserInfo:         &UserBasicInfo{Username: "alice"},
+	}).CSVRecord()
+	assert.Len(t, record, len(AdminQuestionCSVHeader))
+	assert.Equal(t, "'=HYPERLINK(\"http://example.com\")", record[1])
+	assert.Equal(t, "alice", record[2])
+	assert.Equal(t, "", record[5])
+	assert.Equal(t, "2023-11-14T22:13:20Z", record[7])
+	assert.Equal(t, "", record[8])
+}
+
+func TestAdminAnswerInfo_CSVRecord(t *testing.T) {
+	record := (&AdminAnswerInfo{ID: "1", Accepted: AnswerAcceptedEnable}).CSVRecord()
+	assert.Len(t, record, len(AdminAnswerCSVHeader))
+	assert.Equal(t, "", record[3])
+	assert.Equal(t, "true", record[5])
+	assert.Equal(t, "false", record[10])
+}
+
+func TestGetUserPageResp_CSVRecord(t *testing.T) {
+	record := (&GetUserPageResp{UserID: "1", Username: "bob", DisplayName: "-bob", Rank: -1}).CSVRecord()
+	assert.Len(t, record, len(AdminUserCSVHeader))
+	assert.Equal(t, "'-bob", record[2])
+	assert.Equal(t, "-1", record[4])
+}
@@ -20,8 +20,12 @@
 package schema
 
 import (
+	"strings"
+	"time"
+
 	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/base/validator"
+	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/pkg/converter"
 	"github.com/segmentfault/pacman/errors"
 )
@@ -46,18 +50,60 @@ const (
 	AnswerAcceptedEnable = 2
 )
 
+const (
+	// AnswerProvenanceAIAssisted the answer is written with the help of AI
+	AnswerProvenanceAIAssisted = "ai_assisted"
+	// AnswerProvenanceImported the answer is imported in a batch
+	AnswerProvenanceImported = "imported"
+	// AnswerProvenanceSourced the answer has the original source url
+	AnswerProvenanceSourced = "sourced"
+)
+
+// AnswerProvenance the provenance metadata of the answer
+type AnswerProvenance struct {
+	// the original source url of the imported content
+	SourceURL string `validate:"omitempty,url,lte=512" json:"source_url"`
+	// the batch of the import, only set by the importers and admins
+	ImportBatch string `validate:"omitempty,lte=64" json:"import_batch"`
+	AIAssisted  bool   `json:"ai_assisted"`
+}
+
+// NewAnswerProvenance get the provenance of answer, nil if the answer has no provenance
+func NewAnswerProvenance(answer *entity.Answer) *AnswerProvenance {
+	if len(answer.SourceURL) == 0 && len(answer.ImportBatch) == 0 && !answer.AIAssisted {
+		return nil
+	}
+	return &AnswerProvenance{
+		SourceURL:   answer.SourceURL,
+		ImportBatch: answer.ImportBatch,
+		AIAssisted:  answer.AIAssisted,
+	}
+}
+
+// Apply set the provenance to the answer
+func (p *AnswerProvenance) Apply(answer *entity.Answer) {
+	if p == nil {
+		answer.SourceURL, answer.ImportBatch, answer.AIAssisted = "", "", false
+		return
+	}
+	answer.SourceURL = p.SourceURL
+	answer.ImportBatch = p.ImportBatch
+	answer.AIAssisted = p.AIAssisted
+}
+
 type AnswerAddReq struct {
-	QuestionID  string `json:"question_id"`
-	Content     string `validate:"required,notblank,gte=6,lte=65535" json:"content"`
-	HTML        string `json:"-"`
-	UserID      string `json:"-"`
-	CanEdit     bool   `json:"-"`
-	CanDelete   bool   `json:"-"`
-	CanRecover  bool   `json:"-"`
-	CaptchaID   string `json:"captcha_id"`
-	CaptchaCode string `json:"captcha_code"`
-	IP          string `json:"-"`
-	UserAgent   string `json:"-"`
+	QuestionID  string            `json:"question_id"`
+	Content     string            `validate:"required,notblank,gte=6" limit:"content" json:"content"`
+	Provenance  *AnswerProvenance `validate:"omitempty" json:"provenance"`
+	HTML        string            `json:"-"`
+	UserID      string            `json:"-"`
+	CanEdit     bool              `json:"-"`
+	CanDelete   bool              `json:"-"`
+	CanRecover  bool              `json:"-"`
+	CaptchaID   string            `json:"captcha_id"`
+	CaptchaCode string            `json:"captcha_code"`
+	IP          string            `json:"-"`
+	UserAgent   string            `json:"-"`
 }
 
 func (req *AnswerAddReq) Check() (errFields []*validator.FormErrorField, err error) {
@@ -80,7 +126,7 @@ type AnswerUpdateReq struct {
 	ID           string `json:"id"`
 	QuestionID   string `json:"question_id"`
 	Title        string `json:"title"`
-	Content      string `validate:"required,notblank,gte=6,lte=65535" json:"content"`
+	Content      string `validate:"required,notblank,gte=6" limit:"content" json:"content"`
 	EditSummary  string `validate:"omitempty" json:"edit_summary"`
 	HTML         string `json:"-"`
 	UserID       string `json:"-"`
@@ -108,24 +154,28 @@ type AnswerUpdateResp struct {
 
 type AnswerListReq struct {
 	QuestionID string `json:"question_id" form:"question_id"`
-	Order      string `json:"order" form:"order"`
-	Page       int    `json:"page" form:"page"`
-	PageSize   int    `json:"page_size" form:"page_size"`
-	UserID     string `json:"-"`
-	IsAdmin    bool   `json:"-"`
-	CanEdit    bool   `json:"-"`
-	CanDelete  bool   `json:"-"`
-	CanRecover bool   `json:"-"`
+	// the answer pinned on top of question, set by service
+	PinnedAnswerID string `json:"-"`
+	Order          string `json:"order" form:"order"`
+	Page           int    `json:"page" form:"page"`
+	PageSize       int    `json:"page_size" form:"page_size"`
+	UserID         string `json:"-"`
+	IsAdmin        bool   `json:"-"`
+	CanEdit        bool   `json:"-"`
+	CanDelete      bool   `json:"-"`
+	CanRecover     bool   `json:"-"`
 }
 
 type AnswerInfo struct {
-	ID             string            `json:"id"`
-	QuestionID     string            `json:"question_id"`
-	Content        string            `json:"content"`
-	HTML           string            `json:"html"`
-	CreateTime     int64             `json:"create_time"`
-	UpdateTime     int64             `json:"update_time"`
-	Accepted       int               `json:"accepted"`
+	ID         string `json:"id"`
+	QuestionID string `json:"question_id"`
+	Content    string `json:"content"`
+	HTML       string `json:"html"`
+	CreateTime int64  `json:"create_time"`
+	UpdateTime int64  `json:"update_time"`
+	Accepted   int    `json:"accepted"`
+	// pinned to the top by the question author or moderators
+	Pinned         bool              `json:"pinned"`
 	UserID         string            `json:"-"`
 	UpdateUserID   string            `json:"-"`
 	UserInfo       *UserBasicInfo    `json:"user_info,omitempty"`
@@ -135,11 +185,58 @@ type AnswerInfo struct {
 	VoteCount      int               `json:"vote_count"`
 	QuestionInfo   *QuestionInfoResp `json:"question_info,omitempty"`
 	Status         int               `json:"status"`
+	Provenance     *AnswerProvenance `json:"provenance,omitempty"`
 
 	// MemberActions
 	MemberActions []*PermissionMemberAction `json:"member_actions"`
 }
 
+// AnswerRankingMaxAnswers the max number of answers sorted by weighted score, the others are not shown in default order
+const AnswerRankingMaxAnswers = 1000
+
+// AnswerRankingFactor the factors of answer used to calculate the weighted score
+type AnswerRankingFactor struct {
+	AnswerID   string
+	VoteCount  int
+	Accepted   bool
+	CreatedAt  time.Time
+	AuthorRank int
+	Verified   bool
+	// the solved rate and the number of responses of helpfulness survey
+	SolvedRate      float64
+	SurveyResponses int
+}
+
+// AnswerRankingScore the weighted score of answer and the score of each factor
+type AnswerRankingScore struct {
+	AnswerID         string  `json:"answer_id"`
+	Score            float64 `json:"score"`
+	Votes            float64 `json:"votes"`
+	Accepted         float64 `json:"accepted"`
+	Recency          float64 `json:"recency"`
+	AuthorReputation float64 `json:"author_reputation"`
+	Verified         float64 `json:"verified"`
+	SolvedRate       float64 `json:"solved_rate"`
+}
+
+// AdminPreviewAnswerRankingReq preview the answer order of question with the weights, the weights are not saved
+type AdminPreviewAnswerRankingReq struct {
+	QuestionID string                `validate:"required" json:"question_id"`
+	Weights    *SiteAnswerRankingReq `validate:"required" json:"weights"`
+}
+
+// AdminPreviewAnswerRankingResp the answer in the previewed order
+type AdminPreviewAnswerRankingResp struct {
+	*AnswerRankingScore
+	Excerpt    string         `json:"excerpt"`
+	VoteCount  int            `json:"vote_count"`
+	IsAccepted bool           `json:"is_accepted"`
+	CreatedAt  int64          `json:"created_at"`
+	UserInfo   *UserBasicInfo `json:"user_info"`
+	// the position in the current order, start from 1
+	CurrentPosition int `json:"current_position"`
+}
+
 type AdminAnswerInfo struct {
 	ID           string         `json:"id"`
 	QuestionID   string         `json:"question_id"`
@@ -154,18 +251,48 @@ type AdminAnswerInfo struct {
 	QuestionInfo struct {
 		Title string `json:"title"`
 	} `json:"question_info"`
+	Provenance *AnswerProvenance `json:"provenance,omitempty"`
+}
+
+// UpdateAnswerProvenanceReq update answer provenance request
+type UpdateAnswerProvenanceReq struct {
+	ID         string            `validate:"required" json:"id"`
+	Provenance *AnswerProvenance `validate:"omitempty" json:"provenance"`
+	UserID     string            `json:"-"`
+	IsAdmin    bool              `json:"-"`
 }
 
 type AcceptAnswerReq struct {
+	QuestionID string `validate:"required,gt=0,lte=30" json:"question_id"`
+	AnswerID   string `validate:"omitempty" json:"answer_id"`
+	// ResolutionNote short note from the question author explaining why the answer solved the problem
+	ResolutionNote string `validate:"omitempty,lte=500" json:"resolution_note"`
+	UserID         string `json:"-"`
+}
+
+// PinAnswerReq pin the answer to the top of question, the answer id is empty or 0 to unpin
+type PinAnswerReq struct {
 	QuestionID string `validate:"required,gt=0,lte=30" json:"question_id"`
 	AnswerID   string `validate:"omitempty" json:"answer_id"`
 	UserID     string `json:"-"`
 }
 
+// AcceptAnswerResp accept answer response, only returned when the author can not accept own answer yet
+type AcceptAnswerResp struct {
+	// the remaining seconds until the author can accept own answer
+	RemainingSeconds int64 `json:"remaining_seconds"`
+	// the unix timestamp when the author can accept own answer
+	AcceptableAt int64 `json:"acceptable_at"`
+}
+
 func (req *AcceptAnswerReq) Check() (errFields []*validator.FormErrorField, err error) {
 	if len(req.AnswerID) == 0 {
 		req.AnswerID = "0"
 	}
+	req.ResolutionNote = strings.TrimSpace(req.ResolutionNote)
+	if req.AnswerID == "0" {
+		req.ResolutionNote = ""
+	}
 	return nil, nil
 }
 
@@ -0,0 +1,150 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package schema
+
+import (
+	"strings"
+
+	"github.com/apache/answer/internal/base/validator"
+)
+
+// AuditLogRecord the privileged action to be recorded, the before and after values are marshaled as json
+type AuditLogRecord struct {
+	// the operator is read from the request context if it is empty
+	OperatorID string
+	Action     string
+	ObjectType string
+	ObjectID   string
+	Before     any
+	After      any
+}
+
+// GetAuditLogPageReq admin get audit logs
+type GetAuditLogPageReq struct {
+	// filter by the username of operator
+	Operator   string `validate:"omitempty,lte=100" form:"operator"`
+	Action     string `validate:"omitempty,lte=64" form:"action"`
+	ObjectType string `validate:"omitempty,lte=32" form:"object_type"`
+	ObjectID   string `validate:"omitempty,lte=128" form:"object_id"`
+	// filter by the created time, unix timestamp in seconds
+	StartTime int64 `validate:"omitempty,gte=0" form:"start_time"`
+	EndTime   int64 `validate:"omitempty,gte=0" form:"end_time"`
+	Page      int   `validate:"omitempty,min=1" form:"page"`
+	PageSize  int   `validate:"omitempty,min=1,max=100" form:"page_size"`
+}
+
+func (r *GetAuditLogPageReq) Check() (errFields []*validator.FormErrorField, err error) {
+	r.Operator = strings.TrimSpace(r.Operator)
+	r.Action = strings.TrimSpace(r.Action)
+	r.ObjectType = strings.TrimSpace(r.ObjectType)
+	r.ObjectID = strings.TrimSpace(r.ObjectID)
+	return nil, nil
+}
+
+// AuditLogExportReq export the filtered audit logs as csv
+type AuditLogExportReq struct {
+	GetAuditLogPageReq
+	// generate the csv file in background even if the list is small
+	Async bool `validate:"omitempty" form:"async"`
+}
+
+// AuditLogItem the audit log
+type AuditLogItem struct {
+	ID         string         `json:"id"`
+	Operator   *UserBasicInfo `json:"operator"`
+	Action     string         `json:"action"`
+	ObjectType string         `json:"object_type"`
+	ObjectID   string         `json:"object_id"`
+	Before     string         `json:"before"`
+	After      string         `json:"after"`
+	IP         string         `json:"ip"`
+	CreatedAt  int64          `json:"created_at"`
+}
+
+// AuditLogCSVHeader the header of exported audit logs
+var AuditLogCSVHeader = []string{
+	"id", "operator", "action", "object_type", "object_id", "before", "after", "ip", "created_at",
+}
+
+// CSVRecord format the audit log as a csv record
+func (a *AuditLogItem) CSVRecord() []string {
+	return csvSafeRecord(
+		a.ID,
+		csvUsername(a.Operator),
+		a.Action,
+		a.ObjectType,
+		a.ObjectID,
+		a.Before,
+		a.After,
+		a.IP,
+		csvTime(a.CreatedAt),
+	)
+}
+
+// AuditLogCond the condition of searching audit logs
+type AuditLogCond struct {
+	OperatorID string
+	Action     string
+	ObjectType string
+	ObjectID   string
+	StartTime  int64
+	EndTime    int64
+}
+
+// auditLogSensitiveKeys the setting fields whose values are never kept in the audit log,
+// besides them all the fields named as "xxx_key" are masked, such as api_key, private_key and signing_key
+var auditLogSensitiveKeys = []string{"password", "secret", "token", "credential"}
+
+// MaskAuditLogValue replace the values of sensitive fields in the json object, such as passwords and api keys
+func MaskAuditLogValue(value map[string]any) map[string]any {
+	for key, v := range value {
+		switch nested := v.(type) {
+		case map[string]any:
+			value[key] = MaskAuditLogValue(nested)
+			continue
+		case []any:
+			for i, item := range nested {
+				if m, ok := item.(map[string]any); ok {
+					nested[i] = MaskAuditLogValue(m)
+				}
+			}
+			continue
+		}
+		if !isAuditLogSensitiveKey(key) {
+			continue
+		}
+		if s, ok := v.(string); !ok || len(s) > 0 {
+			value[key] = "******"
+		}
+	}
+	return value
+}
+
+func isAuditLogSensitiveKey(key string) bool {
+	lower := strings.ToLower(key)
+	if strings.HasSuffix(lower, "_key") {
+		return true
+	}
+	for _, sensitive := range auditLogSensitiveKeys {
+		if strings.Contains(lower, sensitive) {
+			return true
+		}
+	}
+	return false
+}
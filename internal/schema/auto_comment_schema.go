@@ -0,0 +1,133 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/validator"
+	"github.com/segmentfault/pacman/errors"
+)
+
+const (
+	// AutoCommentTriggerFirstQuestion comment under the first question of the user
+	AutoCommentTriggerFirstQuestion = "first_question"
+	// AutoCommentTriggerTag comment under the question carrying one of the tags
+	AutoCommentTriggerTag = "tag"
+)
+
+// AutoCommentRule the rule of the templated comment posted by the bot account
+type AutoCommentRule struct {
+	Name    string `validate:"required,notblank,lte=100" json:"name"`
+	Trigger string `validate:"required,oneof=first_question tag" json:"trigger"`
+	Enabled bool   `json:"enabled"`
+	// the slug names of the tags, required by the tag trigger, optional to narrow down the first question trigger
+	Tags []string `validate:"omitempty,lte=20,dive,required" json:"tags"`
+	// the markdown go template with AutoCommentTemplateData
+	Template string `validate:"required,notblank,lte=600" json:"template"`
+}
+
+// AutoCommentTemplateData the data used to render the auto comment template
+type AutoCommentTemplateData struct {
+	SiteName        string
+	UserDisplayName string
+	Username        string
+	QuestionTitle   string
+	TagName         string
+}
+
+// Match whether the question matches the rule, the matched tag is returned
+func (r *AutoCommentRule) Match(isFirstQuestion bool, tagNames []string) (matched bool, tagName string) {
+	if !r.Enabled {
+		return false, ""
+	}
+	if r.Trigger == AutoCommentTriggerFirstQuestion && !isFirstQuestion {
+		return false, ""
+	}
+	if len(r.Tags) == 0 {
+		return r.Trigger == AutoCommentTriggerFirstQuestion, ""
+	}
+	for _, name := range tagNames {
+		for _, tag := range r.Tags {
+			if strings.EqualFold(name, tag) {
+				return true, name
+			}
+		}
+	}
+	return false, ""
+}
+
+// Render render the comment template
+func (r *AutoCommentRule) Render(data *AutoCommentTemplateData) (content string, err error) {
+	tpl, err := template.New(r.Name).Parse(r.Template)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err = tpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Check check the rule templates can be rendered
+func (s *SiteAutoCommentReq) Check() (errFields []*validator.FormErrorField, err error) {
+	for _, rule := range s.Rules {
+		if rule.Trigger == AutoCommentTriggerTag && len(rule.Tags) == 0 {
+			errFields = append(errFields, &validator.FormErrorField{
+				ErrorField: "rules",
+				ErrorMsg:   rule.Name,
+			})
+			return errFields, errors.BadRequest(reason.AutoCommentRuleTagsRequired)
+		}
+		if _, err = rule.Render(&AutoCommentTemplateData{}); err != nil {
+			errFields = append(errFields, &validator.FormErrorField{
+				ErrorField: "rules",
+				ErrorMsg:   err.Error(),
+			})
+			return errFields, errors.BadRequest(reason.AutoCommentTemplateInvalid)
+		}
+	}
+	return nil, nil
+}
+
+// MatchRule get the first enabled rule matching the question, nil if none matched
+// or the question carries one of the opt-out tags
+func (s *SiteAutoCommentResp) MatchRule(isFirstQuestion bool, tagNames []string) (rule *AutoCommentRule, tagName string) {
+	if !s.Enabled {
+		return nil, ""
+	}
+	for _, name := range tagNames {
+		for _, optOut := range s.OptOutTags {
+			if strings.EqualFold(name, optOut) {
+				return nil, ""
+			}
+		}
+	}
+	for _, r := range s.Rules {
+		if matched, matchedTag := r.Match(isFirstQuestion, tagNames); matched {
+			return r, matchedTag
+		}
+	}
+	return nil, ""
+}
@@ -0,0 +1,56 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSiteAutoCommentResp_MatchRule(t *testing.T) {
+	conf := &SiteAutoCommentResp{
+		Enabled:    true,
+		OptOutTags: []string{"meta"},
+		Rules: []*AutoCommentRule{
+			{Name: "welcome", Trigger: AutoCommentTriggerFirstQuestion, Enabled: true, Template: "Welcome {{.UserDisplayName}}"},
+			{Name: "golang", Trigger: AutoCommentTriggerTag, Enabled: true, Tags: []string{"go"}, Template: "See the {{.TagName}} wiki"},
+			{Name: "disabled", Trigger: AutoCommentTriggerTag, Tags: []string{"rust"}, Template: "disabled"},
+		},
+	}
+
+	rule, _ := conf.MatchRule(true, []string{"go"})
+	assert.Equal(t, "welcome", rule.Name)
+	rule, tagName := conf.MatchRule(false, []string{"GO"})
+	assert.Equal(t, "golang", rule.Name)
+	assert.Equal(t, "GO", tagName)
+
+	rule, _ = conf.MatchRule(false, []string{"rust"})
+	assert.Nil(t, rule)
+	rule, _ = conf.MatchRule(true, []string{"go", "meta"})
+	assert.Nil(t, rule)
+
+	content, err := conf.Rules[0].Render(&AutoCommentTemplateData{UserDisplayName: "Alice"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Welcome Alice", content)
+
+	_, err = (&SiteAutoCommentReq{Rules: []*AutoCommentRule{{Name: "bad", Trigger: AutoCommentTriggerFirstQuestion, Template: "{{.Oops"}}}).Check()
+	assert.Error(t, err)
+}
@@ -0,0 +1,116 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/validator"
+	"github.com/apache/answer/internal/entity"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// AutoPromotionRule the role is granted to the users who meet all thresholds of the rule in the consecutive daily evaluations
+type AutoPromotionRule struct {
+	// the user and admin roles can't be granted automatically
+	RoleID             int `validate:"required,gte=3" json:"role_id"`
+	MinReputation      int `validate:"omitempty,gte=0" json:"min_reputation"`
+	MinAnswers         int `validate:"omitempty,gte=0" json:"min_answers"`
+	MinAcceptedAnswers int `validate:"omitempty,gte=0" json:"min_accepted_answers"`
+	// the sum of votes of the answers
+	MinAnswerScore    int `validate:"omitempty,gte=0" json:"min_answer_score"`
+	MinAccountAgeDays int `validate:"omitempty,gte=0,lte=3650" json:"min_account_age_days"`
+	// the user is granted after meeting the thresholds in the number of consecutive evaluations
+	SustainedDays int `validate:"omitempty,gte=1,lte=365" json:"sustained_days"`
+	// the granted role is revoked after the user fails the thresholds in the number of consecutive evaluations,
+	// 0 means the granted role is never revoked
+	RevokeAfterDays int `validate:"omitempty,gte=0,lte=365" json:"revoke_after_days"`
+}
+
+// FillDefault fill the default sustained days if not set
+func (r *AutoPromotionRule) FillDefault() {
+	if r.SustainedDays <= 0 {
+		r.SustainedDays = 7
+	}
+}
+
+// Qualified whether the activity of user meets all thresholds of the rule
+func (r *AutoPromotionRule) Qualified(stat *entity.UserActivityStat, now time.Time) bool {
+	return stat.Rank >= r.MinReputation &&
+		stat.AnswerCount >= r.MinAnswers &&
+		stat.AcceptedCount >= r.MinAcceptedAnswers &&
+		stat.AnswerScore >= r.MinAnswerScore &&
+		!stat.CreatedAt.After(now.AddDate(0, 0, -r.MinAccountAgeDays))
+}
+
+// FormatUserActivityStat format the activity of user kept in the audit trail
+func FormatUserActivityStat(stat *entity.UserActivityStat) string {
+	if stat == nil {
+		return ""
+	}
+	return fmt.Sprintf("reputation %d, answers %d, accepted %d, score %d",
+		stat.Rank, stat.AnswerCount, stat.AcceptedCount, stat.AnswerScore)
+}
+
+// Check check every role has only one rule
+func (s *SiteAutoPromotionReq) Check() (errFields []*validator.FormErrorField, err error) {
+	roles := make(map[int]bool, len(s.Rules))
+	for _, rule := range s.Rules {
+		if roles[rule.RoleID] {
+			errFields = append(errFields, &validator.FormErrorField{
+				ErrorField: "rules",
+				ErrorMsg:   reason.AutoPromotionRuleDuplicate,
+			})
+			return errFields, errors.BadRequest(reason.AutoPromotionRuleDuplicate)
+		}
+		roles[rule.RoleID] = true
+	}
+	return nil, nil
+}
+
+// GetRules get the rules if the auto promotion is enabled
+func (s *SiteAutoPromotionResp) GetRules() []*AutoPromotionRule {
+	if !s.Enabled {
+		return nil
+	}
+	for _, rule := range s.Rules {
+		rule.FillDefault()
+	}
+	return s.Rules
+}
+
+// GetAutoPromotionLogsReq get the audit trail of auto promotion
+type GetAutoPromotionLogsReq struct {
+	Page     int    `validate:"omitempty,min=1" form:"page"`
+	PageSize int    `validate:"omitempty,min=1,max=100" form:"page_size"`
+	UserID   string `validate:"omitempty" form:"user_id"`
+}
+
+// AutoPromotionLogItem the role granted or revoked by the auto promotion
+type AutoPromotionLogItem struct {
+	User      *UserBasicInfo `json:"user"`
+	RoleID    int            `json:"role_id"`
+	RoleName  string         `json:"role_name"`
+	Action    string         `json:"action"`
+	Detail    string         `json:"detail"`
+	CreatedAt int64          `json:"created_at"`
+}
@@ -0,0 +1,55 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAutoPromotionRule_Qualified(t *testing.T) {
+	now := time.Now()
+	rule := &AutoPromotionRule{RoleID: 3, MinReputation: 1000, MinAnswers: 20, MinAcceptedAnswers: 5, MinAccountAgeDays: 30}
+	stat := &entity.UserActivityStat{Rank: 1200, AnswerCount: 30, AcceptedCount: 12, CreatedAt: now.AddDate(0, 0, -60)}
+	assert.True(t, rule.Qualified(stat, now))
+
+	stat.AcceptedCount = 4
+	assert.False(t, rule.Qualified(stat, now))
+
+	stat.AcceptedCount = 12
+	stat.CreatedAt = now.AddDate(0, 0, -10)
+	assert.False(t, rule.Qualified(stat, now))
+}
+
+func TestSiteAutoPromotionReq_Check(t *testing.T) {
+	req := &SiteAutoPromotionReq{Enabled: true, Rules: []*AutoPromotionRule{{RoleID: 3}, {RoleID: 4}}}
+	_, err := req.Check()
+	assert.NoError(t, err)
+
+	req.Rules = append(req.Rules, &AutoPromotionRule{RoleID: 3})
+	_, err = req.Check()
+	assert.Error(t, err)
+
+	resp := SiteAutoPromotionResp(*req)
+	assert.Equal(t, 7, resp.GetRules()[0].SustainedDays)
+}
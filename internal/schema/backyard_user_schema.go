@@ -36,16 +36,19 @@ import (
 // UpdateUserStatusReq update user request
 type UpdateUserStatusReq struct {
 	UserID           string `validate:"required" json:"user_id"`
-	Status           string `validate:"required,oneof=normal suspended deleted inactive" json:"status" enums:"normal,suspended,deleted,inactive"`
+	Status           string `validate:"required,oneof=normal suspended deleted inactive shadow_banned" json:"status" enums:"normal,suspended,deleted,inactive,shadow_banned"`
 	SuspendDuration  string `validate:"omitempty,oneof=24h 48h 72h 7d 14d 1m 2m 3m 6m 1y forever" json:"suspend_duration"`
 	RemoveAllContent bool   `validate:"omitempty" json:"remove_all_content"`
-	LoginUserID      string `json:"-"`
+	// the user is removed as spammer, the reputation granted by the votes of user and the reputation user earned are reversed
+	RemoveAsSpammer bool   `validate:"omitempty" json:"remove_as_spammer"`
+	LoginUserID     string `json:"-"`
 }
 
-func (r *UpdateUserStatusReq) IsNormal() bool    { return r.Status == constant.UserNormal }
-func (r *UpdateUserStatusReq) IsSuspended() bool { return r.Status == constant.UserSuspended }
-func (r *UpdateUserStatusReq) IsDeleted() bool   { return r.Status == constant.UserDeleted }
-func (r *UpdateUserStatusReq) IsInactive() bool  { return r.Status == constant.UserInactive }
+func (r *UpdateUserStatusReq) IsNormal() bool       { return r.Status == constant.UserNormal }
+func (r *UpdateUserStatusReq) IsSuspended() bool    { return r.Status == constant.UserSuspended }
+func (r *UpdateUserStatusReq) IsDeleted() bool      { return r.Status == constant.UserDeleted }
+func (r *UpdateUserStatusReq) IsInactive() bool     { return r.Status == constant.UserInactive }
+func (r *UpdateUserStatusReq) IsShadowBanned() bool { return r.Status == constant.UserShadowBanned }
 
 // GetSuspendedUntil calculates the suspended until time based on duration
 func (r *UpdateUserStatusReq) GetSuspendedUntil() time.Time {
@@ -89,14 +92,15 @@ type GetUserPageReq struct {
 	// email
 	Query string `validate:"omitempty,gt=0,lte=100" form:"query"`
 	// user status
-	Status string `validate:"omitempty,oneof=normal suspended deleted inactive" form:"status"`
+	Status string `validate:"omitempty,oneof=normal suspended deleted inactive shadow_banned" form:"status"`
 	// staff, if staff is true means query admin or moderator
 	Staff bool `validate:"omitempty" form:"staff"`
 }
 
-func (r *GetUserPageReq) IsSuspended() bool { return r.Status == constant.UserSuspended }
-func (r *GetUserPageReq) IsDeleted() bool   { return r.Status == constant.UserDeleted }
-func (r *GetUserPageReq) IsInactive() bool  { return r.Status == constant.UserInactive }
+func (r *GetUserPageReq) IsSuspended() bool    { return r.Status == constant.UserSuspended }
+func (r *GetUserPageReq) IsDeleted() bool      { return r.Status == constant.UserDeleted }
+func (r *GetUserPageReq) IsInactive() bool     { return r.Status == constant.UserInactive }
+func (r *GetUserPageReq) IsShadowBanned() bool { return r.Status == constant.UserShadowBanned }
 
 // GetUserPageResp get user response
 type GetUserPageResp struct {
@@ -126,6 +130,8 @@ type GetUserPageResp struct {
 	RoleID int `json:"role_id"`
 	// role name
 	RoleName string `json:"role_name"`
+	// is bot account
+	IsBot bool `json:"is_bot"`
 }
 
 // GetUserInfoReq get user request
@@ -177,6 +183,8 @@ type AddUsersReq struct {
 // DeletePermanentlyReq delete permanently request
 type DeletePermanentlyReq struct {
 	Type string `validate:"required,oneof=users questions answers" json:"type"`
+	// login user id
+	LoginUserID string `json:"-"`
 }
 
 type AddUsersErrorData struct {
@@ -0,0 +1,39 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateUserStatusReq_IsShadowBanned(t *testing.T) {
+	req := &UpdateUserStatusReq{Status: constant.UserShadowBanned}
+	assert.True(t, req.IsShadowBanned())
+	assert.False(t, req.IsNormal())
+	// the suspended until time only makes sense for suspension
+	assert.Equal(t, entity.PermanentSuspensionTime, req.GetSuspendedUntil())
+
+	req.Status = constant.UserNormal
+	assert.False(t, req.IsShadowBanned())
+}
@@ -19,7 +19,12 @@
 
 package schema
 
-import "github.com/apache/answer/internal/entity"
+import (
+	"sort"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/pkg/uid"
+)
 
 const (
 	BadgeStatusActive   BadgeStatus = "active"
@@ -175,3 +180,42 @@ type GetUserBadgeAwardListResp struct {
 type BadgeTplData struct {
 	ProfileURL string
 }
+
+// UserTagBadge the highest tag badge a user has earned in a tag
+type UserTagBadge struct {
+	// badge id
+	BadgeID string `json:"badge_id"`
+	// tag slug name
+	SlugName string `json:"slug_name"`
+	// tag display name
+	DisplayName string `json:"display_name"`
+	// badge level
+	Level entity.BadgeLevel `json:"level"`
+}
+
+// NewUserTagBadges keep the highest level badge of each tag, ordered by level then slug name
+func NewUserTagBadges(awards []*entity.TagBadgeAward) (tagBadges []*UserTagBadge) {
+	highest := make(map[string]*entity.TagBadgeAward, len(awards))
+	for _, award := range awards {
+		if exist, ok := highest[award.TagID]; ok && exist.Level >= award.Level {
+			continue
+		}
+		highest[award.TagID] = award
+	}
+	tagBadges = make([]*UserTagBadge, 0, len(highest))
+	for _, award := range highest {
+		tagBadges = append(tagBadges, &UserTagBadge{
+			BadgeID:     uid.EnShortID(award.BadgeID),
+			SlugName:    award.SlugName,
+			DisplayName: award.DisplayName,
+			Level:       award.Level,
+		})
+	}
+	sort.Slice(tagBadges, func(i, j int) bool {
+		if tagBadges[i].Level != tagBadges[j].Level {
+			return tagBadges[i].Level > tagBadges[j].Level
+		}
+		return tagBadges[i].SlugName < tagBadges[j].SlugName
+	})
+	return tagBadges
+}
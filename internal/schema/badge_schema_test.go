@@ -0,0 +1,42 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewUserTagBadges(t *testing.T) {
+	tagBadges := NewUserTagBadges([]*entity.TagBadgeAward{
+		{BadgeID: "1", TagID: "10", Level: entity.BadgeLevelBronze, SlugName: "go"},
+		{BadgeID: "2", TagID: "10", Level: entity.BadgeLevelSilver, SlugName: "go"},
+		{BadgeID: "1", TagID: "11", Level: entity.BadgeLevelBronze, SlugName: "css"},
+		{BadgeID: "2", TagID: "12", Level: entity.BadgeLevelSilver, SlugName: "angular"},
+	})
+	assert.Len(t, tagBadges, 3)
+	assert.Equal(t, "angular", tagBadges[0].SlugName)
+	assert.Equal(t, "go", tagBadges[1].SlugName)
+	assert.Equal(t, entity.BadgeLevelSilver, tagBadges[1].Level)
+	assert.Equal(t, "css", tagBadges[2].SlugName)
+	assert.Empty(t, NewUserTagBadges(nil))
+}
@@ -0,0 +1,81 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/validator"
+	"github.com/apache/answer/pkg/checker"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// AddBotReq add bot account request, the reserved usernames can be used by bots
+type AddBotReq struct {
+	Username    string `validate:"required,gte=2,lte=30" json:"username"`
+	DisplayName string `validate:"required,gte=2,lte=30" json:"display_name"`
+}
+
+// Check the username of bot should be valid
+func (r *AddBotReq) Check() (errFields []*validator.FormErrorField, err error) {
+	if checker.IsInvalidUsername(r.Username) {
+		errFields = append(errFields, &validator.FormErrorField{
+			ErrorField: "username",
+			ErrorMsg:   reason.UsernameInvalid,
+		})
+		return errFields, errors.BadRequest(reason.UsernameInvalid)
+	}
+	return nil, nil
+}
+
+// AddBotResp add bot account response, the token is only shown once
+type AddBotResp struct {
+	UserID      string `json:"user_id"`
+	Username    string `json:"username"`
+	DisplayName string `json:"display_name"`
+	Token       string `json:"token"`
+}
+
+// RegenerateBotTokenReq regenerate bot token request
+type RegenerateBotTokenReq struct {
+	UserID string `validate:"required" json:"user_id"`
+}
+
+// RegenerateBotTokenResp regenerate bot token response, the previous token is invalid immediately
+type RegenerateBotTokenResp struct {
+	Token string `json:"token"`
+}
+
+// GetBotPageReq get bot page request
+type GetBotPageReq struct {
+	Page     int `validate:"omitempty,min=1" form:"page"`
+	PageSize int `validate:"omitempty,min=1,max=100" form:"page_size"`
+}
+
+// BotItem the bot account
+type BotItem struct {
+	UserID      string `json:"user_id"`
+	Username    string `json:"username"`
+	DisplayName string `json:"display_name"`
+	Avatar      string `json:"avatar"`
+	Status      string `json:"status"`
+	CreatedAt   int64  `json:"created_at"`
+	// the last time the token is used to authenticate, 0 means never
+	LastUsedAt int64 `json:"last_used_at"`
+}
@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	ChatPlatformSlack      = "slack"
+	ChatPlatformMattermost = "mattermost"
+
+	SlackSignatureHeader = "X-Slack-Signature"
+	SlackTimestampHeader = "X-Slack-Request-Timestamp"
+
+	ChatResponseTypeInChannel = "in_channel"
+	ChatResponseTypeEphemeral = "ephemeral"
+
+	// ChatSearchResultLimit the max number of questions replied for searching
+	ChatSearchResultLimit = 5
+)
+
+// ChatCommandReq slash command request, the fields are the same for Slack and Mattermost
+type ChatCommandReq struct {
+	Token       string `form:"token"`
+	UserID      string `form:"user_id"`
+	UserName    string `form:"user_name"`
+	ChannelID   string `form:"channel_id"`
+	Command     string `form:"command"`
+	Text        string `form:"text"`
+	ResponseURL string `form:"response_url"`
+	Platform    string `form:"-"`
+}
+
+// SlackActionPayload slack message action payload, it is sent as the payload field of form
+type SlackActionPayload struct {
+	Type        string `json:"type"`
+	CallbackID  string `json:"callback_id"`
+	ResponseURL string `json:"response_url"`
+	User        struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"user"`
+	Channel struct {
+		ID string `json:"id"`
+	} `json:"channel"`
+	Message struct {
+		Text string `json:"text"`
+	} `json:"message"`
+}
+
+// ChatCommandResp the message replied to chat
+type ChatCommandResp struct {
+	ResponseType string `json:"response_type"`
+	Text         string `json:"text"`
+}
+
+// NewChatEphemeralResp the message only visible to the user who sent the command
+func NewChatEphemeralResp(text string) *ChatCommandResp {
+	return &ChatCommandResp{ResponseType: ChatResponseTypeEphemeral, Text: text}
+}
+
+// NewChatInChannelResp the message visible to everyone in the channel
+func NewChatInChannelResp(text string) *ChatCommandResp {
+	return &ChatCommandResp{ResponseType: ChatResponseTypeInChannel, Text: text}
+}
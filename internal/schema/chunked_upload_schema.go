@@ -0,0 +1,128 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/validator"
+	"github.com/segmentfault/pacman/errors"
+)
+
+const (
+	// ChunkedUploadChunkSize the max size of each chunk
+	ChunkedUploadChunkSize = 5 * 1024 * 1024
+	// ChunkedUploadExpiration the unfinished uploads are removed after this duration
+	ChunkedUploadExpiration = 24 * time.Hour
+	// ChunkChecksumHeader optional sha256 hex checksum of the chunk in request body
+	ChunkChecksumHeader = "X-Chunk-Checksum"
+)
+
+// CreateChunkedUploadReq start a resumable upload
+type CreateChunkedUploadReq struct {
+	// identify the source of the file upload, only post and post_attachment support chunked upload
+	Source   string `validate:"required,oneof=post post_attachment" json:"source"`
+	FileName string `validate:"required,gt=0,lte=255" json:"file_name"`
+	FileSize int64  `validate:"required,min=1" json:"file_size"`
+	// sha256 hex checksum of the whole file, verified after all chunks are uploaded
+	Checksum string `validate:"required,len=64" json:"checksum"`
+	UserID   string `json:"-"`
+}
+
+func (r *CreateChunkedUploadReq) Check() (errFields []*validator.FormErrorField, err error) {
+	r.Checksum = strings.ToLower(r.Checksum)
+	if _, e := hex.DecodeString(r.Checksum); e != nil {
+		errFields = append(errFields, &validator.FormErrorField{
+			ErrorField: "checksum",
+			ErrorMsg:   reason.UploadChecksumInvalid,
+		})
+		return errFields, errors.BadRequest(reason.UploadChecksumInvalid)
+	}
+	return nil, nil
+}
+
+// GetChunkedUploadReq get the state of resumable upload
+type GetChunkedUploadReq struct {
+	UploadID string `json:"-"`
+	UserID   string `json:"-"`
+}
+
+// UploadChunkReq upload the chunk starts at offset, the chunk is the raw request body
+type UploadChunkReq struct {
+	UploadID string `json:"-"`
+	// must be equal to the offset of the upload, which is the size of the chunks received
+	Offset int64 `json:"-"`
+	// optional sha256 hex checksum of the chunk
+	Checksum string `json:"-"`
+	UserID   string `json:"-"`
+}
+
+// ChunkedUploadResp the state of resumable upload
+type ChunkedUploadResp struct {
+	UploadID  string `json:"upload_id"`
+	Source    string `json:"source"`
+	FileName  string `json:"file_name"`
+	FileSize  int64  `json:"file_size"`
+	Offset    int64  `json:"offset"`
+	ChunkSize int64  `json:"chunk_size"`
+	ExpiresAt int64  `json:"expires_at"`
+	Completed bool   `json:"completed"`
+	// the url of the assembled file once completed
+	URL string `json:"url"`
+}
+
+// ChunkedUpload the state of resumable upload saved with the chunks
+type ChunkedUpload struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Source    string    `json:"source"`
+	FileName  string    `json:"file_name"`
+	FileSize  int64     `json:"file_size"`
+	Checksum  string    `json:"checksum"`
+	Offset    int64     `json:"offset"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// IsExpired the upload is not finished in time
+func (u *ChunkedUpload) IsExpired() bool {
+	return time.Now().After(u.ExpiresAt)
+}
+
+// NextChunkSize the max size of the next chunk
+func (u *ChunkedUpload) NextChunkSize() int64 {
+	return min(int64(ChunkedUploadChunkSize), u.FileSize-u.Offset)
+}
+
+// ToResp convert to response
+func (u *ChunkedUpload) ToResp() *ChunkedUploadResp {
+	return &ChunkedUploadResp{
+		UploadID:  u.ID,
+		Source:    u.Source,
+		FileName:  u.FileName,
+		FileSize:  u.FileSize,
+		Offset:    u.Offset,
+		ChunkSize: ChunkedUploadChunkSize,
+		ExpiresAt: u.ExpiresAt.Unix(),
+	}
+}
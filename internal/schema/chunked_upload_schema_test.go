@@ -0,0 +1,44 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateChunkedUploadReq_Check(t *testing.T) {
+	req := &CreateChunkedUploadReq{Checksum: strings.Repeat("AB", 32)}
+	_, err := req.Check()
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("ab", 32), req.Checksum)
+
+	_, err = (&CreateChunkedUploadReq{Checksum: strings.Repeat("zz", 32)}).Check()
+	assert.Error(t, err)
+}
+
+func TestChunkedUpload_NextChunkSize(t *testing.T) {
+	upload := &ChunkedUpload{FileSize: ChunkedUploadChunkSize*2 + 10}
+	assert.Equal(t, int64(ChunkedUploadChunkSize), upload.NextChunkSize())
+	upload.Offset = ChunkedUploadChunkSize * 2
+	assert.Equal(t, int64(10), upload.NextChunkSize())
+}
@@ -20,6 +20,8 @@
 package schema
 
 import (
+	"strings"
+
 	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/base/validator"
 	"github.com/apache/answer/internal/entity"
@@ -35,13 +37,15 @@ type AddCommentReq struct {
 	// reply comment id
 	ReplyCommentID string `validate:"omitempty" json:"reply_comment_id"`
 	// original comment content
-	OriginalText string `validate:"required,notblank,gte=2,lte=600" json:"original_text"`
+	OriginalText string `validate:"required,notblank,gte=2" limit:"comment" json:"original_text"`
 	// parsed comment content
 	ParsedText string `json:"-"`
 	// @ user id list
 	MentionUsernameList []string `validate:"omitempty" json:"mention_username_list"`
 	CaptchaID           string   `json:"captcha_id"`
 	CaptchaCode         string   `json:"captcha_code"`
+	// referenced code block line range of the question or answer
+	CodeAnchor *CommentCodeAnchorReq `validate:"omitempty" json:"code_anchor"`
 
 	// user id
 	UserID string `json:"-"`
@@ -50,7 +54,9 @@ type AddCommentReq struct {
 	// whether user can edit it
 	CanEdit bool `json:"-"`
 	// whether user can delete it
-	CanDelete bool `json:"-"`
+	CanDelete bool   `json:"-"`
+	IP        string `json:"-"`
+	UserAgent string `json:"-"`
 }
 
 func (req *AddCommentReq) Check() (errFields []*validator.FormErrorField, err error) {
@@ -74,12 +80,38 @@ type RemoveCommentReq struct {
 	CaptchaCode string `json:"captcha_code"`
 }
 
+// AdminBulkRemoveCommentMaxCount the max number of comments removed in one bulk operation
+const AdminBulkRemoveCommentMaxCount = 1000
+
+// AdminBulkRemoveCommentReq admin bulk remove comments request, at least one filter is required
+type AdminBulkRemoveCommentReq struct {
+	// remove the comments posted by this user
+	Username string `validate:"omitempty,gt=0,lte=100" json:"username"`
+	// remove the comments created between start time and end time, unix timestamp in seconds
+	StartTime int64 `validate:"omitempty,gte=0" json:"start_time"`
+	EndTime   int64 `validate:"omitempty,gte=0" json:"end_time"`
+	// remove the comments whose content contains the keyword
+	Keyword string `validate:"omitempty,gt=0,lte=100" json:"keyword"`
+	// only count the matched comments without removing them
+	DryRun bool `json:"dry_run"`
+	// user id
+	UserID string `json:"-"`
+}
+
+// AdminBulkRemoveCommentResp admin bulk remove comments response
+type AdminBulkRemoveCommentResp struct {
+	// the number of matched comments, they are removed if not dry run
+	Count int `json:"count"`
+	// there are more matched comments than the max count of one operation
+	HasMore bool `json:"has_more"`
+}
+
 // UpdateCommentReq update comment request
 type UpdateCommentReq struct {
 	// comment id
 	CommentID string `validate:"required" json:"comment_id"`
 	// original comment content
-	OriginalText string `validate:"required,notblank,gte=2,lte=600" json:"original_text"`
+	OriginalText string `validate:"required,notblank,gte=2" limit:"comment" json:"original_text"`
 	// parsed comment content
 	ParsedText string `json:"-"`
 	// user id
@@ -205,6 +237,8 @@ type GetCommentResp struct {
 	ReplyCommentID string `json:"reply_comment_id"`
 	// reply user status
 	ReplyUserStatus string `json:"reply_user_status"`
+	// referenced code block line range, nil if the comment is not anchored to code
+	CodeAnchor *CommentCodeAnchorResp `json:"code_anchor,omitempty"`
 
 	// MemberActions
 	MemberActions []*PermissionMemberAction `json:"member_actions"`
@@ -216,6 +250,77 @@ func (r *GetCommentResp) SetFromComment(comment *entity.Comment) {
 	r.CreatedAt = comment.CreatedAt.Unix()
 	r.ReplyUserID = comment.GetReplyUserID()
 	r.ReplyCommentID = comment.GetReplyCommentID()
+	r.CodeAnchor = NewCommentCodeAnchorResp(comment)
+}
+
+// CommentCodeMaxAnchorLines the max lines that a comment can reference
+const CommentCodeMaxAnchorLines = 200
+
+// CommentCodeAnchorReq reference a line range of a code block, block index and lines start from 1
+type CommentCodeAnchorReq struct {
+	BlockIndex int `validate:"required,min=1" json:"block_index"`
+	LineStart  int `validate:"required,min=1" json:"line_start"`
+	LineEnd    int `validate:"required,min=1" json:"line_end"`
+}
+
+// Excerpt returns the referenced lines in code blocks, ok is false if the block or line range does not exist
+func (req *CommentCodeAnchorReq) Excerpt(codeBlocks []string) (excerpt string, ok bool) {
+	if req.BlockIndex > len(codeBlocks) || req.LineEnd < req.LineStart ||
+		req.LineEnd-req.LineStart+1 > CommentCodeMaxAnchorLines {
+		return "", false
+	}
+	lines := strings.Split(codeBlocks[req.BlockIndex-1], "\n")
+	if req.LineEnd > len(lines) {
+		return "", false
+	}
+	return strings.Join(lines[req.LineStart-1:req.LineEnd], "\n"), true
+}
+
+// CommentCodeAnchorResp the code anchor of comment.
+// Outdated means the referenced code can not be found anymore after the post was edited,
+// clients should show the excerpt as a regular comment.
+type CommentCodeAnchorResp struct {
+	BlockIndex int    `json:"block_index"`
+	LineStart  int    `json:"line_start"`
+	LineEnd    int    `json:"line_end"`
+	Excerpt    string `json:"excerpt"`
+	Outdated   bool   `json:"outdated"`
+}
+
+// NewCommentCodeAnchorResp returns nil if the comment does not reference code
+func NewCommentCodeAnchorResp(comment *entity.Comment) *CommentCodeAnchorResp {
+	if comment.CodeBlockIndex <= 0 {
+		return nil
+	}
+	return &CommentCodeAnchorResp{
+		BlockIndex: comment.CodeBlockIndex,
+		LineStart:  comment.CodeLineStart,
+		LineEnd:    comment.CodeLineEnd,
+		Excerpt:    comment.CodeExcerpt,
+	}
+}
+
+// Relocate find the excerpt in the current code blocks of post.
+// If the referenced lines are unchanged, nothing to do. If the excerpt is moved, anchor to the new position.
+// Otherwise, the anchor is outdated.
+func (r *CommentCodeAnchorResp) Relocate(codeBlocks []string) {
+	anchor := &CommentCodeAnchorReq{BlockIndex: r.BlockIndex, LineStart: r.LineStart, LineEnd: r.LineEnd}
+	if excerpt, ok := anchor.Excerpt(codeBlocks); ok && excerpt == r.Excerpt {
+		return
+	}
+	excerptLines := strings.Split(r.Excerpt, "\n")
+	for i, block := range codeBlocks {
+		lines := strings.Split(block, "\n")
+		for start := 0; start+len(excerptLines) <= len(lines); start++ {
+			if strings.Join(lines[start:start+len(excerptLines)], "\n") == r.Excerpt {
+				r.BlockIndex = i + 1
+				r.LineStart = start + 1
+				r.LineEnd = start + len(excerptLines)
+				return
+			}
+		}
+	}
+	r.Outdated = true
 }
 
 // GetCommentPersonalWithPageReq get comment list page request
@@ -228,6 +333,8 @@ type GetCommentPersonalWithPageReq struct {
 	Username string `validate:"omitempty,gt=0,lte=100" form:"username"`
 	// user id
 	UserID string `json:"-"`
+	// login user id
+	LoginUserID string `json:"-"`
 }
 
 // GetCommentPersonalWithPageResp comment response
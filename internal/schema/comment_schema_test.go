@@ -0,0 +1,56 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/apache/answer/pkg/converter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommentCodeAnchorResp_Relocate(t *testing.T) {
+	original := "text\n\n```go\na := 1\nb := 2\nc := 3\n```\n"
+	anchor := &CommentCodeAnchorReq{BlockIndex: 1, LineStart: 2, LineEnd: 3}
+	excerpt, ok := anchor.Excerpt(converter.ExtractCodeBlocks(original))
+	assert.True(t, ok)
+	assert.Equal(t, "b := 2\nc := 3", excerpt)
+
+	_, ok = (&CommentCodeAnchorReq{BlockIndex: 1, LineStart: 3, LineEnd: 4}).Excerpt(converter.ExtractCodeBlocks(original))
+	assert.False(t, ok)
+
+	// unchanged
+	resp := &CommentCodeAnchorResp{BlockIndex: 1, LineStart: 2, LineEnd: 3, Excerpt: excerpt}
+	resp.Relocate(converter.ExtractCodeBlocks(original))
+	assert.Equal(t, 2, resp.LineStart)
+	assert.False(t, resp.Outdated)
+
+	// moved to another block
+	edited := "```\nfoo\n```\n\n```go\nz := 0\na := 1\nb := 2\nc := 3\n```\n"
+	resp.Relocate(converter.ExtractCodeBlocks(edited))
+	assert.Equal(t, 2, resp.BlockIndex)
+	assert.Equal(t, 3, resp.LineStart)
+	assert.Equal(t, 4, resp.LineEnd)
+	assert.False(t, resp.Outdated)
+
+	// removed
+	resp.Relocate(converter.ExtractCodeBlocks("```go\na := 1\n```\n"))
+	assert.True(t, resp.Outdated)
+}
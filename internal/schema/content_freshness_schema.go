@@ -0,0 +1,76 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import "github.com/apache/answer/internal/entity"
+
+// GetContentFreshnessReportReq get content freshness report request
+type GetContentFreshnessReportReq struct {
+	Page     int `validate:"omitempty,min=1" form:"page"`
+	PageSize int `validate:"omitempty,min=1,max=100" form:"page_size"`
+}
+
+// GetContentFreshnessReportResp get content freshness report response, the tags are ordered by the stale rate
+type GetContentFreshnessReportResp struct {
+	// the time of the last analysis, 0 means not analyzed yet
+	GeneratedAt        int64                   `json:"generated_at"`
+	StaleYears         int                     `json:"stale_years"`
+	ActiveTagDays      int                     `json:"active_tag_days"`
+	DeprecatedVersions []string                `json:"deprecated_versions"`
+	Site               *ContentFreshnessItem   `json:"site"`
+	Total              int64                   `json:"count"`
+	List               []*ContentFreshnessItem `json:"list"`
+}
+
+// ContentFreshnessItem the freshness metrics of the questions in the tag or all active tags
+type ContentFreshnessItem struct {
+	Tag                *TagResp `json:"tag,omitempty"`
+	QuestionCount      int      `json:"question_count"`
+	AcceptedCount      int      `json:"accepted_count"`
+	StaleAcceptedCount int      `json:"stale_accepted_count"`
+	// the percentage of stale accepted answers in accepted answers
+	StaleRate       float64 `json:"stale_rate"`
+	DeprecatedCount int     `json:"deprecated_count"`
+	// the percentage of questions referencing the deprecated versions in questions
+	DeprecatedRate float64 `json:"deprecated_rate"`
+}
+
+// ContentFreshnessStalePercent the percentage of stale accepted answers multiplied by 100, kept for ordering
+func ContentFreshnessStalePercent(staleAcceptedCount, acceptedCount int) int {
+	if acceptedCount == 0 {
+		return 0
+	}
+	return staleAcceptedCount * 10000 / acceptedCount
+}
+
+// NewContentFreshnessItem new content freshness item from the stat
+func NewContentFreshnessItem(stat *entity.ContentFreshnessStat) *ContentFreshnessItem {
+	item := &ContentFreshnessItem{
+		QuestionCount:      stat.QuestionCount,
+		AcceptedCount:      stat.AcceptedCount,
+		StaleAcceptedCount: stat.StaleAcceptedCount,
+		StaleRate:          float64(stat.StalePercent) / 100,
+		DeprecatedCount:    stat.DeprecatedCount,
+	}
+	if stat.QuestionCount > 0 {
+		item.DeprecatedRate = float64(stat.DeprecatedCount*10000/stat.QuestionCount) / 100
+	}
+	return item
+}
@@ -0,0 +1,51 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewContentFreshnessItem(t *testing.T) {
+	stat := &entity.ContentFreshnessStat{QuestionCount: 300, AcceptedCount: 120, StaleAcceptedCount: 41, DeprecatedCount: 7}
+	stat.StalePercent = ContentFreshnessStalePercent(stat.StaleAcceptedCount, stat.AcceptedCount)
+	assert.Equal(t, 3416, stat.StalePercent)
+
+	item := NewContentFreshnessItem(stat)
+	assert.Equal(t, 34.16, item.StaleRate)
+	assert.Equal(t, 2.33, item.DeprecatedRate)
+
+	assert.Equal(t, 0, ContentFreshnessStalePercent(0, 0))
+}
+
+func TestSiteContentFreshnessReq_Check(t *testing.T) {
+	req := &SiteContentFreshnessReq{DeprecatedVersions: []string{" python 2", "python 2", " ", "angularjs"}}
+	_, err := req.Check()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"python 2", "angularjs"}, req.DeprecatedVersions)
+
+	resp := SiteContentFreshnessResp(*req)
+	resp.FillDefault()
+	assert.Equal(t, 3, resp.StaleYears)
+	assert.Equal(t, 90, resp.ActiveTagDays)
+}
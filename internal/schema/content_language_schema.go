@@ -0,0 +1,46 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// ContentLanguageResult the result of post language detection
+type ContentLanguageResult struct {
+	// the detected ISO 639-1 language code
+	Language string
+	// whether the language is enabled for the site
+	Allowed bool
+	// the action for the language is not allowed, review or translate
+	Action string
+	// the language should be translated to, it's the language of site interface
+	TargetLanguage string
+}
+
+// NeedTranslate whether the post should be translated to the site language
+func (r *ContentLanguageResult) NeedTranslate() bool {
+	return r != nil && !r.Allowed && r.Action == LanguageDetectionActionTranslate
+}
+
+// GetContentLanguageStatResp get content language statistics response
+type GetContentLanguageStatResp struct {
+	Language        string `json:"language"`
+	PostCount       int64  `json:"post_count"`
+	ReviewCount     int64  `json:"review_count"`
+	TranslatedCount int64  `json:"translated_count"`
+	UpdatedAt       int64  `json:"updated_at"`
+}
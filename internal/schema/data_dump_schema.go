@@ -0,0 +1,126 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// DataDumpFormatVersion the version of public data dump format, increase it when the record fields changed
+const DataDumpFormatVersion = "1.0"
+
+// The public data dump is a zip file that contains the following files.
+// Each *.jsonl file contains one JSON record per line.
+const (
+	DataDumpMetadataFileName  = "metadata.json"
+	DataDumpQuestionsFileName = "questions.jsonl"
+	DataDumpAnswersFileName   = "answers.jsonl"
+	DataDumpTagsFileName      = "tags.jsonl"
+	DataDumpUsersFileName     = "users.jsonl"
+)
+
+// DataDumpMetadata the metadata of public data dump, it describes the site, the license and the files
+type DataDumpMetadata struct {
+	FormatVersion string `json:"format_version"`
+	SiteName      string `json:"site_name"`
+	SiteURL       string `json:"site_url"`
+	License       string `json:"license"`
+	LicenseURL    string `json:"license_url"`
+	// Attribution how to attribute the content when using the data
+	Attribution string                  `json:"attribution"`
+	GeneratedAt int64                   `json:"generated_at"`
+	Files       []*DataDumpFileMetadata `json:"files"`
+}
+
+// DataDumpFileMetadata the metadata of the file in public data dump
+type DataDumpFileMetadata struct {
+	Name        string `json:"name"`
+	RecordType  string `json:"record_type"`
+	RecordCount int64  `json:"record_count"`
+}
+
+// DataDumpQuestion the question record of public data dump.
+// The UserID is anonymized and is the same as the ID of DataDumpUser.
+type DataDumpQuestion struct {
+	ID               string   `json:"id"`
+	Title            string   `json:"title"`
+	Body             string   `json:"body"`
+	Tags             []string `json:"tags"`
+	UserID           string   `json:"user_id"`
+	AcceptedAnswerID string   `json:"accepted_answer_id,omitempty"`
+	Closed           bool     `json:"closed"`
+	VoteCount        int      `json:"vote_count"`
+	AnswerCount      int      `json:"answer_count"`
+	ViewCount        int      `json:"view_count"`
+	CreatedAt        int64    `json:"created_at"`
+	UpdatedAt        int64    `json:"updated_at"`
+}
+
+// DataDumpAnswer the answer record of public data dump
+type DataDumpAnswer struct {
+	ID         string `json:"id"`
+	QuestionID string `json:"question_id"`
+	Body       string `json:"body"`
+	UserID     string `json:"user_id"`
+	Accepted   bool   `json:"accepted"`
+	VoteCount  int    `json:"vote_count"`
+	CreatedAt  int64  `json:"created_at"`
+	UpdatedAt  int64  `json:"updated_at"`
+}
+
+// DataDumpTag the tag record of public data dump
+type DataDumpTag struct {
+	SlugName      string `json:"slug_name"`
+	DisplayName   string `json:"display_name"`
+	Description   string `json:"description"`
+	SynonymOf     string `json:"synonym_of,omitempty"`
+	QuestionCount int    `json:"question_count"`
+	FollowCount   int    `json:"follow_count"`
+	CreatedAt     int64  `json:"created_at"`
+}
+
+// DataDumpUser the anonymized user record of public data dump.
+// The ID is a keyed hash of real user id, it does not change between dumps.
+// No username, display name, email, ip or profile information is exported.
+type DataDumpUser struct {
+	ID            string `json:"id"`
+	Reputation    int    `json:"reputation"`
+	QuestionCount int    `json:"question_count"`
+	AnswerCount   int    `json:"answer_count"`
+	// CreatedAt only keep the date of user registration
+	CreatedAt int64 `json:"created_at"`
+}
+
+// DataDumpState the state of public data dump, it is saved as site info and not exposed
+type DataDumpState struct {
+	Salt        string `json:"salt"`
+	FileName    string `json:"file_name"`
+	FileSize    int64  `json:"file_size"`
+	GeneratedAt int64  `json:"generated_at"`
+}
+
+// GetDataDumpInfoResp get public data dump info response
+type GetDataDumpInfoResp struct {
+	Enabled       bool   `json:"enabled"`
+	Available     bool   `json:"available"`
+	InProgress    bool   `json:"in_progress"`
+	FormatVersion string `json:"format_version"`
+	FileName      string `json:"file_name"`
+	FileSize      int64  `json:"file_size"`
+	GeneratedAt   int64  `json:"generated_at"`
+	License       string `json:"license"`
+	LicenseURL    string `json:"license_url"`
+}
@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import "time"
+
+const (
+	// DigestFrequencyDaily the digest of the last day
+	DigestFrequencyDaily = "daily"
+	// DigestFrequencyWeekly the digest of the last week
+	DigestFrequencyWeekly = "weekly"
+)
+
+// FillDefault fill the default caps if not set
+func (s *SiteDigestResp) FillDefault() {
+	if s.MaxEmailsPerRun <= 0 {
+		s.MaxEmailsPerRun = 500
+	}
+	if s.MaxItems <= 0 {
+		s.MaxItems = 5
+	}
+}
+
+// PeriodStart get the start of the current period of the frequency, the latest send time not after now
+func (s *SiteDigestResp) PeriodStart(frequency string, now time.Time) time.Time {
+	start := time.Date(now.Year(), now.Month(), now.Day(), s.SendHour, 0, 0, 0, now.Location())
+	if frequency == DigestFrequencyWeekly {
+		start = start.AddDate(0, 0, -((int(now.Weekday()) - s.WeeklyDay + 7) % 7))
+		if start.After(now) {
+			start = start.AddDate(0, 0, -7)
+		}
+		return start
+	}
+	if start.After(now) {
+		start = start.AddDate(0, 0, -1)
+	}
+	return start
+}
+
+// DigestPeriodDays the days covered by the digest of the frequency
+func DigestPeriodDays(frequency string) int {
+	if frequency == DigestFrequencyWeekly {
+		return 7
+	}
+	return 1
+}
+
+// DigestTemplateData the data of digest email templates
+type DigestTemplateData struct {
+	SiteName    string
+	SiteURL     string
+	DisplayName string
+	// the new questions in the followed tags, the total may be more than listed
+	TagQuestions     []*DigestQuestion
+	TagQuestionTotal int64
+	// the followed questions with new answers
+	FollowedQuestions []*DigestQuestion
+	// the top voted questions of the site in the period
+	TopQuestions   []*DigestQuestion
+	UnsubscribeUrl string
+}
+
+// DigestQuestion the question listed in digest email
+type DigestQuestion struct {
+	Title          string
+	URL            string
+	VoteCount      int
+	NewAnswerCount int
+}
+
+// IsEmpty whether there is nothing to send
+func (d *DigestTemplateData) IsEmpty() bool {
+	return len(d.TagQuestions) == 0 && len(d.FollowedQuestions) == 0 && len(d.TopQuestions) == 0
+}
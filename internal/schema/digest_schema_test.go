@@ -0,0 +1,44 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSiteDigestResp_PeriodStart(t *testing.T) {
+	conf := &SiteDigestResp{SendHour: 8, WeeklyDay: int(time.Monday)}
+	// wednesday
+	now := time.Date(2024, 5, 15, 9, 30, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, 5, 15, 8, 0, 0, 0, time.UTC), conf.PeriodStart(DigestFrequencyDaily, now))
+	assert.Equal(t, time.Date(2024, 5, 13, 8, 0, 0, 0, time.UTC), conf.PeriodStart(DigestFrequencyWeekly, now))
+
+	now = time.Date(2024, 5, 15, 7, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, 5, 14, 8, 0, 0, 0, time.UTC), conf.PeriodStart(DigestFrequencyDaily, now))
+
+	// monday before the send hour belongs to the last week
+	now = time.Date(2024, 5, 13, 7, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, 5, 6, 8, 0, 0, 0, time.UTC), conf.PeriodStart(DigestFrequencyWeekly, now))
+	now = time.Date(2024, 5, 13, 8, 0, 0, 0, time.UTC)
+	assert.Equal(t, now, conf.PeriodStart(DigestFrequencyWeekly, now))
+}
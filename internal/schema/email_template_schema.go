@@ -0,0 +1,201 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"bytes"
+	htmltemplate "html/template"
+	"reflect"
+	texttemplate "text/template"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
+	"github.com/apache/answer/internal/base/validator"
+	"github.com/segmentfault/pacman/errors"
+)
+
+const (
+	EmailTemplateKeyRegister        = "register"
+	EmailTemplateKeyPassReset       = "pass_reset"
+	EmailTemplateKeyChangeEmail     = "change_email"
+	EmailTemplateKeyTest            = "test"
+	EmailTemplateKeyNewAnswer       = "new_answer"
+	EmailTemplateKeyInvitedAnswer   = "invited_you_to_answer"
+	EmailTemplateKeyNewComment      = "new_comment"
+	EmailTemplateKeyNewQuestion     = "new_question"
+	EmailTemplateKeyDigestDaily     = "digest_daily"
+	EmailTemplateKeyDigestWeekly    = "digest_weekly"
+	EmailTemplateKeySuspiciousLogin = "suspicious_login"
+)
+
+// EmailTemplateDefinition the email template could be customized by admin
+type EmailTemplateDefinition struct {
+	Key      string
+	TitleKey string
+	BodyKey  string
+	// the empty template data, the custom templates are validated with it
+	data any
+}
+
+// EmailTemplateDefinitions all the email templates could be customized
+var EmailTemplateDefinitions = []*EmailTemplateDefinition{
+	{EmailTemplateKeyRegister, constant.EmailTplKeyRegisterTitle, constant.EmailTplKeyRegisterBody, &RegisterTemplateData{}},
+	{EmailTemplateKeyPassReset, constant.EmailTplKeyPassResetTitle, constant.EmailTplKeyPassResetBody, &PassResetTemplateData{}},
+	{EmailTemplateKeyChangeEmail, constant.EmailTplKeyChangeEmailTitle, constant.EmailTplKeyChangeEmailBody, &ChangeEmailTemplateData{}},
+	{EmailTemplateKeyTest, constant.EmailTplKeyTestTitle, constant.EmailTplKeyTestBody, &TestTemplateData{}},
+	{EmailTemplateKeyNewAnswer, constant.EmailTplKeyNewAnswerTitle, constant.EmailTplKeyNewAnswerBody, &NewAnswerTemplateData{}},
+	{EmailTemplateKeyInvitedAnswer, constant.EmailTplKeyInvitedAnswerTitle, constant.EmailTplKeyInvitedAnswerBody, &NewInviteAnswerTemplateData{}},
+	{EmailTemplateKeyNewComment, constant.EmailTplKeyNewCommentTitle, constant.EmailTplKeyNewCommentBody, &NewCommentTemplateData{}},
+	{EmailTemplateKeyNewQuestion, constant.EmailTplKeyNewQuestionTitle, constant.EmailTplKeyNewQuestionBody, &NewQuestionTemplateData{}},
+	{EmailTemplateKeyDigestDaily, constant.EmailTplKeyDigestDailyTitle, constant.EmailTplKeyDigestBody, &DigestTemplateData{}},
+	{EmailTemplateKeyDigestWeekly, constant.EmailTplKeyDigestWeeklyTitle, constant.EmailTplKeyDigestBody, &DigestTemplateData{}},
+	{EmailTemplateKeySuspiciousLogin, constant.EmailTplKeySuspiciousLoginTitle, constant.EmailTplKeySuspiciousLoginBody, &SuspiciousLoginTemplateData{}},
+}
+
+// GetEmailTemplateDefinition get the definition of the template key, nil if not found
+func GetEmailTemplateDefinition(key string) *EmailTemplateDefinition {
+	for _, definition := range EmailTemplateDefinitions {
+		if definition.Key == key {
+			return definition
+		}
+	}
+	return nil
+}
+
+// Placeholders the fields of template data could be used as {{.Field}}
+func (d *EmailTemplateDefinition) Placeholders() (placeholders []string) {
+	t := reflect.TypeOf(d.data).Elem()
+	for i := 0; i < t.NumField(); i++ {
+		placeholders = append(placeholders, t.Field(i).Name)
+	}
+	return placeholders
+}
+
+// Validate the custom templates must be parsed and only use the placeholders of template data,
+// the field of the invalid template is returned
+func (d *EmailTemplateDefinition) Validate(subject, body string) (field string, err error) {
+	if _, err = renderEmailSubject(subject, d.data); err != nil {
+		return "subject", err
+	}
+	if _, err = renderEmailBody(body, d.data); err != nil {
+		return "body", err
+	}
+	return "", nil
+}
+
+// RenderEmailTemplate render the custom subject and body templates, the body is escaped as html
+func RenderEmailTemplate(subject, body string, data any) (renderedSubject, renderedBody string, err error) {
+	if renderedSubject, err = renderEmailSubject(subject, data); err != nil {
+		return "", "", err
+	}
+	if renderedBody, err = renderEmailBody(body, data); err != nil {
+		return "", "", err
+	}
+	return renderedSubject, renderedBody, nil
+}
+
+func renderEmailSubject(subject string, data any) (string, error) {
+	tpl, err := texttemplate.New("subject").Parse(subject)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err = tpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func renderEmailBody(body string, data any) (string, error) {
+	tpl, err := htmltemplate.New("body").Parse(body)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err = tpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// EmailTemplateItem the template in the list
+type EmailTemplateItem struct {
+	Key          string   `json:"key"`
+	Placeholders []string `json:"placeholders"`
+	// the languages customized by admin
+	CustomLanguages []string `json:"custom_languages"`
+}
+
+// GetEmailTemplateReq get email template request
+type GetEmailTemplateReq struct {
+	Key      string `validate:"required,gt=0,lte=64" form:"key"`
+	Language string `validate:"required,gt=0,lte=16" form:"language"`
+}
+
+// GetEmailTemplateResp get email template response, the default templates are the translations of the language
+type GetEmailTemplateResp struct {
+	Key            string   `json:"key"`
+	Language       string   `json:"language"`
+	Placeholders   []string `json:"placeholders"`
+	Customized     bool     `json:"customized"`
+	Subject        string   `json:"subject"`
+	Body           string   `json:"body"`
+	DefaultSubject string   `json:"default_subject"`
+	DefaultBody    string   `json:"default_body"`
+	UpdatedAt      int64    `json:"updated_at"`
+}
+
+// SaveEmailTemplateReq save email template request
+type SaveEmailTemplateReq struct {
+	Key      string `validate:"required,gt=0,lte=64" json:"key"`
+	Language string `validate:"required,gt=0,lte=16" json:"language"`
+	Subject  string `validate:"required,notblank,gt=0,lte=512" json:"subject"`
+	Body     string `validate:"required,notblank,gt=0,lte=65535" json:"body"`
+}
+
+// Check the language must be valid and the templates must be rendered with the placeholders
+func (r *SaveEmailTemplateReq) Check() (errFields []*validator.FormErrorField, err error) {
+	definition := GetEmailTemplateDefinition(r.Key)
+	if definition == nil {
+		return nil, errors.BadRequest(reason.EmailTemplateNotFound)
+	}
+	if r.Language == translator.DefaultLangOption || !translator.CheckLanguageIsValid(r.Language) {
+		errFields = append(errFields, &validator.FormErrorField{
+			ErrorField: "language",
+			ErrorMsg:   reason.LangNotFound,
+		})
+		return errFields, errors.BadRequest(reason.LangNotFound)
+	}
+	if field, err := definition.Validate(r.Subject, r.Body); err != nil {
+		errFields = append(errFields, &validator.FormErrorField{
+			ErrorField: field,
+			ErrorMsg:   err.Error(),
+		})
+		return errFields, errors.BadRequest(reason.EmailTemplateInvalid)
+	}
+	return nil, nil
+}
+
+// DeleteEmailTemplateReq delete email template request, the default template is used after deleted
+type DeleteEmailTemplateReq struct {
+	Key      string `validate:"required,gt=0,lte=64" json:"key"`
+	Language string `validate:"required,gt=0,lte=16" json:"language"`
+}
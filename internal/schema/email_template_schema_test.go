@@ -0,0 +1,59 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetEmailTemplateDefinition(t *testing.T) {
+	assert.Nil(t, GetEmailTemplateDefinition("not_exist"))
+	for _, definition := range EmailTemplateDefinitions {
+		assert.Equal(t, definition, GetEmailTemplateDefinition(definition.Key))
+	}
+	assert.Equal(t, []string{"SiteName", "RegisterUrl"},
+		GetEmailTemplateDefinition(EmailTemplateKeyRegister).Placeholders())
+}
+
+func TestEmailTemplateDefinition_Validate(t *testing.T) {
+	definition := GetEmailTemplateDefinition(EmailTemplateKeyNewAnswer)
+
+	field, err := definition.Validate("[{{.SiteName}}] {{.DisplayName}} answered", "<a href='{{.AnswerUrl}}'>{{.QuestionTitle}}</a>")
+	assert.NoError(t, err)
+	assert.Empty(t, field)
+
+	field, err = definition.Validate("{{.SiteName", "body")
+	assert.Error(t, err)
+	assert.Equal(t, "subject", field)
+
+	field, err = definition.Validate("subject", "{{.CommentUrl}}")
+	assert.Error(t, err)
+	assert.Equal(t, "body", field)
+}
+
+func TestRenderEmailTemplate(t *testing.T) {
+	subject, body, err := RenderEmailTemplate("{{.SiteName}} & more", "<b>{{.SiteName}}</b>",
+		&TestTemplateData{SiteName: "<Answer>"})
+	assert.NoError(t, err)
+	assert.Equal(t, "<Answer> & more", subject)
+	assert.Equal(t, "<b>&lt;Answer&gt;</b>", body)
+}
@@ -0,0 +1,121 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"net/url"
+	"strings"
+)
+
+const (
+	HelpfulnessSurveyResultSolved    = "solved"
+	HelpfulnessSurveyResultNotSolved = "not_solved"
+	HelpfulnessSurveyResultDismissed = "dismissed"
+	// HelpfulnessSurveyMaxPending the max number of due surveys prompted to user at once
+	HelpfulnessSurveyMaxPending = 5
+	// AnswerHelpfulnessMinResponses the solved rate is used as ranking signal only if the answer has enough responses
+	AnswerHelpfulnessMinResponses = 3
+)
+
+// searchEngineNames the names of search engine in referrer host, e.g. www.google.co.uk
+var searchEngineNames = map[string]bool{
+	"google": true, "bing": true, "duckduckgo": true, "baidu": true, "yandex": true,
+	"yahoo": true, "ecosia": true, "startpage": true, "brave": true, "naver": true, "sogou": true,
+}
+
+// IsSearchReferrer whether the referrer is a search engine or the search page of site
+func IsSearchReferrer(referrer, siteURL string) bool {
+	ref, err := url.Parse(referrer)
+	if err != nil || len(ref.Host) == 0 {
+		return false
+	}
+	host := strings.ToLower(ref.Hostname())
+	if site, err := url.Parse(siteURL); err == nil && strings.EqualFold(site.Hostname(), host) {
+		return ref.Path == "/search" || strings.HasPrefix(ref.Path, "/search/")
+	}
+	labels := strings.Split(host, ".")
+	// the last label is top-level domain
+	for _, label := range labels[:len(labels)-1] {
+		if searchEngineNames[label] {
+			return true
+		}
+	}
+	return false
+}
+
+// RecordHelpfulnessViewReq record the view of question from search, the survey is scheduled if it has accepted answer
+type RecordHelpfulnessViewReq struct {
+	QuestionID string `validate:"required" json:"question_id"`
+	// the referrer of question page
+	Referrer string `validate:"omitempty,lte=2048" json:"referrer"`
+	UserID   string `json:"-"`
+}
+
+// GetPendingHelpfulnessSurveysReq get the due surveys of user
+type GetPendingHelpfulnessSurveysReq struct {
+	UserID string `json:"-"`
+}
+
+// HelpfulnessSurveyItem the survey prompted to user
+type HelpfulnessSurveyItem struct {
+	Token         string `json:"token"`
+	QuestionID    string `json:"question_id"`
+	QuestionTitle string `json:"question_title"`
+	AnswerID      string `json:"answer_id"`
+	URL           string `json:"url"`
+	ViewedAt      int64  `json:"viewed_at"`
+}
+
+// RespondHelpfulnessSurveyReq submit the survey result by token
+type RespondHelpfulnessSurveyReq struct {
+	Token  string `validate:"required,lte=64" json:"token"`
+	Result string `validate:"required,oneof=solved not_solved dismissed" json:"result"`
+}
+
+// GetAnswerHelpfulnessReq get the survey results of answer
+type GetAnswerHelpfulnessReq struct {
+	AnswerID         string `validate:"required" form:"answer_id"`
+	UserID           string `json:"-"`
+	IsAdminModerator bool   `json:"-"`
+}
+
+// AnswerHelpfulnessResp the survey results of answer
+type AnswerHelpfulnessResp struct {
+	AnswerID  string `json:"answer_id"`
+	Responses int    `json:"responses"`
+	Solved    int    `json:"solved"`
+	NotSolved int    `json:"not_solved"`
+	// the ratio of responses that the answer solved the problem, 0 if no response
+	SolvedRate float64 `json:"solved_rate"`
+}
+
+// NewAnswerHelpfulnessResp new answer helpfulness response
+func NewAnswerHelpfulnessResp(answerID string, responses, solved int) *AnswerHelpfulnessResp {
+	resp := &AnswerHelpfulnessResp{
+		AnswerID:  answerID,
+		Responses: responses,
+		Solved:    solved,
+		NotSolved: responses - solved,
+	}
+	if responses > 0 {
+		resp.SolvedRate = float64(solved) / float64(responses)
+	}
+	return resp
+}
@@ -0,0 +1,43 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSearchReferrer(t *testing.T) {
+	siteURL := "https://answer.example.com"
+	assert.True(t, IsSearchReferrer("https://www.google.co.uk/", siteURL))
+	assert.True(t, IsSearchReferrer("https://search.brave.com/search?q=answer", siteURL))
+	assert.True(t, IsSearchReferrer("https://answer.example.com/search?q=go", siteURL))
+	assert.False(t, IsSearchReferrer("https://answer.example.com/questions", siteURL))
+	assert.False(t, IsSearchReferrer("https://example.com/google", siteURL))
+	assert.False(t, IsSearchReferrer("", siteURL))
+}
+
+func TestNewAnswerHelpfulnessResp(t *testing.T) {
+	resp := NewAnswerHelpfulnessResp("1", 4, 3)
+	assert.Equal(t, 1, resp.NotSolved)
+	assert.InDelta(t, 0.75, resp.SolvedRate, 0.001)
+	assert.Zero(t, NewAnswerHelpfulnessResp("1", 0, 0).SolvedRate)
+}
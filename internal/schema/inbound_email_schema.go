@@ -0,0 +1,58 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	// InboundEmailMaxSize the max size of the raw email accepted
+	InboundEmailMaxSize = 10 << 20
+	// InboundEmailMaxPerPoll the max number of the emails fetched in one polling of the IMAP mailbox
+	InboundEmailMaxPerPoll = 100
+
+	// InboundEmailStatusPosted the reply is posted
+	InboundEmailStatusPosted = "posted"
+	// InboundEmailStatusDuplicate the email with the same message id was processed
+	InboundEmailStatusDuplicate = "duplicate"
+	// InboundEmailStatusIgnored the email is not a reply, e.g. the auto reply or the address is not signed
+	InboundEmailStatusIgnored = "ignored"
+	// InboundEmailStatusRejected the reply can not be posted, e.g. the sender is not the receiver of the notification
+	InboundEmailStatusRejected = "rejected"
+)
+
+// FillDefault fill the default IMAP settings if not set
+func (s *SiteInboundEmailResp) FillDefault() {
+	if s.IMAPPort <= 0 {
+		s.IMAPPort = 993
+	}
+	if len(s.IMAPMailbox) == 0 {
+		s.IMAPMailbox = "INBOX"
+	}
+}
+
+// IsAvailable whether the notification emails can be replied
+func (s *SiteInboundEmailResp) IsAvailable() bool {
+	return s.Enabled && len(s.ReplyAddress) > 0 && len(s.Secret) > 0
+}
+
+// InboundEmailResp the result of the processed inbound email
+type InboundEmailResp struct {
+	Status string `json:"status"`
+	// the id of the posted answer or comment
+	ObjectID string `json:"object_id,omitempty"`
+}
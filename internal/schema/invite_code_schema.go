@@ -0,0 +1,78 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// InviteCodeItem the invite code
+type InviteCodeItem struct {
+	ID   string `json:"id"`
+	Code string `json:"code"`
+	// the registration url with the invite code
+	URL       string `json:"url"`
+	UserID    string `json:"user_id"`
+	MaxUses   int    `json:"max_uses"`
+	UsedCount int    `json:"used_count"`
+	// the invite code could be used to register
+	Available bool  `json:"available"`
+	Revoked   bool  `json:"revoked"`
+	ExpiredAt int64 `json:"expired_at"`
+	CreatedAt int64 `json:"created_at"`
+}
+
+// AddInviteCodeReq generate invite code
+type AddInviteCodeReq struct {
+	MaxUses int `validate:"required,gte=1,lte=1000" json:"max_uses"`
+	// the invite code never expires if it is 0
+	ExpiredDays int    `validate:"omitempty,gte=0,lte=365" json:"expired_days"`
+	UserID      string `json:"-"`
+	IsAdmin     bool   `json:"-"`
+}
+
+// RemoveInviteCodeReq revoke invite code, the users registered with it are kept
+type RemoveInviteCodeReq struct {
+	ID      string `validate:"required" json:"id"`
+	UserID  string `json:"-"`
+	IsAdmin bool   `json:"-"`
+}
+
+// GetInviteCodePageReq admin get invite codes
+type GetInviteCodePageReq struct {
+	Page     int `validate:"omitempty,min=1" form:"page"`
+	PageSize int `validate:"omitempty,min=1,max=100" form:"page_size"`
+}
+
+// GetInvitedUsersReq get the users registered with the invite code
+type GetInvitedUsersReq struct {
+	ID      string `validate:"required" form:"id"`
+	UserID  string `json:"-"`
+	IsAdmin bool   `json:"-"`
+}
+
+// InvitedUserItem the user registered with the invite code
+type InvitedUserItem struct {
+	UserID       string `json:"user_id"`
+	Username     string `json:"username"`
+	DisplayName  string `json:"display_name"`
+	RegisteredAt int64  `json:"registered_at"`
+}
+
+// CheckInviteCodeReq check whether the invite code could be used to register
+type CheckInviteCodeReq struct {
+	Code string `validate:"required,lte=64" form:"code"`
+}
@@ -0,0 +1,109 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"net"
+	"strings"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/validator"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// IPBanItem the ip or CIDR range banned from registering and posting
+type IPBanItem struct {
+	ID         string `json:"id"`
+	IPRange    string `json:"ip_range"`
+	Reason     string `json:"reason"`
+	OperatorID string `json:"operator_id"`
+	HitCount   int    `json:"hit_count"`
+	LastHitAt  int64  `json:"last_hit_at"`
+	// the ban is not expired
+	Active    bool  `json:"active"`
+	ExpiredAt int64 `json:"expired_at"`
+	CreatedAt int64 `json:"created_at"`
+}
+
+// AddIPBanReq ban the ip or CIDR range
+type AddIPBanReq struct {
+	// the single ip such as 192.0.2.1 or the CIDR range such as 192.0.2.0/24
+	IPRange string `validate:"required,lte=64" json:"ip_range"`
+	Reason  string `validate:"omitempty,lte=255" json:"reason"`
+	// the ban never expires if it is 0
+	ExpiredHours int    `validate:"omitempty,gte=0,lte=87600" json:"expired_hours"`
+	UserID       string `json:"-"`
+}
+
+func (r *AddIPBanReq) Check() (errFields []*validator.FormErrorField, err error) {
+	r.Reason = strings.TrimSpace(r.Reason)
+	ipRange, ok := NormalizeIPRange(r.IPRange)
+	if !ok {
+		return append(errFields, &validator.FormErrorField{
+			ErrorField: "ip_range",
+			ErrorMsg:   reason.IPBanRangeInvalid,
+		}), errors.BadRequest(reason.IPBanRangeInvalid)
+	}
+	r.IPRange = ipRange
+	return nil, nil
+}
+
+// RemoveIPBanReq lift the ip ban
+type RemoveIPBanReq struct {
+	ID     string `validate:"required" json:"id"`
+	UserID string `json:"-"`
+}
+
+// GetIPBanPageReq admin get ip bans
+type GetIPBanPageReq struct {
+	Page     int `validate:"omitempty,min=1" form:"page"`
+	PageSize int `validate:"omitempty,min=1,max=100" form:"page_size"`
+}
+
+// NormalizeIPRange parse the single ip or CIDR range to the canonical CIDR notation,
+// the single ip is treated as /32 for IPv4 or /128 for IPv6
+func NormalizeIPRange(ipRange string) (normalized string, ok bool) {
+	ipRange = strings.TrimSpace(ipRange)
+	if !strings.Contains(ipRange, "/") {
+		ip := net.ParseIP(ipRange)
+		if ip == nil {
+			return "", false
+		}
+		if ip4 := ip.To4(); ip4 != nil {
+			return ip4.String() + "/32", true
+		}
+		return ip.String() + "/128", true
+	}
+	_, ipNet, err := net.ParseCIDR(ipRange)
+	if err != nil {
+		return "", false
+	}
+	return ipNet.String(), true
+}
+
+// IPRangeContains whether the ip is in the CIDR range
+func IPRangeContains(ipRange, ip string) bool {
+	_, ipNet, err := net.ParseCIDR(ipRange)
+	if err != nil {
+		return false
+	}
+	parsed := net.ParseIP(strings.TrimSpace(ip))
+	return parsed != nil && ipNet.Contains(parsed)
+}
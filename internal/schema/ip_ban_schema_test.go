@@ -0,0 +1,70 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeIPRange(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect string
+		ok     bool
+	}{
+		{input: "192.0.2.1", expect: "192.0.2.1/32", ok: true},
+		{input: " 192.0.2.77/24 ", expect: "192.0.2.0/24", ok: true},
+		{input: "2001:db8::1", expect: "2001:db8::1/128", ok: true},
+		{input: "2001:db8::1/32", expect: "2001:db8::/32", ok: true},
+		{input: "::ffff:192.0.2.1", expect: "192.0.2.1/32", ok: true},
+		{input: "192.0.2.256", ok: false},
+		{input: "192.0.2.0/33", ok: false},
+		{input: "example.com", ok: false},
+	}
+	for _, tt := range tests {
+		normalized, ok := NormalizeIPRange(tt.input)
+		assert.Equal(t, tt.ok, ok, tt.input)
+		assert.Equal(t, tt.expect, normalized, tt.input)
+	}
+}
+
+func TestIPRangeContains(t *testing.T) {
+	assert.True(t, IPRangeContains("192.0.2.0/24", "192.0.2.200"))
+	assert.False(t, IPRangeContains("192.0.2.0/24", "192.0.3.1"))
+	assert.True(t, IPRangeContains("192.0.2.1/32", "::ffff:192.0.2.1"))
+	assert.True(t, IPRangeContains("2001:db8::/32", "2001:db8:1::5"))
+	assert.False(t, IPRangeContains("2001:db8::/32", "192.0.2.1"))
+	assert.False(t, IPRangeContains("192.0.2.0/24", "invalid"))
+}
+
+func TestAddIPBanReq_Check(t *testing.T) {
+	req := &AddIPBanReq{IPRange: "10.1.2.3/8", Reason: " spam "}
+	_, err := req.Check()
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.0/8", req.IPRange)
+	assert.Equal(t, "spam", req.Reason)
+
+	req = &AddIPBanReq{IPRange: "10.1.2"}
+	errFields, err := req.Check()
+	assert.Error(t, err)
+	assert.Len(t, errFields, 1)
+}
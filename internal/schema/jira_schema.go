@@ -0,0 +1,160 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"bytes"
+	"slices"
+	"text/template"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/validator"
+	"github.com/segmentfault/pacman/errors"
+)
+
+const (
+	// JiraDefaultIssueType the issue type used if the project does not set it
+	JiraDefaultIssueType = "Task"
+	// ModeratorNoteSourceJira the moderator note synced from the jira issue
+	ModeratorNoteSourceJira = "jira"
+)
+
+// JiraProject the jira project receiving the issues of the flagged questions
+type JiraProject struct {
+	Key       string `validate:"required,notblank,lte=32" json:"key"`
+	IssueType string `validate:"omitempty,lte=64" json:"issue_type"`
+	// only the questions with any of the tags are created in the project, empty means all questions
+	Tags []string `validate:"omitempty,lte=20,dive,required" json:"tags"`
+	// create the issue as soon as the question is flagged, otherwise only by the moderator
+	AutoCreate bool `json:"auto_create"`
+	// the markdown go template with JiraCommentTemplateData posted under the question when the issue resolves,
+	// empty means no public comment
+	ResolvedTemplate string `validate:"omitempty,lte=600" json:"resolved_template"`
+}
+
+// JiraCommentTemplateData the data used to render the resolved comment template
+type JiraCommentTemplateData struct {
+	IssueKey      string
+	IssueURL      string
+	Status        string
+	Resolution    string
+	QuestionTitle string
+}
+
+// GetIssueType get the issue type of the project
+func (p *JiraProject) GetIssueType() string {
+	if len(p.IssueType) == 0 {
+		return JiraDefaultIssueType
+	}
+	return p.IssueType
+}
+
+// Render render the resolved comment template
+func (p *JiraProject) Render(data *JiraCommentTemplateData) (content string, err error) {
+	tpl, err := template.New(p.Key).Parse(p.ResolvedTemplate)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err = tpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Check check the resolved comment templates can be rendered
+func (s *SiteJiraReq) Check() (errFields []*validator.FormErrorField, err error) {
+	for _, project := range s.Projects {
+		if _, err = project.Render(&JiraCommentTemplateData{}); err != nil {
+			errFields = append(errFields, &validator.FormErrorField{
+				ErrorField: "projects",
+				ErrorMsg:   err.Error(),
+			})
+			return errFields, errors.BadRequest(reason.JiraTemplateInvalid)
+		}
+	}
+	return nil, nil
+}
+
+// GetProject get the project for the question with the tags, the first project with any of the tags is preferred,
+// otherwise the first project without tags, nil if none matched
+func (s *SiteJiraResp) GetProject(tags []string) *JiraProject {
+	var fallback *JiraProject
+	for _, project := range s.Projects {
+		if len(project.Tags) == 0 {
+			if fallback == nil {
+				fallback = project
+			}
+			continue
+		}
+		for _, tag := range tags {
+			if slices.Contains(project.Tags, tag) {
+				return project
+			}
+		}
+	}
+	return fallback
+}
+
+// GetProjectByKey get the project by the key, nil if not configured
+func (s *SiteJiraResp) GetProjectByKey(key string) *JiraProject {
+	for _, project := range s.Projects {
+		if project.Key == key {
+			return project
+		}
+	}
+	return nil
+}
+
+// CreateJiraIssueReq create jira issue request
+type CreateJiraIssueReq struct {
+	QuestionID string `validate:"required" json:"question_id"`
+	UserID     string `json:"-"`
+}
+
+// JiraIssueResp jira issue response
+type JiraIssueResp struct {
+	ProjectKey string `json:"project_key"`
+	IssueKey   string `json:"issue_key"`
+	IssueURL   string `json:"issue_url"`
+	Status     string `json:"status"`
+	Resolved   bool   `json:"resolved"`
+}
+
+// GetQuestionModeratorNotesReq get question moderator notes request
+type GetQuestionModeratorNotesReq struct {
+	QuestionID string `validate:"required" form:"question_id"`
+}
+
+// GetQuestionModeratorNotesResp get question moderator notes response
+type GetQuestionModeratorNotesResp struct {
+	// the jira issue linked to the question, nil if not created
+	JiraIssue *JiraIssueResp               `json:"jira_issue"`
+	Notes     []*QuestionModeratorNoteItem `json:"notes"`
+}
+
+// QuestionModeratorNoteItem question moderator note item
+type QuestionModeratorNoteItem struct {
+	ID        string `json:"id"`
+	Source    string `json:"source"`
+	Author    string `json:"author"`
+	Content   string `json:"content"`
+	CreatedAt int64  `json:"created_at"`
+}
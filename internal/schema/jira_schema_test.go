@@ -0,0 +1,61 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSiteJiraResp_GetProject(t *testing.T) {
+	conf := &SiteJiraResp{
+		Projects: []*JiraProject{
+			{Key: "DOCS", Tags: []string{"docs"}},
+			{Key: "SUP"},
+			{Key: "OPS"},
+			{Key: "SEC", Tags: []string{"security", "auth"}},
+		},
+	}
+
+	assert.Equal(t, "SEC", conf.GetProject([]string{"go", "auth"}).Key)
+	assert.Equal(t, "DOCS", conf.GetProject([]string{"docs", "auth"}).Key)
+	assert.Equal(t, "SUP", conf.GetProject([]string{"go"}).Key)
+	assert.Equal(t, "OPS", conf.GetProjectByKey("OPS").Key)
+	assert.Nil(t, conf.GetProjectByKey("NONE"))
+	assert.Nil(t, (&SiteJiraResp{Projects: conf.Projects[3:]}).GetProject(nil))
+}
+
+func TestJiraProject_Render(t *testing.T) {
+	project := &JiraProject{Key: "SUP", ResolvedTemplate: "Fixed in [{{.IssueKey}}]({{.IssueURL}}): {{.Resolution}}"}
+	content, err := project.Render(&JiraCommentTemplateData{
+		IssueKey:   "SUP-1",
+		IssueURL:   "https://example.atlassian.net/browse/SUP-1",
+		Resolution: "Done",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Fixed in [SUP-1](https://example.atlassian.net/browse/SUP-1): Done", content)
+	assert.Equal(t, JiraDefaultIssueType, project.GetIssueType())
+
+	_, err = (&SiteJiraReq{Projects: []*JiraProject{project, {Key: "BAD", ResolvedTemplate: "{{.Missing}}"}}}).Check()
+	assert.Error(t, err)
+	_, err = (&SiteJiraReq{Projects: []*JiraProject{project}}).Check()
+	assert.NoError(t, err)
+}
@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import "time"
+
+const (
+	LeaderboardPeriodWeek    = "week"
+	LeaderboardPeriodMonth   = "month"
+	LeaderboardPeriodQuarter = "quarter"
+	// LeaderboardMaxUsers the max number of users kept in the site leaderboard of each period
+	LeaderboardMaxUsers = 100
+	// LeaderboardTagMaxUsers the max number of users kept in the tag leaderboard of each period
+	LeaderboardTagMaxUsers = 20
+)
+
+// LeaderboardPeriods all leaderboard periods
+var LeaderboardPeriods = []string{LeaderboardPeriodWeek, LeaderboardPeriodMonth, LeaderboardPeriodQuarter}
+
+// LeaderboardPeriodStart get the start time of the current period. The week starts on Monday, all in UTC.
+func LeaderboardPeriodStart(period string, now time.Time) time.Time {
+	now = now.UTC()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	switch period {
+	case LeaderboardPeriodWeek:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case LeaderboardPeriodMonth:
+		return day.AddDate(0, 0, 1-day.Day())
+	case LeaderboardPeriodQuarter:
+		month := (int(day.Month())-1)/3*3 + 1
+		return time.Date(day.Year(), time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return day
+}
+
+// GetLeaderboardReq get leaderboard request
+type GetLeaderboardReq struct {
+	// week, month or quarter
+	Period string `validate:"required,oneof=week month quarter" form:"period"`
+	// tag slug name, empty means the whole site
+	Tag      string `validate:"omitempty,gt=0,lte=35" form:"tag"`
+	Page     int    `validate:"omitempty,min=1" form:"page"`
+	PageSize int    `validate:"omitempty,min=1,max=100" form:"page_size"`
+}
+
+// GetLeaderboardResp get leaderboard response
+type GetLeaderboardResp struct {
+	Period string `json:"period"`
+	// the start time of the period
+	StartTime int64 `json:"start_time"`
+	// the time of the last refresh, 0 means not refreshed yet
+	RefreshedAt int64              `json:"refreshed_at"`
+	Total       int64              `json:"count"`
+	List        []*LeaderboardItem `json:"list"`
+}
+
+// LeaderboardItem leaderboard item
+type LeaderboardItem struct {
+	Position   int           `json:"position"`
+	Reputation int           `json:"reputation"`
+	User       UserBasicInfo `json:"user"`
+}
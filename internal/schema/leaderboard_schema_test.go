@@ -0,0 +1,39 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeaderboardPeriodStart(t *testing.T) {
+	// Wednesday
+	now := time.Date(2024, 8, 14, 15, 4, 5, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, 8, 12, 0, 0, 0, 0, time.UTC), LeaderboardPeriodStart(LeaderboardPeriodWeek, now))
+	assert.Equal(t, time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC), LeaderboardPeriodStart(LeaderboardPeriodMonth, now))
+	assert.Equal(t, time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), LeaderboardPeriodStart(LeaderboardPeriodQuarter, now))
+
+	// Sunday belongs to the week started on Monday
+	sunday := time.Date(2024, 8, 18, 23, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, 8, 12, 0, 0, 0, 0, time.UTC), LeaderboardPeriodStart(LeaderboardPeriodWeek, sunday))
+}
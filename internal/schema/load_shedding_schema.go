@@ -0,0 +1,37 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// LoadSheddingMetricsResp load shedding metrics response
+type LoadSheddingMetricsResp struct {
+	Enabled bool `json:"enabled"`
+	// the server is overloaded now and the low priority requests are being queued or shed
+	Overloaded bool `json:"overloaded"`
+	// the number of requests being processed
+	InFlight int64 `json:"in_flight"`
+	// the number of low priority requests waiting in queue
+	QueueDepth int64 `json:"queue_depth"`
+	// the average latency of the requests finished in the last window
+	AvgLatencyMs int64 `json:"avg_latency_ms"`
+	// the counters since the server started
+	TotalRequests  int64 `json:"total_requests"`
+	QueuedRequests int64 `json:"queued_requests"`
+	ShedRequests   int64 `json:"shed_requests"`
+}
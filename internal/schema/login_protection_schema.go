@@ -0,0 +1,68 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import "time"
+
+// LoginFailure the failed login attempts of an account or ip
+type LoginFailure struct {
+	// the failed attempts since the last lockout
+	Failures int `json:"failures"`
+	// the times locked, the lockout is doubled each time
+	Lockouts    int   `json:"lockouts"`
+	LockedUntil int64 `json:"locked_until"`
+}
+
+// IsLocked whether it is locked at the time
+func (f *LoginFailure) IsLocked(now time.Time) bool {
+	return f.LockedUntil > now.Unix()
+}
+
+// AddFailure add a failed attempt, it is locked when the failures reach the max attempts,
+// the lockout is doubled for each lockout and not longer than the max lockout
+func (f *LoginFailure) AddFailure(now time.Time, maxAttempts int, lockout, maxLockout time.Duration) (locked bool) {
+	f.Failures++
+	if f.Failures < maxAttempts {
+		return false
+	}
+	f.Failures = 0
+	f.Lockouts++
+	duration := lockout
+	for i := 1; i < f.Lockouts && duration < maxLockout; i++ {
+		duration *= 2
+	}
+	duration = min(duration, maxLockout)
+	f.LockedUntil = now.Add(duration).Unix()
+	return true
+}
+
+// LoginLockedResp the login is locked until the time
+type LoginLockedResp struct {
+	LockedUntil int64 `json:"locked_until"`
+}
+
+// SuspiciousLoginTemplateData the email sent to the user when the account is locked
+type SuspiciousLoginTemplateData struct {
+	SiteName     string
+	DisplayName  string
+	IP           string
+	LockedUntil  string
+	PassResetUrl string
+}
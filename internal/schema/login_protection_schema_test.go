@@ -0,0 +1,49 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginFailure_AddFailure(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	f := &LoginFailure{}
+	for i := 0; i < 2; i++ {
+		assert.False(t, f.AddFailure(now, 3, 15*time.Minute, time.Hour))
+	}
+	assert.True(t, f.AddFailure(now, 3, 15*time.Minute, time.Hour))
+	assert.Equal(t, now.Add(15*time.Minute).Unix(), f.LockedUntil)
+	assert.True(t, f.IsLocked(now))
+	assert.False(t, f.IsLocked(now.Add(15*time.Minute)))
+
+	// the lockout is doubled then limited by the max lockout
+	expected := []time.Duration{30 * time.Minute, time.Hour, time.Hour}
+	for _, duration := range expected {
+		for i := 0; i < 2; i++ {
+			f.AddFailure(now, 3, 15*time.Minute, time.Hour)
+		}
+		assert.True(t, f.AddFailure(now, 3, 15*time.Minute, time.Hour))
+		assert.Equal(t, now.Add(duration).Unix(), f.LockedUntil)
+	}
+}
@@ -0,0 +1,52 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// MobilePushMaxDevices the max number of the registered devices of one user, the oldest is replaced
+const MobilePushMaxDevices = 20
+
+// MobilePushDeviceRegisterReq mobile push device register request
+type MobilePushDeviceRegisterReq struct {
+	// the platform of the token, fcm or apns
+	Platform   string `validate:"required,oneof=fcm apns" json:"platform"`
+	Token      string `validate:"required,lte=255" json:"token"`
+	DeviceName string `validate:"omitempty,lte=128" json:"device_name"`
+	UserID     string `json:"-"`
+}
+
+// MobilePushDeviceRemoveReq mobile push device remove request
+type MobilePushDeviceRemoveReq struct {
+	Token  string `validate:"required,lte=255" json:"token"`
+	UserID string `json:"-"`
+}
+
+// GetMobilePushPlatformsResp get mobile push platforms response
+type GetMobilePushPlatformsResp struct {
+	// the platforms supported by the enabled push provider plugins, the app should not register if its platform is absent
+	Platforms []string `json:"platforms"`
+}
+
+// MobilePushDeviceResp mobile push device response
+type MobilePushDeviceResp struct {
+	ID         string `json:"id"`
+	Platform   string `json:"platform"`
+	DeviceName string `json:"device_name"`
+	CreatedAt  int64  `json:"created_at"`
+}
@@ -28,6 +28,8 @@ type ExternalNotificationMsg struct {
 	ReceiverUserID string `json:"receiver_user_id"`
 	ReceiverEmail  string `json:"receiver_email"`
 	ReceiverLang   string `json:"receiver_lang"`
+	// the user triggering the notification, the receiver does not get it if muted the user
+	TriggerUserID string `json:"trigger_user_id,omitempty"`
 
 	NewAnswerTemplateRawData       *NewAnswerTemplateRawData       `json:"new_answer_template_raw_data,omitempty"`
 	NewInviteAnswerTemplateRawData *NewInviteAnswerTemplateRawData `json:"new_invite_answer_template_raw_data,omitempty"`
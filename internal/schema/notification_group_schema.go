@@ -0,0 +1,92 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/pkg/uid"
+)
+
+const (
+	// NotificationGroupWindow the unread notification updated in it absorbs the similar notifications
+	NotificationGroupWindow = 24 * time.Hour
+	// NotificationGroupMaxUsers the max number of the latest trigger users kept in the grouped notification
+	NotificationGroupMaxUsers = 3
+)
+
+// NotificationGroupKey get the group key of the notification, the answers of a question, the comments of a post
+// and the upvotes of a post are grouped, empty if the notification is not grouped
+func NotificationGroupKey(action string, objInfo *SimpleObjectInfo) string {
+	if objInfo == nil {
+		return ""
+	}
+	var objectID string
+	switch action {
+	case constant.NotificationAnswerTheQuestion, constant.NotificationCommentQuestion:
+		objectID = objInfo.QuestionID
+	case constant.NotificationCommentAnswer:
+		objectID = objInfo.AnswerID
+	case constant.NotificationUpVotedTheQuestion, constant.NotificationUpVotedTheAnswer,
+		constant.NotificationUpVotedTheComment:
+		objectID = objInfo.ObjectID
+	default:
+		return ""
+	}
+	objectID = uid.DeShortID(objectID)
+	if len(objectID) == 0 {
+		return ""
+	}
+	return strings.TrimPrefix(action, "notification.action.") + ":" + objectID
+}
+
+// NotificationGroupedAction get the translation key of the grouped notification action
+func NotificationGroupedAction(action string) string {
+	return strings.Replace(action, "notification.action.", "notification.action.grouped.", 1)
+}
+
+// MergeGroup merge the newer notification of the same group, the newer trigger user and object are shown
+func (n *NotificationContent) MergeGroup(newer *NotificationContent) {
+	if n.GroupCount == 0 {
+		n.GroupCount = 1
+	}
+	n.GroupCount++
+	users := make([]*UserBasicInfo, 0, NotificationGroupMaxUsers)
+	if newer.UserInfo != nil {
+		users = append(users, newer.UserInfo)
+	}
+	if len(n.GroupUsers) == 0 {
+		n.GroupUsers = []*UserBasicInfo{n.UserInfo}
+	}
+	for _, user := range n.GroupUsers {
+		if len(users) >= NotificationGroupMaxUsers {
+			break
+		}
+		if user != nil && (newer.UserInfo == nil || user.ID != newer.UserInfo.ID) {
+			users = append(users, user)
+		}
+	}
+	n.GroupUsers = users
+	n.UserInfo = newer.UserInfo
+	n.TriggerUserID = newer.TriggerUserID
+	n.ObjectInfo = newer.ObjectInfo
+}
@@ -0,0 +1,55 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationGroupKey(t *testing.T) {
+	objInfo := &SimpleObjectInfo{ObjectID: "10050000000000001", QuestionID: "10010000000000001", AnswerID: "10020000000000001"}
+	assert.Equal(t, "answer_the_question:10010000000000001",
+		NotificationGroupKey(constant.NotificationAnswerTheQuestion, objInfo))
+	assert.Equal(t, "comment_answer:10020000000000001",
+		NotificationGroupKey(constant.NotificationCommentAnswer, objInfo))
+	assert.Equal(t, "up_voted_comment:10050000000000001",
+		NotificationGroupKey(constant.NotificationUpVotedTheComment, objInfo))
+	assert.Empty(t, NotificationGroupKey(constant.NotificationAcceptAnswer, objInfo))
+	assert.Empty(t, NotificationGroupKey(constant.NotificationAnswerTheQuestion, nil))
+	assert.Equal(t, "notification.action.grouped.up_voted_answer",
+		NotificationGroupedAction(constant.NotificationUpVotedTheAnswer))
+}
+
+func TestNotificationContent_MergeGroup(t *testing.T) {
+	content := &NotificationContent{UserInfo: &UserBasicInfo{ID: "1"}}
+	for _, id := range []string{"2", "1", "3", "4"} {
+		content.MergeGroup(&NotificationContent{UserInfo: &UserBasicInfo{ID: id}, TriggerUserID: id})
+	}
+	assert.Equal(t, 5, content.GroupCount)
+	assert.Equal(t, "4", content.TriggerUserID)
+	ids := make([]string, 0)
+	for _, user := range content.GroupUsers {
+		ids = append(ids, user.ID)
+	}
+	assert.Equal(t, []string{"4", "3", "1"}, ids)
+}
@@ -0,0 +1,171 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"slices"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
+)
+
+// notificationEventPushEvents the events pushed to the browsers, the others are too noisy for push
+var notificationEventPushEvents = []string{
+	constant.NotificationEventAnswer,
+	constant.NotificationEventComment,
+	constant.NotificationEventMention,
+	constant.NotificationEventInvite,
+	constant.NotificationEventAccept,
+}
+
+// notificationEventEmailEvents the events having the email templates
+var notificationEventEmailEvents = []string{
+	constant.NotificationEventAnswer,
+	constant.NotificationEventComment,
+	constant.NotificationEventInvite,
+}
+
+// NotificationPreference the channels of the event type in the preference matrix
+type NotificationPreference struct {
+	Event   string `validate:"required,oneof=answer comment mention invite accept edit vote moderation achievement saved_search" json:"event"`
+	Inbox   bool   `json:"inbox"`
+	Email   bool   `json:"email"`
+	Push    bool   `json:"push"`
+	Webhook bool   `json:"webhook"`
+	// the channels the event can be delivered to, the others are always off
+	SupportedChannels []constant.NotificationChannelKey `json:"supported_channels"`
+}
+
+// Format turn off the unsupported channels and fill the supported channels
+func (p *NotificationPreference) Format() {
+	p.SupportedChannels = []constant.NotificationChannelKey{constant.InboxChannel}
+	if slices.Contains(notificationEventEmailEvents, p.Event) {
+		p.SupportedChannels = append(p.SupportedChannels, constant.EmailChannel)
+	} else {
+		p.Email = false
+	}
+	if slices.Contains(notificationEventPushEvents, p.Event) {
+		p.SupportedChannels = append(p.SupportedChannels, constant.WebPushChannel)
+	} else {
+		p.Push = false
+	}
+	p.SupportedChannels = append(p.SupportedChannels, constant.WebhookChannel)
+}
+
+// IsEnabled whether the channel of the event is enabled
+func (p *NotificationPreference) IsEnabled(channel constant.NotificationChannelKey) bool {
+	switch channel {
+	case constant.InboxChannel:
+		return p.Inbox
+	case constant.EmailChannel:
+		return p.Email
+	case constant.WebPushChannel:
+		return p.Push
+	case constant.WebhookChannel:
+		return p.Webhook
+	}
+	return false
+}
+
+// ToChannels convert to the channels stored in the user notification config
+func (p *NotificationPreference) ToChannels() NotificationChannels {
+	return NotificationChannels{
+		{Key: constant.InboxChannel, Enable: p.Inbox},
+		{Key: constant.EmailChannel, Enable: p.Email},
+		{Key: constant.WebPushChannel, Enable: p.Push},
+		{Key: constant.WebhookChannel, Enable: p.Webhook},
+	}
+}
+
+// NotificationPreferenceMatrix the channels of each event type of the user
+type NotificationPreferenceMatrix struct {
+	Preferences []*NotificationPreference `validate:"omitempty,dive" json:"preferences"`
+}
+
+// NewNotificationPreferenceMatrix new the preference matrix from the user notification configs,
+// the events never configured follow the email of inbox and the web push settings
+func NewNotificationPreferenceMatrix(configs []*entity.UserNotificationConfig) *NotificationPreferenceMatrix {
+	legacy := NewNotificationConfig(configs)
+	legacy.Format()
+	stored := make(map[string]NotificationChannels, len(configs))
+	for _, item := range configs {
+		stored[item.Source] = NewNotificationChannelsFormJson(item.Channels)
+	}
+
+	m := &NotificationPreferenceMatrix{}
+	for _, event := range constant.NotificationEvents {
+		p := &NotificationPreference{
+			Event:   event,
+			Inbox:   true,
+			Email:   legacy.Inbox.Enable,
+			Push:    legacy.WebPush.Enable,
+			Webhook: true,
+		}
+		if channels, ok := stored[string(constant.NotificationEventSource(event))]; ok {
+			p.Inbox, p.Email, p.Push, p.Webhook = false, false, false, false
+			for _, channel := range channels {
+				switch channel.Key {
+				case constant.InboxChannel:
+					p.Inbox = channel.Enable
+				case constant.EmailChannel:
+					p.Email = channel.Enable
+				case constant.WebPushChannel:
+					p.Push = channel.Enable
+				case constant.WebhookChannel:
+					p.Webhook = channel.Enable
+				}
+			}
+		}
+		p.Format()
+		m.Preferences = append(m.Preferences, p)
+	}
+	return m
+}
+
+// Get get the preference of the event type, nil if not found
+func (m *NotificationPreferenceMatrix) Get(event string) *NotificationPreference {
+	for _, p := range m.Preferences {
+		if p.Event == event {
+			return p
+		}
+	}
+	return nil
+}
+
+// Allowed whether the notification of the action can be delivered to the channel,
+// the actions out of the matrix are only delivered to the inbox and webhook
+func (m *NotificationPreferenceMatrix) Allowed(action string, channel constant.NotificationChannelKey) bool {
+	p := m.Get(constant.GetNotificationEvent(action))
+	if p == nil {
+		return channel == constant.InboxChannel || channel == constant.WebhookChannel
+	}
+	return p.IsEnabled(channel)
+}
+
+// UpdateNotificationPreferenceReq update the notification preference matrix request, the events not listed are kept
+type UpdateNotificationPreferenceReq struct {
+	NotificationPreferenceMatrix
+	UserID string `json:"-"`
+}
+
+// GetNotificationPreferenceResp get the notification preference matrix response
+type GetNotificationPreferenceResp struct {
+	NotificationPreferenceMatrix
+}
@@ -0,0 +1,58 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewNotificationPreferenceMatrix(t *testing.T) {
+	configs := []*entity.UserNotificationConfig{
+		{Source: string(constant.InboxSource), Channels: `[{"key":"email","enable":true}]`},
+		{Source: string(constant.NotificationEventSource(constant.NotificationEventComment)),
+			Channels: `[{"key":"inbox","enable":true},{"key":"email","enable":false},{"key":"web_push","enable":true}]`},
+		{Source: string(constant.NotificationEventSource(constant.NotificationEventVote)),
+			Channels: `[{"key":"inbox","enable":false},{"key":"email","enable":true}]`},
+	}
+	m := NewNotificationPreferenceMatrix(configs)
+	assert.Len(t, m.Preferences, len(constant.NotificationEvents))
+
+	// the events never configured follow the legacy settings
+	assert.True(t, m.Allowed(constant.NotificationAnswerTheQuestion, constant.EmailChannel))
+	assert.False(t, m.Allowed(constant.NotificationAnswerTheQuestion, constant.WebPushChannel))
+	assert.True(t, m.Allowed(constant.NotificationAnswerTheQuestion, constant.WebhookChannel))
+
+	assert.False(t, m.Allowed(constant.NotificationReplyToYou, constant.EmailChannel))
+	assert.True(t, m.Allowed(constant.NotificationReplyToYou, constant.WebPushChannel))
+	assert.False(t, m.Allowed(constant.NotificationReplyToYou, constant.WebhookChannel))
+
+	// the email of vote is not supported
+	assert.False(t, m.Allowed(constant.NotificationUpVotedTheAnswer, constant.InboxChannel))
+	assert.False(t, m.Allowed(constant.NotificationUpVotedTheAnswer, constant.EmailChannel))
+	assert.NotContains(t, m.Get(constant.NotificationEventVote).SupportedChannels, constant.EmailChannel)
+
+	// the actions out of the matrix are only delivered to the inbox and webhook
+	assert.True(t, m.Allowed("notification.action.unknown", constant.InboxChannel))
+	assert.False(t, m.Allowed("notification.action.unknown", constant.WebPushChannel))
+}
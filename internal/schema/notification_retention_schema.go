@@ -0,0 +1,41 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	// NotificationRetentionActionArchive move the notifications out of retention into the archive table
+	NotificationRetentionActionArchive = "archive"
+	// NotificationRetentionActionDelete delete the notifications out of retention permanently
+	NotificationRetentionActionDelete = "delete"
+	// NotificationRetentionBatchSize the notifications cleaned in one batch, the table is not locked for long
+	NotificationRetentionBatchSize = 1000
+	// NotificationRetentionMaxBatches the max batches cleaned in one run, the rest are cleaned in the next run
+	NotificationRetentionMaxBatches = 100
+)
+
+// FillDefault fill the default retention days and action if not set
+func (r *SiteNotificationRetentionResp) FillDefault() {
+	if r.ReadRetentionDays <= 0 {
+		r.ReadRetentionDays = 90
+	}
+	if len(r.Action) == 0 {
+		r.Action = NotificationRetentionActionArchive
+	}
+}
@@ -61,6 +61,9 @@ type NotificationContent struct {
 	Type               int            `json:"-"` //	1 inbox 2 achievement
 	IsRead             bool           `json:"is_read"`
 	UpdateTime         int64          `json:"update_time"`
+	// the number of the similar notifications merged, 0 if not grouped
+	GroupCount int              `json:"group_count,omitempty"`
+	GroupUsers []*UserBasicInfo `json:"group_users,omitempty"`
 }
 
 type GetRedDot struct {
@@ -93,6 +96,19 @@ type NotificationMsg struct {
 	ExtraInfo map[string]string
 }
 
+// NotificationFanOutMsg the notification sent to all followers of the question,
+// the content is resolved once when the notification is sent to the receiver and shared by all followers
+type NotificationFanOutMsg struct {
+	// the notification sent to the receiver
+	Msg        *NotificationMsg
+	QuestionID string
+	ObjectID   string
+	MsgType    int
+	Content    string
+	GroupKey   string
+	ObjectInfo *SimpleObjectInfo
+}
+
 type ObjectInfo struct {
 	Title      string            `json:"title"`
 	ObjectID   string            `json:"object_id"`
@@ -174,11 +190,24 @@ type NotificationSearch struct {
 	TypeStr      string `json:"type" form:"type"`             // inbox achievement
 	InboxTypeStr string `json:"inbox_type" form:"inbox_type"` // inbox achievement
 	InboxType    int    `json:"-" form:"-"`                   // inbox achievement
-	UserID       string `json:"-"`
+	// notification action, e.g. answer_the_question
+	Action string `validate:"omitempty,lte=100" json:"action" form:"action"`
+	// read or unread, empty means both
+	ReadState string `validate:"omitempty,oneof=read unread" json:"read_state" form:"read_state"`
+	// unix timestamp, the notification updated time range
+	StartTime int64 `validate:"omitempty,gte=0" json:"start_time" form:"start_time"`
+	EndTime   int64 `validate:"omitempty,gte=0" json:"end_time" form:"end_time"`
+	// search the object title of notification
+	Query  string `validate:"omitempty,lte=100" json:"q" form:"q"`
+	UserID string `json:"-"`
 }
 
 type NotificationClearRequest struct {
-	NotificationType  string `validate:"required,oneof=inbox achievement" json:"type"`
+	NotificationType string `validate:"required,oneof=inbox achievement" json:"type"`
+	// only mark the inbox notifications of this type as read, empty means all
+	InboxType string `validate:"omitempty,oneof=all posts invites votes" json:"inbox_type"`
+	// only mark the inbox notifications of this action as read, e.g. notification.action.up_voted_answer
+	Action            string `validate:"omitempty,lte=100" json:"action"`
 	UserID            string `json:"-"`
 	CanReviewQuestion bool   `json:"-"`
 	CanReviewAnswer   bool   `json:"-"`
@@ -189,3 +218,65 @@ type NotificationClearIDRequest struct {
 	UserID string `json:"-"`
 	ID     string `json:"id" form:"id"`
 }
+
+// NotificationDeleteRequest delete the notifications older than the days
+type NotificationDeleteRequest struct {
+	// only delete the notifications of this type, empty means all
+	NotificationType string `validate:"omitempty,oneof=inbox achievement" json:"type"`
+	OlderThanDays    int    `validate:"required,gte=1,lte=3650" json:"older_than_days"`
+	// the unread notifications are kept unless this is true
+	IncludeUnread bool   `json:"include_unread"`
+	UserID        string `json:"-"`
+}
+
+// NotificationDeleteResp delete notifications response
+type NotificationDeleteResp struct {
+	Deleted int64 `json:"deleted"`
+}
+
+// NotificationUnreadCountDTO unread notification count group by type and message type
+type NotificationUnreadCountDTO struct {
+	Type    int   `xorm:"type"`
+	MsgType int   `xorm:"msg_type"`
+	Count   int64 `xorm:"count"`
+}
+
+// NotificationInboxUnreadCount unread inbox notification count of each inbox type
+type NotificationInboxUnreadCount struct {
+	All     int64 `json:"all"`
+	Posts   int64 `json:"posts"`
+	Votes   int64 `json:"votes"`
+	Invites int64 `json:"invites"`
+}
+
+// NotificationUnreadCountResp unread notification count response
+type NotificationUnreadCountResp struct {
+	Total       int64                        `json:"total"`
+	Inbox       NotificationInboxUnreadCount `json:"inbox"`
+	Achievement int64                        `json:"achievement"`
+}
+
+// NewNotificationUnreadCountResp new unread notification count response from the count of each type
+func NewNotificationUnreadCountResp(counts []*NotificationUnreadCountDTO) *NotificationUnreadCountResp {
+	resp := &NotificationUnreadCountResp{}
+	for _, c := range counts {
+		switch c.Type {
+		case NotificationTypeInbox:
+			resp.Inbox.All += c.Count
+			switch c.MsgType {
+			case NotificationInboxTypePosts:
+				resp.Inbox.Posts += c.Count
+			case NotificationInboxTypeVotes:
+				resp.Inbox.Votes += c.Count
+			case NotificationInboxTypeInvites:
+				resp.Inbox.Invites += c.Count
+			}
+		case NotificationTypeAchievement:
+			resp.Achievement += c.Count
+		default:
+			continue
+		}
+		resp.Total += c.Count
+	}
+	return resp
+}
@@ -0,0 +1,39 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewNotificationUnreadCountResp(t *testing.T) {
+	resp := NewNotificationUnreadCountResp([]*NotificationUnreadCountDTO{
+		{Type: NotificationTypeInbox, MsgType: NotificationInboxTypePosts, Count: 3},
+		{Type: NotificationTypeInbox, MsgType: NotificationInboxTypeVotes, Count: 2},
+		{Type: NotificationTypeInbox, MsgType: NotificationInboxTypeAll, Count: 1},
+		{Type: NotificationTypeAchievement, Count: 4},
+		{Type: 99, Count: 100},
+	})
+	assert.Equal(t, int64(10), resp.Total)
+	assert.Equal(t, NotificationInboxUnreadCount{All: 6, Posts: 3, Votes: 2}, resp.Inbox)
+	assert.Equal(t, int64(4), resp.Achievement)
+}
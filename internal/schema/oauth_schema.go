@@ -0,0 +1,250 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/validator"
+	"github.com/segmentfault/pacman/errors"
+)
+
+const (
+	OAuthScopeOpenID  = "openid"
+	OAuthScopeProfile = "profile"
+	OAuthScopeEmail   = "email"
+
+	OAuthResponseTypeCode           = "code"
+	OAuthGrantTypeAuthorizationCode = "authorization_code"
+
+	// OAuthAuthorizationCodeTTL the authorization code is exchanged once in the ttl
+	OAuthAuthorizationCodeTTL = 10 * time.Minute
+	// OAuthAccessTokenTTL the ttl of the access token and the id token
+	OAuthAccessTokenTTL = time.Hour
+)
+
+// OAuthScopes the scopes supported
+var OAuthScopes = []string{OAuthScopeOpenID, OAuthScopeProfile, OAuthScopeEmail}
+
+// the error codes of RFC 6749 and OpenID Connect
+const (
+	OAuthErrorInvalidRequest          = "invalid_request"
+	OAuthErrorInvalidClient           = "invalid_client"
+	OAuthErrorInvalidGrant            = "invalid_grant"
+	OAuthErrorInvalidScope            = "invalid_scope"
+	OAuthErrorUnsupportedGrantType    = "unsupported_grant_type"
+	OAuthErrorUnsupportedResponseType = "unsupported_response_type"
+	OAuthErrorAccessDenied            = "access_denied"
+	OAuthErrorInvalidToken            = "invalid_token"
+	OAuthErrorServerError             = "server_error"
+)
+
+// OAuthError the error response of the token and userinfo endpoint
+type OAuthError struct {
+	Status      int    `json:"-"`
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+// NewOAuthError new OAuth error, the invalid client and token are unauthorized
+func NewOAuthError(code, description string) *OAuthError {
+	status := http.StatusBadRequest
+	switch code {
+	case OAuthErrorInvalidClient, OAuthErrorInvalidToken:
+		status = http.StatusUnauthorized
+	case OAuthErrorServerError:
+		status = http.StatusInternalServerError
+	}
+	return &OAuthError{Status: status, Code: code, Description: description}
+}
+
+func (e *OAuthError) Error() string {
+	return e.Code + ": " + e.Description
+}
+
+// OAuthClientItem the OAuth client
+type OAuthClientItem struct {
+	ID           string   `json:"id"`
+	ClientID     string   `json:"client_id"`
+	Name         string   `json:"name"`
+	RedirectURIs []string `json:"redirect_uris"`
+	CreatedAt    int64    `json:"created_at"`
+}
+
+// AddOAuthClientReq add OAuth client request
+type AddOAuthClientReq struct {
+	Name         string   `validate:"required,notblank,lte=100" json:"name"`
+	RedirectURIs []string `validate:"required,gte=1,lte=10,dive,required,lte=512" json:"redirect_uris"`
+}
+
+// Check the redirect uris must be absolute without fragment
+func (r *AddOAuthClientReq) Check() (errFields []*validator.FormErrorField, err error) {
+	return checkOAuthRedirectURIs(r.RedirectURIs)
+}
+
+// AddOAuthClientResp add OAuth client response, the client secret is only shown once
+type AddOAuthClientResp struct {
+	*OAuthClientItem
+	ClientSecret string `json:"client_secret"`
+}
+
+// UpdateOAuthClientReq update OAuth client request
+type UpdateOAuthClientReq struct {
+	ID           string   `validate:"required" json:"id"`
+	Name         string   `validate:"required,notblank,lte=100" json:"name"`
+	RedirectURIs []string `validate:"required,gte=1,lte=10,dive,required,lte=512" json:"redirect_uris"`
+}
+
+// Check the redirect uris must be absolute without fragment
+func (r *UpdateOAuthClientReq) Check() (errFields []*validator.FormErrorField, err error) {
+	return checkOAuthRedirectURIs(r.RedirectURIs)
+}
+
+// RegenerateOAuthClientSecretReq regenerate OAuth client secret request
+type RegenerateOAuthClientSecretReq struct {
+	ID string `validate:"required" json:"id"`
+}
+
+// RegenerateOAuthClientSecretResp regenerate OAuth client secret response, the previous secret is invalid immediately
+type RegenerateOAuthClientSecretResp struct {
+	ClientSecret string `json:"client_secret"`
+}
+
+// RemoveOAuthClientReq remove OAuth client request
+type RemoveOAuthClientReq struct {
+	ID string `validate:"required" json:"id"`
+}
+
+func checkOAuthRedirectURIs(redirectURIs []string) (errFields []*validator.FormErrorField, err error) {
+	for _, redirectURI := range redirectURIs {
+		u, err := url.Parse(redirectURI)
+		if err != nil || !u.IsAbs() || len(u.Host) == 0 || len(u.Fragment) > 0 {
+			errFields = append(errFields, &validator.FormErrorField{
+				ErrorField: "redirect_uris",
+				ErrorMsg:   reason.OAuthRedirectURIInvalid,
+			})
+			return errFields, errors.BadRequest(reason.OAuthRedirectURIInvalid)
+		}
+	}
+	return nil, nil
+}
+
+// OAuthAuthorizeReq the authorization request of the client, approved by the login user
+type OAuthAuthorizeReq struct {
+	ResponseType        string `validate:"required" form:"response_type" json:"response_type"`
+	ClientID            string `validate:"required" form:"client_id" json:"client_id"`
+	RedirectURI         string `validate:"required" form:"redirect_uri" json:"redirect_uri"`
+	Scope               string `validate:"omitempty,lte=256" form:"scope" json:"scope"`
+	State               string `validate:"omitempty,lte=1024" form:"state" json:"state"`
+	Nonce               string `validate:"omitempty,lte=256" form:"nonce" json:"nonce"`
+	CodeChallenge       string `validate:"omitempty,lte=128" form:"code_challenge" json:"code_challenge"`
+	CodeChallengeMethod string `validate:"omitempty,oneof=plain S256" form:"code_challenge_method" json:"code_challenge_method"`
+	// the user approved or denied the client
+	Approved bool   `form:"-" json:"approved"`
+	UserID   string `json:"-"`
+}
+
+// GetScopes get the scopes requested, the unsupported scopes are ignored
+func (r *OAuthAuthorizeReq) GetScopes() (scopes []string) {
+	for _, scope := range strings.Fields(r.Scope) {
+		for _, supported := range OAuthScopes {
+			if scope == supported {
+				scopes = append(scopes, scope)
+				break
+			}
+		}
+	}
+	return scopes
+}
+
+// OAuthAuthorizeInfoResp the client and the scopes shown to the user to approve
+type OAuthAuthorizeInfoResp struct {
+	ClientName string   `json:"client_name"`
+	Scopes     []string `json:"scopes"`
+}
+
+// OAuthAuthorizeResp the url the user is redirected to with the authorization code or the error
+type OAuthAuthorizeResp struct {
+	RedirectURL string `json:"redirect_url"`
+}
+
+// OAuthAuthorizationCode the authorization code cached to exchange for the tokens
+type OAuthAuthorizationCode struct {
+	ClientID            string `json:"client_id"`
+	UserID              string `json:"user_id"`
+	RedirectURI         string `json:"redirect_uri"`
+	Scope               string `json:"scope"`
+	Nonce               string `json:"nonce"`
+	CodeChallenge       string `json:"code_challenge"`
+	CodeChallengeMethod string `json:"code_challenge_method"`
+	AuthTime            int64  `json:"auth_time"`
+}
+
+// OAuthAccessToken the access token cached for the userinfo endpoint
+type OAuthAccessToken struct {
+	ClientID string `json:"client_id"`
+	UserID   string `json:"user_id"`
+	Scope    string `json:"scope"`
+}
+
+// OAuthTokenReq the token request, the client authenticates with the basic auth or the client id and secret in the form
+type OAuthTokenReq struct {
+	GrantType    string `form:"grant_type"`
+	Code         string `form:"code"`
+	RedirectURI  string `form:"redirect_uri"`
+	ClientID     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+	CodeVerifier string `form:"code_verifier"`
+}
+
+// OAuthTokenResp the token response
+type OAuthTokenResp struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Scope       string `json:"scope"`
+	IDToken     string `json:"id_token,omitempty"`
+}
+
+// OAuthUserInfo the claims of the user, the profile and email claims are only returned with the scopes
+type OAuthUserInfo struct {
+	Subject           string `json:"sub"`
+	Name              string `json:"name,omitempty"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+	Profile           string `json:"profile,omitempty"`
+	Picture           string `json:"picture,omitempty"`
+	Email             string `json:"email,omitempty"`
+	EmailVerified     *bool  `json:"email_verified,omitempty"`
+}
+
+// OAuthIDTokenClaims the claims of the id token
+type OAuthIDTokenClaims struct {
+	Issuer    string `json:"iss"`
+	Audience  string `json:"aud"`
+	ExpiresAt int64  `json:"exp"`
+	IssuedAt  int64  `json:"iat"`
+	AuthTime  int64  `json:"auth_time"`
+	Nonce     string `json:"nonce,omitempty"`
+	*OAuthUserInfo
+}
@@ -0,0 +1,41 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckOAuthRedirectURIs(t *testing.T) {
+	_, err := checkOAuthRedirectURIs([]string{"https://tool.example.com/callback", "http://localhost:8080/cb?tenant=1"})
+	assert.NoError(t, err)
+	_, err = checkOAuthRedirectURIs([]string{"/callback"})
+	assert.Error(t, err)
+	_, err = checkOAuthRedirectURIs([]string{"https://tool.example.com/callback#token"})
+	assert.Error(t, err)
+}
+
+func TestOAuthAuthorizeReq_GetScopes(t *testing.T) {
+	req := &OAuthAuthorizeReq{Scope: "openid  email offline_access profile"}
+	assert.Equal(t, []string{OAuthScopeOpenID, OAuthScopeEmail, OAuthScopeProfile}, req.GetScopes())
+	assert.Empty(t, (&OAuthAuthorizeReq{}).GetScopes())
+}
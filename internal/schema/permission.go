@@ -71,3 +71,67 @@ func (r *GetPermissionResp) TrTip(lang i18n.Language, requireRank int) {
 			lang, reason.NoEnoughRankToOperate, &PermissionTrTplData{Rank: requireRank})
 	}
 }
+
+const (
+	// PermissionCheckUserStatus the status of the user, only for reference, it is checked by the login and middleware
+	PermissionCheckUserStatus = "user_status"
+	// PermissionCheckRole the role of the user grants the action
+	PermissionCheckRole = "role"
+	// PermissionCheckObjectStatus the status of the object, only for reference, it is checked by the object service
+	PermissionCheckObjectStatus = "object_status"
+	// PermissionCheckOwnership the creator of the object can operate it
+	PermissionCheckOwnership = "ownership"
+	// PermissionCheckRank the reputation of the user reaches the threshold of the action
+	PermissionCheckRank = "rank"
+	// PermissionCheckTagModerator the moderator of the question tags has the tag moderation actions
+	PermissionCheckTagModerator = "tag_moderator"
+
+	// PermissionTraceAllow the check grants the action
+	PermissionTraceAllow = "allow"
+	// PermissionTracePass the check neither grants nor denies the action
+	PermissionTracePass = "pass"
+	// PermissionTraceSkip the check is not applicable to the action or object
+	PermissionTraceSkip = "skip"
+	// PermissionTraceInfo the check is only reported and does not affect the decision
+	PermissionTraceInfo = "info"
+)
+
+// ExplainPermissionReq explain permission request
+type ExplainPermissionReq struct {
+	UserID string `validate:"required" form:"user_id"`
+	// the permission action, e.g. question.edit
+	Action string `validate:"required,lte=64" form:"action"`
+	// the object the action operates, empty for the actions without object, e.g. question.add
+	ObjectID string `validate:"omitempty" form:"object_id"`
+}
+
+// ExplainPermissionResp explain permission response
+type ExplainPermissionResp struct {
+	Allowed bool                   `json:"allowed"`
+	Action  string                 `json:"action"`
+	Steps   []*PermissionTraceStep `json:"steps"`
+}
+
+// PermissionTraceStep the result of one check in the permission decision
+type PermissionTraceStep struct {
+	Check  string `json:"check" enums:"user_status,role,object_status,ownership,rank,tag_moderator"`
+	Result string `json:"result" enums:"allow,pass,skip,info"`
+	// the values the check based on, e.g. the rank of the user and the required rank
+	Data map[string]any `json:"data"`
+}
+
+// AddStep add the step to the trace
+func (r *ExplainPermissionResp) AddStep(check, result string, data map[string]any) {
+	r.Steps = append(r.Steps, &PermissionTraceStep{Check: check, Result: result, Data: data})
+}
+
+// Decide decide the action is allowed if any check grants it, the same as CheckOperationPermission
+func (r *ExplainPermissionResp) Decide() {
+	r.Allowed = false
+	for _, step := range r.Steps {
+		if step.Result == PermissionTraceAllow {
+			r.Allowed = true
+			return
+		}
+	}
+}
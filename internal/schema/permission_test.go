@@ -0,0 +1,43 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplainPermissionResp_Decide(t *testing.T) {
+	resp := &ExplainPermissionResp{}
+	resp.AddStep(PermissionCheckUserStatus, PermissionTraceInfo, nil)
+	resp.AddStep(PermissionCheckRole, PermissionTracePass, nil)
+	resp.AddStep(PermissionCheckRank, PermissionTracePass, nil)
+	resp.Decide()
+	assert.False(t, resp.Allowed)
+
+	resp.AddStep(PermissionCheckTagModerator, PermissionTraceAllow, nil)
+	resp.Decide()
+	assert.True(t, resp.Allowed)
+
+	resp.AddStep(PermissionCheckObjectStatus, PermissionTraceInfo, nil)
+	resp.Decide()
+	assert.True(t, resp.Allowed)
+}
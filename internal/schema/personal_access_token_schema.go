@@ -0,0 +1,118 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"net/http"
+	"strings"
+)
+
+const (
+	// PersonalAccessTokenScopeRead read the api with the GET requests
+	PersonalAccessTokenScopeRead = "read"
+	// PersonalAccessTokenScopeWriteAnswers add, update and delete the answers and comments
+	PersonalAccessTokenScopeWriteAnswers = "write:answers"
+	// PersonalAccessTokenScopeModerate review the posts and reports, close, reopen and recover the posts
+	PersonalAccessTokenScopeModerate = "moderate"
+	// PersonalAccessTokenScopeAdmin the full access including the admin api, it grants all the other scopes
+	PersonalAccessTokenScopeAdmin = "admin"
+
+	// PersonalAccessTokenMaxAmount the max tokens of user
+	PersonalAccessTokenMaxAmount = 20
+)
+
+// personalAccessTokenWriteAnswersPaths the api paths under /answer/api/v1 written with write:answers, the sub paths included
+var personalAccessTokenWriteAnswersPaths = []string{"/answer", "/comment", "/file", "/post/render"}
+
+// personalAccessTokenModeratePaths the api paths under /answer/api/v1 written with moderate
+var personalAccessTokenModeratePaths = []string{
+	"/review/pending/post", "/revisions/audit", "/report/review", "/report/appeal/review",
+	"/question/status", "/question/operation", "/question/reopen", "/question/recover",
+	"/tag/recover", "/tag/merge", "/tag/synonym", "/tag/deprecation",
+}
+
+// PersonalAccessTokenRequiredScope the scope required by the request of the route path,
+// the admin api and the writes not covered by the other scopes require the admin scope
+func PersonalAccessTokenRequiredScope(method, routePath string) string {
+	if strings.Contains(routePath, "/answer/admin/api") {
+		return PersonalAccessTokenScopeAdmin
+	}
+	if method == http.MethodGet || method == http.MethodHead {
+		return PersonalAccessTokenScopeRead
+	}
+	if i := strings.Index(routePath, "/answer/api/v1"); i >= 0 {
+		routePath = routePath[i+len("/answer/api/v1"):]
+	}
+	for _, path := range personalAccessTokenModeratePaths {
+		if routePath == path {
+			return PersonalAccessTokenScopeModerate
+		}
+	}
+	for _, path := range personalAccessTokenWriteAnswersPaths {
+		if routePath == path || strings.HasPrefix(routePath, path+"/") {
+			return PersonalAccessTokenScopeWriteAnswers
+		}
+	}
+	return PersonalAccessTokenScopeAdmin
+}
+
+// PersonalAccessTokenHasScope whether the scopes grant the scope, the admin scope grants all
+func PersonalAccessTokenHasScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope || s == PersonalAccessTokenScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// AddPersonalAccessTokenReq add personal access token request
+type AddPersonalAccessTokenReq struct {
+	Name   string   `validate:"required,notblank,lte=100" json:"name"`
+	Scopes []string `validate:"required,gte=1,lte=4,dive,oneof=read write:answers moderate admin" json:"scopes"`
+	// the token expires after the days, it never expires if 0
+	ExpiryDays int    `validate:"omitempty,gte=1,lte=365" json:"expiry_days"`
+	UserID     string `json:"-"`
+	RoleID     int    `json:"-"`
+}
+
+// AddPersonalAccessTokenResp add personal access token response, the token is only shown once
+type AddPersonalAccessTokenResp struct {
+	*PersonalAccessTokenItem
+	Token string `json:"token"`
+}
+
+// RemovePersonalAccessTokenReq revoke personal access token request
+type RemovePersonalAccessTokenReq struct {
+	ID     string `validate:"required" json:"id"`
+	UserID string `json:"-"`
+}
+
+// PersonalAccessTokenItem the personal access token
+type PersonalAccessTokenItem struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	CreatedAt int64    `json:"created_at"`
+	// the time the token expires, 0 means never
+	ExpiredAt int64 `json:"expired_at"`
+	// the last time the token is used to authenticate, 0 means never
+	LastUsedAt int64 `json:"last_used_at"`
+}
@@ -0,0 +1,52 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersonalAccessTokenRequiredScope(t *testing.T) {
+	assert.Equal(t, PersonalAccessTokenScopeRead,
+		PersonalAccessTokenRequiredScope(http.MethodGet, "/answer/api/v1/question/info"))
+	assert.Equal(t, PersonalAccessTokenScopeWriteAnswers,
+		PersonalAccessTokenRequiredScope(http.MethodPost, "/answer/api/v1/answer"))
+	assert.Equal(t, PersonalAccessTokenScopeWriteAnswers,
+		PersonalAccessTokenRequiredScope(http.MethodPost, "/base/answer/api/v1/answer/acceptance"))
+	assert.Equal(t, PersonalAccessTokenScopeWriteAnswers,
+		PersonalAccessTokenRequiredScope(http.MethodPatch, "/answer/api/v1/file/chunked/:upload_id"))
+	assert.Equal(t, PersonalAccessTokenScopeModerate,
+		PersonalAccessTokenRequiredScope(http.MethodPut, "/answer/api/v1/question/status"))
+	assert.Equal(t, PersonalAccessTokenScopeAdmin,
+		PersonalAccessTokenRequiredScope(http.MethodPost, "/answer/api/v1/question"))
+	assert.Equal(t, PersonalAccessTokenScopeAdmin,
+		PersonalAccessTokenRequiredScope(http.MethodGet, "/answer/admin/api/users/page"))
+}
+
+func TestPersonalAccessTokenHasScope(t *testing.T) {
+	assert.True(t, PersonalAccessTokenHasScope([]string{PersonalAccessTokenScopeRead}, PersonalAccessTokenScopeRead))
+	assert.False(t, PersonalAccessTokenHasScope([]string{PersonalAccessTokenScopeRead}, PersonalAccessTokenScopeWriteAnswers))
+	assert.False(t, PersonalAccessTokenHasScope([]string{PersonalAccessTokenScopeModerate}, PersonalAccessTokenScopeRead))
+	assert.True(t, PersonalAccessTokenHasScope([]string{PersonalAccessTokenScopeAdmin}, PersonalAccessTokenScopeModerate))
+	assert.False(t, PersonalAccessTokenHasScope(nil, PersonalAccessTokenScopeRead))
+}
@@ -0,0 +1,62 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// QuestionCloseVoteReq vote to close question request
+type QuestionCloseVoteReq struct {
+	QuestionID string `validate:"required" json:"question_id"`
+	CloseType  int    `validate:"required" json:"close_type"`
+	CloseMsg   string `validate:"omitempty,lte=4096" json:"close_msg"`
+	UserID     string `json:"-"`
+}
+
+// QuestionReopenVoteReq vote to reopen question request
+type QuestionReopenVoteReq struct {
+	QuestionID string `validate:"required" json:"question_id"`
+	UserID     string `json:"-"`
+}
+
+// RevokeQuestionCloseVoteReq revoke the close or reopen vote request
+type RevokeQuestionCloseVoteReq struct {
+	QuestionID string `validate:"required" json:"question_id"`
+	UserID     string `json:"-"`
+}
+
+// GetQuestionCloseVoteReq get question close vote status request
+type GetQuestionCloseVoteReq struct {
+	QuestionID string `validate:"required" form:"question_id"`
+	UserID     string `json:"-"`
+}
+
+// QuestionCloseVoteResp question close vote status response
+type QuestionCloseVoteResp struct {
+	// vote type that can be cast now, close or reopen
+	VoteType string `json:"vote_type"`
+	// the number of active votes in the current window
+	VoteCount int64 `json:"vote_count"`
+	// the number of votes required to close or reopen
+	VotesRequired int `json:"votes_required"`
+	// the window in days during which the votes are counted
+	VoteWindowDays int `json:"vote_window_days"`
+	// whether the current user has voted
+	Voted bool `json:"voted"`
+	// whether the question status was changed by this vote
+	Applied bool `json:"applied"`
+}
@@ -0,0 +1,64 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	QuestionQualityStatusPending  = "pending"
+	QuestionQualityStatusClaimed  = "claimed"
+	QuestionQualityStatusResolved = "resolved"
+)
+
+// GetQuestionQualityQueueReq get the needs improvement queue, the lowest scored first
+type GetQuestionQualityQueueReq struct {
+	Page     int `validate:"omitempty,min=1" form:"page"`
+	PageSize int `validate:"omitempty,min=1,max=100" form:"page_size"`
+	// only the items claimed by me
+	Mine             bool   `form:"mine"`
+	UserID           string `json:"-"`
+	IsAdminModerator bool   `json:"-"`
+}
+
+// QuestionQualityQueueItem the question in needs improvement queue
+type QuestionQualityQueueItem struct {
+	ID         string         `json:"id"`
+	QuestionID string         `json:"question_id"`
+	Title      string         `json:"title"`
+	UrlTitle   string         `json:"url_title"`
+	Score      int            `json:"score"`
+	Reasons    []string       `json:"reasons"`
+	Status     string         `json:"status"`
+	ClaimedBy  *UserBasicInfo `json:"claimed_by,omitempty"`
+	ClaimedAt  int64          `json:"claimed_at"`
+	CreatedAt  int64          `json:"created_at"`
+}
+
+// ClaimQuestionQualityReq claim the queue item to improve the question
+type ClaimQuestionQualityReq struct {
+	ID               string `validate:"required" json:"id"`
+	UserID           string `json:"-"`
+	IsAdminModerator bool   `json:"-"`
+}
+
+// ReleaseQuestionQualityReq release the claimed queue item
+type ReleaseQuestionQualityReq struct {
+	ID               string `validate:"required" json:"id"`
+	UserID           string `json:"-"`
+	IsAdminModerator bool   `json:"-"`
+}
@@ -20,15 +20,18 @@
 package schema
 
 import (
+	"context"
 	"strings"
 	"time"
 
+	"github.com/apache/answer/internal/base/handler"
 	"github.com/apache/answer/internal/base/reason"
 	"github.com/segmentfault/pacman/errors"
 
 	"github.com/apache/answer/internal/base/validator"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/pkg/converter"
+	"github.com/apache/answer/pkg/htmltext"
 	"github.com/apache/answer/pkg/uid"
 )
 
@@ -53,7 +56,9 @@ type CloseQuestionReq struct {
 	ID        string `validate:"required" json:"id"`
 	CloseType int    `json:"close_type"` // close_type
 	CloseMsg  string `json:"close_msg"`  // close_type
-	UserID    string `json:"-"`          // user_id
+	// the name of destination suggested to repost the off-topic question
+	MigrationDestination string `validate:"omitempty,lte=100" json:"migration_destination"`
+	UserID               string `json:"-"` // user_id
 }
 
 type OperationQuestionReq struct {
@@ -65,8 +70,18 @@ type OperationQuestionReq struct {
 }
 
 type CloseQuestionMeta struct {
-	CloseType int    `json:"close_type"`
-	CloseMsg  string `json:"close_msg"`
+	CloseType            int    `json:"close_type"`
+	CloseMsg             string `json:"close_msg"`
+	MigrationDestination string `json:"migration_destination,omitempty"`
+}
+
+// QuestionMigrationSuggestion the destination suggested to repost the closed question,
+// the repost url is only shown to the author
+type QuestionMigrationSuggestion struct {
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	Description string `json:"description"`
+	RepostURL   string `json:"repost_url,omitempty"`
 }
 
 // ReopenQuestionReq reopen question request
@@ -77,13 +92,13 @@ type ReopenQuestionReq struct {
 
 type QuestionAdd struct {
 	// question title
-	Title string `validate:"required,notblank,gte=6,lte=150" json:"title"`
+	Title string `validate:"required,notblank,gte=6,lte=150" limit:"title" json:"title"`
 	// content
-	Content string `validate:"required,notblank,gte=6,lte=65535" json:"content"`
+	Content string `validate:"required,notblank,gte=6" limit:"content" json:"content"`
 	// html
 	HTML string `json:"-"`
 	// tags
-	Tags []*TagItem `validate:"required,dive" json:"tags"`
+	Tags []*TagItem `validate:"required,dive" limit:"tags" json:"tags"`
 	// user id
 	UserID string `json:"-"`
 	QuestionPermission
@@ -111,15 +126,15 @@ func (req *QuestionAdd) Check() (errFields []*validator.FormErrorField, err erro
 
 type QuestionAddByAnswer struct {
 	// question title
-	Title string `validate:"required,notblank,gte=6,lte=150" json:"title"`
+	Title string `validate:"required,notblank,gte=6,lte=150" limit:"title" json:"title"`
 	// content
-	Content string `validate:"required,notblank,gte=6,lte=65535" json:"content"`
+	Content string `validate:"required,notblank,gte=6" limit:"content" json:"content"`
 	// html
 	HTML          string `json:"-"`
-	AnswerContent string `validate:"required,notblank,gte=6,lte=65535" json:"answer_content"`
+	AnswerContent string `validate:"required,notblank,gte=6" limit:"content" json:"answer_content"`
 	AnswerHTML    string `json:"-"`
 	// tags
-	Tags []*TagItem `validate:"required,dive" json:"tags"`
+	Tags []*TagItem `validate:"required,dive" limit:"tags" json:"tags"`
 	// user id
 	UserID              string   `json:"-"`
 	MentionUsernameList []string `validate:"omitempty" json:"mention_username_list"`
@@ -193,19 +208,21 @@ type QuestionUpdate struct {
 	// question id
 	ID string `validate:"required" json:"id"`
 	// question title
-	Title string `validate:"required,notblank,gte=6,lte=150" json:"title"`
+	Title string `validate:"required,notblank,gte=6,lte=150" limit:"title" json:"title"`
 	// content
-	Content string `validate:"required,notblank,gte=6,lte=65535" json:"content"`
+	Content string `validate:"required,notblank,gte=6" limit:"content" json:"content"`
 	// html
 	HTML       string   `json:"-"`
 	InviteUser []string `validate:"omitempty"  json:"invite_user"`
 	// tags
-	Tags []*TagItem `validate:"required,dive" json:"tags"`
+	Tags []*TagItem `validate:"required,dive" limit:"tags" json:"tags"`
 	// edit summary
 	EditSummary string `validate:"omitempty" json:"edit_summary"`
 	// user id
 	UserID       string `json:"-"`
 	NoNeedReview bool   `json:"-"`
+	// the update is synced from the source of mirrored question
+	SyndicationSync bool `json:"-"`
 	QuestionPermission
 	CaptchaID   string `json:"captcha_id"` // captcha_id
 	CaptchaCode string `json:"captcha_code"`
@@ -249,40 +266,53 @@ type QuestionBaseInfo struct {
 }
 
 type QuestionInfoResp struct {
-	ID                   string         `json:"id" `
-	Title                string         `json:"title"`
-	UrlTitle             string         `json:"url_title"`
-	Content              string         `json:"content"`
-	HTML                 string         `json:"html"`
-	Description          string         `json:"description"`
-	Tags                 []*TagResp     `json:"tags"`
-	ViewCount            int            `json:"view_count"`
-	UniqueViewCount      int            `json:"unique_view_count"`
-	VoteCount            int            `json:"vote_count"`
-	AnswerCount          int            `json:"answer_count"`
-	CollectionCount      int            `json:"collection_count"`
-	FollowCount          int            `json:"follow_count"`
-	AcceptedAnswerID     string         `json:"accepted_answer_id"`
-	LastAnswerID         string         `json:"last_answer_id"`
-	CreateTime           int64          `json:"create_time"`
-	UpdateTime           int64          `json:"-"`
-	PostUpdateTime       int64          `json:"update_time"`
-	QuestionUpdateTime   int64          `json:"edit_time"`
-	Pin                  int            `json:"pin"`
-	Show                 int            `json:"show"`
-	Status               int            `json:"status"`
-	Operation            *Operation     `json:"operation,omitempty"`
-	UserID               string         `json:"-"`
-	LastEditUserID       string         `json:"-"`
-	LastAnsweredUserID   string         `json:"-"`
-	UserInfo             *UserBasicInfo `json:"user_info"`
-	UpdateUserInfo       *UserBasicInfo `json:"update_user_info,omitempty"`
-	LastAnsweredUserInfo *UserBasicInfo `json:"last_answered_user_info,omitempty"`
-	Answered             bool           `json:"answered"`
-	FirstAnswerId        string         `json:"first_answer_id"`
-	Collected            bool           `json:"collected"`
-	VoteStatus           string         `json:"vote_status"`
-	IsFollowed           bool           `json:"is_followed"`
+	ID                  string                       `json:"id" `
+	Title               string                       `json:"title"`
+	UrlTitle            string                       `json:"url_title"`
+	Content             string                       `json:"content"`
+	HTML                string                       `json:"html"`
+	Description         string                       `json:"description"`
+	Tags                []*TagResp                   `json:"tags"`
+	ViewCount           int                          `json:"view_count"`
+	UniqueViewCount     int                          `json:"unique_view_count"`
+	VoteCount           int                          `json:"vote_count"`
+	AnswerCount         int                          `json:"answer_count"`
+	CollectionCount     int                          `json:"collection_count"`
+	FollowCount         int                          `json:"follow_count"`
+	AcceptedAnswerID    string                       `json:"accepted_answer_id"`
+	PinnedAnswerID      string                       `json:"pinned_answer_id"`
+	ResolutionNote      string                       `json:"resolution_note"`
+	LastAnswerID        string                       `json:"last_answer_id"`
+	CreateTime          int64                        `json:"create_time"`
+	UpdateTime          int64                        `json:"-"`
+	PostUpdateTime      int64                        `json:"update_time"`
+	QuestionUpdateTime  int64                        `json:"edit_time"`
+	Pin                 int                          `json:"pin"`
+	Show                int                          `json:"show"`
+	Status              int                          `json:"status"`
+	Operation           *Operation                   `json:"operation,omitempty"`
+	MigrationSuggestion *QuestionMigrationSuggestion `json:"migration_suggestion,omitempty"`
+	// the questions linked in this question and its answers
+	LinkedQuestions []*QuestionLinkItem `json:"linked_questions"`
+	// the questions referencing this question in them or their answers
+	ReferencedBy []*QuestionLinkItem `json:"referenced_by"`
+	// the question this question was closed as a duplicate of
+	DuplicateOf *QuestionLinkItem `json:"duplicate_of,omitempty"`
+	// the questions closed as duplicates of this question
+	Duplicates           []*QuestionLinkItem `json:"duplicates"`
+	UserID               string              `json:"-"`
+	LastEditUserID       string              `json:"-"`
+	LastAnsweredUserID   string              `json:"-"`
+	UserInfo             *UserBasicInfo      `json:"user_info"`
+	UpdateUserInfo       *UserBasicInfo      `json:"update_user_info,omitempty"`
+	LastAnsweredUserInfo *UserBasicInfo      `json:"last_answered_user_info,omitempty"`
+	Answered             bool                `json:"answered"`
+	FirstAnswerId        string              `json:"first_answer_id"`
+	Collected            bool                `json:"collected"`
+	VoteStatus           string              `json:"vote_status"`
+	IsFollowed           bool                `json:"is_followed"`
+	// the source of mirrored question, nil if the question is not a mirror
+	Syndication *QuestionSyndicationInfo `json:"syndication,omitempty"`
 
 	// MemberActions
 	MemberActions  []*PermissionMemberAction `json:"member_actions"`
@@ -391,6 +421,8 @@ type QuestionPageReq struct {
 	Tag       string `validate:"omitempty,gt=0,lte=100" form:"tag"`
 	Username  string `validate:"omitempty,gt=0,lte=100" form:"username"`
 	InDays    int    `validate:"omitempty,min=1" form:"in_days"`
+	// the slug of the space, the questions are limited to the space if no tag
+	Space string `validate:"omitempty,gt=0,lte=35" form:"space"`
 
 	LoginUserID      string `json:"-"`
 	UserIDBeSearched string `json:"-"`
@@ -466,11 +498,14 @@ func (req *AdminQuestionPageReq) Check() (errField []*validator.FormErrorField,
 
 // AdminAnswerPageReq admin answer page req
 type AdminAnswerPageReq struct {
-	Page          int    `validate:"omitempty,min=1" form:"page"`
-	PageSize      int    `validate:"omitempty,min=1" form:"page_size"`
-	StatusCond    string `validate:"omitempty,oneof=normal deleted pending" form:"status"`
-	Query         string `validate:"omitempty,gt=0,lte=100" form:"query"`
-	QuestionID    string `validate:"omitempty,gt=0,lte=24" form:"question_id"`
+	Page       int    `validate:"omitempty,min=1" form:"page"`
+	PageSize   int    `validate:"omitempty,min=1" form:"page_size"`
+	StatusCond string `validate:"omitempty,oneof=normal deleted pending" form:"status"`
+	Query      string `validate:"omitempty,gt=0,lte=100" form:"query"`
+	QuestionID string `validate:"omitempty,gt=0,lte=24" form:"question_id"`
+	// filter the answers by provenance, ai_assisted, imported or sourced
+	Provenance    string `validate:"omitempty,oneof=ai_assisted imported sourced" form:"provenance"`
+	ImportBatch   string `validate:"omitempty,lte=64" form:"import_batch"`
 	QuestionTitle string `json:"-"`
 	AnswerID      string `json:"-"`
 	Status        int    `json:"-"`
@@ -545,3 +580,61 @@ type GetQuestionLinkReq struct {
 type GetQuestionLinkResp struct {
 	QuestionPageResp
 }
+
+// QuestionLinkItem the question linked with, the answer id is set if the link is from or to its answer
+type QuestionLinkItem struct {
+	QuestionID string `json:"question_id"`
+	AnswerID   string `json:"answer_id,omitempty"`
+	Title      string `json:"title"`
+	UrlTitle   string `json:"url_title"`
+	Status     int    `json:"status"`
+	// link type: url, mention or duplicate
+	LinkType string `json:"link_type"`
+}
+
+// NewQuestionLinkItem new question link item
+func NewQuestionLinkItem(ctx context.Context, question *entity.Question, answerID, linkType string) *QuestionLinkItem {
+	item := &QuestionLinkItem{
+		QuestionID: uid.DeShortID(question.ID),
+		Title:      question.Title,
+		UrlTitle:   htmltext.UrlTitle(question.Title),
+		Status:     question.Status,
+		LinkType:   linkType,
+	}
+	if answerID != "0" {
+		item.AnswerID = answerID
+	}
+	if handler.GetEnableShortID(ctx) {
+		item.QuestionID = uid.EnShortID(item.QuestionID)
+		if len(item.AnswerID) > 0 {
+			item.AnswerID = uid.EnShortID(item.AnswerID)
+		}
+	}
+	return item
+}
+
+const (
+	QuestionExportFormatPDF      = "pdf"
+	QuestionExportFormatMarkdown = "md"
+)
+
+// ExportQuestionReq export question with its answers as a document
+type ExportQuestionReq struct {
+	// question id
+	ID string `json:"-"`
+	// export format, pdf or md
+	Format string `validate:"omitempty,oneof=pdf md" form:"format"`
+	// the max number of answers, the accepted answer is first, and then the top voted
+	AnswerCount int `validate:"omitempty,gte=1,lte=20" form:"answer_count"`
+	// login user id
+	UserID string `json:"-"`
+	// whether the user can view the deleted or pending question
+	CanViewDeleted bool `json:"-"`
+}
+
+// ExportQuestionResp export question response
+type ExportQuestionResp struct {
+	FileName    string
+	ContentType string
+	Data        []byte
+}
@@ -54,3 +54,81 @@ type GetRankPersonalPageResp struct {
 	// rank type
 	RankType string `json:"rank_type"`
 }
+
+// ReputationRecalcReq reputation recalculation request
+type ReputationRecalcReq struct {
+	// only recalculate the users whose id is greater than this, used to resume the interrupted recalculation
+	StartUserID string `validate:"omitempty,gt=0" json:"start_user_id"`
+	// only recalculate the users who have activities changed since this time, unix timestamp in seconds
+	Since int64 `validate:"omitempty,gte=0" json:"since"`
+	// only report the users whose reputation would be changed without updating them
+	DryRun bool `json:"dry_run"`
+	// the number of users recalculated in one batch
+	BatchSize int `validate:"omitempty,gte=1,lte=10000" json:"batch_size"`
+}
+
+// ReputationRecalcProgress reputation recalculation progress
+type ReputationRecalcProgress struct {
+	Running bool `json:"running"`
+	DryRun  bool `json:"dry_run"`
+	// the total number of users need to be recalculated
+	Total int64 `json:"total"`
+	// the number of users have been recalculated
+	Processed int64 `json:"processed"`
+	// the number of users whose reputation is changed
+	Changed int64 `json:"changed"`
+	// the last user id has been recalculated, it can be used as start user id to resume
+	LastUserID string `json:"last_user_id"`
+	StartedAt  int64  `json:"started_at"`
+	FinishedAt int64  `json:"finished_at"`
+	Error      string `json:"error"`
+}
+
+// RankHistoryExportMaxRows the max rows of the reputation history can be exported at once
+const RankHistoryExportMaxRows = 50000
+
+// GetRankHistoryReq get reputation history request
+type GetRankHistoryReq struct {
+	// page
+	Page int `validate:"omitempty,min=1" form:"page"`
+	// page size
+	PageSize int `validate:"omitempty,min=1,max=100" form:"page_size"`
+	// rank type, the activity key, e.g. question.voted_up
+	RankType string `validate:"omitempty,lte=100" form:"rank_type"`
+	// start time, unix timestamp in seconds
+	StartTime int64 `validate:"omitempty,gte=0" form:"start_time"`
+	// end time, unix timestamp in seconds
+	EndTime int64 `validate:"omitempty,gte=0" form:"end_time"`
+	// user id
+	UserID string `json:"-"`
+	// the activity type of rank type
+	ActivityType int `json:"-"`
+}
+
+// GetRankHistoryResp reputation history response
+type GetRankHistoryResp struct {
+	// create time
+	CreatedAt int64 `json:"created_at"`
+	// object id
+	ObjectID string `json:"object_id"`
+	// question id
+	QuestionID string `json:"question_id"`
+	// answer id
+	AnswerID string `json:"answer_id"`
+	// object type
+	ObjectType string `json:"object_type" enums:"question,answer,tag,comment"`
+	// title
+	Title string `json:"title"`
+	// url title
+	UrlTitle string `json:"url_title"`
+	// reputation
+	Reputation int `json:"reputation"`
+	// rank type key, e.g. question.voted_up
+	RankTypeKey string `json:"rank_type_key"`
+	// rank type
+	RankType string `json:"rank_type"`
+	// the reputation is cancelled, e.g. the vote is revoked
+	Cancelled bool `json:"cancelled"`
+	// cancelled time
+	CancelledAt int64 `json:"cancelled_at"`
+}
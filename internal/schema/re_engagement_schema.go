@@ -0,0 +1,125 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/validator"
+	"github.com/segmentfault/pacman/errors"
+)
+
+const (
+	// ReEngagementCampaignNewAnswers email the authors whose old questions gained new answers
+	ReEngagementCampaignNewAnswers = "new_answers"
+	// ReEngagementCampaignInactiveUser email the inactive users with a summary of new questions in their followed tags
+	ReEngagementCampaignInactiveUser = "inactive_user"
+	// ReEngagementMaxSummaryQuestions the max number of questions listed in one email
+	ReEngagementMaxSummaryQuestions = 10
+)
+
+// ReEngagementCampaign the re-engagement email campaign
+type ReEngagementCampaign struct {
+	Type    string `validate:"required,oneof=new_answers inactive_user" json:"type"`
+	Enabled bool   `json:"enabled"`
+	// the question older than it, or the user inactive for it, is emailed
+	Days int `validate:"omitempty,gte=1,lte=3650" json:"days"`
+	// the max number of emails sent in one run, the rest are sent in the next run
+	MaxEmailsPerRun int `validate:"omitempty,gte=1,lte=10000" json:"max_emails_per_run"`
+	// the user receives at most one email of the campaign in the cooldown days,
+	// and the new answers email covers the answers posted in the cooldown days
+	CooldownDays int `validate:"omitempty,gte=1,lte=365" json:"cooldown_days"`
+	// the go templates of subject and body with ReEngagementTemplateData, the default templates are used if empty
+	SubjectTemplate string `validate:"omitempty,lte=256" json:"subject_template"`
+	BodyTemplate    string `validate:"omitempty,lte=65536" json:"body_template"`
+}
+
+// FillDefault fill the default days and caps if not set
+func (c *ReEngagementCampaign) FillDefault() {
+	if c.Days <= 0 {
+		c.Days = 30
+	}
+	if c.MaxEmailsPerRun <= 0 {
+		c.MaxEmailsPerRun = 100
+	}
+	if c.CooldownDays <= 0 {
+		c.CooldownDays = 30
+	}
+}
+
+// HasCustomTemplate whether the campaign uses its own templates
+func (c *ReEngagementCampaign) HasCustomTemplate() bool {
+	return len(c.SubjectTemplate) > 0 && len(c.BodyTemplate) > 0
+}
+
+// Render render the custom templates, the body is escaped as html
+func (c *ReEngagementCampaign) Render(data *ReEngagementTemplateData) (subject, body string, err error) {
+	return RenderEmailTemplate(c.SubjectTemplate, c.BodyTemplate, data)
+}
+
+// Check check the custom templates can be rendered
+func (s *SiteReEngagementReq) Check() (errFields []*validator.FormErrorField, err error) {
+	for _, campaign := range s.Campaigns {
+		if len(campaign.SubjectTemplate) == 0 && len(campaign.BodyTemplate) == 0 {
+			continue
+		}
+		if _, _, err = campaign.Render(&ReEngagementTemplateData{}); err != nil {
+			errFields = append(errFields, &validator.FormErrorField{
+				ErrorField: "campaigns",
+				ErrorMsg:   err.Error(),
+			})
+			return errFields, errors.BadRequest(reason.ReEngagementTemplateInvalid)
+		}
+	}
+	return nil, nil
+}
+
+// GetCampaign get the enabled campaign of the type, nil if not enabled
+func (s *SiteReEngagementResp) GetCampaign(campaignType string) *ReEngagementCampaign {
+	if !s.Enabled {
+		return nil
+	}
+	for _, campaign := range s.Campaigns {
+		if campaign.Type == campaignType && campaign.Enabled {
+			campaign.FillDefault()
+			return campaign
+		}
+	}
+	return nil
+}
+
+// ReEngagementTemplateData the data of re-engagement email templates
+type ReEngagementTemplateData struct {
+	SiteName    string
+	SiteURL     string
+	DisplayName string
+	// the days of campaign
+	Days      int
+	Questions []*ReEngagementQuestion
+	// the total number of questions, it may be more than listed
+	Total          int64
+	UnsubscribeUrl string
+}
+
+// ReEngagementQuestion the question listed in re-engagement email
+type ReEngagementQuestion struct {
+	Title          string
+	URL            string
+	NewAnswerCount int
+}
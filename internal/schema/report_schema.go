@@ -19,6 +19,11 @@
 
 package schema
 
+import (
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
+)
+
 // AddReportReq add report request
 type AddReportReq struct {
 	// object id
@@ -27,6 +32,10 @@ type AddReportReq struct {
 	ReportType int `validate:"required" json:"report_type"`
 	// report content
 	Content string `validate:"omitempty,gt=0,lte=500" json:"content"`
+	// report category, it is inferred from the report type if empty
+	Category string `validate:"omitempty,oneof=spam abuse quality legal other" json:"category"`
+	// the free-text detail for the moderators
+	Detail string `validate:"omitempty,lte=2000" json:"detail"`
 	// user id
 	UserID      string `json:"-"`
 	CaptchaID   string `json:"captcha_id"` // captcha_id
@@ -62,11 +71,15 @@ type ReportHandleReq struct {
 	FlaggedContent string `validate:"omitempty" comment:"flagged content" form:"flagged_content" json:"flagged_content"`
 }
 
-// GetReportListPageDTO report list data transfer object
+// GetReportListPageDTO report list data transfer object, the zero value fields are ignored
 type GetReportListPageDTO struct {
-	Page     int
-	PageSize int
-	Status   int
+	Page       int
+	PageSize   int
+	Status     int
+	Category   string
+	ObjectType int
+	// 0 means the unassigned reports
+	AssigneeID string
 }
 
 // GetReportListPageResp get report list
@@ -92,6 +105,34 @@ type GetReportListPageResp struct {
 	SubmitterUser    UserBasicInfo `json:"submitter_user"`
 	Reason           *ReasonItem   `json:"reason"`
 	ReasonContent    string        `json:"reason_content"`
+	Category         string        `json:"category" enums:"spam,abuse,quality,legal,other"`
+	Detail           string        `json:"detail"`
+	Status           string        `json:"status" enums:"pending,completed,ignored"`
+	AssigneeUser     UserBasicInfo `json:"assignee_user"`
+	Resolution       string        `json:"resolution" enums:"helpful,declined"`
+	ResolutionReason string        `json:"resolution_reason"`
+}
+
+// GetReportPageReq get report page request
+type GetReportPageReq struct {
+	Page       int    `validate:"omitempty,min=1" form:"page"`
+	PageSize   int    `validate:"omitempty,min=1" form:"page_size"`
+	Status     string `validate:"omitempty,oneof=pending completed ignored" form:"status"`
+	Category   string `validate:"omitempty,oneof=spam abuse quality legal other" form:"category"`
+	ObjectType string `validate:"omitempty,oneof=question answer comment" form:"object_type"`
+	// me: assigned to the login user; none: unassigned; or the user id of the assignee
+	Assignee string `validate:"omitempty" form:"assignee"`
+	UserID   string `json:"-"`
+	IsAdmin  bool   `json:"-"`
+}
+
+// AssignReportReq assign report request
+type AssignReportReq struct {
+	FlagID string `validate:"required" json:"flag_id"`
+	// the empty assignee means unassign the report
+	AssigneeID string `validate:"omitempty" json:"assignee_id"`
+	UserID     string `json:"-"`
+	IsAdmin    bool   `json:"-"`
 }
 
 // GetUnreviewedReportPostPageReq get unreviewed report post page request
@@ -110,6 +151,92 @@ type ReviewReportReq struct {
 	Title         string     `validate:"omitempty,notblank,gte=6,lte=150" json:"title"`
 	Content       string     `validate:"omitempty,notblank,gte=6,lte=65535" json:"content"`
 	Tags          []*TagItem `validate:"omitempty,dive" json:"tags"`
-	UserID        string     `json:"-"`
-	IsAdmin       bool       `json:"-"`
+	// the reason told to the reporter, especially when the report is declined
+	ResolutionReason string `validate:"omitempty,lte=2000" json:"resolution_reason"`
+	UserID           string `json:"-"`
+	IsAdmin          bool   `json:"-"`
+}
+
+// GetResolution get the resolution outcome of the report by the operation
+func (r *ReviewReportReq) GetResolution() string {
+	if r.OperationType == constant.ReportOperationIgnoreReport {
+		return entity.ReportResolutionDeclined
+	}
+	return entity.ReportResolutionHelpful
 }
+
+// AddReportAppealReq add report appeal request
+type AddReportAppealReq struct {
+	FlagID  string `validate:"required" json:"flag_id"`
+	Content string `validate:"required,notblank,gt=0,lte=2000" json:"content"`
+	UserID  string `json:"-"`
+}
+
+// GetReportAppealReq get report appeal request
+type GetReportAppealReq struct {
+	FlagID string `validate:"required" form:"flag_id"`
+	UserID string `json:"-"`
+}
+
+// GetReportAppealResp get report appeal response
+type GetReportAppealResp struct {
+	AppealID  string `json:"appeal_id"`
+	FlagID    string `json:"flag_id"`
+	ObjectID  string `json:"object_id"`
+	Content   string `json:"content"`
+	HandledOp string `json:"handled_op"`
+	ReviewMsg string `json:"review_msg"`
+	Status    string `json:"status" enums:"pending,upheld,overturned"`
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+// GetReportAppealPageReq get pending report appeal page request
+type GetReportAppealPageReq struct {
+	Page     int    `validate:"omitempty,min=1" form:"page"`
+	PageSize int    `validate:"omitempty,min=1" form:"page_size"`
+	UserID   string `json:"-"`
+	IsAdmin  bool   `json:"-"`
+}
+
+// GetReportAppealPageResp get report appeal page response, with full context of the flag
+type GetReportAppealPageResp struct {
+	GetReportAppealResp
+	// the flag that the appeal against
+	Flag *GetReportListPageResp `json:"flag"`
+	// the moderator who handled the flag
+	Moderator UserBasicInfo `json:"moderator"`
+}
+
+// ReviewReportAppealReq review report appeal request
+type ReviewReportAppealReq struct {
+	AppealID string `validate:"required" json:"appeal_id"`
+	// uphold: keep the moderation result; overturn: restore the content
+	Operation string `validate:"required,oneof=uphold overturn" json:"operation"`
+	ReviewMsg string `validate:"omitempty,lte=2000" json:"review_msg"`
+	UserID    string `json:"-"`
+	IsAdmin   bool   `json:"-"`
+}
+
+// GetReportAppealStatsResp moderator quality metrics calculated from the appeal outcomes
+type GetReportAppealStatsResp struct {
+	Moderator       UserBasicInfo `json:"moderator"`
+	AppealCount     int64         `json:"appeal_count"`
+	UpheldCount     int64         `json:"upheld_count"`
+	OverturnedCount int64         `json:"overturned_count"`
+	PendingCount    int64         `json:"pending_count"`
+	// overturned / (upheld + overturned)
+	OverturnRate float64 `json:"overturn_rate"`
+}
+
+// ReportAppealStatsDTO report appeal count group by moderator and status
+type ReportAppealStatsDTO struct {
+	ModeratorID string `xorm:"moderator_id"`
+	Status      int    `xorm:"status"`
+	Count       int64  `xorm:"count"`
+}
+
+const (
+	ReportAppealOperationUphold   = "uphold"
+	ReportAppealOperationOverturn = "overturn"
+)
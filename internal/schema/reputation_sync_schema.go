@@ -0,0 +1,62 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	ReputationSyncEventTierChanged = "user.tier_changed"
+	ReputationSyncEventRoleChanged = "user.role_changed"
+
+	// ReputationSyncSignatureHeader the header of the payload signature, the value is "sha256=" + hex(hmac_sha256(secret, body))
+	ReputationSyncSignatureHeader = "X-Answer-Signature"
+)
+
+// ReputationSyncEvent the payload sent to external systems when the reputation tier or role of user changed
+type ReputationSyncEvent struct {
+	Event     string              `json:"event"`
+	Timestamp int64               `json:"timestamp"`
+	User      *ReputationSyncUser `json:"user"`
+	// the tier is empty if the reputation of user is lower than all tiers
+	Tier         *ReputationSyncEventTier `json:"tier,omitempty"`
+	PreviousTier *ReputationSyncEventTier `json:"previous_tier,omitempty"`
+	Role         string                   `json:"role,omitempty"`
+	PreviousRole string                   `json:"previous_role,omitempty"`
+}
+
+// ReputationSyncUser the user info in reputation sync event
+type ReputationSyncUser struct {
+	ID          string `json:"id"`
+	Username    string `json:"username"`
+	DisplayName string `json:"display_name"`
+	Email       string `json:"email"`
+	Reputation  int    `json:"reputation"`
+}
+
+// ReputationSyncEventTier the tier info in reputation sync event
+type ReputationSyncEventTier struct {
+	Name          string `json:"name"`
+	MinReputation int    `json:"min_reputation"`
+	ExternalRole  string `json:"external_role"`
+}
+
+// ReputationSyncState the state of reputation sync, it is saved as site info
+type ReputationSyncState struct {
+	// LastSyncedAt the changes before this time have been synced
+	LastSyncedAt int64 `json:"last_synced_at"`
+}
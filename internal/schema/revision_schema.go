@@ -86,6 +86,8 @@ type GetUnreviewedRevisionResp struct {
 	Type           string                      `json:"type"`
 	Info           *UnreviewedRevisionInfoInfo `json:"info"`
 	UnreviewedInfo *GetRevisionResp            `json:"unreviewed_info"`
+	// the unified diff of the tag description, from the current to the proposed one
+	ContentDiff string `json:"content_diff,omitempty"`
 }
 
 // GetRevisionResp get revision response
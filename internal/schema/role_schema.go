@@ -19,9 +19,89 @@
 
 package schema
 
+import (
+	"strings"
+
+	"github.com/apache/answer/internal/base/validator"
+)
+
 // GetRoleResp get role  response
 type GetRoleResp struct {
 	ID          int    `json:"id"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	// built-in roles can not be removed
+	BuiltIn bool `json:"built_in"`
+}
+
+// GetPowerResp get power response
+type GetPowerResp struct {
+	PowerType   string `json:"power_type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// GetRolePowersReq get role powers request
+type GetRolePowersReq struct {
+	RoleID int `validate:"required" form:"role_id"`
+}
+
+// GetRolePowersResp get role powers response
+type GetRolePowersResp struct {
+	RoleID int      `json:"role_id"`
+	Powers []string `json:"powers"`
+}
+
+// AddRoleReq add role request
+type AddRoleReq struct {
+	Name        string   `validate:"required,notblank,lte=50" json:"name"`
+	Description string   `validate:"omitempty,lte=200" json:"description"`
+	Powers      []string `validate:"omitempty,dive,gt=0,lte=100" json:"powers"`
+}
+
+func (r *AddRoleReq) Check() (errFields []*validator.FormErrorField, err error) {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Description = strings.TrimSpace(r.Description)
+	r.Powers = NormalizeRolePowers(r.Powers)
+	return nil, nil
+}
+
+// AddRoleResp add role response
+type AddRoleResp struct {
+	ID int `json:"id"`
+}
+
+// UpdateRoleReq update role request
+type UpdateRoleReq struct {
+	ID          int      `validate:"required" json:"id"`
+	Name        string   `validate:"required,notblank,lte=50" json:"name"`
+	Description string   `validate:"omitempty,lte=200" json:"description"`
+	Powers      []string `validate:"omitempty,dive,gt=0,lte=100" json:"powers"`
+}
+
+func (r *UpdateRoleReq) Check() (errFields []*validator.FormErrorField, err error) {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Description = strings.TrimSpace(r.Description)
+	r.Powers = NormalizeRolePowers(r.Powers)
+	return nil, nil
+}
+
+// RemoveRoleReq remove role request
+type RemoveRoleReq struct {
+	ID int `validate:"required" json:"id"`
+}
+
+// NormalizeRolePowers trim the power types and remove the empty and duplicate ones, keep the order
+func NormalizeRolePowers(powers []string) []string {
+	result := make([]string, 0, len(powers))
+	seen := make(map[string]bool, len(powers))
+	for _, power := range powers {
+		power = strings.TrimSpace(power)
+		if len(power) == 0 || seen[power] {
+			continue
+		}
+		seen[power] = true
+		result = append(result, power)
+	}
+	return result
 }
@@ -0,0 +1,66 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	// SavedSearchMaxPerUser the max number of saved searches of one user
+	SavedSearchMaxPerUser = 20
+	// SavedSearchMaxMatches the max number of new results notified for one saved search in one evaluation
+	SavedSearchMaxMatches = 10
+)
+
+// AddSavedSearchReq add saved search request
+type AddSavedSearchReq struct {
+	Name      string `validate:"required,notblank,lte=100" json:"name"`
+	Query     string `validate:"required,notblank,lte=60" json:"query"`
+	Frequency string `validate:"required,oneof=instant daily none" json:"frequency" enums:"instant,daily,none"`
+	UserID    string `json:"-"`
+}
+
+// UpdateSavedSearchReq update saved search request
+type UpdateSavedSearchReq struct {
+	ID        string `validate:"required" json:"id"`
+	Name      string `validate:"required,notblank,lte=100" json:"name"`
+	Query     string `validate:"required,notblank,lte=60" json:"query"`
+	Frequency string `validate:"required,oneof=instant daily none" json:"frequency" enums:"instant,daily,none"`
+	UserID    string `json:"-"`
+}
+
+// RemoveSavedSearchReq remove saved search request
+type RemoveSavedSearchReq struct {
+	ID     string `validate:"required" json:"id"`
+	UserID string `json:"-"`
+}
+
+// GetSavedSearchesReq get saved searches request
+type GetSavedSearchesReq struct {
+	UserID string `json:"-"`
+}
+
+// SavedSearchItem saved search
+type SavedSearchItem struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Query     string `json:"query"`
+	Frequency string `json:"frequency"`
+	CreatedAt int64  `json:"created_at"`
+	// the results created after it are notified in the next evaluation
+	LastCheckedAt int64 `json:"last_checked_at"`
+}
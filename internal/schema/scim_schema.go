@@ -0,0 +1,406 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/segmentfault/pacman/errors"
+)
+
+const (
+	SCIMSchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SCIMSchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SCIMSchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	SCIMSchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SCIMSchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SCIMSchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	// SCIMExternalLoginProvider the provider of the external login binding the user to the identity provider
+	SCIMExternalLoginProvider = "scim"
+	// SCIMMaxCount the max resources returned in one page
+	SCIMMaxCount = 100
+	// SCIMDisplayNameMaxLength the display name from the identity provider is truncated to the max length of user
+	SCIMDisplayNameMaxLength = 30
+)
+
+// SCIMName the name of SCIM user
+type SCIMName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// SCIMEmail the email of SCIM user
+type SCIMEmail struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// SCIMMember the member of SCIM group, or the group of SCIM user
+type SCIMMember struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// SCIMMeta the meta of SCIM resource
+type SCIMMeta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location,omitempty"`
+}
+
+// SCIMUser the SCIM user resource, the password is only written and never returned
+type SCIMUser struct {
+	Schemas     []string      `json:"schemas"`
+	ID          string        `json:"id,omitempty"`
+	ExternalID  string        `json:"externalId,omitempty"`
+	UserName    string        `json:"userName"`
+	Name        *SCIMName     `json:"name,omitempty"`
+	DisplayName string        `json:"displayName,omitempty"`
+	Emails      []*SCIMEmail  `json:"emails,omitempty"`
+	Password    string        `json:"password,omitempty"`
+	Active      *bool         `json:"active,omitempty"`
+	Groups      []*SCIMMember `json:"groups,omitempty"`
+	Meta        *SCIMMeta     `json:"meta,omitempty"`
+}
+
+// SCIMGroup the SCIM group resource, the groups are the roles of site
+type SCIMGroup struct {
+	Schemas     []string      `json:"schemas"`
+	ID          string        `json:"id"`
+	DisplayName string        `json:"displayName"`
+	Members     []*SCIMMember `json:"members"`
+	Meta        *SCIMMeta     `json:"meta,omitempty"`
+}
+
+// SCIMExternalLoginMeta the meta info of the SCIM binding, the user name of identity provider is the external id of binding
+type SCIMExternalLoginMeta struct {
+	ExternalID string `json:"external_id"`
+	// the user is suspended by the identity provider, only these users are restored when activated again
+	Deactivated bool `json:"deactivated"`
+}
+
+// SCIMListReq SCIM list request, only the eq filter is supported
+type SCIMListReq struct {
+	Filter     string `form:"filter"`
+	StartIndex int    `form:"startIndex"`
+	Count      int    `form:"count"`
+}
+
+// SCIMListResponse SCIM list response
+type SCIMListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int64    `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    any      `json:"Resources"`
+}
+
+// SCIMPatchOperation SCIM patch operation
+type SCIMPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// SCIMPatchReq SCIM patch request
+type SCIMPatchReq struct {
+	Schemas    []string              `json:"schemas"`
+	Operations []*SCIMPatchOperation `json:"Operations"`
+}
+
+// SCIMError SCIM error response
+type SCIMError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+// NewSCIMError new SCIM error response of the http status
+func NewSCIMError(status int, scimType, detail string) *SCIMError {
+	return &SCIMError{
+		Schemas:  []string{SCIMSchemaError},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	}
+}
+
+// NewSCIMServiceProviderConfig the features supported by the SCIM endpoint
+func NewSCIMServiceProviderConfig() map[string]any {
+	unsupported := map[string]bool{"supported": false}
+	return map[string]any{
+		"schemas":        []string{SCIMSchemaServiceProviderConfig},
+		"patch":          map[string]bool{"supported": true},
+		"bulk":           map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]any{"supported": true, "maxResults": SCIMMaxCount},
+		"changePassword": map[string]bool{"supported": true},
+		"sort":           unsupported,
+		"etag":           unsupported,
+		"authenticationSchemes": []map[string]any{{
+			"type":        "oauthbearertoken",
+			"name":        "OAuth Bearer Token",
+			"description": "Authentication with the SCIM token of site",
+			"primary":     true,
+		}},
+		"meta": map[string]string{"resourceType": "ServiceProviderConfig"},
+	}
+}
+
+// FillDefault the start index is 1-based, the count is limited to the max count
+func (r *SCIMListReq) FillDefault() {
+	if r.StartIndex < 1 {
+		r.StartIndex = 1
+	}
+	if r.Count <= 0 || r.Count > SCIMMaxCount {
+		r.Count = SCIMMaxCount
+	}
+}
+
+var scimFilterRegexp = regexp.MustCompile(`^\s*([A-Za-z][\w.]*)\s+(?i:eq)\s+"((?:[^"\\]|\\.)*)"\s*$`)
+
+// ParseSCIMFilter parse the filter like `userName eq "alice"`, the attribute is returned in lower case
+func ParseSCIMFilter(filter string) (attr, value string, err error) {
+	matches := scimFilterRegexp.FindStringSubmatch(filter)
+	if matches == nil {
+		return "", "", errors.BadRequest(reason.SCIMFilterInvalid)
+	}
+	value, err = strconv.Unquote(`"` + matches[2] + `"`)
+	if err != nil {
+		return "", "", errors.BadRequest(reason.SCIMFilterInvalid)
+	}
+	return strings.ToLower(matches[1]), value, nil
+}
+
+// IsActive the user is active if not set
+func (u *SCIMUser) IsActive() bool {
+	return u.Active == nil || *u.Active
+}
+
+// GetEmail get the primary email or the first email
+func (u *SCIMUser) GetEmail() string {
+	for _, email := range u.Emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+	return ""
+}
+
+// GetDisplayName get the display name from the name or user name if not set
+func (u *SCIMUser) GetDisplayName() string {
+	displayName := strings.TrimSpace(u.DisplayName)
+	if len(displayName) == 0 && u.Name != nil {
+		displayName = strings.TrimSpace(u.Name.Formatted)
+		if len(displayName) == 0 {
+			displayName = strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName)
+		}
+	}
+	if len(displayName) == 0 {
+		displayName = strings.TrimSpace(u.UserName)
+	}
+	if utf8.RuneCountInString(displayName) > SCIMDisplayNameMaxLength {
+		displayName = string([]rune(displayName)[:SCIMDisplayNameMaxLength])
+	}
+	return displayName
+}
+
+// ApplyPatch apply the add and replace operations to the user, the unsupported attributes are ignored
+func (u *SCIMUser) ApplyPatch(operations []*SCIMPatchOperation) (err error) {
+	for _, operation := range operations {
+		switch strings.ToLower(operation.Op) {
+		case "add", "replace":
+		case "remove":
+			continue
+		default:
+			return errors.BadRequest(reason.SCIMPatchInvalid)
+		}
+		if len(operation.Path) > 0 {
+			if err = u.applyPatchValue(operation.Path, operation.Value); err != nil {
+				return err
+			}
+			continue
+		}
+		// the value without path is the object of attributes
+		values := make(map[string]json.RawMessage)
+		if err = json.Unmarshal(operation.Value, &values); err != nil {
+			return errors.BadRequest(reason.SCIMPatchInvalid)
+		}
+		for path, value := range values {
+			if err = u.applyPatchValue(path, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (u *SCIMUser) applyPatchValue(path string, value json.RawMessage) (err error) {
+	var dest any
+	switch strings.ToLower(path) {
+	case "active":
+		active, err := parseSCIMBool(value)
+		if err != nil {
+			return err
+		}
+		u.Active = &active
+		return nil
+	case "username":
+		dest = &u.UserName
+	case "displayname":
+		dest = &u.DisplayName
+	case "externalid":
+		dest = &u.ExternalID
+	case "password":
+		dest = &u.Password
+	case "name":
+		dest = &u.Name
+	case "name.formatted", "name.givenname", "name.familyname":
+		if u.Name == nil {
+			u.Name = &SCIMName{}
+		}
+		dest = map[string]*string{
+			"name.formatted":  &u.Name.Formatted,
+			"name.givenname":  &u.Name.GivenName,
+			"name.familyname": &u.Name.FamilyName,
+		}[strings.ToLower(path)]
+	case "emails":
+		dest = &u.Emails
+	default:
+		// e.g. emails[type eq "work"].value
+		if !strings.HasPrefix(strings.ToLower(path), "emails") || !strings.HasSuffix(strings.ToLower(path), ".value") {
+			return nil
+		}
+		var email string
+		if err = json.Unmarshal(value, &email); err != nil {
+			return errors.BadRequest(reason.SCIMPatchInvalid)
+		}
+		u.Emails = []*SCIMEmail{{Value: email, Primary: true}}
+		return nil
+	}
+	if err = json.Unmarshal(value, dest); err != nil {
+		return errors.BadRequest(reason.SCIMPatchInvalid)
+	}
+	return nil
+}
+
+// parseSCIMBool parse the boolean, some identity providers send it as string like "False"
+func parseSCIMBool(value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		if b, err = strconv.ParseBool(s); err == nil {
+			return b, nil
+		}
+	}
+	return false, errors.BadRequest(reason.SCIMPatchInvalid)
+}
+
+// SCIMGroupMemberPatch the member changes of group patch, the members are replaced if replace is true
+type SCIMGroupMemberPatch struct {
+	Add     []string
+	Remove  []string
+	Replace bool
+	Members []string
+}
+
+var scimMemberPathRegexp = regexp.MustCompile(`^(?i:members)\[\s*(?i:value)\s+(?i:eq)\s+"([^"]*)"\s*]$`)
+
+// ParseSCIMGroupMemberPatch parse the member changes of group patch, the other attributes are ignored
+func ParseSCIMGroupMemberPatch(operations []*SCIMPatchOperation) (patch *SCIMGroupMemberPatch, err error) {
+	patch = &SCIMGroupMemberPatch{}
+	for _, operation := range operations {
+		op := strings.ToLower(operation.Op)
+		path := operation.Path
+		value := operation.Value
+		if len(path) == 0 && op != "remove" {
+			// the value without path is the object of attributes
+			values := make(map[string]json.RawMessage)
+			if err = json.Unmarshal(value, &values); err != nil {
+				return nil, errors.BadRequest(reason.SCIMPatchInvalid)
+			}
+			for k, v := range values {
+				if strings.EqualFold(k, "members") {
+					path, value = k, v
+				}
+			}
+			if len(path) == 0 {
+				continue
+			}
+		}
+		if matches := scimMemberPathRegexp.FindStringSubmatch(path); matches != nil && op == "remove" {
+			patch.Remove = append(patch.Remove, matches[1])
+			continue
+		}
+		if !strings.EqualFold(path, "members") {
+			continue
+		}
+		var members []string
+		if len(value) > 0 {
+			if members, err = parseSCIMMembers(value); err != nil {
+				return nil, err
+			}
+		}
+		switch op {
+		case "add":
+			patch.Add = append(patch.Add, members...)
+		case "remove":
+			if len(value) == 0 {
+				patch.Replace, patch.Members, patch.Add, patch.Remove = true, nil, nil, nil
+				continue
+			}
+			patch.Remove = append(patch.Remove, members...)
+		case "replace":
+			patch.Replace, patch.Members, patch.Add, patch.Remove = true, members, nil, nil
+		default:
+			return nil, errors.BadRequest(reason.SCIMPatchInvalid)
+		}
+	}
+	return patch, nil
+}
+
+func parseSCIMMembers(value json.RawMessage) (memberIDs []string, err error) {
+	members := make([]*SCIMMember, 0)
+	if err = json.Unmarshal(value, &members); err != nil {
+		return nil, errors.BadRequest(reason.SCIMPatchInvalid)
+	}
+	for _, member := range members {
+		if len(member.Value) > 0 {
+			memberIDs = append(memberIDs, member.Value)
+		}
+	}
+	return memberIDs, nil
+}
@@ -0,0 +1,104 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSCIMFilter(t *testing.T) {
+	attr, value, err := ParseSCIMFilter(`userName eq "alice@example.com"`)
+	assert.NoError(t, err)
+	assert.Equal(t, "username", attr)
+	assert.Equal(t, "alice@example.com", value)
+
+	attr, value, err = ParseSCIMFilter(`emails.value EQ "a\"b"`)
+	assert.NoError(t, err)
+	assert.Equal(t, "emails.value", attr)
+	assert.Equal(t, `a"b`, value)
+
+	_, _, err = ParseSCIMFilter(`userName sw "alice"`)
+	assert.Error(t, err)
+	_, _, err = ParseSCIMFilter(`userName eq "alice" and active eq true`)
+	assert.Error(t, err)
+}
+
+func TestSCIMUser_ApplyPatch(t *testing.T) {
+	user := &SCIMUser{UserName: "alice", Emails: []*SCIMEmail{{Value: "alice@example.com", Primary: true}}}
+	req := &SCIMPatchReq{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"Operations": [
+		{"op": "Replace", "path": "active", "value": "False"},
+		{"op": "replace", "path": "name.givenName", "value": "Alice"},
+		{"op": "add", "value": {"displayName": "Alice Liddell", "externalId": "00u1"}},
+		{"op": "replace", "path": "emails[type eq \"work\"].value", "value": "liddell@example.com"},
+		{"op": "replace", "path": "title", "value": "ignored"}
+	]}`), req))
+
+	assert.NoError(t, user.ApplyPatch(req.Operations))
+	assert.False(t, user.IsActive())
+	assert.Equal(t, "Alice", user.Name.GivenName)
+	assert.Equal(t, "Alice Liddell", user.GetDisplayName())
+	assert.Equal(t, "00u1", user.ExternalID)
+	assert.Equal(t, "liddell@example.com", user.GetEmail())
+
+	assert.Error(t, user.ApplyPatch([]*SCIMPatchOperation{{Op: "move", Path: "active"}}))
+	assert.Error(t, user.ApplyPatch([]*SCIMPatchOperation{{Op: "replace", Path: "active", Value: json.RawMessage(`1`)}}))
+}
+
+func TestParseSCIMGroupMemberPatch(t *testing.T) {
+	req := &SCIMPatchReq{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"Operations": [
+		{"op": "add", "path": "members", "value": [{"value": "1"}, {"value": "2"}]},
+		{"op": "remove", "path": "members[value eq \"3\"]"},
+		{"op": "remove", "path": "members", "value": [{"value": "4"}]}
+	]}`), req))
+	patch, err := ParseSCIMGroupMemberPatch(req.Operations)
+	assert.NoError(t, err)
+	assert.False(t, patch.Replace)
+	assert.Equal(t, []string{"1", "2"}, patch.Add)
+	assert.Equal(t, []string{"3", "4"}, patch.Remove)
+
+	// the members are replaced by the operation without path
+	patch, err = ParseSCIMGroupMemberPatch([]*SCIMPatchOperation{
+		{Op: "add", Path: "members", Value: json.RawMessage(`[{"value": "1"}]`)},
+		{Op: "replace", Value: json.RawMessage(`{"displayName": "Admin", "members": [{"value": "5"}]}`)},
+	})
+	assert.NoError(t, err)
+	assert.True(t, patch.Replace)
+	assert.Equal(t, []string{"5"}, patch.Members)
+	assert.Empty(t, patch.Add)
+
+	patch, err = ParseSCIMGroupMemberPatch([]*SCIMPatchOperation{{Op: "remove", Path: "members"}})
+	assert.NoError(t, err)
+	assert.True(t, patch.Replace)
+	assert.Empty(t, patch.Members)
+}
+
+func TestSCIMUser_GetDisplayName(t *testing.T) {
+	user := &SCIMUser{UserName: "alice@example.com", Name: &SCIMName{GivenName: "Alice", FamilyName: "Liddell"}}
+	assert.Equal(t, "Alice Liddell", user.GetDisplayName())
+	user.Name = nil
+	assert.Equal(t, "alice@example.com", user.GetDisplayName())
+	user.DisplayName = "a very long display name of the user alice"
+	assert.Equal(t, 30, len(user.GetDisplayName()))
+}
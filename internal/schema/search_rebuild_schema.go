@@ -0,0 +1,55 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// SearchRebuildReq search index rebuild request
+type SearchRebuildReq struct {
+	// ignore the checkpoint of the last interrupted rebuild and start from the beginning
+	Restart bool `json:"restart"`
+	// the number of contents sent to the search plugin in one batch
+	BatchSize int `validate:"omitempty,gte=1,lte=10000" json:"batch_size"`
+}
+
+// SearchRebuildState the checkpoint of search index rebuild, it is saved as site info and not exposed
+type SearchRebuildState struct {
+	// the slug name of the search plugin the contents are sent to
+	PluginSlugName string `json:"plugin_slug_name"`
+	// the object type being rebuilt, question first and then answer
+	ObjectType string `json:"object_type"`
+	// the last object id has been sent, the rebuild is resumed after it
+	LastID    string `json:"last_id"`
+	Processed int64  `json:"processed"`
+	Finished  bool   `json:"finished"`
+	StartedAt int64  `json:"started_at"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+// SearchRebuildProgress search index rebuild progress
+type SearchRebuildProgress struct {
+	PluginSlugName string `json:"plugin_slug_name"`
+	ObjectType     string `json:"object_type"`
+	// the total number of contents need to be sent including the ones before resuming
+	Total int64 `json:"total"`
+	// the number of contents have been sent including the ones before resuming
+	Processed int64  `json:"processed"`
+	LastID    string `json:"last_id"`
+	// the rebuild is resumed from the checkpoint
+	Resumed bool `json:"resumed"`
+}
@@ -21,6 +21,7 @@ package schema
 
 import (
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/apache/answer/internal/base/constant"
@@ -35,7 +36,9 @@ type SearchDTO struct {
 	Order       string `validate:"required,oneof=newest active score relevance" form:"order,default=relevance" enums:"newest,active,score,relevance"`
 	CaptchaID   string `form:"captcha_id"`
 	CaptchaCode string `form:"captcha_code"`
-	UserID      string `json:"-"`
+	// return the facet counts of all results, only supported by the built-in search
+	Facets bool   `form:"facets"`
+	UserID string `json:"-"`
 }
 
 func (s *SearchDTO) Check() (errField []*validator.FormErrorField, err error) {
@@ -70,7 +73,7 @@ func ReplaceSearchContent(content string) (string, []string) {
 }
 
 type SearchCondition struct {
-	// search target type: all/question/answer
+	// search target type: all/question/answer/comment
 	TargetType string
 	// search query user id
 	UserID string
@@ -90,6 +93,8 @@ type SearchCondition struct {
 	Tags [][]string
 	// search query keywords
 	Words []string
+	// only show the question in this language
+	Language string
 }
 
 // SearchAll check if search all
@@ -107,6 +112,19 @@ func (s *SearchCondition) SearchAnswer() bool {
 	return s.TargetType == constant.AnswerObjectType
 }
 
+// SearchComment check if search only need comment
+func (s *SearchCondition) SearchComment() bool {
+	return s.TargetType == constant.CommentObjectType
+}
+
+// OnlyKeywords check if the search only has keywords without any other filter,
+// the semantic search can only work on this condition
+func (s *SearchCondition) OnlyKeywords() bool {
+	return len(s.Words) > 0 && !s.SearchComment() &&
+		len(s.Tags) == 0 && len(s.UserID) == 0 && len(s.QuestionID) == 0 && len(s.Language) == 0 &&
+		s.VoteAmount == -1 && s.Views == -1 && s.AnswerAmount == -1 && !s.Accepted && !s.NotAccepted
+}
+
 // Convert2PluginSearchCond convert to plugin search condition
 func (s *SearchCondition) Convert2PluginSearchCond(page, pageSize int, order string) *plugin.SearchBasicCond {
 	basic := &plugin.SearchBasicCond{
@@ -137,6 +155,7 @@ func (s *SearchCondition) Convert2PluginSearchCond(page, pageSize int, order str
 type SearchObject struct {
 	ID              string `json:"id"`
 	QuestionID      string `json:"question_id"`
+	AnswerID        string `json:"answer_id,omitempty"`
 	Title           string `json:"title"`
 	UrlTitle        string `json:"url_title"`
 	Excerpt         string `json:"excerpt"`
@@ -150,6 +169,14 @@ type SearchObject struct {
 	Tags []*TagResp `json:"tags"`
 	// Status
 	StatusStr string `json:"status"`
+	// the matched words are wrapped in <em>, so the user can see why the result matched
+	Highlight *SearchHighlight `json:"highlight,omitempty"`
+}
+
+// SearchHighlight the highlighted title and content fragments of search result
+type SearchHighlight struct {
+	Title     string   `json:"title"`
+	Fragments []string `json:"fragments"`
 }
 
 type SearchObjectUser struct {
@@ -181,6 +208,63 @@ type SearchResp struct {
 	Total int64 `json:"count"`
 	// search response
 	SearchResults []*SearchResult `json:"list"`
+	// facet counts of all results, nil if not requested
+	Facets *SearchFacets `json:"facets,omitempty"`
+}
+
+// SearchRankFusionK the constant of reciprocal rank fusion, the larger it is, the less the top ranks dominate
+const SearchRankFusionK = 60
+
+// FuseSearchResults merge the ranked result lists by reciprocal rank fusion.
+// Each result scores 1/(k+rank) in every list it appears, the results are ordered by the sum of scores,
+// so the one ranked high in several lists goes first. The same object is kept only once.
+func FuseSearchResults(lists ...[]*SearchResult) []*SearchResult {
+	scores := make(map[string]float64)
+	fused := make([]*SearchResult, 0)
+	for _, list := range lists {
+		for rank, item := range list {
+			if item == nil || item.Object == nil {
+				continue
+			}
+			key := item.ObjectType + ":" + item.Object.ID
+			if _, ok := scores[key]; !ok {
+				fused = append(fused, item)
+			}
+			scores[key] += 1 / float64(SearchRankFusionK+rank+1)
+		}
+	}
+	sort.SliceStable(fused, func(i, j int) bool {
+		return scores[fused[i].ObjectType+":"+fused[i].Object.ID] > scores[fused[j].ObjectType+":"+fused[j].Object.ID]
+	})
+	return fused
+}
+
+// SearchFacets the counts of all search results grouped by tag, status and date
+type SearchFacets struct {
+	Tags   []*SearchTagFacet  `json:"tags"`
+	Status *SearchStatusFacet `json:"status"`
+	Date   *SearchDateFacet   `json:"date"`
+}
+
+// SearchTagFacet the count of results in the tag
+type SearchTagFacet struct {
+	SlugName    string `json:"slug_name"`
+	DisplayName string `json:"display_name"`
+	Count       int64  `json:"count"`
+}
+
+// SearchStatusFacet the count of results whose question is answered or not
+type SearchStatusFacet struct {
+	Answered   int64 `json:"answered"`
+	Unanswered int64 `json:"unanswered"`
+}
+
+// SearchDateFacet the count of results created in the past day, week, month and year
+type SearchDateFacet struct {
+	PastDay   int64 `json:"past_day"`
+	PastWeek  int64 `json:"past_week"`
+	PastMonth int64 `json:"past_month"`
+	PastYear  int64 `json:"past_year"`
 }
 
 type SearchDescResp struct {
@@ -188,3 +272,25 @@ type SearchDescResp struct {
 	Icon string `json:"icon"`
 	Link string `json:"link"`
 }
+
+// SearchSuggestReq search suggestion request
+type SearchSuggestReq struct {
+	Query string `validate:"required,gte=1,lte=60" form:"q"`
+	Size  int    `validate:"omitempty,min=1,max=20" form:"size,default=10"`
+	// login user id
+	UserID string `json:"-"`
+}
+
+// SearchSuggestQuestion the question which title matches the search prefix
+type SearchSuggestQuestion struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	UrlTitle  string `json:"url_title"`
+	VoteCount int    `json:"vote_count"`
+}
+
+// SearchSuggestResp search suggestion response
+type SearchSuggestResp struct {
+	Questions []*SearchSuggestQuestion `json:"questions"`
+	Tags      []GetTagBasicResp        `json:"tags"`
+}
@@ -39,3 +39,21 @@ func TestReplaceSearchContent(t *testing.T) {
 
 	assert.Equal(t, "user:aaa-sss score:3 [tag1] [tag2] ssssfdfdf as fsadf", ret)
 }
+
+func TestFuseSearchResults(t *testing.T) {
+	item := func(objectType, id string) *SearchResult {
+		return &SearchResult{ObjectType: objectType, Object: &SearchObject{ID: id}}
+	}
+	keyword := []*SearchResult{item("question", "1"), item("question", "2"), item("answer", "3")}
+	semantic := []*SearchResult{item("answer", "3"), item("question", "4"), item("question", "1")}
+
+	fused := FuseSearchResults(keyword, semantic)
+	ids := make([]string, 0, len(fused))
+	for _, r := range fused {
+		ids = append(ids, r.ObjectType+":"+r.Object.ID)
+	}
+	assert.Equal(t, []string{"question:1", "answer:3", "question:2", "question:4"}, ids)
+
+	assert.Empty(t, FuseSearchResults(nil, nil))
+	assert.Len(t, FuseSearchResults(keyword), 3)
+}
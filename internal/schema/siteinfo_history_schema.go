@@ -0,0 +1,48 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// SiteInfoVersionItem the current version of settings, sent back in If-Match header when the settings are saved
+type SiteInfoVersionItem struct {
+	Type      string `json:"type"`
+	Version   int    `json:"version"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+// GetSiteInfoHistoryPageReq get site info history page request
+type GetSiteInfoHistoryPageReq struct {
+	Type     string `validate:"required,gt=0,lte=64" form:"type"`
+	Page     int    `validate:"omitempty,min=1" form:"page"`
+	PageSize int    `validate:"omitempty,min=1,max=100" form:"page_size"`
+}
+
+// SiteInfoHistoryItem the saved version of settings
+type SiteInfoHistoryItem struct {
+	Version    int    `json:"version"`
+	Content    string `json:"content"`
+	OperatorID string `json:"operator_id"`
+	CreatedAt  int64  `json:"created_at"`
+}
+
+// RevertSiteInfoReq revert the settings to the saved version
+type RevertSiteInfoReq struct {
+	Type    string `validate:"required,gt=0,lte=64" json:"type"`
+	Version int    `validate:"required,min=1" json:"version"`
+}
@@ -22,16 +22,21 @@ package schema
 import (
 	"context"
 	"fmt"
+	"math"
 	"net/mail"
 	"net/url"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/handler"
 	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/base/translator"
 	"github.com/apache/answer/internal/base/validator"
+	"github.com/apache/answer/pkg/checker"
 	"github.com/segmentfault/pacman/errors"
 )
 
@@ -75,16 +80,22 @@ type SiteBrandingReq struct {
 
 // SiteWriteReq site write request
 type SiteWriteReq struct {
-	RestrictAnswer                 bool            `validate:"omitempty" json:"restrict_answer"`
-	RequiredTag                    bool            `validate:"omitempty" json:"required_tag"`
-	RecommendTags                  []*SiteWriteTag `validate:"omitempty,dive" json:"recommend_tags"`
-	ReservedTags                   []*SiteWriteTag `validate:"omitempty,dive" json:"reserved_tags"`
-	MaxImageSize                   int             `validate:"omitempty,gt=0" json:"max_image_size"`
-	MaxAttachmentSize              int             `validate:"omitempty,gt=0" json:"max_attachment_size"`
-	MaxImageMegapixel              int             `validate:"omitempty,gt=0" json:"max_image_megapixel"`
-	AuthorizedImageExtensions      []string        `validate:"omitempty" json:"authorized_image_extensions"`
-	AuthorizedAttachmentExtensions []string        `validate:"omitempty" json:"authorized_attachment_extensions"`
-	UserID                         string          `json:"-"`
+	RestrictAnswer    bool            `validate:"omitempty" json:"restrict_answer"`
+	RequiredTag       bool            `validate:"omitempty" json:"required_tag"`
+	RecommendTags     []*SiteWriteTag `validate:"omitempty,dive" json:"recommend_tags"`
+	ReservedTags      []*SiteWriteTag `validate:"omitempty,dive" json:"reserved_tags"`
+	MaxImageSize      int             `validate:"omitempty,gt=0" json:"max_image_size"`
+	MaxAttachmentSize int             `validate:"omitempty,gt=0" json:"max_attachment_size"`
+	MaxImageMegapixel int             `validate:"omitempty,gt=0" json:"max_image_megapixel"`
+	MaxVideoSize      int             `validate:"omitempty,gt=0" json:"max_video_size"`
+	// the max duration of video in seconds
+	MaxVideoDuration               int      `validate:"omitempty,gt=0,lte=3600" json:"max_video_duration"`
+	AuthorizedImageExtensions      []string `validate:"omitempty" json:"authorized_image_extensions"`
+	AuthorizedAttachmentExtensions []string `validate:"omitempty" json:"authorized_attachment_extensions"`
+	ResolutionNote                 string   `validate:"omitempty,oneof=none optional required" json:"resolution_note"`
+	// the question author can accept own answer only after the hours since the answer posted, 0 means no limit
+	SelfAcceptDelayHours int    `validate:"omitempty,gte=0,lte=720" json:"self_accept_delay_hours"`
+	UserID               string `json:"-"`
 }
 
 func (s *SiteWriteResp) GetMaxImageSize() int64 {
@@ -101,6 +112,20 @@ func (s *SiteWriteResp) GetMaxAttachmentSize() int64 {
 	return int64(s.MaxAttachmentSize) * 1024 * 1024
 }
 
+func (s *SiteWriteResp) GetMaxVideoSize() int64 {
+	if s.MaxVideoSize <= 0 {
+		return constant.DefaultMaxVideoSize
+	}
+	return int64(s.MaxVideoSize) * 1024 * 1024
+}
+
+func (s *SiteWriteResp) GetMaxVideoDuration() time.Duration {
+	if s.MaxVideoDuration <= 0 {
+		return constant.DefaultMaxVideoDuration * time.Second
+	}
+	return time.Duration(s.MaxVideoDuration) * time.Second
+}
+
 func (s *SiteWriteResp) GetMaxImageMegapixel() int {
 	if s.MaxImageMegapixel <= 0 {
 		return constant.DefaultMaxImageMegapixel
@@ -108,6 +133,22 @@ func (s *SiteWriteResp) GetMaxImageMegapixel() int {
 	return s.MaxImageMegapixel * 1000 * 1000
 }
 
+const (
+	ResolutionNoteNone     = "none"
+	ResolutionNoteOptional = "optional"
+	ResolutionNoteRequired = "required"
+)
+
+// ResolutionNoteAllowed whether the question author can add a resolution note when accepting an answer
+func (s *SiteWriteResp) ResolutionNoteAllowed() bool {
+	return s.ResolutionNote == ResolutionNoteOptional || s.ResolutionNote == ResolutionNoteRequired
+}
+
+// ResolutionNoteRequired whether the question author must add a resolution note when accepting an answer
+func (s *SiteWriteResp) ResolutionNoteRequired() bool {
+	return s.ResolutionNote == ResolutionNoteRequired
+}
+
 // SiteWriteTag site write response tag
 type SiteWriteTag struct {
 	SlugName    string `validate:"required" json:"slug_name"`
@@ -156,6 +197,896 @@ type SiteUsersReq struct {
 	AllowUpdateLocation    bool   `json:"allow_update_location"`
 }
 
+// SiteLimitsReq site content limits request
+type SiteLimitsReq struct {
+	MaxRequestBodySize int `validate:"omitempty,gte=0,lte=1024" json:"max_request_body_size"`
+	MaxTitleLength     int `validate:"omitempty,gte=0,lte=150" json:"max_title_length"`
+	MaxContentLength   int `validate:"omitempty,gte=0,lte=1000000" json:"max_content_length"`
+	MaxCommentLength   int `validate:"omitempty,gte=0,lte=65535" json:"max_comment_length"`
+	MaxTagsPerQuestion int `validate:"omitempty,gte=0,lte=100" json:"max_tags_per_question"`
+	MaxImagesPerPost   int `validate:"omitempty,gte=0,lte=1000" json:"max_images_per_post"`
+}
+
+// ContentLimits convert to the limits used by validator, MaxRequestBodySize is configured in MB
+func (s *SiteLimitsResp) ContentLimits() *validator.ContentLimits {
+	return &validator.ContentLimits{
+		MaxRequestBodySize: int64(s.MaxRequestBodySize) * 1024 * 1024,
+		MaxTitleLength:     s.MaxTitleLength,
+		MaxContentLength:   s.MaxContentLength,
+		MaxCommentLength:   s.MaxCommentLength,
+		MaxTagsPerQuestion: s.MaxTagsPerQuestion,
+		MaxImagesPerPost:   s.MaxImagesPerPost,
+	}
+}
+
+// FillDefault fill the default value for the limits which are not set, so that client can use it directly
+func (s *SiteLimitsResp) FillDefault() {
+	if s.MaxRequestBodySize <= 0 {
+		s.MaxRequestBodySize = validator.DefaultMaxRequestBodySize / 1024 / 1024
+	}
+	if s.MaxTitleLength <= 0 {
+		s.MaxTitleLength = validator.DefaultMaxTitleLength
+	}
+	if s.MaxContentLength <= 0 {
+		s.MaxContentLength = validator.DefaultMaxContentLength
+	}
+	if s.MaxCommentLength <= 0 {
+		s.MaxCommentLength = validator.DefaultMaxCommentLength
+	}
+	if s.MaxTagsPerQuestion <= 0 {
+		s.MaxTagsPerQuestion = validator.DefaultMaxTagsPerQuestion
+	}
+}
+
+// SiteQuestionCloseReq site question close vote request
+type SiteQuestionCloseReq struct {
+	VoteEnabled    bool `json:"vote_enabled"`
+	VotesRequired  int  `validate:"omitempty,gte=1,lte=100" json:"votes_required"`
+	VoteWindowDays int  `validate:"omitempty,gte=1,lte=365" json:"vote_window_days"`
+}
+
+// FillDefault fill the default votes required and vote window if not set
+func (s *SiteQuestionCloseResp) FillDefault() {
+	if s.VotesRequired <= 0 {
+		s.VotesRequired = 3
+	}
+	if s.VoteWindowDays <= 0 {
+		s.VoteWindowDays = 14
+	}
+}
+
+const (
+	RateLimitActionComment = "comment"
+	RateLimitActionAnswer  = "answer"
+	RateLimitActionExport  = "export"
+)
+
+// RateLimitDefaultExportTier the export limit used when no export tier is configured,
+// exporting renders the whole document so it is always limited
+var RateLimitDefaultExportTier = &RateLimitTier{MaxCount: 10, PeriodSeconds: 60}
+
+// SiteRateLimitReq site rate limit request, the limits are configured per action and reputation
+type SiteRateLimitReq struct {
+	Comment []*RateLimitTier `validate:"omitempty,dive" json:"comment"`
+	Answer  []*RateLimitTier `validate:"omitempty,dive" json:"answer"`
+	Export  []*RateLimitTier `validate:"omitempty,dive" json:"export"`
+}
+
+// RateLimitTier the user whose reputation is not less than MinReputation
+// can do the action at most MaxCount times in PeriodSeconds
+type RateLimitTier struct {
+	MinReputation int `validate:"gte=0" json:"min_reputation"`
+	MaxCount      int `validate:"required,gte=1,lte=10000" json:"max_count"`
+	PeriodSeconds int `validate:"required,gte=1,lte=86400" json:"period_seconds"`
+}
+
+// GetTier get the tier matching the reputation, the tier with the highest MinReputation wins
+func (s *SiteRateLimitResp) GetTier(action string, reputation int) (tier *RateLimitTier) {
+	var tiers []*RateLimitTier
+	switch action {
+	case RateLimitActionComment:
+		tiers = s.Comment
+	case RateLimitActionAnswer:
+		tiers = s.Answer
+	case RateLimitActionExport:
+		tiers = s.Export
+	}
+	for _, t := range tiers {
+		if t.MinReputation > reputation {
+			continue
+		}
+		if tier == nil || t.MinReputation > tier.MinReputation {
+			tier = t
+		}
+	}
+	if tier == nil && action == RateLimitActionExport {
+		tier = RateLimitDefaultExportTier
+	}
+	return tier
+}
+
+// SiteDataDumpReq site public data dump request
+type SiteDataDumpReq struct {
+	Enabled      bool   `json:"enabled"`
+	IntervalDays int    `validate:"omitempty,gte=1,lte=365" json:"interval_days"`
+	License      string `validate:"omitempty,gt=0,lte=100" json:"license"`
+	LicenseURL   string `validate:"omitempty,gt=0,lte=512" json:"license_url"`
+}
+
+// FillDefault fill the default interval and license if not set
+func (s *SiteDataDumpResp) FillDefault() {
+	if s.IntervalDays <= 0 {
+		s.IntervalDays = 7
+	}
+	if len(s.License) == 0 {
+		s.License = "CC BY-SA 4.0"
+		s.LicenseURL = "https://creativecommons.org/licenses/by-sa/4.0/"
+	}
+}
+
+// SiteReputationSyncReq site reputation and role sync request.
+// The changes are sent to the webhook url of the tier, or the default webhook url if not set.
+type SiteReputationSyncReq struct {
+	Enabled         bool                  `json:"enabled"`
+	WebhookURL      string                `validate:"omitempty,gt=0,lte=512,url" json:"webhook_url"`
+	Secret          string                `validate:"omitempty,lte=256" json:"secret"`
+	SyncRoleChanges bool                  `json:"sync_role_changes"`
+	Tiers           []*ReputationSyncTier `validate:"omitempty,dive" json:"tiers"`
+}
+
+// ReputationSyncTier the user whose reputation is not less than MinReputation is in this tier
+type ReputationSyncTier struct {
+	Name          string `validate:"required,gt=0,lte=100" json:"name"`
+	MinReputation int    `validate:"gte=0" json:"min_reputation"`
+	// ExternalRole the role in external system, such as the role id of Discord, it is sent as is
+	ExternalRole string `validate:"omitempty,lte=256" json:"external_role"`
+	WebhookURL   string `validate:"omitempty,gt=0,lte=512,url" json:"webhook_url"`
+}
+
+// GetTier get the tier matching the reputation, the tier with the highest MinReputation wins
+func (s *SiteReputationSyncResp) GetTier(reputation int) (tier *ReputationSyncTier) {
+	for _, t := range s.Tiers {
+		if t.MinReputation > reputation {
+			continue
+		}
+		if tier == nil || t.MinReputation > tier.MinReputation {
+			tier = t
+		}
+	}
+	return tier
+}
+
+// GetTierByName get the tier by name
+func (s *SiteReputationSyncResp) GetTierByName(name string) (tier *ReputationSyncTier) {
+	for _, t := range s.Tiers {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// SiteChatIntakeReq site chat intake request.
+// The bot token is used to get the email of chat user, which is used to find the Answer account.
+type SiteChatIntakeReq struct {
+	Enabled            bool     `json:"enabled"`
+	SlackSigningSecret string   `validate:"omitempty,lte=256" json:"slack_signing_secret"`
+	SlackBotToken      string   `validate:"omitempty,lte=256" json:"slack_bot_token"`
+	MattermostURL      string   `validate:"omitempty,gt=0,lte=512,url" json:"mattermost_url"`
+	MattermostToken    string   `validate:"omitempty,lte=256" json:"mattermost_token"`
+	MattermostBotToken string   `validate:"omitempty,lte=256" json:"mattermost_bot_token"`
+	DefaultTags        []string `validate:"omitempty,dive,gt=0,lte=35" json:"default_tags"`
+}
+
+// SiteVoteFraudReq site vote fraud detection request.
+// The votes in the last WindowHours are checked, the user voting for the same user more than the threshold is serial voting,
+// two users voting for each other more than the ring threshold is a voting ring.
+type SiteVoteFraudReq struct {
+	Enabled             bool `json:"enabled"`
+	WindowHours         int  `validate:"omitempty,gte=1,lte=720" json:"window_hours"`
+	SerialVoteThreshold int  `validate:"omitempty,gte=2,lte=1000" json:"serial_vote_threshold"`
+	RingVoteThreshold   int  `validate:"omitempty,gte=2,lte=1000" json:"ring_vote_threshold"`
+}
+
+// FillDefault fill the default window and thresholds if not set
+func (s *SiteVoteFraudResp) FillDefault() {
+	if s.WindowHours <= 0 {
+		s.WindowHours = 24
+	}
+	if s.SerialVoteThreshold <= 0 {
+		s.SerialVoteThreshold = 10
+	}
+	if s.RingVoteThreshold <= 0 {
+		s.RingVoteThreshold = 5
+	}
+}
+
+// SiteDownvoteCostReq site downvote cost request.
+// When enabled, the downvoter is charged the cost, it overrides the reputation rules of question.vote_down and answer.vote_down.
+// The cost is refunded when the downvote is cancelled.
+type SiteDownvoteCostReq struct {
+	Enabled      bool `json:"enabled"`
+	QuestionCost int  `validate:"omitempty,gte=0,lte=100" json:"question_cost"`
+	AnswerCost   int  `validate:"omitempty,gte=0,lte=100" json:"answer_cost"`
+}
+
+// SiteAnswerRankingReq site answer ranking request.
+// When enabled, the answers in default order are sorted by the weighted score instead of accepted and votes.
+type SiteAnswerRankingReq struct {
+	Enabled                bool    `json:"enabled"`
+	VoteWeight             float64 `validate:"gte=-100,lte=100" json:"vote_weight"`
+	AcceptedWeight         float64 `validate:"gte=-100,lte=100" json:"accepted_weight"`
+	RecencyWeight          float64 `validate:"gte=-100,lte=100" json:"recency_weight"`
+	RecencyHalfLifeDays    int     `validate:"omitempty,gte=1,lte=3650" json:"recency_half_life_days"`
+	AuthorReputationWeight float64 `validate:"gte=-100,lte=100" json:"author_reputation_weight"`
+	// the answer of verified author, the admin or moderator, is weighted by VerifiedWeight
+	VerifiedWeight float64 `validate:"gte=-100,lte=100" json:"verified_weight"`
+	// the solved rate of helpfulness survey, it is weighted only if the answer has enough responses
+	SolvedRateWeight float64 `validate:"gte=-100,lte=100" json:"solved_rate_weight"`
+}
+
+// FillDefault fill the default weights if none of them is set
+func (s *SiteAnswerRankingResp) FillDefault() {
+	if s.RecencyHalfLifeDays <= 0 {
+		s.RecencyHalfLifeDays = 30
+	}
+	if s.VoteWeight == 0 && s.AcceptedWeight == 0 && s.RecencyWeight == 0 &&
+		s.AuthorReputationWeight == 0 && s.VerifiedWeight == 0 && s.SolvedRateWeight == 0 {
+		s.VoteWeight = 1
+		s.AcceptedWeight = 10
+		s.RecencyWeight = 2
+		s.AuthorReputationWeight = 1
+		s.VerifiedWeight = 2
+		s.SolvedRateWeight = 5
+	}
+}
+
+// Score calculate the weighted score of answer.
+// The recency decays by half every RecencyHalfLifeDays, and the reputation is in logarithmic scale,
+// so that the old answers and the high reputation authors do not dominate.
+func (s *SiteAnswerRankingResp) Score(factor *AnswerRankingFactor, now time.Time) (score *AnswerRankingScore) {
+	score = &AnswerRankingScore{AnswerID: factor.AnswerID}
+	score.Votes = s.VoteWeight * float64(factor.VoteCount)
+	if factor.Accepted {
+		score.Accepted = s.AcceptedWeight
+	}
+	ageDays := max(now.Sub(factor.CreatedAt).Hours()/24, 0)
+	score.Recency = s.RecencyWeight * math.Pow(0.5, ageDays/float64(s.RecencyHalfLifeDays))
+	score.AuthorReputation = s.AuthorReputationWeight * math.Log10(1+float64(max(factor.AuthorRank, 0)))
+	if factor.Verified {
+		score.Verified = s.VerifiedWeight
+	}
+	if factor.SurveyResponses >= AnswerHelpfulnessMinResponses {
+		score.SolvedRate = s.SolvedRateWeight * factor.SolvedRate
+	}
+	score.Score = score.Votes + score.Accepted + score.Recency + score.AuthorReputation + score.Verified + score.SolvedRate
+	return score
+}
+
+const (
+	LanguageDetectionActionReview    = "review"
+	LanguageDetectionActionTranslate = "translate"
+)
+
+// SiteLanguageDetectionReq site content language detection request.
+// The post written in a language not allowed is sent to review, or translated by the translator plugin.
+type SiteLanguageDetectionReq struct {
+	Enabled bool `validate:"omitempty" json:"enabled"`
+	// ISO 639-1 codes, e.g. en, zh. If empty, the language of site interface is allowed only.
+	AllowedLanguages []string `validate:"omitempty,dive,gte=2,lte=8" json:"allowed_languages"`
+	Action           string   `validate:"omitempty,oneof=review translate" json:"action"`
+	// the post is checked only if the detection confidence percent is not less than it
+	MinConfidence int `validate:"omitempty,gte=0,lte=100" json:"min_confidence"`
+	// the post shorter than it is not checked, because a short text can not be detected correctly
+	MinLength int `validate:"omitempty,gte=0" json:"min_length"`
+}
+
+// FillDefault fill the default config
+func (s *SiteLanguageDetectionResp) FillDefault() {
+	if len(s.Action) == 0 {
+		s.Action = LanguageDetectionActionReview
+	}
+	if s.MinConfidence <= 0 {
+		s.MinConfidence = 40
+	}
+	if s.MinLength <= 0 {
+		s.MinLength = 20
+	}
+}
+
+// SiteSyndicationReq site question syndication request.
+// The questions with the target tags are pushed to the peer instance by signed webhooks,
+// and the questions pushed from the allowed sources are kept as read-only mirrors.
+type SiteSyndicationReq struct {
+	Enabled bool `json:"enabled"`
+	// the shared secret to sign and verify the webhooks, it must be the same on the peer instances
+	Secret string `validate:"omitempty,gte=16,lte=256" json:"secret"`
+	// the local user as the author of mirrored questions
+	MirrorUsername string `validate:"omitempty,gt=0,lte=50" json:"mirror_username"`
+	// the site urls of the instances allowed to push questions
+	AllowedSources []string             `validate:"omitempty,lte=10,dive,url,lte=512" json:"allowed_sources"`
+	Targets        []*SyndicationTarget `validate:"omitempty,lte=10,dive" json:"targets"`
+}
+
+// SyndicationTarget the questions with any of the tags are pushed to the webhook url of peer instance
+type SyndicationTarget struct {
+	WebhookURL string   `validate:"required,url,lte=512" json:"webhook_url"`
+	Tags       []string `validate:"required,gte=1,lte=10,dive,gt=0,lte=35" json:"tags"`
+}
+
+// IsAllowedSource whether the site url is allowed to push questions
+func (s *SiteSyndicationResp) IsAllowedSource(siteURL string) bool {
+	siteURL = strings.TrimSuffix(siteURL, "/")
+	for _, source := range s.AllowedSources {
+		if strings.TrimSuffix(source, "/") == siteURL {
+			return true
+		}
+	}
+	return false
+}
+
+// SiteWebhookReq site webhook request.
+// The events are posted to the webhooks subscribed them as signed json payloads, the failed deliveries are retried.
+type SiteWebhookReq struct {
+	Enabled  bool             `json:"enabled"`
+	Webhooks []*WebhookTarget `validate:"omitempty,lte=20,dive" json:"webhooks"`
+}
+
+// WebhookTarget the webhook url and the events it subscribed
+type WebhookTarget struct {
+	Name string `validate:"omitempty,lte=64" json:"name"`
+	URL  string `validate:"required,url,lte=512" json:"url"`
+	// the secret to sign the payload, the receiver verifies the signature with it
+	Secret string   `validate:"required,gte=16,lte=256" json:"secret"`
+	Events []string `validate:"required,gte=1,dive,oneof=question.created answer.accepted user.registered content.reported" json:"events"`
+	// the paused webhook receives no new deliveries
+	Paused bool `json:"paused"`
+}
+
+// GetWebhooksByEvent get the webhooks subscribed the event
+func (s *SiteWebhookResp) GetWebhooksByEvent(event string) (webhooks []*WebhookTarget) {
+	if !s.Enabled {
+		return nil
+	}
+	for _, webhook := range s.Webhooks {
+		if !webhook.Paused && slices.Contains(webhook.Events, event) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	return webhooks
+}
+
+// GetWebhookByURL get the webhook of the url, nil if it has been removed
+func (s *SiteWebhookResp) GetWebhookByURL(url string) *WebhookTarget {
+	for _, webhook := range s.Webhooks {
+		if webhook.URL == url {
+			return webhook
+		}
+	}
+	return nil
+}
+
+// SiteSlackReq site slack connector request.
+// The new questions, the daily reminders of unanswered questions and the moderation alerts
+// are posted to the slack channels by incoming webhooks.
+type SiteSlackReq struct {
+	Enabled bool `json:"enabled"`
+	// the question without answer after the hours is included in the daily reminder
+	UnansweredHours int             `validate:"omitempty,min=1,max=720" json:"unanswered_hours"`
+	Channels        []*SlackChannel `validate:"omitempty,lte=20,dive" json:"channels"`
+}
+
+// SlackChannel the slack channel and the messages posted to it
+type SlackChannel struct {
+	Name       string `validate:"omitempty,lte=64" json:"name"`
+	WebhookURL string `validate:"required,url,lte=512" json:"webhook_url"`
+	// only the questions with any of the tags are posted, empty means all questions
+	Tags                []string `validate:"omitempty,lte=10,dive,gt=0,lte=35" json:"tags"`
+	NewQuestions        bool     `json:"new_questions"`
+	UnansweredReminders bool     `json:"unanswered_reminders"`
+	ModerationAlerts    bool     `json:"moderation_alerts"`
+}
+
+// FillDefault fill default value
+func (s *SiteSlackResp) FillDefault() {
+	if s.UnansweredHours <= 0 {
+		s.UnansweredHours = 24
+	}
+}
+
+// MatchTags whether the question with the tags should be posted to the channel
+func (c *SlackChannel) MatchTags(tags []string) bool {
+	if len(c.Tags) == 0 {
+		return true
+	}
+	for _, tag := range tags {
+		if slices.Contains(c.Tags, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	MessengerPlatformTelegram = "telegram"
+	MessengerPlatformDiscord  = "discord"
+)
+
+// SiteMessengerReq site messenger connectors request.
+// The selected events are mirrored to the telegram chats by the bots or to the discord channels by the webhooks.
+type SiteMessengerReq struct {
+	Enabled    bool                  `json:"enabled"`
+	Connectors []*MessengerConnector `validate:"omitempty,lte=20,dive" json:"connectors"`
+}
+
+// MessengerConnector the telegram chat or discord channel and the events mirrored to it
+type MessengerConnector struct {
+	Name     string `validate:"required,notblank,lte=64" json:"name"`
+	Platform string `validate:"required,oneof=telegram discord" json:"platform"`
+	// the bot token and the chat id of telegram
+	BotToken string `validate:"omitempty,lte=256" json:"bot_token"`
+	ChatID   string `validate:"omitempty,lte=64" json:"chat_id"`
+	// the webhook url of discord channel
+	WebhookURL string `validate:"omitempty,url,lte=512" json:"webhook_url"`
+	// the webhook names of the events mirrored
+	Events []string `validate:"required,gte=1,dive,oneof=question.created answer.accepted" json:"events"`
+	// only the questions with any of the tags are mirrored, empty means all questions
+	Tags []string `validate:"omitempty,lte=100,dive,required" json:"tags"`
+}
+
+// Check check the connectors have the credentials of their platforms
+func (s *SiteMessengerReq) Check() (errFields []*validator.FormErrorField, err error) {
+	for _, connector := range s.Connectors {
+		var missing string
+		switch {
+		case connector.Platform == MessengerPlatformTelegram && len(connector.BotToken) == 0:
+			missing = "bot_token"
+		case connector.Platform == MessengerPlatformTelegram && len(connector.ChatID) == 0:
+			missing = "chat_id"
+		case connector.Platform == MessengerPlatformDiscord && len(connector.WebhookURL) == 0:
+			missing = "webhook_url"
+		default:
+			continue
+		}
+		errFields = append(errFields, &validator.FormErrorField{
+			ErrorField: missing,
+			ErrorMsg:   connector.Name,
+		})
+		return errFields, errors.BadRequest(reason.MessengerConnectorInvalid)
+	}
+	return nil, nil
+}
+
+// GetConnector get the connector by the name, nil if not found
+func (s *SiteMessengerResp) GetConnector(name string) *MessengerConnector {
+	for _, connector := range s.Connectors {
+		if connector.Name == name {
+			return connector
+		}
+	}
+	return nil
+}
+
+// TestMessengerConnectorReq test messenger connector request
+type TestMessengerConnectorReq struct {
+	Name string `validate:"required,lte=64" json:"name"`
+}
+
+// Match whether the event of the question with the tags should be mirrored by the connector
+func (c *MessengerConnector) Match(event string, tags []string) bool {
+	if !slices.Contains(c.Events, event) {
+		return false
+	}
+	if len(c.Tags) == 0 {
+		return true
+	}
+	for _, tag := range tags {
+		if slices.Contains(c.Tags, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// SiteSpacesReq site spaces request.
+// The space groups the questions with any of its tags into a distinct community,
+// the branding, theme, write restriction and default question order of the site are overridden in the space.
+type SiteSpacesReq struct {
+	Spaces []*SiteSpace `validate:"omitempty,lte=50,dive" json:"spaces"`
+}
+
+// SiteJiraReq site jira connector request.
+// The moderators create the jira issues from the flagged questions, the status and comments of the issues
+// are synced back as the private moderator notes, and the templated public comment is posted when the issue resolves.
+type SiteJiraReq struct {
+	Enabled bool   `json:"enabled"`
+	BaseURL string `validate:"omitempty,url,lte=512" json:"base_url"`
+	// the account email of jira cloud, empty means the api token is a personal access token of jira server
+	Email    string `validate:"omitempty,lte=256" json:"email"`
+	APIToken string `validate:"omitempty,lte=512" json:"api_token"`
+	// the username of the account posting the public comments when the issues resolve
+	BotUsername string         `validate:"omitempty,lte=30" json:"bot_username"`
+	Projects    []*JiraProject `validate:"omitempty,lte=20,dive" json:"projects"`
+}
+
+// SiteDigestReq site email digest request.
+// The digests are sent once per period after the send hour in the site time zone to the users who opted in.
+type SiteDigestReq struct {
+	Enabled  bool `json:"enabled"`
+	SendHour int  `validate:"omitempty,gte=0,lte=23" json:"send_hour"`
+	// the weekday of the weekly digest, 0 is sunday
+	WeeklyDay int `validate:"omitempty,gte=0,lte=6" json:"weekly_day"`
+	// the max number of digests sent in one run, the rest are sent in the next run of the period
+	MaxEmailsPerRun int `validate:"omitempty,gte=1,lte=10000" json:"max_emails_per_run"`
+	// the max number of questions listed in each section
+	MaxItems int `validate:"omitempty,gte=1,lte=20" json:"max_items"`
+}
+
+// SiteWebPushReq site web push request.
+// The vapid key pair is generated when it is saved empty, the public key is handed to the browsers to subscribe.
+type SiteWebPushReq struct {
+	Enabled bool `json:"enabled"`
+	// the contact of the site sent to the push services, a mailto: or https: url
+	Subject    string `validate:"omitempty,lte=256" json:"subject"`
+	PublicKey  string `validate:"omitempty,lte=128" json:"public_key"`
+	PrivateKey string `validate:"omitempty,lte=128" json:"private_key"`
+}
+
+// SiteInboundEmailReq site inbound email request.
+// When enabled, the notification emails are replied to the signed plus address of the reply address,
+// the replies are fetched from the IMAP mailbox or pushed to the webhook and posted by the user who received the email.
+type SiteInboundEmailReq struct {
+	Enabled bool `json:"enabled"`
+	// the mailbox receiving the replies, it must accept the plus addressing, e.g. reply+token@example.com
+	ReplyAddress string `validate:"omitempty,email,lte=128" json:"reply_address"`
+	// the secret signing the reply addresses and the token of the webhook, they are generated when saved empty
+	Secret       string `validate:"omitempty,lte=128" json:"secret"`
+	WebhookToken string `validate:"omitempty,lte=128" json:"webhook_token"`
+	// the IMAP mailbox polled for the replies, the polling is disabled if the host is empty
+	IMAPHost     string `validate:"omitempty,lte=256" json:"imap_host"`
+	IMAPPort     int    `validate:"omitempty,gte=1,lte=65535" json:"imap_port"`
+	IMAPTLS      bool   `json:"imap_tls"`
+	IMAPUsername string `validate:"omitempty,lte=256" json:"imap_username"`
+	IMAPPassword string `validate:"omitempty,lte=256" json:"imap_password"`
+	IMAPMailbox  string `validate:"omitempty,lte=256" json:"imap_mailbox"`
+}
+
+// SiteNotificationRetentionReq site notification retention request.
+// When enabled, the read notifications not updated in the retention days are archived or deleted every day.
+type SiteNotificationRetentionReq struct {
+	Enabled           bool `json:"enabled"`
+	ReadRetentionDays int  `validate:"omitempty,gte=1,lte=3650" json:"read_retention_days"`
+	// archive moves the notifications into the archive table, delete removes them permanently
+	Action string `validate:"omitempty,oneof=archive delete" json:"action"`
+}
+
+// SiteSCIMReq site SCIM request.
+// When enabled, the identity provider provisions the users and assigns the roles with the SCIM 2.0 API at /answer/scim/v2.
+type SiteSCIMReq struct {
+	Enabled bool `json:"enabled"`
+	// the bearer token of the identity provider, it is generated when saved empty
+	Token string `validate:"omitempty,gte=32,lte=128" json:"token"`
+}
+
+// SiteOAuthProviderReq site OAuth provider request.
+// When enabled, the site is the OpenID provider of the clients registered by admin with the authorization code flow.
+type SiteOAuthProviderReq struct {
+	Enabled bool `json:"enabled"`
+	// the P-256 private key signing the id tokens, it is generated when saved empty
+	SigningKey string `validate:"omitempty,lte=128" json:"signing_key"`
+}
+
+// SiteLoginProtectionReq site login protection request.
+// The account or ip is locked after the failed login attempts, the lockout is doubled each time it is locked again
+// until the max lockout, and the failures are forgotten after the max lockout without any failure.
+type SiteLoginProtectionReq struct {
+	Enabled            bool `json:"enabled"`
+	MaxAccountAttempts int  `validate:"omitempty,gte=1,lte=100" json:"max_account_attempts"`
+	MaxIPAttempts      int  `validate:"omitempty,gte=1,lte=1000" json:"max_ip_attempts"`
+	LockoutMinutes     int  `validate:"omitempty,gte=1,lte=1440" json:"lockout_minutes"`
+	MaxLockoutMinutes  int  `validate:"omitempty,gte=1,lte=43200" json:"max_lockout_minutes"`
+	// notify the user by email when the account is locked
+	NotifyUser bool `json:"notify_user"`
+}
+
+// SiteInviteReq site invite request.
+// When invite-only, the registration requires a valid invite code generated by the admins,
+// or the users reached the min reputation if it is set.
+type SiteInviteReq struct {
+	InviteOnly bool `json:"invite_only"`
+	// only the admins generate the invite codes if it is 0
+	MinReputation int `validate:"omitempty,gte=0" json:"min_reputation"`
+	// the limits of the invite codes generated by the users who are not admin
+	MaxUsesPerCode  int `validate:"omitempty,gte=1,lte=1000" json:"max_uses_per_code"`
+	MaxCodesPerUser int `validate:"omitempty,gte=1,lte=1000" json:"max_codes_per_user"`
+}
+
+func (s *SiteInviteResp) FillDefault() {
+	if s.MaxUsesPerCode <= 0 {
+		s.MaxUsesPerCode = 5
+	}
+	if s.MaxCodesPerUser <= 0 {
+		s.MaxCodesPerUser = 10
+	}
+}
+
+func (s *SiteLoginProtectionResp) FillDefault() {
+	if s.MaxAccountAttempts <= 0 {
+		s.MaxAccountAttempts = 5
+	}
+	if s.MaxIPAttempts <= 0 {
+		s.MaxIPAttempts = 20
+	}
+	if s.LockoutMinutes <= 0 {
+		s.LockoutMinutes = 15
+	}
+	if s.MaxLockoutMinutes < s.LockoutMinutes {
+		s.MaxLockoutMinutes = max(24*60, s.LockoutMinutes)
+	}
+}
+
+// SiteHelpfulnessSurveyReq site helpfulness survey request.
+// When enabled, the user viewed an accepted answer from search is asked whether it solved the problem after the delay days.
+type SiteHelpfulnessSurveyReq struct {
+	Enabled   bool `json:"enabled"`
+	DelayDays int  `validate:"omitempty,gte=1,lte=90" json:"delay_days"`
+}
+
+// FillDefault fill the default delay days if not set
+func (s *SiteHelpfulnessSurveyResp) FillDefault() {
+	if s.DelayDays <= 0 {
+		s.DelayDays = 7
+	}
+}
+
+// SiteReEngagementReq site re-engagement email request
+type SiteReEngagementReq struct {
+	Enabled   bool                    `json:"enabled"`
+	Campaigns []*ReEngagementCampaign `validate:"omitempty,lte=10,dive" json:"campaigns"`
+}
+
+// SiteQuestionQualityReq site question quality request.
+// When enabled, the new questions scored below the threshold are routed into the needs improvement queue,
+// the users whose reputation reaches the min reputation can browse and claim the items.
+type SiteQuestionQualityReq struct {
+	Enabled       bool `json:"enabled"`
+	Threshold     int  `validate:"omitempty,gte=1,lte=100" json:"threshold"`
+	MinReputation int  `validate:"omitempty,gte=1" json:"min_reputation"`
+	// the claimed item is released to others if the claimer does not improve the question in the hours
+	ClaimHours int `validate:"omitempty,gte=1,lte=720" json:"claim_hours"`
+}
+
+// FillDefault fill the default threshold, min reputation and claim hours if not set
+func (s *SiteQuestionQualityResp) FillDefault() {
+	if s.Threshold <= 0 {
+		s.Threshold = 60
+	}
+	if s.MinReputation <= 0 {
+		s.MinReputation = 500
+	}
+	if s.ClaimHours <= 0 {
+		s.ClaimHours = 24
+	}
+}
+
+// SiteTagRulesReq site tag rules request
+type SiteTagRulesReq struct {
+	Rules []*TagRule `validate:"omitempty,lte=50,dive" json:"rules"`
+}
+
+// SiteTagBlocklistReq site tag blocklist request.
+// The tags matching one of the names or the regular expression patterns cannot be created or applied to questions.
+type SiteTagBlocklistReq struct {
+	Names    []string `validate:"omitempty,lte=500,dive,gt=0,lte=35" json:"names"`
+	Patterns []string `validate:"omitempty,lte=50,dive,gt=0,lte=200" json:"patterns"`
+}
+
+// SiteLoadSheddingReq site load shedding request.
+// When enabled and the server is overloaded, the low priority requests, the anonymous list and feed requests,
+// are queued for at most QueueTimeoutMs and then rejected, the writes and logged-in requests are never shed.
+// The server is overloaded if the in-flight requests exceed MaxInFlight or the average latency exceeds LatencyThresholdMs.
+type SiteLoadSheddingReq struct {
+	Enabled            bool `json:"enabled"`
+	MaxInFlight        int  `validate:"omitempty,gte=1,lte=100000" json:"max_in_flight"`
+	LatencyThresholdMs int  `validate:"omitempty,gte=1,lte=60000" json:"latency_threshold_ms"`
+	MaxQueueDepth      int  `validate:"omitempty,gte=1,lte=100000" json:"max_queue_depth"`
+	QueueTimeoutMs     int  `validate:"omitempty,gte=1,lte=30000" json:"queue_timeout_ms"`
+}
+
+// FillDefault fill the default thresholds if not set
+func (s *SiteLoadSheddingResp) FillDefault() {
+	if s.MaxInFlight <= 0 {
+		s.MaxInFlight = 200
+	}
+	if s.LatencyThresholdMs <= 0 {
+		s.LatencyThresholdMs = 2000
+	}
+	if s.MaxQueueDepth <= 0 {
+		s.MaxQueueDepth = 100
+	}
+	if s.QueueTimeoutMs <= 0 {
+		s.QueueTimeoutMs = 500
+	}
+}
+
+// SiteAutoPromotionReq site auto promotion request.
+// When enabled, the users are evaluated daily, the role of rule is granted to the users who meet its thresholds
+// in the sustained days and revoked after they fail the thresholds in the revoke days.
+type SiteAutoPromotionReq struct {
+	Enabled bool                 `json:"enabled"`
+	Rules   []*AutoPromotionRule `validate:"omitempty,lte=10,dive" json:"rules"`
+}
+
+// SiteContentFreshnessReq site content freshness request.
+// The freshness report is analyzed daily in the tags with questions asked in the active days,
+// the accepted answers not updated in the stale years and the questions referencing the deprecated versions are counted.
+type SiteContentFreshnessReq struct {
+	StaleYears    int `validate:"omitempty,gte=1,lte=20" json:"stale_years"`
+	ActiveTagDays int `validate:"omitempty,gte=1,lte=3650" json:"active_tag_days"`
+	// the keywords of deprecated versions such as "python 2" or "angularjs", matched in the title and content of questions
+	DeprecatedVersions []string `validate:"omitempty,lte=50,dive,gt=0,lte=50" json:"deprecated_versions"`
+}
+
+// Check trim the deprecated versions and remove the duplicates
+func (s *SiteContentFreshnessReq) Check() (errFields []*validator.FormErrorField, err error) {
+	versions := make([]string, 0, len(s.DeprecatedVersions))
+	for _, version := range s.DeprecatedVersions {
+		version = strings.TrimSpace(version)
+		if len(version) > 0 && !slices.Contains(versions, version) {
+			versions = append(versions, version)
+		}
+	}
+	s.DeprecatedVersions = versions
+	return nil, nil
+}
+
+// FillDefault fill the default stale years and active tag days if not set
+func (s *SiteContentFreshnessResp) FillDefault() {
+	if s.StaleYears <= 0 {
+		s.StaleYears = 3
+	}
+	if s.ActiveTagDays <= 0 {
+		s.ActiveTagDays = 90
+	}
+}
+
+// SiteQuestionMigrationReq site question migration request.
+// The destinations can be suggested by moderators when closing a question as off-topic,
+// then the author can repost the question there with one click.
+type SiteQuestionMigrationReq struct {
+	Destinations []*QuestionMigrationDestination `validate:"omitempty,lte=50,dive" json:"destinations"`
+}
+
+// QuestionMigrationDestination another community, space or external site that the question can be reposted to
+type QuestionMigrationDestination struct {
+	Name        string `validate:"required,notblank,lte=100" json:"name"`
+	URL         string `validate:"required,url,lte=512" json:"url"`
+	Description string `validate:"omitempty,lte=500" json:"description"`
+	// RepostURL the url template to repost, the {title}, {content} and {tags} are replaced with the question,
+	// if empty, the destination is regarded as another Answer site and its ask page is prefilled
+	RepostURL string `validate:"omitempty,lte=1024" json:"repost_url"`
+}
+
+// Check the names of destinations should be unique
+func (s *SiteQuestionMigrationReq) Check() (errFields []*validator.FormErrorField, err error) {
+	names := make([]string, 0, len(s.Destinations))
+	for _, destination := range s.Destinations {
+		destination.Name = strings.TrimSpace(destination.Name)
+		destination.URL = strings.TrimSuffix(destination.URL, "/")
+		if slices.Contains(names, destination.Name) {
+			return append(errFields, &validator.FormErrorField{
+				ErrorField: "destinations",
+				ErrorMsg:   reason.RequestFormatError,
+			}), errors.BadRequest(reason.RequestFormatError)
+		}
+		names = append(names, destination.Name)
+	}
+	return nil, nil
+}
+
+// GetDestination get the destination by name
+func (s *SiteQuestionMigrationResp) GetDestination(name string) *QuestionMigrationDestination {
+	for _, destination := range s.Destinations {
+		if destination.Name == name {
+			return destination
+		}
+	}
+	return nil
+}
+
+// BuildRepostURL build the url to repost the question to this destination with its title, content and tags carried over
+func (d *QuestionMigrationDestination) BuildRepostURL(title, content string, tags []string) string {
+	if len(d.RepostURL) > 0 {
+		return strings.NewReplacer(
+			"{title}", url.QueryEscape(title),
+			"{content}", url.QueryEscape(content),
+			"{tags}", url.QueryEscape(strings.Join(tags, ",")),
+		).Replace(d.RepostURL)
+	}
+	// the prefill of ask page is a front matter, which is decoded once more by the page
+	prefill := fmt.Sprintf("---\ntitle: %s\ntags: %s\n---\n%s",
+		strconv.Quote(title), strings.Join(tags, ","), content)
+	return d.URL + "/questions/ask?prefill=" + url.QueryEscape(url.PathEscape(prefill))
+}
+
+// SiteCacheWarmingReq site cache warming request.
+// When enabled, the questions predicted to be hot are cached for the visitors before the traffic arrives
+type SiteCacheWarmingReq struct {
+	Enabled bool `json:"enabled"`
+	// the max number of questions warmed in one run
+	MaxQuestions int `validate:"omitempty,gte=1,lte=1000" json:"max_questions"`
+	// the question is warmed if its heat score reaches it,
+	// the heat score is the views per minute plus one for each signal of recently answered, trending and externally linked
+	MinHeatScore float64 `validate:"omitempty,gt=0,lte=10000" json:"min_heat_score"`
+}
+
+// FillDefault fill the default max questions and min heat score if not set
+func (s *SiteCacheWarmingResp) FillDefault() {
+	if s.MaxQuestions <= 0 {
+		s.MaxQuestions = 50
+	}
+	if s.MinHeatScore <= 0 {
+		s.MinHeatScore = 2
+	}
+}
+
+// SiteVoteMilestoneReq site vote milestone request.
+// When enabled, the authors are notified once the post crosses the milestones instead of on every single vote,
+// unless they opt in to every vote notifications.
+type SiteVoteMilestoneReq struct {
+	Enabled    bool  `json:"enabled"`
+	Milestones []int `validate:"omitempty,lte=20,dive,gte=1" json:"milestones"`
+}
+
+// FillDefault fill the default milestones if not set
+func (s *SiteVoteMilestoneResp) FillDefault() {
+	if len(s.Milestones) == 0 {
+		s.Milestones = []int{10, 25, 50, 100}
+	}
+}
+
+// ReachedMilestone returns the highest milestone the vote count has reached, 0 if none
+func (s *SiteVoteMilestoneResp) ReachedMilestone(voteCount int) (milestone int) {
+	for _, m := range s.Milestones {
+		if m <= voteCount && m > milestone {
+			milestone = m
+		}
+	}
+	return milestone
+}
+
+// SiteAutoCommentReq site auto comment request.
+// When enabled, the bot account posts the templated comment of the first matched rule under the new question,
+// at most max comments per hour across the site.
+type SiteAutoCommentReq struct {
+	Enabled bool `json:"enabled"`
+	// the username of the designated system account posting the comments
+	BotUsername        string             `validate:"omitempty,lte=30" json:"bot_username"`
+	MaxCommentsPerHour int                `validate:"omitempty,gte=1,lte=10000" json:"max_comments_per_hour"`
+	OptOutTags         []string           `validate:"omitempty,lte=100,dive,required" json:"opt_out_tags"`
+	Rules              []*AutoCommentRule `validate:"omitempty,lte=20,dive" json:"rules"`
+}
+
+// FillDefault fill the default rate limit if not set
+func (s *SiteAutoCommentResp) FillDefault() {
+	if s.MaxCommentsPerHour <= 0 {
+		s.MaxCommentsPerHour = 30
+	}
+}
+
+// GetReputationRulesResp get reputation rules response
+type GetReputationRulesResp struct {
+	Rules []*constant.ReputationRule `json:"rules"`
+	// the max reputation can be gained in one day, the accepted answer is not limited
+	DailyLimit int `json:"daily_limit"`
+}
+
+// UpdateReputationRulesReq update reputation rules request, the rules not in request are not changed
+type UpdateReputationRulesReq struct {
+	Rules      []*constant.ReputationRule `validate:"omitempty,dive" json:"rules"`
+	DailyLimit int                        `validate:"omitempty,gte=1,lte=100000" json:"daily_limit"`
+}
+
 // SiteLoginReq site login request
 type SiteLoginReq struct {
 	AllowNewRegistrations   bool     `json:"allow_new_registrations"`
@@ -163,6 +1094,10 @@ type SiteLoginReq struct {
 	AllowPasswordLogin      bool     `json:"allow_password_login"`
 	LoginRequired           bool     `json:"login_required"`
 	AllowEmailDomains       []string `json:"allow_email_domains"`
+	// the email domains and their subdomains could not register
+	BlockEmailDomains []string `json:"block_email_domains"`
+	// block the disposable email domains of the maintained list
+	BlockDisposableEmailDomains bool `json:"block_disposable_email_domains"`
 }
 
 // SiteCustomCssHTMLReq site custom css html
@@ -203,12 +1138,131 @@ type SiteBrandingResp SiteBrandingReq
 // SiteLoginResp site login response
 type SiteLoginResp SiteLoginReq
 
+// IsEmailDomainAllowed the email domain must be allowed and not blocked
+func (s *SiteLoginResp) IsEmailDomainAllowed(email string) bool {
+	if !checker.EmailInAllowEmailDomain(email, s.AllowEmailDomains) {
+		return false
+	}
+	if checker.EmailInEmailDomains(email, s.BlockEmailDomains) {
+		return false
+	}
+	return !s.BlockDisposableEmailDomains || !checker.IsDisposableEmail(email)
+}
+
 // SiteCustomCssHTMLResp site custom css html response
 type SiteCustomCssHTMLResp SiteCustomCssHTMLReq
 
 // SiteUsersResp site users response
 type SiteUsersResp SiteUsersReq
 
+// SiteLimitsResp site content limits response
+type SiteLimitsResp SiteLimitsReq
+
+// SiteQuestionCloseResp site question close vote response
+type SiteQuestionCloseResp SiteQuestionCloseReq
+
+// SiteRateLimitResp site rate limit response
+type SiteRateLimitResp SiteRateLimitReq
+
+// SiteDataDumpResp site public data dump response
+type SiteDataDumpResp SiteDataDumpReq
+
+// SiteReputationSyncResp site reputation and role sync response
+type SiteReputationSyncResp SiteReputationSyncReq
+
+// SiteChatIntakeResp site chat intake response
+type SiteChatIntakeResp SiteChatIntakeReq
+
+// SiteVoteFraudResp site vote fraud detection response
+type SiteVoteFraudResp SiteVoteFraudReq
+
+// SiteDownvoteCostResp site downvote cost response
+type SiteDownvoteCostResp SiteDownvoteCostReq
+
+// SiteAnswerRankingResp site answer ranking response
+type SiteAnswerRankingResp SiteAnswerRankingReq
+
+// SiteLanguageDetectionResp site content language detection response
+type SiteLanguageDetectionResp SiteLanguageDetectionReq
+
+// SiteSyndicationResp site question syndication response
+type SiteSyndicationResp SiteSyndicationReq
+
+// SiteWebhookResp site webhook response
+type SiteWebhookResp SiteWebhookReq
+
+// SiteSlackResp site slack connector response
+type SiteSlackResp SiteSlackReq
+
+// SiteMessengerResp site messenger connectors response
+type SiteMessengerResp SiteMessengerReq
+
+// SiteSpacesResp site spaces response
+type SiteSpacesResp SiteSpacesReq
+
+// SiteJiraResp site jira connector response
+type SiteJiraResp SiteJiraReq
+
+// SiteDigestResp site email digest response
+type SiteDigestResp SiteDigestReq
+
+// SiteWebPushResp site web push response
+type SiteWebPushResp SiteWebPushReq
+
+// SiteInboundEmailResp site inbound email response
+type SiteInboundEmailResp SiteInboundEmailReq
+
+// SiteNotificationRetentionResp site notification retention response
+type SiteNotificationRetentionResp SiteNotificationRetentionReq
+
+// SiteSCIMResp site SCIM response
+type SiteSCIMResp SiteSCIMReq
+
+// SiteOAuthProviderResp site OAuth provider response
+type SiteOAuthProviderResp SiteOAuthProviderReq
+
+// SiteLoginProtectionResp site login protection response
+type SiteLoginProtectionResp SiteLoginProtectionReq
+
+// SiteInviteResp site invite response
+type SiteInviteResp SiteInviteReq
+
+// SiteHelpfulnessSurveyResp site helpfulness survey response
+type SiteHelpfulnessSurveyResp SiteHelpfulnessSurveyReq
+
+// SiteReEngagementResp site re-engagement email response
+type SiteReEngagementResp SiteReEngagementReq
+
+// SiteQuestionQualityResp site question quality response
+type SiteQuestionQualityResp SiteQuestionQualityReq
+
+// SiteTagRulesResp site tag rules response
+type SiteTagRulesResp SiteTagRulesReq
+
+// SiteVoteMilestoneResp site vote milestone response
+type SiteVoteMilestoneResp SiteVoteMilestoneReq
+
+// SiteAutoCommentResp site auto comment response
+type SiteAutoCommentResp SiteAutoCommentReq
+
+// SiteTagBlocklistResp site tag blocklist response
+type SiteTagBlocklistResp SiteTagBlocklistReq
+
+// SiteLoadSheddingResp site load shedding response
+type SiteLoadSheddingResp SiteLoadSheddingReq
+
+// SiteAutoPromotionResp site auto promotion response
+type SiteAutoPromotionResp SiteAutoPromotionReq
+
+// SiteContentFreshnessResp site content freshness response
+type SiteContentFreshnessResp SiteContentFreshnessReq
+
+// SiteQuestionMigrationResp site question migration response
+type SiteQuestionMigrationResp SiteQuestionMigrationReq
+
+// SiteCacheWarmingResp site cache warming response
+type SiteCacheWarmingResp SiteCacheWarmingReq
+
 // SiteThemeResp site theme response
 type SiteThemeResp struct {
 	ThemeOptions []*ThemeOption         `json:"theme_options"`
@@ -245,6 +1299,11 @@ type SiteLegalSimpleResp struct {
 	ExternalContentDisplay string `validate:"required,oneof=always_display ask_before_display" json:"external_content_display"`
 }
 
+// SiteInviteSimpleResp whether the registration requires the invite code
+type SiteInviteSimpleResp struct {
+	InviteOnly bool `json:"invite_only"`
+}
+
 // SiteSeoResp site write response
 type SiteSeoResp SiteSeoReq
 
@@ -260,8 +1319,13 @@ type SiteInfoResp struct {
 	SiteUsers     *SiteUsersResp         `json:"site_users"`
 	Write         *SiteWriteResp         `json:"site_write"`
 	Legal         *SiteLegalSimpleResp   `json:"site_legal"`
-	Version       string                 `json:"version"`
-	Revision      string                 `json:"revision"`
+	Limits        *SiteLimitsResp        `json:"site_limits"`
+	QuestionClose *SiteQuestionCloseResp `json:"site_question_close"`
+	Invite        *SiteInviteSimpleResp  `json:"site_invite"`
+	// the space the request resolved to, the branding and theme are already overridden by it
+	Space    *SpaceInfoResp `json:"space,omitempty"`
+	Version  string         `json:"version"`
+	Revision string         `json:"revision"`
 }
 type TemplateSiteInfoResp struct {
 	General       *SiteGeneralResp       `json:"general"`
@@ -433,6 +1497,7 @@ var (
 		constant.RankTagAuditKey:                  {1, 2500, 5000},
 		constant.RankTagEditWithoutReviewKey:      {1, 10000, 20000},
 		constant.RankTagSynonymKey:                {1, 10000, 20000},
+		constant.RankQuestionCloseVoteKey:         {1, 1500, 3000},
 	}
 )
 
@@ -0,0 +1,146 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSiteRateLimitResp_GetTier(t *testing.T) {
+	rateLimit := &SiteRateLimitResp{
+		Comment: []*RateLimitTier{
+			{MinReputation: 0, MaxCount: 1, PeriodSeconds: 60},
+			{MinReputation: 1000, MaxCount: 30, PeriodSeconds: 60},
+			{MinReputation: 100, MaxCount: 5, PeriodSeconds: 60},
+		},
+	}
+
+	assert.Equal(t, 1, rateLimit.GetTier(RateLimitActionComment, 1).MaxCount)
+	assert.Equal(t, 5, rateLimit.GetTier(RateLimitActionComment, 100).MaxCount)
+	assert.Equal(t, 5, rateLimit.GetTier(RateLimitActionComment, 999).MaxCount)
+	assert.Equal(t, 30, rateLimit.GetTier(RateLimitActionComment, 5000).MaxCount)
+	assert.Nil(t, rateLimit.GetTier(RateLimitActionAnswer, 5000))
+}
+
+func TestSiteAnswerRankingResp_Score(t *testing.T) {
+	now := time.Now()
+	ranking := &SiteAnswerRankingResp{
+		VoteWeight:             1,
+		AcceptedWeight:         10,
+		RecencyWeight:          4,
+		RecencyHalfLifeDays:    10,
+		AuthorReputationWeight: 1,
+		VerifiedWeight:         2,
+	}
+
+	score := ranking.Score(&AnswerRankingFactor{
+		VoteCount:  3,
+		Accepted:   true,
+		CreatedAt:  now.Add(-10 * 24 * time.Hour),
+		AuthorRank: 99,
+		Verified:   true,
+	}, now)
+	assert.Equal(t, 3.0, score.Votes)
+	assert.Equal(t, 10.0, score.Accepted)
+	assert.InDelta(t, 2.0, score.Recency, 0.001)
+	assert.InDelta(t, 2.0, score.AuthorReputation, 0.001)
+	assert.Equal(t, 2.0, score.Verified)
+	assert.InDelta(t, 19.0, score.Score, 0.001)
+
+	score = ranking.Score(&AnswerRankingFactor{VoteCount: -2, CreatedAt: now, AuthorRank: -5}, now)
+	assert.InDelta(t, 2.0, score.Score, 0.001)
+}
+
+func TestSiteVoteMilestoneResp_ReachedMilestone(t *testing.T) {
+	milestone := &SiteVoteMilestoneResp{}
+	milestone.FillDefault()
+	assert.Equal(t, []int{10, 25, 50, 100}, milestone.Milestones)
+
+	assert.Equal(t, 0, milestone.ReachedMilestone(9))
+	assert.Equal(t, 10, milestone.ReachedMilestone(10))
+	assert.Equal(t, 25, milestone.ReachedMilestone(49))
+	assert.Equal(t, 100, milestone.ReachedMilestone(1000))
+	assert.Equal(t, 0, milestone.ReachedMilestone(-3))
+}
+
+func TestQuestionMigrationDestination_BuildRepostURL(t *testing.T) {
+	external := &QuestionMigrationDestination{
+		Name:      "Example",
+		URL:       "https://example.com",
+		RepostURL: "https://example.com/new?title={title}&body={content}&tags={tags}",
+	}
+	assert.Equal(t, "https://example.com/new?title=How+to+go%3F&body=a%26b&tags=go%2Cgin",
+		external.BuildRepostURL("How to go?", "a&b", []string{"go", "gin"}))
+
+	answer := &QuestionMigrationDestination{Name: "Answer", URL: "https://answer.example.com"}
+	repostURL, err := url.Parse(answer.BuildRepostURL("How to go?", "50% done", []string{"go"}))
+	assert.NoError(t, err)
+	assert.Equal(t, "/questions/ask", repostURL.Path)
+	prefill, err := url.PathUnescape(repostURL.Query().Get("prefill"))
+	assert.NoError(t, err)
+	assert.Equal(t, "---\ntitle: \"How to go?\"\ntags: go\n---\n50% done", prefill)
+}
+
+func TestSlackChannel_MatchTags(t *testing.T) {
+	all := &SlackChannel{}
+	assert.True(t, all.MatchTags(nil))
+	assert.True(t, all.MatchTags([]string{"go"}))
+
+	channel := &SlackChannel{Tags: []string{"go", "rust"}}
+	assert.True(t, channel.MatchTags([]string{"python", "rust"}))
+	assert.False(t, channel.MatchTags([]string{"python"}))
+	assert.False(t, channel.MatchTags(nil))
+}
+
+func TestMessengerConnector_Match(t *testing.T) {
+	connector := &MessengerConnector{
+		Events: []string{WebhookEventQuestionCreated},
+		Tags:   []string{"go"},
+	}
+	assert.True(t, connector.Match(WebhookEventQuestionCreated, []string{"rust", "go"}))
+	assert.False(t, connector.Match(WebhookEventQuestionCreated, []string{"rust"}))
+	assert.False(t, connector.Match(WebhookEventAnswerAccepted, []string{"go"}))
+
+	connector.Tags = nil
+	assert.True(t, connector.Match(WebhookEventQuestionCreated, nil))
+}
+
+func TestSiteMessengerReq_Check(t *testing.T) {
+	_, err := (&SiteMessengerReq{Connectors: []*MessengerConnector{
+		{Name: "tg", Platform: MessengerPlatformTelegram, BotToken: "token", ChatID: "-100"},
+		{Name: "dc", Platform: MessengerPlatformDiscord, WebhookURL: "https://discord.com/api/webhooks/1/x"},
+	}}).Check()
+	assert.NoError(t, err)
+
+	errFields, err := (&SiteMessengerReq{Connectors: []*MessengerConnector{
+		{Name: "tg", Platform: MessengerPlatformTelegram, BotToken: "token"},
+	}}).Check()
+	assert.Error(t, err)
+	assert.Equal(t, "chat_id", errFields[0].ErrorField)
+
+	_, err = (&SiteMessengerReq{Connectors: []*MessengerConnector{
+		{Name: "dc", Platform: MessengerPlatformDiscord},
+	}}).Check()
+	assert.Error(t, err)
+}
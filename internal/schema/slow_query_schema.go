@@ -0,0 +1,109 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"sort"
+
+	"github.com/apache/answer/internal/base/data"
+)
+
+const (
+	SlowQueryReportDefaultLimit = 50
+	SlowQueryReportMaxLimit     = 500
+)
+
+// GetSlowQueryReportReq get slow query report request
+type GetSlowQueryReportReq struct {
+	// only the queries executed by this handler, e.g. GET /answer/api/v1/question/page
+	Origin string `validate:"omitempty,lte=512" form:"origin"`
+	Limit  int    `validate:"omitempty,min=1,max=500" form:"limit"`
+}
+
+// GetSlowQueryReportResp get slow query report response
+type GetSlowQueryReportResp struct {
+	ThresholdMs  int64 `json:"threshold_ms"`
+	TotalQueries int64 `json:"total_queries"`
+	SlowQueries  int64 `json:"slow_queries"`
+	// the counters are collected since this timestamp
+	Since int64                  `json:"since"`
+	Items []*SlowQueryReportItem `json:"items"`
+}
+
+// SlowQueryReportItem the slow queries grouped by handler and normalized sql
+type SlowQueryReportItem struct {
+	Origin     string   `json:"origin"`
+	SQL        string   `json:"sql"`
+	Count      int      `json:"count"`
+	ErrorCount int      `json:"error_count"`
+	TotalMs    int64    `json:"total_ms"`
+	AvgMs      int64    `json:"avg_ms"`
+	MaxMs      int64    `json:"max_ms"`
+	LastArgs   []string `json:"last_args"`
+	LastError  string   `json:"last_error"`
+	LastSeenAt int64    `json:"last_seen_at"`
+}
+
+// NewSlowQueryReportItems group the slow queries by handler and sql, the most expensive in total first
+func NewSlowQueryReportItems(queries []*data.SlowQuery, origin string, limit int) (items []*SlowQueryReportItem) {
+	if limit <= 0 {
+		limit = SlowQueryReportDefaultLimit
+	}
+	grouped := make(map[string]*SlowQueryReportItem)
+	items = make([]*SlowQueryReportItem, 0)
+	for _, query := range queries {
+		if len(origin) > 0 && query.Origin != origin {
+			continue
+		}
+		key := query.Origin + "\n" + query.SQL
+		item, ok := grouped[key]
+		if !ok {
+			item = &SlowQueryReportItem{Origin: query.Origin, SQL: query.SQL}
+			grouped[key] = item
+			items = append(items, item)
+		}
+		ms := query.Duration.Milliseconds()
+		item.Count++
+		item.TotalMs += ms
+		if ms > item.MaxMs {
+			item.MaxMs = ms
+		}
+		if len(query.Err) > 0 {
+			item.ErrorCount++
+		}
+		if query.At.Unix() >= item.LastSeenAt {
+			item.LastSeenAt = query.At.Unix()
+			item.LastArgs = query.Args
+			if len(query.Err) > 0 {
+				item.LastError = query.Err
+			}
+		}
+	}
+	for _, item := range items {
+		item.AvgMs = item.TotalMs / int64(item.Count)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].TotalMs > items[j].TotalMs
+	})
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items
+}
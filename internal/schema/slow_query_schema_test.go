@@ -0,0 +1,50 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSlowQueryReportItems(t *testing.T) {
+	now := time.Now()
+	queries := []*data.SlowQuery{
+		{Origin: "GET /question/page", SQL: "SELECT * FROM question", Duration: 300 * time.Millisecond, At: now},
+		{Origin: "GET /question/page", SQL: "SELECT * FROM question", Duration: 500 * time.Millisecond,
+			Args: []string{"1"}, At: now.Add(time.Second)},
+		{Origin: "GET /tags", SQL: "SELECT * FROM tag", Duration: 900 * time.Millisecond, Err: "timeout", At: now},
+	}
+	items := NewSlowQueryReportItems(queries, "", 0)
+	assert.Len(t, items, 2)
+	assert.Equal(t, "GET /tags", items[0].Origin)
+	assert.Equal(t, 1, items[0].ErrorCount)
+	assert.Equal(t, 2, items[1].Count)
+	assert.Equal(t, int64(400), items[1].AvgMs)
+	assert.Equal(t, int64(500), items[1].MaxMs)
+	assert.Equal(t, []string{"1"}, items[1].LastArgs)
+
+	items = NewSlowQueryReportItems(queries, "GET /question/page", 1)
+	assert.Len(t, items, 1)
+	assert.Equal(t, "SELECT * FROM question", items[0].SQL)
+}
@@ -0,0 +1,235 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/validator"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// SiteSpace the space and the site settings overridden in it
+type SiteSpace struct {
+	Slug        string `validate:"required,gt=0,lte=35" json:"slug"`
+	Name        string `validate:"required,notblank,lte=64" json:"name"`
+	Description string `validate:"omitempty,lte=512" json:"description"`
+	// the slug names of the tags, the question with any of the tags belongs to the space
+	Tags []string `validate:"required,gt=0,lte=100,dive,gt=0,lte=35" json:"tags"`
+	// the branding images overriding the site ones, empty image means the site one
+	Branding *SiteBrandingReq `validate:"omitempty" json:"branding"`
+	// the color scheme and theme config overriding the site theme, empty means the site one
+	ColorScheme string         `validate:"omitempty,lte=100" json:"color_scheme"`
+	ThemeConfig map[string]any `validate:"omitempty" json:"theme_config"`
+	// only the moderators can ask or answer the questions in the space
+	ModeratorOnly bool `json:"moderator_only"`
+	// the reputation required to ask or answer the questions in the space, 0 means no restriction
+	MinReputation int `validate:"omitempty,gte=0,lte=1000000" json:"min_reputation"`
+	// the question order used by the question list of the space if not set by the request
+	DefaultOrder string `validate:"omitempty,oneof=newest active hot score unanswered frequent" json:"default_order"`
+	// the content of the private space is only visible to the admins and the users with the allowed roles
+	Private      bool  `json:"private"`
+	AllowedRoles []int `validate:"omitempty,lte=50,dive,gt=0" json:"allowed_roles"`
+}
+
+// SpaceInfoResp the space info resolved by the request
+type SpaceInfoResp struct {
+	Slug          string   `json:"slug"`
+	Name          string   `json:"name"`
+	Description   string   `json:"description"`
+	Tags          []string `json:"tags"`
+	ModeratorOnly bool     `json:"moderator_only"`
+	MinReputation int      `json:"min_reputation"`
+	DefaultOrder  string   `json:"default_order"`
+	Private       bool     `json:"private"`
+}
+
+// Check check the slugs of spaces are unique and every tag belongs to one space at most
+func (s *SiteSpacesReq) Check() (errFields []*validator.FormErrorField, err error) {
+	slugs := make(map[string]bool, len(s.Spaces))
+	tags := make(map[string]string)
+	for _, space := range s.Spaces {
+		slug := strings.ToLower(space.Slug)
+		if slugs[slug] {
+			errFields = append(errFields, &validator.FormErrorField{
+				ErrorField: "spaces",
+				ErrorMsg:   space.Slug,
+			})
+			return errFields, errors.BadRequest(reason.SpaceSlugDuplicate)
+		}
+		slugs[slug] = true
+		for _, tag := range space.Tags {
+			tag = strings.ToLower(tag)
+			if other, ok := tags[tag]; ok && other != slug {
+				errFields = append(errFields, &validator.FormErrorField{
+					ErrorField: "spaces",
+					ErrorMsg:   tag,
+				})
+				return errFields, errors.BadRequest(reason.SpaceTagDuplicate)
+			}
+			tags[tag] = slug
+		}
+	}
+	return nil, nil
+}
+
+// GetSpace get the space by the slug, nil if not found
+func (s *SiteSpacesResp) GetSpace(slug string) *SiteSpace {
+	for _, space := range s.Spaces {
+		if strings.EqualFold(space.Slug, slug) {
+			return space
+		}
+	}
+	return nil
+}
+
+// MatchSpace get the space of the question with the tags, nil if the question belongs to none
+func (s *SiteSpacesResp) MatchSpace(tagNames []string) *SiteSpace {
+	for _, space := range s.Spaces {
+		for _, name := range tagNames {
+			for _, tag := range space.Tags {
+				if strings.EqualFold(name, tag) {
+					return space
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// MatchSpaces get all the spaces of the question with the tags
+func (s *SiteSpacesResp) MatchSpaces(tagNames []string) (spaces []*SiteSpace) {
+	for _, space := range s.Spaces {
+		if space.HasAnyTag(tagNames) {
+			spaces = append(spaces, space)
+		}
+	}
+	return spaces
+}
+
+// HasPrivateSpace whether any of the spaces is private
+func (s *SiteSpacesResp) HasPrivateSpace() bool {
+	for _, space := range s.Spaces {
+		if space.Private {
+			return true
+		}
+	}
+	return false
+}
+
+// InvisibleSpaces get the private spaces whose content can not be viewed by the user with the role
+func (s *SiteSpacesResp) InvisibleSpaces(roleID int, isAdmin bool) (spaces []*SiteSpace) {
+	for _, space := range s.Spaces {
+		if !space.CanView(roleID, isAdmin) {
+			spaces = append(spaces, space)
+		}
+	}
+	return spaces
+}
+
+// HasAnyTag whether the space has any of the tags
+func (sp *SiteSpace) HasAnyTag(tagNames []string) bool {
+	for _, name := range tagNames {
+		for _, tag := range sp.Tags {
+			if strings.EqualFold(name, tag) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CanView whether the user with the role can view the content of the space, the admin can view all spaces
+func (sp *SiteSpace) CanView(roleID int, isAdmin bool) bool {
+	if !sp.Private || isAdmin {
+		return true
+	}
+	return slices.Contains(sp.AllowedRoles, roleID)
+}
+
+// ApplyBranding get the branding of the site overridden by the space
+func (sp *SiteSpace) ApplyBranding(branding *SiteBrandingResp) *SiteBrandingResp {
+	resp := &SiteBrandingResp{}
+	if branding != nil {
+		*resp = *branding
+	}
+	if sp.Branding == nil {
+		return resp
+	}
+	if len(sp.Branding.Logo) > 0 {
+		resp.Logo = sp.Branding.Logo
+	}
+	if len(sp.Branding.MobileLogo) > 0 {
+		resp.MobileLogo = sp.Branding.MobileLogo
+	}
+	if len(sp.Branding.SquareIcon) > 0 {
+		resp.SquareIcon = sp.Branding.SquareIcon
+	}
+	if len(sp.Branding.Favicon) > 0 {
+		resp.Favicon = sp.Branding.Favicon
+	}
+	return resp
+}
+
+// ApplyTheme get the theme of the site overridden by the space, the theme config is merged by key
+func (sp *SiteSpace) ApplyTheme(theme *SiteThemeResp) *SiteThemeResp {
+	resp := &SiteThemeResp{}
+	if theme != nil {
+		*resp = *theme
+	}
+	if len(sp.ColorScheme) > 0 {
+		resp.ColorScheme = sp.ColorScheme
+	}
+	if len(sp.ThemeConfig) > 0 {
+		config := make(map[string]any, len(resp.ThemeConfig)+len(sp.ThemeConfig))
+		maps.Copy(config, resp.ThemeConfig)
+		maps.Copy(config, sp.ThemeConfig)
+		resp.ThemeConfig = config
+	}
+	return resp
+}
+
+// CanWrite whether the user with the reputation can ask or answer in the space
+func (sp *SiteSpace) CanWrite(isModerator bool, rank int) bool {
+	if isModerator {
+		return true
+	}
+	if sp.ModeratorOnly {
+		return false
+	}
+	return rank >= sp.MinReputation
+}
+
+// ToInfoResp convert to the space info response
+func (sp *SiteSpace) ToInfoResp() *SpaceInfoResp {
+	return &SpaceInfoResp{
+		Slug:          sp.Slug,
+		Name:          sp.Name,
+		Description:   sp.Description,
+		Tags:          sp.Tags,
+		ModeratorOnly: sp.ModeratorOnly,
+		MinReputation: sp.MinReputation,
+		DefaultOrder:  sp.DefaultOrder,
+		Private:       sp.Private,
+	}
+}
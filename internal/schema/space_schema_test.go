@@ -0,0 +1,114 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSiteSpacesResp_MatchSpace(t *testing.T) {
+	spaces := &SiteSpacesResp{
+		Spaces: []*SiteSpace{
+			{Slug: "dev", Tags: []string{"go", "rust"}},
+			{Slug: "ops", Tags: []string{"k8s"}},
+		},
+	}
+
+	assert.Equal(t, "ops", spaces.MatchSpace([]string{"linux", "K8s"}).Slug)
+	assert.Equal(t, "dev", spaces.MatchSpace([]string{"k8s", "go"}).Slug)
+	assert.Nil(t, spaces.MatchSpace([]string{"linux"}))
+	assert.Equal(t, "dev", spaces.GetSpace("DEV").Slug)
+	assert.Nil(t, spaces.GetSpace("none"))
+}
+
+func TestSiteSpace_Apply(t *testing.T) {
+	space := &SiteSpace{
+		Branding:    &SiteBrandingReq{Logo: "/space.png"},
+		ColorScheme: "dark",
+		ThemeConfig: map[string]any{"primary_color": "#000"},
+	}
+	siteBranding := &SiteBrandingResp{Logo: "/site.png", Favicon: "/site.ico"}
+	branding := space.ApplyBranding(siteBranding)
+	assert.Equal(t, "/space.png", branding.Logo)
+	assert.Equal(t, "/site.ico", branding.Favicon)
+	assert.Equal(t, "/site.png", siteBranding.Logo)
+
+	siteTheme := &SiteThemeResp{Theme: "default", ColorScheme: "light",
+		ThemeConfig: map[string]any{"primary_color": "#fff", "navbar_style": "white"}}
+	theme := space.ApplyTheme(siteTheme)
+	assert.Equal(t, "default", theme.Theme)
+	assert.Equal(t, "dark", theme.ColorScheme)
+	assert.Equal(t, map[string]any{"primary_color": "#000", "navbar_style": "white"}, theme.ThemeConfig)
+	assert.Equal(t, "#fff", siteTheme.ThemeConfig["primary_color"])
+}
+
+func TestSiteSpace_CanWrite(t *testing.T) {
+	space := &SiteSpace{MinReputation: 100}
+	assert.True(t, space.CanWrite(false, 100))
+	assert.False(t, space.CanWrite(false, 99))
+	assert.True(t, space.CanWrite(true, 0))
+
+	space.ModeratorOnly = true
+	assert.False(t, space.CanWrite(false, 1000))
+	assert.True(t, space.CanWrite(true, 0))
+}
+
+func TestSiteSpacesReq_Check(t *testing.T) {
+	_, err := (&SiteSpacesReq{Spaces: []*SiteSpace{
+		{Slug: "dev", Tags: []string{"go"}},
+		{Slug: "ops", Tags: []string{"k8s"}},
+	}}).Check()
+	assert.NoError(t, err)
+
+	_, err = (&SiteSpacesReq{Spaces: []*SiteSpace{
+		{Slug: "dev", Tags: []string{"go"}},
+		{Slug: "Dev", Tags: []string{"k8s"}},
+	}}).Check()
+	assert.Error(t, err)
+
+	_, err = (&SiteSpacesReq{Spaces: []*SiteSpace{
+		{Slug: "dev", Tags: []string{"go"}},
+		{Slug: "ops", Tags: []string{"Go"}},
+	}}).Check()
+	assert.Error(t, err)
+}
+
+func TestSiteSpace_CanView(t *testing.T) {
+	spaces := &SiteSpacesResp{
+		Spaces: []*SiteSpace{
+			{Slug: "public", Tags: []string{"go"}},
+			{Slug: "internal", Tags: []string{"ops"}, Private: true, AllowedRoles: []int{3, 4}},
+		},
+	}
+	assert.True(t, spaces.HasPrivateSpace())
+	assert.Len(t, spaces.MatchSpaces([]string{"Go", "ops"}), 2)
+
+	assert.True(t, spaces.Spaces[1].CanView(4, false))
+	assert.True(t, spaces.Spaces[1].CanView(1, true))
+	assert.False(t, spaces.Spaces[1].CanView(1, false))
+	assert.False(t, spaces.Spaces[1].CanView(0, false))
+
+	invisible := spaces.InvisibleSpaces(1, false)
+	assert.Len(t, invisible, 1)
+	assert.Equal(t, "internal", invisible[0].Slug)
+	assert.Empty(t, spaces.InvisibleSpaces(3, false))
+}
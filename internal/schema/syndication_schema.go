@@ -0,0 +1,67 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	SyndicationEventQuestionSync = "question.sync"
+	// SyndicationTimestampHeader the unix timestamp when the webhook is sent
+	SyndicationTimestampHeader = "X-Answer-Syndication-Timestamp"
+	// SyndicationSignatureHeader the HMAC-SHA256 signature of the timestamp and body
+	SyndicationSignatureHeader = "X-Answer-Syndication-Signature"
+	// SyndicationMaxClockSkewSeconds the webhook sent earlier than it is rejected to prevent replay
+	SyndicationMaxClockSkewSeconds = 300
+)
+
+// SyndicationWebhookPayload the payload of syndication webhook
+type SyndicationWebhookPayload struct {
+	Event string `validate:"required,oneof=question.sync" json:"event"`
+	// the site url of source instance
+	SourceSite string               `validate:"required,url" json:"source_site"`
+	Question   *SyndicationQuestion `validate:"required" json:"question"`
+}
+
+// SyndicationQuestion the question pushed to the peer instance
+type SyndicationQuestion struct {
+	ID      string   `validate:"required,gt=0,lte=64" json:"id"`
+	Title   string   `validate:"required,notblank,gte=6,lte=150" json:"title"`
+	Content string   `validate:"required,notblank,gte=6" json:"content"`
+	Tags    []string `validate:"required,gte=1,dive,gt=0,lte=35" json:"tags"`
+	// the url of source question
+	URL        string `validate:"required,url" json:"url"`
+	AuthorName string `json:"author_name"`
+	CreatedAt  int64  `json:"created_at"`
+	UpdatedAt  int64  `json:"updated_at"`
+}
+
+// ReceiveSyndicationWebhookReq receive syndication webhook request
+type ReceiveSyndicationWebhookReq struct {
+	Timestamp int64
+	Signature string
+	Body      []byte
+}
+
+// QuestionSyndicationInfo the source of mirrored question
+type QuestionSyndicationInfo struct {
+	SourceSite string `json:"source_site"`
+	// the backlink to the source question
+	SourceURL string `json:"source_url"`
+	// the mirrored question can not be edited or answered locally
+	ReadOnly bool `json:"read_only"`
+}
@@ -0,0 +1,160 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	// TagAnalyticsDateFormat the format of the aggregated day, all in UTC
+	TagAnalyticsDateFormat = "2006-01-02"
+	// TagAnalyticsTopAnswerers the max number of top answerers kept for each tag
+	TagAnalyticsTopAnswerers = 10
+	// TagAnalyticsBackfillDays the days aggregated when no stats exist yet
+	TagAnalyticsBackfillDays = 30
+)
+
+// GetTagAnalyticsReq get tag analytics request
+type GetTagAnalyticsReq struct {
+	TagID    string `validate:"omitempty" form:"tag_id"`
+	SlugName string `validate:"omitempty,gt=0,lte=35" form:"slug_name"`
+	// the number of days before today, default 30
+	Days int `validate:"omitempty,min=1,max=365" form:"days"`
+}
+
+// GetTagAnalyticsResp get tag analytics response
+type GetTagAnalyticsResp struct {
+	TagID         string  `json:"tag_id"`
+	SlugName      string  `json:"slug_name"`
+	StartDate     string  `json:"start_date"`
+	EndDate       string  `json:"end_date"`
+	QuestionCount int     `json:"question_count"`
+	AnsweredCount int     `json:"answered_count"`
+	AnswerRate    float64 `json:"answer_rate"`
+	// the median of the daily medians weighted by the answered questions
+	MedianFirstAnswerSeconds int64                `json:"median_first_answer_seconds"`
+	TopAnswerers             []*TagAnalyticsUser  `json:"top_answerers"`
+	Daily                    []*TagAnalyticsDaily `json:"daily"`
+}
+
+// TagAnalyticsDaily the tag stats of one day
+type TagAnalyticsDaily struct {
+	Date                     string  `json:"date"`
+	QuestionCount            int     `json:"question_count"`
+	AnsweredCount            int     `json:"answered_count"`
+	AnswerRate               float64 `json:"answer_rate"`
+	MedianFirstAnswerSeconds int64   `json:"median_first_answer_seconds"`
+	AnswerCount              int     `json:"answer_count"`
+}
+
+// TagAnalyticsAnswerer the answers posted by the user, stored in the daily stat
+type TagAnalyticsAnswerer struct {
+	UserID      string `json:"user_id"`
+	AnswerCount int    `json:"answer_count"`
+}
+
+// TagAnalyticsUser the top answerer of the tag
+type TagAnalyticsUser struct {
+	AnswerCount int            `json:"answer_count"`
+	User        *UserBasicInfo `json:"user"`
+}
+
+// TagAnalyticsDay get the start of the day in UTC
+func TagAnalyticsDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// AnswerRate the rate of answered questions
+func AnswerRate(questionCount, answeredCount int) float64 {
+	if questionCount == 0 {
+		return 0
+	}
+	return float64(answeredCount) / float64(questionCount)
+}
+
+// MedianSeconds get the median of the seconds, 0 if empty
+func MedianSeconds(values []int64) int64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]int64{}, values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return (sorted[mid-1] + sorted[mid]) / 2
+}
+
+// WeightedMedianSeconds get the median of the values weighted by the counts, 0 if all counts are zero
+func WeightedMedianSeconds(values []int64, counts []int) int64 {
+	type pair struct {
+		value int64
+		count int
+	}
+	pairs := make([]pair, 0, len(values))
+	total := 0
+	for i, v := range values {
+		if i >= len(counts) || counts[i] <= 0 {
+			continue
+		}
+		pairs = append(pairs, pair{value: v, count: counts[i]})
+		total += counts[i]
+	}
+	if total == 0 {
+		return 0
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].value < pairs[j].value })
+	cumulative := 0
+	for _, p := range pairs {
+		cumulative += p.count
+		if cumulative*2 >= total {
+			return p.value
+		}
+	}
+	return pairs[len(pairs)-1].value
+}
+
+// MergeTopAnswerers merge the answerers of days and keep the top ones by answers
+func MergeTopAnswerers(days [][]*TagAnalyticsAnswerer, limit int) []*TagAnalyticsAnswerer {
+	counts := make(map[string]int)
+	for _, answerers := range days {
+		for _, a := range answerers {
+			counts[a.UserID] += a.AnswerCount
+		}
+	}
+	merged := make([]*TagAnalyticsAnswerer, 0, len(counts))
+	for userID, count := range counts {
+		merged = append(merged, &TagAnalyticsAnswerer{UserID: userID, AnswerCount: count})
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		if merged[i].AnswerCount != merged[j].AnswerCount {
+			return merged[i].AnswerCount > merged[j].AnswerCount
+		}
+		return merged[i].UserID < merged[j].UserID
+	})
+	if len(merged) > limit {
+		merged = merged[:limit]
+	}
+	return merged
+}
@@ -0,0 +1,49 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMedianSeconds(t *testing.T) {
+	assert.Equal(t, int64(0), MedianSeconds(nil))
+	assert.Equal(t, int64(30), MedianSeconds([]int64{90, 10, 30}))
+	assert.Equal(t, int64(20), MedianSeconds([]int64{30, 10}))
+}
+
+func TestWeightedMedianSeconds(t *testing.T) {
+	assert.Equal(t, int64(0), WeightedMedianSeconds([]int64{10}, []int{0}))
+	assert.Equal(t, int64(100), WeightedMedianSeconds([]int64{10, 100, 1000}, []int{1, 5, 1}))
+	assert.Equal(t, int64(10), WeightedMedianSeconds([]int64{1000, 10}, []int{1, 3}))
+}
+
+func TestMergeTopAnswerers(t *testing.T) {
+	merged := MergeTopAnswerers([][]*TagAnalyticsAnswerer{
+		{{UserID: "1", AnswerCount: 2}, {UserID: "2", AnswerCount: 3}},
+		{{UserID: "1", AnswerCount: 2}, {UserID: "3", AnswerCount: 1}},
+	}, 2)
+	assert.Len(t, merged, 2)
+	assert.Equal(t, "1", merged[0].UserID)
+	assert.Equal(t, 4, merged[0].AnswerCount)
+	assert.Equal(t, "2", merged[1].UserID)
+}
@@ -0,0 +1,77 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/validator"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// TagBlockedTplData the template data of blocked tag error message
+type TagBlockedTplData struct {
+	Tag string
+}
+
+// Check check the patterns of blocklist are valid regular expressions
+func (s *SiteTagBlocklistReq) Check() (errFields []*validator.FormErrorField, err error) {
+	for _, pattern := range s.Patterns {
+		if _, err = regexp.Compile(pattern); err != nil {
+			errFields = append(errFields, &validator.FormErrorField{
+				ErrorField: "patterns",
+				ErrorMsg:   err.Error(),
+			})
+			return errFields, errors.BadRequest(reason.TagBlocklistPatternInvalid)
+		}
+	}
+	return nil, nil
+}
+
+// Blocked get the first blocked tag of the slug names, empty if none is blocked.
+// The names are compared case-insensitively and the patterns must match the whole slug name.
+func (s *SiteTagBlocklistResp) Blocked(slugNames []string) (blockedTag string) {
+	if len(s.Names) == 0 && len(s.Patterns) == 0 {
+		return ""
+	}
+	names := toTagNameSet(s.Names)
+	patterns := make([]*regexp.Regexp, 0, len(s.Patterns))
+	for _, pattern := range s.Patterns {
+		re, err := regexp.Compile("(?i)^(?:" + pattern + ")$")
+		if err != nil {
+			continue
+		}
+		patterns = append(patterns, re)
+	}
+	for _, slugName := range slugNames {
+		name := strings.ToLower(slugName)
+		if names[name] {
+			return slugName
+		}
+		for _, re := range patterns {
+			if re.MatchString(name) {
+				return slugName
+			}
+		}
+	}
+	return ""
+}
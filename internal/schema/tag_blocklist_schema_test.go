@@ -0,0 +1,47 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSiteTagBlocklistReq_Check(t *testing.T) {
+	_, err := (&SiteTagBlocklistReq{Patterns: []string{"crypto-.*"}}).Check()
+	assert.NoError(t, err)
+	errFields, err := (&SiteTagBlocklistReq{Patterns: []string{"crypto-("}}).Check()
+	assert.Error(t, err)
+	assert.Len(t, errFields, 1)
+}
+
+func TestSiteTagBlocklistResp_Blocked(t *testing.T) {
+	blocklist := &SiteTagBlocklistResp{
+		Names:    []string{"Homework"},
+		Patterns: []string{"crypto-.*", "[0-9]+"},
+	}
+	assert.Equal(t, "", blocklist.Blocked([]string{"golang", "crypto"}))
+	assert.Equal(t, "homework", blocklist.Blocked([]string{"golang", "homework"}))
+	assert.Equal(t, "Crypto-Coins", blocklist.Blocked([]string{"Crypto-Coins"}))
+	assert.Equal(t, "", blocklist.Blocked([]string{"go1"}))
+	assert.Equal(t, "2024", blocklist.Blocked([]string{"2024"}))
+	assert.Equal(t, "", (&SiteTagBlocklistResp{}).Blocked([]string{"homework"}))
+}
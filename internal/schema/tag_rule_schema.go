@@ -0,0 +1,114 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import "strings"
+
+const (
+	// TagRuleTypeRequiredOneOf the question must include at least one tag of the set
+	TagRuleTypeRequiredOneOf = "required_one_of"
+	// TagRuleTypeModeratorOnly the tags of the set can only be added or removed by moderators
+	TagRuleTypeModeratorOnly = "moderator_only"
+)
+
+// TagRule the rule of a tag set
+type TagRule struct {
+	Name string `validate:"required,gt=0,lte=64" json:"name"`
+	Type string `validate:"required,oneof=required_one_of moderator_only" json:"type"`
+	// the slug names of the tags in the set
+	Tags []string `validate:"required,gt=0,lte=100,dive,gt=0,lte=35" json:"tags"`
+	// the rule only applies to the questions tagged with one of the scope tags, empty means all questions
+	Scope []string `validate:"omitempty,lte=100,dive,gt=0,lte=35" json:"scope"`
+}
+
+// TagRuleViolation the rule that the tags of question violate
+type TagRuleViolation struct {
+	Rule *TagRule
+	// the tags of the set for required rule, or the restricted tags added or removed for moderator only rule
+	Tags []string
+}
+
+// TagRuleTplData the template data of tag rule error message
+type TagRuleTplData struct {
+	Name string
+	Tags string
+}
+
+// Check check the tags of question against the rules, the tag names are the slug names of the question tags
+// including the main tags of synonyms, the old tag names are the tags before update and empty for new question.
+func (s *SiteTagRulesResp) Check(tagNames, oldTagNames []string, canUseRestricted bool) *TagRuleViolation {
+	tags := toTagNameSet(tagNames)
+	oldTags := toTagNameSet(oldTagNames)
+	for _, rule := range s.Rules {
+		if !rule.inScope(tags) {
+			continue
+		}
+		switch rule.Type {
+		case TagRuleTypeRequiredOneOf:
+			if !rule.matchAny(tags) {
+				return &TagRuleViolation{Rule: rule, Tags: rule.Tags}
+			}
+		case TagRuleTypeModeratorOnly:
+			if canUseRestricted {
+				continue
+			}
+			changed := make([]string, 0)
+			for _, tag := range rule.Tags {
+				name := strings.ToLower(tag)
+				if tags[name] != oldTags[name] {
+					changed = append(changed, tag)
+				}
+			}
+			if len(changed) > 0 {
+				return &TagRuleViolation{Rule: rule, Tags: changed}
+			}
+		}
+	}
+	return nil
+}
+
+func (r *TagRule) inScope(tags map[string]bool) bool {
+	if len(r.Scope) == 0 {
+		return true
+	}
+	for _, tag := range r.Scope {
+		if tags[strings.ToLower(tag)] {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *TagRule) matchAny(tags map[string]bool) bool {
+	for _, tag := range r.Tags {
+		if tags[strings.ToLower(tag)] {
+			return true
+		}
+	}
+	return false
+}
+
+func toTagNameSet(tagNames []string) map[string]bool {
+	set := make(map[string]bool, len(tagNames))
+	for _, name := range tagNames {
+		set[strings.ToLower(name)] = true
+	}
+	return set
+}
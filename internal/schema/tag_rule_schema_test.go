@@ -0,0 +1,52 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSiteTagRulesResp_Check(t *testing.T) {
+	rules := &SiteTagRulesResp{
+		Rules: []*TagRule{
+			{Name: "platform", Type: TagRuleTypeRequiredOneOf, Tags: []string{"android", "ios"}, Scope: []string{"mobile"}},
+			{Name: "announcement", Type: TagRuleTypeModeratorOnly, Tags: []string{"announcement", "faq"}},
+		},
+	}
+
+	assert.Nil(t, rules.Check([]string{"golang"}, nil, false))
+	assert.Nil(t, rules.Check([]string{"mobile", "IOS"}, nil, false))
+
+	violation := rules.Check([]string{"mobile"}, nil, false)
+	assert.Equal(t, "platform", violation.Rule.Name)
+	assert.Equal(t, []string{"android", "ios"}, violation.Tags)
+
+	violation = rules.Check([]string{"faq"}, nil, false)
+	assert.Equal(t, "announcement", violation.Rule.Name)
+	assert.Equal(t, []string{"faq"}, violation.Tags)
+	assert.Nil(t, rules.Check([]string{"faq"}, nil, true))
+
+	// the restricted tag added by moderator can be kept but not removed
+	assert.Nil(t, rules.Check([]string{"faq", "golang"}, []string{"faq"}, false))
+	violation = rules.Check([]string{"golang"}, []string{"faq"}, false)
+	assert.Equal(t, []string{"faq"}, violation.Tags)
+}
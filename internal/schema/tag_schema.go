@@ -20,6 +20,7 @@
 package schema
 
 import (
+	"slices"
 	"strings"
 
 	"github.com/apache/answer/internal/base/validator"
@@ -82,12 +83,16 @@ type GetTagResp struct {
 	FollowCount   int                       `json:"follow_count"`
 	QuestionCount int                       `json:"question_count"`
 	IsFollower    bool                      `json:"is_follower"`
+	IsIgnored     bool                      `json:"is_ignored"`
 	Status        string                    `json:"status"`
 	MemberActions []*PermissionMemberAction `json:"member_actions"`
 	// if main tag slug name is not empty, this tag is synonymous with the main tag
 	MainTagSlugName string `json:"main_tag_slug_name"`
 	Recommend       bool   `json:"recommend"`
 	Reserved        bool   `json:"reserved"`
+	// deprecated tag can't be added to new posts, the replacement tags are shown in the banner
+	Deprecated      bool       `json:"deprecated"`
+	ReplacementTags []*TagResp `json:"replacement_tags"`
 }
 
 func (tr *GetTagResp) GetExcerpt() {
@@ -124,9 +129,10 @@ type GetTagPageResp struct {
 	// created time
 	CreatedAt int64 `json:"created_at"`
 	// updated time
-	UpdatedAt int64 `json:"updated_at"`
-	Recommend bool  `json:"recommend"`
-	Reserved  bool  `json:"reserved"`
+	UpdatedAt  int64 `json:"updated_at"`
+	Recommend  bool  `json:"recommend"`
+	Reserved   bool  `json:"reserved"`
+	Deprecated bool  `json:"deprecated"`
 }
 
 func (tr *GetTagPageResp) GetExcerpt() {
@@ -206,6 +212,8 @@ type UpdateTagReq struct {
 	// user id
 	UserID       string `json:"-"`
 	NoNeedReview bool   `json:"-"`
+	// the user is not privileged to edit the tag, only the description can be edited and the edit is reviewed
+	DescriptionOnly bool `json:"-"`
 }
 
 func (r *UpdateTagReq) Check() (errFields []*validator.FormErrorField, err error) {
@@ -285,6 +293,25 @@ func (req *UpdateTagSynonymReq) Format() {
 	}
 }
 
+// AddTagSynonymReq add tag synonym request
+type AddTagSynonymReq struct {
+	// the main tag that the synonym is retagged to
+	MainTagID string `validate:"required" json:"main_tag_id"`
+	// the synonym tag is created if not exist
+	SynonymSlugName string `validate:"required,gt=0,lte=35" json:"synonym_slug_name"`
+	UserID          string `json:"-"`
+}
+
+func (req *AddTagSynonymReq) Format() {
+	req.SynonymSlugName = strings.ReplaceAll(strings.ToLower(req.SynonymSlugName), " ", "-")
+}
+
+// RemoveTagSynonymReq remove tag synonym request
+type RemoveTagSynonymReq struct {
+	// the synonym tag id
+	TagID string `validate:"required" json:"tag_id"`
+}
+
 // GetFollowingTagsResp get following tags response
 type GetFollowingTagsResp struct {
 	// tag id
@@ -299,6 +326,16 @@ type GetFollowingTagsResp struct {
 	Reserved        bool   `json:"reserved"`
 }
 
+// IgnoreTagReq ignore the tag or stop ignoring it
+type IgnoreTagReq struct {
+	// tag id
+	TagID string `validate:"required" json:"tag_id"`
+	// ignored
+	Ignored bool `json:"ignored"`
+	// user id
+	UserID string `json:"-"`
+}
+
 // GetTagBasicResp get tag basic response
 type GetTagBasicResp struct {
 	TagID       string `json:"tag_id"`
@@ -314,10 +351,113 @@ type MergeTagReq struct {
 	SourceTagID string `validate:"required" json:"source_tag_id"`
 	// target tag id
 	TargetTagID string `validate:"required" json:"target_tag_id"`
+	// delete source tag instead of leaving it as a synonym of target tag
+	DeleteSource bool `json:"delete_source"`
 	// user id
 	UserID string `json:"-"`
 }
 
+// DeprecateTagReq mark the tag as deprecated, or undo it
+type DeprecateTagReq struct {
+	// tag id
+	TagID string `validate:"required" json:"tag_id"`
+	// deprecated
+	Deprecated bool `json:"deprecated"`
+	// the slug names of the tags to use instead
+	ReplacementTags []string `validate:"omitempty,max=5,dive,gt=0,lte=35" json:"replacement_tags"`
+	// user id
+	UserID string `json:"-"`
+}
+
+func (r *DeprecateTagReq) Check() (errFields []*validator.FormErrorField, err error) {
+	replacementTags := make([]string, 0, len(r.ReplacementTags))
+	for _, slugName := range r.ReplacementTags {
+		slugName = strings.ToLower(strings.TrimSpace(slugName))
+		if len(slugName) > 0 && !slices.Contains(replacementTags, slugName) {
+			replacementTags = append(replacementTags, slugName)
+		}
+	}
+	r.ReplacementTags = replacementTags
+	if !r.Deprecated {
+		r.ReplacementTags = nil
+	}
+	return nil, nil
+}
+
+// TagDeprecatedTplData tag deprecated template data
+type TagDeprecatedTplData struct {
+	Tag          string
+	Replacements string
+}
+
 // MergeTagResp merge tag response
 type MergeTagResp struct {
+	TagID         string `json:"tag_id"`
+	SlugName      string `json:"slug_name"`
+	QuestionCount int    `json:"question_count"`
+	FollowCount   int    `json:"follow_count"`
+}
+
+// AddTagModeratorReq assign the user to moderate the questions carrying the tag
+type AddTagModeratorReq struct {
+	TagID string `validate:"required" json:"tag_id"`
+	// the user to be assigned
+	UserID string `validate:"required" json:"user_id"`
+	// the admin or moderator who assigns
+	OperatorID string `json:"-"`
+}
+
+// RemoveTagModeratorReq remove the tag moderator
+type RemoveTagModeratorReq struct {
+	TagID  string `validate:"required" json:"tag_id"`
+	UserID string `validate:"required" json:"user_id"`
+}
+
+// GetTagModeratorsReq get the moderators of tag
+type GetTagModeratorsReq struct {
+	TagID string `validate:"required" form:"tag_id"`
+}
+
+// TagModeratorItem the moderator of tag
+type TagModeratorItem struct {
+	User       *UserBasicInfo `json:"user"`
+	AssignedAt int64          `json:"assigned_at"`
+}
+
+// SaveTagTranslationReq add or update the translation of tag in the language
+type SaveTagTranslationReq struct {
+	TagID string `validate:"required" json:"tag_id"`
+	// the interface language such as zh_CN
+	Language    string `validate:"required,gt=0,lte=20" json:"language"`
+	DisplayName string `validate:"required,gt=0,lte=35" json:"display_name"`
+	// the description is optional, the canonical description is shown if it is empty
+	OriginalText string `validate:"omitempty" json:"original_text"`
+	ParsedText   string `json:"-"`
+}
+
+func (r *SaveTagTranslationReq) Check() (errFields []*validator.FormErrorField, err error) {
+	r.Language = strings.TrimSpace(r.Language)
+	r.DisplayName = strings.TrimSpace(r.DisplayName)
+	r.ParsedText = converter.Markdown2HTML(r.OriginalText)
+	return nil, nil
+}
+
+// RemoveTagTranslationReq remove the translation of tag in the language
+type RemoveTagTranslationReq struct {
+	TagID    string `validate:"required" json:"tag_id"`
+	Language string `validate:"required" json:"language"`
+}
+
+// GetTagTranslationsReq get all translations of tag
+type GetTagTranslationsReq struct {
+	TagID string `validate:"required" form:"tag_id"`
+}
+
+// TagTranslationItem the translation of tag
+type TagTranslationItem struct {
+	Language     string `json:"language"`
+	DisplayName  string `json:"display_name"`
+	OriginalText string `json:"original_text"`
+	ParsedText   string `json:"parsed_text"`
+	UpdatedAt    int64  `json:"updated_at"`
 }
@@ -0,0 +1,47 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecateTagReq_Check(t *testing.T) {
+	req := &DeprecateTagReq{Deprecated: true, ReplacementTags: []string{" Golang", "golang", "", "go-modules"}}
+	_, err := req.Check()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"golang", "go-modules"}, req.ReplacementTags)
+
+	req = &DeprecateTagReq{Deprecated: false, ReplacementTags: []string{"golang"}}
+	_, err = req.Check()
+	assert.NoError(t, err)
+	assert.Empty(t, req.ReplacementTags)
+}
+
+func TestSaveTagTranslationReq_Check(t *testing.T) {
+	req := &SaveTagTranslationReq{Language: " zh_CN ", DisplayName: " 围棋 ", OriginalText: "**Go** 语言"}
+	_, err := req.Check()
+	assert.NoError(t, err)
+	assert.Equal(t, "zh_CN", req.Language)
+	assert.Equal(t, "围棋", req.DisplayName)
+	assert.Contains(t, req.ParsedText, "<strong>Go</strong>")
+}
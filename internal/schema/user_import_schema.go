@@ -0,0 +1,114 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// the columns of the user import csv, only the email and name are required
+const (
+	importUserColumnEmail = iota
+	importUserColumnName
+	importUserColumnRole
+	importUserColumnReputation
+)
+
+// ImportUsersReq import users from csv
+type ImportUsersReq struct {
+	// the csv content, one user per line: email,name,role,reputation. The header line is optional.
+	CSV string `validate:"required" json:"csv"`
+	// the imported users need to activate the account by the email
+	SendActivationEmail bool `json:"send_activation_email"`
+	// wait for the activation emails sent, the command line exits after the import
+	WaitForEmails bool   `json:"-"`
+	LoginUserID   string `json:"-"`
+}
+
+// ImportUserItem the user parsed from the csv line
+type ImportUserItem struct {
+	Line        int    `json:"-"`
+	Email       string `validate:"required,email,gt=0,lte=500" json:"email"`
+	DisplayName string `validate:"required,gte=2,lte=30" json:"name"`
+	// the role name or id, default is user
+	Role       string `validate:"omitempty,lte=50" json:"role"`
+	Reputation int    `json:"reputation"`
+}
+
+// ImportUsersResp import users response
+type ImportUsersResp struct {
+	Count int                 `json:"count"`
+	Users []*ImportedUserItem `json:"users"`
+}
+
+// ImportedUserItem the user created by the import
+type ImportedUserItem struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	RoleID   int    `json:"role_id"`
+}
+
+// ParseImportUsersCSV parse the users from the csv content, the first line is skipped if it is the header.
+// The reputation is 1 if it is empty, the error data contains the line and content that can't be parsed.
+func ParseImportUsersCSV(content string) (users []*ImportUserItem, errData *AddUsersErrorData) {
+	reader := csv.NewReader(strings.NewReader(strings.TrimSpace(content)))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	users = make([]*ImportUserItem, 0)
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, &AddUsersErrorData{Line: line, ExtraMessage: err.Error()}
+		}
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[importUserColumnEmail]), "email") {
+			continue
+		}
+		if len(record) < importUserColumnRole || len(record) > importUserColumnReputation+1 {
+			return nil, &AddUsersErrorData{Line: line, Content: strings.Join(record, ",")}
+		}
+		user := &ImportUserItem{
+			Line:        line,
+			Email:       strings.TrimSpace(record[importUserColumnEmail]),
+			DisplayName: strings.TrimSpace(record[importUserColumnName]),
+			Reputation:  1,
+		}
+		if len(record) > importUserColumnRole {
+			user.Role = strings.TrimSpace(record[importUserColumnRole])
+		}
+		if len(record) > importUserColumnReputation && len(strings.TrimSpace(record[importUserColumnReputation])) > 0 {
+			reputation, err := strconv.Atoi(strings.TrimSpace(record[importUserColumnReputation]))
+			if err != nil || reputation < 0 {
+				return nil, &AddUsersErrorData{Field: "reputation", Line: line, Content: record[importUserColumnReputation]}
+			}
+			// the reputation is at least 1 as the registered user
+			user.Reputation = max(reputation, 1)
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
@@ -0,0 +1,66 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseImportUsersCSV(t *testing.T) {
+	users, errData := ParseImportUsersCSV("email,name,role,reputation\n" +
+		"alice@example.com, Alice, Moderator, 120\n" +
+		"bob@example.com,\"Bob, Jr\"\n" +
+		"carol@example.com,Carol,,0\n")
+	assert.Nil(t, errData)
+	assert.Len(t, users, 3)
+
+	assert.Equal(t, 2, users[0].Line)
+	assert.Equal(t, "alice@example.com", users[0].Email)
+	assert.Equal(t, "Alice", users[0].DisplayName)
+	assert.Equal(t, "Moderator", users[0].Role)
+	assert.Equal(t, 120, users[0].Reputation)
+
+	assert.Equal(t, "Bob, Jr", users[1].DisplayName)
+	assert.Empty(t, users[1].Role)
+	assert.Equal(t, 1, users[1].Reputation)
+
+	// the reputation is at least 1
+	assert.Equal(t, 1, users[2].Reputation)
+}
+
+func TestParseImportUsersCSV_Invalid(t *testing.T) {
+	_, errData := ParseImportUsersCSV("alice@example.com")
+	assert.NotNil(t, errData)
+	assert.Equal(t, 1, errData.Line)
+
+	_, errData = ParseImportUsersCSV("alice@example.com,Alice\nbob@example.com,Bob,user,many")
+	assert.NotNil(t, errData)
+	assert.Equal(t, "reputation", errData.Field)
+	assert.Equal(t, 2, errData.Line)
+
+	_, errData = ParseImportUsersCSV("alice@example.com,Alice,user,1,extra")
+	assert.NotNil(t, errData)
+
+	users, errData := ParseImportUsersCSV("email,name")
+	assert.Nil(t, errData)
+	assert.Empty(t, users)
+}
@@ -0,0 +1,48 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// MuteReq mute the question or user, or stop muting it
+type MuteReq struct {
+	// question or user
+	MuteType string `validate:"required,oneof=question user" json:"mute_type"`
+	// question id or user id
+	ObjectID string `validate:"required" json:"object_id"`
+	Muted    bool   `json:"muted"`
+	UserID   string `json:"-"`
+}
+
+// GetMutesReq get the questions and users muted by user
+type GetMutesReq struct {
+	// question or user, all if empty
+	MuteType string `validate:"omitempty,oneof=question user" form:"mute_type"`
+	UserID   string `json:"-"`
+}
+
+// MuteItem the question or user muted
+type MuteItem struct {
+	MuteType string `json:"mute_type"`
+	ObjectID string `json:"object_id"`
+	// the title of the question muted
+	Title string `json:"title,omitempty"`
+	// the user muted
+	User      *UserBasicInfo `json:"user,omitempty"`
+	CreatedAt int64          `json:"created_at"`
+}
@@ -56,6 +56,12 @@ type NotificationConfig struct {
 	Inbox                          NotificationChannelConfig `json:"inbox"`
 	AllNewQuestion                 NotificationChannelConfig `json:"all_new_question"`
 	AllNewQuestionForFollowingTags NotificationChannelConfig `json:"all_new_question_for_following_tags"`
+	ReEngagement                   NotificationChannelConfig `json:"re_engagement"`
+	EveryVote                      NotificationChannelConfig `json:"every_vote"`
+	VoteMilestone                  NotificationChannelConfig `json:"vote_milestone"`
+	WebPush                        NotificationChannelConfig `json:"web_push"`
+	DigestDaily                    NotificationChannelConfig `json:"digest_daily"`
+	DigestWeekly                   NotificationChannelConfig `json:"digest_weekly"`
 }
 
 func NewNotificationConfig(configs []*entity.UserNotificationConfig) NotificationConfig {
@@ -68,6 +74,18 @@ func NewNotificationConfig(configs []*entity.UserNotificationConfig) Notificatio
 			nc.AllNewQuestion = NewNotificationChannelConfigFormJson(item.Channels)
 		case string(constant.AllNewQuestionForFollowingTagsSource):
 			nc.AllNewQuestionForFollowingTags = NewNotificationChannelConfigFormJson(item.Channels)
+		case string(constant.ReEngagementSource):
+			nc.ReEngagement = NewNotificationChannelConfigFormJson(item.Channels)
+		case string(constant.EveryVoteSource):
+			nc.EveryVote = NewNotificationChannelConfigFormJson(item.Channels)
+		case string(constant.VoteMilestoneSource):
+			nc.VoteMilestone = NewNotificationChannelConfigFormJson(item.Channels)
+		case string(constant.WebPushSource):
+			nc.WebPush = NewNotificationChannelConfigFormJson(item.Channels)
+		case string(constant.DigestDailySource):
+			nc.DigestDaily = NewNotificationChannelConfigFormJson(item.Channels)
+		case string(constant.DigestWeeklySource):
+			nc.DigestWeekly = NewNotificationChannelConfigFormJson(item.Channels)
 		}
 	}
 	return nc
@@ -86,6 +104,32 @@ func (n *NotificationConfig) Format() {
 		n.AllNewQuestionForFollowingTags.Key = constant.EmailChannel
 		n.AllNewQuestionForFollowingTags.Enable = false
 	}
+	// the user is opted in re-engagement emails by default
+	if n.ReEngagement.Key == "" {
+		n.ReEngagement.Key = constant.EmailChannel
+		n.ReEngagement.Enable = true
+	}
+	// the vote notifications are aggregated into the milestones by default
+	if n.EveryVote.Key == "" {
+		n.EveryVote.Key = constant.InboxChannel
+		n.EveryVote.Enable = false
+	}
+	if n.VoteMilestone.Key == "" {
+		n.VoteMilestone.Key = constant.InboxChannel
+		n.VoteMilestone.Enable = true
+	}
+	if n.WebPush.Key == "" {
+		n.WebPush.Key = constant.WebPushChannel
+		n.WebPush.Enable = false
+	}
+	if n.DigestDaily.Key == "" {
+		n.DigestDaily.Key = constant.EmailChannel
+		n.DigestDaily.Enable = false
+	}
+	if n.DigestWeekly.Key == "" {
+		n.DigestWeekly.Key = constant.EmailChannel
+		n.DigestWeekly.Enable = false
+	}
 }
 
 // UpdateUserNotificationConfigReq update user notification config request
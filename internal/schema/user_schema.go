@@ -169,6 +169,10 @@ type GetOtherUserInfoByUsernameResp struct {
 	StatusMsg string `json:"status_msg,omitempty"`
 	// suspended until timestamp
 	SuspendedUntil int64 `json:"suspended_until"`
+	// the highest tag badge earned in each tag
+	TagBadges []*UserTagBadge `json:"tag_badges"`
+	// is bot account
+	IsBot bool `json:"is_bot"`
 }
 
 func (r *GetOtherUserInfoByUsernameResp) ConvertFromUserEntity(userInfo *entity.User) {
@@ -180,6 +184,7 @@ func (r *GetOtherUserInfoByUsernameResp) ConvertFromUserEntity(userInfo *entity.
 		r.SuspendedUntil = userInfo.SuspendedUntil.Unix()
 	}
 	r.StatusMsg = ""
+	r.IsBot = userInfo.UserType == entity.UserTypeBot
 }
 
 func (r *GetOtherUserInfoByUsernameResp) ConvertFromUserEntityWithLang(ctx context.Context, userInfo *entity.User) {
@@ -187,6 +192,7 @@ func (r *GetOtherUserInfoByUsernameResp) ConvertFromUserEntityWithLang(ctx conte
 	r.CreatedAt = userInfo.CreatedAt.Unix()
 	r.LastLoginDate = userInfo.LastLoginDate.Unix()
 	r.Status = constant.ConvertUserStatus(userInfo.Status, userInfo.MailStatus)
+	r.IsBot = userInfo.UserType == entity.UserTypeBot
 
 	lang := handler.GetLangByCtx(ctx)
 	if userInfo.MailStatus == entity.EmailStatusToBeVerified {
@@ -215,6 +221,7 @@ type UserEmailLoginReq struct {
 	Pass        string `validate:"required,gte=8,lte=32" json:"pass"`
 	CaptchaID   string `json:"captcha_id"`
 	CaptchaCode string `json:"captcha_code"`
+	IP          string `json:"-"`
 }
 
 // UserRegisterReq user register request
@@ -224,7 +231,9 @@ type UserRegisterReq struct {
 	Pass        string `validate:"required,gte=8,lte=32" json:"pass"`
 	CaptchaID   string `json:"captcha_id"`
 	CaptchaCode string `json:"captcha_code"`
-	IP          string `json:"-" `
+	// required when the registration is invite-only
+	InviteCode string `validate:"omitempty,lte=64" json:"invite_code"`
+	IP         string `json:"-" `
 }
 
 func (u *UserRegisterReq) Check() (errFields []*validator.FormErrorField, err error) {
@@ -379,6 +388,7 @@ type UserBasicInfo struct {
 	Language       string `json:"language"`
 	Status         string `json:"status"`
 	SuspendedUntil int64  `json:"suspended_until"`
+	IsBot          bool   `json:"is_bot"`
 }
 
 type GetOtherUserInfoByUsernameReq struct {
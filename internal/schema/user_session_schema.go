@@ -0,0 +1,55 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// UserSessionItem the login session of user
+type UserSessionItem struct {
+	// the hash of the access token, the access token itself is never returned
+	SessionID    string `json:"session_id"`
+	IP           string `json:"ip"`
+	UserAgent    string `json:"user_agent"`
+	CreatedAt    int64  `json:"created_at"`
+	LastActiveAt int64  `json:"last_active_at"`
+	// the session of the current request
+	Current bool `json:"current"`
+}
+
+// RemoveUserSessionReq log out the session
+type RemoveUserSessionReq struct {
+	SessionID   string `validate:"required" json:"session_id"`
+	UserID      string `json:"-"`
+	AccessToken string `json:"-"`
+}
+
+// RemoveOtherUserSessionsReq log out the sessions except the current one
+type RemoveOtherUserSessionsReq struct {
+	UserID      string `json:"-"`
+	AccessToken string `json:"-"`
+}
+
+// GetUserSessionsReq admin get the sessions of user
+type GetUserSessionsReq struct {
+	UserID string `validate:"required" form:"user_id"`
+}
+
+// ForceLogoutUserReq admin log out all the sessions of user
+type ForceLogoutUserReq struct {
+	UserID string `validate:"required" json:"user_id"`
+}
@@ -0,0 +1,68 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import "github.com/apache/answer/internal/entity"
+
+const (
+	UserTimelineTypeQuestion     = "question"
+	UserTimelineTypeAnswer       = "answer"
+	UserTimelineTypeComment      = "comment"
+	UserTimelineTypeEdit         = "edit"
+	UserTimelineTypeFlagRaised   = "flag_raised"
+	UserTimelineTypeFlagReceived = "flag_received"
+	UserTimelineTypeLogin        = entity.UserEventTypeLogin
+	UserTimelineTypeStatusChange = entity.UserEventTypeStatusChange
+	// UserTimelineMaxRecords only the latest records can be paged, the timeline is merged from several tables
+	UserTimelineMaxRecords = 1000
+)
+
+// GetUserTimelineReq get user history timeline request
+type GetUserTimelineReq struct {
+	UserID   string `validate:"required" form:"user_id"`
+	Page     int    `validate:"omitempty,min=1" form:"page"`
+	PageSize int    `validate:"omitempty,min=1,max=50" form:"page_size"`
+}
+
+// GetUserTimelineResp get user history timeline response
+type GetUserTimelineResp struct {
+	User UserBasicInfo `json:"user"`
+	// the ip used when registering
+	RegisterIP string `json:"register_ip"`
+	// all the ips used to login
+	IPs         []string            `json:"ips"`
+	LastLoginAt int64               `json:"last_login_at"`
+	Total       int64               `json:"count"`
+	List        []*UserTimelineItem `json:"list"`
+}
+
+// UserTimelineItem user history timeline item
+type UserTimelineItem struct {
+	// question, answer, comment, edit, flag_raised, flag_received, login or status_change
+	Type      string `json:"type"`
+	ObjectID  string `json:"object_id"`
+	CreatedAt int64  `json:"created_at"`
+	Summary   string `json:"summary"`
+	// the status of the post, revision or report
+	Status int    `json:"status,omitempty"`
+	IP     string `json:"ip,omitempty"`
+	// the reported user of flag raised, the reporter of flag received or the operator of status change
+	RelatedUser *UserBasicInfo `json:"related_user,omitempty"`
+}
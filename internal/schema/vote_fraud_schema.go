@@ -0,0 +1,57 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// GetVoteInvalidationPageReq get invalidated vote page request
+type GetVoteInvalidationPageReq struct {
+	Page     int `validate:"omitempty,min=1" form:"page"`
+	PageSize int `validate:"omitempty,min=1" form:"page_size"`
+}
+
+// GetVoteInvalidationPageResp get invalidated vote page response
+type GetVoteInvalidationPageResp struct {
+	ID         string        `json:"id"`
+	Voter      UserBasicInfo `json:"voter"`
+	TargetUser UserBasicInfo `json:"target_user"`
+	ObjectID   string        `json:"object_id"`
+	ObjectType string        `json:"object_type"`
+	VoteUp     bool          `json:"vote_up"`
+	// the admin who reversed the vote, empty if reversed by the vote fraud detection
+	Operator *UserBasicInfo `json:"operator,omitempty"`
+	// serial_voting, voting_ring or admin_reversal
+	Reason    string `json:"reason"`
+	Rank      int    `json:"rank"`
+	VotedAt   int64  `json:"voted_at"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// ReverseUserVotesReq reverse all votes cast by user request
+type ReverseUserVotesReq struct {
+	UserID     string `validate:"required" json:"user_id"`
+	OperatorID string `json:"-"`
+}
+
+// ReverseUserVotesResp reverse all votes cast by user response
+type ReverseUserVotesResp struct {
+	// the number of reversed votes
+	ReversedCount int `json:"reversed_count"`
+	// the reputation of other users reversed in total
+	ReversedRank int `json:"reversed_rank"`
+}
@@ -0,0 +1,106 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/validator"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// WebPushMaxSubscriptions the max number of the subscribed devices of one user, the oldest is replaced
+const WebPushMaxSubscriptions = 20
+
+// WebPushSubscriptionKeys the keys of the push subscription generated by the browser
+type WebPushSubscriptionKeys struct {
+	P256dh string `validate:"required,lte=128" json:"p256dh"`
+	Auth   string `validate:"required,lte=64" json:"auth"`
+}
+
+// WebPushSubscribeReq web push subscribe request, the same as the json of PushSubscription in the browser
+type WebPushSubscribeReq struct {
+	Endpoint  string                  `validate:"required,url,lte=1024" json:"endpoint"`
+	Keys      WebPushSubscriptionKeys `validate:"required" json:"keys"`
+	UserAgent string                  `json:"-"`
+	UserID    string                  `json:"-"`
+}
+
+// Check check the endpoint, the push services only accept https
+func (r *WebPushSubscribeReq) Check() (errFields []*validator.FormErrorField, err error) {
+	endpoint, err := url.Parse(r.Endpoint)
+	if err != nil || !strings.EqualFold(endpoint.Scheme, "https") || len(endpoint.Host) == 0 {
+		errFields = append(errFields, &validator.FormErrorField{
+			ErrorField: "endpoint",
+			ErrorMsg:   reason.WebPushEndpointInvalid,
+		})
+		return errFields, errors.BadRequest(reason.WebPushEndpointInvalid)
+	}
+	return nil, nil
+}
+
+// WebPushUnsubscribeReq web push unsubscribe request
+type WebPushUnsubscribeReq struct {
+	Endpoint string `validate:"required,lte=1024" json:"endpoint"`
+	UserID   string `json:"-"`
+}
+
+// GetWebPushKeyResp get web push vapid public key response
+type GetWebPushKeyResp struct {
+	Enabled bool `json:"enabled"`
+	// the applicationServerKey of the subscription, empty if web push is disabled
+	PublicKey string `json:"public_key"`
+}
+
+// WebPushSubscriptionResp web push subscription response
+type WebPushSubscriptionResp struct {
+	ID        string `json:"id"`
+	Endpoint  string `json:"endpoint"`
+	UserAgent string `json:"user_agent"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// WebPushPayload the payload sent to the service worker of the browser
+type WebPushPayload struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	URL   string `json:"url"`
+	// the notifications with the same tag replace each other in the browser
+	Tag       string `json:"tag"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// NewWebPushPayload new web push payload, the body is truncated to keep the payload in one record
+func NewWebPushPayload(title, body, link, tag string, now time.Time) *WebPushPayload {
+	const maxBodyLength = 280
+	if runes := []rune(body); len(runes) > maxBodyLength {
+		body = string(runes[:maxBodyLength-1]) + "…"
+	}
+	return &WebPushPayload{
+		Title:     title,
+		Body:      body,
+		URL:       link,
+		Tag:       tag,
+		Timestamp: now.UnixMilli(),
+	}
+}
@@ -0,0 +1,47 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebPushSubscribeReq_Check(t *testing.T) {
+	_, err := (&WebPushSubscribeReq{Endpoint: "https://fcm.googleapis.com/fcm/send/abc"}).Check()
+	assert.NoError(t, err)
+	_, err = (&WebPushSubscribeReq{Endpoint: "http://127.0.0.1:8080/internal"}).Check()
+	assert.Error(t, err)
+	_, err = (&WebPushSubscribeReq{Endpoint: "https:///no-host"}).Check()
+	assert.Error(t, err)
+}
+
+func TestNewWebPushPayload(t *testing.T) {
+	payload := NewWebPushPayload("Alice answered question", strings.Repeat("问", 300), "/questions/1", "question-1", time.Now())
+	assert.Equal(t, 280, utf8.RuneCountInString(payload.Body))
+	assert.True(t, strings.HasSuffix(payload.Body, "…"))
+
+	payload = NewWebPushPayload("Alice answered question", "How to go?", "/questions/1", "question-1", time.Now())
+	assert.Equal(t, "How to go?", payload.Body)
+}
@@ -0,0 +1,130 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+)
+
+const (
+	WebhookEventQuestionCreated = "question.created"
+	WebhookEventAnswerAccepted  = "answer.accepted"
+	WebhookEventUserRegistered  = "user.registered"
+	WebhookEventContentReported = "content.reported"
+	// WebhookEventHeader the event of the payload
+	WebhookEventHeader = "X-Answer-Webhook-Event"
+	// WebhookDeliveryHeader the unique id of the delivery, it is the same when the delivery is retried
+	WebhookDeliveryHeader = "X-Answer-Webhook-Delivery"
+	// WebhookTimestampHeader the unix timestamp when the webhook is sent
+	WebhookTimestampHeader = "X-Answer-Webhook-Timestamp"
+	// WebhookSignatureHeader the HMAC-SHA256 signature of the timestamp and body
+	WebhookSignatureHeader = "X-Answer-Webhook-Signature"
+)
+
+// WebhookEventMapping the webhook events of the internal events
+var WebhookEventMapping = map[constant.EventType]string{
+	constant.EventQuestionCreate: WebhookEventQuestionCreated,
+	constant.EventQuestionAccept: WebhookEventAnswerAccepted,
+	constant.EventUserCreate:     WebhookEventUserRegistered,
+	constant.EventQuestionFlag:   WebhookEventContentReported,
+	constant.EventAnswerFlag:     WebhookEventContentReported,
+	constant.EventCommentFlag:    WebhookEventContentReported,
+}
+
+// webhookRetryDelays the delay before each retry of the failed delivery
+var webhookRetryDelays = []time.Duration{
+	time.Minute,
+	5 * time.Minute,
+	30 * time.Minute,
+	2 * time.Hour,
+	12 * time.Hour,
+}
+
+// WebhookRetryDelay get the delay before next retry after the attempts failed,
+// false means the delivery has no more retries
+func WebhookRetryDelay(attempts int) (delay time.Duration, ok bool) {
+	if attempts < 1 || attempts > len(webhookRetryDelays) {
+		return 0, false
+	}
+	return webhookRetryDelays[attempts-1], true
+}
+
+// WebhookPayload the json payload posted to the webhook url
+type WebhookPayload struct {
+	Event     string `json:"event"`
+	SiteURL   string `json:"site_url"`
+	CreatedAt int64  `json:"created_at"`
+	// the user triggered the event, the registered user for user.registered
+	User *WebhookUser `json:"user,omitempty"`
+	// the created question, the accepted answer, or the reported content
+	Object *WebhookObject `json:"object,omitempty"`
+}
+
+// WebhookUser the user of webhook payload
+type WebhookUser struct {
+	ID          string `json:"id"`
+	Username    string `json:"username"`
+	DisplayName string `json:"display_name"`
+}
+
+// WebhookObject the content of webhook payload
+type WebhookObject struct {
+	ObjectType string `json:"object_type"`
+	ID         string `json:"id"`
+	QuestionID string `json:"question_id"`
+	AnswerID   string `json:"answer_id,omitempty"`
+	CommentID  string `json:"comment_id,omitempty"`
+	// the title of question
+	Title string `json:"title"`
+	URL   string `json:"url"`
+	// the author of the content
+	UserID string `json:"user_id"`
+}
+
+// GetWebhookDeliveryPageReq get webhook delivery page request
+type GetWebhookDeliveryPageReq struct {
+	Page       int    `validate:"omitempty,min=1" form:"page"`
+	PageSize   int    `validate:"omitempty,min=1,max=100" form:"page_size"`
+	Status     string `validate:"omitempty,oneof=pending success failed" form:"status"`
+	Event      string `validate:"omitempty,lte=64" form:"event"`
+	WebhookURL string `validate:"omitempty,lte=512" form:"webhook_url"`
+}
+
+// WebhookDeliveryItem the delivery log of webhook
+type WebhookDeliveryItem struct {
+	ID             string `json:"id"`
+	WebhookURL     string `json:"webhook_url"`
+	Event          string `json:"event"`
+	Payload        string `json:"payload"`
+	Status         string `json:"status"`
+	Attempts       int    `json:"attempts"`
+	NextAttemptAt  int64  `json:"next_attempt_at"`
+	ResponseStatus int    `json:"response_status"`
+	LastError      string `json:"last_error"`
+	CreatedAt      int64  `json:"created_at"`
+	UpdatedAt      int64  `json:"updated_at"`
+}
+
+// RedeliverWebhookReq redeliver webhook request
+type RedeliverWebhookReq struct {
+	ID string `validate:"required" json:"id"`
+}
@@ -0,0 +1,63 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookRetryDelay(t *testing.T) {
+	delay, ok := WebhookRetryDelay(1)
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, delay)
+
+	delay, ok = WebhookRetryDelay(5)
+	assert.True(t, ok)
+	assert.Equal(t, 12*time.Hour, delay)
+
+	_, ok = WebhookRetryDelay(6)
+	assert.False(t, ok)
+	_, ok = WebhookRetryDelay(0)
+	assert.False(t, ok)
+}
+
+func TestSiteWebhookResp_GetWebhooksByEvent(t *testing.T) {
+	conf := &SiteWebhookResp{
+		Enabled: true,
+		Webhooks: []*WebhookTarget{
+			{URL: "https://a.example.com", Events: []string{WebhookEventQuestionCreated, WebhookEventAnswerAccepted}},
+			{URL: "https://b.example.com", Events: []string{WebhookEventQuestionCreated}, Paused: true},
+			{URL: "https://c.example.com", Events: []string{WebhookEventUserRegistered}},
+		},
+	}
+	webhooks := conf.GetWebhooksByEvent(WebhookEventQuestionCreated)
+	assert.Len(t, webhooks, 1)
+	assert.Equal(t, "https://a.example.com", webhooks[0].URL)
+	assert.Empty(t, conf.GetWebhooksByEvent(WebhookEventContentReported))
+
+	assert.NotNil(t, conf.GetWebhookByURL("https://b.example.com"))
+	assert.Nil(t, conf.GetWebhookByURL("https://d.example.com"))
+
+	conf.Enabled = false
+	assert.Empty(t, conf.GetWebhooksByEvent(WebhookEventUserRegistered))
+}
@@ -32,10 +32,13 @@ import (
 	"github.com/apache/answer/internal/base/handler"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
+	answercommon "github.com/apache/answer/internal/service/answer_common"
 	"github.com/apache/answer/internal/service/comment_common"
 	"github.com/apache/answer/internal/service/config"
 	"github.com/apache/answer/internal/service/object_info"
 	"github.com/apache/answer/internal/service/revision_common"
+	"github.com/apache/answer/internal/service/role"
+	"github.com/apache/answer/internal/service/space_common"
 	"github.com/apache/answer/internal/service/tag_common"
 	usercommon "github.com/apache/answer/internal/service/user_common"
 	"github.com/apache/answer/pkg/converter"
@@ -47,6 +50,8 @@ import (
 // ActivityRepo activity repository
 type ActivityRepo interface {
 	GetObjectAllActivity(ctx context.Context, objectID string, showVote bool) (activityList []*entity.Activity, err error)
+	GetObjectsActivityByTypes(ctx context.Context, objectIDs []string, activityTypes []int) (
+		activityList []*entity.Activity, err error)
 }
 
 // ActivityService activity service
@@ -60,6 +65,9 @@ type ActivityService struct {
 	revisionService       *revision_common.RevisionService
 	metaService           *metacommon.MetaCommonService
 	configService         *config.ConfigService
+	answerRepo            answercommon.AnswerRepo
+	userRoleRelService    *role.UserRoleRelService
+	spaceCommon           *space_common.SpaceCommon
 }
 
 // NewActivityService new activity service
@@ -73,6 +81,9 @@ func NewActivityService(
 	revisionService *revision_common.RevisionService,
 	metaService *metacommon.MetaCommonService,
 	configService *config.ConfigService,
+	answerRepo answercommon.AnswerRepo,
+	userRoleRelService *role.UserRoleRelService,
+	spaceCommon *space_common.SpaceCommon,
 ) *ActivityService {
 	return &ActivityService{
 		objectInfoService:     objectInfoService,
@@ -84,6 +95,9 @@ func NewActivityService(
 		revisionService:       revisionService,
 		metaService:           metaService,
 		configService:         configService,
+		answerRepo:            answerRepo,
+		userRoleRelService:    userRoleRelService,
+		spaceCommon:           spaceCommon,
 	}
 }
 
@@ -0,0 +1,164 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package activity
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/role"
+	"github.com/apache/answer/pkg/converter"
+	"github.com/apache/answer/pkg/obj"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// GetQuestionTimeline get the timeline of question and its answers, including the revisions, close and status changes.
+// The deleted or pending posts are only visible to their authors and the admins or moderators.
+func (as *ActivityService) GetQuestionTimeline(ctx context.Context, req *schema.GetQuestionTimelineReq) (
+	resp *schema.GetQuestionTimelineResp, err error) {
+	questionInfo, err := as.objectInfoService.GetInfo(ctx, req.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	if questionInfo.ObjectType != constant.QuestionObjectType {
+		return nil, errors.NotFound(reason.QuestionNotFound)
+	}
+	if questionInfo.QuestionStatus == entity.QuestionStatusDeleted || questionInfo.QuestionStatus == entity.QuestionStatusPending {
+		if !req.IsAdminModerator && questionInfo.ObjectCreatorUserID != req.UserID {
+			return nil, errors.NotFound(reason.QuestionNotFound)
+		}
+	}
+	canView, err := as.spaceCommon.CanViewQuestion(ctx, questionInfo.QuestionID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, errors.NotFound(reason.QuestionNotFound)
+	}
+	resp = &schema.GetQuestionTimelineResp{
+		QuestionID: questionInfo.QuestionID,
+		Title:      questionInfo.Title,
+		Timeline:   make([]*schema.QuestionTimelineEvent, 0),
+	}
+	if handler.GetEnableShortID(ctx) {
+		resp.QuestionID = uid.EnShortID(questionInfo.QuestionID)
+	}
+
+	// the owner of each object, the activities of the invisible answers are skipped
+	objectOwners := map[string]string{questionInfo.QuestionID: questionInfo.ObjectCreatorUserID}
+	answers, err := as.answerRepo.GetAnswerList(ctx, &entity.Answer{QuestionID: questionInfo.QuestionID})
+	if err != nil {
+		return nil, err
+	}
+	for _, answer := range answers {
+		answerID := uid.DeShortID(answer.ID)
+		if answer.Status != entity.AnswerStatusAvailable && !req.IsAdminModerator && answer.UserID != req.UserID {
+			continue
+		}
+		objectOwners[answerID] = answer.UserID
+	}
+	objectIDs := make([]string, 0, len(objectOwners))
+	for objectID := range objectOwners {
+		objectIDs = append(objectIDs, objectID)
+	}
+
+	activityTypes := make([]int, 0, len(schema.QuestionTimelineActivities))
+	for key := range schema.QuestionTimelineActivities {
+		id, err := as.configService.GetIDByKey(ctx, string(key))
+		if err != nil {
+			log.Errorf("get config id by key [%s] error: %v", key, err)
+			continue
+		}
+		activityTypes = append(activityTypes, id)
+	}
+	activityList, err := as.activityRepo.GetObjectsActivityByTypes(ctx, objectIDs, activityTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	actorIDs := make([]string, 0, len(activityList))
+	for _, act := range activityList {
+		actorIDs = append(actorIDs, timelineActorID(act))
+	}
+	userInfoMapping, err := as.userCommon.BatchUserBasicInfoByID(ctx, actorIDs)
+	if err != nil {
+		return nil, err
+	}
+	roleMapping, err := as.userRoleRelService.GetUserRoleRelMapping(ctx, actorIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	lang := handler.GetLangByCtx(ctx)
+	for _, act := range activityList {
+		cfg, err := as.configService.GetConfigByID(ctx, act.ActivityType)
+		if err != nil {
+			log.Errorf("fail to get config by id: %d, err: %v, act id is: %s", act.ActivityType, err, act.ID)
+			continue
+		}
+		actorID := timelineActorID(act)
+		item := &schema.QuestionTimelineEvent{
+			ActivityID:   act.ID,
+			ActivityType: cfg.Key,
+			Category:     schema.QuestionTimelineActivities[constant.ActivityTypeKey(cfg.Key)],
+			RevisionID:   converter.IntToString(act.RevisionID),
+			ObjectID:     act.ObjectID,
+			Cancelled:    act.Cancelled == entity.ActivityCancelled,
+			CreatedAt:    act.CreatedAt.Unix(),
+			UserInfo:     &schema.UserBasicInfo{},
+		}
+		if userInfo, ok := userInfoMapping[actorID]; ok {
+			item.UserInfo = userInfo
+		}
+		// the moderator action is performed by the admin or moderator on the post of another user
+		if roleID := roleMapping[actorID]; roleID == role.RoleAdminID || roleID == role.RoleModeratorID {
+			item.ModeratorAction = objectOwners[act.OriginalObjectID] != actorID
+		}
+		item.ObjectType, _ = obj.GetObjectTypeStrByObjectID(act.ObjectID)
+		if item.ObjectType == constant.QuestionObjectType || item.ObjectType == constant.AnswerObjectType {
+			if handler.GetEnableShortID(ctx) {
+				item.ObjectID = uid.EnShortID(act.ObjectID)
+			}
+		}
+		_, activityType, _ := strings.Cut(cfg.Key, ".")
+		item.Comment = as.getTimelineActivityComment(ctx, act.ObjectID, item.ObjectType, activityType, item.RevisionID)
+		item.Description = translator.TrWithData(lang, "timeline."+cfg.Key,
+			&schema.QuestionTimelineTplData{User: item.UserInfo.DisplayName})
+		resp.Timeline = append(resp.Timeline, item)
+	}
+	return resp, nil
+}
+
+// timelineActorID get the user who performed the activity
+func timelineActorID(act *entity.Activity) string {
+	if act.TriggerUserID > 0 {
+		return fmt.Sprintf("%d", act.TriggerUserID)
+	}
+	return act.UserID
+}
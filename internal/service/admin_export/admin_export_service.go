@@ -0,0 +1,354 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package admin_export
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/audit_log"
+	"github.com/apache/answer/internal/service/content"
+	"github.com/apache/answer/internal/service/service_config"
+	"github.com/apache/answer/internal/service/user_admin"
+	"github.com/apache/answer/pkg/dir"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// adminExportBatchSize the number of records read from the admin list at once
+	adminExportBatchSize = 100
+	// adminExportSyncLimit the lists larger than this are exported in background
+	adminExportSyncLimit = 1000
+	// adminExportMaxRows the max number of records in a single export
+	adminExportMaxRows = 100000
+)
+
+// exportPager read one page of the filtered admin list as csv records
+type exportPager func(ctx context.Context, page, pageSize int) (records [][]string, total int64, err error)
+
+// AdminExport the filtered admin list to be exported
+type AdminExport struct {
+	exportType string
+	header     []string
+	pager      exportPager
+	async      bool
+}
+
+// AdminExportService export the admin lists as csv
+type AdminExportService struct {
+	data             *data.Data
+	questionService  *content.QuestionService
+	userAdminService *user_admin.UserAdminService
+	auditLogService  *audit_log.AuditLogService
+	serviceConfig    *service_config.ServiceConfig
+	running          atomic.Bool
+}
+
+// NewAdminExportService new admin export service
+func NewAdminExportService(
+	data *data.Data,
+	questionService *content.QuestionService,
+	userAdminService *user_admin.UserAdminService,
+	auditLogService *audit_log.AuditLogService,
+	serviceConfig *service_config.ServiceConfig,
+) *AdminExportService {
+	return &AdminExportService{
+		data:             data,
+		questionService:  questionService,
+		userAdminService: userAdminService,
+		auditLogService:  auditLogService,
+		serviceConfig:    serviceConfig,
+	}
+}
+
+// QuestionExport the filtered admin question list
+func (es *AdminExportService) QuestionExport(req *schema.AdminQuestionExportReq) *AdminExport {
+	return &AdminExport{
+		exportType: schema.AdminExportTypeQuestion,
+		header:     schema.AdminQuestionCSVHeader,
+		async:      req.Async,
+		pager: func(ctx context.Context, page, pageSize int) (records [][]string, total int64, err error) {
+			pageReq := req.AdminQuestionPageReq
+			pageReq.Page, pageReq.PageSize = page, pageSize
+			resp, err := es.questionService.AdminQuestionPage(ctx, &pageReq)
+			if err != nil {
+				return nil, 0, err
+			}
+			for _, item := range resp.List.([]*schema.AdminQuestionInfo) {
+				records = append(records, item.CSVRecord())
+			}
+			return records, resp.Count, nil
+		},
+	}
+}
+
+// AnswerExport the filtered admin answer list
+func (es *AdminExportService) AnswerExport(req *schema.AdminAnswerExportReq) *AdminExport {
+	return &AdminExport{
+		exportType: schema.AdminExportTypeAnswer,
+		header:     schema.AdminAnswerCSVHeader,
+		async:      req.Async,
+		pager: func(ctx context.Context, page, pageSize int) (records [][]string, total int64, err error) {
+			pageReq := req.AdminAnswerPageReq
+			pageReq.Page, pageReq.PageSize = page, pageSize
+			resp, err := es.questionService.AdminAnswerPage(ctx, &pageReq)
+			if err != nil {
+				return nil, 0, err
+			}
+			for _, item := range resp.List.([]*schema.AdminAnswerInfo) {
+				records = append(records, item.CSVRecord())
+			}
+			return records, resp.Count, nil
+		},
+	}
+}
+
+// UserExport the filtered admin user list
+func (es *AdminExportService) UserExport(req *schema.AdminUserExportReq) *AdminExport {
+	return &AdminExport{
+		exportType: schema.AdminExportTypeUser,
+		header:     schema.AdminUserCSVHeader,
+		async:      req.Async,
+		pager: func(ctx context.Context, page, pageSize int) (records [][]string, total int64, err error) {
+			pageReq := req.GetUserPageReq
+			pageReq.Page, pageReq.PageSize = page, pageSize
+			resp, err := es.userAdminService.GetUserPage(ctx, &pageReq)
+			if err != nil {
+				return nil, 0, err
+			}
+			for _, item := range resp.List.([]*schema.GetUserPageResp) {
+				records = append(records, item.CSVRecord())
+			}
+			return records, resp.Count, nil
+		},
+	}
+}
+
+// AuditLogExport the filtered audit logs
+func (es *AdminExportService) AuditLogExport(req *schema.AuditLogExportReq) *AdminExport {
+	return &AdminExport{
+		exportType: schema.AdminExportTypeAuditLog,
+		header:     schema.AuditLogCSVHeader,
+		async:      req.Async,
+		pager: func(ctx context.Context, page, pageSize int) (records [][]string, total int64, err error) {
+			pageReq := req.GetAuditLogPageReq
+			pageReq.Page, pageReq.PageSize = page, pageSize
+			resp, err := es.auditLogService.GetAuditLogPage(ctx, &pageReq)
+			if err != nil {
+				return nil, 0, err
+			}
+			for _, item := range resp.List.([]*schema.AuditLogItem) {
+				records = append(records, item.CSVRecord())
+			}
+			return records, resp.Count, nil
+		},
+	}
+}
+
+// FileName the file name of the synchronous export
+func (e *AdminExport) FileName() string {
+	return fmt.Sprintf("answer_%s_export_%s.csv", e.exportType, time.Now().Format("20060102150405"))
+}
+
+// IsAsync check whether the export should be generated in background
+func (es *AdminExportService) IsAsync(ctx context.Context, export *AdminExport) (async bool, err error) {
+	if export.async {
+		return true, nil
+	}
+	_, total, err := export.pager(ctx, 1, 1)
+	if err != nil {
+		return false, err
+	}
+	return total > adminExportSyncLimit, nil
+}
+
+// WriteCSV write the whole filtered admin list as csv
+func (es *AdminExportService) WriteCSV(ctx context.Context, export *AdminExport, w io.Writer) (count int64, err error) {
+	cw := csv.NewWriter(w)
+	if err = cw.Write(export.header); err != nil {
+		return 0, err
+	}
+	for page := 1; count < adminExportMaxRows; page++ {
+		records, _, err := export.pager(ctx, page, adminExportBatchSize)
+		if err != nil {
+			return count, err
+		}
+		if err = cw.WriteAll(records); err != nil {
+			return count, err
+		}
+		count += int64(len(records))
+		if len(records) < adminExportBatchSize {
+			break
+		}
+	}
+	cw.Flush()
+	return count, cw.Error()
+}
+
+// CreateExportJob generate the csv file of the filtered admin list in background
+func (es *AdminExportService) CreateExportJob(ctx context.Context, export *AdminExport) (
+	job *schema.AdminExportJob, err error) {
+	if !es.running.CompareAndSwap(false, true) {
+		return nil, errors.BadRequest(reason.AdminExportInProgress)
+	}
+	job = &schema.AdminExportJob{
+		JobID:     generateJobID(),
+		Type:      export.exportType,
+		Status:    schema.AdminExportStatusPending,
+		CreatedAt: time.Now().Unix(),
+	}
+	if err = es.saveJob(ctx, job); err != nil {
+		es.running.Store(false)
+		return nil, err
+	}
+	go func() {
+		defer es.running.Store(false)
+		es.removeExpiredFiles()
+		es.runExportJob(context.Background(), export, job)
+	}()
+	return job, nil
+}
+
+// GetExportJob get the export job
+func (es *AdminExportService) GetExportJob(ctx context.Context, req *schema.GetAdminExportJobReq) (
+	job *schema.AdminExportJob, err error) {
+	jobStr, exist, err := es.data.Cache.GetString(ctx, schema.AdminExportJobCacheKeyPrefix+req.JobID)
+	if err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	if !exist {
+		return nil, errors.NotFound(reason.AdminExportNotFound)
+	}
+	job = &schema.AdminExportJob{}
+	if err = json.Unmarshal([]byte(jobStr), job); err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	if job.Status == schema.AdminExportStatusDone {
+		job.DownloadURL = fmt.Sprintf("/answer/admin/api/export/%s/download", job.JobID)
+	}
+	return job, nil
+}
+
+// GetExportFile get the local file path and file name of the finished export job
+func (es *AdminExportService) GetExportFile(ctx context.Context, req *schema.GetAdminExportJobReq) (
+	filePath, fileName string, err error) {
+	job, err := es.GetExportJob(ctx, req)
+	if err != nil {
+		return "", "", err
+	}
+	if job.Status != schema.AdminExportStatusDone {
+		return "", "", errors.BadRequest(reason.AdminExportNotReady)
+	}
+	filePath = es.exportFilePath(job.JobID)
+	if !dir.CheckFileExist(filePath) {
+		return "", "", errors.NotFound(reason.AdminExportNotFound)
+	}
+	return filePath, job.FileName, nil
+}
+
+func (es *AdminExportService) runExportJob(ctx context.Context, export *AdminExport, job *schema.AdminExportJob) {
+	job.Status = schema.AdminExportStatusFailed
+	count, err := es.writeExportFile(ctx, export, job.JobID)
+	if err != nil {
+		log.Errorf("export admin %s list failed: %v", export.exportType, err)
+	} else {
+		job.Status = schema.AdminExportStatusDone
+		job.RowCount = count
+		job.FileName = export.FileName()
+		log.Infof("admin %s list exported, %d rows", export.exportType, count)
+	}
+	job.FinishedAt = time.Now().Unix()
+	if err = es.saveJob(ctx, job); err != nil {
+		log.Error(err)
+	}
+}
+
+func (es *AdminExportService) writeExportFile(ctx context.Context, export *AdminExport, jobID string) (
+	count int64, err error) {
+	if err = dir.CreateDirIfNotExist(filepath.Join(es.serviceConfig.UploadPath, constant.AdminExportSubPath)); err != nil {
+		return 0, err
+	}
+	filePath := es.exportFilePath(jobID)
+	file, err := os.Create(filePath)
+	if err != nil {
+		return 0, err
+	}
+	count, err = es.WriteCSV(ctx, export, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(filePath)
+	}
+	return count, err
+}
+
+// removeExpiredFiles remove the files of the export jobs that have expired
+func (es *AdminExportService) removeExpiredFiles() {
+	exportDir := filepath.Join(es.serviceConfig.UploadPath, constant.AdminExportSubPath)
+	entries, err := os.ReadDir(exportDir)
+	if err != nil {
+		return
+	}
+	expiredAt := time.Now().Add(-schema.AdminExportJobCacheTime)
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || entry.IsDir() || info.ModTime().After(expiredAt) {
+			continue
+		}
+		if err = os.Remove(filepath.Join(exportDir, entry.Name())); err != nil {
+			log.Warnf("remove expired admin export file failed: %v", err)
+		}
+	}
+}
+
+func (es *AdminExportService) saveJob(ctx context.Context, job *schema.AdminExportJob) error {
+	jobStr, _ := json.Marshal(job)
+	err := es.data.Cache.SetString(ctx, schema.AdminExportJobCacheKeyPrefix+job.JobID,
+		string(jobStr), schema.AdminExportJobCacheTime)
+	if err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+func (es *AdminExportService) exportFilePath(jobID string) string {
+	return filepath.Join(es.serviceConfig.UploadPath, constant.AdminExportSubPath, jobID+".csv")
+}
+
+func generateJobID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
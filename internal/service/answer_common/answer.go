@@ -114,6 +114,7 @@ func (as *AnswerCommon) ShowFormat(ctx context.Context, data *entity.Answer) *sc
 	info.UserID = data.UserID
 	info.UpdateUserID = data.LastEditUserID
 	info.Status = data.Status
+	info.Provenance = schema.NewAnswerProvenance(data)
 	info.MemberActions = make([]*schema.PermissionMemberAction, 0)
 	return &info
 }
@@ -132,5 +133,6 @@ func (as *AnswerCommon) AdminShowFormat(ctx context.Context, data *entity.Answer
 	info.UserID = data.UserID
 	info.UpdateUserID = data.LastEditUserID
 	info.Description = htmltext.FetchExcerpt(data.ParsedText, "...", 240)
+	info.Provenance = schema.NewAnswerProvenance(data)
 	return &info
 }
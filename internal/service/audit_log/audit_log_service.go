@@ -0,0 +1,154 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package audit_log
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/log"
+)
+
+// AuditLogRepo audit log repository
+type AuditLogRepo interface {
+	AddAuditLog(ctx context.Context, auditLog *entity.AuditLog) (err error)
+	GetAuditLogPage(ctx context.Context, page, pageSize int, cond *schema.AuditLogCond) (
+		auditLogs []*entity.AuditLog, total int64, err error)
+}
+
+// AuditLogService record the privileged actions and query them
+type AuditLogService struct {
+	auditLogRepo AuditLogRepo
+	userCommon   *usercommon.UserCommon
+}
+
+// NewAuditLogService new audit log service
+func NewAuditLogService(
+	auditLogRepo AuditLogRepo,
+	userCommon *usercommon.UserCommon,
+) *AuditLogService {
+	return &AuditLogService{
+		auditLogRepo: auditLogRepo,
+		userCommon:   userCommon,
+	}
+}
+
+// Record record the privileged action, the failure is only logged and never breaks the action
+func (as *AuditLogService) Record(ctx context.Context, record *schema.AuditLogRecord) {
+	auditLog := &entity.AuditLog{
+		OperatorID: record.OperatorID,
+		Action:     record.Action,
+		ObjectType: record.ObjectType,
+		ObjectID:   record.ObjectID,
+		Before:     marshalValue(record.Before),
+		After:      marshalValue(record.After),
+	}
+	if len(auditLog.OperatorID) == 0 {
+		auditLog.OperatorID = handler.GetOperatorID(ctx)
+	}
+	if len(auditLog.OperatorID) == 0 {
+		auditLog.OperatorID = "0"
+	}
+	if ginCtx, ok := ctx.(*gin.Context); ok {
+		auditLog.IP = ginCtx.ClientIP()
+	}
+	if err := as.auditLogRepo.AddAuditLog(ctx, auditLog); err != nil {
+		log.Errorf("add audit log %s of %s %s failed: %v", auditLog.Action, auditLog.ObjectType, auditLog.ObjectID, err)
+	}
+}
+
+// GetAuditLogPage get the audit logs matched the filter, the latest first
+func (as *AuditLogService) GetAuditLogPage(ctx context.Context, req *schema.GetAuditLogPageReq) (
+	pageModel *pager.PageModel, err error) {
+	cond := &schema.AuditLogCond{
+		Action:     req.Action,
+		ObjectType: req.ObjectType,
+		ObjectID:   req.ObjectID,
+		StartTime:  req.StartTime,
+		EndTime:    req.EndTime,
+	}
+	if len(req.Operator) > 0 {
+		operator, exist, err := as.userCommon.GetUserBasicInfoByUserName(ctx, req.Operator)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			return pager.NewPageModel(0, make([]*schema.AuditLogItem, 0)), nil
+		}
+		cond.OperatorID = operator.ID
+	}
+	auditLogs, total, err := as.auditLogRepo.GetAuditLogPage(ctx, req.Page, req.PageSize, cond)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]string, 0, len(auditLogs))
+	for _, auditLog := range auditLogs {
+		userIDs = append(userIDs, auditLog.OperatorID)
+	}
+	userInfoMapping, err := as.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*schema.AuditLogItem, 0, len(auditLogs))
+	for _, auditLog := range auditLogs {
+		list = append(list, &schema.AuditLogItem{
+			ID:         auditLog.ID,
+			Operator:   userInfoMapping[auditLog.OperatorID],
+			Action:     auditLog.Action,
+			ObjectType: auditLog.ObjectType,
+			ObjectID:   auditLog.ObjectID,
+			Before:     auditLog.Before,
+			After:      auditLog.After,
+			IP:         auditLog.IP,
+			CreatedAt:  auditLog.CreatedAt.Unix(),
+		})
+	}
+	return pager.NewPageModel(total, list), nil
+}
+
+// marshalValue marshal the value as json and mask the sensitive fields
+func marshalValue(value any) string {
+	if value == nil {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		// the value is already json, such as the content of settings
+		var object map[string]any
+		if err := json.Unmarshal([]byte(s), &object); err != nil {
+			return s
+		}
+		value = object
+	}
+	content, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	var object map[string]any
+	if err = json.Unmarshal(content, &object); err == nil {
+		content, _ = json.Marshal(schema.MaskAuditLogValue(object))
+	}
+	return string(content)
+}
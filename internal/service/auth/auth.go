@@ -21,12 +21,24 @@ package auth
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
 
+	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/pkg/token"
 	"github.com/apache/answer/plugin"
+	"github.com/segmentfault/pacman/log"
 )
 
+// BotTokenPrefix the prefix of bot api token, distinguishes it from the access token of login
+const BotTokenPrefix = "bot_"
+
+// PersonalAccessTokenPrefix the prefix of personal access token
+const PersonalAccessTokenPrefix = "pat_"
+
 // AuthRepo auth repository
 type AuthRepo interface {
 	GetUserCacheInfo(ctx context.Context, accessToken string) (userInfo *entity.UserCacheInfo, err error)
@@ -42,35 +54,85 @@ type AuthRepo interface {
 	RemoveAdminUserCacheInfo(ctx context.Context, accessToken string) (err error)
 	AddUserTokenMapping(ctx context.Context, userID, accessToken string) (err error)
 	RemoveUserTokens(ctx context.Context, userID string, remainToken string)
+	GetUserTokens(ctx context.Context, userID string) (accessTokens []string, err error)
+	RemoveUserTokenMapping(ctx context.Context, userID string, accessTokens ...string) (err error)
+}
+
+// BotTokenRepo bot token repository
+type BotTokenRepo interface {
+	GetBotUserCacheInfo(ctx context.Context, tokenHash string) (userInfo *entity.UserCacheInfo, exist bool, err error)
+}
+
+// PersonalAccessTokenRepo personal access token repository
+type PersonalAccessTokenRepo interface {
+	GetPersonalAccessTokenUserCacheInfo(ctx context.Context, tokenHash string) (
+		userInfo *entity.UserCacheInfo, exist bool, err error)
 }
 
 // AuthService kit service
 type AuthService struct {
-	authRepo AuthRepo
+	authRepo                AuthRepo
+	botTokenRepo            BotTokenRepo
+	personalAccessTokenRepo PersonalAccessTokenRepo
 }
 
 // NewAuthService email service
-func NewAuthService(authRepo AuthRepo) *AuthService {
+func NewAuthService(authRepo AuthRepo, botTokenRepo BotTokenRepo, personalAccessTokenRepo PersonalAccessTokenRepo) *AuthService {
 	return &AuthService{
-		authRepo: authRepo,
+		authRepo:                authRepo,
+		botTokenRepo:            botTokenRepo,
+		personalAccessTokenRepo: personalAccessTokenRepo,
 	}
 }
 
+// HashBotToken the hash of bot token saved in database
+func HashBotToken(botToken string) string {
+	sum := sha256.Sum256([]byte(botToken))
+	return hex.EncodeToString(sum[:])
+}
+
+// HashPersonalAccessToken the hash of personal access token saved in database,
+// it is also the key of the cache so that the cache can be removed when the token is revoked
+func HashPersonalAccessToken(personalAccessToken string) string {
+	return HashBotToken(personalAccessToken)
+}
+
 func (as *AuthService) GetUserCacheInfo(ctx context.Context, accessToken string) (userInfo *entity.UserCacheInfo, err error) {
-	userCacheInfo, err := as.authRepo.GetUserCacheInfo(ctx, accessToken)
+	cacheKey := accessToken
+	isPersonalAccessToken := strings.HasPrefix(accessToken, PersonalAccessTokenPrefix)
+	if isPersonalAccessToken {
+		cacheKey = HashPersonalAccessToken(accessToken)
+	}
+	userCacheInfo, err := as.authRepo.GetUserCacheInfo(ctx, cacheKey)
 	if err != nil {
 		return nil, err
 	}
+	if userCacheInfo == nil && strings.HasPrefix(accessToken, BotTokenPrefix) {
+		userCacheInfo, err = as.getBotUserCacheInfo(ctx, accessToken)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if userCacheInfo == nil && isPersonalAccessToken {
+		userCacheInfo, err = as.getPersonalAccessTokenUserCacheInfo(ctx, cacheKey)
+		if err != nil {
+			return nil, err
+		}
+	}
 	if userCacheInfo == nil {
 		return nil, nil
 	}
+	if userCacheInfo.ExpiredAt > 0 && userCacheInfo.ExpiredAt <= time.Now().Unix() {
+		_ = as.authRepo.RemoveUserCacheInfo(ctx, cacheKey)
+		return nil, nil
+	}
 	cacheInfo, _ := as.authRepo.GetUserStatus(ctx, userCacheInfo.UserID)
 	if cacheInfo != nil {
 		userCacheInfo.UserStatus = cacheInfo.UserStatus
 		userCacheInfo.EmailStatus = cacheInfo.EmailStatus
 		userCacheInfo.RoleID = cacheInfo.RoleID
 		// update current user cache info
-		err := as.authRepo.SetUserCacheInfo(ctx, accessToken, userCacheInfo.VisitToken, userCacheInfo)
+		err := as.authRepo.SetUserCacheInfo(ctx, cacheKey, userCacheInfo.VisitToken, userCacheInfo)
 		if err != nil {
 			return nil, err
 		}
@@ -86,10 +148,45 @@ func (as *AuthService) GetUserCacheInfo(ctx context.Context, accessToken string)
 	return userCacheInfo, nil
 }
 
+// getBotUserCacheInfo get the bot by its api token, then cache it as the access token of login,
+// so the token can be removed like others when the bot token is regenerated
+func (as *AuthService) getBotUserCacheInfo(ctx context.Context, botToken string) (
+	userInfo *entity.UserCacheInfo, err error) {
+	userInfo, exist, err := as.botTokenRepo.GetBotUserCacheInfo(ctx, HashBotToken(botToken))
+	if err != nil || !exist {
+		return nil, err
+	}
+	if err = as.authRepo.SetUserCacheInfo(ctx, botToken, "", userInfo); err != nil {
+		return nil, err
+	}
+	return userInfo, nil
+}
+
+// getPersonalAccessTokenUserCacheInfo get the user by the hash of personal access token, then cache it by the hash
+func (as *AuthService) getPersonalAccessTokenUserCacheInfo(ctx context.Context, tokenHash string) (
+	userInfo *entity.UserCacheInfo, err error) {
+	userInfo, exist, err := as.personalAccessTokenRepo.GetPersonalAccessTokenUserCacheInfo(ctx, tokenHash)
+	if err != nil || !exist {
+		return nil, err
+	}
+	if err = as.authRepo.SetUserCacheInfo(ctx, tokenHash, "", userInfo); err != nil {
+		return nil, err
+	}
+	return userInfo, nil
+}
+
+// RemovePersonalAccessTokenCache remove the cache of the revoked personal access token
+func (as *AuthService) RemovePersonalAccessTokenCache(ctx context.Context, tokenHash string) (err error) {
+	return as.authRepo.RemoveUserCacheInfo(ctx, tokenHash)
+}
+
 func (as *AuthService) SetUserCacheInfo(ctx context.Context, userInfo *entity.UserCacheInfo) (
 	accessToken string, visitToken string, err error) {
 	accessToken = token.GenerateToken()
 	visitToken = token.GenerateToken()
+	if userInfo.CreatedAt == 0 {
+		userInfo.CreatedAt = time.Now().Unix()
+	}
 	err = as.authRepo.SetUserCacheInfo(ctx, accessToken, visitToken, userInfo)
 	if err != nil {
 		return "", "", err
@@ -138,6 +235,78 @@ func (as *AuthService) RemoveTokensExceptCurrentUser(ctx context.Context, userID
 	as.authRepo.RemoveUserTokens(ctx, userID, accessToken)
 }
 
+// RefreshUserSession record the device and the last active time of the login session,
+// the cache is only updated when the device changes or the last active time is stale
+func (as *AuthService) RefreshUserSession(ctx context.Context, accessToken string, userInfo *entity.UserCacheInfo,
+	ip, userAgent string) {
+	// only the login sessions are recorded, the bot tokens and personal access tokens are not
+	if userInfo.CreatedAt == 0 {
+		return
+	}
+	now := time.Now().Unix()
+	if userInfo.IP == ip && userInfo.UserAgent == userAgent &&
+		now-userInfo.LastActiveAt < int64(constant.UserSessionActiveRefreshTime.Seconds()) {
+		return
+	}
+	userInfo.IP = ip
+	userInfo.UserAgent = userAgent
+	userInfo.LastActiveAt = now
+	if err := as.authRepo.SetUserCacheInfo(ctx, accessToken, userInfo.VisitToken, userInfo); err != nil {
+		log.Error(err)
+	}
+}
+
+// GetUserSessions get the login sessions of user, the map key is the access token
+func (as *AuthService) GetUserSessions(ctx context.Context, userID string) (
+	sessions map[string]*entity.UserCacheInfo, err error) {
+	accessTokens, err := as.authRepo.GetUserTokens(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sessions = make(map[string]*entity.UserCacheInfo, len(accessTokens))
+	expiredTokens := make([]string, 0)
+	for _, accessToken := range accessTokens {
+		userInfo, err := as.authRepo.GetUserCacheInfo(ctx, accessToken)
+		if err != nil {
+			return nil, err
+		}
+		if userInfo == nil {
+			expiredTokens = append(expiredTokens, accessToken)
+			continue
+		}
+		if userInfo.CreatedAt == 0 || userInfo.UserID != userID {
+			continue
+		}
+		sessions[accessToken] = userInfo
+	}
+	if len(expiredTokens) > 0 {
+		if err := as.authRepo.RemoveUserTokenMapping(ctx, userID, expiredTokens...); err != nil {
+			log.Error(err)
+		}
+	}
+	return sessions, nil
+}
+
+// RemoveUserSession log out the login session, the admin session and the visit token are removed together
+func (as *AuthService) RemoveUserSession(ctx context.Context, userID, accessToken string) (err error) {
+	userInfo, err := as.authRepo.GetUserCacheInfo(ctx, accessToken)
+	if err != nil {
+		return err
+	}
+	if userInfo != nil && len(userInfo.VisitToken) > 0 {
+		if err = as.authRepo.RemoveUserVisitCacheInfo(ctx, userInfo.VisitToken); err != nil {
+			return err
+		}
+	}
+	if err = as.authRepo.RemoveUserCacheInfo(ctx, accessToken); err != nil {
+		return err
+	}
+	if err = as.authRepo.RemoveAdminUserCacheInfo(ctx, accessToken); err != nil {
+		return err
+	}
+	return as.authRepo.RemoveUserTokenMapping(ctx, userID, accessToken)
+}
+
 //Admin
 
 func (as *AuthService) GetAdminUserCacheInfo(ctx context.Context, accessToken string) (userInfo *entity.UserCacheInfo, err error) {
@@ -0,0 +1,191 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package auto_comment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/comment"
+	questioncommon "github.com/apache/answer/internal/service/question_common"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/converter"
+	"github.com/apache/answer/pkg/display"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+)
+
+// AutoCommentService post the templated comments under the new questions from the bot account
+type AutoCommentService struct {
+	data            *data.Data
+	questionRepo    questioncommon.QuestionRepo
+	userRepo        usercommon.UserRepo
+	commentService  *comment.CommentService
+	siteInfoService siteinfo_common.SiteInfoCommonService
+}
+
+// NewAutoCommentService new auto comment service
+func NewAutoCommentService(
+	data *data.Data,
+	questionRepo questioncommon.QuestionRepo,
+	userRepo usercommon.UserRepo,
+	commentService *comment.CommentService,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *AutoCommentService {
+	return &AutoCommentService{
+		data:            data,
+		questionRepo:    questionRepo,
+		userRepo:        userRepo,
+		commentService:  commentService,
+		siteInfoService: siteInfoService,
+	}
+}
+
+// CommentNewQuestion post the comment of the first matched rule under the new question
+func (as *AutoCommentService) CommentNewQuestion(ctx context.Context, question *entity.Question, tags []*entity.Tag) {
+	conf, err := as.siteInfoService.GetSiteAutoComment(ctx)
+	if err != nil || !conf.Enabled || len(conf.BotUsername) == 0 {
+		return
+	}
+	if question.Status != entity.QuestionStatusAvailable {
+		return
+	}
+	bot, exist, err := as.userRepo.GetByUsername(ctx, conf.BotUsername)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if !exist || bot.Status != entity.UserStatusAvailable {
+		log.Warnf("auto comment bot account %s is not available", conf.BotUsername)
+		return
+	}
+	if bot.ID == question.UserID {
+		return
+	}
+
+	tagNames := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tagNames = append(tagNames, tag.SlugName)
+	}
+	questionCount, err := as.questionRepo.GetUserQuestionCount(ctx, question.UserID, 0)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	// the new question is counted already
+	rule, tagName := conf.MatchRule(questionCount <= 1, tagNames)
+	if rule == nil {
+		return
+	}
+	if as.reachLimit(ctx, int64(conf.MaxCommentsPerHour)) {
+		log.Debugf("auto comment reach the limit, skip question %s", question.ID)
+		return
+	}
+
+	author, exist, err := as.userRepo.GetByUserID(ctx, question.UserID)
+	if err != nil || !exist {
+		return
+	}
+	tplData := &schema.AutoCommentTemplateData{
+		UserDisplayName: author.DisplayName,
+		Username:        author.Username,
+		QuestionTitle:   question.Title,
+		TagName:         tagName,
+	}
+	if general, err := as.siteInfoService.GetSiteGeneral(ctx); err == nil {
+		tplData.SiteName = general.Name
+	}
+	content, err := rule.Render(tplData)
+	if err != nil {
+		log.Errorf("render auto comment rule %s failed: %v", rule.Name, err)
+		return
+	}
+	_, err = as.commentService.AddComment(ctx, &schema.AddCommentReq{
+		ObjectID:     question.ID,
+		OriginalText: content,
+		ParsedText:   converter.Markdown2HTML(content),
+		UserID:       bot.ID,
+	})
+	if err != nil {
+		log.Errorf("add auto comment under question %s failed: %v", question.ID, err)
+	}
+}
+
+// CommentDuplicateQuestion post the comment linking the target question under the question closed as its duplicate,
+// the comment is posted by the user who closed the question in the site language
+func (as *AutoCommentService) CommentDuplicateQuestion(ctx context.Context,
+	question, target *entity.Question, operatorID string) {
+	siteGeneral, err := as.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	permalink := constant.PermalinkQuestionID
+	if seo, err := as.siteInfoService.GetSiteSeo(ctx); err == nil {
+		permalink = seo.Permalink
+	}
+	lang := i18n.DefaultLanguage
+	if siteInterface, err := as.siteInfoService.GetSiteInterface(ctx); err == nil {
+		lang = i18n.Language(siteInterface.Language)
+	}
+	content := translator.TrWithData(lang, reason.QuestionDuplicateOf, map[string]string{
+		"QuestionTitle": target.Title,
+		"QuestionURL":   display.QuestionURL(permalink, siteGeneral.SiteUrl, target.ID, target.Title),
+	})
+	_, err = as.commentService.AddComment(ctx, &schema.AddCommentReq{
+		ObjectID:     question.ID,
+		OriginalText: content,
+		ParsedText:   converter.Markdown2HTML(content),
+		UserID:       operatorID,
+	})
+	if err != nil {
+		log.Errorf("add duplicate comment under question %s failed: %v", question.ID, err)
+	}
+}
+
+// reachLimit check whether the comments posted in current hour reach the max count, if not increase it
+func (as *AutoCommentService) reachLimit(ctx context.Context, maxCount int64) bool {
+	key := fmt.Sprintf("%s%d", constant.AutoCommentLimitCacheKeyPrefix, time.Now().Unix()/3600)
+	count, exist, err := as.data.Cache.GetInt64(ctx, key)
+	if err != nil {
+		log.Error(err)
+		return true
+	}
+	if exist && count >= maxCount {
+		return true
+	}
+	if !exist {
+		err = as.data.Cache.SetInt64(ctx, key, 1, constant.AutoCommentLimitCacheTime)
+	} else {
+		_, err = as.data.Cache.Increase(ctx, key, 1)
+	}
+	if err != nil {
+		log.Error(err)
+	}
+	return false
+}
@@ -0,0 +1,344 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package auto_promotion
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/auth"
+	"github.com/apache/answer/internal/service/notice_queue"
+	"github.com/apache/answer/internal/service/role"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const evaluateBatchSize = 200
+
+// AutoPromotionRepo auto promotion repository
+type AutoPromotionRepo interface {
+	GetUserActivityStats(ctx context.Context, minReputation int, registeredBefore time.Time,
+		startUserID string, limit int) (stats []*entity.UserActivityStat, err error)
+	GetUserAutoPromotions(ctx context.Context, roleID int) (progresses []*entity.UserAutoPromotion, err error)
+	SaveUserAutoPromotion(ctx context.Context, progress *entity.UserAutoPromotion) (err error)
+	RemoveUserAutoPromotion(ctx context.Context, id string) (err error)
+	AddAutoPromotionLog(ctx context.Context, promotionLog *entity.AutoPromotionLog) (err error)
+	GetAutoPromotionLogPage(ctx context.Context, page, pageSize int, userID string) (
+		logs []*entity.AutoPromotionLog, total int64, err error)
+}
+
+// AutoPromotionService grant the roles to the users by their sustained activity
+type AutoPromotionService struct {
+	autoPromotionRepo        AutoPromotionRepo
+	siteInfoService          siteinfo_common.SiteInfoCommonService
+	roleService              *role.RoleService
+	userRoleRelService       *role.UserRoleRelService
+	authService              *auth.AuthService
+	notificationQueueService notice_queue.NotificationQueueService
+	userCommon               *usercommon.UserCommon
+	evaluating               atomic.Bool
+}
+
+// NewAutoPromotionService new auto promotion service
+func NewAutoPromotionService(
+	autoPromotionRepo AutoPromotionRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	roleService *role.RoleService,
+	userRoleRelService *role.UserRoleRelService,
+	authService *auth.AuthService,
+	notificationQueueService notice_queue.NotificationQueueService,
+	userCommon *usercommon.UserCommon,
+) *AutoPromotionService {
+	return &AutoPromotionService{
+		autoPromotionRepo:        autoPromotionRepo,
+		siteInfoService:          siteInfoService,
+		roleService:              roleService,
+		userRoleRelService:       userRoleRelService,
+		authService:              authService,
+		notificationQueueService: notificationQueueService,
+		userCommon:               userCommon,
+	}
+}
+
+// EvaluateCron evaluate the auto promotion rules once a day
+func (as *AutoPromotionService) EvaluateCron(ctx context.Context) {
+	if err := as.Evaluate(ctx); err != nil {
+		log.Errorf("evaluate auto promotion failed: %v", err)
+	}
+}
+
+// TriggerEvaluate evaluate the auto promotion rules in the background
+func (as *AutoPromotionService) TriggerEvaluate(ctx context.Context) (err error) {
+	if as.evaluating.Load() {
+		return errors.BadRequest(reason.AutoPromotionInProgress)
+	}
+	go func() {
+		if err := as.Evaluate(context.Background()); err != nil {
+			log.Errorf("evaluate auto promotion failed: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Evaluate evaluate the activity of users by every auto promotion rule
+func (as *AutoPromotionService) Evaluate(ctx context.Context) (err error) {
+	if !as.evaluating.CompareAndSwap(false, true) {
+		return errors.BadRequest(reason.AutoPromotionInProgress)
+	}
+	defer as.evaluating.Store(false)
+
+	siteInfo, err := as.siteInfoService.GetSiteAutoPromotion(ctx)
+	if err != nil {
+		return err
+	}
+	rules := siteInfo.GetRules()
+	if len(rules) == 0 {
+		return nil
+	}
+	roleMapping, err := as.roleService.GetRoleMapping(ctx)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, rule := range rules {
+		roleInfo, ok := roleMapping[rule.RoleID]
+		if !ok {
+			log.Warnf("auto promotion role %d not found", rule.RoleID)
+			continue
+		}
+		if err = as.evaluateRule(ctx, rule, roleInfo, now); err != nil {
+			log.Errorf("evaluate auto promotion of role %d failed: %v", rule.RoleID, err)
+		}
+	}
+	return nil
+}
+
+func (as *AutoPromotionService) evaluateRule(ctx context.Context, rule *schema.AutoPromotionRule,
+	roleInfo *entity.Role, now time.Time) (err error) {
+	progresses, err := as.autoPromotionRepo.GetUserAutoPromotions(ctx, rule.RoleID)
+	if err != nil {
+		return err
+	}
+	progressMapping := make(map[string]*entity.UserAutoPromotion, len(progresses))
+	for _, progress := range progresses {
+		progressMapping[progress.UserID] = progress
+	}
+
+	// the users meet the thresholds in this evaluation
+	qualified := make(map[string]bool)
+	registeredBefore := now.AddDate(0, 0, -rule.MinAccountAgeDays)
+	startUserID := ""
+	for {
+		stats, err := as.autoPromotionRepo.GetUserActivityStats(ctx, rule.MinReputation, registeredBefore,
+			startUserID, evaluateBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(stats) == 0 {
+			break
+		}
+		startUserID = stats[len(stats)-1].UserID
+
+		userIDs := make([]string, 0, len(stats))
+		for _, stat := range stats {
+			userIDs = append(userIDs, stat.UserID)
+		}
+		userRoleMapping, err := as.userRoleRelService.GetUserRoleRelMapping(ctx, userIDs)
+		if err != nil {
+			return err
+		}
+		for _, stat := range stats {
+			if !rule.Qualified(stat, now) {
+				continue
+			}
+			qualified[stat.UserID] = true
+			progress, ok := progressMapping[stat.UserID]
+			if !ok {
+				progress = &entity.UserAutoPromotion{UserID: stat.UserID, RoleID: rule.RoleID}
+			} else if sameDay(progress.LastEvaluatedAt, now) {
+				continue
+			}
+			currentRoleID := role.RoleUserID
+			if roleID, ok := userRoleMapping[stat.UserID]; ok {
+				currentRoleID = roleID
+			}
+			// the role of the user has been changed by the admin, leave it alone
+			if progress.Granted && currentRoleID != rule.RoleID {
+				continue
+			}
+			progress.QualifiedTimes++
+			progress.FailedTimes = 0
+			progress.LastEvaluatedAt = now
+			if !progress.Granted && progress.QualifiedTimes >= rule.SustainedDays && currentRoleID == role.RoleUserID {
+				if err = as.changeRole(ctx, stat, roleInfo, entity.AutoPromotionActionGrant); err != nil {
+					log.Errorf("grant role %d to user %s failed: %v", rule.RoleID, stat.UserID, err)
+					continue
+				}
+				progress.Granted = true
+			}
+			if err = as.autoPromotionRepo.SaveUserAutoPromotion(ctx, progress); err != nil {
+				return err
+			}
+		}
+		if len(stats) < evaluateBatchSize {
+			break
+		}
+	}
+
+	// the users with progress but not meet the thresholds in this evaluation
+	failedUserIDs := make([]string, 0)
+	for userID := range progressMapping {
+		if !qualified[userID] {
+			failedUserIDs = append(failedUserIDs, userID)
+		}
+	}
+	if len(failedUserIDs) == 0 {
+		return nil
+	}
+	userRoleMapping, err := as.userRoleRelService.GetUserRoleRelMapping(ctx, failedUserIDs)
+	if err != nil {
+		return err
+	}
+	for _, userID := range failedUserIDs {
+		progress := progressMapping[userID]
+		if sameDay(progress.LastEvaluatedAt, now) {
+			continue
+		}
+		roleID, ok := userRoleMapping[userID]
+		// the sustained activity is broken before granted, or the role has been changed by the admin
+		if !progress.Granted || !ok || roleID != rule.RoleID {
+			if err = as.autoPromotionRepo.RemoveUserAutoPromotion(ctx, progress.ID); err != nil {
+				return err
+			}
+			continue
+		}
+		progress.QualifiedTimes = 0
+		progress.FailedTimes++
+		progress.LastEvaluatedAt = now
+		if rule.RevokeAfterDays > 0 && progress.FailedTimes >= rule.RevokeAfterDays {
+			stat := &entity.UserActivityStat{UserID: userID}
+			if err = as.changeRole(ctx, stat, roleInfo, entity.AutoPromotionActionRevoke); err != nil {
+				log.Errorf("revoke role %d of user %s failed: %v", rule.RoleID, userID, err)
+				continue
+			}
+			if err = as.autoPromotionRepo.RemoveUserAutoPromotion(ctx, progress.ID); err != nil {
+				return err
+			}
+			continue
+		}
+		if err = as.autoPromotionRepo.SaveUserAutoPromotion(ctx, progress); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// changeRole grant the role to the user or revoke it, keep the audit trail and notify the user
+func (as *AutoPromotionService) changeRole(ctx context.Context, stat *entity.UserActivityStat,
+	roleInfo *entity.Role, action string) (err error) {
+	roleID, notificationAction := roleInfo.ID, constant.NotificationPromotedToRole
+	if action == entity.AutoPromotionActionRevoke {
+		roleID, notificationAction = role.RoleUserID, constant.NotificationRoleRevoked
+	}
+	if err = as.userRoleRelService.SaveUserRole(ctx, stat.UserID, roleID); err != nil {
+		return err
+	}
+	// the role of user is cached in the token, so the user needs to login again
+	as.authService.RemoveUserAllTokens(ctx, stat.UserID)
+
+	promotionLog := &entity.AutoPromotionLog{
+		UserID: stat.UserID,
+		RoleID: roleInfo.ID,
+		Action: action,
+	}
+	if action == entity.AutoPromotionActionGrant {
+		promotionLog.Detail = schema.FormatUserActivityStat(stat)
+	}
+	if err = as.autoPromotionRepo.AddAutoPromotionLog(ctx, promotionLog); err != nil {
+		return err
+	}
+	log.Infof("auto promotion %s role %d of user %s", action, roleInfo.ID, stat.UserID)
+
+	as.notificationQueueService.Send(ctx, &schema.NotificationMsg{
+		TriggerUserID:       stat.UserID,
+		ReceiverUserID:      stat.UserID,
+		Type:                schema.NotificationTypeInbox,
+		Title:               role.RoleNameTrKey(roleInfo.Name),
+		ObjectID:            promotionLog.ID,
+		ObjectType:          constant.RoleChangeObjectType,
+		NotificationAction:  notificationAction,
+		NoNeedPushAllFollow: true,
+		ExtraInfo:           map[string]string{"role_id": fmt.Sprintf("%d", roleInfo.ID)},
+	})
+	return nil
+}
+
+// GetAutoPromotionLogs get the audit trail of the roles granted and revoked by the auto promotion
+func (as *AutoPromotionService) GetAutoPromotionLogs(ctx context.Context, req *schema.GetAutoPromotionLogsReq) (
+	pageModel *pager.PageModel, err error) {
+	logs, total, err := as.autoPromotionRepo.GetAutoPromotionLogPage(ctx, req.Page, req.PageSize, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(logs))
+	for _, promotionLog := range logs {
+		userIDs = append(userIDs, promotionLog.UserID)
+	}
+	userMapping, err := as.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	roleMapping, err := as.roleService.GetRoleMapping(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lang := handler.GetLangByCtx(ctx)
+
+	list := make([]*schema.AutoPromotionLogItem, 0, len(logs))
+	for _, promotionLog := range logs {
+		item := &schema.AutoPromotionLogItem{
+			User:      userMapping[promotionLog.UserID],
+			RoleID:    promotionLog.RoleID,
+			Action:    promotionLog.Action,
+			Detail:    promotionLog.Detail,
+			CreatedAt: promotionLog.CreatedAt.Unix(),
+		}
+		if roleInfo, ok := roleMapping[promotionLog.RoleID]; ok {
+			item.RoleName = translator.Tr(lang, role.RoleNameTrKey(roleInfo.Name))
+		}
+		list = append(list, item)
+	}
+	return pager.NewPageModel(total, list), nil
+}
+
+func sameDay(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
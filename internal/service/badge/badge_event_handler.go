@@ -22,10 +22,13 @@ package badge
 import (
 	"context"
 	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/event_queue"
+	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
+	"sync/atomic"
 )
 
 type BadgeEventService struct {
@@ -35,12 +38,14 @@ type BadgeEventService struct {
 	badgeRepo         BadgeRepo
 	eventRuleRepo     EventRuleRepo
 	badgeAwardService *BadgeAwardService
+	tagBackfilling    atomic.Bool
 }
 
 type EventRuleHandler func(ctx context.Context, event *schema.EventMsg) (awards []*entity.BadgeAward, err error)
 
 type EventRuleRepo interface {
 	HandleEventWithRule(ctx context.Context, msg *schema.EventMsg) (awards []*entity.BadgeAward)
+	ListTagAnswerScoreAwards(ctx context.Context) (awards []*entity.BadgeAward, err error)
 }
 
 func NewBadgeEventService(
@@ -75,3 +80,37 @@ func (ns *BadgeEventService) Handler(ctx context.Context, msg *schema.EventMsg)
 	}
 	return nil
 }
+
+// TriggerTagBadgeBackfill award the tag badges that users have already reached in background
+func (ns *BadgeEventService) TriggerTagBadgeBackfill(ctx context.Context) (err error) {
+	if ns.tagBackfilling.Load() {
+		return errors.BadRequest(reason.BadgeBackfillInProgress)
+	}
+	go func() {
+		if err := ns.BackfillTagBadges(context.Background()); err != nil {
+			log.Errorf("backfill tag badges failed: %v", err)
+		}
+	}()
+	return nil
+}
+
+// BackfillTagBadges award the tag badges for the answer score users have already reached in each tag
+func (ns *BadgeEventService) BackfillTagBadges(ctx context.Context) (err error) {
+	if !ns.tagBackfilling.CompareAndSwap(false, true) {
+		return errors.BadRequest(reason.BadgeBackfillInProgress)
+	}
+	defer ns.tagBackfilling.Store(false)
+
+	awards, err := ns.eventRuleRepo.ListTagAnswerScoreAwards(ctx)
+	if err != nil {
+		return err
+	}
+	for _, award := range awards {
+		err = ns.badgeAwardService.Award(ctx, award.BadgeID, award.UserID, award.AwardKey)
+		if err != nil {
+			log.Debugf("error awarding badge %s: %v", award.BadgeID, err)
+		}
+	}
+	log.Infof("backfill tag badges finished, %d awards checked", len(awards))
+	return nil
+}
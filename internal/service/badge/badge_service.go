@@ -71,6 +71,11 @@ func NewBadgeService(
 	}
 }
 
+// TriggerTagBadgeBackfill award the tag badges that users have already reached
+func (b *BadgeService) TriggerTagBadgeBackfill(ctx context.Context) (err error) {
+	return b.badgeEventService.TriggerTagBadgeBackfill(ctx)
+}
+
 // ListByGroup list all badges group by group
 func (b *BadgeService) ListByGroup(ctx context.Context, userID string) (resp []*schema.GetBadgeListResp, err error) {
 	var (
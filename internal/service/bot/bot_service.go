@@ -0,0 +1,161 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package bot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/auth"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/token"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// BotRepo bot repository
+type BotRepo interface {
+	AddBot(ctx context.Context, user *entity.User, tokenHash string) (err error)
+	GetBotToken(ctx context.Context, userID string) (botToken *entity.BotToken, exist bool, err error)
+	GetBotTokens(ctx context.Context, userIDs []string) (botTokens []*entity.BotToken, err error)
+	UpdateBotToken(ctx context.Context, userID, tokenHash string) (err error)
+	GetBotPage(ctx context.Context, page, pageSize int) (users []*entity.User, total int64, err error)
+}
+
+// BotService the bot accounts managed by admin, the bots access the api with the token instead of login
+type BotService struct {
+	botRepo         BotRepo
+	userCommon      *usercommon.UserCommon
+	authService     *auth.AuthService
+	siteInfoService siteinfo_common.SiteInfoCommonService
+}
+
+// NewBotService new bot service
+func NewBotService(
+	botRepo BotRepo,
+	userCommon *usercommon.UserCommon,
+	authService *auth.AuthService,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *BotService {
+	return &BotService{
+		botRepo:         botRepo,
+		userCommon:      userCommon,
+		authService:     authService,
+		siteInfoService: siteInfoService,
+	}
+}
+
+// AddBot add bot account, the reserved usernames are allowed, so that the system accounts can be created
+func (bs *BotService) AddBot(ctx context.Context, req *schema.AddBotReq) (resp *schema.AddBotResp, err error) {
+	_, exist, err := bs.userCommon.GetByUsername(ctx, req.Username)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		return nil, errors.BadRequest(reason.UsernameDuplicate)
+	}
+
+	botToken := generateBotToken()
+	userInfo := &entity.User{
+		Username:    req.Username,
+		DisplayName: req.DisplayName,
+		// the bot has no password and the email can't receive mails, so it can never log in
+		EMail:      fmt.Sprintf("%s@bot.invalid", req.Username),
+		MailStatus: entity.EmailStatusAvailable,
+		Status:     entity.UserStatusAvailable,
+		Rank:       1,
+		UserType:   entity.UserTypeBot,
+	}
+	if err = bs.botRepo.AddBot(ctx, userInfo, auth.HashBotToken(botToken)); err != nil {
+		return nil, err
+	}
+	return &schema.AddBotResp{
+		UserID:      userInfo.ID,
+		Username:    userInfo.Username,
+		DisplayName: userInfo.DisplayName,
+		Token:       botToken,
+	}, nil
+}
+
+// RegenerateBotToken regenerate the token of bot, the previous token is revoked
+func (bs *BotService) RegenerateBotToken(ctx context.Context, req *schema.RegenerateBotTokenReq) (
+	resp *schema.RegenerateBotTokenResp, err error) {
+	_, exist, err := bs.botRepo.GetBotToken(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.BotNotFound)
+	}
+
+	botToken := generateBotToken()
+	if err = bs.botRepo.UpdateBotToken(ctx, req.UserID, auth.HashBotToken(botToken)); err != nil {
+		return nil, err
+	}
+	// the previous token may be cached as an access token
+	bs.authService.RemoveUserAllTokens(ctx, req.UserID)
+	return &schema.RegenerateBotTokenResp{Token: botToken}, nil
+}
+
+// GetBotPage get bot page
+func (bs *BotService) GetBotPage(ctx context.Context, req *schema.GetBotPageReq) (resp *pager.PageModel, err error) {
+	users, total, err := bs.botRepo.GetBotPage(ctx, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.ID)
+	}
+	botTokens, err := bs.botRepo.GetBotTokens(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	lastUsedMapping := make(map[string]int64, len(botTokens))
+	for _, botToken := range botTokens {
+		if !botToken.LastUsedAt.IsZero() {
+			lastUsedMapping[botToken.UserID] = botToken.LastUsedAt.Unix()
+		}
+	}
+
+	avatarMapping := bs.siteInfoService.FormatListAvatar(ctx, users)
+	list := make([]*schema.BotItem, 0, len(users))
+	for _, user := range users {
+		list = append(list, &schema.BotItem{
+			UserID:      user.ID,
+			Username:    user.Username,
+			DisplayName: user.DisplayName,
+			Avatar:      avatarMapping[user.ID].GetURL(),
+			Status:      constant.ConvertUserStatus(user.Status, user.MailStatus),
+			CreatedAt:   user.CreatedAt.Unix(),
+			LastUsedAt:  lastUsedMapping[user.ID],
+		})
+	}
+	return pager.NewPageModel(total, list), nil
+}
+
+func generateBotToken() string {
+	return auth.BotTokenPrefix + token.GenerateToken()
+}
@@ -0,0 +1,149 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package cache_warming
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	questioncommon "github.com/apache/answer/internal/service/question_common"
+	"github.com/apache/answer/internal/service/question_heat"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+)
+
+// recentlyAnsweredWindow the questions answered in the window are predicted to be hot
+const recentlyAnsweredWindow = 10 * time.Minute
+
+// CacheWarmingRepo cache warming repository
+type CacheWarmingRepo interface {
+	GetRecentlyAnsweredQuestionIDs(ctx context.Context, since time.Time, limit int) (questionIDs []string, err error)
+	GetTrendingQuestionIDs(ctx context.Context, limit int) (questionIDs []string, err error)
+}
+
+// CacheWarmingService warm the caches of the questions predicted to be hot,
+// so the latency is smooth when a question receives a traffic spike
+type CacheWarmingService struct {
+	cacheWarmingRepo CacheWarmingRepo
+	heatTracker      *question_heat.QuestionHeatTracker
+	questionCommon   *questioncommon.QuestionCommon
+	siteInfoService  siteinfo_common.SiteInfoCommonService
+	running          atomic.Bool
+}
+
+// NewCacheWarmingService new cache warming service
+func NewCacheWarmingService(
+	cacheWarmingRepo CacheWarmingRepo,
+	heatTracker *question_heat.QuestionHeatTracker,
+	questionCommon *questioncommon.QuestionCommon,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *CacheWarmingService {
+	return &CacheWarmingService{
+		cacheWarmingRepo: cacheWarmingRepo,
+		heatTracker:      heatTracker,
+		questionCommon:   questionCommon,
+		siteInfoService:  siteInfoService,
+	}
+}
+
+// WarmCron warm the visitor cache of the questions whose heat score reaches the minimum, the hottest first.
+// The cron runs more often than the cache expires, so the hot questions stay cached.
+func (cs *CacheWarmingService) WarmCron(ctx context.Context) {
+	if !cs.running.CompareAndSwap(false, true) {
+		return
+	}
+	defer cs.running.Store(false)
+
+	conf, err := cs.siteInfoService.GetSiteCacheWarming(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	// the tracker is always drained to expire the old views
+	heats := cs.heatTracker.Snapshot()
+	if !conf.Enabled {
+		return
+	}
+
+	scores, err := cs.predictHeatScores(ctx, heats, conf.MaxQuestions)
+	if err != nil {
+		log.Errorf("predict question heat scores failed: %v", err)
+		return
+	}
+	questionIDs := make([]string, 0, len(scores))
+	for questionID, score := range scores {
+		if score >= conf.MinHeatScore {
+			questionIDs = append(questionIDs, questionID)
+		}
+	}
+	sort.SliceStable(questionIDs, func(i, j int) bool {
+		if scores[questionIDs[i]] != scores[questionIDs[j]] {
+			return scores[questionIDs[i]] > scores[questionIDs[j]]
+		}
+		return questionIDs[i] < questionIDs[j]
+	})
+	questionIDs = questionIDs[:min(len(questionIDs), conf.MaxQuestions)]
+
+	// the visitors mostly use the default language of site
+	if siteInterface, err := cs.siteInfoService.GetSiteInterface(ctx); err == nil && len(siteInterface.Language) > 0 {
+		ctx = context.WithValue(ctx, constant.AcceptLanguageFlag, i18n.Language(siteInterface.Language))
+	}
+	for _, questionID := range questionIDs {
+		if err = cs.questionCommon.WarmVisitorInfoCache(ctx, questionID); err != nil {
+			log.Warnf("warm visitor cache of question %s failed: %v", questionID, err)
+		}
+	}
+	if len(questionIDs) > 0 {
+		log.Infof("warmed visitor cache of %d hot questions", len(questionIDs))
+	}
+}
+
+// predictHeatScores the heat score is the view velocity, plus one for each signal of
+// recently answered, trending and externally linked
+func (cs *CacheWarmingService) predictHeatScores(ctx context.Context, heats []*question_heat.QuestionHeat, limit int) (
+	scores map[string]float64, err error) {
+	scores = make(map[string]float64, len(heats))
+	for _, heat := range heats {
+		scores[heat.QuestionID] = heat.ViewVelocity
+		if heat.ExternallyLinked {
+			scores[heat.QuestionID]++
+		}
+	}
+	answeredIDs, err := cs.cacheWarmingRepo.GetRecentlyAnsweredQuestionIDs(ctx,
+		time.Now().Add(-recentlyAnsweredWindow), limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, questionID := range answeredIDs {
+		scores[questionID]++
+	}
+	trendingIDs, err := cs.cacheWarmingRepo.GetTrendingQuestionIDs(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, questionID := range trendingIDs {
+		scores[questionID]++
+	}
+	return scores, nil
+}
@@ -0,0 +1,473 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package chat_intake
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
+	"github.com/apache/answer/internal/base/validator"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/content"
+	"github.com/apache/answer/internal/service/permission"
+	"github.com/apache/answer/internal/service/rank"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/display"
+	myErrors "github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+)
+
+// slackRequestMaxAge the request whose timestamp is older than this is rejected to prevent replay attacks
+const slackRequestMaxAge = 5 * time.Minute
+
+// ChatIntakeService create or search questions from chat, such as Slack and Mattermost
+type ChatIntakeService struct {
+	siteInfoService siteinfo_common.SiteInfoCommonService
+	userCommon      *usercommon.UserCommon
+	questionService *content.QuestionService
+	searchService   *content.SearchService
+	rankService     *rank.RankService
+	httpClient      *http.Client
+}
+
+// NewChatIntakeService new chat intake service
+func NewChatIntakeService(
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	userCommon *usercommon.UserCommon,
+	questionService *content.QuestionService,
+	searchService *content.SearchService,
+	rankService *rank.RankService,
+) *ChatIntakeService {
+	return &ChatIntakeService{
+		siteInfoService: siteInfoService,
+		userCommon:      userCommon,
+		questionService: questionService,
+		searchService:   searchService,
+		rankService:     rankService,
+		httpClient:      &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// VerifySlackRequest verify the signature of slack request with the signing secret
+func (cs *ChatIntakeService) VerifySlackRequest(ctx context.Context, timestamp, signature string, body []byte) (err error) {
+	conf, err := cs.siteInfoService.GetSiteChatIntake(ctx)
+	if err != nil {
+		return err
+	}
+	if !conf.Enabled || len(conf.SlackSigningSecret) == 0 {
+		return myErrors.Forbidden(reason.ChatIntakeDisabled)
+	}
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return myErrors.Unauthorized(reason.ChatIntakeSignatureInvalid)
+	}
+	if age := time.Since(time.Unix(ts, 0)); age > slackRequestMaxAge || age < -slackRequestMaxAge {
+		return myErrors.Unauthorized(reason.ChatIntakeSignatureInvalid)
+	}
+	mac := hmac.New(sha256.New, []byte(conf.SlackSigningSecret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return myErrors.Unauthorized(reason.ChatIntakeSignatureInvalid)
+	}
+	return nil
+}
+
+// VerifyMattermostRequest verify the token of mattermost slash command
+func (cs *ChatIntakeService) VerifyMattermostRequest(ctx context.Context, token string) (err error) {
+	conf, err := cs.siteInfoService.GetSiteChatIntake(ctx)
+	if err != nil {
+		return err
+	}
+	if !conf.Enabled || len(conf.MattermostToken) == 0 {
+		return myErrors.Forbidden(reason.ChatIntakeDisabled)
+	}
+	if subtle.ConstantTimeCompare([]byte(conf.MattermostToken), []byte(token)) != 1 {
+		return myErrors.Unauthorized(reason.ChatIntakeSignatureInvalid)
+	}
+	return nil
+}
+
+// HandleCommand handle the slash command.
+// `search <keywords>` search the existing questions, `ask <title> | <content> #tag` or just the text create a question.
+// The failure is replied as an ephemeral message, so the user can see why.
+func (cs *ChatIntakeService) HandleCommand(ctx context.Context, req *schema.ChatCommandReq) (resp *schema.ChatCommandResp) {
+	text := strings.TrimSpace(req.Text)
+	action, args, _ := strings.Cut(text, " ")
+	action = strings.ToLower(action)
+	args = strings.TrimSpace(args)
+
+	switch {
+	case len(text) == 0 || action == "help":
+		return schema.NewChatEphemeralResp(cs.tr(ctx, reason.ChatIntakeHelp, map[string]string{"Command": req.Command}))
+	case action == "search":
+		return cs.search(ctx, req.Platform, args)
+	case action == "ask":
+		text = args
+	}
+
+	title, body, tags := parseQuestionText(text)
+	userInfo, err := cs.getAnswerUser(ctx, req.Platform, req.UserID)
+	if err != nil {
+		return schema.NewChatEphemeralResp(cs.trError(ctx, err))
+	}
+	ctx = context.WithValue(ctx, constant.AcceptLanguageFlag, cs.getUserLang(ctx, userInfo))
+	questionURL, title, err := cs.createQuestion(ctx, userInfo, title, body, tags)
+	if err != nil {
+		return schema.NewChatEphemeralResp(cs.trError(ctx, err))
+	}
+	return schema.NewChatInChannelResp(cs.tr(ctx, reason.ChatIntakeQuestionCreated, map[string]string{
+		"User": req.UserName,
+		"Link": formatLink(req.Platform, questionURL, title),
+	}))
+}
+
+// HandleSlackAction handle the slack message action, the message is used to create a question.
+// The result is posted to the response url of action, because the request must be acknowledged immediately.
+func (cs *ChatIntakeService) HandleSlackAction(ctx context.Context, payload *schema.SlackActionPayload) {
+	resp := cs.createQuestionFromMessage(ctx, payload)
+	if len(payload.ResponseURL) == 0 {
+		return
+	}
+	go func() {
+		if err := cs.postResponse(context.Background(), payload.ResponseURL, resp); err != nil {
+			log.Errorf("post chat intake response failed: %v", err)
+		}
+	}()
+}
+
+func (cs *ChatIntakeService) createQuestionFromMessage(ctx context.Context, payload *schema.SlackActionPayload) (
+	resp *schema.ChatCommandResp) {
+	userInfo, err := cs.getAnswerUser(ctx, schema.ChatPlatformSlack, payload.User.ID)
+	if err != nil {
+		return schema.NewChatEphemeralResp(cs.trError(ctx, err))
+	}
+	ctx = context.WithValue(ctx, constant.AcceptLanguageFlag, cs.getUserLang(ctx, userInfo))
+	message := strings.TrimSpace(payload.Message.Text)
+	title, _, _ := strings.Cut(message, "\n")
+	title, _, tags := parseQuestionText(title)
+	questionURL, title, err := cs.createQuestion(ctx, userInfo, title, message, tags)
+	if err != nil {
+		return schema.NewChatEphemeralResp(cs.trError(ctx, err))
+	}
+	return schema.NewChatInChannelResp(cs.tr(ctx, reason.ChatIntakeQuestionCreated, map[string]string{
+		"User": payload.User.Name,
+		"Link": formatLink(schema.ChatPlatformSlack, questionURL, title),
+	}))
+}
+
+func (cs *ChatIntakeService) search(ctx context.Context, platform, keywords string) (resp *schema.ChatCommandResp) {
+	if len(keywords) == 0 {
+		return schema.NewChatEphemeralResp(cs.tr(ctx, reason.ChatIntakeNoResult, nil))
+	}
+	dto := &schema.SearchDTO{
+		Query: "is:question " + keywords,
+		Page:  1,
+		Size:  schema.ChatSearchResultLimit,
+		Order: "relevance",
+	}
+	if _, err := dto.Check(); err != nil {
+		return schema.NewChatEphemeralResp(cs.trError(ctx, err))
+	}
+	result, err := cs.searchService.Search(ctx, dto)
+	if err != nil {
+		log.Error(err)
+		return schema.NewChatEphemeralResp(cs.trError(ctx, err))
+	}
+	if len(result.SearchResults) == 0 {
+		return schema.NewChatEphemeralResp(cs.tr(ctx, reason.ChatIntakeNoResult, nil))
+	}
+	siteGeneral, err := cs.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return schema.NewChatEphemeralResp(cs.trError(ctx, err))
+	}
+	permalink := cs.getPermalink(ctx)
+	lines := make([]string, 0, len(result.SearchResults))
+	for _, item := range result.SearchResults {
+		if item.Object == nil {
+			continue
+		}
+		link := display.QuestionURL(permalink, siteGeneral.SiteUrl, item.Object.QuestionID, item.Object.Title)
+		lines = append(lines, fmt.Sprintf("• %s (%d)", formatLink(platform, link, item.Object.Title), item.Object.AnswerCount))
+	}
+	return schema.NewChatEphemeralResp(strings.Join(lines, "\n"))
+}
+
+// createQuestion create question as the user, the permission is checked in the same way as the web
+func (cs *ChatIntakeService) createQuestion(ctx context.Context, userInfo *entity.User, title, body string, tagNames []string) (
+	questionURL, questionTitle string, err error) {
+	conf, err := cs.siteInfoService.GetSiteChatIntake(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	if len(tagNames) == 0 {
+		tagNames = conf.DefaultTags
+	}
+	if len(body) == 0 {
+		body = title
+	}
+	req := &schema.QuestionAdd{
+		Title:   title,
+		Content: body,
+		Tags:    make([]*schema.TagItem, 0, len(tagNames)),
+		UserID:  userInfo.ID,
+	}
+	for _, name := range tagNames {
+		req.Tags = append(req.Tags, &schema.TagItem{SlugName: name, DisplayName: name})
+	}
+
+	canList, requireRanks, err := cs.rankService.CheckOperationPermissionsForRanks(ctx, userInfo.ID, []string{
+		permission.QuestionAdd,
+		permission.TagUseReservedTag,
+		permission.TagAdd,
+	})
+	if err != nil {
+		return "", "", err
+	}
+	if !canList[0] {
+		return "", "", myErrors.Forbidden(reason.RankFailToMeetTheCondition)
+	}
+	req.CanAdd = canList[0]
+	req.CanUseReservedTag = canList[1]
+	req.CanAddTag = canList[2]
+
+	if errFields, err := validator.GetValidatorByLang(handler.GetLangByCtx(ctx)).Check(req); err != nil {
+		if len(errFields) > 0 {
+			return "", "", myErrors.BadRequest(reason.RequestFormatError).WithMsg(errFields[0].ErrorMsg)
+		}
+		return "", "", err
+	}
+	hasNewTag, err := cs.questionService.HasNewTag(ctx, req.Tags)
+	if err != nil {
+		return "", "", err
+	}
+	if !req.CanAddTag && hasNewTag {
+		msg := translator.TrWithData(handler.GetLangByCtx(ctx), reason.NoEnoughRankToOperate,
+			&schema.PermissionTrTplData{Rank: requireRanks[2]})
+		return "", "", myErrors.Forbidden(reason.NoEnoughRankToOperate).WithMsg(msg)
+	}
+
+	resp, err := cs.questionService.AddQuestion(ctx, req)
+	if err != nil {
+		if errFields, ok := resp.([]*validator.FormErrorField); ok && len(errFields) > 0 {
+			return "", "", myErrors.BadRequest(reason.RequestFormatError).WithMsg(errFields[0].ErrorMsg)
+		}
+		return "", "", err
+	}
+	questionInfo, ok := resp.(*schema.QuestionInfoResp)
+	if !ok {
+		return "", "", myErrors.InternalServer(reason.UnknownError)
+	}
+	siteGeneral, err := cs.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	questionURL = display.QuestionURL(cs.getPermalink(ctx), siteGeneral.SiteUrl, questionInfo.ID, questionInfo.Title)
+	return questionURL, questionInfo.Title, nil
+}
+
+// getAnswerUser get the Answer account of chat user by the email of chat user
+func (cs *ChatIntakeService) getAnswerUser(ctx context.Context, platform, chatUserID string) (
+	userInfo *entity.User, err error) {
+	conf, err := cs.siteInfoService.GetSiteChatIntake(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var email string
+	switch platform {
+	case schema.ChatPlatformSlack:
+		email, err = cs.getSlackUserEmail(ctx, conf, chatUserID)
+	case schema.ChatPlatformMattermost:
+		email, err = cs.getMattermostUserEmail(ctx, conf, chatUserID)
+	default:
+		err = fmt.Errorf("unknown chat platform %s", platform)
+	}
+	if err != nil {
+		log.Errorf("get email of chat user %s failed: %v", chatUserID, err)
+		return nil, myErrors.NotFound(reason.ChatIntakeUserNotFound)
+	}
+	userInfo, exist, err := cs.userCommon.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || userInfo.Status != entity.UserStatusAvailable || userInfo.MailStatus != entity.EmailStatusAvailable {
+		return nil, myErrors.NotFound(reason.ChatIntakeUserNotFound)
+	}
+	return userInfo, nil
+}
+
+func (cs *ChatIntakeService) getSlackUserEmail(ctx context.Context, conf *schema.SiteChatIntakeResp, chatUserID string) (
+	email string, err error) {
+	if len(conf.SlackBotToken) == 0 {
+		return "", errors.New("slack bot token is not set")
+	}
+	result := &struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+		User  struct {
+			Profile struct {
+				Email string `json:"email"`
+			} `json:"profile"`
+		} `json:"user"`
+	}{}
+	apiURL := "https://slack.com/api/users.info?user=" + url.QueryEscape(chatUserID)
+	if err = cs.getJSON(ctx, apiURL, conf.SlackBotToken, result); err != nil {
+		return "", err
+	}
+	if !result.OK {
+		return "", fmt.Errorf("slack api error: %s", result.Error)
+	}
+	return result.User.Profile.Email, nil
+}
+
+func (cs *ChatIntakeService) getMattermostUserEmail(ctx context.Context, conf *schema.SiteChatIntakeResp, chatUserID string) (
+	email string, err error) {
+	if len(conf.MattermostURL) == 0 || len(conf.MattermostBotToken) == 0 {
+		return "", errors.New("mattermost url or bot token is not set")
+	}
+	result := &struct {
+		Email string `json:"email"`
+	}{}
+	apiURL := strings.TrimSuffix(conf.MattermostURL, "/") + "/api/v4/users/" + url.PathEscape(chatUserID)
+	if err = cs.getJSON(ctx, apiURL, conf.MattermostBotToken, result); err != nil {
+		return "", err
+	}
+	return result.Email, nil
+}
+
+func (cs *ChatIntakeService) getJSON(ctx context.Context, apiURL, token string, result any) (err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := cs.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("response status %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+func (cs *ChatIntakeService) postResponse(ctx context.Context, responseURL string, data *schema.ChatCommandResp) (err error) {
+	body, _ := json.Marshal(data)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, responseURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := cs.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("response status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (cs *ChatIntakeService) getPermalink(ctx context.Context) int {
+	seoInfo, err := cs.siteInfoService.GetSiteSeo(ctx)
+	if err != nil {
+		log.Error(err)
+		return constant.PermalinkQuestionID
+	}
+	return seoInfo.Permalink
+}
+
+func (cs *ChatIntakeService) getUserLang(ctx context.Context, userInfo *entity.User) i18n.Language {
+	if len(userInfo.Language) > 0 && userInfo.Language != translator.DefaultLangOption {
+		return i18n.Language(userInfo.Language)
+	}
+	interfaceInfo, err := cs.siteInfoService.GetSiteInterface(ctx)
+	if err != nil {
+		return i18n.DefaultLanguage
+	}
+	return i18n.Language(interfaceInfo.Language)
+}
+
+func (cs *ChatIntakeService) tr(ctx context.Context, key string, data any) string {
+	if data == nil {
+		return translator.Tr(handler.GetLangByCtx(ctx), key)
+	}
+	return translator.TrWithData(handler.GetLangByCtx(ctx), key, data)
+}
+
+func (cs *ChatIntakeService) trError(ctx context.Context, err error) string {
+	var myErr *myErrors.Error
+	if !errors.As(err, &myErr) {
+		return translator.Tr(handler.GetLangByCtx(ctx), reason.UnknownError)
+	}
+	if myErrors.IsInternalServer(myErr) {
+		log.Error(myErr)
+	}
+	if len(myErr.Message) > 0 {
+		return myErr.Message
+	}
+	return translator.Tr(handler.GetLangByCtx(ctx), myErr.Reason)
+}
+
+// parseQuestionText parse the text as `title | content #tag1 #tag2`, the tags can be anywhere
+func parseQuestionText(text string) (title, body string, tags []string) {
+	words := strings.Fields(text)
+	remain := make([]string, 0, len(words))
+	for _, word := range words {
+		if len(word) > 1 && strings.HasPrefix(word, "#") {
+			tags = append(tags, strings.ToLower(strings.TrimPrefix(word, "#")))
+			continue
+		}
+		remain = append(remain, word)
+	}
+	title, body, _ = strings.Cut(strings.Join(remain, " "), "|")
+	return strings.TrimSpace(title), strings.TrimSpace(body), tags
+}
+
+// formatLink format the link in the markup of chat platform
+func formatLink(platform, link, title string) string {
+	if platform == schema.ChatPlatformSlack {
+		title = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(title)
+		return fmt.Sprintf("<%s|%s>", link, title)
+	}
+	return fmt.Sprintf("[%s](%s)", strings.NewReplacer("[", "\\[", "]", "\\]").Replace(title), link)
+}
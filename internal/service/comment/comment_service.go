@@ -31,12 +31,16 @@ import (
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/activity_common"
 	"github.com/apache/answer/internal/service/activity_queue"
+	"github.com/apache/answer/internal/service/audit_log"
 	"github.com/apache/answer/internal/service/comment_common"
 	"github.com/apache/answer/internal/service/export"
 	"github.com/apache/answer/internal/service/notice_queue"
 	"github.com/apache/answer/internal/service/object_info"
 	"github.com/apache/answer/internal/service/permission"
+	"github.com/apache/answer/internal/service/review"
+	"github.com/apache/answer/internal/service/space_common"
 	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/converter"
 	"github.com/apache/answer/pkg/htmltext"
 	"github.com/apache/answer/pkg/token"
 	"github.com/apache/answer/pkg/uid"
@@ -49,6 +53,8 @@ import (
 type CommentRepo interface {
 	AddComment(ctx context.Context, comment *entity.Comment) (err error)
 	RemoveComment(ctx context.Context, commentID string) (err error)
+	RemoveComments(ctx context.Context, commentIDs []string) (err error)
+	GetCommentsByFilter(ctx context.Context, filter *CommentFilter, limit int) (comments []*entity.Comment, err error)
 	UpdateCommentContent(ctx context.Context, commentID string, original string, parsedText string) (err error)
 	GetComment(ctx context.Context, commentID string) (comment *entity.Comment, exist bool, err error)
 	GetCommentPage(ctx context.Context, commentQuery *CommentQuery) (
@@ -63,6 +69,17 @@ type CommentQuery struct {
 	QueryCond string
 	// user id
 	UserID string
+	// the comments of the shadow-banned users are only visible to the authors, unless IncludeShadow is true
+	LoginUserID   string
+	IncludeShadow bool
+}
+
+// CommentFilter the filter of comments, the zero value fields are ignored
+type CommentFilter struct {
+	UserID    string
+	StartTime time.Time
+	EndTime   time.Time
+	Keyword   string
 }
 
 func (c *CommentQuery) GetOrderBy() string {
@@ -88,6 +105,9 @@ type CommentService struct {
 	externalNotificationQueueService notice_queue.ExternalNotificationQueueService
 	activityQueueService             activity_queue.ActivityQueueService
 	eventQueueService                event_queue.EventQueueService
+	spaceCommon                      *space_common.SpaceCommon
+	auditLogService                  *audit_log.AuditLogService
+	reviewService                    *review.ReviewService
 }
 
 // NewCommentService new comment service
@@ -103,8 +123,11 @@ func NewCommentService(
 	externalNotificationQueueService notice_queue.ExternalNotificationQueueService,
 	activityQueueService activity_queue.ActivityQueueService,
 	eventQueueService event_queue.EventQueueService,
+	spaceCommon *space_common.SpaceCommon,
+	auditLogService *audit_log.AuditLogService,
+	reviewService *review.ReviewService,
 ) *CommentService {
-	return &CommentService{
+	cs := &CommentService{
 		commentRepo:                      commentRepo,
 		commentCommonRepo:                commentCommonRepo,
 		userCommon:                       userCommon,
@@ -116,7 +139,24 @@ func NewCommentService(
 		externalNotificationQueueService: externalNotificationQueueService,
 		activityQueueService:             activityQueueService,
 		eventQueueService:                eventQueueService,
+		spaceCommon:                      spaceCommon,
+		auditLogService:                  auditLogService,
+		reviewService:                    reviewService,
+	}
+	reviewService.RegisterCommentApprovedHandler(cs.handleCommentApproved)
+	return cs
+}
+
+// canViewObject whether the user can view the object, the object of the private space can not be viewed by others
+func (cs *CommentService) canViewObject(ctx context.Context, objectID, userID string) (can bool, err error) {
+	objInfo, err := cs.objectInfoService.GetInfo(ctx, objectID)
+	if err != nil {
+		return false, err
 	}
+	if len(objInfo.QuestionID) == 0 {
+		return true, nil
+	}
+	return cs.spaceCommon.CanViewQuestion(ctx, objInfo.QuestionID, userID)
 }
 
 // AddComment add comment
@@ -125,6 +165,7 @@ func (cs *CommentService) AddComment(ctx context.Context, req *schema.AddComment
 	comment := &entity.Comment{}
 	_ = copier.Copy(comment, req)
 	comment.Status = entity.CommentStatusAvailable
+	comment.Shadow = cs.userCommon.IsShadowBanned(ctx, req.UserID)
 
 	objInfo, err := cs.objectInfoService.GetInfo(ctx, req.ObjectID)
 	if err != nil {
@@ -155,19 +196,33 @@ func (cs *CommentService) AddComment(ctx context.Context, req *schema.AddComment
 		comment.SetReplyCommentID("")
 	}
 
+	if req.CodeAnchor != nil {
+		if err = cs.setCodeAnchor(ctx, comment, req.CodeAnchor, objInfo); err != nil {
+			return nil, err
+		}
+	}
+
 	err = cs.commentRepo.AddComment(ctx, comment)
 	if err != nil {
 		return nil, err
 	}
 
 	resp = &schema.GetCommentResp{}
+	// the comment marked as spam is pending until it is approved by the moderator
+	if commentStatus := cs.reviewService.AddCommentReview(ctx, comment, req.IP, req.UserAgent); commentStatus != comment.Status {
+		if err = cs.commentCommonRepo.UpdateCommentStatus(ctx, comment.ID, commentStatus); err != nil {
+			return nil, err
+		}
+		comment.Status = commentStatus
+		resp.SetFromComment(comment)
+		return resp, nil
+	}
 	resp.SetFromComment(comment)
 	resp.MemberActions = permission.GetCommentPermission(ctx, req.UserID, resp.UserID,
 		time.Now(), req.CanEdit, req.CanDelete)
 
-	commentResp, err := cs.addCommentNotification(ctx, req, resp, comment, objInfo)
-	if err != nil {
-		return commentResp, err
+	if err = cs.sendCommentCreatedMessages(ctx, resp, comment, objInfo, req.MentionUsernameList); err != nil {
+		return nil, err
 	}
 
 	// get user info
@@ -181,32 +236,57 @@ func (cs *CommentService) AddComment(ctx context.Context, req *schema.AddComment
 		resp.UserAvatar = userInfo.Avatar
 		resp.UserStatus = userInfo.Status
 	}
+	return resp, nil
+}
+
+// sendCommentCreatedMessages send the notifications, activity and event of the available new comment,
+// the comment pending for review sends them after it is approved
+func (cs *CommentService) sendCommentCreatedMessages(ctx context.Context, resp *schema.GetCommentResp,
+	comment *entity.Comment, objInfo *schema.SimpleObjectInfo, mentionUsernameList []string) (err error) {
+	if _, err = cs.addCommentNotification(ctx, resp, comment, objInfo, mentionUsernameList); err != nil {
+		return err
+	}
 
 	activityMsg := &schema.ActivityMsg{
 		UserID:           comment.UserID,
 		ObjectID:         comment.ID,
-		OriginalObjectID: req.ObjectID,
+		OriginalObjectID: comment.ObjectID,
 		ActivityTypeKey:  constant.ActQuestionCommented,
 	}
 	var event *schema.EventMsg
 	switch objInfo.ObjectType {
 	case constant.QuestionObjectType:
 		activityMsg.ActivityTypeKey = constant.ActQuestionCommented
-		event = schema.NewEvent(constant.EventCommentCreate, req.UserID).TID(comment.ID).
+		event = schema.NewEvent(constant.EventCommentCreate, comment.UserID).TID(comment.ID).
 			CID(comment.ID, comment.UserID).QID(objInfo.QuestionID, objInfo.ObjectCreatorUserID)
 	case constant.AnswerObjectType:
 		activityMsg.ActivityTypeKey = constant.ActAnswerCommented
-		event = schema.NewEvent(constant.EventCommentCreate, req.UserID).TID(comment.ID).
+		event = schema.NewEvent(constant.EventCommentCreate, comment.UserID).TID(comment.ID).
 			CID(comment.ID, comment.UserID).AID(objInfo.AnswerID, objInfo.ObjectCreatorUserID)
 	}
 	cs.activityQueueService.Send(ctx, activityMsg)
 	cs.eventQueueService.Send(ctx, event)
-	return resp, nil
+	return nil
+}
+
+// handleCommentApproved send the messages of the comment approved by the moderator,
+// the mentioned users are not saved with the comment so the mention notifications are not sent
+func (cs *CommentService) handleCommentApproved(ctx context.Context, comment *entity.Comment) (err error) {
+	objInfo, err := cs.objectInfoService.GetInfo(ctx, comment.ObjectID)
+	if err != nil {
+		return err
+	}
+	objInfo.ObjectID = uid.DeShortID(objInfo.ObjectID)
+	objInfo.QuestionID = uid.DeShortID(objInfo.QuestionID)
+	objInfo.AnswerID = uid.DeShortID(objInfo.AnswerID)
+	resp := &schema.GetCommentResp{}
+	resp.SetFromComment(comment)
+	return cs.sendCommentCreatedMessages(ctx, resp, comment, objInfo, nil)
 }
 
 func (cs *CommentService) addCommentNotification(
-	ctx context.Context, req *schema.AddCommentReq, resp *schema.GetCommentResp,
-	comment *entity.Comment, objInfo *schema.SimpleObjectInfo) (*schema.GetCommentResp, error) {
+	ctx context.Context, resp *schema.GetCommentResp, comment *entity.Comment,
+	objInfo *schema.SimpleObjectInfo, mentionUsernameList []string) (*schema.GetCommentResp, error) {
 	// The priority of the notification
 	// 1. reply to user
 	// 2. comment mention to user
@@ -214,7 +294,7 @@ func (cs *CommentService) addCommentNotification(
 	alreadyNotifiedUserID := make(map[string]bool)
 
 	// get reply user info
-	if len(resp.ReplyUserID) > 0 && resp.ReplyUserID != req.UserID {
+	if len(resp.ReplyUserID) > 0 && resp.ReplyUserID != comment.UserID {
 		replyUser, exist, err := cs.userCommon.GetUserBasicInfoByID(ctx, resp.ReplyUserID)
 		if err != nil {
 			return nil, err
@@ -224,15 +304,15 @@ func (cs *CommentService) addCommentNotification(
 			resp.ReplyUserDisplayName = replyUser.DisplayName
 			resp.ReplyUserStatus = replyUser.Status
 		}
-		cs.notificationCommentReply(ctx, replyUser.ID, comment.ID, req.UserID,
+		cs.notificationCommentReply(ctx, replyUser.ID, comment.ID, comment.UserID,
 			objInfo.QuestionID, objInfo.Title, htmltext.FetchExcerpt(comment.ParsedText, "...", 240))
 		alreadyNotifiedUserID[replyUser.ID] = true
 		return nil, nil
 	}
 
-	if len(req.MentionUsernameList) > 0 {
+	if len(mentionUsernameList) > 0 {
 		alreadyNotifiedUserIDs := cs.notificationMention(
-			ctx, req.MentionUsernameList, comment.ID, req.UserID, alreadyNotifiedUserID)
+			ctx, mentionUsernameList, comment.ID, comment.UserID, alreadyNotifiedUserID)
 		for _, userID := range alreadyNotifiedUserIDs {
 			alreadyNotifiedUserID[userID] = true
 		}
@@ -241,25 +321,105 @@ func (cs *CommentService) addCommentNotification(
 
 	if objInfo.ObjectType == constant.QuestionObjectType && !alreadyNotifiedUserID[objInfo.ObjectCreatorUserID] {
 		cs.notificationQuestionComment(ctx, objInfo.ObjectCreatorUserID,
-			objInfo.QuestionID, objInfo.Title, comment.ID, req.UserID, htmltext.FetchExcerpt(comment.ParsedText, "...", 240))
+			objInfo.QuestionID, objInfo.Title, comment.ID, comment.UserID, htmltext.FetchExcerpt(comment.ParsedText, "...", 240))
 	} else if objInfo.ObjectType == constant.AnswerObjectType && !alreadyNotifiedUserID[objInfo.ObjectCreatorUserID] {
 		cs.notificationAnswerComment(ctx, objInfo.QuestionID, objInfo.Title, objInfo.AnswerID,
-			objInfo.ObjectCreatorUserID, comment.ID, req.UserID, htmltext.FetchExcerpt(comment.ParsedText, "...", 240))
+			objInfo.ObjectCreatorUserID, comment.ID, comment.UserID, htmltext.FetchExcerpt(comment.ParsedText, "...", 240))
 	}
 	return nil, nil
 }
 
 // RemoveComment delete comment
 func (cs *CommentService) RemoveComment(ctx context.Context, req *schema.RemoveCommentReq) (err error) {
+	comment, exist, err := cs.commentCommonRepo.GetComment(ctx, req.CommentID)
+	if err != nil {
+		return err
+	}
 	err = cs.commentRepo.RemoveComment(ctx, req.CommentID)
 	if err != nil {
 		return err
 	}
+	// only the deletion of others' comment is privileged
+	if exist && comment.UserID != req.UserID {
+		cs.auditLogService.Record(ctx, &schema.AuditLogRecord{
+			OperatorID: req.UserID,
+			Action:     entity.AuditLogActionCommentDelete,
+			ObjectType: entity.AuditLogObjectTypeComment,
+			ObjectID:   comment.ID,
+			Before:     map[string]string{"object_id": comment.ObjectID, "user_id": comment.UserID},
+		})
+	}
 	cs.eventQueueService.Send(ctx, schema.NewEvent(constant.EventCommentDelete, req.UserID).
 		TID(req.CommentID).CID(req.CommentID, req.UserID))
 	return nil
 }
 
+// AdminBulkRemoveComment admin remove the comments matched the filter in one operation
+func (cs *CommentService) AdminBulkRemoveComment(ctx context.Context, req *schema.AdminBulkRemoveCommentReq) (
+	resp *schema.AdminBulkRemoveCommentResp, err error) {
+	if len(req.Username) == 0 && req.StartTime == 0 && req.EndTime == 0 && len(req.Keyword) == 0 {
+		return nil, errors.BadRequest(reason.CommentBulkRemoveFilterRequired)
+	}
+	filter := &CommentFilter{Keyword: req.Keyword}
+	if len(req.Username) > 0 {
+		userInfo, exist, err := cs.userCommon.GetUserBasicInfoByUserName(ctx, req.Username)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			return nil, errors.BadRequest(reason.UserNotFound)
+		}
+		filter.UserID = userInfo.ID
+	}
+	if req.StartTime > 0 {
+		filter.StartTime = time.Unix(req.StartTime, 0)
+	}
+	if req.EndTime > 0 {
+		filter.EndTime = time.Unix(req.EndTime, 0)
+	}
+
+	// get one more comment to check whether there are more matched comments
+	comments, err := cs.commentRepo.GetCommentsByFilter(ctx, filter, schema.AdminBulkRemoveCommentMaxCount+1)
+	if err != nil {
+		return nil, err
+	}
+	resp = &schema.AdminBulkRemoveCommentResp{}
+	if len(comments) > schema.AdminBulkRemoveCommentMaxCount {
+		comments = comments[:schema.AdminBulkRemoveCommentMaxCount]
+		resp.HasMore = true
+	}
+	resp.Count = len(comments)
+	if req.DryRun || len(comments) == 0 {
+		return resp, nil
+	}
+
+	commentIDs := make([]string, 0, len(comments))
+	for _, c := range comments {
+		commentIDs = append(commentIDs, c.ID)
+	}
+	if err = cs.commentRepo.RemoveComments(ctx, commentIDs); err != nil {
+		return nil, err
+	}
+	for _, c := range comments {
+		cs.eventQueueService.Send(ctx, schema.NewEvent(constant.EventCommentDelete, req.UserID).
+			TID(c.ID).CID(c.ID, c.UserID))
+	}
+	log.Infof("admin %s bulk removed %d comments", req.UserID, len(commentIDs))
+	cs.auditLogService.Record(ctx, &schema.AuditLogRecord{
+		OperatorID: req.UserID,
+		Action:     entity.AuditLogActionCommentBulk,
+		ObjectType: entity.AuditLogObjectTypeComment,
+		After: map[string]any{
+			"username":   req.Username,
+			"start_time": req.StartTime,
+			"end_time":   req.EndTime,
+			"keyword":    req.Keyword,
+			"count":      len(commentIDs),
+		},
+	})
+	return resp, nil
+}
+
 // UpdateComment update comment
 func (cs *CommentService) UpdateComment(ctx context.Context, req *schema.UpdateCommentReq) (
 	resp *schema.UpdateCommentResp, err error) {
@@ -295,6 +455,47 @@ func (cs *CommentService) UpdateComment(ctx context.Context, req *schema.UpdateC
 }
 
 // GetComment get comment one
+// setCodeAnchor anchor the comment to a line range of code block in question or answer
+func (cs *CommentService) setCodeAnchor(ctx context.Context, comment *entity.Comment,
+	anchor *schema.CommentCodeAnchorReq, objInfo *schema.SimpleObjectInfo) error {
+	if objInfo.ObjectType != constant.QuestionObjectType && objInfo.ObjectType != constant.AnswerObjectType {
+		return errors.BadRequest(reason.CommentCodeAnchorInvalid)
+	}
+	originalText, err := cs.objectInfoService.GetOriginalText(ctx, objInfo.ObjectID)
+	if err != nil {
+		return err
+	}
+	excerpt, ok := anchor.Excerpt(converter.ExtractCodeBlocks(originalText))
+	if !ok {
+		return errors.BadRequest(reason.CommentCodeAnchorInvalid)
+	}
+	comment.CodeBlockIndex = anchor.BlockIndex
+	comment.CodeLineStart = anchor.LineStart
+	comment.CodeLineEnd = anchor.LineEnd
+	comment.CodeExcerpt = excerpt
+	return nil
+}
+
+// relocateCodeAnchors the post may be edited after commented, so the code anchors should be relocated
+func (cs *CommentService) relocateCodeAnchors(ctx context.Context, objectID string, comments []*schema.GetCommentResp) {
+	var codeBlocks []string
+	loaded := false
+	for _, comment := range comments {
+		if comment.CodeAnchor == nil {
+			continue
+		}
+		if !loaded {
+			loaded = true
+			originalText, err := cs.objectInfoService.GetOriginalText(ctx, objectID)
+			if err != nil {
+				log.Errorf("get original text of %s failed: %v", objectID, err)
+			}
+			codeBlocks = converter.ExtractCodeBlocks(originalText)
+		}
+		comment.CodeAnchor.Relocate(codeBlocks)
+	}
+}
+
 func (cs *CommentService) GetComment(ctx context.Context, req *schema.GetCommentReq) (resp *schema.GetCommentResp, err error) {
 	comment, exist, err := cs.commentCommonRepo.GetComment(ctx, req.ID)
 	if err != nil {
@@ -303,6 +504,16 @@ func (cs *CommentService) GetComment(ctx context.Context, req *schema.GetComment
 	if !exist {
 		return nil, errors.BadRequest(reason.CommentNotFound)
 	}
+	if comment.Shadow && comment.UserID != req.UserID && !req.CanDelete {
+		return nil, errors.BadRequest(reason.CommentNotFound)
+	}
+	canView, err := cs.canViewObject(ctx, comment.ObjectID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, errors.BadRequest(reason.CommentNotFound)
+	}
 
 	resp = &schema.GetCommentResp{
 		CommentID:      comment.ID,
@@ -314,7 +525,9 @@ func (cs *CommentService) GetComment(ctx context.Context, req *schema.GetComment
 		VoteCount:      comment.VoteCount,
 		OriginalText:   comment.OriginalText,
 		ParsedText:     comment.ParsedText,
+		CodeAnchor:     schema.NewCommentCodeAnchorResp(comment),
 	}
+	cs.relocateCodeAnchors(ctx, comment.ObjectID, []*schema.GetCommentResp{resp})
 
 	// get comment user info
 	if len(resp.UserID) > 0 {
@@ -354,10 +567,19 @@ func (cs *CommentService) GetComment(ctx context.Context, req *schema.GetComment
 // GetCommentWithPage get comment list page
 func (cs *CommentService) GetCommentWithPage(ctx context.Context, req *schema.GetCommentWithPageReq) (
 	pageModel *pager.PageModel, err error) {
+	canView, err := cs.canViewObject(ctx, req.ObjectID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return pager.NewPageModel(0, make([]*schema.GetCommentResp, 0)), nil
+	}
 	dto := &CommentQuery{
-		PageCond:  pager.PageCond{Page: req.Page, PageSize: req.PageSize},
-		ObjectID:  req.ObjectID,
-		QueryCond: req.QueryCond,
+		PageCond:      pager.PageCond{Page: req.Page, PageSize: req.PageSize},
+		ObjectID:      req.ObjectID,
+		QueryCond:     req.QueryCond,
+		LoginUserID:   req.UserID,
+		IncludeShadow: req.CanDelete,
 	}
 	commentList, total, err := cs.commentRepo.GetCommentPage(ctx, dto)
 	if err != nil {
@@ -395,6 +617,7 @@ func (cs *CommentService) GetCommentWithPage(ctx context.Context, req *schema.Ge
 			}
 		}
 	}
+	cs.relocateCodeAnchors(ctx, uid.DeShortID(req.ObjectID), resp)
 	return pager.NewPageModel(total, resp), nil
 }
 
@@ -410,6 +633,7 @@ func (cs *CommentService) convertCommentEntity2Resp(ctx context.Context, req *sc
 		VoteCount:      comment.VoteCount,
 		OriginalText:   comment.OriginalText,
 		ParsedText:     comment.ParsedText,
+		CodeAnchor:     schema.NewCommentCodeAnchorResp(comment),
 	}
 
 	// get comment user info
@@ -470,9 +694,10 @@ func (cs *CommentService) GetCommentPersonalWithPage(ctx context.Context, req *s
 	}
 
 	dto := &CommentQuery{
-		PageCond:  pager.PageCond{Page: req.Page, PageSize: req.PageSize},
-		UserID:    req.UserID,
-		QueryCond: "created_at",
+		PageCond:    pager.PageCond{Page: req.Page, PageSize: req.PageSize},
+		UserID:      req.UserID,
+		QueryCond:   "created_at",
+		LoginUserID: req.LoginUserID,
 	}
 	commentList, total, err := cs.commentRepo.GetCommentPage(ctx, dto)
 	if err != nil {
@@ -491,6 +716,13 @@ func (cs *CommentService) GetCommentPersonalWithPage(ctx context.Context, req *s
 			if err != nil {
 				log.Error(err)
 			} else {
+				canView, err := cs.spaceCommon.CanViewQuestion(ctx, objInfo.QuestionID, req.LoginUserID)
+				if err != nil {
+					return nil, err
+				}
+				if !canView {
+					continue
+				}
 				commentResp.ObjectType = objInfo.ObjectType
 				commentResp.Title = objInfo.Title
 				commentResp.UrlTitle = htmltext.UrlTitle(objInfo.Title)
@@ -537,6 +769,7 @@ func (cs *CommentService) notificationQuestionComment(ctx context.Context, quest
 		ReceiverUserID: receiverUserInfo.ID,
 		ReceiverEmail:  receiverUserInfo.EMail,
 		ReceiverLang:   receiverUserInfo.Language,
+		TriggerUserID:  msg.TriggerUserID,
 	}
 	rawData := &schema.NewCommentTemplateRawData{
 		QuestionTitle:   questionTitle,
@@ -584,6 +817,7 @@ func (cs *CommentService) notificationAnswerComment(ctx context.Context,
 		ReceiverUserID: receiverUserInfo.ID,
 		ReceiverEmail:  receiverUserInfo.EMail,
 		ReceiverLang:   receiverUserInfo.Language,
+		TriggerUserID:  msg.TriggerUserID,
 	}
 	rawData := &schema.NewCommentTemplateRawData{
 		QuestionTitle:   questionTitle,
@@ -627,6 +861,7 @@ func (cs *CommentService) notificationCommentReply(ctx context.Context, replyUse
 		ReceiverUserID: receiverUserInfo.ID,
 		ReceiverEmail:  receiverUserInfo.EMail,
 		ReceiverLang:   receiverUserInfo.Language,
+		TriggerUserID:  msg.TriggerUserID,
 	}
 	rawData := &schema.NewCommentTemplateRawData{
 		QuestionTitle:   questionTitle,
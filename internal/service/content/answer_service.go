@@ -22,6 +22,8 @@ package content
 import (
 	"context"
 	"encoding/json"
+	"math"
+	"sort"
 	"time"
 
 	"github.com/apache/answer/internal/service/event_queue"
@@ -34,15 +36,21 @@ import (
 	"github.com/apache/answer/internal/service/activity_common"
 	"github.com/apache/answer/internal/service/activity_queue"
 	answercommon "github.com/apache/answer/internal/service/answer_common"
+	"github.com/apache/answer/internal/service/audit_log"
 	collectioncommon "github.com/apache/answer/internal/service/collection_common"
 	"github.com/apache/answer/internal/service/export"
+	"github.com/apache/answer/internal/service/helpfulness_survey"
 	"github.com/apache/answer/internal/service/notice_queue"
 	"github.com/apache/answer/internal/service/permission"
 	questioncommon "github.com/apache/answer/internal/service/question_common"
 	"github.com/apache/answer/internal/service/review"
 	"github.com/apache/answer/internal/service/revision_common"
 	"github.com/apache/answer/internal/service/role"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/apache/answer/internal/service/space_common"
+	"github.com/apache/answer/internal/service/syndication_common"
 	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/checker"
 	"github.com/apache/answer/pkg/converter"
 	"github.com/apache/answer/pkg/htmltext"
 	"github.com/apache/answer/pkg/token"
@@ -70,6 +78,11 @@ type AnswerService struct {
 	activityQueueService             activity_queue.ActivityQueueService
 	reviewService                    *review.ReviewService
 	eventQueueService                event_queue.EventQueueService
+	siteInfoService                  siteinfo_common.SiteInfoCommonService
+	syndicationCommon                *syndication_common.SyndicationCommon
+	helpfulnessSurveyService         *helpfulness_survey.HelpfulnessSurveyService
+	spaceCommon                      *space_common.SpaceCommon
+	auditLogService                  *audit_log.AuditLogService
 }
 
 func NewAnswerService(
@@ -90,6 +103,11 @@ func NewAnswerService(
 	activityQueueService activity_queue.ActivityQueueService,
 	reviewService *review.ReviewService,
 	eventQueueService event_queue.EventQueueService,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	syndicationCommon *syndication_common.SyndicationCommon,
+	helpfulnessSurveyService *helpfulness_survey.HelpfulnessSurveyService,
+	spaceCommon *space_common.SpaceCommon,
+	auditLogService *audit_log.AuditLogService,
 ) *AnswerService {
 	return &AnswerService{
 		answerRepo:                       answerRepo,
@@ -109,10 +127,38 @@ func NewAnswerService(
 		activityQueueService:             activityQueueService,
 		reviewService:                    reviewService,
 		eventQueueService:                eventQueueService,
+		siteInfoService:                  siteInfoService,
+		syndicationCommon:                syndicationCommon,
+		helpfulnessSurveyService:         helpfulnessSurveyService,
+		spaceCommon:                      spaceCommon,
+		auditLogService:                  auditLogService,
 	}
 }
 
 // RemoveAnswer delete answer
+// UpdateAnswerProvenance update the provenance of answer, only the author and admins can update it,
+// the import batch is kept unless it is updated by admins
+func (as *AnswerService) UpdateAnswerProvenance(ctx context.Context, req *schema.UpdateAnswerProvenanceReq) (err error) {
+	answerInfo, exist, err := as.answerRepo.GetByID(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.NotFound(reason.AnswerNotFound)
+	}
+	if !req.IsAdmin && answerInfo.UserID != req.UserID {
+		return errors.Forbidden(reason.RankFailToMeetTheCondition)
+	}
+	if !req.IsAdmin {
+		if req.Provenance == nil {
+			req.Provenance = &schema.AnswerProvenance{}
+		}
+		req.Provenance.ImportBatch = answerInfo.ImportBatch
+	}
+	req.Provenance.Apply(answerInfo)
+	return as.answerRepo.UpdateAnswer(ctx, answerInfo, []string{"source_url", "import_batch", "ai_assisted"})
+}
+
 func (as *AnswerService) RemoveAnswer(ctx context.Context, req *schema.RemoveAnswerReq) (err error) {
 	answerInfo, exist, err := as.answerRepo.GetByID(ctx, req.ID)
 	if err != nil {
@@ -153,6 +199,16 @@ func (as *AnswerService) RemoveAnswer(ctx context.Context, req *schema.RemoveAns
 	if err != nil {
 		return err
 	}
+	// only the deletion of others' answer is privileged
+	if answerInfo.UserID != req.UserID {
+		as.auditLogService.Record(ctx, &schema.AuditLogRecord{
+			OperatorID: req.UserID,
+			Action:     entity.AuditLogActionAnswerDelete,
+			ObjectType: entity.AuditLogObjectTypeAnswer,
+			ObjectID:   answerInfo.ID,
+			Before:     map[string]string{"question_id": answerInfo.QuestionID, "user_id": answerInfo.UserID},
+		})
+	}
 
 	// user add question count
 	err = as.questionCommon.UpdateAnswerCount(ctx, answerInfo.QuestionID)
@@ -255,6 +311,20 @@ func (as *AnswerService) Insert(ctx context.Context, req *schema.AnswerAddReq) (
 		err = errors.BadRequest(reason.AnswerCannotAddByClosedQuestion)
 		return "", err
 	}
+	mirror, err := as.syndicationCommon.IsMirrorQuestion(ctx, questionInfo.ID)
+	if err != nil {
+		return "", err
+	}
+	if mirror {
+		return "", errors.BadRequest(reason.QuestionSyndicatedReadOnly)
+	}
+	space, err := as.spaceCommon.ResolveQuestionSpace(ctx, questionInfo.ID)
+	if err != nil {
+		return "", err
+	}
+	if err = as.spaceCommon.CheckWrite(ctx, space, req.UserID); err != nil {
+		return "", err
+	}
 	insertData := &entity.Answer{}
 	insertData.UserID = req.UserID
 	insertData.OriginalText = req.Content
@@ -264,6 +334,8 @@ func (as *AnswerService) Insert(ctx context.Context, req *schema.AnswerAddReq) (
 	insertData.RevisionID = "0"
 	insertData.LastEditUserID = "0"
 	insertData.Status = entity.AnswerStatusPending
+	insertData.Shadow = as.userCommon.IsShadowBanned(ctx, req.UserID)
+	req.Provenance.Apply(insertData)
 	//insertData.UpdatedAt = now
 	if err = as.answerRepo.AddAnswer(ctx, insertData); err != nil {
 		return "", err
@@ -281,17 +353,20 @@ func (as *AnswerService) Insert(ctx context.Context, req *schema.AnswerAddReq) (
 			return "", err
 		}
 	}
-	err = as.questionCommon.UpdateAnswerCount(ctx, req.QuestionID)
-	if err != nil {
-		log.Error("IncreaseAnswerCount error", err.Error())
-	}
-	err = as.questionCommon.UpdateLastAnswer(ctx, req.QuestionID, uid.DeShortID(insertData.ID))
-	if err != nil {
-		log.Error("UpdateLastAnswer error", err.Error())
-	}
-	err = as.questionCommon.UpdatePostTime(ctx, req.QuestionID)
-	if err != nil {
-		return insertData.ID, err
+	// the question is not bumped or counted by the answer of the shadow-banned user
+	if !insertData.Shadow {
+		err = as.questionCommon.UpdateAnswerCount(ctx, req.QuestionID)
+		if err != nil {
+			log.Error("IncreaseAnswerCount error", err.Error())
+		}
+		err = as.questionCommon.UpdateLastAnswer(ctx, req.QuestionID, uid.DeShortID(insertData.ID))
+		if err != nil {
+			log.Error("UpdateLastAnswer error", err.Error())
+		}
+		err = as.questionCommon.UpdatePostTime(ctx, req.QuestionID)
+		if err != nil {
+			return insertData.ID, err
+		}
 	}
 	userAnswerCount, err := as.answerRepo.GetCountByUserID(ctx, req.UserID)
 	if err != nil {
@@ -432,19 +507,20 @@ func (as *AnswerService) Update(ctx context.Context, req *schema.AnswerUpdateReq
 	return insertData.ID, nil
 }
 
-// AcceptAnswer accept answer
-func (as *AnswerService) AcceptAnswer(ctx context.Context, req *schema.AcceptAnswerReq) (err error) {
+// AcceptAnswer accept answer, the resp is returned with error if the author can not accept own answer yet
+func (as *AnswerService) AcceptAnswer(ctx context.Context, req *schema.AcceptAnswerReq) (
+	resp *schema.AcceptAnswerResp, err error) {
 	// find question
 	questionInfo, exist, err := as.questionRepo.GetQuestion(ctx, req.QuestionID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if !exist {
-		return errors.BadRequest(reason.QuestionNotFound)
+		return nil, errors.BadRequest(reason.QuestionNotFound)
 	}
 	questionInfo.ID = uid.DeShortID(questionInfo.ID)
 	if questionInfo.AcceptedAnswerID == req.AnswerID {
-		return nil
+		return nil, nil
 	}
 
 	// find answer
@@ -452,40 +528,134 @@ func (as *AnswerService) AcceptAnswer(ctx context.Context, req *schema.AcceptAns
 	if len(req.AnswerID) > 1 {
 		acceptedAnswerInfo, exist, err = as.answerRepo.GetByID(ctx, req.AnswerID)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if !exist {
-			return errors.BadRequest(reason.AnswerNotFound)
+			return nil, errors.BadRequest(reason.AnswerNotFound)
 		}
 		acceptedAnswerInfo.ID = uid.DeShortID(acceptedAnswerInfo.ID)
 	}
 
+	if resp, err = as.checkSelfAcceptance(ctx, req, questionInfo, acceptedAnswerInfo); err != nil {
+		return resp, err
+	}
+
+	// the resolution note is only kept when the question author accepts an answer
+	if err = as.checkResolutionNote(ctx, req, questionInfo, acceptedAnswerInfo); err != nil {
+		return nil, err
+	}
+
 	// update answers status
 	if err = as.answerRepo.UpdateAcceptedStatus(ctx, req.AnswerID, req.QuestionID); err != nil {
-		return err
+		return nil, err
 	}
 
 	// update question status
-	err = as.questionCommon.UpdateAccepted(ctx, req.QuestionID, req.AnswerID)
+	err = as.questionCommon.UpdateAccepted(ctx, req.QuestionID, req.AnswerID, req.ResolutionNote)
 	if err != nil {
 		log.Error("UpdateLastAnswer error", err.Error())
 	}
+	// the accepted answer is shown on top already, so it is no longer pinned
+	if acceptedAnswerInfo != nil && questionInfo.PinnedAnswerID == acceptedAnswerInfo.ID {
+		err = as.questionRepo.UpdateQuestion(ctx, &entity.Question{ID: questionInfo.ID, PinnedAnswerID: "0"},
+			[]string{"pinned_answer_id"})
+		if err != nil {
+			log.Errorf("unpin the accepted answer failed: %v", err)
+		}
+	}
 
 	var oldAnswerInfo *entity.Answer
 	if len(questionInfo.AcceptedAnswerID) > 1 {
 		oldAnswerInfo, _, err = as.answerRepo.GetByID(ctx, questionInfo.AcceptedAnswerID)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		oldAnswerInfo.ID = uid.DeShortID(oldAnswerInfo.ID)
 	}
 
 	if acceptedAnswerInfo != nil {
 		as.eventQueueService.Send(ctx, schema.NewEvent(constant.EventQuestionAccept, req.UserID).TID(acceptedAnswerInfo.ID).
-			QID(questionInfo.ID, questionInfo.UserID).AID(acceptedAnswerInfo.ID, acceptedAnswerInfo.UserID))
+			QID(questionInfo.ID, questionInfo.UserID).AID(acceptedAnswerInfo.ID, acceptedAnswerInfo.UserID).
+			AddExtra("resolution_note", req.ResolutionNote))
 	}
 
 	as.updateAnswerRank(ctx, req.UserID, questionInfo, acceptedAnswerInfo, oldAnswerInfo)
+	return nil, nil
+}
+
+// PinAnswer pin the answer to the top of question, only one answer which is not accepted can be pinned
+func (as *AnswerService) PinAnswer(ctx context.Context, req *schema.PinAnswerReq) (err error) {
+	questionInfo, exist, err := as.questionRepo.GetQuestion(ctx, req.QuestionID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.QuestionNotFound)
+	}
+	pinnedAnswerID := "0"
+	if checker.IsNotZeroString(req.AnswerID) {
+		answerInfo, exist, err := as.answerRepo.GetByID(ctx, req.AnswerID)
+		if err != nil {
+			return err
+		}
+		if !exist || answerInfo.Status != entity.AnswerStatusAvailable ||
+			uid.DeShortID(answerInfo.QuestionID) != uid.DeShortID(questionInfo.ID) {
+			return errors.BadRequest(reason.AnswerNotFound)
+		}
+		if answerInfo.Accepted == schema.AnswerAcceptedEnable {
+			return errors.BadRequest(reason.AnswerPinAccepted)
+		}
+		pinnedAnswerID = uid.DeShortID(answerInfo.ID)
+	}
+	if questionInfo.PinnedAnswerID == pinnedAnswerID {
+		return nil
+	}
+	return as.questionRepo.UpdateQuestion(ctx, &entity.Question{ID: questionInfo.ID, PinnedAnswerID: pinnedAnswerID},
+		[]string{"pinned_answer_id"})
+}
+
+// checkSelfAcceptance the question author can accept own answer only after the delay hours since it posted
+func (as *AnswerService) checkSelfAcceptance(ctx context.Context, req *schema.AcceptAnswerReq,
+	questionInfo *entity.Question, acceptedAnswerInfo *entity.Answer) (resp *schema.AcceptAnswerResp, err error) {
+	if acceptedAnswerInfo == nil || req.UserID != questionInfo.UserID || acceptedAnswerInfo.UserID != questionInfo.UserID {
+		return nil, nil
+	}
+	siteWrite, err := as.siteInfoService.GetSiteWrite(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if siteWrite.SelfAcceptDelayHours <= 0 {
+		return nil, nil
+	}
+	acceptableAt := acceptedAnswerInfo.CreatedAt.Add(time.Duration(siteWrite.SelfAcceptDelayHours) * time.Hour)
+	remaining := time.Until(acceptableAt)
+	if remaining <= 0 {
+		return nil, nil
+	}
+	resp = &schema.AcceptAnswerResp{
+		RemainingSeconds: int64(math.Ceil(remaining.Seconds())),
+		AcceptableAt:     acceptableAt.Unix(),
+	}
+	return resp, errors.BadRequest(reason.AnswerSelfAcceptTooEarly)
+}
+
+func (as *AnswerService) checkResolutionNote(ctx context.Context, req *schema.AcceptAnswerReq,
+	questionInfo *entity.Question, acceptedAnswerInfo *entity.Answer) error {
+	if acceptedAnswerInfo == nil || req.UserID != questionInfo.UserID {
+		req.ResolutionNote = ""
+		return nil
+	}
+	siteWrite, err := as.siteInfoService.GetSiteWrite(ctx)
+	if err != nil {
+		return err
+	}
+	if !siteWrite.ResolutionNoteAllowed() {
+		req.ResolutionNote = ""
+		return nil
+	}
+	if siteWrite.ResolutionNoteRequired() && len(req.ResolutionNote) == 0 {
+		return errors.BadRequest(reason.AnswerResolutionNoteRequired)
+	}
 	return nil
 }
 
@@ -514,12 +684,29 @@ func (as *AnswerService) Get(ctx context.Context, answerID, loginUserID string)
 	if err != nil {
 		return nil, nil, has, err
 	}
+	// the answer of the shadow-banned user is only visible to the author and the staff
+	if has && answerInfo.Shadow && answerInfo.UserID != loginUserID {
+		roleID, err := as.roleService.GetUserRole(ctx, loginUserID)
+		if err != nil {
+			return nil, nil, has, err
+		}
+		if roleID != role.RoleAdminID && roleID != role.RoleModeratorID {
+			return nil, nil, false, errors.NotFound(reason.AnswerNotFound)
+		}
+	}
 	info := as.ShowFormat(ctx, answerInfo)
 	// todo questionFunc
 	questionInfo, err := as.questionCommon.Info(ctx, answerInfo.QuestionID, loginUserID)
 	if err != nil {
 		return nil, nil, has, err
 	}
+	canView, err := as.spaceCommon.CanViewTags(ctx, questionInfo.Tags, loginUserID)
+	if err != nil {
+		return nil, nil, has, err
+	}
+	if !canView {
+		return nil, nil, false, errors.NotFound(reason.AnswerNotFound)
+	}
 	// todo UserFunc
 
 	userIds := make([]string, 0)
@@ -613,10 +800,48 @@ func (as *AnswerService) AdminSetAnswerStatus(ctx context.Context, req *schema.A
 			return err
 		}
 	}
+	var oldStatus string
+	for status, value := range entity.AdminAnswerSearchStatus {
+		if value == answerInfo.Status {
+			oldStatus = status
+		}
+	}
+	as.auditLogService.Record(ctx, &schema.AuditLogRecord{
+		OperatorID: req.UserID,
+		Action:     entity.AuditLogActionAnswerStatus,
+		ObjectType: entity.AuditLogObjectTypeAnswer,
+		ObjectID:   answerInfo.ID,
+		Before:     map[string]string{"status": oldStatus},
+		After:      map[string]string{"status": req.Status},
+	})
 	return nil
 }
 
 func (as *AnswerService) SearchList(ctx context.Context, req *schema.AnswerListReq) ([]*schema.AnswerInfo, int64, error) {
+	questionInfo, exist, err := as.questionRepo.GetQuestion(ctx, req.QuestionID)
+	if err != nil {
+		return make([]*schema.AnswerInfo, 0), 0, err
+	}
+	if exist && checker.IsNotZeroString(questionInfo.PinnedAnswerID) {
+		req.PinnedAnswerID = questionInfo.PinnedAnswerID
+	}
+	canView, err := as.spaceCommon.CanViewQuestion(ctx, req.QuestionID, req.UserID)
+	if err != nil {
+		return make([]*schema.AnswerInfo, 0), 0, err
+	}
+	if !canView {
+		return make([]*schema.AnswerInfo, 0), 0, nil
+	}
+
+	if len(req.Order) == 0 || req.Order == entity.AnswerSearchOrderByDefault {
+		conf, err := as.siteInfoService.GetSiteAnswerRanking(ctx)
+		if err != nil {
+			log.Error(err)
+		} else if conf.Enabled {
+			return as.searchListByRanking(ctx, req, conf)
+		}
+	}
+
 	list := make([]*schema.AnswerInfo, 0)
 	dbSearch := entity.AnswerSearch{}
 	dbSearch.QuestionID = req.QuestionID
@@ -625,6 +850,7 @@ func (as *AnswerService) SearchList(ctx context.Context, req *schema.AnswerListR
 	dbSearch.Order = req.Order
 	dbSearch.IncludeDeleted = req.CanDelete
 	dbSearch.LoginUserID = req.UserID
+	dbSearch.PinnedAnswerID = req.PinnedAnswerID
 	answerOriginalList, count, err := as.answerRepo.SearchList(ctx, &dbSearch)
 	if err != nil {
 		return list, count, err
@@ -636,6 +862,152 @@ func (as *AnswerService) SearchList(ctx context.Context, req *schema.AnswerListR
 	return answerList, count, nil
 }
 
+// searchListByRanking sort the answers of question by the weighted score, and then paginate
+func (as *AnswerService) searchListByRanking(ctx context.Context, req *schema.AnswerListReq,
+	conf *schema.SiteAnswerRankingResp) ([]*schema.AnswerInfo, int64, error) {
+	dbSearch := entity.AnswerSearch{}
+	dbSearch.QuestionID = req.QuestionID
+	dbSearch.Page = 1
+	dbSearch.PageSize = schema.AnswerRankingMaxAnswers
+	dbSearch.IncludeDeleted = req.CanDelete
+	dbSearch.LoginUserID = req.UserID
+	answers, count, err := as.answerRepo.SearchList(ctx, &dbSearch)
+	if err != nil {
+		return make([]*schema.AnswerInfo, 0), count, err
+	}
+	scores, err := as.rankAnswers(ctx, answers, conf)
+	if err != nil {
+		return make([]*schema.AnswerInfo, 0), count, err
+	}
+	answerMapping := make(map[string]*entity.Answer, len(answers))
+	for _, answer := range answers {
+		answerMapping[answer.ID] = answer
+	}
+	// the pinned answer is shown on top regardless of the score
+	for idx, score := range scores {
+		if uid.DeShortID(score.AnswerID) == req.PinnedAnswerID {
+			copy(scores[1:idx+1], scores[:idx])
+			scores[0] = score
+			break
+		}
+	}
+
+	page, pageSize := req.Page, req.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = constant.DefaultPageSize
+	}
+	start := min((page-1)*pageSize, len(scores))
+	end := min(start+pageSize, len(scores))
+	pageAnswers := make([]*entity.Answer, 0, end-start)
+	for _, score := range scores[start:end] {
+		pageAnswers = append(pageAnswers, answerMapping[score.AnswerID])
+	}
+	answerList, err := as.SearchFormatInfo(ctx, pageAnswers, req)
+	return answerList, count, err
+}
+
+// rankAnswers calculate the weighted score of answers, and sort them by score desc.
+// The answers with the same score keep the original order.
+func (as *AnswerService) rankAnswers(ctx context.Context, answers []*entity.Answer, conf *schema.SiteAnswerRankingResp) (
+	scores []*schema.AnswerRankingScore, err error) {
+	userIDs := make([]string, 0, len(answers))
+	answerIDs := make([]string, 0, len(answers))
+	for _, answer := range answers {
+		userIDs = append(userIDs, answer.UserID)
+		answerIDs = append(answerIDs, answer.ID)
+	}
+	userInfoMapping, err := as.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	userRoleMapping, err := as.roleService.GetUserRoleMapping(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	helpfulnessMapping, err := as.helpfulnessSurveyService.GetAnswersHelpfulnessStat(ctx, answerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	scores = make([]*schema.AnswerRankingScore, 0, len(answers))
+	for _, answer := range answers {
+		factor := &schema.AnswerRankingFactor{
+			AnswerID:  answer.ID,
+			VoteCount: answer.VoteCount,
+			Accepted:  answer.Accepted == schema.AnswerAcceptedEnable,
+			CreatedAt: answer.CreatedAt,
+		}
+		if userInfo, ok := userInfoMapping[answer.UserID]; ok {
+			factor.AuthorRank = userInfo.Rank
+		}
+		if userRole, ok := userRoleMapping[answer.UserID]; ok && userRole != nil {
+			factor.Verified = userRole.ID == role.RoleAdminID || userRole.ID == role.RoleModeratorID
+		}
+		if stat, ok := helpfulnessMapping[answer.ID]; ok {
+			factor.SurveyResponses = stat.Responses
+			factor.SolvedRate = schema.NewAnswerHelpfulnessResp(answer.ID, stat.Responses, stat.Solved).SolvedRate
+		}
+		scores = append(scores, conf.Score(factor, now))
+	}
+	sort.SliceStable(scores, func(i, j int) bool {
+		return scores[i].Score > scores[j].Score
+	})
+	return scores, nil
+}
+
+// AdminPreviewAnswerRanking preview the answer order of question with the weights without saving them
+func (as *AnswerService) AdminPreviewAnswerRanking(ctx context.Context, req *schema.AdminPreviewAnswerRankingReq) (
+	resp []*schema.AdminPreviewAnswerRankingResp, err error) {
+	conf := schema.SiteAnswerRankingResp(*req.Weights)
+	conf.FillDefault()
+
+	answers, _, err := as.answerRepo.SearchList(ctx, &entity.AnswerSearch{
+		Answer:   entity.Answer{QuestionID: req.QuestionID},
+		Page:     1,
+		PageSize: schema.AnswerRankingMaxAnswers,
+	})
+	if err != nil {
+		return nil, err
+	}
+	scores, err := as.rankAnswers(ctx, answers, &conf)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(answers))
+	for _, answer := range answers {
+		userIDs = append(userIDs, answer.UserID)
+	}
+	userInfoMapping, err := as.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	answerMapping := make(map[string]*entity.Answer, len(answers))
+	positionMapping := make(map[string]int, len(answers))
+	for i, answer := range answers {
+		answerMapping[answer.ID] = answer
+		positionMapping[answer.ID] = i + 1
+	}
+	resp = make([]*schema.AdminPreviewAnswerRankingResp, 0, len(scores))
+	for _, score := range scores {
+		answer := answerMapping[score.AnswerID]
+		resp = append(resp, &schema.AdminPreviewAnswerRankingResp{
+			AnswerRankingScore: score,
+			Excerpt:            htmltext.FetchExcerpt(answer.ParsedText, "...", 120),
+			VoteCount:          answer.VoteCount,
+			IsAccepted:         answer.Accepted == schema.AnswerAcceptedEnable,
+			CreatedAt:          answer.CreatedAt.Unix(),
+			UserInfo:           userInfoMapping[answer.UserID],
+			CurrentPosition:    positionMapping[answer.ID],
+		})
+	}
+	return resp, nil
+}
+
 func (as *AnswerService) SearchFormatInfo(ctx context.Context, answers []*entity.Answer, req *schema.AnswerListReq) (
 	[]*schema.AnswerInfo, error) {
 	list := make([]*schema.AnswerInfo, 0)
@@ -643,6 +1015,7 @@ func (as *AnswerService) SearchFormatInfo(ctx context.Context, answers []*entity
 	userIDs := make([]string, 0)
 	for _, info := range answers {
 		item := as.ShowFormat(ctx, info)
+		item.Pinned = len(req.PinnedAnswerID) > 0 && uid.DeShortID(info.ID) == req.PinnedAnswerID
 		list = append(list, item)
 		objectIDs = append(objectIDs, info.ID)
 		userIDs = append(userIDs, info.UserID, info.LastEditUserID)
@@ -729,6 +1102,7 @@ func (as *AnswerService) notificationAnswerTheQuestion(ctx context.Context,
 		ReceiverUserID: receiverUserInfo.ID,
 		ReceiverEmail:  receiverUserInfo.EMail,
 		ReceiverLang:   receiverUserInfo.Language,
+		TriggerUserID:  msg.TriggerUserID,
 	}
 	rawData := &schema.NewAnswerTemplateRawData{
 		QuestionTitle:   questionTitle,
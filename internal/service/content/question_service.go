@@ -22,7 +22,9 @@ package content
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/apache/answer/internal/service/event_queue"
@@ -40,6 +42,8 @@ import (
 	"github.com/apache/answer/internal/service/activity_common"
 	"github.com/apache/answer/internal/service/activity_queue"
 	answercommon "github.com/apache/answer/internal/service/answer_common"
+	"github.com/apache/answer/internal/service/audit_log"
+	"github.com/apache/answer/internal/service/auto_comment"
 	collectioncommon "github.com/apache/answer/internal/service/collection_common"
 	"github.com/apache/answer/internal/service/config"
 	"github.com/apache/answer/internal/service/export"
@@ -48,18 +52,25 @@ import (
 	"github.com/apache/answer/internal/service/notification"
 	"github.com/apache/answer/internal/service/permission"
 	questioncommon "github.com/apache/answer/internal/service/question_common"
+	"github.com/apache/answer/internal/service/question_heat"
+	"github.com/apache/answer/internal/service/question_quality"
 	"github.com/apache/answer/internal/service/review"
 	"github.com/apache/answer/internal/service/revision_common"
 	"github.com/apache/answer/internal/service/role"
+	"github.com/apache/answer/internal/service/service_config"
 	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/apache/answer/internal/service/space_common"
+	"github.com/apache/answer/internal/service/syndication_common"
 	"github.com/apache/answer/internal/service/tag"
 	tagcommon "github.com/apache/answer/internal/service/tag_common"
 	usercommon "github.com/apache/answer/internal/service/user_common"
 	"github.com/apache/answer/pkg/checker"
 	"github.com/apache/answer/pkg/converter"
+	"github.com/apache/answer/pkg/display"
 	"github.com/apache/answer/pkg/htmltext"
 	"github.com/apache/answer/pkg/token"
 	"github.com/apache/answer/pkg/uid"
+	"github.com/apache/answer/pkg/writer"
 	"github.com/jinzhu/copier"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
@@ -93,6 +104,16 @@ type QuestionService struct {
 	configService                    *config.ConfigService
 	eventQueueService                event_queue.EventQueueService
 	reviewRepo                       review.ReviewRepo
+	syndicationCommon                *syndication_common.SyndicationCommon
+	questionQualityService           *question_quality.QuestionQualityService
+	autoCommentService               *auto_comment.AutoCommentService
+	questionHeatTracker              *question_heat.QuestionHeatTracker
+	spaceCommon                      *space_common.SpaceCommon
+	auditLogService                  *audit_log.AuditLogService
+	serviceConfig                    *service_config.ServiceConfig
+	exportFontOnce                   sync.Once
+	exportFont                       *writer.PDFFont
+	closeStatusChangedHandler        func(ctx context.Context, questionID string) error
 }
 
 func NewQuestionService(
@@ -119,6 +140,13 @@ func NewQuestionService(
 	configService *config.ConfigService,
 	eventQueueService event_queue.EventQueueService,
 	reviewRepo review.ReviewRepo,
+	syndicationCommon *syndication_common.SyndicationCommon,
+	questionQualityService *question_quality.QuestionQualityService,
+	autoCommentService *auto_comment.AutoCommentService,
+	questionHeatTracker *question_heat.QuestionHeatTracker,
+	spaceCommon *space_common.SpaceCommon,
+	auditLogService *audit_log.AuditLogService,
+	serviceConfig *service_config.ServiceConfig,
 ) *QuestionService {
 	return &QuestionService{
 		activityRepo:                     activityRepo,
@@ -144,6 +172,28 @@ func NewQuestionService(
 		configService:                    configService,
 		eventQueueService:                eventQueueService,
 		reviewRepo:                       reviewRepo,
+		syndicationCommon:                syndicationCommon,
+		questionQualityService:           questionQualityService,
+		autoCommentService:               autoCommentService,
+		questionHeatTracker:              questionHeatTracker,
+		spaceCommon:                      spaceCommon,
+		auditLogService:                  auditLogService,
+		serviceConfig:                    serviceConfig,
+	}
+}
+
+// RegisterCloseStatusChangedHandler register the handler called after the question is closed or reopened,
+// the close vote service depends on the question service so it registers itself
+func (qs *QuestionService) RegisterCloseStatusChangedHandler(handler func(ctx context.Context, questionID string) error) {
+	qs.closeStatusChangedHandler = handler
+}
+
+func (qs *QuestionService) handleCloseStatusChanged(ctx context.Context, questionID string) {
+	if qs.closeStatusChangedHandler == nil {
+		return
+	}
+	if err := qs.closeStatusChangedHandler(ctx, questionID); err != nil {
+		log.Errorf("handle question close status changed failed, err: %v", err)
 	}
 }
 
@@ -163,23 +213,43 @@ func (qs *QuestionService) CloseQuestion(ctx context.Context, req *schema.CloseQ
 	if cf.Key == constant.ReasonADuplicate && !checker.IsURL(req.CloseMsg) {
 		return errors.BadRequest(reason.InvalidURLError)
 	}
+	if len(req.MigrationDestination) > 0 {
+		if cf.Key != constant.ReasonOffTopic {
+			return errors.BadRequest(reason.QuestionMigrationNotOffTopic)
+		}
+		siteMigration, err := qs.siteInfoService.GetSiteQuestionMigration(ctx)
+		if err != nil {
+			return err
+		}
+		if siteMigration.GetDestination(req.MigrationDestination) == nil {
+			return errors.BadRequest(reason.QuestionMigrationNotFound)
+		}
+	}
 
+	oldStatus := questionInfo.Status
 	questionInfo.Status = entity.QuestionStatusClosed
 	err = qs.questionRepo.UpdateQuestionStatus(ctx, questionInfo.ID, questionInfo.Status)
 	if err != nil {
 		return err
 	}
+	qs.recordQuestionStatus(ctx, req.UserID, questionInfo.ID, oldStatus, questionInfo.Status)
+	qs.handleCloseStatusChanged(ctx, questionInfo.ID)
 
 	closeMeta, _ := json.Marshal(schema.CloseQuestionMeta{
-		CloseType: req.CloseType,
-		CloseMsg:  req.CloseMsg,
+		CloseType:            req.CloseType,
+		CloseMsg:             req.CloseMsg,
+		MigrationDestination: req.MigrationDestination,
 	})
 	err = qs.metaService.AddMeta(ctx, req.ID, entity.QuestionCloseReasonKey, string(closeMeta))
 	if err != nil {
 		return err
 	}
 	if cf.Key == constant.ReasonADuplicate {
-		qs.questioncommon.AddQuestionLinkForCloseReason(ctx, questionInfo, req.CloseMsg)
+		target := qs.questioncommon.AddQuestionLinkForCloseReason(ctx, questionInfo, req.CloseMsg)
+		if target != nil {
+			qs.autoCommentService.CommentDuplicateQuestion(ctx, questionInfo, target, req.UserID)
+			qs.notificationDuplicateQuestion(ctx, questionInfo, target, req.UserID)
+		}
 	}
 
 	qs.activityQueueService.Send(ctx, &schema.ActivityMsg{
@@ -191,6 +261,38 @@ func (qs *QuestionService) CloseQuestion(ctx context.Context, req *schema.CloseQ
 	return nil
 }
 
+// notificationDuplicateQuestion notify the authors and followers of both questions
+// when the question is closed as a duplicate of the target question
+func (qs *QuestionService) notificationDuplicateQuestion(ctx context.Context,
+	question, target *entity.Question, operatorID string) {
+	notices := []struct {
+		question *entity.Question
+		action   string
+		extra    map[string]string
+	}{
+		{question: question, action: constant.NotificationMarkedAsDuplicate,
+			extra: map[string]string{"duplicate_of": uid.DeShortID(target.ID)}},
+		{question: target, action: constant.NotificationDuplicateOfQuestion,
+			extra: map[string]string{"duplicate_question": uid.DeShortID(question.ID)}},
+	}
+	for _, notice := range notices {
+		// If the question is closed by its author, there is no notification for the author and followers.
+		if notice.question.UserID == operatorID {
+			continue
+		}
+		msg := &schema.NotificationMsg{
+			ReceiverUserID: notice.question.UserID,
+			TriggerUserID:  operatorID,
+			Type:           schema.NotificationTypeInbox,
+			ObjectID:       notice.question.ID,
+			ExtraInfo:      notice.extra,
+		}
+		msg.ObjectType = constant.QuestionObjectType
+		msg.NotificationAction = notice.action
+		qs.notificationQueueService.Send(ctx, msg)
+	}
+}
+
 // ReopenQuestion reopen question
 func (qs *QuestionService) ReopenQuestion(ctx context.Context, req *schema.ReopenQuestionReq) error {
 	questionInfo, has, err := qs.questionRepo.GetQuestion(ctx, req.QuestionID)
@@ -201,11 +303,14 @@ func (qs *QuestionService) ReopenQuestion(ctx context.Context, req *schema.Reope
 		return nil
 	}
 
+	oldStatus := questionInfo.Status
 	questionInfo.Status = entity.QuestionStatusAvailable
 	err = qs.questionRepo.UpdateQuestionStatus(ctx, questionInfo.ID, questionInfo.Status)
 	if err != nil {
 		return err
 	}
+	qs.recordQuestionStatus(ctx, req.UserID, questionInfo.ID, oldStatus, questionInfo.Status)
+	qs.handleCloseStatusChanged(ctx, questionInfo.ID)
 	qs.questioncommon.RemoveQuestionLinkForReopen(ctx, questionInfo)
 	qs.activityQueueService.Send(ctx, &schema.ActivityMsg{
 		UserID:           req.UserID,
@@ -274,6 +379,13 @@ func (qs *QuestionService) CheckAddQuestion(ctx context.Context, req *schema.Que
 			return errorlist, err
 		}
 	}
+	if errorlist, err := qs.checkTagRules(ctx, tagNameList, Tags, nil,
+		req.QuestionPermission.CanUseReservedTag); err != nil {
+		return errorlist, err
+	}
+	if err = qs.checkSpaceWrite(ctx, tagNameList, Tags, req.UserID); err != nil {
+		return nil, err
+	}
 	return nil, nil
 }
 
@@ -330,6 +442,13 @@ func (qs *QuestionService) AddQuestion(ctx context.Context, req *schema.Question
 			return errorlist, err
 		}
 	}
+	if errorlist, err := qs.checkTagRules(ctx, tagNameList, tags, nil,
+		req.QuestionPermission.CanUseReservedTag); err != nil {
+		return errorlist, err
+	}
+	if err = qs.checkSpaceWrite(ctx, tagNameList, tags, req.UserID); err != nil {
+		return nil, err
+	}
 
 	question := &entity.Question{}
 	now := time.Now()
@@ -347,6 +466,9 @@ func (qs *QuestionService) AddQuestion(ctx context.Context, req *schema.Question
 	question.PostUpdateTime = now
 	question.Pin = entity.QuestionUnPin
 	question.Show = entity.QuestionShow
+	if qs.userCommon.IsShadowBanned(ctx, req.UserID) {
+		question.Show = entity.QuestionShadow
+	}
 	//question.UpdatedAt = nil
 	err = qs.questionRepo.AddQuestion(ctx, question)
 	if err != nil {
@@ -418,6 +540,8 @@ func (qs *QuestionService) AddQuestion(ctx context.Context, req *schema.Question
 	}
 	qs.eventQueueService.Send(ctx, schema.NewEvent(constant.EventQuestionCreate, req.UserID).TID(question.ID).
 		QID(question.ID, question.UserID))
+	qs.questionQualityService.EvaluateNewQuestion(ctx, question.ID)
+	qs.autoCommentService.CommentNewQuestion(ctx, question, tags)
 
 	questionInfo, err = qs.GetQuestion(ctx, question.ID, question.UserID, req.QuestionPermission)
 	return
@@ -488,6 +612,13 @@ func (qs *QuestionService) OperationQuestion(ctx context.Context, req *schema.Op
 	if err != nil {
 		return err
 	}
+	qs.auditLogService.Record(ctx, &schema.AuditLogRecord{
+		OperatorID: req.UserID,
+		Action:     entity.AuditLogActionQuestionOperation,
+		ObjectType: entity.AuditLogObjectTypeQuestion,
+		ObjectID:   questionInfo.ID,
+		After:      map[string]string{"operation": req.Operation},
+	})
 
 	actMap := make(map[string]constant.ActivityTypeKey)
 	actMap[schema.QuestionOperationPin] = constant.ActQuestionPin
@@ -552,6 +683,16 @@ func (qs *QuestionService) RemoveQuestion(ctx context.Context, req *schema.Remov
 	if err != nil {
 		return err
 	}
+	// only the deletion of others' question is privileged
+	if questionInfo.UserID != req.UserID {
+		qs.auditLogService.Record(ctx, &schema.AuditLogRecord{
+			OperatorID: req.UserID,
+			Action:     entity.AuditLogActionQuestionDelete,
+			ObjectType: entity.AuditLogObjectTypeQuestion,
+			ObjectID:   questionInfo.ID,
+			Before:     map[string]string{"title": questionInfo.Title, "user_id": questionInfo.UserID},
+		})
+	}
 
 	userQuestionCount, err := qs.questioncommon.GetUserQuestionCount(ctx, questionInfo.UserID)
 	if err != nil {
@@ -680,6 +821,9 @@ func (qs *QuestionService) UpdateQuestionCheckTags(ctx context.Context, req *sch
 			return errorlist, err
 		}
 	}
+	if errorlist, err := qs.checkTagRules(ctx, tagNameList, Tags, oldTags, req.CanUseReservedTag); err != nil {
+		return errorlist, err
+	}
 	return nil, nil
 }
 
@@ -843,6 +987,7 @@ func (qs *QuestionService) notificationInviteUser(
 			ReceiverUserID: receiverUserInfo.ID,
 			ReceiverEmail:  receiverUserInfo.EMail,
 			ReceiverLang:   receiverUserInfo.Language,
+			TriggerUserID:  msg.TriggerUserID,
 		}
 		rawData := &schema.NewInviteAnswerTemplateRawData{
 			InviterDisplayName: inviter.DisplayName,
@@ -880,6 +1025,16 @@ func (qs *QuestionService) UpdateQuestion(ctx context.Context, req *schema.Quest
 		err = errors.BadRequest(reason.QuestionCannotUpdate)
 		return nil, err
 	}
+	// the mirrored question is only updated by the sync from source
+	if !req.SyndicationSync {
+		mirror, err := qs.syndicationCommon.IsMirrorQuestion(ctx, dbinfo.ID)
+		if err != nil {
+			return nil, err
+		}
+		if mirror {
+			return nil, errors.BadRequest(reason.QuestionSyndicatedReadOnly)
+		}
+	}
 
 	now := time.Now()
 	question := &entity.Question{}
@@ -945,6 +1100,9 @@ func (qs *QuestionService) UpdateQuestion(ctx context.Context, req *schema.Quest
 			return errorlist, err
 		}
 	}
+	if errorlist, err := qs.checkTagRules(ctx, tagNameList, Tags, oldTags, req.CanUseReservedTag); err != nil {
+		return errorlist, err
+	}
 	// Check whether mandatory labels are selected
 	recommendExist, err := qs.tagCommon.ExistRecommend(ctx, req.Tags)
 	if err != nil {
@@ -1019,6 +1177,7 @@ func (qs *QuestionService) UpdateQuestion(ctx context.Context, req *schema.Quest
 		})
 		qs.eventQueueService.Send(ctx, schema.NewEvent(constant.EventQuestionUpdate, req.UserID).TID(question.ID).
 			QID(question.ID, question.UserID))
+		qs.questionQualityService.ReevaluateQuestion(ctx, question.ID)
 	}
 
 	questionInfo, err = qs.GetQuestion(ctx, question.ID, question.UserID, req.QuestionPermission)
@@ -1037,6 +1196,22 @@ func (qs *QuestionService) GetQuestion(ctx context.Context, questionID, userID s
 		question.Status == entity.QuestionStatusPending) && !per.CanReopen && question.UserID != userID {
 		return nil, errors.NotFound(reason.QuestionNotFound)
 	}
+	// the question of the shadow-banned user is only visible to the author and the staff,
+	// and it looks like a normal question to the author
+	if question.Show == entity.QuestionShadow && !per.CanReopen {
+		if question.UserID != userID {
+			return nil, errors.NotFound(reason.QuestionNotFound)
+		}
+		question.Show = entity.QuestionShow
+	}
+	// the question of the private space is not found for the user who can not view the space
+	canView, err := qs.spaceCommon.CanViewTags(ctx, question.Tags, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, errors.NotFound(reason.QuestionNotFound)
+	}
 	if question.Status != entity.QuestionStatusClosed {
 		per.CanReopen = false
 	}
@@ -1070,6 +1245,37 @@ func (qs *QuestionService) GetQuestion(ctx context.Context, questionID, userID s
 		operation.Level = schema.OperationLevelSecondary
 		question.Operation = operation
 	}
+	question.Syndication, err = qs.syndicationCommon.GetQuestionSyndicationInfo(ctx, question.ID)
+	if err != nil {
+		return nil, err
+	}
+	question.LinkedQuestions, question.ReferencedBy, err = qs.questioncommon.GetQuestionLinkGraph(ctx, question.ID)
+	if err != nil {
+		return nil, err
+	}
+	question.LinkedQuestions, err = qs.filterVisibleLinkItems(ctx, question.LinkedQuestions, userID)
+	if err != nil {
+		return nil, err
+	}
+	question.ReferencedBy, err = qs.filterVisibleLinkItems(ctx, question.ReferencedBy, userID)
+	if err != nil {
+		return nil, err
+	}
+	question.Duplicates = make([]*schema.QuestionLinkItem, 0)
+	for _, item := range question.LinkedQuestions {
+		if item.LinkType == entity.QuestionLinkTypeMapping[entity.QuestionLinkTypeDuplicate] {
+			question.DuplicateOf = item
+			break
+		}
+	}
+	for _, item := range question.ReferencedBy {
+		if item.LinkType == entity.QuestionLinkTypeMapping[entity.QuestionLinkTypeDuplicate] {
+			question.Duplicates = append(question.Duplicates, item)
+		}
+	}
+	if question.Syndication != nil {
+		per.CanEdit = false
+	}
 
 	question.Description = htmltext.FetchExcerpt(question.HTML, "...", 240)
 	question.MemberActions = permission.GetQuestionPermission(ctx, userID, question.UserID, question.Status,
@@ -1080,6 +1286,26 @@ func (qs *QuestionService) GetQuestion(ctx context.Context, questionID, userID s
 	return question, nil
 }
 
+// filterVisibleLinkItems remove the linked questions of the spaces which can not be viewed by the user
+func (qs *QuestionService) filterVisibleLinkItems(ctx context.Context, items []*schema.QuestionLinkItem, userID string) (
+	[]*schema.QuestionLinkItem, error) {
+	questionIDs := make([]string, 0, len(items))
+	for _, item := range items {
+		questionIDs = append(questionIDs, item.QuestionID)
+	}
+	visible, err := qs.spaceCommon.GetVisibleQuestionIDs(ctx, questionIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+	visibleItems := make([]*schema.QuestionLinkItem, 0, len(items))
+	for _, item := range items {
+		if visible[item.QuestionID] {
+			visibleItems = append(visibleItems, item)
+		}
+	}
+	return visibleItems, nil
+}
+
 // GetQuestionAndAddPV get question one
 func (qs *QuestionService) GetQuestionAndAddPV(ctx context.Context, questionID, loginUserID string,
 	per schema.QuestionPermission) (
@@ -1088,9 +1314,15 @@ func (qs *QuestionService) GetQuestionAndAddPV(ctx context.Context, questionID,
 	if err != nil {
 		log.Error(err)
 	}
+	qs.questionHeatTracker.RecordView(uid.DeShortID(questionID))
 	return qs.GetQuestion(ctx, questionID, loginUserID, per)
 }
 
+// MarkQuestionExternallyLinked mark the question is visited from other sites, it's a signal of cache warming
+func (qs *QuestionService) MarkQuestionExternallyLinked(questionID string) {
+	qs.questionHeatTracker.MarkExternallyLinked(uid.DeShortID(questionID))
+}
+
 func (qs *QuestionService) InviteUserInfo(ctx context.Context, questionID string) (inviteList []*schema.UserBasicInfo, err error) {
 	return qs.questioncommon.InviteUserInfo(ctx, questionID)
 }
@@ -1099,6 +1331,121 @@ func (qs *QuestionService) ChangeTag(ctx context.Context, objectTagData *schema.
 	return qs.tagCommon.ObjectChangeTag(ctx, objectTagData)
 }
 
+// checkTagRules check the tags of question against the tag rules, the old tags are empty for new question
+func (qs *QuestionService) checkTagRules(ctx context.Context, tagNames []string, tags, oldTags []*entity.Tag,
+	canUseRestricted bool) (errorlist []*validator.FormErrorField, err error) {
+	if errorlist, err = qs.checkTagBlocklist(ctx, tagNames, oldTags); err != nil {
+		return errorlist, err
+	}
+	if errorlist, err = qs.checkTagDeprecated(ctx, tagNames, oldTags); err != nil {
+		return errorlist, err
+	}
+	tagRules, err := qs.siteInfoService.GetSiteTagRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(tagRules.Rules) == 0 {
+		return nil, nil
+	}
+	tagNames = append([]string{}, tagNames...)
+	for _, tag := range tags {
+		if len(tag.MainTagSlugName) > 0 {
+			tagNames = append(tagNames, tag.MainTagSlugName)
+		}
+	}
+	oldTagNames := make([]string, 0, len(oldTags))
+	for _, tag := range oldTags {
+		oldTagNames = append(oldTagNames, tag.SlugName)
+	}
+	violation := tagRules.Check(tagNames, oldTagNames, canUseRestricted)
+	if violation == nil {
+		return nil, nil
+	}
+	errReason := reason.TagRuleRequiredOneOf
+	if violation.Rule.Type == schema.TagRuleTypeModeratorOnly {
+		errReason = reason.TagRuleModeratorOnly
+	}
+	errMsg := translator.TrWithData(handler.GetLangByCtx(ctx), errReason, &schema.TagRuleTplData{
+		Name: violation.Rule.Name,
+		Tags: strings.Join(violation.Tags, ", "),
+	})
+	errorlist = append(errorlist, &validator.FormErrorField{
+		ErrorField: "tags",
+		ErrorMsg:   errMsg,
+	})
+	return errorlist, errors.BadRequest(errReason).WithMsg(errMsg)
+}
+
+// checkSpaceWrite check the user can ask the question in the space of its tags
+func (qs *QuestionService) checkSpaceWrite(ctx context.Context, tagNames []string, tags []*entity.Tag,
+	userID string) (err error) {
+	tagNames = append([]string{}, tagNames...)
+	for _, tag := range tags {
+		if len(tag.MainTagSlugName) > 0 {
+			tagNames = append(tagNames, tag.MainTagSlugName)
+		}
+	}
+	space, err := qs.spaceCommon.ResolveSpaceByTags(ctx, tagNames)
+	if err != nil {
+		return err
+	}
+	return qs.spaceCommon.CheckWrite(ctx, space, userID)
+}
+
+// checkTagBlocklist check none of the tags newly added to the question is blocked
+func (qs *QuestionService) checkTagBlocklist(ctx context.Context, tagNames []string, oldTags []*entity.Tag) (
+	errorlist []*validator.FormErrorField, err error) {
+	blockedTag, err := qs.tagCommon.GetBlockedTag(ctx, addedTagNames(tagNames, oldTags))
+	if err != nil || len(blockedTag) == 0 {
+		return nil, err
+	}
+	errMsg := translator.TrWithData(handler.GetLangByCtx(ctx), reason.TagBlocked, &schema.TagBlockedTplData{Tag: blockedTag})
+	errorlist = append(errorlist, &validator.FormErrorField{
+		ErrorField: "tags",
+		ErrorMsg:   errMsg,
+	})
+	return errorlist, errors.BadRequest(reason.TagBlocked).WithMsg(errMsg)
+}
+
+// checkTagDeprecated check none of the tags newly added to the question is deprecated,
+// the posts already carrying the deprecated tag can still be edited.
+func (qs *QuestionService) checkTagDeprecated(ctx context.Context, tagNames []string, oldTags []*entity.Tag) (
+	errorlist []*validator.FormErrorField, err error) {
+	deprecatedTag, err := qs.tagCommon.GetDeprecatedTag(ctx, addedTagNames(tagNames, oldTags))
+	if err != nil || deprecatedTag == nil {
+		return nil, err
+	}
+	errReason := reason.TagDeprecated
+	replacementTags := deprecatedTag.GetReplacementTags()
+	if len(replacementTags) > 0 {
+		errReason = reason.TagDeprecatedWithReplacement
+	}
+	errMsg := translator.TrWithData(handler.GetLangByCtx(ctx), errReason, &schema.TagDeprecatedTplData{
+		Tag:          deprecatedTag.SlugName,
+		Replacements: strings.Join(replacementTags, ", "),
+	})
+	errorlist = append(errorlist, &validator.FormErrorField{
+		ErrorField: "tags",
+		ErrorMsg:   errMsg,
+	})
+	return errorlist, errors.BadRequest(errReason).WithMsg(errMsg)
+}
+
+// addedTagNames the tag names that are not in the old tags
+func addedTagNames(tagNames []string, oldTags []*entity.Tag) (added []string) {
+	oldTagNames := make(map[string]bool, len(oldTags))
+	for _, tag := range oldTags {
+		oldTagNames[strings.ToLower(tag.SlugName)] = true
+	}
+	added = make([]string, 0, len(tagNames))
+	for _, name := range tagNames {
+		if !oldTagNames[strings.ToLower(name)] {
+			added = append(added, name)
+		}
+	}
+	return added
+}
+
 func (qs *QuestionService) CheckChangeReservedTag(ctx context.Context, oldobjectTagData, objectTagData []*entity.Tag) (bool, bool, []string, []string) {
 	return qs.tagCommon.CheckChangeReservedTag(ctx, oldobjectTagData, objectTagData)
 }
@@ -1177,6 +1524,10 @@ func (qs *QuestionService) PersonalAnswerPage(ctx context.Context, req *schema.P
 	if err != nil {
 		return nil, err
 	}
+	invisibleSpaces, err := qs.spaceCommon.GetInvisibleSpaces(ctx, req.LoginUserID)
+	if err != nil {
+		return nil, err
+	}
 
 	for _, item := range answerlist {
 		_, ok := questionMaps[item.QuestionID]
@@ -1185,6 +1536,9 @@ func (qs *QuestionService) PersonalAnswerPage(ctx context.Context, req *schema.P
 		} else {
 			continue
 		}
+		if space_common.HasInvisibleTag(invisibleSpaces, item.QuestionInfo.Tags) {
+			continue
+		}
 		info := &schema.UserAnswerInfo{}
 		_ = copier.Copy(info, item)
 		info.AnswerID = item.ID
@@ -1281,6 +1635,10 @@ func (qs *QuestionService) SearchUserTopList(ctx context.Context, userName strin
 	if err != nil {
 		return userQuestionlist, userAnswerlist, err
 	}
+	invisibleSpaces, err := qs.spaceCommon.GetInvisibleSpaces(ctx, loginUserID)
+	if err != nil {
+		return userQuestionlist, userAnswerlist, err
+	}
 	for _, item := range answerlist {
 		_, ok := questionMaps[item.QuestionID]
 		if ok {
@@ -1296,6 +1654,9 @@ func (qs *QuestionService) SearchUserTopList(ctx context.Context, userName strin
 	}
 
 	for _, item := range answerlist {
+		if item.QuestionInfo != nil && space_common.HasInvisibleTag(invisibleSpaces, item.QuestionInfo.Tags) {
+			continue
+		}
 		info := &schema.UserAnswerInfo{}
 		_ = copier.Copy(info, item)
 		info.AnswerID = item.ID
@@ -1308,7 +1669,7 @@ func (qs *QuestionService) SearchUserTopList(ctx context.Context, userName strin
 }
 
 // GetQuestionsByTitle get questions by title
-func (qs *QuestionService) GetQuestionsByTitle(ctx context.Context, title string) (
+func (qs *QuestionService) GetQuestionsByTitle(ctx context.Context, title, loginUserID string) (
 	resp []*schema.QuestionBaseInfo, err error) {
 	resp = make([]*schema.QuestionBaseInfo, 0)
 	if len(title) == 0 {
@@ -1343,10 +1704,21 @@ func (qs *QuestionService) GetQuestionsByTitle(ctx context.Context, title string
 		questions, err = qs.questionRepo.GetQuestionsByTitle(ctx, title, 10)
 	}
 
+	if err != nil {
+		return resp, err
+	}
+	questionIDs := make([]string, 0, len(questions))
+	for _, question := range questions {
+		questionIDs = append(questionIDs, question.ID)
+	}
+	visible, err := qs.spaceCommon.GetVisibleQuestionIDs(ctx, questionIDs, loginUserID)
 	if err != nil {
 		return resp, err
 	}
 	for _, question := range questions {
+		if !visible[question.ID] {
+			continue
+		}
 		item := &schema.QuestionBaseInfo{}
 		item.ID = question.ID
 		item.Title = question.Title
@@ -1414,8 +1786,36 @@ func (qs *QuestionService) GetQuestionPage(ctx context.Context, req *schema.Ques
 			showHidden = userRole == role.RoleAdminID || userRole == role.RoleModeratorID
 		}
 	}
+	// the space of the request overrides the default order, and the questions are limited to the space if no tag
+	space, err := qs.spaceCommon.ResolveSpace(ctx, req.Space, req.Tag)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(req.Space) > 0 && space == nil {
+		return questions, 0, nil
+	}
+	canView, err := qs.spaceCommon.CanViewSpace(ctx, space, req.LoginUserID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !canView {
+		return questions, 0, nil
+	}
+	if space != nil && len(req.OrderCond) == 0 {
+		req.OrderCond = space.DefaultOrder
+	}
+
 	// query by tag condition
 	var tagIDs = make([]string, 0)
+	if len(req.Tag) == 0 && space != nil {
+		tagIDs, err = qs.spaceCommon.GetSpaceTagIDs(ctx, space)
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(tagIDs) == 0 {
+			return questions, 0, nil
+		}
+	}
 	if len(req.Tag) > 0 {
 		tagInfo, exist, err := qs.tagCommon.GetTagBySlugName(ctx, strings.ToLower(req.Tag))
 		if err != nil {
@@ -1448,8 +1848,24 @@ func (qs *QuestionService) GetQuestionPage(ctx context.Context, req *schema.Ques
 		req.InDays = schema.HotInDays
 	}
 
+	// the questions carrying the tags ignored by the login user are excluded from the home feed,
+	// but not from the pages of a specific tag or user which are opened on purpose
+	var excludedTagIDs []string
+	if len(tagIDs) == 0 && len(req.UserIDBeSearched) == 0 {
+		excludedTagIDs, err = qs.tagService.GetIgnoredTagIDs(ctx, req.LoginUserID)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	// the questions of the private spaces which can not be viewed by the login user are always excluded
+	invisibleTagIDs, err := qs.spaceCommon.GetInvisibleTagIDs(ctx, req.LoginUserID)
+	if err != nil {
+		return nil, 0, err
+	}
+	excludedTagIDs = append(excludedTagIDs, invisibleTagIDs...)
+
 	questionList, total, err := qs.questionRepo.GetQuestionPage(ctx, req.Page, req.PageSize,
-		tagIDs, req.UserIDBeSearched, req.OrderCond, req.InDays, showHidden, req.ShowPending)
+		tagIDs, excludedTagIDs, req.UserIDBeSearched, req.OrderCond, req.InDays, showHidden, req.ShowPending)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -1488,7 +1904,16 @@ func (qs *QuestionService) GetRecommendQuestionPage(ctx context.Context, req *sc
 		}
 		followedQuestionIDs = append(followedQuestionIDs, activity.ObjectID)
 	}
-	questionList, total, err := qs.questionRepo.GetRecommendQuestionPageByTags(ctx, req.LoginUserID, tagIDs, followedQuestionIDs, req.Page, req.PageSize)
+	ignoredTagIDs, err := qs.tagService.GetIgnoredTagIDs(ctx, req.LoginUserID)
+	if err != nil {
+		return nil, 0, err
+	}
+	invisibleTagIDs, err := qs.spaceCommon.GetInvisibleTagIDs(ctx, req.LoginUserID)
+	if err != nil {
+		return nil, 0, err
+	}
+	ignoredTagIDs = append(ignoredTagIDs, invisibleTagIDs...)
+	questionList, total, err := qs.questionRepo.GetRecommendQuestionPageByTags(ctx, req.LoginUserID, tagIDs, ignoredTagIDs, followedQuestionIDs, req.Page, req.PageSize)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -1517,6 +1942,7 @@ func (qs *QuestionService) AdminSetQuestionStatus(ctx context.Context, req *sche
 	if err != nil {
 		return err
 	}
+	qs.recordQuestionStatus(ctx, req.UserID, questionInfo.ID, questionInfo.Status, setStatus)
 
 	msg := &schema.NotificationMsg{}
 	if setStatus == entity.QuestionStatusDeleted {
@@ -1576,6 +2002,18 @@ func (qs *QuestionService) AdminSetQuestionStatus(ctx context.Context, req *sche
 	return nil
 }
 
+// recordQuestionStatus record the status change of question in audit log
+func (qs *QuestionService) recordQuestionStatus(ctx context.Context, operatorID, questionID string, before, after int) {
+	qs.auditLogService.Record(ctx, &schema.AuditLogRecord{
+		OperatorID: operatorID,
+		Action:     entity.AuditLogActionQuestionStatus,
+		ObjectType: entity.AuditLogObjectTypeQuestion,
+		ObjectID:   questionID,
+		Before:     map[string]string{"status": entity.AdminQuestionSearchStatusIntToString[before]},
+		After:      map[string]string{"status": entity.AdminQuestionSearchStatusIntToString[after]},
+	})
+}
+
 func (qs *QuestionService) AdminQuestionPage(
 	ctx context.Context, req *schema.AdminQuestionPageReq) (
 	resp *pager.PageModel, err error) {
@@ -1665,7 +2103,22 @@ func (qs *QuestionService) SitemapCron(ctx context.Context) {
 		return
 	}
 	ctx = context.WithValue(ctx, constant.ShortIDFlag, siteSeo.IsShortLink())
-	qs.questioncommon.SitemapCron(ctx)
+	privateTagIDs, err := qs.spaceCommon.GetPrivateTagIDs(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	qs.questioncommon.SitemapCron(ctx, privateTagIDs)
+}
+
+// SitemapQuestions get the questions of the sitemap page, the questions of the private spaces are excluded
+func (qs *QuestionService) SitemapQuestions(ctx context.Context, page, pageSize int) (
+	questions []*schema.SiteMapQuestionInfo, err error) {
+	privateTagIDs, err := qs.spaceCommon.GetPrivateTagIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return qs.questionRepo.SitemapQuestions(ctx, page, pageSize, privateTagIDs)
 }
 
 func (qs *QuestionService) GetQuestionLink(ctx context.Context, req *schema.GetQuestionLinkReq) (
@@ -1678,6 +2131,23 @@ func (qs *QuestionService) GetQuestionLink(ctx context.Context, req *schema.GetQ
 	if err != nil {
 		return nil, 0, err
 	}
+	questionIDs := make([]string, 0, len(questionList))
+	for _, question := range questionList {
+		questionIDs = append(questionIDs, question.ID)
+	}
+	visible, err := qs.spaceCommon.GetVisibleQuestionIDs(ctx, questionIDs, req.LoginUserID)
+	if err != nil {
+		return nil, 0, err
+	}
+	visibleList := make([]*entity.Question, 0, len(questionList))
+	for _, question := range questionList {
+		if visible[question.ID] {
+			visibleList = append(visibleList, question)
+		} else {
+			total--
+		}
+	}
+	questionList = visibleList
 
 	questions, err = qs.questioncommon.FormatQuestionsPage(ctx, questionList, req.LoginUserID, req.OrderCond)
 	if err != nil {
@@ -1685,3 +2155,127 @@ func (qs *QuestionService) GetQuestionLink(ctx context.Context, req *schema.GetQ
 	}
 	return questions, total, nil
 }
+
+// ExportQuestion export question with the accepted and top voted answers as a pdf or markdown document
+func (qs *QuestionService) ExportQuestion(ctx context.Context, req *schema.ExportQuestionReq) (
+	resp *schema.ExportQuestionResp, err error) {
+	question, err := qs.GetQuestion(ctx, req.ID, req.UserID, schema.QuestionPermission{CanReopen: req.CanViewDeleted})
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Format) == 0 {
+		req.Format = schema.QuestionExportFormatPDF
+	}
+	if req.AnswerCount <= 0 {
+		req.AnswerCount = 5
+	}
+
+	// only the available answers are exported, in default order: the accepted first, then the top voted
+	answers, _, err := qs.answerRepo.SearchList(ctx, &entity.AnswerSearch{
+		Answer:   entity.Answer{QuestionID: question.ID},
+		Page:     1,
+		PageSize: req.AnswerCount,
+	})
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(answers))
+	for _, answer := range answers {
+		userIDs = append(userIDs, answer.UserID)
+	}
+	users, err := qs.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	questionURL := ""
+	if siteGeneral, err := qs.siteInfoService.GetSiteGeneral(ctx); err == nil {
+		permalink := constant.PermalinkQuestionID
+		if siteSeo, err := qs.siteInfoService.GetSiteSeo(ctx); err == nil {
+			permalink = siteSeo.Permalink
+		}
+		questionURL = display.QuestionURL(permalink, siteGeneral.SiteUrl, question.ID, question.Title)
+	}
+	displayName := func(user *schema.UserBasicInfo) string {
+		if user == nil {
+			return "-"
+		}
+		return user.DisplayName
+	}
+	tags := make([]string, 0, len(question.Tags))
+	for _, tag := range question.Tags {
+		tags = append(tags, tag.SlugName)
+	}
+	meta := fmt.Sprintf("Asked by %s on %s", displayName(question.UserInfo),
+		time.Unix(question.CreateTime, 0).UTC().Format(time.DateOnly))
+	if len(tags) > 0 {
+		meta += " | Tags: " + strings.Join(tags, ", ")
+	}
+	answerHeading := func(answer *entity.Answer) string {
+		heading := fmt.Sprintf("Answer by %s (%d votes)", displayName(users[answer.UserID]), answer.VoteCount)
+		if answer.Accepted == schema.AnswerAcceptedEnable {
+			heading = "Accepted " + heading
+		}
+		return heading
+	}
+	// the parsed html is exported rather than the markdown source, so the content is the same as the page shows
+	questionText := htmltext.PlainText(question.HTML)
+
+	resp = &schema.ExportQuestionResp{FileName: fmt.Sprintf("question-%s.%s", uid.EnShortID(question.ID), req.Format)}
+	if req.Format == schema.QuestionExportFormatMarkdown {
+		b := &strings.Builder{}
+		fmt.Fprintf(b, "# %s\n\n%s\n\n", question.Title, meta)
+		if len(questionURL) > 0 {
+			fmt.Fprintf(b, "<%s>\n\n", questionURL)
+		}
+		fmt.Fprintf(b, "%s\n", questionText)
+		for _, answer := range answers {
+			fmt.Fprintf(b, "\n---\n\n## %s\n\n%s\n", answerHeading(answer), htmltext.PlainText(answer.ParsedText))
+		}
+		resp.ContentType = "text/markdown; charset=utf-8"
+		resp.Data = []byte(b.String())
+		return resp, nil
+	}
+
+	doc := writer.NewPDFDocument()
+	if font := qs.getExportFont(); font != nil {
+		doc = writer.NewPDFDocumentWithFont(font)
+	}
+	doc.AddHeading(question.Title)
+	doc.AddParagraph(meta)
+	if len(questionURL) > 0 {
+		doc.AddParagraph(questionURL)
+	}
+	doc.AddSeparator()
+	doc.AddParagraph(questionText)
+	for _, answer := range answers {
+		doc.AddSeparator()
+		doc.AddHeading(answerHeading(answer))
+		doc.AddParagraph(htmltext.PlainText(answer.ParsedText))
+	}
+	resp.ContentType = "application/pdf"
+	resp.Data, err = doc.Bytes()
+	if err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return resp, nil
+}
+
+// getExportFont returns the configured font of the pdf export, nil means the default fonts are used.
+// The font is loaded only once, the failure is logged and the default fonts are used.
+func (qs *QuestionService) getExportFont() *writer.PDFFont {
+	qs.exportFontOnce.Do(func() {
+		if qs.serviceConfig == nil || len(qs.serviceConfig.PDFFontPath) == 0 {
+			return
+		}
+		data, err := os.ReadFile(qs.serviceConfig.PDFFontPath)
+		if err != nil {
+			log.Errorf("read pdf font failed: %v", err)
+			return
+		}
+		if qs.exportFont, err = writer.NewPDFFont(data); err != nil {
+			log.Errorf("load pdf font failed: %v", err)
+		}
+	})
+	return qs.exportFont
+}
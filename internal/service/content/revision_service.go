@@ -180,6 +180,15 @@ func (rs *RevisionService) RevisionAudit(ctx context.Context, req *schema.Revisi
 	return nil
 }
 
+// GetRevisionObjectID get the object id of revision, empty if the revision is not found
+func (rs *RevisionService) GetRevisionObjectID(ctx context.Context, revisionID string) (objectID string, err error) {
+	revisionInfo, exist, err := rs.revisionRepo.GetRevisionByID(ctx, revisionID)
+	if err != nil || !exist {
+		return "", err
+	}
+	return revisionInfo.ObjectID, nil
+}
+
 func (rs *RevisionService) revisionAuditQuestion(ctx context.Context, revisionitem *schema.GetRevisionResp) (err error) {
 	questioninfo, ok := revisionitem.ContentParsed.(*schema.QuestionInfoResp)
 	if ok {
@@ -360,6 +369,9 @@ func (rs *RevisionService) GetUnreviewedRevisionPage(ctx context.Context, req *s
 		_ = copier.Copy(revisionitem, rev)
 		rs.parseItem(ctx, revisionitem)
 		item.UnreviewedInfo = revisionitem
+		if tagInfo, ok := revisionitem.ContentParsed.(*schema.GetTagResp); ok && info != nil {
+			item.ContentDiff = converter.TextDiff(info.Content, tagInfo.OriginalText)
+		}
 
 		// get user info
 		userInfo, exists, e := rs.userCommon.GetUserBasicInfoByID(ctx, revisionitem.UserID)
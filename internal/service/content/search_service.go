@@ -21,26 +21,71 @@ package content
 
 import (
 	"context"
+	"strings"
 
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/search_common"
 	"github.com/apache/answer/internal/service/search_parser"
+	"github.com/apache/answer/internal/service/space_common"
+	tagcommon "github.com/apache/answer/internal/service/tag_common"
 	"github.com/apache/answer/plugin"
+	"github.com/segmentfault/pacman/log"
 )
 
+// hybridSearchMaxResults the max number of top results fused with semantic results,
+// the results after it are only ordered by keyword relevance
+const hybridSearchMaxResults = 100
+
 type SearchService struct {
-	searchParser *search_parser.SearchParser
-	searchRepo   search_common.SearchRepo
+	searchParser     *search_parser.SearchParser
+	searchRepo       search_common.SearchRepo
+	tagCommonService *tagcommon.TagCommonService
+	spaceCommon      *space_common.SpaceCommon
 }
 
 func NewSearchService(
 	searchParser *search_parser.SearchParser,
 	searchRepo search_common.SearchRepo,
+	tagCommonService *tagcommon.TagCommonService,
+	spaceCommon *space_common.SpaceCommon,
 ) *SearchService {
 	return &SearchService{
-		searchParser: searchParser,
-		searchRepo:   searchRepo,
+		searchParser:     searchParser,
+		searchRepo:       searchRepo,
+		tagCommonService: tagCommonService,
+		spaceCommon:      spaceCommon,
+	}
+}
+
+// Suggest get the question titles and tags matching the prefix
+func (ss *SearchService) Suggest(ctx context.Context, req *schema.SearchSuggestReq) (resp *schema.SearchSuggestResp, err error) {
+	resp = &schema.SearchSuggestResp{}
+	questions, err := ss.searchRepo.SuggestQuestions(ctx, req.Query, req.Size)
+	if err != nil {
+		return nil, err
+	}
+	questionIDs := make([]string, 0, len(questions))
+	for _, question := range questions {
+		questionIDs = append(questionIDs, question.ID)
+	}
+	visible, err := ss.spaceCommon.GetVisibleQuestionIDs(ctx, questionIDs, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	resp.Questions = make([]*schema.SearchSuggestQuestion, 0, len(questions))
+	for _, question := range questions {
+		if visible[question.ID] {
+			resp.Questions = append(resp.Questions, question)
+		}
+	}
+	resp.Tags, err = ss.tagCommonService.SearchTagLike(ctx, &schema.SearchTagLikeReq{Tag: req.Query})
+	if err != nil {
+		return nil, err
 	}
+	if len(resp.Tags) > req.Size {
+		resp.Tags = resp.Tags[:req.Size]
+	}
+	return resp, nil
 }
 
 // Search search contents
@@ -59,30 +104,127 @@ func (ss *SearchService) Search(ctx context.Context, dto *schema.SearchDTO) (res
 	cond := ss.searchParser.ParseStructure(ctx, dto)
 
 	// check search plugin
-	var finder plugin.Search
+	var (
+		finder       plugin.Search
+		vectorFinder plugin.VectorSearch
+	)
 	_ = plugin.CallSearch(func(search plugin.Search) error {
 		finder = search
 		return nil
 	})
+	_ = plugin.CallVectorSearch(func(search plugin.VectorSearch) error {
+		vectorFinder = search
+		return nil
+	})
 
+	// the semantic results only fuse into the top results ordered by relevance
+	if vectorFinder != nil && dto.Order == string(plugin.SearchRelevanceOrder) &&
+		cond.OnlyKeywords() && dto.Page*dto.Size <= hybridSearchMaxResults {
+		resp, err = ss.searchHybrid(ctx, finder, vectorFinder, cond, dto)
+	} else {
+		resp, err = ss.searchByKeyword(ctx, finder, cond, dto)
+	}
+	if err != nil {
+		return resp, err
+	}
+	return resp, ss.filterInvisibleResults(ctx, resp, dto.UserID)
+}
+
+// filterInvisibleResults remove the results of the private spaces which can not be viewed by the user,
+// the search plugins know nothing about the spaces so the results are always filtered after searching
+func (ss *SearchService) filterInvisibleResults(ctx context.Context, resp *schema.SearchResp, userID string) (err error) {
+	spaces, err := ss.spaceCommon.GetInvisibleSpaces(ctx, userID)
+	if err != nil || len(spaces) == 0 {
+		return err
+	}
+	results := make([]*schema.SearchResult, 0, len(resp.SearchResults))
+	for _, result := range resp.SearchResults {
+		if result.Object != nil && space_common.HasInvisibleTag(spaces, result.Object.Tags) {
+			resp.Total--
+			continue
+		}
+		results = append(results, result)
+	}
+	resp.SearchResults = results
+	return nil
+}
+
+// searchByKeyword search contents by the search plugin, or by system if search plugin is not found
+func (ss *SearchService) searchByKeyword(ctx context.Context, finder plugin.Search, cond *schema.SearchCondition, dto *schema.SearchDTO) (
+	resp *schema.SearchResp, err error) {
 	resp = &schema.SearchResp{}
 	// search plugin is not found, call system search
 	if finder == nil {
+		ss.searchParser.AnalyzeWords(cond)
 		if cond.SearchAll() {
-			resp.SearchResults, resp.Total, err =
-				ss.searchRepo.SearchContents(ctx, cond.Words, cond.Tags, cond.UserID, cond.VoteAmount, dto.Page, dto.Size, dto.Order)
+			resp.SearchResults, resp.Total, resp.Facets, err =
+				ss.searchRepo.SearchContents(ctx, cond.Words, cond.Tags, cond.UserID, cond.VoteAmount, dto.Page, dto.Size, dto.Order, dto.Facets)
 		} else if cond.SearchQuestion() {
-			resp.SearchResults, resp.Total, err =
-				ss.searchRepo.SearchQuestions(ctx, cond.Words, cond.Tags, cond.NotAccepted, cond.Views, cond.AnswerAmount, dto.Page, dto.Size, dto.Order)
+			resp.SearchResults, resp.Total, resp.Facets, err =
+				ss.searchRepo.SearchQuestions(ctx, cond.Words, cond.Tags, cond.NotAccepted, cond.Views, cond.AnswerAmount, cond.Language, dto.Page, dto.Size, dto.Order, dto.Facets)
 		} else if cond.SearchAnswer() {
-			resp.SearchResults, resp.Total, err =
-				ss.searchRepo.SearchAnswers(ctx, cond.Words, cond.Tags, cond.Accepted, cond.QuestionID, dto.Page, dto.Size, dto.Order)
+			resp.SearchResults, resp.Total, resp.Facets, err =
+				ss.searchRepo.SearchAnswers(ctx, cond.Words, cond.Tags, cond.Accepted, cond.QuestionID, dto.Page, dto.Size, dto.Order, dto.Facets)
+		} else if cond.SearchComment() {
+			resp.SearchResults, resp.Total, resp.Facets, err =
+				ss.searchRepo.SearchComments(ctx, cond.Words, cond.Tags, cond.UserID, cond.QuestionID, dto.Page, dto.Size, dto.Order, dto.Facets)
 		}
 		return
 	}
+	// the search plugins only index questions and answers, so the comments are always searched by system
+	if cond.SearchComment() {
+		resp.SearchResults, resp.Total, resp.Facets, err =
+			ss.searchRepo.SearchComments(ctx, cond.Words, cond.Tags, cond.UserID, cond.QuestionID, dto.Page, dto.Size, dto.Order, dto.Facets)
+		return
+	}
 	return ss.searchByPlugin(ctx, finder, cond, dto)
 }
 
+// searchHybrid fuse the keyword results and the semantic results of vector search plugin by rank fusion
+func (ss *SearchService) searchHybrid(ctx context.Context, finder plugin.Search, vectorFinder plugin.VectorSearch,
+	cond *schema.SearchCondition, dto *schema.SearchDTO) (resp *schema.SearchResp, err error) {
+	query := strings.Join(cond.Words, " ")
+	limit := dto.Page * dto.Size
+
+	// the fused results are paged by ourselves, so get all the top results from the first page
+	keywordDTO := *dto
+	keywordDTO.Page, keywordDTO.Size = 1, limit
+	resp, err = ss.searchByKeyword(ctx, finder, cond, &keywordDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	vectorResults, err := vectorFinder.QueryVectors(ctx, &plugin.VectorSearchCond{
+		Query: query,
+		Type:  cond.TargetType,
+		Limit: limit,
+	})
+	if err != nil {
+		// the keyword results are still available if the vector search is not working
+		log.Errorf("query vectors failed: %v", err)
+		vectorResults = nil
+	}
+	sres := make([]plugin.SearchResult, 0, len(vectorResults))
+	for _, r := range vectorResults {
+		sres = append(sres, plugin.SearchResult{ID: r.ID, Type: r.Type})
+	}
+	semantic, err := ss.searchRepo.ParseSearchPluginResult(ctx, sres, cond.Words)
+	if err != nil {
+		return nil, err
+	}
+
+	fused := schema.FuseSearchResults(resp.SearchResults, semantic)
+	if int64(len(fused)) > resp.Total {
+		resp.Total = int64(len(fused))
+	}
+	start := (dto.Page - 1) * dto.Size
+	if start > len(fused) {
+		start = len(fused)
+	}
+	resp.SearchResults = fused[start:min(start+dto.Size, len(fused))]
+	return resp, nil
+}
+
 func (ss *SearchService) searchByPlugin(ctx context.Context, finder plugin.Search, cond *schema.SearchCondition, dto *schema.SearchDTO) (resp *schema.SearchResp, err error) {
 	var res []plugin.SearchResult
 	resp = &schema.SearchResp{}
@@ -31,6 +31,7 @@ import (
 	"github.com/apache/answer/internal/base/constant"
 	questioncommon "github.com/apache/answer/internal/service/question_common"
 	"github.com/apache/answer/internal/service/user_notification_config"
+	"github.com/apache/answer/internal/service/user_timeline"
 
 	"github.com/apache/answer/internal/base/handler"
 	"github.com/apache/answer/internal/base/reason"
@@ -41,8 +42,10 @@ import (
 	"github.com/apache/answer/internal/service/activity"
 	"github.com/apache/answer/internal/service/activity_common"
 	"github.com/apache/answer/internal/service/auth"
+	"github.com/apache/answer/internal/service/badge"
 	"github.com/apache/answer/internal/service/export"
 	"github.com/apache/answer/internal/service/file_record"
+	"github.com/apache/answer/internal/service/invite_code"
 	"github.com/apache/answer/internal/service/role"
 	"github.com/apache/answer/internal/service/siteinfo_common"
 	usercommon "github.com/apache/answer/internal/service/user_common"
@@ -70,6 +73,9 @@ type UserService struct {
 	questionService               *questioncommon.QuestionCommon
 	eventQueueService             event_queue.EventQueueService
 	fileRecordService             *file_record.FileRecordService
+	userTimelineService           *user_timeline.UserTimelineService
+	badgeAwardRepo                badge.BadgeAwardRepo
+	inviteCodeService             *invite_code.InviteCodeService
 }
 
 func NewUserService(userRepo usercommon.UserRepo,
@@ -86,6 +92,9 @@ func NewUserService(userRepo usercommon.UserRepo,
 	questionService *questioncommon.QuestionCommon,
 	eventQueueService event_queue.EventQueueService,
 	fileRecordService *file_record.FileRecordService,
+	userTimelineService *user_timeline.UserTimelineService,
+	badgeAwardRepo badge.BadgeAwardRepo,
+	inviteCodeService *invite_code.InviteCodeService,
 ) *UserService {
 	return &UserService{
 		userCommonService:             userCommonService,
@@ -102,6 +111,9 @@ func NewUserService(userRepo usercommon.UserRepo,
 		questionService:               questionService,
 		eventQueueService:             eventQueueService,
 		fileRecordService:             fileRecordService,
+		inviteCodeService:             inviteCodeService,
+		userTimelineService:           userTimelineService,
+		badgeAwardRepo:                badgeAwardRepo,
 	}
 }
 
@@ -150,6 +162,12 @@ func (us *UserService) GetOtherUserInfoByUsername(ctx context.Context, req *sche
 		return nil, err
 	}
 	resp.QuestionCount = int(questionCount)
+
+	tagBadgeAwards, err := us.badgeAwardRepo.ListTagBadgeAwards(ctx, userInfo.ID)
+	if err != nil {
+		return nil, err
+	}
+	resp.TagBadges = schema.NewUserTagBadges(tagBadgeAwards)
 	return resp, nil
 }
 
@@ -169,6 +187,9 @@ func (us *UserService) EmailLogin(ctx context.Context, req *schema.UserEmailLogi
 	if !exist || userInfo.Status == entity.UserStatusDeleted {
 		return nil, errors.BadRequest(reason.EmailOrPasswordWrong)
 	}
+	if userInfo.UserType == entity.UserTypeBot {
+		return nil, errors.BadRequest(reason.BotNotAllowedLogin)
+	}
 	if !us.verifyPassword(ctx, req.Pass, userInfo.Pass) {
 		return nil, errors.BadRequest(reason.EmailOrPasswordWrong)
 	}
@@ -184,6 +205,7 @@ func (us *UserService) EmailLogin(ctx context.Context, req *schema.UserEmailLogi
 	if err != nil {
 		log.Errorf("update last login data failed, err: %v", err)
 	}
+	us.userTimelineService.RecordLogin(ctx, userInfo.ID, req.IP)
 
 	roleID, err := us.userRoleService.GetUserRole(ctx, userInfo.ID)
 	if err != nil {
@@ -221,7 +243,8 @@ func (us *UserService) RetrievePassWord(ctx context.Context, req *schema.UserRet
 	if err != nil {
 		return err
 	}
-	if !has {
+	// the bot can't log in, so it never resets the password
+	if !has || userInfo.UserType == entity.UserTypeBot {
 		return nil
 	}
 
@@ -441,6 +464,17 @@ func (us *UserService) UserUpdateInterface(ctx context.Context, req *schema.Upda
 func (us *UserService) UserRegisterByEmail(ctx context.Context, registerUserInfo *schema.UserRegisterReq) (
 	resp *schema.UserLoginResp, errFields []*validator.FormErrorField, err error,
 ) {
+	siteLogin, err := us.siteInfoService.GetSiteLogin(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !siteLogin.IsEmailDomainAllowed(registerUserInfo.Email) {
+		errFields = append(errFields, &validator.FormErrorField{
+			ErrorField: "e_mail",
+			ErrorMsg:   reason.EmailIllegalDomainError,
+		})
+		return nil, errFields, errors.BadRequest(reason.EmailIllegalDomainError)
+	}
 	_, has, err := us.userRepo.GetByEmail(ctx, registerUserInfo.Email)
 	if err != nil {
 		return nil, nil, err
@@ -472,13 +506,21 @@ func (us *UserService) UserRegisterByEmail(ctx context.Context, registerUserInfo
 	userInfo.MailStatus = entity.EmailStatusToBeVerified
 	userInfo.Status = entity.UserStatusAvailable
 	userInfo.LastLoginDate = time.Now()
+	// the invite code is used before the user is added, so it cannot be used more than the max uses
+	inviteCodeID, err := us.inviteCodeService.UseInviteCode(ctx, registerUserInfo.InviteCode)
+	if err != nil {
+		return nil, nil, err
+	}
 	err = us.userRepo.AddUser(ctx, userInfo)
 	if err != nil {
+		us.inviteCodeService.ReleaseInviteCode(ctx, inviteCodeID)
 		return nil, nil, err
 	}
+	us.inviteCodeService.RecordInvitedUser(ctx, inviteCodeID, userInfo.ID)
 	if err := us.userNotificationConfigService.SetDefaultUserNotificationConfig(ctx, []string{userInfo.ID}); err != nil {
 		log.Errorf("set default user notification config failed, err: %v", err)
 	}
+	us.eventQueueService.Send(ctx, schema.NewEvent(constant.EventUserCreate, userInfo.ID))
 
 	// send email
 	data := &schema.EmailCodeContent{
@@ -818,7 +860,15 @@ func (us *UserService) UserUnsubscribeNotification(
 			return err
 		}
 		if !exist {
-			continue
+			// the re-engagement emails are sent to the user without config, so the config is added to opt out
+			if source != constant.ReEngagementSource {
+				continue
+			}
+			notificationConfig = &entity.UserNotificationConfig{
+				UserID:   data.UserID,
+				Source:   string(source),
+				Channels: `[{"key":"email","enable":true}]`,
+			}
 		}
 		channels := schema.NewNotificationChannelsFormJson(notificationConfig.Channels)
 		// unsubscribe email notification
@@ -36,7 +36,9 @@ import (
 	"github.com/apache/answer/internal/service/comment_common"
 	"github.com/apache/answer/internal/service/config"
 	"github.com/apache/answer/internal/service/object_info"
+	"github.com/apache/answer/internal/service/siteinfo_common"
 	"github.com/apache/answer/pkg/htmltext"
+	"github.com/apache/answer/pkg/obj"
 	"github.com/segmentfault/pacman/log"
 
 	"github.com/apache/answer/internal/base/reason"
@@ -53,6 +55,10 @@ type VoteRepo interface {
 	GetAndSaveVoteResult(ctx context.Context, objectID, objectType string) (up, down int64, err error)
 	ListUserVotes(ctx context.Context, userID string, page int, pageSize int, activityTypes []int) (
 		voteList []*entity.Activity, total int64, err error)
+	RevokeUserVotesAndReputation(ctx context.Context, userID string, voteActivityTypes []int) (
+		votedObjectIDs []string, err error)
+	RevokeUserCastVotes(ctx context.Context, voterID, operatorID string,
+		castActivityTypes, votedActivityTypes, voteUpActivityTypes []int) (invalidations []*entity.VoteInvalidation, err error)
 }
 
 // VoteService user service
@@ -65,6 +71,7 @@ type VoteService struct {
 	objectService     *object_info.ObjService
 	activityRepo      activity_common.ActivityRepo
 	eventQueueService event_queue.EventQueueService
+	siteInfoService   siteinfo_common.SiteInfoCommonService
 }
 
 func NewVoteService(
@@ -75,6 +82,7 @@ func NewVoteService(
 	commentCommonRepo comment_common.CommentCommonRepo,
 	objectService *object_info.ObjService,
 	eventQueueService event_queue.EventQueueService,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
 ) *VoteService {
 	return &VoteService{
 		voteRepo:          voteRepo,
@@ -84,6 +92,7 @@ func NewVoteService(
 		commentCommonRepo: commentCommonRepo,
 		objectService:     objectService,
 		eventQueueService: eventQueueService,
+		siteInfoService:   siteInfoService,
 	}
 }
 
@@ -187,6 +196,94 @@ func (vs *VoteService) VoteDown(ctx context.Context, req *schema.VoteReq) (resp
 	return resp, nil
 }
 
+// RevokeVote cancel the vote of user on the object, no matter it is vote up or vote down.
+// It is used to reverse the invalid votes, so no event is sent.
+func (vs *VoteService) RevokeVote(ctx context.Context, userID, objectID string) (err error) {
+	objectInfo, err := vs.objectService.GetInfo(ctx, objectID)
+	if err != nil {
+		return err
+	}
+	objectInfo.ObjectID = objectID
+
+	if err = vs.voteRepo.CancelVote(ctx, vs.createVoteOperationInfo(ctx, userID, true, objectInfo)); err != nil {
+		return err
+	}
+	if err = vs.voteRepo.CancelVote(ctx, vs.createVoteOperationInfo(ctx, userID, false, objectInfo)); err != nil {
+		return err
+	}
+	_, _, err = vs.voteRepo.GetAndSaveVoteResult(ctx, objectID, objectInfo.ObjectType)
+	return err
+}
+
+// InvalidateSpammerVotes reverse the reputation granted by the votes of spammer and all the reputation spammer earned.
+// The votes and reputation are reversed in one transaction, then the vote count of voted objects is recalculated.
+func (vs *VoteService) InvalidateSpammerVotes(ctx context.Context, userID string) (err error) {
+	voteActivityTypes := make([]int, 0, len(activity_type.VoteActivityTypeList))
+	for _, typeKey := range activity_type.VoteActivityTypeList {
+		cfg, err := vs.configService.GetConfigByKey(ctx, typeKey)
+		if err != nil {
+			log.Warnf("get config by key error: %v", err)
+			continue
+		}
+		voteActivityTypes = append(voteActivityTypes, cfg.ID)
+	}
+
+	objectIDs, err := vs.voteRepo.RevokeUserVotesAndReputation(ctx, userID, voteActivityTypes)
+	if err != nil {
+		return err
+	}
+	for _, objectID := range objectIDs {
+		objectType, err := obj.GetObjectTypeStrByObjectID(objectID)
+		if err != nil {
+			continue
+		}
+		if _, _, err = vs.voteRepo.GetAndSaveVoteResult(ctx, objectID, objectType); err != nil {
+			log.Error(err)
+		}
+	}
+	log.Infof("invalidated votes of spammer %s on %d objects", userID, len(objectIDs))
+	return nil
+}
+
+// ReverseUserCastVotes reverse all the votes cast by the voter, the scores of voted objects and the reputation
+// are updated. Returns the reversed votes.
+func (vs *VoteService) ReverseUserCastVotes(ctx context.Context, voterID, operatorID string) (
+	invalidations []*entity.VoteInvalidation, err error) {
+	getActivityTypes := func(keys ...string) (activityTypes []int) {
+		for _, key := range keys {
+			cfg, err := vs.configService.GetConfigByKey(ctx, key)
+			if err != nil {
+				log.Warnf("get config by key error: %v", err)
+				continue
+			}
+			activityTypes = append(activityTypes, cfg.ID)
+		}
+		return activityTypes
+	}
+	castTypes := getActivityTypes(activity_type.QuestionVoteUp, activity_type.QuestionVoteDown,
+		activity_type.AnswerVoteUp, activity_type.AnswerVoteDown, activity_type.CommentVoteUp)
+	votedTypes := getActivityTypes(activity_type.QuestionVotedUp, activity_type.QuestionVotedDown,
+		activity_type.AnswerVotedUp, activity_type.AnswerVotedDown)
+	voteUpTypes := getActivityTypes(activity_type.QuestionVoteUp, activity_type.QuestionVotedUp,
+		activity_type.AnswerVoteUp, activity_type.AnswerVotedUp, activity_type.CommentVoteUp)
+
+	invalidations, err = vs.voteRepo.RevokeUserCastVotes(ctx, voterID, operatorID, castTypes, votedTypes, voteUpTypes)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range invalidations {
+		objectType, err := obj.GetObjectTypeStrByObjectID(record.ObjectID)
+		if err != nil {
+			continue
+		}
+		if _, _, err = vs.voteRepo.GetAndSaveVoteResult(ctx, record.ObjectID, objectType); err != nil {
+			log.Error(err)
+		}
+	}
+	log.Infof("user %s reversed %d votes cast by user %s", operatorID, len(invalidations), voterID)
+	return invalidations, nil
+}
+
 // ListUserVotes list user's votes
 func (vs *VoteService) ListUserVotes(ctx context.Context, req schema.GetVoteWithPageReq) (resp *pager.PageModel, err error) {
 	typeKeys := []string{
@@ -287,6 +384,9 @@ func (vs *VoteService) getActivities(ctx context.Context, op *schema.VoteOperati
 			continue
 		}
 		t.ActivityType, t.Rank = cfg.ID, cfg.GetIntValue()
+		if cost, ok := vs.getDownvoteCost(ctx, action); ok {
+			t.Rank = -cost
+		}
 
 		if strings.Contains(action, "voted") {
 			t.ActivityUserID = op.ObjectCreatorUserID
@@ -300,6 +400,25 @@ func (vs *VoteService) getActivities(ctx context.Context, op *schema.VoteOperati
 	return activities
 }
 
+// getDownvoteCost get the reputation cost of downvoter if the downvote cost is enabled
+func (vs *VoteService) getDownvoteCost(ctx context.Context, action string) (cost int, ok bool) {
+	if action != activity_type.QuestionVoteDown && action != activity_type.AnswerVoteDown {
+		return 0, false
+	}
+	conf, err := vs.siteInfoService.GetSiteDownvoteCost(ctx)
+	if err != nil {
+		log.Errorf("get site downvote cost failed: %v", err)
+		return 0, false
+	}
+	if !conf.Enabled {
+		return 0, false
+	}
+	if action == activity_type.QuestionVoteDown {
+		return conf.QuestionCost, true
+	}
+	return conf.AnswerCost, true
+}
+
 func (vs *VoteService) sendEvent(ctx context.Context,
 	req *schema.VoteReq, objectInfo *schema.SimpleObjectInfo, resp *schema.VoteResp) {
 	var event *schema.EventMsg
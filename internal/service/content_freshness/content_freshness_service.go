@@ -0,0 +1,163 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content_freshness
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	tagcommon "github.com/apache/answer/internal/service/tag_common"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// ContentFreshnessRepo content freshness repository
+type ContentFreshnessRepo interface {
+	AnalyzeContentFreshness(ctx context.Context, activeSince, staleBefore time.Time, deprecatedVersions []string) (
+		stats []*entity.ContentFreshnessStat, err error)
+	ReplaceContentFreshnessStats(ctx context.Context, stats []*entity.ContentFreshnessStat) (err error)
+	GetContentFreshnessStat(ctx context.Context, tagID string) (stat *entity.ContentFreshnessStat, exist bool, err error)
+	GetContentFreshnessStatPage(ctx context.Context, page, pageSize int) (
+		stats []*entity.ContentFreshnessStat, total int64, err error)
+}
+
+// ContentFreshnessService the freshness report of the content to guide the documentation cleanup,
+// the report is analyzed by the scheduler instead of live queries.
+type ContentFreshnessService struct {
+	contentFreshnessRepo ContentFreshnessRepo
+	siteInfoService      siteinfo_common.SiteInfoCommonService
+	tagCommonService     *tagcommon.TagCommonService
+	running              atomic.Bool
+}
+
+// NewContentFreshnessService new content freshness service
+func NewContentFreshnessService(
+	contentFreshnessRepo ContentFreshnessRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	tagCommonService *tagcommon.TagCommonService,
+) *ContentFreshnessService {
+	return &ContentFreshnessService{
+		contentFreshnessRepo: contentFreshnessRepo,
+		siteInfoService:      siteInfoService,
+		tagCommonService:     tagCommonService,
+	}
+}
+
+// AnalyzeCron analyze the content freshness once a day
+func (cs *ContentFreshnessService) AnalyzeCron(ctx context.Context) {
+	if err := cs.Analyze(ctx); err != nil {
+		log.Errorf("analyze content freshness failed: %v", err)
+	}
+}
+
+// TriggerAnalyze analyze the content freshness in the background
+func (cs *ContentFreshnessService) TriggerAnalyze(ctx context.Context) (err error) {
+	if cs.running.Load() {
+		return errors.BadRequest(reason.ContentFreshnessInProgress)
+	}
+	go cs.AnalyzeCron(context.Background())
+	return nil
+}
+
+// Analyze analyze the freshness metrics of the active tags and replace the report
+func (cs *ContentFreshnessService) Analyze(ctx context.Context) (err error) {
+	if !cs.running.CompareAndSwap(false, true) {
+		return errors.BadRequest(reason.ContentFreshnessInProgress)
+	}
+	defer cs.running.Store(false)
+
+	siteInfo, err := cs.siteInfoService.GetSiteContentFreshness(ctx)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	stats, err := cs.contentFreshnessRepo.AnalyzeContentFreshness(ctx,
+		now.AddDate(0, 0, -siteInfo.ActiveTagDays), now.AddDate(-siteInfo.StaleYears, 0, 0), siteInfo.DeprecatedVersions)
+	if err != nil {
+		return err
+	}
+	if err = cs.contentFreshnessRepo.ReplaceContentFreshnessStats(ctx, stats); err != nil {
+		return err
+	}
+	log.Infof("analyze content freshness finished, %d active tags", max(len(stats)-1, 0))
+	return nil
+}
+
+// GetContentFreshnessReport get the freshness report of all active tags and the page of tags, the most stale first
+func (cs *ContentFreshnessService) GetContentFreshnessReport(ctx context.Context, req *schema.GetContentFreshnessReportReq) (
+	resp *schema.GetContentFreshnessReportResp, err error) {
+	siteInfo, err := cs.siteInfoService.GetSiteContentFreshness(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp = &schema.GetContentFreshnessReportResp{
+		StaleYears:         siteInfo.StaleYears,
+		ActiveTagDays:      siteInfo.ActiveTagDays,
+		DeprecatedVersions: siteInfo.DeprecatedVersions,
+		Site:               &schema.ContentFreshnessItem{},
+		List:               make([]*schema.ContentFreshnessItem, 0),
+	}
+	siteStat, exist, err := cs.contentFreshnessRepo.GetContentFreshnessStat(ctx, "0")
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return resp, nil
+	}
+	resp.GeneratedAt = siteStat.CreatedAt.Unix()
+	resp.Site = schema.NewContentFreshnessItem(siteStat)
+
+	stats, total, err := cs.contentFreshnessRepo.GetContentFreshnessStatPage(ctx, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	resp.Total = total
+	tagIDs := make([]string, 0, len(stats))
+	for _, stat := range stats {
+		tagIDs = append(tagIDs, stat.TagID)
+	}
+	tags, err := cs.tagCommonService.GetTagListByIDs(ctx, tagIDs)
+	if err != nil {
+		return nil, err
+	}
+	cs.tagCommonService.LocalizeTags(ctx, tags)
+	tagMapping := make(map[string]*schema.TagResp, len(tags))
+	for _, tag := range tags {
+		tagMapping[tag.ID] = &schema.TagResp{
+			ID:              tag.ID,
+			SlugName:        tag.SlugName,
+			DisplayName:     tag.DisplayName,
+			MainTagSlugName: tag.MainTagSlugName,
+			Recommend:       tag.Recommend,
+			Reserved:        tag.Reserved,
+		}
+	}
+	for _, stat := range stats {
+		item := schema.NewContentFreshnessItem(stat)
+		item.Tag = tagMapping[stat.TagID]
+		resp.List = append(resp.List, item)
+	}
+	return resp, nil
+}
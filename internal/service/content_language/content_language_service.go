@@ -0,0 +1,140 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content_language
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/apache/answer/pkg/checker"
+	"github.com/apache/answer/plugin"
+	"github.com/segmentfault/pacman/log"
+)
+
+// ContentLanguageRepo content language repository
+type ContentLanguageRepo interface {
+	IncrLanguageStat(ctx context.Context, language string, review, translated bool) (err error)
+	GetLanguageStats(ctx context.Context) (stats []*entity.ContentLanguageStat, err error)
+}
+
+// ContentLanguageService detect the language of posts and translate them if needed
+type ContentLanguageService struct {
+	contentLanguageRepo ContentLanguageRepo
+	siteInfoService     siteinfo_common.SiteInfoCommonService
+}
+
+// NewContentLanguageService new content language service
+func NewContentLanguageService(
+	contentLanguageRepo ContentLanguageRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *ContentLanguageService {
+	return &ContentLanguageService{
+		contentLanguageRepo: contentLanguageRepo,
+		siteInfoService:     siteInfoService,
+	}
+}
+
+// Detect the language of text, returns nil if the detection is disabled or the language can not be detected
+func (cs *ContentLanguageService) Detect(ctx context.Context, text string) (result *schema.ContentLanguageResult) {
+	conf, err := cs.siteInfoService.GetSiteLanguageDetection(ctx)
+	if err != nil {
+		log.Errorf("get site language detection config failed: %v", err)
+		return nil
+	}
+	if !conf.Enabled || utf8.RuneCountInString(text) < conf.MinLength {
+		return nil
+	}
+	language, confidence := checker.DetectLanguage(text)
+	if len(language) == 0 || confidence*100 < float64(conf.MinConfidence) {
+		return nil
+	}
+
+	siteLanguage := ""
+	if siteInterface, _ := cs.siteInfoService.GetSiteInterface(ctx); siteInterface != nil {
+		// the site language is like en_US, only the language code is compared
+		siteLanguage, _, _ = strings.Cut(strings.ToLower(siteInterface.Language), "_")
+	}
+	allowedLanguages := conf.AllowedLanguages
+	if len(allowedLanguages) == 0 {
+		allowedLanguages = []string{siteLanguage}
+	}
+	result = &schema.ContentLanguageResult{
+		Language:       language,
+		Action:         conf.Action,
+		TargetLanguage: siteLanguage,
+	}
+	for _, allowed := range allowedLanguages {
+		if strings.EqualFold(allowed, language) {
+			result.Allowed = true
+			break
+		}
+	}
+	return result
+}
+
+// Translate the text by the translator plugin, ok is false if there is no translator or it failed
+func (cs *ContentLanguageService) Translate(text, sourceLanguage, targetLanguage string) (translated string, ok bool) {
+	_ = plugin.CallContentTranslator(func(translator plugin.ContentTranslator) error {
+		if ok {
+			return nil
+		}
+		result, err := translator.Translate(text, sourceLanguage, targetLanguage)
+		if err != nil {
+			log.Errorf("translate by %s failed: %v", translator.Info().SlugName, err)
+			return nil
+		}
+		translated, ok = result, true
+		return nil
+	})
+	return translated, ok
+}
+
+// RecordStat record the statistics of the detected language
+func (cs *ContentLanguageService) RecordStat(ctx context.Context, result *schema.ContentLanguageResult, review, translated bool) {
+	if result == nil {
+		return
+	}
+	if err := cs.contentLanguageRepo.IncrLanguageStat(ctx, result.Language, review, translated); err != nil {
+		log.Errorf("record content language stat failed: %v", err)
+	}
+}
+
+// GetLanguageStats get the statistics of all detected languages
+func (cs *ContentLanguageService) GetLanguageStats(ctx context.Context) (resp []*schema.GetContentLanguageStatResp, err error) {
+	stats, err := cs.contentLanguageRepo.GetLanguageStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.GetContentLanguageStatResp, 0, len(stats))
+	for _, stat := range stats {
+		resp = append(resp, &schema.GetContentLanguageStatResp{
+			Language:        stat.Language,
+			PostCount:       stat.PostCount,
+			ReviewCount:     stat.ReviewCount,
+			TranslatedCount: stat.TranslatedCount,
+			UpdatedAt:       stat.UpdatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
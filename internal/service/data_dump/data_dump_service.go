@@ -0,0 +1,459 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package data_dump
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/service_config"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/apache/answer/internal/service/space_common"
+	"github.com/apache/answer/pkg/dir"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// dataDumpBatchSize the number of records read from database at once
+const dataDumpBatchSize = 500
+
+// DataDumpRepo public data dump repository
+type DataDumpRepo interface {
+	GetQuestionsAfter(ctx context.Context, lastID string, limit int, excludedTagIDs []string) (
+		questions []*entity.Question, err error)
+	GetAnswersAfter(ctx context.Context, lastID string, limit int, excludedTagIDs []string) (
+		answers []*entity.Answer, err error)
+	GetTagsAfter(ctx context.Context, lastID string, limit int) (tags []*entity.Tag, err error)
+	GetUsersAfter(ctx context.Context, lastID string, limit int) (users []*entity.User, err error)
+	GetTagSlugNamesByObjectIDs(ctx context.Context, objectIDs []string) (mapping map[string][]string, err error)
+}
+
+// DataDumpService public data dump service
+type DataDumpService struct {
+	dataDumpRepo    DataDumpRepo
+	siteInfoRepo    siteinfo_common.SiteInfoRepo
+	siteInfoService siteinfo_common.SiteInfoCommonService
+	serviceConfig   *service_config.ServiceConfig
+	spaceCommon     *space_common.SpaceCommon
+	running         atomic.Bool
+}
+
+// NewDataDumpService new public data dump service
+func NewDataDumpService(
+	dataDumpRepo DataDumpRepo,
+	siteInfoRepo siteinfo_common.SiteInfoRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	serviceConfig *service_config.ServiceConfig,
+	spaceCommon *space_common.SpaceCommon,
+) *DataDumpService {
+	return &DataDumpService{
+		dataDumpRepo:    dataDumpRepo,
+		siteInfoRepo:    siteInfoRepo,
+		siteInfoService: siteInfoService,
+		serviceConfig:   serviceConfig,
+		spaceCommon:     spaceCommon,
+	}
+}
+
+// GetDataDumpInfo get the latest public data dump info
+func (ds *DataDumpService) GetDataDumpInfo(ctx context.Context) (resp *schema.GetDataDumpInfoResp, err error) {
+	conf, err := ds.siteInfoService.GetSiteDataDump(ctx)
+	if err != nil {
+		return nil, err
+	}
+	state, err := ds.getState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp = &schema.GetDataDumpInfoResp{
+		Enabled:       conf.Enabled,
+		InProgress:    ds.running.Load(),
+		FormatVersion: schema.DataDumpFormatVersion,
+		License:       conf.License,
+		LicenseURL:    conf.LicenseURL,
+	}
+	if conf.Enabled && len(state.FileName) > 0 && dir.CheckFileExist(ds.dumpFilePath(state.FileName)) {
+		resp.Available = true
+		resp.FileName = state.FileName
+		resp.FileSize = state.FileSize
+		resp.GeneratedAt = state.GeneratedAt
+	}
+	return resp, nil
+}
+
+// GetLatestDumpFile get the local file path and file name of the latest public data dump
+func (ds *DataDumpService) GetLatestDumpFile(ctx context.Context) (filePath, fileName string, err error) {
+	info, err := ds.GetDataDumpInfo(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	if !info.Available {
+		return "", "", errors.NotFound(reason.DataDumpNotFound)
+	}
+	return ds.dumpFilePath(info.FileName), info.FileName, nil
+}
+
+// TriggerDataDump generate a new public data dump in background
+func (ds *DataDumpService) TriggerDataDump(ctx context.Context) (err error) {
+	if ds.running.Load() {
+		return errors.BadRequest(reason.DataDumpInProgress)
+	}
+	go func() {
+		if err := ds.GenerateDataDump(context.Background()); err != nil {
+			log.Errorf("generate public data dump failed: %v", err)
+		}
+	}()
+	return nil
+}
+
+// DataDumpCron generate the public data dump if it is enabled and the latest one is out of date
+func (ds *DataDumpService) DataDumpCron(ctx context.Context) {
+	conf, err := ds.siteInfoService.GetSiteDataDump(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if !conf.Enabled {
+		return
+	}
+	state, err := ds.getState(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	nextTime := time.Unix(state.GeneratedAt, 0).Add(time.Duration(conf.IntervalDays) * 24 * time.Hour)
+	if state.GeneratedAt > 0 && time.Now().Before(nextTime) {
+		return
+	}
+	if err = ds.GenerateDataDump(ctx); err != nil {
+		log.Errorf("generate public data dump failed: %v", err)
+	}
+}
+
+// GenerateDataDump generate a new public data dump and replace the previous one
+func (ds *DataDumpService) GenerateDataDump(ctx context.Context) (err error) {
+	if !ds.running.CompareAndSwap(false, true) {
+		return errors.BadRequest(reason.DataDumpInProgress)
+	}
+	defer ds.running.Store(false)
+
+	conf, err := ds.siteInfoService.GetSiteDataDump(ctx)
+	if err != nil {
+		return err
+	}
+	general, err := ds.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return err
+	}
+	state, err := ds.getState(ctx)
+	if err != nil {
+		return err
+	}
+	if len(state.Salt) == 0 {
+		state.Salt = generateSalt()
+		if err = ds.saveState(ctx, state); err != nil {
+			return err
+		}
+	}
+
+	if err = dir.CreateDirIfNotExist(filepath.Join(ds.serviceConfig.UploadPath, constant.DataDumpSubPath)); err != nil {
+		return err
+	}
+	now := time.Now()
+	fileName := fmt.Sprintf("answer_data_dump_%s.zip", now.Format("20060102150405"))
+	tmpFilePath := ds.dumpFilePath(fileName) + ".tmp"
+	log.Infof("start to generate public data dump %s", fileName)
+
+	file, err := os.Create(tmpFilePath)
+	if err != nil {
+		return err
+	}
+	// the temp file is removed on any failure, it no longer exists once renamed to the dump file
+	defer func() {
+		_ = file.Close()
+		_ = os.Remove(tmpFilePath)
+	}()
+	zw := zip.NewWriter(file)
+	metadata := &schema.DataDumpMetadata{
+		FormatVersion: schema.DataDumpFormatVersion,
+		SiteName:      general.Name,
+		SiteURL:       general.SiteUrl,
+		License:       conf.License,
+		LicenseURL:    conf.LicenseURL,
+		Attribution: fmt.Sprintf("Content from %s (%s), licensed under %s. Link back to the original post when you use it.",
+			general.Name, general.SiteUrl, conf.License),
+		GeneratedAt: now.Unix(),
+	}
+	writers := []struct {
+		name       string
+		recordType string
+		write      func(ctx context.Context, enc *json.Encoder, salt string) (int64, error)
+	}{
+		{schema.DataDumpQuestionsFileName, "question", ds.writeQuestions},
+		{schema.DataDumpAnswersFileName, "answer", ds.writeAnswers},
+		{schema.DataDumpTagsFileName, "tag", ds.writeTags},
+		{schema.DataDumpUsersFileName, "user", ds.writeUsers},
+	}
+	for _, w := range writers {
+		fw, err := zw.Create(w.name)
+		if err != nil {
+			return err
+		}
+		count, err := w.write(ctx, json.NewEncoder(fw), state.Salt)
+		if err != nil {
+			return err
+		}
+		metadata.Files = append(metadata.Files, &schema.DataDumpFileMetadata{
+			Name: w.name, RecordType: w.recordType, RecordCount: count})
+	}
+	fw, err := zw.Create(schema.DataDumpMetadataFileName)
+	if err == nil {
+		enc := json.NewEncoder(fw)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(metadata)
+	}
+	if err == nil {
+		err = zw.Close()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if err = os.Rename(tmpFilePath, ds.dumpFilePath(fileName)); err != nil {
+		return err
+	}
+	fileInfo, err := os.Stat(ds.dumpFilePath(fileName))
+	if err != nil {
+		return err
+	}
+
+	previousFileName := state.FileName
+	state.FileName = fileName
+	state.FileSize = fileInfo.Size()
+	state.GeneratedAt = now.Unix()
+	if err = ds.saveState(ctx, state); err != nil {
+		return err
+	}
+	if len(previousFileName) > 0 && previousFileName != fileName {
+		if err := os.Remove(ds.dumpFilePath(previousFileName)); err != nil && !os.IsNotExist(err) {
+			log.Warnf("remove previous public data dump failed: %v", err)
+		}
+	}
+	log.Infof("public data dump %s generated, size %d", fileName, state.FileSize)
+	return nil
+}
+
+func (ds *DataDumpService) writeQuestions(ctx context.Context, enc *json.Encoder, salt string) (count int64, err error) {
+	// the questions of the private spaces are never published
+	privateTagIDs, err := ds.spaceCommon.GetPrivateTagIDs(ctx)
+	if err != nil {
+		return 0, err
+	}
+	lastID := "0"
+	for {
+		questions, err := ds.dataDumpRepo.GetQuestionsAfter(ctx, lastID, dataDumpBatchSize, privateTagIDs)
+		if err != nil {
+			return count, err
+		}
+		if len(questions) == 0 {
+			return count, nil
+		}
+		questionIDs := make([]string, 0, len(questions))
+		for _, question := range questions {
+			questionIDs = append(questionIDs, question.ID)
+		}
+		tagMapping, err := ds.dataDumpRepo.GetTagSlugNamesByObjectIDs(ctx, questionIDs)
+		if err != nil {
+			return count, err
+		}
+		for _, question := range questions {
+			record := &schema.DataDumpQuestion{
+				ID:          question.ID,
+				Title:       question.Title,
+				Body:        question.OriginalText,
+				Tags:        tagMapping[question.ID],
+				UserID:      anonymizeUserID(salt, question.UserID),
+				Closed:      question.Status == entity.QuestionStatusClosed,
+				VoteCount:   question.VoteCount,
+				AnswerCount: question.AnswerCount,
+				ViewCount:   question.ViewCount,
+				CreatedAt:   question.CreatedAt.Unix(),
+				UpdatedAt:   question.UpdatedAt.Unix(),
+			}
+			if question.AcceptedAnswerID != "0" {
+				record.AcceptedAnswerID = question.AcceptedAnswerID
+			}
+			if record.Tags == nil {
+				record.Tags = make([]string, 0)
+			}
+			if err = enc.Encode(record); err != nil {
+				return count, err
+			}
+			count++
+		}
+		lastID = questions[len(questions)-1].ID
+	}
+}
+
+func (ds *DataDumpService) writeAnswers(ctx context.Context, enc *json.Encoder, salt string) (count int64, err error) {
+	privateTagIDs, err := ds.spaceCommon.GetPrivateTagIDs(ctx)
+	if err != nil {
+		return 0, err
+	}
+	lastID := "0"
+	for {
+		answers, err := ds.dataDumpRepo.GetAnswersAfter(ctx, lastID, dataDumpBatchSize, privateTagIDs)
+		if err != nil {
+			return count, err
+		}
+		if len(answers) == 0 {
+			return count, nil
+		}
+		for _, answer := range answers {
+			record := &schema.DataDumpAnswer{
+				ID:         answer.ID,
+				QuestionID: answer.QuestionID,
+				Body:       answer.OriginalText,
+				UserID:     anonymizeUserID(salt, answer.UserID),
+				Accepted:   answer.Accepted == schema.AnswerAcceptedEnable,
+				VoteCount:  answer.VoteCount,
+				CreatedAt:  answer.CreatedAt.Unix(),
+				UpdatedAt:  answer.UpdatedAt.Unix(),
+			}
+			if err = enc.Encode(record); err != nil {
+				return count, err
+			}
+			count++
+		}
+		lastID = answers[len(answers)-1].ID
+	}
+}
+
+func (ds *DataDumpService) writeTags(ctx context.Context, enc *json.Encoder, _ string) (count int64, err error) {
+	lastID := "0"
+	for {
+		tags, err := ds.dataDumpRepo.GetTagsAfter(ctx, lastID, dataDumpBatchSize)
+		if err != nil {
+			return count, err
+		}
+		if len(tags) == 0 {
+			return count, nil
+		}
+		for _, tag := range tags {
+			record := &schema.DataDumpTag{
+				SlugName:      tag.SlugName,
+				DisplayName:   tag.DisplayName,
+				Description:   tag.OriginalText,
+				SynonymOf:     tag.MainTagSlugName,
+				QuestionCount: tag.QuestionCount,
+				FollowCount:   tag.FollowCount,
+				CreatedAt:     tag.CreatedAt.Unix(),
+			}
+			if err = enc.Encode(record); err != nil {
+				return count, err
+			}
+			count++
+		}
+		lastID = tags[len(tags)-1].ID
+	}
+}
+
+func (ds *DataDumpService) writeUsers(ctx context.Context, enc *json.Encoder, salt string) (count int64, err error) {
+	lastID := "0"
+	for {
+		users, err := ds.dataDumpRepo.GetUsersAfter(ctx, lastID, dataDumpBatchSize)
+		if err != nil {
+			return count, err
+		}
+		if len(users) == 0 {
+			return count, nil
+		}
+		for _, user := range users {
+			y, m, d := user.CreatedAt.UTC().Date()
+			record := &schema.DataDumpUser{
+				ID:            anonymizeUserID(salt, user.ID),
+				Reputation:    user.Rank,
+				QuestionCount: user.QuestionCount,
+				AnswerCount:   user.AnswerCount,
+				CreatedAt:     time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix(),
+			}
+			if err = enc.Encode(record); err != nil {
+				return count, err
+			}
+			count++
+		}
+		lastID = users[len(users)-1].ID
+	}
+}
+
+func (ds *DataDumpService) dumpFilePath(fileName string) string {
+	return filepath.Join(ds.serviceConfig.UploadPath, constant.DataDumpSubPath, filepath.Base(fileName))
+}
+
+func (ds *DataDumpService) getState(ctx context.Context) (state *schema.DataDumpState, err error) {
+	state = &schema.DataDumpState{}
+	siteInfo, exist, err := ds.siteInfoRepo.GetByType(ctx, constant.SiteTypeDataDumpState)
+	if err != nil || !exist {
+		return state, err
+	}
+	_ = json.Unmarshal([]byte(siteInfo.Content), state)
+	return state, nil
+}
+
+func (ds *DataDumpService) saveState(ctx context.Context, state *schema.DataDumpState) (err error) {
+	content, _ := json.Marshal(state)
+	return ds.siteInfoRepo.SaveByType(ctx, constant.SiteTypeDataDumpState, &entity.SiteInfo{
+		Type:    constant.SiteTypeDataDumpState,
+		Content: string(content),
+		Status:  1,
+	})
+}
+
+// anonymizeUserID the anonymized id is stable for the same salt, so that the records can be linked between dumps
+func anonymizeUserID(salt, userID string) string {
+	if len(userID) == 0 || userID == "0" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, []byte(salt))
+	mac.Write([]byte(userID))
+	return hex.EncodeToString(mac.Sum(nil))[:16]
+}
+
+func generateSalt() string {
+	bytes := make([]byte, 32)
+	_, _ = rand.Read(bytes)
+	return hex.EncodeToString(bytes)
+}
@@ -0,0 +1,354 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package digest
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/activity_common"
+	"github.com/apache/answer/internal/service/export"
+	questioncommon "github.com/apache/answer/internal/service/question_common"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/apache/answer/internal/service/space_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/internal/service/user_notification_config"
+	"github.com/apache/answer/pkg/display"
+	"github.com/apache/answer/pkg/token"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+)
+
+// digestUserBatchSize the number of opted-in users checked in one batch
+const digestUserBatchSize = 100
+
+// DigestRepo digest repository
+type DigestRepo interface {
+	GetSentUserIDs(ctx context.Context, frequency string, userIDs []string, since time.Time) (
+		sent map[string]bool, err error)
+	GetTagQuestions(ctx context.Context, tagIDs []string, excludeUserID string, since, until time.Time, limit int) (
+		questions []*entity.Question, total int64, err error)
+	GetNewAnswerStats(ctx context.Context, questionIDs []string, excludeUserID string, since, until time.Time) (
+		stats []*entity.DigestAnswerStat, err error)
+	GetTopQuestions(ctx context.Context, since, until time.Time, limit int) (questions []*entity.Question, err error)
+	AddEmailRecord(ctx context.Context, record *entity.DigestEmail) (err error)
+}
+
+// DigestService email the daily and weekly digests to the users who opted in
+type DigestService struct {
+	digestRepo                 DigestRepo
+	siteInfoService            siteinfo_common.SiteInfoCommonService
+	userRepo                   usercommon.UserRepo
+	userNotificationConfigRepo user_notification_config.UserNotificationConfigRepo
+	followRepo                 activity_common.FollowRepo
+	questionRepo               questioncommon.QuestionRepo
+	emailService               *export.EmailService
+	spaceCommon                *space_common.SpaceCommon
+	running                    atomic.Bool
+}
+
+// NewDigestService new digest service
+func NewDigestService(
+	digestRepo DigestRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	userRepo usercommon.UserRepo,
+	userNotificationConfigRepo user_notification_config.UserNotificationConfigRepo,
+	followRepo activity_common.FollowRepo,
+	questionRepo questioncommon.QuestionRepo,
+	emailService *export.EmailService,
+	spaceCommon *space_common.SpaceCommon,
+) *DigestService {
+	return &DigestService{
+		digestRepo:                 digestRepo,
+		siteInfoService:            siteInfoService,
+		userRepo:                   userRepo,
+		userNotificationConfigRepo: userNotificationConfigRepo,
+		followRepo:                 followRepo,
+		questionRepo:               questionRepo,
+		emailService:               emailService,
+		spaceCommon:                spaceCommon,
+	}
+}
+
+// SendCron send the digests of the current periods, at most MaxEmailsPerRun emails in one run
+func (ds *DigestService) SendCron(ctx context.Context) {
+	if !ds.running.CompareAndSwap(false, true) {
+		return
+	}
+	defer ds.running.Store(false)
+
+	conf, err := ds.siteInfoService.GetSiteDigest(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if !conf.Enabled {
+		return
+	}
+	now := time.Now().In(ds.getSiteLocation(ctx))
+	quota := conf.MaxEmailsPerRun
+	for _, frequency := range []string{schema.DigestFrequencyDaily, schema.DigestFrequencyWeekly} {
+		sentCount, err := ds.sendFrequency(ctx, conf, frequency, now, quota)
+		if err != nil {
+			log.Errorf("send %s digest emails failed: %v", frequency, err)
+		}
+		quota -= sentCount
+		if quota <= 0 {
+			return
+		}
+	}
+}
+
+// sendFrequency send the digests of the frequency covering the last period before the current period start
+func (ds *DigestService) sendFrequency(ctx context.Context, conf *schema.SiteDigestResp, frequency string,
+	now time.Time, quota int) (sentCount int, err error) {
+	periodStart := conf.PeriodStart(frequency, now)
+	since := periodStart.AddDate(0, 0, -schema.DigestPeriodDays(frequency))
+	source := constant.DigestDailySource
+	if frequency == schema.DigestFrequencyWeekly {
+		source = constant.DigestWeeklySource
+	}
+	userIDs, err := ds.getOptedInUserIDs(ctx, source)
+	if err != nil || len(userIDs) == 0 {
+		return 0, err
+	}
+	topQuestions, err := ds.digestRepo.GetTopQuestions(ctx, since, periodStart, conf.MaxItems)
+	if err != nil {
+		return 0, err
+	}
+
+	for start := 0; start < len(userIDs) && sentCount < quota; start += digestUserBatchSize {
+		batch := userIDs[start:min(start+digestUserBatchSize, len(userIDs))]
+		sent, err := ds.digestRepo.GetSentUserIDs(ctx, frequency, batch, periodStart)
+		if err != nil {
+			return sentCount, err
+		}
+		candidateIDs := make([]string, 0, len(batch))
+		for _, userID := range batch {
+			if !sent[userID] {
+				candidateIDs = append(candidateIDs, userID)
+			}
+		}
+		if len(candidateIDs) == 0 {
+			continue
+		}
+		users, err := ds.userRepo.BatchGetByID(ctx, candidateIDs)
+		if err != nil {
+			return sentCount, err
+		}
+		sort.SliceStable(users, func(i, j int) bool {
+			return users[i].ID < users[j].ID
+		})
+		for _, user := range users {
+			if sentCount >= quota {
+				break
+			}
+			if user.Status != entity.UserStatusAvailable || user.MailStatus != entity.EmailStatusAvailable ||
+				len(user.EMail) == 0 {
+				continue
+			}
+			data, err := ds.buildDigest(ctx, conf, user, since, periodStart, topQuestions)
+			if err != nil {
+				return sentCount, err
+			}
+			if data.IsEmpty() {
+				continue
+			}
+			ds.send(ctx, frequency, source, user, data)
+			sentCount++
+		}
+	}
+	return sentCount, nil
+}
+
+// getOptedInUserIDs get the users who enabled the email channel of the digest source, sorted by id
+func (ds *DigestService) getOptedInUserIDs(ctx context.Context, source constant.NotificationSource) (
+	userIDs []string, err error) {
+	configs, err := ds.userNotificationConfigRepo.GetBySource(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	userIDs = make([]string, 0, len(configs))
+	for _, config := range configs {
+		for _, channel := range schema.NewNotificationChannelsFormJson(config.Channels) {
+			if channel.Key == constant.EmailChannel && channel.Enable {
+				userIDs = append(userIDs, config.UserID)
+				break
+			}
+		}
+	}
+	sort.Strings(userIDs)
+	return userIDs, nil
+}
+
+func (ds *DigestService) buildDigest(ctx context.Context, conf *schema.SiteDigestResp, user *entity.User,
+	since, until time.Time, topQuestions []*entity.Question) (data *schema.DigestTemplateData, err error) {
+	data = &schema.DigestTemplateData{DisplayName: user.DisplayName}
+
+	tagIDs, err := ds.followRepo.GetFollowIDs(ctx, user.ID, entity.Tag{}.TableName())
+	if err != nil {
+		return nil, err
+	}
+	tagQuestions, total, err := ds.digestRepo.GetTagQuestions(ctx, tagIDs, user.ID, since, until, conf.MaxItems)
+	if err != nil {
+		return nil, err
+	}
+	visibleTagQuestions, err := ds.filterVisibleQuestions(ctx, tagQuestions, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	data.TagQuestionTotal = total - int64(len(tagQuestions)-len(visibleTagQuestions))
+	for _, question := range visibleTagQuestions {
+		data.TagQuestions = append(data.TagQuestions, ds.toDigestQuestion(ctx, question))
+	}
+
+	questionIDs, err := ds.followRepo.GetFollowIDs(ctx, user.ID, entity.Question{}.TableName())
+	if err != nil {
+		return nil, err
+	}
+	stats, err := ds.digestRepo.GetNewAnswerStats(ctx, questionIDs, user.ID, since, until)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(stats, func(i, j int) bool {
+		return stats[i].NewAnswerCount > stats[j].NewAnswerCount
+	})
+	stats = stats[:min(len(stats), conf.MaxItems)]
+	statQuestionIDs := make([]string, 0, len(stats))
+	for _, stat := range stats {
+		statQuestionIDs = append(statQuestionIDs, stat.QuestionID)
+	}
+	followedQuestions, err := ds.questionRepo.FindByID(ctx, statQuestionIDs)
+	if err != nil {
+		return nil, err
+	}
+	followedQuestions, err = ds.filterVisibleQuestions(ctx, followedQuestions, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	questionMapping := make(map[string]*entity.Question, len(followedQuestions))
+	for _, question := range followedQuestions {
+		questionMapping[question.ID] = question
+	}
+	for _, stat := range stats {
+		question, ok := questionMapping[stat.QuestionID]
+		if !ok || question.Status == entity.QuestionStatusDeleted {
+			continue
+		}
+		item := ds.toDigestQuestion(ctx, question)
+		item.NewAnswerCount = stat.NewAnswerCount
+		data.FollowedQuestions = append(data.FollowedQuestions, item)
+	}
+
+	topQuestions, err = ds.filterVisibleQuestions(ctx, topQuestions, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, question := range topQuestions {
+		data.TopQuestions = append(data.TopQuestions, ds.toDigestQuestion(ctx, question))
+	}
+	return data, nil
+}
+
+// filterVisibleQuestions remove the questions of the spaces which can not be viewed by the receiver
+func (ds *DigestService) filterVisibleQuestions(ctx context.Context, questions []*entity.Question, userID string) (
+	[]*entity.Question, error) {
+	questionIDs := make([]string, 0, len(questions))
+	for _, question := range questions {
+		questionIDs = append(questionIDs, question.ID)
+	}
+	visible, err := ds.spaceCommon.GetVisibleQuestionIDs(ctx, questionIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+	visibleQuestions := make([]*entity.Question, 0, len(questions))
+	for _, question := range questions {
+		if visible[question.ID] {
+			visibleQuestions = append(visibleQuestions, question)
+		}
+	}
+	return visibleQuestions, nil
+}
+
+func (ds *DigestService) send(ctx context.Context, frequency string, source constant.NotificationSource,
+	user *entity.User, data *schema.DigestTemplateData) {
+	codeContent := &schema.EmailCodeContent{
+		SourceType:               schema.UnsubscribeSourceType,
+		NotificationSources:      []constant.NotificationSource{source},
+		Email:                    user.EMail,
+		UserID:                   user.ID,
+		SkipValidationLatestCode: true,
+	}
+	// If receiver has set language, use it to send email.
+	if len(user.Language) > 0 {
+		ctx = context.WithValue(ctx, constant.AcceptLanguageFlag, i18n.Language(user.Language))
+	}
+	unsubscribeCode := token.GenerateToken()
+	title, body, err := ds.emailService.DigestTemplate(ctx, frequency, data, unsubscribeCode)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	ds.emailService.SendAndSaveCodeWithTime(ctx, user.ID, user.EMail, title, body,
+		unsubscribeCode, codeContent.ToJSONString(), 7*24*time.Hour)
+	err = ds.digestRepo.AddEmailRecord(ctx, &entity.DigestEmail{UserID: user.ID, Frequency: frequency})
+	if err != nil {
+		log.Error(err)
+	}
+}
+
+func (ds *DigestService) toDigestQuestion(ctx context.Context, question *entity.Question) *schema.DigestQuestion {
+	return &schema.DigestQuestion{
+		Title:     question.Title,
+		URL:       ds.questionURL(ctx, question),
+		VoteCount: question.VoteCount,
+	}
+}
+
+func (ds *DigestService) questionURL(ctx context.Context, question *entity.Question) string {
+	siteGeneral, err := ds.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		log.Error(err)
+		return ""
+	}
+	permalink := constant.PermalinkQuestionID
+	if siteSeo, err := ds.siteInfoService.GetSiteSeo(ctx); err == nil {
+		permalink = siteSeo.Permalink
+	}
+	return display.QuestionURL(permalink, siteGeneral.SiteUrl, question.ID, question.Title)
+}
+
+// getSiteLocation get the time zone of the site, the send hour is in it
+func (ds *DigestService) getSiteLocation(ctx context.Context) *time.Location {
+	interfaceInfo, err := ds.siteInfoService.GetSiteInterface(ctx)
+	if err != nil {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(interfaceInfo.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
@@ -0,0 +1,128 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package email_template
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/i18n"
+)
+
+// EmailTemplateRepo email template repository
+type EmailTemplateRepo interface {
+	GetEmailTemplate(ctx context.Context, key, language string) (tpl *entity.EmailTemplate, exist bool, err error)
+	GetEmailTemplates(ctx context.Context) (tpls []*entity.EmailTemplate, err error)
+	SaveEmailTemplate(ctx context.Context, tpl *entity.EmailTemplate) (err error)
+	DeleteEmailTemplate(ctx context.Context, key, language string) (err error)
+}
+
+// EmailTemplateService the email templates customized by admin
+type EmailTemplateService struct {
+	emailTemplateRepo EmailTemplateRepo
+}
+
+// NewEmailTemplateService new email template service
+func NewEmailTemplateService(emailTemplateRepo EmailTemplateRepo) *EmailTemplateService {
+	return &EmailTemplateService{
+		emailTemplateRepo: emailTemplateRepo,
+	}
+}
+
+// GetEmailTemplates get all the email templates could be customized with the customized languages
+func (es *EmailTemplateService) GetEmailTemplates(ctx context.Context) (resp []*schema.EmailTemplateItem, err error) {
+	tpls, err := es.emailTemplateRepo.GetEmailTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	customLanguages := make(map[string][]string)
+	for _, tpl := range tpls {
+		customLanguages[tpl.TemplateKey] = append(customLanguages[tpl.TemplateKey], tpl.Language)
+	}
+	resp = make([]*schema.EmailTemplateItem, 0, len(schema.EmailTemplateDefinitions))
+	for _, definition := range schema.EmailTemplateDefinitions {
+		item := &schema.EmailTemplateItem{
+			Key:             definition.Key,
+			Placeholders:    definition.Placeholders(),
+			CustomLanguages: customLanguages[definition.Key],
+		}
+		if item.CustomLanguages == nil {
+			item.CustomLanguages = make([]string, 0)
+		}
+		resp = append(resp, item)
+	}
+	return resp, nil
+}
+
+// GetEmailTemplate get the email template of the language, the default templates are returned together
+func (es *EmailTemplateService) GetEmailTemplate(ctx context.Context, req *schema.GetEmailTemplateReq) (
+	resp *schema.GetEmailTemplateResp, err error) {
+	definition := schema.GetEmailTemplateDefinition(req.Key)
+	if definition == nil {
+		return nil, errors.NotFound(reason.EmailTemplateNotFound)
+	}
+	if req.Language == translator.DefaultLangOption || !translator.CheckLanguageIsValid(req.Language) {
+		return nil, errors.BadRequest(reason.LangNotFound)
+	}
+	lang := i18n.Language(req.Language)
+	resp = &schema.GetEmailTemplateResp{
+		Key:            definition.Key,
+		Language:       req.Language,
+		Placeholders:   definition.Placeholders(),
+		DefaultSubject: translator.TrRaw(lang, definition.TitleKey),
+		DefaultBody:    translator.TrRaw(lang, definition.BodyKey),
+	}
+	tpl, exist, err := es.emailTemplateRepo.GetEmailTemplate(ctx, definition.Key, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		resp.Customized = true
+		resp.Subject = tpl.Subject
+		resp.Body = tpl.Body
+		resp.UpdatedAt = tpl.UpdatedAt.Unix()
+	} else {
+		resp.Subject = resp.DefaultSubject
+		resp.Body = resp.DefaultBody
+	}
+	return resp, nil
+}
+
+// SaveEmailTemplate save the custom email template of the language
+func (es *EmailTemplateService) SaveEmailTemplate(ctx context.Context, req *schema.SaveEmailTemplateReq) (err error) {
+	return es.emailTemplateRepo.SaveEmailTemplate(ctx, &entity.EmailTemplate{
+		TemplateKey: req.Key,
+		Language:    req.Language,
+		Subject:     req.Subject,
+		Body:        req.Body,
+	})
+}
+
+// DeleteEmailTemplate delete the custom email template of the language, the default template is used again
+func (es *EmailTemplateService) DeleteEmailTemplate(ctx context.Context, req *schema.DeleteEmailTemplateReq) (err error) {
+	if schema.GetEmailTemplateDefinition(req.Key) == nil {
+		return errors.NotFound(reason.EmailTemplateNotFound)
+	}
+	return es.emailTemplateRepo.DeleteEmailTemplate(ctx, req.Key, req.Language)
+}
@@ -32,8 +32,8 @@ type EventQueueService interface {
 }
 
 type eventQueueService struct {
-	Queue   chan *schema.EventMsg
-	Handler func(ctx context.Context, msg *schema.EventMsg) error
+	Queue    chan *schema.EventMsg
+	Handlers []func(ctx context.Context, msg *schema.EventMsg) error
 }
 
 func (ns *eventQueueService) Send(ctx context.Context, msg *schema.EventMsg) {
@@ -42,19 +42,22 @@ func (ns *eventQueueService) Send(ctx context.Context, msg *schema.EventMsg) {
 
 func (ns *eventQueueService) RegisterHandler(
 	handler func(ctx context.Context, msg *schema.EventMsg) error) {
-	ns.Handler = handler
+	ns.Handlers = append(ns.Handlers, handler)
 }
 
 func (ns *eventQueueService) working() {
 	go func() {
 		for msg := range ns.Queue {
 			log.Debugf("received badge %+v", msg)
-			if ns.Handler == nil {
+			if len(ns.Handlers) == 0 {
 				log.Warnf("no handler for badge")
 				continue
 			}
-			if err := ns.Handler(context.Background(), msg); err != nil {
-				log.Error(err)
+			// every handler receives the event, such as badge and webhook
+			for _, handler := range ns.Handlers {
+				if err := handler(context.Background(), msg); err != nil {
+					log.Error(err)
+				}
 			}
 		}
 	}()
@@ -35,6 +35,7 @@ import (
 	"github.com/apache/answer/internal/base/translator"
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/config"
+	"github.com/apache/answer/internal/service/email_template"
 	"github.com/apache/answer/internal/service/siteinfo_common"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
@@ -44,9 +45,10 @@ import (
 
 // EmailService kit service
 type EmailService struct {
-	configService   *config.ConfigService
-	emailRepo       EmailRepo
-	siteInfoService siteinfo_common.SiteInfoCommonService
+	configService     *config.ConfigService
+	emailRepo         EmailRepo
+	siteInfoService   siteinfo_common.SiteInfoCommonService
+	emailTemplateRepo email_template.EmailTemplateRepo
 }
 
 // EmailRepo email repository
@@ -60,11 +62,13 @@ func NewEmailService(
 	configService *config.ConfigService,
 	emailRepo EmailRepo,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
+	emailTemplateRepo email_template.EmailTemplateRepo,
 ) *EmailService {
 	return &EmailService{
-		configService:   configService,
-		emailRepo:       emailRepo,
-		siteInfoService: siteInfoService,
+		configService:     configService,
+		emailRepo:         emailRepo,
+		siteInfoService:   siteInfoService,
+		emailTemplateRepo: emailTemplateRepo,
 	}
 }
 
@@ -117,8 +121,24 @@ func (es *EmailService) SendAndSaveCodeWithTime(
 	es.Send(ctx, toEmailAddr, subject, body)
 }
 
+// SendAndSaveCodeWithReplyTo send email with the reply address and save code
+func (es *EmailService) SendAndSaveCodeWithReplyTo(ctx context.Context,
+	userID, toEmailAddr, subject, body, code, codeContent, replyTo string, duration time.Duration) {
+	err := es.emailRepo.SetCode(ctx, userID, code, codeContent, duration)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	es.SendWithReplyTo(ctx, toEmailAddr, subject, body, replyTo)
+}
+
 // Send email send
 func (es *EmailService) Send(ctx context.Context, toEmailAddr, subject, body string) {
+	es.SendWithReplyTo(ctx, toEmailAddr, subject, body, "")
+}
+
+// SendWithReplyTo email send, the replies go to the reply address if it is not empty
+func (es *EmailService) SendWithReplyTo(ctx context.Context, toEmailAddr, subject, body, replyTo string) {
 	log.Infof("try to send email to %s", toEmailAddr)
 	ec, err := es.GetEmailConfig(ctx)
 	if err != nil {
@@ -135,6 +155,9 @@ func (es *EmailService) Send(ctx context.Context, toEmailAddr, subject, body str
 	m.SetHeader("From", fmt.Sprintf("%s <%s>", fromName, ec.FromEmail))
 	m.SetHeader("To", toEmailAddr)
 	m.SetHeader("Subject", subject)
+	if len(replyTo) > 0 {
+		m.SetHeader("Reply-To", replyTo)
+	}
 	m.SetBody("text/html", body)
 
 	d := gomail.NewDialer(ec.SMTPHost, ec.SMTPPort, ec.SMTPUsername, ec.SMTPPassword)
@@ -173,9 +196,7 @@ func (es *EmailService) RegisterTemplate(ctx context.Context, registerUrl string
 		RegisterUrl: registerUrl,
 	}
 
-	lang := handler.GetLangByCtx(ctx)
-	title = translator.TrWithData(lang, constant.EmailTplKeyRegisterTitle, templateData)
-	body = translator.TrWithData(lang, constant.EmailTplKeyRegisterBody, templateData)
+	title, body = es.renderTemplate(ctx, schema.EmailTemplateKeyRegister, templateData)
 	return title, body, nil
 }
 
@@ -187,9 +208,7 @@ func (es *EmailService) PassResetTemplate(ctx context.Context, passResetUrl stri
 
 	templateData := &schema.PassResetTemplateData{SiteName: siteInfo.Name, PassResetUrl: passResetUrl}
 
-	lang := handler.GetLangByCtx(ctx)
-	title = translator.TrWithData(lang, constant.EmailTplKeyPassResetTitle, templateData)
-	body = translator.TrWithData(lang, constant.EmailTplKeyPassResetBody, templateData)
+	title, body = es.renderTemplate(ctx, schema.EmailTemplateKeyPassReset, templateData)
 	return title, body, nil
 }
 
@@ -203,9 +222,7 @@ func (es *EmailService) ChangeEmailTemplate(ctx context.Context, changeEmailUrl
 		ChangeEmailUrl: changeEmailUrl,
 	}
 
-	lang := handler.GetLangByCtx(ctx)
-	title = translator.TrWithData(lang, constant.EmailTplKeyChangeEmailTitle, templateData)
-	body = translator.TrWithData(lang, constant.EmailTplKeyChangeEmailBody, templateData)
+	title, body = es.renderTemplate(ctx, schema.EmailTemplateKeyChangeEmail, templateData)
 	return title, body, nil
 }
 
@@ -217,9 +234,7 @@ func (es *EmailService) TestTemplate(ctx context.Context) (title, body string, e
 	}
 	templateData := &schema.TestTemplateData{SiteName: siteInfo.Name}
 
-	lang := handler.GetLangByCtx(ctx)
-	title = translator.TrWithData(lang, constant.EmailTplKeyTestTitle, templateData)
-	body = translator.TrWithData(lang, constant.EmailTplKeyTestBody, templateData)
+	title, body = es.renderTemplate(ctx, schema.EmailTemplateKeyTest, templateData)
 	return title, body, nil
 }
 
@@ -243,9 +258,7 @@ func (es *EmailService) NewAnswerTemplate(ctx context.Context, raw *schema.NewAn
 		UnsubscribeUrl: fmt.Sprintf("%s/users/unsubscribe?code=%s", siteInfo.SiteUrl, raw.UnsubscribeCode),
 	}
 
-	lang := handler.GetLangByCtx(ctx)
-	title = translator.TrWithData(lang, constant.EmailTplKeyNewAnswerTitle, templateData)
-	body = translator.TrWithData(lang, constant.EmailTplKeyNewAnswerBody, templateData)
+	title, body = es.renderTemplate(ctx, schema.EmailTemplateKeyNewAnswer, templateData)
 	return title, body, nil
 }
 
@@ -268,9 +281,7 @@ func (es *EmailService) NewInviteAnswerTemplate(ctx context.Context, raw *schema
 		UnsubscribeUrl: fmt.Sprintf("%s/users/unsubscribe?code=%s", siteInfo.SiteUrl, raw.UnsubscribeCode),
 	}
 
-	lang := handler.GetLangByCtx(ctx)
-	title = translator.TrWithData(lang, constant.EmailTplKeyInvitedAnswerTitle, templateData)
-	body = translator.TrWithData(lang, constant.EmailTplKeyInvitedAnswerBody, templateData)
+	title, body = es.renderTemplate(ctx, schema.EmailTemplateKeyInvitedAnswer, templateData)
 	return title, body, nil
 }
 
@@ -295,9 +306,7 @@ func (es *EmailService) NewCommentTemplate(ctx context.Context, raw *schema.NewC
 	templateData.CommentUrl = display.CommentURL(seoInfo.Permalink,
 		siteInfo.SiteUrl, raw.QuestionID, raw.QuestionTitle, raw.AnswerID, raw.CommentID)
 
-	lang := handler.GetLangByCtx(ctx)
-	title = translator.TrWithData(lang, constant.EmailTplKeyNewCommentTitle, templateData)
-	body = translator.TrWithData(lang, constant.EmailTplKeyNewCommentBody, templateData)
+	title, body = es.renderTemplate(ctx, schema.EmailTemplateKeyNewComment, templateData)
 	return title, body, nil
 }
 
@@ -321,12 +330,87 @@ func (es *EmailService) NewQuestionTemplate(ctx context.Context, raw *schema.New
 	templateData.QuestionUrl = display.QuestionURL(
 		seoInfo.Permalink, siteInfo.SiteUrl, raw.QuestionID, raw.QuestionTitle)
 
+	title, body = es.renderTemplate(ctx, schema.EmailTemplateKeyNewQuestion, templateData)
+	return title, body, nil
+}
+
+// ReEngagementTemplate re-engagement template, the custom templates of campaign are used if set
+func (es *EmailService) ReEngagementTemplate(ctx context.Context, campaign *schema.ReEngagementCampaign,
+	data *schema.ReEngagementTemplateData, unsubscribeCode string) (title, body string, err error) {
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	data.SiteName = siteInfo.Name
+	data.SiteURL = siteInfo.SiteUrl
+	data.UnsubscribeUrl = fmt.Sprintf("%s/users/unsubscribe?code=%s", siteInfo.SiteUrl, unsubscribeCode)
+	if campaign.HasCustomTemplate() {
+		return campaign.Render(data)
+	}
+
+	titleKey, bodyKey := constant.EmailTplKeyReEngagementNewAnswersTitle, constant.EmailTplKeyReEngagementNewAnswersBody
+	if campaign.Type == schema.ReEngagementCampaignInactiveUser {
+		titleKey, bodyKey = constant.EmailTplKeyReEngagementInactiveUserTitle, constant.EmailTplKeyReEngagementInactiveUserBody
+	}
 	lang := handler.GetLangByCtx(ctx)
-	title = translator.TrWithData(lang, constant.EmailTplKeyNewQuestionTitle, templateData)
-	body = translator.TrWithData(lang, constant.EmailTplKeyNewQuestionBody, templateData)
+	title = translator.TrWithData(lang, titleKey, data)
+	body = translator.TrWithData(lang, bodyKey, data)
 	return title, body, nil
 }
 
+// DigestTemplate digest template of the daily or weekly frequency
+func (es *EmailService) DigestTemplate(ctx context.Context, frequency string,
+	data *schema.DigestTemplateData, unsubscribeCode string) (title, body string, err error) {
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	data.SiteName = siteInfo.Name
+	data.SiteURL = siteInfo.SiteUrl
+	data.UnsubscribeUrl = fmt.Sprintf("%s/users/unsubscribe?code=%s", siteInfo.SiteUrl, unsubscribeCode)
+
+	key := schema.EmailTemplateKeyDigestDaily
+	if frequency == schema.DigestFrequencyWeekly {
+		key = schema.EmailTemplateKeyDigestWeekly
+	}
+	title, body = es.renderTemplate(ctx, key, data)
+	return title, body, nil
+}
+
+// SuspiciousLoginTemplate the email notifying the user the account is locked by the failed login attempts
+func (es *EmailService) SuspiciousLoginTemplate(ctx context.Context, data *schema.SuspiciousLoginTemplateData) (
+	title, body string, err error) {
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	data.SiteName = siteInfo.Name
+	data.PassResetUrl = fmt.Sprintf("%s/users/account-recovery", siteInfo.SiteUrl)
+
+	title, body = es.renderTemplate(ctx, schema.EmailTemplateKeySuspiciousLogin, data)
+	return title, body, nil
+}
+
+// renderTemplate render the template customized by admin for the language,
+// the default translation is used if not customized or the custom template is failed to render
+func (es *EmailService) renderTemplate(ctx context.Context, key string, data any) (title, body string) {
+	definition := schema.GetEmailTemplateDefinition(key)
+	lang := handler.GetLangByCtx(ctx)
+	tpl, exist, err := es.emailTemplateRepo.GetEmailTemplate(ctx, key, string(lang))
+	if err != nil {
+		log.Error(err)
+	} else if exist {
+		title, body, err = schema.RenderEmailTemplate(tpl.Subject, tpl.Body, data)
+		if err == nil {
+			return title, body
+		}
+		log.Errorf("render custom email template %s of %s failed: %v", key, lang, err)
+	}
+	title = translator.TrWithData(lang, definition.TitleKey, data)
+	body = translator.TrWithData(lang, definition.BodyKey, data)
+	return title, body
+}
+
 func (es *EmailService) GetEmailConfig(ctx context.Context) (ec *EmailConfig, err error) {
 	emailConf, err := es.configService.GetStringValue(ctx, constant.EmailConfigKey)
 	if err != nil {
@@ -191,6 +191,15 @@ func (fs *FileRecordService) DeleteAndMoveFileRecord(ctx context.Context, fileRe
 	if err := writer.MoveFile(oldFilePath, deletedPath); err != nil {
 		return fmt.Errorf("move file error: %v", err)
 	}
+	// the thumbnail of video is not recorded, it is moved with the video
+	if strings.HasPrefix(fileRecord.FilePath, constant.VideoSubPath+"/") {
+		thumbnailPath := strings.TrimSuffix(oldFilePath, filepath.Ext(oldFilePath)) + ".jpg"
+		if dir.CheckFileExist(thumbnailPath) {
+			if err := writer.MoveFile(thumbnailPath, filepath.Join(filepath.Dir(deletedPath), filepath.Base(thumbnailPath))); err != nil {
+				log.Errorf("move video thumbnail error: %v", err)
+			}
+		}
+	}
 
 	log.Debugf("delete and move file: %s", fileRecord.FileURL)
 	return nil
@@ -0,0 +1,218 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package helpfulness_survey
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	answercommon "github.com/apache/answer/internal/service/answer_common"
+	questioncommon "github.com/apache/answer/internal/service/question_common"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/apache/answer/pkg/display"
+	"github.com/apache/answer/pkg/token"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// HelpfulnessSurveyRepo helpfulness survey repository
+type HelpfulnessSurveyRepo interface {
+	AddSurvey(ctx context.Context, survey *entity.HelpfulnessSurvey) (err error)
+	GetSurvey(ctx context.Context, userID, answerID string) (survey *entity.HelpfulnessSurvey, exist bool, err error)
+	GetSurveyByToken(ctx context.Context, token string) (survey *entity.HelpfulnessSurvey, exist bool, err error)
+	GetDueSurveys(ctx context.Context, userID string, now time.Time, limit int) (
+		surveys []*entity.HelpfulnessSurvey, err error)
+	UpdateSurveyResult(ctx context.Context, survey *entity.HelpfulnessSurvey) (err error)
+	GetAnswersHelpfulnessStat(ctx context.Context, answerIDs []string) (
+		stats map[string]*entity.AnswerHelpfulnessStat, err error)
+}
+
+// HelpfulnessSurveyService ask the user who viewed an accepted answer from search whether it solved the problem
+type HelpfulnessSurveyService struct {
+	helpfulnessSurveyRepo HelpfulnessSurveyRepo
+	questionRepo          questioncommon.QuestionRepo
+	answerRepo            answercommon.AnswerRepo
+	siteInfoService       siteinfo_common.SiteInfoCommonService
+}
+
+// NewHelpfulnessSurveyService new helpfulness survey service
+func NewHelpfulnessSurveyService(
+	helpfulnessSurveyRepo HelpfulnessSurveyRepo,
+	questionRepo questioncommon.QuestionRepo,
+	answerRepo answercommon.AnswerRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *HelpfulnessSurveyService {
+	return &HelpfulnessSurveyService{
+		helpfulnessSurveyRepo: helpfulnessSurveyRepo,
+		questionRepo:          questionRepo,
+		answerRepo:            answerRepo,
+		siteInfoService:       siteInfoService,
+	}
+}
+
+// RecordView schedule the survey if the user viewed the question with accepted answer from search.
+// The user is surveyed once for each answer, and never for the answer written by self.
+func (hs *HelpfulnessSurveyService) RecordView(ctx context.Context, req *schema.RecordHelpfulnessViewReq) (err error) {
+	conf, err := hs.siteInfoService.GetSiteHelpfulnessSurvey(ctx)
+	if err != nil || !conf.Enabled {
+		return err
+	}
+	siteGeneral, err := hs.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return err
+	}
+	if !schema.IsSearchReferrer(req.Referrer, siteGeneral.SiteUrl) {
+		return nil
+	}
+	question, exist, err := hs.questionRepo.GetQuestion(ctx, req.QuestionID)
+	if err != nil {
+		return err
+	}
+	if !exist || len(question.AcceptedAnswerID) == 0 || question.AcceptedAnswerID == "0" {
+		return nil
+	}
+	answer, exist, err := hs.answerRepo.GetAnswer(ctx, question.AcceptedAnswerID)
+	if err != nil {
+		return err
+	}
+	if !exist || answer.UserID == req.UserID {
+		return nil
+	}
+	_, exist, err = hs.helpfulnessSurveyRepo.GetSurvey(ctx, req.UserID, answer.ID)
+	if err != nil || exist {
+		return err
+	}
+	return hs.helpfulnessSurveyRepo.AddSurvey(ctx, &entity.HelpfulnessSurvey{
+		UserID:     req.UserID,
+		QuestionID: question.ID,
+		AnswerID:   answer.ID,
+		Token:      token.GenerateToken(),
+		Status:     entity.HelpfulnessSurveyStatusPending,
+		DueAt:      time.Now().AddDate(0, 0, conf.DelayDays),
+	})
+}
+
+// GetPendingSurveys get the due surveys of user to prompt
+func (hs *HelpfulnessSurveyService) GetPendingSurveys(ctx context.Context, req *schema.GetPendingHelpfulnessSurveysReq) (
+	resp []*schema.HelpfulnessSurveyItem, err error) {
+	resp = make([]*schema.HelpfulnessSurveyItem, 0)
+	conf, err := hs.siteInfoService.GetSiteHelpfulnessSurvey(ctx)
+	if err != nil || !conf.Enabled {
+		return resp, err
+	}
+	surveys, err := hs.helpfulnessSurveyRepo.GetDueSurveys(ctx, req.UserID, time.Now(), schema.HelpfulnessSurveyMaxPending)
+	if err != nil {
+		return nil, err
+	}
+	siteGeneral, err := hs.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return nil, err
+	}
+	permalink := constant.PermalinkQuestionID
+	if siteSeo, err := hs.siteInfoService.GetSiteSeo(ctx); err == nil {
+		permalink = siteSeo.Permalink
+	}
+	enableShortID := handler.GetEnableShortID(ctx)
+	for _, survey := range surveys {
+		question, exist, err := hs.questionRepo.GetQuestion(ctx, survey.QuestionID)
+		if err != nil {
+			log.Error(err)
+			continue
+		}
+		if !exist || question.Status == entity.QuestionStatusDeleted ||
+			question.AcceptedAnswerID != survey.AnswerID {
+			continue
+		}
+		item := &schema.HelpfulnessSurveyItem{
+			Token:         survey.Token,
+			QuestionID:    survey.QuestionID,
+			QuestionTitle: question.Title,
+			AnswerID:      survey.AnswerID,
+			URL:           display.QuestionURL(permalink, siteGeneral.SiteUrl, question.ID, question.Title),
+			ViewedAt:      survey.CreatedAt.Unix(),
+		}
+		if enableShortID {
+			item.QuestionID = uid.EnShortID(item.QuestionID)
+			item.AnswerID = uid.EnShortID(item.AnswerID)
+		}
+		resp = append(resp, item)
+	}
+	return resp, nil
+}
+
+// RespondSurvey submit the survey result by token, each survey can be responded once
+func (hs *HelpfulnessSurveyService) RespondSurvey(ctx context.Context, req *schema.RespondHelpfulnessSurveyReq) (err error) {
+	survey, exist, err := hs.helpfulnessSurveyRepo.GetSurveyByToken(ctx, req.Token)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.NotFound(reason.HelpfulnessSurveyNotFound)
+	}
+	if survey.Status != entity.HelpfulnessSurveyStatusPending {
+		return errors.BadRequest(reason.HelpfulnessSurveyResponded)
+	}
+	switch req.Result {
+	case schema.HelpfulnessSurveyResultSolved:
+		survey.Status = entity.HelpfulnessSurveyStatusSolved
+	case schema.HelpfulnessSurveyResultNotSolved:
+		survey.Status = entity.HelpfulnessSurveyStatusNotSolved
+	default:
+		survey.Status = entity.HelpfulnessSurveyStatusDismissed
+	}
+	survey.AnsweredAt = time.Now()
+	return hs.helpfulnessSurveyRepo.UpdateSurveyResult(ctx, survey)
+}
+
+// GetAnswerHelpfulness get the survey results of answer, only the author, admin or moderator can see it
+func (hs *HelpfulnessSurveyService) GetAnswerHelpfulness(ctx context.Context, req *schema.GetAnswerHelpfulnessReq) (
+	resp *schema.AnswerHelpfulnessResp, err error) {
+	answer, exist, err := hs.answerRepo.GetAnswer(ctx, req.AnswerID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.NotFound(reason.AnswerNotFound)
+	}
+	if !req.IsAdminModerator && answer.UserID != req.UserID {
+		return nil, errors.Forbidden(reason.ForbiddenError)
+	}
+	stats, err := hs.helpfulnessSurveyRepo.GetAnswersHelpfulnessStat(ctx, []string{answer.ID})
+	if err != nil {
+		return nil, err
+	}
+	resp = schema.NewAnswerHelpfulnessResp(req.AnswerID, 0, 0)
+	if stat, ok := stats[answer.ID]; ok {
+		resp = schema.NewAnswerHelpfulnessResp(req.AnswerID, stat.Responses, stat.Solved)
+	}
+	return resp, nil
+}
+
+// GetAnswersHelpfulnessStat get the aggregated survey results of answers, it is used as ranking signal
+func (hs *HelpfulnessSurveyService) GetAnswersHelpfulnessStat(ctx context.Context, answerIDs []string) (
+	stats map[string]*entity.AnswerHelpfulnessStat, err error) {
+	return hs.helpfulnessSurveyRepo.GetAnswersHelpfulnessStat(ctx, answerIDs)
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/apache/answer/internal/service/permission"
 	"github.com/apache/answer/internal/service/rank"
 	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/uid"
 	"github.com/apache/answer/plugin"
 	"github.com/gin-gonic/gin"
 	"github.com/segmentfault/pacman/errors"
@@ -41,6 +42,7 @@ import (
 // ImporterService importer service
 type ImporterService struct {
 	questionService *content.QuestionService
+	answerService   *content.AnswerService
 	rankService     *rank.RankService
 	userCommon      *usercommon.UserCommon
 }
@@ -48,10 +50,12 @@ type ImporterService struct {
 // NewRankService new rank service
 func NewImporterService(
 	questionService *content.QuestionService,
+	answerService *content.AnswerService,
 	rankService *rank.RankService,
 	userCommon *usercommon.UserCommon) *ImporterService {
 	return &ImporterService{
 		questionService: questionService,
+		answerService:   answerService,
 		rankService:     rankService,
 		userCommon:      userCommon,
 	}
@@ -66,6 +70,10 @@ func (ipfunc *ImporterFunc) AddQuestion(ctx context.Context, questionInfo plugin
 	return nil
 }
 
+func (ipfunc *ImporterFunc) AddAnswer(ctx context.Context, answerInfo plugin.AnswerImporterInfo) (err error) {
+	return ipfunc.importerService.ImportAnswer(ctx, answerInfo)
+}
+
 func (ip *ImporterService) NewImporterFunc() plugin.ImporterFunc {
 	return &ImporterFunc{importerService: ip}
 }
@@ -170,3 +178,45 @@ func (ip *ImporterService) ImportQuestion(ctx context.Context, questionInfo plug
 	log.Info("Add Question Successfully")
 	return nil
 }
+
+// ImportAnswer import the answer with its provenance
+func (ip *ImporterService) ImportAnswer(ctx context.Context, answerInfo plugin.AnswerImporterInfo) (err error) {
+	userInfo, exist, err := ip.userCommon.GetByEmail(ctx, answerInfo.UserEmail)
+	if err != nil {
+		log.Errorf("error: %v", err)
+		return err
+	}
+	if !exist {
+		return fmt.Errorf("User not found")
+	}
+	can, err := ip.rankService.CheckOperationPermission(ctx, userInfo.ID, permission.AnswerAdd, "")
+	if err != nil {
+		return err
+	}
+	if !can {
+		return errors.Forbidden(reason.RankFailToMeetTheCondition)
+	}
+
+	req := &schema.AnswerAddReq{
+		QuestionID: uid.DeShortID(answerInfo.QuestionID),
+		Content:    answerInfo.Content,
+		UserID:     userInfo.ID,
+		Provenance: &schema.AnswerProvenance{
+			SourceURL:   answerInfo.SourceURL,
+			ImportBatch: answerInfo.ImportBatch,
+			AIAssisted:  answerInfo.AIAssisted,
+		},
+	}
+	if _, err = req.Check(); err != nil {
+		return err
+	}
+	if ginCtx, ok := ctx.(*gin.Context); ok {
+		req.UserAgent = ginCtx.GetHeader("User-Agent")
+		req.IP = ginCtx.ClientIP()
+	}
+	if _, err = ip.answerService.Insert(ctx, req); err != nil {
+		return err
+	}
+	log.Info("Add Answer Successfully")
+	return nil
+}
@@ -0,0 +1,306 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package inbound_email
+
+import (
+	"context"
+	"crypto/subtle"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/comment"
+	"github.com/apache/answer/internal/service/comment_common"
+	"github.com/apache/answer/internal/service/content"
+	"github.com/apache/answer/internal/service/permission"
+	"github.com/apache/answer/internal/service/rank"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/emailreply"
+	"github.com/apache/answer/pkg/imapclient"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// imapTimeout the timeout of each command of the IMAP polling
+const imapTimeout = 30 * time.Second
+
+// InboundEmailRepo inbound email repository
+type InboundEmailRepo interface {
+	AddInboundEmail(ctx context.Context, email *entity.InboundEmail) (err error)
+	IsProcessed(ctx context.Context, messageID string) (processed bool, err error)
+}
+
+// InboundEmailService post the replies of the notification emails as the answers or the comments
+type InboundEmailService struct {
+	inboundEmailRepo  InboundEmailRepo
+	siteInfoService   siteinfo_common.SiteInfoCommonService
+	userRepo          usercommon.UserRepo
+	commentCommonRepo comment_common.CommentCommonRepo
+	answerService     *content.AnswerService
+	commentService    *comment.CommentService
+	rankService       *rank.RankService
+}
+
+// NewInboundEmailService new inbound email service
+func NewInboundEmailService(
+	inboundEmailRepo InboundEmailRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	userRepo usercommon.UserRepo,
+	commentCommonRepo comment_common.CommentCommonRepo,
+	answerService *content.AnswerService,
+	commentService *comment.CommentService,
+	rankService *rank.RankService,
+) *InboundEmailService {
+	return &InboundEmailService{
+		inboundEmailRepo:  inboundEmailRepo,
+		siteInfoService:   siteInfoService,
+		userRepo:          userRepo,
+		commentCommonRepo: commentCommonRepo,
+		answerService:     answerService,
+		commentService:    commentService,
+		rankService:       rankService,
+	}
+}
+
+// ReceiveWebhook process the raw email pushed by the inbound parse service of the mail provider
+func (is *InboundEmailService) ReceiveWebhook(ctx context.Context, webhookToken string, raw []byte) (
+	resp *schema.InboundEmailResp, err error) {
+	conf, err := is.siteInfoService.GetSiteInboundEmail(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !conf.IsAvailable() {
+		return nil, errors.BadRequest(reason.InboundEmailDisabled)
+	}
+	if len(conf.WebhookToken) == 0 ||
+		subtle.ConstantTimeCompare([]byte(webhookToken), []byte(conf.WebhookToken)) != 1 {
+		return nil, errors.Forbidden(reason.InboundEmailWebhookTokenInvalid)
+	}
+	return is.ProcessEmail(ctx, conf, raw)
+}
+
+// PollCron fetch the unseen emails of the IMAP mailbox and process them, the emails are marked as seen once processed
+func (is *InboundEmailService) PollCron(ctx context.Context) {
+	conf, err := is.siteInfoService.GetSiteInboundEmail(ctx)
+	if err != nil {
+		log.Errorf("get site inbound email config failed: %v", err)
+		return
+	}
+	if !conf.IsAvailable() || len(conf.IMAPHost) == 0 {
+		return
+	}
+	client, err := imapclient.Dial(net.JoinHostPort(conf.IMAPHost, strconv.Itoa(conf.IMAPPort)), conf.IMAPTLS, imapTimeout)
+	if err != nil {
+		log.Errorf("connect imap server %s failed: %v", conf.IMAPHost, err)
+		return
+	}
+	defer func() {
+		if err := client.Logout(); err != nil {
+			log.Warnf("logout imap server failed: %v", err)
+		}
+	}()
+	if err = client.Login(conf.IMAPUsername, conf.IMAPPassword); err != nil {
+		log.Errorf("login imap server failed: %v", err)
+		return
+	}
+	if err = client.Select(conf.IMAPMailbox); err != nil {
+		log.Errorf("select imap mailbox %s failed: %v", conf.IMAPMailbox, err)
+		return
+	}
+	uids, err := client.SearchUnseen()
+	if err != nil {
+		log.Errorf("search unseen emails failed: %v", err)
+		return
+	}
+	if len(uids) > schema.InboundEmailMaxPerPoll {
+		uids = uids[:schema.InboundEmailMaxPerPoll]
+	}
+	for _, uid := range uids {
+		raw, err := client.FetchRaw(uid)
+		if err != nil {
+			log.Errorf("fetch email %d failed: %v", uid, err)
+			return
+		}
+		// the email failed by the database error is kept unseen to be retried in the next polling
+		if _, err = is.ProcessEmail(ctx, conf, raw); err != nil {
+			log.Errorf("process inbound email %d failed: %v", uid, err)
+			continue
+		}
+		if err = client.MarkSeen(uid); err != nil {
+			log.Errorf("mark email %d as seen failed: %v", uid, err)
+			return
+		}
+	}
+}
+
+// ProcessEmail post the reply of the email, the error is returned only if it should be retried
+func (is *InboundEmailService) ProcessEmail(ctx context.Context, conf *schema.SiteInboundEmailResp, raw []byte) (
+	resp *schema.InboundEmailResp, err error) {
+	resp = &schema.InboundEmailResp{Status: schema.InboundEmailStatusIgnored}
+	if len(raw) > schema.InboundEmailMaxSize {
+		return resp, nil
+	}
+	msg, err := emailreply.ParseMessage(raw)
+	if err != nil {
+		log.Warnf("parse inbound email failed: %v", err)
+		return resp, nil
+	}
+	if msg.AutoSubmitted || len(msg.MessageID) == 0 {
+		return resp, nil
+	}
+	var (
+		kind             emailreply.Kind
+		userID, objectID string
+		signed           bool
+	)
+	for _, recipient := range msg.Recipients {
+		token, ok := emailreply.TokenFromAddress(conf.ReplyAddress, recipient)
+		if !ok {
+			continue
+		}
+		if kind, userID, objectID, signed = emailreply.ParseToken(conf.Secret, token); signed {
+			break
+		}
+	}
+	if !signed {
+		return resp, nil
+	}
+	processed, err := is.inboundEmailRepo.IsProcessed(ctx, msg.MessageID)
+	if err != nil {
+		return nil, err
+	}
+	if processed {
+		resp.Status = schema.InboundEmailStatusDuplicate
+		return resp, nil
+	}
+
+	record := &entity.InboundEmail{
+		MessageID:     msg.MessageID,
+		UserID:        userID,
+		ReplyObjectID: objectID,
+		Status:        schema.InboundEmailStatusRejected,
+	}
+	record.PostedObjectID, err = is.post(ctx, msg, kind, userID, objectID)
+	if err != nil {
+		log.Infof("reject inbound email %s of user %s: %v", msg.MessageID, userID, err)
+	} else {
+		record.Status = schema.InboundEmailStatusPosted
+	}
+	if err = is.inboundEmailRepo.AddInboundEmail(ctx, record); err != nil {
+		return nil, err
+	}
+	resp.Status, resp.ObjectID = record.Status, record.PostedObjectID
+	return resp, nil
+}
+
+// post post the reply by the user who received the notification email
+func (is *InboundEmailService) post(ctx context.Context, msg *emailreply.Message, kind emailreply.Kind,
+	userID, objectID string) (postedObjectID string, err error) {
+	// the signed address may be forwarded, so the sender must be the user
+	userInfo, exist, err := is.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if !exist || userInfo.Status != entity.UserStatusAvailable || userInfo.MailStatus != entity.EmailStatusAvailable ||
+		!strings.EqualFold(userInfo.EMail, msg.From) {
+		return "", errors.Forbidden(reason.InboundEmailSenderMismatch)
+	}
+	text := emailreply.ExtractReply(msg.Text)
+	if len(text) == 0 {
+		return "", errors.BadRequest(reason.InboundEmailReplyEmpty)
+	}
+
+	if kind == emailreply.KindAnswer {
+		return is.postAnswer(ctx, userID, objectID, text)
+	}
+	req := &schema.AddCommentReq{
+		ObjectID:     objectID,
+		OriginalText: text,
+		UserID:       userID,
+	}
+	if kind == emailreply.KindReply {
+		replyComment, exist, err := is.commentCommonRepo.GetComment(ctx, objectID)
+		if err != nil {
+			return "", err
+		}
+		if !exist {
+			return "", errors.BadRequest(reason.CommentNotFound)
+		}
+		req.ObjectID, req.ReplyCommentID = replyComment.ObjectID, replyComment.ID
+	}
+	if _, err = req.Check(); err != nil {
+		return "", err
+	}
+	canList, err := is.rankService.CheckOperationPermissions(ctx, userID, []string{
+		permission.CommentAdd,
+		permission.CommentEdit,
+		permission.CommentDelete,
+	})
+	if err != nil {
+		return "", err
+	}
+	req.CanAdd, req.CanEdit, req.CanDelete = canList[0], canList[1], canList[2]
+	if !req.CanAdd {
+		return "", errors.Forbidden(reason.RankFailToMeetTheCondition)
+	}
+	commentResp, err := is.commentService.AddComment(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	return commentResp.CommentID, nil
+}
+
+func (is *InboundEmailService) postAnswer(ctx context.Context, userID, questionID, text string) (
+	answerID string, err error) {
+	can, err := is.rankService.CheckOperationPermission(ctx, userID, permission.AnswerAdd, "")
+	if err != nil {
+		return "", err
+	}
+	if !can {
+		return "", errors.Forbidden(reason.RankFailToMeetTheCondition)
+	}
+	write, err := is.siteInfoService.GetSiteWrite(ctx)
+	if err != nil {
+		return "", err
+	}
+	if write.RestrictAnswer {
+		ids, err := is.answerService.GetCountByUserIDQuestionID(ctx, userID, questionID)
+		if err != nil {
+			return "", err
+		}
+		if len(ids) >= 1 {
+			return "", errors.Forbidden(reason.AnswerRestrictAnswer)
+		}
+	}
+	req := &schema.AnswerAddReq{
+		QuestionID: questionID,
+		Content:    text,
+		UserID:     userID,
+	}
+	if _, err = req.Check(); err != nil {
+		return "", err
+	}
+	return is.answerService.Insert(ctx, req)
+}
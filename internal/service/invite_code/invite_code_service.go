@@ -0,0 +1,316 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package invite_code
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/token"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// InviteCodeRepo invite code repository
+type InviteCodeRepo interface {
+	AddInviteCode(ctx context.Context, inviteCode *entity.InviteCode) (err error)
+	GetInviteCode(ctx context.Context, id string) (inviteCode *entity.InviteCode, exist bool, err error)
+	GetInviteCodeByCode(ctx context.Context, code string) (inviteCode *entity.InviteCode, exist bool, err error)
+	GetInviteCodesByUserID(ctx context.Context, userID string) (inviteCodes []*entity.InviteCode, err error)
+	GetInviteCodePage(ctx context.Context, page, pageSize int) (inviteCodes []*entity.InviteCode, total int64, err error)
+	RevokeInviteCode(ctx context.Context, id string) (err error)
+	UseInviteCode(ctx context.Context, id string) (used bool, err error)
+	ReleaseInviteCode(ctx context.Context, id string) (err error)
+	AddInviteCodeUsage(ctx context.Context, usage *entity.InviteCodeUsage) (err error)
+	GetInviteCodeUsages(ctx context.Context, inviteCodeID string) (usages []*entity.InviteCodeUsage, err error)
+}
+
+// InviteCodeService the invite codes of the invite-only registration
+type InviteCodeService struct {
+	inviteCodeRepo  InviteCodeRepo
+	userRepo        usercommon.UserRepo
+	siteInfoService siteinfo_common.SiteInfoCommonService
+}
+
+// NewInviteCodeService new invite code service
+func NewInviteCodeService(
+	inviteCodeRepo InviteCodeRepo,
+	userRepo usercommon.UserRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *InviteCodeService {
+	return &InviteCodeService{
+		inviteCodeRepo:  inviteCodeRepo,
+		userRepo:        userRepo,
+		siteInfoService: siteInfoService,
+	}
+}
+
+// GetInviteCodes get the invite codes generated by user
+func (is *InviteCodeService) GetInviteCodes(ctx context.Context, userID string) (resp []*schema.InviteCodeItem, err error) {
+	inviteCodes, err := is.inviteCodeRepo.GetInviteCodesByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return is.formatInviteCodes(ctx, inviteCodes), nil
+}
+
+// GetInviteCodePage admin get all the invite codes
+func (is *InviteCodeService) GetInviteCodePage(ctx context.Context, req *schema.GetInviteCodePageReq) (
+	resp *pager.PageModel, err error) {
+	inviteCodes, total, err := is.inviteCodeRepo.GetInviteCodePage(ctx, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	return pager.NewPageModel(total, is.formatInviteCodes(ctx, inviteCodes)), nil
+}
+
+// AddInviteCode generate invite code, the users who are not admin must reach the min reputation
+// and the invite codes are limited by the site invite config
+func (is *InviteCodeService) AddInviteCode(ctx context.Context, req *schema.AddInviteCodeReq) (
+	resp *schema.InviteCodeItem, err error) {
+	if !req.IsAdmin {
+		if err = is.checkUserCanInvite(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	code, err := token.GenerateRandomToken(8)
+	if err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	inviteCode := &entity.InviteCode{
+		Code:    code,
+		UserID:  req.UserID,
+		MaxUses: req.MaxUses,
+		Status:  entity.InviteCodeStatusAvailable,
+	}
+	if req.ExpiredDays > 0 {
+		inviteCode.ExpiredAt = time.Now().AddDate(0, 0, req.ExpiredDays)
+	}
+	if err = is.inviteCodeRepo.AddInviteCode(ctx, inviteCode); err != nil {
+		return nil, err
+	}
+	return is.formatInviteCodes(ctx, []*entity.InviteCode{inviteCode})[0], nil
+}
+
+// RemoveInviteCode revoke invite code, only the admin or the user generated it could revoke
+func (is *InviteCodeService) RemoveInviteCode(ctx context.Context, req *schema.RemoveInviteCodeReq) (err error) {
+	if _, err = is.getOwnInviteCode(ctx, req.ID, req.UserID, req.IsAdmin); err != nil {
+		return err
+	}
+	return is.inviteCodeRepo.RevokeInviteCode(ctx, req.ID)
+}
+
+// GetInvitedUsers get the users registered with the invite code
+func (is *InviteCodeService) GetInvitedUsers(ctx context.Context, req *schema.GetInvitedUsersReq) (
+	resp []*schema.InvitedUserItem, err error) {
+	if _, err = is.getOwnInviteCode(ctx, req.ID, req.UserID, req.IsAdmin); err != nil {
+		return nil, err
+	}
+	usages, err := is.inviteCodeRepo.GetInviteCodeUsages(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(usages))
+	for _, usage := range usages {
+		userIDs = append(userIDs, usage.UserID)
+	}
+	users, err := is.userRepo.BatchGetByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	userMapping := make(map[string]*entity.User, len(users))
+	for _, user := range users {
+		userMapping[user.ID] = user
+	}
+	resp = make([]*schema.InvitedUserItem, 0, len(usages))
+	for _, usage := range usages {
+		user, ok := userMapping[usage.UserID]
+		if !ok {
+			continue
+		}
+		resp = append(resp, &schema.InvitedUserItem{
+			UserID:       user.ID,
+			Username:     user.Username,
+			DisplayName:  user.DisplayName,
+			RegisteredAt: usage.CreatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// CheckInviteCode check whether the invite code could be used to register
+func (is *InviteCodeService) CheckInviteCode(ctx context.Context, req *schema.CheckInviteCodeReq) (err error) {
+	_, err = is.getAvailableInviteCode(ctx, req.Code)
+	return err
+}
+
+// UseInviteCode use the invite code to register if the registration is invite-only,
+// the invite code id is returned to record the user registered with it, empty if not invite-only
+func (is *InviteCodeService) UseInviteCode(ctx context.Context, code string) (inviteCodeID string, err error) {
+	conf, err := is.siteInfoService.GetSiteInvite(ctx)
+	if err != nil {
+		return "", err
+	}
+	if !conf.InviteOnly {
+		return "", nil
+	}
+	if len(code) == 0 {
+		return "", errors.BadRequest(reason.InviteCodeRequired)
+	}
+	inviteCode, err := is.getAvailableInviteCode(ctx, code)
+	if err != nil {
+		return "", err
+	}
+	used, err := is.inviteCodeRepo.UseInviteCode(ctx, inviteCode.ID)
+	if err != nil {
+		return "", err
+	}
+	if !used {
+		return "", errors.BadRequest(reason.InviteCodeInvalid)
+	}
+	return inviteCode.ID, nil
+}
+
+// ReleaseInviteCode give back the use of the invite code if the registration is failed
+func (is *InviteCodeService) ReleaseInviteCode(ctx context.Context, inviteCodeID string) {
+	if len(inviteCodeID) == 0 {
+		return
+	}
+	if err := is.inviteCodeRepo.ReleaseInviteCode(ctx, inviteCodeID); err != nil {
+		log.Error(err)
+	}
+}
+
+// RecordInvitedUser record the user registered with the invite code
+func (is *InviteCodeService) RecordInvitedUser(ctx context.Context, inviteCodeID, userID string) {
+	if len(inviteCodeID) == 0 {
+		return
+	}
+	err := is.inviteCodeRepo.AddInviteCodeUsage(ctx, &entity.InviteCodeUsage{
+		InviteCodeID: inviteCodeID,
+		UserID:       userID,
+	})
+	if err != nil {
+		log.Error(err)
+	}
+}
+
+func (is *InviteCodeService) checkUserCanInvite(ctx context.Context, req *schema.AddInviteCodeReq) (err error) {
+	conf, err := is.siteInfoService.GetSiteInvite(ctx)
+	if err != nil {
+		return err
+	}
+	if conf.MinReputation <= 0 {
+		return errors.Forbidden(reason.InviteCodeForbidden)
+	}
+	userInfo, exist, err := is.userRepo.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	if !exist || userInfo.Rank < conf.MinReputation {
+		return errors.Forbidden(reason.InviteCodeForbidden)
+	}
+	if req.MaxUses > conf.MaxUsesPerCode {
+		req.MaxUses = conf.MaxUsesPerCode
+	}
+	inviteCodes, err := is.inviteCodeRepo.GetInviteCodesByUserID(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	available := 0
+	now := time.Now()
+	for _, inviteCode := range inviteCodes {
+		if isInviteCodeAvailable(inviteCode, now) {
+			available++
+		}
+	}
+	if available >= conf.MaxCodesPerUser {
+		return errors.BadRequest(reason.InviteCodeLimit)
+	}
+	return nil
+}
+
+func (is *InviteCodeService) getOwnInviteCode(ctx context.Context, id, userID string, isAdmin bool) (
+	inviteCode *entity.InviteCode, err error) {
+	inviteCode, exist, err := is.inviteCodeRepo.GetInviteCode(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || (!isAdmin && inviteCode.UserID != userID) {
+		return nil, errors.NotFound(reason.InviteCodeNotFound)
+	}
+	return inviteCode, nil
+}
+
+func (is *InviteCodeService) getAvailableInviteCode(ctx context.Context, code string) (
+	inviteCode *entity.InviteCode, err error) {
+	inviteCode, exist, err := is.inviteCodeRepo.GetInviteCodeByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || !isInviteCodeAvailable(inviteCode, time.Now()) {
+		return nil, errors.BadRequest(reason.InviteCodeInvalid)
+	}
+	return inviteCode, nil
+}
+
+func (is *InviteCodeService) formatInviteCodes(ctx context.Context, inviteCodes []*entity.InviteCode) (
+	resp []*schema.InviteCodeItem) {
+	siteUrl := ""
+	if siteGeneral, err := is.siteInfoService.GetSiteGeneral(ctx); err != nil {
+		log.Error(err)
+	} else {
+		siteUrl = siteGeneral.SiteUrl
+	}
+	now := time.Now()
+	resp = make([]*schema.InviteCodeItem, 0, len(inviteCodes))
+	for _, inviteCode := range inviteCodes {
+		item := &schema.InviteCodeItem{
+			ID:        inviteCode.ID,
+			Code:      inviteCode.Code,
+			URL:       fmt.Sprintf("%s/users/register?invite_code=%s", siteUrl, url.QueryEscape(inviteCode.Code)),
+			UserID:    inviteCode.UserID,
+			MaxUses:   inviteCode.MaxUses,
+			UsedCount: inviteCode.UsedCount,
+			Available: isInviteCodeAvailable(inviteCode, now),
+			Revoked:   inviteCode.Status == entity.InviteCodeStatusRevoked,
+			CreatedAt: inviteCode.CreatedAt.Unix(),
+		}
+		if !inviteCode.ExpiredAt.IsZero() {
+			item.ExpiredAt = inviteCode.ExpiredAt.Unix()
+		}
+		resp = append(resp, item)
+	}
+	return resp
+}
+
+func isInviteCodeAvailable(inviteCode *entity.InviteCode, now time.Time) bool {
+	return inviteCode.Status == entity.InviteCodeStatusAvailable &&
+		inviteCode.UsedCount < inviteCode.MaxUses &&
+		(inviteCode.ExpiredAt.IsZero() || inviteCode.ExpiredAt.After(now))
+}
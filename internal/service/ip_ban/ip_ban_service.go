@@ -0,0 +1,179 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ip_ban
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/audit_log"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// IPBanRepo ip ban repository
+type IPBanRepo interface {
+	AddIPBan(ctx context.Context, ipBan *entity.IPBan) (err error)
+	GetIPBan(ctx context.Context, id string) (ipBan *entity.IPBan, exist bool, err error)
+	GetIPBanByRange(ctx context.Context, ipRange string) (ipBan *entity.IPBan, exist bool, err error)
+	GetIPBanPage(ctx context.Context, page, pageSize int) (ipBans []*entity.IPBan, total int64, err error)
+	GetActiveIPBans(ctx context.Context, now time.Time) (ipBans []*entity.IPBan, err error)
+	RemoveIPBan(ctx context.Context, id string) (err error)
+	IncreaseHitCount(ctx context.Context, id string, hitAt time.Time) (err error)
+}
+
+// IPBanService the ip and CIDR range bans managed by admin
+type IPBanService struct {
+	ipBanRepo       IPBanRepo
+	auditLogService *audit_log.AuditLogService
+}
+
+// NewIPBanService new ip ban service
+func NewIPBanService(
+	ipBanRepo IPBanRepo,
+	auditLogService *audit_log.AuditLogService,
+) *IPBanService {
+	return &IPBanService{
+		ipBanRepo:       ipBanRepo,
+		auditLogService: auditLogService,
+	}
+}
+
+// GetIPBanPage admin get all the ip bans
+func (is *IPBanService) GetIPBanPage(ctx context.Context, req *schema.GetIPBanPageReq) (
+	resp *pager.PageModel, err error) {
+	ipBans, total, err := is.ipBanRepo.GetIPBanPage(ctx, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	list := make([]*schema.IPBanItem, 0, len(ipBans))
+	for _, ipBan := range ipBans {
+		list = append(list, formatIPBan(ipBan, now))
+	}
+	return pager.NewPageModel(total, list), nil
+}
+
+// AddIPBan ban the ip or CIDR range, the range is already normalized by the request check
+func (is *IPBanService) AddIPBan(ctx context.Context, req *schema.AddIPBanReq) (resp *schema.IPBanItem, err error) {
+	_, exist, err := is.ipBanRepo.GetIPBanByRange(ctx, req.IPRange)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		return nil, errors.BadRequest(reason.IPBanAlreadyExist)
+	}
+	ipBan := &entity.IPBan{
+		IPRange:    req.IPRange,
+		Reason:     req.Reason,
+		OperatorID: req.UserID,
+	}
+	if req.ExpiredHours > 0 {
+		ipBan.ExpiredAt = time.Now().Add(time.Duration(req.ExpiredHours) * time.Hour)
+	}
+	if err = is.ipBanRepo.AddIPBan(ctx, ipBan); err != nil {
+		return nil, err
+	}
+	is.auditLogService.Record(ctx, &schema.AuditLogRecord{
+		OperatorID: req.UserID,
+		Action:     entity.AuditLogActionIPBanAdd,
+		ObjectType: entity.AuditLogObjectTypeIPBan,
+		ObjectID:   ipBan.ID,
+		After: map[string]any{
+			"ip_range":      ipBan.IPRange,
+			"reason":        ipBan.Reason,
+			"expired_hours": req.ExpiredHours,
+		},
+	})
+	return formatIPBan(ipBan, time.Now()), nil
+}
+
+// RemoveIPBan lift the ip ban
+func (is *IPBanService) RemoveIPBan(ctx context.Context, req *schema.RemoveIPBanReq) (err error) {
+	ipBan, exist, err := is.ipBanRepo.GetIPBan(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.NotFound(reason.IPBanNotFound)
+	}
+	if err = is.ipBanRepo.RemoveIPBan(ctx, req.ID); err != nil {
+		return err
+	}
+	is.auditLogService.Record(ctx, &schema.AuditLogRecord{
+		OperatorID: req.UserID,
+		Action:     entity.AuditLogActionIPBanRemove,
+		ObjectType: entity.AuditLogObjectTypeIPBan,
+		ObjectID:   ipBan.ID,
+		Before: map[string]any{
+			"ip_range":  ipBan.IPRange,
+			"reason":    ipBan.Reason,
+			"hit_count": ipBan.HitCount,
+		},
+	})
+	return nil
+}
+
+// IsBanned whether the ip is in any active ban, the hit count of the matched ban is increased.
+// The failure of query is only logged and the request is not blocked.
+func (is *IPBanService) IsBanned(ctx context.Context, ip string) (banned bool) {
+	if len(ip) == 0 {
+		return false
+	}
+	now := time.Now()
+	ipBans, err := is.ipBanRepo.GetActiveIPBans(ctx, now)
+	if err != nil {
+		log.Errorf("get active ip bans error: %v", err)
+		return false
+	}
+	for _, ipBan := range ipBans {
+		if !schema.IPRangeContains(ipBan.IPRange, ip) {
+			continue
+		}
+		if err = is.ipBanRepo.IncreaseHitCount(ctx, ipBan.ID, now); err != nil {
+			log.Errorf("increase ip ban hit count error: %v", err)
+		}
+		return true
+	}
+	return false
+}
+
+func formatIPBan(ipBan *entity.IPBan, now time.Time) *schema.IPBanItem {
+	item := &schema.IPBanItem{
+		ID:         ipBan.ID,
+		IPRange:    ipBan.IPRange,
+		Reason:     ipBan.Reason,
+		OperatorID: ipBan.OperatorID,
+		HitCount:   ipBan.HitCount,
+		Active:     ipBan.ExpiredAt.IsZero() || ipBan.ExpiredAt.After(now),
+		CreatedAt:  ipBan.CreatedAt.Unix(),
+	}
+	if !ipBan.LastHitAt.IsZero() {
+		item.LastHitAt = ipBan.LastHitAt.Unix()
+	}
+	if !ipBan.ExpiredAt.IsZero() {
+		item.ExpiredAt = ipBan.ExpiredAt.Unix()
+	}
+	return item
+}
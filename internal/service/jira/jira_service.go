@@ -0,0 +1,519 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/comment"
+	"github.com/apache/answer/internal/service/event_queue"
+	questioncommon "github.com/apache/answer/internal/service/question_common"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/apache/answer/internal/service/space_common"
+	tagcommon "github.com/apache/answer/internal/service/tag_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/converter"
+	"github.com/apache/answer/pkg/display"
+	"github.com/apache/answer/pkg/htmltext"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// jiraSyncBatchSize the number of issue links synced in one batch
+	jiraSyncBatchSize = 100
+	// jiraDescriptionMaxLength the max length of the question excerpt in the issue description
+	jiraDescriptionMaxLength = 2000
+)
+
+// JiraRepo jira repository
+type JiraRepo interface {
+	AddIssueLink(ctx context.Context, link *entity.JiraIssueLink) (err error)
+	GetIssueLinkByQuestionID(ctx context.Context, questionID string) (link *entity.JiraIssueLink, exist bool, err error)
+	GetUnresolvedIssueLinks(ctx context.Context, afterID string, limit int) (links []*entity.JiraIssueLink, err error)
+	UpdateIssueLink(ctx context.Context, link *entity.JiraIssueLink) (err error)
+	AddModeratorNotes(ctx context.Context, notes []*entity.QuestionModeratorNote) (err error)
+	GetModeratorNotes(ctx context.Context, questionID string) (notes []*entity.QuestionModeratorNote, err error)
+	GetModeratorNoteExternalIDs(ctx context.Context, questionID, source string) (externalIDs []string, err error)
+}
+
+// jiraCreateIssueReq the request creating the issue by jira rest api v2
+type jiraCreateIssueReq struct {
+	Fields struct {
+		Project struct {
+			Key string `json:"key"`
+		} `json:"project"`
+		Summary     string `json:"summary"`
+		Description string `json:"description"`
+		IssueType   struct {
+			Name string `json:"name"`
+		} `json:"issuetype"`
+		Labels []string `json:"labels"`
+	} `json:"fields"`
+}
+
+// jiraCreateIssueResp the response of the created issue
+type jiraCreateIssueResp struct {
+	ID  string `json:"id"`
+	Key string `json:"key"`
+}
+
+// jiraIssue the issue fields synced back
+type jiraIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Status struct {
+			Name string `json:"name"`
+		} `json:"status"`
+		// null if the issue is unresolved
+		Resolution *struct {
+			Name string `json:"name"`
+		} `json:"resolution"`
+		Comment struct {
+			Comments []*struct {
+				ID     string `json:"id"`
+				Body   string `json:"body"`
+				Author struct {
+					DisplayName string `json:"displayName"`
+				} `json:"author"`
+			} `json:"comments"`
+		} `json:"comment"`
+	} `json:"fields"`
+}
+
+// JiraService create the jira issues from the flagged questions and sync the issues back as the moderator notes
+type JiraService struct {
+	jiraRepo         JiraRepo
+	questionRepo     questioncommon.QuestionRepo
+	tagCommonService *tagcommon.TagCommonService
+	userRepo         usercommon.UserRepo
+	commentService   *comment.CommentService
+	siteInfoService  siteinfo_common.SiteInfoCommonService
+	spaceCommon      *space_common.SpaceCommon
+	httpClient       *http.Client
+	syncing          atomic.Bool
+}
+
+// NewJiraService new jira service
+func NewJiraService(
+	jiraRepo JiraRepo,
+	questionRepo questioncommon.QuestionRepo,
+	tagCommonService *tagcommon.TagCommonService,
+	userRepo usercommon.UserRepo,
+	commentService *comment.CommentService,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	spaceCommon *space_common.SpaceCommon,
+	eventQueueService event_queue.EventQueueService,
+) *JiraService {
+	js := &JiraService{
+		jiraRepo:         jiraRepo,
+		questionRepo:     questionRepo,
+		tagCommonService: tagCommonService,
+		userRepo:         userRepo,
+		commentService:   commentService,
+		siteInfoService:  siteInfoService,
+		spaceCommon:      spaceCommon,
+		httpClient:       &http.Client{Timeout: 10 * time.Second},
+	}
+	eventQueueService.RegisterHandler(js.handleEvent)
+	return js
+}
+
+// handleEvent create the issue of the flagged question if the project creates it automatically
+func (js *JiraService) handleEvent(ctx context.Context, msg *schema.EventMsg) error {
+	if msg.EventType != constant.EventQuestionFlag {
+		return nil
+	}
+	conf, err := js.siteInfoService.GetSiteJira(ctx)
+	if err != nil {
+		return err
+	}
+	if !isConfigured(conf) {
+		return nil
+	}
+	question, exist, err := js.questionRepo.GetQuestion(ctx, msg.QuestionID)
+	if err != nil || !exist {
+		return err
+	}
+	if question.Show == entity.QuestionShadow {
+		return nil
+	}
+	// the issue of the private space question is only created by the moderator manually
+	if canView, err := js.spaceCommon.CanViewQuestion(ctx, question.ID, ""); err != nil || !canView {
+		return err
+	}
+	_, exist, err = js.jiraRepo.GetIssueLinkByQuestionID(ctx, question.ID)
+	if err != nil || exist {
+		return err
+	}
+	project, err := js.getProject(ctx, conf, question.ID)
+	if err != nil {
+		return err
+	}
+	if project == nil || !project.AutoCreate {
+		return nil
+	}
+	_, err = js.createIssue(ctx, conf, project, question, "")
+	return err
+}
+
+// CreateIssue create the issue of the question by the moderator, the linked issue is returned if already created
+func (js *JiraService) CreateIssue(ctx context.Context, req *schema.CreateJiraIssueReq) (
+	resp *schema.JiraIssueResp, err error) {
+	conf, err := js.siteInfoService.GetSiteJira(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !isConfigured(conf) {
+		return nil, errors.BadRequest(reason.JiraDisabled)
+	}
+	question, exist, err := js.questionRepo.GetQuestion(ctx, uid.DeShortID(req.QuestionID))
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.QuestionNotFound)
+	}
+	link, exist, err := js.jiraRepo.GetIssueLinkByQuestionID(ctx, question.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		project, err := js.getProject(ctx, conf, question.ID)
+		if err != nil {
+			return nil, err
+		}
+		if project == nil {
+			return nil, errors.BadRequest(reason.JiraProjectNotFound)
+		}
+		link, err = js.createIssue(ctx, conf, project, question, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return formatIssueResp(conf, link), nil
+}
+
+// GetQuestionModeratorNotes get the linked jira issue and the moderator notes of the question
+func (js *JiraService) GetQuestionModeratorNotes(ctx context.Context, req *schema.GetQuestionModeratorNotesReq) (
+	resp *schema.GetQuestionModeratorNotesResp, err error) {
+	questionID := uid.DeShortID(req.QuestionID)
+	resp = &schema.GetQuestionModeratorNotesResp{Notes: make([]*schema.QuestionModeratorNoteItem, 0)}
+	link, exist, err := js.jiraRepo.GetIssueLinkByQuestionID(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		conf, err := js.siteInfoService.GetSiteJira(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resp.JiraIssue = formatIssueResp(conf, link)
+	}
+	notes, err := js.jiraRepo.GetModeratorNotes(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	for _, note := range notes {
+		resp.Notes = append(resp.Notes, &schema.QuestionModeratorNoteItem{
+			ID:        note.ID,
+			Source:    note.Source,
+			Author:    note.Author,
+			Content:   note.Content,
+			CreatedAt: note.CreatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// SyncCron sync the status and comments of the unresolved issues back to the questions
+func (js *JiraService) SyncCron(ctx context.Context) {
+	if !js.syncing.CompareAndSwap(false, true) {
+		return
+	}
+	defer js.syncing.Store(false)
+
+	conf, err := js.siteInfoService.GetSiteJira(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if !isConfigured(conf) {
+		return
+	}
+	lang := js.getSiteLang(ctx)
+	afterID := ""
+	for {
+		links, err := js.jiraRepo.GetUnresolvedIssueLinks(ctx, afterID, jiraSyncBatchSize)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		for _, link := range links {
+			if err := js.syncIssue(ctx, conf, lang, link); err != nil {
+				log.Errorf("sync jira issue %s failed: %v", link.IssueKey, err)
+			}
+		}
+		if len(links) < jiraSyncBatchSize {
+			return
+		}
+		afterID = links[len(links)-1].ID
+	}
+}
+
+// createIssue create the issue in the project and link it to the question
+func (js *JiraService) createIssue(ctx context.Context, conf *schema.SiteJiraResp, project *schema.JiraProject,
+	question *entity.Question, userID string) (link *entity.JiraIssueLink, err error) {
+	siteURL, permalink := js.getSiteURL(ctx)
+	req := &jiraCreateIssueReq{}
+	req.Fields.Project.Key = project.Key
+	req.Fields.Summary = question.Title
+	req.Fields.Description = display.QuestionURL(permalink, siteURL, question.ID, question.Title) +
+		"\n\n" + htmltext.FetchExcerpt(question.ParsedText, "...", jiraDescriptionMaxLength)
+	req.Fields.IssueType.Name = project.GetIssueType()
+	req.Fields.Labels = []string{"answer"}
+	created := &jiraCreateIssueResp{}
+	if err = js.request(ctx, conf, http.MethodPost, "/rest/api/2/issue", req, created); err != nil {
+		return nil, errors.BadRequest(reason.JiraRequestFailed).WithError(err).WithStack()
+	}
+
+	link = &entity.JiraIssueLink{
+		QuestionID: question.ID,
+		ProjectKey: project.Key,
+		IssueKey:   created.Key,
+		UserID:     userID,
+	}
+	if err = js.jiraRepo.AddIssueLink(ctx, link); err != nil {
+		return nil, err
+	}
+	note := &entity.QuestionModeratorNote{
+		QuestionID: question.ID,
+		Source:     schema.ModeratorNoteSourceJira,
+		Content: translator.TrWithData(js.getSiteLang(ctx), reason.JiraIssueCreated, map[string]any{
+			"IssueKey": created.Key,
+		}),
+	}
+	if len(userID) > 0 {
+		if user, exist, err := js.userRepo.GetByUserID(ctx, userID); err == nil && exist {
+			note.Author = user.DisplayName
+		}
+	}
+	if err = js.jiraRepo.AddModeratorNotes(ctx, []*entity.QuestionModeratorNote{note}); err != nil {
+		log.Error(err)
+	}
+	return link, nil
+}
+
+// syncIssue add the new comments and the status change of the issue as the moderator notes,
+// and post the public comment when the issue resolves
+func (js *JiraService) syncIssue(ctx context.Context, conf *schema.SiteJiraResp, lang i18n.Language,
+	link *entity.JiraIssueLink) (err error) {
+	issue := &jiraIssue{}
+	path := "/rest/api/2/issue/" + url.PathEscape(link.IssueKey) + "?fields=status,resolution,comment"
+	if err = js.request(ctx, conf, http.MethodGet, path, nil, issue); err != nil {
+		return err
+	}
+	syncedIDs, err := js.jiraRepo.GetModeratorNoteExternalIDs(ctx, link.QuestionID, schema.ModeratorNoteSourceJira)
+	if err != nil {
+		return err
+	}
+	notes := make([]*entity.QuestionModeratorNote, 0)
+	for _, c := range issue.Fields.Comment.Comments {
+		if slices.Contains(syncedIDs, c.ID) {
+			continue
+		}
+		notes = append(notes, &entity.QuestionModeratorNote{
+			QuestionID: link.QuestionID,
+			Source:     schema.ModeratorNoteSourceJira,
+			ExternalID: c.ID,
+			Author:     c.Author.DisplayName,
+			Content:    c.Body,
+		})
+	}
+	status := issue.Fields.Status.Name
+	changed := status != link.Status
+	if changed {
+		notes = append(notes, &entity.QuestionModeratorNote{
+			QuestionID: link.QuestionID,
+			Source:     schema.ModeratorNoteSourceJira,
+			Content: translator.TrWithData(lang, reason.JiraStatusChanged, map[string]any{
+				"IssueKey": link.IssueKey,
+				"Status":   status,
+			}),
+		})
+		link.Status = status
+	}
+	if err = js.jiraRepo.AddModeratorNotes(ctx, notes); err != nil {
+		return err
+	}
+	if issue.Fields.Resolution != nil {
+		link.Resolved = true
+		js.commentResolved(ctx, conf, link, issue.Fields.Resolution.Name)
+	}
+	if !changed && !link.Resolved {
+		return nil
+	}
+	return js.jiraRepo.UpdateIssueLink(ctx, link)
+}
+
+// commentResolved post the templated public comment of the project under the question by the bot account
+func (js *JiraService) commentResolved(ctx context.Context, conf *schema.SiteJiraResp,
+	link *entity.JiraIssueLink, resolution string) {
+	project := conf.GetProjectByKey(link.ProjectKey)
+	if project == nil || len(project.ResolvedTemplate) == 0 || len(conf.BotUsername) == 0 {
+		return
+	}
+	bot, exist, err := js.userRepo.GetByUsername(ctx, conf.BotUsername)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if !exist || bot.Status != entity.UserStatusAvailable {
+		log.Warnf("jira bot account %s is not available", conf.BotUsername)
+		return
+	}
+	question, exist, err := js.questionRepo.GetQuestion(ctx, link.QuestionID)
+	if err != nil || !exist || question.Status != entity.QuestionStatusAvailable {
+		return
+	}
+	content, err := project.Render(&schema.JiraCommentTemplateData{
+		IssueKey:      link.IssueKey,
+		IssueURL:      issueURL(conf, link.IssueKey),
+		Status:        link.Status,
+		Resolution:    resolution,
+		QuestionTitle: question.Title,
+	})
+	if err != nil {
+		log.Errorf("render jira resolved comment of project %s failed: %v", project.Key, err)
+		return
+	}
+	_, err = js.commentService.AddComment(ctx, &schema.AddCommentReq{
+		ObjectID:     question.ID,
+		OriginalText: content,
+		ParsedText:   converter.Markdown2HTML(content),
+		UserID:       bot.ID,
+	})
+	if err != nil {
+		log.Errorf("add jira resolved comment under question %s failed: %v", question.ID, err)
+	}
+}
+
+// request send the request to the jira rest api and decode the response into the result
+func (js *JiraService) request(ctx context.Context, conf *schema.SiteJiraResp, method, path string,
+	body, result any) (err error) {
+	var reader io.Reader
+	if body != nil {
+		content, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(content)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(conf.BaseURL, "/")+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Answer/"+constant.Version)
+	if len(conf.Email) > 0 {
+		req.SetBasicAuth(conf.Email, conf.APIToken)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+conf.APIToken)
+	}
+	resp, err := js.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		content, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("response status %d: %s", resp.StatusCode, string(content))
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// getProject get the project matching the tags of the question
+func (js *JiraService) getProject(ctx context.Context, conf *schema.SiteJiraResp, questionID string) (
+	project *schema.JiraProject, err error) {
+	objTags, err := js.tagCommonService.GetObjectTag(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]string, 0, len(objTags))
+	for _, tag := range objTags {
+		tags = append(tags, tag.SlugName)
+	}
+	return conf.GetProject(tags), nil
+}
+
+func (js *JiraService) getSiteURL(ctx context.Context) (siteURL string, permalink int) {
+	permalink = constant.PermalinkQuestionID
+	if siteSeo, err := js.siteInfoService.GetSiteSeo(ctx); err == nil {
+		permalink = siteSeo.Permalink
+	}
+	if siteGeneral, err := js.siteInfoService.GetSiteGeneral(ctx); err == nil {
+		siteURL = siteGeneral.SiteUrl
+	}
+	return siteURL, permalink
+}
+
+func (js *JiraService) getSiteLang(ctx context.Context) i18n.Language {
+	interfaceInfo, err := js.siteInfoService.GetSiteInterface(ctx)
+	if err != nil {
+		return i18n.DefaultLanguage
+	}
+	return i18n.Language(interfaceInfo.Language)
+}
+
+func isConfigured(conf *schema.SiteJiraResp) bool {
+	return conf.Enabled && len(conf.BaseURL) > 0 && len(conf.APIToken) > 0
+}
+
+func issueURL(conf *schema.SiteJiraResp, issueKey string) string {
+	return strings.TrimSuffix(conf.BaseURL, "/") + "/browse/" + issueKey
+}
+
+func formatIssueResp(conf *schema.SiteJiraResp, link *entity.JiraIssueLink) *schema.JiraIssueResp {
+	return &schema.JiraIssueResp{
+		ProjectKey: link.ProjectKey,
+		IssueKey:   link.IssueKey,
+		IssueURL:   issueURL(conf, link.IssueKey),
+		Status:     link.Status,
+		Resolved:   link.Resolved,
+	}
+}
@@ -0,0 +1,181 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package leaderboard
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/activity_common"
+	tagcommon "github.com/apache/answer/internal/service/tag_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// LeaderboardRepo leaderboard repository
+type LeaderboardRepo interface {
+	GetUserTagRankStat(ctx context.Context, startTime time.Time) (stats []*entity.LeaderboardUserTagRankStat, err error)
+	ReplaceLeaderboard(ctx context.Context, period string, stats []*entity.LeaderboardStat) (err error)
+	GetLeaderboardPage(ctx context.Context, period, tagID string, page, pageSize int) (
+		stats []*entity.LeaderboardStat, total int64, err error)
+}
+
+// LeaderboardService the leaderboard of users by reputation earned in the period,
+// the leaderboard is pre-aggregated by the scheduler instead of live queries.
+type LeaderboardService struct {
+	leaderboardRepo  LeaderboardRepo
+	activityRepo     activity_common.ActivityRepo
+	tagCommonService *tagcommon.TagCommonService
+	userCommon       *usercommon.UserCommon
+	running          atomic.Bool
+}
+
+// NewLeaderboardService new leaderboard service
+func NewLeaderboardService(
+	leaderboardRepo LeaderboardRepo,
+	activityRepo activity_common.ActivityRepo,
+	tagCommonService *tagcommon.TagCommonService,
+	userCommon *usercommon.UserCommon,
+) *LeaderboardService {
+	return &LeaderboardService{
+		leaderboardRepo:  leaderboardRepo,
+		activityRepo:     activityRepo,
+		tagCommonService: tagCommonService,
+		userCommon:       userCommon,
+	}
+}
+
+// RefreshCron refresh the site and tag leaderboards of all periods
+func (ls *LeaderboardService) RefreshCron(ctx context.Context) {
+	if !ls.running.CompareAndSwap(false, true) {
+		return
+	}
+	defer ls.running.Store(false)
+
+	now := time.Now()
+	for _, period := range schema.LeaderboardPeriods {
+		if err := ls.refresh(ctx, period, now); err != nil {
+			log.Errorf("refresh %s leaderboard failed: %v", period, err)
+		}
+	}
+}
+
+func (ls *LeaderboardService) refresh(ctx context.Context, period string, now time.Time) (err error) {
+	startTime := schema.LeaderboardPeriodStart(period, now)
+	stats := make([]*entity.LeaderboardStat, 0)
+
+	siteStat, err := ls.activityRepo.GetUsersWhoHasGainedTheMostReputation(ctx, startTime, now, schema.LeaderboardMaxUsers)
+	if err != nil {
+		return err
+	}
+	for _, stat := range siteStat {
+		if stat.Rank <= 0 {
+			break
+		}
+		stats = append(stats, &entity.LeaderboardStat{
+			Period:     period,
+			TagID:      "0",
+			UserID:     stat.UserID,
+			Reputation: stat.Rank,
+			Position:   len(stats) + 1,
+		})
+	}
+
+	tagStat, err := ls.leaderboardRepo.GetUserTagRankStat(ctx, startTime)
+	if err != nil {
+		return err
+	}
+	tagUsers := make(map[string][]*entity.LeaderboardUserTagRankStat)
+	for _, stat := range tagStat {
+		if stat.Rank > 0 {
+			tagUsers[stat.TagID] = append(tagUsers[stat.TagID], stat)
+		}
+	}
+	for tagID, users := range tagUsers {
+		sort.SliceStable(users, func(i, j int) bool {
+			return users[i].Rank > users[j].Rank
+		})
+		for i, stat := range users[:min(len(users), schema.LeaderboardTagMaxUsers)] {
+			stats = append(stats, &entity.LeaderboardStat{
+				Period:     period,
+				TagID:      tagID,
+				UserID:     stat.UserID,
+				Reputation: stat.Rank,
+				Position:   i + 1,
+			})
+		}
+	}
+	return ls.leaderboardRepo.ReplaceLeaderboard(ctx, period, stats)
+}
+
+// GetLeaderboard get the site or tag leaderboard of the period
+func (ls *LeaderboardService) GetLeaderboard(ctx context.Context, req *schema.GetLeaderboardReq) (
+	resp *schema.GetLeaderboardResp, err error) {
+	tagID := "0"
+	if len(req.Tag) > 0 {
+		tag, exist, err := ls.tagCommonService.GetTagBySlugName(ctx, req.Tag)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			return nil, errors.BadRequest(reason.TagNotFound)
+		}
+		tagID = tag.ID
+	}
+	stats, total, err := ls.leaderboardRepo.GetLeaderboardPage(ctx, req.Period, tagID, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(stats))
+	for _, stat := range stats {
+		userIDs = append(userIDs, stat.UserID)
+	}
+	userInfoMapping, err := ls.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &schema.GetLeaderboardResp{
+		Period:    req.Period,
+		StartTime: schema.LeaderboardPeriodStart(req.Period, time.Now()).Unix(),
+		Total:     total,
+		List:      make([]*schema.LeaderboardItem, 0, len(stats)),
+	}
+	for _, stat := range stats {
+		if resp.RefreshedAt == 0 {
+			resp.RefreshedAt = stat.CreatedAt.Unix()
+		}
+		item := &schema.LeaderboardItem{
+			Position:   stat.Position,
+			Reputation: stat.Reputation,
+		}
+		if userInfo, ok := userInfoMapping[stat.UserID]; ok {
+			item.User = *userInfo
+		}
+		resp.List = append(resp.List, item)
+	}
+	return resp, nil
+}
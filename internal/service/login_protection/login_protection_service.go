@@ -0,0 +1,170 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package login_protection
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/export"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// LoginProtectionRepo login protection repository
+type LoginProtectionRepo interface {
+	GetLoginFailure(ctx context.Context, key string) (failure *schema.LoginFailure, err error)
+	SetLoginFailure(ctx context.Context, key string, failure *schema.LoginFailure, ttl time.Duration) (err error)
+	RemoveLoginFailure(ctx context.Context, key string) (err error)
+}
+
+// LoginProtectionService throttle the failed password logins of the account and ip
+type LoginProtectionService struct {
+	loginProtectionRepo LoginProtectionRepo
+	userRepo            usercommon.UserRepo
+	siteInfoService     siteinfo_common.SiteInfoCommonService
+	emailService        *export.EmailService
+}
+
+// NewLoginProtectionService new login protection service
+func NewLoginProtectionService(
+	loginProtectionRepo LoginProtectionRepo,
+	userRepo usercommon.UserRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	emailService *export.EmailService,
+) *LoginProtectionService {
+	return &LoginProtectionService{
+		loginProtectionRepo: loginProtectionRepo,
+		userRepo:            userRepo,
+		siteInfoService:     siteInfoService,
+		emailService:        emailService,
+	}
+}
+
+// CheckLogin check whether the account or ip is locked, the time it is locked until is returned with the error
+func (ls *LoginProtectionService) CheckLogin(ctx context.Context, email, ip string) (
+	resp *schema.LoginLockedResp, err error) {
+	conf, err := ls.siteInfoService.GetSiteLoginProtection(ctx)
+	if err != nil || !conf.Enabled {
+		return nil, err
+	}
+	now := time.Now()
+	for _, key := range []string{accountKey(email), ipKey(ip)} {
+		failure, err := ls.loginProtectionRepo.GetLoginFailure(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if failure != nil && failure.IsLocked(now) {
+			return &schema.LoginLockedResp{LockedUntil: failure.LockedUntil}, errors.Forbidden(reason.LoginLocked)
+		}
+	}
+	return nil, nil
+}
+
+// LoginFailed record the failed attempt of the account and ip, the user is notified when the account is locked
+func (ls *LoginProtectionService) LoginFailed(ctx context.Context, email, ip string) {
+	conf, err := ls.siteInfoService.GetSiteLoginProtection(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if !conf.Enabled {
+		return
+	}
+	lockout := time.Duration(conf.LockoutMinutes) * time.Minute
+	maxLockout := time.Duration(conf.MaxLockoutMinutes) * time.Minute
+
+	accountFailure, locked := ls.addFailure(ctx, accountKey(email), conf.MaxAccountAttempts, lockout, maxLockout)
+	if locked {
+		log.Infof("login of account %s is locked until %d by the failed attempts from %s",
+			email, accountFailure.LockedUntil, ip)
+		if conf.NotifyUser {
+			ls.notifyUser(ctx, email, ip, accountFailure.LockedUntil)
+		}
+	}
+	if ipFailure, locked := ls.addFailure(ctx, ipKey(ip), conf.MaxIPAttempts, lockout, maxLockout); locked {
+		log.Infof("login of ip %s is locked until %d by the failed attempts", ip, ipFailure.LockedUntil)
+	}
+}
+
+// LoginSucceeded forget the failed attempts of the account, the failed attempts of the ip are kept
+// so that the attacker cannot reset it by logging in another account
+func (ls *LoginProtectionService) LoginSucceeded(ctx context.Context, email string) {
+	if err := ls.loginProtectionRepo.RemoveLoginFailure(ctx, accountKey(email)); err != nil {
+		log.Error(err)
+	}
+}
+
+func (ls *LoginProtectionService) addFailure(ctx context.Context, key string, maxAttempts int,
+	lockout, maxLockout time.Duration) (failure *schema.LoginFailure, locked bool) {
+	failure, err := ls.loginProtectionRepo.GetLoginFailure(ctx, key)
+	if err != nil {
+		log.Error(err)
+		return nil, false
+	}
+	if failure == nil {
+		failure = &schema.LoginFailure{}
+	}
+	now := time.Now()
+	locked = failure.AddFailure(now, maxAttempts, lockout, maxLockout)
+	// the failures are forgotten if there is no failure during the max lockout after it is unlocked
+	ttl := maxLockout
+	if failure.IsLocked(now) {
+		ttl += time.Unix(failure.LockedUntil, 0).Sub(now)
+	}
+	if err = ls.loginProtectionRepo.SetLoginFailure(ctx, key, failure, ttl); err != nil {
+		log.Error(err)
+	}
+	return failure, locked
+}
+
+func (ls *LoginProtectionService) notifyUser(ctx context.Context, email, ip string, lockedUntil int64) {
+	userInfo, exist, err := ls.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if !exist {
+		return
+	}
+	title, body, err := ls.emailService.SuspiciousLoginTemplate(ctx, &schema.SuspiciousLoginTemplateData{
+		DisplayName: userInfo.DisplayName,
+		IP:          ip,
+		LockedUntil: time.Unix(lockedUntil, 0).UTC().Format(time.RFC1123),
+	})
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	go ls.emailService.Send(ctx, userInfo.EMail, title, body)
+}
+
+func accountKey(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
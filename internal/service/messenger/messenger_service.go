@@ -0,0 +1,266 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package messenger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/event_queue"
+	"github.com/apache/answer/internal/service/object_info"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/apache/answer/internal/service/space_common"
+	tagcommon "github.com/apache/answer/internal/service/tag_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/display"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+)
+
+// telegramAPIURL the url of telegram bot api
+const telegramAPIURL = "https://api.telegram.org"
+
+// telegramMessage the message sent by the telegram bot
+type telegramMessage struct {
+	ChatID                string `json:"chat_id"`
+	Text                  string `json:"text"`
+	ParseMode             string `json:"parse_mode"`
+	DisableWebPagePreview bool   `json:"disable_web_page_preview"`
+}
+
+// discordMessage the message posted to the discord webhook
+type discordMessage struct {
+	Content string `json:"content"`
+}
+
+// MessengerService mirror the site events to the telegram chats and discord channels
+type MessengerService struct {
+	tagCommonService *tagcommon.TagCommonService
+	objectService    *object_info.ObjService
+	userCommon       *usercommon.UserCommon
+	siteInfoService  siteinfo_common.SiteInfoCommonService
+	spaceCommon      *space_common.SpaceCommon
+	httpClient       *http.Client
+}
+
+// NewMessengerService new messenger service
+func NewMessengerService(
+	tagCommonService *tagcommon.TagCommonService,
+	objectService *object_info.ObjService,
+	userCommon *usercommon.UserCommon,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	spaceCommon *space_common.SpaceCommon,
+	eventQueueService event_queue.EventQueueService,
+) *MessengerService {
+	ms := &MessengerService{
+		tagCommonService: tagCommonService,
+		objectService:    objectService,
+		userCommon:       userCommon,
+		siteInfoService:  siteInfoService,
+		spaceCommon:      spaceCommon,
+		httpClient:       &http.Client{Timeout: 10 * time.Second},
+	}
+	eventQueueService.RegisterHandler(ms.handleEvent)
+	return ms
+}
+
+func (ms *MessengerService) handleEvent(ctx context.Context, msg *schema.EventMsg) error {
+	var event, messageKey string
+	switch msg.EventType {
+	case constant.EventQuestionCreate:
+		event, messageKey = schema.WebhookEventQuestionCreated, reason.MessengerNewQuestion
+	case constant.EventQuestionAccept:
+		event, messageKey = schema.WebhookEventAnswerAccepted, reason.MessengerAnswerAccepted
+	default:
+		return nil
+	}
+	conf, err := ms.siteInfoService.GetSiteMessenger(ctx)
+	if err != nil {
+		return err
+	}
+	if !conf.Enabled || len(conf.Connectors) == 0 {
+		return nil
+	}
+	objInfo, err := ms.objectService.GetInfo(ctx, msg.QuestionID)
+	if err != nil {
+		return err
+	}
+	// the question waiting for review or of the shadow-banned user is not mirrored
+	if objInfo.QuestionStatus != entity.QuestionStatusAvailable || objInfo.QuestionShow == entity.QuestionShadow {
+		return nil
+	}
+	objTags, err := ms.tagCommonService.GetObjectTag(ctx, objInfo.QuestionID)
+	if err != nil {
+		return err
+	}
+	// the question of the private space is not mirrored
+	if canView, err := ms.spaceCommon.CanViewTags(ctx, objTags, ""); err != nil || !canView {
+		return err
+	}
+	tags := make([]string, 0, len(objTags))
+	for _, tag := range objTags {
+		tags = append(tags, tag.SlugName)
+	}
+
+	siteURL, permalink := ms.getSiteURL(ctx)
+	link := display.QuestionURL(permalink, siteURL, objInfo.QuestionID, objInfo.Title)
+	if event == schema.WebhookEventAnswerAccepted {
+		link = display.AnswerURL(permalink, siteURL, objInfo.QuestionID, objInfo.Title, msg.AnswerID)
+	}
+	lang := ms.getSiteLang(ctx)
+	userName := ms.getUserName(ctx, msg.UserID)
+	for _, connector := range conf.Connectors {
+		if !connector.Match(event, tags) {
+			continue
+		}
+		var err error
+		switch connector.Platform {
+		case schema.MessengerPlatformTelegram:
+			labels := make([]string, 0, len(tags))
+			for _, tag := range tags {
+				labels = append(labels, "<code>"+html.EscapeString(tag)+"</code>")
+			}
+			err = ms.send(ctx, connector, translator.TrWithData(lang, messageKey, map[string]any{
+				"User": html.EscapeString(userName),
+				"Link": fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(link), html.EscapeString(objInfo.Title)),
+				"Tags": strings.Join(labels, " "),
+			}))
+		case schema.MessengerPlatformDiscord:
+			labels := make([]string, 0, len(tags))
+			for _, tag := range tags {
+				labels = append(labels, "`"+tag+"`")
+			}
+			err = ms.send(ctx, connector, translator.TrWithData(lang, messageKey, map[string]any{
+				"User": escapeDiscord(userName),
+				"Link": fmt.Sprintf("[%s](<%s>)", escapeDiscord(objInfo.Title), link),
+				"Tags": strings.Join(labels, " "),
+			}))
+		}
+		// the failed message is dropped because it is only a notification
+		if err != nil {
+			log.Errorf("post %s message to %s failed: %v", connector.Platform, connector.Name, err)
+		}
+	}
+	return nil
+}
+
+// TestConnector send a test message to the connector to verify its settings
+func (ms *MessengerService) TestConnector(ctx context.Context, req *schema.TestMessengerConnectorReq) (err error) {
+	conf, err := ms.siteInfoService.GetSiteMessenger(ctx)
+	if err != nil {
+		return err
+	}
+	connector := conf.GetConnector(req.Name)
+	if connector == nil {
+		return errors.BadRequest(reason.MessengerConnectorNotFound)
+	}
+	siteName := ""
+	if siteGeneral, err := ms.siteInfoService.GetSiteGeneral(ctx); err == nil {
+		siteName = siteGeneral.Name
+	}
+	text := translator.TrWithData(ms.getSiteLang(ctx), reason.MessengerTestMessage, map[string]any{"SiteName": siteName})
+	if err = ms.send(ctx, connector, text); err != nil {
+		return errors.BadRequest(reason.MessengerSendFailed).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// send the formatted text to the chat or channel of the connector
+func (ms *MessengerService) send(ctx context.Context, connector *schema.MessengerConnector, text string) (err error) {
+	if connector.Platform == schema.MessengerPlatformTelegram {
+		return ms.post(ctx, connector, telegramAPIURL+"/bot"+connector.BotToken+"/sendMessage", &telegramMessage{
+			ChatID:                connector.ChatID,
+			Text:                  text,
+			ParseMode:             "HTML",
+			DisableWebPagePreview: true,
+		})
+	}
+	return ms.post(ctx, connector, connector.WebhookURL, &discordMessage{Content: text})
+}
+
+// post the message to the connector
+func (ms *MessengerService) post(ctx context.Context, connector *schema.MessengerConnector, url string, message any) (
+	err error) {
+	body, _ := json.Marshal(message)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Answer/"+constant.Version)
+	resp, err := ms.httpClient.Do(req)
+	if err != nil {
+		// the error of telegram request contains the bot token in url
+		if len(connector.BotToken) > 0 {
+			err = fmt.Errorf("%s", strings.ReplaceAll(err.Error(), connector.BotToken, "***"))
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("response status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (ms *MessengerService) getUserName(ctx context.Context, userID string) string {
+	userInfo, exist, err := ms.userCommon.GetUserBasicInfoByID(ctx, userID)
+	if err != nil || !exist {
+		return userID
+	}
+	return userInfo.DisplayName
+}
+
+func (ms *MessengerService) getSiteURL(ctx context.Context) (siteURL string, permalink int) {
+	permalink = constant.PermalinkQuestionID
+	if siteSeo, err := ms.siteInfoService.GetSiteSeo(ctx); err == nil {
+		permalink = siteSeo.Permalink
+	}
+	if siteGeneral, err := ms.siteInfoService.GetSiteGeneral(ctx); err == nil {
+		siteURL = siteGeneral.SiteUrl
+	}
+	return siteURL, permalink
+}
+
+func (ms *MessengerService) getSiteLang(ctx context.Context) i18n.Language {
+	interfaceInfo, err := ms.siteInfoService.GetSiteInterface(ctx)
+	if err != nil {
+		return i18n.DefaultLanguage
+	}
+	return i18n.Language(interfaceInfo.Language)
+}
+
+// escapeDiscord escape the control characters of discord markdown
+func escapeDiscord(text string) string {
+	return strings.NewReplacer("\\", "\\\\", "[", "\\[", "]", "\\]", "*", "\\*", "_", "\\_", "`", "\\`",
+		"~", "\\~", "|", "\\|", "@", "@​").Replace(text)
+}
@@ -0,0 +1,252 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package mobile_push
+
+import (
+	"context"
+	"sort"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/internal/service/user_notification_config"
+	"github.com/apache/answer/pkg/display"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/apache/answer/plugin"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+)
+
+// MobilePushRepo mobile push device repository
+type MobilePushRepo interface {
+	SaveDevice(ctx context.Context, device *entity.MobilePushDevice) (err error)
+	RemoveDevice(ctx context.Context, userID, token string) (err error)
+	RemoveDeviceByID(ctx context.Context, id string) (err error)
+	RemoveDevicesByTokens(ctx context.Context, tokens []string) (err error)
+	GetDevicesByUserID(ctx context.Context, userID string) (devices []*entity.MobilePushDevice, err error)
+}
+
+// MobilePushService push the inbox notifications to the registered mobile devices through the push provider plugins
+type MobilePushService struct {
+	mobilePushRepo             MobilePushRepo
+	siteInfoService            siteinfo_common.SiteInfoCommonService
+	userCommon                 *usercommon.UserCommon
+	userNotificationConfigRepo user_notification_config.UserNotificationConfigRepo
+}
+
+// NewMobilePushService new mobile push service
+func NewMobilePushService(
+	mobilePushRepo MobilePushRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	userCommon *usercommon.UserCommon,
+	userNotificationConfigRepo user_notification_config.UserNotificationConfigRepo,
+) *MobilePushService {
+	return &MobilePushService{
+		mobilePushRepo:             mobilePushRepo,
+		siteInfoService:            siteInfoService,
+		userCommon:                 userCommon,
+		userNotificationConfigRepo: userNotificationConfigRepo,
+	}
+}
+
+// GetPlatforms get the platforms supported by the enabled push providers
+func (ms *MobilePushService) GetPlatforms(ctx context.Context) (resp *schema.GetMobilePushPlatformsResp, err error) {
+	resp = &schema.GetMobilePushPlatformsResp{Platforms: make([]string, 0)}
+	for platform := range ms.getProviders() {
+		resp.Platforms = append(resp.Platforms, string(platform))
+	}
+	sort.Strings(resp.Platforms)
+	return resp, nil
+}
+
+// RegisterDevice save the token of the device, the first device opts the user in push notifications
+func (ms *MobilePushService) RegisterDevice(ctx context.Context, req *schema.MobilePushDeviceRegisterReq) (err error) {
+	if _, ok := ms.getProviders()[plugin.MobilePushPlatform(req.Platform)]; !ok {
+		return errors.BadRequest(reason.MobilePushPlatformUnsupported)
+	}
+	err = ms.mobilePushRepo.SaveDevice(ctx, &entity.MobilePushDevice{
+		UserID:     req.UserID,
+		Platform:   req.Platform,
+		Token:      req.Token,
+		DeviceName: req.DeviceName,
+	})
+	if err != nil {
+		return err
+	}
+
+	devices, err := ms.mobilePushRepo.GetDevicesByUserID(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	for i := schema.MobilePushMaxDevices; i < len(devices); i++ {
+		if err = ms.mobilePushRepo.RemoveDeviceByID(ctx, devices[i].ID); err != nil {
+			return err
+		}
+	}
+
+	// the browsers and the mobile devices share the push preference
+	_, exist, err := ms.userNotificationConfigRepo.GetByUserIDAndSource(ctx, req.UserID, constant.WebPushSource)
+	if err != nil || exist {
+		return err
+	}
+	channels := schema.NotificationChannels{{Key: constant.WebPushChannel, Enable: true}}
+	return ms.userNotificationConfigRepo.Save(ctx, &entity.UserNotificationConfig{
+		UserID:   req.UserID,
+		Source:   string(constant.WebPushSource),
+		Channels: channels.ToJsonString(),
+		Enabled:  true,
+	})
+}
+
+// RemoveDevice remove the token of the device, e.g. the user signs out of the app
+func (ms *MobilePushService) RemoveDevice(ctx context.Context, req *schema.MobilePushDeviceRemoveReq) (err error) {
+	return ms.mobilePushRepo.RemoveDevice(ctx, req.UserID, req.Token)
+}
+
+// GetDevices get the registered devices of the user
+func (ms *MobilePushService) GetDevices(ctx context.Context, userID string) (
+	resp []*schema.MobilePushDeviceResp, err error) {
+	devices, err := ms.mobilePushRepo.GetDevicesByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.MobilePushDeviceResp, 0, len(devices))
+	for _, device := range devices {
+		resp = append(resp, &schema.MobilePushDeviceResp{
+			ID:         device.ID,
+			Platform:   device.Platform,
+			DeviceName: device.DeviceName,
+			CreatedAt:  device.CreatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// PushNotification push the inbox notification to the devices of the receiver,
+// the caller checks the receiver enabled the push of the notification in the preference matrix
+func (ms *MobilePushService) PushNotification(ctx context.Context, msg *schema.NotificationMsg,
+	objInfo *schema.SimpleObjectInfo, triggerUser *schema.UserBasicInfo) {
+	if objInfo == nil || triggerUser == nil {
+		return
+	}
+	providers := ms.getProviders()
+	if len(providers) == 0 {
+		return
+	}
+	devices, err := ms.mobilePushRepo.GetDevicesByUserID(ctx, msg.ReceiverUserID)
+	if err != nil || len(devices) == 0 {
+		return
+	}
+	platformTokens := make(map[plugin.MobilePushPlatform][]string)
+	for _, device := range devices {
+		platform := plugin.MobilePushPlatform(device.Platform)
+		platformTokens[platform] = append(platformTokens[platform], device.Token)
+	}
+
+	pushMsg, err := ms.buildMessage(ctx, msg, objInfo, triggerUser)
+	if err != nil {
+		log.Errorf("build mobile push message failed: %v", err)
+		return
+	}
+	for platform, tokens := range platformTokens {
+		provider, ok := providers[platform]
+		if !ok {
+			continue
+		}
+		invalidTokens, err := provider.Push(platform, tokens, pushMsg)
+		if err != nil {
+			log.Warnf("push notification to %s devices by %s failed: %v", platform, provider.Info().SlugName, err)
+		}
+		// the tokens are expired or the app is uninstalled
+		if err = ms.mobilePushRepo.RemoveDevicesByTokens(ctx, invalidTokens); err != nil {
+			log.Error(err)
+		}
+	}
+}
+
+// getProviders get the enabled push provider of each platform, the first registered one wins
+func (ms *MobilePushService) getProviders() (providers map[plugin.MobilePushPlatform]plugin.MobilePush) {
+	providers = make(map[plugin.MobilePushPlatform]plugin.MobilePush)
+	_ = plugin.CallMobilePush(func(provider plugin.MobilePush) error {
+		for _, platform := range provider.Platforms() {
+			if _, ok := providers[platform]; !ok {
+				providers[platform] = provider
+			}
+		}
+		return nil
+	})
+	return providers
+}
+
+func (ms *MobilePushService) buildMessage(ctx context.Context, msg *schema.NotificationMsg,
+	objInfo *schema.SimpleObjectInfo, triggerUser *schema.UserBasicInfo) (pushMsg plugin.MobilePushMessage, err error) {
+	siteInfo, err := ms.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return pushMsg, err
+	}
+	seoInfo, err := ms.siteInfoService.GetSiteSeo(ctx)
+	if err != nil {
+		return pushMsg, err
+	}
+	questionID, answerID := uid.DeShortID(objInfo.QuestionID), uid.DeShortID(objInfo.AnswerID)
+	var link string
+	switch {
+	case len(objInfo.CommentID) > 0:
+		link = display.CommentURL(seoInfo.Permalink, siteInfo.SiteUrl, questionID, objInfo.Title, answerID, objInfo.CommentID)
+	case len(answerID) > 0:
+		link = display.AnswerURL(seoInfo.Permalink, siteInfo.SiteUrl, questionID, objInfo.Title, answerID)
+	default:
+		link = display.QuestionURL(seoInfo.Permalink, siteInfo.SiteUrl, questionID, objInfo.Title)
+	}
+
+	lang := ms.getUserLang(ctx, msg.ReceiverUserID)
+	return plugin.MobilePushMessage{
+		Type:           plugin.NotificationType(msg.NotificationAction),
+		ReceiverUserID: msg.ReceiverUserID,
+		ReceiverLang:   string(lang),
+		Title: translator.TrWithData(lang, reason.WebPushNotificationTitle, map[string]any{
+			"User":   triggerUser.DisplayName,
+			"Action": translator.Tr(lang, msg.NotificationAction),
+		}),
+		Body:       objInfo.Title,
+		URL:        link,
+		ThreadID:   "question-" + questionID,
+		QuestionID: questionID,
+		AnswerID:   answerID,
+		CommentID:  objInfo.CommentID,
+	}, nil
+}
+
+func (ms *MobilePushService) getUserLang(ctx context.Context, userID string) i18n.Language {
+	userInfo, exist, err := ms.userCommon.GetUserBasicInfoByID(ctx, userID)
+	if err == nil && exist && len(userInfo.Language) > 0 && userInfo.Language != translator.DefaultLangOption {
+		return i18n.Language(userInfo.Language)
+	}
+	interfaceInfo, err := ms.siteInfoService.GetSiteInterface(ctx)
+	if err != nil {
+		return i18n.DefaultLanguage
+	}
+	return i18n.Language(interfaceInfo.Language)
+}
@@ -77,6 +77,53 @@ func (mr *MockSiteInfoRepoMockRecorder) GetByType(ctx, siteType any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByType", reflect.TypeOf((*MockSiteInfoRepo)(nil).GetByType), ctx, siteType)
 }
 
+// GetHistory mocks base method.
+func (m *MockSiteInfoRepo) GetHistory(ctx context.Context, siteType string, version int) (*entity.SiteInfoHistory, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, siteType, version)
+	ret0, _ := ret[0].(*entity.SiteInfoHistory)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockSiteInfoRepoMockRecorder) GetHistory(ctx, siteType, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockSiteInfoRepo)(nil).GetHistory), ctx, siteType, version)
+}
+
+// GetHistoryPage mocks base method.
+func (m *MockSiteInfoRepo) GetHistoryPage(ctx context.Context, siteType string, page, pageSize int) ([]*entity.SiteInfoHistory, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistoryPage", ctx, siteType, page, pageSize)
+	ret0, _ := ret[0].([]*entity.SiteInfoHistory)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetHistoryPage indicates an expected call of GetHistoryPage.
+func (mr *MockSiteInfoRepoMockRecorder) GetHistoryPage(ctx, siteType, page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistoryPage", reflect.TypeOf((*MockSiteInfoRepo)(nil).GetHistoryPage), ctx, siteType, page, pageSize)
+}
+
+// GetVersions mocks base method.
+func (m *MockSiteInfoRepo) GetVersions(ctx context.Context) ([]*entity.SiteInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersions", ctx)
+	ret0, _ := ret[0].([]*entity.SiteInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVersions indicates an expected call of GetVersions.
+func (mr *MockSiteInfoRepoMockRecorder) GetVersions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersions", reflect.TypeOf((*MockSiteInfoRepo)(nil).GetVersions), ctx)
+}
+
 // IsBrandingFileUsed mocks base method.
 func (m *MockSiteInfoRepo) IsBrandingFileUsed(ctx context.Context, filePath string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -158,6 +205,51 @@ func (mr *MockSiteInfoCommonServiceMockRecorder) FormatListAvatar(ctx, userList
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FormatListAvatar", reflect.TypeOf((*MockSiteInfoCommonService)(nil).FormatListAvatar), ctx, userList)
 }
 
+// GetSiteAnswerRanking mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteAnswerRanking(ctx context.Context) (*schema.SiteAnswerRankingResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteAnswerRanking", ctx)
+	ret0, _ := ret[0].(*schema.SiteAnswerRankingResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteAnswerRanking indicates an expected call of GetSiteAnswerRanking.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteAnswerRanking(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteAnswerRanking", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteAnswerRanking), ctx)
+}
+
+// GetSiteAutoComment mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteAutoComment(ctx context.Context) (*schema.SiteAutoCommentResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteAutoComment", ctx)
+	ret0, _ := ret[0].(*schema.SiteAutoCommentResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteAutoComment indicates an expected call of GetSiteAutoComment.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteAutoComment(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteAutoComment", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteAutoComment), ctx)
+}
+
+// GetSiteAutoPromotion mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteAutoPromotion(ctx context.Context) (*schema.SiteAutoPromotionResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteAutoPromotion", ctx)
+	ret0, _ := ret[0].(*schema.SiteAutoPromotionResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteAutoPromotion indicates an expected call of GetSiteAutoPromotion.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteAutoPromotion(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteAutoPromotion", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteAutoPromotion), ctx)
+}
+
 // GetSiteBranding mocks base method.
 func (m *MockSiteInfoCommonService) GetSiteBranding(ctx context.Context) (*schema.SiteBrandingResp, error) {
 	m.ctrl.T.Helper()
@@ -173,6 +265,51 @@ func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteBranding(ctx any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteBranding", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteBranding), ctx)
 }
 
+// GetSiteCacheWarming mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteCacheWarming(ctx context.Context) (*schema.SiteCacheWarmingResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteCacheWarming", ctx)
+	ret0, _ := ret[0].(*schema.SiteCacheWarmingResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteCacheWarming indicates an expected call of GetSiteCacheWarming.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteCacheWarming(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteCacheWarming", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteCacheWarming), ctx)
+}
+
+// GetSiteChatIntake mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteChatIntake(ctx context.Context) (*schema.SiteChatIntakeResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteChatIntake", ctx)
+	ret0, _ := ret[0].(*schema.SiteChatIntakeResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteChatIntake indicates an expected call of GetSiteChatIntake.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteChatIntake(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteChatIntake", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteChatIntake), ctx)
+}
+
+// GetSiteContentFreshness mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteContentFreshness(ctx context.Context) (*schema.SiteContentFreshnessResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteContentFreshness", ctx)
+	ret0, _ := ret[0].(*schema.SiteContentFreshnessResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteContentFreshness indicates an expected call of GetSiteContentFreshness.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteContentFreshness(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteContentFreshness", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteContentFreshness), ctx)
+}
+
 // GetSiteCustomCssHTML mocks base method.
 func (m *MockSiteInfoCommonService) GetSiteCustomCssHTML(ctx context.Context) (*schema.SiteCustomCssHTMLResp, error) {
 	m.ctrl.T.Helper()
@@ -188,6 +325,51 @@ func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteCustomCssHTML(ctx any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteCustomCssHTML", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteCustomCssHTML), ctx)
 }
 
+// GetSiteDataDump mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteDataDump(ctx context.Context) (*schema.SiteDataDumpResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteDataDump", ctx)
+	ret0, _ := ret[0].(*schema.SiteDataDumpResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteDataDump indicates an expected call of GetSiteDataDump.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteDataDump(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteDataDump", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteDataDump), ctx)
+}
+
+// GetSiteDigest mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteDigest(ctx context.Context) (*schema.SiteDigestResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteDigest", ctx)
+	ret0, _ := ret[0].(*schema.SiteDigestResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteDigest indicates an expected call of GetSiteDigest.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteDigest(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteDigest", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteDigest), ctx)
+}
+
+// GetSiteDownvoteCost mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteDownvoteCost(ctx context.Context) (*schema.SiteDownvoteCostResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteDownvoteCost", ctx)
+	ret0, _ := ret[0].(*schema.SiteDownvoteCostResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteDownvoteCost indicates an expected call of GetSiteDownvoteCost.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteDownvoteCost(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteDownvoteCost", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteDownvoteCost), ctx)
+}
+
 // GetSiteGeneral mocks base method.
 func (m *MockSiteInfoCommonService) GetSiteGeneral(ctx context.Context) (*schema.SiteGeneralResp, error) {
 	m.ctrl.T.Helper()
@@ -203,6 +385,36 @@ func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteGeneral(ctx any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteGeneral", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteGeneral), ctx)
 }
 
+// GetSiteHelpfulnessSurvey mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteHelpfulnessSurvey(ctx context.Context) (*schema.SiteHelpfulnessSurveyResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteHelpfulnessSurvey", ctx)
+	ret0, _ := ret[0].(*schema.SiteHelpfulnessSurveyResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteHelpfulnessSurvey indicates an expected call of GetSiteHelpfulnessSurvey.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteHelpfulnessSurvey(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteHelpfulnessSurvey", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteHelpfulnessSurvey), ctx)
+}
+
+// GetSiteInboundEmail mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteInboundEmail(ctx context.Context) (*schema.SiteInboundEmailResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteInboundEmail", ctx)
+	ret0, _ := ret[0].(*schema.SiteInboundEmailResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteInboundEmail indicates an expected call of GetSiteInboundEmail.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteInboundEmail(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteInboundEmail", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteInboundEmail), ctx)
+}
+
 // GetSiteInfoByType mocks base method.
 func (m *MockSiteInfoCommonService) GetSiteInfoByType(ctx context.Context, siteType string, resp any) error {
 	m.ctrl.T.Helper()
@@ -232,6 +444,51 @@ func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteInterface(ctx any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteInterface", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteInterface), ctx)
 }
 
+// GetSiteInvite mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteInvite(ctx context.Context) (*schema.SiteInviteResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteInvite", ctx)
+	ret0, _ := ret[0].(*schema.SiteInviteResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteInvite indicates an expected call of GetSiteInvite.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteInvite(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteInvite", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteInvite), ctx)
+}
+
+// GetSiteJira mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteJira(ctx context.Context) (*schema.SiteJiraResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteJira", ctx)
+	ret0, _ := ret[0].(*schema.SiteJiraResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteJira indicates an expected call of GetSiteJira.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteJira(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteJira", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteJira), ctx)
+}
+
+// GetSiteLanguageDetection mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteLanguageDetection(ctx context.Context) (*schema.SiteLanguageDetectionResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteLanguageDetection", ctx)
+	ret0, _ := ret[0].(*schema.SiteLanguageDetectionResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteLanguageDetection indicates an expected call of GetSiteLanguageDetection.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteLanguageDetection(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteLanguageDetection", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteLanguageDetection), ctx)
+}
+
 // GetSiteLegal mocks base method.
 func (m *MockSiteInfoCommonService) GetSiteLegal(ctx context.Context) (*schema.SiteLegalResp, error) {
 	m.ctrl.T.Helper()
@@ -247,6 +504,36 @@ func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteLegal(ctx any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteLegal", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteLegal), ctx)
 }
 
+// GetSiteLimits mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteLimits(ctx context.Context) (*schema.SiteLimitsResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteLimits", ctx)
+	ret0, _ := ret[0].(*schema.SiteLimitsResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteLimits indicates an expected call of GetSiteLimits.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteLimits(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteLimits", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteLimits), ctx)
+}
+
+// GetSiteLoadShedding mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteLoadShedding(ctx context.Context) (*schema.SiteLoadSheddingResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteLoadShedding", ctx)
+	ret0, _ := ret[0].(*schema.SiteLoadSheddingResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteLoadShedding indicates an expected call of GetSiteLoadShedding.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteLoadShedding(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteLoadShedding", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteLoadShedding), ctx)
+}
+
 // GetSiteLogin mocks base method.
 func (m *MockSiteInfoCommonService) GetSiteLogin(ctx context.Context) (*schema.SiteLoginResp, error) {
 	m.ctrl.T.Helper()
@@ -262,6 +549,171 @@ func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteLogin(ctx any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteLogin", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteLogin), ctx)
 }
 
+// GetSiteLoginProtection mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteLoginProtection(ctx context.Context) (*schema.SiteLoginProtectionResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteLoginProtection", ctx)
+	ret0, _ := ret[0].(*schema.SiteLoginProtectionResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteLoginProtection indicates an expected call of GetSiteLoginProtection.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteLoginProtection(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteLoginProtection", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteLoginProtection), ctx)
+}
+
+// GetSiteMessenger mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteMessenger(ctx context.Context) (*schema.SiteMessengerResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteMessenger", ctx)
+	ret0, _ := ret[0].(*schema.SiteMessengerResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteMessenger indicates an expected call of GetSiteMessenger.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteMessenger(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteMessenger", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteMessenger), ctx)
+}
+
+// GetSiteNotificationRetention mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteNotificationRetention(ctx context.Context) (*schema.SiteNotificationRetentionResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteNotificationRetention", ctx)
+	ret0, _ := ret[0].(*schema.SiteNotificationRetentionResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteNotificationRetention indicates an expected call of GetSiteNotificationRetention.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteNotificationRetention(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteNotificationRetention", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteNotificationRetention), ctx)
+}
+
+// GetSiteOAuthProvider mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteOAuthProvider(ctx context.Context) (*schema.SiteOAuthProviderResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteOAuthProvider", ctx)
+	ret0, _ := ret[0].(*schema.SiteOAuthProviderResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteOAuthProvider indicates an expected call of GetSiteOAuthProvider.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteOAuthProvider(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteOAuthProvider", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteOAuthProvider), ctx)
+}
+
+// GetSiteQuestionClose mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteQuestionClose(ctx context.Context) (*schema.SiteQuestionCloseResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteQuestionClose", ctx)
+	ret0, _ := ret[0].(*schema.SiteQuestionCloseResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteQuestionClose indicates an expected call of GetSiteQuestionClose.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteQuestionClose(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteQuestionClose", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteQuestionClose), ctx)
+}
+
+// GetSiteQuestionMigration mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteQuestionMigration(ctx context.Context) (*schema.SiteQuestionMigrationResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteQuestionMigration", ctx)
+	ret0, _ := ret[0].(*schema.SiteQuestionMigrationResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteQuestionMigration indicates an expected call of GetSiteQuestionMigration.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteQuestionMigration(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteQuestionMigration", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteQuestionMigration), ctx)
+}
+
+// GetSiteQuestionQuality mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteQuestionQuality(ctx context.Context) (*schema.SiteQuestionQualityResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteQuestionQuality", ctx)
+	ret0, _ := ret[0].(*schema.SiteQuestionQualityResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteQuestionQuality indicates an expected call of GetSiteQuestionQuality.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteQuestionQuality(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteQuestionQuality", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteQuestionQuality), ctx)
+}
+
+// GetSiteRateLimit mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteRateLimit(ctx context.Context) (*schema.SiteRateLimitResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteRateLimit", ctx)
+	ret0, _ := ret[0].(*schema.SiteRateLimitResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteRateLimit indicates an expected call of GetSiteRateLimit.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteRateLimit(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteRateLimit", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteRateLimit), ctx)
+}
+
+// GetSiteReEngagement mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteReEngagement(ctx context.Context) (*schema.SiteReEngagementResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteReEngagement", ctx)
+	ret0, _ := ret[0].(*schema.SiteReEngagementResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteReEngagement indicates an expected call of GetSiteReEngagement.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteReEngagement(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteReEngagement", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteReEngagement), ctx)
+}
+
+// GetSiteReputationSync mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteReputationSync(ctx context.Context) (*schema.SiteReputationSyncResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteReputationSync", ctx)
+	ret0, _ := ret[0].(*schema.SiteReputationSyncResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteReputationSync indicates an expected call of GetSiteReputationSync.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteReputationSync(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteReputationSync", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteReputationSync), ctx)
+}
+
+// GetSiteSCIM mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteSCIM(ctx context.Context) (*schema.SiteSCIMResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteSCIM", ctx)
+	ret0, _ := ret[0].(*schema.SiteSCIMResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteSCIM indicates an expected call of GetSiteSCIM.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteSCIM(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteSCIM", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteSCIM), ctx)
+}
+
 // GetSiteSeo mocks base method.
 func (m *MockSiteInfoCommonService) GetSiteSeo(ctx context.Context) (*schema.SiteSeoResp, error) {
 	m.ctrl.T.Helper()
@@ -277,6 +729,81 @@ func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteSeo(ctx any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteSeo", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteSeo), ctx)
 }
 
+// GetSiteSlack mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteSlack(ctx context.Context) (*schema.SiteSlackResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteSlack", ctx)
+	ret0, _ := ret[0].(*schema.SiteSlackResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteSlack indicates an expected call of GetSiteSlack.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteSlack(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteSlack", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteSlack), ctx)
+}
+
+// GetSiteSpaces mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteSpaces(ctx context.Context) (*schema.SiteSpacesResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteSpaces", ctx)
+	ret0, _ := ret[0].(*schema.SiteSpacesResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteSpaces indicates an expected call of GetSiteSpaces.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteSpaces(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteSpaces", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteSpaces), ctx)
+}
+
+// GetSiteSyndication mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteSyndication(ctx context.Context) (*schema.SiteSyndicationResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteSyndication", ctx)
+	ret0, _ := ret[0].(*schema.SiteSyndicationResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteSyndication indicates an expected call of GetSiteSyndication.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteSyndication(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteSyndication", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteSyndication), ctx)
+}
+
+// GetSiteTagBlocklist mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteTagBlocklist(ctx context.Context) (*schema.SiteTagBlocklistResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteTagBlocklist", ctx)
+	ret0, _ := ret[0].(*schema.SiteTagBlocklistResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteTagBlocklist indicates an expected call of GetSiteTagBlocklist.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteTagBlocklist(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteTagBlocklist", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteTagBlocklist), ctx)
+}
+
+// GetSiteTagRules mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteTagRules(ctx context.Context) (*schema.SiteTagRulesResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteTagRules", ctx)
+	ret0, _ := ret[0].(*schema.SiteTagRulesResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteTagRules indicates an expected call of GetSiteTagRules.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteTagRules(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteTagRules", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteTagRules), ctx)
+}
+
 // GetSiteTheme mocks base method.
 func (m *MockSiteInfoCommonService) GetSiteTheme(ctx context.Context) (*schema.SiteThemeResp, error) {
 	m.ctrl.T.Helper()
@@ -307,6 +834,66 @@ func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteUsers(ctx any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteUsers", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteUsers), ctx)
 }
 
+// GetSiteVoteFraud mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteVoteFraud(ctx context.Context) (*schema.SiteVoteFraudResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteVoteFraud", ctx)
+	ret0, _ := ret[0].(*schema.SiteVoteFraudResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteVoteFraud indicates an expected call of GetSiteVoteFraud.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteVoteFraud(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteVoteFraud", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteVoteFraud), ctx)
+}
+
+// GetSiteVoteMilestone mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteVoteMilestone(ctx context.Context) (*schema.SiteVoteMilestoneResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteVoteMilestone", ctx)
+	ret0, _ := ret[0].(*schema.SiteVoteMilestoneResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteVoteMilestone indicates an expected call of GetSiteVoteMilestone.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteVoteMilestone(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteVoteMilestone", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteVoteMilestone), ctx)
+}
+
+// GetSiteWebPush mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteWebPush(ctx context.Context) (*schema.SiteWebPushResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteWebPush", ctx)
+	ret0, _ := ret[0].(*schema.SiteWebPushResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteWebPush indicates an expected call of GetSiteWebPush.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteWebPush(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteWebPush", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteWebPush), ctx)
+}
+
+// GetSiteWebhook mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteWebhook(ctx context.Context) (*schema.SiteWebhookResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteWebhook", ctx)
+	ret0, _ := ret[0].(*schema.SiteWebhookResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteWebhook indicates an expected call of GetSiteWebhook.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteWebhook(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteWebhook", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteWebhook), ctx)
+}
+
 // GetSiteWrite mocks base method.
 func (m *MockSiteInfoCommonService) GetSiteWrite(ctx context.Context) (*schema.SiteWriteResp, error) {
 	m.ctrl.T.Helper()
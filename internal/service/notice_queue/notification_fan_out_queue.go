@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package notice_queue
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/schema"
+	"github.com/segmentfault/pacman/log"
+)
+
+// NotificationFanOutQueueService the queue of notifications sent to all followers,
+// it is separated from the notification queue to avoid the popular questions blocking the direct notifications
+type NotificationFanOutQueueService interface {
+	Send(ctx context.Context, msg *schema.NotificationFanOutMsg)
+	RegisterHandler(handler func(ctx context.Context, msg *schema.NotificationFanOutMsg) error)
+}
+
+type notificationFanOutQueueService struct {
+	Queue   chan *schema.NotificationFanOutMsg
+	Handler func(ctx context.Context, msg *schema.NotificationFanOutMsg) error
+}
+
+func (ns *notificationFanOutQueueService) Send(ctx context.Context, msg *schema.NotificationFanOutMsg) {
+	ns.Queue <- msg
+}
+
+func (ns *notificationFanOutQueueService) RegisterHandler(
+	handler func(ctx context.Context, msg *schema.NotificationFanOutMsg) error) {
+	ns.Handler = handler
+}
+
+func (ns *notificationFanOutQueueService) working() {
+	go func() {
+		for msg := range ns.Queue {
+			log.Debugf("received notification fan out %+v", msg)
+			if ns.Handler == nil {
+				log.Warnf("no handler for notification fan out")
+				continue
+			}
+			if err := ns.Handler(context.Background(), msg); err != nil {
+				log.Error(err)
+			}
+		}
+	}()
+}
+
+// NewNotificationFanOutQueueService create a new notification fan out queue service
+func NewNotificationFanOutQueueService() NotificationFanOutQueueService {
+	ns := &notificationFanOutQueueService{}
+	ns.Queue = make(chan *schema.NotificationFanOutMsg, 128)
+	ns.working()
+	return ns
+}
@@ -21,8 +21,11 @@ package notification
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/handler"
 	"github.com/apache/answer/internal/base/translator"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
@@ -32,7 +35,10 @@ import (
 	"github.com/apache/answer/internal/service/siteinfo_common"
 	usercommon "github.com/apache/answer/internal/service/user_common"
 	"github.com/apache/answer/internal/service/user_external_login"
+	"github.com/apache/answer/internal/service/user_mute"
 	"github.com/apache/answer/internal/service/user_notification_config"
+	"github.com/apache/answer/pkg/emailreply"
+	"github.com/apache/answer/pkg/uid"
 	"github.com/segmentfault/pacman/log"
 )
 
@@ -45,6 +51,7 @@ type ExternalNotificationService struct {
 	notificationQueueService   notice_queue.ExternalNotificationQueueService
 	userExternalLoginRepo      user_external_login.UserExternalLoginRepo
 	siteInfoService            siteinfo_common.SiteInfoCommonService
+	userMuteService            *user_mute.UserMuteService
 }
 
 func NewExternalNotificationService(
@@ -56,6 +63,7 @@ func NewExternalNotificationService(
 	notificationQueueService notice_queue.ExternalNotificationQueueService,
 	userExternalLoginRepo user_external_login.UserExternalLoginRepo,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
+	userMuteService *user_mute.UserMuteService,
 ) *ExternalNotificationService {
 	n := &ExternalNotificationService{
 		data:                       data,
@@ -66,6 +74,7 @@ func NewExternalNotificationService(
 		notificationQueueService:   notificationQueueService,
 		userExternalLoginRepo:      userExternalLoginRepo,
 		siteInfoService:            siteInfoService,
+		userMuteService:            userMuteService,
 	}
 	notificationQueueService.RegisterHandler(n.Handler)
 	return n
@@ -80,9 +89,17 @@ func (ns *ExternalNotificationService) Handler(ctx context.Context, msg *schema.
 			msg.ReceiverLang = interfaceInfo.Language
 		}
 	}
+	if ns.isTriggeredByShadowBanned(ctx, msg) {
+		log.Debugf("the notification %+v is triggered by a shadow-banned user", msg)
+		return nil
+	}
 	if msg.NewQuestionTemplateRawData != nil {
 		return ns.handleNewQuestionNotification(ctx, msg)
 	}
+	if ns.isMuted(ctx, msg) {
+		log.Debugf("user %s muted the notification %+v", msg.ReceiverUserID, msg)
+		return nil
+	}
 	if msg.NewCommentTemplateRawData != nil {
 		return ns.handleNewCommentNotification(ctx, msg)
 	}
@@ -96,6 +113,65 @@ func (ns *ExternalNotificationService) Handler(ctx context.Context, msg *schema.
 	return nil
 }
 
+// isMuted whether the receiver muted the question or the user triggering the notification
+func (ns *ExternalNotificationService) isMuted(ctx context.Context, msg *schema.ExternalNotificationMsg) bool {
+	var questionID string
+	switch {
+	case msg.NewAnswerTemplateRawData != nil:
+		questionID = msg.NewAnswerTemplateRawData.QuestionID
+	case msg.NewCommentTemplateRawData != nil:
+		questionID = msg.NewCommentTemplateRawData.QuestionID
+	case msg.NewInviteAnswerTemplateRawData != nil:
+		questionID = msg.NewInviteAnswerTemplateRawData.QuestionID
+	}
+	return ns.userMuteService.IsMuted(ctx, msg.ReceiverUserID, questionID, msg.TriggerUserID)
+}
+
+// isTriggeredByShadowBanned whether the notification is triggered by a shadow-banned user, the content of these users
+// is only visible to themselves and staff, so nobody else should be notified about it
+func (ns *ExternalNotificationService) isTriggeredByShadowBanned(ctx context.Context, msg *schema.ExternalNotificationMsg) bool {
+	triggerUserID := msg.TriggerUserID
+	if msg.NewQuestionTemplateRawData != nil {
+		triggerUserID = msg.NewQuestionTemplateRawData.QuestionAuthorUserID
+	}
+	if len(triggerUserID) == 0 || triggerUserID == msg.ReceiverUserID {
+		return false
+	}
+	userInfo, exist, err := ns.userRepo.GetByUserID(ctx, triggerUserID)
+	if err != nil {
+		log.Errorf("get user %s info error: %v", triggerUserID, err)
+		return false
+	}
+	return exist && userInfo.ShadowBanned
+}
+
+// isEmailEnabled whether the user enabled the email of the event type in the preference matrix
+func (ns *ExternalNotificationService) isEmailEnabled(ctx context.Context, userID, event string) (
+	enabled bool, err error) {
+	configs, err := ns.userNotificationConfigRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	preference := schema.NewNotificationPreferenceMatrix(configs).Get(event)
+	return preference != nil && preference.Email, nil
+}
+
+// getReplyAddress get the signed reply address of the user replying to the object by email and put the marker above
+// the body, the address is empty and the body is kept if the inbound email is not available
+func (ns *ExternalNotificationService) getReplyAddress(ctx context.Context, kind emailreply.Kind,
+	userID, objectID, body string) (replyTo, replyBody string) {
+	conf, err := ns.siteInfoService.GetSiteInboundEmail(ctx)
+	if err != nil || !conf.IsAvailable() {
+		return "", body
+	}
+	replyTo = emailreply.Address(conf.ReplyAddress, emailreply.Token(conf.Secret, kind, userID, uid.DeShortID(objectID)))
+	if len(replyTo) == 0 {
+		return "", body
+	}
+	marker := translator.Tr(handler.GetLangByCtx(ctx), constant.EmailTplKeyReplyAboveThisLine)
+	return replyTo, fmt.Sprintf("<div style=\"color:#b5b5b5\">%s</div><br>\n%s", marker, body)
+}
+
 func (ns *ExternalNotificationService) checkUserStatusBeforeNotification(ctx context.Context, userID string) (
 	unavailable bool) {
 	userInfo, exist, err := ns.userRepo.GetByUserID(ctx, userID)
@@ -25,6 +25,7 @@ import (
 
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/pkg/emailreply"
 	"github.com/segmentfault/pacman/i18n"
 	"github.com/segmentfault/pacman/log"
 )
@@ -33,23 +34,11 @@ func (ns *ExternalNotificationService) handleInviteAnswerNotification(ctx contex
 	msg *schema.ExternalNotificationMsg) error {
 	log.Debugf("try to send invite answer notification %+v", msg)
 
-	notificationConfig, exist, err := ns.userNotificationConfigRepo.GetByUserIDAndSource(ctx, msg.ReceiverUserID, constant.InboxSource)
-	if err != nil {
+	enabled, err := ns.isEmailEnabled(ctx, msg.ReceiverUserID, constant.NotificationEventInvite)
+	if err != nil || !enabled {
 		return err
 	}
-	if !exist {
-		return nil
-	}
-	channels := schema.NewNotificationChannelsFormJson(notificationConfig.Channels)
-	for _, channel := range channels {
-		if !channel.Enable {
-			continue
-		}
-		switch channel.Key {
-		case constant.EmailChannel:
-			ns.sendInviteAnswerNotificationEmail(ctx, msg.ReceiverUserID, msg.ReceiverEmail, msg.ReceiverLang, msg.NewInviteAnswerTemplateRawData)
-		}
-	}
+	ns.sendInviteAnswerNotificationEmail(ctx, msg.ReceiverUserID, msg.ReceiverEmail, msg.ReceiverLang, msg.NewInviteAnswerTemplateRawData)
 	return nil
 }
 
@@ -62,6 +51,7 @@ func (ns *ExternalNotificationService) sendInviteAnswerNotificationEmail(ctx con
 		SourceType: schema.UnsubscribeSourceType,
 		NotificationSources: []constant.NotificationSource{
 			constant.InboxSource,
+			constant.NotificationEventSource(constant.NotificationEventInvite),
 		},
 		Email:                    email,
 		UserID:                   userID,
@@ -78,6 +68,7 @@ func (ns *ExternalNotificationService) sendInviteAnswerNotificationEmail(ctx con
 		return
 	}
 
-	ns.emailService.SendAndSaveCodeWithTime(
-		ctx, userID, email, title, body, rawData.UnsubscribeCode, codeContent.ToJSONString(), 1*24*time.Hour)
+	replyTo, body := ns.getReplyAddress(ctx, emailreply.KindAnswer, userID, rawData.QuestionID, body)
+	ns.emailService.SendAndSaveCodeWithReplyTo(
+		ctx, userID, email, title, body, rawData.UnsubscribeCode, codeContent.ToJSONString(), replyTo, 1*24*time.Hour)
 }
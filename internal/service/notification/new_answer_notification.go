@@ -25,6 +25,7 @@ import (
 
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/pkg/emailreply"
 	"github.com/segmentfault/pacman/i18n"
 	"github.com/segmentfault/pacman/log"
 )
@@ -33,23 +34,11 @@ func (ns *ExternalNotificationService) handleNewAnswerNotification(ctx context.C
 	msg *schema.ExternalNotificationMsg) error {
 	log.Debugf("try to send new comment notification %+v", msg)
 
-	notificationConfig, exist, err := ns.userNotificationConfigRepo.GetByUserIDAndSource(ctx, msg.ReceiverUserID, constant.InboxSource)
-	if err != nil {
+	enabled, err := ns.isEmailEnabled(ctx, msg.ReceiverUserID, constant.NotificationEventAnswer)
+	if err != nil || !enabled {
 		return err
 	}
-	if !exist {
-		return nil
-	}
-	channels := schema.NewNotificationChannelsFormJson(notificationConfig.Channels)
-	for _, channel := range channels {
-		if !channel.Enable {
-			continue
-		}
-		switch channel.Key {
-		case constant.EmailChannel:
-			ns.sendNewAnswerNotificationEmail(ctx, msg.ReceiverUserID, msg.ReceiverEmail, msg.ReceiverLang, msg.NewAnswerTemplateRawData)
-		}
-	}
+	ns.sendNewAnswerNotificationEmail(ctx, msg.ReceiverUserID, msg.ReceiverEmail, msg.ReceiverLang, msg.NewAnswerTemplateRawData)
 	return nil
 }
 
@@ -62,6 +51,7 @@ func (ns *ExternalNotificationService) sendNewAnswerNotificationEmail(ctx contex
 		SourceType: schema.UnsubscribeSourceType,
 		NotificationSources: []constant.NotificationSource{
 			constant.InboxSource,
+			constant.NotificationEventSource(constant.NotificationEventAnswer),
 		},
 		Email:                    email,
 		UserID:                   userID,
@@ -78,6 +68,7 @@ func (ns *ExternalNotificationService) sendNewAnswerNotificationEmail(ctx contex
 		return
 	}
 
-	ns.emailService.SendAndSaveCodeWithTime(
-		ctx, userID, email, title, body, rawData.UnsubscribeCode, codeContent.ToJSONString(), 1*24*time.Hour)
+	replyTo, body := ns.getReplyAddress(ctx, emailreply.KindComment, userID, rawData.AnswerID, body)
+	ns.emailService.SendAndSaveCodeWithReplyTo(
+		ctx, userID, email, title, body, rawData.UnsubscribeCode, codeContent.ToJSONString(), replyTo, 1*24*time.Hour)
 }
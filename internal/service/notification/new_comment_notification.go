@@ -25,6 +25,7 @@ import (
 
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/pkg/emailreply"
 	"github.com/segmentfault/pacman/i18n"
 	"github.com/segmentfault/pacman/log"
 )
@@ -33,23 +34,11 @@ func (ns *ExternalNotificationService) handleNewCommentNotification(ctx context.
 	msg *schema.ExternalNotificationMsg) error {
 	log.Debugf("try to send new comment notification %+v", msg)
 
-	notificationConfig, exist, err := ns.userNotificationConfigRepo.GetByUserIDAndSource(ctx, msg.ReceiverUserID, constant.InboxSource)
-	if err != nil {
+	enabled, err := ns.isEmailEnabled(ctx, msg.ReceiverUserID, constant.NotificationEventComment)
+	if err != nil || !enabled {
 		return err
 	}
-	if !exist {
-		return nil
-	}
-	channels := schema.NewNotificationChannelsFormJson(notificationConfig.Channels)
-	for _, channel := range channels {
-		if !channel.Enable {
-			continue
-		}
-		switch channel.Key {
-		case constant.EmailChannel:
-			ns.sendNewCommentNotificationEmail(ctx, msg.ReceiverUserID, msg.ReceiverEmail, msg.ReceiverLang, msg.NewCommentTemplateRawData)
-		}
-	}
+	ns.sendNewCommentNotificationEmail(ctx, msg.ReceiverUserID, msg.ReceiverEmail, msg.ReceiverLang, msg.NewCommentTemplateRawData)
 	return nil
 }
 
@@ -62,6 +51,7 @@ func (ns *ExternalNotificationService) sendNewCommentNotificationEmail(ctx conte
 		SourceType: schema.UnsubscribeSourceType,
 		NotificationSources: []constant.NotificationSource{
 			constant.InboxSource,
+			constant.NotificationEventSource(constant.NotificationEventComment),
 		},
 		Email:                    email,
 		UserID:                   userID,
@@ -77,6 +67,7 @@ func (ns *ExternalNotificationService) sendNewCommentNotificationEmail(ctx conte
 		return
 	}
 
-	ns.emailService.SendAndSaveCodeWithTime(
-		ctx, userID, email, title, body, rawData.UnsubscribeCode, codeContent.ToJSONString(), 1*24*time.Hour)
+	replyTo, body := ns.getReplyAddress(ctx, emailreply.KindReply, userID, rawData.CommentID, body)
+	ns.emailService.SendAndSaveCodeWithReplyTo(
+		ctx, userID, email, title, body, rawData.UnsubscribeCode, codeContent.ToJSONString(), replyTo, 1*24*time.Hour)
 }
@@ -28,6 +28,7 @@ import (
 	"github.com/apache/answer/internal/base/translator"
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/pkg/display"
+	"github.com/apache/answer/pkg/emailreply"
 	"github.com/apache/answer/pkg/token"
 	"github.com/apache/answer/plugin"
 	"github.com/jinzhu/copier"
@@ -77,21 +78,9 @@ func (ns *ExternalNotificationService) getNewQuestionSubscribers(ctx context.Con
 	subscribersMapping := make(map[string]*NewQuestionSubscriber)
 
 	// 1. get all this new question's tags followers
-	tagsFollowerIDs := make([]string, 0)
-	followerMapping := make(map[string]bool)
-	for _, tagID := range msg.NewQuestionTemplateRawData.TagIDs {
-		userIDs, err := ns.followRepo.GetFollowUserIDs(ctx, tagID)
-		if err != nil {
-			log.Error(err)
-			continue
-		}
-		for _, userID := range userIDs {
-			if _, ok := followerMapping[userID]; ok {
-				continue
-			}
-			followerMapping[userID] = true
-			tagsFollowerIDs = append(tagsFollowerIDs, userID)
-		}
+	tagsFollowerIDs, err := ns.followRepo.GetFollowUserIDsByObjectIDs(ctx, msg.NewQuestionTemplateRawData.TagIDs)
+	if err != nil {
+		return nil, err
 	}
 	userNotificationConfigs, err := ns.userNotificationConfigRepo.GetByUsersAndSource(
 		ctx, tagsFollowerIDs, constant.AllNewQuestionForFollowingTagsSource)
@@ -129,8 +118,16 @@ func (ns *ExternalNotificationService) getNewQuestionSubscribers(ctx context.Con
 		}
 	}
 
-	// 3. remove question owner
+	// 3. remove question owner and the users muted the owner
 	delete(subscribersMapping, msg.NewQuestionTemplateRawData.QuestionAuthorUserID)
+	subscriberIDs := make([]string, 0, len(subscribersMapping))
+	for userID := range subscribersMapping {
+		subscriberIDs = append(subscriberIDs, userID)
+	}
+	muted := ns.userMuteService.GetMutedUserIDs(ctx, subscriberIDs, "", msg.NewQuestionTemplateRawData.QuestionAuthorUserID)
+	for userID := range muted {
+		delete(subscribersMapping, userID)
+	}
 	for _, subscriber := range subscribersMapping {
 		subscribers = append(subscribers, subscriber)
 	}
@@ -194,24 +191,29 @@ func (ns *ExternalNotificationService) sendNewQuestionNotificationEmail(ctx cont
 		},
 		SkipValidationLatestCode: true,
 	}
-	ns.emailService.SendAndSaveCodeWithTime(
-		ctx, userInfo.ID, userInfo.EMail, title, body, rawData.UnsubscribeCode, codeContent.ToJSONString(), 1*24*time.Hour)
+	replyTo, body := ns.getReplyAddress(ctx, emailreply.KindAnswer, userID, rawData.QuestionID, body)
+	ns.emailService.SendAndSaveCodeWithReplyTo(ctx, userInfo.ID, userInfo.EMail, title, body,
+		rawData.UnsubscribeCode, codeContent.ToJSONString(), replyTo, 1*24*time.Hour)
 }
 
 func (ns *ExternalNotificationService) syncNewQuestionNotificationToPlugin(ctx context.Context,
 	msg *schema.ExternalNotificationMsg) {
+	// the tags followers are the same for all plugins, so only get them once
+	var tagsFollowerIDs []string
+	tagsFollowerLoaded := false
 	_ = plugin.CallNotification(func(fn plugin.Notification) error {
 		// 1. get all this new question's tags followers
-		subscribersMapping := make(map[string]plugin.NotificationType)
-		for _, tagID := range msg.NewQuestionTemplateRawData.TagIDs {
-			userIDs, err := ns.followRepo.GetFollowUserIDs(ctx, tagID)
+		if !tagsFollowerLoaded {
+			tagsFollowerLoaded = true
+			userIDs, err := ns.followRepo.GetFollowUserIDsByObjectIDs(ctx, msg.NewQuestionTemplateRawData.TagIDs)
 			if err != nil {
 				log.Error(err)
-				continue
-			}
-			for _, userID := range userIDs {
-				subscribersMapping[userID] = plugin.NotificationNewQuestionFollowedTag
 			}
+			tagsFollowerIDs = userIDs
+		}
+		subscribersMapping := make(map[string]plugin.NotificationType)
+		for _, userID := range tagsFollowerIDs {
+			subscribersMapping[userID] = plugin.NotificationNewQuestionFollowedTag
 		}
 
 		// 2. get all new question's followers
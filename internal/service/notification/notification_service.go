@@ -23,6 +23,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
+
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/data"
 	"github.com/apache/answer/internal/base/handler"
@@ -169,10 +171,19 @@ func (ns *NotificationService) ClearRedDot(ctx context.Context, req *schema.Noti
 	return ns.GetRedDot(ctx, resp)
 }
 
-func (ns *NotificationService) ClearUnRead(ctx context.Context, userID string, notificationType string) error {
-	botType, ok := schema.NotificationType[notificationType]
+func (ns *NotificationService) ClearUnRead(ctx context.Context, req *schema.NotificationClearRequest) error {
+	botType, ok := schema.NotificationType[req.NotificationType]
 	if ok {
-		err := ns.notificationRepo.ClearUnRead(ctx, userID, botType)
+		// mark all of the action as read
+		if botType == schema.NotificationTypeInbox && len(req.Action) > 0 {
+			return ns.notificationRepo.ClearUnReadByAction(ctx, req.UserID, req.Action)
+		}
+		// mark all of the inbox type as read
+		msgType := schema.NotificationInboxType[req.InboxType]
+		if botType == schema.NotificationTypeInbox && msgType != schema.NotificationInboxTypeAll {
+			return ns.notificationRepo.ClearUnReadByMsgType(ctx, req.UserID, botType, msgType)
+		}
+		err := ns.notificationRepo.ClearUnRead(ctx, req.UserID, botType)
 		if err != nil {
 			return err
 		}
@@ -180,6 +191,28 @@ func (ns *NotificationService) ClearUnRead(ctx context.Context, userID string, n
 	return nil
 }
 
+// GetUnreadCount get the unread notification count of each category
+func (ns *NotificationService) GetUnreadCount(ctx context.Context, userID string) (
+	resp *schema.NotificationUnreadCountResp, err error) {
+	counts, err := ns.notificationRepo.CountUnreadNotifications(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return schema.NewNotificationUnreadCountResp(counts), nil
+}
+
+// DeleteNotifications delete the notifications older than the days
+func (ns *NotificationService) DeleteNotifications(ctx context.Context, req *schema.NotificationDeleteRequest) (
+	resp *schema.NotificationDeleteResp, err error) {
+	notificationType := schema.NotificationType[req.NotificationType]
+	before := time.Now().AddDate(0, 0, -req.OlderThanDays)
+	deleted, err := ns.notificationRepo.DeleteNotificationsBefore(ctx, req.UserID, notificationType, before, req.IncludeUnread)
+	if err != nil {
+		return nil, err
+	}
+	return &schema.NotificationDeleteResp{Deleted: deleted}, nil
+}
+
 func (ns *NotificationService) ClearIDUnRead(ctx context.Context, userID string, id string) error {
 	notificationInfo, exist, err := ns.notificationRepo.GetById(ctx, id)
 	if err != nil {
@@ -258,8 +291,37 @@ func (ns *NotificationService) formatNotificationPage(ctx context.Context, notif
 			item.UserInfo = nil
 		}
 
+		// If notification is role change, the user info is not needed and the role name need to be translated.
+		if item.ObjectInfo.ObjectType == constant.RoleChangeObjectType {
+			item.ObjectInfo.Title = translator.TrWithData(lang, item.NotificationAction, struct {
+				RoleName string
+			}{RoleName: translator.Tr(lang, item.ObjectInfo.Title)})
+			item.UserInfo = nil
+		}
+
 		item.ID = notificationInfo.ID
-		item.NotificationAction = translator.Tr(lang, item.NotificationAction)
+		// If notification is vote milestone, the user info is not needed and the milestone need to be filled.
+		if item.NotificationAction == constant.NotificationVoteMilestone {
+			item.UserInfo = nil
+			item.NotificationAction = translator.TrWithData(lang, item.NotificationAction, struct {
+				Milestone string
+			}{Milestone: item.ObjectInfo.ObjectMap["milestone"]})
+		} else if item.NotificationAction == constant.NotificationSavedSearchMatched ||
+			item.NotificationAction == constant.NotificationSavedSearchDigest {
+			// If notification is saved search, the user info is the receiver, and the name of saved search need to be filled.
+			item.UserInfo = nil
+			item.NotificationAction = translator.TrWithData(lang, item.NotificationAction, struct {
+				Name  string
+				Count string
+			}{Name: item.ObjectInfo.ObjectMap["saved_search_name"], Count: item.ObjectInfo.ObjectMap["match_count"]})
+		} else if item.GroupCount > 1 {
+			// If notification is grouped, the count of the other users need to be filled.
+			item.NotificationAction = translator.TrWithData(lang, schema.NotificationGroupedAction(item.NotificationAction), struct {
+				Others int
+			}{Others: item.GroupCount - 1})
+		} else {
+			item.NotificationAction = translator.Tr(lang, item.NotificationAction)
+		}
 		item.UpdateTime = notificationInfo.UpdatedAt.Unix()
 		item.IsRead = notificationInfo.IsRead == schema.NotificationRead
 
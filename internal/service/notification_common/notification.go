@@ -22,6 +22,7 @@ package notificationcommon
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/apache/answer/internal/base/translator"
@@ -35,9 +36,13 @@ import (
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/activity_common"
+	"github.com/apache/answer/internal/service/mobile_push"
 	"github.com/apache/answer/internal/service/notice_queue"
 	"github.com/apache/answer/internal/service/object_info"
 	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/internal/service/user_mute"
+	"github.com/apache/answer/internal/service/user_notification_config"
+	"github.com/apache/answer/internal/service/web_push"
 	"github.com/apache/answer/pkg/uid"
 	"github.com/apache/answer/plugin"
 	"github.com/goccy/go-json"
@@ -46,29 +51,51 @@ import (
 	"github.com/segmentfault/pacman/log"
 )
 
+// notificationFanOutBatchSize the number of followers notified in one batch
+const notificationFanOutBatchSize = 100
+
 type NotificationRepo interface {
 	AddNotification(ctx context.Context, notification *entity.Notification) (err error)
+	AddNotifications(ctx context.Context, notifications []*entity.Notification) (err error)
 	GetNotificationPage(ctx context.Context, search *schema.NotificationSearch) ([]*entity.Notification, int64, error)
 	ClearUnRead(ctx context.Context, userID string, notificationType int) (err error)
+	ClearUnReadByMsgType(ctx context.Context, userID string, notificationType, msgType int) (err error)
+	ClearUnReadByAction(ctx context.Context, userID string, action string) (err error)
 	ClearIDUnRead(ctx context.Context, userID string, id string) (err error)
 	GetByUserIdObjectIdTypeId(ctx context.Context, userID, objectID string, notificationType int) (*entity.Notification, bool, error)
 	UpdateNotificationContent(ctx context.Context, notification *entity.Notification) (err error)
+	GetUnreadGroupedNotifications(ctx context.Context, userIDs []string, groupKey string, since time.Time) (
+		notifications []*entity.Notification, err error)
 	GetById(ctx context.Context, id string) (*entity.Notification, bool, error)
 	CountNotificationByUser(ctx context.Context, cond *entity.Notification) (int64, error)
 	DeleteNotification(ctx context.Context, userID string) (err error)
+	DeleteNotificationsBefore(ctx context.Context, userID string, notificationType int, before time.Time,
+		includeUnread bool) (deleted int64, err error)
+	CleanReadNotificationsBefore(ctx context.Context, before time.Time, limit int, archive bool) (cleaned int64, err error)
+	CountUnreadNotifications(ctx context.Context, userID string) (counts []*schema.NotificationUnreadCountDTO, err error)
 	DeleteUserNotificationConfig(ctx context.Context, userID string) (err error)
 }
 
+type VoteMilestoneRepo interface {
+	RaiseVoteMilestone(ctx context.Context, objectID string, milestone int) (raised bool, err error)
+}
+
 type NotificationCommon struct {
-	data                     *data.Data
-	notificationRepo         NotificationRepo
-	activityRepo             activity_common.ActivityRepo
-	followRepo               activity_common.FollowRepo
-	userCommon               *usercommon.UserCommon
-	objectInfoService        *object_info.ObjService
-	notificationQueueService notice_queue.NotificationQueueService
-	userExternalLoginRepo    user_external_login.UserExternalLoginRepo
-	siteInfoService          siteinfo_common.SiteInfoCommonService
+	data                       *data.Data
+	notificationRepo           NotificationRepo
+	activityRepo               activity_common.ActivityRepo
+	followRepo                 activity_common.FollowRepo
+	userCommon                 *usercommon.UserCommon
+	objectInfoService          *object_info.ObjService
+	notificationQueueService   notice_queue.NotificationQueueService
+	userExternalLoginRepo      user_external_login.UserExternalLoginRepo
+	siteInfoService            siteinfo_common.SiteInfoCommonService
+	voteMilestoneRepo          VoteMilestoneRepo
+	userNotificationConfigRepo user_notification_config.UserNotificationConfigRepo
+	notificationFanOutQueue    notice_queue.NotificationFanOutQueueService
+	webPushService             *web_push.WebPushService
+	mobilePushService          *mobile_push.MobilePushService
+	userMuteService            *user_mute.UserMuteService
 }
 
 func NewNotificationCommon(
@@ -81,19 +108,32 @@ func NewNotificationCommon(
 	notificationQueueService notice_queue.NotificationQueueService,
 	userExternalLoginRepo user_external_login.UserExternalLoginRepo,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
+	voteMilestoneRepo VoteMilestoneRepo,
+	userNotificationConfigRepo user_notification_config.UserNotificationConfigRepo,
+	notificationFanOutQueue notice_queue.NotificationFanOutQueueService,
+	webPushService *web_push.WebPushService,
+	mobilePushService *mobile_push.MobilePushService,
+	userMuteService *user_mute.UserMuteService,
 ) *NotificationCommon {
 	notification := &NotificationCommon{
-		data:                     data,
-		notificationRepo:         notificationRepo,
-		activityRepo:             activityRepo,
-		followRepo:               followRepo,
-		userCommon:               userCommon,
-		objectInfoService:        objectInfoService,
-		notificationQueueService: notificationQueueService,
-		userExternalLoginRepo:    userExternalLoginRepo,
-		siteInfoService:          siteInfoService,
+		data:                       data,
+		notificationRepo:           notificationRepo,
+		activityRepo:               activityRepo,
+		followRepo:                 followRepo,
+		userCommon:                 userCommon,
+		objectInfoService:          objectInfoService,
+		notificationQueueService:   notificationQueueService,
+		userExternalLoginRepo:      userExternalLoginRepo,
+		siteInfoService:            siteInfoService,
+		voteMilestoneRepo:          voteMilestoneRepo,
+		userNotificationConfigRepo: userNotificationConfigRepo,
+		notificationFanOutQueue:    notificationFanOutQueue,
+		webPushService:             webPushService,
+		mobilePushService:          mobilePushService,
+		userMuteService:            userMuteService,
 	}
 	notificationQueueService.RegisterHandler(notification.AddNotification)
+	notificationFanOutQueue.RegisterHandler(notification.FanOutNotification)
 	return notification
 }
 
@@ -110,6 +150,25 @@ func (ns *NotificationCommon) AddNotification(ctx context.Context, msg *schema.N
 	if msg.Type == schema.NotificationTypeAchievement && plugin.RankAgentEnabled() {
 		return nil
 	}
+	// the content of shadow-banned users is only visible to themselves and staff, nobody else is notified about it
+	if msg.Type == schema.NotificationTypeInbox && msg.ReceiverUserID != msg.TriggerUserID &&
+		ns.userCommon.IsShadowBanned(ctx, msg.TriggerUserID) {
+		return nil
+	}
+	if msg.Type == schema.NotificationTypeInbox && constant.IsVoteNotificationAction(msg.NotificationAction) {
+		if !ns.aggregateVoteNotification(ctx, msg) {
+			return nil
+		}
+	}
+	// the achievement is always shown, the inbox notifications are delivered to the channels the receiver enabled
+	preferences := &schema.NotificationPreferenceMatrix{}
+	if msg.Type == schema.NotificationTypeInbox {
+		configs, err := ns.userNotificationConfigRepo.GetByUserID(ctx, msg.ReceiverUserID)
+		if err != nil {
+			return err
+		}
+		preferences = schema.NewNotificationPreferenceMatrix(configs)
+	}
 	req := &schema.NotificationContent{
 		TriggerUserID:  msg.TriggerUserID,
 		ReceiverUserID: msg.ReceiverUserID,
@@ -128,6 +187,10 @@ func (ns *NotificationCommon) AddNotification(ctx context.Context, msg *schema.N
 		objectMap := make(map[string]string)
 		objectMap["badge_id"] = msg.ExtraInfo["badge_id"]
 		req.ObjectInfo.ObjectMap = objectMap
+	} else if msg.ObjectType == constant.RoleChangeObjectType {
+		// the object is the audit log of role change, the title is the name of role
+		req.ObjectInfo.Title = msg.Title
+		req.ObjectInfo.ObjectMap = map[string]string{"role_id": msg.ExtraInfo["role_id"]}
 	} else {
 		objInfo, err = ns.objectInfoService.GetInfo(ctx, req.ObjectInfo.ObjectID)
 		if err != nil {
@@ -140,6 +203,9 @@ func (ns *NotificationCommon) AddNotification(ctx context.Context, msg *schema.N
 			objectMap["question"] = uid.DeShortID(objInfo.QuestionID)
 			objectMap["answer"] = uid.DeShortID(objInfo.AnswerID)
 			objectMap["comment"] = objInfo.CommentID
+			for k, v := range msg.ExtraInfo {
+				objectMap[k] = v
+			}
 			req.ObjectInfo.ObjectMap = objectMap
 		}
 	}
@@ -181,6 +247,9 @@ func (ns *NotificationCommon) AddNotification(ctx context.Context, msg *schema.N
 	info.CreatedAt = now
 	info.UpdatedAt = now
 	info.ObjectID = req.ObjectInfo.ObjectID
+	if msg.Type == schema.NotificationTypeInbox {
+		info.GroupKey = schema.NotificationGroupKey(msg.NotificationAction, objInfo)
+	}
 
 	userBasicInfo, exist, err := ns.userCommon.GetUserBasicInfoByID(ctx, req.TriggerUserID)
 	if err != nil {
@@ -196,24 +265,89 @@ func (ns *NotificationCommon) AddNotification(ctx context.Context, msg *schema.N
 		info.MsgType = constant.NotificationMsgTypeMapping[req.NotificationAction]
 	}
 	info.Content = string(content)
-	err = ns.notificationRepo.AddNotification(ctx, info)
-	if err != nil {
-		return fmt.Errorf("add notification error: %w", err)
+	// the notifications about the questions and users muted by the receiver are not delivered to any channel
+	muted := msg.Type == schema.NotificationTypeInbox && objInfo != nil &&
+		ns.userMuteService.IsMuted(ctx, msg.ReceiverUserID, questionID, msg.TriggerUserID)
+	if !muted && preferences.Allowed(msg.NotificationAction, constant.InboxChannel) {
+		rest, err := ns.mergeGroupedNotifications(ctx, []*entity.Notification{info})
+		if err != nil {
+			return err
+		}
+		// the merged notification is still unread, so the red dot is not increased again
+		if len(rest) > 0 {
+			err = ns.notificationRepo.AddNotification(ctx, info)
+			if err != nil {
+				return fmt.Errorf("add notification error: %w", err)
+			}
+			err = ns.addRedDot(ctx, info.UserID, msg.Type)
+			if err != nil {
+				log.Error("addRedDot Error", err.Error())
+			}
+			if req.ObjectInfo.ObjectType == constant.BadgeAwardObjectType {
+				err = ns.AddBadgeAwardAlertCache(ctx, info.UserID, info.ID, req.ObjectInfo.ObjectMap["badge_id"])
+			}
+		}
+	}
+
+	ns.SendNotificationToAllFollower(ctx, msg, info, objInfo, questionID)
+
+	if msg.Type == schema.NotificationTypeInbox && !muted {
+		if preferences.Allowed(msg.NotificationAction, constant.WebPushChannel) {
+			ns.webPushService.PushNotification(ctx, msg, objInfo, userBasicInfo)
+			ns.mobilePushService.PushNotification(ctx, msg, objInfo, userBasicInfo)
+		}
+		if preferences.Allowed(msg.NotificationAction, constant.WebhookChannel) {
+			ns.syncNotificationToPlugin(ctx, objInfo, msg)
+		}
 	}
-	err = ns.addRedDot(ctx, info.UserID, msg.Type)
+	return nil
+}
+
+// aggregateVoteNotification decide whether the vote notification should be sent to the author.
+// If the user does not opt in to every vote, the upvote is turned into a milestone notification
+// when the post crosses a milestone that has not been reached before, otherwise it is dropped.
+func (ns *NotificationCommon) aggregateVoteNotification(ctx context.Context, msg *schema.NotificationMsg) (send bool) {
+	milestoneConfig, err := ns.siteInfoService.GetSiteVoteMilestone(ctx)
 	if err != nil {
-		log.Error("addRedDot Error", err.Error())
+		log.Errorf("get site vote milestone config failed: %v", err)
+		return true
 	}
-	if req.ObjectInfo.ObjectType == constant.BadgeAwardObjectType {
-		err = ns.AddBadgeAwardAlertCache(ctx, info.UserID, info.ID, req.ObjectInfo.ObjectMap["badge_id"])
+	if !milestoneConfig.Enabled {
+		return true
 	}
+	configs, err := ns.userNotificationConfigRepo.GetByUserID(ctx, msg.ReceiverUserID)
+	if err != nil {
+		log.Errorf("get user notification config failed: %v", err)
+		return false
+	}
+	userConfig := schema.NewNotificationConfig(configs)
+	userConfig.Format()
 
-	go ns.SendNotificationToAllFollower(ctx, msg, questionID)
-
-	if msg.Type == schema.NotificationTypeInbox {
-		ns.syncNotificationToPlugin(ctx, objInfo, msg)
+	milestone := 0
+	voteCount, err := strconv.Atoi(msg.ExtraInfo["vote_count"])
+	if err == nil && constant.IsUpVoteNotificationAction(msg.NotificationAction) {
+		milestone = milestoneConfig.ReachedMilestone(voteCount)
+	}
+	raised := false
+	if milestone > 0 {
+		// the milestone is recorded even if the user receives every vote, avoid sending it again after switching
+		raised, err = ns.voteMilestoneRepo.RaiseVoteMilestone(ctx, msg.ObjectID, milestone)
+		if err != nil {
+			log.Errorf("raise vote milestone failed: %v", err)
+		}
 	}
-	return nil
+	if userConfig.EveryVote.Enable {
+		return true
+	}
+	if !raised || !userConfig.VoteMilestone.Enable {
+		return false
+	}
+	msg.NotificationAction = constant.NotificationVoteMilestone
+	if msg.ExtraInfo == nil {
+		msg.ExtraInfo = make(map[string]string)
+	}
+	msg.ExtraInfo["milestone"] = strconv.Itoa(milestone)
+	return true
 }
 
 func (ns *NotificationCommon) addRedDot(ctx context.Context, userID string, noticeType int) error {
@@ -321,36 +455,169 @@ func (ns *NotificationCommon) RemoveBadgeAwardAlertCache(ctx context.Context, us
 	return ns.data.Cache.SetString(ctx, key, c.ToJSON(), constant.RedDotCacheTime)
 }
 
-// SendNotificationToAllFollower send notification to all followers
+// SendNotificationToAllFollower send the notification to all followers of the question in the fan-out queue,
+// the content of the notification sent to the receiver is shared by all followers
 func (ns *NotificationCommon) SendNotificationToAllFollower(ctx context.Context, msg *schema.NotificationMsg,
-	questionID string) {
+	info *entity.Notification, objInfo *schema.SimpleObjectInfo, questionID string) {
 	if msg.NoNeedPushAllFollow || len(questionID) == 0 {
 		return
 	}
 	if msg.NotificationAction != constant.NotificationUpdateQuestion &&
 		msg.NotificationAction != constant.NotificationAnswerTheQuestion &&
 		msg.NotificationAction != constant.NotificationUpdateAnswer &&
-		msg.NotificationAction != constant.NotificationAcceptAnswer {
+		msg.NotificationAction != constant.NotificationAcceptAnswer &&
+		msg.NotificationAction != constant.NotificationMarkedAsDuplicate &&
+		msg.NotificationAction != constant.NotificationDuplicateOfQuestion {
 		return
 	}
-	condObjectID := msg.ObjectID
-	if len(questionID) > 0 {
-		condObjectID = uid.DeShortID(questionID)
+	ns.notificationFanOutQueue.Send(ctx, &schema.NotificationFanOutMsg{
+		Msg:        msg,
+		QuestionID: uid.DeShortID(questionID),
+		ObjectID:   info.ObjectID,
+		MsgType:    info.MsgType,
+		Content:    info.Content,
+		GroupKey:   info.GroupKey,
+		ObjectInfo: objInfo,
+	})
+}
+
+// FanOutNotification add the notification for all followers of the question in batches,
+// the receiver and the trigger user are excluded because they have been notified or done it themselves
+func (ns *NotificationCommon) FanOutNotification(ctx context.Context, fanOut *schema.NotificationFanOutMsg) (err error) {
+	userIDs, err := ns.followRepo.GetFollowUserIDs(ctx, fanOut.QuestionID)
+	if err != nil {
+		return err
 	}
-	userIDs, err := ns.followRepo.GetFollowUserIDs(ctx, condObjectID)
+	excluded := map[string]bool{fanOut.Msg.ReceiverUserID: true, fanOut.Msg.TriggerUserID: true}
+	receiverIDs := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if excluded[userID] {
+			continue
+		}
+		excluded[userID] = true
+		receiverIDs = append(receiverIDs, userID)
+	}
+	log.Infof("send notification to all followers: %s %d", fanOut.QuestionID, len(receiverIDs))
+
+	for start := 0; start < len(receiverIDs); start += notificationFanOutBatchSize {
+		batch := receiverIDs[start:min(start+notificationFanOutBatchSize, len(receiverIDs))]
+		preferences, err := ns.getEventPreferences(ctx, batch, fanOut.Msg.NotificationAction)
+		if err != nil {
+			return err
+		}
+		muted := ns.userMuteService.GetMutedUserIDs(ctx, batch, fanOut.QuestionID, fanOut.Msg.TriggerUserID)
+		now := time.Now()
+		notifications := make([]*entity.Notification, 0, len(batch))
+		for _, userID := range batch {
+			if muted[userID] || !preferences[userID].IsEnabled(constant.InboxChannel) {
+				continue
+			}
+			notifications = append(notifications, &entity.Notification{
+				CreatedAt: now,
+				UpdatedAt: now,
+				UserID:    userID,
+				ObjectID:  fanOut.ObjectID,
+				Content:   fanOut.Content,
+				Type:      fanOut.Msg.Type,
+				MsgType:   fanOut.MsgType,
+				IsRead:    schema.NotificationNotRead,
+				Status:    schema.NotificationStatusNormal,
+				GroupKey:  fanOut.GroupKey,
+			})
+		}
+		notifications, err = ns.mergeGroupedNotifications(ctx, notifications)
+		if err != nil {
+			return err
+		}
+		if err = ns.notificationRepo.AddNotifications(ctx, notifications); err != nil {
+			return fmt.Errorf("add notifications error: %w", err)
+		}
+		for _, notification := range notifications {
+			if err := ns.addRedDot(ctx, notification.UserID, fanOut.Msg.Type); err != nil {
+				log.Error("addRedDot Error", err.Error())
+			}
+		}
+		for _, userID := range batch {
+			if fanOut.Msg.Type == schema.NotificationTypeInbox && fanOut.ObjectInfo != nil && !muted[userID] &&
+				preferences[userID].IsEnabled(constant.WebhookChannel) {
+				t := &schema.NotificationMsg{}
+				_ = copier.Copy(t, fanOut.Msg)
+				t.ReceiverUserID = userID
+				t.NoNeedPushAllFollow = true
+				objInfo := *fanOut.ObjectInfo
+				ns.syncNotificationToPlugin(ctx, &objInfo, t)
+			}
+		}
+	}
+	return nil
+}
+
+// mergeGroupedNotifications merge the notifications of the same group into the unread notifications of their receivers
+// updated in the group window, the notifications not merged are returned to be added
+func (ns *NotificationCommon) mergeGroupedNotifications(ctx context.Context, notifications []*entity.Notification) (
+	rest []*entity.Notification, err error) {
+	if len(notifications) == 0 || len(notifications[0].GroupKey) == 0 {
+		return notifications, nil
+	}
+	userIDs := make([]string, 0, len(notifications))
+	for _, notification := range notifications {
+		userIDs = append(userIDs, notification.UserID)
+	}
+	grouped, err := ns.notificationRepo.GetUnreadGroupedNotifications(ctx, userIDs, notifications[0].GroupKey,
+		time.Now().Add(-schema.NotificationGroupWindow))
 	if err != nil {
-		log.Error(err)
-		return
+		return nil, err
+	}
+	groupedMapping := make(map[string]*entity.Notification, len(grouped))
+	for _, notification := range grouped {
+		groupedMapping[notification.UserID] = notification
+	}
+
+	rest = make([]*entity.Notification, 0, len(notifications))
+	for _, notification := range notifications {
+		existing, ok := groupedMapping[notification.UserID]
+		if !ok {
+			rest = append(rest, notification)
+			continue
+		}
+		existingContent, newerContent := &schema.NotificationContent{}, &schema.NotificationContent{}
+		if json.Unmarshal([]byte(existing.Content), existingContent) != nil ||
+			json.Unmarshal([]byte(notification.Content), newerContent) != nil {
+			rest = append(rest, notification)
+			continue
+		}
+		existingContent.MergeGroup(newerContent)
+		content, _ := json.Marshal(existingContent)
+		existing.Content = string(content)
+		if err = ns.notificationRepo.UpdateNotificationContent(ctx, existing); err != nil {
+			return nil, err
+		}
 	}
-	log.Infof("send notification to all followers: %s %d", condObjectID, len(userIDs))
+	return rest, nil
+}
+
+// getEventPreferences get the preferences of the event of the action for the users,
+// only the inbox and webhook are delivered to the followers so the legacy settings are not loaded
+func (ns *NotificationCommon) getEventPreferences(ctx context.Context, userIDs []string, action string) (
+	preferences map[string]*schema.NotificationPreference, err error) {
+	event := constant.GetNotificationEvent(action)
+	preferences = make(map[string]*schema.NotificationPreference, len(userIDs))
+	defaults := &schema.NotificationPreference{Event: event, Inbox: true, Webhook: true}
 	for _, userID := range userIDs {
-		t := &schema.NotificationMsg{}
-		_ = copier.Copy(t, msg)
-		t.ReceiverUserID = userID
-		t.TriggerUserID = msg.TriggerUserID
-		t.NoNeedPushAllFollow = true
-		ns.notificationQueueService.Send(ctx, t)
+		preferences[userID] = defaults
+	}
+	if len(event) == 0 {
+		return preferences, nil
+	}
+	configs, err := ns.userNotificationConfigRepo.GetByUsersAndSource(ctx, userIDs, constant.NotificationEventSource(event))
+	if err != nil {
+		return nil, err
+	}
+	for _, config := range configs {
+		preferences[config.UserID] = schema.NewNotificationPreferenceMatrix(
+			[]*entity.UserNotificationConfig{config}).Get(event)
 	}
+	return preferences, nil
 }
 
 func (ns *NotificationCommon) syncNotificationToPlugin(ctx context.Context, objInfo *schema.SimpleObjectInfo,
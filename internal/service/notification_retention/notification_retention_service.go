@@ -0,0 +1,77 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package notification_retention
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/schema"
+	notificationcommon "github.com/apache/answer/internal/service/notification_common"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/segmentfault/pacman/log"
+)
+
+// NotificationRetentionService archive or delete the read notifications out of retention
+type NotificationRetentionService struct {
+	notificationRepo notificationcommon.NotificationRepo
+	siteInfoService  siteinfo_common.SiteInfoCommonService
+}
+
+// NewNotificationRetentionService new notification retention service
+func NewNotificationRetentionService(
+	notificationRepo notificationcommon.NotificationRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *NotificationRetentionService {
+	return &NotificationRetentionService{
+		notificationRepo: notificationRepo,
+		siteInfoService:  siteInfoService,
+	}
+}
+
+// CleanCron clean the read notifications not updated in the retention days by batches,
+// the rest are cleaned in the next run if there are more than the max batches
+func (ns *NotificationRetentionService) CleanCron(ctx context.Context) {
+	conf, err := ns.siteInfoService.GetSiteNotificationRetention(ctx)
+	if err != nil {
+		log.Errorf("get site notification retention config failed: %v", err)
+		return
+	}
+	if !conf.Enabled {
+		return
+	}
+	before := time.Now().AddDate(0, 0, -conf.ReadRetentionDays)
+	archive := conf.Action == schema.NotificationRetentionActionArchive
+
+	var total int64
+	for i := 0; i < schema.NotificationRetentionMaxBatches; i++ {
+		cleaned, err := ns.notificationRepo.CleanReadNotificationsBefore(ctx, before,
+			schema.NotificationRetentionBatchSize, archive)
+		if err != nil {
+			log.Error(err)
+			break
+		}
+		total += cleaned
+		if cleaned < schema.NotificationRetentionBatchSize {
+			break
+		}
+	}
+	log.Infof("%s %d notifications not updated since %s", conf.Action, total, before.Format(time.DateOnly))
+}
@@ -0,0 +1,480 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package oauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/oauth"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// OAuthRepo OAuth repository
+type OAuthRepo interface {
+	AddOAuthClient(ctx context.Context, client *entity.OAuthClient) (err error)
+	UpdateOAuthClient(ctx context.Context, client *entity.OAuthClient) (err error)
+	UpdateOAuthClientSecret(ctx context.Context, id, secretHash string) (err error)
+	GetOAuthClient(ctx context.Context, id string) (client *entity.OAuthClient, exist bool, err error)
+	GetOAuthClientByClientID(ctx context.Context, clientID string) (client *entity.OAuthClient, exist bool, err error)
+	GetOAuthClients(ctx context.Context) (clients []*entity.OAuthClient, err error)
+	RemoveOAuthClient(ctx context.Context, id string) (err error)
+	SetAuthorizationCode(ctx context.Context, code string, info *schema.OAuthAuthorizationCode) (err error)
+	TakeAuthorizationCode(ctx context.Context, code string) (info *schema.OAuthAuthorizationCode, err error)
+	SetAccessToken(ctx context.Context, tokenHash string, info *schema.OAuthAccessToken) (err error)
+	GetAccessToken(ctx context.Context, tokenHash string) (info *schema.OAuthAccessToken, err error)
+}
+
+// OAuthService the site as the OpenID provider of the clients registered by admin,
+// the users log in the clients with the authorization code flow
+type OAuthService struct {
+	oauthRepo       OAuthRepo
+	userRepo        usercommon.UserRepo
+	siteInfoService siteinfo_common.SiteInfoCommonService
+}
+
+// NewOAuthService new OAuth service
+func NewOAuthService(
+	oauthRepo OAuthRepo,
+	userRepo usercommon.UserRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *OAuthService {
+	return &OAuthService{
+		oauthRepo:       oauthRepo,
+		userRepo:        userRepo,
+		siteInfoService: siteInfoService,
+	}
+}
+
+// GetOAuthClients get OAuth clients
+func (os *OAuthService) GetOAuthClients(ctx context.Context) (resp []*schema.OAuthClientItem, err error) {
+	clients, err := os.oauthRepo.GetOAuthClients(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.OAuthClientItem, 0, len(clients))
+	for _, client := range clients {
+		resp = append(resp, formatOAuthClient(client))
+	}
+	return resp, nil
+}
+
+// AddOAuthClient register OAuth client, the client secret is only returned once
+func (os *OAuthService) AddOAuthClient(ctx context.Context, req *schema.AddOAuthClientReq) (
+	resp *schema.AddOAuthClientResp, err error) {
+	clientID, err := oauth.GenerateSecret(16)
+	if err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	clientSecret, err := oauth.GenerateSecret(32)
+	if err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	redirectURIs, _ := json.Marshal(req.RedirectURIs)
+	client := &entity.OAuthClient{
+		ClientID:     clientID,
+		SecretHash:   hashSecret(clientSecret),
+		Name:         strings.TrimSpace(req.Name),
+		RedirectURIs: string(redirectURIs),
+	}
+	if err = os.oauthRepo.AddOAuthClient(ctx, client); err != nil {
+		return nil, err
+	}
+	return &schema.AddOAuthClientResp{OAuthClientItem: formatOAuthClient(client), ClientSecret: clientSecret}, nil
+}
+
+// UpdateOAuthClient update the name and redirect uris of OAuth client
+func (os *OAuthService) UpdateOAuthClient(ctx context.Context, req *schema.UpdateOAuthClientReq) (err error) {
+	client, exist, err := os.oauthRepo.GetOAuthClient(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.NotFound(reason.OAuthClientNotFound)
+	}
+	redirectURIs, _ := json.Marshal(req.RedirectURIs)
+	client.Name = strings.TrimSpace(req.Name)
+	client.RedirectURIs = string(redirectURIs)
+	return os.oauthRepo.UpdateOAuthClient(ctx, client)
+}
+
+// RegenerateOAuthClientSecret regenerate the secret of OAuth client, the previous secret is revoked
+func (os *OAuthService) RegenerateOAuthClientSecret(ctx context.Context, req *schema.RegenerateOAuthClientSecretReq) (
+	resp *schema.RegenerateOAuthClientSecretResp, err error) {
+	_, exist, err := os.oauthRepo.GetOAuthClient(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.NotFound(reason.OAuthClientNotFound)
+	}
+	clientSecret, err := oauth.GenerateSecret(32)
+	if err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	if err = os.oauthRepo.UpdateOAuthClientSecret(ctx, req.ID, hashSecret(clientSecret)); err != nil {
+		return nil, err
+	}
+	return &schema.RegenerateOAuthClientSecretResp{ClientSecret: clientSecret}, nil
+}
+
+// RemoveOAuthClient remove OAuth client, the access tokens issued to it are invalid immediately
+func (os *OAuthService) RemoveOAuthClient(ctx context.Context, req *schema.RemoveOAuthClientReq) (err error) {
+	return os.oauthRepo.RemoveOAuthClient(ctx, req.ID)
+}
+
+// GetDiscovery get the OpenID provider metadata
+func (os *OAuthService) GetDiscovery(ctx context.Context) (resp map[string]any, err error) {
+	if _, err = os.getSiteOAuthProvider(ctx); err != nil {
+		return nil, err
+	}
+	issuer, err := os.getIssuer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"issuer":                                issuer,
+		"authorization_endpoint":                issuer + "/authorize",
+		"token_endpoint":                        issuer + "/token",
+		"userinfo_endpoint":                     issuer + "/userinfo",
+		"jwks_uri":                              issuer + "/jwks",
+		"response_types_supported":              []string{schema.OAuthResponseTypeCode},
+		"grant_types_supported":                 []string{schema.OAuthGrantTypeAuthorizationCode},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"ES256"},
+		"scopes_supported":                      schema.OAuthScopes,
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post"},
+		"code_challenge_methods_supported":      []string{oauth.CodeChallengeMethodS256, oauth.CodeChallengeMethodPlain},
+		"claims_supported": []string{"sub", "iss", "aud", "exp", "iat", "auth_time", "nonce",
+			"name", "preferred_username", "profile", "picture", "email", "email_verified"},
+	}, nil
+}
+
+// GetJWKS get the JSON web key set verifying the id tokens
+func (os *OAuthService) GetJWKS(ctx context.Context) (resp map[string]any, err error) {
+	conf, err := os.getSiteOAuthProvider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err = oauth.JWKS(conf.SigningKey)
+	if err != nil {
+		return nil, errors.InternalServer(reason.OAuthSigningKeyInvalid).WithError(err).WithStack()
+	}
+	return resp, nil
+}
+
+// GetAuthorizePageURL the page of site the user approves the authorization request in
+func (os *OAuthService) GetAuthorizePageURL(ctx context.Context, rawQuery string) (pageURL string, err error) {
+	siteGeneral, err := os.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/users/oauth/authorize?%s", siteGeneral.SiteUrl, rawQuery), nil
+}
+
+// GetAuthorizeInfo get the client and the scopes of the authorization request to approve
+func (os *OAuthService) GetAuthorizeInfo(ctx context.Context, req *schema.OAuthAuthorizeReq) (
+	resp *schema.OAuthAuthorizeInfoResp, err error) {
+	client, err := os.checkAuthorizeReq(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &schema.OAuthAuthorizeInfoResp{ClientName: client.Name, Scopes: req.GetScopes()}, nil
+}
+
+// Authorize approve or deny the authorization request, the user is redirected to the client with the code or error
+func (os *OAuthService) Authorize(ctx context.Context, req *schema.OAuthAuthorizeReq) (
+	resp *schema.OAuthAuthorizeResp, err error) {
+	if _, err = os.checkAuthorizeReq(ctx, req); err != nil {
+		return nil, err
+	}
+	query := url.Values{}
+	if len(req.State) > 0 {
+		query.Set("state", req.State)
+	}
+	if !req.Approved {
+		query.Set("error", schema.OAuthErrorAccessDenied)
+		return &schema.OAuthAuthorizeResp{RedirectURL: appendQuery(req.RedirectURI, query)}, nil
+	}
+
+	code, err := oauth.GenerateSecret(32)
+	if err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	err = os.oauthRepo.SetAuthorizationCode(ctx, code, &schema.OAuthAuthorizationCode{
+		ClientID:            req.ClientID,
+		UserID:              req.UserID,
+		RedirectURI:         req.RedirectURI,
+		Scope:               strings.Join(req.GetScopes(), " "),
+		Nonce:               req.Nonce,
+		CodeChallenge:       req.CodeChallenge,
+		CodeChallengeMethod: req.CodeChallengeMethod,
+		AuthTime:            time.Now().Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	query.Set("code", code)
+	return &schema.OAuthAuthorizeResp{RedirectURL: appendQuery(req.RedirectURI, query)}, nil
+}
+
+// ExchangeToken exchange the authorization code for the access token, and the id token with the openid scope
+func (os *OAuthService) ExchangeToken(ctx context.Context, req *schema.OAuthTokenReq) (resp *schema.OAuthTokenResp, err error) {
+	conf, err := os.getSiteOAuthProvider(ctx)
+	if err != nil {
+		return nil, schema.NewOAuthError(schema.OAuthErrorInvalidClient, "the provider is disabled")
+	}
+	if req.GrantType != schema.OAuthGrantTypeAuthorizationCode {
+		return nil, schema.NewOAuthError(schema.OAuthErrorUnsupportedGrantType, "only authorization_code is supported")
+	}
+	client, exist, err := os.oauthRepo.GetOAuthClientByClientID(ctx, req.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || len(req.ClientSecret) == 0 ||
+		subtle.ConstantTimeCompare([]byte(hashSecret(req.ClientSecret)), []byte(client.SecretHash)) != 1 {
+		return nil, schema.NewOAuthError(schema.OAuthErrorInvalidClient, "the client authentication failed")
+	}
+
+	code, err := os.oauthRepo.TakeAuthorizationCode(ctx, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	if code == nil || code.ClientID != client.ClientID || code.RedirectURI != req.RedirectURI {
+		return nil, schema.NewOAuthError(schema.OAuthErrorInvalidGrant, "the code is invalid or expired")
+	}
+	if len(code.CodeChallenge) > 0 && !oauth.VerifyCodeChallenge(req.CodeVerifier, code.CodeChallenge, code.CodeChallengeMethod) {
+		return nil, schema.NewOAuthError(schema.OAuthErrorInvalidGrant, "the code verifier is invalid")
+	}
+	userInfo, err := os.getAvailableUser(ctx, code.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if userInfo == nil {
+		return nil, schema.NewOAuthError(schema.OAuthErrorInvalidGrant, "the user is unavailable")
+	}
+
+	accessToken, err := oauth.GenerateSecret(32)
+	if err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	err = os.oauthRepo.SetAccessToken(ctx, hashSecret(accessToken), &schema.OAuthAccessToken{
+		ClientID: client.ClientID,
+		UserID:   userInfo.ID,
+		Scope:    code.Scope,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp = &schema.OAuthTokenResp{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(schema.OAuthAccessTokenTTL.Seconds()),
+		Scope:       code.Scope,
+	}
+	if !hasScope(code.Scope, schema.OAuthScopeOpenID) {
+		return resp, nil
+	}
+
+	issuer, err := os.getIssuer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	claims := &schema.OAuthIDTokenClaims{
+		Issuer:        issuer,
+		Audience:      client.ClientID,
+		ExpiresAt:     now.Add(schema.OAuthAccessTokenTTL).Unix(),
+		IssuedAt:      now.Unix(),
+		AuthTime:      code.AuthTime,
+		Nonce:         code.Nonce,
+		OAuthUserInfo: os.formatUserInfo(ctx, userInfo, code.Scope),
+	}
+	if resp.IDToken, err = oauth.Sign(conf.SigningKey, claims); err != nil {
+		return nil, errors.InternalServer(reason.OAuthSigningKeyInvalid).WithError(err).WithStack()
+	}
+	return resp, nil
+}
+
+// GetUserInfo get the claims of the user authorized the access token
+func (os *OAuthService) GetUserInfo(ctx context.Context, accessToken string) (resp *schema.OAuthUserInfo, err error) {
+	if _, err = os.getSiteOAuthProvider(ctx); err != nil {
+		return nil, schema.NewOAuthError(schema.OAuthErrorInvalidToken, "the provider is disabled")
+	}
+	token, err := os.oauthRepo.GetAccessToken(ctx, hashSecret(accessToken))
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		return nil, schema.NewOAuthError(schema.OAuthErrorInvalidToken, "the access token is invalid or expired")
+	}
+	// the client may be removed after the token is issued
+	_, exist, err := os.oauthRepo.GetOAuthClientByClientID(ctx, token.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	userInfo, err := os.getAvailableUser(ctx, token.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || userInfo == nil {
+		return nil, schema.NewOAuthError(schema.OAuthErrorInvalidToken, "the access token is revoked")
+	}
+	return os.formatUserInfo(ctx, userInfo, token.Scope), nil
+}
+
+// checkAuthorizeReq the client and redirect uri must be registered, the response type must be code
+func (os *OAuthService) checkAuthorizeReq(ctx context.Context, req *schema.OAuthAuthorizeReq) (
+	client *entity.OAuthClient, err error) {
+	if _, err = os.getSiteOAuthProvider(ctx); err != nil {
+		return nil, err
+	}
+	client, exist, err := os.oauthRepo.GetOAuthClientByClientID(ctx, req.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.OAuthClientNotFound)
+	}
+	redirectURIs := make([]string, 0)
+	_ = json.Unmarshal([]byte(client.RedirectURIs), &redirectURIs)
+	registered := false
+	for _, redirectURI := range redirectURIs {
+		if redirectURI == req.RedirectURI {
+			registered = true
+			break
+		}
+	}
+	if !registered {
+		return nil, errors.BadRequest(reason.OAuthRedirectURIInvalid)
+	}
+	if req.ResponseType != schema.OAuthResponseTypeCode {
+		return nil, errors.BadRequest(reason.OAuthRequestInvalid)
+	}
+	return client, nil
+}
+
+func (os *OAuthService) getSiteOAuthProvider(ctx context.Context) (conf *schema.SiteOAuthProviderResp, err error) {
+	conf, err = os.siteInfoService.GetSiteOAuthProvider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !conf.Enabled || len(conf.SigningKey) == 0 {
+		return nil, errors.Forbidden(reason.OAuthProviderDisabled)
+	}
+	return conf, nil
+}
+
+func (os *OAuthService) getIssuer(ctx context.Context) (issuer string, err error) {
+	siteGeneral, err := os.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(siteGeneral.SiteUrl, "/") + "/answer/api/v1/oauth", nil
+}
+
+func (os *OAuthService) getAvailableUser(ctx context.Context, userID string) (userInfo *entity.User, err error) {
+	userInfo, exist, err := os.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || userInfo.Status != entity.UserStatusAvailable {
+		return nil, nil
+	}
+	return userInfo, nil
+}
+
+func (os *OAuthService) formatUserInfo(ctx context.Context, userInfo *entity.User, scope string) (resp *schema.OAuthUserInfo) {
+	resp = &schema.OAuthUserInfo{Subject: userInfo.ID}
+	if hasScope(scope, schema.OAuthScopeProfile) {
+		resp.Name = userInfo.DisplayName
+		resp.PreferredUsername = userInfo.Username
+		siteGeneral, err := os.siteInfoService.GetSiteGeneral(ctx)
+		if err != nil {
+			log.Error(err)
+		} else {
+			resp.Profile = fmt.Sprintf("%s/users/%s", siteGeneral.SiteUrl, userInfo.Username)
+		}
+		resp.Picture = os.siteInfoService.FormatAvatar(ctx, userInfo.Avatar, userInfo.EMail, userInfo.Status).GetURL()
+		if strings.HasPrefix(resp.Picture, "/") && siteGeneral != nil {
+			resp.Picture = siteGeneral.SiteUrl + resp.Picture
+		}
+	}
+	if hasScope(scope, schema.OAuthScopeEmail) {
+		verified := userInfo.MailStatus == entity.EmailStatusAvailable
+		resp.Email = userInfo.EMail
+		resp.EmailVerified = &verified
+	}
+	return resp
+}
+
+func formatOAuthClient(client *entity.OAuthClient) *schema.OAuthClientItem {
+	item := &schema.OAuthClientItem{
+		ID:           client.ID,
+		ClientID:     client.ClientID,
+		Name:         client.Name,
+		RedirectURIs: make([]string, 0),
+		CreatedAt:    client.CreatedAt.Unix(),
+	}
+	_ = json.Unmarshal([]byte(client.RedirectURIs), &item.RedirectURIs)
+	return item
+}
+
+func hasScope(scope, target string) bool {
+	for _, s := range strings.Fields(scope) {
+		if s == target {
+			return true
+		}
+	}
+	return false
+}
+
+// hashSecret the hash of the client secret and access token saved
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// appendQuery append the query to the redirect uri, the query of the redirect uri is kept
+func appendQuery(redirectURI string, query url.Values) string {
+	u, err := url.Parse(redirectURI)
+	if err != nil {
+		return redirectURI
+	}
+	values := u.Query()
+	for k, v := range query {
+		values[k] = v
+	}
+	u.RawQuery = values.Encode()
+	return u.String()
+}
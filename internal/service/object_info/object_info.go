@@ -204,6 +204,7 @@ func (os *ObjService) GetInfo(ctx context.Context, objectID string) (objInfo *sc
 			ObjectCreatorUserID: questionInfo.UserID,
 			QuestionID:          questionInfo.ID,
 			QuestionStatus:      questionInfo.Status,
+			QuestionShow:        questionInfo.Show,
 			ObjectType:          objectType,
 			Title:               questionInfo.Title,
 			Content:             questionInfo.ParsedText, // todo trim
@@ -228,6 +229,7 @@ func (os *ObjService) GetInfo(ctx context.Context, objectID string) (objInfo *sc
 			ObjectCreatorUserID: answerInfo.UserID,
 			QuestionID:          answerInfo.QuestionID,
 			QuestionStatus:      questionInfo.Status,
+			QuestionShow:        questionInfo.Show,
 			AnswerStatus:        answerInfo.Status,
 			AnswerID:            answerInfo.ID,
 			ObjectType:          objectType,
@@ -258,6 +260,7 @@ func (os *ObjService) GetInfo(ctx context.Context, objectID string) (objInfo *sc
 			if exist {
 				objInfo.QuestionID = questionInfo.ID
 				objInfo.QuestionStatus = questionInfo.Status
+				objInfo.QuestionShow = questionInfo.Show
 				objInfo.Title = questionInfo.Title
 			}
 			answerInfo, exist, err := os.answerRepo.GetAnswer(ctx, commentInfo.ObjectID)
@@ -289,3 +292,30 @@ func (os *ObjService) GetInfo(ctx context.Context, objectID string) (objInfo *sc
 	}
 	return objInfo, err
 }
+
+// GetOriginalText get the original markdown text of question or answer
+func (os *ObjService) GetOriginalText(ctx context.Context, objectID string) (originalText string, err error) {
+	objectType, err := obj.GetObjectTypeStrByObjectID(objectID)
+	if err != nil {
+		return "", err
+	}
+	switch objectType {
+	case constant.QuestionObjectType:
+		questionInfo, exist, err := os.questionRepo.GetQuestion(ctx, objectID)
+		if err != nil {
+			return "", err
+		}
+		if exist {
+			return questionInfo.OriginalText, nil
+		}
+	case constant.AnswerObjectType:
+		answerInfo, exist, err := os.answerRepo.GetAnswer(ctx, objectID)
+		if err != nil {
+			return "", err
+		}
+		if exist {
+			return answerInfo.OriginalText, nil
+		}
+	}
+	return "", errors.BadRequest(reason.ObjectNotFound)
+}
@@ -27,6 +27,7 @@ const (
 	QuestionDelete              = "question.delete"
 	QuestionClose               = "question.close"
 	QuestionReopen              = "question.reopen"
+	QuestionCloseVote           = "question.close_vote"
 	QuestionVoteUp              = "question.vote_up"
 	QuestionVoteDown            = "question.vote_down"
 	QuestionPin                 = "question.pin"
@@ -63,8 +64,39 @@ const (
 	AnswerUnDelete              = "answer.undeleted"
 	QuestionUnDelete            = "question.undeleted"
 	TagUnDelete                 = "tag.undeleted"
+	TagManage                   = "tag.manage"
+	ReportHandle                = "report.handle"
+	ReportView                  = "admin.report_view"
+	SiteSettingEdit             = "admin.site_setting_edit"
 )
 
+// powerGroups the power that grants a group of the discrete powers
+var powerGroups = map[string][]string{
+	TagManage: {
+		TagAdd, TagEdit, TagEditSlugName, TagEditWithoutReview, TagDelete, TagMerge,
+		TagSynonym, TagAudit, TagUseReservedTag, TagUnDelete,
+	},
+}
+
+// ExpandPowers expand the power groups to the discrete powers they grant
+func ExpandPowers(powers []string) []string {
+	result := make([]string, 0, len(powers))
+	seen := make(map[string]bool, len(powers))
+	add := func(power string) {
+		if !seen[power] {
+			seen[power] = true
+			result = append(result, power)
+		}
+	}
+	for _, power := range powers {
+		add(power)
+		for _, p := range powerGroups[power] {
+			add(p)
+		}
+	}
+	return result
+}
+
 const (
 	reportActionName                = "action.report"
 	editActionName                  = "action.edit"
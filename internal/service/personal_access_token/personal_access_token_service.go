@@ -0,0 +1,153 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package personal_access_token
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/auth"
+	"github.com/apache/answer/internal/service/role"
+	"github.com/apache/answer/pkg/token"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// PersonalAccessTokenRepo personal access token repository
+type PersonalAccessTokenRepo interface {
+	AddPersonalAccessToken(ctx context.Context, token *entity.PersonalAccessToken) (err error)
+	GetPersonalAccessToken(ctx context.Context, userID, id string) (token *entity.PersonalAccessToken, exist bool, err error)
+	GetPersonalAccessTokens(ctx context.Context, userID string) (tokens []*entity.PersonalAccessToken, err error)
+	RemovePersonalAccessToken(ctx context.Context, id string) (err error)
+}
+
+// PersonalAccessTokenService the long-lived api tokens of users, accepted as the access token with the scopes
+type PersonalAccessTokenService struct {
+	personalAccessTokenRepo PersonalAccessTokenRepo
+	authService             *auth.AuthService
+}
+
+// NewPersonalAccessTokenService new personal access token service
+func NewPersonalAccessTokenService(
+	personalAccessTokenRepo PersonalAccessTokenRepo,
+	authService *auth.AuthService,
+) *PersonalAccessTokenService {
+	return &PersonalAccessTokenService{
+		personalAccessTokenRepo: personalAccessTokenRepo,
+		authService:             authService,
+	}
+}
+
+// GetPersonalAccessTokens get the personal access tokens of user, the tokens themselves are never shown again
+func (ps *PersonalAccessTokenService) GetPersonalAccessTokens(ctx context.Context, userID string) (
+	resp []*schema.PersonalAccessTokenItem, err error) {
+	tokens, err := ps.personalAccessTokenRepo.GetPersonalAccessTokens(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.PersonalAccessTokenItem, 0, len(tokens))
+	for _, t := range tokens {
+		resp = append(resp, formatPersonalAccessToken(t))
+	}
+	return resp, nil
+}
+
+// AddPersonalAccessToken add personal access token, the moderate and admin scopes are limited to the staffs
+func (ps *PersonalAccessTokenService) AddPersonalAccessToken(ctx context.Context, req *schema.AddPersonalAccessTokenReq) (
+	resp *schema.AddPersonalAccessTokenResp, err error) {
+	tokens, err := ps.personalAccessTokenRepo.GetPersonalAccessTokens(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) >= schema.PersonalAccessTokenMaxAmount {
+		return nil, errors.BadRequest(reason.PersonalAccessTokenLimit)
+	}
+
+	scopes := make([]string, 0, len(req.Scopes))
+	added := make(map[string]bool, len(req.Scopes))
+	for _, scope := range req.Scopes {
+		switch scope {
+		case schema.PersonalAccessTokenScopeAdmin:
+			if req.RoleID != role.RoleAdminID {
+				return nil, errors.Forbidden(reason.PersonalAccessTokenScopeDenied)
+			}
+		case schema.PersonalAccessTokenScopeModerate:
+			if req.RoleID != role.RoleAdminID && req.RoleID != role.RoleModeratorID {
+				return nil, errors.Forbidden(reason.PersonalAccessTokenScopeDenied)
+			}
+		}
+		if !added[scope] {
+			added[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+
+	personalAccessToken := auth.PersonalAccessTokenPrefix + token.GenerateToken()
+	t := &entity.PersonalAccessToken{
+		UserID:    req.UserID,
+		Name:      strings.TrimSpace(req.Name),
+		TokenHash: auth.HashPersonalAccessToken(personalAccessToken),
+		Scopes:    strings.Join(scopes, ","),
+	}
+	if req.ExpiryDays > 0 {
+		t.ExpiredAt = time.Now().AddDate(0, 0, req.ExpiryDays)
+	}
+	if err = ps.personalAccessTokenRepo.AddPersonalAccessToken(ctx, t); err != nil {
+		return nil, err
+	}
+	return &schema.AddPersonalAccessTokenResp{
+		PersonalAccessTokenItem: formatPersonalAccessToken(t),
+		Token:                   personalAccessToken,
+	}, nil
+}
+
+// RemovePersonalAccessToken revoke the personal access token, it is invalid immediately
+func (ps *PersonalAccessTokenService) RemovePersonalAccessToken(ctx context.Context, req *schema.RemovePersonalAccessTokenReq) (err error) {
+	t, exist, err := ps.personalAccessTokenRepo.GetPersonalAccessToken(ctx, req.UserID, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.NotFound(reason.PersonalAccessTokenNotFound)
+	}
+	if err = ps.personalAccessTokenRepo.RemovePersonalAccessToken(ctx, t.ID); err != nil {
+		return err
+	}
+	return ps.authService.RemovePersonalAccessTokenCache(ctx, t.TokenHash)
+}
+
+func formatPersonalAccessToken(t *entity.PersonalAccessToken) *schema.PersonalAccessTokenItem {
+	item := &schema.PersonalAccessTokenItem{
+		ID:        t.ID,
+		Name:      t.Name,
+		Scopes:    strings.Split(t.Scopes, ","),
+		CreatedAt: t.CreatedAt.Unix(),
+	}
+	if !t.ExpiredAt.IsZero() {
+		item.ExpiredAt = t.ExpiredAt.Unix()
+	}
+	if !t.LastUsedAt.IsZero() {
+		item.LastUsedAt = t.LastUsedAt.Unix()
+	}
+	return item
+}
@@ -134,18 +134,12 @@ func (ps *PluginCommonService) GetUserPluginConfig(ctx context.Context, req *sch
 	return pluginUserConfig.Value, nil
 }
 
-func (ps *PluginCommonService) initPluginData() {
-	_ = plugin.CallKVStorage(func(k plugin.KVStorage) error {
-		k.SetOperator(plugin.NewKVOperator(
-			ps.data.DB,
-			ps.data.Cache,
-			k.Info().SlugName,
-		))
-		return nil
-	})
-
+// LoadPluginStatusAndConfig enable the plugins and send the saved config to them,
+// it returns false if the plugin config can't be loaded
+func LoadPluginStatusAndConfig(ctx context.Context, configService *config.ConfigService,
+	pluginConfigRepo PluginConfigRepo) (configLoaded bool) {
 	// init plugin status
-	pluginStatus, err := ps.configService.GetStringValue(context.TODO(), constant.PluginStatus)
+	pluginStatus, err := configService.GetStringValue(ctx, constant.PluginStatus)
 	if err != nil {
 		log.Error(err)
 	} else {
@@ -155,22 +149,36 @@ func (ps *PluginCommonService) initPluginData() {
 	}
 
 	// init plugin config
-	pluginConfigs, err := ps.pluginConfigRepo.GetPluginConfigAll(context.Background())
+	pluginConfigs, err := pluginConfigRepo.GetPluginConfigAll(ctx)
 	if err != nil {
 		log.Error(err)
-	} else {
-		for _, pluginConfig := range pluginConfigs {
-			err := plugin.CallConfig(func(fn plugin.Config) error {
-				if fn.Info().SlugName == pluginConfig.PluginSlugName {
-					return fn.ConfigReceiver([]byte(pluginConfig.Value))
-				}
-				return nil
-			})
-			if err != nil {
-				log.Errorf("parse plugin config failed: %s %v", pluginConfig.PluginSlugName, err)
+		return false
+	}
+	for _, pluginConfig := range pluginConfigs {
+		err := plugin.CallConfig(func(fn plugin.Config) error {
+			if fn.Info().SlugName == pluginConfig.PluginSlugName {
+				return fn.ConfigReceiver([]byte(pluginConfig.Value))
 			}
+			return nil
+		})
+		if err != nil {
+			log.Errorf("parse plugin config failed: %s %v", pluginConfig.PluginSlugName, err)
 		}
+	}
+	return true
+}
+
+func (ps *PluginCommonService) initPluginData() {
+	_ = plugin.CallKVStorage(func(k plugin.KVStorage) error {
+		k.SetOperator(plugin.NewKVOperator(
+			ps.data.DB,
+			ps.data.Cache,
+			k.Info().SlugName,
+		))
+		return nil
+	})
 
+	if LoadPluginStatusAndConfig(context.Background(), ps.configService, ps.pluginConfigRepo) {
 		_ = plugin.CallCache(func(cache plugin.Cache) error {
 			ps.data.Cache = cache
 			return nil
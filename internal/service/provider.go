@@ -24,46 +24,92 @@ import (
 	"github.com/apache/answer/internal/service/activity"
 	"github.com/apache/answer/internal/service/activity_common"
 	"github.com/apache/answer/internal/service/activity_queue"
+	"github.com/apache/answer/internal/service/admin_export"
 	answercommon "github.com/apache/answer/internal/service/answer_common"
+	"github.com/apache/answer/internal/service/audit_log"
 	"github.com/apache/answer/internal/service/auth"
+	"github.com/apache/answer/internal/service/auto_comment"
+	"github.com/apache/answer/internal/service/auto_promotion"
 	"github.com/apache/answer/internal/service/badge"
+	"github.com/apache/answer/internal/service/bot"
+	"github.com/apache/answer/internal/service/cache_warming"
+	"github.com/apache/answer/internal/service/chat_intake"
 	"github.com/apache/answer/internal/service/collection"
 	collectioncommon "github.com/apache/answer/internal/service/collection_common"
 	"github.com/apache/answer/internal/service/comment"
 	"github.com/apache/answer/internal/service/comment_common"
 	"github.com/apache/answer/internal/service/config"
 	"github.com/apache/answer/internal/service/content"
+	"github.com/apache/answer/internal/service/content_freshness"
+	"github.com/apache/answer/internal/service/content_language"
 	"github.com/apache/answer/internal/service/dashboard"
+	"github.com/apache/answer/internal/service/data_dump"
+	"github.com/apache/answer/internal/service/digest"
+	"github.com/apache/answer/internal/service/email_template"
 	"github.com/apache/answer/internal/service/event_queue"
 	"github.com/apache/answer/internal/service/export"
 	"github.com/apache/answer/internal/service/file_record"
 	"github.com/apache/answer/internal/service/follow"
+	"github.com/apache/answer/internal/service/helpfulness_survey"
 	"github.com/apache/answer/internal/service/importer"
+	"github.com/apache/answer/internal/service/inbound_email"
+	"github.com/apache/answer/internal/service/invite_code"
+	"github.com/apache/answer/internal/service/ip_ban"
+	"github.com/apache/answer/internal/service/jira"
+	"github.com/apache/answer/internal/service/leaderboard"
+	"github.com/apache/answer/internal/service/login_protection"
+	"github.com/apache/answer/internal/service/messenger"
 	"github.com/apache/answer/internal/service/meta"
 	"github.com/apache/answer/internal/service/meta_common"
+	"github.com/apache/answer/internal/service/mobile_push"
 	"github.com/apache/answer/internal/service/notice_queue"
 	"github.com/apache/answer/internal/service/notification"
 	notficationcommon "github.com/apache/answer/internal/service/notification_common"
+	"github.com/apache/answer/internal/service/notification_retention"
+	"github.com/apache/answer/internal/service/oauth"
 	"github.com/apache/answer/internal/service/object_info"
+	"github.com/apache/answer/internal/service/personal_access_token"
 	"github.com/apache/answer/internal/service/plugin_common"
+	"github.com/apache/answer/internal/service/question_close_vote"
 	questioncommon "github.com/apache/answer/internal/service/question_common"
+	"github.com/apache/answer/internal/service/question_heat"
+	"github.com/apache/answer/internal/service/question_quality"
 	"github.com/apache/answer/internal/service/rank"
+	"github.com/apache/answer/internal/service/re_engagement"
 	"github.com/apache/answer/internal/service/reason"
 	"github.com/apache/answer/internal/service/report"
+	"github.com/apache/answer/internal/service/report_appeal"
 	"github.com/apache/answer/internal/service/report_handle"
+	"github.com/apache/answer/internal/service/reputation_sync"
 	"github.com/apache/answer/internal/service/review"
 	"github.com/apache/answer/internal/service/revision_common"
 	"github.com/apache/answer/internal/service/role"
+	"github.com/apache/answer/internal/service/saved_search"
+	"github.com/apache/answer/internal/service/scim"
 	"github.com/apache/answer/internal/service/search_parser"
 	"github.com/apache/answer/internal/service/siteinfo"
 	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/apache/answer/internal/service/slack"
+	"github.com/apache/answer/internal/service/space_common"
+	"github.com/apache/answer/internal/service/syndication"
+	"github.com/apache/answer/internal/service/syndication_common"
+	"github.com/apache/answer/internal/service/table_partition"
 	"github.com/apache/answer/internal/service/tag"
+	"github.com/apache/answer/internal/service/tag_analytics"
 	tagcommon "github.com/apache/answer/internal/service/tag_common"
+	"github.com/apache/answer/internal/service/tag_moderator"
 	"github.com/apache/answer/internal/service/uploader"
 	"github.com/apache/answer/internal/service/user_admin"
 	usercommon "github.com/apache/answer/internal/service/user_common"
 	"github.com/apache/answer/internal/service/user_external_login"
+	"github.com/apache/answer/internal/service/user_import"
+	"github.com/apache/answer/internal/service/user_mute"
 	"github.com/apache/answer/internal/service/user_notification_config"
+	"github.com/apache/answer/internal/service/user_session"
+	"github.com/apache/answer/internal/service/user_timeline"
+	"github.com/apache/answer/internal/service/vote_fraud"
+	"github.com/apache/answer/internal/service/web_push"
+	"github.com/apache/answer/internal/service/webhook"
 	"github.com/google/wire"
 )
 
@@ -115,6 +161,7 @@ var ProviderSetService = wire.NewSet(
 	plugin_common.NewPluginCommonService,
 	config.NewConfigService,
 	notice_queue.NewNotificationQueueService,
+	notice_queue.NewNotificationFanOutQueueService,
 	activity_queue.NewActivityQueueService,
 	user_notification_config.NewUserNotificationConfigService,
 	notification.NewExternalNotificationService,
@@ -128,4 +175,46 @@ var ProviderSetService = wire.NewSet(
 	badge.NewBadgeGroupService,
 	importer.NewImporterService,
 	file_record.NewFileRecordService,
+	question_close_vote.NewQuestionCloseVoteService,
+	report_appeal.NewReportAppealService,
+	data_dump.NewDataDumpService,
+	admin_export.NewAdminExportService,
+	chat_intake.NewChatIntakeService,
+	rank.NewReputationRecalcService,
+	reputation_sync.NewReputationSyncService,
+	vote_fraud.NewVoteFraudService,
+	leaderboard.NewLeaderboardService,
+	user_timeline.NewUserTimelineService,
+	syndication_common.NewSyndicationCommon,
+	syndication.NewSyndicationService,
+	re_engagement.NewReEngagementService,
+	question_quality.NewQuestionQualityService,
+	auto_comment.NewAutoCommentService,
+	tag_moderator.NewTagModeratorService,
+	tag_analytics.NewTagAnalyticsService,
+	helpfulness_survey.NewHelpfulnessSurveyService,
+	content_language.NewContentLanguageService,
+	auto_promotion.NewAutoPromotionService,
+	content_freshness.NewContentFreshnessService,
+	bot.NewBotService,
+	saved_search.NewSavedSearchService,
+	question_heat.NewQuestionHeatTracker,
+	cache_warming.NewCacheWarmingService,
+	table_partition.NewTablePartitionService,
+	webhook.NewWebhookService,
+	slack.NewSlackService,
+	jira.NewJiraService,
+	space_common.NewSpaceCommon,
+	messenger.NewMessengerService,
+	web_push.NewWebPushService,
+	mobile_push.NewMobilePushService,
+	inbound_email.NewInboundEmailService,
+	email_template.NewEmailTemplateService,
+	user_mute.NewUserMuteService,
+	notification_retention.NewNotificationRetentionService,
+	scim.NewSCIMService,
+	personal_access_token.NewPersonalAccessTokenService, oauth.NewOAuthService, user_session.NewUserSessionService, login_protection.NewLoginProtectionService, invite_code.NewInviteCodeService, audit_log.NewAuditLogService,
+	digest.NewDigestService,
+	ip_ban.NewIPBanService,
+	user_import.NewUserImportService,
 )
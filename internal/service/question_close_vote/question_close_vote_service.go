@@ -0,0 +1,364 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question_close_vote
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/config"
+	"github.com/apache/answer/internal/service/content"
+	"github.com/apache/answer/internal/service/notice_queue"
+	questioncommon "github.com/apache/answer/internal/service/question_common"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/apache/answer/pkg/checker"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// QuestionCloseVoteRepo question close vote repository
+type QuestionCloseVoteRepo interface {
+	AddVote(ctx context.Context, vote *entity.QuestionCloseVote) (err error)
+	GetUserActiveVote(ctx context.Context, questionID, userID string, since time.Time) (
+		vote *entity.QuestionCloseVote, exist bool, err error)
+	GetActiveVotes(ctx context.Context, questionID, voteType string, since time.Time) (
+		votes []*entity.QuestionCloseVote, err error)
+	UpdateVoteStatus(ctx context.Context, id, status int) (err error)
+	ApplyActiveVotes(ctx context.Context, questionID string) (err error)
+	ExpireActiveVotes(ctx context.Context, questionID string) (err error)
+	ExpireVotes(ctx context.Context, before time.Time) (affected int64, err error)
+}
+
+// QuestionCloseVoteService community vote to close or reopen question
+type QuestionCloseVoteService struct {
+	questionCloseVoteRepo    QuestionCloseVoteRepo
+	questionRepo             questioncommon.QuestionRepo
+	questionService          *content.QuestionService
+	configService            *config.ConfigService
+	siteInfoService          siteinfo_common.SiteInfoCommonService
+	notificationQueueService notice_queue.NotificationQueueService
+}
+
+// NewQuestionCloseVoteService new question close vote service
+func NewQuestionCloseVoteService(
+	questionCloseVoteRepo QuestionCloseVoteRepo,
+	questionRepo questioncommon.QuestionRepo,
+	questionService *content.QuestionService,
+	configService *config.ConfigService,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	notificationQueueService notice_queue.NotificationQueueService,
+) *QuestionCloseVoteService {
+	qs := &QuestionCloseVoteService{
+		questionCloseVoteRepo:    questionCloseVoteRepo,
+		questionRepo:             questionRepo,
+		questionService:          questionService,
+		configService:            configService,
+		siteInfoService:          siteInfoService,
+		notificationQueueService: notificationQueueService,
+	}
+	// the votes cast before the question is closed or reopened by others no longer count
+	questionService.RegisterCloseStatusChangedHandler(qs.questionCloseVoteRepo.ExpireActiveVotes)
+	return qs
+}
+
+// GetVoteStatus get the close or reopen vote status of question
+func (qs *QuestionCloseVoteService) GetVoteStatus(ctx context.Context, req *schema.GetQuestionCloseVoteReq) (
+	resp *schema.QuestionCloseVoteResp, err error) {
+	setting, err := qs.siteInfoService.GetSiteQuestionClose(ctx)
+	if err != nil {
+		return nil, err
+	}
+	questionInfo, err := qs.getQuestion(ctx, req.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	return qs.buildVoteStatus(ctx, setting, questionInfo, req.UserID)
+}
+
+// CastCloseVote vote to close question, the question will be closed when enough votes are cast in the window
+func (qs *QuestionCloseVoteService) CastCloseVote(ctx context.Context, req *schema.QuestionCloseVoteReq) (
+	resp *schema.QuestionCloseVoteResp, err error) {
+	setting, err := qs.getEnabledSetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+	questionInfo, err := qs.getQuestion(ctx, req.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	if questionInfo.Status == entity.QuestionStatusClosed {
+		return nil, errors.BadRequest(reason.QuestionAlreadyClosed)
+	}
+
+	cf, err := qs.configService.GetConfigByID(ctx, req.CloseType)
+	if err != nil || cf == nil {
+		return nil, errors.BadRequest(reason.QuestionCloseTypeInvalid)
+	}
+	closeReasons, err := qs.configService.GetArrayStringValue(ctx, constant.QuestionCloseReasonsKey)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(closeReasons, cf.Key) {
+		return nil, errors.BadRequest(reason.QuestionCloseTypeInvalid)
+	}
+	if cf.Key == constant.ReasonADuplicate && !checker.IsURL(req.CloseMsg) {
+		return nil, errors.BadRequest(reason.InvalidURLError)
+	}
+
+	if err = qs.addVote(ctx, setting, &entity.QuestionCloseVote{
+		QuestionID: questionInfo.ID,
+		UserID:     req.UserID,
+		VoteType:   entity.QuestionCloseVoteTypeClose,
+		CloseType:  req.CloseType,
+		CloseMsg:   req.CloseMsg,
+		Status:     entity.QuestionCloseVoteStatusActive,
+	}); err != nil {
+		return nil, err
+	}
+
+	votes, err := qs.questionCloseVoteRepo.GetActiveVotes(ctx, questionInfo.ID,
+		entity.QuestionCloseVoteTypeClose, windowStart(setting))
+	if err != nil {
+		return nil, err
+	}
+	if len(votes) < setting.VotesRequired {
+		return qs.buildVoteStatus(ctx, setting, questionInfo, req.UserID)
+	}
+
+	// the votes are applied before closing, otherwise they are expired as the question is closed
+	closeType, closeMsg := tallyCloseReason(votes)
+	if err = qs.questionCloseVoteRepo.ApplyActiveVotes(ctx, questionInfo.ID); err != nil {
+		return nil, err
+	}
+	err = qs.questionService.CloseQuestion(ctx, &schema.CloseQuestionReq{
+		ID:        questionInfo.ID,
+		CloseType: closeType,
+		CloseMsg:  closeMsg,
+		UserID:    req.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	qs.notifyAuthor(ctx, questionInfo, req.UserID, constant.NotificationYourQuestionIsClosed)
+	return &schema.QuestionCloseVoteResp{
+		VoteType:       entity.QuestionCloseVoteTypeReopen,
+		VotesRequired:  setting.VotesRequired,
+		VoteWindowDays: setting.VoteWindowDays,
+		Applied:        true,
+	}, nil
+}
+
+// CastReopenVote vote to reopen question, the question will be reopened when enough votes are cast in the window
+func (qs *QuestionCloseVoteService) CastReopenVote(ctx context.Context, req *schema.QuestionReopenVoteReq) (
+	resp *schema.QuestionCloseVoteResp, err error) {
+	setting, err := qs.getEnabledSetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+	questionInfo, err := qs.getQuestion(ctx, req.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	if questionInfo.Status != entity.QuestionStatusClosed {
+		return nil, errors.BadRequest(reason.QuestionNotClosed)
+	}
+
+	if err = qs.addVote(ctx, setting, &entity.QuestionCloseVote{
+		QuestionID: questionInfo.ID,
+		UserID:     req.UserID,
+		VoteType:   entity.QuestionCloseVoteTypeReopen,
+		Status:     entity.QuestionCloseVoteStatusActive,
+	}); err != nil {
+		return nil, err
+	}
+
+	votes, err := qs.questionCloseVoteRepo.GetActiveVotes(ctx, questionInfo.ID,
+		entity.QuestionCloseVoteTypeReopen, windowStart(setting))
+	if err != nil {
+		return nil, err
+	}
+	if len(votes) < setting.VotesRequired {
+		return qs.buildVoteStatus(ctx, setting, questionInfo, req.UserID)
+	}
+
+	if err = qs.questionCloseVoteRepo.ApplyActiveVotes(ctx, questionInfo.ID); err != nil {
+		return nil, err
+	}
+	err = qs.questionService.ReopenQuestion(ctx, &schema.ReopenQuestionReq{
+		QuestionID: questionInfo.ID,
+		UserID:     req.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	qs.notifyAuthor(ctx, questionInfo, req.UserID, constant.NotificationYourQuestionIsReopened)
+	return &schema.QuestionCloseVoteResp{
+		VoteType:       entity.QuestionCloseVoteTypeClose,
+		VotesRequired:  setting.VotesRequired,
+		VoteWindowDays: setting.VoteWindowDays,
+		Applied:        true,
+	}, nil
+}
+
+// RevokeVote revoke the active close or reopen vote of user
+func (qs *QuestionCloseVoteService) RevokeVote(ctx context.Context, req *schema.RevokeQuestionCloseVoteReq) (
+	resp *schema.QuestionCloseVoteResp, err error) {
+	setting, err := qs.getEnabledSetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+	questionInfo, err := qs.getQuestion(ctx, req.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	vote, exist, err := qs.questionCloseVoteRepo.GetUserActiveVote(ctx, questionInfo.ID, req.UserID, windowStart(setting))
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.QuestionCloseVoteNotFound)
+	}
+	if err = qs.questionCloseVoteRepo.UpdateVoteStatus(ctx, vote.ID, entity.QuestionCloseVoteStatusRevoked); err != nil {
+		return nil, err
+	}
+	return qs.buildVoteStatus(ctx, setting, questionInfo, req.UserID)
+}
+
+// ExpireVotesCron expire the votes that are out of the vote window
+func (qs *QuestionCloseVoteService) ExpireVotesCron(ctx context.Context) {
+	setting, err := qs.siteInfoService.GetSiteQuestionClose(ctx)
+	if err != nil {
+		log.Errorf("get question close setting failed: %v", err)
+		return
+	}
+	affected, err := qs.questionCloseVoteRepo.ExpireVotes(ctx, windowStart(setting))
+	if err != nil {
+		log.Errorf("expire question close votes failed: %v", err)
+		return
+	}
+	if affected > 0 {
+		log.Infof("expired %d question close votes", affected)
+	}
+}
+
+func (qs *QuestionCloseVoteService) getEnabledSetting(ctx context.Context) (
+	setting *schema.SiteQuestionCloseResp, err error) {
+	setting, err = qs.siteInfoService.GetSiteQuestionClose(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !setting.VoteEnabled {
+		return nil, errors.BadRequest(reason.QuestionCloseVoteDisabled)
+	}
+	return setting, nil
+}
+
+func (qs *QuestionCloseVoteService) getQuestion(ctx context.Context, questionID string) (
+	questionInfo *entity.Question, err error) {
+	questionInfo, exist, err := qs.questionRepo.GetQuestion(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || questionInfo.Status == entity.QuestionStatusDeleted {
+		return nil, errors.BadRequest(reason.QuestionNotFound)
+	}
+	return questionInfo, nil
+}
+
+// addVote add vote, each user can only have one active vote for a question at the same time
+func (qs *QuestionCloseVoteService) addVote(ctx context.Context, setting *schema.SiteQuestionCloseResp,
+	vote *entity.QuestionCloseVote) (err error) {
+	_, exist, err := qs.questionCloseVoteRepo.GetUserActiveVote(ctx, vote.QuestionID, vote.UserID, windowStart(setting))
+	if err != nil {
+		return err
+	}
+	if exist {
+		return errors.BadRequest(reason.QuestionCloseVoteAlreadyCast)
+	}
+	return qs.questionCloseVoteRepo.AddVote(ctx, vote)
+}
+
+func (qs *QuestionCloseVoteService) buildVoteStatus(ctx context.Context, setting *schema.SiteQuestionCloseResp,
+	questionInfo *entity.Question, userID string) (resp *schema.QuestionCloseVoteResp, err error) {
+	resp = &schema.QuestionCloseVoteResp{
+		VoteType:       entity.QuestionCloseVoteTypeClose,
+		VotesRequired:  setting.VotesRequired,
+		VoteWindowDays: setting.VoteWindowDays,
+	}
+	if questionInfo.Status == entity.QuestionStatusClosed {
+		resp.VoteType = entity.QuestionCloseVoteTypeReopen
+	}
+	votes, err := qs.questionCloseVoteRepo.GetActiveVotes(ctx, questionInfo.ID, resp.VoteType, windowStart(setting))
+	if err != nil {
+		return nil, err
+	}
+	resp.VoteCount = int64(len(votes))
+	for _, vote := range votes {
+		if len(userID) > 0 && vote.UserID == userID {
+			resp.Voted = true
+			break
+		}
+	}
+	return resp, nil
+}
+
+func (qs *QuestionCloseVoteService) notifyAuthor(ctx context.Context, questionInfo *entity.Question,
+	triggerUserID, action string) {
+	msg := &schema.NotificationMsg{
+		TriggerUserID:      triggerUserID,
+		ReceiverUserID:     questionInfo.UserID,
+		Type:               schema.NotificationTypeInbox,
+		ObjectID:           questionInfo.ID,
+		ObjectType:         constant.QuestionObjectType,
+		NotificationAction: action,
+	}
+	qs.notificationQueueService.Send(ctx, msg)
+}
+
+func windowStart(setting *schema.SiteQuestionCloseResp) time.Time {
+	return time.Now().AddDate(0, 0, -setting.VoteWindowDays)
+}
+
+// tallyCloseReason pick the most voted close reason, the latest one wins if tied.
+// The votes are ordered from the latest to the earliest.
+func tallyCloseReason(votes []*entity.QuestionCloseVote) (closeType int, closeMsg string) {
+	counts := make(map[int]int)
+	for _, vote := range votes {
+		counts[vote.CloseType]++
+	}
+	maxCount := 0
+	for _, vote := range votes {
+		if counts[vote.CloseType] > maxCount {
+			maxCount = counts[vote.CloseType]
+			closeType = vote.CloseType
+		}
+	}
+	for _, vote := range votes {
+		if vote.CloseType == closeType && len(vote.CloseMsg) > 0 {
+			return closeType, vote.CloseMsg
+		}
+	}
+	return closeType, ""
+}
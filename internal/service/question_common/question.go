@@ -52,6 +52,9 @@ import (
 	"github.com/segmentfault/pacman/log"
 )
 
+// questionLinkGraphLimit the max number of links shown in question detail
+const questionLinkGraphLimit = 20
+
 // QuestionRepo question repository
 type QuestionRepo interface {
 	AddQuestion(ctx context.Context, question *entity.Question) (err error)
@@ -59,9 +62,9 @@ type QuestionRepo interface {
 	UpdateQuestion(ctx context.Context, question *entity.Question, Cols []string) (err error)
 	GetQuestion(ctx context.Context, id string) (question *entity.Question, exist bool, err error)
 	GetQuestionList(ctx context.Context, question *entity.Question) (questions []*entity.Question, err error)
-	GetQuestionPage(ctx context.Context, page, pageSize int, tagIDs []string, userID, orderCond string, inDays int, showHidden, showPending bool) (
+	GetQuestionPage(ctx context.Context, page, pageSize int, tagIDs, excludedTagIDs []string, userID, orderCond string, inDays int, showHidden, showPending bool) (
 		questionList []*entity.Question, total int64, err error)
-	GetRecommendQuestionPageByTags(ctx context.Context, userID string, tagIDs, followedQuestionIDs []string, page, pageSize int) (questionList []*entity.Question, total int64, err error)
+	GetRecommendQuestionPageByTags(ctx context.Context, userID string, tagIDs, excludedTagIDs, followedQuestionIDs []string, page, pageSize int) (questionList []*entity.Question, total int64, err error)
 	UpdateQuestionStatus(ctx context.Context, questionID string, status int) (err error)
 	UpdateQuestionStatusWithOutUpdateTime(ctx context.Context, question *entity.Question) (err error)
 	DeletePermanentlyQuestions(ctx context.Context) (err error)
@@ -79,12 +82,14 @@ type QuestionRepo interface {
 	GetUnansweredQuestionCount(ctx context.Context) (count int64, err error)
 	GetResolvedQuestionCount(ctx context.Context) (count int64, err error)
 	GetUserQuestionCount(ctx context.Context, userID string, show int) (count int64, err error)
-	SitemapQuestions(ctx context.Context, page, pageSize int) (questionIDList []*schema.SiteMapQuestionInfo, err error)
+	SitemapQuestions(ctx context.Context, page, pageSize int, excludedTagIDs []string) (questionIDList []*schema.SiteMapQuestionInfo, err error)
 	RemoveAllUserQuestion(ctx context.Context, userID string) (err error)
 	UpdateSearch(ctx context.Context, questionID string) (err error)
 	LinkQuestion(ctx context.Context, link ...*entity.QuestionLink) (err error)
 	GetLinkedQuestionIDs(ctx context.Context, questionID string, status int) (questionIDs []string, err error)
 	UpdateQuestionLinkCount(ctx context.Context, questionID string) (err error)
+	GetQuestionLinksFrom(ctx context.Context, questionID string, limit int) (links []*entity.QuestionLink, err error)
+	GetQuestionLinksTo(ctx context.Context, questionID string, limit int) (links []*entity.QuestionLink, err error)
 	RemoveQuestionLink(ctx context.Context, link ...*entity.QuestionLink) (err error)
 	RecoverQuestionLink(ctx context.Context, link ...*entity.QuestionLink) (err error)
 	UpdateQuestionLinkStatus(ctx context.Context, status int, links ...*entity.QuestionLink) (err error)
@@ -179,10 +184,11 @@ func (qs *QuestionCommon) UpdateCollectionCount(ctx context.Context, questionID
 	return qs.questionRepo.UpdateCollectionCount(ctx, questionID)
 }
 
-func (qs *QuestionCommon) UpdateAccepted(ctx context.Context, questionID, AnswerID string) error {
+func (qs *QuestionCommon) UpdateAccepted(ctx context.Context, questionID, AnswerID, resolutionNote string) error {
 	question := &entity.Question{}
 	question.ID = questionID
 	question.AcceptedAnswerID = AnswerID
+	question.ResolutionNote = resolutionNote
 	return qs.questionRepo.UpdateAccepted(ctx, question)
 }
 
@@ -251,7 +257,71 @@ func (qs *QuestionCommon) InviteUserInfo(ctx context.Context, questionID string)
 	return InviteUserInfo, nil
 }
 
+// questionVisitorInfoCache the question info cached for visitors, the fields hidden in json are kept separately
+type questionVisitorInfoCache struct {
+	Info               *schema.QuestionInfoResp `json:"info"`
+	UpdateTime         int64                    `json:"update_time"`
+	UserID             string                   `json:"user_id"`
+	LastEditUserID     string                   `json:"last_edit_user_id"`
+	LastAnsweredUserID string                   `json:"last_answered_user_id"`
+}
+
 func (qs *QuestionCommon) Info(ctx context.Context, questionID string, loginUserID string) (resp *schema.QuestionInfoResp, err error) {
+	// the hot questions are cached for visitors by the cache warming
+	if len(loginUserID) == 0 {
+		if resp, exist := qs.getVisitorInfoCache(ctx, questionID); exist {
+			return resp, nil
+		}
+	}
+	return qs.info(ctx, questionID, loginUserID)
+}
+
+// WarmVisitorInfoCache cache the question info for visitors in the language of context
+func (qs *QuestionCommon) WarmVisitorInfoCache(ctx context.Context, questionID string) (err error) {
+	resp, err := qs.info(ctx, questionID, "")
+	if err != nil {
+		return err
+	}
+	cacheData, err := json.Marshal(&questionVisitorInfoCache{
+		Info:               resp,
+		UpdateTime:         resp.UpdateTime,
+		UserID:             resp.UserID,
+		LastEditUserID:     resp.LastEditUserID,
+		LastAnsweredUserID: resp.LastAnsweredUserID,
+	})
+	if err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	err = qs.data.Cache.SetString(ctx, qs.visitorInfoCacheKey(ctx, questionID), string(cacheData),
+		constant.QuestionVisitorInfoCacheTime)
+	if err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+func (qs *QuestionCommon) getVisitorInfoCache(ctx context.Context, questionID string) (
+	resp *schema.QuestionInfoResp, exist bool) {
+	cacheData, exist, err := qs.data.Cache.GetString(ctx, qs.visitorInfoCacheKey(ctx, questionID))
+	if err != nil || !exist {
+		return nil, false
+	}
+	info := &questionVisitorInfoCache{}
+	if err = json.Unmarshal([]byte(cacheData), info); err != nil || info.Info == nil {
+		return nil, false
+	}
+	info.Info.UpdateTime = info.UpdateTime
+	info.Info.UserID = info.UserID
+	info.Info.LastEditUserID = info.LastEditUserID
+	info.Info.LastAnsweredUserID = info.LastAnsweredUserID
+	return info.Info, true
+}
+
+func (qs *QuestionCommon) visitorInfoCacheKey(ctx context.Context, questionID string) string {
+	return fmt.Sprintf(constant.QuestionVisitorInfoCacheKey, uid.DeShortID(questionID), handler.GetLangByCtx(ctx))
+}
+
+func (qs *QuestionCommon) info(ctx context.Context, questionID string, loginUserID string) (resp *schema.QuestionInfoResp, err error) {
 	questionInfo, has, err := qs.questionRepo.GetQuestion(ctx, questionID)
 	if err != nil {
 		return resp, err
@@ -261,6 +331,7 @@ func (qs *QuestionCommon) Info(ctx context.Context, questionID string, loginUser
 		return resp, errors.NotFound(reason.QuestionNotFound)
 	}
 	resp = qs.ShowFormat(ctx, questionInfo)
+	var migrationDestination *schema.QuestionMigrationDestination
 	if resp.Status == entity.QuestionStatusClosed {
 		metaInfo, err := qs.metaCommonService.GetMetaByObjectIdAndKey(ctx, questionInfo.ID, entity.QuestionCloseReasonKey)
 		if err != nil {
@@ -286,6 +357,16 @@ func (qs *QuestionCommon) Info(ctx context.Context, questionID string, loginUser
 					operation.Level = schema.OperationLevelInfo
 					resp.Operation = operation
 				}
+				if len(closeMsg.MigrationDestination) > 0 {
+					migrationDestination = qs.getMigrationDestination(ctx, closeMsg.MigrationDestination)
+				}
+				if migrationDestination != nil {
+					resp.MigrationSuggestion = &schema.QuestionMigrationSuggestion{
+						Name:        migrationDestination.Name,
+						URL:         migrationDestination.URL,
+						Description: migrationDestination.Description,
+					}
+				}
 			}
 		}
 	}
@@ -339,6 +420,16 @@ func (qs *QuestionCommon) Info(ctx context.Context, questionID string, loginUser
 		return resp, nil
 	}
 
+	// only the author can repost the question with its content carried over
+	if migrationDestination != nil && loginUserID == questionInfo.UserID {
+		tagSlugNames := make([]string, 0, len(resp.Tags))
+		for _, tag := range resp.Tags {
+			tagSlugNames = append(tagSlugNames, tag.SlugName)
+		}
+		resp.MigrationSuggestion.RepostURL = migrationDestination.BuildRepostURL(
+			questionInfo.Title, questionInfo.OriginalText, tagSlugNames)
+	}
+
 	resp.VoteStatus = qs.voteRepo.GetVoteStatus(ctx, questionID, loginUserID)
 	resp.IsFollowed, _ = qs.followCommon.IsFollowed(ctx, loginUserID, questionID)
 
@@ -598,14 +689,15 @@ func (qs *QuestionCommon) RemoveAnswer(ctx context.Context, id string) (err erro
 	return qs.answerRepo.RemoveAnswer(ctx, id)
 }
 
-func (qs *QuestionCommon) SitemapCron(ctx context.Context) {
+// SitemapCron cache the sitemap questions, the questions carrying the excluded tags are not in the sitemap
+func (qs *QuestionCommon) SitemapCron(ctx context.Context, excludedTagIDs []string) {
 	questionNum, err := qs.questionRepo.GetQuestionCount(ctx)
 	if err != nil {
 		log.Error(err)
 		return
 	}
 	if questionNum <= constant.SitemapMaxSize {
-		_, err = qs.questionRepo.SitemapQuestions(ctx, 1, int(questionNum))
+		_, err = qs.questionRepo.SitemapQuestions(ctx, 1, int(questionNum), excludedTagIDs)
 		if err != nil {
 			log.Errorf("get site map question error: %v", err)
 		}
@@ -614,7 +706,7 @@ func (qs *QuestionCommon) SitemapCron(ctx context.Context) {
 
 	totalPages := int(math.Ceil(float64(questionNum) / float64(constant.SitemapMaxSize)))
 	for i := 1; i <= totalPages; i++ {
-		_, err = qs.questionRepo.SitemapQuestions(ctx, i, constant.SitemapMaxSize)
+		_, err = qs.questionRepo.SitemapQuestions(ctx, i, constant.SitemapMaxSize, excludedTagIDs)
 		if err != nil {
 			log.Errorf("get site map question error: %v", err)
 			return
@@ -656,6 +748,8 @@ func (qs *QuestionCommon) ShowFormat(ctx context.Context, data *entity.Question)
 	info.CollectionCount = data.CollectionCount
 	info.FollowCount = data.FollowCount
 	info.AcceptedAnswerID = data.AcceptedAnswerID
+	info.PinnedAnswerID = data.PinnedAnswerID
+	info.ResolutionNote = data.ResolutionNote
 	info.LastAnswerID = data.LastAnswerID
 	info.CreateTime = data.CreatedAt.Unix()
 	info.UpdateTime = data.UpdatedAt.Unix()
@@ -789,6 +883,7 @@ func (qs *QuestionCommon) UpdateQuestionLink(ctx context.Context, questionID, an
 			FromAnswerID:   uid.DeShortID(answerID),
 			ToQuestionID:   uid.DeShortID(link.QuestionID),
 			ToAnswerID:     uid.DeShortID(link.AnswerID),
+			LinkType:       link.LinkType,
 		}
 		// replace link in parsed text
 		if link.QuestionID != "" {
@@ -828,30 +923,110 @@ func (qs *QuestionCommon) UpdateQuestionLink(ctx context.Context, questionID, an
 	return parsedText, nil
 }
 
-// AddQuestionLinkForCloseReason When the reason about close question is a question link, add the link to the question
+// AddQuestionLinkForCloseReason When the reason about close question is a question link, add the link to the question,
+// the linked question is returned if it exists
 func (qs *QuestionCommon) AddQuestionLinkForCloseReason(ctx context.Context,
-	questionInfo *entity.Question, closeMsg string) {
+	questionInfo *entity.Question, closeMsg string) (linkedQuestion *entity.Question) {
 	questionID := qs.tryToGetQuestionIDFromMsg(ctx, closeMsg)
 	if len(questionID) == 0 {
-		return
+		return nil
 	}
 
 	linkedQuestion, exist, err := qs.questionRepo.GetQuestion(ctx, questionID)
 	if err != nil {
 		log.Errorf("get question error %s", err)
-		return
+		return nil
 	}
-	if !exist {
-		return
+	if !exist || uid.DeShortID(linkedQuestion.ID) == uid.DeShortID(questionInfo.ID) {
+		return nil
 	}
 	err = qs.questionRepo.LinkQuestion(ctx, &entity.QuestionLink{
 		FromQuestionID: questionInfo.ID,
 		ToQuestionID:   linkedQuestion.ID,
 		Status:         entity.QuestionLinkStatusAvailable,
+		LinkType:       entity.QuestionLinkTypeDuplicate,
 	})
 	if err != nil {
 		log.Errorf("link question error %s", err)
 	}
+	return linkedQuestion
+}
+
+// GetQuestionLinkGraph get the questions linked by this question and the questions referencing it
+func (qs *QuestionCommon) GetQuestionLinkGraph(ctx context.Context, questionID string) (
+	linked, referencedBy []*schema.QuestionLinkItem, err error) {
+	linksFrom, err := qs.questionRepo.GetQuestionLinksFrom(ctx, questionID, questionLinkGraphLimit)
+	if err != nil {
+		return nil, nil, err
+	}
+	linksTo, err := qs.questionRepo.GetQuestionLinksTo(ctx, questionID, questionLinkGraphLimit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	questionIDs := make([]string, 0, len(linksFrom)+len(linksTo))
+	for _, link := range linksFrom {
+		questionIDs = append(questionIDs, link.ToQuestionID)
+	}
+	for _, link := range linksTo {
+		questionIDs = append(questionIDs, link.FromQuestionID)
+	}
+	questionMapping := make(map[string]*entity.Question)
+	if len(questionIDs) > 0 {
+		questions, err := qs.questionRepo.FindByID(ctx, questionIDs)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, question := range questions {
+			if question.Show == entity.QuestionShow &&
+				(question.Status == entity.QuestionStatusAvailable || question.Status == entity.QuestionStatusClosed) {
+				questionMapping[uid.DeShortID(question.ID)] = question
+			}
+		}
+	}
+
+	// the same question linked several times such as by the question and its answers is only shown once,
+	// and it is shown as duplicate if one of the links is
+	buildItems := func(links []*entity.QuestionLink, getQuestionID, getAnswerID func(link *entity.QuestionLink) string) []*schema.QuestionLinkItem {
+		items := make([]*schema.QuestionLinkItem, 0, len(links))
+		added := make(map[string]*schema.QuestionLinkItem)
+		for _, link := range links {
+			id := getQuestionID(link)
+			question, ok := questionMapping[id]
+			if !ok || id == uid.DeShortID(questionID) {
+				continue
+			}
+			if item, ok := added[id]; ok {
+				if link.LinkType == entity.QuestionLinkTypeDuplicate {
+					item.LinkType = entity.QuestionLinkTypeMapping[link.LinkType]
+				}
+				continue
+			}
+			item := schema.NewQuestionLinkItem(ctx, question, getAnswerID(link),
+				entity.QuestionLinkTypeMapping[link.LinkType])
+			added[id] = item
+			items = append(items, item)
+		}
+		return items
+	}
+	linked = buildItems(linksFrom,
+		func(link *entity.QuestionLink) string { return link.ToQuestionID },
+		func(link *entity.QuestionLink) string { return link.ToAnswerID })
+	referencedBy = buildItems(linksTo,
+		func(link *entity.QuestionLink) string { return link.FromQuestionID },
+		func(link *entity.QuestionLink) string { return link.FromAnswerID })
+	return linked, referencedBy, nil
+}
+
+// getMigrationDestination get the destination suggested when the question closed,
+// the destination may have been removed by admin after that
+func (qs *QuestionCommon) getMigrationDestination(ctx context.Context, name string) *schema.QuestionMigrationDestination {
+	siteMigration, err := qs.siteInfoService.GetSiteQuestionMigration(ctx)
+	if err != nil {
+		log.Errorf("get site question migration error %s", err)
+		return nil
+	}
+	return siteMigration.GetDestination(name)
 }
 
 func (qs *QuestionCommon) RemoveQuestionLinkForReopen(ctx context.Context, questionInfo *entity.Question) {
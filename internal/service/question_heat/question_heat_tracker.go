@@ -0,0 +1,148 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question_heat
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// heatWindowMinutes the views in the recent minutes are counted for the view velocity
+	heatWindowMinutes = 10
+	// heatMaxTrackedQuestions the max number of questions tracked in memory, the others are ignored until expired
+	heatMaxTrackedQuestions = 10000
+)
+
+// QuestionHeat the heat signals of question in the recent window
+type QuestionHeat struct {
+	QuestionID string
+	// the views per minute in the recent window
+	ViewVelocity float64
+	// the question is visited from other sites in the recent window
+	ExternallyLinked bool
+}
+
+type questionViews struct {
+	// the views of each minute, the index is the minute modulo the window
+	buckets [heatWindowMinutes]int
+	// the minute of each bucket, the bucket of old minute is expired
+	minutes        [heatWindowMinutes]int64
+	lastExternalAt int64
+}
+
+// QuestionHeatTracker track the recent views of questions in memory as the signal of cache warming
+type QuestionHeatTracker struct {
+	lock      sync.Mutex
+	questions map[string]*questionViews
+}
+
+// NewQuestionHeatTracker new question heat tracker
+func NewQuestionHeatTracker() *QuestionHeatTracker {
+	return &QuestionHeatTracker{
+		questions: make(map[string]*questionViews),
+	}
+}
+
+// RecordView record the view of question
+func (t *QuestionHeatTracker) RecordView(questionID string) {
+	t.recordViewAt(questionID, time.Now())
+}
+
+// MarkExternallyLinked mark the question is visited from other sites, the view is recorded separately
+func (t *QuestionHeatTracker) MarkExternallyLinked(questionID string) {
+	t.markExternallyLinkedAt(questionID, time.Now())
+}
+
+func (t *QuestionHeatTracker) recordViewAt(questionID string, now time.Time) {
+	minute := now.Unix() / 60
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	views := t.getOrAdd(questionID)
+	if views == nil {
+		return
+	}
+	idx := minute % heatWindowMinutes
+	// the bucket is reused by a later minute, the view is out of the window
+	if views.minutes[idx] > minute {
+		return
+	}
+	if views.minutes[idx] != minute {
+		views.minutes[idx] = minute
+		views.buckets[idx] = 0
+	}
+	views.buckets[idx]++
+}
+
+func (t *QuestionHeatTracker) markExternallyLinkedAt(questionID string, now time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if views := t.getOrAdd(questionID); views != nil {
+		views.lastExternalAt = now.Unix() / 60
+	}
+}
+
+func (t *QuestionHeatTracker) getOrAdd(questionID string) *questionViews {
+	if len(questionID) == 0 {
+		return nil
+	}
+	views, ok := t.questions[questionID]
+	if !ok {
+		if len(t.questions) >= heatMaxTrackedQuestions {
+			return nil
+		}
+		views = &questionViews{}
+		t.questions[questionID] = views
+	}
+	return views
+}
+
+// Snapshot get the heat of questions viewed in the recent window, the expired questions are removed
+func (t *QuestionHeatTracker) Snapshot() (heats []*QuestionHeat) {
+	return t.snapshotAt(time.Now())
+}
+
+func (t *QuestionHeatTracker) snapshotAt(now time.Time) (heats []*QuestionHeat) {
+	since := now.Unix()/60 - heatWindowMinutes + 1
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	heats = make([]*QuestionHeat, 0, len(t.questions))
+	for questionID, views := range t.questions {
+		total := 0
+		for i, minute := range views.minutes {
+			if minute >= since {
+				total += views.buckets[i]
+			}
+		}
+		if total == 0 {
+			// the question marked externally linked may be viewed later
+			if views.lastExternalAt < since {
+				delete(t.questions, questionID)
+			}
+			continue
+		}
+		heats = append(heats, &QuestionHeat{
+			QuestionID:       questionID,
+			ViewVelocity:     float64(total) / heatWindowMinutes,
+			ExternallyLinked: views.lastExternalAt >= since,
+		})
+	}
+	return heats
+}
@@ -0,0 +1,54 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question_heat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuestionHeatTracker_Snapshot(t *testing.T) {
+	tracker := NewQuestionHeatTracker()
+	now := time.Unix(1700000000, 0)
+	for i := 0; i < 20; i++ {
+		tracker.recordViewAt("1", now.Add(-time.Duration(i)*time.Minute))
+	}
+	tracker.recordViewAt("2", now.Add(-2*time.Minute))
+	tracker.markExternallyLinkedAt("2", now.Add(-2*time.Minute))
+	tracker.recordViewAt("3", now.Add(-time.Hour))
+	tracker.markExternallyLinkedAt("4", now)
+
+	heats := make(map[string]*QuestionHeat)
+	for _, heat := range tracker.snapshotAt(now) {
+		heats[heat.QuestionID] = heat
+	}
+	assert.Len(t, heats, 2)
+	// the views older than the window are not counted
+	assert.Equal(t, 1.0, heats["1"].ViewVelocity)
+	assert.False(t, heats["1"].ExternallyLinked)
+	assert.Equal(t, 0.1, heats["2"].ViewVelocity)
+	assert.True(t, heats["2"].ExternallyLinked)
+
+	// the question not viewed in the window is removed
+	assert.Empty(t, tracker.snapshotAt(now.Add(time.Hour)))
+	assert.Empty(t, tracker.questions)
+}
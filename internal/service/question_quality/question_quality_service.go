@@ -0,0 +1,301 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question_quality
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	questioncommon "github.com/apache/answer/internal/service/question_common"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	tagcommon "github.com/apache/answer/internal/service/tag_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/checker"
+	"github.com/apache/answer/pkg/htmltext"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+var qualityStatusMapping = map[int]string{
+	entity.QuestionQualityStatusPending:  schema.QuestionQualityStatusPending,
+	entity.QuestionQualityStatusClaimed:  schema.QuestionQualityStatusClaimed,
+	entity.QuestionQualityStatusResolved: schema.QuestionQualityStatusResolved,
+}
+
+// QuestionQualityRepo question quality repository
+type QuestionQualityRepo interface {
+	AddQuality(ctx context.Context, quality *entity.QuestionQuality) (err error)
+	UpdateQuality(ctx context.Context, quality *entity.QuestionQuality) (err error)
+	GetQuality(ctx context.Context, id string) (quality *entity.QuestionQuality, exist bool, err error)
+	GetQualityByQuestionID(ctx context.Context, questionID string) (quality *entity.QuestionQuality, exist bool, err error)
+	GetQueuePage(ctx context.Context, claimedBy string, claimExpiredBefore time.Time, page, pageSize int) (
+		list []*entity.QuestionQuality, total int64, err error)
+	ClaimQuality(ctx context.Context, id, userID string, claimExpiredBefore time.Time) (claimed bool, err error)
+	ReleaseQuality(ctx context.Context, id string) (err error)
+}
+
+// QuestionQualityService score the new questions and manage the needs improvement queue
+type QuestionQualityService struct {
+	questionQualityRepo QuestionQualityRepo
+	questionRepo        questioncommon.QuestionRepo
+	tagCommonService    *tagcommon.TagCommonService
+	userRepo            usercommon.UserRepo
+	userCommon          *usercommon.UserCommon
+	siteInfoService     siteinfo_common.SiteInfoCommonService
+}
+
+// NewQuestionQualityService new question quality service
+func NewQuestionQualityService(
+	questionQualityRepo QuestionQualityRepo,
+	questionRepo questioncommon.QuestionRepo,
+	tagCommonService *tagcommon.TagCommonService,
+	userRepo usercommon.UserRepo,
+	userCommon *usercommon.UserCommon,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *QuestionQualityService {
+	return &QuestionQualityService{
+		questionQualityRepo: questionQualityRepo,
+		questionRepo:        questionRepo,
+		tagCommonService:    tagCommonService,
+		userRepo:            userRepo,
+		userCommon:          userCommon,
+		siteInfoService:     siteInfoService,
+	}
+}
+
+// EvaluateNewQuestion score the new question, route it into the queue if the score is below the threshold
+func (qs *QuestionQualityService) EvaluateNewQuestion(ctx context.Context, questionID string) {
+	conf, err := qs.siteInfoService.GetSiteQuestionQuality(ctx)
+	if err != nil || !conf.Enabled {
+		return
+	}
+	quality, err := qs.scoreQuestion(ctx, questionID)
+	if err != nil || quality == nil {
+		return
+	}
+	if quality.Score >= conf.Threshold {
+		return
+	}
+	reasons, _ := json.Marshal(quality.Reasons)
+	err = qs.questionQualityRepo.AddQuality(ctx, &entity.QuestionQuality{
+		QuestionID: questionID,
+		Score:      quality.Score,
+		Reasons:    string(reasons),
+		Status:     entity.QuestionQualityStatusPending,
+	})
+	if err != nil {
+		log.Error(err)
+	}
+}
+
+// ReevaluateQuestion score the edited question again if it is in the queue,
+// the item is resolved when the score reaches the threshold
+func (qs *QuestionQualityService) ReevaluateQuestion(ctx context.Context, questionID string) {
+	item, exist, err := qs.questionQualityRepo.GetQualityByQuestionID(ctx, questionID)
+	if err != nil || !exist || item.Status == entity.QuestionQualityStatusResolved {
+		return
+	}
+	conf, err := qs.siteInfoService.GetSiteQuestionQuality(ctx)
+	if err != nil {
+		return
+	}
+	quality, err := qs.scoreQuestion(ctx, questionID)
+	if err != nil || quality == nil {
+		return
+	}
+	reasons, _ := json.Marshal(quality.Reasons)
+	item.Score = quality.Score
+	item.Reasons = string(reasons)
+	if quality.Score >= conf.Threshold {
+		item.Status = entity.QuestionQualityStatusResolved
+	}
+	if err = qs.questionQualityRepo.UpdateQuality(ctx, item); err != nil {
+		log.Error(err)
+	}
+}
+
+func (qs *QuestionQualityService) scoreQuestion(ctx context.Context, questionID string) (
+	quality *checker.QuestionQuality, err error) {
+	question, exist, err := qs.questionRepo.GetQuestion(ctx, questionID)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	if !exist {
+		return nil, nil
+	}
+	tags, err := qs.tagCommonService.GetObjectEntityTag(ctx, questionID)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	totalQuestions, err := qs.questionRepo.GetQuestionCount(ctx)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	qualityTags := make([]*checker.QuestionQualityTag, 0, len(tags))
+	for _, tag := range tags {
+		qualityTags = append(qualityTags, &checker.QuestionQualityTag{SlugName: tag.SlugName, QuestionCount: tag.QuestionCount})
+	}
+	return checker.GetQuestionQuality(question.Title, question.OriginalText, qualityTags, totalQuestions), nil
+}
+
+// GetQueuePage get the needs improvement queue
+func (qs *QuestionQualityService) GetQueuePage(ctx context.Context, req *schema.GetQuestionQualityQueueReq) (
+	pageModel *pager.PageModel, err error) {
+	conf, err := qs.checkPermission(ctx, req.UserID, req.IsAdminModerator)
+	if err != nil {
+		return nil, err
+	}
+	claimedBy := ""
+	if req.Mine {
+		claimedBy = req.UserID
+	}
+	claimExpiredBefore := time.Now().Add(-time.Duration(conf.ClaimHours) * time.Hour)
+	list, total, err := qs.questionQualityRepo.GetQueuePage(ctx, claimedBy, claimExpiredBefore, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	questionIDs := make([]string, 0, len(list))
+	claimerIDs := make([]string, 0)
+	for _, item := range list {
+		questionIDs = append(questionIDs, item.QuestionID)
+		if item.Status == entity.QuestionQualityStatusClaimed {
+			claimerIDs = append(claimerIDs, item.ClaimedBy)
+		}
+	}
+	questions, err := qs.questionRepo.FindByID(ctx, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+	questionMapping := make(map[string]*entity.Question, len(questions))
+	for _, question := range questions {
+		questionMapping[question.ID] = question
+	}
+	claimers, err := qs.userCommon.BatchUserBasicInfoByID(ctx, claimerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*schema.QuestionQualityQueueItem, 0, len(list))
+	for _, item := range list {
+		question, ok := questionMapping[item.QuestionID]
+		if !ok {
+			continue
+		}
+		respItem := &schema.QuestionQualityQueueItem{
+			ID:         item.ID,
+			QuestionID: question.ID,
+			Title:      question.Title,
+			UrlTitle:   htmltext.UrlTitle(question.Title),
+			Score:      item.Score,
+			Reasons:    make([]string, 0),
+			Status:     qualityStatusMapping[item.Status],
+			CreatedAt:  item.CreatedAt.Unix(),
+		}
+		_ = json.Unmarshal([]byte(item.Reasons), &respItem.Reasons)
+		// the expired claim is shown as pending, others can claim it again
+		if item.Status == entity.QuestionQualityStatusClaimed && item.ClaimedAt.After(claimExpiredBefore) {
+			respItem.ClaimedBy = claimers[item.ClaimedBy]
+			respItem.ClaimedAt = item.ClaimedAt.Unix()
+		} else if item.Status == entity.QuestionQualityStatusClaimed {
+			respItem.Status = schema.QuestionQualityStatusPending
+		}
+		if handler.GetEnableShortID(ctx) {
+			respItem.QuestionID = uid.EnShortID(respItem.QuestionID)
+		}
+		resp = append(resp, respItem)
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// ClaimItem claim the queue item, the claimer is expected to edit or comment the question
+func (qs *QuestionQualityService) ClaimItem(ctx context.Context, req *schema.ClaimQuestionQualityReq) (err error) {
+	conf, err := qs.checkPermission(ctx, req.UserID, req.IsAdminModerator)
+	if err != nil {
+		return err
+	}
+	item, exist, err := qs.questionQualityRepo.GetQuality(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist || item.Status == entity.QuestionQualityStatusResolved {
+		return errors.NotFound(reason.QuestionQualityNotFound)
+	}
+	claimExpiredBefore := time.Now().Add(-time.Duration(conf.ClaimHours) * time.Hour)
+	claimed, err := qs.questionQualityRepo.ClaimQuality(ctx, item.ID, req.UserID, claimExpiredBefore)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return errors.BadRequest(reason.QuestionQualityClaimed)
+	}
+	return nil
+}
+
+// ReleaseItem release the claimed queue item, only the claimer or admin and moderator can release it
+func (qs *QuestionQualityService) ReleaseItem(ctx context.Context, req *schema.ReleaseQuestionQualityReq) (err error) {
+	if _, err = qs.checkPermission(ctx, req.UserID, req.IsAdminModerator); err != nil {
+		return err
+	}
+	item, exist, err := qs.questionQualityRepo.GetQuality(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist || item.Status != entity.QuestionQualityStatusClaimed {
+		return errors.NotFound(reason.QuestionQualityNotFound)
+	}
+	if item.ClaimedBy != req.UserID && !req.IsAdminModerator {
+		return errors.Forbidden(reason.QuestionQualityClaimed)
+	}
+	return qs.questionQualityRepo.ReleaseQuality(ctx, item.ID)
+}
+
+// checkPermission only the experienced users whose reputation reaches the min reputation can work on the queue
+func (qs *QuestionQualityService) checkPermission(ctx context.Context, userID string, isAdminModerator bool) (
+	conf *schema.SiteQuestionQualityResp, err error) {
+	conf, err = qs.siteInfoService.GetSiteQuestionQuality(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !conf.Enabled {
+		return nil, errors.BadRequest(reason.QuestionQualityDisabled)
+	}
+	if isAdminModerator {
+		return conf, nil
+	}
+	user, exist, err := qs.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || user.Rank < conf.MinReputation {
+		return nil, errors.Forbidden(reason.RankFailToMeetTheCondition)
+	}
+	return conf, nil
+}
@@ -20,7 +20,11 @@
 package rank
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
+	"strconv"
+	"time"
 
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/handler"
@@ -34,6 +38,7 @@ import (
 	"github.com/apache/answer/internal/service/object_info"
 	"github.com/apache/answer/internal/service/permission"
 	"github.com/apache/answer/internal/service/role"
+	"github.com/apache/answer/internal/service/tag_moderator"
 	usercommon "github.com/apache/answer/internal/service/user_common"
 	"github.com/apache/answer/pkg/htmltext"
 	"github.com/apache/answer/pkg/uid"
@@ -54,6 +59,7 @@ type UserRankRepo interface {
 		userID string, userCurrentScore, deltaRank int) (err error)
 	TriggerUserRank(ctx context.Context, session *xorm.Session, userId string, rank int, activityType int) (isReachStandard bool, err error)
 	UserRankPage(ctx context.Context, userId string, page, pageSize int) (rankPage []*entity.Activity, total int64, err error)
+	UserRankHistoryPage(ctx context.Context, req *schema.GetRankHistoryReq) (rankPage []*entity.Activity, total int64, err error)
 }
 
 // RankService rank service
@@ -64,6 +70,19 @@ type RankService struct {
 	objectInfoService *object_info.ObjService
 	roleService       *role.UserRoleRelService
 	rolePowerService  *role.RolePowerRelService
+	tagModeratorRepo  tag_moderator.TagModeratorRepo
+}
+
+// tagModeratorActions the actions the tag moderator can do on the questions carrying the moderated tags and their answers
+var tagModeratorActions = map[string]bool{
+	permission.QuestionEdit:              true,
+	permission.QuestionEditWithoutReview: true,
+	permission.QuestionClose:             true,
+	permission.QuestionReopen:            true,
+	permission.QuestionAudit:             true,
+	permission.AnswerEdit:                true,
+	permission.AnswerEditWithoutReview:   true,
+	permission.AnswerAudit:               true,
 }
 
 // NewRankService new rank service
@@ -73,7 +92,8 @@ func NewRankService(
 	objectInfoService *object_info.ObjService,
 	roleService *role.UserRoleRelService,
 	rolePowerService *role.RolePowerRelService,
-	configService *config.ConfigService) *RankService {
+	configService *config.ConfigService,
+	tagModeratorRepo tag_moderator.TagModeratorRepo) *RankService {
 	return &RankService{
 		userCommon:        userCommon,
 		configService:     configService,
@@ -81,6 +101,7 @@ func NewRankService(
 		objectInfoService: objectInfoService,
 		roleService:       roleService,
 		rolePowerService:  rolePowerService,
+		tagModeratorRepo:  tagModeratorRepo,
 	}
 }
 
@@ -117,9 +138,55 @@ func (rs *RankService) CheckOperationPermission(ctx context.Context, userID stri
 	}
 
 	can, _ = rs.checkUserRank(ctx, userInfo.ID, userInfo.Rank, PermissionPrefix+action)
+	if !can && tagModeratorActions[action] && len(objectID) > 0 {
+		can = rs.isObjectTagModerator(ctx, userID, objectID)
+	}
 	return can, nil
 }
 
+// CheckObjectOperationPermissions verify that the user has permissions on the object,
+// the tag moderator of the object has the tag moderation permissions regardless of rank
+func (rs *RankService) CheckObjectOperationPermissions(ctx context.Context, userID, objectID string, actions []string) (
+	can []bool, requireRanks []int, err error) {
+	can, requireRanks, err = rs.CheckOperationPermissionsForRanks(ctx, userID, actions)
+	if err != nil || len(userID) == 0 || len(objectID) == 0 {
+		return can, requireRanks, err
+	}
+	needCheck := false
+	for idx, action := range actions {
+		if !can[idx] && tagModeratorActions[action] {
+			needCheck = true
+		}
+	}
+	if !needCheck || !rs.isObjectTagModerator(ctx, userID, objectID) {
+		return can, requireRanks, nil
+	}
+	for idx, action := range actions {
+		if tagModeratorActions[action] {
+			can[idx] = true
+		}
+	}
+	return can, requireRanks, nil
+}
+
+// isObjectTagModerator whether the user moderates any tag of the question that the object belongs to
+func (rs *RankService) isObjectTagModerator(ctx context.Context, userID, objectID string) bool {
+	objectInfo, err := rs.objectInfoService.GetInfo(ctx, uid.DeShortID(objectID))
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+	if objectInfo == nil || len(objectInfo.QuestionID) == 0 {
+		return false
+	}
+	is, err := rs.tagModeratorRepo.IsQuestionTagModerator(ctx, userID, uid.DeShortID(objectInfo.QuestionID))
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+	return is
+}
+
 // CheckOperationPermissionsForRanks verify that the user has permission
 func (rs *RankService) CheckOperationPermissionsForRanks(ctx context.Context, userID string, actions []string) (
 	can []bool, requireRanks []int, err error) {
@@ -158,6 +225,14 @@ func (rs *RankService) CheckOperationPermissions(ctx context.Context, userID str
 	return can, err
 }
 
+// CheckUserPower whether the role of the user has the power, the power can not be obtained by the reputation
+func (rs *RankService) CheckUserPower(ctx context.Context, userID string, power string) bool {
+	if len(userID) == 0 {
+		return false
+	}
+	return rs.getUserPowerMapping(ctx, userID)[power]
+}
+
 // CheckOperationObjectOwner check operation object owner
 func (rs *RankService) CheckOperationObjectOwner(ctx context.Context, userID, objectID string) bool {
 	objectID = uid.DeShortID(objectID)
@@ -222,6 +297,85 @@ func (rs *RankService) CheckVotePermission(ctx context.Context, userID, objectID
 	return can, needRank, nil
 }
 
+// ExplainPermission explain the decision of the user operating the action on the object step by step,
+// the checks are the same as CheckOperationPermission, and the user status and object status are only reported for reference
+func (rs *RankService) ExplainPermission(ctx context.Context, req *schema.ExplainPermissionReq) (
+	resp *schema.ExplainPermissionResp, err error) {
+	resp = &schema.ExplainPermissionResp{Action: req.Action, Steps: make([]*schema.PermissionTraceStep, 0)}
+	userInfo, exist, err := rs.userCommon.GetUserBasicInfoByID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+	resp.AddStep(schema.PermissionCheckUserStatus, schema.PermissionTraceInfo, map[string]any{"status": userInfo.Status})
+
+	roleMapping, err := rs.roleService.GetUserRoleMapping(ctx, []string{userInfo.ID})
+	if err != nil {
+		return nil, err
+	}
+	roleData := map[string]any{}
+	if userRole, ok := roleMapping[userInfo.ID]; ok {
+		roleData["role_id"] = userRole.ID
+		roleData["role_name"] = userRole.Name
+	}
+	powerMapping := rs.getUserPowerMapping(ctx, userInfo.ID)
+	result := schema.PermissionTracePass
+	if powerMapping[req.Action] {
+		result = schema.PermissionTraceAllow
+	}
+	resp.AddStep(schema.PermissionCheckRole, result, roleData)
+
+	var objectInfo *schema.SimpleObjectInfo
+	if len(req.ObjectID) > 0 {
+		objectInfo, err = rs.objectInfoService.GetInfo(ctx, uid.DeShortID(req.ObjectID))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if objectInfo == nil {
+		resp.AddStep(schema.PermissionCheckObjectStatus, schema.PermissionTraceSkip, nil)
+		resp.AddStep(schema.PermissionCheckOwnership, schema.PermissionTraceSkip, nil)
+	} else {
+		resp.AddStep(schema.PermissionCheckObjectStatus, schema.PermissionTraceInfo, map[string]any{
+			"object_type": objectInfo.ObjectType,
+			"deleted":     objectInfo.IsDeleted(),
+		})
+		result = schema.PermissionTracePass
+		if objectInfo.ObjectCreatorUserID == userInfo.ID {
+			result = schema.PermissionTraceAllow
+		}
+		resp.AddStep(schema.PermissionCheckOwnership, result, map[string]any{
+			"object_creator_user_id": objectInfo.ObjectCreatorUserID,
+		})
+	}
+
+	meetRank, requireRank := rs.checkUserRank(ctx, userInfo.ID, userInfo.Rank, PermissionPrefix+req.Action)
+	result = schema.PermissionTracePass
+	if meetRank {
+		result = schema.PermissionTraceAllow
+	}
+	resp.AddStep(schema.PermissionCheckRank, result, map[string]any{
+		"rank":         userInfo.Rank,
+		"require_rank": requireRank,
+		// the negative required rank means the action is not allowed by reputation
+		"disabled": requireRank < 0,
+	})
+
+	if !tagModeratorActions[req.Action] || objectInfo == nil {
+		resp.AddStep(schema.PermissionCheckTagModerator, schema.PermissionTraceSkip, nil)
+	} else {
+		result = schema.PermissionTracePass
+		if rs.isObjectTagModerator(ctx, userInfo.ID, req.ObjectID) {
+			result = schema.PermissionTraceAllow
+		}
+		resp.AddStep(schema.PermissionCheckTagModerator, result, nil)
+	}
+	resp.Decide()
+	return resp, nil
+}
+
 // getUserPowerMapping get user power mapping
 func (rs *RankService) getUserPowerMapping(ctx context.Context, userID string) (powerMapping map[string]bool) {
 	powerMapping = make(map[string]bool, 0)
@@ -327,3 +481,125 @@ func (rs *RankService) decorateRankPersonalPageResp(
 	}
 	return resp
 }
+
+// GetRankHistoryPage get the full reputation history of user, including the cancelled reputation changes
+func (rs *RankService) GetRankHistoryPage(ctx context.Context, req *schema.GetRankHistoryReq) (
+	pageModel *pager.PageModel, err error) {
+	if plugin.RankAgentEnabled() {
+		return pager.NewPageModel(0, []string{}), nil
+	}
+	if err = rs.fillRankHistoryActivityType(ctx, req); err != nil {
+		return nil, err
+	}
+	userRankPage, total, err := rs.userRankRepo.UserRankHistoryPage(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return pager.NewPageModel(total, rs.decorateRankHistoryResp(ctx, userRankPage)), nil
+}
+
+// ExportRankHistoryCSV export the reputation history of user as csv
+func (rs *RankService) ExportRankHistoryCSV(ctx context.Context, req *schema.GetRankHistoryReq) (data []byte, err error) {
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+	_ = w.Write([]string{"created_at", "rank_type_key", "rank_type", "reputation", "object_type",
+		"object_id", "question_id", "answer_id", "title", "cancelled", "cancelled_at"})
+	if plugin.RankAgentEnabled() {
+		w.Flush()
+		return buf.Bytes(), w.Error()
+	}
+	if err = rs.fillRankHistoryActivityType(ctx, req); err != nil {
+		return nil, err
+	}
+
+	req.PageSize = 100
+	for req.Page = 1; (req.Page-1)*req.PageSize < schema.RankHistoryExportMaxRows; req.Page++ {
+		userRankPage, _, err := rs.userRankRepo.UserRankHistoryPage(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range rs.decorateRankHistoryResp(ctx, userRankPage) {
+			cancelledAt := ""
+			if item.Cancelled {
+				cancelledAt = time.Unix(item.CancelledAt, 0).UTC().Format(time.RFC3339)
+			}
+			_ = w.Write([]string{
+				time.Unix(item.CreatedAt, 0).UTC().Format(time.RFC3339),
+				item.RankTypeKey,
+				item.RankType,
+				strconv.Itoa(item.Reputation),
+				item.ObjectType,
+				item.ObjectID,
+				item.QuestionID,
+				item.AnswerID,
+				item.Title,
+				strconv.FormatBool(item.Cancelled),
+				cancelledAt,
+			})
+		}
+		if len(userRankPage) < req.PageSize {
+			break
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+func (rs *RankService) fillRankHistoryActivityType(ctx context.Context, req *schema.GetRankHistoryReq) (err error) {
+	if len(req.RankType) == 0 {
+		return nil
+	}
+	req.ActivityType, err = rs.configService.GetIDByKey(ctx, req.RankType)
+	if err != nil {
+		return errors.BadRequest(reason.RequestFormatError)
+	}
+	return nil
+}
+
+func (rs *RankService) decorateRankHistoryResp(
+	ctx context.Context, userRankPage []*entity.Activity) []*schema.GetRankHistoryResp {
+	resp := make([]*schema.GetRankHistoryResp, 0, len(userRankPage))
+	lang := handler.GetLangByCtx(ctx)
+	configKeys := make(map[int]string)
+
+	for _, userRankInfo := range userRankPage {
+		item := &schema.GetRankHistoryResp{
+			CreatedAt:  userRankInfo.CreatedAt.Unix(),
+			ObjectID:   userRankInfo.ObjectID,
+			Reputation: userRankInfo.Rank,
+			Cancelled:  userRankInfo.Cancelled == entity.ActivityCancelled,
+		}
+		if item.Cancelled {
+			item.CancelledAt = userRankInfo.CancelledAt.Unix()
+		}
+		key, ok := configKeys[userRankInfo.ActivityType]
+		if !ok {
+			if cfg, err := rs.configService.GetConfigByID(ctx, userRankInfo.ActivityType); err != nil {
+				log.Error(err)
+			} else {
+				key = cfg.Key
+			}
+			configKeys[userRankInfo.ActivityType] = key
+		}
+		item.RankTypeKey = key
+		item.RankType = translator.Tr(lang, activity_type.ActivityTypeFlagMapping[key])
+
+		// the object may be deleted, the history should be kept anyway
+		if len(userRankInfo.ObjectID) > 0 && userRankInfo.ObjectID != "0" {
+			if objInfo, err := rs.objectInfoService.GetInfo(ctx, userRankInfo.ObjectID); err != nil {
+				log.Error(err)
+			} else {
+				item.ObjectType = objInfo.ObjectType
+				item.Title = objInfo.Title
+				item.UrlTitle = htmltext.UrlTitle(objInfo.Title)
+				if objInfo.QuestionStatus == entity.QuestionStatusDeleted {
+					item.Title = translator.Tr(lang, constant.DeletedQuestionTitleTrKey)
+				}
+				item.QuestionID = objInfo.QuestionID
+				item.AnswerID = objInfo.AnswerID
+			}
+		}
+		resp = append(resp, item)
+	}
+	return resp
+}
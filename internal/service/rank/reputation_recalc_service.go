@@ -0,0 +1,207 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package rank
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/plugin"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// defaultReputationRecalcBatchSize the default number of users recalculated in one batch
+const defaultReputationRecalcBatchSize = 200
+
+// ReputationRecalcRepo reputation recalculation repository
+type ReputationRecalcRepo interface {
+	CountUsers(ctx context.Context, startUserID string, since time.Time) (total int64, err error)
+	GetUsers(ctx context.Context, startUserID string, since time.Time, limit int) (users []*entity.User, err error)
+	GetUsersActivityRankSum(ctx context.Context, userIDs []string) (rankMapping map[string]int, err error)
+	UpdateUserRank(ctx context.Context, userID string, rank int) (err error)
+}
+
+// ReputationRecalcService replay the reputation activities to rebuild the user reputation
+type ReputationRecalcService struct {
+	reputationRecalcRepo ReputationRecalcRepo
+	progress             *schema.ReputationRecalcProgress
+	lock                 sync.RWMutex
+}
+
+// NewReputationRecalcService new reputation recalculation service
+func NewReputationRecalcService(reputationRecalcRepo ReputationRecalcRepo) *ReputationRecalcService {
+	return &ReputationRecalcService{
+		reputationRecalcRepo: reputationRecalcRepo,
+		progress:             &schema.ReputationRecalcProgress{},
+	}
+}
+
+// GetProgress get the progress of the current or last recalculation
+func (rs *ReputationRecalcService) GetProgress(ctx context.Context) (resp *schema.ReputationRecalcProgress) {
+	rs.lock.RLock()
+	defer rs.lock.RUnlock()
+	progress := *rs.progress
+	return &progress
+}
+
+// StartRecalculate recalculate the user reputation in background
+func (rs *ReputationRecalcService) StartRecalculate(ctx context.Context, req *schema.ReputationRecalcReq) (err error) {
+	if err = rs.begin(req); err != nil {
+		return err
+	}
+	go func() {
+		if err := rs.recalculate(context.Background(), req, nil); err != nil {
+			log.Errorf("recalculate reputation failed: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Recalculate recalculate the user reputation, the onProgress is called after each batch
+func (rs *ReputationRecalcService) Recalculate(ctx context.Context, req *schema.ReputationRecalcReq,
+	onProgress func(progress *schema.ReputationRecalcProgress)) (resp *schema.ReputationRecalcProgress, err error) {
+	if err = rs.begin(req); err != nil {
+		return nil, err
+	}
+	err = rs.recalculate(ctx, req, onProgress)
+	return rs.GetProgress(ctx), err
+}
+
+func (rs *ReputationRecalcService) begin(req *schema.ReputationRecalcReq) (err error) {
+	// IMPORTANT: If user center enabled the rank agent, then the reputation is not managed by activities.
+	if plugin.RankAgentEnabled() {
+		return errors.BadRequest(reason.ReputationRecalcRankAgentEnabled)
+	}
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+	if rs.progress.Running {
+		return errors.BadRequest(reason.ReputationRecalcInProgress)
+	}
+	rs.progress = &schema.ReputationRecalcProgress{
+		Running:    true,
+		DryRun:     req.DryRun,
+		LastUserID: req.StartUserID,
+		StartedAt:  time.Now().Unix(),
+	}
+	return nil
+}
+
+func (rs *ReputationRecalcService) recalculate(ctx context.Context, req *schema.ReputationRecalcReq,
+	onProgress func(progress *schema.ReputationRecalcProgress)) (err error) {
+	defer func() {
+		rs.updateProgress(func(p *schema.ReputationRecalcProgress) {
+			p.Running = false
+			p.FinishedAt = time.Now().Unix()
+			if err != nil {
+				p.Error = err.Error()
+			}
+		})
+	}()
+
+	var since time.Time
+	if req.Since > 0 {
+		since = time.Unix(req.Since, 0)
+	}
+	batchSize := req.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultReputationRecalcBatchSize
+	}
+	lastUserID := req.StartUserID
+	if len(lastUserID) == 0 {
+		lastUserID = "0"
+	}
+
+	total, err := rs.reputationRecalcRepo.CountUsers(ctx, lastUserID, since)
+	if err != nil {
+		return err
+	}
+	rs.updateProgress(func(p *schema.ReputationRecalcProgress) { p.Total = total })
+	log.Infof("start to recalculate reputation of %d users", total)
+
+	for {
+		users, err := rs.reputationRecalcRepo.GetUsers(ctx, lastUserID, since, batchSize)
+		if err != nil {
+			return err
+		}
+		if len(users) == 0 {
+			break
+		}
+		userIDs := make([]string, 0, len(users))
+		for _, user := range users {
+			userIDs = append(userIDs, user.ID)
+		}
+		rankMapping, err := rs.reputationRecalcRepo.GetUsersActivityRankSum(ctx, userIDs)
+		if err != nil {
+			return err
+		}
+
+		var changed int64
+		for _, user := range users {
+			rank := calcUserRank(user, rankMapping[user.ID])
+			if rank == user.Rank {
+				continue
+			}
+			changed++
+			log.Infof("user %s reputation changed from %d to %d", user.ID, user.Rank, rank)
+			if req.DryRun {
+				continue
+			}
+			if err = rs.reputationRecalcRepo.UpdateUserRank(ctx, user.ID, rank); err != nil {
+				return err
+			}
+		}
+		lastUserID = users[len(users)-1].ID
+		rs.updateProgress(func(p *schema.ReputationRecalcProgress) {
+			p.Processed += int64(len(users))
+			p.Changed += changed
+			p.LastUserID = lastUserID
+		})
+		if onProgress != nil {
+			onProgress(rs.GetProgress(ctx))
+		}
+	}
+	log.Infof("recalculate reputation done, last user id: %s", lastUserID)
+	return nil
+}
+
+func (rs *ReputationRecalcService) updateProgress(fn func(progress *schema.ReputationRecalcProgress)) {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+	fn(rs.progress)
+}
+
+// calcUserRank the reputation is the sum of activities that are not cancelled.
+// The same as changing reputation, the activated user's reputation is at least 1.
+func calcUserRank(user *entity.User, activityRankSum int) (rank int) {
+	rank = activityRankSum
+	minRank := 0
+	if user.MailStatus == entity.EmailStatusAvailable {
+		minRank = 1
+	}
+	if rank < minRank {
+		rank = minRank
+	}
+	return rank
+}
@@ -0,0 +1,317 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package re_engagement
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/activity_common"
+	"github.com/apache/answer/internal/service/export"
+	questioncommon "github.com/apache/answer/internal/service/question_common"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/internal/service/user_notification_config"
+	"github.com/apache/answer/pkg/display"
+	"github.com/apache/answer/pkg/token"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+)
+
+// reEngagementUserBatchSize the number of inactive users checked in one batch
+const reEngagementUserBatchSize = 100
+
+// ReEngagementRepo re-engagement repository
+type ReEngagementRepo interface {
+	GetNewAnswerStats(ctx context.Context, since, until, questionCreatedBefore time.Time) (
+		stats []*entity.ReEngagementNewAnswerStat, err error)
+	GetInactiveUsers(ctx context.Context, campaign string, inactiveBefore, cooldownSince time.Time,
+		startUserID string, limit int) (users []*entity.User, err error)
+	GetSentUserIDs(ctx context.Context, campaign string, userIDs []string, since time.Time) (
+		sent map[string]bool, err error)
+	GetTagQuestionsSince(ctx context.Context, tagIDs []string, since time.Time, limit int) (
+		questions []*entity.Question, total int64, err error)
+	AddEmailRecord(ctx context.Context, record *entity.ReEngagementEmail) (err error)
+}
+
+// ReEngagementService email the users to bring them back by the campaigns configured by admin
+type ReEngagementService struct {
+	reEngagementRepo           ReEngagementRepo
+	siteInfoService            siteinfo_common.SiteInfoCommonService
+	userRepo                   usercommon.UserRepo
+	userNotificationConfigRepo user_notification_config.UserNotificationConfigRepo
+	followRepo                 activity_common.FollowRepo
+	questionRepo               questioncommon.QuestionRepo
+	emailService               *export.EmailService
+	running                    atomic.Bool
+}
+
+// NewReEngagementService new re-engagement service
+func NewReEngagementService(
+	reEngagementRepo ReEngagementRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	userRepo usercommon.UserRepo,
+	userNotificationConfigRepo user_notification_config.UserNotificationConfigRepo,
+	followRepo activity_common.FollowRepo,
+	questionRepo questioncommon.QuestionRepo,
+	emailService *export.EmailService,
+) *ReEngagementService {
+	return &ReEngagementService{
+		reEngagementRepo:           reEngagementRepo,
+		siteInfoService:            siteInfoService,
+		userRepo:                   userRepo,
+		userNotificationConfigRepo: userNotificationConfigRepo,
+		followRepo:                 followRepo,
+		questionRepo:               questionRepo,
+		emailService:               emailService,
+	}
+}
+
+// SendCron send the emails of enabled campaigns, each campaign sends at most MaxEmailsPerRun emails
+func (rs *ReEngagementService) SendCron(ctx context.Context) {
+	if !rs.running.CompareAndSwap(false, true) {
+		return
+	}
+	defer rs.running.Store(false)
+
+	conf, err := rs.siteInfoService.GetSiteReEngagement(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	now := time.Now()
+	if campaign := conf.GetCampaign(schema.ReEngagementCampaignNewAnswers); campaign != nil {
+		if err = rs.sendNewAnswers(ctx, campaign, now); err != nil {
+			log.Errorf("send re-engagement new answers emails failed: %v", err)
+		}
+	}
+	if campaign := conf.GetCampaign(schema.ReEngagementCampaignInactiveUser); campaign != nil {
+		if err = rs.sendInactiveUser(ctx, campaign, now); err != nil {
+			log.Errorf("send re-engagement inactive user emails failed: %v", err)
+		}
+	}
+}
+
+// sendNewAnswers email the authors whose questions older than the campaign days gained answers in the cooldown days
+func (rs *ReEngagementService) sendNewAnswers(ctx context.Context, campaign *schema.ReEngagementCampaign,
+	now time.Time) (err error) {
+	cooldownSince := now.AddDate(0, 0, -campaign.CooldownDays)
+	stats, err := rs.reEngagementRepo.GetNewAnswerStats(ctx, cooldownSince, now, now.AddDate(0, 0, -campaign.Days))
+	if err != nil {
+		return err
+	}
+	userStats := make(map[string][]*entity.ReEngagementNewAnswerStat)
+	userIDs := make([]string, 0)
+	for _, stat := range stats {
+		if _, ok := userStats[stat.UserID]; !ok {
+			userIDs = append(userIDs, stat.UserID)
+		}
+		userStats[stat.UserID] = append(userStats[stat.UserID], stat)
+	}
+	sort.Strings(userIDs)
+	sent, err := rs.reEngagementRepo.GetSentUserIDs(ctx, campaign.Type, userIDs, cooldownSince)
+	if err != nil {
+		return err
+	}
+	candidateIDs := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if !sent[userID] {
+			candidateIDs = append(candidateIDs, userID)
+		}
+	}
+	candidateIDs = candidateIDs[:min(len(candidateIDs), campaign.MaxEmailsPerRun)]
+	users, err := rs.getReachableUsers(ctx, candidateIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		list := userStats[user.ID]
+		sort.SliceStable(list, func(i, j int) bool {
+			return list[i].NewAnswerCount > list[j].NewAnswerCount
+		})
+		data := &schema.ReEngagementTemplateData{
+			DisplayName: user.DisplayName,
+			Days:        campaign.Days,
+			Total:       int64(len(list)),
+		}
+		for _, stat := range list[:min(len(list), schema.ReEngagementMaxSummaryQuestions)] {
+			question, exist, err := rs.questionRepo.GetQuestion(ctx, stat.QuestionID)
+			if err != nil {
+				return err
+			}
+			if !exist {
+				continue
+			}
+			data.Questions = append(data.Questions, &schema.ReEngagementQuestion{
+				Title:          question.Title,
+				URL:            rs.questionURL(ctx, question),
+				NewAnswerCount: stat.NewAnswerCount,
+			})
+		}
+		if len(data.Questions) == 0 {
+			continue
+		}
+		rs.send(ctx, campaign, user, data)
+	}
+	return nil
+}
+
+// sendInactiveUser email the users who have not logged in for the campaign days
+// with the new questions in their followed tags since their last login
+func (rs *ReEngagementService) sendInactiveUser(ctx context.Context, campaign *schema.ReEngagementCampaign,
+	now time.Time) (err error) {
+	inactiveBefore := now.AddDate(0, 0, -campaign.Days)
+	cooldownSince := now.AddDate(0, 0, -campaign.CooldownDays)
+	sentCount := 0
+	lastUserID := "0"
+	for sentCount < campaign.MaxEmailsPerRun {
+		batch, err := rs.reEngagementRepo.GetInactiveUsers(ctx, campaign.Type, inactiveBefore, cooldownSince,
+			lastUserID, reEngagementUserBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			break
+		}
+		lastUserID = batch[len(batch)-1].ID
+		userIDs := make([]string, 0, len(batch))
+		for _, user := range batch {
+			userIDs = append(userIDs, user.ID)
+		}
+		users, err := rs.getReachableUsers(ctx, userIDs)
+		if err != nil {
+			return err
+		}
+		for _, user := range users {
+			if sentCount >= campaign.MaxEmailsPerRun {
+				break
+			}
+			tagIDs, err := rs.followRepo.GetFollowIDs(ctx, user.ID, entity.Tag{}.TableName())
+			if err != nil {
+				return err
+			}
+			questions, total, err := rs.reEngagementRepo.GetTagQuestionsSince(ctx, tagIDs, user.LastLoginDate,
+				schema.ReEngagementMaxSummaryQuestions)
+			if err != nil {
+				return err
+			}
+			if total == 0 {
+				continue
+			}
+			data := &schema.ReEngagementTemplateData{
+				DisplayName: user.DisplayName,
+				Days:        campaign.Days,
+				Total:       total,
+			}
+			for _, question := range questions {
+				data.Questions = append(data.Questions, &schema.ReEngagementQuestion{
+					Title: question.Title,
+					URL:   rs.questionURL(ctx, question),
+				})
+			}
+			rs.send(ctx, campaign, user, data)
+			sentCount++
+		}
+	}
+	return nil
+}
+
+// getReachableUsers get the available users with verified email who do not opt out re-engagement emails
+func (rs *ReEngagementService) getReachableUsers(ctx context.Context, userIDs []string) (
+	users []*entity.User, err error) {
+	users = make([]*entity.User, 0, len(userIDs))
+	if len(userIDs) == 0 {
+		return users, nil
+	}
+	configs, err := rs.userNotificationConfigRepo.GetByUsersAndSource(ctx, userIDs, constant.ReEngagementSource)
+	if err != nil {
+		return nil, err
+	}
+	optOut := make(map[string]bool)
+	for _, config := range configs {
+		optOut[config.UserID] = true
+		for _, channel := range schema.NewNotificationChannelsFormJson(config.Channels) {
+			if channel.Key == constant.EmailChannel && channel.Enable {
+				optOut[config.UserID] = false
+			}
+		}
+	}
+	list, err := rs.userRepo.BatchGetByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range list {
+		if user.Status != entity.UserStatusAvailable || user.MailStatus != entity.EmailStatusAvailable ||
+			len(user.EMail) == 0 || optOut[user.ID] {
+			continue
+		}
+		users = append(users, user)
+	}
+	sort.SliceStable(users, func(i, j int) bool {
+		return users[i].ID < users[j].ID
+	})
+	return users, nil
+}
+
+func (rs *ReEngagementService) send(ctx context.Context, campaign *schema.ReEngagementCampaign, user *entity.User,
+	data *schema.ReEngagementTemplateData) {
+	codeContent := &schema.EmailCodeContent{
+		SourceType:               schema.UnsubscribeSourceType,
+		NotificationSources:      []constant.NotificationSource{constant.ReEngagementSource},
+		Email:                    user.EMail,
+		UserID:                   user.ID,
+		SkipValidationLatestCode: true,
+	}
+	// If receiver has set language, use it to send email.
+	if len(user.Language) > 0 {
+		ctx = context.WithValue(ctx, constant.AcceptLanguageFlag, i18n.Language(user.Language))
+	}
+	unsubscribeCode := token.GenerateToken()
+	title, body, err := rs.emailService.ReEngagementTemplate(ctx, campaign, data, unsubscribeCode)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	rs.emailService.SendAndSaveCodeWithTime(ctx, user.ID, user.EMail, title, body,
+		unsubscribeCode, codeContent.ToJSONString(), 7*24*time.Hour)
+	err = rs.reEngagementRepo.AddEmailRecord(ctx, &entity.ReEngagementEmail{UserID: user.ID, Campaign: campaign.Type})
+	if err != nil {
+		log.Error(err)
+	}
+}
+
+func (rs *ReEngagementService) questionURL(ctx context.Context, question *entity.Question) string {
+	siteGeneral, err := rs.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		log.Error(err)
+		return ""
+	}
+	permalink := constant.PermalinkQuestionID
+	if siteSeo, err := rs.siteInfoService.GetSiteSeo(ctx); err == nil {
+		permalink = siteSeo.Permalink
+	}
+	return display.QuestionURL(permalink, siteGeneral.SiteUrl, question.ID, question.Title)
+}
@@ -22,6 +22,8 @@ package report
 import (
 	"encoding/json"
 	"github.com/apache/answer/internal/service/event_queue"
+	"github.com/apache/answer/internal/service/notice_queue"
+	"strconv"
 
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/handler"
@@ -30,6 +32,7 @@ import (
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
 	answercommon "github.com/apache/answer/internal/service/answer_common"
+	"github.com/apache/answer/internal/service/audit_log"
 	"github.com/apache/answer/internal/service/comment_common"
 	"github.com/apache/answer/internal/service/config"
 	"github.com/apache/answer/internal/service/object_info"
@@ -42,6 +45,7 @@ import (
 	"github.com/apache/answer/pkg/obj"
 	"github.com/jinzhu/copier"
 	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/i18n"
 	"github.com/segmentfault/pacman/log"
 	"golang.org/x/net/context"
 )
@@ -57,6 +61,9 @@ type ReportService struct {
 	reportHandle      *report_handle.ReportHandle
 	configService     *config.ConfigService
 	eventQueueService event_queue.EventQueueService
+	auditLogService   *audit_log.AuditLogService
+
+	notificationQueueService notice_queue.NotificationQueueService
 }
 
 // NewReportService new report service
@@ -70,6 +77,8 @@ func NewReportService(
 	reportHandle *report_handle.ReportHandle,
 	configService *config.ConfigService,
 	eventQueueService event_queue.EventQueueService,
+	notificationQueueService notice_queue.NotificationQueueService,
+	auditLogService *audit_log.AuditLogService,
 ) *ReportService {
 	return &ReportService{
 		reportRepo:        reportRepo,
@@ -81,6 +90,9 @@ func NewReportService(
 		reportHandle:      reportHandle,
 		configService:     configService,
 		eventQueueService: eventQueueService,
+		auditLogService:   auditLogService,
+
+		notificationQueueService: notificationQueueService,
 	}
 }
 
@@ -113,9 +125,14 @@ func (rs *ReportService) AddReport(ctx context.Context, req *schema.AddReportReq
 		ObjectID:       req.ObjectID,
 		ObjectType:     objectTypeNumber,
 		ReportType:     req.ReportType,
+		Category:       req.Category,
 		Content:        req.Content,
+		Detail:         req.Detail,
 		Status:         entity.ReportStatusPending,
 	}
+	if len(report.Category) == 0 {
+		report.Category = entity.GetReportCategoryByReason(cf.Key)
+	}
 	err = rs.reportRepo.AddReport(ctx, report)
 	if err != nil {
 		return err
@@ -142,64 +159,193 @@ func (rs *ReportService) GetUnreviewedReportPostPage(ctx context.Context, req *s
 
 	resp := make([]*schema.GetReportListPageResp, 0)
 	for _, report := range reports {
-		info, err := rs.objectInfoService.GetUnreviewedRevisionInfo(ctx, report.ObjectID)
+		r, err := rs.convertReportResp(ctx, report, lang)
 		if err != nil {
 			log.Errorf("GetUnreviewedRevisionInfo failed, err: %v", err)
 			continue
 		}
+		resp = append(resp, r)
+	}
+	return pager.NewPageModel(total, resp), nil
+}
 
-		r := &schema.GetReportListPageResp{
-			FlagID:           report.ID,
-			CreatedAt:        info.CreatedAt,
-			ObjectID:         info.ObjectID,
-			ObjectType:       info.ObjectType,
-			QuestionID:       info.QuestionID,
-			AnswerID:         info.AnswerID,
-			CommentID:        info.CommentID,
-			Title:            info.Title,
-			UrlTitle:         htmltext.UrlTitle(info.Title),
-			OriginalText:     info.Content,
-			ParsedText:       info.Html,
-			AnswerCount:      info.AnswerCount,
-			AnswerAccepted:   info.AnswerAccepted,
-			Tags:             info.Tags,
-			SubmitAt:         report.CreatedAt.Unix(),
-			ObjectStatus:     info.Status,
-			ObjectShowStatus: info.ShowStatus,
-			ReasonContent:    report.Content,
-		}
+// GetReportPage get the report page filtered by status, category, object type and assignee
+func (rs *ReportService) GetReportPage(ctx context.Context, req *schema.GetReportPageReq) (
+	pageModel *pager.PageModel, err error) {
+	if !req.IsAdmin {
+		return pager.NewPageModel(0, make([]*schema.GetReportListPageResp, 0)), nil
+	}
+	dto := &schema.GetReportListPageDTO{
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		Status:     entity.ReportStatusPending,
+		Category:   req.Category,
+		ObjectType: constant.ObjectTypeStrMapping[req.ObjectType],
+	}
+	if len(req.Status) > 0 {
+		dto.Status = entity.ReportStatus[req.Status]
+	}
+	switch req.Assignee {
+	case "":
+	case "me":
+		dto.AssigneeID = req.UserID
+	case "none":
+		dto.AssigneeID = "0"
+	default:
+		dto.AssigneeID = req.Assignee
+	}
+	if dto.PageSize == 0 {
+		dto.PageSize = constant.DefaultPageSize
+	}
+	reports, total, err := rs.reportRepo.GetReportListPage(ctx, dto)
+	if err != nil {
+		return nil, err
+	}
 
-		// get user info
-		userInfo, exists, e := rs.commonUser.GetUserBasicInfoByID(ctx, info.ObjectCreatorUserID)
-		if e != nil {
-			log.Errorf("user not found by id: %s, err: %v", info.ObjectCreatorUserID, e)
+	lang := handler.GetLangByCtx(ctx)
+	resp := make([]*schema.GetReportListPageResp, 0, len(reports))
+	for _, report := range reports {
+		r, err := rs.convertReportResp(ctx, report, lang)
+		if err != nil {
+			log.Errorf("convert report %s failed, err: %v", report.ID, err)
+			continue
 		}
-		if exists {
-			_ = copier.Copy(&r.AuthorUserInfo, userInfo)
+		resp = append(resp, r)
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// AssignReport assign the pending report to the moderator, or unassign it if the assignee is empty
+func (rs *ReportService) AssignReport(ctx context.Context, req *schema.AssignReportReq) (err error) {
+	report, exist, err := rs.reportRepo.GetByID(ctx, req.FlagID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.NotFound(reason.ReportNotFound)
+	}
+	if report.Status != entity.ReportStatusPending {
+		return errors.BadRequest(reason.ReportAlreadyHandled)
+	}
+	if len(req.AssigneeID) == 0 {
+		req.AssigneeID = "0"
+	}
+	if report.AssigneeID == req.AssigneeID {
+		return nil
+	}
+	if err = rs.reportRepo.UpdateAssignee(ctx, report.ID, req.AssigneeID); err != nil {
+		return err
+	}
+	if req.AssigneeID == "0" || req.AssigneeID == req.UserID {
+		return nil
+	}
+	objectType, _ := obj.GetObjectTypeStrByObjectID(report.ObjectID)
+	rs.notificationQueueService.Send(ctx, &schema.NotificationMsg{
+		TriggerUserID:       req.UserID,
+		ReceiverUserID:      req.AssigneeID,
+		Type:                schema.NotificationTypeInbox,
+		ObjectID:            report.ObjectID,
+		ObjectType:          objectType,
+		NotificationAction:  constant.NotificationFlagAssignedToYou,
+		NoNeedPushAllFollow: true,
+		ExtraInfo:           map[string]string{"flag_id": report.ID},
+	})
+	return nil
+}
+
+// GetReportContext get the report with the reported object info, reason, author and submitter
+func (rs *ReportService) GetReportContext(ctx context.Context, reportID string) (
+	resp *schema.GetReportListPageResp, report *entity.Report, err error) {
+	report, exist, err := rs.reportRepo.GetByID(ctx, reportID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !exist {
+		return nil, nil, errors.NotFound(reason.ReportNotFound)
+	}
+	resp, err = rs.convertReportResp(ctx, report, handler.GetLangByCtx(ctx))
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, report, nil
+}
+
+func (rs *ReportService) convertReportResp(ctx context.Context, report *entity.Report, lang i18n.Language) (
+	r *schema.GetReportListPageResp, err error) {
+	info, err := rs.objectInfoService.GetUnreviewedRevisionInfo(ctx, report.ObjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	r = &schema.GetReportListPageResp{
+		FlagID:           report.ID,
+		CreatedAt:        info.CreatedAt,
+		ObjectID:         info.ObjectID,
+		ObjectType:       info.ObjectType,
+		QuestionID:       info.QuestionID,
+		AnswerID:         info.AnswerID,
+		CommentID:        info.CommentID,
+		Title:            info.Title,
+		UrlTitle:         htmltext.UrlTitle(info.Title),
+		OriginalText:     info.Content,
+		ParsedText:       info.Html,
+		AnswerCount:      info.AnswerCount,
+		AnswerAccepted:   info.AnswerAccepted,
+		Tags:             info.Tags,
+		SubmitAt:         report.CreatedAt.Unix(),
+		ObjectStatus:     info.Status,
+		ObjectShowStatus: info.ShowStatus,
+		ReasonContent:    report.Content,
+		Category:         report.Category,
+		Detail:           report.Detail,
+		Resolution:       report.Resolution,
+		ResolutionReason: report.ResolutionReason,
+	}
+	for status, value := range entity.ReportStatus {
+		if value == report.Status {
+			r.Status = status
 		}
+	}
+
+	// get user info
+	userInfo, exists, e := rs.commonUser.GetUserBasicInfoByID(ctx, info.ObjectCreatorUserID)
+	if e != nil {
+		log.Errorf("user not found by id: %s, err: %v", info.ObjectCreatorUserID, e)
+	}
+	if exists {
+		_ = copier.Copy(&r.AuthorUserInfo, userInfo)
+	}
 
-		// get submitter info
-		submitter, exists, e := rs.commonUser.GetUserBasicInfoByID(ctx, report.ReportedUserID)
+	// get submitter info
+	submitter, exists, e := rs.commonUser.GetUserBasicInfoByID(ctx, report.ReportedUserID)
+	if e != nil {
+		log.Errorf("user not found by id: %s, err: %v", info.ObjectCreatorUserID, e)
+	}
+	if exists {
+		_ = copier.Copy(&r.SubmitterUser, submitter)
+	}
+
+	if len(report.AssigneeID) > 0 && report.AssigneeID != "0" {
+		assignee, exists, e := rs.commonUser.GetUserBasicInfoByID(ctx, report.AssigneeID)
 		if e != nil {
-			log.Errorf("user not found by id: %s, err: %v", info.ObjectCreatorUserID, e)
+			log.Errorf("user not found by id: %s, err: %v", report.AssigneeID, e)
 		}
 		if exists {
-			_ = copier.Copy(&r.SubmitterUser, submitter)
+			_ = copier.Copy(&r.AssigneeUser, assignee)
 		}
+	}
 
-		if report.ReportType > 0 {
-			r.Reason = &schema.ReasonItem{ReasonType: report.ReportType}
-			cf, err := rs.configService.GetConfigByID(ctx, report.ReportType)
-			if err != nil {
-				log.Error(err)
-			} else {
-				_ = json.Unmarshal([]byte(cf.Value), r.Reason)
-				r.Reason.Translate(cf.Key, lang)
-			}
+	if report.ReportType > 0 {
+		r.Reason = &schema.ReasonItem{ReasonType: report.ReportType}
+		cf, err := rs.configService.GetConfigByID(ctx, report.ReportType)
+		if err != nil {
+			log.Error(err)
+		} else {
+			_ = json.Unmarshal([]byte(cf.Value), r.Reason)
+			r.Reason.Translate(cf.Key, lang)
 		}
-		resp = append(resp, r)
 	}
-	return pager.NewPageModel(total, resp), nil
+	return r, nil
 }
 
 // ReviewReport review report
@@ -216,16 +362,107 @@ func (rs *ReportService) ReviewReport(ctx context.Context, req *schema.ReviewRep
 		return nil
 	}
 
+	report.HandledBy = req.UserID
+	report.HandledOp = req.OperationType
+	report.Resolution = req.GetResolution()
+	report.ResolutionReason = req.ResolutionReason
+
 	// ignore this report
 	if req.OperationType == constant.ReportOperationIgnoreReport {
-		return rs.reportRepo.UpdateStatus(ctx, report.ID, entity.ReportStatusIgnore)
+		report.Status = entity.ReportStatusIgnore
+		if err = rs.reportRepo.UpdateHandleResult(ctx, report); err != nil {
+			return err
+		}
+		rs.recordResolution(ctx, report)
+		rs.notifyReporterResolution(ctx, report)
+		return nil
 	}
 
 	if err = rs.reportHandle.UpdateReportedObject(ctx, report, req); err != nil {
 		return
 	}
 
-	return rs.reportRepo.UpdateStatus(ctx, report.ID, entity.ReportStatusCompleted)
+	report.Status = entity.ReportStatusCompleted
+	if err = rs.reportRepo.UpdateHandleResult(ctx, report); err != nil {
+		return err
+	}
+	rs.recordResolution(ctx, report)
+	if constant.IsReportRemovalOperation(req.OperationType) {
+		rs.notifyAuthorContentRemoved(ctx, report, req.UserID)
+	}
+	rs.notifyReporterResolution(ctx, report)
+	return nil
+}
+
+// recordResolution record the handled report and the operation on the reported object in the audit log
+func (rs *ReportService) recordResolution(ctx context.Context, report *entity.Report) {
+	rs.auditLogService.Record(ctx, &schema.AuditLogRecord{
+		OperatorID: report.HandledBy,
+		Action:     entity.AuditLogActionReportResolve,
+		ObjectType: entity.AuditLogObjectTypeReport,
+		ObjectID:   report.ID,
+		Before:     map[string]any{"status": entity.ReportStatusPending},
+		After: map[string]any{
+			"status":            report.Status,
+			"object_id":         report.ObjectID,
+			"operation":         report.HandledOp,
+			"resolution":        report.Resolution,
+			"resolution_reason": report.ResolutionReason,
+		},
+	})
+}
+
+// notifyReporterResolution tell the reporter whether the flag was helpful or declined and why
+func (rs *ReportService) notifyReporterResolution(ctx context.Context, report *entity.Report) {
+	if report.UserID == report.HandledBy {
+		return
+	}
+	action := constant.NotificationYourFlagWasHelpful
+	if report.Resolution == entity.ReportResolutionDeclined {
+		action = constant.NotificationYourFlagWasDeclined
+	}
+	objectType, _ := obj.GetObjectTypeStrByObjectID(report.ObjectID)
+	rs.notificationQueueService.Send(ctx, &schema.NotificationMsg{
+		TriggerUserID:       report.HandledBy,
+		ReceiverUserID:      report.UserID,
+		Type:                schema.NotificationTypeInbox,
+		ObjectID:            report.ObjectID,
+		ObjectType:          objectType,
+		NotificationAction:  action,
+		NoNeedPushAllFollow: true,
+		ExtraInfo: map[string]string{
+			"flag_id":           report.ID,
+			"resolution":        report.Resolution,
+			"resolution_reason": report.ResolutionReason,
+		},
+	})
+}
+
+// notifyAuthorContentRemoved tell the author why the content was removed, so that the author can appeal
+func (rs *ReportService) notifyAuthorContentRemoved(ctx context.Context, report *entity.Report, moderatorID string) {
+	if len(report.ReportedUserID) == 0 || report.ReportedUserID == "0" {
+		return
+	}
+	extraInfo := map[string]string{
+		"flag_id":          report.ID,
+		"flag_reason_type": strconv.Itoa(report.ReportType),
+	}
+	if cf, err := rs.configService.GetConfigByID(ctx, report.ReportType); err == nil && cf != nil {
+		reasonItem := &schema.ReasonItem{}
+		_ = json.Unmarshal([]byte(cf.Value), reasonItem)
+		extraInfo["flag_reason"] = reasonItem.Name
+	}
+	objectType, _ := obj.GetObjectTypeStrByObjectID(report.ObjectID)
+	rs.notificationQueueService.Send(ctx, &schema.NotificationMsg{
+		TriggerUserID:       moderatorID,
+		ReceiverUserID:      report.ReportedUserID,
+		Type:                schema.NotificationTypeInbox,
+		ObjectID:            report.ObjectID,
+		ObjectType:          objectType,
+		NotificationAction:  constant.NotificationYourPostWasRemovedByFlag,
+		NoNeedPushAllFollow: true,
+		ExtraInfo:           extraInfo,
+	})
 }
 
 func (rs *ReportService) sendEvent(ctx context.Context,
@@ -0,0 +1,299 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package report_appeal
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/content"
+	"github.com/apache/answer/internal/service/notice_queue"
+	"github.com/apache/answer/internal/service/report"
+	"github.com/apache/answer/internal/service/report_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/obj"
+	"github.com/jinzhu/copier"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// ReportAppealRepo report appeal repository
+type ReportAppealRepo interface {
+	AddAppeal(ctx context.Context, appeal *entity.ReportAppeal) (err error)
+	GetByID(ctx context.Context, id string) (appeal *entity.ReportAppeal, exist bool, err error)
+	GetByReportID(ctx context.Context, reportID string) (appeal *entity.ReportAppeal, exist bool, err error)
+	GetPendingAppealPage(ctx context.Context, page, pageSize int, excludeModeratorID string) (
+		appeals []*entity.ReportAppeal, total int64, err error)
+	UpdateReviewResult(ctx context.Context, appeal *entity.ReportAppeal) (affected int64, err error)
+	GetAppealStats(ctx context.Context) (stats []*schema.ReportAppealStatsDTO, err error)
+}
+
+// ReportAppealService the content author can appeal the content removed by flag,
+// the appeal is reviewed by a moderator other than the one who handled the flag.
+type ReportAppealService struct {
+	reportAppealRepo         ReportAppealRepo
+	reportRepo               report_common.ReportRepo
+	reportService            *report.ReportService
+	questionService          *content.QuestionService
+	answerService            *content.AnswerService
+	userCommon               *usercommon.UserCommon
+	notificationQueueService notice_queue.NotificationQueueService
+}
+
+// NewReportAppealService new report appeal service
+func NewReportAppealService(
+	reportAppealRepo ReportAppealRepo,
+	reportRepo report_common.ReportRepo,
+	reportService *report.ReportService,
+	questionService *content.QuestionService,
+	answerService *content.AnswerService,
+	userCommon *usercommon.UserCommon,
+	notificationQueueService notice_queue.NotificationQueueService,
+) *ReportAppealService {
+	return &ReportAppealService{
+		reportAppealRepo:         reportAppealRepo,
+		reportRepo:               reportRepo,
+		reportService:            reportService,
+		questionService:          questionService,
+		answerService:            answerService,
+		userCommon:               userCommon,
+		notificationQueueService: notificationQueueService,
+	}
+}
+
+// AddAppeal the author appeal against the handled report
+func (rs *ReportAppealService) AddAppeal(ctx context.Context, req *schema.AddReportAppealReq) (err error) {
+	reportInfo, exist, err := rs.reportRepo.GetByID(ctx, req.FlagID)
+	if err != nil {
+		return err
+	}
+	if !exist || reportInfo.ReportedUserID != req.UserID {
+		return errors.BadRequest(reason.ReportNotFound)
+	}
+	if reportInfo.Status != entity.ReportStatusCompleted || !constant.IsReportRemovalOperation(reportInfo.HandledOp) {
+		return errors.BadRequest(reason.ReportCannotAppeal)
+	}
+
+	_, exist, err = rs.reportAppealRepo.GetByReportID(ctx, reportInfo.ID)
+	if err != nil {
+		return err
+	}
+	if exist {
+		return errors.BadRequest(reason.ReportAppealAlreadyExist)
+	}
+	return rs.reportAppealRepo.AddAppeal(ctx, &entity.ReportAppeal{
+		ReportID:    reportInfo.ID,
+		ObjectID:    reportInfo.ObjectID,
+		UserID:      req.UserID,
+		Content:     req.Content,
+		ModeratorID: reportInfo.HandledBy,
+		HandledOp:   reportInfo.HandledOp,
+		Status:      entity.ReportAppealStatusPending,
+	})
+}
+
+// GetAppeal get the appeal of the author
+func (rs *ReportAppealService) GetAppeal(ctx context.Context, req *schema.GetReportAppealReq) (
+	resp *schema.GetReportAppealResp, err error) {
+	appeal, exist, err := rs.reportAppealRepo.GetByReportID(ctx, req.FlagID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || appeal.UserID != req.UserID {
+		return nil, errors.NotFound(reason.ReportAppealNotFound)
+	}
+	return convertAppealResp(appeal), nil
+}
+
+// GetPendingAppealPage get the pending appeals, the appeals against the flags handled by current moderator are excluded
+func (rs *ReportAppealService) GetPendingAppealPage(ctx context.Context, req *schema.GetReportAppealPageReq) (
+	pageModel *pager.PageModel, err error) {
+	resp := make([]*schema.GetReportAppealPageResp, 0)
+	if !req.IsAdmin {
+		return pager.NewPageModel(0, resp), nil
+	}
+	appeals, total, err := rs.reportAppealRepo.GetPendingAppealPage(ctx, req.Page, req.PageSize, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	for _, appeal := range appeals {
+		item := &schema.GetReportAppealPageResp{GetReportAppealResp: *convertAppealResp(appeal)}
+		item.Flag, _, err = rs.reportService.GetReportContext(ctx, appeal.ReportID)
+		if err != nil {
+			log.Errorf("get report context failed, report id: %s, err: %v", appeal.ReportID, err)
+			continue
+		}
+		moderator, exist, err := rs.userCommon.GetUserBasicInfoByID(ctx, appeal.ModeratorID)
+		if err != nil {
+			log.Errorf("user not found by id: %s, err: %v", appeal.ModeratorID, err)
+		}
+		if exist {
+			_ = copier.Copy(&item.Moderator, moderator)
+		}
+		resp = append(resp, item)
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// ReviewAppeal review the appeal, if overturned the content will be restored
+func (rs *ReportAppealService) ReviewAppeal(ctx context.Context, req *schema.ReviewReportAppealReq) (err error) {
+	appeal, exist, err := rs.reportAppealRepo.GetByID(ctx, req.AppealID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.NotFound(reason.ReportAppealNotFound)
+	}
+	if appeal.Status != entity.ReportAppealStatusPending {
+		return errors.BadRequest(reason.ReportAppealAlreadyReviewed)
+	}
+	if appeal.ModeratorID == req.UserID {
+		return errors.Forbidden(reason.ReportAppealSameModerator)
+	}
+
+	appeal.ReviewerID = req.UserID
+	appeal.ReviewMsg = req.ReviewMsg
+	appeal.Status = entity.ReportAppealStatusUpheld
+	if req.Operation == schema.ReportAppealOperationOverturn {
+		appeal.Status = entity.ReportAppealStatusOverturned
+	}
+	action := constant.NotificationYourAppealWasRejected
+	if appeal.Status == entity.ReportAppealStatusOverturned {
+		if err = rs.restoreObject(ctx, appeal, req.UserID); err != nil {
+			return err
+		}
+		action = constant.NotificationYourAppealWasAccepted
+	}
+	affected, err := rs.reportAppealRepo.UpdateReviewResult(ctx, appeal)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.BadRequest(reason.ReportAppealAlreadyReviewed)
+	}
+	objectType, _ := obj.GetObjectTypeStrByObjectID(appeal.ObjectID)
+	rs.notificationQueueService.Send(ctx, &schema.NotificationMsg{
+		TriggerUserID:       req.UserID,
+		ReceiverUserID:      appeal.UserID,
+		Type:                schema.NotificationTypeInbox,
+		ObjectID:            appeal.ObjectID,
+		ObjectType:          objectType,
+		NotificationAction:  action,
+		NoNeedPushAllFollow: true,
+		ExtraInfo:           map[string]string{"flag_id": appeal.ReportID, "appeal_id": appeal.ID},
+	})
+	return nil
+}
+
+// GetAppealStats get the appeal outcomes of each moderator
+func (rs *ReportAppealService) GetAppealStats(ctx context.Context) (resp []*schema.GetReportAppealStatsResp, err error) {
+	stats, err := rs.reportAppealRepo.GetAppealStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.GetReportAppealStatsResp, 0)
+	mapping := make(map[string]*schema.GetReportAppealStatsResp)
+	for _, stat := range stats {
+		item, ok := mapping[stat.ModeratorID]
+		if !ok {
+			item = &schema.GetReportAppealStatsResp{}
+			moderator, exist, err := rs.userCommon.GetUserBasicInfoByID(ctx, stat.ModeratorID)
+			if err != nil {
+				log.Errorf("user not found by id: %s, err: %v", stat.ModeratorID, err)
+			}
+			if exist {
+				_ = copier.Copy(&item.Moderator, moderator)
+			}
+			mapping[stat.ModeratorID] = item
+			resp = append(resp, item)
+		}
+		item.AppealCount += stat.Count
+		switch stat.Status {
+		case entity.ReportAppealStatusPending:
+			item.PendingCount += stat.Count
+		case entity.ReportAppealStatusUpheld:
+			item.UpheldCount += stat.Count
+		case entity.ReportAppealStatusOverturned:
+			item.OverturnedCount += stat.Count
+		}
+	}
+	for _, item := range resp {
+		if reviewed := item.UpheldCount + item.OverturnedCount; reviewed > 0 {
+			item.OverturnRate = float64(item.OverturnedCount) / float64(reviewed)
+		}
+	}
+	return resp, nil
+}
+
+// restoreObject revert the operation of the handled report
+func (rs *ReportAppealService) restoreObject(ctx context.Context, appeal *entity.ReportAppeal, userID string) (err error) {
+	objectType, err := obj.GetObjectTypeStrByObjectID(appeal.ObjectID)
+	if err != nil {
+		return err
+	}
+	switch objectType {
+	case constant.QuestionObjectType:
+		switch appeal.HandledOp {
+		case constant.ReportOperationDeletePost:
+			err = rs.questionService.RecoverQuestion(ctx, &schema.QuestionRecoverReq{
+				QuestionID: appeal.ObjectID, UserID: userID})
+		case constant.ReportOperationUnlistPost:
+			err = rs.questionService.OperationQuestion(ctx, &schema.OperationQuestionReq{
+				ID: appeal.ObjectID, Operation: schema.QuestionOperationShow, UserID: userID})
+		case constant.ReportOperationClosePost:
+			err = rs.questionService.ReopenQuestion(ctx, &schema.ReopenQuestionReq{
+				QuestionID: appeal.ObjectID, UserID: userID})
+		}
+	case constant.AnswerObjectType:
+		if appeal.HandledOp == constant.ReportOperationDeletePost {
+			err = rs.answerService.RecoverAnswer(ctx, &schema.RecoverAnswerReq{
+				AnswerID: appeal.ObjectID, UserID: userID})
+		}
+	default:
+		log.Warnf("the %s can not be restored automatically, object id: %s", objectType, appeal.ObjectID)
+	}
+	return err
+}
+
+func convertAppealResp(appeal *entity.ReportAppeal) *schema.GetReportAppealResp {
+	resp := &schema.GetReportAppealResp{
+		AppealID:  appeal.ID,
+		FlagID:    appeal.ReportID,
+		ObjectID:  appeal.ObjectID,
+		Content:   appeal.Content,
+		HandledOp: appeal.HandledOp,
+		ReviewMsg: appeal.ReviewMsg,
+		CreatedAt: appeal.CreatedAt.Unix(),
+		UpdatedAt: appeal.UpdatedAt.Unix(),
+	}
+	switch appeal.Status {
+	case entity.ReportAppealStatusPending:
+		resp.Status = "pending"
+	case entity.ReportAppealStatusUpheld:
+		resp.Status = "upheld"
+	case entity.ReportAppealStatusOverturned:
+		resp.Status = "overturned"
+	}
+	return resp
+}
@@ -33,5 +33,7 @@ type ReportRepo interface {
 		reports []*entity.Report, total int64, err error)
 	GetByID(ctx context.Context, id string) (report *entity.Report, exist bool, err error)
 	UpdateStatus(ctx context.Context, id string, status int) (err error)
+	UpdateHandleResult(ctx context.Context, report *entity.Report) (err error)
+	UpdateAssignee(ctx context.Context, id, assigneeID string) (err error)
 	GetReportCount(ctx context.Context) (count int64, err error)
 }
@@ -0,0 +1,330 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package reputation_sync
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/role"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/log"
+)
+
+// reputationSyncBatchSize the number of users synced in one batch
+const reputationSyncBatchSize = 100
+
+// ReputationSyncRepo reputation sync repository
+type ReputationSyncRepo interface {
+	GetUpdatedUserIDs(ctx context.Context, start, end time.Time, lastUserID string, limit int) (userIDs []string, err error)
+	GetRoleUpdatedUserIDs(ctx context.Context, start, end time.Time) (userIDs []string, err error)
+	GetSyncRecords(ctx context.Context, userIDs []string) (records map[string]*entity.UserReputationSync, err error)
+	SaveSyncRecord(ctx context.Context, record *entity.UserReputationSync) (err error)
+}
+
+// ReputationSyncService propagate the reputation tier and role changes of users to external systems
+type ReputationSyncService struct {
+	reputationSyncRepo ReputationSyncRepo
+	siteInfoRepo       siteinfo_common.SiteInfoRepo
+	siteInfoService    siteinfo_common.SiteInfoCommonService
+	userRepo           usercommon.UserRepo
+	userRoleRelService *role.UserRoleRelService
+	roleService        *role.RoleService
+	httpClient         *http.Client
+}
+
+// NewReputationSyncService new reputation sync service
+func NewReputationSyncService(
+	reputationSyncRepo ReputationSyncRepo,
+	siteInfoRepo siteinfo_common.SiteInfoRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	userRepo usercommon.UserRepo,
+	userRoleRelService *role.UserRoleRelService,
+	roleService *role.RoleService,
+) *ReputationSyncService {
+	return &ReputationSyncService{
+		reputationSyncRepo: reputationSyncRepo,
+		siteInfoRepo:       siteInfoRepo,
+		siteInfoService:    siteInfoService,
+		userRepo:           userRepo,
+		userRoleRelService: userRoleRelService,
+		roleService:        roleService,
+		httpClient:         &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// SyncCron sync the users whose reputation or role changed since last sync.
+// If any change failed to send, the same period will be synced again next time,
+// and the changes that have been sent will not be sent again.
+func (rs *ReputationSyncService) SyncCron(ctx context.Context) {
+	conf, err := rs.siteInfoService.GetSiteReputationSync(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if !conf.Enabled {
+		return
+	}
+	state, err := rs.getState(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	var start time.Time
+	if state.LastSyncedAt > 0 {
+		start = time.Unix(state.LastSyncedAt, 0)
+	}
+	end := time.Now()
+
+	roleMapping, err := rs.roleService.GetRoleMapping(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	roleChangedUserIDs := make(map[string]bool)
+	if conf.SyncRoleChanges {
+		userIDs, err := rs.reputationSyncRepo.GetRoleUpdatedUserIDs(ctx, start, end)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		for _, userID := range userIDs {
+			roleChangedUserIDs[userID] = true
+		}
+	}
+
+	allSynced := true
+	lastUserID := "0"
+	for {
+		userIDs, err := rs.reputationSyncRepo.GetUpdatedUserIDs(ctx, start, end, lastUserID, reputationSyncBatchSize)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		if len(userIDs) == 0 {
+			break
+		}
+		for _, userID := range userIDs {
+			delete(roleChangedUserIDs, userID)
+		}
+		if !rs.syncUsers(ctx, conf, roleMapping, userIDs) {
+			allSynced = false
+		}
+		lastUserID = userIDs[len(userIDs)-1]
+	}
+	// the users whose role changed but the user info not changed
+	userIDs := make([]string, 0, len(roleChangedUserIDs))
+	for userID := range roleChangedUserIDs {
+		userIDs = append(userIDs, userID)
+	}
+	for i := 0; i < len(userIDs); i += reputationSyncBatchSize {
+		batch := userIDs[i:min(i+reputationSyncBatchSize, len(userIDs))]
+		if !rs.syncUsers(ctx, conf, roleMapping, batch) {
+			allSynced = false
+		}
+	}
+
+	if !allSynced {
+		log.Warnf("some reputation sync changes failed to send, they will be retried next time")
+		return
+	}
+	state.LastSyncedAt = end.Unix()
+	if err = rs.saveState(ctx, state); err != nil {
+		log.Error(err)
+	}
+}
+
+// syncUsers compare the users with the last synced records and send the changes, return true if all changes are sent
+func (rs *ReputationSyncService) syncUsers(ctx context.Context, conf *schema.SiteReputationSyncResp,
+	roleMapping map[int]*entity.Role, userIDs []string) (allSynced bool) {
+	users, err := rs.userRepo.BatchGetByID(ctx, userIDs)
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+	records, err := rs.reputationSyncRepo.GetSyncRecords(ctx, userIDs)
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+	userRoleMapping, err := rs.userRoleRelService.GetUserRoleRelMapping(ctx, userIDs)
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+
+	allSynced = true
+	for _, user := range users {
+		if user.Status == entity.UserStatusDeleted {
+			continue
+		}
+		record := records[user.ID]
+		if record == nil {
+			// the default role of new user is user, so there is no role change for them
+			record = &entity.UserReputationSync{UserID: user.ID, RoleID: role.RoleUserID}
+		}
+		roleID, ok := userRoleMapping[user.ID]
+		if !ok {
+			roleID = role.RoleUserID
+		}
+		event := &schema.ReputationSyncEvent{
+			Timestamp: time.Now().Unix(),
+			User: &schema.ReputationSyncUser{
+				ID:          user.ID,
+				Username:    user.Username,
+				DisplayName: user.DisplayName,
+				Email:       user.EMail,
+				Reputation:  user.Rank,
+			},
+		}
+		changed := len(record.ID) == 0
+
+		tier := conf.GetTier(user.Rank)
+		previousTier := conf.GetTierByName(record.Tier)
+		tierName := ""
+		if tier != nil {
+			tierName = tier.Name
+		}
+		if tierName != record.Tier {
+			event.Event = schema.ReputationSyncEventTierChanged
+			event.Tier = convertEventTier(tier)
+			event.PreviousTier = convertEventTier(previousTier)
+			webhookURL := conf.WebhookURL
+			if previousTier != nil && len(previousTier.WebhookURL) > 0 {
+				webhookURL = previousTier.WebhookURL
+			}
+			if tier != nil && len(tier.WebhookURL) > 0 {
+				webhookURL = tier.WebhookURL
+			}
+			if err := rs.send(ctx, webhookURL, conf.Secret, event); err != nil {
+				log.Errorf("send reputation tier change of user %s failed: %v", user.ID, err)
+				allSynced = false
+				continue
+			}
+			record.Tier = tierName
+			changed = true
+		}
+
+		if roleID != record.RoleID {
+			if conf.SyncRoleChanges {
+				event.Event = schema.ReputationSyncEventRoleChanged
+				event.Tier, event.PreviousTier = convertEventTier(tier), nil
+				event.Role = getRoleName(roleMapping, roleID)
+				event.PreviousRole = getRoleName(roleMapping, record.RoleID)
+				if err := rs.send(ctx, conf.WebhookURL, conf.Secret, event); err != nil {
+					log.Errorf("send role change of user %s failed: %v", user.ID, err)
+					allSynced = false
+					if changed {
+						rs.saveRecord(ctx, record)
+					}
+					continue
+				}
+			}
+			record.RoleID = roleID
+			changed = true
+		}
+		if changed {
+			rs.saveRecord(ctx, record)
+		}
+	}
+	return allSynced
+}
+
+func (rs *ReputationSyncService) send(ctx context.Context, webhookURL, secret string,
+	event *schema.ReputationSyncEvent) (err error) {
+	if len(webhookURL) == 0 {
+		return nil
+	}
+	body, _ := json.Marshal(event)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Answer/"+constant.Version)
+	if len(secret) > 0 {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		req.Header.Set(schema.ReputationSyncSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+	resp, err := rs.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook response status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (rs *ReputationSyncService) saveRecord(ctx context.Context, record *entity.UserReputationSync) {
+	if err := rs.reputationSyncRepo.SaveSyncRecord(ctx, record); err != nil {
+		log.Error(err)
+	}
+}
+
+func (rs *ReputationSyncService) getState(ctx context.Context) (state *schema.ReputationSyncState, err error) {
+	state = &schema.ReputationSyncState{}
+	siteInfo, exist, err := rs.siteInfoRepo.GetByType(ctx, constant.SiteTypeReputationSyncState)
+	if err != nil || !exist {
+		return state, err
+	}
+	_ = json.Unmarshal([]byte(siteInfo.Content), state)
+	return state, nil
+}
+
+func (rs *ReputationSyncService) saveState(ctx context.Context, state *schema.ReputationSyncState) (err error) {
+	content, _ := json.Marshal(state)
+	return rs.siteInfoRepo.SaveByType(ctx, constant.SiteTypeReputationSyncState, &entity.SiteInfo{
+		Type:    constant.SiteTypeReputationSyncState,
+		Content: string(content),
+		Status:  1,
+	})
+}
+
+func convertEventTier(tier *schema.ReputationSyncTier) *schema.ReputationSyncEventTier {
+	if tier == nil {
+		return nil
+	}
+	return &schema.ReputationSyncEventTier{
+		Name:          tier.Name,
+		MinReputation: tier.MinReputation,
+		ExternalRole:  tier.ExternalRole,
+	}
+}
+
+func getRoleName(roleMapping map[int]*entity.Role, roleID int) string {
+	if r, ok := roleMapping[roleID]; ok {
+		return r.Name
+	}
+	return ""
+}
@@ -21,13 +21,17 @@ package review
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/pager"
 	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
 	answercommon "github.com/apache/answer/internal/service/answer_common"
+	"github.com/apache/answer/internal/service/comment_common"
+	"github.com/apache/answer/internal/service/content_language"
 	"github.com/apache/answer/internal/service/notice_queue"
 	"github.com/apache/answer/internal/service/object_info"
 	questioncommon "github.com/apache/answer/internal/service/question_common"
@@ -35,12 +39,15 @@ import (
 	"github.com/apache/answer/internal/service/siteinfo_common"
 	tagcommon "github.com/apache/answer/internal/service/tag_common"
 	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/converter"
+	"github.com/apache/answer/pkg/display"
 	"github.com/apache/answer/pkg/htmltext"
 	"github.com/apache/answer/pkg/token"
 	"github.com/apache/answer/pkg/uid"
 	"github.com/apache/answer/plugin"
 	"github.com/jinzhu/copier"
 	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/i18n"
 	"github.com/segmentfault/pacman/log"
 )
 
@@ -68,6 +75,9 @@ type ReviewService struct {
 	externalNotificationQueueService notice_queue.ExternalNotificationQueueService
 	notificationQueueService         notice_queue.NotificationQueueService
 	siteInfoService                  siteinfo_common.SiteInfoCommonService
+	contentLanguageService           *content_language.ContentLanguageService
+	commentCommonRepo                comment_common.CommentCommonRepo
+	commentApprovedHandler           func(ctx context.Context, comment *entity.Comment) error
 }
 
 // NewReviewService new review service
@@ -84,6 +94,8 @@ func NewReviewService(
 	questionCommon *questioncommon.QuestionCommon,
 	notificationQueueService notice_queue.NotificationQueueService,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
+	contentLanguageService *content_language.ContentLanguageService,
+	commentCommonRepo comment_common.CommentCommonRepo,
 ) *ReviewService {
 	return &ReviewService{
 		reviewRepo:                       reviewRepo,
@@ -98,12 +110,25 @@ func NewReviewService(
 		questionCommon:                   questionCommon,
 		notificationQueueService:         notificationQueueService,
 		siteInfoService:                  siteInfoService,
+		contentLanguageService:           contentLanguageService,
+		commentCommonRepo:                commentCommonRepo,
 	}
 }
 
+// RegisterCommentApprovedHandler register the handler sending the messages of the comment approved by the moderator,
+// the comment service depends on the review service so it registers itself
+func (cs *ReviewService) RegisterCommentApprovedHandler(handler func(ctx context.Context, comment *entity.Comment) error) {
+	cs.commentApprovedHandler = handler
+}
+
 // AddQuestionReview add review for question if needed
 func (cs *ReviewService) AddQuestionReview(ctx context.Context,
 	question *entity.Question, tags []*schema.TagItem, ip, ua string) (questionStatus int) {
+	languageResult := cs.contentLanguageService.Detect(ctx, question.Title+"\n"+htmltext.ClearText(question.ParsedText))
+	translated := false
+	if languageResult.NeedTranslate() {
+		translated = cs.translateQuestion(ctx, question, languageResult)
+	}
 	reviewContent := &plugin.ReviewContent{
 		ObjectType: constant.QuestionObjectType,
 		Title:      question.Title,
@@ -115,7 +140,9 @@ func (cs *ReviewService) AddQuestionReview(ctx context.Context,
 		reviewContent.Tags = append(reviewContent.Tags, tag.SlugName)
 	}
 	reviewContent.Author = cs.getReviewContentAuthorInfo(ctx, question.UserID)
-	reviewStatus := cs.callPluginToReview(ctx, question.UserID, question.ID, reviewContent)
+	reviewStatus := cs.callPluginToReview(ctx, question.UserID, question.ID, reviewContent,
+		cs.questionURL(ctx, question.ID, question.Title), cs.contentLanguageReviewReason(ctx, languageResult, translated))
+	cs.contentLanguageService.RecordStat(ctx, languageResult, reviewStatus == plugin.ReviewStatusNeedReview, translated)
 	switch reviewStatus {
 	case plugin.ReviewStatusApproved:
 		questionStatus = entity.QuestionStatusAvailable
@@ -132,6 +159,11 @@ func (cs *ReviewService) AddQuestionReview(ctx context.Context,
 // AddAnswerReview add review for answer if needed
 func (cs *ReviewService) AddAnswerReview(ctx context.Context,
 	answer *entity.Answer, ip, ua string) (answerStatus int) {
+	languageResult := cs.contentLanguageService.Detect(ctx, htmltext.ClearText(answer.ParsedText))
+	translated := false
+	if languageResult.NeedTranslate() {
+		translated = cs.translateAnswer(ctx, answer, languageResult)
+	}
 	reviewContent := &plugin.ReviewContent{
 		ObjectType: constant.AnswerObjectType,
 		Content:    answer.ParsedText,
@@ -139,7 +171,9 @@ func (cs *ReviewService) AddAnswerReview(ctx context.Context,
 		UserAgent:  ua,
 	}
 	reviewContent.Author = cs.getReviewContentAuthorInfo(ctx, answer.UserID)
-	reviewStatus := cs.callPluginToReview(ctx, answer.UserID, answer.ID, reviewContent)
+	reviewStatus := cs.callPluginToReview(ctx, answer.UserID, answer.ID, reviewContent,
+		cs.questionURL(ctx, answer.QuestionID, ""), cs.contentLanguageReviewReason(ctx, languageResult, translated))
+	cs.contentLanguageService.RecordStat(ctx, languageResult, reviewStatus == plugin.ReviewStatusNeedReview, translated)
 	switch reviewStatus {
 	case plugin.ReviewStatusApproved:
 		answerStatus = entity.AnswerStatusAvailable
@@ -153,6 +187,42 @@ func (cs *ReviewService) AddAnswerReview(ctx context.Context,
 	return answerStatus
 }
 
+// AddCommentReview add review for comment if needed, only the anti-spam plugins check the comment
+func (cs *ReviewService) AddCommentReview(ctx context.Context,
+	comment *entity.Comment, ip, ua string) (commentStatus int) {
+	reviewContent := &plugin.ReviewContent{
+		ObjectType: constant.CommentObjectType,
+		Content:    comment.ParsedText,
+		IP:         ip,
+		UserAgent:  ua,
+	}
+	r := cs.newReview(ctx, comment.UserID, comment.ID, reviewContent)
+	if !cs.callPluginToCheckSpam(ctx, comment.UserID, reviewContent, cs.questionURL(ctx, comment.QuestionID, ""), r) {
+		return entity.CommentStatusAvailable
+	}
+	if err := cs.reviewRepo.AddReview(ctx, r); err != nil {
+		log.Errorf("add review failed, err: %v", err)
+		return entity.CommentStatusAvailable
+	}
+	return entity.CommentStatusPending
+}
+
+// questionURL the url of the question, used as the permalink of the content checked by anti-spam plugins
+func (cs *ReviewService) questionURL(ctx context.Context, questionID, title string) string {
+	if len(questionID) == 0 || questionID == "0" {
+		return ""
+	}
+	siteGeneral, err := cs.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return ""
+	}
+	permalink := constant.PermalinkQuestionID
+	if siteSeo, err := cs.siteInfoService.GetSiteSeo(ctx); err == nil {
+		permalink = siteSeo.Permalink
+	}
+	return display.QuestionURL(permalink, siteGeneral.SiteUrl, questionID, title)
+}
+
 // get review content author info
 func (cs *ReviewService) getReviewContentAuthorInfo(ctx context.Context, userID string) (author plugin.ReviewContentAuthor) {
 	user, exist, err := cs.userCommon.GetUserBasicInfoByID(ctx, userID)
@@ -171,23 +241,89 @@ func (cs *ReviewService) getReviewContentAuthorInfo(ctx context.Context, userID
 	return
 }
 
-// call plugin to review
-func (cs *ReviewService) callPluginToReview(ctx context.Context, userID, objectID string,
-	reviewContent *plugin.ReviewContent) (reviewStatus plugin.ReviewStatus) {
-	// As default, no need review
-	reviewStatus = plugin.ReviewStatusApproved
-	objectID = uid.DeShortID(objectID)
+// contentLanguageReviewReason returns the reason if the post should be reviewed because of its language
+func (cs *ReviewService) contentLanguageReviewReason(ctx context.Context,
+	languageResult *schema.ContentLanguageResult, translated bool) string {
+	if languageResult == nil || languageResult.Allowed || translated {
+		return ""
+	}
+	return translator.TrWithData(cs.getSiteLang(ctx), reason.ContentLanguageNotAllowed,
+		map[string]string{"Language": languageResult.Language})
+}
 
-	r := &entity.Review{
+// translateQuestion translate the question to the site language, the original text is replaced
+func (cs *ReviewService) translateQuestion(ctx context.Context,
+	question *entity.Question, languageResult *schema.ContentLanguageResult) bool {
+	title, ok := cs.contentLanguageService.Translate(question.Title, languageResult.Language, languageResult.TargetLanguage)
+	if !ok {
+		return false
+	}
+	originalText, ok := cs.contentLanguageService.Translate(question.OriginalText, languageResult.Language, languageResult.TargetLanguage)
+	if !ok {
+		return false
+	}
+	question.Title = title
+	question.OriginalText = cs.appendTranslatedNote(ctx, originalText, languageResult)
+	question.ParsedText = converter.Markdown2HTML(question.OriginalText)
+	if err := cs.questionRepo.UpdateQuestion(ctx, question, []string{"title", "original_text", "parsed_text"}); err != nil {
+		log.Errorf("update translated question failed: %v", err)
+		return false
+	}
+	return true
+}
+
+// translateAnswer translate the answer to the site language, the original text is replaced
+func (cs *ReviewService) translateAnswer(ctx context.Context,
+	answer *entity.Answer, languageResult *schema.ContentLanguageResult) bool {
+	originalText, ok := cs.contentLanguageService.Translate(answer.OriginalText, languageResult.Language, languageResult.TargetLanguage)
+	if !ok {
+		return false
+	}
+	answer.OriginalText = cs.appendTranslatedNote(ctx, originalText, languageResult)
+	answer.ParsedText = converter.Markdown2HTML(answer.OriginalText)
+	if err := cs.answerRepo.UpdateAnswer(ctx, answer, []string{"original_text", "parsed_text"}); err != nil {
+		log.Errorf("update translated answer failed: %v", err)
+		return false
+	}
+	return true
+}
+
+func (cs *ReviewService) appendTranslatedNote(ctx context.Context,
+	text string, languageResult *schema.ContentLanguageResult) string {
+	note := translator.TrWithData(cs.getSiteLang(ctx), reason.ContentLanguageTranslated,
+		map[string]string{"Language": languageResult.Language})
+	return text + "\n\n*" + note + "*"
+}
+
+func (cs *ReviewService) getSiteLang(ctx context.Context) i18n.Language {
+	siteInterface, err := cs.siteInfoService.GetSiteInterface(ctx)
+	if err != nil {
+		return i18n.DefaultLanguage
+	}
+	return i18n.Language(siteInterface.Language)
+}
+
+// newReview new the pending review of the content
+func (cs *ReviewService) newReview(ctx context.Context, userID, objectID string,
+	reviewContent *plugin.ReviewContent) *entity.Review {
+	if siteInterface, _ := cs.siteInfoService.GetSiteInterface(ctx); siteInterface != nil {
+		reviewContent.Language = siteInterface.Language
+	}
+	return &entity.Review{
 		UserID:         userID,
-		ObjectID:       objectID,
+		ObjectID:       uid.DeShortID(objectID),
 		ObjectType:     constant.ObjectTypeStrMapping[reviewContent.ObjectType],
 		ReviewerUserID: "0",
 		Status:         entity.ReviewStatusPending,
 	}
-	if siteInterface, _ := cs.siteInfoService.GetSiteInterface(ctx); siteInterface != nil {
-		reviewContent.Language = siteInterface.Language
-	}
+}
+
+// call plugin to review, if the forceReviewReason is not empty, the post should be reviewed even if the plugins approve it
+func (cs *ReviewService) callPluginToReview(ctx context.Context, userID, objectID string,
+	reviewContent *plugin.ReviewContent, permalink, forceReviewReason string) (reviewStatus plugin.ReviewStatus) {
+	// As default, no need review
+	reviewStatus = plugin.ReviewStatusApproved
+	r := cs.newReview(ctx, userID, objectID, reviewContent)
 
 	_ = plugin.CallReviewer(func(reviewer plugin.Reviewer) error {
 		// If one of the reviewer plugin return false, then the review is not approved
@@ -201,6 +337,15 @@ func (cs *ReviewService) callPluginToReview(ctx context.Context, userID, objectI
 		}
 		return nil
 	})
+	// the spam is quarantined in the review queue
+	if reviewStatus == plugin.ReviewStatusApproved && cs.callPluginToCheckSpam(ctx, userID, reviewContent, permalink, r) {
+		reviewStatus = plugin.ReviewStatusNeedReview
+	}
+	if reviewStatus == plugin.ReviewStatusApproved && len(forceReviewReason) > 0 {
+		reviewStatus = plugin.ReviewStatusNeedReview
+		r.Reason = forceReviewReason
+		r.Submitter = constant.SiteTypeLanguageDetection
+	}
 
 	if reviewStatus == plugin.ReviewStatusNeedReview {
 		if err := cs.reviewRepo.AddReview(ctx, r); err != nil {
@@ -210,6 +355,67 @@ func (cs *ReviewService) callPluginToReview(ctx context.Context, userID, objectI
 	return reviewStatus
 }
 
+// callPluginToCheckSpam call the anti-spam plugins, the reason and submitter of review are set if the content is spam
+func (cs *ReviewService) callPluginToCheckSpam(ctx context.Context, userID string,
+	reviewContent *plugin.ReviewContent, permalink string, r *entity.Review) (spam bool) {
+	var spamContent *plugin.SpamContent
+	_ = plugin.CallAntiSpam(func(antiSpam plugin.AntiSpam) error {
+		if spam {
+			return nil
+		}
+		if spamContent == nil {
+			spamContent = &plugin.SpamContent{
+				ObjectType: reviewContent.ObjectType,
+				Title:      reviewContent.Title,
+				Content:    reviewContent.Content,
+				Permalink:  permalink,
+				Author:     cs.getSpamContentAuthor(ctx, userID),
+				Language:   reviewContent.Language,
+				UserAgent:  reviewContent.UserAgent,
+				IP:         reviewContent.IP,
+			}
+		}
+		result, err := antiSpam.CheckSpam(spamContent)
+		// the content is published if the anti-spam service is unavailable
+		if err != nil {
+			log.Errorf("anti-spam plugin %s check failed: %v", antiSpam.Info().SlugName, err)
+			return nil
+		}
+		if result == nil || !result.Spam {
+			return nil
+		}
+		spam = true
+		r.Submitter = antiSpam.Info().SlugName
+		r.Reason = result.Reason
+		if len(r.Reason) == 0 {
+			r.Reason = translator.TrWithData(cs.getSiteLang(ctx), reason.AntiSpamMarkedAsSpam, map[string]any{
+				"Plugin": antiSpam.Info().SlugName,
+				"Score":  fmt.Sprintf("%.2f", result.Score),
+			})
+		}
+		return nil
+	})
+	return spam
+}
+
+// getSpamContentAuthor get the author info of the content checked by anti-spam plugins
+func (cs *ReviewService) getSpamContentAuthor(ctx context.Context, userID string) (author plugin.SpamContentAuthor) {
+	user, exist, err := cs.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		log.Errorf("get user info failed, err: %v", err)
+		return
+	}
+	if !exist {
+		return
+	}
+	author.Username = user.Username
+	author.DisplayName = user.DisplayName
+	author.Email = user.EMail
+	author.Rank = user.Rank
+	author.Role, _ = cs.userRoleService.GetUserRole(ctx, userID)
+	return
+}
+
 // UpdateReview update review
 func (cs *ReviewService) UpdateReview(ctx context.Context, req *schema.UpdateReviewReq) (err error) {
 	review, exist, err := cs.reviewRepo.GetReview(ctx, req.ReviewID)
@@ -314,6 +520,27 @@ func (cs *ReviewService) updateObjectStatus(ctx context.Context, review *entity.
 				log.Errorf("update user answer count failed, err: %v", err)
 			}
 		}
+	case constant.CommentObjectType:
+		commentInfo, exist, err := cs.commentCommonRepo.GetCommentWithoutStatus(ctx, review.ObjectID)
+		if err != nil {
+			return err
+		}
+		if !exist {
+			return errors.BadRequest(reason.ObjectNotFound)
+		}
+		status := entity.CommentStatusDeleted
+		if isApprove {
+			status = entity.CommentStatusAvailable
+		}
+		if err := cs.commentCommonRepo.UpdateCommentStatus(ctx, commentInfo.ID, status); err != nil {
+			return err
+		}
+		if isApprove && cs.commentApprovedHandler != nil {
+			commentInfo.Status = status
+			if err := cs.commentApprovedHandler(ctx, commentInfo); err != nil {
+				log.Errorf("send approved comment messages failed, err: %v", err)
+			}
+		}
 	}
 	return
 }
@@ -348,6 +575,7 @@ func (cs *ReviewService) notificationAnswerTheQuestion(ctx context.Context,
 		ReceiverUserID: receiverUserInfo.ID,
 		ReceiverEmail:  receiverUserInfo.EMail,
 		ReceiverLang:   receiverUserInfo.Language,
+		TriggerUserID:  msg.TriggerUserID,
 	}
 	rawData := &schema.NewAnswerTemplateRawData{
 		QuestionTitle:   questionTitle,
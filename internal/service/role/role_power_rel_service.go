@@ -21,6 +21,8 @@ package role
 
 import (
 	"context"
+
+	"github.com/apache/answer/internal/service/permission"
 )
 
 // RolePowerRelRepo rolePowerRel repository
@@ -43,9 +45,27 @@ func NewRolePowerRelService(rolePowerRelRepo RolePowerRelRepo,
 	}
 }
 
-// GetRolePowerList get role power list
+// GetRolePowerList get role power list, the power groups are expanded to the powers they grant
 func (rs *RolePowerRelService) GetRolePowerList(ctx context.Context, roleID int) (powers []string, err error) {
-	return rs.rolePowerRelRepo.GetRolePowerTypeList(ctx, roleID)
+	powers, err = rs.rolePowerRelRepo.GetRolePowerTypeList(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+	return permission.ExpandPowers(powers), nil
+}
+
+// HasRolePower whether the role has the power
+func (rs *RolePowerRelService) HasRolePower(ctx context.Context, roleID int, power string) (has bool, err error) {
+	powers, err := rs.GetRolePowerList(ctx, roleID)
+	if err != nil {
+		return false, err
+	}
+	for _, p := range powers {
+		if p == power {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
 // GetUserPowerList get  list all
@@ -54,5 +74,5 @@ func (rs *RolePowerRelService) GetUserPowerList(ctx context.Context, userID stri
 	if err != nil {
 		return nil, err
 	}
-	return rs.rolePowerRelRepo.GetRolePowerTypeList(ctx, roleID)
+	return rs.GetRolePowerList(ctx, roleID)
 }
@@ -23,16 +23,17 @@ import (
 	"context"
 
 	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/base/translator"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
 	"github.com/jinzhu/copier"
+	"github.com/segmentfault/pacman/errors"
 )
 
 const (
-	// Since there is currently no need to edit roles to add roles and other operations,
-	// the current role information is translated directly.
-	// Later on, when the relevant ability is available, it can be adjusted by the user himself.
+	// The built-in roles can not be removed and their information is translated directly,
+	// the custom roles are composed of the powers chosen by the admin.
 
 	RoleUserID      = 1
 	RoleAdminID     = 2
@@ -55,19 +56,40 @@ const (
 type RoleRepo interface {
 	GetRoleAllList(ctx context.Context) (roles []*entity.Role, err error)
 	GetRoleAllMapping(ctx context.Context) (roleMapping map[int]*entity.Role, err error)
+	GetRole(ctx context.Context, roleID int) (role *entity.Role, exist bool, err error)
+	GetRoleByName(ctx context.Context, name string) (role *entity.Role, exist bool, err error)
+	AddRole(ctx context.Context, role *entity.Role, powers []string) (err error)
+	UpdateRole(ctx context.Context, role *entity.Role, powers []string) (err error)
+	RemoveRole(ctx context.Context, roleID int) (err error)
 }
 
 // RoleService user service
 type RoleService struct {
-	roleRepo RoleRepo
+	roleRepo         RoleRepo
+	powerRepo        PowerRepo
+	rolePowerRelRepo RolePowerRelRepo
+	userRoleRelRepo  UserRoleRelRepo
 }
 
-func NewRoleService(roleRepo RoleRepo) *RoleService {
+func NewRoleService(
+	roleRepo RoleRepo,
+	powerRepo PowerRepo,
+	rolePowerRelRepo RolePowerRelRepo,
+	userRoleRelRepo UserRoleRelRepo,
+) *RoleService {
 	return &RoleService{
-		roleRepo: roleRepo,
+		roleRepo:         roleRepo,
+		powerRepo:        powerRepo,
+		rolePowerRelRepo: rolePowerRelRepo,
+		userRoleRelRepo:  userRoleRelRepo,
 	}
 }
 
+// IsBuiltInRole whether the role is built-in
+func IsBuiltInRole(roleID int) bool {
+	return roleID == RoleUserID || roleID == RoleAdminID || roleID == RoleModeratorID
+}
+
 // GetRoleList get role list all
 func (rs *RoleService) GetRoleList(ctx context.Context) (resp []*schema.GetRoleResp, err error) {
 	roles, err := rs.roleRepo.GetRoleAllList(ctx)
@@ -81,9 +103,152 @@ func (rs *RoleService) GetRoleList(ctx context.Context) (resp []*schema.GetRoleR
 
 	resp = []*schema.GetRoleResp{}
 	_ = copier.Copy(&resp, roles)
+	for _, r := range resp {
+		r.BuiltIn = IsBuiltInRole(r.ID)
+	}
 	return
 }
 
+// GetPowerList get all the powers that can be granted to the role
+func (rs *RoleService) GetPowerList(ctx context.Context) (resp []*schema.GetPowerResp, err error) {
+	powers, err := rs.powerRepo.GetPowerList(ctx, &entity.Power{})
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.GetPowerResp, 0, len(powers))
+	for _, power := range powers {
+		resp = append(resp, &schema.GetPowerResp{
+			PowerType:   power.PowerType,
+			Name:        power.Name,
+			Description: power.Description,
+		})
+	}
+	return resp, nil
+}
+
+// GetRolePowers get the powers granted to the role
+func (rs *RoleService) GetRolePowers(ctx context.Context, req *schema.GetRolePowersReq) (
+	resp *schema.GetRolePowersResp, err error) {
+	_, exist, err := rs.roleRepo.GetRole(ctx, req.RoleID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.RoleNotFound)
+	}
+	powers, err := rs.rolePowerRelRepo.GetRolePowerTypeList(ctx, req.RoleID)
+	if err != nil {
+		return nil, err
+	}
+	return &schema.GetRolePowersResp{RoleID: req.RoleID, Powers: powers}, nil
+}
+
+// AddRole add custom role
+func (rs *RoleService) AddRole(ctx context.Context, req *schema.AddRoleReq) (resp *schema.AddRoleResp, err error) {
+	if err = rs.checkRoleName(ctx, 0, req.Name); err != nil {
+		return nil, err
+	}
+	if err = rs.checkPowers(ctx, req.Powers); err != nil {
+		return nil, err
+	}
+	role := &entity.Role{Name: req.Name, Description: req.Description}
+	if err = rs.roleRepo.AddRole(ctx, role, req.Powers); err != nil {
+		return nil, err
+	}
+	return &schema.AddRoleResp{ID: role.ID}, nil
+}
+
+// UpdateRole update role, the built-in roles keep their name and the powers of admin can not be modified
+func (rs *RoleService) UpdateRole(ctx context.Context, req *schema.UpdateRoleReq) (err error) {
+	if req.ID == RoleAdminID {
+		return errors.BadRequest(reason.RoleCannotModify)
+	}
+	role, exist, err := rs.roleRepo.GetRole(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.RoleNotFound)
+	}
+	if !IsBuiltInRole(role.ID) {
+		if err = rs.checkRoleName(ctx, role.ID, req.Name); err != nil {
+			return err
+		}
+		role.Name = req.Name
+		role.Description = req.Description
+	}
+	if err = rs.checkPowers(ctx, req.Powers); err != nil {
+		return err
+	}
+	return rs.roleRepo.UpdateRole(ctx, role, req.Powers)
+}
+
+// RemoveRole remove custom role which is not assigned to any user
+func (rs *RoleService) RemoveRole(ctx context.Context, req *schema.RemoveRoleReq) (err error) {
+	if IsBuiltInRole(req.ID) {
+		return errors.BadRequest(reason.RoleCannotRemove)
+	}
+	_, exist, err := rs.roleRepo.GetRole(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.RoleNotFound)
+	}
+	rels, err := rs.userRoleRelRepo.GetUserRoleRelListByRoleID(ctx, []int{req.ID})
+	if err != nil {
+		return err
+	}
+	if len(rels) > 0 {
+		return errors.BadRequest(reason.RoleInUse)
+	}
+	return rs.roleRepo.RemoveRole(ctx, req.ID)
+}
+
+// GetRoleAuditValue get the name, description and powers of the role, it is recorded in the audit log
+func (rs *RoleService) GetRoleAuditValue(ctx context.Context, roleID int) (value map[string]any, err error) {
+	role, exist, err := rs.roleRepo.GetRole(ctx, roleID)
+	if err != nil || !exist {
+		return nil, err
+	}
+	powers, err := rs.rolePowerRelRepo.GetRolePowerTypeList(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"name": role.Name, "description": role.Description, "powers": powers}, nil
+}
+
+func (rs *RoleService) checkRoleName(ctx context.Context, roleID int, name string) (err error) {
+	role, exist, err := rs.roleRepo.GetRoleByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if exist && role.ID != roleID {
+		return errors.BadRequest(reason.RoleNameDuplicate)
+	}
+	return nil
+}
+
+func (rs *RoleService) checkPowers(ctx context.Context, powers []string) (err error) {
+	if len(powers) == 0 {
+		return nil
+	}
+	allPowers, err := rs.powerRepo.GetPowerList(ctx, &entity.Power{})
+	if err != nil {
+		return err
+	}
+	powerMapping := make(map[string]bool, len(allPowers))
+	for _, power := range allPowers {
+		powerMapping[power.PowerType] = true
+	}
+	for _, power := range powers {
+		if !powerMapping[power] {
+			return errors.BadRequest(reason.RolePowerNotFound)
+		}
+	}
+	return nil
+}
+
 func (rs *RoleService) GetRoleMapping(ctx context.Context) (roleMapping map[int]*entity.Role, err error) {
 	return rs.roleRepo.GetRoleAllMapping(ctx)
 }
@@ -101,3 +266,16 @@ func (rs *RoleService) translateRole(ctx context.Context, role *entity.Role) {
 		role.Description = translator.Tr(handler.GetLangByCtx(ctx), trRoleDescriptionModerator)
 	}
 }
+
+// RoleNameTrKey get the translation key of the built-in role name
+func RoleNameTrKey(roleName string) string {
+	switch roleName {
+	case roleUserName:
+		return trRoleNameUser
+	case roleAdminName:
+		return trRoleNameAdmin
+	case roleModeratorName:
+		return trRoleNameModerator
+	}
+	return roleName
+}
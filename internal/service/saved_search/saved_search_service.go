@@ -0,0 +1,249 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package saved_search
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/content"
+	"github.com/apache/answer/internal/service/notice_queue"
+	"github.com/apache/answer/internal/service/space_common"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// savedSearchBatchSize the number of saved searches evaluated in one batch
+const savedSearchBatchSize = 100
+
+// SavedSearchRepo saved search repository
+type SavedSearchRepo interface {
+	AddSavedSearch(ctx context.Context, savedSearch *entity.SavedSearch) (err error)
+	UpdateSavedSearch(ctx context.Context, savedSearch *entity.SavedSearch) (err error)
+	UpdateLastCheckedAt(ctx context.Context, id string, lastCheckedAt time.Time) (err error)
+	RemoveSavedSearch(ctx context.Context, id, userID string) (err error)
+	GetSavedSearch(ctx context.Context, id string) (savedSearch *entity.SavedSearch, exist bool, err error)
+	GetUserSavedSearches(ctx context.Context, userID string) (savedSearches []*entity.SavedSearch, err error)
+	GetDueSavedSearches(ctx context.Context, startID string, dailyBefore time.Time, limit int) (
+		savedSearches []*entity.SavedSearch, err error)
+}
+
+// SavedSearchService the search queries saved by users, the new results are notified by the scheduler
+type SavedSearchService struct {
+	savedSearchRepo          SavedSearchRepo
+	searchService            *content.SearchService
+	notificationQueueService notice_queue.NotificationQueueService
+	spaceCommon              *space_common.SpaceCommon
+	running                  atomic.Bool
+}
+
+// NewSavedSearchService new saved search service
+func NewSavedSearchService(
+	savedSearchRepo SavedSearchRepo,
+	searchService *content.SearchService,
+	notificationQueueService notice_queue.NotificationQueueService,
+	spaceCommon *space_common.SpaceCommon,
+) *SavedSearchService {
+	return &SavedSearchService{
+		savedSearchRepo:          savedSearchRepo,
+		searchService:            searchService,
+		notificationQueueService: notificationQueueService,
+		spaceCommon:              spaceCommon,
+	}
+}
+
+// AddSavedSearch add saved search, only the results created after now are notified
+func (ss *SavedSearchService) AddSavedSearch(ctx context.Context, req *schema.AddSavedSearchReq) (
+	resp *schema.SavedSearchItem, err error) {
+	savedSearches, err := ss.savedSearchRepo.GetUserSavedSearches(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if len(savedSearches) >= schema.SavedSearchMaxPerUser {
+		return nil, errors.BadRequest(reason.SavedSearchLimitExceeded)
+	}
+	savedSearch := &entity.SavedSearch{
+		UserID:        req.UserID,
+		Name:          req.Name,
+		Query:         req.Query,
+		Frequency:     req.Frequency,
+		LastCheckedAt: time.Now(),
+	}
+	if err = ss.savedSearchRepo.AddSavedSearch(ctx, savedSearch); err != nil {
+		return nil, err
+	}
+	return formatSavedSearch(savedSearch), nil
+}
+
+// UpdateSavedSearch update saved search, if the query is changed, only the results created after now are notified
+func (ss *SavedSearchService) UpdateSavedSearch(ctx context.Context, req *schema.UpdateSavedSearchReq) (err error) {
+	savedSearch, exist, err := ss.savedSearchRepo.GetSavedSearch(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist || savedSearch.UserID != req.UserID {
+		return errors.BadRequest(reason.SavedSearchNotFound)
+	}
+	if savedSearch.Query != req.Query {
+		savedSearch.LastCheckedAt = time.Now()
+	}
+	savedSearch.Name = req.Name
+	savedSearch.Query = req.Query
+	savedSearch.Frequency = req.Frequency
+	return ss.savedSearchRepo.UpdateSavedSearch(ctx, savedSearch)
+}
+
+// RemoveSavedSearch remove saved search
+func (ss *SavedSearchService) RemoveSavedSearch(ctx context.Context, req *schema.RemoveSavedSearchReq) (err error) {
+	return ss.savedSearchRepo.RemoveSavedSearch(ctx, req.ID, req.UserID)
+}
+
+// GetSavedSearches get the saved searches of user
+func (ss *SavedSearchService) GetSavedSearches(ctx context.Context, req *schema.GetSavedSearchesReq) (
+	resp []*schema.SavedSearchItem, err error) {
+	savedSearches, err := ss.savedSearchRepo.GetUserSavedSearches(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.SavedSearchItem, 0, len(savedSearches))
+	for _, savedSearch := range savedSearches {
+		resp = append(resp, formatSavedSearch(savedSearch))
+	}
+	return resp, nil
+}
+
+// EvaluateCron evaluate the due saved searches, only the results created since the last checked time are notified,
+// the instant saved searches are evaluated every time and the daily saved searches once a day
+func (ss *SavedSearchService) EvaluateCron(ctx context.Context) {
+	if !ss.running.CompareAndSwap(false, true) {
+		return
+	}
+	defer ss.running.Store(false)
+
+	now := time.Now()
+	lastID := "0"
+	for {
+		savedSearches, err := ss.savedSearchRepo.GetDueSavedSearches(ctx, lastID, now.Add(-24*time.Hour),
+			savedSearchBatchSize)
+		if err != nil {
+			log.Errorf("get due saved searches failed: %v", err)
+			return
+		}
+		if len(savedSearches) == 0 {
+			return
+		}
+		lastID = savedSearches[len(savedSearches)-1].ID
+		for _, savedSearch := range savedSearches {
+			if err = ss.evaluate(ctx, savedSearch, now); err != nil {
+				log.Errorf("evaluate saved search %s failed: %v", savedSearch.ID, err)
+			}
+		}
+	}
+}
+
+// evaluate search the newest results of saved search and notify the ones created after the last checked time,
+// the checked time is taken before searching so the results created during the evaluation are notified next time
+func (ss *SavedSearchService) evaluate(ctx context.Context, savedSearch *entity.SavedSearch, now time.Time) (err error) {
+	dto := &schema.SearchDTO{
+		Query:  savedSearch.Query,
+		Page:   1,
+		Size:   schema.SavedSearchMaxMatches,
+		Order:  "newest",
+		UserID: savedSearch.UserID,
+	}
+	_, _ = dto.Check()
+	resp, err := ss.searchService.Search(ctx, dto)
+	if err != nil {
+		return err
+	}
+	matches := make([]*schema.SearchResult, 0, len(resp.SearchResults))
+	for _, result := range resp.SearchResults {
+		if result.Object == nil || result.Object.CreatedAtParsed <= savedSearch.LastCheckedAt.Unix() {
+			continue
+		}
+		// the user needn't be notified of the own posts
+		if result.Object.UserInfo != nil && result.Object.UserInfo.ID == savedSearch.UserID {
+			continue
+		}
+		matches = append(matches, result)
+	}
+	// the results are checked by the questions they belong to, the user may not view the private spaces
+	questionIDs := make([]string, 0, len(matches))
+	for _, match := range matches {
+		questionIDs = append(questionIDs, match.Object.QuestionID)
+	}
+	visible, err := ss.spaceCommon.GetVisibleQuestionIDs(ctx, questionIDs, savedSearch.UserID)
+	if err != nil {
+		return err
+	}
+	visibleMatches := make([]*schema.SearchResult, 0, len(matches))
+	for _, match := range matches {
+		if visible[match.Object.QuestionID] {
+			visibleMatches = append(visibleMatches, match)
+		}
+	}
+	matches = visibleMatches
+
+	if len(matches) > 0 {
+		if savedSearch.Frequency == entity.SavedSearchFrequencyDaily {
+			ss.notify(ctx, savedSearch, matches[0], constant.NotificationSavedSearchDigest, len(matches))
+		} else {
+			for _, match := range matches {
+				ss.notify(ctx, savedSearch, match, constant.NotificationSavedSearchMatched, 1)
+			}
+		}
+	}
+	return ss.savedSearchRepo.UpdateLastCheckedAt(ctx, savedSearch.ID, now)
+}
+
+func (ss *SavedSearchService) notify(ctx context.Context, savedSearch *entity.SavedSearch,
+	match *schema.SearchResult, action string, matchCount int) {
+	ss.notificationQueueService.Send(ctx, &schema.NotificationMsg{
+		TriggerUserID:       savedSearch.UserID,
+		ReceiverUserID:      savedSearch.UserID,
+		Type:                schema.NotificationTypeInbox,
+		ObjectID:            match.Object.ID,
+		ObjectType:          match.ObjectType,
+		NotificationAction:  action,
+		NoNeedPushAllFollow: true,
+		ExtraInfo: map[string]string{
+			"saved_search_id":   savedSearch.ID,
+			"saved_search_name": savedSearch.Name,
+			"match_count":       strconv.Itoa(matchCount),
+		},
+	})
+}
+
+func formatSavedSearch(savedSearch *entity.SavedSearch) *schema.SavedSearchItem {
+	return &schema.SavedSearchItem{
+		ID:            savedSearch.ID,
+		Name:          savedSearch.Name,
+		Query:         savedSearch.Query,
+		Frequency:     savedSearch.Frequency,
+		CreatedAt:     savedSearch.CreatedAt.Unix(),
+		LastCheckedAt: savedSearch.LastCheckedAt.Unix(),
+	}
+}
@@ -0,0 +1,745 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package scim
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/auth"
+	"github.com/apache/answer/internal/service/role"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/apache/answer/internal/service/user_admin"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/internal/service/user_external_login"
+	"github.com/apache/answer/pkg/checker"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// SCIMRepo SCIM repository
+type SCIMRepo interface {
+	GetUsers(ctx context.Context, offset, limit int) (users []*entity.User, total int64, err error)
+	GetExternalLogins(ctx context.Context, userIDs []string) (logins []*entity.UserExternalLogin, err error)
+}
+
+// SCIMService provisions the users and the roles for the identity provider.
+// The user is bound to the identity provider by the external login of the scim provider,
+// the user name of identity provider is the external id so that the username of site is never changed by it.
+type SCIMService struct {
+	scimRepo              SCIMRepo
+	userRepo              usercommon.UserRepo
+	userCommon            *usercommon.UserCommon
+	userAdminService      *user_admin.UserAdminService
+	userExternalLoginRepo user_external_login.UserExternalLoginRepo
+	userRoleRelService    *role.UserRoleRelService
+	roleService           *role.RoleService
+	authService           *auth.AuthService
+	siteInfoService       siteinfo_common.SiteInfoCommonService
+}
+
+// NewSCIMService new SCIM service
+func NewSCIMService(
+	scimRepo SCIMRepo,
+	userRepo usercommon.UserRepo,
+	userCommon *usercommon.UserCommon,
+	userAdminService *user_admin.UserAdminService,
+	userExternalLoginRepo user_external_login.UserExternalLoginRepo,
+	userRoleRelService *role.UserRoleRelService,
+	roleService *role.RoleService,
+	authService *auth.AuthService,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *SCIMService {
+	return &SCIMService{
+		scimRepo:              scimRepo,
+		userRepo:              userRepo,
+		userCommon:            userCommon,
+		userAdminService:      userAdminService,
+		userExternalLoginRepo: userExternalLoginRepo,
+		userRoleRelService:    userRoleRelService,
+		roleService:           roleService,
+		authService:           authService,
+		siteInfoService:       siteInfoService,
+	}
+}
+
+// CheckToken check the bearer token of the identity provider
+func (ss *SCIMService) CheckToken(ctx context.Context, token string) (err error) {
+	siteSCIM, err := ss.siteInfoService.GetSiteSCIM(ctx)
+	if err != nil {
+		return err
+	}
+	if !siteSCIM.Enabled || len(siteSCIM.Token) == 0 {
+		return errors.Forbidden(reason.SCIMDisabled)
+	}
+	if len(token) == 0 || subtle.ConstantTimeCompare([]byte(token), []byte(siteSCIM.Token)) != 1 {
+		return errors.Unauthorized(reason.UnauthorizedError)
+	}
+	return nil
+}
+
+// CreateUser create the user, the user with the same user name or email must not exist
+func (ss *SCIMService) CreateUser(ctx context.Context, req *schema.SCIMUser) (resp *schema.SCIMUser, err error) {
+	email, err := ss.checkUser(req)
+	if err != nil {
+		return nil, err
+	}
+	_, exist, err := ss.userExternalLoginRepo.GetByExternalID(ctx, schema.SCIMExternalLoginProvider, req.UserName)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		return nil, errors.Conflict(reason.UsernameDuplicate)
+	}
+	_, exist, err = ss.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		return nil, errors.Conflict(reason.EmailDuplicate)
+	}
+
+	userInfo := &entity.User{
+		EMail:       email,
+		DisplayName: req.GetDisplayName(),
+		MailStatus:  entity.EmailStatusAvailable,
+		Status:      entity.UserStatusAvailable,
+		Rank:        1,
+	}
+	if userInfo.Username, err = ss.makeUsername(ctx, req); err != nil {
+		return nil, err
+	}
+	if len(req.Password) > 0 {
+		hashPwd, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+		}
+		userInfo.Pass = string(hashPwd)
+	}
+	if err = ss.userRepo.AddUser(ctx, userInfo); err != nil {
+		return nil, err
+	}
+
+	meta := &schema.SCIMExternalLoginMeta{ExternalID: req.ExternalID}
+	metaInfo, _ := json.Marshal(meta)
+	login := &entity.UserExternalLogin{
+		UserID:     userInfo.ID,
+		Provider:   schema.SCIMExternalLoginProvider,
+		ExternalID: req.UserName,
+		MetaInfo:   string(metaInfo),
+	}
+	if err = ss.userExternalLoginRepo.AddUserExternalLogin(ctx, login); err != nil {
+		return nil, err
+	}
+	if !req.IsActive() {
+		if err = ss.setUserActive(ctx, userInfo, login, false); err != nil {
+			return nil, err
+		}
+	}
+	return ss.GetUser(ctx, userInfo.ID)
+}
+
+// GetUser get the user, the deleted users and bots are not found
+func (ss *SCIMService) GetUser(ctx context.Context, userID string) (resp *schema.SCIMUser, err error) {
+	userInfo, err := ss.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	users, err := ss.formatUsers(ctx, []*entity.User{userInfo})
+	if err != nil {
+		return nil, err
+	}
+	return users[0], nil
+}
+
+// GetUsers get the page of users, the filter of userName, emails.value and id are supported
+func (ss *SCIMService) GetUsers(ctx context.Context, req *schema.SCIMListReq) (resp *schema.SCIMListResponse, err error) {
+	req.FillDefault()
+	var users []*entity.User
+	var total int64
+	if len(req.Filter) == 0 {
+		users, total, err = ss.scimRepo.GetUsers(ctx, req.StartIndex-1, req.Count)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		userInfo, err := ss.filterUser(ctx, req.Filter)
+		if err != nil {
+			return nil, err
+		}
+		if userInfo != nil {
+			total = 1
+			if req.StartIndex == 1 {
+				users = append(users, userInfo)
+			}
+		}
+	}
+	resources, err := ss.formatUsers(ctx, users)
+	if err != nil {
+		return nil, err
+	}
+	return &schema.SCIMListResponse{
+		Schemas:      []string{schema.SCIMSchemaListResponse},
+		TotalResults: total,
+		StartIndex:   req.StartIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}, nil
+}
+
+// ReplaceUser replace the attributes of user
+func (ss *SCIMService) ReplaceUser(ctx context.Context, userID string, req *schema.SCIMUser) (resp *schema.SCIMUser, err error) {
+	userInfo, err := ss.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err = ss.updateUser(ctx, userInfo, req); err != nil {
+		return nil, err
+	}
+	return ss.GetUser(ctx, userID)
+}
+
+// PatchUser apply the patch operations to the current attributes of user
+func (ss *SCIMService) PatchUser(ctx context.Context, userID string, req *schema.SCIMPatchReq) (resp *schema.SCIMUser, err error) {
+	userInfo, err := ss.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	users, err := ss.formatUsers(ctx, []*entity.User{userInfo})
+	if err != nil {
+		return nil, err
+	}
+	user := users[0]
+	if err = user.ApplyPatch(req.Operations); err != nil {
+		return nil, err
+	}
+	if err = ss.updateUser(ctx, userInfo, user); err != nil {
+		return nil, err
+	}
+	return ss.GetUser(ctx, userID)
+}
+
+// DeleteUser delete the user, the content of user is kept
+func (ss *SCIMService) DeleteUser(ctx context.Context, userID string) (err error) {
+	if _, err = ss.getUser(ctx, userID); err != nil {
+		return err
+	}
+	err = ss.userAdminService.UpdateUserStatus(ctx, &schema.UpdateUserStatusReq{
+		UserID: userID,
+		Status: constant.UserDeleted,
+	})
+	if err != nil {
+		return err
+	}
+	ss.authService.RemoveUserAllTokens(ctx, userID)
+	return nil
+}
+
+// GetGroups get the roles as groups
+func (ss *SCIMService) GetGroups(ctx context.Context, req *schema.SCIMListReq) (resp *schema.SCIMListResponse, err error) {
+	req.FillDefault()
+	roleMapping, err := ss.roleService.GetRoleMapping(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var attr, value string
+	if len(req.Filter) > 0 {
+		if attr, value, err = schema.ParseSCIMFilter(req.Filter); err != nil {
+			return nil, err
+		}
+		if attr != "displayname" && attr != "id" {
+			return nil, errors.BadRequest(reason.SCIMFilterInvalid)
+		}
+	}
+	roleIDs := make([]int, 0, len(roleMapping))
+	for roleID := range roleMapping {
+		roleIDs = append(roleIDs, roleID)
+	}
+	sort.Ints(roleIDs)
+	groups := make([]*schema.SCIMGroup, 0)
+	for _, roleID := range roleIDs {
+		roleInfo := roleMapping[roleID]
+		if (attr == "displayname" && !strings.EqualFold(roleInfo.Name, value)) ||
+			(attr == "id" && strconv.Itoa(roleInfo.ID) != value) {
+			continue
+		}
+		group, err := ss.formatGroup(ctx, roleInfo)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	total := int64(len(groups))
+	if req.StartIndex-1 < len(groups) {
+		groups = groups[req.StartIndex-1 : min(len(groups), req.StartIndex-1+req.Count)]
+	} else {
+		groups = groups[:0]
+	}
+	return &schema.SCIMListResponse{
+		Schemas:      []string{schema.SCIMSchemaListResponse},
+		TotalResults: total,
+		StartIndex:   req.StartIndex,
+		ItemsPerPage: len(groups),
+		Resources:    groups,
+	}, nil
+}
+
+// GetGroup get the role as group
+func (ss *SCIMService) GetGroup(ctx context.Context, groupID string) (resp *schema.SCIMGroup, err error) {
+	roleInfo, err := ss.getRole(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	return ss.formatGroup(ctx, roleInfo)
+}
+
+// PatchGroup assign the role to the added members, the removed members are reset to the user role
+func (ss *SCIMService) PatchGroup(ctx context.Context, groupID string, req *schema.SCIMPatchReq) (resp *schema.SCIMGroup, err error) {
+	roleInfo, err := ss.getRole(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	patch, err := schema.ParseSCIMGroupMemberPatch(req.Operations)
+	if err != nil {
+		return nil, err
+	}
+	if err = ss.updateGroupMembers(ctx, roleInfo.ID, patch); err != nil {
+		return nil, err
+	}
+	return ss.formatGroup(ctx, roleInfo)
+}
+
+// ReplaceGroup replace the members of group, the display name of role cannot be changed
+func (ss *SCIMService) ReplaceGroup(ctx context.Context, groupID string, req *schema.SCIMGroup) (resp *schema.SCIMGroup, err error) {
+	roleInfo, err := ss.getRole(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	patch := &schema.SCIMGroupMemberPatch{Replace: true}
+	for _, member := range req.Members {
+		patch.Members = append(patch.Members, member.Value)
+	}
+	if err = ss.updateGroupMembers(ctx, roleInfo.ID, patch); err != nil {
+		return nil, err
+	}
+	return ss.formatGroup(ctx, roleInfo)
+}
+
+func (ss *SCIMService) updateGroupMembers(ctx context.Context, roleID int, patch *schema.SCIMGroupMemberPatch) (err error) {
+	add, remove := patch.Add, patch.Remove
+	if patch.Replace {
+		add = patch.Members
+		keep := make(map[string]bool, len(patch.Members))
+		for _, memberID := range patch.Members {
+			keep[memberID] = true
+		}
+		rels, err := ss.userRoleRelService.GetUserByRoleID(ctx, []int{roleID})
+		if err != nil {
+			return err
+		}
+		for _, rel := range rels {
+			if !keep[rel.UserID] {
+				remove = append(remove, rel.UserID)
+			}
+		}
+	}
+	for _, userID := range remove {
+		currentRoleID, err := ss.userRoleRelService.GetUserRole(ctx, userID)
+		if err != nil {
+			return err
+		}
+		// the user has been assigned to another role
+		if currentRoleID != roleID || roleID == role.RoleUserID {
+			continue
+		}
+		if err = ss.saveUserRole(ctx, userID, role.RoleUserID); err != nil {
+			return err
+		}
+	}
+	for _, userID := range add {
+		if _, err = ss.getUser(ctx, userID); err != nil {
+			return err
+		}
+		if err = ss.saveUserRole(ctx, userID, roleID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ss *SCIMService) saveUserRole(ctx context.Context, userID string, roleID int) (err error) {
+	currentRoleID, err := ss.userRoleRelService.GetUserRole(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if currentRoleID == roleID {
+		return nil
+	}
+	if err = ss.userRoleRelService.SaveUserRole(ctx, userID, roleID); err != nil {
+		return err
+	}
+	// the permissions are cached with the tokens
+	ss.authService.RemoveUserAllTokens(ctx, userID)
+	return nil
+}
+
+func (ss *SCIMService) updateUser(ctx context.Context, userInfo *entity.User, req *schema.SCIMUser) (err error) {
+	email, err := ss.checkUser(req)
+	if err != nil {
+		return err
+	}
+	login, err := ss.getOrBindExternalLogin(ctx, userInfo)
+	if err != nil {
+		return err
+	}
+	if login.ExternalID != req.UserName {
+		bound, exist, err := ss.userExternalLoginRepo.GetByExternalID(ctx, schema.SCIMExternalLoginProvider, req.UserName)
+		if err != nil {
+			return err
+		}
+		if exist && bound.UserID != userInfo.ID {
+			return errors.Conflict(reason.UsernameDuplicate)
+		}
+	}
+	if email != userInfo.EMail {
+		emailUser, exist, err := ss.userRepo.GetByEmail(ctx, email)
+		if err != nil {
+			return err
+		}
+		if exist && emailUser.ID != userInfo.ID {
+			return errors.Conflict(reason.EmailDuplicate)
+		}
+	}
+
+	displayName := req.GetDisplayName()
+	if displayName != userInfo.DisplayName || email != userInfo.EMail {
+		userInfo.DisplayName = displayName
+		userInfo.EMail = email
+		userInfo.MailStatus = entity.EmailStatusAvailable
+		if err = ss.userRepo.UpdateUserProfile(ctx, userInfo); err != nil {
+			return err
+		}
+	}
+	if len(req.Password) > 0 {
+		hashPwd, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+		}
+		if err = ss.userRepo.UpdatePass(ctx, userInfo.ID, string(hashPwd)); err != nil {
+			return err
+		}
+		ss.authService.RemoveUserAllTokens(ctx, userInfo.ID)
+	}
+
+	meta := ss.getExternalLoginMeta(login)
+	if login.ExternalID != req.UserName || meta.ExternalID != req.ExternalID {
+		meta.ExternalID = req.ExternalID
+		metaInfo, _ := json.Marshal(meta)
+		login.ExternalID = req.UserName
+		login.MetaInfo = string(metaInfo)
+		if err = ss.userExternalLoginRepo.UpdateInfo(ctx, login); err != nil {
+			return err
+		}
+	}
+	return ss.setUserActive(ctx, userInfo, login, req.IsActive())
+}
+
+// setUserActive suspend the user forever when deactivated, the user suspended by the admin is not restored when activated
+func (ss *SCIMService) setUserActive(ctx context.Context, userInfo *entity.User, login *entity.UserExternalLogin, active bool) (err error) {
+	meta := ss.getExternalLoginMeta(login)
+	status := ""
+	switch {
+	case !active && userInfo.Status != entity.UserStatusSuspended:
+		status = constant.UserSuspended
+	case active && userInfo.Status == entity.UserStatusSuspended && meta.Deactivated:
+		status = constant.UserNormal
+	}
+	if len(status) == 0 {
+		return nil
+	}
+	err = ss.userAdminService.UpdateUserStatus(ctx, &schema.UpdateUserStatusReq{
+		UserID:          userInfo.ID,
+		Status:          status,
+		SuspendDuration: "forever",
+	})
+	if err != nil {
+		return err
+	}
+	if !active {
+		ss.authService.RemoveUserAllTokens(ctx, userInfo.ID)
+	}
+
+	meta.Deactivated = !active
+	metaInfo, _ := json.Marshal(meta)
+	login.MetaInfo = string(metaInfo)
+	return ss.userExternalLoginRepo.UpdateInfo(ctx, login)
+}
+
+// getOrBindExternalLogin get the SCIM binding of user, the user created before is bound by the email as user name
+func (ss *SCIMService) getOrBindExternalLogin(ctx context.Context, userInfo *entity.User) (
+	login *entity.UserExternalLogin, err error) {
+	login, exist, err := ss.userExternalLoginRepo.GetByUserID(ctx, schema.SCIMExternalLoginProvider, userInfo.ID)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		return login, nil
+	}
+	metaInfo, _ := json.Marshal(&schema.SCIMExternalLoginMeta{})
+	login = &entity.UserExternalLogin{
+		UserID:     userInfo.ID,
+		Provider:   schema.SCIMExternalLoginProvider,
+		ExternalID: userInfo.EMail,
+		MetaInfo:   string(metaInfo),
+	}
+	if err = ss.userExternalLoginRepo.AddUserExternalLogin(ctx, login); err != nil {
+		return nil, err
+	}
+	return login, nil
+}
+
+func (ss *SCIMService) getExternalLoginMeta(login *entity.UserExternalLogin) (meta *schema.SCIMExternalLoginMeta) {
+	meta = &schema.SCIMExternalLoginMeta{}
+	if len(login.MetaInfo) == 0 {
+		return meta
+	}
+	if err := json.Unmarshal([]byte(login.MetaInfo), meta); err != nil {
+		log.Warnf("parse scim external login meta info of user %s failed: %v", login.UserID, err)
+	}
+	return meta
+}
+
+// filterUser find the user matched the filter, the user created before is matched by the email as user name
+func (ss *SCIMService) filterUser(ctx context.Context, filter string) (userInfo *entity.User, err error) {
+	attr, value, err := schema.ParseSCIMFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		exist, bound bool
+		login        *entity.UserExternalLogin
+	)
+	switch attr {
+	case "id":
+		userInfo, exist, err = ss.userRepo.GetByUserID(ctx, value)
+	case "username":
+		login, bound, err = ss.userExternalLoginRepo.GetByExternalID(ctx, schema.SCIMExternalLoginProvider, value)
+		if err != nil {
+			return nil, err
+		}
+		if bound {
+			userInfo, exist, err = ss.userRepo.GetByUserID(ctx, login.UserID)
+		} else if _, parseErr := mail.ParseAddress(value); parseErr == nil {
+			userInfo, exist, err = ss.userRepo.GetByEmail(ctx, value)
+			if err == nil && exist {
+				// the user bound to another user name is not matched by the email
+				_, bound, err = ss.userExternalLoginRepo.GetByUserID(ctx, schema.SCIMExternalLoginProvider, userInfo.ID)
+				exist = !bound
+			}
+		}
+	case "emails.value", "emails":
+		userInfo, exist, err = ss.userRepo.GetByEmail(ctx, value)
+	default:
+		return nil, errors.BadRequest(reason.SCIMFilterInvalid)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !exist || userInfo.Status == entity.UserStatusDeleted || userInfo.UserType == entity.UserTypeBot {
+		return nil, nil
+	}
+	return userInfo, nil
+}
+
+func (ss *SCIMService) getUser(ctx context.Context, userID string) (userInfo *entity.User, err error) {
+	userInfo, exist, err := ss.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || userInfo.Status == entity.UserStatusDeleted || userInfo.UserType == entity.UserTypeBot {
+		return nil, errors.NotFound(reason.UserNotFound)
+	}
+	return userInfo, nil
+}
+
+func (ss *SCIMService) getRole(ctx context.Context, groupID string) (roleInfo *entity.Role, err error) {
+	roleMapping, err := ss.roleService.GetRoleMapping(ctx)
+	if err != nil {
+		return nil, err
+	}
+	roleID, _ := strconv.Atoi(groupID)
+	roleInfo, ok := roleMapping[roleID]
+	if !ok {
+		return nil, errors.NotFound(reason.SCIMGroupNotFound)
+	}
+	return roleInfo, nil
+}
+
+// checkUser check the user name and email of the user, the email is returned
+func (ss *SCIMService) checkUser(req *schema.SCIMUser) (email string, err error) {
+	req.UserName = strings.TrimSpace(req.UserName)
+	email = strings.TrimSpace(req.GetEmail())
+	if len(email) == 0 {
+		// the user name of most identity providers is the email
+		email = req.UserName
+	}
+	if len(req.UserName) == 0 || len(req.UserName) > 128 || len(email) > 100 {
+		return "", errors.BadRequest(reason.SCIMUserInvalid)
+	}
+	if _, err = mail.ParseAddress(email); err != nil {
+		return "", errors.BadRequest(reason.SCIMUserInvalid)
+	}
+	return email, nil
+}
+
+// makeUsername make the username from the user name, the local part of email or the display name
+func (ss *SCIMService) makeUsername(ctx context.Context, req *schema.SCIMUser) (username string, err error) {
+	candidates := []string{req.UserName}
+	if i := strings.Index(req.UserName, "@"); i > 0 {
+		candidates = append(candidates, req.UserName[:i])
+	}
+	candidates = append(candidates, req.GetDisplayName(), "user")
+	for _, candidate := range candidates {
+		if checker.IsInvalidUsername(strings.ToLower(strings.ReplaceAll(candidate, " ", "-"))) {
+			continue
+		}
+		username, err = ss.userCommon.MakeUsername(ctx, candidate)
+		if err == nil {
+			return username, nil
+		}
+	}
+	return "", err
+}
+
+func (ss *SCIMService) formatUsers(ctx context.Context, users []*entity.User) (resp []*schema.SCIMUser, err error) {
+	resp = make([]*schema.SCIMUser, 0, len(users))
+	if len(users) == 0 {
+		return resp, nil
+	}
+	userIDs := make([]string, 0, len(users))
+	for _, u := range users {
+		userIDs = append(userIDs, u.ID)
+	}
+	logins, err := ss.scimRepo.GetExternalLogins(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	loginMapping := make(map[string]*entity.UserExternalLogin, len(logins))
+	for _, login := range logins {
+		loginMapping[login.UserID] = login
+	}
+	roleMapping, err := ss.userRoleRelService.GetUserRoleMapping(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	baseURL := ss.getBaseURL(ctx)
+	for _, u := range users {
+		active := u.Status == entity.UserStatusAvailable
+		user := &schema.SCIMUser{
+			Schemas:     []string{schema.SCIMSchemaUser},
+			ID:          u.ID,
+			UserName:    u.EMail,
+			DisplayName: u.DisplayName,
+			Emails:      []*schema.SCIMEmail{{Value: u.EMail, Type: "work", Primary: true}},
+			Active:      &active,
+			Meta: &schema.SCIMMeta{
+				ResourceType: "User",
+				Created:      u.CreatedAt.UTC().Format(time.RFC3339),
+				LastModified: u.UpdatedAt.UTC().Format(time.RFC3339),
+				Location:     baseURL + "/Users/" + u.ID,
+			},
+		}
+		if login, ok := loginMapping[u.ID]; ok {
+			user.UserName = login.ExternalID
+			user.ExternalID = ss.getExternalLoginMeta(login).ExternalID
+		}
+		if roleInfo, ok := roleMapping[u.ID]; ok && roleInfo != nil {
+			groupID := strconv.Itoa(roleInfo.ID)
+			user.Groups = []*schema.SCIMMember{{Value: groupID, Display: roleInfo.Name, Ref: baseURL + "/Groups/" + groupID}}
+		}
+		resp = append(resp, user)
+	}
+	return resp, nil
+}
+
+// formatGroup format the role as group, the members of the default user role are not listed because all users have it
+func (ss *SCIMService) formatGroup(ctx context.Context, roleInfo *entity.Role) (resp *schema.SCIMGroup, err error) {
+	groupID := strconv.Itoa(roleInfo.ID)
+	baseURL := ss.getBaseURL(ctx)
+	resp = &schema.SCIMGroup{
+		Schemas:     []string{schema.SCIMSchemaGroup},
+		ID:          groupID,
+		DisplayName: roleInfo.Name,
+		Members:     make([]*schema.SCIMMember, 0),
+		Meta: &schema.SCIMMeta{
+			ResourceType: "Group",
+			Created:      roleInfo.CreatedAt.UTC().Format(time.RFC3339),
+			LastModified: roleInfo.UpdatedAt.UTC().Format(time.RFC3339),
+			Location:     baseURL + "/Groups/" + groupID,
+		},
+	}
+	if roleInfo.ID == role.RoleUserID {
+		return resp, nil
+	}
+	rels, err := ss.userRoleRelService.GetUserByRoleID(ctx, []int{roleInfo.ID})
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(rels))
+	for _, rel := range rels {
+		userIDs = append(userIDs, rel.UserID)
+	}
+	users, err := ss.userRepo.BatchGetByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range users {
+		if u.Status == entity.UserStatusDeleted || u.UserType == entity.UserTypeBot {
+			continue
+		}
+		resp.Members = append(resp.Members, &schema.SCIMMember{
+			Value:   u.ID,
+			Display: u.DisplayName,
+			Ref:     baseURL + "/Users/" + u.ID,
+		})
+	}
+	return resp, nil
+}
+
+func (ss *SCIMService) getBaseURL(ctx context.Context) string {
+	siteGeneral, err := ss.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		log.Error(err)
+		return ""
+	}
+	return fmt.Sprintf("%s/answer/scim/v2", strings.TrimSuffix(siteGeneral.SiteUrl, "/"))
+}
@@ -27,8 +27,10 @@ import (
 )
 
 type SearchRepo interface {
-	SearchContents(ctx context.Context, words []string, tagIDs [][]string, userID string, votes, page, size int, order string) (resp []*schema.SearchResult, total int64, err error)
-	SearchQuestions(ctx context.Context, words []string, tagIDs [][]string, notAccepted bool, views, answers int, page, size int, order string) (resp []*schema.SearchResult, total int64, err error)
-	SearchAnswers(ctx context.Context, words []string, tagIDs [][]string, accepted bool, questionID string, page, size int, order string) (resp []*schema.SearchResult, total int64, err error)
+	SearchContents(ctx context.Context, words []string, tagIDs [][]string, userID string, votes, page, size int, order string, withFacets bool) (resp []*schema.SearchResult, total int64, facets *schema.SearchFacets, err error)
+	SearchQuestions(ctx context.Context, words []string, tagIDs [][]string, notAccepted bool, views, answers int, language string, page, size int, order string, withFacets bool) (resp []*schema.SearchResult, total int64, facets *schema.SearchFacets, err error)
+	SearchAnswers(ctx context.Context, words []string, tagIDs [][]string, accepted bool, questionID string, page, size int, order string, withFacets bool) (resp []*schema.SearchResult, total int64, facets *schema.SearchFacets, err error)
+	SearchComments(ctx context.Context, words []string, tagIDs [][]string, userID, questionID string, page, size int, order string, withFacets bool) (resp []*schema.SearchResult, total int64, facets *schema.SearchFacets, err error)
 	ParseSearchPluginResult(ctx context.Context, sres []plugin.SearchResult, words []string) (resp []*schema.SearchResult, err error)
+	SuggestQuestions(ctx context.Context, query string, size int) (resp []*schema.SearchSuggestQuestion, err error)
 }
@@ -0,0 +1,124 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search_parser
+
+import (
+	"strings"
+	"unicode"
+)
+
+// maxAnalyzedWords the max number of words after analyzing, the words are matched with LIKE so too many words are slow
+const maxAnalyzedWords = 10
+
+// Analyzer split the search word into the words matched by the built-in search
+type Analyzer interface {
+	Analyze(word string) []string
+}
+
+// whitespaceAnalyzer the words of space-delimited languages are already split by spaces
+type whitespaceAnalyzer struct{}
+
+func (whitespaceAnalyzer) Analyze(word string) []string {
+	return []string{word}
+}
+
+// ngramAnalyzer split the runs of CJK characters into n-grams, because the languages are not delimited by spaces
+// and the words in query are seldom the same sequence with the content. The other characters are kept as is.
+type ngramAnalyzer struct {
+	size int
+}
+
+func (a ngramAnalyzer) Analyze(word string) []string {
+	words := make([]string, 0)
+	var run, other []rune
+	flushRun := func() {
+		if len(run) <= a.size {
+			if len(run) > 0 {
+				words = append(words, string(run))
+			}
+		} else {
+			for i := 0; i+a.size <= len(run); i++ {
+				words = append(words, string(run[i:i+a.size]))
+			}
+		}
+		run = run[:0]
+	}
+	flushOther := func() {
+		if s := strings.TrimSpace(string(other)); len(s) > 0 {
+			words = append(words, s)
+		}
+		other = other[:0]
+	}
+	for _, r := range word {
+		if isCJK(r) {
+			flushOther()
+			run = append(run, r)
+			continue
+		}
+		flushRun()
+		other = append(other, r)
+	}
+	flushRun()
+	flushOther()
+	return words
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+var analyzers = map[string]Analyzer{
+	"zh": ngramAnalyzer{size: 2},
+	"ja": ngramAnalyzer{size: 2},
+	"ko": ngramAnalyzer{size: 2},
+}
+
+// GetAnalyzer get the analyzer of language, the space-delimited analyzer is returned if the language has no analyzer
+func GetAnalyzer(language string) Analyzer {
+	if analyzer, ok := analyzers[language]; ok {
+		return analyzer
+	}
+	return whitespaceAnalyzer{}
+}
+
+// AnalyzeWords analyze the search words with the analyzer of language.
+// The quoted phrases are matched exactly, so they are not analyzed.
+func AnalyzeWords(language string, words []string) (res []string) {
+	analyzer := GetAnalyzer(language)
+	res = make([]string, 0, len(words))
+	seen := make(map[string]bool)
+	for _, word := range words {
+		analyzed := []string{word}
+		if !(len(word) > 1 && strings.HasPrefix(word, `"`) && strings.HasSuffix(word, `"`)) {
+			analyzed = analyzer.Analyze(word)
+		}
+		for _, w := range analyzed {
+			if seen[w] {
+				continue
+			}
+			seen[w] = true
+			res = append(res, w)
+		}
+	}
+	if len(res) > maxAnalyzedWords {
+		res = res[:maxAnalyzedWords]
+	}
+	return res
+}
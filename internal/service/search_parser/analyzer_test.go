@@ -0,0 +1,35 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search_parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeWords(t *testing.T) {
+	assert.Equal(t, []string{"go", "modules"}, AnalyzeWords("en", []string{"go", "modules"}))
+	assert.Equal(t, []string{"数据", "据库", "连接"}, AnalyzeWords("zh", []string{"数据库", "连接"}))
+	assert.Equal(t, []string{"mysql", "连接", "接超", "超时"}, AnalyzeWords("zh", []string{"mysql连接超时"}))
+	assert.Equal(t, []string{`"数据库连接"`}, AnalyzeWords("zh", []string{`"数据库连接"`}))
+	assert.Equal(t, []string{"検索", "索で", "でき", "きな", "ない"}, AnalyzeWords("ja", []string{"検索できない"}))
+	assert.Len(t, AnalyzeWords("zh", []string{"一二三四五六七八九十百千"}), maxAnalyzedWords)
+}
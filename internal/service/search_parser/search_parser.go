@@ -29,6 +29,7 @@ import (
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/tag_common"
 	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/checker"
 	"github.com/apache/answer/pkg/converter"
 )
 
@@ -74,6 +75,10 @@ func (sp *SearchParser) ParseStructure(ctx context.Context, dto *schema.SearchDT
 	if cond.AnswerAmount != -1 {
 		cond.TargetType = constant.QuestionObjectType
 	}
+	cond.Language = sp.parseLanguage(&query)
+	if cond.Language != "" {
+		cond.TargetType = constant.QuestionObjectType
+	}
 
 	// match answers
 	cond.Accepted = sp.parseAccepted(&query)
@@ -91,6 +96,9 @@ func (sp *SearchParser) ParseStructure(ctx context.Context, dto *schema.SearchDT
 	if sp.parseIsAnswer(&query) {
 		cond.TargetType = constant.AnswerObjectType
 	}
+	if sp.parseIsComment(&query) {
+		cond.TargetType = constant.CommentObjectType
+	}
 
 	if len(strings.TrimSpace(query)) > 0 {
 		words := strings.Split(strings.TrimSpace(query), " ")
@@ -264,6 +272,33 @@ func (sp *SearchParser) parseViews(query *string) (views int) {
 	return
 }
 
+// parseLanguage check search has question language or not, like: lang:zh
+func (sp *SearchParser) parseLanguage(query *string) (language string) {
+	var (
+		q    = *query
+		expr = `lang:([a-z]{2})`
+	)
+
+	re := regexp.MustCompile(expr)
+	res := re.FindStringSubmatch(q)
+	if len(res) > 1 {
+		language = res[1]
+		q = re.ReplaceAllString(q, "")
+	}
+	*query = strings.TrimSpace(q)
+	return
+}
+
+// AnalyzeWords analyze the search words for the built-in search, the analyzer is selected by
+// the language of question if it is specified, otherwise by the detected language of words
+func (sp *SearchParser) AnalyzeWords(cond *schema.SearchCondition) {
+	language := cond.Language
+	if len(language) == 0 {
+		language, _ = checker.DetectLanguage(strings.Join(cond.Words, " "))
+	}
+	cond.Words = AnalyzeWords(language, cond.Words)
+}
+
 // parseAnswers check whether specified answer count for question
 func (sp *SearchParser) parseAnswers(query *string) (answers int) {
 	var (
@@ -332,3 +367,19 @@ func (sp *SearchParser) parseIsAnswer(query *string) (isAnswer bool) {
 	*query = strings.TrimSpace(q)
 	return
 }
+
+// parseIsComment check the result if only limit comment or not
+func (sp *SearchParser) parseIsComment(query *string) (isComment bool) {
+	var (
+		q    = *query
+		expr = `is:comment`
+	)
+
+	if strings.Contains(q, expr) {
+		isComment = true
+		q = strings.ReplaceAll(q, expr, "")
+	}
+
+	*query = strings.TrimSpace(q)
+	return
+}
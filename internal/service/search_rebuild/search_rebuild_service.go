@@ -0,0 +1,186 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search_rebuild
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/apache/answer/plugin"
+	"github.com/segmentfault/pacman/log"
+)
+
+// defaultSearchRebuildBatchSize the default number of contents sent to the search plugin in one batch
+const defaultSearchRebuildBatchSize = 100
+
+// SearchRebuildRepo search index rebuild repository
+type SearchRebuildRepo interface {
+	CountQuestions(ctx context.Context, lastID string) (total int64, err error)
+	CountAnswers(ctx context.Context, lastID string) (total int64, err error)
+	GetQuestionContents(ctx context.Context, lastID string, limit int) (
+		contents []*plugin.SearchContent, nextID string, fetched int, err error)
+	GetAnswerContents(ctx context.Context, lastID string, limit int) (
+		contents []*plugin.SearchContent, nextID string, fetched int, err error)
+}
+
+// SearchRebuildService stream all questions and answers into the search plugin,
+// the checkpoint is saved after each batch so that the interrupted rebuild can be resumed
+type SearchRebuildService struct {
+	searchRebuildRepo SearchRebuildRepo
+	siteInfoRepo      siteinfo_common.SiteInfoRepo
+}
+
+// NewSearchRebuildService new search index rebuild service
+func NewSearchRebuildService(
+	searchRebuildRepo SearchRebuildRepo,
+	siteInfoRepo siteinfo_common.SiteInfoRepo,
+) *SearchRebuildService {
+	return &SearchRebuildService{
+		searchRebuildRepo: searchRebuildRepo,
+		siteInfoRepo:      siteInfoRepo,
+	}
+}
+
+// Rebuild send all contents to the search plugin, the onProgress is called after each batch.
+// The rebuild is resumed from the checkpoint if the last one of the same plugin is not finished.
+func (ss *SearchRebuildService) Rebuild(ctx context.Context, finder plugin.Search, req *schema.SearchRebuildReq,
+	onProgress func(progress *schema.SearchRebuildProgress)) (progress *schema.SearchRebuildProgress, err error) {
+	batchSize := req.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultSearchRebuildBatchSize
+	}
+	slugName := finder.Info().SlugName
+
+	state, err := ss.getState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resumed := !req.Restart && !state.Finished && state.PluginSlugName == slugName && len(state.ObjectType) > 0
+	if !resumed {
+		state = &schema.SearchRebuildState{
+			PluginSlugName: slugName,
+			ObjectType:     constant.QuestionObjectType,
+			LastID:         "0",
+			StartedAt:      time.Now().Unix(),
+		}
+	}
+
+	remaining, err := ss.countRemaining(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	progress = &schema.SearchRebuildProgress{
+		PluginSlugName: slugName,
+		ObjectType:     state.ObjectType,
+		Total:          state.Processed + remaining,
+		Processed:      state.Processed,
+		LastID:         state.LastID,
+		Resumed:        resumed,
+	}
+	log.Infof("start to rebuild search index of %s, %d contents remaining", slugName, remaining)
+
+	for !state.Finished {
+		var (
+			contents []*plugin.SearchContent
+			nextID   string
+			fetched  int
+		)
+		if state.ObjectType == constant.QuestionObjectType {
+			contents, nextID, fetched, err = ss.searchRebuildRepo.GetQuestionContents(ctx, state.LastID, batchSize)
+		} else {
+			contents, nextID, fetched, err = ss.searchRebuildRepo.GetAnswerContents(ctx, state.LastID, batchSize)
+		}
+		if err != nil {
+			return progress, err
+		}
+
+		if fetched == 0 {
+			// all questions are sent, then the answers
+			if state.ObjectType == constant.QuestionObjectType {
+				state.ObjectType, state.LastID = constant.AnswerObjectType, "0"
+			} else {
+				state.Finished = true
+			}
+		} else {
+			for _, content := range contents {
+				if err = finder.UpdateContent(ctx, content); err != nil {
+					return progress, fmt.Errorf("update %s %s failed: %w", content.Type, content.ObjectID, err)
+				}
+			}
+			state.Processed += int64(fetched)
+			state.LastID = nextID
+		}
+		if err = ss.saveState(ctx, state); err != nil {
+			return progress, err
+		}
+		if fetched == 0 {
+			continue
+		}
+		progress.ObjectType, progress.Processed, progress.LastID = state.ObjectType, state.Processed, state.LastID
+		if onProgress != nil {
+			onProgress(progress)
+		}
+	}
+	log.Infof("rebuild search index of %s done, %d contents sent", slugName, state.Processed)
+	return progress, nil
+}
+
+// countRemaining count the contents after the checkpoint, the answers are all remaining when the questions are not finished
+func (ss *SearchRebuildService) countRemaining(ctx context.Context, state *schema.SearchRebuildState) (total int64, err error) {
+	answerLastID := state.LastID
+	if state.ObjectType == constant.QuestionObjectType {
+		total, err = ss.searchRebuildRepo.CountQuestions(ctx, state.LastID)
+		if err != nil {
+			return 0, err
+		}
+		answerLastID = "0"
+	}
+	answers, err := ss.searchRebuildRepo.CountAnswers(ctx, answerLastID)
+	if err != nil {
+		return 0, err
+	}
+	return total + answers, nil
+}
+
+func (ss *SearchRebuildService) getState(ctx context.Context) (state *schema.SearchRebuildState, err error) {
+	state = &schema.SearchRebuildState{}
+	siteInfo, exist, err := ss.siteInfoRepo.GetByType(ctx, constant.SiteTypeSearchRebuildState)
+	if err != nil || !exist {
+		return state, err
+	}
+	_ = json.Unmarshal([]byte(siteInfo.Content), state)
+	return state, nil
+}
+
+func (ss *SearchRebuildService) saveState(ctx context.Context, state *schema.SearchRebuildState) (err error) {
+	state.UpdatedAt = time.Now().Unix()
+	content, _ := json.Marshal(state)
+	return ss.siteInfoRepo.SaveByType(ctx, constant.SiteTypeSearchRebuildState, &entity.SiteInfo{
+		Type:    constant.SiteTypeSearchRebuildState,
+		Content: string(content),
+		Status:  1,
+	})
+}
@@ -24,4 +24,10 @@ type ServiceConfig struct {
 	CleanUpUploads                bool   `json:"clean_up_uploads" mapstructure:"clean_up_uploads" yaml:"clean_up_uploads"`
 	CleanOrphanUploadsPeriodHours int    `json:"clean_orphan_uploads_period_hours" mapstructure:"clean_orphan_uploads_period_hours" yaml:"clean_orphan_uploads_period_hours"`
 	PurgeDeletedFilesPeriodDays   int    `json:"purge_deleted_files_period_days" mapstructure:"purge_deleted_files_period_days" yaml:"purge_deleted_files_period_days"`
+	// the activity and notification tables must be partitioned by range of created_at before enabled
+	TablePartitionEnabled         bool `json:"table_partition_enabled" mapstructure:"table_partition_enabled" yaml:"table_partition_enabled"`
+	TablePartitionMonthsAhead     int  `json:"table_partition_months_ahead" mapstructure:"table_partition_months_ahead" yaml:"table_partition_months_ahead"`
+	TablePartitionRetentionMonths int  `json:"table_partition_retention_months" mapstructure:"table_partition_retention_months" yaml:"table_partition_retention_months"`
+	// the TrueType font of the exported pdf, such as a CJK font, the Go fonts are used if empty
+	PDFFontPath string `json:"pdf_font_path" mapstructure:"pdf_font_path" yaml:"pdf_font_path"`
 }
@@ -28,16 +28,22 @@ import (
 
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/pager"
 	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/base/translator"
+	"github.com/apache/answer/internal/base/validator"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/audit_log"
 	"github.com/apache/answer/internal/service/config"
 	"github.com/apache/answer/internal/service/export"
 	"github.com/apache/answer/internal/service/file_record"
 	questioncommon "github.com/apache/answer/internal/service/question_common"
 	"github.com/apache/answer/internal/service/siteinfo_common"
 	tagcommon "github.com/apache/answer/internal/service/tag_common"
+	"github.com/apache/answer/pkg/emailreply"
+	"github.com/apache/answer/pkg/oauth"
+	"github.com/apache/answer/pkg/webpush"
 	"github.com/apache/answer/plugin"
 	"github.com/jinzhu/copier"
 	"github.com/segmentfault/pacman/errors"
@@ -52,6 +58,7 @@ type SiteInfoService struct {
 	configService         *config.ConfigService
 	questioncommon        *questioncommon.QuestionCommon
 	fileRecordService     *file_record.FileRecordService
+	auditLogService       *audit_log.AuditLogService
 }
 
 func NewSiteInfoService(
@@ -62,7 +69,7 @@ func NewSiteInfoService(
 	configService *config.ConfigService,
 	questioncommon *questioncommon.QuestionCommon,
 	fileRecordService *file_record.FileRecordService,
-
+	auditLogService *audit_log.AuditLogService,
 ) *SiteInfoService {
 	plugin.RegisterGetSiteURLFunc(func() string {
 		generalSiteInfo, err := siteInfoCommonService.GetSiteGeneral(context.Background())
@@ -72,6 +79,14 @@ func NewSiteInfoService(
 		}
 		return generalSiteInfo.SiteUrl
 	})
+	validator.RegisterContentLimitsGetter(func(ctx context.Context) *validator.ContentLimits {
+		siteLimits, err := siteInfoCommonService.GetSiteLimits(ctx)
+		if err != nil {
+			log.Error(err)
+			return nil
+		}
+		return siteLimits.ContentLimits()
+	})
 
 	return &SiteInfoService{
 		siteInfoRepo:          siteInfoRepo,
@@ -81,6 +96,7 @@ func NewSiteInfoService(
 		configService:         configService,
 		questioncommon:        questioncommon,
 		fileRecordService:     fileRecordService,
+		auditLogService:       auditLogService,
 	}
 }
 
@@ -155,7 +171,7 @@ func (s *SiteInfoService) SaveSiteGeneral(ctx context.Context, req schema.SiteGe
 		Content: string(content),
 		Status:  1,
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeGeneral, data)
+	return s.saveSiteInfo(ctx, constant.SiteTypeGeneral, data)
 }
 
 func (s *SiteInfoService) SaveSiteInterface(ctx context.Context, req schema.SiteInterfaceReq) (err error) {
@@ -170,7 +186,7 @@ func (s *SiteInfoService) SaveSiteInterface(ctx context.Context, req schema.Site
 		Type:    constant.SiteTypeInterface,
 		Content: string(content),
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeInterface, &data)
+	return s.saveSiteInfo(ctx, constant.SiteTypeInterface, &data)
 }
 
 // SaveSiteBranding save site branding information
@@ -181,7 +197,7 @@ func (s *SiteInfoService) SaveSiteBranding(ctx context.Context, req *schema.Site
 		Content: string(content),
 		Status:  1,
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeBranding, data)
+	return s.saveSiteInfo(ctx, constant.SiteTypeBranding, data)
 }
 
 // SaveSiteWrite save site configuration about write
@@ -216,7 +232,7 @@ func (s *SiteInfoService) SaveSiteWrite(ctx context.Context, req *schema.SiteWri
 		Content: string(content),
 		Status:  1,
 	}
-	return nil, s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeWrite, data)
+	return nil, s.saveSiteInfo(ctx, constant.SiteTypeWrite, data)
 }
 
 // SaveSiteLegal save site legal configuration
@@ -227,7 +243,7 @@ func (s *SiteInfoService) SaveSiteLegal(ctx context.Context, req *schema.SiteLeg
 		Content: string(content),
 		Status:  1,
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeLegal, data)
+	return s.saveSiteInfo(ctx, constant.SiteTypeLegal, data)
 }
 
 // SaveSiteLogin save site legal configuration
@@ -238,7 +254,7 @@ func (s *SiteInfoService) SaveSiteLogin(ctx context.Context, req *schema.SiteLog
 		Content: string(content),
 		Status:  1,
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeLogin, data)
+	return s.saveSiteInfo(ctx, constant.SiteTypeLogin, data)
 }
 
 // SaveSiteCustomCssHTML save site custom html configuration
@@ -249,7 +265,7 @@ func (s *SiteInfoService) SaveSiteCustomCssHTML(ctx context.Context, req *schema
 		Content: string(content),
 		Status:  1,
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeCustomCssHTML, data)
+	return s.saveSiteInfo(ctx, constant.SiteTypeCustomCssHTML, data)
 }
 
 // SaveSiteTheme save site custom html configuration
@@ -260,7 +276,7 @@ func (s *SiteInfoService) SaveSiteTheme(ctx context.Context, req *schema.SiteThe
 		Content: string(content),
 		Status:  1,
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeTheme, data)
+	return s.saveSiteInfo(ctx, constant.SiteTypeTheme, data)
 }
 
 // SaveSiteUsers save site users
@@ -271,7 +287,614 @@ func (s *SiteInfoService) SaveSiteUsers(ctx context.Context, req *schema.SiteUse
 		Content: string(content),
 		Status:  1,
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeUsers, data)
+	return s.saveSiteInfo(ctx, constant.SiteTypeUsers, data)
+}
+
+// GetSiteLimits get site content limits
+func (s *SiteInfoService) GetSiteLimits(ctx context.Context) (resp *schema.SiteLimitsResp, err error) {
+	return s.siteInfoCommonService.GetSiteLimits(ctx)
+}
+
+// SaveSiteLimits save site content limits
+func (s *SiteInfoService) SaveSiteLimits(ctx context.Context, req *schema.SiteLimitsReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeLimits,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeLimits, data)
+}
+
+// GetSiteQuestionClose get site question close vote config
+func (s *SiteInfoService) GetSiteQuestionClose(ctx context.Context) (resp *schema.SiteQuestionCloseResp, err error) {
+	return s.siteInfoCommonService.GetSiteQuestionClose(ctx)
+}
+
+// SaveSiteQuestionClose save site question close vote config
+func (s *SiteInfoService) SaveSiteQuestionClose(ctx context.Context, req *schema.SiteQuestionCloseReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeQuestionClose,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeQuestionClose, data)
+}
+
+// GetSiteRateLimit get site rate limit config
+func (s *SiteInfoService) GetSiteRateLimit(ctx context.Context) (resp *schema.SiteRateLimitResp, err error) {
+	return s.siteInfoCommonService.GetSiteRateLimit(ctx)
+}
+
+// SaveSiteRateLimit save site rate limit config
+func (s *SiteInfoService) SaveSiteRateLimit(ctx context.Context, req *schema.SiteRateLimitReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeRateLimit,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeRateLimit, data)
+}
+
+// GetSiteDataDump get site public data dump config
+func (s *SiteInfoService) GetSiteDataDump(ctx context.Context) (resp *schema.SiteDataDumpResp, err error) {
+	return s.siteInfoCommonService.GetSiteDataDump(ctx)
+}
+
+// SaveSiteDataDump save site public data dump config
+func (s *SiteInfoService) SaveSiteDataDump(ctx context.Context, req *schema.SiteDataDumpReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeDataDump,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeDataDump, data)
+}
+
+// GetSiteReputationSync get site reputation and role sync config
+func (s *SiteInfoService) GetSiteReputationSync(ctx context.Context) (resp *schema.SiteReputationSyncResp, err error) {
+	return s.siteInfoCommonService.GetSiteReputationSync(ctx)
+}
+
+// SaveSiteReputationSync save site reputation and role sync config
+func (s *SiteInfoService) SaveSiteReputationSync(ctx context.Context, req *schema.SiteReputationSyncReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeReputationSync,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeReputationSync, data)
+}
+
+// GetSiteChatIntake get site chat intake config
+func (s *SiteInfoService) GetSiteChatIntake(ctx context.Context) (resp *schema.SiteChatIntakeResp, err error) {
+	return s.siteInfoCommonService.GetSiteChatIntake(ctx)
+}
+
+// SaveSiteChatIntake save site chat intake config
+func (s *SiteInfoService) SaveSiteChatIntake(ctx context.Context, req *schema.SiteChatIntakeReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeChatIntake,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeChatIntake, data)
+}
+
+// GetSiteVoteFraud get site vote fraud detection config
+func (s *SiteInfoService) GetSiteVoteFraud(ctx context.Context) (resp *schema.SiteVoteFraudResp, err error) {
+	return s.siteInfoCommonService.GetSiteVoteFraud(ctx)
+}
+
+// SaveSiteVoteFraud save site vote fraud detection config
+func (s *SiteInfoService) SaveSiteVoteFraud(ctx context.Context, req *schema.SiteVoteFraudReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeVoteFraud,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeVoteFraud, data)
+}
+
+// GetSiteAnswerRanking get site answer ranking config
+func (s *SiteInfoService) GetSiteAnswerRanking(ctx context.Context) (resp *schema.SiteAnswerRankingResp, err error) {
+	return s.siteInfoCommonService.GetSiteAnswerRanking(ctx)
+}
+
+// SaveSiteAnswerRanking save site answer ranking config
+func (s *SiteInfoService) SaveSiteAnswerRanking(ctx context.Context, req *schema.SiteAnswerRankingReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeAnswerRanking,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeAnswerRanking, data)
+}
+
+// GetSiteLanguageDetection get site content language detection config
+func (s *SiteInfoService) GetSiteLanguageDetection(ctx context.Context) (resp *schema.SiteLanguageDetectionResp, err error) {
+	return s.siteInfoCommonService.GetSiteLanguageDetection(ctx)
+}
+
+// SaveSiteLanguageDetection save site content language detection config
+func (s *SiteInfoService) SaveSiteLanguageDetection(ctx context.Context, req *schema.SiteLanguageDetectionReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeLanguageDetection,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeLanguageDetection, data)
+}
+
+// GetSiteDownvoteCost get site downvote cost config
+func (s *SiteInfoService) GetSiteDownvoteCost(ctx context.Context) (resp *schema.SiteDownvoteCostResp, err error) {
+	return s.siteInfoCommonService.GetSiteDownvoteCost(ctx)
+}
+
+// SaveSiteDownvoteCost save site downvote cost config
+func (s *SiteInfoService) SaveSiteDownvoteCost(ctx context.Context, req *schema.SiteDownvoteCostReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeDownvoteCost,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeDownvoteCost, data)
+}
+
+// GetSiteSyndication get site question syndication config
+func (s *SiteInfoService) GetSiteSyndication(ctx context.Context) (resp *schema.SiteSyndicationResp, err error) {
+	return s.siteInfoCommonService.GetSiteSyndication(ctx)
+}
+
+// SaveSiteSyndication save site question syndication config
+func (s *SiteInfoService) SaveSiteSyndication(ctx context.Context, req *schema.SiteSyndicationReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeSyndication,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeSyndication, data)
+}
+
+// GetSiteHelpfulnessSurvey get site helpfulness survey config
+func (s *SiteInfoService) GetSiteHelpfulnessSurvey(ctx context.Context) (resp *schema.SiteHelpfulnessSurveyResp, err error) {
+	return s.siteInfoCommonService.GetSiteHelpfulnessSurvey(ctx)
+}
+
+// SaveSiteHelpfulnessSurvey save site helpfulness survey config
+func (s *SiteInfoService) SaveSiteHelpfulnessSurvey(ctx context.Context, req *schema.SiteHelpfulnessSurveyReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeHelpfulnessSurvey,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeHelpfulnessSurvey, data)
+}
+
+// GetSiteReEngagement get site re-engagement email config
+func (s *SiteInfoService) GetSiteReEngagement(ctx context.Context) (resp *schema.SiteReEngagementResp, err error) {
+	return s.siteInfoCommonService.GetSiteReEngagement(ctx)
+}
+
+// SaveSiteReEngagement save site re-engagement email config
+func (s *SiteInfoService) SaveSiteReEngagement(ctx context.Context, req *schema.SiteReEngagementReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeReEngagement,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeReEngagement, data)
+}
+
+// GetSiteQuestionQuality get site question quality config
+func (s *SiteInfoService) GetSiteQuestionQuality(ctx context.Context) (resp *schema.SiteQuestionQualityResp, err error) {
+	return s.siteInfoCommonService.GetSiteQuestionQuality(ctx)
+}
+
+// SaveSiteQuestionQuality save site question quality config
+func (s *SiteInfoService) SaveSiteQuestionQuality(ctx context.Context, req *schema.SiteQuestionQualityReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeQuestionQuality,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeQuestionQuality, data)
+}
+
+// GetSiteTagRules get site tag rules config
+func (s *SiteInfoService) GetSiteTagRules(ctx context.Context) (resp *schema.SiteTagRulesResp, err error) {
+	return s.siteInfoCommonService.GetSiteTagRules(ctx)
+}
+
+// SaveSiteTagRules save site tag rules config
+func (s *SiteInfoService) SaveSiteTagRules(ctx context.Context, req *schema.SiteTagRulesReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeTagRules,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeTagRules, data)
+}
+
+// GetSiteVoteMilestone get site vote milestone config
+func (s *SiteInfoService) GetSiteVoteMilestone(ctx context.Context) (resp *schema.SiteVoteMilestoneResp, err error) {
+	return s.siteInfoCommonService.GetSiteVoteMilestone(ctx)
+}
+
+// SaveSiteVoteMilestone save site vote milestone config
+func (s *SiteInfoService) SaveSiteVoteMilestone(ctx context.Context, req *schema.SiteVoteMilestoneReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeVoteMilestone,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeVoteMilestone, data)
+}
+
+// GetSiteAutoComment get site auto comment config
+func (s *SiteInfoService) GetSiteAutoComment(ctx context.Context) (resp *schema.SiteAutoCommentResp, err error) {
+	return s.siteInfoCommonService.GetSiteAutoComment(ctx)
+}
+
+// SaveSiteAutoComment save site auto comment config
+func (s *SiteInfoService) SaveSiteAutoComment(ctx context.Context, req *schema.SiteAutoCommentReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeAutoComment,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeAutoComment, data)
+}
+
+// GetSiteTagBlocklist get site tag blocklist config
+func (s *SiteInfoService) GetSiteTagBlocklist(ctx context.Context) (resp *schema.SiteTagBlocklistResp, err error) {
+	return s.siteInfoCommonService.GetSiteTagBlocklist(ctx)
+}
+
+// SaveSiteTagBlocklist save site tag blocklist config
+func (s *SiteInfoService) SaveSiteTagBlocklist(ctx context.Context, req *schema.SiteTagBlocklistReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeTagBlocklist,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeTagBlocklist, data)
+}
+
+// GetSiteLoadShedding get site load shedding config
+func (s *SiteInfoService) GetSiteLoadShedding(ctx context.Context) (resp *schema.SiteLoadSheddingResp, err error) {
+	return s.siteInfoCommonService.GetSiteLoadShedding(ctx)
+}
+
+// SaveSiteLoadShedding save site load shedding config
+func (s *SiteInfoService) SaveSiteLoadShedding(ctx context.Context, req *schema.SiteLoadSheddingReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeLoadShedding,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeLoadShedding, data)
+}
+
+// GetSiteAutoPromotion get site auto promotion config
+func (s *SiteInfoService) GetSiteAutoPromotion(ctx context.Context) (resp *schema.SiteAutoPromotionResp, err error) {
+	return s.siteInfoCommonService.GetSiteAutoPromotion(ctx)
+}
+
+// SaveSiteAutoPromotion save site auto promotion config
+func (s *SiteInfoService) SaveSiteAutoPromotion(ctx context.Context, req *schema.SiteAutoPromotionReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeAutoPromotion,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeAutoPromotion, data)
+}
+
+// GetSiteContentFreshness get site content freshness config
+func (s *SiteInfoService) GetSiteContentFreshness(ctx context.Context) (resp *schema.SiteContentFreshnessResp, err error) {
+	return s.siteInfoCommonService.GetSiteContentFreshness(ctx)
+}
+
+// SaveSiteContentFreshness save site content freshness config
+func (s *SiteInfoService) SaveSiteContentFreshness(ctx context.Context, req *schema.SiteContentFreshnessReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeContentFreshness,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeContentFreshness, data)
+}
+
+// GetSiteQuestionMigration get site question migration config
+func (s *SiteInfoService) GetSiteQuestionMigration(ctx context.Context) (resp *schema.SiteQuestionMigrationResp, err error) {
+	return s.siteInfoCommonService.GetSiteQuestionMigration(ctx)
+}
+
+// SaveSiteQuestionMigration save site question migration config
+func (s *SiteInfoService) SaveSiteQuestionMigration(ctx context.Context, req *schema.SiteQuestionMigrationReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeQuestionMigration,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeQuestionMigration, data)
+}
+
+// GetSiteCacheWarming get site cache warming config
+func (s *SiteInfoService) GetSiteCacheWarming(ctx context.Context) (resp *schema.SiteCacheWarmingResp, err error) {
+	return s.siteInfoCommonService.GetSiteCacheWarming(ctx)
+}
+
+// SaveSiteCacheWarming save site cache warming config
+func (s *SiteInfoService) SaveSiteCacheWarming(ctx context.Context, req *schema.SiteCacheWarmingReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeCacheWarming,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeCacheWarming, data)
+}
+
+// GetSiteWebhook get site webhook config
+func (s *SiteInfoService) GetSiteWebhook(ctx context.Context) (resp *schema.SiteWebhookResp, err error) {
+	return s.siteInfoCommonService.GetSiteWebhook(ctx)
+}
+
+// SaveSiteWebhook save site webhook config
+func (s *SiteInfoService) SaveSiteWebhook(ctx context.Context, req *schema.SiteWebhookReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeWebhook,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeWebhook, data)
+}
+
+// GetSiteSlack get site slack connector config
+func (s *SiteInfoService) GetSiteSlack(ctx context.Context) (resp *schema.SiteSlackResp, err error) {
+	return s.siteInfoCommonService.GetSiteSlack(ctx)
+}
+
+// SaveSiteSlack save site slack connector config
+func (s *SiteInfoService) SaveSiteSlack(ctx context.Context, req *schema.SiteSlackReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeSlack,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeSlack, data)
+}
+
+// GetSiteJira get site jira connector config
+func (s *SiteInfoService) GetSiteJira(ctx context.Context) (resp *schema.SiteJiraResp, err error) {
+	return s.siteInfoCommonService.GetSiteJira(ctx)
+}
+
+// SaveSiteJira save site jira connector config
+func (s *SiteInfoService) SaveSiteJira(ctx context.Context, req *schema.SiteJiraReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeJira,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeJira, data)
+}
+
+// GetSiteSpaces get site spaces config
+func (s *SiteInfoService) GetSiteSpaces(ctx context.Context) (resp *schema.SiteSpacesResp, err error) {
+	return s.siteInfoCommonService.GetSiteSpaces(ctx)
+}
+
+// SaveSiteSpaces save site spaces config
+func (s *SiteInfoService) SaveSiteSpaces(ctx context.Context, req *schema.SiteSpacesReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeSpaces,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeSpaces, data)
+}
+
+// GetSiteMessenger get site messenger connectors config
+func (s *SiteInfoService) GetSiteMessenger(ctx context.Context) (resp *schema.SiteMessengerResp, err error) {
+	return s.siteInfoCommonService.GetSiteMessenger(ctx)
+}
+
+// SaveSiteMessenger save site messenger connectors config
+func (s *SiteInfoService) SaveSiteMessenger(ctx context.Context, req *schema.SiteMessengerReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeMessenger,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeMessenger, data)
+}
+
+// GetSiteWebPush get site web push config
+func (s *SiteInfoService) GetSiteWebPush(ctx context.Context) (resp *schema.SiteWebPushResp, err error) {
+	return s.siteInfoCommonService.GetSiteWebPush(ctx)
+}
+
+// SaveSiteWebPush save site web push config
+func (s *SiteInfoService) SaveSiteWebPush(ctx context.Context, req *schema.SiteWebPushReq) (err error) {
+	// the subscriptions of the browsers are bound to the public key, the key pair is only generated when it is missing
+	if len(req.PublicKey) == 0 || len(req.PrivateKey) == 0 {
+		req.PrivateKey, req.PublicKey, err = webpush.GenerateVAPIDKeys()
+		if err != nil {
+			return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+		}
+	}
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeWebPush,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeWebPush, data)
+}
+
+// GetSiteDigest get site email digest config
+func (s *SiteInfoService) GetSiteDigest(ctx context.Context) (resp *schema.SiteDigestResp, err error) {
+	return s.siteInfoCommonService.GetSiteDigest(ctx)
+}
+
+// SaveSiteDigest save site email digest config
+func (s *SiteInfoService) SaveSiteDigest(ctx context.Context, req *schema.SiteDigestReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeDigest,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeDigest, data)
+}
+
+// GetSiteInboundEmail get site inbound email config
+func (s *SiteInfoService) GetSiteInboundEmail(ctx context.Context) (resp *schema.SiteInboundEmailResp, err error) {
+	return s.siteInfoCommonService.GetSiteInboundEmail(ctx)
+}
+
+// SaveSiteInboundEmail save site inbound email config
+func (s *SiteInfoService) SaveSiteInboundEmail(ctx context.Context, req *schema.SiteInboundEmailReq) (err error) {
+	// changing the secret invalidates the reply addresses of the sent emails, so it is only generated when it is missing
+	if len(req.Secret) == 0 {
+		if req.Secret, err = emailreply.GenerateSecret(); err != nil {
+			return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+		}
+	}
+	if len(req.WebhookToken) == 0 {
+		if req.WebhookToken, err = emailreply.GenerateSecret(); err != nil {
+			return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+		}
+	}
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeInboundEmail,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeInboundEmail, data)
+}
+
+// GetSiteNotificationRetention get site notification retention config
+func (s *SiteInfoService) GetSiteNotificationRetention(ctx context.Context) (resp *schema.SiteNotificationRetentionResp, err error) {
+	return s.siteInfoCommonService.GetSiteNotificationRetention(ctx)
+}
+
+// SaveSiteNotificationRetention save site notification retention config
+func (s *SiteInfoService) SaveSiteNotificationRetention(ctx context.Context, req *schema.SiteNotificationRetentionReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeNotificationRetention,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeNotificationRetention, data)
+}
+
+// GetSiteSCIM get site SCIM config
+func (s *SiteInfoService) GetSiteSCIM(ctx context.Context) (resp *schema.SiteSCIMResp, err error) {
+	return s.siteInfoCommonService.GetSiteSCIM(ctx)
+}
+
+// SaveSiteSCIM save site SCIM config
+func (s *SiteInfoService) SaveSiteSCIM(ctx context.Context, req *schema.SiteSCIMReq) (err error) {
+	if len(req.Token) == 0 {
+		if req.Token, err = emailreply.GenerateSecret(); err != nil {
+			return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+		}
+	}
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeSCIM,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeSCIM, data)
+}
+
+// GetSiteOAuthProvider get site OAuth provider config
+func (s *SiteInfoService) GetSiteOAuthProvider(ctx context.Context) (resp *schema.SiteOAuthProviderResp, err error) {
+	return s.siteInfoCommonService.GetSiteOAuthProvider(ctx)
+}
+
+// SaveSiteOAuthProvider save site OAuth provider config
+func (s *SiteInfoService) SaveSiteOAuthProvider(ctx context.Context, req *schema.SiteOAuthProviderReq) (err error) {
+	// changing the signing key invalidates the id tokens issued, so it is only generated when it is missing
+	if len(req.SigningKey) == 0 {
+		if req.SigningKey, err = oauth.GenerateSigningKey(); err != nil {
+			return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+		}
+	} else if _, err = oauth.JWKS(req.SigningKey); err != nil {
+		return errors.BadRequest(reason.OAuthSigningKeyInvalid)
+	}
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeOAuthProvider,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeOAuthProvider, data)
+}
+
+// GetSiteLoginProtection get site login protection config
+func (s *SiteInfoService) GetSiteLoginProtection(ctx context.Context) (resp *schema.SiteLoginProtectionResp, err error) {
+	return s.siteInfoCommonService.GetSiteLoginProtection(ctx)
+}
+
+// SaveSiteLoginProtection save site login protection config
+func (s *SiteInfoService) SaveSiteLoginProtection(ctx context.Context, req *schema.SiteLoginProtectionReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeLoginProtection,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeLoginProtection, data)
+}
+
+// GetSiteInvite get site invite config
+func (s *SiteInfoService) GetSiteInvite(ctx context.Context) (resp *schema.SiteInviteResp, err error) {
+	return s.siteInfoCommonService.GetSiteInvite(ctx)
+}
+
+// SaveSiteInvite save site invite config
+func (s *SiteInfoService) SaveSiteInvite(ctx context.Context, req *schema.SiteInviteReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeInvite,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeInvite, data)
 }
 
 // GetSMTPConfig get smtp config
@@ -304,6 +927,13 @@ func (s *SiteInfoService) UpdateSMTPConfig(ctx context.Context, req *schema.Upda
 	if err != nil {
 		return err
 	}
+	s.auditLogService.Record(ctx, &schema.AuditLogRecord{
+		Action:     entity.AuditLogActionSettingEdit,
+		ObjectType: entity.AuditLogObjectTypeSetting,
+		ObjectID:   "smtp",
+		Before:     emailConfig,
+		After:      ec,
+	})
 	if len(req.TestEmailRecipient) > 0 {
 		title, body, err := s.emailService.TestTemplate(ctx)
 		if err != nil {
@@ -338,7 +968,7 @@ func (s *SiteInfoService) SaveSeo(ctx context.Context, req schema.SiteSeoReq) (e
 		Type:    constant.SiteTypeSeo,
 		Content: string(content),
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeSeo, &data)
+	return s.saveSiteInfo(ctx, constant.SiteTypeSeo, &data)
 }
 
 func (s *SiteInfoService) GetPrivilegesConfig(ctx context.Context) (resp *schema.GetPrivilegesConfigResp, err error) {
@@ -429,7 +1059,7 @@ func (s *SiteInfoService) UpdatePrivilegesConfig(ctx context.Context, req *schem
 		Content: string(content),
 		Status:  1,
 	}
-	err = s.siteInfoRepo.SaveByType(ctx, constant.SiteTypePrivileges, data)
+	err = s.saveSiteInfo(ctx, constant.SiteTypePrivileges, data)
 	if err != nil {
 		return err
 	}
@@ -444,6 +1074,143 @@ func (s *SiteInfoService) UpdatePrivilegesConfig(ctx context.Context, req *schem
 	return
 }
 
+// GetReputationRules get the reputation awarded or deducted for each event
+func (s *SiteInfoService) GetReputationRules(ctx context.Context) (resp *schema.GetReputationRulesResp, err error) {
+	la := handler.GetLangByCtx(ctx)
+	resp = &schema.GetReputationRulesResp{}
+	for _, rule := range constant.ReputationAllRules {
+		value, err := s.configService.GetIntValue(ctx, rule.Key)
+		if err != nil {
+			return nil, err
+		}
+		resp.Rules = append(resp.Rules, &constant.ReputationRule{
+			Key:   rule.Key,
+			Label: translator.Tr(la, rule.Label),
+			Value: value,
+		})
+	}
+	resp.DailyLimit, err = s.configService.GetIntValue(ctx, constant.ReputationDailyLimitKey)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UpdateReputationRules update the reputation awarded or deducted for each event
+func (s *SiteInfoService) UpdateReputationRules(ctx context.Context, req *schema.UpdateReputationRulesReq) (err error) {
+	allowedKeys := make(map[string]bool, len(constant.ReputationAllRules))
+	for _, rule := range constant.ReputationAllRules {
+		allowedKeys[rule.Key] = true
+	}
+	for _, rule := range req.Rules {
+		if !allowedKeys[rule.Key] {
+			return errors.BadRequest(reason.ReputationRuleNotFound)
+		}
+	}
+	for _, rule := range req.Rules {
+		err = s.configService.UpdateConfig(ctx, rule.Key, fmt.Sprintf("%d", rule.Value))
+		if err != nil {
+			return err
+		}
+	}
+	if req.DailyLimit > 0 {
+		err = s.configService.UpdateConfig(ctx, constant.ReputationDailyLimitKey, fmt.Sprintf("%d", req.DailyLimit))
+		if err != nil {
+			return err
+		}
+	}
+	s.auditLogService.Record(ctx, &schema.AuditLogRecord{
+		Action:     entity.AuditLogActionSettingEdit,
+		ObjectType: entity.AuditLogObjectTypeSetting,
+		ObjectID:   "reputation_rules",
+		After:      req,
+	})
+	return nil
+}
+
+// GetSiteInfoVersions get the current version of all types of settings
+func (s *SiteInfoService) GetSiteInfoVersions(ctx context.Context) (resp []*schema.SiteInfoVersionItem, err error) {
+	siteInfoList, err := s.siteInfoRepo.GetVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.SiteInfoVersionItem, 0, len(siteInfoList))
+	for _, siteInfo := range siteInfoList {
+		resp = append(resp, &schema.SiteInfoVersionItem{
+			Type:      siteInfo.Type,
+			Version:   siteInfo.Version,
+			UpdatedAt: siteInfo.UpdatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// GetSiteInfoHistoryPage get the saved versions of settings
+func (s *SiteInfoService) GetSiteInfoHistoryPage(ctx context.Context, req *schema.GetSiteInfoHistoryPageReq) (
+	pageModel *pager.PageModel, err error) {
+	historyList, total, err := s.siteInfoRepo.GetHistoryPage(ctx, req.Type, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*schema.SiteInfoHistoryItem, 0, len(historyList))
+	for _, history := range historyList {
+		list = append(list, &schema.SiteInfoHistoryItem{
+			Version:    history.Version,
+			Content:    history.Content,
+			OperatorID: history.OperatorID,
+			CreatedAt:  history.CreatedAt.Unix(),
+		})
+	}
+	return pager.NewPageModel(total, list), nil
+}
+
+// RevertSiteInfo save the content of old version as a new version
+func (s *SiteInfoService) RevertSiteInfo(ctx context.Context, req *schema.RevertSiteInfoReq) (err error) {
+	history, exist, err := s.siteInfoRepo.GetHistory(ctx, req.Type, req.Version)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.SiteInfoHistoryNotFound)
+	}
+	// the privileges are also saved in config, so they are updated as set by admin
+	if req.Type == constant.SiteTypePrivileges {
+		privileges := &schema.UpdatePrivilegesConfigReq{}
+		if err = json.Unmarshal([]byte(history.Content), privileges); err != nil {
+			return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+		}
+		return s.UpdatePrivilegesConfig(ctx, privileges)
+	}
+	return s.saveSiteInfo(ctx, req.Type, &entity.SiteInfo{
+		Type:    req.Type,
+		Content: history.Content,
+		Status:  1,
+	})
+}
+
+// saveSiteInfo save the settings and record the change in audit log
+func (s *SiteInfoService) saveSiteInfo(ctx context.Context, siteType string, data *entity.SiteInfo) (err error) {
+	var before string
+	old, exist, err := s.siteInfoRepo.GetByType(ctx, siteType)
+	if err != nil {
+		return err
+	}
+	if exist {
+		before = old.Content
+	}
+	if err = s.siteInfoRepo.SaveByType(ctx, siteType, data); err != nil {
+		return err
+	}
+	s.auditLogService.Record(ctx, &schema.AuditLogRecord{
+		Action:     entity.AuditLogActionSettingEdit,
+		ObjectType: entity.AuditLogObjectTypeSetting,
+		ObjectID:   siteType,
+		Before:     before,
+		After:      data.Content,
+	})
+	return nil
+}
+
 func (s *SiteInfoService) CleanUpRemovedBrandingFiles(
 	ctx context.Context,
 	newBranding *schema.SiteBrandingReq,
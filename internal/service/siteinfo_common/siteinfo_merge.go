@@ -0,0 +1,96 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package siteinfo_common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// NewVersionConflictError the error of settings changed concurrently, the message contains the conflicting fields
+func NewVersionConflictError(ctx context.Context, conflicts []string) error {
+	msg := translator.TrWithData(handler.GetLangByCtx(ctx), reason.SiteInfoVersionConflict,
+		map[string]any{"Fields": strings.Join(conflicts, ", ")})
+	return errors.Conflict(reason.SiteInfoVersionConflict).WithMsg(msg)
+}
+
+// MergeSiteInfoContent merge the top level fields of settings content changed concurrently.
+// The base is the content which the incoming write is based on, and the current is the latest saved content.
+// The fields only changed by incoming or current are merged, the fields changed differently by both are conflicts.
+func MergeSiteInfoContent(base, current, incoming string) (merged string, conflicts []string, err error) {
+	baseFields, currentFields, incomingFields := map[string]json.RawMessage{}, map[string]json.RawMessage{}, map[string]json.RawMessage{}
+	if err = unmarshalFields(base, baseFields); err != nil {
+		return "", nil, err
+	}
+	if err = unmarshalFields(current, currentFields); err != nil {
+		return "", nil, err
+	}
+	if err = unmarshalFields(incoming, incomingFields); err != nil {
+		return "", nil, err
+	}
+
+	mergedFields := make(map[string]json.RawMessage, len(currentFields))
+	for key, value := range currentFields {
+		mergedFields[key] = value
+	}
+	for key, incomingValue := range incomingFields {
+		baseValue, currentValue := baseFields[key], currentFields[key]
+		if fieldEqual(baseValue, incomingValue) {
+			continue
+		}
+		if !fieldEqual(baseValue, currentValue) && !fieldEqual(currentValue, incomingValue) {
+			conflicts = append(conflicts, key)
+			continue
+		}
+		mergedFields[key] = incomingValue
+	}
+	sort.Strings(conflicts)
+	if len(conflicts) > 0 {
+		return "", conflicts, nil
+	}
+	content, err := json.Marshal(mergedFields)
+	if err != nil {
+		return "", nil, err
+	}
+	return string(content), nil, nil
+}
+
+func unmarshalFields(content string, fields map[string]json.RawMessage) error {
+	if len(content) == 0 {
+		return nil
+	}
+	return json.Unmarshal([]byte(content), &fields)
+}
+
+func fieldEqual(a, b json.RawMessage) bool {
+	var ca, cb bytes.Buffer
+	if json.Compact(&ca, a) != nil || json.Compact(&cb, b) != nil {
+		return bytes.Equal(a, b)
+	}
+	return bytes.Equal(ca.Bytes(), cb.Bytes())
+}
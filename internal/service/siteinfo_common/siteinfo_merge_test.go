@@ -0,0 +1,51 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package siteinfo_common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeSiteInfoContent(t *testing.T) {
+	base := `{"name":"a","description":"b","contact_email":"c"}`
+
+	// the fields changed by others are kept
+	merged, conflicts, err := MergeSiteInfoContent(base,
+		`{"name":"x","description":"b","contact_email":"c"}`,
+		`{"name":"a","description":"y","contact_email":"c"}`)
+	assert.NoError(t, err)
+	assert.Empty(t, conflicts)
+	assert.JSONEq(t, `{"name":"x","description":"y","contact_email":"c"}`, merged)
+
+	// the same change is not a conflict
+	_, conflicts, err = MergeSiteInfoContent(base,
+		`{"name":"x","description":"b","contact_email":"c"}`,
+		`{"name":"x","description":"b","contact_email":"z"}`)
+	assert.NoError(t, err)
+	assert.Empty(t, conflicts)
+
+	_, conflicts, err = MergeSiteInfoContent(base,
+		`{"name":"x","description":"m","contact_email":"c"}`,
+		`{"name":"y","description":"n","contact_email":"c"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"description", "name"}, conflicts)
+}
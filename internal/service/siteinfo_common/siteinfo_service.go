@@ -36,6 +36,9 @@ type SiteInfoRepo interface {
 	SaveByType(ctx context.Context, siteType string, data *entity.SiteInfo) (err error)
 	GetByType(ctx context.Context, siteType string) (siteInfo *entity.SiteInfo, exist bool, err error)
 	IsBrandingFileUsed(ctx context.Context, filePath string) (bool, error)
+	GetVersions(ctx context.Context) (siteInfoList []*entity.SiteInfo, err error)
+	GetHistory(ctx context.Context, siteType string, version int) (history *entity.SiteInfoHistory, exist bool, err error)
+	GetHistoryPage(ctx context.Context, siteType string, page, pageSize int) (historyList []*entity.SiteInfoHistory, total int64, err error)
 }
 
 // siteInfoCommonService site info common service
@@ -56,6 +59,42 @@ type SiteInfoCommonService interface {
 	GetSiteCustomCssHTML(ctx context.Context) (resp *schema.SiteCustomCssHTMLResp, err error)
 	GetSiteTheme(ctx context.Context) (resp *schema.SiteThemeResp, err error)
 	GetSiteSeo(ctx context.Context) (resp *schema.SiteSeoResp, err error)
+	GetSiteLimits(ctx context.Context) (resp *schema.SiteLimitsResp, err error)
+	GetSiteQuestionClose(ctx context.Context) (resp *schema.SiteQuestionCloseResp, err error)
+	GetSiteRateLimit(ctx context.Context) (resp *schema.SiteRateLimitResp, err error)
+	GetSiteDataDump(ctx context.Context) (resp *schema.SiteDataDumpResp, err error)
+	GetSiteReputationSync(ctx context.Context) (resp *schema.SiteReputationSyncResp, err error)
+	GetSiteChatIntake(ctx context.Context) (resp *schema.SiteChatIntakeResp, err error)
+	GetSiteVoteFraud(ctx context.Context) (resp *schema.SiteVoteFraudResp, err error)
+	GetSiteAnswerRanking(ctx context.Context) (resp *schema.SiteAnswerRankingResp, err error)
+	GetSiteLanguageDetection(ctx context.Context) (resp *schema.SiteLanguageDetectionResp, err error)
+	GetSiteDownvoteCost(ctx context.Context) (resp *schema.SiteDownvoteCostResp, err error)
+	GetSiteSyndication(ctx context.Context) (resp *schema.SiteSyndicationResp, err error)
+	GetSiteHelpfulnessSurvey(ctx context.Context) (resp *schema.SiteHelpfulnessSurveyResp, err error)
+	GetSiteReEngagement(ctx context.Context) (resp *schema.SiteReEngagementResp, err error)
+	GetSiteQuestionQuality(ctx context.Context) (resp *schema.SiteQuestionQualityResp, err error)
+	GetSiteTagRules(ctx context.Context) (resp *schema.SiteTagRulesResp, err error)
+	GetSiteVoteMilestone(ctx context.Context) (resp *schema.SiteVoteMilestoneResp, err error)
+	GetSiteAutoComment(ctx context.Context) (resp *schema.SiteAutoCommentResp, err error)
+	GetSiteTagBlocklist(ctx context.Context) (resp *schema.SiteTagBlocklistResp, err error)
+	GetSiteLoadShedding(ctx context.Context) (resp *schema.SiteLoadSheddingResp, err error)
+	GetSiteAutoPromotion(ctx context.Context) (resp *schema.SiteAutoPromotionResp, err error)
+	GetSiteContentFreshness(ctx context.Context) (resp *schema.SiteContentFreshnessResp, err error)
+	GetSiteQuestionMigration(ctx context.Context) (resp *schema.SiteQuestionMigrationResp, err error)
+	GetSiteCacheWarming(ctx context.Context) (resp *schema.SiteCacheWarmingResp, err error)
+	GetSiteWebhook(ctx context.Context) (resp *schema.SiteWebhookResp, err error)
+	GetSiteSlack(ctx context.Context) (resp *schema.SiteSlackResp, err error)
+	GetSiteJira(ctx context.Context) (resp *schema.SiteJiraResp, err error)
+	GetSiteSpaces(ctx context.Context) (resp *schema.SiteSpacesResp, err error)
+	GetSiteMessenger(ctx context.Context) (resp *schema.SiteMessengerResp, err error)
+	GetSiteWebPush(ctx context.Context) (resp *schema.SiteWebPushResp, err error)
+	GetSiteDigest(ctx context.Context) (resp *schema.SiteDigestResp, err error)
+	GetSiteInboundEmail(ctx context.Context) (resp *schema.SiteInboundEmailResp, err error)
+	GetSiteNotificationRetention(ctx context.Context) (resp *schema.SiteNotificationRetentionResp, err error)
+	GetSiteSCIM(ctx context.Context) (resp *schema.SiteSCIMResp, err error)
+	GetSiteOAuthProvider(ctx context.Context) (resp *schema.SiteOAuthProviderResp, err error)
+	GetSiteLoginProtection(ctx context.Context) (resp *schema.SiteLoginProtectionResp, err error)
+	GetSiteInvite(ctx context.Context) (resp *schema.SiteInviteResp, err error)
 	GetSiteInfoByType(ctx context.Context, siteType string, resp interface{}) (err error)
 	IsBrandingFileUsed(ctx context.Context, filePath string) bool
 }
@@ -215,6 +254,83 @@ func (s *siteInfoCommonService) GetSiteSeo(ctx context.Context) (resp *schema.Si
 	return resp, nil
 }
 
+// GetSiteLimits get site content limits
+func (s *siteInfoCommonService) GetSiteLimits(ctx context.Context) (resp *schema.SiteLimitsResp, err error) {
+	resp = &schema.SiteLimitsResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeLimits, resp); err != nil {
+		return nil, err
+	}
+	resp.FillDefault()
+	return resp, nil
+}
+
+// GetSiteQuestionClose get site question close vote config
+func (s *siteInfoCommonService) GetSiteQuestionClose(ctx context.Context) (resp *schema.SiteQuestionCloseResp, err error) {
+	resp = &schema.SiteQuestionCloseResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeQuestionClose, resp); err != nil {
+		return nil, err
+	}
+	resp.FillDefault()
+	return resp, nil
+}
+
+// GetSiteRateLimit get site rate limit config
+func (s *siteInfoCommonService) GetSiteRateLimit(ctx context.Context) (resp *schema.SiteRateLimitResp, err error) {
+	resp = &schema.SiteRateLimitResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeRateLimit, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteDataDump get site public data dump config
+func (s *siteInfoCommonService) GetSiteDataDump(ctx context.Context) (resp *schema.SiteDataDumpResp, err error) {
+	resp = &schema.SiteDataDumpResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeDataDump, resp); err != nil {
+		return nil, err
+	}
+	resp.FillDefault()
+	return resp, nil
+}
+
+// GetSiteReputationSync get site reputation and role sync config
+func (s *siteInfoCommonService) GetSiteReputationSync(ctx context.Context) (resp *schema.SiteReputationSyncResp, err error) {
+	resp = &schema.SiteReputationSyncResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeReputationSync, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteChatIntake get site chat intake config
+func (s *siteInfoCommonService) GetSiteChatIntake(ctx context.Context) (resp *schema.SiteChatIntakeResp, err error) {
+	resp = &schema.SiteChatIntakeResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeChatIntake, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteVoteFraud get site vote fraud detection config
+func (s *siteInfoCommonService) GetSiteVoteFraud(ctx context.Context) (resp *schema.SiteVoteFraudResp, err error) {
+	resp = &schema.SiteVoteFraudResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeVoteFraud, resp); err != nil {
+		return nil, err
+	}
+	resp.FillDefault()
+	return resp, nil
+}
+
+// GetSiteAnswerRanking get site answer ranking config
+func (s *siteInfoCommonService) GetSiteAnswerRanking(ctx context.Context) (resp *schema.SiteAnswerRankingResp, err error) {
+	resp = &schema.SiteAnswerRankingResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeAnswerRanking, resp); err != nil {
+		return nil, err
+	}
+	resp.FillDefault()
+	return resp, nil
+}
+
 func (s *siteInfoCommonService) EnableShortID(ctx context.Context) (enabled bool) {
 	siteSeo, err := s.GetSiteSeo(ctx)
 	if err != nil {
@@ -236,6 +352,272 @@ func (s *siteInfoCommonService) GetSiteInfoByType(ctx context.Context, siteType
 	return nil
 }
 
+// GetSiteLanguageDetection get site content language detection config
+func (s *siteInfoCommonService) GetSiteLanguageDetection(ctx context.Context) (resp *schema.SiteLanguageDetectionResp, err error) {
+	resp = &schema.SiteLanguageDetectionResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeLanguageDetection, resp); err != nil {
+		return nil, err
+	}
+	resp.FillDefault()
+	return resp, nil
+}
+
+// GetSiteDownvoteCost get site downvote cost config
+func (s *siteInfoCommonService) GetSiteDownvoteCost(ctx context.Context) (resp *schema.SiteDownvoteCostResp, err error) {
+	resp = &schema.SiteDownvoteCostResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeDownvoteCost, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteSyndication get site question syndication config
+func (s *siteInfoCommonService) GetSiteSyndication(ctx context.Context) (resp *schema.SiteSyndicationResp, err error) {
+	resp = &schema.SiteSyndicationResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeSyndication, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteHelpfulnessSurvey get site helpfulness survey config
+func (s *siteInfoCommonService) GetSiteHelpfulnessSurvey(ctx context.Context) (resp *schema.SiteHelpfulnessSurveyResp, err error) {
+	resp = &schema.SiteHelpfulnessSurveyResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeHelpfulnessSurvey, resp); err != nil {
+		return nil, err
+	}
+	resp.FillDefault()
+	return resp, nil
+}
+
+// GetSiteReEngagement get site re-engagement email config
+func (s *siteInfoCommonService) GetSiteReEngagement(ctx context.Context) (resp *schema.SiteReEngagementResp, err error) {
+	resp = &schema.SiteReEngagementResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeReEngagement, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteQuestionQuality get site question quality config
+func (s *siteInfoCommonService) GetSiteQuestionQuality(ctx context.Context) (resp *schema.SiteQuestionQualityResp, err error) {
+	resp = &schema.SiteQuestionQualityResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeQuestionQuality, resp); err != nil {
+		return nil, err
+	}
+	resp.FillDefault()
+	return resp, nil
+}
+
+// GetSiteTagRules get site tag rules config
+func (s *siteInfoCommonService) GetSiteTagRules(ctx context.Context) (resp *schema.SiteTagRulesResp, err error) {
+	resp = &schema.SiteTagRulesResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeTagRules, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteVoteMilestone get site vote milestone config
+func (s *siteInfoCommonService) GetSiteVoteMilestone(ctx context.Context) (resp *schema.SiteVoteMilestoneResp, err error) {
+	resp = &schema.SiteVoteMilestoneResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeVoteMilestone, resp); err != nil {
+		return nil, err
+	}
+	resp.FillDefault()
+	return resp, nil
+}
+
+// GetSiteAutoComment get site auto comment config
+func (s *siteInfoCommonService) GetSiteAutoComment(ctx context.Context) (resp *schema.SiteAutoCommentResp, err error) {
+	resp = &schema.SiteAutoCommentResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeAutoComment, resp); err != nil {
+		return nil, err
+	}
+	resp.FillDefault()
+	return resp, nil
+}
+
+// GetSiteTagBlocklist get site tag blocklist config
+func (s *siteInfoCommonService) GetSiteTagBlocklist(ctx context.Context) (resp *schema.SiteTagBlocklistResp, err error) {
+	resp = &schema.SiteTagBlocklistResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeTagBlocklist, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteLoadShedding get site load shedding config
+func (s *siteInfoCommonService) GetSiteLoadShedding(ctx context.Context) (resp *schema.SiteLoadSheddingResp, err error) {
+	resp = &schema.SiteLoadSheddingResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeLoadShedding, resp); err != nil {
+		return nil, err
+	}
+	resp.FillDefault()
+	return resp, nil
+}
+
+// GetSiteAutoPromotion get site auto promotion config
+func (s *siteInfoCommonService) GetSiteAutoPromotion(ctx context.Context) (resp *schema.SiteAutoPromotionResp, err error) {
+	resp = &schema.SiteAutoPromotionResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeAutoPromotion, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteContentFreshness get site content freshness config
+func (s *siteInfoCommonService) GetSiteContentFreshness(ctx context.Context) (resp *schema.SiteContentFreshnessResp, err error) {
+	resp = &schema.SiteContentFreshnessResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeContentFreshness, resp); err != nil {
+		return nil, err
+	}
+	resp.FillDefault()
+	return resp, nil
+}
+
+// GetSiteQuestionMigration get site question migration config
+func (s *siteInfoCommonService) GetSiteQuestionMigration(ctx context.Context) (resp *schema.SiteQuestionMigrationResp, err error) {
+	resp = &schema.SiteQuestionMigrationResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeQuestionMigration, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteCacheWarming get site cache warming config
+func (s *siteInfoCommonService) GetSiteCacheWarming(ctx context.Context) (resp *schema.SiteCacheWarmingResp, err error) {
+	resp = &schema.SiteCacheWarmingResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeCacheWarming, resp); err != nil {
+		return nil, err
+	}
+	resp.FillDefault()
+	return resp, nil
+}
+
+// GetSiteWebhook get site webhook config
+func (s *siteInfoCommonService) GetSiteWebhook(ctx context.Context) (resp *schema.SiteWebhookResp, err error) {
+	resp = &schema.SiteWebhookResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeWebhook, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteSlack get site slack connector config
+func (s *siteInfoCommonService) GetSiteSlack(ctx context.Context) (resp *schema.SiteSlackResp, err error) {
+	resp = &schema.SiteSlackResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeSlack, resp); err != nil {
+		return nil, err
+	}
+	resp.FillDefault()
+	return resp, nil
+}
+
+// GetSiteJira get site jira connector config
+func (s *siteInfoCommonService) GetSiteJira(ctx context.Context) (resp *schema.SiteJiraResp, err error) {
+	resp = &schema.SiteJiraResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeJira, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteSpaces get site spaces config
+func (s *siteInfoCommonService) GetSiteSpaces(ctx context.Context) (resp *schema.SiteSpacesResp, err error) {
+	resp = &schema.SiteSpacesResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeSpaces, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteMessenger get site messenger connectors config
+func (s *siteInfoCommonService) GetSiteMessenger(ctx context.Context) (resp *schema.SiteMessengerResp, err error) {
+	resp = &schema.SiteMessengerResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeMessenger, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteWebPush get site web push config
+func (s *siteInfoCommonService) GetSiteWebPush(ctx context.Context) (resp *schema.SiteWebPushResp, err error) {
+	resp = &schema.SiteWebPushResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeWebPush, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteDigest get site email digest config
+func (s *siteInfoCommonService) GetSiteDigest(ctx context.Context) (resp *schema.SiteDigestResp, err error) {
+	resp = &schema.SiteDigestResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeDigest, resp); err != nil {
+		return nil, err
+	}
+	resp.FillDefault()
+	return resp, nil
+}
+
+// GetSiteInboundEmail get site inbound email config
+func (s *siteInfoCommonService) GetSiteInboundEmail(ctx context.Context) (resp *schema.SiteInboundEmailResp, err error) {
+	resp = &schema.SiteInboundEmailResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeInboundEmail, resp); err != nil {
+		return nil, err
+	}
+	resp.FillDefault()
+	return resp, nil
+}
+
+// GetSiteNotificationRetention get site notification retention config
+func (s *siteInfoCommonService) GetSiteNotificationRetention(ctx context.Context) (resp *schema.SiteNotificationRetentionResp, err error) {
+	resp = &schema.SiteNotificationRetentionResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeNotificationRetention, resp); err != nil {
+		return nil, err
+	}
+	resp.FillDefault()
+	return resp, nil
+}
+
+// GetSiteSCIM get site SCIM config
+func (s *siteInfoCommonService) GetSiteSCIM(ctx context.Context) (resp *schema.SiteSCIMResp, err error) {
+	resp = &schema.SiteSCIMResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeSCIM, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteOAuthProvider get site OAuth provider config
+func (s *siteInfoCommonService) GetSiteOAuthProvider(ctx context.Context) (resp *schema.SiteOAuthProviderResp, err error) {
+	resp = &schema.SiteOAuthProviderResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeOAuthProvider, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteLoginProtection get site login protection config
+func (s *siteInfoCommonService) GetSiteLoginProtection(ctx context.Context) (resp *schema.SiteLoginProtectionResp, err error) {
+	resp = &schema.SiteLoginProtectionResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeLoginProtection, resp); err != nil {
+		return nil, err
+	}
+	resp.FillDefault()
+	return resp, nil
+}
+
+// GetSiteInvite get site invite config
+func (s *siteInfoCommonService) GetSiteInvite(ctx context.Context) (resp *schema.SiteInviteResp, err error) {
+	resp = &schema.SiteInviteResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeInvite, resp); err != nil {
+		return nil, err
+	}
+	resp.FillDefault()
+	return resp, nil
+}
+
 func (s *siteInfoCommonService) IsBrandingFileUsed(ctx context.Context, filePath string) bool {
 	used, err := s.siteInfoRepo.IsBrandingFileUsed(ctx, filePath)
 	if err != nil {
@@ -0,0 +1,304 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/event_queue"
+	"github.com/apache/answer/internal/service/object_info"
+	questioncommon "github.com/apache/answer/internal/service/question_common"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/apache/answer/internal/service/space_common"
+	tagcommon "github.com/apache/answer/internal/service/tag_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/display"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// slackReminderInDays only the questions asked in the days are included in the reminder
+	slackReminderInDays = 7
+	// slackReminderMaxQuestions the max number of questions listed in one reminder
+	slackReminderMaxQuestions = 10
+)
+
+// slackMessage the message posted to the incoming webhook
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+// SlackService post the site events to the slack channels by incoming webhooks
+type SlackService struct {
+	questionRepo     questioncommon.QuestionRepo
+	tagCommonService *tagcommon.TagCommonService
+	objectService    *object_info.ObjService
+	userCommon       *usercommon.UserCommon
+	siteInfoService  siteinfo_common.SiteInfoCommonService
+	spaceCommon      *space_common.SpaceCommon
+	httpClient       *http.Client
+}
+
+// NewSlackService new slack service
+func NewSlackService(
+	questionRepo questioncommon.QuestionRepo,
+	tagCommonService *tagcommon.TagCommonService,
+	objectService *object_info.ObjService,
+	userCommon *usercommon.UserCommon,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	spaceCommon *space_common.SpaceCommon,
+	eventQueueService event_queue.EventQueueService,
+) *SlackService {
+	ss := &SlackService{
+		questionRepo:     questionRepo,
+		tagCommonService: tagCommonService,
+		objectService:    objectService,
+		userCommon:       userCommon,
+		siteInfoService:  siteInfoService,
+		spaceCommon:      spaceCommon,
+		httpClient:       &http.Client{Timeout: 10 * time.Second},
+	}
+	eventQueueService.RegisterHandler(ss.handleEvent)
+	return ss
+}
+
+func (ss *SlackService) handleEvent(ctx context.Context, msg *schema.EventMsg) error {
+	switch msg.EventType {
+	case constant.EventQuestionCreate, constant.EventQuestionFlag, constant.EventAnswerFlag, constant.EventCommentFlag:
+	default:
+		return nil
+	}
+	conf, err := ss.siteInfoService.GetSiteSlack(ctx)
+	if err != nil {
+		return err
+	}
+	if !conf.Enabled {
+		return nil
+	}
+	if msg.EventType == constant.EventQuestionCreate {
+		return ss.postNewQuestion(ctx, conf, msg)
+	}
+	return ss.postModerationAlert(ctx, conf, msg)
+}
+
+// postNewQuestion post the new question to the channels subscribed its tags
+func (ss *SlackService) postNewQuestion(ctx context.Context, conf *schema.SiteSlackResp, msg *schema.EventMsg) error {
+	objInfo, err := ss.objectService.GetInfo(ctx, msg.QuestionID)
+	if err != nil {
+		return err
+	}
+	// the question waiting for review is posted after it is approved, the shadow question is never posted
+	if objInfo.QuestionStatus != entity.QuestionStatusAvailable || objInfo.QuestionShow == entity.QuestionShadow {
+		return nil
+	}
+	objTags, err := ss.tagCommonService.GetObjectTag(ctx, objInfo.QuestionID)
+	if err != nil {
+		return err
+	}
+	// the question of the private space is not posted outside the site
+	if canView, err := ss.spaceCommon.CanViewTags(ctx, objTags, ""); err != nil || !canView {
+		return err
+	}
+	tags := make([]string, 0, len(objTags))
+	tagLabels := make([]string, 0, len(objTags))
+	for _, tag := range objTags {
+		tags = append(tags, tag.SlugName)
+		tagLabels = append(tagLabels, "`"+tag.SlugName+"`")
+	}
+	siteURL, permalink := ss.getSiteURL(ctx)
+	text := translator.TrWithData(ss.getSiteLang(ctx), reason.SlackNewQuestion, map[string]any{
+		"User": escape(ss.getUserName(ctx, msg.UserID)),
+		"Link": formatLink(display.QuestionURL(permalink, siteURL, objInfo.QuestionID, objInfo.Title), objInfo.Title),
+		"Tags": strings.Join(tagLabels, " "),
+	})
+	for _, channel := range conf.Channels {
+		if channel.NewQuestions && channel.MatchTags(tags) {
+			ss.post(ctx, channel, text)
+		}
+	}
+	return nil
+}
+
+// postModerationAlert post the reported content to the channels of moderators
+func (ss *SlackService) postModerationAlert(ctx context.Context, conf *schema.SiteSlackResp, msg *schema.EventMsg) error {
+	objInfo, err := ss.objectService.GetInfo(ctx, msg.TriggerObjectID)
+	if err != nil {
+		return err
+	}
+	siteURL, permalink := ss.getSiteURL(ctx)
+	var link string
+	switch objInfo.ObjectType {
+	case constant.AnswerObjectType:
+		link = display.AnswerURL(permalink, siteURL, objInfo.QuestionID, objInfo.Title, objInfo.AnswerID)
+	case constant.CommentObjectType:
+		link = display.CommentURL(permalink, siteURL, objInfo.QuestionID, objInfo.Title, objInfo.AnswerID, objInfo.CommentID)
+	default:
+		link = display.QuestionURL(permalink, siteURL, objInfo.QuestionID, objInfo.Title)
+	}
+	text := translator.TrWithData(ss.getSiteLang(ctx), reason.SlackContentReported, map[string]any{
+		"User": escape(ss.getUserName(ctx, msg.UserID)),
+		"Link": formatLink(link, objInfo.Title),
+	})
+	for _, channel := range conf.Channels {
+		if channel.ModerationAlerts {
+			ss.post(ctx, channel, text)
+		}
+	}
+	return nil
+}
+
+// UnansweredReminderCron post the recent questions still without answer to the channels subscribed the reminder
+func (ss *SlackService) UnansweredReminderCron(ctx context.Context) {
+	conf, err := ss.siteInfoService.GetSiteSlack(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if !conf.Enabled {
+		return
+	}
+	privateTagIDs, err := ss.spaceCommon.GetPrivateTagIDs(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	questions, _, err := ss.questionRepo.GetQuestionPage(ctx, 1, 100, nil, privateTagIDs, "", "unanswered",
+		slackReminderInDays, false, false)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	before := time.Now().Add(-time.Duration(conf.UnansweredHours) * time.Hour)
+	unanswered := make([]*entity.Question, 0, len(questions))
+	questionIDs := make([]string, 0, len(questions))
+	for _, question := range questions {
+		if question.CreatedAt.Before(before) {
+			unanswered = append(unanswered, question)
+			questionIDs = append(questionIDs, question.ID)
+		}
+	}
+	if len(unanswered) == 0 {
+		return
+	}
+	tagMapping, err := ss.tagCommonService.BatchGetObjectTag(ctx, questionIDs)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	siteURL, permalink := ss.getSiteURL(ctx)
+	lang := ss.getSiteLang(ctx)
+	for _, channel := range conf.Channels {
+		if !channel.UnansweredReminders {
+			continue
+		}
+		lines := make([]string, 0, slackReminderMaxQuestions+1)
+		for _, question := range unanswered {
+			tags := make([]string, 0, len(tagMapping[question.ID]))
+			for _, tag := range tagMapping[question.ID] {
+				tags = append(tags, tag.SlugName)
+			}
+			if !channel.MatchTags(tags) {
+				continue
+			}
+			lines = append(lines, "• "+formatLink(display.QuestionURL(permalink, siteURL, question.ID, question.Title), question.Title))
+			if len(lines) == slackReminderMaxQuestions {
+				break
+			}
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		header := translator.TrWithData(lang, reason.SlackUnansweredReminder, map[string]any{
+			"Count": len(lines),
+			"Hours": conf.UnansweredHours,
+		})
+		ss.post(ctx, channel, header+"\n"+strings.Join(lines, "\n"))
+	}
+}
+
+// post the message to the channel, the failed message is dropped because it is only a notification
+func (ss *SlackService) post(ctx context.Context, channel *schema.SlackChannel, text string) {
+	body, _ := json.Marshal(&slackMessage{Text: text})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, channel.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := ss.httpClient.Do(req)
+	if err != nil {
+		log.Errorf("post slack message to %s failed: %v", channel.Name, err)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		log.Errorf("post slack message to %s failed: %v", channel.Name, fmt.Errorf("response status %d", resp.StatusCode))
+	}
+}
+
+func (ss *SlackService) getUserName(ctx context.Context, userID string) string {
+	userInfo, exist, err := ss.userCommon.GetUserBasicInfoByID(ctx, userID)
+	if err != nil || !exist {
+		return userID
+	}
+	return userInfo.DisplayName
+}
+
+func (ss *SlackService) getSiteURL(ctx context.Context) (siteURL string, permalink int) {
+	permalink = constant.PermalinkQuestionID
+	if siteSeo, err := ss.siteInfoService.GetSiteSeo(ctx); err == nil {
+		permalink = siteSeo.Permalink
+	}
+	if siteGeneral, err := ss.siteInfoService.GetSiteGeneral(ctx); err == nil {
+		siteURL = siteGeneral.SiteUrl
+	}
+	return siteURL, permalink
+}
+
+func (ss *SlackService) getSiteLang(ctx context.Context) i18n.Language {
+	interfaceInfo, err := ss.siteInfoService.GetSiteInterface(ctx)
+	if err != nil {
+		return i18n.DefaultLanguage
+	}
+	return i18n.Language(interfaceInfo.Language)
+}
+
+// escape the control characters of slack message markup
+func escape(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
+
+// formatLink format the link in slack message markup
+func formatLink(link, title string) string {
+	return fmt.Sprintf("<%s|%s>", link, escape(title))
+}
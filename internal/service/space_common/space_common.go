@@ -0,0 +1,290 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package space_common
+
+import (
+	"context"
+	"strings"
+
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/role"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	tagcommon "github.com/apache/answer/internal/service/tag_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// SpaceCommon resolve the space of the request, whose settings override the site settings
+type SpaceCommon struct {
+	siteInfoService    siteinfo_common.SiteInfoCommonService
+	tagCommonService   *tagcommon.TagCommonService
+	userCommon         *usercommon.UserCommon
+	userRoleRelService *role.UserRoleRelService
+}
+
+// NewSpaceCommon new space common service
+func NewSpaceCommon(
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	tagCommonService *tagcommon.TagCommonService,
+	userCommon *usercommon.UserCommon,
+	userRoleRelService *role.UserRoleRelService,
+) *SpaceCommon {
+	return &SpaceCommon{
+		siteInfoService:    siteInfoService,
+		tagCommonService:   tagCommonService,
+		userCommon:         userCommon,
+		userRoleRelService: userRoleRelService,
+	}
+}
+
+// ResolveSpace resolve the space by the slug, or by the tag if the slug is empty, the synonym resolves to the space
+// of its main tag, nil if none resolved
+func (sc *SpaceCommon) ResolveSpace(ctx context.Context, slug, tagSlugName string) (space *schema.SiteSpace, err error) {
+	if len(slug) == 0 && len(tagSlugName) == 0 {
+		return nil, nil
+	}
+	spaces, err := sc.siteInfoService.GetSiteSpaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(spaces.Spaces) == 0 {
+		return nil, nil
+	}
+	if len(slug) > 0 {
+		return spaces.GetSpace(slug), nil
+	}
+	tagNames := []string{tagSlugName}
+	tag, exist, err := sc.tagCommonService.GetTagBySlugName(ctx, strings.ToLower(tagSlugName))
+	if err != nil {
+		return nil, err
+	}
+	if exist && len(tag.MainTagSlugName) > 0 {
+		tagNames = append(tagNames, tag.MainTagSlugName)
+	}
+	return spaces.MatchSpace(tagNames), nil
+}
+
+// ResolveSpaceByTags resolve the space of the question with the tags, nil if none resolved
+func (sc *SpaceCommon) ResolveSpaceByTags(ctx context.Context, tagNames []string) (space *schema.SiteSpace, err error) {
+	spaces, err := sc.siteInfoService.GetSiteSpaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return spaces.MatchSpace(tagNames), nil
+}
+
+// ResolveQuestionSpace resolve the space of the question by its tags, nil if none resolved
+func (sc *SpaceCommon) ResolveQuestionSpace(ctx context.Context, questionID string) (space *schema.SiteSpace, err error) {
+	objTags, err := sc.tagCommonService.GetObjectTag(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	tagNames := make([]string, 0, len(objTags))
+	for _, tag := range objTags {
+		tagNames = append(tagNames, tag.SlugName)
+	}
+	return sc.ResolveSpaceByTags(ctx, tagNames)
+}
+
+// GetSpaceTagIDs get the ids of the tags of the space including their synonyms
+func (sc *SpaceCommon) GetSpaceTagIDs(ctx context.Context, space *schema.SiteSpace) (tagIDs []string, err error) {
+	tags, err := sc.tagCommonService.GetTagListByNames(ctx, space.Tags)
+	if err != nil {
+		return nil, err
+	}
+	tagIDs = make([]string, 0, len(tags))
+	for _, tag := range tags {
+		synTagIDs, err := sc.tagCommonService.GetTagIDsByMainTagID(ctx, tag.ID)
+		if err != nil {
+			return nil, err
+		}
+		tagIDs = append(tagIDs, synTagIDs...)
+		tagIDs = append(tagIDs, tag.ID)
+	}
+	return tagIDs, nil
+}
+
+// CheckWrite check the user can ask or answer in the space, nil space means no restriction
+func (sc *SpaceCommon) CheckWrite(ctx context.Context, space *schema.SiteSpace, userID string) (err error) {
+	if space == nil || (!space.ModeratorOnly && space.MinReputation <= 0) {
+		return nil
+	}
+	roleID, err := sc.userRoleRelService.GetUserRole(ctx, userID)
+	if err != nil {
+		return err
+	}
+	isModerator := roleID == role.RoleAdminID || roleID == role.RoleModeratorID
+	userInfo, exist, err := sc.userCommon.GetUserBasicInfoByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	rank := 0
+	if exist {
+		rank = userInfo.Rank
+	}
+	if space.CanWrite(isModerator, rank) {
+		return nil
+	}
+	errReason := reason.SpaceWriteReputationRequired
+	if space.ModeratorOnly {
+		errReason = reason.SpaceWriteModeratorOnly
+	}
+	errMsg := translator.TrWithData(handler.GetLangByCtx(ctx), errReason, map[string]any{
+		"Space":      space.Name,
+		"Reputation": space.MinReputation,
+	})
+	return errors.Forbidden(errReason).WithMsg(errMsg)
+}
+
+// GetInvisibleSpaces get the private spaces whose content can not be viewed by the user, the guest can view none of them
+func (sc *SpaceCommon) GetInvisibleSpaces(ctx context.Context, userID string) (spaces []*schema.SiteSpace, err error) {
+	siteSpaces, err := sc.siteInfoService.GetSiteSpaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !siteSpaces.HasPrivateSpace() {
+		return nil, nil
+	}
+	roleID := 0
+	if len(userID) > 0 {
+		roleID, err = sc.userRoleRelService.GetUserRole(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return siteSpaces.InvisibleSpaces(roleID, roleID == role.RoleAdminID), nil
+}
+
+// GetInvisibleTagIDs get the ids of the tags of the private spaces which can not be viewed by the user,
+// the questions carrying them should be excluded from the lists
+func (sc *SpaceCommon) GetInvisibleTagIDs(ctx context.Context, userID string) (tagIDs []string, err error) {
+	spaces, err := sc.GetInvisibleSpaces(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	tagIDs = make([]string, 0)
+	for _, space := range spaces {
+		spaceTagIDs, err := sc.GetSpaceTagIDs(ctx, space)
+		if err != nil {
+			return nil, err
+		}
+		tagIDs = append(tagIDs, spaceTagIDs...)
+	}
+	return tagIDs, nil
+}
+
+// GetPrivateTagIDs get the ids of the tags of all private spaces, for the content published to the guests of other sites
+func (sc *SpaceCommon) GetPrivateTagIDs(ctx context.Context) (tagIDs []string, err error) {
+	return sc.GetInvisibleTagIDs(ctx, "")
+}
+
+// CanViewSpace whether the user can view the content of the space, nil space means no restriction
+func (sc *SpaceCommon) CanViewSpace(ctx context.Context, space *schema.SiteSpace, userID string) (can bool, err error) {
+	if space == nil || !space.Private {
+		return true, nil
+	}
+	spaces, err := sc.GetInvisibleSpaces(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	for _, s := range spaces {
+		if strings.EqualFold(s.Slug, space.Slug) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// CanViewTags whether the user can view the question with the tags, the question belongs to all spaces of its tags
+func (sc *SpaceCommon) CanViewTags(ctx context.Context, tags []*schema.TagResp, userID string) (can bool, err error) {
+	spaces, err := sc.GetInvisibleSpaces(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if len(spaces) == 0 {
+		return true, nil
+	}
+	return !HasInvisibleTag(spaces, tags), nil
+}
+
+// CanViewQuestion whether the user can view the question and its answers and comments
+func (sc *SpaceCommon) CanViewQuestion(ctx context.Context, questionID, userID string) (can bool, err error) {
+	spaces, err := sc.GetInvisibleSpaces(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if len(spaces) == 0 {
+		return true, nil
+	}
+	tags, err := sc.tagCommonService.GetObjectTag(ctx, uid.DeShortID(questionID))
+	if err != nil {
+		return false, err
+	}
+	return !HasInvisibleTag(spaces, tags), nil
+}
+
+// GetVisibleQuestionIDs get the ids of the questions which can be viewed by the user
+func (sc *SpaceCommon) GetVisibleQuestionIDs(ctx context.Context, questionIDs []string, userID string) (
+	visible map[string]bool, err error) {
+	visible = make(map[string]bool, len(questionIDs))
+	spaces, err := sc.GetInvisibleSpaces(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(spaces) == 0 {
+		for _, questionID := range questionIDs {
+			visible[questionID] = true
+		}
+		return visible, nil
+	}
+	objectIDs := make([]string, 0, len(questionIDs))
+	for _, questionID := range questionIDs {
+		objectIDs = append(objectIDs, uid.DeShortID(questionID))
+	}
+	tagMapping, err := sc.tagCommonService.BatchGetObjectTag(ctx, objectIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, questionID := range questionIDs {
+		visible[questionID] = !HasInvisibleTag(spaces, tagMapping[uid.DeShortID(questionID)])
+	}
+	return visible, nil
+}
+
+// HasInvisibleTag whether the question with the tags belongs to any of the invisible spaces
+func HasInvisibleTag(spaces []*schema.SiteSpace, tags []*schema.TagResp) bool {
+	tagNames := make([]string, 0, len(tags)*2)
+	for _, tag := range tags {
+		tagNames = append(tagNames, tag.SlugName)
+		if len(tag.MainTagSlugName) > 0 {
+			tagNames = append(tagNames, tag.MainTagSlugName)
+		}
+	}
+	for _, space := range spaces {
+		if space.HasAnyTag(tagNames) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,366 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package syndication
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
+	"github.com/apache/answer/internal/base/validator"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/content"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/apache/answer/internal/service/space_common"
+	"github.com/apache/answer/internal/service/syndication_common"
+	tagcommon "github.com/apache/answer/internal/service/tag_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/display"
+	"github.com/apache/answer/pkg/token"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+)
+
+// syndicationPushBatchSize the number of questions pushed to one target in one run
+const syndicationPushBatchSize = 50
+
+// SyndicationService push the questions with configured tags to peer instances,
+// and keep the questions pushed from peer instances as read-only mirrors
+type SyndicationService struct {
+	syndicationRepo  syndication_common.SyndicationRepo
+	questionService  *content.QuestionService
+	tagCommonService *tagcommon.TagCommonService
+	siteInfoService  siteinfo_common.SiteInfoCommonService
+	userCommon       *usercommon.UserCommon
+	spaceCommon      *space_common.SpaceCommon
+	httpClient       *http.Client
+	running          atomic.Bool
+}
+
+// NewSyndicationService new syndication service
+func NewSyndicationService(
+	syndicationRepo syndication_common.SyndicationRepo,
+	questionService *content.QuestionService,
+	tagCommonService *tagcommon.TagCommonService,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	userCommon *usercommon.UserCommon,
+	spaceCommon *space_common.SpaceCommon,
+) *SyndicationService {
+	return &SyndicationService{
+		syndicationRepo:  syndicationRepo,
+		questionService:  questionService,
+		tagCommonService: tagCommonService,
+		siteInfoService:  siteInfoService,
+		userCommon:       userCommon,
+		spaceCommon:      spaceCommon,
+		httpClient:       &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// ReceiveWebhook verify the webhook pushed from peer instance and create or update the mirrored question
+func (ss *SyndicationService) ReceiveWebhook(ctx context.Context, req *schema.ReceiveSyndicationWebhookReq) (err error) {
+	conf, err := ss.siteInfoService.GetSiteSyndication(ctx)
+	if err != nil {
+		return err
+	}
+	if !conf.Enabled || len(conf.Secret) == 0 {
+		return errors.Forbidden(reason.SyndicationDisabled)
+	}
+	skew := time.Now().Unix() - req.Timestamp
+	if skew < 0 {
+		skew = -skew
+	}
+	signature := strings.TrimPrefix(req.Signature, "sha256=")
+	if skew > schema.SyndicationMaxClockSkewSeconds ||
+		!token.VerifySignature(conf.Secret, req.Timestamp, req.Body, signature) {
+		return errors.Unauthorized(reason.SyndicationSignatureInvalid)
+	}
+
+	payload := &schema.SyndicationWebhookPayload{}
+	if err = json.Unmarshal(req.Body, payload); err != nil {
+		return errors.BadRequest(reason.RequestFormatError).WithError(err)
+	}
+	if _, err = validator.GetValidatorByLang(handler.GetLangByCtx(ctx)).Check(payload); err != nil {
+		return err
+	}
+	if !conf.IsAllowedSource(payload.SourceSite) {
+		return errors.Forbidden(reason.SyndicationSourceNotAllowed)
+	}
+	mirrorUser, exist, err := ss.userCommon.GetByUsername(ctx, conf.MirrorUsername)
+	if err != nil {
+		return err
+	}
+	if !exist || mirrorUser.Status != entity.UserStatusAvailable {
+		return errors.BadRequest(reason.SyndicationMirrorUserNotFound)
+	}
+
+	sourceSite := strings.TrimSuffix(payload.SourceSite, "/")
+	question := payload.Question
+	sourceUpdatedAt := time.Unix(question.UpdatedAt, 0)
+	link, exist, err := ss.syndicationRepo.GetLink(ctx, entity.SyndicationDirectionMirror, sourceSite, question.ID)
+	if err != nil {
+		return err
+	}
+	// the webhook may be retried or arrive out of order, the older version is ignored
+	if exist && !sourceUpdatedAt.After(link.SourceUpdatedAt) {
+		return nil
+	}
+
+	tags := make([]*schema.TagItem, 0, len(question.Tags))
+	for _, tag := range question.Tags {
+		tags = append(tags, &schema.TagItem{SlugName: tag, DisplayName: tag})
+	}
+	mirrorContent := question.Content + "\n\n---\n\n" + translator.TrWithData(ss.getSiteLang(ctx),
+		reason.SyndicationMirroredFrom, map[string]string{"SourceSite": sourceSite, "SourceURL": question.URL})
+	permission := schema.QuestionPermission{
+		CanAdd:            true,
+		CanEdit:           true,
+		CanUseReservedTag: true,
+		CanAddTag:         true,
+	}
+
+	if exist {
+		updateReq := &schema.QuestionUpdate{
+			ID:                 link.LocalQuestionID,
+			Title:              question.Title,
+			Content:            mirrorContent,
+			Tags:               tags,
+			UserID:             mirrorUser.ID,
+			NoNeedReview:       true,
+			SyndicationSync:    true,
+			QuestionPermission: permission,
+		}
+		if _, err = updateReq.Check(); err != nil {
+			return err
+		}
+		if _, err = ss.questionService.UpdateQuestion(ctx, updateReq); err != nil {
+			return err
+		}
+	} else {
+		addReq := &schema.QuestionAdd{
+			Title:              question.Title,
+			Content:            mirrorContent,
+			Tags:               tags,
+			UserID:             mirrorUser.ID,
+			QuestionPermission: permission,
+		}
+		if _, err = addReq.Check(); err != nil {
+			return err
+		}
+		resp, err := ss.questionService.AddQuestion(ctx, addReq)
+		if err != nil {
+			return err
+		}
+		questionInfo, ok := resp.(*schema.QuestionInfoResp)
+		if !ok {
+			return errors.InternalServer(reason.UnknownError)
+		}
+		link = &entity.SyndicationLink{
+			Direction:        entity.SyndicationDirectionMirror,
+			PeerURL:          sourceSite,
+			SourceQuestionID: question.ID,
+			LocalQuestionID:  uid.DeShortID(questionInfo.ID),
+		}
+	}
+	link.SourceURL = question.URL
+	link.SourceUpdatedAt = sourceUpdatedAt
+	return ss.syndicationRepo.SaveLink(ctx, link)
+}
+
+// PushCron push the questions with the target tags posted or updated since last push to each target.
+// If a push failed, the rest questions of the target are pushed again next time.
+func (ss *SyndicationService) PushCron(ctx context.Context) {
+	if !ss.running.CompareAndSwap(false, true) {
+		return
+	}
+	defer ss.running.Store(false)
+
+	conf, err := ss.siteInfoService.GetSiteSyndication(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if !conf.Enabled || len(conf.Secret) == 0 || len(conf.Targets) == 0 {
+		return
+	}
+	siteGeneral, err := ss.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	permalink := constant.PermalinkQuestionID
+	if siteSeo, err := ss.siteInfoService.GetSiteSeo(ctx); err == nil {
+		permalink = siteSeo.Permalink
+	}
+	for _, target := range conf.Targets {
+		ss.pushTarget(ctx, conf.Secret, siteGeneral.SiteUrl, permalink, target)
+	}
+}
+
+func (ss *SyndicationService) pushTarget(ctx context.Context, secret, siteURL string, permalink int,
+	target *schema.SyndicationTarget) {
+	tagList, err := ss.tagCommonService.GetTagListByNames(ctx, target.Tags)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	tagIDs := make([]string, 0, len(tagList))
+	for _, tag := range tagList {
+		tagIDs = append(tagIDs, tag.ID)
+	}
+	cursor, err := ss.syndicationRepo.GetPushCursor(ctx, target.WebhookURL)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	// the questions of the private spaces are never pushed to the peers
+	privateTagIDs, err := ss.spaceCommon.GetPrivateTagIDs(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	questions, err := ss.syndicationRepo.GetQuestionsByTagsUpdatedSince(ctx, tagIDs, privateTagIDs, cursor,
+		syndicationPushBatchSize)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	for _, question := range questions {
+		// the mirrored questions are never pushed back, otherwise they would be synced between instances endlessly
+		_, mirror, err := ss.syndicationRepo.GetMirrorLinkByQuestionID(ctx, question.ID)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		if mirror {
+			continue
+		}
+		link, exist, err := ss.syndicationRepo.GetLink(ctx, entity.SyndicationDirectionPush, target.WebhookURL, question.ID)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		if exist && !question.PostUpdateTime.After(link.SourceUpdatedAt) {
+			continue
+		}
+		payload, err := ss.buildPayload(ctx, siteURL, permalink, question)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		if err = ss.send(ctx, target.WebhookURL, secret, payload); err != nil {
+			log.Errorf("push question %s to %s failed: %v", question.ID, target.WebhookURL, err)
+			return
+		}
+		if !exist {
+			link = &entity.SyndicationLink{
+				Direction:        entity.SyndicationDirectionPush,
+				PeerURL:          target.WebhookURL,
+				SourceQuestionID: question.ID,
+				LocalQuestionID:  question.ID,
+			}
+		}
+		link.SourceURL = payload.Question.URL
+		link.SourceUpdatedAt = question.PostUpdateTime
+		if err = ss.syndicationRepo.SaveLink(ctx, link); err != nil {
+			log.Error(err)
+			return
+		}
+	}
+}
+
+func (ss *SyndicationService) buildPayload(ctx context.Context, siteURL string, permalink int,
+	question *entity.Question) (payload *schema.SyndicationWebhookPayload, err error) {
+	objTags, err := ss.tagCommonService.GetObjectTag(ctx, question.ID)
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]string, 0, len(objTags))
+	for _, tag := range objTags {
+		tags = append(tags, tag.SlugName)
+	}
+	authorName := ""
+	author, exist, err := ss.userCommon.GetUserBasicInfoByID(ctx, question.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		authorName = author.DisplayName
+	}
+	return &schema.SyndicationWebhookPayload{
+		Event:      schema.SyndicationEventQuestionSync,
+		SourceSite: siteURL,
+		Question: &schema.SyndicationQuestion{
+			ID:         question.ID,
+			Title:      question.Title,
+			Content:    question.OriginalText,
+			Tags:       tags,
+			URL:        display.QuestionURL(permalink, siteURL, question.ID, question.Title),
+			AuthorName: authorName,
+			CreatedAt:  question.CreatedAt.Unix(),
+			UpdatedAt:  question.PostUpdateTime.Unix(),
+		},
+	}, nil
+}
+
+func (ss *SyndicationService) send(ctx context.Context, webhookURL, secret string,
+	payload *schema.SyndicationWebhookPayload) (err error) {
+	body, _ := json.Marshal(payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Answer/"+constant.Version)
+	req.Header.Set(schema.SyndicationTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(schema.SyndicationSignatureHeader, "sha256="+token.Sign(secret, timestamp, body))
+	resp, err := ss.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook response status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (ss *SyndicationService) getSiteLang(ctx context.Context) i18n.Language {
+	interfaceInfo, err := ss.siteInfoService.GetSiteInterface(ctx)
+	if err != nil {
+		return i18n.DefaultLanguage
+	}
+	return i18n.Language(interfaceInfo.Language)
+}
@@ -0,0 +1,72 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package syndication_common
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+)
+
+// SyndicationRepo syndication repository
+type SyndicationRepo interface {
+	GetLink(ctx context.Context, direction, peerURL, sourceQuestionID string) (
+		link *entity.SyndicationLink, exist bool, err error)
+	GetMirrorLinkByQuestionID(ctx context.Context, questionID string) (
+		link *entity.SyndicationLink, exist bool, err error)
+	SaveLink(ctx context.Context, link *entity.SyndicationLink) (err error)
+	GetPushCursor(ctx context.Context, peerURL string) (cursor time.Time, err error)
+	GetQuestionsByTagsUpdatedSince(ctx context.Context, tagIDs, excludedTagIDs []string, since time.Time, limit int) (
+		questions []*entity.Question, err error)
+}
+
+// SyndicationCommon syndication common service
+type SyndicationCommon struct {
+	syndicationRepo SyndicationRepo
+}
+
+// NewSyndicationCommon new syndication common service
+func NewSyndicationCommon(syndicationRepo SyndicationRepo) *SyndicationCommon {
+	return &SyndicationCommon{
+		syndicationRepo: syndicationRepo,
+	}
+}
+
+// GetQuestionSyndicationInfo get the source of mirrored question, nil if the question is not a mirror
+func (sc *SyndicationCommon) GetQuestionSyndicationInfo(ctx context.Context, questionID string) (
+	info *schema.QuestionSyndicationInfo, err error) {
+	link, exist, err := sc.syndicationRepo.GetMirrorLinkByQuestionID(ctx, questionID)
+	if err != nil || !exist {
+		return nil, err
+	}
+	return &schema.QuestionSyndicationInfo{
+		SourceSite: link.PeerURL,
+		SourceURL:  link.SourceURL,
+		ReadOnly:   true,
+	}, nil
+}
+
+// IsMirrorQuestion whether the question is a read-only mirror of the peer question
+func (sc *SyndicationCommon) IsMirrorQuestion(ctx context.Context, questionID string) (mirror bool, err error) {
+	_, mirror, err = sc.syndicationRepo.GetMirrorLinkByQuestionID(ctx, questionID)
+	return mirror, err
+}
@@ -0,0 +1,167 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package table_partition
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/service_config"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// defaultMonthsAhead the number of months partitions are created in advance
+	defaultMonthsAhead = 3
+	// monthPartitionLayout the month in partition name, like p202601
+	monthPartitionLayout = "200601"
+)
+
+// partitionTables the write-heavy tables which grow with time, they are partitioned by month of created_at
+var partitionTables = []string{
+	entity.Activity{}.TableName(),
+	entity.Notification{}.TableName(),
+}
+
+// TablePartitionRepo table partition repository, every supported database has its own partition syntax
+type TablePartitionRepo interface {
+	IsSupported() bool
+	IsPartitioned(ctx context.Context, table string) (partitioned bool, err error)
+	GetPartitionMonths(ctx context.Context, table string) (months []time.Time, err error)
+	AddMonthPartition(ctx context.Context, table string, month time.Time) (err error)
+	DropMonthPartition(ctx context.Context, table string, month time.Time) (err error)
+}
+
+// TablePartitionService create the partitions of next months and drop the expired ones
+type TablePartitionService struct {
+	tablePartitionRepo TablePartitionRepo
+	serviceConfig      *service_config.ServiceConfig
+}
+
+// NewTablePartitionService new table partition service
+func NewTablePartitionService(
+	tablePartitionRepo TablePartitionRepo,
+	serviceConfig *service_config.ServiceConfig,
+) *TablePartitionService {
+	return &TablePartitionService{
+		tablePartitionRepo: tablePartitionRepo,
+		serviceConfig:      serviceConfig,
+	}
+}
+
+// MonthPartitionName the partition name of month
+func MonthPartitionName(month time.Time) string {
+	return "p" + month.Format(monthPartitionLayout)
+}
+
+// ParseMonthPartitionName parse the month from partition name, the partition not created by this service is not parsed
+func ParseMonthPartitionName(name string) (month time.Time, ok bool) {
+	if len(name) != len(monthPartitionLayout)+1 || name[0] != 'p' {
+		return time.Time{}, false
+	}
+	month, err := time.ParseInLocation(monthPartitionLayout, name[1:], time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return month, true
+}
+
+// MonthStart the first moment of the month of t
+func MonthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.Local)
+}
+
+// RotateCron the tables are only managed after they are partitioned by range of created_at manually,
+// because converting the existing large table is slow and must be done in the maintenance window.
+func (ts *TablePartitionService) RotateCron(ctx context.Context) {
+	if !ts.serviceConfig.TablePartitionEnabled {
+		return
+	}
+	if !ts.tablePartitionRepo.IsSupported() {
+		log.Warnf("table partition is not supported by the database")
+		return
+	}
+	for _, table := range partitionTables {
+		if err := ts.rotate(ctx, table, time.Now()); err != nil {
+			log.Errorf("rotate partitions of table %s failed: %v", table, err)
+		}
+	}
+}
+
+func (ts *TablePartitionService) rotate(ctx context.Context, table string, now time.Time) (err error) {
+	partitioned, err := ts.tablePartitionRepo.IsPartitioned(ctx, table)
+	if err != nil {
+		return err
+	}
+	if !partitioned {
+		log.Infof("table %s is not partitioned, skip", table)
+		return nil
+	}
+	months, err := ts.tablePartitionRepo.GetPartitionMonths(ctx, table)
+	if err != nil {
+		return err
+	}
+
+	monthsAhead := ts.serviceConfig.TablePartitionMonthsAhead
+	if monthsAhead <= 0 {
+		monthsAhead = defaultMonthsAhead
+	}
+	toAdd, toDrop := planPartitions(months, MonthStart(now), monthsAhead, ts.serviceConfig.TablePartitionRetentionMonths)
+	for _, month := range toAdd {
+		if err = ts.tablePartitionRepo.AddMonthPartition(ctx, table, month); err != nil {
+			return fmt.Errorf("add partition %s: %w", MonthPartitionName(month), err)
+		}
+		log.Infof("added partition %s of table %s", MonthPartitionName(month), table)
+	}
+	for _, month := range toDrop {
+		if err = ts.tablePartitionRepo.DropMonthPartition(ctx, table, month); err != nil {
+			return fmt.Errorf("drop partition %s: %w", MonthPartitionName(month), err)
+		}
+		log.Infof("dropped partition %s of table %s", MonthPartitionName(month), table)
+	}
+	return nil
+}
+
+// planPartitions get the months to add after the latest existing partition up to the months ahead,
+// and the months older than retention to drop. The partitions are never dropped if retention is not positive.
+func planPartitions(existing []time.Time, current time.Time, monthsAhead, retentionMonths int) (toAdd, toDrop []time.Time) {
+	latest := current.AddDate(0, -1, 0)
+	for _, month := range existing {
+		if month.After(latest) {
+			latest = month
+		}
+	}
+	last := current.AddDate(0, monthsAhead, 0)
+	for month := latest.AddDate(0, 1, 0); !month.After(last); month = month.AddDate(0, 1, 0) {
+		toAdd = append(toAdd, month)
+	}
+
+	if retentionMonths > 0 {
+		oldest := current.AddDate(0, -retentionMonths, 0)
+		for _, month := range existing {
+			if month.Before(oldest) {
+				toDrop = append(toDrop, month)
+			}
+		}
+	}
+	return toAdd, toDrop
+}
@@ -0,0 +1,53 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package table_partition
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func month(year int, m time.Month) time.Time {
+	return time.Date(year, m, 1, 0, 0, 0, 0, time.Local)
+}
+
+func TestMonthPartitionName(t *testing.T) {
+	assert.Equal(t, "p202602", MonthPartitionName(month(2026, time.February)))
+	parsed, ok := ParseMonthPartitionName("p202602")
+	assert.True(t, ok)
+	assert.Equal(t, month(2026, time.February), parsed)
+	_, ok = ParseMonthPartitionName("pmax")
+	assert.False(t, ok)
+}
+
+func TestPlanPartitions(t *testing.T) {
+	current := month(2026, time.January)
+
+	toAdd, toDrop := planPartitions(nil, current, 2, 0)
+	assert.Equal(t, []time.Time{month(2026, time.January), month(2026, time.February), month(2026, time.March)}, toAdd)
+	assert.Empty(t, toDrop)
+
+	existing := []time.Time{month(2025, time.June), month(2025, time.December), month(2026, time.January), month(2026, time.February)}
+	toAdd, toDrop = planPartitions(existing, current, 2, 6)
+	assert.Equal(t, []time.Time{month(2026, time.March)}, toAdd)
+	assert.Equal(t, []time.Time{month(2025, time.June)}, toDrop)
+}
@@ -23,9 +23,11 @@ import (
 	"context"
 	"encoding/json"
 	"strings"
+	"sync/atomic"
 
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/service/activity_queue"
+	"github.com/apache/answer/internal/service/audit_log"
 	"github.com/apache/answer/internal/service/revision_common"
 	"github.com/apache/answer/internal/service/siteinfo_common"
 	tagcommonser "github.com/apache/answer/internal/service/tag_common"
@@ -43,14 +45,27 @@ import (
 	"github.com/segmentfault/pacman/log"
 )
 
+// TagIgnoreRepo the tags ignored by users
+type TagIgnoreRepo interface {
+	AddTagIgnore(ctx context.Context, userID, tagID string) (err error)
+	RemoveTagIgnore(ctx context.Context, userID, tagID string) (err error)
+	GetIgnoredTagIDs(ctx context.Context, userID string) (tagIDs []string, err error)
+	IsIgnored(ctx context.Context, userID, tagID string) (ignored bool, err error)
+}
+
 // TagService user service
 type TagService struct {
 	tagRepo              tagcommonser.TagRepo
 	tagCommonService     *tagcommonser.TagCommonService
 	revisionService      *revision_common.RevisionService
 	followCommon         activity_common.FollowRepo
+	activityRepo         activity_common.ActivityRepo
 	siteInfoService      siteinfo_common.SiteInfoCommonService
 	activityQueueService activity_queue.ActivityQueueService
+	tagIgnoreRepo        TagIgnoreRepo
+	auditLogService      *audit_log.AuditLogService
+	remapRunning         atomic.Bool
+	burninateRunning     atomic.Bool
 }
 
 // NewTagService new tag service
@@ -59,16 +74,22 @@ func NewTagService(
 	tagCommonService *tagcommonser.TagCommonService,
 	revisionService *revision_common.RevisionService,
 	followCommon activity_common.FollowRepo,
+	activityRepo activity_common.ActivityRepo,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
 	activityQueueService activity_queue.ActivityQueueService,
+	tagIgnoreRepo TagIgnoreRepo,
+	auditLogService *audit_log.AuditLogService,
 ) *TagService {
 	return &TagService{
 		tagRepo:              tagRepo,
 		tagCommonService:     tagCommonService,
 		revisionService:      revisionService,
 		followCommon:         followCommon,
+		activityRepo:         activityRepo,
 		siteInfoService:      siteInfoService,
 		activityQueueService: activityQueueService,
+		tagIgnoreRepo:        tagIgnoreRepo,
+		auditLogService:      auditLogService,
 	}
 }
 
@@ -92,11 +113,24 @@ func (ts *TagService) RemoveTag(ctx context.Context, req *schema.RemoveTagReq) (
 		return errors.BadRequest(reason.TagIsUsedCannotDelete)
 	}
 
+	tagInfo, exist, err := ts.tagCommonService.GetTagByID(ctx, req.TagID)
+	if err != nil {
+		return err
+	}
 	// tagRelRepo
 	err = ts.tagRepo.RemoveTag(ctx, req.TagID)
 	if err != nil {
 		return err
 	}
+	if exist {
+		ts.auditLogService.Record(ctx, &schema.AuditLogRecord{
+			OperatorID: req.UserID,
+			Action:     entity.AuditLogActionTagDelete,
+			ObjectType: entity.AuditLogObjectTypeTag,
+			ObjectID:   tagInfo.ID,
+			Before:     map[string]string{"slug_name": tagInfo.SlugName, "display_name": tagInfo.DisplayName},
+		})
+	}
 	ts.activityQueueService.Send(ctx, &schema.ActivityMsg{
 		UserID:           req.UserID,
 		ObjectID:         req.TagID,
@@ -172,6 +206,7 @@ func (ts *TagService) GetTagInfo(ctx context.Context, req *schema.GetTagInfoReq)
 		}
 		resp.MainTagSlugName = tagInfo.SlugName
 	}
+	ts.tagCommonService.LocalizeTags(ctx, []*entity.Tag{tagInfo})
 	resp.TagID = tagInfo.ID
 	resp.CreatedAt = tagInfo.CreatedAt.Unix()
 	resp.UpdatedAt = tagInfo.UpdatedAt.Unix()
@@ -185,8 +220,21 @@ func (ts *TagService) GetTagInfo(ctx context.Context, req *schema.GetTagInfoReq)
 	resp.Recommend = tagInfo.Recommend
 	resp.Reserved = tagInfo.Reserved
 	resp.IsFollower = ts.checkTagIsFollow(ctx, req.UserID, tagInfo.ID)
+	resp.IsIgnored = ts.checkTagIsIgnored(ctx, req.UserID, tagInfo.ID)
 	resp.Status = entity.TagStatusDisplayMapping[tagInfo.Status]
 	resp.MemberActions = permission.GetTagPermission(ctx, tagInfo.Status, req.CanEdit, req.CanDelete, req.CanMerge, req.CanRecover)
+	resp.Deprecated = tagInfo.Deprecated
+	resp.ReplacementTags = make([]*schema.TagResp, 0)
+	if replacementTags := tagInfo.GetReplacementTags(); tagInfo.Deprecated && len(replacementTags) > 0 {
+		tagList, err := ts.tagCommonService.GetTagListByNames(ctx, replacementTags)
+		if err != nil {
+			return nil, err
+		}
+		resp.ReplacementTags, err = ts.tagCommonService.TagFormat(ctx, tagList)
+		if err != nil {
+			return nil, err
+		}
+	}
 	resp.GetExcerpt()
 	return resp, nil
 }
@@ -226,6 +274,7 @@ func (ts *TagService) GetFollowingTags(ctx context.Context, userID string) (
 	if err != nil {
 		return nil, err
 	}
+	ts.tagCommonService.LocalizeTags(ctx, tagList)
 	for _, t := range tagList {
 		tagInfo := &schema.GetFollowingTagsResp{
 			TagID:       t.ID,
@@ -248,6 +297,53 @@ func (ts *TagService) GetFollowingTags(ctx context.Context, userID string) (
 	return resp, nil
 }
 
+// IgnoreTag ignore the tag or stop ignoring it
+func (ts *TagService) IgnoreTag(ctx context.Context, req *schema.IgnoreTagReq) (err error) {
+	tagInfo, exist, err := ts.tagCommonService.GetTagByID(ctx, req.TagID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.TagNotFound)
+	}
+	if !req.Ignored {
+		return ts.tagIgnoreRepo.RemoveTagIgnore(ctx, req.UserID, tagInfo.ID)
+	}
+	return ts.tagIgnoreRepo.AddTagIgnore(ctx, req.UserID, tagInfo.ID)
+}
+
+// GetIgnoredTags get the tags ignored by user
+func (ts *TagService) GetIgnoredTags(ctx context.Context, userID string) (
+	resp []*schema.GetTagBasicResp, err error) {
+	resp = make([]*schema.GetTagBasicResp, 0)
+	if len(userID) == 0 {
+		return resp, nil
+	}
+	tagIDs, err := ts.tagIgnoreRepo.GetIgnoredTagIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	tagList, err := ts.tagCommonService.GetTagListByIDs(ctx, tagIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tagList {
+		tagItem := &schema.GetTagBasicResp{}
+		_ = copier.Copy(tagItem, t)
+		tagItem.TagID = t.ID
+		resp = append(resp, tagItem)
+	}
+	return resp, nil
+}
+
+// GetIgnoredTagIDs get the ids of the tags ignored by user, the questions carrying them are excluded from the feeds
+func (ts *TagService) GetIgnoredTagIDs(ctx context.Context, userID string) (tagIDs []string, err error) {
+	if len(userID) == 0 {
+		return nil, nil
+	}
+	return ts.tagIgnoreRepo.GetIgnoredTagIDs(ctx, userID)
+}
+
 // GetTagSynonyms get tag synonyms
 func (ts *TagService) GetTagSynonyms(ctx context.Context, req *schema.GetTagSynonymsReq) (
 	resp *schema.GetTagSynonymsResp, err error) {
@@ -395,6 +491,178 @@ func (ts *TagService) UpdateTagSynonym(ctx context.Context, req *schema.UpdateTa
 		if err != nil {
 			return err
 		}
+		ts.startRemapSynonymTags()
+	}
+	return nil
+}
+
+// AddTagSynonym mark the tag as the synonym of main tag, the posts tagged with the synonym are retagged in background
+func (ts *TagService) AddTagSynonym(ctx context.Context, req *schema.AddTagSynonymReq) (err error) {
+	req.Format()
+	mainTagInfo, exist, err := ts.tagCommonService.GetTagByID(ctx, req.MainTagID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.TagNotFound)
+	}
+	if mainTagInfo.MainTagID != 0 {
+		return errors.BadRequest(reason.TagCannotSetSynonymOfSynonym)
+	}
+	if mainTagInfo.SlugName == req.SynonymSlugName {
+		return errors.BadRequest(reason.TagCannotSetSynonymAsItself)
+	}
+
+	synonymTagInfo, exist, err := ts.tagCommonService.GetTagBySlugName(ctx, req.SynonymSlugName)
+	if err != nil {
+		return err
+	}
+	if exist {
+		synonymCount, err := ts.tagRepo.GetTagSynonymCount(ctx, synonymTagInfo.ID)
+		if err != nil {
+			return err
+		}
+		if synonymCount > 0 {
+			return errors.BadRequest(reason.TagSynonymHasSynonyms)
+		}
+	} else {
+		_, err = ts.tagCommonService.AddTag(ctx, &schema.AddTagReq{
+			SlugName:    req.SynonymSlugName,
+			DisplayName: req.SynonymSlugName,
+			UserID:      req.UserID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = ts.tagRepo.UpdateTagSynonym(ctx, []string{req.SynonymSlugName},
+		converter.StringToInt64(mainTagInfo.ID), mainTagInfo.SlugName)
+	if err != nil {
+		return err
+	}
+	ts.startRemapSynonymTags()
+	return nil
+}
+
+// RemoveTagSynonym remove the synonym relation, the posts that have been retagged keep the main tag
+func (ts *TagService) RemoveTagSynonym(ctx context.Context, req *schema.RemoveTagSynonymReq) (err error) {
+	tagInfo, exist, err := ts.tagCommonService.GetTagByID(ctx, req.TagID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.TagNotFound)
+	}
+	if tagInfo.MainTagID == 0 {
+		return errors.BadRequest(reason.TagIsNotSynonym)
+	}
+	return ts.tagRepo.UpdateTagSynonym(ctx, []string{tagInfo.SlugName}, 0, "")
+}
+
+// RemapSynonymTagsCron retag the posts tagged with synonyms to their main tags.
+// It also covers the synonyms set before the posts were retagged automatically.
+func (ts *TagService) RemapSynonymTagsCron(ctx context.Context) {
+	if !ts.remapRunning.CompareAndSwap(false, true) {
+		return
+	}
+	defer ts.remapRunning.Store(false)
+	if err := ts.remapSynonymTags(ctx); err != nil {
+		log.Errorf("remap synonym tags failed: %v", err)
+	}
+}
+
+func (ts *TagService) startRemapSynonymTags() {
+	go ts.RemapSynonymTagsCron(context.Background())
+}
+
+func (ts *TagService) remapSynonymTags(ctx context.Context) (err error) {
+	synonymTagList, err := ts.tagRepo.GetSynonymTagList(ctx)
+	if err != nil {
+		return err
+	}
+	// the hidden and deleted posts are retagged too, so they keep the main tag after being recovered
+	for _, synonymTag := range synonymTagList {
+		mainTagID := converter.IntToString(synonymTag.MainTagID)
+		if err = ts.tagCommonService.MigrateTagQuestions(ctx, synonymTag.ID, mainTagID); err != nil {
+			return err
+		}
+		if err = ts.tagCommonService.RefreshTagQuestionCount(ctx, []string{mainTagID, synonymTag.ID}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeprecateTag mark the tag as deprecated so that it can't be added to new posts,
+// it is removed from all posts by the burninate cron once no available post uses it.
+func (ts *TagService) DeprecateTag(ctx context.Context, req *schema.DeprecateTagReq) (err error) {
+	tagInfo, exist, err := ts.tagCommonService.GetTagByID(ctx, req.TagID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.TagNotFound)
+	}
+
+	if len(req.ReplacementTags) > 0 {
+		replacementTags, err := ts.tagCommonService.GetTagListByNames(ctx, req.ReplacementTags)
+		if err != nil {
+			return err
+		}
+		if len(replacementTags) != len(req.ReplacementTags) {
+			return errors.BadRequest(reason.TagReplacementInvalid)
+		}
+		for _, replacement := range replacementTags {
+			if replacement.ID == tagInfo.ID || replacement.Deprecated {
+				return errors.BadRequest(reason.TagReplacementInvalid)
+			}
+		}
+	}
+
+	err = ts.tagRepo.UpdateTagDeprecation(ctx, tagInfo.ID, req.Deprecated, strings.Join(req.ReplacementTags, ","))
+	if err != nil {
+		return err
+	}
+	log.Infof("user %s set tag %s deprecated %v, replacements: %v", req.UserID, tagInfo.SlugName, req.Deprecated, req.ReplacementTags)
+	return nil
+}
+
+// BurninateDeprecatedTagsCron remove the deprecated tags that are no longer used by any available post
+func (ts *TagService) BurninateDeprecatedTagsCron(ctx context.Context) {
+	if !ts.burninateRunning.CompareAndSwap(false, true) {
+		return
+	}
+	defer ts.burninateRunning.Store(false)
+	if err := ts.burninateDeprecatedTags(ctx); err != nil {
+		log.Errorf("burninate deprecated tags failed: %v", err)
+	}
+}
+
+func (ts *TagService) burninateDeprecatedTags(ctx context.Context) (err error) {
+	deprecatedTagList, err := ts.tagRepo.GetDeprecatedTagList(ctx)
+	if err != nil {
+		return err
+	}
+	for _, deprecatedTag := range deprecatedTagList {
+		usage, err := ts.tagCommonService.CountTagRelByTagID(ctx, deprecatedTag.ID)
+		if err != nil {
+			return err
+		}
+		if usage > 0 {
+			continue
+		}
+		// the hidden and deleted posts still carry the tag, remove it from them as well
+		if err = ts.tagCommonService.DeleteTagRelListByTagID(ctx, deprecatedTag.ID); err != nil {
+			return err
+		}
+		if err = ts.tagRepo.RemoveTag(ctx, deprecatedTag.ID); err != nil {
+			return err
+		}
+		if err = ts.tagCommonService.RefreshTagQuestionCount(ctx, []string{deprecatedTag.ID}); err != nil {
+			return err
+		}
+		log.Infof("burninated deprecated tag %s", deprecatedTag.SlugName)
 	}
 	return nil
 }
@@ -412,6 +680,7 @@ func (ts *TagService) GetTagWithPage(ctx context.Context, req *schema.GetTagWith
 	if err != nil {
 		return
 	}
+	ts.tagCommonService.LocalizeTags(ctx, tags)
 
 	resp := make([]*schema.GetTagPageResp, 0)
 	for _, tag := range tags {
@@ -429,6 +698,7 @@ func (ts *TagService) GetTagWithPage(ctx context.Context, req *schema.GetTagWith
 			UpdatedAt:     tag.UpdatedAt.Unix(),
 			Recommend:     tag.Recommend,
 			Reserved:      tag.Reserved,
+			Deprecated:    tag.Deprecated,
 		}
 		item.GetExcerpt()
 		resp = append(resp, item)
@@ -436,62 +706,70 @@ func (ts *TagService) GetTagWithPage(ctx context.Context, req *schema.GetTagWith
 	return pager.NewPageModel(total, resp), nil
 }
 
-// MergeTag merge tag
-func (ts *TagService) MergeTag(ctx context.Context, req *schema.MergeTagReq) (err error) {
-	// 1. get source tag and its synonyms
+// MergeTag merge source tag into target tag, the posts, followers and synonyms of source tag are moved to target tag
+func (ts *TagService) MergeTag(ctx context.Context, req *schema.MergeTagReq) (resp *schema.MergeTagResp, err error) {
+	if req.SourceTagID == req.TargetTagID {
+		return nil, errors.BadRequest(reason.TagCannotSetSynonymAsItself)
+	}
 	sourceTag, exist, err := ts.tagCommonService.GetTagByID(ctx, req.SourceTagID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if !exist {
-		return errors.BadRequest(reason.TagNotFound)
-	}
-
-	sourceTagSynonyms, err := ts.tagRepo.GetTagList(ctx, &entity.Tag{MainTagID: converter.StringToInt64(sourceTag.ID)})
-	if err != nil {
-		return err
-	}
-
-	addSynonymTagList := make([]string, 0)
-	addSynonymTagList = append(addSynonymTagList, sourceTag.SlugName)
-	for _, tag := range sourceTagSynonyms {
-		addSynonymTagList = append(addSynonymTagList, tag.SlugName)
+		return nil, errors.BadRequest(reason.TagNotFound)
 	}
-
-	// 2. get target tag
 	targetTagInfo, exist, err := ts.tagCommonService.GetTagByID(ctx, req.TargetTagID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if !exist {
-		return errors.BadRequest(reason.TagNotFound)
+		return nil, errors.BadRequest(reason.TagNotFound)
 	}
-
-	// 3. update source tag and its synonyms as synonyms of target tag
-	if len(addSynonymTagList) > 0 {
-		err = ts.tagRepo.UpdateTagSynonym(ctx, addSynonymTagList, converter.StringToInt64(targetTagInfo.ID), targetTagInfo.SlugName)
-		if err != nil {
-			return err
-		}
+	if targetTagInfo.MainTagID > 0 {
+		return nil, errors.BadRequest(reason.TagCannotSetSynonymOfSynonym)
 	}
 
-	// 4. update tag followers
-	err = ts.followCommon.MigrateFollowers(ctx, sourceTag.ID, targetTagInfo.ID, "follow")
+	followActivityType, err := ts.activityRepo.GetActivityTypeByObjectType(ctx, constant.TagObjectType, "follow")
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	// 5. update question tags
-	err = ts.tagCommonService.MigrateTagQuestions(ctx, sourceTag.ID, targetTagInfo.ID)
+	err = ts.tagRepo.MergeTag(ctx, sourceTag.ID, targetTagInfo, followActivityType, req.DeleteSource)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	err = ts.tagCommonService.RefreshTagQuestionCount(ctx, []string{targetTagInfo.ID, sourceTag.ID})
+	log.Infof("user %s merged tag %s into %s", req.UserID, sourceTag.SlugName, targetTagInfo.SlugName)
+	ts.auditLogService.Record(ctx, &schema.AuditLogRecord{
+		OperatorID: req.UserID,
+		Action:     entity.AuditLogActionTagMerge,
+		ObjectType: entity.AuditLogObjectTypeTag,
+		ObjectID:   sourceTag.ID,
+		Before:     map[string]any{"slug_name": sourceTag.SlugName, "question_count": sourceTag.QuestionCount},
+		After: map[string]any{"target_tag_id": targetTagInfo.ID, "target_slug_name": targetTagInfo.SlugName,
+			"delete_source": req.DeleteSource},
+	})
+
+	targetTagInfo, _, err = ts.tagCommonService.GetTagByID(ctx, req.TargetTagID)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return &schema.MergeTagResp{
+		TagID:         targetTagInfo.ID,
+		SlugName:      targetTagInfo.SlugName,
+		QuestionCount: targetTagInfo.QuestionCount,
+		FollowCount:   targetTagInfo.FollowCount,
+	}, nil
+}
 
-	return nil
+// checkTagIsIgnored whether the user ignored the tag
+func (ts *TagService) checkTagIsIgnored(ctx context.Context, userID, tagID string) bool {
+	if len(userID) == 0 {
+		return false
+	}
+	ignored, err := ts.tagIgnoreRepo.IsIgnored(ctx, userID, tagID)
+	if err != nil {
+		log.Error(err)
+	}
+	return ignored
 }
 
 // checkTagIsFollow get tag list page
@@ -0,0 +1,238 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tag_analytics
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	tagcommon "github.com/apache/answer/internal/service/tag_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// TagAnalyticsRepo tag analytics repository
+type TagAnalyticsRepo interface {
+	GetQuestionFirstAnswers(ctx context.Context, startTime, endTime time.Time) (
+		questions []*entity.TagQuestionFirstAnswer, err error)
+	GetAnswererStats(ctx context.Context, startTime, endTime time.Time) (stats []*entity.TagAnswererStat, err error)
+	ReplaceDailyStats(ctx context.Context, date string, stats []*entity.TagDailyStat) (err error)
+	GetDailyStats(ctx context.Context, tagID, startDate, endDate string) (stats []*entity.TagDailyStat, err error)
+	GetLatestDate(ctx context.Context) (date string, exist bool, err error)
+}
+
+// TagAnalyticsService the per-tag stats over time, aggregated by day in the nightly scheduler
+type TagAnalyticsService struct {
+	tagAnalyticsRepo TagAnalyticsRepo
+	tagCommonService *tagcommon.TagCommonService
+	userCommon       *usercommon.UserCommon
+	running          atomic.Bool
+}
+
+// NewTagAnalyticsService new tag analytics service
+func NewTagAnalyticsService(
+	tagAnalyticsRepo TagAnalyticsRepo,
+	tagCommonService *tagcommon.TagCommonService,
+	userCommon *usercommon.UserCommon,
+) *TagAnalyticsService {
+	return &TagAnalyticsService{
+		tagAnalyticsRepo: tagAnalyticsRepo,
+		tagCommonService: tagCommonService,
+		userCommon:       userCommon,
+	}
+}
+
+// AggregateCron aggregate the stats of the days since the latest aggregated day until yesterday.
+// The last week is always aggregated again, so the questions answered late are counted.
+func (ts *TagAnalyticsService) AggregateCron(ctx context.Context) {
+	if !ts.running.CompareAndSwap(false, true) {
+		return
+	}
+	defer ts.running.Store(false)
+
+	yesterday := schema.TagAnalyticsDay(time.Now()).AddDate(0, 0, -1)
+	earliestDay := yesterday.AddDate(0, 0, 1-schema.TagAnalyticsBackfillDays)
+	startDay := yesterday.AddDate(0, 0, -6)
+	latest, exist, err := ts.tagAnalyticsRepo.GetLatestDate(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if !exist {
+		startDay = earliestDay
+	} else if latestDay, err := time.Parse(schema.TagAnalyticsDateFormat, latest); err == nil &&
+		latestDay.Before(startDay) {
+		startDay = latestDay.AddDate(0, 0, 1)
+		if startDay.Before(earliestDay) {
+			startDay = earliestDay
+		}
+	}
+	for day := startDay; !day.After(yesterday); day = day.AddDate(0, 0, 1) {
+		if err := ts.aggregateDay(ctx, day); err != nil {
+			log.Errorf("aggregate tag stats of %s failed: %v", day.Format(schema.TagAnalyticsDateFormat), err)
+		}
+	}
+}
+
+func (ts *TagAnalyticsService) aggregateDay(ctx context.Context, day time.Time) (err error) {
+	date := day.Format(schema.TagAnalyticsDateFormat)
+	endTime := day.AddDate(0, 0, 1)
+	questions, err := ts.tagAnalyticsRepo.GetQuestionFirstAnswers(ctx, day, endTime)
+	if err != nil {
+		return err
+	}
+	answerers, err := ts.tagAnalyticsRepo.GetAnswererStats(ctx, day, endTime)
+	if err != nil {
+		return err
+	}
+
+	statMapping := make(map[string]*entity.TagDailyStat)
+	getStat := func(tagID string) *entity.TagDailyStat {
+		if stat, ok := statMapping[tagID]; ok {
+			return stat
+		}
+		stat := &entity.TagDailyStat{TagID: tagID, Date: date, TopAnswerers: "[]"}
+		statMapping[tagID] = stat
+		return stat
+	}
+	firstAnswerSeconds := make(map[string][]int64)
+	for _, q := range questions {
+		stat := getStat(q.TagID)
+		stat.QuestionCount++
+		if q.FirstAnswerAt.IsZero() {
+			continue
+		}
+		stat.AnsweredCount++
+		firstAnswerSeconds[q.TagID] = append(firstAnswerSeconds[q.TagID],
+			max(int64(q.FirstAnswerAt.Sub(q.CreatedAt).Seconds()), 0))
+	}
+	tagAnswerers := make(map[string][]*schema.TagAnalyticsAnswerer)
+	for _, a := range answerers {
+		getStat(a.TagID).AnswerCount += a.AnswerCount
+		tagAnswerers[a.TagID] = append(tagAnswerers[a.TagID],
+			&schema.TagAnalyticsAnswerer{UserID: a.UserID, AnswerCount: a.AnswerCount})
+	}
+
+	stats := make([]*entity.TagDailyStat, 0, len(statMapping))
+	for tagID, stat := range statMapping {
+		stat.MedianFirstAnswerSeconds = schema.MedianSeconds(firstAnswerSeconds[tagID])
+		top := schema.MergeTopAnswerers([][]*schema.TagAnalyticsAnswerer{tagAnswerers[tagID]},
+			schema.TagAnalyticsTopAnswerers)
+		content, _ := json.Marshal(top)
+		stat.TopAnswerers = string(content)
+		stats = append(stats, stat)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].TagID < stats[j].TagID })
+	return ts.tagAnalyticsRepo.ReplaceDailyStats(ctx, date, stats)
+}
+
+// GetTagAnalytics get the stats of tag in the last days, the days without questions or answers are zero
+func (ts *TagAnalyticsService) GetTagAnalytics(ctx context.Context, req *schema.GetTagAnalyticsReq) (
+	resp *schema.GetTagAnalyticsResp, err error) {
+	var (
+		tag   *entity.Tag
+		exist bool
+	)
+	if len(req.TagID) > 0 {
+		tag, exist, err = ts.tagCommonService.GetTagByID(ctx, req.TagID)
+	} else {
+		tag, exist, err = ts.tagCommonService.GetTagBySlugName(ctx, req.SlugName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.NotFound(reason.TagNotFound)
+	}
+	if req.Days <= 0 {
+		req.Days = schema.TagAnalyticsBackfillDays
+	}
+
+	endDay := schema.TagAnalyticsDay(time.Now()).AddDate(0, 0, -1)
+	startDay := endDay.AddDate(0, 0, 1-req.Days)
+	resp = &schema.GetTagAnalyticsResp{
+		TagID:        tag.ID,
+		SlugName:     tag.SlugName,
+		StartDate:    startDay.Format(schema.TagAnalyticsDateFormat),
+		EndDate:      endDay.Format(schema.TagAnalyticsDateFormat),
+		TopAnswerers: make([]*schema.TagAnalyticsUser, 0),
+		Daily:        make([]*schema.TagAnalyticsDaily, 0, req.Days),
+	}
+	stats, err := ts.tagAnalyticsRepo.GetDailyStats(ctx, tag.ID, resp.StartDate, resp.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	statMapping := make(map[string]*entity.TagDailyStat, len(stats))
+	for _, stat := range stats {
+		statMapping[stat.Date] = stat
+	}
+
+	medians, answered := make([]int64, 0), make([]int, 0)
+	answererDays := make([][]*schema.TagAnalyticsAnswerer, 0)
+	for day := startDay; !day.After(endDay); day = day.AddDate(0, 0, 1) {
+		daily := &schema.TagAnalyticsDaily{Date: day.Format(schema.TagAnalyticsDateFormat)}
+		resp.Daily = append(resp.Daily, daily)
+		stat, ok := statMapping[daily.Date]
+		if !ok {
+			continue
+		}
+		daily.QuestionCount = stat.QuestionCount
+		daily.AnsweredCount = stat.AnsweredCount
+		daily.AnswerRate = schema.AnswerRate(stat.QuestionCount, stat.AnsweredCount)
+		daily.MedianFirstAnswerSeconds = stat.MedianFirstAnswerSeconds
+		daily.AnswerCount = stat.AnswerCount
+
+		resp.QuestionCount += stat.QuestionCount
+		resp.AnsweredCount += stat.AnsweredCount
+		medians = append(medians, stat.MedianFirstAnswerSeconds)
+		answered = append(answered, stat.AnsweredCount)
+		dayAnswerers := make([]*schema.TagAnalyticsAnswerer, 0)
+		_ = json.Unmarshal([]byte(stat.TopAnswerers), &dayAnswerers)
+		answererDays = append(answererDays, dayAnswerers)
+	}
+	resp.AnswerRate = schema.AnswerRate(resp.QuestionCount, resp.AnsweredCount)
+	resp.MedianFirstAnswerSeconds = schema.WeightedMedianSeconds(medians, answered)
+
+	top := schema.MergeTopAnswerers(answererDays, schema.TagAnalyticsTopAnswerers)
+	userIDs := make([]string, 0, len(top))
+	for _, a := range top {
+		userIDs = append(userIDs, a.UserID)
+	}
+	userInfoMapping, err := ts.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range top {
+		if userInfo, ok := userInfoMapping[a.UserID]; ok {
+			resp.TopAnswerers = append(resp.TopAnswerers, &schema.TagAnalyticsUser{
+				AnswerCount: a.AnswerCount,
+				User:        userInfo,
+			})
+		}
+	}
+	return resp, nil
+}
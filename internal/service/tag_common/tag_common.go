@@ -27,7 +27,9 @@ import (
 	"strings"
 
 	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/handler"
 	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
 	"github.com/apache/answer/internal/base/validator"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
@@ -62,6 +64,11 @@ type TagRepo interface {
 	GetTagSynonymCount(ctx context.Context, tagID string) (count int64, err error)
 	GetIDsByMainTagId(ctx context.Context, mainTagID string) (tagIDs []string, err error)
 	GetTagList(ctx context.Context, tag *entity.Tag) (tagList []*entity.Tag, err error)
+	GetSynonymTagList(ctx context.Context) (tagList []*entity.Tag, err error)
+	MergeTag(ctx context.Context, sourceTagID string, target *entity.Tag, followActivityType int,
+		deleteSource bool) (err error)
+	UpdateTagDeprecation(ctx context.Context, tagID string, deprecated bool, replacementTags string) (err error)
+	GetDeprecatedTagList(ctx context.Context) (tagList []*entity.Tag, err error)
 }
 
 type TagRelRepo interface {
@@ -78,6 +85,16 @@ type TagRelRepo interface {
 	CountTagRelByTagID(ctx context.Context, tagID string) (count int64, err error)
 	GetTagRelDefaultStatusByObjectID(ctx context.Context, objectID string) (status int, err error)
 	MigrateTagObjects(ctx context.Context, sourceTagId, targetTagId string) error
+	DeleteTagRelListByTagID(ctx context.Context, tagID string) (err error)
+}
+
+// TagTranslationRepo the translations of tag display name and description
+type TagTranslationRepo interface {
+	SaveTagTranslation(ctx context.Context, translation *entity.TagTranslation) (err error)
+	RemoveTagTranslation(ctx context.Context, tagID, language string) (err error)
+	GetTagTranslations(ctx context.Context, tagID string) (translations []*entity.TagTranslation, err error)
+	GetTagTranslationsByLanguage(ctx context.Context, tagIDs []string, language string) (
+		translations []*entity.TagTranslation, err error)
 }
 
 // TagCommonService user service
@@ -88,6 +105,7 @@ type TagCommonService struct {
 	tagRepo              TagRepo
 	siteInfoService      siteinfo_common.SiteInfoCommonService
 	activityQueueService activity_queue.ActivityQueueService
+	tagTranslationRepo   TagTranslationRepo
 }
 
 // NewTagCommonService new tag service
@@ -98,6 +116,7 @@ func NewTagCommonService(
 	revisionService *revision_common.RevisionService,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
 	activityQueueService activity_queue.ActivityQueueService,
+	tagTranslationRepo TagTranslationRepo,
 ) *TagCommonService {
 	return &TagCommonService{
 		tagCommonRepo:        tagCommonRepo,
@@ -106,6 +125,7 @@ func NewTagCommonService(
 		revisionService:      revisionService,
 		siteInfoService:      siteInfoService,
 		activityQueueService: activityQueueService,
+		tagTranslationRepo:   tagTranslationRepo,
 	}
 }
 
@@ -116,6 +136,7 @@ func (ts *TagCommonService) SearchTagLike(ctx context.Context, req *schema.Searc
 		return
 	}
 	ts.TagsFormatRecommendAndReserved(ctx, tags)
+	ts.LocalizeTags(ctx, tags)
 	mainTagId := make([]string, 0)
 	for _, tag := range tags {
 		if tag.MainTagID != 0 {
@@ -338,6 +359,9 @@ func (ts *TagCommonService) AddTag(ctx context.Context, req *schema.AddTagReq) (
 	}
 	slugName := strings.ReplaceAll(req.SlugName, " ", "-")
 	slugName = strings.ToLower(slugName)
+	if err = ts.CheckTagBlocklist(ctx, []string{slugName}); err != nil {
+		return nil, err
+	}
 	tagInfo := &entity.Tag{
 		SlugName:     slugName,
 		DisplayName:  req.DisplayName,
@@ -442,6 +466,7 @@ func (ts *TagCommonService) GetObjectEntityTag(ctx context.Context, objectId str
 
 func (ts *TagCommonService) TagFormat(ctx context.Context, tags []*entity.Tag) (objTags []*schema.TagResp, err error) {
 	objTags = make([]*schema.TagResp, 0)
+	ts.LocalizeTags(ctx, tags)
 	for _, tagInfo := range tags {
 		objTags = append(objTags, &schema.TagResp{
 			SlugName:        tagInfo.SlugName,
@@ -502,6 +527,7 @@ func (ts *TagCommonService) BatchGetObjectTag(ctx context.Context, objectIds []s
 	if err != nil {
 		return objectIDTagMap, err
 	}
+	ts.LocalizeTags(ctx, tagsInfoList)
 	tagsInfoMapping := make(map[string]*entity.Tag)
 	tagsRank := make(map[string]int) // Used for sorting
 	for idx, item := range tagsInfoList {
@@ -586,6 +612,131 @@ func (ts *TagCommonService) CheckTag(ctx context.Context, tags []string, userID
 	return nil
 }
 
+// GetBlockedTag get the first tag blocked by the site tag blocklist, empty if none is blocked
+func (ts *TagCommonService) GetBlockedTag(ctx context.Context, slugNames []string) (blockedTag string, err error) {
+	if len(slugNames) == 0 {
+		return "", nil
+	}
+	blocklist, err := ts.siteInfoService.GetSiteTagBlocklist(ctx)
+	if err != nil {
+		return "", err
+	}
+	return blocklist.Blocked(slugNames), nil
+}
+
+// LocalizeTags replace the display name and description of tags with their translations in the request language.
+// The tags are only used for display after localized, their slug names are kept for urls and search.
+func (ts *TagCommonService) LocalizeTags(ctx context.Context, tagList []*entity.Tag) {
+	if len(tagList) == 0 {
+		return
+	}
+	tagIDs := make([]string, 0, len(tagList))
+	for _, tag := range tagList {
+		tagIDs = append(tagIDs, tag.ID)
+	}
+	translations, err := ts.tagTranslationRepo.GetTagTranslationsByLanguage(ctx, tagIDs, string(handler.GetLangByCtx(ctx)))
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if len(translations) == 0 {
+		return
+	}
+	mapping := make(map[string]*entity.TagTranslation, len(translations))
+	for _, translation := range translations {
+		mapping[translation.TagID] = translation
+	}
+	for _, tag := range tagList {
+		translation, ok := mapping[tag.ID]
+		if !ok {
+			continue
+		}
+		tag.DisplayName = translation.DisplayName
+		if len(translation.OriginalText) > 0 {
+			tag.OriginalText = translation.OriginalText
+			tag.ParsedText = translation.ParsedText
+		}
+	}
+}
+
+// SaveTagTranslation add or update the translation of tag
+func (ts *TagCommonService) SaveTagTranslation(ctx context.Context, req *schema.SaveTagTranslationReq) (err error) {
+	if req.Language == translator.DefaultLangOption || !translator.CheckLanguageIsValid(req.Language) {
+		return errors.BadRequest(reason.LangNotFound)
+	}
+	tagInfo, exist, err := ts.GetTagByID(ctx, req.TagID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.TagNotFound)
+	}
+	return ts.tagTranslationRepo.SaveTagTranslation(ctx, &entity.TagTranslation{
+		TagID:        tagInfo.ID,
+		Language:     req.Language,
+		DisplayName:  req.DisplayName,
+		OriginalText: req.OriginalText,
+		ParsedText:   req.ParsedText,
+	})
+}
+
+// RemoveTagTranslation remove the translation of tag
+func (ts *TagCommonService) RemoveTagTranslation(ctx context.Context, req *schema.RemoveTagTranslationReq) (err error) {
+	return ts.tagTranslationRepo.RemoveTagTranslation(ctx, req.TagID, req.Language)
+}
+
+// GetTagTranslations get all translations of tag
+func (ts *TagCommonService) GetTagTranslations(ctx context.Context, req *schema.GetTagTranslationsReq) (
+	resp []*schema.TagTranslationItem, err error) {
+	translations, err := ts.tagTranslationRepo.GetTagTranslations(ctx, req.TagID)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.TagTranslationItem, 0, len(translations))
+	for _, translation := range translations {
+		resp = append(resp, &schema.TagTranslationItem{
+			Language:     translation.Language,
+			DisplayName:  translation.DisplayName,
+			OriginalText: translation.OriginalText,
+			ParsedText:   translation.ParsedText,
+			UpdatedAt:    translation.UpdatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// CheckTagBlocklist check none of the tags is blocked by the site tag blocklist
+func (ts *TagCommonService) CheckTagBlocklist(ctx context.Context, slugNames []string) (err error) {
+	blockedTag, err := ts.GetBlockedTag(ctx, slugNames)
+	if err != nil || len(blockedTag) == 0 {
+		return err
+	}
+	errMsg := translator.TrWithData(handler.GetLangByCtx(ctx), reason.TagBlocked, &schema.TagBlockedTplData{Tag: blockedTag})
+	return errors.BadRequest(reason.TagBlocked).WithMsg(errMsg)
+}
+
+// GetDeprecatedTag get the first deprecated tag of the given tags
+func (ts *TagCommonService) GetDeprecatedTag(ctx context.Context, slugNames []string) (deprecatedTag *entity.Tag, err error) {
+	if len(slugNames) == 0 {
+		return nil, nil
+	}
+	tagList, err := ts.tagCommonRepo.GetTagListByNames(ctx, slugNames)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range tagList {
+		if tag.Deprecated {
+			return tag, nil
+		}
+	}
+	return nil, nil
+}
+
+// DeleteTagRelListByTagID remove the tag from all posts
+func (ts *TagCommonService) DeleteTagRelListByTagID(ctx context.Context, tagID string) (err error) {
+	return ts.tagRelRepo.DeleteTagRelListByTagID(ctx, tagID)
+}
+
 // CheckTagsIsChange
 func (ts *TagCommonService) CheckTagsIsChange(ctx context.Context, tagNameList, oldtagNameList []string) bool {
 	check := make(map[string]bool)
@@ -667,9 +818,18 @@ func (ts *TagCommonService) ObjectChangeTag(ctx context.Context, objectTagData *
 	}
 
 	tagInDbMapping := make(map[string]*entity.Tag)
+	tagIDMapping := make(map[string]bool)
 	for _, tag := range tagListInDb {
 		tagInDbMapping[strings.ToLower(tag.SlugName)] = tag
-		thisObjTagIDList = append(thisObjTagIDList, tag.ID)
+		// the object tagged with synonym is retagged to the main tag
+		tagID := tag.ID
+		if tag.MainTagID != 0 {
+			tagID = converter.IntToString(tag.MainTagID)
+		}
+		if !tagIDMapping[tagID] {
+			tagIDMapping[tagID] = true
+			thisObjTagIDList = append(thisObjTagIDList, tagID)
+		}
 	}
 
 	addTagList := make([]*entity.Tag, 0)
@@ -689,6 +849,13 @@ func (ts *TagCommonService) ObjectChangeTag(ctx context.Context, objectTagData *
 	}
 
 	if len(addTagList) > 0 {
+		addTagNames := make([]string, 0, len(addTagList))
+		for _, tag := range addTagList {
+			addTagNames = append(addTagNames, tag.SlugName)
+		}
+		if err = ts.CheckTagBlocklist(ctx, addTagNames); err != nil {
+			return err
+		}
 		err = ts.tagCommonRepo.AddTagList(ctx, addTagList)
 		if err != nil {
 			return err
@@ -864,9 +1031,26 @@ func (ts *TagCommonService) UpdateTag(ctx context.Context, req *schema.UpdateTag
 		return errors.BadRequest(reason.TagNotFound)
 	}
 
+	if req.DescriptionOnly {
+		if len(req.SlugName) == 0 {
+			req.SlugName = tagInfo.SlugName
+		}
+		if len(req.DisplayName) == 0 {
+			req.DisplayName = tagInfo.DisplayName
+		}
+	}
+
 	//Adding equivalent slug formatting for tag update
 	slugName := strings.ReplaceAll(req.SlugName, " ", "-")
 	slugName = strings.ToLower(slugName)
+	if req.DescriptionOnly && (tagInfo.SlugName != slugName || tagInfo.DisplayName != req.DisplayName) {
+		return errors.Forbidden(reason.TagEditDescriptionOnly)
+	}
+	if tagInfo.SlugName != slugName {
+		if err = ts.CheckTagBlocklist(ctx, []string{slugName}); err != nil {
+			return err
+		}
+	}
 
 	//If the content is the same, ignore it
 	if tagInfo.OriginalText == req.OriginalText &&
@@ -0,0 +1,118 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tag_moderator
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	tagcommon "github.com/apache/answer/internal/service/tag_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// TagModeratorRepo tag moderator repository
+type TagModeratorRepo interface {
+	AddTagModerator(ctx context.Context, tagModerator *entity.TagModerator) (err error)
+	RemoveTagModerator(ctx context.Context, tagID, userID string) (err error)
+	GetTagModerators(ctx context.Context, tagID string) (tagModerators []*entity.TagModerator, err error)
+	IsQuestionTagModerator(ctx context.Context, userID, questionID string) (is bool, err error)
+}
+
+// TagModeratorService manage the users who moderate the questions carrying the tags
+type TagModeratorService struct {
+	tagModeratorRepo TagModeratorRepo
+	tagCommonService *tagcommon.TagCommonService
+	userCommon       *usercommon.UserCommon
+}
+
+// NewTagModeratorService new tag moderator service
+func NewTagModeratorService(
+	tagModeratorRepo TagModeratorRepo,
+	tagCommonService *tagcommon.TagCommonService,
+	userCommon *usercommon.UserCommon,
+) *TagModeratorService {
+	return &TagModeratorService{
+		tagModeratorRepo: tagModeratorRepo,
+		tagCommonService: tagCommonService,
+		userCommon:       userCommon,
+	}
+}
+
+// AddTagModerator assign the user to moderate the tag
+func (ts *TagModeratorService) AddTagModerator(ctx context.Context, req *schema.AddTagModeratorReq) (err error) {
+	tagInfo, exist, err := ts.tagCommonService.GetTagByID(ctx, req.TagID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.TagNotFound)
+	}
+	// the questions are tagged with the main tag, so the synonym can not be moderated
+	if tagInfo.MainTagID > 0 {
+		return errors.BadRequest(reason.TagCannotSetSynonymOfSynonym)
+	}
+	userInfo, exist, err := ts.userCommon.GetUserBasicInfoByID(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	if !exist || userInfo.Status == constant.UserDeleted || userInfo.Status == constant.UserSuspended {
+		return errors.BadRequest(reason.UserNotFound)
+	}
+	return ts.tagModeratorRepo.AddTagModerator(ctx, &entity.TagModerator{
+		TagID:      tagInfo.ID,
+		UserID:     userInfo.ID,
+		AssignedBy: req.OperatorID,
+	})
+}
+
+// RemoveTagModerator remove the tag moderator
+func (ts *TagModeratorService) RemoveTagModerator(ctx context.Context, req *schema.RemoveTagModeratorReq) (err error) {
+	return ts.tagModeratorRepo.RemoveTagModerator(ctx, req.TagID, req.UserID)
+}
+
+// GetTagModerators get the moderators of tag
+func (ts *TagModeratorService) GetTagModerators(ctx context.Context, req *schema.GetTagModeratorsReq) (
+	resp []*schema.TagModeratorItem, err error) {
+	tagModerators, err := ts.tagModeratorRepo.GetTagModerators(ctx, req.TagID)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(tagModerators))
+	for _, item := range tagModerators {
+		userIDs = append(userIDs, item.UserID)
+	}
+	users, err := ts.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.TagModeratorItem, 0, len(tagModerators))
+	for _, item := range tagModerators {
+		user, ok := users[item.UserID]
+		if !ok {
+			continue
+		}
+		resp = append(resp, &schema.TagModeratorItem{User: user, AssignedAt: item.CreatedAt.Unix()})
+	}
+	return resp, nil
+}
@@ -0,0 +1,351 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package uploader
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	errpkg "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/pkg/checker"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/apache/answer/plugin"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	chunkedUploadMetaFile = "meta.json"
+	chunkedUploadDataFile = "data.part"
+)
+
+// CreateChunkedUpload start a resumable upload, the chunks are uploaded by UploadChunk in order
+func (us *uploaderService) CreateChunkedUpload(ctx *gin.Context, req *schema.CreateChunkedUploadReq) (
+	resp *schema.ChunkedUploadResp, err error) {
+	// the storage plugins read the whole file from the request, so they can't assemble the chunks
+	storageEnabled := false
+	_ = plugin.CallStorage(func(fn plugin.Storage) error {
+		storageEnabled = true
+		return nil
+	})
+	if storageEnabled {
+		return nil, errors.BadRequest(reason.UploadChunkedUnsupported)
+	}
+
+	siteWrite, err := us.siteInfoService.GetSiteWrite(ctx)
+	if err != nil {
+		return nil, err
+	}
+	maxSize, allowedExtensions := siteWrite.GetMaxAttachmentSize(), siteWrite.AuthorizedAttachmentExtensions
+	if req.Source == string(plugin.UserPost) {
+		maxSize, allowedExtensions = siteWrite.GetMaxImageSize(), siteWrite.AuthorizedImageExtensions
+	}
+	if req.FileSize > maxSize {
+		return nil, errors.BadRequest(reason.UploadFileTooLarge)
+	}
+	if checker.IsUnAuthorizedExtension(req.FileName, allowedExtensions) {
+		return nil, errors.BadRequest(reason.UploadFileUnsupportedFileFormat)
+	}
+
+	us.removeExpiredChunkedUploads()
+
+	now := time.Now()
+	upload := &schema.ChunkedUpload{
+		ID:        uid.IDStr12() + uid.IDStr12(),
+		UserID:    req.UserID,
+		Source:    req.Source,
+		FileName:  filepath.Base(req.FileName),
+		FileSize:  req.FileSize,
+		Checksum:  req.Checksum,
+		CreatedAt: now,
+		ExpiresAt: now.Add(schema.ChunkedUploadExpiration),
+	}
+	if err = os.MkdirAll(us.chunkedUploadDir(upload.ID), os.ModePerm); err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	dataFile, err := os.Create(us.chunkedUploadDataPath(upload.ID))
+	if err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	_ = dataFile.Close()
+	if err = us.saveChunkedUpload(upload); err != nil {
+		return nil, err
+	}
+	return upload.ToResp(), nil
+}
+
+// GetChunkedUpload get the offset to resume the upload from
+func (us *uploaderService) GetChunkedUpload(ctx *gin.Context, req *schema.GetChunkedUploadReq) (
+	resp *schema.ChunkedUploadResp, err error) {
+	upload, err := us.getChunkedUpload(req.UploadID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return upload.ToResp(), nil
+}
+
+// UploadChunk append the chunk in request body to the upload, the file is assembled after the last chunk
+func (us *uploaderService) UploadChunk(ctx *gin.Context, req *schema.UploadChunkReq) (
+	resp *schema.ChunkedUploadResp, err error) {
+	unlock := us.lockChunkedUpload(req.UploadID)
+	defer unlock()
+
+	upload, err := us.getChunkedUpload(req.UploadID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Offset != upload.Offset {
+		return nil, errors.BadRequest(reason.UploadOffsetMismatch)
+	}
+
+	if upload.Offset < upload.FileSize {
+		if err = us.appendChunk(ctx, upload, req.Checksum); err != nil {
+			return nil, err
+		}
+		if err = us.saveChunkedUpload(upload); err != nil {
+			return nil, err
+		}
+	}
+	if upload.Offset < upload.FileSize {
+		return upload.ToResp(), nil
+	}
+
+	url, err := us.assembleChunkedUpload(ctx, upload)
+	if err != nil {
+		return nil, err
+	}
+	resp = upload.ToResp()
+	resp.Completed = true
+	resp.URL = url
+	return resp, nil
+}
+
+// AbortChunkedUpload remove the uploaded chunks
+func (us *uploaderService) AbortChunkedUpload(ctx *gin.Context, req *schema.GetChunkedUploadReq) (err error) {
+	unlock := us.lockChunkedUpload(req.UploadID)
+	defer unlock()
+
+	if _, err = us.getChunkedUpload(req.UploadID, req.UserID); err != nil {
+		return err
+	}
+	return us.removeChunkedUpload(req.UploadID)
+}
+
+// appendChunk write the request body at the offset of upload. If the chunk is broken,
+// the data file is truncated back so that the client can retry the same chunk.
+func (us *uploaderService) appendChunk(ctx *gin.Context, upload *schema.ChunkedUpload, checksum string) (err error) {
+	dataFile, err := os.OpenFile(us.chunkedUploadDataPath(upload.ID), os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	defer dataFile.Close()
+	if _, err = dataFile.Seek(upload.Offset, io.SeekStart); err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+
+	body := http.MaxBytesReader(ctx.Writer, ctx.Request.Body, upload.NextChunkSize())
+	hash := sha256.New()
+	written, copyErr := io.Copy(io.MultiWriter(dataFile, hash), body)
+
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case copyErr != nil && errpkg.As(copyErr, &maxBytesErr):
+		err = errors.BadRequest(reason.UploadFileTooLarge)
+	case len(checksum) > 0 && !strings.EqualFold(checksum, hex.EncodeToString(hash.Sum(nil))):
+		err = errors.BadRequest(reason.UploadChecksumMismatch)
+	case copyErr != nil && len(checksum) > 0:
+		err = errors.BadRequest(reason.RequestFormatError).WithError(copyErr)
+	}
+	if err != nil {
+		if e := dataFile.Truncate(upload.Offset); e != nil {
+			log.Errorf("truncate chunked upload %s failed: %v", upload.ID, e)
+		}
+		return err
+	}
+	// without the chunk checksum, the data received before the connection broke is kept
+	upload.Offset += written
+	if e := dataFile.Truncate(upload.Offset); e != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(e).WithStack()
+	}
+	return nil
+}
+
+// assembleChunkedUpload verify the checksum of the whole file and move it to the upload path of source
+func (us *uploaderService) assembleChunkedUpload(ctx *gin.Context, upload *schema.ChunkedUpload) (url string, err error) {
+	dataPath := us.chunkedUploadDataPath(upload.ID)
+	checksum, err := fileChecksum(dataPath)
+	if err != nil {
+		return "", errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	if checksum != upload.Checksum {
+		// the data can't be recovered, the client has to start over
+		if e := us.removeChunkedUpload(upload.ID); e != nil {
+			log.Error(e)
+		}
+		return "", errors.BadRequest(reason.UploadChecksumMismatch)
+	}
+
+	siteGeneral, err := us.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return "", err
+	}
+	subPath := constant.FilesPostSubPath
+	if upload.Source == string(plugin.UserPost) {
+		subPath = constant.PostSubPath
+	}
+	fileSubPath := path.Join(subPath, fmt.Sprintf("%s%s", uid.IDStr12(), strings.ToLower(path.Ext(upload.FileName))))
+	filePath := path.Join(us.serviceConfig.UploadPath, fileSubPath)
+	if err = os.Rename(dataPath, filePath); err != nil {
+		return "", errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	if e := us.removeChunkedUpload(upload.ID); e != nil {
+		log.Error(e)
+	}
+
+	if upload.Source == string(plugin.UserPost) {
+		siteWrite, err := us.siteInfoService.GetSiteWrite(ctx)
+		if err != nil {
+			return "", err
+		}
+		if !checker.DecodeAndCheckImageFile(filePath, siteWrite.GetMaxImageMegapixel()) {
+			_ = os.Remove(filePath)
+			return "", errors.BadRequest(reason.UploadFileUnsupportedFileFormat)
+		}
+		if err := removeExif(filePath); err != nil {
+			log.Error(err)
+		}
+		url = fmt.Sprintf("%s/uploads/%s", siteGeneral.SiteUrl, fileSubPath)
+	} else {
+		url = attachmentDownloadURL(siteGeneral.SiteUrl, upload.FileName, fileSubPath)
+	}
+	us.fileRecordService.AddFileRecord(ctx, upload.UserID, fileSubPath, url, upload.Source)
+	return url, nil
+}
+
+func (us *uploaderService) getChunkedUpload(uploadID, userID string) (upload *schema.ChunkedUpload, err error) {
+	// the upload id is used as directory name, make sure it can't escape the chunked upload path
+	if len(uploadID) == 0 || filepath.Base(uploadID) != uploadID || strings.HasPrefix(uploadID, ".") {
+		return nil, errors.NotFound(reason.UploadNotFound)
+	}
+	content, err := os.ReadFile(filepath.Join(us.chunkedUploadDir(uploadID), chunkedUploadMetaFile))
+	if err != nil {
+		return nil, errors.NotFound(reason.UploadNotFound)
+	}
+	upload = &schema.ChunkedUpload{}
+	if err = json.Unmarshal(content, upload); err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	if upload.UserID != userID || upload.IsExpired() {
+		return nil, errors.NotFound(reason.UploadNotFound)
+	}
+	return upload, nil
+}
+
+func (us *uploaderService) saveChunkedUpload(upload *schema.ChunkedUpload) (err error) {
+	content, err := json.Marshal(upload)
+	if err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	metaPath := filepath.Join(us.chunkedUploadDir(upload.ID), chunkedUploadMetaFile)
+	if err = os.WriteFile(metaPath+".tmp", content, 0644); err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	if err = os.Rename(metaPath+".tmp", metaPath); err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+func (us *uploaderService) removeChunkedUpload(uploadID string) (err error) {
+	us.chunkedLocks.Delete(uploadID)
+	if err = os.RemoveAll(us.chunkedUploadDir(uploadID)); err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// removeExpiredChunkedUploads remove the uploads that are not finished in time
+func (us *uploaderService) removeExpiredChunkedUploads() {
+	entries, err := os.ReadDir(filepath.Join(us.serviceConfig.UploadPath, constant.ChunkedSubPath))
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || !entry.IsDir() {
+			continue
+		}
+		// the meta file is rewritten after each chunk, so the directory is not touched by active uploads
+		metaInfo, err := os.Stat(filepath.Join(us.chunkedUploadDir(entry.Name()), chunkedUploadMetaFile))
+		if err == nil {
+			info = metaInfo
+		}
+		if time.Since(info.ModTime()) < schema.ChunkedUploadExpiration {
+			continue
+		}
+		if err = us.removeChunkedUpload(entry.Name()); err != nil {
+			log.Error(err)
+		}
+	}
+}
+
+// lockChunkedUpload the chunks of the same upload are written one by one
+func (us *uploaderService) lockChunkedUpload(uploadID string) (unlock func()) {
+	lock, _ := us.chunkedLocks.LoadOrStore(uploadID, &sync.Mutex{})
+	mu := lock.(*sync.Mutex)
+	mu.Lock()
+	return mu.Unlock
+}
+
+func (us *uploaderService) chunkedUploadDir(uploadID string) string {
+	return filepath.Join(us.serviceConfig.UploadPath, constant.ChunkedSubPath, uploadID)
+}
+
+func (us *uploaderService) chunkedUploadDataPath(uploadID string) string {
+	return filepath.Join(us.chunkedUploadDir(uploadID), chunkedUploadDataFile)
+}
+
+func fileChecksum(filePath string) (checksum string, err error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err = io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
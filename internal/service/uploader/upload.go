@@ -30,7 +30,9 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"sync"
 
+	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/file_record"
 
 	"github.com/apache/answer/internal/base/constant"
@@ -56,6 +58,8 @@ var (
 		constant.BrandingSubPath,
 		constant.FilesPostSubPath,
 		constant.DeletedSubPath,
+		constant.VideoSubPath,
+		constant.ChunkedSubPath,
 	}
 	supportedThumbFileExtMapping = map[string]imaging.Format{
 		".jpg":  imaging.JPEG,
@@ -70,7 +74,12 @@ type UploaderService interface {
 	UploadPostFile(ctx *gin.Context, userID string) (url string, err error)
 	UploadPostAttachment(ctx *gin.Context, userID string) (url string, err error)
 	UploadBrandingFile(ctx *gin.Context, userID string) (url string, err error)
+	UploadPostVideo(ctx *gin.Context, userID string) (url string, err error)
 	AvatarThumbFile(ctx *gin.Context, fileName string, size int) (url string, err error)
+	CreateChunkedUpload(ctx *gin.Context, req *schema.CreateChunkedUploadReq) (resp *schema.ChunkedUploadResp, err error)
+	GetChunkedUpload(ctx *gin.Context, req *schema.GetChunkedUploadReq) (resp *schema.ChunkedUploadResp, err error)
+	UploadChunk(ctx *gin.Context, req *schema.UploadChunkReq) (resp *schema.ChunkedUploadResp, err error)
+	AbortChunkedUpload(ctx *gin.Context, req *schema.GetChunkedUploadReq) (err error)
 }
 
 // uploaderService uploader service
@@ -78,6 +87,7 @@ type uploaderService struct {
 	serviceConfig     *service_config.ServiceConfig
 	siteInfoService   siteinfo_common.SiteInfoCommonService
 	fileRecordService *file_record.FileRecordService
+	chunkedLocks      sync.Map
 }
 
 // NewUploaderService new upload service
@@ -341,6 +351,10 @@ func (us *uploaderService) uploadAttachmentFile(ctx *gin.Context, file *multipar
 		return "", errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
 	}
 
+	return attachmentDownloadURL(siteGeneral.SiteUrl, originalFilename, fileSubPath), nil
+}
+
+func attachmentDownloadURL(siteURL, originalFilename, fileSubPath string) string {
 	// Need url encode the original filename. Because the filename may contain special characters that conflict with the markdown syntax.
 	originalFilename = url.QueryEscape(originalFilename)
 
@@ -348,8 +362,7 @@ func (us *uploaderService) uploadAttachmentFile(ctx *gin.Context, file *multipar
 	// The local saved path is /UploadPath/hash.pdf
 	// When downloading, the download link will be redirect to the local saved path. And the download filename will be 123.png.
 	downloadPath := strings.TrimSuffix(fileSubPath, filepath.Ext(fileSubPath)) + "/" + originalFilename
-	downloadUrl = fmt.Sprintf("%s/uploads/%s", siteGeneral.SiteUrl, downloadPath)
-	return downloadUrl, nil
+	return fmt.Sprintf("%s/uploads/%s", siteURL, downloadPath)
 }
 
 func (us *uploaderService) tryToUploadByPlugin(ctx *gin.Context, source plugin.UploadSource) (
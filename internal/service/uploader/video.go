@@ -0,0 +1,164 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package uploader
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/pkg/checker"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/apache/answer/plugin"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// the thumbnail is saved beside the video with the same name, it is used as the poster of the video
+	videoThumbnailExt   = ".jpg"
+	videoThumbnailWidth = 640
+	videoProcessTimeout = 30 * time.Second
+)
+
+var supportedVideoExtMapping = map[string]string{
+	".mp4":  checker.VideoFormatMP4,
+	".webm": checker.VideoFormatWebm,
+}
+
+// UploadPostVideo upload the short video such as screen recording to be played in posts
+func (us *uploaderService) UploadPostVideo(ctx *gin.Context, userID string) (
+	url string, err error) {
+	// the video is checked and its thumbnail is extracted from the local file
+	storageEnabled := false
+	_ = plugin.CallStorage(func(fn plugin.Storage) error {
+		storageEnabled = true
+		return nil
+	})
+	if storageEnabled {
+		return "", errors.BadRequest(reason.UploadVideoUnsupported)
+	}
+
+	siteGeneral, err := us.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return "", err
+	}
+	siteWrite, err := us.siteInfoService.GetSiteWrite(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, siteWrite.GetMaxVideoSize())
+	file, fileHeader, err := ctx.Request.FormFile("file")
+	if err != nil {
+		return "", errors.BadRequest(reason.RequestFormatError).WithError(err)
+	}
+	defer file.Close()
+	fileExt := strings.ToLower(path.Ext(fileHeader.Filename))
+	videoFormat, ok := supportedVideoExtMapping[fileExt]
+	if !ok {
+		return "", errors.BadRequest(reason.UploadFileUnsupportedFileFormat)
+	}
+
+	videoSubPath := path.Join(constant.VideoSubPath, fmt.Sprintf("%s%s", uid.IDStr12(), fileExt))
+	videoPath := path.Join(us.serviceConfig.UploadPath, videoSubPath)
+	if err := ctx.SaveUploadedFile(fileHeader, videoPath); err != nil {
+		return "", errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	if err = checkVideoFile(videoPath, videoFormat, siteWrite.GetMaxVideoDuration()); err != nil {
+		_ = os.Remove(videoPath)
+		return "", err
+	}
+
+	thumbnailPath := strings.TrimSuffix(videoPath, fileExt) + videoThumbnailExt
+	if err := extractVideoThumbnail(videoPath, thumbnailPath); err != nil {
+		log.Warnf("extract thumbnail of video %s failed: %v", videoSubPath, err)
+	}
+
+	url = fmt.Sprintf("%s/uploads/%s", siteGeneral.SiteUrl, videoSubPath)
+	us.fileRecordService.AddFileRecord(ctx, userID, videoSubPath, url, string(plugin.UserPostVideo))
+	return url, nil
+}
+
+// checkVideoFile check the content of video matches its extension and the video is not too long
+func checkVideoFile(videoPath, videoFormat string, maxDuration time.Duration) (err error) {
+	format, duration, err := checker.DecodeVideoFile(videoPath)
+	if err != nil || format != videoFormat {
+		return errors.BadRequest(reason.UploadFileUnsupportedFileFormat).WithError(err)
+	}
+	// the webm recorded by browsers doesn't have the duration in its header, so it is probed if ffprobe is installed
+	if duration == 0 {
+		duration, err = probeVideoDuration(videoPath)
+		if err != nil {
+			log.Warnf("probe duration of video %s failed: %v", videoPath, err)
+		}
+	}
+	if duration > maxDuration {
+		return errors.BadRequest(reason.UploadVideoTooLong)
+	}
+	return nil
+}
+
+// extractVideoThumbnail extract the first frame of video as its thumbnail, it is skipped if ffmpeg is not installed
+func extractVideoThumbnail(videoPath, thumbnailPath string) error {
+	ffmpeg, err := exec.LookPath("ffmpeg")
+	if err != nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), videoProcessTimeout)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, ffmpeg, "-v", "error", "-y", "-i", videoPath,
+		"-frames:v", "1", "-vf", fmt.Sprintf("scale='min(%d,iw)':-2", videoThumbnailWidth), thumbnailPath,
+	).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// probeVideoDuration get the duration of video by ffprobe, returns zero if ffprobe is not installed
+func probeVideoDuration(videoPath string) (duration time.Duration, err error) {
+	ffprobe, err := exec.LookPath("ffprobe")
+	if err != nil {
+		return 0, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), videoProcessTimeout)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, ffprobe, "-v", "error", "-show_entries", "format=duration",
+		"-of", "default=noprint_wrappers=1:nokey=1", videoPath).Output()
+	if err != nil {
+		return 0, err
+	}
+	seconds, err := strconv.ParseFloat(strings.TrimSpace(string(output)), 64)
+	if err != nil {
+		// the duration is N/A if it can't be determined
+		return 0, nil
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
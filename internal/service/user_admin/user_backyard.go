@@ -34,6 +34,7 @@ import (
 	answercommon "github.com/apache/answer/internal/service/answer_common"
 	"github.com/apache/answer/internal/service/badge"
 	"github.com/apache/answer/internal/service/comment_common"
+	"github.com/apache/answer/internal/service/content"
 	"github.com/apache/answer/internal/service/export"
 	notificationcommon "github.com/apache/answer/internal/service/notification_common"
 	"github.com/apache/answer/internal/service/plugin_common"
@@ -45,11 +46,13 @@ import (
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/activity"
+	"github.com/apache/answer/internal/service/audit_log"
 	"github.com/apache/answer/internal/service/auth"
 	"github.com/apache/answer/internal/service/role"
 	"github.com/apache/answer/internal/service/siteinfo_common"
 	usercommon "github.com/apache/answer/internal/service/user_common"
 	"github.com/apache/answer/internal/service/user_external_login"
+	"github.com/apache/answer/internal/service/user_timeline"
 	"github.com/apache/answer/pkg/checker"
 	"github.com/jinzhu/copier"
 	"github.com/segmentfault/pacman/errors"
@@ -69,6 +72,7 @@ type UserAdminRepo interface {
 	UpdateUserPassword(ctx context.Context, userID string, password string) (err error)
 	DeletePermanentlyUsers(ctx context.Context) (err error)
 	GetExpiredSuspendedUsers(ctx context.Context) (users []*entity.User, err error)
+	UpdateUserShadowBanned(ctx context.Context, userID string, shadowBanned bool) (err error)
 }
 
 // UserAdminService user service
@@ -87,6 +91,9 @@ type UserAdminService struct {
 	notificationRepo      notificationcommon.NotificationRepo
 	pluginUserConfigRepo  plugin_common.PluginUserConfigRepo
 	badgeAwardRepo        badge.BadgeAwardRepo
+	voteService           *content.VoteService
+	userTimelineService   *user_timeline.UserTimelineService
+	auditLogService       *audit_log.AuditLogService
 }
 
 // NewUserAdminService new user admin service
@@ -105,6 +112,9 @@ func NewUserAdminService(
 	notificationRepo notificationcommon.NotificationRepo,
 	pluginUserConfigRepo plugin_common.PluginUserConfigRepo,
 	badgeAwardRepo badge.BadgeAwardRepo,
+	voteService *content.VoteService,
+	userTimelineService *user_timeline.UserTimelineService,
+	auditLogService *audit_log.AuditLogService,
 ) *UserAdminService {
 	return &UserAdminService{
 		userRepo:              userRepo,
@@ -121,6 +131,9 @@ func NewUserAdminService(
 		notificationRepo:      notificationRepo,
 		pluginUserConfigRepo:  pluginUserConfigRepo,
 		badgeAwardRepo:        badgeAwardRepo,
+		voteService:           voteService,
+		userTimelineService:   userTimelineService,
+		auditLogService:       auditLogService,
 	}
 }
 
@@ -141,6 +154,10 @@ func (us *UserAdminService) UpdateUserStatus(ctx context.Context, req *schema.Up
 	if userInfo.Status == entity.UserStatusDeleted {
 		return nil
 	}
+	before := map[string]string{"status": constant.ConvertUserStatus(userInfo.Status, userInfo.MailStatus)}
+	if userInfo.ShadowBanned {
+		before["status"] = constant.UserShadowBanned
+	}
 
 	if req.IsInactive() {
 		userInfo.MailStatus = entity.EmailStatusToBeVerified
@@ -156,18 +173,52 @@ func (us *UserAdminService) UpdateUserStatus(ctx context.Context, req *schema.Up
 		userInfo.Status = entity.UserStatusAvailable
 		userInfo.MailStatus = entity.EmailStatusAvailable
 	}
+	// the shadow-banned user is still available, but the new content is only visible to the user and staff
+	if req.IsShadowBanned() {
+		userInfo.Status = entity.UserStatusAvailable
+	}
 
 	suspendedUntil := req.GetSuspendedUntil()
 	err = us.userRepo.UpdateUserStatus(ctx, userInfo.ID, userInfo.Status, userInfo.MailStatus, userInfo.EMail, suspendedUntil)
 	if err != nil {
 		return err
 	}
+	if (req.IsShadowBanned() || req.IsNormal()) && userInfo.ShadowBanned != req.IsShadowBanned() {
+		if err = us.userRepo.UpdateUserShadowBanned(ctx, userInfo.ID, req.IsShadowBanned()); err != nil {
+			return err
+		}
+	}
+	statusChange := req.Status
+	if req.IsSuspended() && len(req.SuspendDuration) > 0 {
+		statusChange += " " + req.SuspendDuration
+	}
+	us.userTimelineService.RecordStatusChange(ctx, userInfo.ID, req.LoginUserID, statusChange)
+	us.auditLogService.Record(ctx, &schema.AuditLogRecord{
+		OperatorID: req.LoginUserID,
+		Action:     entity.AuditLogActionUserStatus,
+		ObjectType: entity.AuditLogObjectTypeUser,
+		ObjectID:   userInfo.ID,
+		Before:     before,
+		After: map[string]any{
+			"status":             req.Status,
+			"suspend_duration":   req.SuspendDuration,
+			"remove_all_content": req.RemoveAllContent,
+			"remove_as_spammer":  req.RemoveAsSpammer,
+		},
+	})
 
 	// remove all content that user created, such as question, answer, comment, etc.
 	if req.RemoveAllContent {
 		us.removeAllUserCreatedContent(ctx, userInfo.ID)
 	}
 
+	// reverse the reputation involved with the spammer
+	if req.RemoveAsSpammer && (req.IsSuspended() || req.IsDeleted()) {
+		if err = us.voteService.InvalidateSpammerVotes(ctx, userInfo.ID); err != nil {
+			log.Errorf("invalidate spammer votes error: %v", err)
+		}
+	}
+
 	if req.IsDeleted() {
 		us.removeAllUserConfiguration(ctx, userInfo.ID)
 	}
@@ -223,10 +274,22 @@ func (us *UserAdminService) UpdateUserRole(ctx context.Context, req *schema.Upda
 		return errors.BadRequest(reason.UserCannotUpdateYourRole)
 	}
 
+	oldRoleID, err := us.userRoleRelService.GetUserRole(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
 	err = us.userRoleRelService.SaveUserRole(ctx, req.UserID, req.RoleID)
 	if err != nil {
 		return err
 	}
+	us.auditLogService.Record(ctx, &schema.AuditLogRecord{
+		OperatorID: req.LoginUserID,
+		Action:     entity.AuditLogActionUserRole,
+		ObjectType: entity.AuditLogObjectTypeUser,
+		ObjectID:   req.UserID,
+		Before:     map[string]int{"role_id": oldRoleID},
+		After:      map[string]int{"role_id": req.RoleID},
+	})
 
 	us.authService.RemoveUserAllTokens(ctx, req.UserID)
 	return
@@ -389,6 +452,12 @@ func (us *UserAdminService) UpdateUserPassword(ctx context.Context, req *schema.
 	if err != nil {
 		return err
 	}
+	us.auditLogService.Record(ctx, &schema.AuditLogRecord{
+		OperatorID: req.LoginUserID,
+		Action:     entity.AuditLogActionUserPassword,
+		ObjectType: entity.AuditLogObjectTypeUser,
+		ObjectID:   userInfo.ID,
+	})
 	// logout this user
 	us.authService.RemoveUserAllTokens(ctx, req.UserID)
 	return
@@ -477,6 +546,9 @@ func (us *UserAdminService) GetUserPage(ctx context.Context, req *schema.GetUser
 		user.Status = entity.UserStatusSuspended
 	} else if req.IsDeleted() {
 		user.Status = entity.UserStatusDeleted
+	} else if req.IsShadowBanned() {
+		user.Status = entity.UserStatusAvailable
+		user.ShadowBanned = true
 	} else {
 		user.MailStatus = entity.EmailStatusAvailable
 		user.Status = entity.UserStatusAvailable
@@ -520,6 +592,7 @@ func (us *UserAdminService) GetUserPage(ctx context.Context, req *schema.GetUser
 			Rank:        u.Rank,
 			DisplayName: u.DisplayName,
 			Avatar:      avatarMapping[u.ID].GetURL(),
+			IsBot:       u.UserType == entity.UserTypeBot,
 		}
 		if u.Status == entity.UserStatusDeleted {
 			t.Status = constant.UserDeleted
@@ -532,6 +605,8 @@ func (us *UserAdminService) GetUserPage(ctx context.Context, req *schema.GetUser
 			}
 		} else if u.MailStatus == entity.EmailStatusToBeVerified {
 			t.Status = constant.UserInactive
+		} else if u.ShadowBanned {
+			t.Status = constant.UserShadowBanned
 		} else {
 			t.Status = constant.UserNormal
 		}
@@ -621,14 +696,23 @@ func (us *UserAdminService) SendUserActivation(ctx context.Context, req *schema.
 
 func (us *UserAdminService) DeletePermanently(ctx context.Context, req *schema.DeletePermanentlyReq) (err error) {
 	if req.Type == constant.DeletePermanentlyUsers {
-		return us.userRepo.DeletePermanentlyUsers(ctx)
+		err = us.userRepo.DeletePermanentlyUsers(ctx)
 	} else if req.Type == constant.DeletePermanentlyQuestions {
-		return us.questionCommonRepo.DeletePermanentlyQuestions(ctx)
+		err = us.questionCommonRepo.DeletePermanentlyQuestions(ctx)
 	} else if req.Type == constant.DeletePermanentlyAnswers {
-		return us.answerCommonRepo.DeletePermanentlyAnswers(ctx)
+		err = us.answerCommonRepo.DeletePermanentlyAnswers(ctx)
+	} else {
+		return errors.BadRequest(reason.RequestFormatError)
 	}
-
-	return errors.BadRequest(reason.RequestFormatError)
+	if err != nil {
+		return err
+	}
+	us.auditLogService.Record(ctx, &schema.AuditLogRecord{
+		OperatorID: req.LoginUserID,
+		Action:     entity.AuditLogActionContentPurge,
+		ObjectID:   req.Type,
+	})
+	return nil
 }
 
 // CheckAndUnsuspendExpiredUsers checks for users whose suspension has expired and restores them to normal status
@@ -657,6 +741,14 @@ func (us *UserAdminService) CheckAndUnsuspendExpiredUsers(ctx context.Context) e
 					user.Username, user.ID, err)
 				continue
 			}
+			us.userTimelineService.RecordStatusChange(ctx, user.ID, "", constant.UserNormal)
+			us.auditLogService.Record(ctx, &schema.AuditLogRecord{
+				Action:     entity.AuditLogActionUserStatus,
+				ObjectType: entity.AuditLogObjectTypeUser,
+				ObjectID:   user.ID,
+				Before:     map[string]string{"status": constant.UserSuspended},
+				After:      map[string]string{"status": constant.UserNormal},
+			})
 		}
 	}
 
@@ -129,6 +129,19 @@ func (us *UserCommon) GetByUsername(ctx context.Context, username string) (userI
 	return us.userRepo.GetByUsername(ctx, username)
 }
 
+// IsShadowBanned whether the new content of the user is only visible to the user and the staff
+func (us *UserCommon) IsShadowBanned(ctx context.Context, userID string) bool {
+	if len(userID) == 0 || userID == "0" {
+		return false
+	}
+	userInfo, exist, err := us.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		log.Errorf("get user %s info error: %v", userID, err)
+		return false
+	}
+	return exist && userInfo.ShadowBanned
+}
+
 func (us *UserCommon) UpdateUserProfile(ctx context.Context, userInfo *entity.User) (err error) {
 	return us.userRepo.UpdateUserProfile(ctx, userInfo)
 }
@@ -180,6 +193,7 @@ func (us *UserCommon) FormatUserBasicInfo(ctx context.Context, userInfo *entity.
 	userBasicInfo.Location = userInfo.Location
 	userBasicInfo.Language = userInfo.Language
 	userBasicInfo.Status = constant.ConvertUserStatus(userInfo.Status, userInfo.MailStatus)
+	userBasicInfo.IsBot = userInfo.UserType == entity.UserTypeBot
 	if !userInfo.SuspendedUntil.IsZero() {
 		userBasicInfo.SuspendedUntil = userInfo.SuspendedUntil.Unix()
 	}
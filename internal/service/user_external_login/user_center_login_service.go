@@ -31,9 +31,9 @@ import (
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/activity"
+	"github.com/apache/answer/internal/service/event_queue"
 	"github.com/apache/answer/internal/service/siteinfo_common"
 	usercommon "github.com/apache/answer/internal/service/user_common"
-	"github.com/apache/answer/pkg/checker"
 	"github.com/apache/answer/pkg/converter"
 	"github.com/apache/answer/pkg/random"
 	"github.com/apache/answer/plugin"
@@ -47,6 +47,7 @@ type UserCenterLoginService struct {
 	userCommonService     *usercommon.UserCommon
 	userActivity          activity.UserActiveActivityRepo
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService
+	eventQueueService     event_queue.EventQueueService
 }
 
 // NewUserCenterLoginService new user external login service
@@ -56,6 +57,7 @@ func NewUserCenterLoginService(
 	userExternalLoginRepo UserExternalLoginRepo,
 	userActivity activity.UserActiveActivityRepo,
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
+	eventQueueService event_queue.EventQueueService,
 ) *UserCenterLoginService {
 	return &UserCenterLoginService{
 		userRepo:              userRepo,
@@ -63,6 +65,7 @@ func NewUserCenterLoginService(
 		userExternalLoginRepo: userExternalLoginRepo,
 		userActivity:          userActivity,
 		siteInfoCommonService: siteInfoCommonService,
+		eventQueueService:     eventQueueService,
 	}
 }
 
@@ -82,7 +85,7 @@ func (us *UserCenterLoginService) ExternalLogin(
 		if err != nil {
 			return nil, err
 		}
-		if !checker.EmailInAllowEmailDomain(basicUserInfo.Email, siteInfo.AllowEmailDomains) {
+		if !siteInfo.IsEmailDomainAllowed(basicUserInfo.Email) {
 			log.Debugf("email domain not allowed: %s", basicUserInfo.Email)
 			return &schema.UserExternalLoginResp{
 				ErrTitle: translator.Tr(handler.GetLangByCtx(ctx), reason.UserAccessDenied),
@@ -168,6 +171,7 @@ func (us *UserCenterLoginService) registerNewUser(ctx context.Context, provider
 	if err != nil {
 		return nil, err
 	}
+	us.eventQueueService.Send(ctx, schema.NewEvent(constant.EventUserCreate, userInfo.ID))
 
 	metaInfo, _ := json.Marshal(basicUserInfo)
 	newExternalUserInfo := &entity.UserExternalLogin{
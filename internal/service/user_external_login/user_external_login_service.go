@@ -32,11 +32,11 @@ import (
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/activity"
+	"github.com/apache/answer/internal/service/event_queue"
 	"github.com/apache/answer/internal/service/export"
 	"github.com/apache/answer/internal/service/siteinfo_common"
 	usercommon "github.com/apache/answer/internal/service/user_common"
 	"github.com/apache/answer/internal/service/user_notification_config"
-	"github.com/apache/answer/pkg/checker"
 	"github.com/apache/answer/pkg/random"
 	"github.com/apache/answer/pkg/token"
 	"github.com/apache/answer/plugin"
@@ -65,6 +65,7 @@ type UserExternalLoginService struct {
 	siteInfoCommonService         siteinfo_common.SiteInfoCommonService
 	userActivity                  activity.UserActiveActivityRepo
 	userNotificationConfigService *user_notification_config.UserNotificationConfigService
+	eventQueueService             event_queue.EventQueueService
 }
 
 // NewUserExternalLoginService new user external login service
@@ -76,6 +77,7 @@ func NewUserExternalLoginService(
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
 	userActivity activity.UserActiveActivityRepo,
 	userNotificationConfigService *user_notification_config.UserNotificationConfigService,
+	eventQueueService event_queue.EventQueueService,
 ) *UserExternalLoginService {
 	return &UserExternalLoginService{
 		userRepo:                      userRepo,
@@ -85,6 +87,7 @@ func NewUserExternalLoginService(
 		siteInfoCommonService:         siteInfoCommonService,
 		userActivity:                  userActivity,
 		userNotificationConfigService: userNotificationConfigService,
+		eventQueueService:             eventQueueService,
 	}
 }
 
@@ -139,7 +142,7 @@ func (us *UserExternalLoginService) ExternalLogin(
 	if err != nil {
 		return nil, err
 	}
-	if !checker.EmailInAllowEmailDomain(externalUserInfo.Email, siteInfo.AllowEmailDomains) {
+	if !siteInfo.IsEmailDomainAllowed(externalUserInfo.Email) {
 		log.Debugf("email domain not allowed: %s", externalUserInfo.Email)
 		return &schema.UserExternalLoginResp{
 			ErrTitle: translator.Tr(handler.GetLangByCtx(ctx), reason.UserAccessDenied),
@@ -153,6 +156,16 @@ func (us *UserExternalLoginService) ExternalLogin(
 	}
 	// if user is not a member, register a new user
 	if !exist {
+		inviteOnly, err := us.isInviteOnly(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if inviteOnly {
+			return &schema.UserExternalLoginResp{
+				ErrTitle: translator.Tr(handler.GetLangByCtx(ctx), reason.UserAccessDenied),
+				ErrMsg:   translator.Tr(handler.GetLangByCtx(ctx), reason.InviteCodeRequired),
+			}, nil
+		}
 		oldUserInfo, err = us.registerNewUser(ctx, externalUserInfo)
 		if err != nil {
 			return nil, err
@@ -180,6 +193,15 @@ func (us *UserExternalLoginService) ExternalLogin(
 	return &schema.UserExternalLoginResp{AccessToken: accessToken}, err
 }
 
+// isInviteOnly the new user cannot register with the external login without the invite code if it is invite-only
+func (us *UserExternalLoginService) isInviteOnly(ctx context.Context) (inviteOnly bool, err error) {
+	inviteConf, err := us.siteInfoCommonService.GetSiteInvite(ctx)
+	if err != nil {
+		return false, err
+	}
+	return inviteConf.InviteOnly, nil
+}
+
 func (us *UserExternalLoginService) registerNewUser(ctx context.Context,
 	externalUserInfo *schema.ExternalLoginUserInfoCache) (userInfo *entity.User, err error) {
 	userInfo = &entity.User{}
@@ -210,6 +232,7 @@ func (us *UserExternalLoginService) registerNewUser(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	us.eventQueueService.Send(ctx, schema.NewEvent(constant.EventUserCreate, userInfo.ID))
 	return userInfo, nil
 }
 
@@ -299,6 +322,13 @@ func (us *UserExternalLoginService) ExternalLoginBindingUserSendEmail(
 	}
 
 	if !exist {
+		inviteOnly, err := us.isInviteOnly(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if inviteOnly {
+			return nil, errors.BadRequest(reason.InviteCodeRequired)
+		}
 		externalLoginInfo.Email = req.Email
 		userInfo, err = us.registerNewUser(ctx, externalLoginInfo)
 		if err != nil {
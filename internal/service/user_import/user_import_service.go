@@ -0,0 +1,251 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_import
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
+	"github.com/apache/answer/internal/base/validator"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/audit_log"
+	"github.com/apache/answer/internal/service/export"
+	"github.com/apache/answer/internal/service/role"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/random"
+	"github.com/apache/answer/pkg/token"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// UserImportRepo user import repository
+type UserImportRepo interface {
+	AddImportedUsers(ctx context.Context, users []*entity.User, roleIDs []int) (err error)
+}
+
+// UserImportService import the users of the existing community in bulk
+type UserImportService struct {
+	userImportRepo        UserImportRepo
+	userRepo              usercommon.UserRepo
+	userCommon            *usercommon.UserCommon
+	roleService           *role.RoleService
+	emailService          *export.EmailService
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService
+	auditLogService       *audit_log.AuditLogService
+}
+
+// NewUserImportService new user import service
+func NewUserImportService(
+	userImportRepo UserImportRepo,
+	userRepo usercommon.UserRepo,
+	userCommon *usercommon.UserCommon,
+	roleService *role.RoleService,
+	emailService *export.EmailService,
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
+	auditLogService *audit_log.AuditLogService,
+) *UserImportService {
+	return &UserImportService{
+		userImportRepo:        userImportRepo,
+		userRepo:              userRepo,
+		userCommon:            userCommon,
+		roleService:           roleService,
+		emailService:          emailService,
+		siteInfoCommonService: siteInfoCommonService,
+		auditLogService:       auditLogService,
+	}
+}
+
+// ImportUsers create the users from the csv, nothing is created if any line is invalid.
+// The imported users have no password, they set it by resetting the password.
+func (is *UserImportService) ImportUsers(ctx context.Context, req *schema.ImportUsersReq) (
+	resp *schema.ImportUsersResp, errFields []*validator.FormErrorField, err error) {
+	items, errData := schema.ParseImportUsersCSV(req.CSV)
+	if errData != nil {
+		return nil, errData.GetErrField(ctx), errors.BadRequest(reason.RequestFormatError)
+	}
+	if len(items) == 0 || len(items) > constant.DefaultBulkUser {
+		errFields = append(errFields, &validator.FormErrorField{
+			ErrorField: "users",
+			ErrorMsg: translator.TrWithData(handler.GetLangByCtx(ctx), reason.AddBulkUsersAmountError,
+				map[string]int{
+					"MaxAmount": constant.DefaultBulkUser,
+				}),
+		})
+		return nil, errFields, errors.BadRequest(reason.RequestFormatError)
+	}
+	users, roleIDs, errData, err := is.formatImportUsers(ctx, items, req.SendActivationEmail)
+	if err != nil {
+		return nil, nil, err
+	}
+	if errData != nil {
+		return nil, errData.GetErrField(ctx), errors.BadRequest(reason.RequestFormatError)
+	}
+
+	if err = is.userImportRepo.AddImportedUsers(ctx, users, roleIDs); err != nil {
+		return nil, nil, err
+	}
+	resp = &schema.ImportUsersResp{Users: make([]*schema.ImportedUserItem, 0, len(users))}
+	for i, user := range users {
+		resp.Users = append(resp.Users, &schema.ImportedUserItem{
+			UserID:   user.ID,
+			Username: user.Username,
+			Email:    user.EMail,
+			RoleID:   roleIDs[i],
+		})
+	}
+	resp.Count = len(resp.Users)
+	if resp.Count > 0 {
+		is.auditLogService.Record(ctx, &schema.AuditLogRecord{
+			OperatorID: req.LoginUserID,
+			Action:     entity.AuditLogActionUserImport,
+			ObjectType: entity.AuditLogObjectTypeUser,
+			After: map[string]any{
+				"count":                 resp.Count,
+				"send_activation_email": req.SendActivationEmail,
+			},
+		})
+	}
+	if req.SendActivationEmail && resp.Count > 0 {
+		if req.WaitForEmails {
+			is.SendActivationEmails(ctx, resp.Users)
+		} else {
+			go is.SendActivationEmails(context.WithoutCancel(ctx), resp.Users)
+		}
+	}
+	return resp, nil, nil
+}
+
+// SendActivationEmails send the activation email to the imported users one by one
+func (is *UserImportService) SendActivationEmails(ctx context.Context, users []*schema.ImportedUserItem) {
+	general, err := is.siteInfoCommonService.GetSiteGeneral(ctx)
+	if err != nil {
+		log.Errorf("get site general failed, the activation emails are not sent: %v", err)
+		return
+	}
+	for _, user := range users {
+		data := &schema.EmailCodeContent{
+			Email:  user.Email,
+			UserID: user.UserID,
+		}
+		code := token.GenerateToken()
+		verifyEmailURL := fmt.Sprintf("%s/users/account-activation?code=%s", general.SiteUrl, code)
+		title, body, err := is.emailService.RegisterTemplate(ctx, verifyEmailURL)
+		if err != nil {
+			log.Errorf("render activation email of %s failed: %v", user.Email, err)
+			continue
+		}
+		is.emailService.SendAndSaveCode(ctx, user.UserID, user.Email, title, body, code, data.ToJSONString())
+	}
+}
+
+// formatImportUsers check all the users and convert them to the entities with the role ids
+func (is *UserImportService) formatImportUsers(ctx context.Context, items []*schema.ImportUserItem,
+	sendActivationEmail bool) (users []*entity.User, roleIDs []int, errData *schema.AddUsersErrorData, err error) {
+	lang := handler.GetLangByCtx(ctx)
+	val := validator.GetValidatorByLang(lang)
+	roleMapping, err := is.roleService.GetRoleMapping(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	emails := make(map[string]bool, len(items))
+	usernames := make(map[string]bool, len(items))
+	for _, item := range items {
+		content := fmt.Sprintf("%s, %s, %s, %d", item.Email, item.DisplayName, item.Role, item.Reputation)
+		if errFields, e := val.Check(item); e != nil {
+			errData = &schema.AddUsersErrorData{Line: item.Line, Content: content}
+			if len(errFields) > 0 {
+				errData.Field = errFields[0].ErrorField
+				errData.ExtraMessage = errFields[0].ErrorMsg
+			}
+			return nil, nil, errData, nil
+		}
+		email := strings.ToLower(item.Email)
+		if emails[email] {
+			return nil, nil, &schema.AddUsersErrorData{Field: "email", Line: item.Line, Content: item.Email,
+				ExtraMessage: translator.Tr(lang, reason.EmailDuplicate)}, nil
+		}
+		emails[email] = true
+		_, exist, err := is.userRepo.GetByEmail(ctx, item.Email)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if exist {
+			return nil, nil, &schema.AddUsersErrorData{Field: "email", Line: item.Line, Content: item.Email,
+				ExtraMessage: translator.Tr(lang, reason.EmailDuplicate)}, nil
+		}
+
+		roleID, ok := matchImportRole(roleMapping, item.Role)
+		if !ok {
+			return nil, nil, &schema.AddUsersErrorData{Field: "role", Line: item.Line, Content: item.Role,
+				ExtraMessage: translator.Tr(lang, reason.RoleNotFound)}, nil
+		}
+
+		username, err := is.userCommon.MakeUsername(ctx, item.DisplayName)
+		if err != nil {
+			return nil, nil, &schema.AddUsersErrorData{Field: "name", Line: item.Line, Content: item.DisplayName,
+				ExtraMessage: translator.Tr(lang, reason.UsernameInvalid)}, nil
+		}
+		// the username is not saved yet, so the users with the same name in the csv need to be distinguished
+		for base := username; usernames[username]; {
+			username = base + random.UsernameSuffix()
+		}
+		usernames[username] = true
+
+		user := &entity.User{
+			EMail:       item.Email,
+			DisplayName: item.DisplayName,
+			Username:    username,
+			Rank:        item.Reputation,
+			MailStatus:  entity.EmailStatusAvailable,
+			Status:      entity.UserStatusAvailable,
+		}
+		if sendActivationEmail {
+			user.MailStatus = entity.EmailStatusToBeVerified
+		}
+		users = append(users, user)
+		roleIDs = append(roleIDs, roleID)
+	}
+	return users, roleIDs, nil, nil
+}
+
+// matchImportRole match the role by the id or the name ignoring case, the default role is user
+func matchImportRole(roleMapping map[int]*entity.Role, name string) (roleID int, ok bool) {
+	if len(name) == 0 {
+		return role.RoleUserID, true
+	}
+	if id, err := strconv.Atoi(name); err == nil {
+		return id, roleMapping[id] != nil
+	}
+	for id, r := range roleMapping {
+		if strings.EqualFold(r.Name, name) {
+			return id, true
+		}
+	}
+	return 0, false
+}
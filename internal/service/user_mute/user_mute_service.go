@@ -0,0 +1,164 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_mute
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	questioncommon "github.com/apache/answer/internal/service/question_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// UserMuteRepo the questions and users muted by users
+type UserMuteRepo interface {
+	AddUserMute(ctx context.Context, userID, muteType, objectID string) (err error)
+	RemoveUserMute(ctx context.Context, userID, muteType, objectID string) (err error)
+	GetUserMutes(ctx context.Context, userID, muteType string) (mutes []*entity.UserMute, err error)
+	GetMutedUserIDs(ctx context.Context, userIDs []string, questionID, triggerUserID string) (
+		mutedUserIDs []string, err error)
+}
+
+// UserMuteService the mute lists of users, the notifications about the muted questions and users are not delivered
+type UserMuteService struct {
+	userMuteRepo UserMuteRepo
+	questionRepo questioncommon.QuestionRepo
+	userCommon   *usercommon.UserCommon
+}
+
+// NewUserMuteService new user mute service
+func NewUserMuteService(
+	userMuteRepo UserMuteRepo,
+	questionRepo questioncommon.QuestionRepo,
+	userCommon *usercommon.UserCommon,
+) *UserMuteService {
+	return &UserMuteService{
+		userMuteRepo: userMuteRepo,
+		questionRepo: questionRepo,
+		userCommon:   userCommon,
+	}
+}
+
+// Mute mute the question or user, or stop muting it
+func (us *UserMuteService) Mute(ctx context.Context, req *schema.MuteReq) (err error) {
+	objectID := req.ObjectID
+	if req.MuteType == constant.QuestionObjectType {
+		objectID = uid.DeShortID(objectID)
+	}
+	if !req.Muted {
+		return us.userMuteRepo.RemoveUserMute(ctx, req.UserID, req.MuteType, objectID)
+	}
+
+	if req.MuteType == constant.QuestionObjectType {
+		_, exist, err := us.questionRepo.GetQuestion(ctx, objectID)
+		if err != nil {
+			return err
+		}
+		if !exist {
+			return errors.BadRequest(reason.QuestionNotFound)
+		}
+	} else {
+		if objectID == req.UserID {
+			return errors.BadRequest(reason.UserMuteYourself)
+		}
+		_, exist, err := us.userCommon.GetUserBasicInfoByID(ctx, objectID)
+		if err != nil {
+			return err
+		}
+		if !exist {
+			return errors.BadRequest(reason.UserNotFound)
+		}
+	}
+	return us.userMuteRepo.AddUserMute(ctx, req.UserID, req.MuteType, objectID)
+}
+
+// GetMutes get the questions and users muted by user
+func (us *UserMuteService) GetMutes(ctx context.Context, req *schema.GetMutesReq) (resp []*schema.MuteItem, err error) {
+	mutes, err := us.userMuteRepo.GetUserMutes(ctx, req.UserID, req.MuteType)
+	if err != nil {
+		return nil, err
+	}
+	questionIDs, userIDs := make([]string, 0), make([]string, 0)
+	for _, mute := range mutes {
+		if mute.MuteType == constant.QuestionObjectType {
+			questionIDs = append(questionIDs, mute.ObjectID)
+		} else {
+			userIDs = append(userIDs, mute.ObjectID)
+		}
+	}
+	questionTitles := make(map[string]string, len(questionIDs))
+	if len(questionIDs) > 0 {
+		questions, err := us.questionRepo.FindByID(ctx, questionIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, question := range questions {
+			questionTitles[question.ID] = question.Title
+		}
+	}
+	users, err := us.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = make([]*schema.MuteItem, 0, len(mutes))
+	for _, mute := range mutes {
+		item := &schema.MuteItem{
+			MuteType:  mute.MuteType,
+			ObjectID:  mute.ObjectID,
+			CreatedAt: mute.CreatedAt.Unix(),
+		}
+		if mute.MuteType == constant.QuestionObjectType {
+			item.ObjectID = uid.EnShortID(mute.ObjectID)
+			item.Title = questionTitles[mute.ObjectID]
+		} else {
+			item.User = users[mute.ObjectID]
+		}
+		resp = append(resp, item)
+	}
+	return resp, nil
+}
+
+// GetMutedUserIDs get the users muted the question or the user triggering the notification,
+// the notification is delivered to everyone if failed to get the mute lists
+func (us *UserMuteService) GetMutedUserIDs(ctx context.Context, userIDs []string, questionID, triggerUserID string) (
+	muted map[string]bool) {
+	muted = make(map[string]bool)
+	mutedUserIDs, err := us.userMuteRepo.GetMutedUserIDs(ctx, userIDs, uid.DeShortID(questionID), triggerUserID)
+	if err != nil {
+		log.Errorf("get muted users of question %s and user %s failed: %v", questionID, triggerUserID, err)
+		return muted
+	}
+	for _, userID := range mutedUserIDs {
+		muted[userID] = true
+	}
+	return muted
+}
+
+// IsMuted whether the user muted the question or the user triggering the notification
+func (us *UserMuteService) IsMuted(ctx context.Context, userID, questionID, triggerUserID string) bool {
+	return us.GetMutedUserIDs(ctx, []string{userID}, questionID, triggerUserID)[userID]
+}
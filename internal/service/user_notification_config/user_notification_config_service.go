@@ -85,6 +85,69 @@ func (us *UserNotificationConfigService) UpdateUserNotificationConfig(
 	if err != nil {
 		return err
 	}
+	err = us.userNotificationConfigRepo.Save(ctx,
+		us.convertToEntity(ctx, req.UserID, constant.ReEngagementSource, req.NotificationConfig.ReEngagement))
+	if err != nil {
+		return err
+	}
+	err = us.userNotificationConfigRepo.Save(ctx,
+		us.convertToEntity(ctx, req.UserID, constant.EveryVoteSource, req.NotificationConfig.EveryVote))
+	if err != nil {
+		return err
+	}
+	err = us.userNotificationConfigRepo.Save(ctx,
+		us.convertToEntity(ctx, req.UserID, constant.VoteMilestoneSource, req.NotificationConfig.VoteMilestone))
+	if err != nil {
+		return err
+	}
+	err = us.userNotificationConfigRepo.Save(ctx,
+		us.convertToEntity(ctx, req.UserID, constant.WebPushSource, req.NotificationConfig.WebPush))
+	if err != nil {
+		return err
+	}
+	err = us.userNotificationConfigRepo.Save(ctx,
+		us.convertToEntity(ctx, req.UserID, constant.DigestDailySource, req.NotificationConfig.DigestDaily))
+	if err != nil {
+		return err
+	}
+	err = us.userNotificationConfigRepo.Save(ctx,
+		us.convertToEntity(ctx, req.UserID, constant.DigestWeeklySource, req.NotificationConfig.DigestWeekly))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetUserNotificationPreferences get the notification preference matrix of the user
+func (us *UserNotificationConfigService) GetUserNotificationPreferences(ctx context.Context, userID string) (
+	resp *schema.GetNotificationPreferenceResp, err error) {
+	notificationConfigs, err := us.userNotificationConfigRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp = &schema.GetNotificationPreferenceResp{}
+	resp.NotificationPreferenceMatrix = *schema.NewNotificationPreferenceMatrix(notificationConfigs)
+	return resp, nil
+}
+
+// UpdateUserNotificationPreferences save the channels of the listed event types in the preference matrix
+func (us *UserNotificationConfigService) UpdateUserNotificationPreferences(
+	ctx context.Context, req *schema.UpdateNotificationPreferenceReq) (err error) {
+	for _, preference := range req.Preferences {
+		preference.Format()
+		channels := preference.ToChannels()
+		c := &entity.UserNotificationConfig{
+			UserID:   req.UserID,
+			Source:   string(constant.NotificationEventSource(preference.Event)),
+			Channels: channels.ToJsonString(),
+		}
+		for _, ch := range channels {
+			c.Enabled = c.Enabled || ch.Enable
+		}
+		if err = us.userNotificationConfigRepo.Save(ctx, c); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,138 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_session
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/auth"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// UserSessionService the login sessions of user
+type UserSessionService struct {
+	authService *auth.AuthService
+	userRepo    usercommon.UserRepo
+}
+
+// NewUserSessionService new user session service
+func NewUserSessionService(
+	authService *auth.AuthService,
+	userRepo usercommon.UserRepo,
+) *UserSessionService {
+	return &UserSessionService{
+		authService: authService,
+		userRepo:    userRepo,
+	}
+}
+
+// GetUserSessions get the login sessions of user, the latest active first
+func (us *UserSessionService) GetUserSessions(ctx context.Context, userID, currentAccessToken string) (
+	resp []*schema.UserSessionItem, err error) {
+	sessions, err := us.authService.GetUserSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.UserSessionItem, 0, len(sessions))
+	for accessToken, session := range sessions {
+		resp = append(resp, &schema.UserSessionItem{
+			SessionID:    sessionID(accessToken),
+			IP:           session.IP,
+			UserAgent:    session.UserAgent,
+			CreatedAt:    session.CreatedAt,
+			LastActiveAt: session.LastActiveAt,
+			Current:      len(currentAccessToken) > 0 && accessToken == currentAccessToken,
+		})
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		if resp[i].LastActiveAt != resp[j].LastActiveAt {
+			return resp[i].LastActiveAt > resp[j].LastActiveAt
+		}
+		return resp[i].CreatedAt > resp[j].CreatedAt
+	})
+	return resp, nil
+}
+
+// RemoveUserSession log out the session of user
+func (us *UserSessionService) RemoveUserSession(ctx context.Context, req *schema.RemoveUserSessionReq) (err error) {
+	sessions, err := us.authService.GetUserSessions(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	for accessToken := range sessions {
+		if sessionID(accessToken) == req.SessionID {
+			return us.authService.RemoveUserSession(ctx, req.UserID, accessToken)
+		}
+	}
+	return errors.NotFound(reason.UserSessionNotFound)
+}
+
+// RemoveOtherUserSessions log out the sessions of user except the current one
+func (us *UserSessionService) RemoveOtherUserSessions(ctx context.Context, req *schema.RemoveOtherUserSessionsReq) (err error) {
+	sessions, err := us.authService.GetUserSessions(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	for accessToken := range sessions {
+		if accessToken == req.AccessToken {
+			continue
+		}
+		if err = us.authService.RemoveUserSession(ctx, req.UserID, accessToken); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ForceLogoutUser admin log out all the sessions of user,
+// the bot tokens and personal access tokens are not revoked
+func (us *UserSessionService) ForceLogoutUser(ctx context.Context, req *schema.ForceLogoutUserReq) (err error) {
+	_, exist, err := us.userRepo.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.NotFound(reason.UserNotFound)
+	}
+	sessions, err := us.authService.GetUserSessions(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	for accessToken := range sessions {
+		if err = us.authService.RemoveUserSession(ctx, req.UserID, accessToken); err != nil {
+			return err
+		}
+	}
+	// the sessions logged in before the device is recorded are not listed
+	us.authService.RemoveUserAllTokens(ctx, req.UserID)
+	return nil
+}
+
+// sessionID the session is identified by the hash of the access token
+func sessionID(accessToken string) string {
+	sum := sha256.Sum256([]byte(accessToken))
+	return hex.EncodeToString(sum[:])
+}
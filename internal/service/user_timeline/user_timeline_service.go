@@ -0,0 +1,154 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_timeline
+
+import (
+	"context"
+	"sort"
+
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/htmltext"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// UserTimelineRepo user timeline repository
+type UserTimelineRepo interface {
+	AddUserEvent(ctx context.Context, event *entity.UserEvent) (err error)
+	GetUserTimelineRecords(ctx context.Context, userID string, limit int) (
+		records []*entity.UserTimelineRecord, total int64, err error)
+	GetUserIPs(ctx context.Context, userID string) (ips []string, err error)
+}
+
+// UserTimelineService assemble the chronological history of user for abuse investigations
+type UserTimelineService struct {
+	userTimelineRepo UserTimelineRepo
+	userRepo         usercommon.UserRepo
+	userCommon       *usercommon.UserCommon
+}
+
+// NewUserTimelineService new user timeline service
+func NewUserTimelineService(
+	userTimelineRepo UserTimelineRepo,
+	userRepo usercommon.UserRepo,
+	userCommon *usercommon.UserCommon,
+) *UserTimelineService {
+	return &UserTimelineService{
+		userTimelineRepo: userTimelineRepo,
+		userRepo:         userRepo,
+		userCommon:       userCommon,
+	}
+}
+
+// RecordLogin record the login event of user
+func (us *UserTimelineService) RecordLogin(ctx context.Context, userID, ip string) {
+	us.addUserEvent(ctx, &entity.UserEvent{
+		UserID:     userID,
+		EventType:  entity.UserEventTypeLogin,
+		IP:         ip,
+		OperatorID: "0",
+	})
+}
+
+// RecordStatusChange record the status change of user, the operator is empty if changed by the system
+func (us *UserTimelineService) RecordStatusChange(ctx context.Context, userID, operatorID, content string) {
+	if len(operatorID) == 0 {
+		operatorID = "0"
+	}
+	us.addUserEvent(ctx, &entity.UserEvent{
+		UserID:     userID,
+		EventType:  entity.UserEventTypeStatusChange,
+		OperatorID: operatorID,
+		Content:    content,
+	})
+}
+
+func (us *UserTimelineService) addUserEvent(ctx context.Context, event *entity.UserEvent) {
+	if err := us.userTimelineRepo.AddUserEvent(ctx, event); err != nil {
+		log.Errorf("add user %s event %s failed: %v", event.UserID, event.EventType, err)
+	}
+}
+
+// GetUserTimeline get the history timeline of user, the latest first
+func (us *UserTimelineService) GetUserTimeline(ctx context.Context, req *schema.GetUserTimelineReq) (
+	resp *schema.GetUserTimelineResp, err error) {
+	userInfo, exist, err := us.userRepo.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+	ips, err := us.userTimelineRepo.GetUserIPs(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	resp = &schema.GetUserTimelineResp{
+		User:       *us.userCommon.FormatUserBasicInfo(ctx, userInfo),
+		RegisterIP: userInfo.IPInfo,
+		IPs:        ips,
+		List:       make([]*schema.UserTimelineItem, 0),
+	}
+	if !userInfo.LastLoginDate.IsZero() {
+		resp.LastLoginAt = userInfo.LastLoginDate.Unix()
+	}
+
+	// every source is sorted, so the latest page*pageSize records of each source are enough to merge the page
+	page, pageSize := pager.ValPageAndPageSize(req.Page, req.PageSize)
+	offset := (page - 1) * pageSize
+	limit := min(offset+pageSize, schema.UserTimelineMaxRecords)
+	records, total, err := us.userTimelineRepo.GetUserTimelineRecords(ctx, req.UserID, limit)
+	if err != nil {
+		return nil, err
+	}
+	resp.Total = total
+	if offset >= limit {
+		return resp, nil
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].CreatedAt.After(records[j].CreatedAt)
+	})
+	records = records[min(offset, len(records)):min(limit, len(records))]
+
+	userIDs := make([]string, 0, len(records))
+	for _, record := range records {
+		userIDs = append(userIDs, record.RelatedUserID)
+	}
+	userInfoMapping, err := us.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		resp.List = append(resp.List, &schema.UserTimelineItem{
+			Type:        record.Type,
+			ObjectID:    record.ObjectID,
+			CreatedAt:   record.CreatedAt.Unix(),
+			Summary:     htmltext.FetchExcerpt(record.Summary, "...", 200),
+			Status:      record.Status,
+			IP:          record.IP,
+			RelatedUser: userInfoMapping[record.RelatedUserID],
+		})
+	}
+	return resp, nil
+}
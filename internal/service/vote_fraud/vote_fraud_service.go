@@ -0,0 +1,232 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package vote_fraud
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/activity_type"
+	"github.com/apache/answer/internal/service/config"
+	"github.com/apache/answer/internal/service/content"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/obj"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// VoteFraudRepo vote fraud repository
+type VoteFraudRepo interface {
+	GetVotePairCounts(ctx context.Context, activityTypes []int, since time.Time, minCount int) (
+		pairs []*entity.VotePairCount, err error)
+	GetPairVotes(ctx context.Context, activityTypes []int, since time.Time, voterID, targetUserID string) (
+		activities []*entity.Activity, err error)
+	AddVoteInvalidation(ctx context.Context, record *entity.VoteInvalidation) (err error)
+	GetVoteInvalidationPage(ctx context.Context, page, pageSize int) (
+		records []*entity.VoteInvalidation, total int64, err error)
+}
+
+// VoteFraudService detect the serial voting and voting rings, and reverse the votes
+type VoteFraudService struct {
+	voteFraudRepo   VoteFraudRepo
+	voteService     *content.VoteService
+	configService   *config.ConfigService
+	siteInfoService siteinfo_common.SiteInfoCommonService
+	userCommon      *usercommon.UserCommon
+	running         atomic.Bool
+}
+
+// NewVoteFraudService new vote fraud service
+func NewVoteFraudService(
+	voteFraudRepo VoteFraudRepo,
+	voteService *content.VoteService,
+	configService *config.ConfigService,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	userCommon *usercommon.UserCommon,
+) *VoteFraudService {
+	return &VoteFraudService{
+		voteFraudRepo:   voteFraudRepo,
+		voteService:     voteService,
+		configService:   configService,
+		siteInfoService: siteInfoService,
+		userCommon:      userCommon,
+	}
+}
+
+// DetectCron detect the vote fraud in the recent window and reverse the votes.
+// The reversed votes are cancelled, so they will not be detected again.
+func (vs *VoteFraudService) DetectCron(ctx context.Context) {
+	conf, err := vs.siteInfoService.GetSiteVoteFraud(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if !conf.Enabled {
+		return
+	}
+	if !vs.running.CompareAndSwap(false, true) {
+		return
+	}
+	defer vs.running.Store(false)
+
+	since := time.Now().Add(-time.Duration(conf.WindowHours) * time.Hour)
+	upTypes := vs.getActivityTypes(ctx, activity_type.QuestionVotedUp, activity_type.AnswerVotedUp)
+	downTypes := vs.getActivityTypes(ctx, activity_type.QuestionVotedDown, activity_type.AnswerVotedDown)
+
+	// vote up: serial voting and voting rings
+	minCount := min(conf.SerialVoteThreshold, conf.RingVoteThreshold)
+	upPairs, err := vs.voteFraudRepo.GetVotePairCounts(ctx, upTypes, since, minCount)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	pairCounts := make(map[[2]string]int, len(upPairs))
+	for _, pair := range upPairs {
+		pairCounts[[2]string{pair.VoterID, pair.TargetUserID}] = pair.VoteCount
+	}
+	for _, pair := range upPairs {
+		if pair.VoteCount >= conf.SerialVoteThreshold {
+			vs.invalidateVotes(ctx, upTypes, since, pair, true, entity.VoteInvalidationReasonSerialVoting)
+			continue
+		}
+		reverseCount := pairCounts[[2]string{pair.TargetUserID, pair.VoterID}]
+		if pair.VoteCount >= conf.RingVoteThreshold && reverseCount >= conf.RingVoteThreshold {
+			vs.invalidateVotes(ctx, upTypes, since, pair, true, entity.VoteInvalidationReasonVotingRing)
+		}
+	}
+
+	// vote down: only serial voting, targeted downvoting
+	downPairs, err := vs.voteFraudRepo.GetVotePairCounts(ctx, downTypes, since, conf.SerialVoteThreshold)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	for _, pair := range downPairs {
+		vs.invalidateVotes(ctx, downTypes, since, pair, false, entity.VoteInvalidationReasonSerialVoting)
+	}
+}
+
+func (vs *VoteFraudService) invalidateVotes(ctx context.Context, activityTypes []int, since time.Time,
+	pair *entity.VotePairCount, voteUp bool, reason string) {
+	activities, err := vs.voteFraudRepo.GetPairVotes(ctx, activityTypes, since, pair.VoterID, pair.TargetUserID)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	log.Infof("invalidate %d votes from user %s to user %s for %s", len(activities), pair.VoterID, pair.TargetUserID, reason)
+	for _, act := range activities {
+		if err := vs.voteService.RevokeVote(ctx, pair.VoterID, act.ObjectID); err != nil {
+			log.Errorf("revoke vote of user %s on object %s failed: %v", pair.VoterID, act.ObjectID, err)
+			continue
+		}
+		err = vs.voteFraudRepo.AddVoteInvalidation(ctx, &entity.VoteInvalidation{
+			VoterID:      pair.VoterID,
+			TargetUserID: pair.TargetUserID,
+			ObjectID:     act.ObjectID,
+			VoteUp:       voteUp,
+			Reason:       reason,
+			Rank:         act.Rank,
+			VotedAt:      act.UpdatedAt,
+		})
+		if err != nil {
+			log.Error(err)
+		}
+	}
+}
+
+// ReverseUserVotes reverse all the votes cast by the user, it is used after the user is confirmed as a sock puppet.
+// The reversed votes are recorded as vote invalidations of the operator.
+func (vs *VoteFraudService) ReverseUserVotes(ctx context.Context, req *schema.ReverseUserVotesReq) (
+	resp *schema.ReverseUserVotesResp, err error) {
+	_, exist, err := vs.userCommon.GetUserBasicInfoByID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+	invalidations, err := vs.voteService.ReverseUserCastVotes(ctx, req.UserID, req.OperatorID)
+	if err != nil {
+		return nil, err
+	}
+	resp = &schema.ReverseUserVotesResp{ReversedCount: len(invalidations)}
+	for _, record := range invalidations {
+		resp.ReversedRank += record.Rank
+	}
+	return resp, nil
+}
+
+// GetVoteInvalidationPage get the page of invalidated votes
+func (vs *VoteFraudService) GetVoteInvalidationPage(ctx context.Context, req *schema.GetVoteInvalidationPageReq) (
+	resp *pager.PageModel, err error) {
+	records, total, err := vs.voteFraudRepo.GetVoteInvalidationPage(ctx, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(records)*2)
+	for _, record := range records {
+		userIDs = append(userIDs, record.VoterID, record.TargetUserID, record.OperatorID)
+	}
+	userInfoMapping, err := vs.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*schema.GetVoteInvalidationPageResp, 0, len(records))
+	for _, record := range records {
+		item := &schema.GetVoteInvalidationPageResp{
+			ID:        record.ID,
+			ObjectID:  record.ObjectID,
+			VoteUp:    record.VoteUp,
+			Reason:    record.Reason,
+			Rank:      record.Rank,
+			VotedAt:   record.VotedAt.Unix(),
+			CreatedAt: record.CreatedAt.Unix(),
+		}
+		item.ObjectType, _ = obj.GetObjectTypeStrByObjectID(record.ObjectID)
+		if userInfo, ok := userInfoMapping[record.VoterID]; ok {
+			item.Voter = *userInfo
+		}
+		if userInfo, ok := userInfoMapping[record.TargetUserID]; ok {
+			item.TargetUser = *userInfo
+		}
+		item.Operator = userInfoMapping[record.OperatorID]
+		list = append(list, item)
+	}
+	return pager.NewPageModel(total, list), nil
+}
+
+func (vs *VoteFraudService) getActivityTypes(ctx context.Context, keys ...string) (activityTypes []int) {
+	for _, key := range keys {
+		cfg, err := vs.configService.GetConfigByKey(ctx, key)
+		if err != nil {
+			log.Warnf("get config by key error: %v", err)
+			continue
+		}
+		activityTypes = append(activityTypes, cfg.ID)
+	}
+	return activityTypes
+}
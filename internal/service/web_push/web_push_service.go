@@ -0,0 +1,278 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package web_push
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/internal/service/user_notification_config"
+	"github.com/apache/answer/pkg/display"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/apache/answer/pkg/webpush"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+)
+
+// webPushTTL the seconds the push service keeps the message while the device is offline
+const webPushTTL = 24 * 60 * 60
+
+// WebPushRepo web push subscription repository
+type WebPushRepo interface {
+	SaveSubscription(ctx context.Context, subscription *entity.WebPushSubscription) (err error)
+	RemoveSubscription(ctx context.Context, userID, endpoint string) (err error)
+	RemoveSubscriptionByID(ctx context.Context, id string) (err error)
+	GetSubscriptionsByUserID(ctx context.Context, userID string) (subscriptions []*entity.WebPushSubscription, err error)
+}
+
+// WebPushService push the inbox notifications to the subscribed browsers of the users
+type WebPushService struct {
+	webPushRepo                WebPushRepo
+	siteInfoService            siteinfo_common.SiteInfoCommonService
+	userCommon                 *usercommon.UserCommon
+	userNotificationConfigRepo user_notification_config.UserNotificationConfigRepo
+	httpClient                 *http.Client
+}
+
+// NewWebPushService new web push service
+func NewWebPushService(
+	webPushRepo WebPushRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	userCommon *usercommon.UserCommon,
+	userNotificationConfigRepo user_notification_config.UserNotificationConfigRepo,
+) *WebPushService {
+	return &WebPushService{
+		webPushRepo:                webPushRepo,
+		siteInfoService:            siteInfoService,
+		userCommon:                 userCommon,
+		userNotificationConfigRepo: userNotificationConfigRepo,
+		httpClient:                 &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// GetVAPIDPublicKey get the public key the browsers subscribe with
+func (ws *WebPushService) GetVAPIDPublicKey(ctx context.Context) (resp *schema.GetWebPushKeyResp, err error) {
+	conf, err := ws.siteInfoService.GetSiteWebPush(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp = &schema.GetWebPushKeyResp{Enabled: conf.Enabled && len(conf.PublicKey) > 0}
+	if resp.Enabled {
+		resp.PublicKey = conf.PublicKey
+	}
+	return resp, nil
+}
+
+// Subscribe save the subscription of the device, the first subscription opts the user in web push
+func (ws *WebPushService) Subscribe(ctx context.Context, req *schema.WebPushSubscribeReq) (err error) {
+	conf, err := ws.siteInfoService.GetSiteWebPush(ctx)
+	if err != nil {
+		return err
+	}
+	if !conf.Enabled {
+		return errors.BadRequest(reason.WebPushDisabled)
+	}
+	err = ws.webPushRepo.SaveSubscription(ctx, &entity.WebPushSubscription{
+		UserID:    req.UserID,
+		Endpoint:  req.Endpoint,
+		P256dh:    req.Keys.P256dh,
+		Auth:      req.Keys.Auth,
+		UserAgent: req.UserAgent,
+	})
+	if err != nil {
+		return err
+	}
+
+	subscriptions, err := ws.webPushRepo.GetSubscriptionsByUserID(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	for i := schema.WebPushMaxSubscriptions; i < len(subscriptions); i++ {
+		if err = ws.webPushRepo.RemoveSubscriptionByID(ctx, subscriptions[i].ID); err != nil {
+			return err
+		}
+	}
+
+	_, exist, err := ws.userNotificationConfigRepo.GetByUserIDAndSource(ctx, req.UserID, constant.WebPushSource)
+	if err != nil || exist {
+		return err
+	}
+	channels := schema.NotificationChannels{{Key: constant.WebPushChannel, Enable: true}}
+	return ws.userNotificationConfigRepo.Save(ctx, &entity.UserNotificationConfig{
+		UserID:   req.UserID,
+		Source:   string(constant.WebPushSource),
+		Channels: channels.ToJsonString(),
+		Enabled:  true,
+	})
+}
+
+// Unsubscribe remove the subscription of the device
+func (ws *WebPushService) Unsubscribe(ctx context.Context, req *schema.WebPushUnsubscribeReq) (err error) {
+	return ws.webPushRepo.RemoveSubscription(ctx, req.UserID, req.Endpoint)
+}
+
+// GetSubscriptions get the subscribed devices of the user
+func (ws *WebPushService) GetSubscriptions(ctx context.Context, userID string) (
+	resp []*schema.WebPushSubscriptionResp, err error) {
+	subscriptions, err := ws.webPushRepo.GetSubscriptionsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.WebPushSubscriptionResp, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		resp = append(resp, &schema.WebPushSubscriptionResp{
+			ID:        subscription.ID,
+			Endpoint:  subscription.Endpoint,
+			UserAgent: subscription.UserAgent,
+			CreatedAt: subscription.CreatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// PushNotification push the inbox notification to the devices of the receiver,
+// the caller checks the receiver enabled the push of the notification in the preference matrix
+func (ws *WebPushService) PushNotification(ctx context.Context, msg *schema.NotificationMsg,
+	objInfo *schema.SimpleObjectInfo, triggerUser *schema.UserBasicInfo) {
+	if objInfo == nil || triggerUser == nil {
+		return
+	}
+	conf, err := ws.siteInfoService.GetSiteWebPush(ctx)
+	if err != nil {
+		log.Errorf("get site web push config failed: %v", err)
+		return
+	}
+	if !conf.Enabled || len(conf.PrivateKey) == 0 {
+		return
+	}
+	subscriptions, err := ws.webPushRepo.GetSubscriptionsByUserID(ctx, msg.ReceiverUserID)
+	if err != nil || len(subscriptions) == 0 {
+		return
+	}
+
+	payload, err := ws.buildPayload(ctx, msg, objInfo, triggerUser)
+	if err != nil {
+		log.Errorf("build web push payload failed: %v", err)
+		return
+	}
+	for _, subscription := range subscriptions {
+		gone, err := ws.send(ctx, conf, subscription, payload)
+		if err != nil {
+			log.Warnf("send web push to subscription %s failed: %v", subscription.ID, err)
+		}
+		// the subscription is expired or unsubscribed in the browser
+		if gone {
+			if err = ws.webPushRepo.RemoveSubscriptionByID(ctx, subscription.ID); err != nil {
+				log.Error(err)
+			}
+		}
+	}
+}
+
+func (ws *WebPushService) buildPayload(ctx context.Context, msg *schema.NotificationMsg,
+	objInfo *schema.SimpleObjectInfo, triggerUser *schema.UserBasicInfo) (payload []byte, err error) {
+	siteInfo, err := ws.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return nil, err
+	}
+	seoInfo, err := ws.siteInfoService.GetSiteSeo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	questionID, answerID := uid.DeShortID(objInfo.QuestionID), uid.DeShortID(objInfo.AnswerID)
+	var link string
+	switch {
+	case len(objInfo.CommentID) > 0:
+		link = display.CommentURL(seoInfo.Permalink, siteInfo.SiteUrl, questionID, objInfo.Title, answerID, objInfo.CommentID)
+	case len(answerID) > 0:
+		link = display.AnswerURL(seoInfo.Permalink, siteInfo.SiteUrl, questionID, objInfo.Title, answerID)
+	default:
+		link = display.QuestionURL(seoInfo.Permalink, siteInfo.SiteUrl, questionID, objInfo.Title)
+	}
+
+	lang := ws.getUserLang(ctx, msg.ReceiverUserID)
+	title := translator.TrWithData(lang, reason.WebPushNotificationTitle, map[string]any{
+		"User":   triggerUser.DisplayName,
+		"Action": translator.Tr(lang, msg.NotificationAction),
+	})
+	// the notifications of the same question replace each other instead of piling up
+	return json.Marshal(schema.NewWebPushPayload(title, objInfo.Title, link, "question-"+questionID, time.Now()))
+}
+
+// send send the payload to the push service, gone is true if the subscription is no longer valid
+func (ws *WebPushService) send(ctx context.Context, conf *schema.SiteWebPushResp,
+	subscription *entity.WebPushSubscription, payload []byte) (gone bool, err error) {
+	body, err := webpush.Encrypt(subscription.P256dh, subscription.Auth, payload)
+	if err != nil {
+		// the keys of the subscription are broken, it can never be delivered
+		return true, err
+	}
+	authorization, err := webpush.VAPIDAuthorization(subscription.Endpoint, conf.Subject, conf.PrivateKey, conf.PublicKey)
+	if err != nil {
+		return false, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return true, err
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(webPushTTL))
+	req.Header.Set("Urgency", "normal")
+	req.Header.Set("User-Agent", "Answer/"+constant.Version)
+	resp, err := ws.httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		return true, nil
+	}
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return false, fmt.Errorf("response status %d", resp.StatusCode)
+	}
+	return false, nil
+}
+
+func (ws *WebPushService) getUserLang(ctx context.Context, userID string) i18n.Language {
+	userInfo, exist, err := ws.userCommon.GetUserBasicInfoByID(ctx, userID)
+	if err == nil && exist && len(userInfo.Language) > 0 && userInfo.Language != translator.DefaultLangOption {
+		return i18n.Language(userInfo.Language)
+	}
+	interfaceInfo, err := ws.siteInfoService.GetSiteInterface(ctx)
+	if err != nil {
+		return i18n.DefaultLanguage
+	}
+	return i18n.Language(interfaceInfo.Language)
+}
@@ -0,0 +1,354 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/event_queue"
+	"github.com/apache/answer/internal/service/object_info"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/apache/answer/internal/service/space_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/display"
+	"github.com/apache/answer/pkg/token"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// webhookDeliveryBatchSize the number of due deliveries attempted in one batch
+	webhookDeliveryBatchSize = 100
+	// webhookDeliveryRetentionDays the finished deliveries older than it are deleted
+	webhookDeliveryRetentionDays = 30
+	// webhookDeliveryErrorMaxLength the max length of the error saved in delivery log
+	webhookDeliveryErrorMaxLength = 1024
+)
+
+// WebhookRepo webhook repository
+type WebhookRepo interface {
+	AddDeliveries(ctx context.Context, deliveries []*entity.WebhookDelivery) (err error)
+	GetDelivery(ctx context.Context, id string) (delivery *entity.WebhookDelivery, exist bool, err error)
+	GetDueDeliveries(ctx context.Context, now time.Time, limit int) (deliveries []*entity.WebhookDelivery, err error)
+	UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) (err error)
+	GetDeliveryPage(ctx context.Context, req *schema.GetWebhookDeliveryPageReq) (
+		deliveries []*entity.WebhookDelivery, total int64, err error)
+	DeleteDeliveriesBefore(ctx context.Context, before time.Time) (err error)
+}
+
+// WebhookService post the events to the webhooks configured by admin, and retry the failed deliveries
+type WebhookService struct {
+	webhookRepo     WebhookRepo
+	siteInfoService siteinfo_common.SiteInfoCommonService
+	userCommon      *usercommon.UserCommon
+	objectService   *object_info.ObjService
+	spaceCommon     *space_common.SpaceCommon
+	httpClient      *http.Client
+	running         atomic.Bool
+}
+
+// NewWebhookService new webhook service
+func NewWebhookService(
+	webhookRepo WebhookRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	userCommon *usercommon.UserCommon,
+	objectService *object_info.ObjService,
+	spaceCommon *space_common.SpaceCommon,
+	eventQueueService event_queue.EventQueueService,
+) *WebhookService {
+	ws := &WebhookService{
+		webhookRepo:     webhookRepo,
+		siteInfoService: siteInfoService,
+		userCommon:      userCommon,
+		objectService:   objectService,
+		spaceCommon:     spaceCommon,
+		httpClient:      &http.Client{Timeout: 10 * time.Second},
+	}
+	eventQueueService.RegisterHandler(ws.handleEvent)
+	return ws
+}
+
+// handleEvent create the deliveries of the event for each webhook subscribed it
+func (ws *WebhookService) handleEvent(ctx context.Context, msg *schema.EventMsg) error {
+	event, ok := schema.WebhookEventMapping[msg.EventType]
+	if !ok {
+		return nil
+	}
+	conf, err := ws.siteInfoService.GetSiteWebhook(ctx)
+	if err != nil {
+		return err
+	}
+	webhooks := conf.GetWebhooksByEvent(event)
+	if len(webhooks) == 0 {
+		return nil
+	}
+	// the question of the shadow-banned user or the private space is not delivered outside the site
+	if event == schema.WebhookEventQuestionCreated || event == schema.WebhookEventAnswerAccepted {
+		objInfo, err := ws.objectService.GetInfo(ctx, msg.QuestionID)
+		if err != nil {
+			return err
+		}
+		if objInfo.QuestionShow == entity.QuestionShadow {
+			return nil
+		}
+		if canView, err := ws.spaceCommon.CanViewQuestion(ctx, msg.QuestionID, ""); err != nil || !canView {
+			return err
+		}
+	}
+	payload, err := ws.buildPayload(ctx, event, msg)
+	if err != nil {
+		return err
+	}
+	body, _ := json.Marshal(payload)
+	now := time.Now()
+	deliveries := make([]*entity.WebhookDelivery, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		deliveries = append(deliveries, &entity.WebhookDelivery{
+			WebhookURL:    webhook.URL,
+			Event:         event,
+			Payload:       string(body),
+			Status:        entity.WebhookDeliveryStatusPending,
+			NextAttemptAt: now,
+		})
+	}
+	if err = ws.webhookRepo.AddDeliveries(ctx, deliveries); err != nil {
+		return err
+	}
+	go ws.DeliverCron(context.Background())
+	return nil
+}
+
+func (ws *WebhookService) buildPayload(ctx context.Context, event string, msg *schema.EventMsg) (
+	payload *schema.WebhookPayload, err error) {
+	siteGeneral, err := ws.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return nil, err
+	}
+	payload = &schema.WebhookPayload{
+		Event:     event,
+		SiteURL:   siteGeneral.SiteUrl,
+		CreatedAt: time.Now().Unix(),
+	}
+	user, exist, err := ws.userCommon.GetUserBasicInfoByID(ctx, msg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		payload.User = &schema.WebhookUser{
+			ID:          user.ID,
+			Username:    user.Username,
+			DisplayName: user.DisplayName,
+		}
+	}
+
+	var objectID string
+	switch event {
+	case schema.WebhookEventQuestionCreated:
+		objectID = msg.QuestionID
+	case schema.WebhookEventAnswerAccepted:
+		objectID = msg.AnswerID
+	case schema.WebhookEventContentReported:
+		objectID = msg.TriggerObjectID
+	}
+	if len(objectID) == 0 {
+		return payload, nil
+	}
+	objInfo, err := ws.objectService.GetInfo(ctx, objectID)
+	if err != nil {
+		return nil, err
+	}
+	permalink := constant.PermalinkQuestionID
+	if siteSeo, err := ws.siteInfoService.GetSiteSeo(ctx); err == nil {
+		permalink = siteSeo.Permalink
+	}
+	payload.Object = &schema.WebhookObject{
+		ObjectType: objInfo.ObjectType,
+		ID:         objInfo.ObjectID,
+		QuestionID: objInfo.QuestionID,
+		AnswerID:   objInfo.AnswerID,
+		CommentID:  objInfo.CommentID,
+		Title:      objInfo.Title,
+		UserID:     objInfo.ObjectCreatorUserID,
+	}
+	switch objInfo.ObjectType {
+	case constant.QuestionObjectType:
+		payload.Object.URL = display.QuestionURL(permalink, siteGeneral.SiteUrl, objInfo.QuestionID, objInfo.Title)
+	case constant.AnswerObjectType:
+		payload.Object.URL = display.AnswerURL(permalink, siteGeneral.SiteUrl, objInfo.QuestionID, objInfo.Title, objInfo.AnswerID)
+	case constant.CommentObjectType:
+		payload.Object.URL = display.CommentURL(permalink, siteGeneral.SiteUrl, objInfo.QuestionID, objInfo.Title,
+			objInfo.AnswerID, objInfo.CommentID)
+	}
+	return payload, nil
+}
+
+// DeliverCron attempt the pending deliveries whose next attempt time is due
+func (ws *WebhookService) DeliverCron(ctx context.Context) {
+	if !ws.running.CompareAndSwap(false, true) {
+		return
+	}
+	defer ws.running.Store(false)
+
+	conf, err := ws.siteInfoService.GetSiteWebhook(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if !conf.Enabled {
+		return
+	}
+	// the failed deliveries are scheduled later than now, so they are not attempted again in this run
+	now := time.Now()
+	for {
+		deliveries, err := ws.webhookRepo.GetDueDeliveries(ctx, now, webhookDeliveryBatchSize)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		for _, delivery := range deliveries {
+			ws.attempt(ctx, conf, delivery)
+		}
+		if len(deliveries) < webhookDeliveryBatchSize {
+			return
+		}
+	}
+}
+
+// CleanCron delete the finished deliveries out of retention
+func (ws *WebhookService) CleanCron(ctx context.Context) {
+	before := time.Now().AddDate(0, 0, -webhookDeliveryRetentionDays)
+	if err := ws.webhookRepo.DeleteDeliveriesBefore(ctx, before); err != nil {
+		log.Error(err)
+	}
+}
+
+func (ws *WebhookService) attempt(ctx context.Context, conf *schema.SiteWebhookResp, delivery *entity.WebhookDelivery) {
+	delivery.Attempts++
+	webhook := conf.GetWebhookByURL(delivery.WebhookURL)
+	if webhook == nil {
+		delivery.Status = entity.WebhookDeliveryStatusFailed
+		delivery.LastError = "the webhook has been removed"
+	} else {
+		var err error
+		delivery.ResponseStatus, err = ws.send(ctx, webhook, delivery)
+		if err == nil {
+			delivery.Status = entity.WebhookDeliveryStatusSuccess
+			delivery.LastError = ""
+		} else {
+			log.Warnf("deliver webhook %s to %s failed: %v", delivery.ID, delivery.WebhookURL, err)
+			delivery.LastError = err.Error()
+			if len(delivery.LastError) > webhookDeliveryErrorMaxLength {
+				delivery.LastError = delivery.LastError[:webhookDeliveryErrorMaxLength]
+			}
+			if delay, ok := schema.WebhookRetryDelay(delivery.Attempts); ok {
+				delivery.NextAttemptAt = time.Now().Add(delay)
+			} else {
+				delivery.Status = entity.WebhookDeliveryStatusFailed
+			}
+		}
+	}
+	if err := ws.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+		log.Error(err)
+	}
+}
+
+func (ws *WebhookService) send(ctx context.Context, webhook *schema.WebhookTarget, delivery *entity.WebhookDelivery) (
+	statusCode int, err error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Answer/"+constant.Version)
+	req.Header.Set(schema.WebhookEventHeader, delivery.Event)
+	req.Header.Set(schema.WebhookDeliveryHeader, delivery.ID)
+	req.Header.Set(schema.WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(schema.WebhookSignatureHeader, "sha256="+token.Sign(webhook.Secret, timestamp, body))
+	resp, err := ws.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook response status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// GetDeliveryPage get the delivery logs
+func (ws *WebhookService) GetDeliveryPage(ctx context.Context, req *schema.GetWebhookDeliveryPageReq) (
+	resp *pager.PageModel, err error) {
+	deliveries, total, err := ws.webhookRepo.GetDeliveryPage(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*schema.WebhookDeliveryItem, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		item := &schema.WebhookDeliveryItem{
+			ID:             delivery.ID,
+			WebhookURL:     delivery.WebhookURL,
+			Event:          delivery.Event,
+			Payload:        delivery.Payload,
+			Status:         delivery.Status,
+			Attempts:       delivery.Attempts,
+			ResponseStatus: delivery.ResponseStatus,
+			LastError:      delivery.LastError,
+			CreatedAt:      delivery.CreatedAt.Unix(),
+			UpdatedAt:      delivery.UpdatedAt.Unix(),
+		}
+		if delivery.Status == entity.WebhookDeliveryStatusPending {
+			item.NextAttemptAt = delivery.NextAttemptAt.Unix()
+		}
+		list = append(list, item)
+	}
+	return pager.NewPageModel(total, list), nil
+}
+
+// Redeliver attempt the delivery again immediately, whatever it succeeded or failed
+func (ws *WebhookService) Redeliver(ctx context.Context, req *schema.RedeliverWebhookReq) (err error) {
+	delivery, exist, err := ws.webhookRepo.GetDelivery(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.WebhookDeliveryNotFound)
+	}
+	delivery.Status = entity.WebhookDeliveryStatusPending
+	delivery.NextAttemptAt = time.Now()
+	if err = ws.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+		return err
+	}
+	go ws.DeliverCron(context.Background())
+	return nil
+}
@@ -19,18 +19,86 @@
 
 package checker
 
-import "strings"
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 
+	"github.com/apache/answer/configs"
+	"github.com/apache/answer/internal/cli"
+	"github.com/apache/answer/pkg/dir"
+)
+
+var (
+	disposableEmailDomainMapping = make(map[string]bool)
+	disposableEmailDomainInit    sync.Once
+)
+
+// EmailInAllowEmailDomain the email domain is one of the domains or their subdomains, any email is allowed if empty
 func EmailInAllowEmailDomain(email string, allowEmailDomains []string) bool {
 	if len(allowEmailDomains) == 0 {
 		return true
 	}
+	return EmailInEmailDomains(email, allowEmailDomains)
+}
 
-	for _, domain := range allowEmailDomains {
-		if strings.HasSuffix(email, domain) {
+// EmailInEmailDomains the email domain is one of the domains or their subdomains
+func EmailInEmailDomains(email string, domains []string) bool {
+	emailDomain := getEmailDomain(email)
+	if len(emailDomain) == 0 {
+		return false
+	}
+	for _, domain := range domains {
+		domain = strings.ToLower(strings.TrimLeft(strings.TrimSpace(domain), "@."))
+		if len(domain) == 0 {
+			continue
+		}
+		if emailDomain == domain || strings.HasSuffix(emailDomain, "."+domain) {
 			return true
 		}
 	}
+	return false
+}
 
+func initDisposableEmailDomain() {
+	filePath := filepath.Join(cli.ConfigFileDir, cli.DefaultDisposableEmailDomainsFileName)
+	if dir.CheckFileExist(filePath) {
+		// if the disposable email domains file exists, read it and replace the maintained list
+		content, err := os.ReadFile(filePath)
+		if err == nil {
+			configs.DisposableEmailDomains = content
+		}
+	}
+	var domains []string
+	_ = json.Unmarshal(configs.DisposableEmailDomains, &domains)
+	for _, domain := range domains {
+		disposableEmailDomainMapping[strings.ToLower(domain)] = true
+	}
+}
+
+// IsDisposableEmail checks whether the email domain or its parent domain is a disposable email domain
+func IsDisposableEmail(email string) bool {
+	disposableEmailDomainInit.Do(initDisposableEmailDomain)
+	emailDomain := getEmailDomain(email)
+	for len(emailDomain) > 0 {
+		if disposableEmailDomainMapping[emailDomain] {
+			return true
+		}
+		_, parent, found := strings.Cut(emailDomain, ".")
+		if !found {
+			break
+		}
+		emailDomain = parent
+	}
 	return false
 }
+
+func getEmailDomain(email string) string {
+	idx := strings.LastIndex(email, "@")
+	if idx < 0 {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(email[idx+1:]))
+}
@@ -0,0 +1,41 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailInAllowEmailDomain(t *testing.T) {
+	assert.True(t, EmailInAllowEmailDomain("a@evil.com", nil))
+	assert.True(t, EmailInAllowEmailDomain("a@example.com", []string{"example.com"}))
+	assert.True(t, EmailInAllowEmailDomain("a@Dev.Example.com", []string{"@example.com"}))
+	assert.False(t, EmailInAllowEmailDomain("a@evilexample.com", []string{"example.com"}))
+	assert.False(t, EmailInAllowEmailDomain("example.com", []string{"example.com"}))
+}
+
+func TestIsDisposableEmail(t *testing.T) {
+	assert.True(t, IsDisposableEmail("a@mailinator.com"))
+	assert.True(t, IsDisposableEmail("a@box.MAILINATOR.com"))
+	assert.False(t, IsDisposableEmail("a@example.com"))
+	assert.False(t, IsDisposableEmail("mailinator.com"))
+}
@@ -0,0 +1,123 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package checker
+
+import (
+	"strings"
+	"unicode"
+)
+
+// latinStopWords the most common words of languages written in latin script
+var latinStopWords = map[string][]string{
+	"en": {"the", "and", "is", "are", "to", "of", "in", "it", "that", "this", "with", "for", "how", "what", "not", "have", "you", "can"},
+	"es": {"el", "la", "los", "las", "que", "es", "en", "con", "por", "para", "una", "del", "como", "pero", "no", "se", "mi", "al"},
+	"fr": {"le", "la", "les", "des", "est", "et", "un", "une", "que", "dans", "pour", "pas", "avec", "sur", "je", "ce", "du", "au"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "ich", "mit", "ein", "eine", "zu", "auf", "wie", "den", "es", "sich", "auch", "von"},
+	"pt": {"o", "os", "as", "que", "é", "em", "com", "não", "uma", "um", "para", "do", "da", "como", "mas", "se", "eu", "no"},
+	"it": {"il", "lo", "gli", "che", "è", "di", "non", "per", "una", "un", "con", "come", "della", "sono", "ma", "mi", "questo", "ho"},
+	"nl": {"de", "het", "een", "en", "ik", "niet", "is", "van", "dat", "op", "te", "met", "voor", "hoe", "maar", "wat", "zijn", "er"},
+	"tr": {"bir", "ve", "bu", "için", "ile", "ne", "da", "de", "mi", "nasıl", "çok", "ama", "gibi", "var", "yok", "olan", "ben", "daha"},
+	"id": {"yang", "dan", "di", "ini", "itu", "dengan", "untuk", "tidak", "saya", "ada", "bagaimana", "apa", "dari", "ke", "bisa", "akan", "juga", "atau"},
+	"pl": {"nie", "jest", "się", "i", "w", "na", "że", "to", "jak", "z", "do", "co", "ale", "mam", "czy", "dla", "jestem", "tak"},
+	"vi": {"và", "là", "của", "có", "không", "được", "cho", "một", "này", "những", "với", "tôi", "các", "làm", "như", "thì", "khi", "để"},
+}
+
+// DetectLanguage detect the language of text, returns the ISO 639-1 code and the confidence between 0 and 1.
+// It is a heuristic based on unicode script and latin stop words, the language is empty if it cannot be detected.
+func DetectLanguage(text string) (language string, confidence float64) {
+	scripts := make(map[string]int)
+	letters := 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		switch {
+		case unicode.Is(unicode.Hiragana, r), unicode.Is(unicode.Katakana, r):
+			scripts["ja"]++
+		case unicode.Is(unicode.Han, r):
+			scripts["zh"]++
+		case unicode.Is(unicode.Hangul, r):
+			scripts["ko"]++
+		case unicode.Is(unicode.Cyrillic, r):
+			scripts["ru"]++
+		case unicode.Is(unicode.Arabic, r):
+			scripts["ar"]++
+		case unicode.Is(unicode.Hebrew, r):
+			scripts["he"]++
+		case unicode.Is(unicode.Greek, r):
+			scripts["el"]++
+		case unicode.Is(unicode.Thai, r):
+			scripts["th"]++
+		case unicode.Is(unicode.Devanagari, r):
+			scripts["hi"]++
+		case unicode.Is(unicode.Latin, r):
+			scripts["latin"]++
+		}
+	}
+	if letters == 0 {
+		return "", 0
+	}
+
+	// Japanese text is mixed with kanji, so any kana means Japanese
+	if scripts["ja"] > 0 && scripts["ja"]+scripts["zh"] > scripts["latin"] {
+		return "ja", float64(scripts["ja"]+scripts["zh"]) / float64(letters)
+	}
+	best, bestCount := "", 0
+	for script, count := range scripts {
+		if count > bestCount || (count == bestCount && script < best) {
+			best, bestCount = script, count
+		}
+	}
+	confidence = float64(bestCount) / float64(letters)
+	if best == "ru" && strings.ContainsAny(text, "іїєґІЇЄҐ") {
+		best = "uk"
+	}
+	if best != "latin" {
+		return best, confidence
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	hits := make(map[string]int)
+	total := 0
+	for _, word := range words {
+		for lang, stopWords := range latinStopWords {
+			for _, stopWord := range stopWords {
+				if word == stopWord {
+					hits[lang]++
+					total++
+					break
+				}
+			}
+		}
+	}
+	if total == 0 {
+		return "", 0
+	}
+	best, bestCount = "", 0
+	for lang, count := range hits {
+		if count > bestCount || (count == bestCount && lang < best) {
+			best, bestCount = lang, count
+		}
+	}
+	return best, confidence * float64(bestCount) / float64(total)
+}
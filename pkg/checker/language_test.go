@@ -0,0 +1,48 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package checker_test
+
+import (
+	"testing"
+
+	"github.com/apache/answer/pkg/checker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	cases := map[string]string{
+		"How do I configure the proxy for this service and what is the default port?":            "en",
+		"¿Cómo puedo configurar el proxy para que funcione con la red de la empresa?":            "es",
+		"Comment est-ce que je peux configurer le proxy pour les requêtes dans une application":  "fr",
+		"Wie kann ich den Proxy für die Anwendung einrichten, und ist das nicht zu kompliziert?": "de",
+		"如何为这个服务配置代理，默认端口是多少？":                                                                   "zh",
+		"このサービスのプロキシを設定するにはどうすればいいですか？":                                                          "ja",
+		"이 서비스의 프록시를 어떻게 설정합니까?":                                                                 "ko",
+		"Как настроить прокси для этого сервиса?":                                                "ru",
+	}
+	for text, expected := range cases {
+		language, confidence := checker.DetectLanguage(text)
+		assert.Equal(t, expected, language, text)
+		assert.Greater(t, confidence, 0.0, text)
+	}
+
+	language, _ := checker.DetectLanguage("12345 !!!")
+	assert.Empty(t, language)
+}
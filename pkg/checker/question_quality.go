@@ -0,0 +1,167 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package checker
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The reasons that lower the question quality score
+const (
+	QuestionQualityTitleTooShort    = "title_too_short"
+	QuestionQualityTitleAllCaps     = "title_all_caps"
+	QuestionQualityBodyTooShort     = "body_too_short"
+	QuestionQualityCodeNotFormatted = "code_not_formatted"
+	QuestionQualityTagsTooGeneric   = "tags_too_generic"
+)
+
+const (
+	qualityTitleWeight = 25
+	qualityBodyWeight  = 35
+	qualityCodeWeight  = 20
+	qualityTagWeight   = 20
+
+	qualityTitleMinLength = 15
+	qualityTitleMinWords  = 4
+	qualityBodyMinLength  = 30
+	qualityBodyGoodLength = 300
+	// a tag used by more than this share of all questions is too generic to describe the question
+	qualityGenericTagShare = 0.2
+)
+
+var (
+	codeFencePattern  = regexp.MustCompile("(?m)^\\s*(```|~~~)")
+	inlineCodePattern = regexp.MustCompile("`[^`\n]+`")
+	codeLinePattern   = regexp.MustCompile(`(?m)^.*([;{}]\s*$|^\s*(func|def|class|import|return|var|let|const|public|private|#include)\b|\w+\(.*\)\s*;?\s*$|</?\w+[^>]*>\s*$)`)
+)
+
+// QuestionQualityTag the tag of the question to be scored
+type QuestionQualityTag struct {
+	SlugName      string
+	QuestionCount int
+}
+
+// QuestionQuality the quality score of the question, from 0 to 100
+type QuestionQuality struct {
+	Score   int
+	Reasons []string
+}
+
+// GetQuestionQuality score the question by title quality, body length, code formatting and tag specificity.
+// content is the markdown body of question, totalQuestions is the count of all questions in the site.
+func GetQuestionQuality(title, content string, tags []*QuestionQualityTag, totalQuestions int64) *QuestionQuality {
+	quality := &QuestionQuality{Reasons: make([]string, 0)}
+	quality.Score += scoreTitle(title, quality)
+	quality.Score += scoreBody(content, quality)
+	quality.Score += scoreCode(content, quality)
+	quality.Score += scoreTags(tags, totalQuestions, quality)
+	return quality
+}
+
+func scoreTitle(title string, quality *QuestionQuality) int {
+	title = strings.TrimSpace(title)
+	score := qualityTitleWeight
+	if utf8.RuneCountInString(title) < qualityTitleMinLength ||
+		(!hasCJK(title) && len(strings.Fields(title)) < qualityTitleMinWords) {
+		quality.Reasons = append(quality.Reasons, QuestionQualityTitleTooShort)
+		score -= qualityTitleWeight * 3 / 5
+	}
+	letters, upper := 0, 0
+	for _, r := range title {
+		if unicode.IsUpper(r) {
+			letters++
+			upper++
+		} else if unicode.IsLower(r) {
+			letters++
+		}
+	}
+	if letters >= qualityTitleMinLength/2 && upper == letters {
+		quality.Reasons = append(quality.Reasons, QuestionQualityTitleAllCaps)
+		score -= qualityTitleWeight * 2 / 5
+	}
+	return max(score, 0)
+}
+
+func scoreBody(content string, quality *QuestionQuality) int {
+	length := utf8.RuneCountInString(strings.TrimSpace(content))
+	if hasCJK(content) {
+		// CJK characters carry more information than the latin letters
+		length *= 3
+	}
+	if length < qualityBodyMinLength {
+		quality.Reasons = append(quality.Reasons, QuestionQualityBodyTooShort)
+		return 0
+	}
+	if length >= qualityBodyGoodLength {
+		return qualityBodyWeight
+	}
+	return qualityBodyWeight * length / qualityBodyGoodLength
+}
+
+func scoreCode(content string, quality *QuestionQuality) int {
+	if codeFencePattern.MatchString(content) || inlineCodePattern.MatchString(content) {
+		return qualityCodeWeight
+	}
+	codeLines := 0
+	for _, line := range strings.Split(content, "\n") {
+		// the lines indented by 4 spaces or a tab are markdown code blocks
+		if strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t") {
+			return qualityCodeWeight
+		}
+		if codeLinePattern.MatchString(line) {
+			codeLines++
+		}
+	}
+	if codeLines >= 2 {
+		quality.Reasons = append(quality.Reasons, QuestionQualityCodeNotFormatted)
+		return 0
+	}
+	return qualityCodeWeight
+}
+
+func scoreTags(tags []*QuestionQualityTag, totalQuestions int64, quality *QuestionQuality) int {
+	if len(tags) == 0 {
+		quality.Reasons = append(quality.Reasons, QuestionQualityTagsTooGeneric)
+		return 0
+	}
+	specific := 0
+	for _, tag := range tags {
+		if totalQuestions <= 0 || float64(tag.QuestionCount)/float64(totalQuestions) <= qualityGenericTagShare {
+			specific++
+		}
+	}
+	if specific == 0 {
+		quality.Reasons = append(quality.Reasons, QuestionQualityTagsTooGeneric)
+	}
+	return qualityTagWeight * specific / len(tags)
+}
+
+func hasCJK(s string) bool {
+	for _, r := range s {
+		if unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) ||
+			unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package checker_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/answer/pkg/checker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetQuestionQuality(t *testing.T) {
+	body := strings.Repeat("I tried to configure the service but it keeps failing on start. ", 6)
+	specificTags := []*checker.QuestionQualityTag{{SlugName: "golang", QuestionCount: 10}}
+
+	t.Run("Good question", func(t *testing.T) {
+		quality := checker.GetQuestionQuality("How to read a config file in Go?", body+"\n```\nfmt.Println(1);\n```",
+			specificTags, 1000)
+		assert.Equal(t, 100, quality.Score)
+		assert.Empty(t, quality.Reasons)
+	})
+
+	t.Run("Short title and body", func(t *testing.T) {
+		quality := checker.GetQuestionQuality("help", "not work", specificTags, 1000)
+		assert.Contains(t, quality.Reasons, checker.QuestionQualityTitleTooShort)
+		assert.Contains(t, quality.Reasons, checker.QuestionQualityBodyTooShort)
+		assert.Equal(t, 50, quality.Score)
+	})
+
+	t.Run("All caps title", func(t *testing.T) {
+		quality := checker.GetQuestionQuality("WHY DOES MY BUILD FAIL ALWAYS", body, specificTags, 1000)
+		assert.Equal(t, []string{checker.QuestionQualityTitleAllCaps}, quality.Reasons)
+	})
+
+	t.Run("Code not formatted", func(t *testing.T) {
+		content := body + "\nfunc main() {\nfmt.Println(\"hi\");\n}"
+		quality := checker.GetQuestionQuality("How to print a line in Go?", content, specificTags, 1000)
+		assert.Equal(t, []string{checker.QuestionQualityCodeNotFormatted}, quality.Reasons)
+		assert.Equal(t, 80, quality.Score)
+	})
+
+	t.Run("Indented code is formatted", func(t *testing.T) {
+		content := body + "\n    func main() {\n    fmt.Println(\"hi\");\n    }"
+		quality := checker.GetQuestionQuality("How to print a line in Go?", content, specificTags, 1000)
+		assert.Empty(t, quality.Reasons)
+	})
+
+	t.Run("Generic tags", func(t *testing.T) {
+		tags := []*checker.QuestionQualityTag{{SlugName: "question", QuestionCount: 500}}
+		quality := checker.GetQuestionQuality("How to read a config file in Go?", body, tags, 1000)
+		assert.Equal(t, []string{checker.QuestionQualityTagsTooGeneric}, quality.Reasons)
+		assert.Equal(t, 80, quality.Score)
+	})
+}
@@ -0,0 +1,255 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package checker
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"time"
+)
+
+const (
+	VideoFormatMP4  = "mp4"
+	VideoFormatWebm = "webm"
+
+	// the info element of webm is placed before the clusters, so only the head of file is read
+	webmHeadSize = 1 << 20
+
+	ebmlHeaderID        = 0x1A45DFA3
+	ebmlDocTypeID       = 0x4282
+	webmSegmentID       = 0x18538067
+	webmInfoID          = 0x1549A966
+	webmTimecodeScaleID = 0x2AD7B1
+	webmDurationID      = 0x4489
+	webmClusterID       = 0x1F43B675
+)
+
+// DecodeVideoFile check the file is a mp4 or webm video by its content and get the duration of it.
+// The duration is zero if it is not recorded in the file, e.g. the webm recorded by browsers.
+func DecodeVideoFile(localFilePath string) (format string, duration time.Duration, err error) {
+	file, err := os.Open(localFilePath)
+	if err != nil {
+		return "", 0, err
+	}
+	defer file.Close()
+	stat, err := file.Stat()
+	if err != nil {
+		return "", 0, err
+	}
+
+	head := make([]byte, webmHeadSize)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", 0, err
+	}
+	head = head[:n]
+
+	switch {
+	case len(head) >= 8 && string(head[4:8]) == "ftyp":
+		duration, err = mp4Duration(file, stat.Size())
+		return VideoFormatMP4, duration, err
+	case len(head) >= 4 && binary.BigEndian.Uint32(head[:4]) == ebmlHeaderID:
+		duration, err = webmDuration(head)
+		return VideoFormatWebm, duration, err
+	}
+	return "", 0, fmt.Errorf("unsupported video format")
+}
+
+// mp4Duration read the duration from the movie header box moov/mvhd
+func mp4Duration(r io.ReadSeeker, size int64) (time.Duration, error) {
+	moovStart, moovSize, err := findMP4Box(r, 0, size, "moov")
+	if err != nil {
+		return 0, err
+	}
+	mvhdStart, mvhdSize, err := findMP4Box(r, moovStart, moovStart+moovSize, "mvhd")
+	if err != nil {
+		return 0, err
+	}
+	if mvhdSize < 32 {
+		return 0, fmt.Errorf("invalid mvhd box")
+	}
+	buf := make([]byte, 32)
+	if _, err = r.Seek(mvhdStart, io.SeekStart); err != nil {
+		return 0, err
+	}
+	if _, err = io.ReadFull(r, buf); err != nil {
+		return 0, err
+	}
+	var timescale, units uint64
+	// version 0 uses 32 bits times, version 1 uses 64 bits times
+	if buf[0] == 1 {
+		timescale = uint64(binary.BigEndian.Uint32(buf[20:24]))
+		units = binary.BigEndian.Uint64(buf[24:32])
+	} else {
+		timescale = uint64(binary.BigEndian.Uint32(buf[12:16]))
+		units = uint64(binary.BigEndian.Uint32(buf[16:20]))
+	}
+	if timescale == 0 {
+		return 0, fmt.Errorf("invalid mvhd timescale")
+	}
+	return time.Duration(float64(units) / float64(timescale) * float64(time.Second)), nil
+}
+
+// findMP4Box find the box of the type between start and end, returns the start and size of its payload
+func findMP4Box(r io.ReadSeeker, start, end int64, boxType string) (payloadStart, payloadSize int64, err error) {
+	header := make([]byte, 16)
+	for offset := start; offset+8 <= end; {
+		if _, err = r.Seek(offset, io.SeekStart); err != nil {
+			return 0, 0, err
+		}
+		if _, err = io.ReadFull(r, header[:8]); err != nil {
+			return 0, 0, err
+		}
+		boxSize, headerSize := int64(binary.BigEndian.Uint32(header[:4])), int64(8)
+		switch boxSize {
+		case 0:
+			// the box extends to the end
+			boxSize = end - offset
+		case 1:
+			if _, err = io.ReadFull(r, header[8:16]); err != nil {
+				return 0, 0, err
+			}
+			boxSize, headerSize = int64(binary.BigEndian.Uint64(header[8:16])), 16
+		}
+		if boxSize < headerSize || offset+boxSize > end {
+			return 0, 0, fmt.Errorf("invalid %s box", string(header[4:8]))
+		}
+		if string(header[4:8]) == boxType {
+			return offset + headerSize, boxSize - headerSize, nil
+		}
+		offset += boxSize
+	}
+	return 0, 0, fmt.Errorf("%s box not found", boxType)
+}
+
+// webmDuration read the duration from the Segment/Info element of the head of webm file
+func webmDuration(data []byte) (time.Duration, error) {
+	id, payload, rest, err := nextEBMLElement(data)
+	if err != nil || id != ebmlHeaderID {
+		return 0, fmt.Errorf("invalid ebml header")
+	}
+	docType := ""
+	for len(payload) > 0 {
+		var childID uint32
+		var child []byte
+		childID, child, payload, err = nextEBMLElement(payload)
+		if err != nil {
+			return 0, err
+		}
+		if childID == ebmlDocTypeID {
+			docType = string(bytes.TrimRight(child, "\x00"))
+		}
+	}
+	if docType != VideoFormatWebm {
+		return 0, fmt.Errorf("unsupported doc type %s", docType)
+	}
+
+	id, segment, _, err := nextEBMLElement(rest)
+	if err != nil || id != webmSegmentID {
+		return 0, fmt.Errorf("segment not found")
+	}
+	for len(segment) > 0 {
+		var child []byte
+		id, child, segment, err = nextEBMLElement(segment)
+		if err != nil || id == webmClusterID {
+			break
+		}
+		if id == webmInfoID {
+			return webmInfoDuration(child)
+		}
+	}
+	return 0, nil
+}
+
+func webmInfoDuration(info []byte) (time.Duration, error) {
+	timecodeScale, duration := uint64(1000000), float64(0)
+	for len(info) > 0 {
+		id, child, rest, err := nextEBMLElement(info)
+		if err != nil {
+			return 0, err
+		}
+		info = rest
+		switch id {
+		case webmTimecodeScaleID:
+			timecodeScale = 0
+			for _, b := range child {
+				timecodeScale = timecodeScale<<8 | uint64(b)
+			}
+		case webmDurationID:
+			switch len(child) {
+			case 4:
+				duration = float64(math.Float32frombits(binary.BigEndian.Uint32(child)))
+			case 8:
+				duration = math.Float64frombits(binary.BigEndian.Uint64(child))
+			}
+		}
+	}
+	return time.Duration(duration * float64(timecodeScale)), nil
+}
+
+// nextEBMLElement read the element at the beginning of data. The payload of the element in unknown size
+// or exceeding the data is truncated to the data.
+func nextEBMLElement(data []byte) (id uint32, payload, rest []byte, err error) {
+	if len(data) == 0 {
+		return 0, nil, nil, io.ErrUnexpectedEOF
+	}
+	idLength := vintLength(data[0])
+	if idLength == 0 || idLength > 4 || len(data) < idLength {
+		return 0, nil, nil, fmt.Errorf("invalid ebml element id")
+	}
+	for _, b := range data[:idLength] {
+		id = id<<8 | uint32(b)
+	}
+	data = data[idLength:]
+
+	if len(data) == 0 {
+		return 0, nil, nil, io.ErrUnexpectedEOF
+	}
+	sizeLength := vintLength(data[0])
+	if sizeLength == 0 || len(data) < sizeLength {
+		return 0, nil, nil, fmt.Errorf("invalid ebml element size")
+	}
+	size := uint64(data[0] & (0xFF >> sizeLength))
+	unknown := size == uint64(0xFF>>sizeLength)
+	for _, b := range data[1:sizeLength] {
+		size = size<<8 | uint64(b)
+		unknown = unknown && b == 0xFF
+	}
+	data = data[sizeLength:]
+
+	if unknown || size > uint64(len(data)) {
+		return id, data, nil, nil
+	}
+	return id, data[:size], data[size:], nil
+}
+
+// vintLength the length of variable size integer is determined by the leading zero bits of the first byte
+func vintLength(b byte) int {
+	for i := 0; i < 8; i++ {
+		if b&(0x80>>i) != 0 {
+			return i + 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,77 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package checker_test
+
+import (
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/pkg/checker"
+	"github.com/stretchr/testify/assert"
+)
+
+func mp4Box(boxType string, payload []byte) []byte {
+	box := binary.BigEndian.AppendUint32(nil, uint32(8+len(payload)))
+	return append(append(box, boxType...), payload...)
+}
+
+func writeTempFile(t *testing.T, name string, data []byte) string {
+	filePath := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(filePath, data, 0o600))
+	return filePath
+}
+
+func TestDecodeVideoFile_MP4(t *testing.T) {
+	// version 0 mvhd: version/flags, creation, modification, timescale 1000, duration 12500
+	mvhd := make([]byte, 100)
+	binary.BigEndian.PutUint32(mvhd[12:16], 1000)
+	binary.BigEndian.PutUint32(mvhd[16:20], 12500)
+	data := append(mp4Box("ftyp", []byte("isom\x00\x00\x02\x00")), mp4Box("free", nil)...)
+	data = append(data, mp4Box("moov", mp4Box("mvhd", mvhd))...)
+
+	format, duration, err := checker.DecodeVideoFile(writeTempFile(t, "a.mp4", data))
+	assert.NoError(t, err)
+	assert.Equal(t, checker.VideoFormatMP4, format)
+	assert.Equal(t, 12500*time.Millisecond, duration)
+}
+
+func TestDecodeVideoFile_Webm(t *testing.T) {
+	docType := append([]byte{0x42, 0x82, 0x84}, "webm"...)
+	data := append([]byte{0x1A, 0x45, 0xDF, 0xA3, 0x80 | byte(len(docType))}, docType...)
+	durationValue := binary.BigEndian.AppendUint64(nil, math.Float64bits(3000))
+	info := append([]byte{0x2A, 0xD7, 0xB1, 0x83, 0x0F, 0x42, 0x40}, append([]byte{0x44, 0x89, 0x88}, durationValue...)...)
+	// the segment is in unknown size like the live recordings
+	data = append(data, 0x18, 0x53, 0x80, 0x67, 0x01, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF)
+	data = append(data, append([]byte{0x15, 0x49, 0xA9, 0x66, 0x80 | byte(len(info))}, info...)...)
+
+	format, duration, err := checker.DecodeVideoFile(writeTempFile(t, "a.webm", data))
+	assert.NoError(t, err)
+	assert.Equal(t, checker.VideoFormatWebm, format)
+	assert.Equal(t, 3*time.Second, duration)
+}
+
+func TestDecodeVideoFile_Unsupported(t *testing.T) {
+	_, _, err := checker.DecodeVideoFile(writeTempFile(t, "a.mp4", []byte("<html><script></script></html>")))
+	assert.Error(t, err)
+}
@@ -34,9 +34,17 @@ import (
 	"github.com/yuin/goldmark/renderer"
 	"github.com/yuin/goldmark/renderer/html"
 	goldmarkHTML "github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
 	"github.com/yuin/goldmark/util"
 )
 
+var (
+	// the videos uploaded to the site are written as images in markdown and played in posts, the other videos are not allowed
+	uploadedVideoImageRegexp  = regexp.MustCompile(`<img src="((?:https?://[^"\s]+)?/uploads/video/[0-9A-Za-z]+)\.(mp4|webm)"[^>]*>`)
+	uploadedVideoSrcRegexp    = regexp.MustCompile(`^(https?://[^"\s]+)?/uploads/video/[0-9A-Za-z]+\.(mp4|webm)$`)
+	uploadedVideoPosterRegexp = regexp.MustCompile(`^(https?://[^"\s]+)?/uploads/video/[0-9A-Za-z]+\.jpg$`)
+)
+
 // Markdown2HTML convert markdown to html
 func Markdown2HTML(source string) string {
 	mdConverter := goldmark.New(
@@ -53,7 +61,8 @@ func Markdown2HTML(source string) string {
 		log.Error(err)
 		return source
 	}
-	html := buf.String()
+	html := uploadedVideoImageRegexp.ReplaceAllString(buf.String(),
+		`<video src="$1.$2" poster="$1.jpg" controls preload="metadata"></video>`)
 	filter := bluemonday.UGCPolicy()
 	filter.AllowStyling()
 	filter.RequireNoFollowOnLinks(false)
@@ -62,6 +71,10 @@ func Markdown2HTML(source string) string {
 	filter.AllowElements("kbd")
 	filter.AllowAttrs("title").Matching(regexp.MustCompile(`^[\p{L}\p{N}\s\-_',\[\]!\./\\\(\)]*$|^@embed?$`)).Globally()
 	filter.AllowAttrs("start").OnElements("ol")
+	filter.AllowAttrs("src").Matching(uploadedVideoSrcRegexp).OnElements("video")
+	filter.AllowAttrs("poster").Matching(uploadedVideoPosterRegexp).OnElements("video")
+	filter.AllowAttrs("controls").OnElements("video")
+	filter.AllowAttrs("preload").Matching(regexp.MustCompile(`^metadata$`)).OnElements("video")
 	html = strings.TrimSpace(filter.Sanitize(html))
 	return html
 }
@@ -190,3 +203,27 @@ func (r *DangerousHTMLRenderer) renderLinkIsUrl(verifyUrl string) bool {
 	isPath, _ := regexp.MatchString(`^/`, verifyUrl)
 	return isURL || isPath
 }
+
+// ExtractCodeBlocks returns the content of every fenced or indented code block in markdown, in document order
+func ExtractCodeBlocks(source string) (blocks []string) {
+	src := []byte(source)
+	doc := goldmark.New(goldmark.WithExtensions(extension.GFM)).Parser().Parse(text.NewReader(src))
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n.Kind() {
+		case ast.KindFencedCodeBlock, ast.KindCodeBlock:
+			var buf bytes.Buffer
+			lines := n.Lines()
+			for i := 0; i < lines.Len(); i++ {
+				line := lines.At(i)
+				buf.Write(line.Value(src))
+			}
+			blocks = append(blocks, strings.TrimSuffix(buf.String(), "\n"))
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	return blocks
+}
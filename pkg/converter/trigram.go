@@ -0,0 +1,63 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package converter
+
+import (
+	"strings"
+	"unicode"
+)
+
+// TrigramSize the rune length of each trigram
+const TrigramSize = 3
+
+// NormalizeTrigramText lowercase the text and collapse all the characters which are not letters or digits into single spaces
+func NormalizeTrigramText(s string) string {
+	var b strings.Builder
+	space := true
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			space = false
+			continue
+		}
+		if !space {
+			b.WriteRune(' ')
+			space = true
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// Trigrams split the normalized text into distinct trigrams in order of first appearance.
+// The text shorter than TrigramSize has no trigrams.
+func Trigrams(s string) []string {
+	runes := []rune(NormalizeTrigramText(s))
+	trigrams := make([]string, 0)
+	seen := make(map[string]bool)
+	for i := 0; i+TrigramSize <= len(runes); i++ {
+		t := string(runes[i : i+TrigramSize])
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+		trigrams = append(trigrams, t)
+	}
+	return trigrams
+}
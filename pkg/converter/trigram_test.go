@@ -0,0 +1,38 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package converter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTrigramText(t *testing.T) {
+	assert.Equal(t, "how to use go modules", NormalizeTrigramText("  How to use Go-modules? "))
+	assert.Equal(t, "", NormalizeTrigramText("?!"))
+}
+
+func TestTrigrams(t *testing.T) {
+	assert.Equal(t, []string{"go ", "o m", " mo", "mod"}, Trigrams("Go mod"))
+	assert.Equal(t, []string{"aaa"}, Trigrams("aaaa"))
+	assert.Equal(t, []string{"数据库"}, Trigrams("数据库"))
+	assert.Empty(t, Trigrams("ab"))
+}
@@ -0,0 +1,124 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package emailreply signs the reply addresses of the notification emails and parses the replies sent to them.
+package emailreply
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+// Kind is what the reply is posted as
+type Kind byte
+
+const (
+	// KindAnswer the reply is posted as an answer of the question
+	KindAnswer Kind = 'a'
+	// KindComment the reply is posted as a comment of the question or the answer
+	KindComment Kind = 'c'
+	// KindReply the reply is posted as a reply of the comment
+	KindReply Kind = 'r'
+)
+
+// signatureLength the hex length of the truncated signature, the local part of the address is at most 64 characters
+const signatureLength = 16
+
+// GenerateSecret generate the random secret in hex
+func GenerateSecret() (secret string, err error) {
+	b := make([]byte, 32)
+	if _, err = rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Token sign the token of the user replying to the object, the ids are decimal and encoded in base36 to keep it short,
+// empty if the ids are not decimal
+func Token(secret string, kind Kind, userID, objectID string) string {
+	uid, err := strconv.ParseUint(userID, 10, 64)
+	if err != nil {
+		return ""
+	}
+	oid, err := strconv.ParseUint(objectID, 10, 64)
+	if err != nil {
+		return ""
+	}
+	payload := string(kind) + strconv.FormatUint(uid, 36) + "-" + strconv.FormatUint(oid, 36)
+	return payload + "-" + sign(secret, payload)
+}
+
+// ParseToken verify the token and get the user and the object from it
+func ParseToken(secret, token string) (kind Kind, userID, objectID string, ok bool) {
+	token = strings.ToLower(token)
+	parts := strings.Split(token, "-")
+	if len(parts) != 3 || len(parts[0]) < 2 {
+		return 0, "", "", false
+	}
+	payload := parts[0] + "-" + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(sign(secret, payload))) {
+		return 0, "", "", false
+	}
+	kind = Kind(parts[0][0])
+	if kind != KindAnswer && kind != KindComment && kind != KindReply {
+		return 0, "", "", false
+	}
+	uid, err := strconv.ParseUint(parts[0][1:], 36, 64)
+	if err != nil {
+		return 0, "", "", false
+	}
+	oid, err := strconv.ParseUint(parts[1], 36, 64)
+	if err != nil {
+		return 0, "", "", false
+	}
+	return kind, strconv.FormatUint(uid, 10), strconv.FormatUint(oid, 10), true
+}
+
+// Address put the token into the reply address with the plus addressing, e.g. reply+token@example.com
+func Address(replyAddress, token string) string {
+	at := strings.LastIndex(replyAddress, "@")
+	if at <= 0 || len(token) == 0 {
+		return ""
+	}
+	return replyAddress[:at] + "+" + token + replyAddress[at:]
+}
+
+// TokenFromAddress get the token from the recipient address if it is the plus address of the reply address
+func TokenFromAddress(replyAddress, address string) (token string, ok bool) {
+	at, addressAt := strings.LastIndex(replyAddress, "@"), strings.LastIndex(address, "@")
+	if at <= 0 || addressAt <= 0 || !strings.EqualFold(replyAddress[at:], address[addressAt:]) {
+		return "", false
+	}
+	prefix := replyAddress[:at] + "+"
+	local := address[:addressAt]
+	if len(local) <= len(prefix) || !strings.EqualFold(local[:len(prefix)], prefix) {
+		return "", false
+	}
+	return local[len(prefix):], true
+}
+
+func sign(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))[:signatureLength]
+}
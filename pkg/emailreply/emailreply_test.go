@@ -0,0 +1,100 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package emailreply
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToken(t *testing.T) {
+	token := Token("secret", KindComment, "1000000000000000001", "10020000000000123")
+	kind, userID, objectID, ok := ParseToken("secret", token)
+	assert.True(t, ok)
+	assert.Equal(t, KindComment, kind)
+	assert.Equal(t, "1000000000000000001", userID)
+	assert.Equal(t, "10020000000000123", objectID)
+
+	// the mail servers may change the case of the address
+	_, _, _, ok = ParseToken("secret", "C"+token[1:])
+	assert.True(t, ok)
+	_, _, _, ok = ParseToken("other", token)
+	assert.False(t, ok)
+	_, _, _, ok = ParseToken("secret", "a"+token[1:])
+	assert.False(t, ok)
+	assert.Empty(t, Token("secret", KindAnswer, "D1", "1"))
+
+	address := Address("reply@example.com", token)
+	assert.LessOrEqual(t, len(address)-len("@example.com"), 64)
+	parsed, ok := TokenFromAddress("reply@example.com", address)
+	assert.True(t, ok)
+	assert.Equal(t, token, parsed)
+	_, ok = TokenFromAddress("reply@example.com", "reply@example.com")
+	assert.False(t, ok)
+	_, ok = TokenFromAddress("reply@example.com", "reply+"+token+"@other.com")
+	assert.False(t, ok)
+}
+
+func TestParseMessage(t *testing.T) {
+	raw := "From: Alice <Alice@Example.com>\r\n" +
+		"To: reply+abc@example.com\r\n" +
+		"Message-ID: <id-1@mail.example.com>\r\n" +
+		"Subject: =?utf-8?q?Re:_caf=C3=A9?=\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: multipart/alternative; boundary=b1\r\n" +
+		"\r\n" +
+		"--b1\r\n" +
+		"Content-Type: text/plain; charset=utf-8\r\n" +
+		"Content-Transfer-Encoding: quoted-printable\r\n" +
+		"\r\n" +
+		"Thanks, it works caf=C3=A9!\r\n" +
+		"\r\n" +
+		"On Mon, Jan 1, 2024 at 10:00 AM Answer <reply+abc@example.com>\r\n" +
+		"wrote:\r\n" +
+		"> ##- Please type your reply above this line -##\r\n" +
+		"--b1\r\n" +
+		"Content-Type: text/html; charset=utf-8\r\n" +
+		"\r\n" +
+		"<p>Thanks</p>\r\n" +
+		"--b1--\r\n"
+	msg, err := ParseMessage([]byte(raw))
+	assert.NoError(t, err)
+	assert.Equal(t, "id-1@mail.example.com", msg.MessageID)
+	assert.Equal(t, "alice@example.com", msg.From)
+	assert.Equal(t, []string{"reply+abc@example.com"}, msg.Recipients)
+	assert.Equal(t, "Re: café", msg.Subject)
+	assert.False(t, msg.AutoSubmitted)
+	assert.Equal(t, "Thanks, it works café!", ExtractReply(msg.Text))
+
+	raw = "From: a@example.com\r\nAuto-Submitted: auto-replied\r\nContent-Type: text/html\r\n" +
+		"Content-Transfer-Encoding: base64\r\n\r\nPHA+SSBhbSBv\r\ndXQ8L3A+PGJsb2NrcXVvdGU+cXVvdGU8L2Jsb2NrcXVvdGU+\r\n"
+	msg, err = ParseMessage([]byte(raw))
+	assert.NoError(t, err)
+	assert.True(t, msg.AutoSubmitted)
+	assert.Equal(t, "I am out", ExtractReply(msg.Text))
+}
+
+func TestExtractReply(t *testing.T) {
+	assert.Equal(t, "first\n\nsecond", ExtractReply("first\n\nsecond\n-- \nAlice\n"))
+	assert.Equal(t, "answer", ExtractReply("answer\n-----Original Message-----\nFrom: x"))
+	assert.Equal(t, "top", ExtractReply("top\n> quoted\n"))
+	assert.Equal(t, "ok", ExtractReply("ok\n##- Please type your reply above this line -##\nold"))
+}
@@ -0,0 +1,215 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package emailreply
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+
+	strip "github.com/grokify/html-strip-tags-go"
+)
+
+// Marker the notification emails put the line with it above the content, the reply is the text above the line
+const Marker = "##-"
+
+// maxPartDepth the max depth of the nested multipart to be walked
+const maxPartDepth = 5
+
+var (
+	htmlQuoteReg = regexp.MustCompile(`(?is)<blockquote.*?</blockquote>|<div[^>]*class="gmail_quote".*`)
+	htmlLineReg  = regexp.MustCompile(`(?i)<br\s*/?>|</p>|</div>|</li>`)
+	wroteReg     = regexp.MustCompile(`(?i)^on\s.+wrote:$`)
+)
+
+// Message the parsed inbound email
+type Message struct {
+	MessageID string
+	// the address of the sender, lower case
+	From string
+	// the addresses of the recipients, including the delivery headers set by the mail servers
+	Recipients []string
+	Subject    string
+	// the plain text of the body, the html is converted if there is no plain text part
+	Text string
+	// whether the email is sent automatically, e.g. out of office or bounce
+	AutoSubmitted bool
+}
+
+// ParseMessage parse the raw email of RFC 5322
+func ParseMessage(raw []byte) (msg *Message, err error) {
+	m, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	from, err := mail.ParseAddress(m.Header.Get("From"))
+	if err != nil {
+		return nil, fmt.Errorf("parse from address failed: %w", err)
+	}
+	decoder := new(mime.WordDecoder)
+	subject, err := decoder.DecodeHeader(m.Header.Get("Subject"))
+	if err != nil {
+		subject = m.Header.Get("Subject")
+	}
+	msg = &Message{
+		MessageID: strings.Trim(strings.TrimSpace(m.Header.Get("Message-Id")), "<>"),
+		From:      strings.ToLower(from.Address),
+		Subject:   subject,
+	}
+	for _, key := range []string{"To", "Cc", "Delivered-To", "X-Original-To", "Envelope-To"} {
+		for _, value := range m.Header[key] {
+			addresses, err := mail.ParseAddressList(value)
+			if err != nil {
+				continue
+			}
+			for _, address := range addresses {
+				msg.Recipients = append(msg.Recipients, strings.ToLower(address.Address))
+			}
+		}
+	}
+	autoSubmitted := strings.ToLower(m.Header.Get("Auto-Submitted"))
+	precedence := strings.ToLower(m.Header.Get("Precedence"))
+	msg.AutoSubmitted = (len(autoSubmitted) > 0 && autoSubmitted != "no") ||
+		precedence == "bulk" || precedence == "junk" || precedence == "list" || precedence == "auto_reply" ||
+		len(m.Header.Get("X-Autoreply")) > 0 || len(m.Header.Get("X-Autorespond")) > 0
+
+	plain, htmlText, err := readText(m.Header.Get("Content-Type"), m.Header.Get("Content-Transfer-Encoding"), m.Body, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(strings.TrimSpace(plain)) > 0 {
+		msg.Text = plain
+	} else {
+		msg.Text = htmlToText(htmlText)
+	}
+	return msg, nil
+}
+
+// readText read the first plain text and the first html of the body
+func readText(contentType, encoding string, body io.Reader, depth int) (plain, htmlText string, err error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "text/plain"
+	}
+	if strings.HasPrefix(mediaType, "multipart/") {
+		if depth >= maxPartDepth {
+			return "", "", nil
+		}
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return plain, htmlText, err
+			}
+			// the attachments are ignored
+			if strings.HasPrefix(strings.ToLower(part.Header.Get("Content-Disposition")), "attachment") {
+				continue
+			}
+			partPlain, partHTML, err := readText(part.Header.Get("Content-Type"),
+				part.Header.Get("Content-Transfer-Encoding"), part, depth+1)
+			if err != nil {
+				return plain, htmlText, err
+			}
+			if len(plain) == 0 {
+				plain = partPlain
+			}
+			if len(htmlText) == 0 {
+				htmlText = partHTML
+			}
+		}
+		return plain, htmlText, nil
+	}
+	if mediaType != "text/plain" && mediaType != "text/html" {
+		return "", "", nil
+	}
+
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, newlineRemover{body})
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	}
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return "", "", err
+	}
+	if mediaType == "text/html" {
+		return "", string(content), nil
+	}
+	return string(content), "", nil
+}
+
+// newlineRemover remove the line breaks of the base64 content
+type newlineRemover struct {
+	r io.Reader
+}
+
+func (n newlineRemover) Read(p []byte) (int, error) {
+	count, err := n.r.Read(p)
+	j := 0
+	for _, b := range p[:count] {
+		if b != '\r' && b != '\n' {
+			p[j] = b
+			j++
+		}
+	}
+	return j, err
+}
+
+func htmlToText(content string) string {
+	content = htmlQuoteReg.ReplaceAllString(content, "")
+	content = htmlLineReg.ReplaceAllString(content, "\n")
+	return html.UnescapeString(strip.StripTags(content))
+}
+
+// ExtractReply get the reply text written above the quoted notification email
+func ExtractReply(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	reply := make([]string, 0, len(lines))
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.Contains(trimmed, Marker) || trimmed == "-- " || line == "-- " ||
+			strings.HasPrefix(trimmed, "-----Original Message-----") || strings.HasPrefix(trimmed, "________________") {
+			break
+		}
+		// the quote header may be wrapped, e.g. "On Mon, Jan 1, 2024 at 10:00 AM Answer <reply@example.com>\nwrote:"
+		if wroteReg.MatchString(trimmed) ||
+			(i+1 < len(lines) && strings.HasPrefix(strings.ToLower(trimmed), "on ") &&
+				wroteReg.MatchString(trimmed+" "+strings.TrimSpace(lines[i+1]))) {
+			break
+		}
+		if strings.HasPrefix(trimmed, ">") {
+			continue
+		}
+		reply = append(reply, strings.TrimRight(line, " \t"))
+	}
+	return strings.TrimSpace(strings.Join(reply, "\n"))
+}
@@ -20,10 +20,12 @@
 package htmltext
 
 import (
+	"html"
 	"io"
 	"net/http"
 	"net/url"
 	"regexp"
+	"sort"
 	"strings"
 	"unicode/utf8"
 
@@ -187,6 +189,39 @@ func FetchMatchedExcerpt(html string, words []string, trimMarker string, trimLen
 	return FetchRangedExcerpt(html, trimMarker, runeOffset, runeLimit)
 }
 
+// HighlightWords escape the text and wrap the matched words in <em>, the words are matched case-insensitively
+func HighlightWords(text string, words []string) string {
+	patterns := make([]string, 0, len(words))
+	for _, word := range converter.UniqueArray(words) {
+		if word = strings.TrimSpace(word); len(word) > 0 {
+			patterns = append(patterns, regexp.QuoteMeta(word))
+		}
+	}
+	if len(patterns) == 0 {
+		return html.EscapeString(text)
+	}
+	// match the longer words first, so the words contained in others are not highlighted partially
+	sort.SliceStable(patterns, func(i, j int) bool { return len(patterns[i]) > len(patterns[j]) })
+	re := regexp.MustCompile("(?i)" + strings.Join(patterns, "|"))
+
+	var sb strings.Builder
+	last := 0
+	for _, loc := range re.FindAllStringIndex(text, -1) {
+		sb.WriteString(html.EscapeString(text[last:loc[0]]))
+		sb.WriteString("<em>")
+		sb.WriteString(html.EscapeString(text[loc[0]:loc[1]]))
+		sb.WriteString("</em>")
+		last = loc[1]
+	}
+	sb.WriteString(html.EscapeString(text[last:]))
+	return sb.String()
+}
+
+// SanitizeHighlight escape the highlight returned by others such as search plugins, only the <em> tags are kept
+func SanitizeHighlight(highlight string) string {
+	return strings.NewReplacer("&lt;em&gt;", "<em>", "&lt;/em&gt;", "</em>").Replace(html.EscapeString(highlight))
+}
+
 func GetPicByUrl(Url string) string {
 	res, err := http.Get(Url)
 	if err != nil {
@@ -49,6 +49,16 @@ func TestClearText(t *testing.T) {
 	assert.Equal(t, expected, clearedText)
 }
 
+func TestPlainText(t *testing.T) {
+	assert.Equal(t, "", PlainText(""))
+	assert.Equal(t, "title\n\nhello world\n\n- one\n- two", PlainText(
+		"<h1>title</h1>\n<p>hello\n  <strong>world</strong></p><ul><li>one</li><li>two</li></ul>"))
+	assert.Equal(t, "code:\n\nif a {\n    b()\n}", PlainText("<p>code:</p><pre><code>if a {\n    b()\n}</code></pre>"))
+	assert.Equal(t, "see docs (https://example.com/docs) and https://example.com", PlainText(
+		`<p>see <a href="https://example.com/docs">docs</a> and <a href="https://example.com">https://example.com</a></p>`))
+	assert.Equal(t, "a < b & 你好", PlainText("<p>a &lt; b &amp; 你好</p>"))
+}
+
 func TestFetchExcerpt(t *testing.T) {
 	var (
 		expected,
@@ -204,3 +214,16 @@ func TestFetchMatchedExcerpt(t *testing.T) {
 	actual = FetchMatchedExcerpt(html, []string{"中文", "😂"}, "...", 6)
 	assert.Equal(t, expected, actual)
 }
+
+func TestHighlightWords(t *testing.T) {
+	assert.Equal(t, "<em>Hello</em>, &lt;b&gt; and <em>hello</em> world",
+		HighlightWords("Hello, <b> and hello world", []string{"hello"}))
+	assert.Equal(t, "<em>golang</em> and <em>go</em>",
+		HighlightWords("golang and go", []string{"go", "golang"}))
+	assert.Equal(t, "中<em>文</em>", HighlightWords("中文", []string{"文", " "}))
+	assert.Equal(t, "a &amp; b", HighlightWords("a & b", nil))
+}
+
+func TestSanitizeHighlight(t *testing.T) {
+	assert.Equal(t, "<em>go</em> &lt;script&gt;", SanitizeHighlight("<em>go</em> <script>"))
+}
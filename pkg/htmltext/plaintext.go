@@ -0,0 +1,125 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package htmltext
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var (
+	plainTextSpaceReg   = regexp.MustCompile(`[ \t\r\n]+`)
+	plainTextNewLineReg = regexp.MustCompile(`\n{3,}`)
+	plainTextLineEndReg = regexp.MustCompile(` +\n`)
+)
+
+// PlainText convert the HTML to the readable plain text, such as the content of the exported document.
+// Unlike ClearText, the blocks are kept in separated lines, the code blocks are kept as they are
+// and the link target is written after the link text.
+func PlainText(source string) string {
+	var (
+		b         = &strings.Builder{}
+		tokenizer = html.NewTokenizer(strings.NewReader(source))
+		pre       = 0
+		links     = make([]string, 0)
+	)
+	lineBreak := func(count int) {
+		text := strings.TrimRight(b.String(), " ")
+		b.Reset()
+		b.WriteString(text)
+		if b.Len() > 0 {
+			b.WriteString(strings.Repeat("\n", count))
+		}
+	}
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+		token := tokenizer.Token()
+		switch tokenType {
+		case html.TextToken:
+			text := token.Data
+			if pre == 0 {
+				text = plainTextSpaceReg.ReplaceAllString(text, " ")
+				if current := b.String(); len(current) == 0 || strings.HasSuffix(current, "\n") ||
+					strings.HasSuffix(current, " ") {
+					text = strings.TrimLeft(text, " ")
+				}
+			}
+			b.WriteString(text)
+		case html.StartTagToken, html.SelfClosingTagToken:
+			switch token.Data {
+			case "br":
+				lineBreak(1)
+			case "li", "tr":
+				lineBreak(1)
+				if token.Data == "li" {
+					b.WriteString("- ")
+				}
+			case "td", "th":
+				b.WriteString(" ")
+			case "p", "div", "h1", "h2", "h3", "h4", "h5", "h6", "blockquote", "ul", "ol", "table", "hr":
+				lineBreak(2)
+			case "pre":
+				lineBreak(2)
+				pre++
+			case "img":
+				for _, attr := range token.Attr {
+					if attr.Key == "alt" && len(attr.Val) > 0 {
+						b.WriteString("[" + attr.Val + "]")
+					}
+				}
+			case "a":
+				href := ""
+				for _, attr := range token.Attr {
+					if attr.Key == "href" {
+						href = attr.Val
+					}
+				}
+				if tokenType == html.StartTagToken {
+					links = append(links, href)
+				}
+			}
+		case html.EndTagToken:
+			switch token.Data {
+			case "p", "div", "h1", "h2", "h3", "h4", "h5", "h6", "blockquote", "ul", "ol", "table":
+				lineBreak(2)
+			case "pre":
+				pre = max(pre-1, 0)
+				lineBreak(2)
+			case "a":
+				if len(links) == 0 {
+					continue
+				}
+				href := links[len(links)-1]
+				links = links[:len(links)-1]
+				if len(href) > 0 && !strings.HasPrefix(href, "#") && !strings.HasSuffix(b.String(), href) {
+					b.WriteString(" (" + href + ")")
+				}
+			}
+		}
+	}
+	text := strings.TrimRight(b.String(), " ")
+	text = plainTextLineEndReg.ReplaceAllString(text, "\n")
+	return strings.TrimSpace(plainTextNewLineReg.ReplaceAllString(text, "\n\n"))
+}
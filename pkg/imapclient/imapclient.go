@@ -0,0 +1,211 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package imapclient is the minimal IMAP4rev1 client of RFC 3501 to fetch the unseen emails of the mailbox.
+package imapclient
+
+import (
+	"bufio"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxLiteralSize the max size of the literal, e.g. the raw email, read from the server
+const maxLiteralSize = 25 << 20
+
+var literalReg = regexp.MustCompile(`\{(\d+)\}$`)
+
+// Client the IMAP client, it is not safe for concurrent use
+type Client struct {
+	conn    net.Conn
+	reader  *bufio.Reader
+	tag     int
+	timeout time.Duration
+}
+
+// Dial connect the IMAP server, the implicit TLS is used if useTLS is true
+func Dial(addr string, useTLS bool, timeout time.Duration) (c *Client, err error) {
+	dialer := &net.Dialer{Timeout: timeout}
+	var conn net.Conn
+	if useTLS {
+		host, _, _ := net.SplitHostPort(addr)
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{ServerName: host})
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	c, err = NewClient(conn, timeout)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// NewClient new client of the connection and read the greeting
+func NewClient(conn net.Conn, timeout time.Duration) (c *Client, err error) {
+	c = &Client{conn: conn, reader: bufio.NewReader(conn), timeout: timeout}
+	c.deadline()
+	greeting, err := c.readLine()
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(greeting, "* OK") && !strings.HasPrefix(greeting, "* PREAUTH") {
+		return nil, fmt.Errorf("unexpected greeting: %s", greeting)
+	}
+	return c, nil
+}
+
+// Login login with the username and password
+func (c *Client) Login(username, password string) (err error) {
+	_, err = c.command("LOGIN %s %s", quote(username), quote(password))
+	return err
+}
+
+// Select select the mailbox
+func (c *Client) Select(mailbox string) (err error) {
+	_, err = c.command("SELECT %s", quote(mailbox))
+	return err
+}
+
+// SearchUnseen get the uids of the unseen emails
+func (c *Client) SearchUnseen() (uids []uint32, err error) {
+	resp, err := c.command("UID SEARCH UNSEEN")
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range resp.lines {
+		if !strings.HasPrefix(line, "* SEARCH") {
+			continue
+		}
+		for _, field := range strings.Fields(strings.TrimPrefix(line, "* SEARCH")) {
+			uid, err := strconv.ParseUint(field, 10, 32)
+			if err == nil {
+				uids = append(uids, uint32(uid))
+			}
+		}
+	}
+	return uids, nil
+}
+
+// FetchRaw get the raw email of the uid without marking it as seen
+func (c *Client) FetchRaw(uid uint32) (raw []byte, err error) {
+	resp, err := c.command("UID FETCH %d BODY.PEEK[]", uid)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.literals) == 0 {
+		return nil, fmt.Errorf("email %d not found", uid)
+	}
+	return resp.literals[0], nil
+}
+
+// MarkSeen mark the email of the uid as seen
+func (c *Client) MarkSeen(uid uint32) (err error) {
+	_, err = c.command(`UID STORE %d +FLAGS.SILENT (\Seen)`, uid)
+	return err
+}
+
+// Logout logout and close the connection
+func (c *Client) Logout() (err error) {
+	_, err = c.command("LOGOUT")
+	if closeErr := c.conn.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+type response struct {
+	// the untagged lines, the literals are replaced by their size
+	lines    []string
+	literals [][]byte
+}
+
+// command send the command and read the response until the tagged status
+func (c *Client) command(format string, args ...any) (resp *response, err error) {
+	c.tag++
+	tag := fmt.Sprintf("A%04d", c.tag)
+	c.deadline()
+	if _, err = fmt.Fprintf(c.conn, "%s %s\r\n", tag, fmt.Sprintf(format, args...)); err != nil {
+		return nil, err
+	}
+	resp = &response{}
+	for {
+		line, err := c.readLine()
+		if err != nil {
+			return nil, err
+		}
+		// the line continues after the literal
+		for {
+			match := literalReg.FindStringSubmatch(line)
+			if match == nil {
+				break
+			}
+			size, _ := strconv.Atoi(match[1])
+			if size > maxLiteralSize {
+				return nil, fmt.Errorf("literal size %d exceeds the limit", size)
+			}
+			literal := make([]byte, size)
+			if _, err = io.ReadFull(c.reader, literal); err != nil {
+				return nil, err
+			}
+			resp.literals = append(resp.literals, literal)
+			rest, err := c.readLine()
+			if err != nil {
+				return nil, err
+			}
+			line += rest
+		}
+		if strings.HasPrefix(line, tag+" ") {
+			status := strings.TrimPrefix(line, tag+" ")
+			if !strings.HasPrefix(status, "OK") {
+				return nil, fmt.Errorf("imap command failed: %s", status)
+			}
+			return resp, nil
+		}
+		resp.lines = append(resp.lines, line)
+	}
+}
+
+func (c *Client) readLine() (line string, err error) {
+	line, err = c.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (c *Client) deadline() {
+	if c.timeout > 0 {
+		_ = c.conn.SetDeadline(time.Now().Add(c.timeout))
+	}
+}
+
+// quote quote the string as the quoted string of IMAP
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,74 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package imapclient
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient(t *testing.T) {
+	server, conn := net.Pipe()
+	raw := "Subject: hi\r\n\r\nhello"
+	go func() {
+		reader := bufio.NewReader(server)
+		fmt.Fprint(server, "* OK IMAP ready\r\n")
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			fields := strings.Fields(line)
+			tag, command := fields[0], strings.Join(fields[1:], " ")
+			switch {
+			case strings.HasPrefix(command, "LOGIN"):
+				if command != `LOGIN "user" "pa\"ss"` {
+					fmt.Fprintf(server, "%s NO bad credentials\r\n", tag)
+					continue
+				}
+			case command == "UID SEARCH UNSEEN":
+				fmt.Fprint(server, "* SEARCH 3 7\r\n")
+			case command == "UID FETCH 7 BODY.PEEK[]":
+				fmt.Fprintf(server, "* 2 FETCH (UID 7 BODY[] {%d}\r\n%s)\r\n", len(raw), raw)
+			}
+			fmt.Fprintf(server, "%s OK done\r\n", tag)
+		}
+	}()
+
+	c, err := NewClient(conn, time.Second)
+	assert.NoError(t, err)
+	assert.NoError(t, c.Login("user", `pa"ss`))
+	assert.NoError(t, c.Select("INBOX"))
+	uids, err := c.SearchUnseen()
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{3, 7}, uids)
+	content, err := c.FetchRaw(7)
+	assert.NoError(t, err)
+	assert.Equal(t, raw, string(content))
+	assert.NoError(t, c.MarkSeen(7))
+	assert.Error(t, c.Login("user", "wrong"))
+	assert.NoError(t, c.Logout())
+}
@@ -0,0 +1,150 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package oauth implements the ES256 signing of the OpenID Connect id token with the JSON web key set,
+// and the PKCE verification of RFC 7636, for the site acting as the OpenID provider.
+package oauth
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+)
+
+const (
+	// CodeChallengeMethodPlain the code challenge is the code verifier
+	CodeChallengeMethodPlain = "plain"
+	// CodeChallengeMethodS256 the code challenge is the base64url sha256 of the code verifier
+	CodeChallengeMethodS256 = "S256"
+)
+
+var encoding = base64.RawURLEncoding
+
+// GenerateSigningKey generate the P-256 private key signing the id tokens, base64url encoded without padding
+func GenerateSigningKey() (privateKey string, err error) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(key.Bytes()), nil
+}
+
+// GenerateSecret generate the random secret, hex encoded
+func GenerateSecret(size int) (secret string, err error) {
+	b := make([]byte, size)
+	if _, err = rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Sign sign the claims as the ES256 JSON web token, the key id is derived from the public key
+func Sign(privateKey string, claims any) (token string, err error) {
+	signer, err := parseSigningKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+	header, _ := json.Marshal(map[string]string{"typ": "JWT", "alg": "ES256", "kid": keyID(&signer.PublicKey)})
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	unsigned := encoding.EncodeToString(header) + "." + encoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(unsigned))
+	r, s, err := ecdsa.Sign(rand.Reader, signer, digest[:])
+	if err != nil {
+		return "", err
+	}
+	// the ES256 signature is the fixed size r | s
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+	return unsigned + "." + encoding.EncodeToString(signature), nil
+}
+
+// JWKS the JSON web key set of the public key, the relying parties verify the id tokens with it
+func JWKS(privateKey string) (jwks map[string]any, err error) {
+	signer, err := parseSigningKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"keys": []map[string]string{{
+			"kty": "EC",
+			"crv": "P-256",
+			"use": "sig",
+			"alg": "ES256",
+			"kid": keyID(&signer.PublicKey),
+			"x":   encoding.EncodeToString(signer.PublicKey.X.FillBytes(make([]byte, 32))),
+			"y":   encoding.EncodeToString(signer.PublicKey.Y.FillBytes(make([]byte, 32))),
+		}},
+	}, nil
+}
+
+// VerifyCodeChallenge verify the code verifier of the token request matches the code challenge of the authorization
+func VerifyCodeChallenge(codeVerifier, codeChallenge, method string) bool {
+	if len(codeVerifier) == 0 {
+		return false
+	}
+	expected := codeVerifier
+	switch method {
+	case CodeChallengeMethodS256:
+		sum := sha256.Sum256([]byte(codeVerifier))
+		expected = encoding.EncodeToString(sum[:])
+	case CodeChallengeMethodPlain, "":
+	default:
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(codeChallenge)) == 1
+}
+
+func parseSigningKey(privateKey string) (signer *ecdsa.PrivateKey, err error) {
+	privateBytes, err := encoding.DecodeString(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	key, err := ecdh.P256().NewPrivateKey(privateBytes)
+	if err != nil {
+		return nil, err
+	}
+	publicBytes := key.PublicKey().Bytes()
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(publicBytes[1:33]),
+			Y:     new(big.Int).SetBytes(publicBytes[33:]),
+		},
+		D: new(big.Int).SetBytes(privateBytes),
+	}, nil
+}
+
+// keyID the key id is the truncated sha256 of the uncompressed public key
+func keyID(publicKey *ecdsa.PublicKey) string {
+	point := append([]byte{4}, publicKey.X.FillBytes(make([]byte, 32))...)
+	point = append(point, publicKey.Y.FillBytes(make([]byte, 32))...)
+	sum := sha256.Sum256(point)
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,76 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package oauth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign(t *testing.T) {
+	privateKey, err := GenerateSigningKey()
+	require.NoError(t, err)
+	token, err := Sign(privateKey, map[string]any{"sub": "1", "aud": "client"})
+	require.NoError(t, err)
+	parts := strings.Split(token, ".")
+	require.Len(t, parts, 3)
+
+	payload, err := encoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"sub": "1", "aud": "client"}`, string(payload))
+
+	// verify the signature with the public key of the key set
+	jwks, err := JWKS(privateKey)
+	require.NoError(t, err)
+	key := jwks["keys"].([]map[string]string)[0]
+	header := make(map[string]string)
+	headerBytes, _ := encoding.DecodeString(parts[0])
+	require.NoError(t, json.Unmarshal(headerBytes, &header))
+	assert.Equal(t, key["kid"], header["kid"])
+
+	x, _ := encoding.DecodeString(key["x"])
+	y, _ := encoding.DecodeString(key["y"])
+	publicKey := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+	signature, _ := encoding.DecodeString(parts[2])
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	assert.True(t, ecdsa.Verify(publicKey, digest[:],
+		new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])))
+
+	_, err = Sign("invalid", nil)
+	assert.Error(t, err)
+}
+
+func TestVerifyCodeChallenge(t *testing.T) {
+	// the example of RFC 7636 appendix B
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	assert.True(t, VerifyCodeChallenge(verifier, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", CodeChallengeMethodS256))
+	assert.False(t, VerifyCodeChallenge(verifier, verifier, CodeChallengeMethodS256))
+	assert.True(t, VerifyCodeChallenge(verifier, verifier, CodeChallengeMethodPlain))
+	assert.False(t, VerifyCodeChallenge("", "", CodeChallengeMethodPlain))
+	assert.False(t, VerifyCodeChallenge(verifier, verifier, "S512"))
+}
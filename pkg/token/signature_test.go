@@ -0,0 +1,36 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package token
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"event":"question.sync"}`)
+	signature := Sign("secret-of-peer-instance", 1700000000, body)
+
+	assert.True(t, VerifySignature("secret-of-peer-instance", 1700000000, body, signature))
+	assert.False(t, VerifySignature("another-secret", 1700000000, body, signature))
+	assert.False(t, VerifySignature("secret-of-peer-instance", 1700000001, body, signature))
+	assert.False(t, VerifySignature("secret-of-peer-instance", 1700000000, []byte(`{}`), signature))
+}
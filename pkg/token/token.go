@@ -19,10 +19,24 @@
 
 package token
 
-import "github.com/google/uuid"
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/google/uuid"
+)
 
 // GenerateToken generate token
 func GenerateToken() string {
 	uid, _ := uuid.NewV7()
 	return uid.String()
 }
+
+// GenerateRandomToken generate the hex token of the random bytes, it is not ordered by the time like GenerateToken
+func GenerateRandomToken(size int) (token string, err error) {
+	b := make([]byte, size)
+	if _, err = rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
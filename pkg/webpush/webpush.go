@@ -0,0 +1,194 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package webpush implements the message encryption of RFC 8291 and the VAPID authorization of RFC 8292
+// to send the push messages to the browser push services.
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/url"
+	"time"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// recordSize the record size of the encrypted content, the payload must fit in one record
+	recordSize = 4096
+	// MaxPayloadSize the max size of the payload in one record, the padding delimiter and the tag are excluded
+	MaxPayloadSize = recordSize - 17
+	// vapidTokenTTL the expiration of the vapid token, at most 24 hours by RFC 8292
+	vapidTokenTTL = 12 * time.Hour
+)
+
+var encoding = base64.RawURLEncoding
+
+// GenerateVAPIDKeys generate the P-256 key pair of vapid, the keys are base64url encoded without padding,
+// the public key is the uncompressed point which is used as the applicationServerKey by the browser
+func GenerateVAPIDKeys() (privateKey, publicKey string, err error) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	return encoding.EncodeToString(key.Bytes()), encoding.EncodeToString(key.PublicKey().Bytes()), nil
+}
+
+// Encrypt encrypt the payload for the subscription by the aes128gcm content encoding,
+// p256dh and auth are the base64url encoded keys of the subscription
+func Encrypt(p256dh, auth string, payload []byte) (body []byte, err error) {
+	if len(payload) > MaxPayloadSize {
+		return nil, fmt.Errorf("payload size %d exceeds %d", len(payload), MaxPayloadSize)
+	}
+	uaPublicBytes, err := decodeKey(p256dh)
+	if err != nil {
+		return nil, err
+	}
+	authSecret, err := decodeKey(auth)
+	if err != nil {
+		return nil, err
+	}
+	uaPublic, err := ecdh.P256().NewPublicKey(uaPublicBytes)
+	if err != nil {
+		return nil, err
+	}
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, 16)
+	if _, err = rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return encrypt(asPrivate, uaPublic, authSecret, salt, payload)
+}
+
+func encrypt(asPrivate *ecdh.PrivateKey, uaPublic *ecdh.PublicKey, authSecret, salt, payload []byte) (
+	body []byte, err error) {
+	ecdhSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, err
+	}
+	asPublicBytes := asPrivate.PublicKey().Bytes()
+	keyInfo := append([]byte("WebPush: info\x00"), uaPublic.Bytes()...)
+	keyInfo = append(keyInfo, asPublicBytes...)
+	ikm, err := expand(hkdf.Extract(sha256.New, ecdhSecret, authSecret), keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+	prk := hkdf.Extract(sha256.New, ikm, salt)
+	cek, err := expand(prk, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := expand(prk, []byte("Content-Encoding: nonce\x00"), 12)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// header: salt(16) | record size(4) | key id length(1) | key id, the key id is the public key of sender
+	header := make([]byte, 0, 21+len(asPublicBytes))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, recordSize)
+	header = append(header, byte(len(asPublicBytes)))
+	header = append(header, asPublicBytes...)
+	// the single record ends with the padding delimiter 0x02
+	plaintext := append(append([]byte{}, payload...), 0x02)
+	return gcm.Seal(header, nonce, plaintext, nil), nil
+}
+
+// VAPIDAuthorization build the authorization header of the request to the push service endpoint,
+// the subject is the contact of the sender, a mailto: or https: url
+func VAPIDAuthorization(endpoint, subject, privateKey, publicKey string) (authorization string, err error) {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	privateBytes, err := decodeKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+	key, err := ecdh.P256().NewPrivateKey(privateBytes)
+	if err != nil {
+		return "", err
+	}
+	publicBytes := key.PublicKey().Bytes()
+	signer := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(publicBytes[1:33]),
+			Y:     new(big.Int).SetBytes(publicBytes[33:]),
+		},
+		D: new(big.Int).SetBytes(privateBytes),
+	}
+
+	header, _ := json.Marshal(map[string]string{"typ": "JWT", "alg": "ES256"})
+	claims, _ := json.Marshal(map[string]any{
+		"aud": endpointURL.Scheme + "://" + endpointURL.Host,
+		"exp": time.Now().Add(vapidTokenTTL).Unix(),
+		"sub": subject,
+	})
+	unsigned := encoding.EncodeToString(header) + "." + encoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	r, s, err := ecdsa.Sign(rand.Reader, signer, digest[:])
+	if err != nil {
+		return "", err
+	}
+	// the ES256 signature is the fixed size r | s
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+	return fmt.Sprintf("vapid t=%s.%s, k=%s", unsigned, encoding.EncodeToString(signature), publicKey), nil
+}
+
+func expand(prk, info []byte, length int) ([]byte, error) {
+	out := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.Expand(sha256.New, prk, info), out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// decodeKey decode the base64url key, the padding is optional as the browsers differ
+func decodeKey(key string) ([]byte, error) {
+	if data, err := base64.RawURLEncoding.DecodeString(key); err == nil {
+		return data, nil
+	}
+	return base64.URLEncoding.DecodeString(key)
+}
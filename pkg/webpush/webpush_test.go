@@ -0,0 +1,109 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/hkdf"
+)
+
+// decrypt the content as the user agent does by RFC 8291
+func decrypt(t *testing.T, ua *ecdh.PrivateKey, authSecret, body []byte) []byte {
+	salt, rs, idLen := body[:16], binary.BigEndian.Uint32(body[16:20]), int(body[20])
+	assert.Equal(t, uint32(recordSize), rs)
+	asPublic, err := ecdh.P256().NewPublicKey(body[21 : 21+idLen])
+	require.NoError(t, err)
+	ecdhSecret, err := ua.ECDH(asPublic)
+	require.NoError(t, err)
+
+	keyInfo := append([]byte("WebPush: info\x00"), ua.PublicKey().Bytes()...)
+	keyInfo = append(keyInfo, asPublic.Bytes()...)
+	ikm, err := expand(hkdf.Extract(sha256.New, ecdhSecret, authSecret), keyInfo, 32)
+	require.NoError(t, err)
+	prk := hkdf.Extract(sha256.New, ikm, salt)
+	cek, _ := expand(prk, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce, _ := expand(prk, []byte("Content-Encoding: nonce\x00"), 12)
+	block, _ := aes.NewCipher(cek)
+	gcm, _ := cipher.NewGCM(block)
+	plaintext, err := gcm.Open(nil, nonce, body[21+idLen:], nil)
+	require.NoError(t, err)
+	require.Equal(t, byte(0x02), plaintext[len(plaintext)-1])
+	return plaintext[:len(plaintext)-1]
+}
+
+func TestEncrypt(t *testing.T) {
+	ua, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	authSecret := make([]byte, 16)
+	_, _ = rand.Read(authSecret)
+
+	payload := []byte(`{"title":"new answer","url":"/questions/1"}`)
+	body, err := Encrypt(encoding.EncodeToString(ua.PublicKey().Bytes()), encoding.EncodeToString(authSecret), payload)
+	require.NoError(t, err)
+	assert.Equal(t, payload, decrypt(t, ua, authSecret, body))
+
+	_, err = Encrypt(encoding.EncodeToString(ua.PublicKey().Bytes()), encoding.EncodeToString(authSecret),
+		make([]byte, MaxPayloadSize+1))
+	assert.Error(t, err)
+	_, err = Encrypt("invalid", encoding.EncodeToString(authSecret), payload)
+	assert.Error(t, err)
+}
+
+func TestVAPIDAuthorization(t *testing.T) {
+	privateKey, publicKey, err := GenerateVAPIDKeys()
+	require.NoError(t, err)
+
+	authorization, err := VAPIDAuthorization("https://push.example.com/send/abc", "mailto:admin@example.com",
+		privateKey, publicKey)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(authorization, "vapid t="))
+	parts := strings.Split(strings.TrimPrefix(authorization, "vapid t="), ", k=")
+	require.Len(t, parts, 2)
+	assert.Equal(t, publicKey, parts[1])
+
+	token := strings.Split(parts[0], ".")
+	require.Len(t, token, 3)
+	claims, _ := encoding.DecodeString(token[1])
+	assert.Contains(t, string(claims), `"aud":"https://push.example.com"`)
+
+	publicBytes, _ := encoding.DecodeString(publicKey)
+	verifier := &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(publicBytes[1:33]),
+		Y:     new(big.Int).SetBytes(publicBytes[33:]),
+	}
+	signature, _ := encoding.DecodeString(token[2])
+	digest := sha256.Sum256([]byte(token[0] + "." + token[1]))
+	assert.True(t, ecdsa.Verify(verifier, digest[:],
+		new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])))
+}
@@ -0,0 +1,231 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package writer
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	pdfPageWidth   = 595.0 // A4
+	pdfPageHeight  = 842.0
+	pdfMargin      = 56.0
+	pdfBodySize    = 11.0
+	pdfHeadingSize = 16.0
+	pdfLineSpacing = 1.45
+)
+
+// PDFDocument is a simple text PDF document writer, it supports headings and paragraphs with line wrapping.
+// The text is written in the embedded TrueType font, the Go fonts are used by default.
+type PDFDocument struct {
+	pages   []*bytes.Buffer
+	current *bytes.Buffer
+	y       float64
+	regular *pdfFontUsage
+	bold    *pdfFontUsage
+	// the font has no bold style, the bold text is stroked
+	fakeBold bool
+}
+
+// NewPDFDocument create a new pdf document with the Go fonts
+func NewPDFDocument() *PDFDocument {
+	doc := &PDFDocument{
+		regular: newPDFFontUsage(defaultPDFRegularFont),
+		bold:    newPDFFontUsage(defaultPDFBoldFont),
+	}
+	doc.newPage()
+	return doc
+}
+
+// NewPDFDocumentWithFont create a new pdf document with the font, such as the font of CJK characters
+func NewPDFDocumentWithFont(font *PDFFont) *PDFDocument {
+	usage := newPDFFontUsage(font)
+	doc := &PDFDocument{regular: usage, bold: usage, fakeBold: true}
+	doc.newPage()
+	return doc
+}
+
+// AddHeading add a bold heading
+func (d *PDFDocument) AddHeading(text string) {
+	d.y -= pdfHeadingSize * 0.5
+	d.writeText(text, true, pdfHeadingSize)
+	d.y -= pdfHeadingSize * 0.3
+}
+
+// AddParagraph add a paragraph, the line breaks in text are kept
+func (d *PDFDocument) AddParagraph(text string) {
+	d.writeText(text, false, pdfBodySize)
+	d.y -= pdfBodySize * 0.6
+}
+
+// AddSeparator add a horizontal line
+func (d *PDFDocument) AddSeparator() {
+	if d.y-pdfBodySize < pdfMargin {
+		d.newPage()
+		return
+	}
+	d.y -= pdfBodySize * 0.5
+	fmt.Fprintf(d.current, "%.2f %.2f m %.2f %.2f l 0.5 w S\n", pdfMargin, d.y, pdfPageWidth-pdfMargin, d.y)
+	d.y -= pdfBodySize
+}
+
+// Bytes returns the content of pdf file
+func (d *PDFDocument) Bytes() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	offsets := make([]int, 0)
+	writeObject := func(content string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(buf, "%d 0 obj\n%s\nendobj\n", len(offsets), content)
+	}
+
+	// 1: catalog, 2: pages, then the objects of fonts, then the page and content objects of every page
+	fonts := []*pdfFontUsage{d.regular}
+	if d.bold != d.regular {
+		fonts = append(fonts, d.bold)
+	}
+	fontObjects := make([]string, 0)
+	for _, font := range fonts {
+		objects, err := font.objects(3 + len(fontObjects))
+		if err != nil {
+			return nil, fmt.Errorf("embed font failed: %w", err)
+		}
+		fontObjects = append(fontObjects, objects...)
+	}
+	firstPage := 3 + len(fontObjects)
+	// every font is written as 5 objects
+	boldObject := 3 + 5*(len(fonts)-1)
+
+	buf.WriteString("%PDF-1.4\n")
+	kids := make([]string, 0, len(d.pages))
+	for i := range d.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", firstPage+i*2))
+	}
+	writeObject("<< /Type /Catalog /Pages 2 0 R >>")
+	writeObject(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	for _, object := range fontObjects {
+		writeObject(object)
+	}
+	for i, page := range d.pages {
+		writeObject(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] "+
+			"/Resources << /Font << /F1 3 0 R /F2 %d 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, boldObject, firstPage+i*2+1))
+		content, err := compressPDFStream(page.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		writeObject(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", len(content), content))
+	}
+
+	xrefOffset := buf.Len()
+	fmt.Fprintf(buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xrefOffset)
+	return buf.Bytes(), nil
+}
+
+func (d *PDFDocument) newPage() {
+	d.current = &bytes.Buffer{}
+	d.pages = append(d.pages, d.current)
+	d.y = pdfPageHeight - pdfMargin
+}
+
+func (d *PDFDocument) writeText(text string, bold bool, size float64) {
+	usage, font, style := d.regular, "F1", ""
+	if bold {
+		usage, font = d.bold, "F2"
+		if d.fakeBold {
+			style = fmt.Sprintf("2 Tr %.2f w ", size*0.03)
+		}
+	}
+	runeWidth := func(r rune) float64 {
+		_, width := usage.glyph(r)
+		return width * size / 1000
+	}
+	lineHeight := size * pdfLineSpacing
+	for _, paragraphLine := range strings.Split(cleanPDFText(text), "\n") {
+		for _, line := range wrapPDFLine(paragraphLine, pdfPageWidth-pdfMargin*2, runeWidth) {
+			if d.y-lineHeight < pdfMargin {
+				d.newPage()
+			}
+			d.y -= lineHeight
+			fmt.Fprintf(d.current, "BT /%s %.1f Tf %s%.2f %.2f Td %s Tj ET\n",
+				font, size, style, pdfMargin, d.y, usage.encode(line))
+		}
+	}
+}
+
+// wrapPDFLine wrap the line by words in max width, the word longer than max width is split
+func wrapPDFLine(line string, maxWidth float64, runeWidth func(r rune) float64) (lines []string) {
+	current, currentWidth := make([]rune, 0), 0.0
+	spaceWidth := runeWidth(' ')
+	for i, word := range strings.Split(line, " ") {
+		runes := []rune(word)
+		wordWidth := 0.0
+		for _, r := range runes {
+			wordWidth += runeWidth(r)
+		}
+		if i > 0 {
+			if currentWidth+spaceWidth+wordWidth <= maxWidth {
+				current, currentWidth = append(current, ' '), currentWidth+spaceWidth
+			} else if len(current) > 0 {
+				lines = append(lines, string(current))
+				current, currentWidth = current[:0], 0
+			}
+		}
+		for _, r := range runes {
+			width := runeWidth(r)
+			if len(current) > 0 && currentWidth+width > maxWidth {
+				lines = append(lines, string(current))
+				current, currentWidth = current[:0], 0
+			}
+			current, currentWidth = append(current, r), currentWidth+width
+		}
+	}
+	return append(lines, string(current))
+}
+
+// cleanPDFText normalize the line breaks and tabs, the other control characters are removed
+func cleanPDFText(text string) string {
+	text = strings.NewReplacer("\r\n", "\n", "\t", "    ").Replace(text)
+	return strings.Map(func(r rune) rune {
+		if r != '\n' && unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, text)
+}
+
+func compressPDFStream(data []byte) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	w := zlib.NewWriter(buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
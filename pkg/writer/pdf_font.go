@@ -0,0 +1,393 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package writer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf16"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+var (
+	defaultPDFRegularFont = mustParsePDFFont(goregular.TTF)
+	defaultPDFBoldFont    = mustParsePDFFont(gobold.TTF)
+)
+
+// PDFFont is a TrueType font embedded in the pdf document, only the glyphs used by the document are embedded.
+// It is safe to share the font between documents.
+type PDFFont struct {
+	font       *sfnt.Font
+	name       string
+	unitsPerEm int
+	tables     map[string][]byte
+}
+
+// NewPDFFont parse the TrueType font, the font with PostScript outlines and the font collection are not supported
+func NewPDFFont(data []byte) (*PDFFont, error) {
+	f, err := sfnt.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse font failed: %w", err)
+	}
+	tables, err := parseTrueTypeTables(data)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range []string{"head", "hhea", "maxp", "hmtx", "loca", "glyf"} {
+		if _, ok := tables[tag]; !ok {
+			return nil, fmt.Errorf("the font is not a TrueType font, the %s table is missing", tag)
+		}
+	}
+	pf := &PDFFont{font: f, unitsPerEm: int(f.UnitsPerEm()), tables: tables}
+	name, _ := f.Name(&sfnt.Buffer{}, sfnt.NameIDPostScript)
+	pf.name = strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' {
+			return r
+		}
+		return -1
+	}, name)
+	if len(pf.name) == 0 {
+		pf.name = "Font"
+	}
+	return pf, nil
+}
+
+func mustParsePDFFont(data []byte) *PDFFont {
+	f, err := NewPDFFont(data)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// pdfFontUsage the glyphs of the font used by one document
+type pdfFontUsage struct {
+	font   *PDFFont
+	buf    sfnt.Buffer
+	glyphs map[sfnt.GlyphIndex]rune
+	widths map[rune]float64
+}
+
+func newPDFFontUsage(f *PDFFont) *pdfFontUsage {
+	return &pdfFontUsage{font: f, glyphs: make(map[sfnt.GlyphIndex]rune), widths: make(map[rune]float64)}
+}
+
+// glyph returns the glyph of the rune and its advance width in 1/1000 of the font size.
+// The rune not in the font is shown as the missing glyph.
+func (u *pdfFontUsage) glyph(r rune) (gid sfnt.GlyphIndex, width float64) {
+	gid, err := u.font.font.GlyphIndex(&u.buf, r)
+	if err != nil {
+		gid = 0
+	}
+	if w, ok := u.widths[r]; ok {
+		return gid, w
+	}
+	advance, err := u.font.font.GlyphAdvance(&u.buf, gid, fixed.I(1000), font.HintingNone)
+	if err == nil {
+		width = float64(advance) / 64
+	}
+	u.widths[r] = width
+	return gid, width
+}
+
+// width returns the width of the text in 1/1000 of the font size
+func (u *pdfFontUsage) width(text string) (width float64) {
+	for _, r := range text {
+		_, w := u.glyph(r)
+		width += w
+	}
+	return width
+}
+
+// encode returns the text as the hex string of glyph ids, the used glyphs are recorded
+func (u *pdfFontUsage) encode(text string) string {
+	b := &strings.Builder{}
+	b.WriteByte('<')
+	for _, r := range text {
+		gid, _ := u.glyph(r)
+		if _, ok := u.glyphs[gid]; !ok && gid != 0 {
+			u.glyphs[gid] = r
+		}
+		fmt.Fprintf(b, "%04X", uint16(gid))
+	}
+	b.WriteByte('>')
+	return b.String()
+}
+
+// objects returns the type0 font, the cid font, the font descriptor, the font file and the to unicode cmap,
+// the first object number is first
+func (u *pdfFontUsage) objects(first int) (objects []string, err error) {
+	f := u.font
+	scale := func(v fixed.Int26_6) int { return int(float64(v) / 64) }
+	bounds, err := f.font.Bounds(&u.buf, fixed.I(1000), font.HintingNone)
+	if err != nil {
+		return nil, err
+	}
+	metrics, err := f.font.Metrics(&u.buf, fixed.I(1000), font.HintingNone)
+	if err != nil {
+		return nil, err
+	}
+	gids := make([]int, 0, len(u.glyphs))
+	for gid := range u.glyphs {
+		gids = append(gids, int(gid))
+	}
+	sort.Ints(gids)
+
+	widths := &strings.Builder{}
+	for _, gid := range gids {
+		fmt.Fprintf(widths, "%d [%.0f] ", gid, u.widths[u.glyphs[sfnt.GlyphIndex(gid)]])
+	}
+	subset, err := f.subset(gids)
+	if err != nil {
+		return nil, err
+	}
+	fontFile, err := compressPDFStream(subset)
+	if err != nil {
+		return nil, err
+	}
+	// the subset font name is tagged by the 6 uppercase letters
+	baseFont := fmt.Sprintf("%s+%s", subsetTag(gids), f.name)
+
+	objects = append(objects,
+		fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H "+
+			"/DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>", baseFont, first+1, first+4),
+		fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s "+
+			"/CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> "+
+			"/FontDescriptor %d 0 R /CIDToGIDMap /Identity /DW 1000 /W [%s] >>", baseFont, first+2, widths.String()),
+		fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 4 /FontBBox [%d %d %d %d] /ItalicAngle 0 "+
+			"/Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>", baseFont,
+			scale(bounds.Min.X), -scale(bounds.Max.Y), scale(bounds.Max.X), -scale(bounds.Min.Y),
+			scale(metrics.Ascent), -scale(metrics.Descent), scale(metrics.CapHeight), first+3),
+		fmt.Sprintf("<< /Length %d /Length1 %d /Filter /FlateDecode >>\nstream\n%s\nendstream",
+			len(fontFile), len(subset), fontFile),
+	)
+	toUnicode := u.toUnicode(gids)
+	objects = append(objects, fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(toUnicode), toUnicode))
+	return objects, nil
+}
+
+// toUnicode returns the cmap mapping the glyph ids to unicode, then the text can be copied and searched
+func (u *pdfFontUsage) toUnicode(gids []int) string {
+	b := &strings.Builder{}
+	b.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n" +
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n" +
+		"/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n" +
+		"1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+	// at most 100 entries are allowed in one bfchar block
+	for i := 0; i < len(gids); i += 100 {
+		block := gids[i:min(i+100, len(gids))]
+		fmt.Fprintf(b, "%d beginbfchar\n", len(block))
+		for _, gid := range block {
+			b.WriteString(fmt.Sprintf("<%04X> <", gid))
+			for _, c := range utf16.Encode([]rune{u.glyphs[sfnt.GlyphIndex(gid)]}) {
+				fmt.Fprintf(b, "%04X", c)
+			}
+			b.WriteString(">\n")
+		}
+		b.WriteString("endbfchar\n")
+	}
+	b.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend")
+	return b.String()
+}
+
+func subsetTag(gids []int) string {
+	hash := uint32(2166136261)
+	for _, gid := range gids {
+		hash = (hash ^ uint32(gid)) * 16777619
+	}
+	tag := make([]byte, 6)
+	for i := range tag {
+		tag[i] = 'A' + byte(hash%26)
+		hash /= 26
+	}
+	return string(tag)
+}
+
+// parseTrueTypeTables returns the tables of font by tag
+func parseTrueTypeTables(data []byte) (tables map[string][]byte, err error) {
+	if len(data) < 12 {
+		return nil, fmt.Errorf("invalid font data")
+	}
+	numTables := int(binary.BigEndian.Uint16(data[4:]))
+	if len(data) < 12+numTables*16 {
+		return nil, fmt.Errorf("invalid font table directory")
+	}
+	tables = make(map[string][]byte, numTables)
+	for i := 0; i < numTables; i++ {
+		record := data[12+i*16:]
+		offset, length := binary.BigEndian.Uint32(record[8:]), binary.BigEndian.Uint32(record[12:])
+		if uint64(offset)+uint64(length) > uint64(len(data)) {
+			return nil, fmt.Errorf("invalid font table %s", record[:4])
+		}
+		tables[string(record[:4])] = data[offset : offset+length]
+	}
+	return tables, nil
+}
+
+// subset returns the font only with the outlines of glyphs, the glyph ids are kept so no cmap is needed.
+// The notdef glyph and the components of composite glyphs are always kept.
+func (f *PDFFont) subset(gids []int) ([]byte, error) {
+	head, maxp, loca, glyf := f.tables["head"], f.tables["maxp"], f.tables["loca"], f.tables["glyf"]
+	if len(head) < 54 || len(maxp) < 6 {
+		return nil, fmt.Errorf("invalid font head or maxp table")
+	}
+	numGlyphs := int(binary.BigEndian.Uint16(maxp[4:]))
+	longLoca := binary.BigEndian.Uint16(head[50:]) == 1
+	location := func(gid int) (start, end int, ok bool) {
+		if longLoca {
+			if len(loca) < (gid+2)*4 {
+				return 0, 0, false
+			}
+			start, end = int(binary.BigEndian.Uint32(loca[gid*4:])), int(binary.BigEndian.Uint32(loca[gid*4+4:]))
+		} else {
+			if len(loca) < (gid+2)*2 {
+				return 0, 0, false
+			}
+			start, end = int(binary.BigEndian.Uint16(loca[gid*2:]))*2, int(binary.BigEndian.Uint16(loca[gid*2+2:]))*2
+		}
+		return start, end, start <= end && end <= len(glyf)
+	}
+
+	keep := map[int]bool{0: true}
+	queue := append([]int{0}, gids...)
+	for len(queue) > 0 {
+		gid := queue[0]
+		queue = queue[1:]
+		keep[gid] = true
+		start, end, ok := location(gid)
+		if !ok || end-start < 10 || int16(binary.BigEndian.Uint16(glyf[start:])) >= 0 {
+			continue
+		}
+		// the composite glyph, see https://learn.microsoft.com/typography/opentype/spec/glyf
+		for p := start + 10; p+4 <= end; {
+			flags, component := binary.BigEndian.Uint16(glyf[p:]), int(binary.BigEndian.Uint16(glyf[p+2:]))
+			if !keep[component] && component < numGlyphs {
+				queue = append(queue, component)
+			}
+			p += 4
+			switch {
+			case flags&0x0001 != 0:
+				p += 4
+			default:
+				p += 2
+			}
+			switch {
+			case flags&0x0008 != 0:
+				p += 2
+			case flags&0x0040 != 0:
+				p += 4
+			case flags&0x0080 != 0:
+				p += 8
+			}
+			if flags&0x0020 == 0 {
+				break
+			}
+		}
+	}
+
+	newGlyf := &bytes.Buffer{}
+	newLoca := make([]byte, (numGlyphs+1)*4)
+	for gid := 0; gid < numGlyphs; gid++ {
+		binary.BigEndian.PutUint32(newLoca[gid*4:], uint32(newGlyf.Len()))
+		if start, end, ok := location(gid); ok && keep[gid] {
+			newGlyf.Write(glyf[start:end])
+			// every glyph is aligned to 4 bytes
+			for newGlyf.Len()%4 != 0 {
+				newGlyf.WriteByte(0)
+			}
+		}
+	}
+	binary.BigEndian.PutUint32(newLoca[numGlyphs*4:], uint32(newGlyf.Len()))
+	newHead := append([]byte{}, head...)
+	binary.BigEndian.PutUint32(newHead[8:], 0)  // checkSumAdjustment
+	binary.BigEndian.PutUint16(newHead[50:], 1) // the long loca format
+
+	tables := map[string][]byte{
+		"head": newHead, "hhea": f.tables["hhea"], "maxp": maxp, "hmtx": f.tables["hmtx"],
+		"loca": newLoca, "glyf": newGlyf.Bytes(),
+	}
+	// the hinting tables are kept
+	for _, tag := range []string{"cvt ", "fpgm", "prep"} {
+		if table, ok := f.tables[tag]; ok {
+			tables[tag] = table
+		}
+	}
+	return writeTrueTypeTables(tables), nil
+}
+
+// writeTrueTypeTables returns the font data with the tables sorted by tag
+func writeTrueTypeTables(tables map[string][]byte) []byte {
+	tags := make([]string, 0, len(tables))
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	numTables := len(tags)
+	entrySelector := 0
+	for 1<<(entrySelector+1) <= numTables {
+		entrySelector++
+	}
+	searchRange := (1 << entrySelector) * 16
+	out := &bytes.Buffer{}
+	header := make([]byte, 12)
+	binary.BigEndian.PutUint32(header, 0x00010000)
+	binary.BigEndian.PutUint16(header[4:], uint16(numTables))
+	binary.BigEndian.PutUint16(header[6:], uint16(searchRange))
+	binary.BigEndian.PutUint16(header[8:], uint16(entrySelector))
+	binary.BigEndian.PutUint16(header[10:], uint16(numTables*16-searchRange))
+	out.Write(header)
+
+	offset := 12 + numTables*16
+	records := make([]byte, numTables*16)
+	for i, tag := range tags {
+		table := tables[tag]
+		copy(records[i*16:], tag)
+		binary.BigEndian.PutUint32(records[i*16+4:], trueTypeChecksum(table))
+		binary.BigEndian.PutUint32(records[i*16+8:], uint32(offset))
+		binary.BigEndian.PutUint32(records[i*16+12:], uint32(len(table)))
+		offset += (len(table) + 3) &^ 3
+	}
+	out.Write(records)
+	for _, tag := range tags {
+		out.Write(tables[tag])
+		for out.Len()%4 != 0 {
+			out.WriteByte(0)
+		}
+	}
+	return out.Bytes()
+}
+
+func trueTypeChecksum(table []byte) (sum uint32) {
+	for i := 0; i < len(table); i += 4 {
+		word := make([]byte, 4)
+		copy(word, table[i:])
+		sum += binary.BigEndian.Uint32(word)
+	}
+	return sum
+}
@@ -0,0 +1,109 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package writer
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/sfnt"
+)
+
+func TestPDFDocument_Bytes(t *testing.T) {
+	doc := NewPDFDocument()
+	doc.AddHeading("How to (escape) \\ text?")
+	for i := 0; i < 100; i++ {
+		doc.AddParagraph(strings.Repeat("lorem ipsum café Привет ", 20))
+	}
+	doc.AddSeparator()
+	data, err := doc.Bytes()
+	assert.NoError(t, err)
+
+	assert.True(t, bytes.HasPrefix(data, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(data, []byte("%%EOF\n")))
+	assert.Contains(t, string(data), "/Subtype /Type0")
+	assert.Contains(t, string(data), "/Encoding /Identity-H")
+	assert.Greater(t, len(doc.pages), 1)
+
+	// the non Latin-1 characters are mapped back to unicode
+	gid, _ := doc.regular.glyph('П')
+	assert.NotZero(t, gid)
+	assert.Contains(t, string(data), fmt.Sprintf("<%04X> <041F>", gid))
+
+	// the content of page is compressed
+	stream := regexp.MustCompile(`(?s)/Filter /FlateDecode >>\nstream\n(.*?)\nendstream`).FindAllSubmatch(data, -1)
+	assert.NotEmpty(t, stream)
+	r, err := zlib.NewReader(bytes.NewReader(stream[len(stream)-1][1]))
+	assert.NoError(t, err)
+	content, _ := io.ReadAll(r)
+	assert.Contains(t, string(content), " Tj ET")
+
+	// every xref entry must point to its object
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(data)
+	assert.NotNil(t, startxref)
+	offset, _ := strconv.Atoi(string(startxref[1]))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(data[offset:], -1)
+	assert.Equal(t, 2+2*5+len(doc.pages)*2, len(entries))
+	for i, entry := range entries {
+		objOffset, _ := strconv.Atoi(string(entry[1]))
+		assert.True(t, bytes.HasPrefix(data[objOffset:], []byte(fmt.Sprintf("%d 0 obj", i+1))))
+	}
+}
+
+func TestPDFFont_subset(t *testing.T) {
+	font, err := NewPDFFont(goregular.TTF)
+	assert.NoError(t, err)
+	usage := newPDFFontUsage(font)
+	used, _ := usage.glyph('Ж')
+	unused, _ := usage.glyph('A')
+	assert.NotZero(t, used)
+
+	subset, err := font.subset([]int{int(used)})
+	assert.NoError(t, err)
+	assert.Less(t, len(subset), len(goregular.TTF)/2)
+
+	// the glyph ids are kept, only the outlines of used glyphs are left in the long loca
+	tables, err := parseTrueTypeTables(subset)
+	assert.NoError(t, err)
+	assert.Equal(t, (font.font.NumGlyphs()+1)*4, len(tables["loca"]))
+	glyphLength := func(gid sfnt.GlyphIndex) uint32 {
+		return binary.BigEndian.Uint32(tables["loca"][gid*4+4:]) - binary.BigEndian.Uint32(tables["loca"][gid*4:])
+	}
+	assert.NotZero(t, glyphLength(used))
+	assert.NotZero(t, glyphLength(0))
+	assert.Zero(t, glyphLength(unused))
+	assert.Equal(t, font.tables["hmtx"], tables["hmtx"])
+}
+
+func TestWrapPDFLine(t *testing.T) {
+	runeWidth := func(r rune) float64 { return 1 }
+	assert.Equal(t, []string{"hello"}, wrapPDFLine("hello", 10, runeWidth))
+	assert.Equal(t, []string{"hello", "world foo"}, wrapPDFLine("hello world foo", 10, runeWidth))
+	assert.Equal(t, []string{"abcdefghij", "klm"}, wrapPDFLine("abcdefghijklm", 10, runeWidth))
+}
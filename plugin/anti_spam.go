@@ -0,0 +1,73 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package plugin
+
+type AntiSpam interface {
+	Base
+
+	// CheckSpam checks whether the content is spam when it is created, the spam is quarantined
+	// in the review queue instead of being published
+	CheckSpam(content *SpamContent) (result *SpamResult, err error)
+}
+
+// SpamContent is the content to be checked
+type SpamContent struct {
+	// The type of the content, e.g. question, answer, comment
+	ObjectType string
+	// The title of the content, only available for the question
+	Title string
+	// The content in html, always available
+	Content string
+	// The url of the content, or the question the content belongs to
+	Permalink string
+	// The author of the content
+	Author SpamContentAuthor
+	// The site language, e.g. en_US
+	Language string
+	// The user agent of the request web browser
+	UserAgent string
+	// The IP address of the request
+	IP string
+}
+
+type SpamContentAuthor struct {
+	Username    string
+	DisplayName string
+	Email       string
+	// The user's reputation
+	Rank int
+	// 1:User 2:Admin 3:Moderator
+	Role int
+}
+
+// SpamResult is the verdict of the anti-spam plugin
+type SpamResult struct {
+	// If the content is spam
+	Spam bool
+	// The probability of spam from 0 to 1, it is 1 if the plugin only gives the verdict
+	Score float64
+	// The reason for the result
+	Reason string
+}
+
+var (
+	// CallAntiSpam is a function that calls all registered anti-spam plugins
+	CallAntiSpam,
+	registerAntiSpam = MakePlugin[AntiSpam](false)
+)
@@ -0,0 +1,108 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package anti_spam_akismet
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/apache/answer/plugin"
+)
+
+// AntiSpamConfig the config of the Akismet account
+type AntiSpamConfig struct {
+	APIKey  string `json:"api_key"`
+	SiteURL string `json:"site_url"`
+}
+
+// AntiSpam the anti-spam plugin backed by Akismet
+type AntiSpam struct {
+	lock   sync.RWMutex
+	config *AntiSpamConfig
+	client *client
+}
+
+func init() {
+	plugin.Register(&AntiSpam{config: &AntiSpamConfig{}})
+}
+
+func (a *AntiSpam) Info() plugin.Info {
+	return plugin.Info{
+		Name:        plugin.MakeTranslator("backend.anti_spam_akismet.name"),
+		SlugName:    "akismet_anti_spam",
+		Description: plugin.MakeTranslator("backend.anti_spam_akismet.description"),
+		Author:      "answerdev",
+		Version:     "1.0.0",
+		Link:        "https://github.com/apache/answer/tree/main/plugin/anti_spam_akismet",
+	}
+}
+
+func (a *AntiSpam) ConfigFields() []plugin.ConfigField {
+	return []plugin.ConfigField{
+		{
+			Name:        "api_key",
+			Type:        plugin.ConfigTypeInput,
+			Title:       plugin.MakeTranslator("backend.anti_spam_akismet.config.api_key.title"),
+			Description: plugin.MakeTranslator("backend.anti_spam_akismet.config.api_key.description"),
+			Required:    true,
+			UIOptions:   plugin.ConfigFieldUIOptions{InputType: plugin.InputTypePassword},
+			Value:       a.config.APIKey,
+		},
+		{
+			Name:        "site_url",
+			Type:        plugin.ConfigTypeInput,
+			Title:       plugin.MakeTranslator("backend.anti_spam_akismet.config.site_url.title"),
+			Description: plugin.MakeTranslator("backend.anti_spam_akismet.config.site_url.description"),
+			UIOptions:   plugin.ConfigFieldUIOptions{InputType: plugin.InputTypeUrl},
+			Value:       a.config.SiteURL,
+		},
+	}
+}
+
+func (a *AntiSpam) ConfigReceiver(config []byte) error {
+	conf := &AntiSpamConfig{}
+	if err := json.Unmarshal(config, conf); err != nil {
+		return err
+	}
+	conf.APIKey = strings.TrimSpace(conf.APIKey)
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.config = conf
+	a.client = nil
+	if len(conf.APIKey) > 0 {
+		a.client = newClient(conf.APIKey)
+	}
+	return nil
+}
+
+// CheckSpam check the content with the comment-check API of Akismet
+func (a *AntiSpam) CheckSpam(content *plugin.SpamContent) (result *plugin.SpamResult, err error) {
+	a.lock.RLock()
+	c, siteURL := a.client, a.config.SiteURL
+	a.lock.RUnlock()
+	if c == nil {
+		return nil, fmt.Errorf("akismet api key is not configured")
+	}
+	if len(siteURL) == 0 {
+		siteURL = plugin.SiteURL()
+	}
+	return c.commentCheck(buildCommentForm(siteURL, content))
+}
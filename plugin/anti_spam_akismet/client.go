@@ -0,0 +1,110 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package anti_spam_akismet
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/plugin"
+)
+
+// commentTypes the comment_type of Akismet for the object types
+var commentTypes = map[string]string{
+	constant.QuestionObjectType: "forum-post",
+	constant.AnswerObjectType:   "reply",
+	constant.CommentObjectType:  "comment",
+}
+
+// client the minimal client of the Akismet REST API
+type client struct {
+	endpoint   string
+	httpClient *http.Client
+}
+
+func newClient(apiKey string) *client {
+	return &client{
+		endpoint:   fmt.Sprintf("https://%s.rest.akismet.com/1.1", url.PathEscape(apiKey)),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// buildCommentForm build the form of the comment-check API from the content
+func buildCommentForm(siteURL string, content *plugin.SpamContent) url.Values {
+	form := url.Values{}
+	form.Set("blog", siteURL)
+	form.Set("user_ip", content.IP)
+	form.Set("user_agent", content.UserAgent)
+	form.Set("permalink", content.Permalink)
+	form.Set("comment_type", commentTypes[content.ObjectType])
+	form.Set("comment_author", content.Author.DisplayName)
+	form.Set("comment_author_email", content.Author.Email)
+	if len(content.Title) > 0 {
+		form.Set("comment_content", content.Title+"\n\n"+content.Content)
+	} else {
+		form.Set("comment_content", content.Content)
+	}
+	if len(content.Language) > 0 {
+		form.Set("blog_lang", content.Language)
+	}
+	// the privileged users are never spammers
+	if content.Author.Role == 2 || content.Author.Role == 3 {
+		form.Set("user_role", "administrator")
+	}
+	return form
+}
+
+// commentCheck call the comment-check API, the response body is "true" if the content is spam
+func (c *client) commentCheck(form url.Values) (result *plugin.SpamResult, err error) {
+	resp, err := c.httpClient.PostForm(c.endpoint+"/comment-check", form)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return parseCommentCheck(resp.StatusCode, resp.Header, string(body))
+}
+
+func parseCommentCheck(status int, header http.Header, body string) (result *plugin.SpamResult, err error) {
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("akismet comment-check failed: %d %s", status, body)
+	}
+	switch strings.TrimSpace(body) {
+	case "true":
+		result = &plugin.SpamResult{Spam: true, Score: 1}
+		// the blatant spam can be discarded safely
+		if header.Get("X-akismet-pro-tip") == "discard" {
+			result.Reason = "Akismet: blatant spam"
+		}
+		return result, nil
+	case "false":
+		return &plugin.SpamResult{}, nil
+	default:
+		// the "invalid" means the api key or the blog is invalid, the detail is in the debug header
+		return nil, fmt.Errorf("akismet comment-check failed: %s %s", body, header.Get("X-akismet-debug-help"))
+	}
+}
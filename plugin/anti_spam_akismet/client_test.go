@@ -0,0 +1,66 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package anti_spam_akismet
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/apache/answer/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildCommentForm(t *testing.T) {
+	content := &plugin.SpamContent{
+		ObjectType: "question",
+		Title:      "title",
+		Content:    "<p>content</p>",
+		Permalink:  "https://example.com/questions/1",
+		Author:     plugin.SpamContentAuthor{DisplayName: "Tom", Email: "tom@example.com", Role: 1},
+		Language:   "en_US",
+		UserAgent:  "Mozilla/5.0",
+		IP:         "127.0.0.1",
+	}
+	form := buildCommentForm("https://example.com", content)
+	assert.Equal(t, "https://example.com", form.Get("blog"))
+	assert.Equal(t, "forum-post", form.Get("comment_type"))
+	assert.Equal(t, "title\n\n<p>content</p>", form.Get("comment_content"))
+	assert.Equal(t, "tom@example.com", form.Get("comment_author_email"))
+	assert.False(t, form.Has("user_role"))
+
+	content.ObjectType, content.Title, content.Author.Role = "answer", "", 2
+	form = buildCommentForm("https://example.com", content)
+	assert.Equal(t, "reply", form.Get("comment_type"))
+	assert.Equal(t, "<p>content</p>", form.Get("comment_content"))
+	assert.Equal(t, "administrator", form.Get("user_role"))
+}
+
+func TestParseCommentCheck(t *testing.T) {
+	result, err := parseCommentCheck(http.StatusOK, http.Header{}, "true")
+	assert.NoError(t, err)
+	assert.True(t, result.Spam)
+	assert.Equal(t, float64(1), result.Score)
+
+	result, err = parseCommentCheck(http.StatusOK, http.Header{}, "false")
+	assert.NoError(t, err)
+	assert.False(t, result.Spam)
+
+	_, err = parseCommentCheck(http.StatusOK, http.Header{}, "invalid")
+	assert.Error(t, err)
+}
@@ -0,0 +1,33 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package plugin
+
+type ContentTranslator interface {
+	Base
+	// Translate the text from source language to target language, the text may be html.
+	// Language is the ISO 639-1 code, e.g. en, zh
+	Translate(text, sourceLanguage, targetLanguage string) (translated string, err error)
+}
+
+var (
+	// CallContentTranslator is a function that calls all registered content translators
+	CallContentTranslator,
+	registerContentTranslator = MakePlugin[ContentTranslator](false)
+)
@@ -30,6 +30,16 @@ type QuestionImporterInfo struct {
 	UserEmail string   `json:"user_email"`
 }
 
+// AnswerImporterInfo the imported answer with its provenance
+type AnswerImporterInfo struct {
+	QuestionID  string `json:"question_id"`
+	Content     string `json:"content"`
+	UserEmail   string `json:"user_email"`
+	SourceURL   string `json:"source_url"`
+	ImportBatch string `json:"import_batch"`
+	AIAssisted  bool   `json:"ai_assisted"`
+}
+
 type Importer interface {
 	Base
 	RegisterImporterFunc(ctx context.Context, importer ImporterFunc)
@@ -37,6 +47,7 @@ type Importer interface {
 
 type ImporterFunc interface {
 	AddQuestion(ctx context.Context, questionInfo QuestionImporterInfo) (err error)
+	AddAnswer(ctx context.Context, answerInfo AnswerImporterInfo) (err error)
 }
 
 var (
@@ -0,0 +1,70 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package plugin
+
+// MobilePushPlatform is the platform of the device token
+type MobilePushPlatform string
+
+const (
+	// MobilePushPlatformFCM the registration token of Firebase Cloud Messaging
+	MobilePushPlatformFCM MobilePushPlatform = "fcm"
+	// MobilePushPlatformAPNs the device token of Apple Push Notification service
+	MobilePushPlatformAPNs MobilePushPlatform = "apns"
+)
+
+type MobilePush interface {
+	Base
+
+	// Platforms returns the platforms of the device tokens the provider delivers to
+	Platforms() []MobilePushPlatform
+
+	// Push sends the message to the device tokens of the platform, the tokens which are
+	// rejected as unregistered by the push service should be returned to be removed
+	Push(platform MobilePushPlatform, tokens []string, msg MobilePushMessage) (invalidTokens []string, err error)
+}
+
+type MobilePushMessage struct {
+	// the type of the notification
+	Type NotificationType `json:"notification_type"`
+	// the receiver user id
+	ReceiverUserID string `json:"receiver_user_id"`
+	// the receiver user using language
+	ReceiverLang string `json:"receiver_lang"`
+
+	// the title of the notification, e.g. "Alice answered question"
+	Title string `json:"title"`
+	// the body of the notification, the title of the question
+	Body string `json:"body"`
+	// the url the app opens when the notification is tapped
+	URL string `json:"url"`
+	// the notifications with the same thread id can be collapsed by the app, e.g. "question-1"
+	ThreadID string `json:"thread_id"`
+
+	// the question id, the answer id (optional) and the comment id (optional) of the notification
+	QuestionID string `json:"question_id"`
+	AnswerID   string `json:"answer_id"`
+	CommentID  string `json:"comment_id"`
+}
+
+var (
+	// CallMobilePush is a function that calls all registered mobile push providers
+	CallMobilePush,
+	registerMobilePush = MakePlugin[MobilePush](false)
+)
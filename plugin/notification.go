@@ -23,26 +23,33 @@ package plugin
 type NotificationType string
 
 const (
-	NotificationUpdateQuestion         NotificationType = "notification.action.update_question"
-	NotificationAnswerTheQuestion      NotificationType = "notification.action.answer_the_question"
-	NotificationUpVotedTheQuestion     NotificationType = "notification.action.up_voted_question"
-	NotificationDownVotedTheQuestion   NotificationType = "notification.action.down_voted_question"
-	NotificationUpdateAnswer           NotificationType = "notification.action.update_answer"
-	NotificationAcceptAnswer           NotificationType = "notification.action.accept_answer"
-	NotificationUpVotedTheAnswer       NotificationType = "notification.action.up_voted_answer"
-	NotificationDownVotedTheAnswer     NotificationType = "notification.action.down_voted_answer"
-	NotificationCommentQuestion        NotificationType = "notification.action.comment_question"
-	NotificationCommentAnswer          NotificationType = "notification.action.comment_answer"
-	NotificationUpVotedTheComment      NotificationType = "notification.action.up_voted_comment"
-	NotificationReplyToYou             NotificationType = "notification.action.reply_to_you"
-	NotificationMentionYou             NotificationType = "notification.action.mention_you"
-	NotificationYourQuestionIsClosed   NotificationType = "notification.action.your_question_is_closed"
-	NotificationYourQuestionWasDeleted NotificationType = "notification.action.your_question_was_deleted"
-	NotificationYourAnswerWasDeleted   NotificationType = "notification.action.your_answer_was_deleted"
-	NotificationYourCommentWasDeleted  NotificationType = "notification.action.your_comment_was_deleted"
-	NotificationInvitedYouToAnswer     NotificationType = "notification.action.invited_you_to_answer"
-	NotificationNewQuestion            NotificationType = "notification.action.new_question"
-	NotificationNewQuestionFollowedTag NotificationType = "notification.action.new_question_followed_tag"
+	NotificationUpdateQuestion           NotificationType = "notification.action.update_question"
+	NotificationAnswerTheQuestion        NotificationType = "notification.action.answer_the_question"
+	NotificationUpVotedTheQuestion       NotificationType = "notification.action.up_voted_question"
+	NotificationDownVotedTheQuestion     NotificationType = "notification.action.down_voted_question"
+	NotificationUpdateAnswer             NotificationType = "notification.action.update_answer"
+	NotificationAcceptAnswer             NotificationType = "notification.action.accept_answer"
+	NotificationUpVotedTheAnswer         NotificationType = "notification.action.up_voted_answer"
+	NotificationDownVotedTheAnswer       NotificationType = "notification.action.down_voted_answer"
+	NotificationCommentQuestion          NotificationType = "notification.action.comment_question"
+	NotificationCommentAnswer            NotificationType = "notification.action.comment_answer"
+	NotificationUpVotedTheComment        NotificationType = "notification.action.up_voted_comment"
+	NotificationReplyToYou               NotificationType = "notification.action.reply_to_you"
+	NotificationMentionYou               NotificationType = "notification.action.mention_you"
+	NotificationYourQuestionIsClosed     NotificationType = "notification.action.your_question_is_closed"
+	NotificationYourQuestionIsReopened   NotificationType = "notification.action.your_question_is_reopened"
+	NotificationYourQuestionWasDeleted   NotificationType = "notification.action.your_question_was_deleted"
+	NotificationYourAnswerWasDeleted     NotificationType = "notification.action.your_answer_was_deleted"
+	NotificationYourCommentWasDeleted    NotificationType = "notification.action.your_comment_was_deleted"
+	NotificationInvitedYouToAnswer       NotificationType = "notification.action.invited_you_to_answer"
+	NotificationYourPostWasRemovedByFlag NotificationType = "notification.action.your_post_was_removed_by_flag"
+	NotificationYourAppealWasAccepted    NotificationType = "notification.action.your_appeal_was_accepted"
+	NotificationYourAppealWasRejected    NotificationType = "notification.action.your_appeal_was_rejected"
+	NotificationYourFlagWasHelpful       NotificationType = "notification.action.your_flag_was_helpful"
+	NotificationYourFlagWasDeclined      NotificationType = "notification.action.your_flag_was_declined"
+	NotificationFlagAssignedToYou        NotificationType = "notification.action.flag_assigned_to_you"
+	NotificationNewQuestion              NotificationType = "notification.action.new_question"
+	NotificationNewQuestionFollowedTag   NotificationType = "notification.action.new_question_followed_tag"
 )
 
 type Notification interface {
@@ -95,6 +95,10 @@ func Register(p Base) {
 		registerSearch(p.(Search))
 	}
 
+	if _, ok := p.(VectorSearch); ok {
+		registerVectorSearch(p.(VectorSearch))
+	}
+
 	if _, ok := p.(Notification); ok {
 		registerNotification(p.(Notification))
 	}
@@ -103,6 +107,10 @@ func Register(p Base) {
 		registerReviewer(p.(Reviewer))
 	}
 
+	if _, ok := p.(AntiSpam); ok {
+		registerAntiSpam(p.(AntiSpam))
+	}
+
 	if _, ok := p.(Captcha); ok {
 		registerCaptcha(p.(Captcha))
 	}
@@ -126,6 +134,14 @@ func Register(p Base) {
 	if _, ok := p.(KVStorage); ok {
 		registerKVStorage(p.(KVStorage))
 	}
+
+	if _, ok := p.(ContentTranslator); ok {
+		registerContentTranslator(p.(ContentTranslator))
+	}
+
+	if _, ok := p.(MobilePush); ok {
+		registerMobilePush(p.(MobilePush))
+	}
 }
 
 type Stack[T Base] struct {
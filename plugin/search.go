@@ -28,6 +28,10 @@ type SearchResult struct {
 	ID string
 	// Type content type, example: "answer", "question"
 	Type string
+	// TitleHighlight optional, the title with the matched words wrapped in <em>
+	TitleHighlight string
+	// ContentHighlights optional, the fragments of content with the matched words wrapped in <em>
+	ContentHighlights []string
 }
 
 type SearchContent struct {
@@ -0,0 +1,230 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search_elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/plugin"
+)
+
+// indexMapping the mapping of the fields of plugin.SearchContent, the new fields can be added to it,
+// they are put to the existing index when the plugin starts, but the type of existing fields can't be changed.
+var indexMapping = map[string]any{
+	"dynamic": false,
+	"properties": map[string]any{
+		"objectID":    map[string]any{"type": "keyword"},
+		"title":       map[string]any{"type": "text"},
+		"type":        map[string]any{"type": "keyword"},
+		"content":     map[string]any{"type": "text"},
+		"answers":     map[string]any{"type": "long"},
+		"status":      map[string]any{"type": "integer"},
+		"tags":        map[string]any{"type": "keyword"},
+		"questionID":  map[string]any{"type": "keyword"},
+		"userID":      map[string]any{"type": "keyword"},
+		"views":       map[string]any{"type": "long"},
+		"created":     map[string]any{"type": "long"},
+		"active":      map[string]any{"type": "long"},
+		"score":       map[string]any{"type": "long"},
+		"hasAccepted": map[string]any{"type": "boolean"},
+	},
+}
+
+// client the minimal client of the REST API shared by Elasticsearch and OpenSearch
+type client struct {
+	endpoint   string
+	index      string
+	username   string
+	password   string
+	apiKey     string
+	httpClient *http.Client
+}
+
+func newClient(conf *SearchEngineConfig) *client {
+	return &client{
+		endpoint:   strings.TrimRight(conf.Endpoint, "/"),
+		index:      conf.IndexName,
+		username:   conf.Username,
+		password:   conf.Password,
+		apiKey:     conf.APIKey,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+type searchResp struct {
+	Hits struct {
+		Total struct {
+			Value int64 `json:"value"`
+		} `json:"total"`
+		Hits []*searchHit `json:"hits"`
+	} `json:"hits"`
+}
+
+type searchHit struct {
+	Source plugin.SearchContent `json:"_source"`
+	// the highlighted fragments by field name
+	Highlight map[string][]string `json:"highlight"`
+}
+
+type bulkResp struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		ID     string `json:"_id"`
+		Status int    `json:"status"`
+		Error  any    `json:"error"`
+	} `json:"items"`
+}
+
+// ensureIndex create the index with the mapping if not exist, otherwise put the mapping to add the new fields
+func (c *client) ensureIndex(ctx context.Context) (err error) {
+	status, _, err := c.do(ctx, http.MethodHead, c.index, nil, "")
+	if err != nil {
+		return err
+	}
+	if status == http.StatusNotFound {
+		body, _ := json.Marshal(map[string]any{"mappings": indexMapping})
+		_, _, err = c.doExpect(ctx, http.MethodPut, c.index, body, "application/json")
+		return err
+	}
+	body, _ := json.Marshal(indexMapping)
+	_, _, err = c.doExpect(ctx, http.MethodPut, c.index+"/_mapping", body, "application/json")
+	return err
+}
+
+// indexContent add the document of the content or replace it if exists
+func (c *client) indexContent(ctx context.Context, content *plugin.SearchContent) (err error) {
+	body, _ := json.Marshal(content)
+	_, _, err = c.doExpect(ctx, http.MethodPut, c.docPath(content.ObjectID), body, "application/json")
+	return err
+}
+
+// deleteContent delete the document of the content, it is fine if the document not exists
+func (c *client) deleteContent(ctx context.Context, objectID string) (err error) {
+	status, respBody, err := c.do(ctx, http.MethodDelete, c.docPath(objectID), nil, "")
+	if err != nil {
+		return err
+	}
+	if status != http.StatusNotFound && status >= http.StatusMultipleChoices {
+		return fmt.Errorf("delete document %s failed: %d %s", objectID, status, respBody)
+	}
+	return nil
+}
+
+// bulkIndex add or replace the documents of the contents in one request
+func (c *client) bulkIndex(ctx context.Context, contents []*plugin.SearchContent) (err error) {
+	if len(contents) == 0 {
+		return nil
+	}
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	for _, content := range contents {
+		_ = encoder.Encode(map[string]any{"index": map[string]any{"_index": c.index, "_id": content.ObjectID}})
+		_ = encoder.Encode(content)
+	}
+	_, respBody, err := c.doExpect(ctx, http.MethodPost, "_bulk", buf.Bytes(), "application/x-ndjson")
+	if err != nil {
+		return err
+	}
+	resp := &bulkResp{}
+	if err = json.Unmarshal(respBody, resp); err != nil {
+		return fmt.Errorf("parse bulk response failed: %w", err)
+	}
+	if !resp.Errors {
+		return nil
+	}
+	failed := 0
+	for _, item := range resp.Items {
+		for _, result := range item {
+			if result.Error != nil {
+				failed++
+			}
+		}
+	}
+	return fmt.Errorf("bulk index %d of %d documents failed", failed, len(contents))
+}
+
+// search run the query and return the matched contents
+func (c *client) search(ctx context.Context, query map[string]any) (hits []*searchHit, total int64, err error) {
+	body, _ := json.Marshal(query)
+	_, respBody, err := c.doExpect(ctx, http.MethodPost, c.index+"/_search", body, "application/json")
+	if err != nil {
+		return nil, 0, err
+	}
+	resp := &searchResp{}
+	if err = json.Unmarshal(respBody, resp); err != nil {
+		return nil, 0, fmt.Errorf("parse search response failed: %w", err)
+	}
+	return resp.Hits.Hits, resp.Hits.Total.Value, nil
+}
+
+func (c *client) docPath(objectID string) string {
+	return c.index + "/_doc/" + url.PathEscape(objectID)
+}
+
+// doExpect do the request and treat the status that is not 2xx as error
+func (c *client) doExpect(ctx context.Context, method, path string, body []byte, contentType string) (
+	status int, respBody []byte, err error) {
+	status, respBody, err = c.do(ctx, method, path, body, contentType)
+	if err != nil {
+		return status, nil, err
+	}
+	if status < http.StatusOK || status >= http.StatusMultipleChoices {
+		return status, nil, fmt.Errorf("%s %s failed: %d %s", method, path, status, respBody)
+	}
+	return status, respBody, nil
+}
+
+func (c *client) do(ctx context.Context, method, path string, body []byte, contentType string) (
+	status int, respBody []byte, err error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+"/"+path, reader)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(contentType) > 0 {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if len(c.apiKey) > 0 {
+		req.Header.Set("Authorization", "ApiKey "+c.apiKey)
+	} else if len(c.username) > 0 {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, nil, err
+	}
+	return resp.StatusCode, respBody, nil
+}
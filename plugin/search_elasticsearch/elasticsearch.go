@@ -0,0 +1,265 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search_elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/plugin"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	defaultIndexName = "answer_search"
+	// syncPageSize the number of contents indexed in one bulk request of reindex
+	syncPageSize = 100
+)
+
+// SearchEngineConfig the config of the connection to Elasticsearch or OpenSearch
+type SearchEngineConfig struct {
+	Endpoint  string `json:"endpoint"`
+	Username  string `json:"username"`
+	Password  string `json:"password"`
+	APIKey    string `json:"api_key"`
+	IndexName string `json:"index_name"`
+}
+
+// SearchEngine the search plugin backed by Elasticsearch or OpenSearch
+type SearchEngine struct {
+	lock    sync.RWMutex
+	config  *SearchEngineConfig
+	client  *client
+	syncing atomic.Bool
+}
+
+func init() {
+	plugin.Register(&SearchEngine{config: &SearchEngineConfig{}})
+}
+
+func (s *SearchEngine) Info() plugin.Info {
+	return plugin.Info{
+		Name:        plugin.MakeTranslator("backend.search_elasticsearch.name"),
+		SlugName:    "elasticsearch_search",
+		Description: plugin.MakeTranslator("backend.search_elasticsearch.description"),
+		Author:      "answerdev",
+		Version:     "1.0.0",
+		Link:        "https://github.com/apache/answer/tree/main/plugin/search_elasticsearch",
+	}
+}
+
+func (s *SearchEngine) Description() plugin.SearchDesc {
+	return plugin.SearchDesc{
+		Link: "https://www.elastic.co/elasticsearch",
+	}
+}
+
+func (s *SearchEngine) ConfigFields() []plugin.ConfigField {
+	return []plugin.ConfigField{
+		{
+			Name:        "endpoint",
+			Type:        plugin.ConfigTypeInput,
+			Title:       plugin.MakeTranslator("backend.search_elasticsearch.config.endpoint.title"),
+			Description: plugin.MakeTranslator("backend.search_elasticsearch.config.endpoint.description"),
+			Required:    true,
+			UIOptions:   plugin.ConfigFieldUIOptions{InputType: plugin.InputTypeUrl},
+			Value:       s.config.Endpoint,
+		},
+		{
+			Name:      "username",
+			Type:      plugin.ConfigTypeInput,
+			Title:     plugin.MakeTranslator("backend.search_elasticsearch.config.username.title"),
+			UIOptions: plugin.ConfigFieldUIOptions{InputType: plugin.InputTypeText},
+			Value:     s.config.Username,
+		},
+		{
+			Name:      "password",
+			Type:      plugin.ConfigTypeInput,
+			Title:     plugin.MakeTranslator("backend.search_elasticsearch.config.password.title"),
+			UIOptions: plugin.ConfigFieldUIOptions{InputType: plugin.InputTypePassword},
+			Value:     s.config.Password,
+		},
+		{
+			Name:        "api_key",
+			Type:        plugin.ConfigTypeInput,
+			Title:       plugin.MakeTranslator("backend.search_elasticsearch.config.api_key.title"),
+			Description: plugin.MakeTranslator("backend.search_elasticsearch.config.api_key.description"),
+			UIOptions:   plugin.ConfigFieldUIOptions{InputType: plugin.InputTypePassword},
+			Value:       s.config.APIKey,
+		},
+		{
+			Name:        "index_name",
+			Type:        plugin.ConfigTypeInput,
+			Title:       plugin.MakeTranslator("backend.search_elasticsearch.config.index_name.title"),
+			Description: plugin.MakeTranslator("backend.search_elasticsearch.config.index_name.description"),
+			UIOptions:   plugin.ConfigFieldUIOptions{InputType: plugin.InputTypeText},
+			Value:       s.config.IndexName,
+		},
+	}
+}
+
+func (s *SearchEngine) ConfigReceiver(config []byte) error {
+	conf := &SearchEngineConfig{}
+	if err := json.Unmarshal(config, conf); err != nil {
+		return err
+	}
+	if len(conf.IndexName) == 0 {
+		conf.IndexName = defaultIndexName
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.config = conf
+	s.client = nil
+	if len(conf.Endpoint) == 0 {
+		return nil
+	}
+	s.client = newClient(conf)
+	// the unreachable cluster should not block the startup
+	go func(c *client) {
+		if err := c.ensureIndex(context.Background()); err != nil {
+			log.Errorf("ensure elasticsearch index %s failed: %v", conf.IndexName, err)
+		}
+	}(s.client)
+	return nil
+}
+
+// RegisterSyncer reindex all contents in the background, it is called when the config is saved
+func (s *SearchEngine) RegisterSyncer(ctx context.Context, syncer plugin.SearchSyncer) {
+	c := s.getClient()
+	if c == nil {
+		return
+	}
+	if !s.syncing.CompareAndSwap(false, true) {
+		log.Warnf("elasticsearch reindex is running, skip it")
+		return
+	}
+	go func() {
+		defer s.syncing.Store(false)
+		if err := s.reindex(context.Background(), c, syncer); err != nil {
+			log.Errorf("elasticsearch reindex failed: %v", err)
+		}
+	}()
+}
+
+func (s *SearchEngine) reindex(ctx context.Context, c *client, syncer plugin.SearchSyncer) (err error) {
+	if err = c.ensureIndex(ctx); err != nil {
+		return err
+	}
+	total := 0
+	for page := 1; ; page++ {
+		questions, err := syncer.GetQuestionsPage(ctx, page, syncPageSize)
+		if err != nil {
+			return err
+		}
+		if len(questions) == 0 {
+			break
+		}
+		if err = c.bulkIndex(ctx, questions); err != nil {
+			return err
+		}
+		total += len(questions)
+	}
+	for page := 1; ; page++ {
+		answers, err := syncer.GetAnswersPage(ctx, page, syncPageSize)
+		if err != nil {
+			return err
+		}
+		if len(answers) == 0 {
+			break
+		}
+		if err = c.bulkIndex(ctx, answers); err != nil {
+			return err
+		}
+		total += len(answers)
+	}
+	log.Infof("elasticsearch reindex finished, %d contents indexed", total)
+	return nil
+}
+
+func (s *SearchEngine) SearchContents(ctx context.Context, cond *plugin.SearchBasicCond) (
+	res []plugin.SearchResult, total int64, err error) {
+	return s.search(ctx, cond, constant.QuestionObjectType, constant.AnswerObjectType)
+}
+
+func (s *SearchEngine) SearchQuestions(ctx context.Context, cond *plugin.SearchBasicCond) (
+	res []plugin.SearchResult, total int64, err error) {
+	return s.search(ctx, cond, constant.QuestionObjectType)
+}
+
+func (s *SearchEngine) SearchAnswers(ctx context.Context, cond *plugin.SearchBasicCond) (
+	res []plugin.SearchResult, total int64, err error) {
+	return s.search(ctx, cond, constant.AnswerObjectType)
+}
+
+// UpdateContent index the content when it is created or updated, the deleted content is kept with its status
+// and filtered out when searching
+func (s *SearchEngine) UpdateContent(ctx context.Context, content *plugin.SearchContent) (err error) {
+	c := s.getClient()
+	if c == nil {
+		return nil
+	}
+	return c.indexContent(ctx, content)
+}
+
+func (s *SearchEngine) DeleteContent(ctx context.Context, objectID string) (err error) {
+	c := s.getClient()
+	if c == nil {
+		return nil
+	}
+	return c.deleteContent(ctx, objectID)
+}
+
+func (s *SearchEngine) search(ctx context.Context, cond *plugin.SearchBasicCond, contentTypes ...string) (
+	res []plugin.SearchResult, total int64, err error) {
+	c := s.getClient()
+	if c == nil {
+		return nil, 0, fmt.Errorf("elasticsearch is not configured")
+	}
+	query, ok := buildQuery(cond, contentTypes...)
+	if !ok {
+		return nil, 0, nil
+	}
+	hits, total, err := c.search(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, hit := range hits {
+		result := plugin.SearchResult{
+			ID:                hit.Source.ObjectID,
+			Type:              hit.Source.Type,
+			ContentHighlights: hit.Highlight["content"],
+		}
+		if titles := hit.Highlight["title"]; len(titles) > 0 {
+			result.TitleHighlight = titles[0]
+		}
+		res = append(res, result)
+	}
+	return res, total, nil
+}
+
+func (s *SearchEngine) getClient() *client {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.client
+}
@@ -0,0 +1,156 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search_elasticsearch
+
+import (
+	"strings"
+
+	"github.com/apache/answer/plugin"
+)
+
+// maxResultWindow the default max_result_window of the index, the deeper page can't be searched
+const maxResultWindow = 10000
+
+// buildQuery build the search request body of the condition, the content types are the types to be searched
+func buildQuery(cond *plugin.SearchBasicCond, contentTypes ...string) (query map[string]any, ok bool) {
+	page, pageSize := cond.Page, cond.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	from := (page - 1) * pageSize
+	if from+pageSize > maxResultWindow {
+		return nil, false
+	}
+
+	must := make([]any, 0)
+	if words := strings.TrimSpace(strings.Join(cond.Words, " ")); len(words) > 0 {
+		must = append(must, map[string]any{
+			"multi_match": map[string]any{
+				"query":    words,
+				"fields":   []string{"title^3", "content"},
+				"operator": "and",
+			},
+		})
+	}
+
+	filter := []any{
+		term("status", plugin.SearchContentStatusAvailable),
+		map[string]any{"terms": map[string]any{"type": contentTypes}},
+	}
+	// every group of tags is the tag and its synonyms, the content must have one tag of each group
+	for _, tagIDs := range cond.TagIDs {
+		if len(tagIDs) > 0 {
+			filter = append(filter, map[string]any{"terms": map[string]any{"tags": tagIDs}})
+		}
+	}
+	if len(cond.UserID) > 0 {
+		filter = append(filter, term("userID", cond.UserID))
+	}
+	if len(cond.QuestionID) > 0 {
+		filter = append(filter, term("questionID", cond.QuestionID))
+	}
+	// the same as the built-in search, 0 means exactly 0, -1 means the amount is not limited
+	if filterAmount, ok := amountFilter("score", cond.VoteAmount); ok {
+		filter = append(filter, filterAmount)
+	}
+	if cond.ViewAmount > -1 {
+		filter = append(filter, gte("views", cond.ViewAmount))
+	}
+	if filterAmount, ok := amountFilter("answers", cond.AnswerAmount); ok {
+		filter = append(filter, filterAmount)
+	}
+	if accepted, ok := acceptedFilter(cond.QuestionAccepted); ok {
+		filter = append(filter, accepted)
+	}
+	if accepted, ok := acceptedFilter(cond.AnswerAccepted); ok {
+		filter = append(filter, accepted)
+	}
+
+	query = map[string]any{
+		"from":             from,
+		"size":             pageSize,
+		"track_total_hits": true,
+		"query": map[string]any{
+			"bool": map[string]any{
+				"must":   must,
+				"filter": filter,
+			},
+		},
+		"sort": buildSort(cond.Order),
+	}
+	if len(must) > 0 {
+		query["highlight"] = highlight
+	}
+	return query, true
+}
+
+// highlight the whole title and some fragments of content, the fragments are escaped by Answer except the tags
+var highlight = map[string]any{
+	"pre_tags":  []string{"<em>"},
+	"post_tags": []string{"</em>"},
+	"fields": map[string]any{
+		"title":   map[string]any{"number_of_fragments": 0},
+		"content": map[string]any{"fragment_size": 150, "number_of_fragments": 3},
+	},
+}
+
+func buildSort(order plugin.SearchOrderCond) []any {
+	switch order {
+	case plugin.SearchNewestOrder:
+		return []any{map[string]any{"created": "desc"}}
+	case plugin.SearchActiveOrder:
+		return []any{map[string]any{"active": "desc"}}
+	case plugin.SearchScoreOrder:
+		return []any{map[string]any{"score": "desc"}, map[string]any{"created": "desc"}}
+	default:
+		return []any{"_score", map[string]any{"created": "desc"}}
+	}
+}
+
+func acceptedFilter(cond plugin.SearchAcceptedCond) (filter any, ok bool) {
+	switch cond {
+	case plugin.AcceptedCondTrue:
+		return term("hasAccepted", true), true
+	case plugin.AcceptedCondFalse:
+		return term("hasAccepted", false), true
+	}
+	return nil, false
+}
+
+func amountFilter(field string, amount int) (filter any, ok bool) {
+	if amount == 0 {
+		return term(field, 0), true
+	}
+	if amount > 0 {
+		return gte(field, amount), true
+	}
+	return nil, false
+}
+
+func term(field string, value any) map[string]any {
+	return map[string]any{"term": map[string]any{field: value}}
+}
+
+func gte(field string, value int) map[string]any {
+	return map[string]any{"range": map[string]any{field: map[string]any{"gte": value}}}
+}
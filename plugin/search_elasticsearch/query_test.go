@@ -0,0 +1,60 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search_elasticsearch
+
+import (
+	"testing"
+
+	"github.com/apache/answer/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildQuery(t *testing.T) {
+	cond := &plugin.SearchBasicCond{
+		Page:             2,
+		PageSize:         20,
+		Words:            []string{"go", "modules"},
+		TagIDs:           [][]string{{"1", "2"}},
+		VoteAmount:       -1,
+		ViewAmount:       -1,
+		AnswerAmount:     0,
+		QuestionAccepted: plugin.AcceptedCondFalse,
+		Order:            plugin.SearchNewestOrder,
+	}
+	query, ok := buildQuery(cond, "question")
+	assert.True(t, ok)
+	assert.Equal(t, 20, query["from"])
+	assert.Equal(t, []any{map[string]any{"created": "desc"}}, query["sort"])
+	assert.Equal(t, highlight, query["highlight"])
+
+	boolQuery := query["query"].(map[string]any)["bool"].(map[string]any)
+	assert.Len(t, boolQuery["must"], 1)
+	assert.Equal(t, []any{
+		term("status", plugin.SearchContentStatusAvailable),
+		map[string]any{"terms": map[string]any{"type": []string{"question"}}},
+		map[string]any{"terms": map[string]any{"tags": []string{"1", "2"}}},
+		term("answers", 0),
+		term("hasAccepted", false),
+	}, boolQuery["filter"])
+
+	cond.Page = maxResultWindow
+	_, ok = buildQuery(cond, "question")
+	assert.False(t, ok)
+}
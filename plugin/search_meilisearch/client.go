@@ -0,0 +1,164 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search_meilisearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/plugin"
+)
+
+// indexSettings the searchable, filterable and sortable attributes of plugin.SearchContent,
+// the typo tolerance and prefix search are enabled by default
+var indexSettings = map[string]any{
+	"searchableAttributes": []string{"title", "content"},
+	"filterableAttributes": []string{"type", "status", "tags", "questionID", "userID", "views", "answers", "score", "hasAccepted"},
+	"sortableAttributes":   []string{"created", "active", "score"},
+}
+
+// client the minimal client of the Meilisearch REST API
+type client struct {
+	endpoint   string
+	index      string
+	apiKey     string
+	httpClient *http.Client
+}
+
+func newClient(conf *SearchEngineConfig) *client {
+	return &client{
+		endpoint:   strings.TrimRight(conf.Endpoint, "/"),
+		index:      conf.IndexName,
+		apiKey:     conf.APIKey,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+type searchResp struct {
+	Hits      []*searchHit `json:"hits"`
+	TotalHits int64        `json:"totalHits"`
+}
+
+type searchHit struct {
+	plugin.SearchContent
+	// the highlighted and cropped attributes
+	Formatted struct {
+		Title   string `json:"title"`
+		Content string `json:"content"`
+	} `json:"_formatted"`
+}
+
+// ensureIndex create the index with the primary key if not exist and update the settings,
+// both of them are enqueued as tasks and done asynchronously by Meilisearch
+func (c *client) ensureIndex(ctx context.Context) (err error) {
+	status, _, err := c.do(ctx, http.MethodGet, c.indexPath(), nil)
+	if err != nil {
+		return err
+	}
+	if status == http.StatusNotFound {
+		body, _ := json.Marshal(map[string]any{"uid": c.index, "primaryKey": "objectID"})
+		if _, err = c.doExpect(ctx, http.MethodPost, "indexes", body); err != nil {
+			return err
+		}
+	}
+	body, _ := json.Marshal(indexSettings)
+	_, err = c.doExpect(ctx, http.MethodPatch, c.indexPath()+"/settings", body)
+	return err
+}
+
+// addContents add the documents of the contents or replace them if exist
+func (c *client) addContents(ctx context.Context, contents []*plugin.SearchContent) (err error) {
+	if len(contents) == 0 {
+		return nil
+	}
+	body, _ := json.Marshal(contents)
+	_, err = c.doExpect(ctx, http.MethodPost, c.indexPath()+"/documents", body)
+	return err
+}
+
+// deleteContent delete the document of the content, it is fine if the document not exists
+func (c *client) deleteContent(ctx context.Context, objectID string) (err error) {
+	_, err = c.doExpect(ctx, http.MethodDelete, c.indexPath()+"/documents/"+url.PathEscape(objectID), nil)
+	return err
+}
+
+// search run the query and return the matched contents
+func (c *client) search(ctx context.Context, query map[string]any) (resp *searchResp, err error) {
+	body, _ := json.Marshal(query)
+	respBody, err := c.doExpect(ctx, http.MethodPost, c.indexPath()+"/search", body)
+	if err != nil {
+		return nil, err
+	}
+	resp = &searchResp{}
+	if err = json.Unmarshal(respBody, resp); err != nil {
+		return nil, fmt.Errorf("parse search response failed: %w", err)
+	}
+	return resp, nil
+}
+
+func (c *client) indexPath() string {
+	return "indexes/" + url.PathEscape(c.index)
+}
+
+// doExpect do the request and treat the status that is not 2xx as error
+func (c *client) doExpect(ctx context.Context, method, path string, body []byte) (respBody []byte, err error) {
+	status, respBody, err := c.do(ctx, method, path, body)
+	if err != nil {
+		return nil, err
+	}
+	if status < http.StatusOK || status >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("%s %s failed: %d %s", method, path, status, respBody)
+	}
+	return respBody, nil
+}
+
+func (c *client) do(ctx context.Context, method, path string, body []byte) (status int, respBody []byte, err error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+"/"+path, reader)
+	if err != nil {
+		return 0, nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if len(c.apiKey) > 0 {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, nil, err
+	}
+	return resp.StatusCode, respBody, nil
+}
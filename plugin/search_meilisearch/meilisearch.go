@@ -0,0 +1,245 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search_meilisearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/plugin"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	defaultIndexName = "answer_search"
+	// syncPageSize the number of contents added in one request of reindex
+	syncPageSize = 500
+)
+
+// SearchEngineConfig the config of the connection to Meilisearch
+type SearchEngineConfig struct {
+	Endpoint  string `json:"endpoint"`
+	APIKey    string `json:"api_key"`
+	IndexName string `json:"index_name"`
+}
+
+// SearchEngine the search plugin backed by Meilisearch
+type SearchEngine struct {
+	lock    sync.RWMutex
+	config  *SearchEngineConfig
+	client  *client
+	syncing atomic.Bool
+}
+
+func init() {
+	plugin.Register(&SearchEngine{config: &SearchEngineConfig{}})
+}
+
+func (s *SearchEngine) Info() plugin.Info {
+	return plugin.Info{
+		Name:        plugin.MakeTranslator("backend.search_meilisearch.name"),
+		SlugName:    "meilisearch_search",
+		Description: plugin.MakeTranslator("backend.search_meilisearch.description"),
+		Author:      "answerdev",
+		Version:     "1.0.0",
+		Link:        "https://github.com/apache/answer/tree/main/plugin/search_meilisearch",
+	}
+}
+
+func (s *SearchEngine) Description() plugin.SearchDesc {
+	return plugin.SearchDesc{
+		Link: "https://www.meilisearch.com",
+	}
+}
+
+func (s *SearchEngine) ConfigFields() []plugin.ConfigField {
+	return []plugin.ConfigField{
+		{
+			Name:        "endpoint",
+			Type:        plugin.ConfigTypeInput,
+			Title:       plugin.MakeTranslator("backend.search_meilisearch.config.endpoint.title"),
+			Description: plugin.MakeTranslator("backend.search_meilisearch.config.endpoint.description"),
+			Required:    true,
+			UIOptions:   plugin.ConfigFieldUIOptions{InputType: plugin.InputTypeUrl},
+			Value:       s.config.Endpoint,
+		},
+		{
+			Name:        "api_key",
+			Type:        plugin.ConfigTypeInput,
+			Title:       plugin.MakeTranslator("backend.search_meilisearch.config.api_key.title"),
+			Description: plugin.MakeTranslator("backend.search_meilisearch.config.api_key.description"),
+			UIOptions:   plugin.ConfigFieldUIOptions{InputType: plugin.InputTypePassword},
+			Value:       s.config.APIKey,
+		},
+		{
+			Name:        "index_name",
+			Type:        plugin.ConfigTypeInput,
+			Title:       plugin.MakeTranslator("backend.search_meilisearch.config.index_name.title"),
+			Description: plugin.MakeTranslator("backend.search_meilisearch.config.index_name.description"),
+			UIOptions:   plugin.ConfigFieldUIOptions{InputType: plugin.InputTypeText},
+			Value:       s.config.IndexName,
+		},
+	}
+}
+
+func (s *SearchEngine) ConfigReceiver(config []byte) error {
+	conf := &SearchEngineConfig{}
+	if err := json.Unmarshal(config, conf); err != nil {
+		return err
+	}
+	if len(conf.IndexName) == 0 {
+		conf.IndexName = defaultIndexName
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.config = conf
+	s.client = nil
+	if len(conf.Endpoint) == 0 {
+		return nil
+	}
+	s.client = newClient(conf)
+	// the unreachable server should not block the startup
+	go func(c *client) {
+		if err := c.ensureIndex(context.Background()); err != nil {
+			log.Errorf("ensure meilisearch index %s failed: %v", conf.IndexName, err)
+		}
+	}(s.client)
+	return nil
+}
+
+// RegisterSyncer reindex all contents in the background, it is called when the config is saved
+func (s *SearchEngine) RegisterSyncer(ctx context.Context, syncer plugin.SearchSyncer) {
+	c := s.getClient()
+	if c == nil {
+		return
+	}
+	if !s.syncing.CompareAndSwap(false, true) {
+		log.Warnf("meilisearch reindex is running, skip it")
+		return
+	}
+	go func() {
+		defer s.syncing.Store(false)
+		if err := s.reindex(context.Background(), c, syncer); err != nil {
+			log.Errorf("meilisearch reindex failed: %v", err)
+		}
+	}()
+}
+
+func (s *SearchEngine) reindex(ctx context.Context, c *client, syncer plugin.SearchSyncer) (err error) {
+	if err = c.ensureIndex(ctx); err != nil {
+		return err
+	}
+	total := 0
+	for page := 1; ; page++ {
+		questions, err := syncer.GetQuestionsPage(ctx, page, syncPageSize)
+		if err != nil {
+			return err
+		}
+		if len(questions) == 0 {
+			break
+		}
+		if err = c.addContents(ctx, questions); err != nil {
+			return err
+		}
+		total += len(questions)
+	}
+	for page := 1; ; page++ {
+		answers, err := syncer.GetAnswersPage(ctx, page, syncPageSize)
+		if err != nil {
+			return err
+		}
+		if len(answers) == 0 {
+			break
+		}
+		if err = c.addContents(ctx, answers); err != nil {
+			return err
+		}
+		total += len(answers)
+	}
+	log.Infof("meilisearch reindex finished, %d contents enqueued", total)
+	return nil
+}
+
+func (s *SearchEngine) SearchContents(ctx context.Context, cond *plugin.SearchBasicCond) (
+	res []plugin.SearchResult, total int64, err error) {
+	return s.search(ctx, cond, constant.QuestionObjectType, constant.AnswerObjectType)
+}
+
+func (s *SearchEngine) SearchQuestions(ctx context.Context, cond *plugin.SearchBasicCond) (
+	res []plugin.SearchResult, total int64, err error) {
+	return s.search(ctx, cond, constant.QuestionObjectType)
+}
+
+func (s *SearchEngine) SearchAnswers(ctx context.Context, cond *plugin.SearchBasicCond) (
+	res []plugin.SearchResult, total int64, err error) {
+	return s.search(ctx, cond, constant.AnswerObjectType)
+}
+
+// UpdateContent add the content when it is created or updated, the deleted content is kept with its status
+// and filtered out when searching
+func (s *SearchEngine) UpdateContent(ctx context.Context, content *plugin.SearchContent) (err error) {
+	c := s.getClient()
+	if c == nil {
+		return nil
+	}
+	return c.addContents(ctx, []*plugin.SearchContent{content})
+}
+
+func (s *SearchEngine) DeleteContent(ctx context.Context, objectID string) (err error) {
+	c := s.getClient()
+	if c == nil {
+		return nil
+	}
+	return c.deleteContent(ctx, objectID)
+}
+
+func (s *SearchEngine) search(ctx context.Context, cond *plugin.SearchBasicCond, contentTypes ...string) (
+	res []plugin.SearchResult, total int64, err error) {
+	c := s.getClient()
+	if c == nil {
+		return nil, 0, fmt.Errorf("meilisearch is not configured")
+	}
+	resp, err := c.search(ctx, buildQuery(cond, contentTypes...))
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, hit := range resp.Hits {
+		result := plugin.SearchResult{
+			ID:             hit.ObjectID,
+			Type:           hit.Type,
+			TitleHighlight: hit.Formatted.Title,
+		}
+		if len(hit.Formatted.Content) > 0 {
+			result.ContentHighlights = []string{hit.Formatted.Content}
+		}
+		res = append(res, result)
+	}
+	return res, resp.TotalHits, nil
+}
+
+func (s *SearchEngine) getClient() *client {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.client
+}
@@ -0,0 +1,137 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search_meilisearch
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/apache/answer/plugin"
+)
+
+// buildQuery build the search request body of the condition, the content types are the types to be searched
+func buildQuery(cond *plugin.SearchBasicCond, contentTypes ...string) (query map[string]any) {
+	page, pageSize := cond.Page, cond.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	filter := []string{
+		fmt.Sprintf("status = %d", plugin.SearchContentStatusAvailable),
+		"type IN " + stringList(contentTypes),
+	}
+	// every group of tags is the tag and its synonyms, the content must have one tag of each group
+	for _, tagIDs := range cond.TagIDs {
+		if len(tagIDs) > 0 {
+			filter = append(filter, "tags IN "+stringList(tagIDs))
+		}
+	}
+	if len(cond.UserID) > 0 {
+		filter = append(filter, "userID = "+quote(cond.UserID))
+	}
+	if len(cond.QuestionID) > 0 {
+		filter = append(filter, "questionID = "+quote(cond.QuestionID))
+	}
+	// the same as the built-in search, 0 means exactly 0, -1 means the amount is not limited
+	if expr, ok := amountFilter("score", cond.VoteAmount); ok {
+		filter = append(filter, expr)
+	}
+	if cond.ViewAmount > -1 {
+		filter = append(filter, fmt.Sprintf("views >= %d", cond.ViewAmount))
+	}
+	if expr, ok := amountFilter("answers", cond.AnswerAmount); ok {
+		filter = append(filter, expr)
+	}
+	if expr, ok := acceptedFilter(cond.QuestionAccepted); ok {
+		filter = append(filter, expr)
+	}
+	if expr, ok := acceptedFilter(cond.AnswerAccepted); ok {
+		filter = append(filter, expr)
+	}
+
+	q := strings.TrimSpace(strings.Join(cond.Words, " "))
+	query = map[string]any{
+		"q":           q,
+		"filter":      strings.Join(filter, " AND "),
+		"page":        page,
+		"hitsPerPage": pageSize,
+	}
+	if sort := buildSort(cond.Order); len(sort) > 0 {
+		query["sort"] = sort
+	}
+	// highlight the matched words, the highlights are escaped by Answer except the tags
+	if len(q) > 0 {
+		query["attributesToHighlight"] = []string{"title", "content"}
+		query["attributesToCrop"] = []string{"content"}
+		query["cropLength"] = 30
+		query["highlightPreTag"] = "<em>"
+		query["highlightPostTag"] = "</em>"
+	}
+	return query
+}
+
+// buildSort the relevance order is the default ranking rules of Meilisearch, no need to sort
+func buildSort(order plugin.SearchOrderCond) []string {
+	switch order {
+	case plugin.SearchNewestOrder:
+		return []string{"created:desc"}
+	case plugin.SearchActiveOrder:
+		return []string{"active:desc"}
+	case plugin.SearchScoreOrder:
+		return []string{"score:desc", "created:desc"}
+	}
+	return nil
+}
+
+func acceptedFilter(cond plugin.SearchAcceptedCond) (expr string, ok bool) {
+	switch cond {
+	case plugin.AcceptedCondTrue:
+		return "hasAccepted = true", true
+	case plugin.AcceptedCondFalse:
+		return "hasAccepted = false", true
+	}
+	return "", false
+}
+
+func amountFilter(field string, amount int) (expr string, ok bool) {
+	if amount == 0 {
+		return field + " = 0", true
+	}
+	if amount > 0 {
+		return fmt.Sprintf("%s >= %d", field, amount), true
+	}
+	return "", false
+}
+
+func stringList(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, quote(value))
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+func quote(value string) string {
+	return strconv.Quote(value)
+}
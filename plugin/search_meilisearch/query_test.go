@@ -0,0 +1,53 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search_meilisearch
+
+import (
+	"testing"
+
+	"github.com/apache/answer/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildQuery(t *testing.T) {
+	cond := &plugin.SearchBasicCond{
+		Page:           2,
+		PageSize:       20,
+		Words:          []string{"go", "modul"},
+		TagIDs:         [][]string{{"1", "2"}},
+		UserID:         "10",
+		VoteAmount:     3,
+		ViewAmount:     -1,
+		AnswerAmount:   -1,
+		AnswerAccepted: plugin.AcceptedCondTrue,
+		Order:          plugin.SearchScoreOrder,
+	}
+	query := buildQuery(cond, "question", "answer")
+	assert.Equal(t, "go modul", query["q"])
+	assert.Equal(t, 2, query["page"])
+	assert.Equal(t, `status = 1 AND type IN ["question", "answer"] AND tags IN ["1", "2"] AND userID = "10" `+
+		`AND score >= 3 AND hasAccepted = true`, query["filter"])
+	assert.Equal(t, []string{"score:desc", "created:desc"}, query["sort"])
+	assert.Equal(t, []string{"title", "content"}, query["attributesToHighlight"])
+
+	cond.Order = plugin.SearchRelevanceOrder
+	query = buildQuery(cond, "question")
+	assert.NotContains(t, query, "sort")
+}
@@ -26,6 +26,8 @@ const (
 	UserPost           UploadSource = "user_post"
 	UserPostAttachment UploadSource = "user_post_attachment"
 	AdminBranding      UploadSource = "admin_branding"
+	// UserPostVideo the short videos are always kept in the local storage, they are not uploaded by the plugins
+	UserPostVideo UploadSource = "user_post_video"
 )
 
 var (
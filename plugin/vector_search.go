@@ -0,0 +1,62 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package plugin
+
+import (
+	"context"
+)
+
+// VectorSearch is the semantic search engine, it embeds the contents by itself and
+// finds the contents whose meaning is close to the query, such as pgvector or Qdrant.
+// The results are combined with the keyword search results by rank fusion.
+type VectorSearch interface {
+	Base
+	// UpdateVector embed the content and save the vector, the old vector of the same object will be replaced.
+	// The content with deleted status should be removed from the index.
+	UpdateVector(ctx context.Context, content *SearchContent) (err error)
+	// DeleteVector remove the vector of the object
+	DeleteVector(ctx context.Context, objectID string) (err error)
+	// QueryVectors return the contents most similar to the query, ordered by the similarity desc
+	QueryVectors(ctx context.Context, cond *VectorSearchCond) (res []VectorSearchResult, err error)
+}
+
+type VectorSearchCond struct {
+	// Query the text need to be embedded and compared
+	Query string
+	// Type content type, "question" or "answer", empty means both
+	Type string
+	// Limit the max number of results
+	Limit int
+}
+
+type VectorSearchResult struct {
+	// ID content ID
+	ID string
+	// Type content type, example: "answer", "question"
+	Type string
+	// Score the similarity between the content and the query, the higher the closer
+	Score float64
+}
+
+var (
+	// CallVectorSearch is a function that calls all registered vector search engines
+	CallVectorSearch,
+	registerVectorSearch = MakePlugin[VectorSearch](false)
+)
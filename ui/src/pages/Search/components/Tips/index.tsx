@@ -42,9 +42,12 @@ const Index: FC = () => {
         <div className="mb-1">
           <Trans i18nKey="search.tips.question" components={{ 1: <code /> }} />
         </div>
-        <div>
+        <div className="mb-1">
           <Trans i18nKey="search.tips.is_answer" components={{ 1: <code /> }} />
         </div>
+        <div>
+          <Trans i18nKey="search.tips.is_comment" components={{ 1: <code /> }} />
+        </div>
       </Card.Body>
     </Card>
   );